@@ -10,7 +10,9 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	anypb "google.golang.org/protobuf/types/known/anypb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -29,6 +31,9 @@ const (
 	LoadBalancePolicy_ROUND_ROBIN                     LoadBalancePolicy = 1
 	LoadBalancePolicy_LEAST_CONNECTION                LoadBalancePolicy = 2
 	LoadBalancePolicy_IP_HASH                         LoadBalancePolicy = 3
+	// LEAST_LATENCY prefers the endpoint of the upstream with the lowest
+	// moving average of latencies.
+	LoadBalancePolicy_LEAST_LATENCY LoadBalancePolicy = 4
 	// CUSTOM means the load balance policy is defined by the filters.
 	LoadBalancePolicy_CUSTOM LoadBalancePolicy = 15
 )
@@ -40,6 +45,7 @@ var (
 		1:  "ROUND_ROBIN",
 		2:  "LEAST_CONNECTION",
 		3:  "IP_HASH",
+		4:  "LEAST_LATENCY",
 		15: "CUSTOM",
 	}
 	LoadBalancePolicy_value = map[string]int32{
@@ -47,6 +53,7 @@ var (
 		"ROUND_ROBIN":                     1,
 		"LEAST_CONNECTION":                2,
 		"IP_HASH":                         3,
+		"LEAST_LATENCY":                   4,
 		"CUSTOM":                          15,
 	}
 )
@@ -85,6 +92,11 @@ const (
 	ClusterType_LLM                      ClusterType = 1
 	ClusterType_IMAGE_GENERATION         ClusterType = 2
 	ClusterType_SPEECH_GENERATION        ClusterType = 3
+	ClusterType_MODERATION               ClusterType = 4
+	ClusterType_EMBEDDING                ClusterType = 5
+	ClusterType_SPEECH_RECOGNITION       ClusterType = 6
+	ClusterType_RERANK                   ClusterType = 7
+	ClusterType_VIDEO_GENERATION         ClusterType = 8
 )
 
 // Enum value maps for ClusterType.
@@ -94,12 +106,22 @@ var (
 		1: "LLM",
 		2: "IMAGE_GENERATION",
 		3: "SPEECH_GENERATION",
+		4: "MODERATION",
+		5: "EMBEDDING",
+		6: "SPEECH_RECOGNITION",
+		7: "RERANK",
+		8: "VIDEO_GENERATION",
 	}
 	ClusterType_value = map[string]int32{
 		"CLUSTER_TYPE_UNSPECIFIED": 0,
 		"LLM":                      1,
 		"IMAGE_GENERATION":         2,
 		"SPEECH_GENERATION":        3,
+		"MODERATION":               4,
+		"EMBEDDING":                5,
+		"SPEECH_RECOGNITION":       6,
+		"RERANK":                   7,
+		"VIDEO_GENERATION":         8,
 	}
 )
 
@@ -144,6 +166,11 @@ const (
 	ClusterProvider_VOLCENGINE_SEED_SPEECH_V1    ClusterProvider = 8
 	ClusterProvider_ALIBABA_COSY_VOICE_SERVICE   ClusterProvider = 9
 	ClusterProvider_MICROSOFT_SPEECH_SERVICE_V1  ClusterProvider = 10
+	ClusterProvider_AZURE_OPEN_AI                ClusterProvider = 11
+	ClusterProvider_AWS_BEDROCK                  ClusterProvider = 12
+	ClusterProvider_GOOGLE_GEMINI                ClusterProvider = 13
+	ClusterProvider_ANTHROPIC                    ClusterProvider = 14
+	ClusterProvider_RUNWAY                       ClusterProvider = 15
 )
 
 // Enum value maps for ClusterProvider.
@@ -160,6 +187,11 @@ var (
 		8:  "VOLCENGINE_SEED_SPEECH_V1",
 		9:  "ALIBABA_COSY_VOICE_SERVICE",
 		10: "MICROSOFT_SPEECH_SERVICE_V1",
+		11: "AZURE_OPEN_AI",
+		12: "AWS_BEDROCK",
+		13: "GOOGLE_GEMINI",
+		14: "ANTHROPIC",
+		15: "RUNWAY",
 	}
 	ClusterProvider_value = map[string]int32{
 		"CLUSTER_PROVIDER_UNSPECIFIED": 0,
@@ -173,6 +205,11 @@ var (
 		"VOLCENGINE_SEED_SPEECH_V1":    8,
 		"ALIBABA_COSY_VOICE_SERVICE":   9,
 		"MICROSOFT_SPEECH_SERVICE_V1":  10,
+		"AZURE_OPEN_AI":                11,
+		"AWS_BEDROCK":                  12,
+		"GOOGLE_GEMINI":                13,
+		"ANTHROPIC":                    14,
+		"RUNWAY":                       15,
 	}
 )
 
@@ -203,6 +240,113 @@ func (ClusterProvider) EnumDescriptor() ([]byte, []int) {
 	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{2}
 }
 
+type ModelCapability int32
+
+const (
+	ModelCapability_MODEL_CAPABILITY_UNSPECIFIED ModelCapability = 0
+	ModelCapability_STREAMING                    ModelCapability = 1
+	ModelCapability_TOOLS                        ModelCapability = 2
+	ModelCapability_VISION                       ModelCapability = 3
+	ModelCapability_JSON_SCHEMA                  ModelCapability = 4
+	ModelCapability_REASONING                    ModelCapability = 5
+)
+
+// Enum value maps for ModelCapability.
+var (
+	ModelCapability_name = map[int32]string{
+		0: "MODEL_CAPABILITY_UNSPECIFIED",
+		1: "STREAMING",
+		2: "TOOLS",
+		3: "VISION",
+		4: "JSON_SCHEMA",
+		5: "REASONING",
+	}
+	ModelCapability_value = map[string]int32{
+		"MODEL_CAPABILITY_UNSPECIFIED": 0,
+		"STREAMING":                    1,
+		"TOOLS":                        2,
+		"VISION":                       3,
+		"JSON_SCHEMA":                  4,
+		"REASONING":                    5,
+	}
+)
+
+func (x ModelCapability) Enum() *ModelCapability {
+	p := new(ModelCapability)
+	*p = x
+	return p
+}
+
+func (x ModelCapability) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ModelCapability) Descriptor() protoreflect.EnumDescriptor {
+	return file_clusters_v1alpha1_cluster_proto_enumTypes[3].Descriptor()
+}
+
+func (ModelCapability) Type() protoreflect.EnumType {
+	return &file_clusters_v1alpha1_cluster_proto_enumTypes[3]
+}
+
+func (x ModelCapability) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ModelCapability.Descriptor instead.
+func (ModelCapability) EnumDescriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{3}
+}
+
+type Upstream_Auth_Scheme int32
+
+const (
+	Upstream_Auth_SCHEME_UNSPECIFIED Upstream_Auth_Scheme = 0
+	Upstream_Auth_QUERY_PARAM        Upstream_Auth_Scheme = 1
+	Upstream_Auth_COOKIE             Upstream_Auth_Scheme = 2
+)
+
+// Enum value maps for Upstream_Auth_Scheme.
+var (
+	Upstream_Auth_Scheme_name = map[int32]string{
+		0: "SCHEME_UNSPECIFIED",
+		1: "QUERY_PARAM",
+		2: "COOKIE",
+	}
+	Upstream_Auth_Scheme_value = map[string]int32{
+		"SCHEME_UNSPECIFIED": 0,
+		"QUERY_PARAM":        1,
+		"COOKIE":             2,
+	}
+)
+
+func (x Upstream_Auth_Scheme) Enum() *Upstream_Auth_Scheme {
+	p := new(Upstream_Auth_Scheme)
+	*p = x
+	return p
+}
+
+func (x Upstream_Auth_Scheme) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Upstream_Auth_Scheme) Descriptor() protoreflect.EnumDescriptor {
+	return file_clusters_v1alpha1_cluster_proto_enumTypes[4].Descriptor()
+}
+
+func (Upstream_Auth_Scheme) Type() protoreflect.EnumType {
+	return &file_clusters_v1alpha1_cluster_proto_enumTypes[4]
+}
+
+func (x Upstream_Auth_Scheme) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Upstream_Auth_Scheme.Descriptor instead.
+func (Upstream_Auth_Scheme) EnumDescriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{2, 4, 0}
+}
+
 type ClusterMeteringPolicy_SizeFrom int32
 
 const (
@@ -262,11 +406,11 @@ func (x ClusterMeteringPolicy_SizeFrom) String() string {
 }
 
 func (ClusterMeteringPolicy_SizeFrom) Descriptor() protoreflect.EnumDescriptor {
-	return file_clusters_v1alpha1_cluster_proto_enumTypes[3].Descriptor()
+	return file_clusters_v1alpha1_cluster_proto_enumTypes[5].Descriptor()
 }
 
 func (ClusterMeteringPolicy_SizeFrom) Type() protoreflect.EnumType {
-	return &file_clusters_v1alpha1_cluster_proto_enumTypes[3]
+	return &file_clusters_v1alpha1_cluster_proto_enumTypes[5]
 }
 
 func (x ClusterMeteringPolicy_SizeFrom) Number() protoreflect.EnumNumber {
@@ -285,6 +429,9 @@ type ClusterFilter struct {
 
 	Name   string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Config *anypb.Any `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// Timeout is the budget of every invocation of the filter, 0 means
+	// unlimited.
+	Timeout *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
 }
 
 func (x *ClusterFilter) Reset() {
@@ -333,6 +480,13 @@ func (x *ClusterFilter) GetConfig() *anypb.Any {
 	return nil
 }
 
+func (x *ClusterFilter) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
 type TLSConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -382,6 +536,24 @@ type Upstream struct {
 	DefaultParams   map[string]*structpb.Value `protobuf:"bytes,5,rep,name=defaultParams,proto3" json:"defaultParams,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	OverrideParams  map[string]*structpb.Value `protobuf:"bytes,6,rep,name=overrideParams,proto3" json:"overrideParams,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	RemoveParamKeys []string                   `protobuf:"bytes,7,rep,name=removeParamKeys,proto3" json:"removeParamKeys,omitempty"`
+	HeadersFrom     []*Upstream_HeaderFrom     `protobuf:"bytes,8,rep,name=headersFrom,proto3" json:"headersFrom,omitempty"`
+	Auth            []*Upstream_Auth           `protobuf:"bytes,9,rep,name=auth,proto3" json:"auth,omitempty"`
+	// Paths overrides the paths appended to url for each type of requests,
+	// keyed by the type, e.g. chat_completions, embeddings or models, the
+	// {model} placeholder is replaced by the name of the model.
+	Paths map[string]string `protobuf:"bytes,10,rep,name=paths,proto3" json:"paths,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Query is the static query parameters of upstream requests, the ones
+	// kept in secrets are placed through auth instead.
+	Query map[string]string `protobuf:"bytes,11,rep,name=query,proto3" json:"query,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Unset forwards nothing
+	DeadlineHeader *Upstream_DeadlineHeader `protobuf:"bytes,12,opt,name=deadlineHeader,proto3" json:"deadlineHeader,omitempty"`
+	// Endpoints are the replicas serving the upstream, e.g. the pods behind
+	// the service of url, as URLs with the scheme and the host only, such as
+	// http://10.0.0.12:8000. Requests are sent to the endpoint chosen by the
+	// endpoint selector filters of the cluster, or to each of them in turn
+	// when none chooses, with the scheme and the host of url replaced. Empty
+	// sends requests to url.
+	Endpoints []string `protobuf:"bytes,13,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
 }
 
 func (x *Upstream) Reset() {
@@ -458,12 +630,62 @@ func (x *Upstream) GetRemoveParamKeys() []string {
 	return nil
 }
 
+func (x *Upstream) GetHeadersFrom() []*Upstream_HeaderFrom {
+	if x != nil {
+		return x.HeadersFrom
+	}
+	return nil
+}
+
+func (x *Upstream) GetAuth() []*Upstream_Auth {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *Upstream) GetPaths() map[string]string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *Upstream) GetQuery() map[string]string {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *Upstream) GetDeadlineHeader() *Upstream_DeadlineHeader {
+	if x != nil {
+		return x.DeadlineHeader
+	}
+	return nil
+}
+
+func (x *Upstream) GetEndpoints() []string {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
 type ClusterMeteringPolicy struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SizeFrom *ClusterMeteringPolicy_SizeFrom `protobuf:"varint,1,opt,name=sizeFrom,proto3,enum=knoway.clusters.v1alpha1.ClusterMeteringPolicy_SizeFrom,oneof" json:"sizeFrom,omitempty"`
+	SizeFrom   *ClusterMeteringPolicy_SizeFrom   `protobuf:"varint,1,opt,name=sizeFrom,proto3,enum=knoway.clusters.v1alpha1.ClusterMeteringPolicy_SizeFrom,oneof" json:"sizeFrom,omitempty"`
+	ImageFetch *ClusterMeteringPolicy_ImageFetch `protobuf:"bytes,2,opt,name=imageFetch,proto3" json:"imageFetch,omitempty"`
+	// DurationFrom and ResolutionFrom are where the duration and the
+	// resolution of the generated videos are taken from, as SizeFrom is for
+	// images. The videos are metered once when the jobs are submitted, the
+	// output is the one the upstream reports for the submitted jobs.
+	DurationFrom   *ClusterMeteringPolicy_SizeFrom     `protobuf:"varint,4,opt,name=durationFrom,proto3,enum=knoway.clusters.v1alpha1.ClusterMeteringPolicy_SizeFrom,oneof" json:"durationFrom,omitempty"`
+	ResolutionFrom *ClusterMeteringPolicy_SizeFrom     `protobuf:"varint,5,opt,name=resolutionFrom,proto3,enum=knoway.clusters.v1alpha1.ClusterMeteringPolicy_SizeFrom,oneof" json:"resolutionFrom,omitempty"`
+	Expressions    []*ClusterMeteringPolicy_Expression `protobuf:"bytes,3,rep,name=expressions,proto3" json:"expressions,omitempty"`
 }
 
 func (x *ClusterMeteringPolicy) Reset() {
@@ -505,6 +727,34 @@ func (x *ClusterMeteringPolicy) GetSizeFrom() ClusterMeteringPolicy_SizeFrom {
 	return ClusterMeteringPolicy_SIZE_FROM_UNSPECIFIED
 }
 
+func (x *ClusterMeteringPolicy) GetImageFetch() *ClusterMeteringPolicy_ImageFetch {
+	if x != nil {
+		return x.ImageFetch
+	}
+	return nil
+}
+
+func (x *ClusterMeteringPolicy) GetDurationFrom() ClusterMeteringPolicy_SizeFrom {
+	if x != nil && x.DurationFrom != nil {
+		return *x.DurationFrom
+	}
+	return ClusterMeteringPolicy_SIZE_FROM_UNSPECIFIED
+}
+
+func (x *ClusterMeteringPolicy) GetResolutionFrom() ClusterMeteringPolicy_SizeFrom {
+	if x != nil && x.ResolutionFrom != nil {
+		return *x.ResolutionFrom
+	}
+	return ClusterMeteringPolicy_SIZE_FROM_UNSPECIFIED
+}
+
+func (x *ClusterMeteringPolicy) GetExpressions() []*ClusterMeteringPolicy_Expression {
+	if x != nil {
+		return x.Expressions
+	}
+	return nil
+}
+
 type Cluster struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -519,6 +769,21 @@ type Cluster struct {
 	Created           int64                  `protobuf:"varint,7,opt,name=created,proto3" json:"created,omitempty"`
 	Type              ClusterType            `protobuf:"varint,8,opt,name=type,proto3,enum=knoway.clusters.v1alpha1.ClusterType" json:"type,omitempty"`
 	MeteringPolicy    *ClusterMeteringPolicy `protobuf:"bytes,9,opt,name=meteringPolicy,proto3" json:"meteringPolicy,omitempty"`
+	// Maintenance takes the cluster out of rotation, requests already
+	// in-flight are not affected.
+	Maintenance *ClusterMaintenance `protobuf:"bytes,10,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
+	// CircuitBreaker ejects the cluster from the route targets after
+	// consecutive upstream errors, unset disables it.
+	CircuitBreaker *ClusterCircuitBreaker `protobuf:"bytes,11,opt,name=circuitBreaker,proto3" json:"circuitBreaker,omitempty"`
+	// Connection tunes the connections to the upstream, which are kept
+	// apart from other clusters, unset uses the defaults.
+	Connection *ClusterConnection `protobuf:"bytes,12,opt,name=connection,proto3" json:"connection,omitempty"`
+	// Pricing computes the cost of the requests served by the cluster from
+	// the usage reported by the upstream, unset leaves them unpriced.
+	Pricing *ClusterPricing `protobuf:"bytes,13,opt,name=pricing,proto3" json:"pricing,omitempty"`
+	// ModelInfo describes the model served by the cluster to the clients
+	// listing the models, unset lists the model without it.
+	ModelInfo *ClusterModelInfo `protobuf:"bytes,14,opt,name=modelInfo,proto3" json:"modelInfo,omitempty"`
 }
 
 func (x *Cluster) Reset() {
@@ -616,17 +881,58 @@ func (x *Cluster) GetMeteringPolicy() *ClusterMeteringPolicy {
 	return nil
 }
 
-type Upstream_Header struct {
+func (x *Cluster) GetMaintenance() *ClusterMaintenance {
+	if x != nil {
+		return x.Maintenance
+	}
+	return nil
+}
+
+func (x *Cluster) GetCircuitBreaker() *ClusterCircuitBreaker {
+	if x != nil {
+		return x.CircuitBreaker
+	}
+	return nil
+}
+
+func (x *Cluster) GetConnection() *ClusterConnection {
+	if x != nil {
+		return x.Connection
+	}
+	return nil
+}
+
+func (x *Cluster) GetPricing() *ClusterPricing {
+	if x != nil {
+		return x.Pricing
+	}
+	return nil
+}
+
+func (x *Cluster) GetModelInfo() *ClusterModelInfo {
+	if x != nil {
+		return x.ModelInfo
+	}
+	return nil
+}
+
+type ClusterMaintenance struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Start of the maintenance window, unset means the window has already
+	// started.
+	Start *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// End of the maintenance window, unset means the window never ends.
+	End *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// Reason of the maintenance, returned to clients whose requests are
+	// rejected.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *Upstream_Header) Reset() {
-	*x = Upstream_Header{}
+func (x *ClusterMaintenance) Reset() {
+	*x = ClusterMaintenance{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -634,13 +940,13 @@ func (x *Upstream_Header) Reset() {
 	}
 }
 
-func (x *Upstream_Header) String() string {
+func (x *ClusterMaintenance) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Upstream_Header) ProtoMessage() {}
+func (*ClusterMaintenance) ProtoMessage() {}
 
-func (x *Upstream_Header) ProtoReflect() protoreflect.Message {
+func (x *ClusterMaintenance) ProtoReflect() protoreflect.Message {
 	mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -652,243 +958,1529 @@ func (x *Upstream_Header) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Upstream_Header.ProtoReflect.Descriptor instead.
-func (*Upstream_Header) Descriptor() ([]byte, []int) {
-	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{2, 0}
+// Deprecated: Use ClusterMaintenance.ProtoReflect.Descriptor instead.
+func (*ClusterMaintenance) Descriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Upstream_Header) GetKey() string {
+func (x *ClusterMaintenance) GetStart() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Key
+		return x.Start
 	}
-	return ""
+	return nil
 }
 
-func (x *Upstream_Header) GetValue() string {
+func (x *ClusterMaintenance) GetEnd() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Value
+		return x.End
+	}
+	return nil
+}
+
+func (x *ClusterMaintenance) GetReason() string {
+	if x != nil {
+		return x.Reason
 	}
 	return ""
 }
 
-var File_clusters_v1alpha1_cluster_proto protoreflect.FileDescriptor
+type ClusterCircuitBreaker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_clusters_v1alpha1_cluster_proto_rawDesc = []byte{
-	0x0a, 0x1f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x31, 0x2f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x18, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
-	0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x19, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x51, 0x0a, 0x0d, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x46,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52,
-	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x0b, 0x0a, 0x09, 0x54, 0x4c, 0x53, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x22, 0xc9, 0x04, 0x0a, 0x08, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x75, 0x72, 0x6c, 0x12, 0x43, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c,
-	0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52,
-	0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65,
-	0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f,
-	0x75, 0x74, 0x12, 0x5b, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x61, 0x72,
-	0x61, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6b, 0x6e, 0x6f, 0x77,
-	0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x44, 0x65,
-	0x66, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12,
-	0x5e, 0x0a, 0x0e, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d,
-	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79,
-	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x4f, 0x76, 0x65, 0x72,
-	0x72, 0x69, 0x64, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x0e, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12,
-	0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x4b, 0x65,
-	0x79, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
-	0x50, 0x61, 0x72, 0x61, 0x6d, 0x4b, 0x65, 0x79, 0x73, 0x1a, 0x30, 0x0a, 0x06, 0x48, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x58, 0x0a, 0x12, 0x44,
-	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x59, 0x0a, 0x13, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
-	0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x22, 0xe9, 0x01, 0x0a, 0x15, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x65,
-	0x72, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x59, 0x0a, 0x08, 0x73, 0x69,
-	0x7a, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x38, 0x2e, 0x6b,
-	0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4d,
-	0x65, 0x74, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x53, 0x69,
-	0x7a, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x48, 0x00, 0x52, 0x08, 0x73, 0x69, 0x7a, 0x65, 0x46, 0x72,
-	0x6f, 0x6d, 0x88, 0x01, 0x01, 0x22, 0x68, 0x0a, 0x08, 0x53, 0x69, 0x7a, 0x65, 0x46, 0x72, 0x6f,
-	0x6d, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x49, 0x5a, 0x45, 0x5f, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x55,
-	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f,
-	0x53, 0x49, 0x5a, 0x45, 0x5f, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x10,
-	0x01, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x49, 0x5a, 0x45, 0x5f, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x4f,
-	0x55, 0x54, 0x50, 0x55, 0x54, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x49, 0x5a, 0x45, 0x5f,
-	0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x47, 0x52, 0x45, 0x41, 0x54, 0x45, 0x53, 0x54, 0x10, 0x03, 0x42,
-	0x0b, 0x0a, 0x09, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x22, 0xb3, 0x04, 0x0a,
-	0x07, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x59, 0x0a, 0x11,
-	0x6c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63,
-	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79,
-	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x6f,
-	0x6c, 0x69, 0x63, 0x79, 0x52, 0x11, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
-	0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x3e, 0x0a, 0x08, 0x75, 0x70, 0x73, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b, 0x6e, 0x6f, 0x77,
-	0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x08, 0x75,
-	0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x41, 0x0a, 0x09, 0x74, 0x6c, 0x73, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x6e, 0x6f,
-	0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
-	0x09, 0x74, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x41, 0x0a, 0x07, 0x66, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6b, 0x6e,
-	0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x45, 0x0a,
-	0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x29, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
-	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74,
-	0x65, 0x72, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76,
-	0x69, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x39,
-	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x6b,
-	0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x54,
-	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x57, 0x0a, 0x0e, 0x6d, 0x65, 0x74,
-	0x65, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74,
-	0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75,
-	0x73, 0x74, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69,
-	0x63, 0x79, 0x52, 0x0e, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69,
-	0x63, 0x79, 0x2a, 0x78, 0x0a, 0x11, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
-	0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x23, 0x0a, 0x1f, 0x4c, 0x4f, 0x41, 0x44, 0x5f,
-	0x42, 0x41, 0x4c, 0x41, 0x4e, 0x43, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x55,
-	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b,
-	0x52, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x52, 0x4f, 0x42, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x14, 0x0a,
-	0x10, 0x4c, 0x45, 0x41, 0x53, 0x54, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f,
-	0x4e, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x50, 0x5f, 0x48, 0x41, 0x53, 0x48, 0x10, 0x03,
-	0x12, 0x0a, 0x0a, 0x06, 0x43, 0x55, 0x53, 0x54, 0x4f, 0x4d, 0x10, 0x0f, 0x2a, 0x61, 0x0a, 0x0b,
-	0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x43,
-	0x4c, 0x55, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
-	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x4c, 0x4d,
-	0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x4d, 0x41, 0x47, 0x45, 0x5f, 0x47, 0x45, 0x4e, 0x45,
-	0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x50, 0x45, 0x45,
-	0x43, 0x48, 0x5f, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x03, 0x2a,
-	0x8e, 0x02, 0x0a, 0x0f, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x76, 0x69,
-	0x64, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x4c, 0x55, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x50,
-	0x52, 0x4f, 0x56, 0x49, 0x44, 0x45, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
-	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49,
-	0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x56, 0x4c, 0x4c, 0x4d, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06,
-	0x4f, 0x4c, 0x4c, 0x41, 0x4d, 0x41, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x4f, 0x50, 0x45, 0x4e,
-	0x5f, 0x41, 0x49, 0x5f, 0x56, 0x31, 0x5f, 0x53, 0x50, 0x45, 0x45, 0x43, 0x48, 0x10, 0x04, 0x12,
-	0x19, 0x0a, 0x15, 0x44, 0x45, 0x45, 0x50, 0x47, 0x52, 0x41, 0x4d, 0x5f, 0x57, 0x45, 0x42, 0x53,
-	0x4f, 0x43, 0x4b, 0x45, 0x54, 0x5f, 0x56, 0x31, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x45, 0x4c,
-	0x45, 0x56, 0x45, 0x4e, 0x5f, 0x4c, 0x41, 0x42, 0x53, 0x5f, 0x56, 0x31, 0x10, 0x06, 0x12, 0x10,
-	0x0a, 0x0c, 0x4b, 0x4f, 0x45, 0x4d, 0x4f, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x56, 0x31, 0x10, 0x07,
-	0x12, 0x1d, 0x0a, 0x19, 0x56, 0x4f, 0x4c, 0x43, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x53,
-	0x45, 0x45, 0x44, 0x5f, 0x53, 0x50, 0x45, 0x45, 0x43, 0x48, 0x5f, 0x56, 0x31, 0x10, 0x08, 0x12,
-	0x1e, 0x0a, 0x1a, 0x41, 0x4c, 0x49, 0x42, 0x41, 0x42, 0x41, 0x5f, 0x43, 0x4f, 0x53, 0x59, 0x5f,
-	0x56, 0x4f, 0x49, 0x43, 0x45, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x49, 0x43, 0x45, 0x10, 0x09, 0x12,
-	0x1f, 0x0a, 0x1b, 0x4d, 0x49, 0x43, 0x52, 0x4f, 0x53, 0x4f, 0x46, 0x54, 0x5f, 0x53, 0x50, 0x45,
-	0x45, 0x43, 0x48, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x56, 0x31, 0x10, 0x0a,
-	0x42, 0x22, 0x5a, 0x20, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	// Consecutive 5xx responses or timeouts of the upstream to eject the
+	// cluster, default: 5
+	ConsecutiveErrors uint32 `protobuf:"varint,1,opt,name=consecutiveErrors,proto3" json:"consecutiveErrors,omitempty"`
+	// Cooldown of the ejection, after which the cluster takes requests
+	// again, default: 30s
+	Cooldown *durationpb.Duration `protobuf:"bytes,2,opt,name=cooldown,proto3" json:"cooldown,omitempty"`
 }
 
-var (
-	file_clusters_v1alpha1_cluster_proto_rawDescOnce sync.Once
-	file_clusters_v1alpha1_cluster_proto_rawDescData = file_clusters_v1alpha1_cluster_proto_rawDesc
-)
+func (x *ClusterCircuitBreaker) Reset() {
+	*x = ClusterCircuitBreaker{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_clusters_v1alpha1_cluster_proto_rawDescGZIP() []byte {
-	file_clusters_v1alpha1_cluster_proto_rawDescOnce.Do(func() {
-		file_clusters_v1alpha1_cluster_proto_rawDescData = protoimpl.X.CompressGZIP(file_clusters_v1alpha1_cluster_proto_rawDescData)
-	})
-	return file_clusters_v1alpha1_cluster_proto_rawDescData
+func (x *ClusterCircuitBreaker) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_clusters_v1alpha1_cluster_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_clusters_v1alpha1_cluster_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_clusters_v1alpha1_cluster_proto_goTypes = []interface{}{
-	(LoadBalancePolicy)(0),              // 0: knoway.clusters.v1alpha1.LoadBalancePolicy
-	(ClusterType)(0),                    // 1: knoway.clusters.v1alpha1.ClusterType
-	(ClusterProvider)(0),                // 2: knoway.clusters.v1alpha1.ClusterProvider
-	(ClusterMeteringPolicy_SizeFrom)(0), // 3: knoway.clusters.v1alpha1.ClusterMeteringPolicy.SizeFrom
-	(*ClusterFilter)(nil),               // 4: knoway.clusters.v1alpha1.ClusterFilter
-	(*TLSConfig)(nil),                   // 5: knoway.clusters.v1alpha1.TLSConfig
-	(*Upstream)(nil),                    // 6: knoway.clusters.v1alpha1.Upstream
-	(*ClusterMeteringPolicy)(nil),       // 7: knoway.clusters.v1alpha1.ClusterMeteringPolicy
-	(*Cluster)(nil),                     // 8: knoway.clusters.v1alpha1.Cluster
-	(*Upstream_Header)(nil),             // 9: knoway.clusters.v1alpha1.Upstream.Header
-	nil,                                 // 10: knoway.clusters.v1alpha1.Upstream.DefaultParamsEntry
-	nil,                                 // 11: knoway.clusters.v1alpha1.Upstream.OverrideParamsEntry
-	(*anypb.Any)(nil),                   // 12: google.protobuf.Any
-	(*structpb.Value)(nil),              // 13: google.protobuf.Value
+func (*ClusterCircuitBreaker) ProtoMessage() {}
+
+func (x *ClusterCircuitBreaker) ProtoReflect() protoreflect.Message {
+	mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_clusters_v1alpha1_cluster_proto_depIdxs = []int32{
-	12, // 0: knoway.clusters.v1alpha1.ClusterFilter.config:type_name -> google.protobuf.Any
-	9,  // 1: knoway.clusters.v1alpha1.Upstream.headers:type_name -> knoway.clusters.v1alpha1.Upstream.Header
-	10, // 2: knoway.clusters.v1alpha1.Upstream.defaultParams:type_name -> knoway.clusters.v1alpha1.Upstream.DefaultParamsEntry
-	11, // 3: knoway.clusters.v1alpha1.Upstream.overrideParams:type_name -> knoway.clusters.v1alpha1.Upstream.OverrideParamsEntry
-	3,  // 4: knoway.clusters.v1alpha1.ClusterMeteringPolicy.sizeFrom:type_name -> knoway.clusters.v1alpha1.ClusterMeteringPolicy.SizeFrom
-	0,  // 5: knoway.clusters.v1alpha1.Cluster.loadBalancePolicy:type_name -> knoway.clusters.v1alpha1.LoadBalancePolicy
-	6,  // 6: knoway.clusters.v1alpha1.Cluster.upstream:type_name -> knoway.clusters.v1alpha1.Upstream
-	5,  // 7: knoway.clusters.v1alpha1.Cluster.tlsConfig:type_name -> knoway.clusters.v1alpha1.TLSConfig
-	4,  // 8: knoway.clusters.v1alpha1.Cluster.filters:type_name -> knoway.clusters.v1alpha1.ClusterFilter
-	2,  // 9: knoway.clusters.v1alpha1.Cluster.provider:type_name -> knoway.clusters.v1alpha1.ClusterProvider
-	1,  // 10: knoway.clusters.v1alpha1.Cluster.type:type_name -> knoway.clusters.v1alpha1.ClusterType
-	7,  // 11: knoway.clusters.v1alpha1.Cluster.meteringPolicy:type_name -> knoway.clusters.v1alpha1.ClusterMeteringPolicy
-	13, // 12: knoway.clusters.v1alpha1.Upstream.DefaultParamsEntry.value:type_name -> google.protobuf.Value
-	13, // 13: knoway.clusters.v1alpha1.Upstream.OverrideParamsEntry.value:type_name -> google.protobuf.Value
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+
+// Deprecated: Use ClusterCircuitBreaker.ProtoReflect.Descriptor instead.
+func (*ClusterCircuitBreaker) Descriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{6}
 }
 
-func init() { file_clusters_v1alpha1_cluster_proto_init() }
-func file_clusters_v1alpha1_cluster_proto_init() {
-	if File_clusters_v1alpha1_cluster_proto != nil {
-		return
+func (x *ClusterCircuitBreaker) GetConsecutiveErrors() uint32 {
+	if x != nil {
+		return x.ConsecutiveErrors
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_clusters_v1alpha1_cluster_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClusterFilter); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return 0
+}
+
+func (x *ClusterCircuitBreaker) GetCooldown() *durationpb.Duration {
+	if x != nil {
+		return x.Cooldown
+	}
+	return nil
+}
+
+type ClusterConnection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// PinAddresses resolves the host of the upstream when the cluster is
+	// registered, and dials the resolved addresses instead of resolving on
+	// every new connection.
+	PinAddresses bool `protobuf:"varint,1,opt,name=pinAddresses,proto3" json:"pinAddresses,omitempty"`
+	// Interval to resolve the pinned addresses again, default: 30s
+	DnsRefreshInterval *durationpb.Duration `protobuf:"bytes,2,opt,name=dnsRefreshInterval,proto3" json:"dnsRefreshInterval,omitempty"`
+	// Number of TLS sessions cached to resume them on new connections,
+	// skipping full handshakes, 0 disables the cache, default: 64
+	TlsSessionCacheSize *uint32 `protobuf:"varint,3,opt,name=tlsSessionCacheSize,proto3,oneof" json:"tlsSessionCacheSize,omitempty"`
+	// Maximum idle connections kept to the upstream, default: 32
+	MaxIdleConns uint32 `protobuf:"varint,4,opt,name=maxIdleConns,proto3" json:"maxIdleConns,omitempty"`
+	// Maximum idle connections kept per host of the upstream, default:
+	// maxIdleConns
+	MaxIdleConnsPerHost uint32 `protobuf:"varint,5,opt,name=maxIdleConnsPerHost,proto3" json:"maxIdleConnsPerHost,omitempty"`
+	// Timeout of dialing new connections, default: 30s
+	DialTimeout *durationpb.Duration `protobuf:"bytes,6,opt,name=dialTimeout,proto3" json:"dialTimeout,omitempty"`
+	// Timeout of waiting for the response headers after the request is
+	// written, which bounds the time to the first token of streams, default:
+	// no timeout
+	ResponseHeaderTimeout *durationpb.Duration `protobuf:"bytes,7,opt,name=responseHeaderTimeout,proto3" json:"responseHeaderTimeout,omitempty"`
+	// Interval of the TCP keep-alive probes of the connections, default: 30s
+	KeepAlive *durationpb.Duration `protobuf:"bytes,8,opt,name=keepAlive,proto3" json:"keepAlive,omitempty"`
+	// How long idle connections are kept before closed, default: 90s
+	IdleConnTimeout *durationpb.Duration `protobuf:"bytes,9,opt,name=idleConnTimeout,proto3" json:"idleConnTimeout,omitempty"`
+	// DisableHTTP2 sends requests over HTTP/1.1 only, for upstreams with
+	// broken HTTP/2 support.
+	DisableHTTP2 bool `protobuf:"varint,10,opt,name=disableHTTP2,proto3" json:"disableHTTP2,omitempty"`
+}
+
+func (x *ClusterConnection) Reset() {
+	*x = ClusterConnection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClusterConnection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterConnection) ProtoMessage() {}
+
+func (x *ClusterConnection) ProtoReflect() protoreflect.Message {
+	mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_clusters_v1alpha1_cluster_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TLSConfig); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterConnection.ProtoReflect.Descriptor instead.
+func (*ClusterConnection) Descriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ClusterConnection) GetPinAddresses() bool {
+	if x != nil {
+		return x.PinAddresses
+	}
+	return false
+}
+
+func (x *ClusterConnection) GetDnsRefreshInterval() *durationpb.Duration {
+	if x != nil {
+		return x.DnsRefreshInterval
+	}
+	return nil
+}
+
+func (x *ClusterConnection) GetTlsSessionCacheSize() uint32 {
+	if x != nil && x.TlsSessionCacheSize != nil {
+		return *x.TlsSessionCacheSize
+	}
+	return 0
+}
+
+func (x *ClusterConnection) GetMaxIdleConns() uint32 {
+	if x != nil {
+		return x.MaxIdleConns
+	}
+	return 0
+}
+
+func (x *ClusterConnection) GetMaxIdleConnsPerHost() uint32 {
+	if x != nil {
+		return x.MaxIdleConnsPerHost
+	}
+	return 0
+}
+
+func (x *ClusterConnection) GetDialTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.DialTimeout
+	}
+	return nil
+}
+
+func (x *ClusterConnection) GetResponseHeaderTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ResponseHeaderTimeout
+	}
+	return nil
+}
+
+func (x *ClusterConnection) GetKeepAlive() *durationpb.Duration {
+	if x != nil {
+		return x.KeepAlive
+	}
+	return nil
+}
+
+func (x *ClusterConnection) GetIdleConnTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.IdleConnTimeout
+	}
+	return nil
+}
+
+func (x *ClusterConnection) GetDisableHTTP2() bool {
+	if x != nil {
+		return x.DisableHTTP2
+	}
+	return false
+}
+
+type ClusterPricing struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Currency of the prices, such as USD, only informational.
+	Currency string `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Price of every 1000 prompt tokens.
+	PromptPer1KTokens float64 `protobuf:"fixed64,2,opt,name=promptPer1kTokens,proto3" json:"promptPer1kTokens,omitempty"`
+	// Price of every 1000 completion tokens.
+	CompletionPer1KTokens float64 `protobuf:"fixed64,3,opt,name=completionPer1kTokens,proto3" json:"completionPer1kTokens,omitempty"`
+	// Prices of every image generated, the first one matching the size and
+	// the quality of the image applies.
+	Images []*ClusterPricing_Image `protobuf:"bytes,4,rep,name=images,proto3" json:"images,omitempty"`
+	// Price of every second of the audio transcribed or translated.
+	PerAudioSecond float64 `protobuf:"fixed64,5,opt,name=perAudioSecond,proto3" json:"perAudioSecond,omitempty"`
+}
+
+func (x *ClusterPricing) Reset() {
+	*x = ClusterPricing{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClusterPricing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterPricing) ProtoMessage() {}
+
+func (x *ClusterPricing) ProtoReflect() protoreflect.Message {
+	mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterPricing.ProtoReflect.Descriptor instead.
+func (*ClusterPricing) Descriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ClusterPricing) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ClusterPricing) GetPromptPer1KTokens() float64 {
+	if x != nil {
+		return x.PromptPer1KTokens
+	}
+	return 0
+}
+
+func (x *ClusterPricing) GetCompletionPer1KTokens() float64 {
+	if x != nil {
+		return x.CompletionPer1KTokens
+	}
+	return 0
+}
+
+func (x *ClusterPricing) GetImages() []*ClusterPricing_Image {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *ClusterPricing) GetPerAudioSecond() float64 {
+	if x != nil {
+		return x.PerAudioSecond
+	}
+	return 0
+}
+
+type ClusterModelInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Max number of tokens of the prompt and the completion together, 0 if
+	// unknown.
+	ContextWindow int64 `protobuf:"varint,1,opt,name=contextWindow,proto3" json:"contextWindow,omitempty"`
+	// Capabilities of the model, empty if unknown.
+	Capabilities []ModelCapability `protobuf:"varint,2,rep,packed,name=capabilities,proto3,enum=knoway.clusters.v1alpha1.ModelCapability" json:"capabilities,omitempty"`
+}
+
+func (x *ClusterModelInfo) Reset() {
+	*x = ClusterModelInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClusterModelInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterModelInfo) ProtoMessage() {}
+
+func (x *ClusterModelInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterModelInfo.ProtoReflect.Descriptor instead.
+func (*ClusterModelInfo) Descriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ClusterModelInfo) GetContextWindow() int64 {
+	if x != nil {
+		return x.ContextWindow
+	}
+	return 0
+}
+
+func (x *ClusterModelInfo) GetCapabilities() []ModelCapability {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+type Upstream_Header struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Upstream_Header) Reset() {
+	*x = Upstream_Header{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upstream_Header) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upstream_Header) ProtoMessage() {}
+
+func (x *Upstream_Header) ProtoReflect() protoreflect.Message {
+	mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upstream_Header.ProtoReflect.Descriptor instead.
+func (*Upstream_Header) Descriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *Upstream_Header) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Upstream_Header) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// HeaderFrom references headers kept in external credential providers,
+// they are resolved and rotated by the gateway at runtime, so that the
+// values never get stored in the cluster config.
+type Upstream_HeaderFrom struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Prefix is prepended to every key of the secret
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Types that are assignable to Source:
+	//
+	//	*Upstream_HeaderFrom_Vault_
+	Source isUpstream_HeaderFrom_Source `protobuf_oneof:"source"`
+}
+
+func (x *Upstream_HeaderFrom) Reset() {
+	*x = Upstream_HeaderFrom{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upstream_HeaderFrom) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upstream_HeaderFrom) ProtoMessage() {}
+
+func (x *Upstream_HeaderFrom) ProtoReflect() protoreflect.Message {
+	mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upstream_HeaderFrom.ProtoReflect.Descriptor instead.
+func (*Upstream_HeaderFrom) Descriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{2, 3}
+}
+
+func (x *Upstream_HeaderFrom) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (m *Upstream_HeaderFrom) GetSource() isUpstream_HeaderFrom_Source {
+	if m != nil {
+		return m.Source
+	}
+	return nil
+}
+
+func (x *Upstream_HeaderFrom) GetVault() *Upstream_HeaderFrom_Vault {
+	if x, ok := x.GetSource().(*Upstream_HeaderFrom_Vault_); ok {
+		return x.Vault
+	}
+	return nil
+}
+
+type isUpstream_HeaderFrom_Source interface {
+	isUpstream_HeaderFrom_Source()
+}
+
+type Upstream_HeaderFrom_Vault_ struct {
+	Vault *Upstream_HeaderFrom_Vault `protobuf:"bytes,2,opt,name=vault,proto3,oneof"`
+}
+
+func (*Upstream_HeaderFrom_Vault_) isUpstream_HeaderFrom_Source() {}
+
+// Auth places credentials into the query parameters or cookies of
+// upstream requests, for upstreams not authenticating with headers.
+type Upstream_Auth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Scheme Upstream_Auth_Scheme `protobuf:"varint,1,opt,name=scheme,proto3,enum=knoway.clusters.v1alpha1.Upstream_Auth_Scheme" json:"scheme,omitempty"`
+	// Name of the query parameter or the cookie
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Types that are assignable to Source:
+	//
+	//	*Upstream_Auth_Value
+	//	*Upstream_Auth_Vault_
+	Source isUpstream_Auth_Source `protobuf_oneof:"source"`
+}
+
+func (x *Upstream_Auth) Reset() {
+	*x = Upstream_Auth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upstream_Auth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upstream_Auth) ProtoMessage() {}
+
+func (x *Upstream_Auth) ProtoReflect() protoreflect.Message {
+	mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upstream_Auth.ProtoReflect.Descriptor instead.
+func (*Upstream_Auth) Descriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{2, 4}
+}
+
+func (x *Upstream_Auth) GetScheme() Upstream_Auth_Scheme {
+	if x != nil {
+		return x.Scheme
+	}
+	return Upstream_Auth_SCHEME_UNSPECIFIED
+}
+
+func (x *Upstream_Auth) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (m *Upstream_Auth) GetSource() isUpstream_Auth_Source {
+	if m != nil {
+		return m.Source
+	}
+	return nil
+}
+
+func (x *Upstream_Auth) GetValue() string {
+	if x, ok := x.GetSource().(*Upstream_Auth_Value); ok {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Upstream_Auth) GetVault() *Upstream_Auth_Vault {
+	if x, ok := x.GetSource().(*Upstream_Auth_Vault_); ok {
+		return x.Vault
+	}
+	return nil
+}
+
+type isUpstream_Auth_Source interface {
+	isUpstream_Auth_Source()
+}
+
+type Upstream_Auth_Value struct {
+	// Value resolved by the controller
+	Value string `protobuf:"bytes,3,opt,name=value,proto3,oneof"`
+}
+
+type Upstream_Auth_Vault_ struct {
+	// Vault references the credential kept in HashiCorp Vault, it is
+	// resolved and rotated by the gateway
+	Vault *Upstream_Auth_Vault `protobuf:"bytes,4,opt,name=vault,proto3,oneof"`
+}
+
+func (*Upstream_Auth_Value) isUpstream_Auth_Source() {}
+
+func (*Upstream_Auth_Vault_) isUpstream_Auth_Source() {}
+
+// DeadlineHeader forwards the time remaining of the timeout of the route
+// to the upstream in milliseconds, so that upstreams able to adapt, e.g.
+// by shortening generations, can respond within it.
+type Upstream_DeadlineHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the header, default: X-Knoway-Deadline-Ms
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *Upstream_DeadlineHeader) Reset() {
+	*x = Upstream_DeadlineHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upstream_DeadlineHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upstream_DeadlineHeader) ProtoMessage() {}
+
+func (x *Upstream_DeadlineHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upstream_DeadlineHeader.ProtoReflect.Descriptor instead.
+func (*Upstream_DeadlineHeader) Descriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{2, 7}
+}
+
+func (x *Upstream_DeadlineHeader) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Upstream_HeaderFrom_Vault struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path of the secret, e.g. secret/data/openai for KV v2
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Role to login with through the Kubernetes auth method
+	Role string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+}
+
+func (x *Upstream_HeaderFrom_Vault) Reset() {
+	*x = Upstream_HeaderFrom_Vault{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upstream_HeaderFrom_Vault) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upstream_HeaderFrom_Vault) ProtoMessage() {}
+
+func (x *Upstream_HeaderFrom_Vault) ProtoReflect() protoreflect.Message {
+	mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upstream_HeaderFrom_Vault.ProtoReflect.Descriptor instead.
+func (*Upstream_HeaderFrom_Vault) Descriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{2, 3, 0}
+}
+
+func (x *Upstream_HeaderFrom_Vault) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Upstream_HeaderFrom_Vault) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type Upstream_Auth_Vault struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path of the secret, e.g. secret/data/gemini for KV v2
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Role to login with through the Kubernetes auth method
+	Role string `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	// Key of the secret holding the credential
+	Key string `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *Upstream_Auth_Vault) Reset() {
+	*x = Upstream_Auth_Vault{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upstream_Auth_Vault) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upstream_Auth_Vault) ProtoMessage() {}
+
+func (x *Upstream_Auth_Vault) ProtoReflect() protoreflect.Message {
+	mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upstream_Auth_Vault.ProtoReflect.Descriptor instead.
+func (*Upstream_Auth_Vault) Descriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{2, 4, 0}
+}
+
+func (x *Upstream_Auth_Vault) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Upstream_Auth_Vault) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Upstream_Auth_Vault) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// ImageFetch restricts how images returned as URLs by upstream are
+// fetched when SIZE_FROM_OUTPUT or SIZE_FROM_GREATEST is used, since the
+// URLs are not trusted.
+type ClusterMeteringPolicy_ImageFetch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Timeout of fetching each image, default: 30s
+	Timeout *durationpb.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// Maximum size of each image in bytes, default: 20 MiB
+	MaxSizeBytes *uint64 `protobuf:"varint,2,opt,name=maxSizeBytes,proto3,oneof" json:"maxSizeBytes,omitempty"`
+	// Maximum number of redirects to follow, 0 disallows redirects,
+	// default: 3
+	MaxRedirects *uint32 `protobuf:"varint,3,opt,name=maxRedirects,proto3,oneof" json:"maxRedirects,omitempty"`
+	// Allow fetching from loopback, private, link-local and other
+	// non-public addresses, default: false
+	AllowPrivateNetworks bool `protobuf:"varint,4,opt,name=allowPrivateNetworks,proto3" json:"allowPrivateNetworks,omitempty"`
+	// Allowed media types of the response, wildcards like image/* are
+	// supported, default: image/*
+	AllowedContentTypes []string `protobuf:"bytes,5,rep,name=allowedContentTypes,proto3" json:"allowedContentTypes,omitempty"`
+}
+
+func (x *ClusterMeteringPolicy_ImageFetch) Reset() {
+	*x = ClusterMeteringPolicy_ImageFetch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClusterMeteringPolicy_ImageFetch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterMeteringPolicy_ImageFetch) ProtoMessage() {}
+
+func (x *ClusterMeteringPolicy_ImageFetch) ProtoReflect() protoreflect.Message {
+	mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterMeteringPolicy_ImageFetch.ProtoReflect.Descriptor instead.
+func (*ClusterMeteringPolicy_ImageFetch) Descriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *ClusterMeteringPolicy_ImageFetch) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *ClusterMeteringPolicy_ImageFetch) GetMaxSizeBytes() uint64 {
+	if x != nil && x.MaxSizeBytes != nil {
+		return *x.MaxSizeBytes
+	}
+	return 0
+}
+
+func (x *ClusterMeteringPolicy_ImageFetch) GetMaxRedirects() uint32 {
+	if x != nil && x.MaxRedirects != nil {
+		return *x.MaxRedirects
+	}
+	return 0
+}
+
+func (x *ClusterMeteringPolicy_ImageFetch) GetAllowPrivateNetworks() bool {
+	if x != nil {
+		return x.AllowPrivateNetworks
+	}
+	return false
+}
+
+func (x *ClusterMeteringPolicy_ImageFetch) GetAllowedContentTypes() []string {
+	if x != nil {
+		return x.AllowedContentTypes
+	}
+	return nil
+}
+
+// Expression computes the billable units of the requests served by the
+// cluster with a CEL expression, evaluated against the metadata of the
+// request and the response when the usage is reported.
+//
+// Variables available to the expression:
+//
+//	request:          map with model, type, stream and body
+//	response:         map with model
+//	usage:            map with prompt_tokens, completion_tokens,
+//	                  total_tokens, images and videos, images is a list
+//	                  of maps with width, height, quality and style,
+//	                  videos is a list of maps with width, height and
+//	                  seconds
+//	duration_seconds: double, seconds elapsed since the request arrived
+//
+// For example, "usage.prompt_tokens + usage.completion_tokens * 3" or
+// "size(request.body.input)" for characters of text-to-speech requests.
+type ClusterMeteringPolicy_Expression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unit of the billable units, such as tokens, images, characters or
+	// seconds.
+	Unit string `protobuf:"bytes,1,opt,name=unit,proto3" json:"unit,omitempty"`
+	// Expression evaluates to an int, uint or double.
+	Expression string `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
+}
+
+func (x *ClusterMeteringPolicy_Expression) Reset() {
+	*x = ClusterMeteringPolicy_Expression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClusterMeteringPolicy_Expression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterMeteringPolicy_Expression) ProtoMessage() {}
+
+func (x *ClusterMeteringPolicy_Expression) ProtoReflect() protoreflect.Message {
+	mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterMeteringPolicy_Expression.ProtoReflect.Descriptor instead.
+func (*ClusterMeteringPolicy_Expression) Descriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{3, 1}
+}
+
+func (x *ClusterMeteringPolicy_Expression) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *ClusterMeteringPolicy_Expression) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+type ClusterPricing_Image struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Size of the images, such as 1024x1024, empty matches any size.
+	Size string `protobuf:"bytes,1,opt,name=size,proto3" json:"size,omitempty"`
+	// Quality of the images, such as hd, empty matches any quality.
+	Quality string `protobuf:"bytes,2,opt,name=quality,proto3" json:"quality,omitempty"`
+	// Price of every image.
+	Price float64 `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+}
+
+func (x *ClusterPricing_Image) Reset() {
+	*x = ClusterPricing_Image{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClusterPricing_Image) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClusterPricing_Image) ProtoMessage() {}
+
+func (x *ClusterPricing_Image) ProtoReflect() protoreflect.Message {
+	mi := &file_clusters_v1alpha1_cluster_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClusterPricing_Image.ProtoReflect.Descriptor instead.
+func (*ClusterPricing_Image) Descriptor() ([]byte, []int) {
+	return file_clusters_v1alpha1_cluster_proto_rawDescGZIP(), []int{8, 0}
+}
+
+func (x *ClusterPricing_Image) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *ClusterPricing_Image) GetQuality() string {
+	if x != nil {
+		return x.Quality
+	}
+	return ""
+}
+
+func (x *ClusterPricing_Image) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+var File_clusters_v1alpha1_cluster_proto protoreflect.FileDescriptor
+
+var file_clusters_v1alpha1_cluster_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x18, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x19, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x86, 0x01, 0x0a, 0x0d, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e,
+	0x79, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x0b,
+	0x0a, 0x09, 0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0xf3, 0x0c, 0x0a, 0x08,
+	0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x43, 0x0a, 0x07, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x5b, 0x0a, 0x0d, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x35, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x2e, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x5e, 0x0a, 0x0e, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69,
+	0x64, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36,
+	0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x4b, 0x65, 0x79, 0x73,
+	0x12, 0x4f, 0x0a, 0x0b, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x46, 0x72, 0x6f, 0x6d, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x46, 0x72, 0x6f, 0x6d, 0x52, 0x0b, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x46, 0x72, 0x6f,
+	0x6d, 0x12, 0x3b, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x27, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x43,
+	0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e,
+	0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x70, 0x61,
+	0x74, 0x68, 0x73, 0x12, 0x43, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x0b, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x59, 0x0a, 0x0e, 0x64, 0x65, 0x61, 0x64,
+	0x6c, 0x69, 0x6e, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x31, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x2e, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x52, 0x0e, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x1a, 0x30, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x1a, 0x58, 0x0a, 0x12, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x59, 0x0a,
+	0x13, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xac, 0x01, 0x0a, 0x0a, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12,
+	0x4b, 0x0a, 0x05, 0x76, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33,
+	0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x46, 0x72, 0x6f, 0x6d, 0x2e, 0x56, 0x61,
+	0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x05, 0x76, 0x61, 0x75, 0x6c, 0x74, 0x1a, 0x2f, 0x0a, 0x05,
+	0x56, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x42, 0x08, 0x0a,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0xcd, 0x02, 0x0a, 0x04, 0x41, 0x75, 0x74, 0x68,
+	0x12, 0x46, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x2e, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65,
+	0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x45, 0x0a, 0x05, 0x76, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55,
+	0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x2e, 0x56, 0x61, 0x75,
+	0x6c, 0x74, 0x48, 0x00, 0x52, 0x05, 0x76, 0x61, 0x75, 0x6c, 0x74, 0x1a, 0x41, 0x0a, 0x05, 0x56,
+	0x61, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x3d,
+	0x0a, 0x06, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x43, 0x48, 0x45,
+	0x4d, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x0f, 0x0a, 0x0b, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x50, 0x41, 0x52, 0x41, 0x4d, 0x10,
+	0x01, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x4f, 0x4f, 0x4b, 0x49, 0x45, 0x10, 0x02, 0x42, 0x08, 0x0a,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x38, 0x0a, 0x0a, 0x50, 0x61, 0x74, 0x68, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x1a, 0x38, 0x0a, 0x0a, 0x51, 0x75, 0x65, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x24, 0x0a, 0x0e, 0x44,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x22, 0xf1, 0x07, 0x0a, 0x15, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4d, 0x65, 0x74,
+	0x65, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x59, 0x0a, 0x08, 0x73,
+	0x69, 0x7a, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x38, 0x2e,
+	0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x4d, 0x65, 0x74, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x53,
+	0x69, 0x7a, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x48, 0x00, 0x52, 0x08, 0x73, 0x69, 0x7a, 0x65, 0x46,
+	0x72, 0x6f, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x5a, 0x0a, 0x0a, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x46,
+	0x65, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x6b, 0x6e, 0x6f,
+	0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4d, 0x65, 0x74,
+	0x65, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x0a, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x46, 0x65, 0x74,
+	0x63, 0x68, 0x12, 0x61, 0x0a, 0x0c, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72,
+	0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x38, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x65, 0x72,
+	0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x53, 0x69, 0x7a, 0x65, 0x46, 0x72,
+	0x6f, 0x6d, 0x48, 0x01, 0x52, 0x0c, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72,
+	0x6f, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x65, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x38, 0x2e,
+	0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x4d, 0x65, 0x74, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x53,
+	0x69, 0x7a, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x48, 0x02, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x6c,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72, 0x6f, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x5c, 0x0a, 0x0b,
+	0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x3a, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x2e, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x65,
+	0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x9b, 0x02, 0x0a, 0x0a, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x46, 0x65, 0x74, 0x63, 0x68, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x27,
+	0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x52, 0x65,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52,
+	0x0c, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x88, 0x01, 0x01,
+	0x12, 0x32, 0x0a, 0x14, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x43,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x13, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x6d, 0x61, 0x78, 0x53, 0x69,
+	0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x6d, 0x61, 0x78, 0x52,
+	0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x1a, 0x40, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x68, 0x0a, 0x08, 0x53, 0x69,
+	0x7a, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x49, 0x5a, 0x45, 0x5f, 0x46,
+	0x52, 0x4f, 0x4d, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x49, 0x5a, 0x45, 0x5f, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x49,
+	0x4e, 0x50, 0x55, 0x54, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x49, 0x5a, 0x45, 0x5f, 0x46,
+	0x52, 0x4f, 0x4d, 0x5f, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12,
+	0x53, 0x49, 0x5a, 0x45, 0x5f, 0x46, 0x52, 0x4f, 0x4d, 0x5f, 0x47, 0x52, 0x45, 0x41, 0x54, 0x45,
+	0x53, 0x54, 0x10, 0x03, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x46, 0x72, 0x6f,
+	0x6d, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72,
+	0x6f, 0x6d, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x46, 0x72, 0x6f, 0x6d, 0x22, 0xb7, 0x07, 0x0a, 0x07, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x59, 0x0a, 0x11, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x2b, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x6f, 0x61, 0x64,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x11, 0x6c,
+	0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x3e, 0x0a, 0x08, 0x75, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x08, 0x75, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x12, 0x41, 0x0a, 0x09, 0x74, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x54,
+	0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x09, 0x74, 0x6c, 0x73, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x41, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x07, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x45, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x39, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63,
+	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x57, 0x0a, 0x0e, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x6e, 0x6f,
+	0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4d, 0x65, 0x74,
+	0x65, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0e, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x4e, 0x0a, 0x0b, 0x6d,
+	0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2c, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x0b,
+	0x6d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x57, 0x0a, 0x0e, 0x63,
+	0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43,
+	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72, 0x65,
+	0x61, 0x6b, 0x65, 0x72, 0x52, 0x0e, 0x63, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72, 0x65,
+	0x61, 0x6b, 0x65, 0x72, 0x12, 0x4b, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x42, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x63, 0x69, 0x6e, 0x67, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x69, 0x63, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x70, 0x72,
+	0x69, 0x63, 0x69, 0x6e, 0x67, 0x12, 0x48, 0x0a, 0x09, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x49, 0x6e,
+	0x66, 0x6f, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65, 0x6c,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x22,
+	0x8c, 0x01, 0x0a, 0x12, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4d, 0x61, 0x69, 0x6e, 0x74,
+	0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2c, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x7c,
+	0x0a, 0x15, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74,
+	0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x73, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x11, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x74, 0x69, 0x76, 0x65, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x63, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x08, 0x63, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x22, 0xd7, 0x04, 0x0a,
+	0x11, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x69, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x70, 0x69, 0x6e, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x49, 0x0a, 0x12, 0x64, 0x6e, 0x73, 0x52, 0x65, 0x66,
+	0x72, 0x65, 0x73, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x64,
+	0x6e, 0x73, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x12, 0x35, 0x0a, 0x13, 0x74, 0x6c, 0x73, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00,
+	0x52, 0x13, 0x74, 0x6c, 0x73, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x53, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x49,
+	0x64, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c,
+	0x6d, 0x61, 0x78, 0x49, 0x64, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x73, 0x12, 0x30, 0x0a, 0x13,
+	0x6d, 0x61, 0x78, 0x49, 0x64, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x73, 0x50, 0x65, 0x72, 0x48,
+	0x6f, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x49, 0x64,
+	0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x73, 0x50, 0x65, 0x72, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x3b,
+	0x0a, 0x0b, 0x64, 0x69, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b,
+	0x64, 0x69, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x4f, 0x0a, 0x15, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x54, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x15, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x37, 0x0a, 0x09,
+	0x6b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6b, 0x65, 0x65, 0x70,
+	0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x43, 0x0a, 0x0f, 0x69, 0x64, 0x6c, 0x65, 0x43, 0x6f, 0x6e,
+	0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x69, 0x64, 0x6c, 0x65, 0x43,
+	0x6f, 0x6e, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x69,
+	0x73, 0x61, 0x62, 0x6c, 0x65, 0x48, 0x54, 0x54, 0x50, 0x32, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0c, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x48, 0x54, 0x54, 0x50, 0x32, 0x42, 0x16,
+	0x0a, 0x14, 0x5f, 0x74, 0x6c, 0x73, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xcd, 0x02, 0x0a, 0x0e, 0x43, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x50, 0x72, 0x69, 0x63, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2c, 0x0a, 0x11, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x50,
+	0x65, 0x72, 0x31, 0x6b, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x11, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x50, 0x65, 0x72, 0x31, 0x6b, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x73, 0x12, 0x34, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f,
+	0x6e, 0x50, 0x65, 0x72, 0x31, 0x6b, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x15, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x65,
+	0x72, 0x31, 0x6b, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x46, 0x0a, 0x06, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x61, 0x79, 0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x69, 0x63,
+	0x69, 0x6e, 0x67, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x06, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x73, 0x12, 0x26, 0x0a, 0x0e, 0x70, 0x65, 0x72, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x70, 0x65, 0x72, 0x41, 0x75,
+	0x64, 0x69, 0x6f, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x1a, 0x4b, 0x0a, 0x05, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x22, 0x87, 0x01, 0x0a, 0x10, 0x43, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x24, 0x0a, 0x0d, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x57, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x12, 0x4d, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79,
+	0x2e, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x2a, 0x8b, 0x01, 0x0a, 0x11, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x23, 0x0a, 0x1f, 0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x42,
+	0x41, 0x4c, 0x41, 0x4e, 0x43, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x52,
+	0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x52, 0x4f, 0x42, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10,
+	0x4c, 0x45, 0x41, 0x53, 0x54, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x50, 0x5f, 0x48, 0x41, 0x53, 0x48, 0x10, 0x03, 0x12,
+	0x11, 0x0a, 0x0d, 0x4c, 0x45, 0x41, 0x53, 0x54, 0x5f, 0x4c, 0x41, 0x54, 0x45, 0x4e, 0x43, 0x59,
+	0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x55, 0x53, 0x54, 0x4f, 0x4d, 0x10, 0x0f, 0x2a, 0xba,
+	0x01, 0x0a, 0x0b, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c,
+	0x0a, 0x18, 0x43, 0x4c, 0x55, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03,
+	0x4c, 0x4c, 0x4d, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x49, 0x4d, 0x41, 0x47, 0x45, 0x5f, 0x47,
+	0x45, 0x4e, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x53,
+	0x50, 0x45, 0x45, 0x43, 0x48, 0x5f, 0x47, 0x45, 0x4e, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x4f, 0x44, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x44, 0x49, 0x4e, 0x47, 0x10,
+	0x05, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x50, 0x45, 0x45, 0x43, 0x48, 0x5f, 0x52, 0x45, 0x43, 0x4f,
+	0x47, 0x4e, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x06, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45, 0x52,
+	0x41, 0x4e, 0x4b, 0x10, 0x07, 0x12, 0x14, 0x0a, 0x10, 0x56, 0x49, 0x44, 0x45, 0x4f, 0x5f, 0x47,
+	0x45, 0x4e, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x08, 0x2a, 0xe0, 0x02, 0x0a, 0x0f,
+	0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12,
+	0x20, 0x0a, 0x1c, 0x43, 0x4c, 0x55, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x50, 0x52, 0x4f, 0x56, 0x49,
+	0x44, 0x45, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x0b, 0x0a, 0x07, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x10, 0x01, 0x12, 0x08,
+	0x0a, 0x04, 0x56, 0x4c, 0x4c, 0x4d, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x4f, 0x4c, 0x4c, 0x41,
+	0x4d, 0x41, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x5f,
+	0x56, 0x31, 0x5f, 0x53, 0x50, 0x45, 0x45, 0x43, 0x48, 0x10, 0x04, 0x12, 0x19, 0x0a, 0x15, 0x44,
+	0x45, 0x45, 0x50, 0x47, 0x52, 0x41, 0x4d, 0x5f, 0x57, 0x45, 0x42, 0x53, 0x4f, 0x43, 0x4b, 0x45,
+	0x54, 0x5f, 0x56, 0x31, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x45, 0x4c, 0x45, 0x56, 0x45, 0x4e,
+	0x5f, 0x4c, 0x41, 0x42, 0x53, 0x5f, 0x56, 0x31, 0x10, 0x06, 0x12, 0x10, 0x0a, 0x0c, 0x4b, 0x4f,
+	0x45, 0x4d, 0x4f, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x56, 0x31, 0x10, 0x07, 0x12, 0x1d, 0x0a, 0x19,
+	0x56, 0x4f, 0x4c, 0x43, 0x45, 0x4e, 0x47, 0x49, 0x4e, 0x45, 0x5f, 0x53, 0x45, 0x45, 0x44, 0x5f,
+	0x53, 0x50, 0x45, 0x45, 0x43, 0x48, 0x5f, 0x56, 0x31, 0x10, 0x08, 0x12, 0x1e, 0x0a, 0x1a, 0x41,
+	0x4c, 0x49, 0x42, 0x41, 0x42, 0x41, 0x5f, 0x43, 0x4f, 0x53, 0x59, 0x5f, 0x56, 0x4f, 0x49, 0x43,
+	0x45, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x49, 0x43, 0x45, 0x10, 0x09, 0x12, 0x1f, 0x0a, 0x1b, 0x4d,
+	0x49, 0x43, 0x52, 0x4f, 0x53, 0x4f, 0x46, 0x54, 0x5f, 0x53, 0x50, 0x45, 0x45, 0x43, 0x48, 0x5f,
+	0x53, 0x45, 0x52, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x56, 0x31, 0x10, 0x0a, 0x12, 0x11, 0x0a, 0x0d,
+	0x41, 0x5a, 0x55, 0x52, 0x45, 0x5f, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x41, 0x49, 0x10, 0x0b, 0x12,
+	0x0f, 0x0a, 0x0b, 0x41, 0x57, 0x53, 0x5f, 0x42, 0x45, 0x44, 0x52, 0x4f, 0x43, 0x4b, 0x10, 0x0c,
+	0x12, 0x11, 0x0a, 0x0d, 0x47, 0x4f, 0x4f, 0x47, 0x4c, 0x45, 0x5f, 0x47, 0x45, 0x4d, 0x49, 0x4e,
+	0x49, 0x10, 0x0d, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x4e, 0x54, 0x48, 0x52, 0x4f, 0x50, 0x49, 0x43,
+	0x10, 0x0e, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x55, 0x4e, 0x57, 0x41, 0x59, 0x10, 0x0f, 0x2a, 0x79,
+	0x0a, 0x0f, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x12, 0x20, 0x0a, 0x1c, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x43, 0x41, 0x50, 0x41, 0x42,
+	0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x54, 0x52, 0x45, 0x41, 0x4d, 0x49, 0x4e, 0x47,
+	0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x4f, 0x4f, 0x4c, 0x53, 0x10, 0x02, 0x12, 0x0a, 0x0a,
+	0x06, 0x56, 0x49, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x4a, 0x53, 0x4f,
+	0x4e, 0x5f, 0x53, 0x43, 0x48, 0x45, 0x4d, 0x41, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x45,
+	0x41, 0x53, 0x4f, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x42, 0x22, 0x5a, 0x20, 0x6b, 0x6e, 0x6f,
+	0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x63, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_clusters_v1alpha1_cluster_proto_rawDescOnce sync.Once
+	file_clusters_v1alpha1_cluster_proto_rawDescData = file_clusters_v1alpha1_cluster_proto_rawDesc
+)
+
+func file_clusters_v1alpha1_cluster_proto_rawDescGZIP() []byte {
+	file_clusters_v1alpha1_cluster_proto_rawDescOnce.Do(func() {
+		file_clusters_v1alpha1_cluster_proto_rawDescData = protoimpl.X.CompressGZIP(file_clusters_v1alpha1_cluster_proto_rawDescData)
+	})
+	return file_clusters_v1alpha1_cluster_proto_rawDescData
+}
+
+var file_clusters_v1alpha1_cluster_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_clusters_v1alpha1_cluster_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_clusters_v1alpha1_cluster_proto_goTypes = []interface{}{
+	(LoadBalancePolicy)(0),                   // 0: knoway.clusters.v1alpha1.LoadBalancePolicy
+	(ClusterType)(0),                         // 1: knoway.clusters.v1alpha1.ClusterType
+	(ClusterProvider)(0),                     // 2: knoway.clusters.v1alpha1.ClusterProvider
+	(ModelCapability)(0),                     // 3: knoway.clusters.v1alpha1.ModelCapability
+	(Upstream_Auth_Scheme)(0),                // 4: knoway.clusters.v1alpha1.Upstream.Auth.Scheme
+	(ClusterMeteringPolicy_SizeFrom)(0),      // 5: knoway.clusters.v1alpha1.ClusterMeteringPolicy.SizeFrom
+	(*ClusterFilter)(nil),                    // 6: knoway.clusters.v1alpha1.ClusterFilter
+	(*TLSConfig)(nil),                        // 7: knoway.clusters.v1alpha1.TLSConfig
+	(*Upstream)(nil),                         // 8: knoway.clusters.v1alpha1.Upstream
+	(*ClusterMeteringPolicy)(nil),            // 9: knoway.clusters.v1alpha1.ClusterMeteringPolicy
+	(*Cluster)(nil),                          // 10: knoway.clusters.v1alpha1.Cluster
+	(*ClusterMaintenance)(nil),               // 11: knoway.clusters.v1alpha1.ClusterMaintenance
+	(*ClusterCircuitBreaker)(nil),            // 12: knoway.clusters.v1alpha1.ClusterCircuitBreaker
+	(*ClusterConnection)(nil),                // 13: knoway.clusters.v1alpha1.ClusterConnection
+	(*ClusterPricing)(nil),                   // 14: knoway.clusters.v1alpha1.ClusterPricing
+	(*ClusterModelInfo)(nil),                 // 15: knoway.clusters.v1alpha1.ClusterModelInfo
+	(*Upstream_Header)(nil),                  // 16: knoway.clusters.v1alpha1.Upstream.Header
+	nil,                                      // 17: knoway.clusters.v1alpha1.Upstream.DefaultParamsEntry
+	nil,                                      // 18: knoway.clusters.v1alpha1.Upstream.OverrideParamsEntry
+	(*Upstream_HeaderFrom)(nil),              // 19: knoway.clusters.v1alpha1.Upstream.HeaderFrom
+	(*Upstream_Auth)(nil),                    // 20: knoway.clusters.v1alpha1.Upstream.Auth
+	nil,                                      // 21: knoway.clusters.v1alpha1.Upstream.PathsEntry
+	nil,                                      // 22: knoway.clusters.v1alpha1.Upstream.QueryEntry
+	(*Upstream_DeadlineHeader)(nil),          // 23: knoway.clusters.v1alpha1.Upstream.DeadlineHeader
+	(*Upstream_HeaderFrom_Vault)(nil),        // 24: knoway.clusters.v1alpha1.Upstream.HeaderFrom.Vault
+	(*Upstream_Auth_Vault)(nil),              // 25: knoway.clusters.v1alpha1.Upstream.Auth.Vault
+	(*ClusterMeteringPolicy_ImageFetch)(nil), // 26: knoway.clusters.v1alpha1.ClusterMeteringPolicy.ImageFetch
+	(*ClusterMeteringPolicy_Expression)(nil), // 27: knoway.clusters.v1alpha1.ClusterMeteringPolicy.Expression
+	(*ClusterPricing_Image)(nil),             // 28: knoway.clusters.v1alpha1.ClusterPricing.Image
+	(*anypb.Any)(nil),                        // 29: google.protobuf.Any
+	(*durationpb.Duration)(nil),              // 30: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),            // 31: google.protobuf.Timestamp
+	(*structpb.Value)(nil),                   // 32: google.protobuf.Value
+}
+var file_clusters_v1alpha1_cluster_proto_depIdxs = []int32{
+	29, // 0: knoway.clusters.v1alpha1.ClusterFilter.config:type_name -> google.protobuf.Any
+	30, // 1: knoway.clusters.v1alpha1.ClusterFilter.timeout:type_name -> google.protobuf.Duration
+	16, // 2: knoway.clusters.v1alpha1.Upstream.headers:type_name -> knoway.clusters.v1alpha1.Upstream.Header
+	17, // 3: knoway.clusters.v1alpha1.Upstream.defaultParams:type_name -> knoway.clusters.v1alpha1.Upstream.DefaultParamsEntry
+	18, // 4: knoway.clusters.v1alpha1.Upstream.overrideParams:type_name -> knoway.clusters.v1alpha1.Upstream.OverrideParamsEntry
+	19, // 5: knoway.clusters.v1alpha1.Upstream.headersFrom:type_name -> knoway.clusters.v1alpha1.Upstream.HeaderFrom
+	20, // 6: knoway.clusters.v1alpha1.Upstream.auth:type_name -> knoway.clusters.v1alpha1.Upstream.Auth
+	21, // 7: knoway.clusters.v1alpha1.Upstream.paths:type_name -> knoway.clusters.v1alpha1.Upstream.PathsEntry
+	22, // 8: knoway.clusters.v1alpha1.Upstream.query:type_name -> knoway.clusters.v1alpha1.Upstream.QueryEntry
+	23, // 9: knoway.clusters.v1alpha1.Upstream.deadlineHeader:type_name -> knoway.clusters.v1alpha1.Upstream.DeadlineHeader
+	5,  // 10: knoway.clusters.v1alpha1.ClusterMeteringPolicy.sizeFrom:type_name -> knoway.clusters.v1alpha1.ClusterMeteringPolicy.SizeFrom
+	26, // 11: knoway.clusters.v1alpha1.ClusterMeteringPolicy.imageFetch:type_name -> knoway.clusters.v1alpha1.ClusterMeteringPolicy.ImageFetch
+	5,  // 12: knoway.clusters.v1alpha1.ClusterMeteringPolicy.durationFrom:type_name -> knoway.clusters.v1alpha1.ClusterMeteringPolicy.SizeFrom
+	5,  // 13: knoway.clusters.v1alpha1.ClusterMeteringPolicy.resolutionFrom:type_name -> knoway.clusters.v1alpha1.ClusterMeteringPolicy.SizeFrom
+	27, // 14: knoway.clusters.v1alpha1.ClusterMeteringPolicy.expressions:type_name -> knoway.clusters.v1alpha1.ClusterMeteringPolicy.Expression
+	0,  // 15: knoway.clusters.v1alpha1.Cluster.loadBalancePolicy:type_name -> knoway.clusters.v1alpha1.LoadBalancePolicy
+	8,  // 16: knoway.clusters.v1alpha1.Cluster.upstream:type_name -> knoway.clusters.v1alpha1.Upstream
+	7,  // 17: knoway.clusters.v1alpha1.Cluster.tlsConfig:type_name -> knoway.clusters.v1alpha1.TLSConfig
+	6,  // 18: knoway.clusters.v1alpha1.Cluster.filters:type_name -> knoway.clusters.v1alpha1.ClusterFilter
+	2,  // 19: knoway.clusters.v1alpha1.Cluster.provider:type_name -> knoway.clusters.v1alpha1.ClusterProvider
+	1,  // 20: knoway.clusters.v1alpha1.Cluster.type:type_name -> knoway.clusters.v1alpha1.ClusterType
+	9,  // 21: knoway.clusters.v1alpha1.Cluster.meteringPolicy:type_name -> knoway.clusters.v1alpha1.ClusterMeteringPolicy
+	11, // 22: knoway.clusters.v1alpha1.Cluster.maintenance:type_name -> knoway.clusters.v1alpha1.ClusterMaintenance
+	12, // 23: knoway.clusters.v1alpha1.Cluster.circuitBreaker:type_name -> knoway.clusters.v1alpha1.ClusterCircuitBreaker
+	13, // 24: knoway.clusters.v1alpha1.Cluster.connection:type_name -> knoway.clusters.v1alpha1.ClusterConnection
+	14, // 25: knoway.clusters.v1alpha1.Cluster.pricing:type_name -> knoway.clusters.v1alpha1.ClusterPricing
+	15, // 26: knoway.clusters.v1alpha1.Cluster.modelInfo:type_name -> knoway.clusters.v1alpha1.ClusterModelInfo
+	31, // 27: knoway.clusters.v1alpha1.ClusterMaintenance.start:type_name -> google.protobuf.Timestamp
+	31, // 28: knoway.clusters.v1alpha1.ClusterMaintenance.end:type_name -> google.protobuf.Timestamp
+	30, // 29: knoway.clusters.v1alpha1.ClusterCircuitBreaker.cooldown:type_name -> google.protobuf.Duration
+	30, // 30: knoway.clusters.v1alpha1.ClusterConnection.dnsRefreshInterval:type_name -> google.protobuf.Duration
+	30, // 31: knoway.clusters.v1alpha1.ClusterConnection.dialTimeout:type_name -> google.protobuf.Duration
+	30, // 32: knoway.clusters.v1alpha1.ClusterConnection.responseHeaderTimeout:type_name -> google.protobuf.Duration
+	30, // 33: knoway.clusters.v1alpha1.ClusterConnection.keepAlive:type_name -> google.protobuf.Duration
+	30, // 34: knoway.clusters.v1alpha1.ClusterConnection.idleConnTimeout:type_name -> google.protobuf.Duration
+	28, // 35: knoway.clusters.v1alpha1.ClusterPricing.images:type_name -> knoway.clusters.v1alpha1.ClusterPricing.Image
+	3,  // 36: knoway.clusters.v1alpha1.ClusterModelInfo.capabilities:type_name -> knoway.clusters.v1alpha1.ModelCapability
+	32, // 37: knoway.clusters.v1alpha1.Upstream.DefaultParamsEntry.value:type_name -> google.protobuf.Value
+	32, // 38: knoway.clusters.v1alpha1.Upstream.OverrideParamsEntry.value:type_name -> google.protobuf.Value
+	24, // 39: knoway.clusters.v1alpha1.Upstream.HeaderFrom.vault:type_name -> knoway.clusters.v1alpha1.Upstream.HeaderFrom.Vault
+	4,  // 40: knoway.clusters.v1alpha1.Upstream.Auth.scheme:type_name -> knoway.clusters.v1alpha1.Upstream.Auth.Scheme
+	25, // 41: knoway.clusters.v1alpha1.Upstream.Auth.vault:type_name -> knoway.clusters.v1alpha1.Upstream.Auth.Vault
+	30, // 42: knoway.clusters.v1alpha1.ClusterMeteringPolicy.ImageFetch.timeout:type_name -> google.protobuf.Duration
+	43, // [43:43] is the sub-list for method output_type
+	43, // [43:43] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
+}
+
+func init() { file_clusters_v1alpha1_cluster_proto_init() }
+func file_clusters_v1alpha1_cluster_proto_init() {
+	if File_clusters_v1alpha1_cluster_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_clusters_v1alpha1_cluster_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClusterFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clusters_v1alpha1_cluster_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TLSConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
 				return nil
 			}
 		}
@@ -929,6 +2521,66 @@ func file_clusters_v1alpha1_cluster_proto_init() {
 			}
 		}
 		file_clusters_v1alpha1_cluster_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClusterMaintenance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clusters_v1alpha1_cluster_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClusterCircuitBreaker); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clusters_v1alpha1_cluster_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClusterConnection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clusters_v1alpha1_cluster_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClusterPricing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clusters_v1alpha1_cluster_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClusterModelInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clusters_v1alpha1_cluster_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Upstream_Header); i {
 			case 0:
 				return &v.state
@@ -940,15 +2592,120 @@ func file_clusters_v1alpha1_cluster_proto_init() {
 				return nil
 			}
 		}
+		file_clusters_v1alpha1_cluster_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upstream_HeaderFrom); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clusters_v1alpha1_cluster_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upstream_Auth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clusters_v1alpha1_cluster_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upstream_DeadlineHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clusters_v1alpha1_cluster_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upstream_HeaderFrom_Vault); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clusters_v1alpha1_cluster_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upstream_Auth_Vault); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clusters_v1alpha1_cluster_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClusterMeteringPolicy_ImageFetch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clusters_v1alpha1_cluster_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClusterMeteringPolicy_Expression); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_clusters_v1alpha1_cluster_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClusterPricing_Image); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_clusters_v1alpha1_cluster_proto_msgTypes[3].OneofWrappers = []interface{}{}
+	file_clusters_v1alpha1_cluster_proto_msgTypes[7].OneofWrappers = []interface{}{}
+	file_clusters_v1alpha1_cluster_proto_msgTypes[13].OneofWrappers = []interface{}{
+		(*Upstream_HeaderFrom_Vault_)(nil),
+	}
+	file_clusters_v1alpha1_cluster_proto_msgTypes[14].OneofWrappers = []interface{}{
+		(*Upstream_Auth_Value)(nil),
+		(*Upstream_Auth_Vault_)(nil),
+	}
+	file_clusters_v1alpha1_cluster_proto_msgTypes[20].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_clusters_v1alpha1_cluster_proto_rawDesc,
-			NumEnums:      4,
-			NumMessages:   8,
+			NumEnums:      6,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -21,12 +21,71 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type UsageStatsConfig_BillingModel int32
+
+const (
+	// Same as BILLING_MODEL_SERVED.
+	UsageStatsConfig_BILLING_MODEL_UNSPECIFIED UsageStatsConfig_BillingModel = 0
+	// Pricing applies to the model requested by the user, even if the
+	// request was routed or fell back to another model.
+	UsageStatsConfig_BILLING_MODEL_REQUESTED UsageStatsConfig_BillingModel = 1
+	// Pricing applies to the model that actually served the request.
+	UsageStatsConfig_BILLING_MODEL_SERVED UsageStatsConfig_BillingModel = 2
+)
+
+// Enum value maps for UsageStatsConfig_BillingModel.
+var (
+	UsageStatsConfig_BillingModel_name = map[int32]string{
+		0: "BILLING_MODEL_UNSPECIFIED",
+		1: "BILLING_MODEL_REQUESTED",
+		2: "BILLING_MODEL_SERVED",
+	}
+	UsageStatsConfig_BillingModel_value = map[string]int32{
+		"BILLING_MODEL_UNSPECIFIED": 0,
+		"BILLING_MODEL_REQUESTED":   1,
+		"BILLING_MODEL_SERVED":      2,
+	}
+)
+
+func (x UsageStatsConfig_BillingModel) Enum() *UsageStatsConfig_BillingModel {
+	p := new(UsageStatsConfig_BillingModel)
+	*p = x
+	return p
+}
+
+func (x UsageStatsConfig_BillingModel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UsageStatsConfig_BillingModel) Descriptor() protoreflect.EnumDescriptor {
+	return file_filters_v1alpha1_api_key_auth_proto_enumTypes[0].Descriptor()
+}
+
+func (UsageStatsConfig_BillingModel) Type() protoreflect.EnumType {
+	return &file_filters_v1alpha1_api_key_auth_proto_enumTypes[0]
+}
+
+func (x UsageStatsConfig_BillingModel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UsageStatsConfig_BillingModel.Descriptor instead.
+func (UsageStatsConfig_BillingModel) EnumDescriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_api_key_auth_proto_rawDescGZIP(), []int{1, 0}
+}
+
 type APIKeyAuthConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	AuthServer *APIKeyAuthConfig_AuthServer `protobuf:"bytes,3,opt,name=auth_server,json=authServer,proto3" json:"auth_server,omitempty"`
+	// max_priorities caps the X-Priority header of requests by the tier
+	// of the API key returned by the auth server, values are one of low,
+	// normal and high. Tiers not listed are capped at normal.
+	MaxPriorities map[string]string `protobuf:"bytes,4,rep,name=max_priorities,json=maxPriorities,proto3" json:"max_priorities,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// jwt optional: exclusive with auth_server.
+	Jwt *APIKeyAuthConfig_JWT `protobuf:"bytes,5,opt,name=jwt,proto3" json:"jwt,omitempty"`
 }
 
 func (x *APIKeyAuthConfig) Reset() {
@@ -68,12 +127,29 @@ func (x *APIKeyAuthConfig) GetAuthServer() *APIKeyAuthConfig_AuthServer {
 	return nil
 }
 
+func (x *APIKeyAuthConfig) GetMaxPriorities() map[string]string {
+	if x != nil {
+		return x.MaxPriorities
+	}
+	return nil
+}
+
+func (x *APIKeyAuthConfig) GetJwt() *APIKeyAuthConfig_JWT {
+	if x != nil {
+		return x.Jwt
+	}
+	return nil
+}
+
 type UsageStatsConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	StatsServer *UsageStatsConfig_StatsServer `protobuf:"bytes,3,opt,name=stats_server,json=statsServer,proto3" json:"stats_server,omitempty"`
+	// billing_model decides which model name is reported as
+	// billing_model_name, default is BILLING_MODEL_SERVED.
+	BillingModel UsageStatsConfig_BillingModel `protobuf:"varint,4,opt,name=billing_model,json=billingModel,proto3,enum=knoway.filters.v1alpha1.UsageStatsConfig_BillingModel" json:"billing_model,omitempty"`
 }
 
 func (x *UsageStatsConfig) Reset() {
@@ -115,6 +191,13 @@ func (x *UsageStatsConfig) GetStatsServer() *UsageStatsConfig_StatsServer {
 	return nil
 }
 
+func (x *UsageStatsConfig) GetBillingModel() UsageStatsConfig_BillingModel {
+	if x != nil {
+		return x.BillingModel
+	}
+	return UsageStatsConfig_BILLING_MODEL_UNSPECIFIED
+}
+
 type OpenAIRequestHandlerConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -191,6 +274,162 @@ func (*OpenAIResponseHandlerConfig) Descriptor() ([]byte, []int) {
 	return file_filters_v1alpha1_api_key_auth_proto_rawDescGZIP(), []int{3}
 }
 
+// AzureOpenAIConfig adapts the requests built by the OpenAI request handler
+// to Azure OpenAI, and the errors of Azure OpenAI to OpenAI.
+type AzureOpenAIConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// deployment of the model, default is the model name of the cluster
+	Deployment string `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	// api_version query parameter, default is 2024-10-21
+	ApiVersion string `protobuf:"bytes,2,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	// keep_authorization sends the Authorization header as is instead of
+	// as the api-key header, for Microsoft Entra ID tokens.
+	KeepAuthorization bool `protobuf:"varint,3,opt,name=keep_authorization,json=keepAuthorization,proto3" json:"keep_authorization,omitempty"`
+}
+
+func (x *AzureOpenAIConfig) Reset() {
+	*x = AzureOpenAIConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AzureOpenAIConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AzureOpenAIConfig) ProtoMessage() {}
+
+func (x *AzureOpenAIConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AzureOpenAIConfig.ProtoReflect.Descriptor instead.
+func (*AzureOpenAIConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_api_key_auth_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AzureOpenAIConfig) GetDeployment() string {
+	if x != nil {
+		return x.Deployment
+	}
+	return ""
+}
+
+func (x *AzureOpenAIConfig) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *AzureOpenAIConfig) GetKeepAuthorization() bool {
+	if x != nil {
+		return x.KeepAuthorization
+	}
+	return false
+}
+
+// AWSSigV4Config signs the requests built by the OpenAI request handler with
+// AWS Signature Version 4, for AWS Bedrock.
+type AWSSigV4Config struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// region of the service, default is parsed from the host of the upstream,
+	// e.g. us-east-1 of bedrock-runtime.us-east-1.amazonaws.com
+	Region string `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	// service name to sign for, default is bedrock
+	Service string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	// credentials, default are read from the AWS_ACCESS_KEY_ID,
+	// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables of
+	// the gateway
+	AccessKeyId     string `protobuf:"bytes,3,opt,name=access_key_id,json=accessKeyId,proto3" json:"access_key_id,omitempty"`
+	SecretAccessKey string `protobuf:"bytes,4,opt,name=secret_access_key,json=secretAccessKey,proto3" json:"secret_access_key,omitempty"`
+	SessionToken    string `protobuf:"bytes,5,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
+}
+
+func (x *AWSSigV4Config) Reset() {
+	*x = AWSSigV4Config{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AWSSigV4Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AWSSigV4Config) ProtoMessage() {}
+
+func (x *AWSSigV4Config) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AWSSigV4Config.ProtoReflect.Descriptor instead.
+func (*AWSSigV4Config) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_api_key_auth_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AWSSigV4Config) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *AWSSigV4Config) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *AWSSigV4Config) GetAccessKeyId() string {
+	if x != nil {
+		return x.AccessKeyId
+	}
+	return ""
+}
+
+func (x *AWSSigV4Config) GetSecretAccessKey() string {
+	if x != nil {
+		return x.SecretAccessKey
+	}
+	return ""
+}
+
+func (x *AWSSigV4Config) GetSessionToken() string {
+	if x != nil {
+		return x.SessionToken
+	}
+	return ""
+}
+
 type APIKeyAuthConfig_AuthServer struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -203,7 +442,7 @@ type APIKeyAuthConfig_AuthServer struct {
 func (x *APIKeyAuthConfig_AuthServer) Reset() {
 	*x = APIKeyAuthConfig_AuthServer{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[4]
+		mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -216,7 +455,7 @@ func (x *APIKeyAuthConfig_AuthServer) String() string {
 func (*APIKeyAuthConfig_AuthServer) ProtoMessage() {}
 
 func (x *APIKeyAuthConfig_AuthServer) ProtoReflect() protoreflect.Message {
-	mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[4]
+	mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -246,6 +485,197 @@ func (x *APIKeyAuthConfig_AuthServer) GetTimeout() *durationpb.Duration {
 	return nil
 }
 
+// JWT validates the bearer tokens as JSON Web Tokens signed by an OpenID
+// Connect provider, instead of calling the auth server.
+type APIKeyAuthConfig_JWT struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// issuer required: the iss claim expected, the JSON Web Key Set is
+	// discovered from its OpenID configuration unless jwks_url is set.
+	Issuer string `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	// audiences optional: the aud claim must contain one of them, not
+	// checked if empty.
+	Audiences []string `protobuf:"bytes,2,rep,name=audiences,proto3" json:"audiences,omitempty"`
+	// jwks_url optional: the URL of the JSON Web Key Set.
+	JwksUrl string `protobuf:"bytes,3,opt,name=jwks_url,json=jwksUrl,proto3" json:"jwks_url,omitempty"`
+	// jwks_cache_duration optional: how long the keys are cached before
+	// fetched again, default is 10m. Keys are fetched again earlier when
+	// tokens are signed by unknown keys.
+	JwksCacheDuration *durationpb.Duration `protobuf:"bytes,4,opt,name=jwks_cache_duration,json=jwksCacheDuration,proto3" json:"jwks_cache_duration,omitempty"`
+	// clock_skew optional: the leeway of exp and nbf, default is 1m.
+	ClockSkew *durationpb.Duration         `protobuf:"bytes,5,opt,name=clock_skew,json=clockSkew,proto3" json:"clock_skew,omitempty"`
+	Claims    *APIKeyAuthConfig_JWT_Claims `protobuf:"bytes,6,opt,name=claims,proto3" json:"claims,omitempty"`
+}
+
+func (x *APIKeyAuthConfig_JWT) Reset() {
+	*x = APIKeyAuthConfig_JWT{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *APIKeyAuthConfig_JWT) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKeyAuthConfig_JWT) ProtoMessage() {}
+
+func (x *APIKeyAuthConfig_JWT) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKeyAuthConfig_JWT.ProtoReflect.Descriptor instead.
+func (*APIKeyAuthConfig_JWT) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_api_key_auth_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *APIKeyAuthConfig_JWT) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *APIKeyAuthConfig_JWT) GetAudiences() []string {
+	if x != nil {
+		return x.Audiences
+	}
+	return nil
+}
+
+func (x *APIKeyAuthConfig_JWT) GetJwksUrl() string {
+	if x != nil {
+		return x.JwksUrl
+	}
+	return ""
+}
+
+func (x *APIKeyAuthConfig_JWT) GetJwksCacheDuration() *durationpb.Duration {
+	if x != nil {
+		return x.JwksCacheDuration
+	}
+	return nil
+}
+
+func (x *APIKeyAuthConfig_JWT) GetClockSkew() *durationpb.Duration {
+	if x != nil {
+		return x.ClockSkew
+	}
+	return nil
+}
+
+func (x *APIKeyAuthConfig_JWT) GetClaims() *APIKeyAuthConfig_JWT_Claims {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+// Claims maps the claims of tokens to the auth info of requests, the
+// claims of lists are either arrays of strings or strings separated
+// by spaces.
+type APIKeyAuthConfig_JWT_Claims struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// user_id default is sub
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// api_key_id default is jti
+	ApiKeyId string `protobuf:"bytes,2,opt,name=api_key_id,json=apiKeyId,proto3" json:"api_key_id,omitempty"`
+	// allow_models optional: all models are allowed if unset
+	AllowModels string `protobuf:"bytes,3,opt,name=allow_models,json=allowModels,proto3" json:"allow_models,omitempty"`
+	DenyModels  string `protobuf:"bytes,4,opt,name=deny_models,json=denyModels,proto3" json:"deny_models,omitempty"`
+	Tier        string `protobuf:"bytes,5,opt,name=tier,proto3" json:"tier,omitempty"`
+	TenantId    string `protobuf:"bytes,6,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+}
+
+func (x *APIKeyAuthConfig_JWT_Claims) Reset() {
+	*x = APIKeyAuthConfig_JWT_Claims{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *APIKeyAuthConfig_JWT_Claims) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKeyAuthConfig_JWT_Claims) ProtoMessage() {}
+
+func (x *APIKeyAuthConfig_JWT_Claims) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKeyAuthConfig_JWT_Claims.ProtoReflect.Descriptor instead.
+func (*APIKeyAuthConfig_JWT_Claims) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_api_key_auth_proto_rawDescGZIP(), []int{0, 2, 0}
+}
+
+func (x *APIKeyAuthConfig_JWT_Claims) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *APIKeyAuthConfig_JWT_Claims) GetApiKeyId() string {
+	if x != nil {
+		return x.ApiKeyId
+	}
+	return ""
+}
+
+func (x *APIKeyAuthConfig_JWT_Claims) GetAllowModels() string {
+	if x != nil {
+		return x.AllowModels
+	}
+	return ""
+}
+
+func (x *APIKeyAuthConfig_JWT_Claims) GetDenyModels() string {
+	if x != nil {
+		return x.DenyModels
+	}
+	return ""
+}
+
+func (x *APIKeyAuthConfig_JWT_Claims) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *APIKeyAuthConfig_JWT_Claims) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
 type UsageStatsConfig_StatsServer struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -258,7 +688,7 @@ type UsageStatsConfig_StatsServer struct {
 func (x *UsageStatsConfig_StatsServer) Reset() {
 	*x = UsageStatsConfig_StatsServer{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[5]
+		mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -271,7 +701,7 @@ func (x *UsageStatsConfig_StatsServer) String() string {
 func (*UsageStatsConfig_StatsServer) ProtoMessage() {}
 
 func (x *UsageStatsConfig_StatsServer) ProtoReflect() protoreflect.Message {
-	mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[5]
+	mi := &file_filters_v1alpha1_api_key_auth_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -309,38 +739,115 @@ var file_filters_v1alpha1_api_key_auth_proto_rawDesc = []byte{
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69,
 	0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x1e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
-	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xbe,
-	0x01, 0x0a, 0x10, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x89,
+	0x07, 0x0a, 0x10, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e,
 	0x66, 0x69, 0x67, 0x12, 0x55, 0x0a, 0x0b, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x73, 0x65, 0x72, 0x76,
 	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61,
 	0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
 	0x61, 0x31, 0x2e, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e,
 	0x66, 0x69, 0x67, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x0a,
-	0x61, 0x75, 0x74, 0x68, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x1a, 0x53, 0x0a, 0x0a, 0x41, 0x75,
-	0x74, 0x68, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69,
-	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22,
-	0xc2, 0x01, 0x0a, 0x10, 0x55, 0x73, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x12, 0x58, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6b, 0x6e, 0x6f,
-	0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x1a, 0x54,
-	0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x10, 0x0a,
-	0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12,
-	0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d,
-	0x65, 0x6f, 0x75, 0x74, 0x22, 0x1c, 0x0a, 0x1a, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x22, 0x1d, 0x0a, 0x1b, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f,
-	0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x75, 0x74, 0x68, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x63, 0x0a, 0x0e, 0x6d, 0x61,
+	0x78, 0x5f, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x50, 0x49,
+	0x4b, 0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4d, 0x61,
+	0x78, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0d, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12,
+	0x3f, 0x0a, 0x03, 0x6a, 0x77, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6b,
+	0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x41, 0x75, 0x74,
+	0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4a, 0x57, 0x54, 0x52, 0x03, 0x6a, 0x77, 0x74,
+	0x1a, 0x53, 0x0a, 0x0a, 0x41, 0x75, 0x74, 0x68, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c,
+	0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x1a, 0x40, 0x0a, 0x12, 0x4d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x6f,
+	0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xe0, 0x03, 0x0a, 0x03, 0x4a, 0x57, 0x54, 0x12,
+	0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x64, 0x69, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69,
+	0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6a, 0x77, 0x6b, 0x73, 0x5f, 0x75, 0x72,
+	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6a, 0x77, 0x6b, 0x73, 0x55, 0x72, 0x6c,
+	0x12, 0x49, 0x0a, 0x13, 0x6a, 0x77, 0x6b, 0x73, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x6a, 0x77, 0x6b, 0x73, 0x43, 0x61,
+	0x63, 0x68, 0x65, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x38, 0x0a, 0x0a, 0x63,
+	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x6b, 0x65, 0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x63, 0x6c, 0x6f, 0x63,
+	0x6b, 0x53, 0x6b, 0x65, 0x77, 0x12, 0x4c, 0x0a, 0x06, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x4a, 0x57, 0x54, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x52, 0x06, 0x63, 0x6c, 0x61,
+	0x69, 0x6d, 0x73, 0x1a, 0xb4, 0x01, 0x0a, 0x06, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x0a, 0x61, 0x70, 0x69, 0x5f, 0x6b,
+	0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x70, 0x69,
+	0x4b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x6e, 0x79,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64,
+	0x65, 0x6e, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x65,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x69, 0x65, 0x72, 0x12, 0x1b, 0x0a,
+	0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x85, 0x03, 0x0a, 0x10, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x58, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x0b, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x5b, 0x0a, 0x0d, 0x62, 0x69, 0x6c,
+	0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x36, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x42, 0x69, 0x6c, 0x6c,
+	0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x0c, 0x62, 0x69, 0x6c, 0x6c, 0x69, 0x6e,
+	0x67, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x1a, 0x54, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x64, 0x0a, 0x0c,
+	0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x1d, 0x0a, 0x19,
+	0x42, 0x49, 0x4c, 0x4c, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x42,
+	0x49, 0x4c, 0x4c, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x52, 0x45, 0x51,
+	0x55, 0x45, 0x53, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x42, 0x49, 0x4c, 0x4c,
+	0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45, 0x44,
+	0x10, 0x02, 0x22, 0x1c, 0x0a, 0x1a, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x22, 0x1d, 0x0a, 0x1b, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22,
+	0x83, 0x01, 0x0a, 0x11, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d,
+	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x69, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x11, 0x6b, 0x65, 0x65, 0x70, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xb7, 0x01, 0x0a, 0x0e, 0x41, 0x57, 0x53, 0x53, 0x69, 0x67,
+	0x56, 0x34, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x2a,
+	0x0a, 0x11, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x42,
+	0x21, 0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -355,26 +862,39 @@ func file_filters_v1alpha1_api_key_auth_proto_rawDescGZIP() []byte {
 	return file_filters_v1alpha1_api_key_auth_proto_rawDescData
 }
 
-var file_filters_v1alpha1_api_key_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_filters_v1alpha1_api_key_auth_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_filters_v1alpha1_api_key_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_filters_v1alpha1_api_key_auth_proto_goTypes = []interface{}{
-	(*APIKeyAuthConfig)(nil),             // 0: knoway.filters.v1alpha1.APIKeyAuthConfig
-	(*UsageStatsConfig)(nil),             // 1: knoway.filters.v1alpha1.UsageStatsConfig
-	(*OpenAIRequestHandlerConfig)(nil),   // 2: knoway.filters.v1alpha1.OpenAIRequestHandlerConfig
-	(*OpenAIResponseHandlerConfig)(nil),  // 3: knoway.filters.v1alpha1.OpenAIResponseHandlerConfig
-	(*APIKeyAuthConfig_AuthServer)(nil),  // 4: knoway.filters.v1alpha1.APIKeyAuthConfig.AuthServer
-	(*UsageStatsConfig_StatsServer)(nil), // 5: knoway.filters.v1alpha1.UsageStatsConfig.StatsServer
-	(*durationpb.Duration)(nil),          // 6: google.protobuf.Duration
+	(UsageStatsConfig_BillingModel)(0),   // 0: knoway.filters.v1alpha1.UsageStatsConfig.BillingModel
+	(*APIKeyAuthConfig)(nil),             // 1: knoway.filters.v1alpha1.APIKeyAuthConfig
+	(*UsageStatsConfig)(nil),             // 2: knoway.filters.v1alpha1.UsageStatsConfig
+	(*OpenAIRequestHandlerConfig)(nil),   // 3: knoway.filters.v1alpha1.OpenAIRequestHandlerConfig
+	(*OpenAIResponseHandlerConfig)(nil),  // 4: knoway.filters.v1alpha1.OpenAIResponseHandlerConfig
+	(*AzureOpenAIConfig)(nil),            // 5: knoway.filters.v1alpha1.AzureOpenAIConfig
+	(*AWSSigV4Config)(nil),               // 6: knoway.filters.v1alpha1.AWSSigV4Config
+	(*APIKeyAuthConfig_AuthServer)(nil),  // 7: knoway.filters.v1alpha1.APIKeyAuthConfig.AuthServer
+	nil,                                  // 8: knoway.filters.v1alpha1.APIKeyAuthConfig.MaxPrioritiesEntry
+	(*APIKeyAuthConfig_JWT)(nil),         // 9: knoway.filters.v1alpha1.APIKeyAuthConfig.JWT
+	(*APIKeyAuthConfig_JWT_Claims)(nil),  // 10: knoway.filters.v1alpha1.APIKeyAuthConfig.JWT.Claims
+	(*UsageStatsConfig_StatsServer)(nil), // 11: knoway.filters.v1alpha1.UsageStatsConfig.StatsServer
+	(*durationpb.Duration)(nil),          // 12: google.protobuf.Duration
 }
 var file_filters_v1alpha1_api_key_auth_proto_depIdxs = []int32{
-	4, // 0: knoway.filters.v1alpha1.APIKeyAuthConfig.auth_server:type_name -> knoway.filters.v1alpha1.APIKeyAuthConfig.AuthServer
-	5, // 1: knoway.filters.v1alpha1.UsageStatsConfig.stats_server:type_name -> knoway.filters.v1alpha1.UsageStatsConfig.StatsServer
-	6, // 2: knoway.filters.v1alpha1.APIKeyAuthConfig.AuthServer.timeout:type_name -> google.protobuf.Duration
-	6, // 3: knoway.filters.v1alpha1.UsageStatsConfig.StatsServer.timeout:type_name -> google.protobuf.Duration
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	7,  // 0: knoway.filters.v1alpha1.APIKeyAuthConfig.auth_server:type_name -> knoway.filters.v1alpha1.APIKeyAuthConfig.AuthServer
+	8,  // 1: knoway.filters.v1alpha1.APIKeyAuthConfig.max_priorities:type_name -> knoway.filters.v1alpha1.APIKeyAuthConfig.MaxPrioritiesEntry
+	9,  // 2: knoway.filters.v1alpha1.APIKeyAuthConfig.jwt:type_name -> knoway.filters.v1alpha1.APIKeyAuthConfig.JWT
+	11, // 3: knoway.filters.v1alpha1.UsageStatsConfig.stats_server:type_name -> knoway.filters.v1alpha1.UsageStatsConfig.StatsServer
+	0,  // 4: knoway.filters.v1alpha1.UsageStatsConfig.billing_model:type_name -> knoway.filters.v1alpha1.UsageStatsConfig.BillingModel
+	12, // 5: knoway.filters.v1alpha1.APIKeyAuthConfig.AuthServer.timeout:type_name -> google.protobuf.Duration
+	12, // 6: knoway.filters.v1alpha1.APIKeyAuthConfig.JWT.jwks_cache_duration:type_name -> google.protobuf.Duration
+	12, // 7: knoway.filters.v1alpha1.APIKeyAuthConfig.JWT.clock_skew:type_name -> google.protobuf.Duration
+	10, // 8: knoway.filters.v1alpha1.APIKeyAuthConfig.JWT.claims:type_name -> knoway.filters.v1alpha1.APIKeyAuthConfig.JWT.Claims
+	12, // 9: knoway.filters.v1alpha1.UsageStatsConfig.StatsServer.timeout:type_name -> google.protobuf.Duration
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_filters_v1alpha1_api_key_auth_proto_init() }
@@ -432,7 +952,7 @@ func file_filters_v1alpha1_api_key_auth_proto_init() {
 			}
 		}
 		file_filters_v1alpha1_api_key_auth_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*APIKeyAuthConfig_AuthServer); i {
+			switch v := v.(*AzureOpenAIConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -444,6 +964,54 @@ func file_filters_v1alpha1_api_key_auth_proto_init() {
 			}
 		}
 		file_filters_v1alpha1_api_key_auth_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AWSSigV4Config); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_api_key_auth_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*APIKeyAuthConfig_AuthServer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_api_key_auth_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*APIKeyAuthConfig_JWT); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_api_key_auth_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*APIKeyAuthConfig_JWT_Claims); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_api_key_auth_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UsageStatsConfig_StatsServer); i {
 			case 0:
 				return &v.state
@@ -461,13 +1029,14 @@ func file_filters_v1alpha1_api_key_auth_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_filters_v1alpha1_api_key_auth_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      1,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_filters_v1alpha1_api_key_auth_proto_goTypes,
 		DependencyIndexes: file_filters_v1alpha1_api_key_auth_proto_depIdxs,
+		EnumInfos:         file_filters_v1alpha1_api_key_auth_proto_enumTypes,
 		MessageInfos:      file_filters_v1alpha1_api_key_auth_proto_msgTypes,
 	}.Build()
 	File_filters_v1alpha1_api_key_auth_proto = out.File
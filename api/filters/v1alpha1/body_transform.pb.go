@@ -0,0 +1,424 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: filters/v1alpha1/body_transform.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// BodyTransform transforms JSON bodies, the JSON Patch operations are applied
+// before the CEL assignments.
+type BodyTransform struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JsonPatch []*BodyTransform_JSONPatchOperation `protobuf:"bytes,1,rep,name=json_patch,json=jsonPatch,proto3" json:"json_patch,omitempty"`
+	Cel       []*BodyTransform_CELAssignment      `protobuf:"bytes,2,rep,name=cel,proto3" json:"cel,omitempty"`
+}
+
+func (x *BodyTransform) Reset() {
+	*x = BodyTransform{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_body_transform_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BodyTransform) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BodyTransform) ProtoMessage() {}
+
+func (x *BodyTransform) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_body_transform_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BodyTransform.ProtoReflect.Descriptor instead.
+func (*BodyTransform) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_body_transform_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BodyTransform) GetJsonPatch() []*BodyTransform_JSONPatchOperation {
+	if x != nil {
+		return x.JsonPatch
+	}
+	return nil
+}
+
+func (x *BodyTransform) GetCel() []*BodyTransform_CELAssignment {
+	if x != nil {
+		return x.Cel
+	}
+	return nil
+}
+
+// BodyTransformConfig transforms the bodies of the upstream requests of the
+// cluster after they are translated for the provider, and of the non-streaming
+// responses after they are translated from the provider.
+type BodyTransformConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Request  *BodyTransform `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Response *BodyTransform `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+}
+
+func (x *BodyTransformConfig) Reset() {
+	*x = BodyTransformConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_body_transform_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BodyTransformConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BodyTransformConfig) ProtoMessage() {}
+
+func (x *BodyTransformConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_body_transform_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BodyTransformConfig.ProtoReflect.Descriptor instead.
+func (*BodyTransformConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_body_transform_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BodyTransformConfig) GetRequest() *BodyTransform {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *BodyTransformConfig) GetResponse() *BodyTransform {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+// JSONPatchOperation is an operation of JSON Patch (RFC 6902).
+type BodyTransform_JSONPatchOperation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// op is one of add, remove, replace, move, copy and test
+	Op   string `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// from is the source of move and copy
+	From string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	// value of add, replace and test
+	Value *structpb.Value `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *BodyTransform_JSONPatchOperation) Reset() {
+	*x = BodyTransform_JSONPatchOperation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_body_transform_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BodyTransform_JSONPatchOperation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BodyTransform_JSONPatchOperation) ProtoMessage() {}
+
+func (x *BodyTransform_JSONPatchOperation) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_body_transform_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BodyTransform_JSONPatchOperation.ProtoReflect.Descriptor instead.
+func (*BodyTransform_JSONPatchOperation) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_body_transform_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *BodyTransform_JSONPatchOperation) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *BodyTransform_JSONPatchOperation) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *BodyTransform_JSONPatchOperation) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *BodyTransform_JSONPatchOperation) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// CELAssignment sets the field of the path to the result of the
+// expression, the field is removed when the result is null.
+type BodyTransform_CELAssignment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path       string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Expression string `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
+}
+
+func (x *BodyTransform_CELAssignment) Reset() {
+	*x = BodyTransform_CELAssignment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_body_transform_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BodyTransform_CELAssignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BodyTransform_CELAssignment) ProtoMessage() {}
+
+func (x *BodyTransform_CELAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_body_transform_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BodyTransform_CELAssignment.ProtoReflect.Descriptor instead.
+func (*BodyTransform_CELAssignment) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_body_transform_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *BodyTransform_CELAssignment) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *BodyTransform_CELAssignment) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+var File_filters_v1alpha1_body_transform_proto protoreflect.FileDescriptor
+
+var file_filters_v1alpha1_body_transform_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x62, 0x6f, 0x64, 0x79, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72,
+	0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf2,
+	0x02, 0x0a, 0x0d, 0x42, 0x6f, 0x64, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d,
+	0x12, 0x58, 0x0a, 0x0a, 0x6a, 0x73, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x42,
+	0x6f, 0x64, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x4a, 0x53, 0x4f,
+	0x4e, 0x50, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x09, 0x6a, 0x73, 0x6f, 0x6e, 0x50, 0x61, 0x74, 0x63, 0x68, 0x12, 0x46, 0x0a, 0x03, 0x63, 0x65,
+	0x6c, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79,
+	0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x42, 0x6f, 0x64, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e,
+	0x43, 0x45, 0x4c, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x03, 0x63,
+	0x65, 0x6c, 0x1a, 0x7a, 0x0a, 0x12, 0x4a, 0x53, 0x4f, 0x4e, 0x50, 0x61, 0x74, 0x63, 0x68, 0x4f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x43,
+	0x0a, 0x0d, 0x43, 0x45, 0x4c, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x9b, 0x01, 0x0a, 0x13, 0x42, 0x6f, 0x64, 0x79, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x6f, 0x72, 0x6d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x40, 0x0a, 0x07, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6b,
+	0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x64, 0x79, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x6f, 0x72, 0x6d, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x42, 0x0a,
+	0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x26, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x42, 0x6f, 0x64, 0x79, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filters_v1alpha1_body_transform_proto_rawDescOnce sync.Once
+	file_filters_v1alpha1_body_transform_proto_rawDescData = file_filters_v1alpha1_body_transform_proto_rawDesc
+)
+
+func file_filters_v1alpha1_body_transform_proto_rawDescGZIP() []byte {
+	file_filters_v1alpha1_body_transform_proto_rawDescOnce.Do(func() {
+		file_filters_v1alpha1_body_transform_proto_rawDescData = protoimpl.X.CompressGZIP(file_filters_v1alpha1_body_transform_proto_rawDescData)
+	})
+	return file_filters_v1alpha1_body_transform_proto_rawDescData
+}
+
+var file_filters_v1alpha1_body_transform_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_filters_v1alpha1_body_transform_proto_goTypes = []interface{}{
+	(*BodyTransform)(nil),                    // 0: knoway.filters.v1alpha1.BodyTransform
+	(*BodyTransformConfig)(nil),              // 1: knoway.filters.v1alpha1.BodyTransformConfig
+	(*BodyTransform_JSONPatchOperation)(nil), // 2: knoway.filters.v1alpha1.BodyTransform.JSONPatchOperation
+	(*BodyTransform_CELAssignment)(nil),      // 3: knoway.filters.v1alpha1.BodyTransform.CELAssignment
+	(*structpb.Value)(nil),                   // 4: google.protobuf.Value
+}
+var file_filters_v1alpha1_body_transform_proto_depIdxs = []int32{
+	2, // 0: knoway.filters.v1alpha1.BodyTransform.json_patch:type_name -> knoway.filters.v1alpha1.BodyTransform.JSONPatchOperation
+	3, // 1: knoway.filters.v1alpha1.BodyTransform.cel:type_name -> knoway.filters.v1alpha1.BodyTransform.CELAssignment
+	0, // 2: knoway.filters.v1alpha1.BodyTransformConfig.request:type_name -> knoway.filters.v1alpha1.BodyTransform
+	0, // 3: knoway.filters.v1alpha1.BodyTransformConfig.response:type_name -> knoway.filters.v1alpha1.BodyTransform
+	4, // 4: knoway.filters.v1alpha1.BodyTransform.JSONPatchOperation.value:type_name -> google.protobuf.Value
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_filters_v1alpha1_body_transform_proto_init() }
+func file_filters_v1alpha1_body_transform_proto_init() {
+	if File_filters_v1alpha1_body_transform_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filters_v1alpha1_body_transform_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BodyTransform); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_body_transform_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BodyTransformConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_body_transform_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BodyTransform_JSONPatchOperation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_body_transform_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BodyTransform_CELAssignment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filters_v1alpha1_body_transform_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_filters_v1alpha1_body_transform_proto_goTypes,
+		DependencyIndexes: file_filters_v1alpha1_body_transform_proto_depIdxs,
+		MessageInfos:      file_filters_v1alpha1_body_transform_proto_msgTypes,
+	}.Build()
+	File_filters_v1alpha1_body_transform_proto = out.File
+	file_filters_v1alpha1_body_transform_proto_rawDesc = nil
+	file_filters_v1alpha1_body_transform_proto_goTypes = nil
+	file_filters_v1alpha1_body_transform_proto_depIdxs = nil
+}
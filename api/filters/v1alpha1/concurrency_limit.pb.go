@@ -0,0 +1,362 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: filters/v1alpha1/concurrency_limit.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConcurrencyLimitBaseOn int32
+
+const (
+	ConcurrencyLimitBaseOn_CONCURRENCY_LIMIT_BASE_ON_UNSPECIFIED ConcurrencyLimitBaseOn = 0
+	// PER_ROUTE shares the limit among all of the requests of the route
+	ConcurrencyLimitBaseOn_PER_ROUTE ConcurrencyLimitBaseOn = 1
+	// PER_USER shares the limit among the requests of each user
+	ConcurrencyLimitBaseOn_PER_USER ConcurrencyLimitBaseOn = 2
+	// PER_API_KEY shares the limit among the requests of each API key
+	ConcurrencyLimitBaseOn_PER_API_KEY ConcurrencyLimitBaseOn = 3
+)
+
+// Enum value maps for ConcurrencyLimitBaseOn.
+var (
+	ConcurrencyLimitBaseOn_name = map[int32]string{
+		0: "CONCURRENCY_LIMIT_BASE_ON_UNSPECIFIED",
+		1: "PER_ROUTE",
+		2: "PER_USER",
+		3: "PER_API_KEY",
+	}
+	ConcurrencyLimitBaseOn_value = map[string]int32{
+		"CONCURRENCY_LIMIT_BASE_ON_UNSPECIFIED": 0,
+		"PER_ROUTE":                             1,
+		"PER_USER":                              2,
+		"PER_API_KEY":                           3,
+	}
+)
+
+func (x ConcurrencyLimitBaseOn) Enum() *ConcurrencyLimitBaseOn {
+	p := new(ConcurrencyLimitBaseOn)
+	*p = x
+	return p
+}
+
+func (x ConcurrencyLimitBaseOn) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConcurrencyLimitBaseOn) Descriptor() protoreflect.EnumDescriptor {
+	return file_filters_v1alpha1_concurrency_limit_proto_enumTypes[0].Descriptor()
+}
+
+func (ConcurrencyLimitBaseOn) Type() protoreflect.EnumType {
+	return &file_filters_v1alpha1_concurrency_limit_proto_enumTypes[0]
+}
+
+func (x ConcurrencyLimitBaseOn) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConcurrencyLimitBaseOn.Descriptor instead.
+func (ConcurrencyLimitBaseOn) EnumDescriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_concurrency_limit_proto_rawDescGZIP(), []int{0}
+}
+
+type ConcurrencyLimitPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// PER_ROUTE by default.
+	BasedOn ConcurrencyLimitBaseOn `protobuf:"varint,1,opt,name=based_on,json=basedOn,proto3,enum=knoway.filters.v1alpha1.ConcurrencyLimitBaseOn" json:"based_on,omitempty"`
+	// Match the user or the API key, ignored for PER_ROUTE, unset matches any.
+	Match *StringMatch `protobuf:"bytes,2,opt,name=match,proto3" json:"match,omitempty"`
+	// Maximum number of requests in-flight at the same time.
+	MaxInFlight int32 `protobuf:"varint,3,opt,name=max_in_flight,json=maxInFlight,proto3" json:"max_in_flight,omitempty"`
+	// Maximum number of requests waiting for a slot, requests beyond it are
+	// rejected immediately, 0 disables queueing.
+	MaxQueued int32 `protobuf:"varint,4,opt,name=max_queued,json=maxQueued,proto3" json:"max_queued,omitempty"`
+	// Maximum time a request waits for a slot before being rejected,
+	// default: 30s
+	QueueTimeout *durationpb.Duration `protobuf:"bytes,5,opt,name=queue_timeout,json=queueTimeout,proto3" json:"queue_timeout,omitempty"`
+	// Hints clients when to retry with the Retry-After header of the
+	// rejections, default: 1s
+	RetryAfter *durationpb.Duration `protobuf:"bytes,6,opt,name=retry_after,json=retryAfter,proto3" json:"retry_after,omitempty"`
+}
+
+func (x *ConcurrencyLimitPolicy) Reset() {
+	*x = ConcurrencyLimitPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_concurrency_limit_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConcurrencyLimitPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConcurrencyLimitPolicy) ProtoMessage() {}
+
+func (x *ConcurrencyLimitPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_concurrency_limit_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConcurrencyLimitPolicy.ProtoReflect.Descriptor instead.
+func (*ConcurrencyLimitPolicy) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_concurrency_limit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConcurrencyLimitPolicy) GetBasedOn() ConcurrencyLimitBaseOn {
+	if x != nil {
+		return x.BasedOn
+	}
+	return ConcurrencyLimitBaseOn_CONCURRENCY_LIMIT_BASE_ON_UNSPECIFIED
+}
+
+func (x *ConcurrencyLimitPolicy) GetMatch() *StringMatch {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *ConcurrencyLimitPolicy) GetMaxInFlight() int32 {
+	if x != nil {
+		return x.MaxInFlight
+	}
+	return 0
+}
+
+func (x *ConcurrencyLimitPolicy) GetMaxQueued() int32 {
+	if x != nil {
+		return x.MaxQueued
+	}
+	return 0
+}
+
+func (x *ConcurrencyLimitPolicy) GetQueueTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.QueueTimeout
+	}
+	return nil
+}
+
+func (x *ConcurrencyLimitPolicy) GetRetryAfter() *durationpb.Duration {
+	if x != nil {
+		return x.RetryAfter
+	}
+	return nil
+}
+
+// ConcurrencyLimitConfig limits the requests in-flight at the same time,
+// for each kind of based_on the first policy matching the request applies.
+// The limits are enforced by each replica of the gateway.
+type ConcurrencyLimitConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Policies []*ConcurrencyLimitPolicy `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+}
+
+func (x *ConcurrencyLimitConfig) Reset() {
+	*x = ConcurrencyLimitConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_concurrency_limit_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConcurrencyLimitConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConcurrencyLimitConfig) ProtoMessage() {}
+
+func (x *ConcurrencyLimitConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_concurrency_limit_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConcurrencyLimitConfig.ProtoReflect.Descriptor instead.
+func (*ConcurrencyLimitConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_concurrency_limit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConcurrencyLimitConfig) GetPolicies() []*ConcurrencyLimitPolicy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+var File_filters_v1alpha1_concurrency_limit_proto protoreflect.FileDescriptor
+
+var file_filters_v1alpha1_concurrency_limit_proto_rawDesc = []byte{
+	0x0a, 0x28, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6b, 0x6e, 0x6f, 0x77,
+	0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x21, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xdf, 0x02, 0x0a, 0x16, 0x43, 0x6f, 0x6e, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x12, 0x4a, 0x0a, 0x08, 0x62, 0x61, 0x73, 0x65, 0x64, 0x5f, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x2f, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f,
+	0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x61,
+	0x73, 0x65, 0x4f, 0x6e, 0x52, 0x07, 0x62, 0x61, 0x73, 0x65, 0x64, 0x4f, 0x6e, 0x12, 0x3a, 0x0a,
+	0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6b,
+	0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78,
+	0x5f, 0x69, 0x6e, 0x5f, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x6d, 0x61, 0x78, 0x49, 0x6e, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x51, 0x75, 0x65, 0x75, 0x65, 0x64, 0x12, 0x3e, 0x0a, 0x0d,
+	0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c,
+	0x71, 0x75, 0x65, 0x75, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x3a, 0x0a, 0x0b,
+	0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x65,
+	0x74, 0x72, 0x79, 0x41, 0x66, 0x74, 0x65, 0x72, 0x22, 0x65, 0x0a, 0x16, 0x43, 0x6f, 0x6e, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x4b, 0x0a, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x2a,
+	0x71, 0x0a, 0x16, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x42, 0x61, 0x73, 0x65, 0x4f, 0x6e, 0x12, 0x29, 0x0a, 0x25, 0x43, 0x4f, 0x4e,
+	0x43, 0x55, 0x52, 0x52, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x42,
+	0x41, 0x53, 0x45, 0x5f, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x45, 0x52, 0x5f, 0x52, 0x4f, 0x55, 0x54,
+	0x45, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x45, 0x52, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x10,
+	0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x45, 0x52, 0x5f, 0x41, 0x50, 0x49, 0x5f, 0x4b, 0x45, 0x59,
+	0x10, 0x03, 0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filters_v1alpha1_concurrency_limit_proto_rawDescOnce sync.Once
+	file_filters_v1alpha1_concurrency_limit_proto_rawDescData = file_filters_v1alpha1_concurrency_limit_proto_rawDesc
+)
+
+func file_filters_v1alpha1_concurrency_limit_proto_rawDescGZIP() []byte {
+	file_filters_v1alpha1_concurrency_limit_proto_rawDescOnce.Do(func() {
+		file_filters_v1alpha1_concurrency_limit_proto_rawDescData = protoimpl.X.CompressGZIP(file_filters_v1alpha1_concurrency_limit_proto_rawDescData)
+	})
+	return file_filters_v1alpha1_concurrency_limit_proto_rawDescData
+}
+
+var file_filters_v1alpha1_concurrency_limit_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_filters_v1alpha1_concurrency_limit_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_filters_v1alpha1_concurrency_limit_proto_goTypes = []interface{}{
+	(ConcurrencyLimitBaseOn)(0),    // 0: knoway.filters.v1alpha1.ConcurrencyLimitBaseOn
+	(*ConcurrencyLimitPolicy)(nil), // 1: knoway.filters.v1alpha1.ConcurrencyLimitPolicy
+	(*ConcurrencyLimitConfig)(nil), // 2: knoway.filters.v1alpha1.ConcurrencyLimitConfig
+	(*StringMatch)(nil),            // 3: knoway.filters.v1alpha1.StringMatch
+	(*durationpb.Duration)(nil),    // 4: google.protobuf.Duration
+}
+var file_filters_v1alpha1_concurrency_limit_proto_depIdxs = []int32{
+	0, // 0: knoway.filters.v1alpha1.ConcurrencyLimitPolicy.based_on:type_name -> knoway.filters.v1alpha1.ConcurrencyLimitBaseOn
+	3, // 1: knoway.filters.v1alpha1.ConcurrencyLimitPolicy.match:type_name -> knoway.filters.v1alpha1.StringMatch
+	4, // 2: knoway.filters.v1alpha1.ConcurrencyLimitPolicy.queue_timeout:type_name -> google.protobuf.Duration
+	4, // 3: knoway.filters.v1alpha1.ConcurrencyLimitPolicy.retry_after:type_name -> google.protobuf.Duration
+	1, // 4: knoway.filters.v1alpha1.ConcurrencyLimitConfig.policies:type_name -> knoway.filters.v1alpha1.ConcurrencyLimitPolicy
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_filters_v1alpha1_concurrency_limit_proto_init() }
+func file_filters_v1alpha1_concurrency_limit_proto_init() {
+	if File_filters_v1alpha1_concurrency_limit_proto != nil {
+		return
+	}
+	file_filters_v1alpha1_rate_limit_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_filters_v1alpha1_concurrency_limit_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConcurrencyLimitPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_concurrency_limit_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConcurrencyLimitConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filters_v1alpha1_concurrency_limit_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_filters_v1alpha1_concurrency_limit_proto_goTypes,
+		DependencyIndexes: file_filters_v1alpha1_concurrency_limit_proto_depIdxs,
+		EnumInfos:         file_filters_v1alpha1_concurrency_limit_proto_enumTypes,
+		MessageInfos:      file_filters_v1alpha1_concurrency_limit_proto_msgTypes,
+	}.Build()
+	File_filters_v1alpha1_concurrency_limit_proto = out.File
+	file_filters_v1alpha1_concurrency_limit_proto_rawDesc = nil
+	file_filters_v1alpha1_concurrency_limit_proto_goTypes = nil
+	file_filters_v1alpha1_concurrency_limit_proto_depIdxs = nil
+}
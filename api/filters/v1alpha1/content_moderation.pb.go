@@ -0,0 +1,462 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: filters/v1alpha1/content_moderation.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ContentModerationConfig classifies the prompts of chat completions, and
+// optionally the output, with an external moderation service, and blocks or
+// annotates the requests by the scores of the categories. The results are
+// recorded in the metadata of the requests and the access log.
+type ContentModerationConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Service:
+	//
+	//	*ContentModerationConfig_Openai
+	//	*ContentModerationConfig_ModerationServer_
+	Service isContentModerationConfig_Service `protobuf_oneof:"service"`
+	// timeout of the calls, default is 3s
+	Timeout *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// block_thresholds reject the requests scoring at least the threshold of
+	// any category. When neither block_thresholds nor annotate_thresholds are
+	// configured, the requests flagged by the service are rejected.
+	BlockThresholds map[string]float64 `protobuf:"bytes,4,rep,name=block_thresholds,json=blockThresholds,proto3" json:"block_thresholds,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// annotate_thresholds record the categories scoring at least the
+	// threshold in the metadata of the requests, which are still served.
+	AnnotateThresholds map[string]float64 `protobuf:"bytes,5,rep,name=annotate_thresholds,json=annotateThresholds,proto3" json:"annotate_thresholds,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// fail_closed rejects the requests when the service fails, they are
+	// served unmoderated by default.
+	FailClosed bool `protobuf:"varint,6,opt,name=fail_closed,json=failClosed,proto3" json:"fail_closed,omitempty"`
+	// moderate_output classifies the responses too, the text of streams is
+	// classified every output_interval_chars and once finished, streams are
+	// terminated once blocked.
+	ModerateOutput bool `protobuf:"varint,7,opt,name=moderate_output,json=moderateOutput,proto3" json:"moderate_output,omitempty"`
+	// output_interval_chars default is 1000
+	OutputIntervalChars uint32 `protobuf:"varint,8,opt,name=output_interval_chars,json=outputIntervalChars,proto3" json:"output_interval_chars,omitempty"`
+}
+
+func (x *ContentModerationConfig) Reset() {
+	*x = ContentModerationConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_content_moderation_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContentModerationConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentModerationConfig) ProtoMessage() {}
+
+func (x *ContentModerationConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_content_moderation_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContentModerationConfig.ProtoReflect.Descriptor instead.
+func (*ContentModerationConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_content_moderation_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *ContentModerationConfig) GetService() isContentModerationConfig_Service {
+	if m != nil {
+		return m.Service
+	}
+	return nil
+}
+
+func (x *ContentModerationConfig) GetOpenai() *ContentModerationConfig_OpenAI {
+	if x, ok := x.GetService().(*ContentModerationConfig_Openai); ok {
+		return x.Openai
+	}
+	return nil
+}
+
+func (x *ContentModerationConfig) GetModerationServer() *ContentModerationConfig_ModerationServer {
+	if x, ok := x.GetService().(*ContentModerationConfig_ModerationServer_); ok {
+		return x.ModerationServer
+	}
+	return nil
+}
+
+func (x *ContentModerationConfig) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *ContentModerationConfig) GetBlockThresholds() map[string]float64 {
+	if x != nil {
+		return x.BlockThresholds
+	}
+	return nil
+}
+
+func (x *ContentModerationConfig) GetAnnotateThresholds() map[string]float64 {
+	if x != nil {
+		return x.AnnotateThresholds
+	}
+	return nil
+}
+
+func (x *ContentModerationConfig) GetFailClosed() bool {
+	if x != nil {
+		return x.FailClosed
+	}
+	return false
+}
+
+func (x *ContentModerationConfig) GetModerateOutput() bool {
+	if x != nil {
+		return x.ModerateOutput
+	}
+	return false
+}
+
+func (x *ContentModerationConfig) GetOutputIntervalChars() uint32 {
+	if x != nil {
+		return x.OutputIntervalChars
+	}
+	return 0
+}
+
+type isContentModerationConfig_Service interface {
+	isContentModerationConfig_Service()
+}
+
+type ContentModerationConfig_Openai struct {
+	Openai *ContentModerationConfig_OpenAI `protobuf:"bytes,1,opt,name=openai,proto3,oneof"`
+}
+
+type ContentModerationConfig_ModerationServer_ struct {
+	ModerationServer *ContentModerationConfig_ModerationServer `protobuf:"bytes,2,opt,name=moderation_server,json=moderationServer,proto3,oneof"`
+}
+
+func (*ContentModerationConfig_Openai) isContentModerationConfig_Service() {}
+
+func (*ContentModerationConfig_ModerationServer_) isContentModerationConfig_Service() {}
+
+// OpenAI calls an OpenAI compatible /v1/moderations endpoint.
+type ContentModerationConfig_OpenAI struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// url of the endpoint, e.g. https://api.openai.com/v1/moderations
+	Url     string            `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Model   string            `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Headers map[string]string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ContentModerationConfig_OpenAI) Reset() {
+	*x = ContentModerationConfig_OpenAI{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_content_moderation_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContentModerationConfig_OpenAI) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentModerationConfig_OpenAI) ProtoMessage() {}
+
+func (x *ContentModerationConfig_OpenAI) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_content_moderation_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContentModerationConfig_OpenAI.ProtoReflect.Descriptor instead.
+func (*ContentModerationConfig_OpenAI) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_content_moderation_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ContentModerationConfig_OpenAI) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ContentModerationConfig_OpenAI) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ContentModerationConfig_OpenAI) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+// ModerationServer calls a gRPC knoway.service.v1alpha1.ModerationService.
+type ContentModerationConfig_ModerationServer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *ContentModerationConfig_ModerationServer) Reset() {
+	*x = ContentModerationConfig_ModerationServer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_content_moderation_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContentModerationConfig_ModerationServer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentModerationConfig_ModerationServer) ProtoMessage() {}
+
+func (x *ContentModerationConfig_ModerationServer) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_content_moderation_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContentModerationConfig_ModerationServer.ProtoReflect.Descriptor instead.
+func (*ContentModerationConfig_ModerationServer) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_content_moderation_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *ContentModerationConfig_ModerationServer) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+var File_filters_v1alpha1_content_moderation_proto protoreflect.FileDescriptor
+
+var file_filters_v1alpha1_content_moderation_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6b, 0x6e, 0x6f,
+	0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x89, 0x08, 0x0a, 0x17, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x12, 0x51, 0x0a, 0x06, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x37, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49, 0x48, 0x00, 0x52, 0x06, 0x6f, 0x70, 0x65,
+	0x6e, 0x61, 0x69, 0x12, 0x70, 0x0a, 0x11, 0x6d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x41,
+	0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x48, 0x00, 0x52, 0x10, 0x6d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x70, 0x0a, 0x10, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x45, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x12, 0x79, 0x0a, 0x13,
+	0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x48, 0x2e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x41, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x12, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x54, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x5f,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x66, 0x61,
+	0x69, 0x6c, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x6f, 0x64, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0e, 0x6d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x12, 0x32, 0x0a, 0x15, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x5f, 0x63, 0x68, 0x61, 0x72, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x13, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x43, 0x68, 0x61, 0x72, 0x73, 0x1a, 0xcc, 0x01, 0x0a, 0x06, 0x4f, 0x70, 0x65, 0x6e, 0x41, 0x49,
+	0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75,
+	0x72, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x5e, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x44, 0x2e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4f, 0x70, 0x65, 0x6e,
+	0x41, 0x49, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x24, 0x0a, 0x10, 0x4d, 0x6f, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x1a, 0x42, 0x0a, 0x14, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x45,
+	0x0a, 0x17, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filters_v1alpha1_content_moderation_proto_rawDescOnce sync.Once
+	file_filters_v1alpha1_content_moderation_proto_rawDescData = file_filters_v1alpha1_content_moderation_proto_rawDesc
+)
+
+func file_filters_v1alpha1_content_moderation_proto_rawDescGZIP() []byte {
+	file_filters_v1alpha1_content_moderation_proto_rawDescOnce.Do(func() {
+		file_filters_v1alpha1_content_moderation_proto_rawDescData = protoimpl.X.CompressGZIP(file_filters_v1alpha1_content_moderation_proto_rawDescData)
+	})
+	return file_filters_v1alpha1_content_moderation_proto_rawDescData
+}
+
+var file_filters_v1alpha1_content_moderation_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_filters_v1alpha1_content_moderation_proto_goTypes = []interface{}{
+	(*ContentModerationConfig)(nil),                  // 0: knoway.filters.v1alpha1.ContentModerationConfig
+	(*ContentModerationConfig_OpenAI)(nil),           // 1: knoway.filters.v1alpha1.ContentModerationConfig.OpenAI
+	(*ContentModerationConfig_ModerationServer)(nil), // 2: knoway.filters.v1alpha1.ContentModerationConfig.ModerationServer
+	nil,                         // 3: knoway.filters.v1alpha1.ContentModerationConfig.BlockThresholdsEntry
+	nil,                         // 4: knoway.filters.v1alpha1.ContentModerationConfig.AnnotateThresholdsEntry
+	nil,                         // 5: knoway.filters.v1alpha1.ContentModerationConfig.OpenAI.HeadersEntry
+	(*durationpb.Duration)(nil), // 6: google.protobuf.Duration
+}
+var file_filters_v1alpha1_content_moderation_proto_depIdxs = []int32{
+	1, // 0: knoway.filters.v1alpha1.ContentModerationConfig.openai:type_name -> knoway.filters.v1alpha1.ContentModerationConfig.OpenAI
+	2, // 1: knoway.filters.v1alpha1.ContentModerationConfig.moderation_server:type_name -> knoway.filters.v1alpha1.ContentModerationConfig.ModerationServer
+	6, // 2: knoway.filters.v1alpha1.ContentModerationConfig.timeout:type_name -> google.protobuf.Duration
+	3, // 3: knoway.filters.v1alpha1.ContentModerationConfig.block_thresholds:type_name -> knoway.filters.v1alpha1.ContentModerationConfig.BlockThresholdsEntry
+	4, // 4: knoway.filters.v1alpha1.ContentModerationConfig.annotate_thresholds:type_name -> knoway.filters.v1alpha1.ContentModerationConfig.AnnotateThresholdsEntry
+	5, // 5: knoway.filters.v1alpha1.ContentModerationConfig.OpenAI.headers:type_name -> knoway.filters.v1alpha1.ContentModerationConfig.OpenAI.HeadersEntry
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_filters_v1alpha1_content_moderation_proto_init() }
+func file_filters_v1alpha1_content_moderation_proto_init() {
+	if File_filters_v1alpha1_content_moderation_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filters_v1alpha1_content_moderation_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContentModerationConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_content_moderation_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContentModerationConfig_OpenAI); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_content_moderation_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContentModerationConfig_ModerationServer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_filters_v1alpha1_content_moderation_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*ContentModerationConfig_Openai)(nil),
+		(*ContentModerationConfig_ModerationServer_)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filters_v1alpha1_content_moderation_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_filters_v1alpha1_content_moderation_proto_goTypes,
+		DependencyIndexes: file_filters_v1alpha1_content_moderation_proto_depIdxs,
+		MessageInfos:      file_filters_v1alpha1_content_moderation_proto_msgTypes,
+	}.Build()
+	File_filters_v1alpha1_content_moderation_proto = out.File
+	file_filters_v1alpha1_content_moderation_proto_rawDesc = nil
+	file_filters_v1alpha1_content_moderation_proto_goTypes = nil
+	file_filters_v1alpha1_content_moderation_proto_depIdxs = nil
+}
@@ -0,0 +1,138 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: filters/v1alpha1/cost.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CostConfig computes the cost of the requests served successfully by the
+// pricing of the clusters that served them, exposed in the access logs, the
+// metrics and the route stats of the admin server. Requests served by
+// clusters without pricing are left unpriced.
+type CostConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CostConfig) Reset() {
+	*x = CostConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_cost_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CostConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CostConfig) ProtoMessage() {}
+
+func (x *CostConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_cost_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CostConfig.ProtoReflect.Descriptor instead.
+func (*CostConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_cost_proto_rawDescGZIP(), []int{0}
+}
+
+var File_filters_v1alpha1_cost_proto protoreflect.FileDescriptor
+
+var file_filters_v1alpha1_cost_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x63, 0x6f, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6b,
+	0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x22, 0x0c, 0x0a, 0x0a, 0x43, 0x6f, 0x73, 0x74, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64,
+	0x65, 0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filters_v1alpha1_cost_proto_rawDescOnce sync.Once
+	file_filters_v1alpha1_cost_proto_rawDescData = file_filters_v1alpha1_cost_proto_rawDesc
+)
+
+func file_filters_v1alpha1_cost_proto_rawDescGZIP() []byte {
+	file_filters_v1alpha1_cost_proto_rawDescOnce.Do(func() {
+		file_filters_v1alpha1_cost_proto_rawDescData = protoimpl.X.CompressGZIP(file_filters_v1alpha1_cost_proto_rawDescData)
+	})
+	return file_filters_v1alpha1_cost_proto_rawDescData
+}
+
+var file_filters_v1alpha1_cost_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_filters_v1alpha1_cost_proto_goTypes = []interface{}{
+	(*CostConfig)(nil), // 0: knoway.filters.v1alpha1.CostConfig
+}
+var file_filters_v1alpha1_cost_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_filters_v1alpha1_cost_proto_init() }
+func file_filters_v1alpha1_cost_proto_init() {
+	if File_filters_v1alpha1_cost_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filters_v1alpha1_cost_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CostConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filters_v1alpha1_cost_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_filters_v1alpha1_cost_proto_goTypes,
+		DependencyIndexes: file_filters_v1alpha1_cost_proto_depIdxs,
+		MessageInfos:      file_filters_v1alpha1_cost_proto_msgTypes,
+	}.Build()
+	File_filters_v1alpha1_cost_proto = out.File
+	file_filters_v1alpha1_cost_proto_rawDesc = nil
+	file_filters_v1alpha1_cost_proto_goTypes = nil
+	file_filters_v1alpha1_cost_proto_depIdxs = nil
+}
@@ -0,0 +1,428 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: filters/v1alpha1/external_processor.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ExternalProcessorConfig processes the chat completions with a processor out
+// of process, which replaces the bodies of the requests and the responses, or
+// rejects the requests, as a knoway.service.v1alpha1.ProcessingResponse.
+type ExternalProcessorConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Processor:
+	//
+	//	*ExternalProcessorConfig_GrpcService
+	//	*ExternalProcessorConfig_Wasm
+	Processor isExternalProcessorConfig_Processor `protobuf_oneof:"processor"`
+	// timeout of the calls, default is 3s
+	Timeout *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// process_request processes the requests, and process_response the
+	// responses, at least one of them is required.
+	ProcessRequest  bool `protobuf:"varint,4,opt,name=process_request,json=processRequest,proto3" json:"process_request,omitempty"`
+	ProcessResponse bool `protobuf:"varint,5,opt,name=process_response,json=processResponse,proto3" json:"process_response,omitempty"`
+	// fail_closed rejects the requests when the processor fails, they are
+	// served unprocessed by default.
+	FailClosed bool `protobuf:"varint,6,opt,name=fail_closed,json=failClosed,proto3" json:"fail_closed,omitempty"`
+	// forward_headers are the headers of the client requests sent to the
+	// processor, e.g. X-Request-Id.
+	ForwardHeaders []string `protobuf:"bytes,7,rep,name=forward_headers,json=forwardHeaders,proto3" json:"forward_headers,omitempty"`
+	// config is given to the processor along with every request.
+	Config *structpb.Struct `protobuf:"bytes,8,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (x *ExternalProcessorConfig) Reset() {
+	*x = ExternalProcessorConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_external_processor_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExternalProcessorConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalProcessorConfig) ProtoMessage() {}
+
+func (x *ExternalProcessorConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_external_processor_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalProcessorConfig.ProtoReflect.Descriptor instead.
+func (*ExternalProcessorConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_external_processor_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *ExternalProcessorConfig) GetProcessor() isExternalProcessorConfig_Processor {
+	if m != nil {
+		return m.Processor
+	}
+	return nil
+}
+
+func (x *ExternalProcessorConfig) GetGrpcService() *ExternalProcessorConfig_GRPCService {
+	if x, ok := x.GetProcessor().(*ExternalProcessorConfig_GrpcService); ok {
+		return x.GrpcService
+	}
+	return nil
+}
+
+func (x *ExternalProcessorConfig) GetWasm() *ExternalProcessorConfig_WASMModule {
+	if x, ok := x.GetProcessor().(*ExternalProcessorConfig_Wasm); ok {
+		return x.Wasm
+	}
+	return nil
+}
+
+func (x *ExternalProcessorConfig) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *ExternalProcessorConfig) GetProcessRequest() bool {
+	if x != nil {
+		return x.ProcessRequest
+	}
+	return false
+}
+
+func (x *ExternalProcessorConfig) GetProcessResponse() bool {
+	if x != nil {
+		return x.ProcessResponse
+	}
+	return false
+}
+
+func (x *ExternalProcessorConfig) GetFailClosed() bool {
+	if x != nil {
+		return x.FailClosed
+	}
+	return false
+}
+
+func (x *ExternalProcessorConfig) GetForwardHeaders() []string {
+	if x != nil {
+		return x.ForwardHeaders
+	}
+	return nil
+}
+
+func (x *ExternalProcessorConfig) GetConfig() *structpb.Struct {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type isExternalProcessorConfig_Processor interface {
+	isExternalProcessorConfig_Processor()
+}
+
+type ExternalProcessorConfig_GrpcService struct {
+	GrpcService *ExternalProcessorConfig_GRPCService `protobuf:"bytes,1,opt,name=grpc_service,json=grpcService,proto3,oneof"`
+}
+
+type ExternalProcessorConfig_Wasm struct {
+	Wasm *ExternalProcessorConfig_WASMModule `protobuf:"bytes,2,opt,name=wasm,proto3,oneof"`
+}
+
+func (*ExternalProcessorConfig_GrpcService) isExternalProcessorConfig_Processor() {}
+
+func (*ExternalProcessorConfig_Wasm) isExternalProcessorConfig_Processor() {}
+
+// GRPCService calls a gRPC knoway.service.v1alpha1.ExternalProcessorService.
+type ExternalProcessorConfig_GRPCService struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *ExternalProcessorConfig_GRPCService) Reset() {
+	*x = ExternalProcessorConfig_GRPCService{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_external_processor_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExternalProcessorConfig_GRPCService) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalProcessorConfig_GRPCService) ProtoMessage() {}
+
+func (x *ExternalProcessorConfig_GRPCService) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_external_processor_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalProcessorConfig_GRPCService.ProtoReflect.Descriptor instead.
+func (*ExternalProcessorConfig_GRPCService) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_external_processor_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ExternalProcessorConfig_GRPCService) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// WASMModule loads the module from the file when the filter is created,
+// the module is run by the WASM runtime registered in the gateway.
+//
+// The module exports `memory`, `knoway_alloc(size: i32) -> i32` and
+// `knoway_process(ptr: i32, len: i32) -> i64`. The gateway writes the
+// serialized knoway.service.v1alpha1.ProcessingRequest to the memory
+// allocated by knoway_alloc, and knoway_process returns the pointer in
+// the high 32 bits and the length in the low 32 bits of the serialized
+// knoway.service.v1alpha1.ProcessingResponse.
+type ExternalProcessorConfig_WASMModule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// path of the .wasm file
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// sha256 optional: the hex SHA-256 digest the module is verified with.
+	Sha256 string `protobuf:"bytes,2,opt,name=sha256,proto3" json:"sha256,omitempty"`
+}
+
+func (x *ExternalProcessorConfig_WASMModule) Reset() {
+	*x = ExternalProcessorConfig_WASMModule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_external_processor_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExternalProcessorConfig_WASMModule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalProcessorConfig_WASMModule) ProtoMessage() {}
+
+func (x *ExternalProcessorConfig_WASMModule) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_external_processor_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalProcessorConfig_WASMModule.ProtoReflect.Descriptor instead.
+func (*ExternalProcessorConfig_WASMModule) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_external_processor_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *ExternalProcessorConfig_WASMModule) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ExternalProcessorConfig_WASMModule) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+var File_filters_v1alpha1_external_processor_proto protoreflect.FileDescriptor
+
+var file_filters_v1alpha1_external_processor_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6b, 0x6e, 0x6f,
+	0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xbb, 0x04, 0x0a, 0x17, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x61,
+	0x0a, 0x0c, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45,
+	0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x47, 0x52, 0x50, 0x43, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x67, 0x72, 0x70, 0x63, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x51, 0x0a, 0x04, 0x77, 0x61, 0x73, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x3b, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x57, 0x41, 0x53, 0x4d, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x04,
+	0x77, 0x61, 0x73, 0x6d, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x70, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x66, 0x61, 0x69, 0x6c, 0x5f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x66, 0x61, 0x69, 0x6c, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x27,
+	0x0a, 0x0f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x2f, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0x1f, 0x0a, 0x0b, 0x47, 0x52, 0x50, 0x43,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x1a, 0x38, 0x0a, 0x0a, 0x57, 0x41, 0x53,
+	0x4d, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x68, 0x61,
+	0x32, 0x35, 0x36, 0x42, 0x0b, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x6f, 0x72,
+	0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filters_v1alpha1_external_processor_proto_rawDescOnce sync.Once
+	file_filters_v1alpha1_external_processor_proto_rawDescData = file_filters_v1alpha1_external_processor_proto_rawDesc
+)
+
+func file_filters_v1alpha1_external_processor_proto_rawDescGZIP() []byte {
+	file_filters_v1alpha1_external_processor_proto_rawDescOnce.Do(func() {
+		file_filters_v1alpha1_external_processor_proto_rawDescData = protoimpl.X.CompressGZIP(file_filters_v1alpha1_external_processor_proto_rawDescData)
+	})
+	return file_filters_v1alpha1_external_processor_proto_rawDescData
+}
+
+var file_filters_v1alpha1_external_processor_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_filters_v1alpha1_external_processor_proto_goTypes = []interface{}{
+	(*ExternalProcessorConfig)(nil),             // 0: knoway.filters.v1alpha1.ExternalProcessorConfig
+	(*ExternalProcessorConfig_GRPCService)(nil), // 1: knoway.filters.v1alpha1.ExternalProcessorConfig.GRPCService
+	(*ExternalProcessorConfig_WASMModule)(nil),  // 2: knoway.filters.v1alpha1.ExternalProcessorConfig.WASMModule
+	(*durationpb.Duration)(nil),                 // 3: google.protobuf.Duration
+	(*structpb.Struct)(nil),                     // 4: google.protobuf.Struct
+}
+var file_filters_v1alpha1_external_processor_proto_depIdxs = []int32{
+	1, // 0: knoway.filters.v1alpha1.ExternalProcessorConfig.grpc_service:type_name -> knoway.filters.v1alpha1.ExternalProcessorConfig.GRPCService
+	2, // 1: knoway.filters.v1alpha1.ExternalProcessorConfig.wasm:type_name -> knoway.filters.v1alpha1.ExternalProcessorConfig.WASMModule
+	3, // 2: knoway.filters.v1alpha1.ExternalProcessorConfig.timeout:type_name -> google.protobuf.Duration
+	4, // 3: knoway.filters.v1alpha1.ExternalProcessorConfig.config:type_name -> google.protobuf.Struct
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_filters_v1alpha1_external_processor_proto_init() }
+func file_filters_v1alpha1_external_processor_proto_init() {
+	if File_filters_v1alpha1_external_processor_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filters_v1alpha1_external_processor_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExternalProcessorConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_external_processor_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExternalProcessorConfig_GRPCService); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_external_processor_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExternalProcessorConfig_WASMModule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_filters_v1alpha1_external_processor_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*ExternalProcessorConfig_GrpcService)(nil),
+		(*ExternalProcessorConfig_Wasm)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filters_v1alpha1_external_processor_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_filters_v1alpha1_external_processor_proto_goTypes,
+		DependencyIndexes: file_filters_v1alpha1_external_processor_proto_depIdxs,
+		MessageInfos:      file_filters_v1alpha1_external_processor_proto_msgTypes,
+	}.Build()
+	File_filters_v1alpha1_external_processor_proto = out.File
+	file_filters_v1alpha1_external_processor_proto_rawDesc = nil
+	file_filters_v1alpha1_external_processor_proto_goTypes = nil
+	file_filters_v1alpha1_external_processor_proto_depIdxs = nil
+}
@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: filters/v1alpha1/image_prompt_policy.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ImagePromptPolicyConfig enforces the brand-safety rules of image generation
+// requests before they are sent to the upstream, the prompts with banned terms
+// are rejected, the others are wrapped by the prefix and the suffix, and the
+// mandatory negative prompts are added.
+type ImagePromptPolicyConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// prefix is prepended to the prompts, separated by a space
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// suffix is appended to the prompts, separated by a space
+	Suffix string `protobuf:"bytes,2,opt,name=suffix,proto3" json:"suffix,omitempty"`
+	// negative_prompts are added to every request
+	NegativePrompts []string `protobuf:"bytes,3,rep,name=negative_prompts,json=negativePrompts,proto3" json:"negative_prompts,omitempty"`
+	// negative_prompt_param is the request parameter the negative prompts
+	// are merged into, e.g. negative_prompt of Stable Diffusion backends.
+	// When empty, they are appended to the prompt as "Avoid: ...", since
+	// OpenAI has no parameter of negative prompts.
+	NegativePromptParam string `protobuf:"bytes,4,opt,name=negative_prompt_param,json=negativePromptParam,proto3" json:"negative_prompt_param,omitempty"`
+	// banned_terms reject the prompts containing any of them as words,
+	// case-insensitively
+	BannedTerms []string `protobuf:"bytes,5,rep,name=banned_terms,json=bannedTerms,proto3" json:"banned_terms,omitempty"`
+}
+
+func (x *ImagePromptPolicyConfig) Reset() {
+	*x = ImagePromptPolicyConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_image_prompt_policy_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImagePromptPolicyConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImagePromptPolicyConfig) ProtoMessage() {}
+
+func (x *ImagePromptPolicyConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_image_prompt_policy_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImagePromptPolicyConfig.ProtoReflect.Descriptor instead.
+func (*ImagePromptPolicyConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_image_prompt_policy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ImagePromptPolicyConfig) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ImagePromptPolicyConfig) GetSuffix() string {
+	if x != nil {
+		return x.Suffix
+	}
+	return ""
+}
+
+func (x *ImagePromptPolicyConfig) GetNegativePrompts() []string {
+	if x != nil {
+		return x.NegativePrompts
+	}
+	return nil
+}
+
+func (x *ImagePromptPolicyConfig) GetNegativePromptParam() string {
+	if x != nil {
+		return x.NegativePromptParam
+	}
+	return ""
+}
+
+func (x *ImagePromptPolicyConfig) GetBannedTerms() []string {
+	if x != nil {
+		return x.BannedTerms
+	}
+	return nil
+}
+
+var File_filters_v1alpha1_image_prompt_policy_proto protoreflect.FileDescriptor
+
+var file_filters_v1alpha1_image_prompt_policy_proto_rawDesc = []byte{
+	0x0a, 0x2a, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x5f,
+	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6b, 0x6e,
+	0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x22, 0xcb, 0x01, 0x0a, 0x17, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x50,
+	0x72, 0x6f, 0x6d, 0x70, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x75, 0x66,
+	0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x75, 0x66, 0x66, 0x69,
+	0x78, 0x12, 0x29, 0x0a, 0x10, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x70, 0x72,
+	0x6f, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x6e, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x15,
+	0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x5f,
+	0x70, 0x61, 0x72, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x6e, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x12, 0x21, 0x0a, 0x0c, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x74, 0x65, 0x72, 0x6d, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x54, 0x65,
+	0x72, 0x6d, 0x73, 0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65,
+	0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filters_v1alpha1_image_prompt_policy_proto_rawDescOnce sync.Once
+	file_filters_v1alpha1_image_prompt_policy_proto_rawDescData = file_filters_v1alpha1_image_prompt_policy_proto_rawDesc
+)
+
+func file_filters_v1alpha1_image_prompt_policy_proto_rawDescGZIP() []byte {
+	file_filters_v1alpha1_image_prompt_policy_proto_rawDescOnce.Do(func() {
+		file_filters_v1alpha1_image_prompt_policy_proto_rawDescData = protoimpl.X.CompressGZIP(file_filters_v1alpha1_image_prompt_policy_proto_rawDescData)
+	})
+	return file_filters_v1alpha1_image_prompt_policy_proto_rawDescData
+}
+
+var file_filters_v1alpha1_image_prompt_policy_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_filters_v1alpha1_image_prompt_policy_proto_goTypes = []interface{}{
+	(*ImagePromptPolicyConfig)(nil), // 0: knoway.filters.v1alpha1.ImagePromptPolicyConfig
+}
+var file_filters_v1alpha1_image_prompt_policy_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_filters_v1alpha1_image_prompt_policy_proto_init() }
+func file_filters_v1alpha1_image_prompt_policy_proto_init() {
+	if File_filters_v1alpha1_image_prompt_policy_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filters_v1alpha1_image_prompt_policy_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImagePromptPolicyConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filters_v1alpha1_image_prompt_policy_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_filters_v1alpha1_image_prompt_policy_proto_goTypes,
+		DependencyIndexes: file_filters_v1alpha1_image_prompt_policy_proto_depIdxs,
+		MessageInfos:      file_filters_v1alpha1_image_prompt_policy_proto_msgTypes,
+	}.Build()
+	File_filters_v1alpha1_image_prompt_policy_proto = out.File
+	file_filters_v1alpha1_image_prompt_policy_proto_rawDesc = nil
+	file_filters_v1alpha1_image_prompt_policy_proto_goTypes = nil
+	file_filters_v1alpha1_image_prompt_policy_proto_depIdxs = nil
+}
@@ -0,0 +1,324 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: filters/v1alpha1/pii_redaction.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PIIRedactionConfig_Detector int32
+
+const (
+	PIIRedactionConfig_DETECTOR_UNSPECIFIED PIIRedactionConfig_Detector = 0
+	PIIRedactionConfig_DETECTOR_EMAIL       PIIRedactionConfig_Detector = 1
+	// Phone numbers of 9 to 15 digits, with an optional country code and
+	// separators.
+	PIIRedactionConfig_DETECTOR_PHONE_NUMBER PIIRedactionConfig_Detector = 2
+	// Card numbers of 13 to 19 digits passing the Luhn check.
+	PIIRedactionConfig_DETECTOR_CREDIT_CARD PIIRedactionConfig_Detector = 3
+)
+
+// Enum value maps for PIIRedactionConfig_Detector.
+var (
+	PIIRedactionConfig_Detector_name = map[int32]string{
+		0: "DETECTOR_UNSPECIFIED",
+		1: "DETECTOR_EMAIL",
+		2: "DETECTOR_PHONE_NUMBER",
+		3: "DETECTOR_CREDIT_CARD",
+	}
+	PIIRedactionConfig_Detector_value = map[string]int32{
+		"DETECTOR_UNSPECIFIED":  0,
+		"DETECTOR_EMAIL":        1,
+		"DETECTOR_PHONE_NUMBER": 2,
+		"DETECTOR_CREDIT_CARD":  3,
+	}
+)
+
+func (x PIIRedactionConfig_Detector) Enum() *PIIRedactionConfig_Detector {
+	p := new(PIIRedactionConfig_Detector)
+	*p = x
+	return p
+}
+
+func (x PIIRedactionConfig_Detector) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PIIRedactionConfig_Detector) Descriptor() protoreflect.EnumDescriptor {
+	return file_filters_v1alpha1_pii_redaction_proto_enumTypes[0].Descriptor()
+}
+
+func (PIIRedactionConfig_Detector) Type() protoreflect.EnumType {
+	return &file_filters_v1alpha1_pii_redaction_proto_enumTypes[0]
+}
+
+func (x PIIRedactionConfig_Detector) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PIIRedactionConfig_Detector.Descriptor instead.
+func (PIIRedactionConfig_Detector) EnumDescriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_pii_redaction_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// PIIRedactionConfig masks the personally identifiable information (PII)
+// detected in the messages of chat completions before they are sent to the
+// upstream, and optionally in the responses before they are returned to the
+// client. Matches are replaced by the names of the detectors, e.g. [EMAIL].
+type PIIRedactionConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// detectors enabled, all of them when neither detectors nor patterns are
+	// configured.
+	Detectors []PIIRedactionConfig_Detector `protobuf:"varint,1,rep,packed,name=detectors,proto3,enum=knoway.filters.v1alpha1.PIIRedactionConfig_Detector" json:"detectors,omitempty"`
+	// patterns are the custom detectors, applied after the built-in ones.
+	Patterns []*PIIRedactionConfig_Pattern `protobuf:"bytes,2,rep,name=patterns,proto3" json:"patterns,omitempty"`
+	// redact_responses masks the PII in the responses too, the text of
+	// streams is held back until it ends with a whitespace, so that the PII
+	// split across chunks is masked as well.
+	RedactResponses bool `protobuf:"varint,3,opt,name=redact_responses,json=redactResponses,proto3" json:"redact_responses,omitempty"`
+}
+
+func (x *PIIRedactionConfig) Reset() {
+	*x = PIIRedactionConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_pii_redaction_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PIIRedactionConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PIIRedactionConfig) ProtoMessage() {}
+
+func (x *PIIRedactionConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_pii_redaction_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PIIRedactionConfig.ProtoReflect.Descriptor instead.
+func (*PIIRedactionConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_pii_redaction_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PIIRedactionConfig) GetDetectors() []PIIRedactionConfig_Detector {
+	if x != nil {
+		return x.Detectors
+	}
+	return nil
+}
+
+func (x *PIIRedactionConfig) GetPatterns() []*PIIRedactionConfig_Pattern {
+	if x != nil {
+		return x.Patterns
+	}
+	return nil
+}
+
+func (x *PIIRedactionConfig) GetRedactResponses() bool {
+	if x != nil {
+		return x.RedactResponses
+	}
+	return false
+}
+
+type PIIRedactionConfig_Pattern struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name of the pattern, matches are replaced by [NAME] in upper case.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// pattern is a RE2 regular expression.
+	Pattern string `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+}
+
+func (x *PIIRedactionConfig_Pattern) Reset() {
+	*x = PIIRedactionConfig_Pattern{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_pii_redaction_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PIIRedactionConfig_Pattern) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PIIRedactionConfig_Pattern) ProtoMessage() {}
+
+func (x *PIIRedactionConfig_Pattern) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_pii_redaction_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PIIRedactionConfig_Pattern.ProtoReflect.Descriptor instead.
+func (*PIIRedactionConfig_Pattern) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_pii_redaction_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *PIIRedactionConfig_Pattern) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PIIRedactionConfig_Pattern) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+var File_filters_v1alpha1_pii_redaction_proto protoreflect.FileDescriptor
+
+var file_filters_v1alpha1_pii_redaction_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x70, 0x69, 0x69, 0x5f, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x22,
+	0x8c, 0x03, 0x0a, 0x12, 0x50, 0x49, 0x49, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x52, 0x0a, 0x09, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x34, 0x2e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x50, 0x49, 0x49, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52,
+	0x09, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x4f, 0x0a, 0x08, 0x70, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6b,
+	0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x49, 0x49, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x52, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x72,
+	0x65, 0x64, 0x61, 0x63, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x1a, 0x37, 0x0a, 0x07, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x22,
+	0x6d, 0x0a, 0x08, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x14, 0x44,
+	0x45, 0x54, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x44, 0x45, 0x54, 0x45, 0x43, 0x54, 0x4f,
+	0x52, 0x5f, 0x45, 0x4d, 0x41, 0x49, 0x4c, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x44, 0x45, 0x54,
+	0x45, 0x43, 0x54, 0x4f, 0x52, 0x5f, 0x50, 0x48, 0x4f, 0x4e, 0x45, 0x5f, 0x4e, 0x55, 0x4d, 0x42,
+	0x45, 0x52, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x44, 0x45, 0x54, 0x45, 0x43, 0x54, 0x4f, 0x52,
+	0x5f, 0x43, 0x52, 0x45, 0x44, 0x49, 0x54, 0x5f, 0x43, 0x41, 0x52, 0x44, 0x10, 0x03, 0x42, 0x21,
+	0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filters_v1alpha1_pii_redaction_proto_rawDescOnce sync.Once
+	file_filters_v1alpha1_pii_redaction_proto_rawDescData = file_filters_v1alpha1_pii_redaction_proto_rawDesc
+)
+
+func file_filters_v1alpha1_pii_redaction_proto_rawDescGZIP() []byte {
+	file_filters_v1alpha1_pii_redaction_proto_rawDescOnce.Do(func() {
+		file_filters_v1alpha1_pii_redaction_proto_rawDescData = protoimpl.X.CompressGZIP(file_filters_v1alpha1_pii_redaction_proto_rawDescData)
+	})
+	return file_filters_v1alpha1_pii_redaction_proto_rawDescData
+}
+
+var file_filters_v1alpha1_pii_redaction_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_filters_v1alpha1_pii_redaction_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_filters_v1alpha1_pii_redaction_proto_goTypes = []interface{}{
+	(PIIRedactionConfig_Detector)(0),   // 0: knoway.filters.v1alpha1.PIIRedactionConfig.Detector
+	(*PIIRedactionConfig)(nil),         // 1: knoway.filters.v1alpha1.PIIRedactionConfig
+	(*PIIRedactionConfig_Pattern)(nil), // 2: knoway.filters.v1alpha1.PIIRedactionConfig.Pattern
+}
+var file_filters_v1alpha1_pii_redaction_proto_depIdxs = []int32{
+	0, // 0: knoway.filters.v1alpha1.PIIRedactionConfig.detectors:type_name -> knoway.filters.v1alpha1.PIIRedactionConfig.Detector
+	2, // 1: knoway.filters.v1alpha1.PIIRedactionConfig.patterns:type_name -> knoway.filters.v1alpha1.PIIRedactionConfig.Pattern
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_filters_v1alpha1_pii_redaction_proto_init() }
+func file_filters_v1alpha1_pii_redaction_proto_init() {
+	if File_filters_v1alpha1_pii_redaction_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filters_v1alpha1_pii_redaction_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PIIRedactionConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_pii_redaction_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PIIRedactionConfig_Pattern); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filters_v1alpha1_pii_redaction_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_filters_v1alpha1_pii_redaction_proto_goTypes,
+		DependencyIndexes: file_filters_v1alpha1_pii_redaction_proto_depIdxs,
+		EnumInfos:         file_filters_v1alpha1_pii_redaction_proto_enumTypes,
+		MessageInfos:      file_filters_v1alpha1_pii_redaction_proto_msgTypes,
+	}.Build()
+	File_filters_v1alpha1_pii_redaction_proto = out.File
+	file_filters_v1alpha1_pii_redaction_proto_rawDesc = nil
+	file_filters_v1alpha1_pii_redaction_proto_goTypes = nil
+	file_filters_v1alpha1_pii_redaction_proto_depIdxs = nil
+}
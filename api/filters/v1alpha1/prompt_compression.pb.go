@@ -0,0 +1,331 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: filters/v1alpha1/prompt_compression.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PromptCompressionSummarization summarizes the earlier messages of requests
+// with a cheap model behind an OpenAI compatible chat completions endpoint.
+type PromptCompressionSummarization struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// url of the endpoint, e.g. http://qwen-mini:8000/v1/chat/completions
+	Url     string               `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Model   string               `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Headers map[string]string    `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Timeout *durationpb.Duration `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// keep_last_messages are never summarized, default: 4
+	KeepLastMessages uint32 `protobuf:"varint,5,opt,name=keep_last_messages,json=keepLastMessages,proto3" json:"keep_last_messages,omitempty"`
+}
+
+func (x *PromptCompressionSummarization) Reset() {
+	*x = PromptCompressionSummarization{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_prompt_compression_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PromptCompressionSummarization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptCompressionSummarization) ProtoMessage() {}
+
+func (x *PromptCompressionSummarization) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_prompt_compression_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptCompressionSummarization.ProtoReflect.Descriptor instead.
+func (*PromptCompressionSummarization) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_prompt_compression_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PromptCompressionSummarization) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *PromptCompressionSummarization) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *PromptCompressionSummarization) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *PromptCompressionSummarization) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *PromptCompressionSummarization) GetKeepLastMessages() uint32 {
+	if x != nil {
+		return x.KeepLastMessages
+	}
+	return 0
+}
+
+// PromptCompressionConfig compresses the prompts of chat completions requests
+// before they are sent to the upstream, the steps enabled are applied in the
+// order of whitespace collapse, deduplication and summarization.
+type PromptCompressionConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// collapse_whitespace collapses runs of whitespaces and blank lines in the
+	// text of messages
+	CollapseWhitespace bool `protobuf:"varint,1,opt,name=collapse_whitespace,json=collapseWhitespace,proto3" json:"collapse_whitespace,omitempty"`
+	// deduplicate drops the paragraphs of system and user messages repeated
+	// from earlier messages
+	Deduplicate bool `protobuf:"varint,2,opt,name=deduplicate,proto3" json:"deduplicate,omitempty"`
+	// summarization replaces the earlier messages with a summary of them
+	Summarization *PromptCompressionSummarization `protobuf:"bytes,3,opt,name=summarization,proto3" json:"summarization,omitempty"`
+	// min_prompt_tokens skips the prompts estimated to be shorter
+	MinPromptTokens uint64 `protobuf:"varint,4,opt,name=min_prompt_tokens,json=minPromptTokens,proto3" json:"min_prompt_tokens,omitempty"`
+	// max_compression_ratio bounds the fraction of the estimated prompt
+	// tokens removed, steps exceeding the budget are skipped, 0 means
+	// unlimited
+	MaxCompressionRatio float32 `protobuf:"fixed32,5,opt,name=max_compression_ratio,json=maxCompressionRatio,proto3" json:"max_compression_ratio,omitempty"`
+}
+
+func (x *PromptCompressionConfig) Reset() {
+	*x = PromptCompressionConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_prompt_compression_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PromptCompressionConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptCompressionConfig) ProtoMessage() {}
+
+func (x *PromptCompressionConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_prompt_compression_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptCompressionConfig.ProtoReflect.Descriptor instead.
+func (*PromptCompressionConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_prompt_compression_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PromptCompressionConfig) GetCollapseWhitespace() bool {
+	if x != nil {
+		return x.CollapseWhitespace
+	}
+	return false
+}
+
+func (x *PromptCompressionConfig) GetDeduplicate() bool {
+	if x != nil {
+		return x.Deduplicate
+	}
+	return false
+}
+
+func (x *PromptCompressionConfig) GetSummarization() *PromptCompressionSummarization {
+	if x != nil {
+		return x.Summarization
+	}
+	return nil
+}
+
+func (x *PromptCompressionConfig) GetMinPromptTokens() uint64 {
+	if x != nil {
+		return x.MinPromptTokens
+	}
+	return 0
+}
+
+func (x *PromptCompressionConfig) GetMaxCompressionRatio() float32 {
+	if x != nil {
+		return x.MaxCompressionRatio
+	}
+	return 0
+}
+
+var File_filters_v1alpha1_prompt_compression_proto protoreflect.FileDescriptor
+
+var file_filters_v1alpha1_prompt_compression_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6b, 0x6e, 0x6f,
+	0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc7, 0x02, 0x0a, 0x1e, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x43,
+	0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12,
+	0x5e, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x44, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x70,
+	0x74, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12,
+	0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x10, 0x6b, 0x65, 0x65, 0x70, 0x4c, 0x61, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xab,
+	0x02, 0x0a, 0x17, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2f, 0x0a, 0x13, 0x63, 0x6f,
+	0x6c, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x5f, 0x77, 0x68, 0x69, 0x74, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x70, 0x73,
+	0x65, 0x57, 0x68, 0x69, 0x74, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0b, 0x64, 0x65, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x5d, 0x0a,
+	0x0d, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50,
+	0x72, 0x6f, 0x6d, 0x70, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x73,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x11,
+	0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6d, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x6d,
+	0x70, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x6d, 0x61, 0x78, 0x5f,
+	0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6d, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x42, 0x21, 0x5a, 0x1f,
+	0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filters_v1alpha1_prompt_compression_proto_rawDescOnce sync.Once
+	file_filters_v1alpha1_prompt_compression_proto_rawDescData = file_filters_v1alpha1_prompt_compression_proto_rawDesc
+)
+
+func file_filters_v1alpha1_prompt_compression_proto_rawDescGZIP() []byte {
+	file_filters_v1alpha1_prompt_compression_proto_rawDescOnce.Do(func() {
+		file_filters_v1alpha1_prompt_compression_proto_rawDescData = protoimpl.X.CompressGZIP(file_filters_v1alpha1_prompt_compression_proto_rawDescData)
+	})
+	return file_filters_v1alpha1_prompt_compression_proto_rawDescData
+}
+
+var file_filters_v1alpha1_prompt_compression_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_filters_v1alpha1_prompt_compression_proto_goTypes = []interface{}{
+	(*PromptCompressionSummarization)(nil), // 0: knoway.filters.v1alpha1.PromptCompressionSummarization
+	(*PromptCompressionConfig)(nil),        // 1: knoway.filters.v1alpha1.PromptCompressionConfig
+	nil,                                    // 2: knoway.filters.v1alpha1.PromptCompressionSummarization.HeadersEntry
+	(*durationpb.Duration)(nil),            // 3: google.protobuf.Duration
+}
+var file_filters_v1alpha1_prompt_compression_proto_depIdxs = []int32{
+	2, // 0: knoway.filters.v1alpha1.PromptCompressionSummarization.headers:type_name -> knoway.filters.v1alpha1.PromptCompressionSummarization.HeadersEntry
+	3, // 1: knoway.filters.v1alpha1.PromptCompressionSummarization.timeout:type_name -> google.protobuf.Duration
+	0, // 2: knoway.filters.v1alpha1.PromptCompressionConfig.summarization:type_name -> knoway.filters.v1alpha1.PromptCompressionSummarization
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_filters_v1alpha1_prompt_compression_proto_init() }
+func file_filters_v1alpha1_prompt_compression_proto_init() {
+	if File_filters_v1alpha1_prompt_compression_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filters_v1alpha1_prompt_compression_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PromptCompressionSummarization); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_prompt_compression_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PromptCompressionConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filters_v1alpha1_prompt_compression_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_filters_v1alpha1_prompt_compression_proto_goTypes,
+		DependencyIndexes: file_filters_v1alpha1_prompt_compression_proto_depIdxs,
+		MessageInfos:      file_filters_v1alpha1_prompt_compression_proto_msgTypes,
+	}.Build()
+	File_filters_v1alpha1_prompt_compression_proto = out.File
+	file_filters_v1alpha1_prompt_compression_proto_rawDesc = nil
+	file_filters_v1alpha1_prompt_compression_proto_goTypes = nil
+	file_filters_v1alpha1_prompt_compression_proto_depIdxs = nil
+}
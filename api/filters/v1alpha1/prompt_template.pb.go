@@ -0,0 +1,299 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: filters/v1alpha1/prompt_template.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PromptTemplateConfig_SystemPromptMode int32
+
+const (
+	// Same as SYSTEM_PROMPT_MODE_DEFAULT.
+	PromptTemplateConfig_SYSTEM_PROMPT_MODE_UNSPECIFIED PromptTemplateConfig_SystemPromptMode = 0
+	// The system prompt is added only when the request has no system
+	// message.
+	PromptTemplateConfig_SYSTEM_PROMPT_MODE_DEFAULT PromptTemplateConfig_SystemPromptMode = 1
+	// The system prompt is added before the system messages of the
+	// request.
+	PromptTemplateConfig_SYSTEM_PROMPT_MODE_PREPEND PromptTemplateConfig_SystemPromptMode = 2
+	// The system prompt replaces the system messages of the request.
+	PromptTemplateConfig_SYSTEM_PROMPT_MODE_OVERRIDE PromptTemplateConfig_SystemPromptMode = 3
+)
+
+// Enum value maps for PromptTemplateConfig_SystemPromptMode.
+var (
+	PromptTemplateConfig_SystemPromptMode_name = map[int32]string{
+		0: "SYSTEM_PROMPT_MODE_UNSPECIFIED",
+		1: "SYSTEM_PROMPT_MODE_DEFAULT",
+		2: "SYSTEM_PROMPT_MODE_PREPEND",
+		3: "SYSTEM_PROMPT_MODE_OVERRIDE",
+	}
+	PromptTemplateConfig_SystemPromptMode_value = map[string]int32{
+		"SYSTEM_PROMPT_MODE_UNSPECIFIED": 0,
+		"SYSTEM_PROMPT_MODE_DEFAULT":     1,
+		"SYSTEM_PROMPT_MODE_PREPEND":     2,
+		"SYSTEM_PROMPT_MODE_OVERRIDE":    3,
+	}
+)
+
+func (x PromptTemplateConfig_SystemPromptMode) Enum() *PromptTemplateConfig_SystemPromptMode {
+	p := new(PromptTemplateConfig_SystemPromptMode)
+	*p = x
+	return p
+}
+
+func (x PromptTemplateConfig_SystemPromptMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PromptTemplateConfig_SystemPromptMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_filters_v1alpha1_prompt_template_proto_enumTypes[0].Descriptor()
+}
+
+func (PromptTemplateConfig_SystemPromptMode) Type() protoreflect.EnumType {
+	return &file_filters_v1alpha1_prompt_template_proto_enumTypes[0]
+}
+
+func (x PromptTemplateConfig_SystemPromptMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PromptTemplateConfig_SystemPromptMode.Descriptor instead.
+func (PromptTemplateConfig_SystemPromptMode) EnumDescriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_prompt_template_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// PromptTemplateConfig injects the system prompt of the operators into the
+// chat completions requests, and wraps the last user message by the prefix
+// and the suffix. The variables in the templates are substituted before
+// they are applied:
+//
+//   - {{user_id}}: the user owning the API key of the request
+//   - {{api_key_id}}: the ID of the API key of the request
+//   - {{model}}: the model requested by the user
+//   - {{date}}: the current date in UTC, formatted by date_format
+//
+// and the ones of variables. Unknown variables are kept as they are.
+type PromptTemplateConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SystemPrompt     string                                `protobuf:"bytes,1,opt,name=system_prompt,json=systemPrompt,proto3" json:"system_prompt,omitempty"`
+	SystemPromptMode PromptTemplateConfig_SystemPromptMode `protobuf:"varint,2,opt,name=system_prompt_mode,json=systemPromptMode,proto3,enum=knoway.filters.v1alpha1.PromptTemplateConfig_SystemPromptMode" json:"system_prompt_mode,omitempty"`
+	// user_prefix and user_suffix wrap the text of the last user message,
+	// separated by new lines.
+	UserPrefix string `protobuf:"bytes,3,opt,name=user_prefix,json=userPrefix,proto3" json:"user_prefix,omitempty"`
+	UserSuffix string `protobuf:"bytes,4,opt,name=user_suffix,json=userSuffix,proto3" json:"user_suffix,omitempty"`
+	// variables are the custom variables, e.g. {"team": "search"} for
+	// {{team}}. The built-in variables can't be overridden.
+	Variables map[string]string `protobuf:"bytes,5,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// date_format is the layout of {{date}} in Go, default is 2006-01-02.
+	DateFormat string `protobuf:"bytes,6,opt,name=date_format,json=dateFormat,proto3" json:"date_format,omitempty"`
+}
+
+func (x *PromptTemplateConfig) Reset() {
+	*x = PromptTemplateConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_prompt_template_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PromptTemplateConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptTemplateConfig) ProtoMessage() {}
+
+func (x *PromptTemplateConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_prompt_template_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptTemplateConfig.ProtoReflect.Descriptor instead.
+func (*PromptTemplateConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_prompt_template_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PromptTemplateConfig) GetSystemPrompt() string {
+	if x != nil {
+		return x.SystemPrompt
+	}
+	return ""
+}
+
+func (x *PromptTemplateConfig) GetSystemPromptMode() PromptTemplateConfig_SystemPromptMode {
+	if x != nil {
+		return x.SystemPromptMode
+	}
+	return PromptTemplateConfig_SYSTEM_PROMPT_MODE_UNSPECIFIED
+}
+
+func (x *PromptTemplateConfig) GetUserPrefix() string {
+	if x != nil {
+		return x.UserPrefix
+	}
+	return ""
+}
+
+func (x *PromptTemplateConfig) GetUserSuffix() string {
+	if x != nil {
+		return x.UserSuffix
+	}
+	return ""
+}
+
+func (x *PromptTemplateConfig) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *PromptTemplateConfig) GetDateFormat() string {
+	if x != nil {
+		return x.DateFormat
+	}
+	return ""
+}
+
+var File_filters_v1alpha1_prompt_template_proto protoreflect.FileDescriptor
+
+var file_filters_v1alpha1_prompt_template_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61,
+	0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79,
+	0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x22, 0xc0, 0x04, 0x0a, 0x14, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x54, 0x65, 0x6d, 0x70,
+	0x6c, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x5f, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x12,
+	0x6c, 0x0a, 0x12, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3e, 0x2e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x54, 0x65, 0x6d, 0x70,
+	0x6c, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x10, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1f,
+	0x0a, 0x0b, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x53, 0x75, 0x66, 0x66, 0x69, 0x78, 0x12,
+	0x5a, 0x0a, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x6f,
+	0x6d, 0x70, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x09, 0x76, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x64,
+	0x61, 0x74, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x64, 0x61, 0x74, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x1a, 0x3c, 0x0a, 0x0e,
+	0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x97, 0x01, 0x0a, 0x10, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x22, 0x0a, 0x1e, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x5f, 0x50, 0x52, 0x4f, 0x4d, 0x50, 0x54,
+	0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x5f, 0x50, 0x52,
+	0x4f, 0x4d, 0x50, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c,
+	0x54, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x5f, 0x50, 0x52,
+	0x4f, 0x4d, 0x50, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x50, 0x52, 0x45, 0x50, 0x45, 0x4e,
+	0x44, 0x10, 0x02, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x5f, 0x50, 0x52,
+	0x4f, 0x4d, 0x50, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x4f, 0x56, 0x45, 0x52, 0x52, 0x49,
+	0x44, 0x45, 0x10, 0x03, 0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64,
+	0x65, 0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filters_v1alpha1_prompt_template_proto_rawDescOnce sync.Once
+	file_filters_v1alpha1_prompt_template_proto_rawDescData = file_filters_v1alpha1_prompt_template_proto_rawDesc
+)
+
+func file_filters_v1alpha1_prompt_template_proto_rawDescGZIP() []byte {
+	file_filters_v1alpha1_prompt_template_proto_rawDescOnce.Do(func() {
+		file_filters_v1alpha1_prompt_template_proto_rawDescData = protoimpl.X.CompressGZIP(file_filters_v1alpha1_prompt_template_proto_rawDescData)
+	})
+	return file_filters_v1alpha1_prompt_template_proto_rawDescData
+}
+
+var file_filters_v1alpha1_prompt_template_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_filters_v1alpha1_prompt_template_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_filters_v1alpha1_prompt_template_proto_goTypes = []interface{}{
+	(PromptTemplateConfig_SystemPromptMode)(0), // 0: knoway.filters.v1alpha1.PromptTemplateConfig.SystemPromptMode
+	(*PromptTemplateConfig)(nil),               // 1: knoway.filters.v1alpha1.PromptTemplateConfig
+	nil,                                        // 2: knoway.filters.v1alpha1.PromptTemplateConfig.VariablesEntry
+}
+var file_filters_v1alpha1_prompt_template_proto_depIdxs = []int32{
+	0, // 0: knoway.filters.v1alpha1.PromptTemplateConfig.system_prompt_mode:type_name -> knoway.filters.v1alpha1.PromptTemplateConfig.SystemPromptMode
+	2, // 1: knoway.filters.v1alpha1.PromptTemplateConfig.variables:type_name -> knoway.filters.v1alpha1.PromptTemplateConfig.VariablesEntry
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_filters_v1alpha1_prompt_template_proto_init() }
+func file_filters_v1alpha1_prompt_template_proto_init() {
+	if File_filters_v1alpha1_prompt_template_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filters_v1alpha1_prompt_template_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PromptTemplateConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filters_v1alpha1_prompt_template_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_filters_v1alpha1_prompt_template_proto_goTypes,
+		DependencyIndexes: file_filters_v1alpha1_prompt_template_proto_depIdxs,
+		EnumInfos:         file_filters_v1alpha1_prompt_template_proto_enumTypes,
+		MessageInfos:      file_filters_v1alpha1_prompt_template_proto_msgTypes,
+	}.Build()
+	File_filters_v1alpha1_prompt_template_proto = out.File
+	file_filters_v1alpha1_prompt_template_proto_rawDesc = nil
+	file_filters_v1alpha1_prompt_template_proto_goTypes = nil
+	file_filters_v1alpha1_prompt_template_proto_depIdxs = nil
+}
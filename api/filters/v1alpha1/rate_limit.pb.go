@@ -71,6 +71,57 @@ func (RateLimitBaseOn) EnumDescriptor() ([]byte, []int) {
 	return file_filters_v1alpha1_rate_limit_proto_rawDescGZIP(), []int{0}
 }
 
+type RateLimitUnit int32
+
+const (
+	RateLimitUnit_RATE_LIMIT_UNIT_UNSPECIFIED RateLimitUnit = 0
+	RateLimitUnit_REQUESTS                    RateLimitUnit = 1
+	// TOKENS limits the total tokens of prompts and completions, the tokens
+	// are deducted after the responses are received.
+	RateLimitUnit_TOKENS RateLimitUnit = 2
+)
+
+// Enum value maps for RateLimitUnit.
+var (
+	RateLimitUnit_name = map[int32]string{
+		0: "RATE_LIMIT_UNIT_UNSPECIFIED",
+		1: "REQUESTS",
+		2: "TOKENS",
+	}
+	RateLimitUnit_value = map[string]int32{
+		"RATE_LIMIT_UNIT_UNSPECIFIED": 0,
+		"REQUESTS":                    1,
+		"TOKENS":                      2,
+	}
+)
+
+func (x RateLimitUnit) Enum() *RateLimitUnit {
+	p := new(RateLimitUnit)
+	*p = x
+	return p
+}
+
+func (x RateLimitUnit) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RateLimitUnit) Descriptor() protoreflect.EnumDescriptor {
+	return file_filters_v1alpha1_rate_limit_proto_enumTypes[1].Descriptor()
+}
+
+func (RateLimitUnit) Type() protoreflect.EnumType {
+	return &file_filters_v1alpha1_rate_limit_proto_enumTypes[1]
+}
+
+func (x RateLimitUnit) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RateLimitUnit.Descriptor instead.
+func (RateLimitUnit) EnumDescriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_rate_limit_proto_rawDescGZIP(), []int{1}
+}
+
 type RateLimitMode int32
 
 const (
@@ -104,11 +155,11 @@ func (x RateLimitMode) String() string {
 }
 
 func (RateLimitMode) Descriptor() protoreflect.EnumDescriptor {
-	return file_filters_v1alpha1_rate_limit_proto_enumTypes[1].Descriptor()
+	return file_filters_v1alpha1_rate_limit_proto_enumTypes[2].Descriptor()
 }
 
 func (RateLimitMode) Type() protoreflect.EnumType {
-	return &file_filters_v1alpha1_rate_limit_proto_enumTypes[1]
+	return &file_filters_v1alpha1_rate_limit_proto_enumTypes[2]
 }
 
 func (x RateLimitMode) Number() protoreflect.EnumNumber {
@@ -117,7 +168,7 @@ func (x RateLimitMode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RateLimitMode.Descriptor instead.
 func (RateLimitMode) EnumDescriptor() ([]byte, []int) {
-	return file_filters_v1alpha1_rate_limit_proto_rawDescGZIP(), []int{1}
+	return file_filters_v1alpha1_rate_limit_proto_rawDescGZIP(), []int{2}
 }
 
 type StringMatch struct {
@@ -210,6 +261,11 @@ type RateLimitPolicy struct {
 	Limit    int32                `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	BasedOn  RateLimitBaseOn      `protobuf:"varint,3,opt,name=based_on,json=basedOn,proto3,enum=knoway.filters.v1alpha1.RateLimitBaseOn" json:"based_on,omitempty"`
 	Duration *durationpb.Duration `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	// Requests by default.
+	Unit RateLimitUnit `protobuf:"varint,5,opt,name=unit,proto3,enum=knoway.filters.v1alpha1.RateLimitUnit" json:"unit,omitempty"`
+	// Prepaid turns the limit into a budget that never gets replenished,
+	// duration is ignored, only valid for TOKENS.
+	Prepaid bool `protobuf:"varint,6,opt,name=prepaid,proto3" json:"prepaid,omitempty"`
 }
 
 func (x *RateLimitPolicy) Reset() {
@@ -272,6 +328,20 @@ func (x *RateLimitPolicy) GetDuration() *durationpb.Duration {
 	return nil
 }
 
+func (x *RateLimitPolicy) GetUnit() RateLimitUnit {
+	if x != nil {
+		return x.Unit
+	}
+	return RateLimitUnit_RATE_LIMIT_UNIT_UNSPECIFIED
+}
+
+func (x *RateLimitPolicy) GetPrepaid() bool {
+	if x != nil {
+		return x.Prepaid
+	}
+	return false
+}
+
 // RateLimitConfig defines rate limiting configuration
 type RateLimitConfig struct {
 	state         protoimpl.MessageState
@@ -282,6 +352,19 @@ type RateLimitConfig struct {
 	Model        RateLimitMode      `protobuf:"varint,2,opt,name=model,proto3,enum=knoway.filters.v1alpha1.RateLimitMode" json:"model,omitempty"`
 	ServerPrefix string             `protobuf:"bytes,3,opt,name=server_prefix,json=serverPrefix,proto3" json:"server_prefix,omitempty"`
 	RedisServer  *RedisServer       `protobuf:"bytes,4,opt,name=redis_server,json=redisServer,proto3" json:"redis_server,omitempty"`
+	// redis_retry retries the operations of Redis failed to connect before
+	// the requests fail. Timeouts and broken connections are not retried
+	// since the operations may have been applied.
+	RedisRetry *RedisRetryPolicy `protobuf:"bytes,5,opt,name=redis_retry,json=redisRetry,proto3" json:"redis_retry,omitempty"`
+	// decision_cache_ttl keeps the rejections of Redis per key for the
+	// duration. Keys rejected are rejected locally without querying Redis
+	// until the rejections expire, including while Redis is unavailable.
+	// Disabled by default.
+	DecisionCacheTtl *durationpb.Duration `protobuf:"bytes,6,opt,name=decision_cache_ttl,json=decisionCacheTtl,proto3" json:"decision_cache_ttl,omitempty"`
+	// fail_open allows the requests when Redis stays unavailable after the
+	// retries, instead of failing them. Keys with cached rejections are
+	// still rejected.
+	FailOpen bool `protobuf:"varint,7,opt,name=fail_open,json=failOpen,proto3" json:"fail_open,omitempty"`
 }
 
 func (x *RateLimitConfig) Reset() {
@@ -344,6 +427,27 @@ func (x *RateLimitConfig) GetRedisServer() *RedisServer {
 	return nil
 }
 
+func (x *RateLimitConfig) GetRedisRetry() *RedisRetryPolicy {
+	if x != nil {
+		return x.RedisRetry
+	}
+	return nil
+}
+
+func (x *RateLimitConfig) GetDecisionCacheTtl() *durationpb.Duration {
+	if x != nil {
+		return x.DecisionCacheTtl
+	}
+	return nil
+}
+
+func (x *RateLimitConfig) GetFailOpen() bool {
+	if x != nil {
+		return x.FailOpen
+	}
+	return false
+}
+
 type RedisServer struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -391,6 +495,74 @@ func (x *RedisServer) GetUrl() string {
 	return ""
 }
 
+type RedisRetryPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// max_retries of the operations failed to connect, 2 by
+	// default, 0 disables the retries.
+	MaxRetries *int32 `protobuf:"varint,1,opt,name=max_retries,json=maxRetries,proto3,oneof" json:"max_retries,omitempty"`
+	// base_interval is the ceiling of the random delay before the first
+	// retry, doubled for each of the following ones, 10ms by default.
+	BaseInterval *durationpb.Duration `protobuf:"bytes,2,opt,name=base_interval,json=baseInterval,proto3" json:"base_interval,omitempty"`
+	// max_interval caps the delays before the retries, 100ms by default.
+	MaxInterval *durationpb.Duration `protobuf:"bytes,3,opt,name=max_interval,json=maxInterval,proto3" json:"max_interval,omitempty"`
+}
+
+func (x *RedisRetryPolicy) Reset() {
+	*x = RedisRetryPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_rate_limit_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RedisRetryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedisRetryPolicy) ProtoMessage() {}
+
+func (x *RedisRetryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_rate_limit_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedisRetryPolicy.ProtoReflect.Descriptor instead.
+func (*RedisRetryPolicy) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_rate_limit_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RedisRetryPolicy) GetMaxRetries() int32 {
+	if x != nil && x.MaxRetries != nil {
+		return *x.MaxRetries
+	}
+	return 0
+}
+
+func (x *RedisRetryPolicy) GetBaseInterval() *durationpb.Duration {
+	if x != nil {
+		return x.BaseInterval
+	}
+	return nil
+}
+
+func (x *RedisRetryPolicy) GetMaxInterval() *durationpb.Duration {
+	if x != nil {
+		return x.MaxInterval
+	}
+	return nil
+}
+
 var File_filters_v1alpha1_rate_limit_proto protoreflect.FileDescriptor
 
 var file_filters_v1alpha1_rate_limit_proto_rawDesc = []byte{
@@ -406,7 +578,7 @@ var file_filters_v1alpha1_rate_limit_proto_rawDesc = []byte{
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x65, 0x78, 0x61, 0x63, 0x74, 0x12, 0x18,
 	0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
 	0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x42, 0x07, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63,
-	0x68, 0x22, 0xdf, 0x01, 0x0a, 0x0f, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x50,
+	0x68, 0x22, 0xb5, 0x02, 0x0a, 0x0f, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x50,
 	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x3a, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69,
 	0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53,
@@ -420,37 +592,71 @@ var file_filters_v1alpha1_rate_limit_proto_rawDesc = []byte{
 	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
 	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
 	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x22, 0x83, 0x02, 0x0a, 0x0f, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69,
-	0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x44, 0x0a, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63,
-	0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x6e, 0x6f, 0x77,
-	0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x31, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x50, 0x6f, 0x6c,
-	0x69, 0x63, 0x79, 0x52, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x12, 0x3c, 0x0a,
-	0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x6b,
+	0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x26, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x61, 0x74, 0x65,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x70, 0x61, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x70, 0x72, 0x65, 0x70, 0x61, 0x69, 0x64, 0x22, 0xb5, 0x03, 0x0a, 0x0f, 0x52, 0x61,
+	0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x44, 0x0a,
+	0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x28, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x08, 0x70, 0x6f, 0x6c, 0x69, 0x63,
+	0x69, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x26, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x61, 0x74,
+	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x47, 0x0a, 0x0c, 0x72, 0x65, 0x64, 0x69, 0x73, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6b,
 	0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74,
-	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78,
-	0x12, 0x47, 0x0a, 0x0c, 0x72, 0x65, 0x64, 0x69, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e,
-	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x52, 0x65, 0x64, 0x69, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x0b, 0x72, 0x65,
-	0x64, 0x69, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x1f, 0x0a, 0x0b, 0x52, 0x65, 0x64,
-	0x69, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x2a, 0x4f, 0x0a, 0x0f, 0x52, 0x61,
-	0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x61, 0x73, 0x65, 0x4f, 0x6e, 0x12, 0x22, 0x0a,
-	0x1e, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x42, 0x41, 0x53, 0x45,
-	0x5f, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
-	0x00, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x49, 0x44, 0x10, 0x01, 0x12, 0x0b,
-	0x0a, 0x07, 0x41, 0x50, 0x49, 0x5f, 0x4b, 0x45, 0x59, 0x10, 0x02, 0x2a, 0x47, 0x0a, 0x0d, 0x52,
-	0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x1c,
-	0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c,
-	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09,
-	0x0a, 0x05, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x45, 0x44,
-	0x49, 0x53, 0x10, 0x02, 0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64,
-	0x65, 0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x73, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x52, 0x0b, 0x72, 0x65, 0x64, 0x69, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12,
+	0x4a, 0x0a, 0x0b, 0x72, 0x65, 0x64, 0x69, 0x73, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x79, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52,
+	0x65, 0x64, 0x69, 0x73, 0x52, 0x65, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x0a, 0x72, 0x65, 0x64, 0x69, 0x73, 0x52, 0x65, 0x74, 0x72, 0x79, 0x12, 0x47, 0x0a, 0x12, 0x64,
+	0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x74, 0x74,
+	0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x10, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x54, 0x74, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x61, 0x69, 0x6c, 0x5f, 0x6f, 0x70, 0x65,
+	0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x4f, 0x70, 0x65,
+	0x6e, 0x22, 0x1f, 0x0a, 0x0b, 0x52, 0x65, 0x64, 0x69, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75,
+	0x72, 0x6c, 0x22, 0xc6, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x64, 0x69, 0x73, 0x52, 0x65, 0x74, 0x72,
+	0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x24, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72,
+	0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x0a,
+	0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x3e, 0x0a,
+	0x0d, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0c, 0x62, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x3c, 0x0a,
+	0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b,
+	0x6d, 0x61, 0x78, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x42, 0x0e, 0x0a, 0x0c, 0x5f,
+	0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x2a, 0x4f, 0x0a, 0x0f, 0x52,
+	0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x61, 0x73, 0x65, 0x4f, 0x6e, 0x12, 0x22,
+	0x0a, 0x1e, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x42, 0x41, 0x53,
+	0x45, 0x5f, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x49, 0x44, 0x10, 0x01, 0x12,
+	0x0b, 0x0a, 0x07, 0x41, 0x50, 0x49, 0x5f, 0x4b, 0x45, 0x59, 0x10, 0x02, 0x2a, 0x4a, 0x0a, 0x0d,
+	0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x1f, 0x0a,
+	0x1b, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x55, 0x4e, 0x49, 0x54,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c,
+	0x0a, 0x08, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x53, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06,
+	0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x53, 0x10, 0x02, 0x2a, 0x47, 0x0a, 0x0d, 0x52, 0x61, 0x74, 0x65,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x1c, 0x52, 0x41, 0x54,
+	0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x4c,
+	0x4f, 0x43, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x45, 0x44, 0x49, 0x53, 0x10,
+	0x02, 0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -465,29 +671,36 @@ func file_filters_v1alpha1_rate_limit_proto_rawDescGZIP() []byte {
 	return file_filters_v1alpha1_rate_limit_proto_rawDescData
 }
 
-var file_filters_v1alpha1_rate_limit_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_filters_v1alpha1_rate_limit_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_filters_v1alpha1_rate_limit_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_filters_v1alpha1_rate_limit_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_filters_v1alpha1_rate_limit_proto_goTypes = []interface{}{
 	(RateLimitBaseOn)(0),        // 0: knoway.filters.v1alpha1.RateLimitBaseOn
-	(RateLimitMode)(0),          // 1: knoway.filters.v1alpha1.RateLimitMode
-	(*StringMatch)(nil),         // 2: knoway.filters.v1alpha1.StringMatch
-	(*RateLimitPolicy)(nil),     // 3: knoway.filters.v1alpha1.RateLimitPolicy
-	(*RateLimitConfig)(nil),     // 4: knoway.filters.v1alpha1.RateLimitConfig
-	(*RedisServer)(nil),         // 5: knoway.filters.v1alpha1.RedisServer
-	(*durationpb.Duration)(nil), // 6: google.protobuf.Duration
+	(RateLimitUnit)(0),          // 1: knoway.filters.v1alpha1.RateLimitUnit
+	(RateLimitMode)(0),          // 2: knoway.filters.v1alpha1.RateLimitMode
+	(*StringMatch)(nil),         // 3: knoway.filters.v1alpha1.StringMatch
+	(*RateLimitPolicy)(nil),     // 4: knoway.filters.v1alpha1.RateLimitPolicy
+	(*RateLimitConfig)(nil),     // 5: knoway.filters.v1alpha1.RateLimitConfig
+	(*RedisServer)(nil),         // 6: knoway.filters.v1alpha1.RedisServer
+	(*RedisRetryPolicy)(nil),    // 7: knoway.filters.v1alpha1.RedisRetryPolicy
+	(*durationpb.Duration)(nil), // 8: google.protobuf.Duration
 }
 var file_filters_v1alpha1_rate_limit_proto_depIdxs = []int32{
-	2, // 0: knoway.filters.v1alpha1.RateLimitPolicy.match:type_name -> knoway.filters.v1alpha1.StringMatch
-	0, // 1: knoway.filters.v1alpha1.RateLimitPolicy.based_on:type_name -> knoway.filters.v1alpha1.RateLimitBaseOn
-	6, // 2: knoway.filters.v1alpha1.RateLimitPolicy.duration:type_name -> google.protobuf.Duration
-	3, // 3: knoway.filters.v1alpha1.RateLimitConfig.policies:type_name -> knoway.filters.v1alpha1.RateLimitPolicy
-	1, // 4: knoway.filters.v1alpha1.RateLimitConfig.model:type_name -> knoway.filters.v1alpha1.RateLimitMode
-	5, // 5: knoway.filters.v1alpha1.RateLimitConfig.redis_server:type_name -> knoway.filters.v1alpha1.RedisServer
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	3,  // 0: knoway.filters.v1alpha1.RateLimitPolicy.match:type_name -> knoway.filters.v1alpha1.StringMatch
+	0,  // 1: knoway.filters.v1alpha1.RateLimitPolicy.based_on:type_name -> knoway.filters.v1alpha1.RateLimitBaseOn
+	8,  // 2: knoway.filters.v1alpha1.RateLimitPolicy.duration:type_name -> google.protobuf.Duration
+	1,  // 3: knoway.filters.v1alpha1.RateLimitPolicy.unit:type_name -> knoway.filters.v1alpha1.RateLimitUnit
+	4,  // 4: knoway.filters.v1alpha1.RateLimitConfig.policies:type_name -> knoway.filters.v1alpha1.RateLimitPolicy
+	2,  // 5: knoway.filters.v1alpha1.RateLimitConfig.model:type_name -> knoway.filters.v1alpha1.RateLimitMode
+	6,  // 6: knoway.filters.v1alpha1.RateLimitConfig.redis_server:type_name -> knoway.filters.v1alpha1.RedisServer
+	7,  // 7: knoway.filters.v1alpha1.RateLimitConfig.redis_retry:type_name -> knoway.filters.v1alpha1.RedisRetryPolicy
+	8,  // 8: knoway.filters.v1alpha1.RateLimitConfig.decision_cache_ttl:type_name -> google.protobuf.Duration
+	8,  // 9: knoway.filters.v1alpha1.RedisRetryPolicy.base_interval:type_name -> google.protobuf.Duration
+	8,  // 10: knoway.filters.v1alpha1.RedisRetryPolicy.max_interval:type_name -> google.protobuf.Duration
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_filters_v1alpha1_rate_limit_proto_init() }
@@ -544,18 +757,31 @@ func file_filters_v1alpha1_rate_limit_proto_init() {
 				return nil
 			}
 		}
+		file_filters_v1alpha1_rate_limit_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RedisRetryPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_filters_v1alpha1_rate_limit_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*StringMatch_Exact)(nil),
 		(*StringMatch_Prefix)(nil),
 	}
+	file_filters_v1alpha1_rate_limit_proto_msgTypes[4].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_filters_v1alpha1_rate_limit_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   4,
+			NumEnums:      3,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,412 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: filters/v1alpha1/response_cache.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ResponseCacheMode int32
+
+const (
+	ResponseCacheMode_RESPONSE_CACHE_MODE_UNSPECIFIED ResponseCacheMode = 0
+	// EXACT serves requests with the same normalized body from the cache
+	ResponseCacheMode_EXACT ResponseCacheMode = 1
+	// SEMANTIC serves requests whose messages are similar enough to a cached
+	// one, measured by the cosine similarity of their embeddings
+	ResponseCacheMode_SEMANTIC ResponseCacheMode = 2
+)
+
+// Enum value maps for ResponseCacheMode.
+var (
+	ResponseCacheMode_name = map[int32]string{
+		0: "RESPONSE_CACHE_MODE_UNSPECIFIED",
+		1: "EXACT",
+		2: "SEMANTIC",
+	}
+	ResponseCacheMode_value = map[string]int32{
+		"RESPONSE_CACHE_MODE_UNSPECIFIED": 0,
+		"EXACT":                           1,
+		"SEMANTIC":                        2,
+	}
+)
+
+func (x ResponseCacheMode) Enum() *ResponseCacheMode {
+	p := new(ResponseCacheMode)
+	*p = x
+	return p
+}
+
+func (x ResponseCacheMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ResponseCacheMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_filters_v1alpha1_response_cache_proto_enumTypes[0].Descriptor()
+}
+
+func (ResponseCacheMode) Type() protoreflect.EnumType {
+	return &file_filters_v1alpha1_response_cache_proto_enumTypes[0]
+}
+
+func (x ResponseCacheMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ResponseCacheMode.Descriptor instead.
+func (ResponseCacheMode) EnumDescriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_response_cache_proto_rawDescGZIP(), []int{0}
+}
+
+// ResponseCacheEmbedding is an OpenAI compatible embeddings endpoint used to
+// embed the messages of requests in SEMANTIC mode.
+type ResponseCacheEmbedding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// url of the endpoint, e.g. http://bge-m3:8000/v1/embeddings
+	Url     string               `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Model   string               `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Headers map[string]string    `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Timeout *durationpb.Duration `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *ResponseCacheEmbedding) Reset() {
+	*x = ResponseCacheEmbedding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_response_cache_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseCacheEmbedding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseCacheEmbedding) ProtoMessage() {}
+
+func (x *ResponseCacheEmbedding) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_response_cache_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseCacheEmbedding.ProtoReflect.Descriptor instead.
+func (*ResponseCacheEmbedding) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_response_cache_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ResponseCacheEmbedding) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ResponseCacheEmbedding) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ResponseCacheEmbedding) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *ResponseCacheEmbedding) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+// ResponseCacheConfig caches non-streaming chat completion responses.
+type ResponseCacheConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode ResponseCacheMode    `protobuf:"varint,1,opt,name=mode,proto3,enum=knoway.filters.v1alpha1.ResponseCacheMode" json:"mode,omitempty"`
+	Ttl  *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// max_entries bounds the number of responses cached in memory
+	MaxEntries int32 `protobuf:"varint,3,opt,name=max_entries,json=maxEntries,proto3" json:"max_entries,omitempty"`
+	// similarity_threshold is the minimum cosine similarity for a cache hit
+	// in SEMANTIC mode
+	SimilarityThreshold float32                 `protobuf:"fixed32,4,opt,name=similarity_threshold,json=similarityThreshold,proto3" json:"similarity_threshold,omitempty"`
+	Embedding           *ResponseCacheEmbedding `protobuf:"bytes,5,opt,name=embedding,proto3" json:"embedding,omitempty"`
+	// redis_server shares the cache of EXACT mode across replicas
+	RedisServer  *RedisServer `protobuf:"bytes,6,opt,name=redis_server,json=redisServer,proto3" json:"redis_server,omitempty"`
+	ServerPrefix string       `protobuf:"bytes,7,opt,name=server_prefix,json=serverPrefix,proto3" json:"server_prefix,omitempty"`
+	// per_user keys the cache by the user of requests too, so that cached
+	// responses are never shared across users
+	PerUser bool `protobuf:"varint,8,opt,name=per_user,json=perUser,proto3" json:"per_user,omitempty"`
+}
+
+func (x *ResponseCacheConfig) Reset() {
+	*x = ResponseCacheConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_response_cache_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseCacheConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseCacheConfig) ProtoMessage() {}
+
+func (x *ResponseCacheConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_response_cache_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseCacheConfig.ProtoReflect.Descriptor instead.
+func (*ResponseCacheConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_response_cache_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ResponseCacheConfig) GetMode() ResponseCacheMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ResponseCacheMode_RESPONSE_CACHE_MODE_UNSPECIFIED
+}
+
+func (x *ResponseCacheConfig) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *ResponseCacheConfig) GetMaxEntries() int32 {
+	if x != nil {
+		return x.MaxEntries
+	}
+	return 0
+}
+
+func (x *ResponseCacheConfig) GetSimilarityThreshold() float32 {
+	if x != nil {
+		return x.SimilarityThreshold
+	}
+	return 0
+}
+
+func (x *ResponseCacheConfig) GetEmbedding() *ResponseCacheEmbedding {
+	if x != nil {
+		return x.Embedding
+	}
+	return nil
+}
+
+func (x *ResponseCacheConfig) GetRedisServer() *RedisServer {
+	if x != nil {
+		return x.RedisServer
+	}
+	return nil
+}
+
+func (x *ResponseCacheConfig) GetServerPrefix() string {
+	if x != nil {
+		return x.ServerPrefix
+	}
+	return ""
+}
+
+func (x *ResponseCacheConfig) GetPerUser() bool {
+	if x != nil {
+		return x.PerUser
+	}
+	return false
+}
+
+var File_filters_v1alpha1_response_cache_proto protoreflect.FileDescriptor
+
+var file_filters_v1alpha1_response_cache_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x63, 0x61, 0x63, 0x68,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x21, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x89, 0x02, 0x0a, 0x16, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c,
+	0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x56, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79,
+	0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x45,
+	0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x33,
+	0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0xae, 0x03, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3e, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x2b, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x03, 0x74, 0x74, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x65, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x45, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x14, 0x73, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72,
+	0x69, 0x74, 0x79, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x02, 0x52, 0x13, 0x73, 0x69, 0x6d, 0x69, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x54,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x4d, 0x0a, 0x09, 0x65, 0x6d, 0x62, 0x65,
+	0x64, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x61,
+	0x63, 0x68, 0x65, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x09, 0x65, 0x6d,
+	0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x47, 0x0a, 0x0c, 0x72, 0x65, 0x64, 0x69, 0x73,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x73, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x52, 0x0b, 0x72, 0x65, 0x64, 0x69, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x65, 0x72, 0x5f, 0x75, 0x73, 0x65,
+	0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72,
+	0x2a, 0x51, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x1f, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53,
+	0x45, 0x5f, 0x43, 0x41, 0x43, 0x48, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x58,
+	0x41, 0x43, 0x54, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x45, 0x4d, 0x41, 0x4e, 0x54, 0x49,
+	0x43, 0x10, 0x02, 0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65,
+	0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filters_v1alpha1_response_cache_proto_rawDescOnce sync.Once
+	file_filters_v1alpha1_response_cache_proto_rawDescData = file_filters_v1alpha1_response_cache_proto_rawDesc
+)
+
+func file_filters_v1alpha1_response_cache_proto_rawDescGZIP() []byte {
+	file_filters_v1alpha1_response_cache_proto_rawDescOnce.Do(func() {
+		file_filters_v1alpha1_response_cache_proto_rawDescData = protoimpl.X.CompressGZIP(file_filters_v1alpha1_response_cache_proto_rawDescData)
+	})
+	return file_filters_v1alpha1_response_cache_proto_rawDescData
+}
+
+var file_filters_v1alpha1_response_cache_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_filters_v1alpha1_response_cache_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_filters_v1alpha1_response_cache_proto_goTypes = []interface{}{
+	(ResponseCacheMode)(0),         // 0: knoway.filters.v1alpha1.ResponseCacheMode
+	(*ResponseCacheEmbedding)(nil), // 1: knoway.filters.v1alpha1.ResponseCacheEmbedding
+	(*ResponseCacheConfig)(nil),    // 2: knoway.filters.v1alpha1.ResponseCacheConfig
+	nil,                            // 3: knoway.filters.v1alpha1.ResponseCacheEmbedding.HeadersEntry
+	(*durationpb.Duration)(nil),    // 4: google.protobuf.Duration
+	(*RedisServer)(nil),            // 5: knoway.filters.v1alpha1.RedisServer
+}
+var file_filters_v1alpha1_response_cache_proto_depIdxs = []int32{
+	3, // 0: knoway.filters.v1alpha1.ResponseCacheEmbedding.headers:type_name -> knoway.filters.v1alpha1.ResponseCacheEmbedding.HeadersEntry
+	4, // 1: knoway.filters.v1alpha1.ResponseCacheEmbedding.timeout:type_name -> google.protobuf.Duration
+	0, // 2: knoway.filters.v1alpha1.ResponseCacheConfig.mode:type_name -> knoway.filters.v1alpha1.ResponseCacheMode
+	4, // 3: knoway.filters.v1alpha1.ResponseCacheConfig.ttl:type_name -> google.protobuf.Duration
+	1, // 4: knoway.filters.v1alpha1.ResponseCacheConfig.embedding:type_name -> knoway.filters.v1alpha1.ResponseCacheEmbedding
+	5, // 5: knoway.filters.v1alpha1.ResponseCacheConfig.redis_server:type_name -> knoway.filters.v1alpha1.RedisServer
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_filters_v1alpha1_response_cache_proto_init() }
+func file_filters_v1alpha1_response_cache_proto_init() {
+	if File_filters_v1alpha1_response_cache_proto != nil {
+		return
+	}
+	file_filters_v1alpha1_rate_limit_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_filters_v1alpha1_response_cache_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseCacheEmbedding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_response_cache_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseCacheConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filters_v1alpha1_response_cache_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_filters_v1alpha1_response_cache_proto_goTypes,
+		DependencyIndexes: file_filters_v1alpha1_response_cache_proto_depIdxs,
+		EnumInfos:         file_filters_v1alpha1_response_cache_proto_enumTypes,
+		MessageInfos:      file_filters_v1alpha1_response_cache_proto_msgTypes,
+	}.Build()
+	File_filters_v1alpha1_response_cache_proto = out.File
+	file_filters_v1alpha1_response_cache_proto_rawDesc = nil
+	file_filters_v1alpha1_response_cache_proto_goTypes = nil
+	file_filters_v1alpha1_response_cache_proto_depIdxs = nil
+}
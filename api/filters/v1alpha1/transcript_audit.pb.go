@@ -0,0 +1,305 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: filters/v1alpha1/transcript_audit.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	v1alpha1 "knoway.dev/api/listeners/v1alpha1"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TranscriptAuditConfig records the transcripts of chat completions, the
+// prompts and the responses, into audit sinks. The chunks of streams are teed
+// as they are sent to the clients, and recorded together once the streams
+// end, so that clients are never slowed down by the sinks. It works both as
+// a listener filter and a route filter.
+type TranscriptAuditConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// sinks the transcripts are written to, every transcript is a JSON
+	// object, they are written to the logs of the gateway when empty.
+	Sinks []*v1alpha1.LogSink `protobuf:"bytes,1,rep,name=sinks,proto3" json:"sinks,omitempty"`
+	// sampling_rate is the fraction of the requests recorded, between 0 and
+	// 1, default is 1.
+	SamplingRate *float64 `protobuf:"fixed64,2,opt,name=sampling_rate,json=samplingRate,proto3,oneof" json:"sampling_rate,omitempty"`
+	// api_key_sampling_rates override sampling_rate for the requests of the
+	// API keys by their api_key_id, e.g. 0 to never record the requests of
+	// an API key.
+	ApiKeySamplingRates map[string]float64 `protobuf:"bytes,3,rep,name=api_key_sampling_rates,json=apiKeySamplingRates,proto3" json:"api_key_sampling_rates,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// redactions are applied in order to every text of the prompts and the
+	// responses before they are recorded.
+	Redactions []*TranscriptAuditConfig_Redaction `protobuf:"bytes,4,rep,name=redactions,proto3" json:"redactions,omitempty"`
+	// redactors are the names of the redaction hooks registered in the
+	// gateway, applied after redactions.
+	Redactors []string `protobuf:"bytes,5,rep,name=redactors,proto3" json:"redactors,omitempty"`
+}
+
+func (x *TranscriptAuditConfig) Reset() {
+	*x = TranscriptAuditConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_transcript_audit_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TranscriptAuditConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscriptAuditConfig) ProtoMessage() {}
+
+func (x *TranscriptAuditConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_transcript_audit_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscriptAuditConfig.ProtoReflect.Descriptor instead.
+func (*TranscriptAuditConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_transcript_audit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TranscriptAuditConfig) GetSinks() []*v1alpha1.LogSink {
+	if x != nil {
+		return x.Sinks
+	}
+	return nil
+}
+
+func (x *TranscriptAuditConfig) GetSamplingRate() float64 {
+	if x != nil && x.SamplingRate != nil {
+		return *x.SamplingRate
+	}
+	return 0
+}
+
+func (x *TranscriptAuditConfig) GetApiKeySamplingRates() map[string]float64 {
+	if x != nil {
+		return x.ApiKeySamplingRates
+	}
+	return nil
+}
+
+func (x *TranscriptAuditConfig) GetRedactions() []*TranscriptAuditConfig_Redaction {
+	if x != nil {
+		return x.Redactions
+	}
+	return nil
+}
+
+func (x *TranscriptAuditConfig) GetRedactors() []string {
+	if x != nil {
+		return x.Redactors
+	}
+	return nil
+}
+
+type TranscriptAuditConfig_Redaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// pattern is a RE2 regular expression matching the text redacted.
+	Pattern string `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// replacement of the matches, default is [REDACTED], $1 and so on
+	// are expanded to the groups of the pattern.
+	Replacement string `protobuf:"bytes,2,opt,name=replacement,proto3" json:"replacement,omitempty"`
+}
+
+func (x *TranscriptAuditConfig_Redaction) Reset() {
+	*x = TranscriptAuditConfig_Redaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_transcript_audit_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TranscriptAuditConfig_Redaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscriptAuditConfig_Redaction) ProtoMessage() {}
+
+func (x *TranscriptAuditConfig_Redaction) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_transcript_audit_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscriptAuditConfig_Redaction.ProtoReflect.Descriptor instead.
+func (*TranscriptAuditConfig_Redaction) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_transcript_audit_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *TranscriptAuditConfig_Redaction) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *TranscriptAuditConfig_Redaction) GetReplacement() string {
+	if x != nil {
+		return x.Replacement
+	}
+	return ""
+}
+
+var File_filters_v1alpha1_transcript_audit_proto protoreflect.FileDescriptor
+
+var file_filters_v1alpha1_transcript_audit_proto_rawDesc = []byte{
+	0x0a, 0x27, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x5f, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6b, 0x6e, 0x6f, 0x77, 0x61,
+	0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x1a, 0x1f, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x94, 0x04, 0x0a, 0x15, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x38, 0x0a,
+	0x05, 0x73, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b,
+	0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x53, 0x69, 0x6e, 0x6b,
+	0x52, 0x05, 0x73, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x28, 0x0a, 0x0d, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x69, 0x6e, 0x67, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00,
+	0x52, 0x0c, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x61, 0x74, 0x65, 0x88, 0x01,
+	0x01, 0x12, 0x7c, 0x0a, 0x16, 0x61, 0x70, 0x69, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x61, 0x6d,
+	0x70, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x47, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67,
+	0x52, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13, 0x61, 0x70, 0x69, 0x4b,
+	0x65, 0x79, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x61, 0x74, 0x65, 0x73, 0x12,
+	0x58, 0x0a, 0x0a, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72,
+	0x65, 0x64, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x64,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65,
+	0x64, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x1a, 0x46, 0x0a, 0x18, 0x41, 0x70, 0x69, 0x4b, 0x65,
+	0x79, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x47, 0x0a, 0x09, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x70,
+	0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x73, 0x61, 0x6d,
+	0x70, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e,
+	0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filters_v1alpha1_transcript_audit_proto_rawDescOnce sync.Once
+	file_filters_v1alpha1_transcript_audit_proto_rawDescData = file_filters_v1alpha1_transcript_audit_proto_rawDesc
+)
+
+func file_filters_v1alpha1_transcript_audit_proto_rawDescGZIP() []byte {
+	file_filters_v1alpha1_transcript_audit_proto_rawDescOnce.Do(func() {
+		file_filters_v1alpha1_transcript_audit_proto_rawDescData = protoimpl.X.CompressGZIP(file_filters_v1alpha1_transcript_audit_proto_rawDescData)
+	})
+	return file_filters_v1alpha1_transcript_audit_proto_rawDescData
+}
+
+var file_filters_v1alpha1_transcript_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_filters_v1alpha1_transcript_audit_proto_goTypes = []interface{}{
+	(*TranscriptAuditConfig)(nil),           // 0: knoway.filters.v1alpha1.TranscriptAuditConfig
+	nil,                                     // 1: knoway.filters.v1alpha1.TranscriptAuditConfig.ApiKeySamplingRatesEntry
+	(*TranscriptAuditConfig_Redaction)(nil), // 2: knoway.filters.v1alpha1.TranscriptAuditConfig.Redaction
+	(*v1alpha1.LogSink)(nil),                // 3: knoway.listeners.v1alpha1.LogSink
+}
+var file_filters_v1alpha1_transcript_audit_proto_depIdxs = []int32{
+	3, // 0: knoway.filters.v1alpha1.TranscriptAuditConfig.sinks:type_name -> knoway.listeners.v1alpha1.LogSink
+	1, // 1: knoway.filters.v1alpha1.TranscriptAuditConfig.api_key_sampling_rates:type_name -> knoway.filters.v1alpha1.TranscriptAuditConfig.ApiKeySamplingRatesEntry
+	2, // 2: knoway.filters.v1alpha1.TranscriptAuditConfig.redactions:type_name -> knoway.filters.v1alpha1.TranscriptAuditConfig.Redaction
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_filters_v1alpha1_transcript_audit_proto_init() }
+func file_filters_v1alpha1_transcript_audit_proto_init() {
+	if File_filters_v1alpha1_transcript_audit_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filters_v1alpha1_transcript_audit_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TranscriptAuditConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_transcript_audit_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TranscriptAuditConfig_Redaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_filters_v1alpha1_transcript_audit_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filters_v1alpha1_transcript_audit_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_filters_v1alpha1_transcript_audit_proto_goTypes,
+		DependencyIndexes: file_filters_v1alpha1_transcript_audit_proto_depIdxs,
+		MessageInfos:      file_filters_v1alpha1_transcript_audit_proto_msgTypes,
+	}.Build()
+	File_filters_v1alpha1_transcript_audit_proto = out.File
+	file_filters_v1alpha1_transcript_audit_proto_rawDesc = nil
+	file_filters_v1alpha1_transcript_audit_proto_goTypes = nil
+	file_filters_v1alpha1_transcript_audit_proto_depIdxs = nil
+}
@@ -0,0 +1,282 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: filters/v1alpha1/upstream_api_keys.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// UpstreamAPIKeysConfig rotates the requests of the cluster between multiple
+// API keys of the upstream. Keys answered with 401, 403 or 429 for
+// failure_threshold consecutive requests are disabled for the cooldown, or
+// until the time of the Retry-After header of 429 responses if later. The
+// key recovering the earliest is used when all of them are disabled.
+type UpstreamAPIKeysConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys []*UpstreamAPIKeysConfig_Key `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	// Header the key is placed into, default is Authorization
+	Header string `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	// Prefix prepended to the key, default is "Bearer " for the
+	// Authorization header and none for the others
+	Prefix *string `protobuf:"bytes,3,opt,name=prefix,proto3,oneof" json:"prefix,omitempty"`
+	// Cooldown of disabled keys, default is 60s
+	Cooldown *durationpb.Duration `protobuf:"bytes,4,opt,name=cooldown,proto3" json:"cooldown,omitempty"`
+	// FailureThreshold is the number of consecutive 401, 403 or 429
+	// responses for a key to be disabled, default is 1
+	FailureThreshold uint32 `protobuf:"varint,5,opt,name=failure_threshold,json=failureThreshold,proto3" json:"failure_threshold,omitempty"`
+}
+
+func (x *UpstreamAPIKeysConfig) Reset() {
+	*x = UpstreamAPIKeysConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_upstream_api_keys_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpstreamAPIKeysConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpstreamAPIKeysConfig) ProtoMessage() {}
+
+func (x *UpstreamAPIKeysConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_upstream_api_keys_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpstreamAPIKeysConfig.ProtoReflect.Descriptor instead.
+func (*UpstreamAPIKeysConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_upstream_api_keys_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UpstreamAPIKeysConfig) GetKeys() []*UpstreamAPIKeysConfig_Key {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *UpstreamAPIKeysConfig) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *UpstreamAPIKeysConfig) GetPrefix() string {
+	if x != nil && x.Prefix != nil {
+		return *x.Prefix
+	}
+	return ""
+}
+
+func (x *UpstreamAPIKeysConfig) GetCooldown() *durationpb.Duration {
+	if x != nil {
+		return x.Cooldown
+	}
+	return nil
+}
+
+func (x *UpstreamAPIKeysConfig) GetFailureThreshold() uint32 {
+	if x != nil {
+		return x.FailureThreshold
+	}
+	return 0
+}
+
+type UpstreamAPIKeysConfig_Key struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name identifies the key in metrics and logs, never the value
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *UpstreamAPIKeysConfig_Key) Reset() {
+	*x = UpstreamAPIKeysConfig_Key{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_upstream_api_keys_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpstreamAPIKeysConfig_Key) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpstreamAPIKeysConfig_Key) ProtoMessage() {}
+
+func (x *UpstreamAPIKeysConfig_Key) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_upstream_api_keys_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpstreamAPIKeysConfig_Key.ProtoReflect.Descriptor instead.
+func (*UpstreamAPIKeysConfig_Key) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_upstream_api_keys_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *UpstreamAPIKeysConfig_Key) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpstreamAPIKeysConfig_Key) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_filters_v1alpha1_upstream_api_keys_proto protoreflect.FileDescriptor
+
+var file_filters_v1alpha1_upstream_api_keys_proto_rawDesc = []byte{
+	0x0a, 0x28, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x75, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x61, 0x70, 0x69, 0x5f,
+	0x6b, 0x65, 0x79, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6b, 0x6e, 0x6f, 0x77,
+	0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0xb4, 0x02, 0x0a, 0x15, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x46, 0x0a,
+	0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x50,
+	0x49, 0x4b, 0x65, 0x79, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4b, 0x65, 0x79, 0x52,
+	0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x0a,
+	0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x08, 0x63, 0x6f,
+	0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x63, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77,
+	0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x74, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x66, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x1a, 0x2f,
+	0x0a, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42,
+	0x09, 0x0a, 0x07, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e,
+	0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filters_v1alpha1_upstream_api_keys_proto_rawDescOnce sync.Once
+	file_filters_v1alpha1_upstream_api_keys_proto_rawDescData = file_filters_v1alpha1_upstream_api_keys_proto_rawDesc
+)
+
+func file_filters_v1alpha1_upstream_api_keys_proto_rawDescGZIP() []byte {
+	file_filters_v1alpha1_upstream_api_keys_proto_rawDescOnce.Do(func() {
+		file_filters_v1alpha1_upstream_api_keys_proto_rawDescData = protoimpl.X.CompressGZIP(file_filters_v1alpha1_upstream_api_keys_proto_rawDescData)
+	})
+	return file_filters_v1alpha1_upstream_api_keys_proto_rawDescData
+}
+
+var file_filters_v1alpha1_upstream_api_keys_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_filters_v1alpha1_upstream_api_keys_proto_goTypes = []interface{}{
+	(*UpstreamAPIKeysConfig)(nil),     // 0: knoway.filters.v1alpha1.UpstreamAPIKeysConfig
+	(*UpstreamAPIKeysConfig_Key)(nil), // 1: knoway.filters.v1alpha1.UpstreamAPIKeysConfig.Key
+	(*durationpb.Duration)(nil),       // 2: google.protobuf.Duration
+}
+var file_filters_v1alpha1_upstream_api_keys_proto_depIdxs = []int32{
+	1, // 0: knoway.filters.v1alpha1.UpstreamAPIKeysConfig.keys:type_name -> knoway.filters.v1alpha1.UpstreamAPIKeysConfig.Key
+	2, // 1: knoway.filters.v1alpha1.UpstreamAPIKeysConfig.cooldown:type_name -> google.protobuf.Duration
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_filters_v1alpha1_upstream_api_keys_proto_init() }
+func file_filters_v1alpha1_upstream_api_keys_proto_init() {
+	if File_filters_v1alpha1_upstream_api_keys_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filters_v1alpha1_upstream_api_keys_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpstreamAPIKeysConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_upstream_api_keys_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpstreamAPIKeysConfig_Key); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_filters_v1alpha1_upstream_api_keys_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filters_v1alpha1_upstream_api_keys_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_filters_v1alpha1_upstream_api_keys_proto_goTypes,
+		DependencyIndexes: file_filters_v1alpha1_upstream_api_keys_proto_depIdxs,
+		MessageInfos:      file_filters_v1alpha1_upstream_api_keys_proto_msgTypes,
+	}.Build()
+	File_filters_v1alpha1_upstream_api_keys_proto = out.File
+	file_filters_v1alpha1_upstream_api_keys_proto_rawDesc = nil
+	file_filters_v1alpha1_upstream_api_keys_proto_goTypes = nil
+	file_filters_v1alpha1_upstream_api_keys_proto_depIdxs = nil
+}
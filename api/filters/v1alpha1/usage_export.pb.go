@@ -0,0 +1,717 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: filters/v1alpha1/usage_export.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// UsageExportConfig exports the usage of every request to external billing
+// systems in batches. Batches failed to export are spooled to the local disk
+// and retried, so that usage is delivered at least once, consumers should
+// deduplicate the records by their ids.
+type UsageExportConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Destination:
+	//
+	//	*UsageExportConfig_Grpc
+	//	*UsageExportConfig_Http
+	//	*UsageExportConfig_Kafka_
+	Destination isUsageExportConfig_Destination `protobuf_oneof:"destination"`
+	Batch       *UsageExportConfig_Batch        `protobuf:"bytes,4,opt,name=batch,proto3" json:"batch,omitempty"`
+	Spool       *UsageExportConfig_Spool        `protobuf:"bytes,5,opt,name=spool,proto3" json:"spool,omitempty"`
+	// billing_model decides which model name is exported as
+	// billing_model_name, default is BILLING_MODEL_SERVED.
+	BillingModel UsageStatsConfig_BillingModel `protobuf:"varint,6,opt,name=billing_model,json=billingModel,proto3,enum=knoway.filters.v1alpha1.UsageStatsConfig_BillingModel" json:"billing_model,omitempty"`
+}
+
+func (x *UsageExportConfig) Reset() {
+	*x = UsageExportConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_usage_export_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UsageExportConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageExportConfig) ProtoMessage() {}
+
+func (x *UsageExportConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_usage_export_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageExportConfig.ProtoReflect.Descriptor instead.
+func (*UsageExportConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_usage_export_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *UsageExportConfig) GetDestination() isUsageExportConfig_Destination {
+	if m != nil {
+		return m.Destination
+	}
+	return nil
+}
+
+func (x *UsageExportConfig) GetGrpc() *UsageExportConfig_GRPC {
+	if x, ok := x.GetDestination().(*UsageExportConfig_Grpc); ok {
+		return x.Grpc
+	}
+	return nil
+}
+
+func (x *UsageExportConfig) GetHttp() *UsageExportConfig_HTTP {
+	if x, ok := x.GetDestination().(*UsageExportConfig_Http); ok {
+		return x.Http
+	}
+	return nil
+}
+
+func (x *UsageExportConfig) GetKafka() *UsageExportConfig_Kafka {
+	if x, ok := x.GetDestination().(*UsageExportConfig_Kafka_); ok {
+		return x.Kafka
+	}
+	return nil
+}
+
+func (x *UsageExportConfig) GetBatch() *UsageExportConfig_Batch {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+func (x *UsageExportConfig) GetSpool() *UsageExportConfig_Spool {
+	if x != nil {
+		return x.Spool
+	}
+	return nil
+}
+
+func (x *UsageExportConfig) GetBillingModel() UsageStatsConfig_BillingModel {
+	if x != nil {
+		return x.BillingModel
+	}
+	return UsageStatsConfig_BILLING_MODEL_UNSPECIFIED
+}
+
+type isUsageExportConfig_Destination interface {
+	isUsageExportConfig_Destination()
+}
+
+type UsageExportConfig_Grpc struct {
+	Grpc *UsageExportConfig_GRPC `protobuf:"bytes,1,opt,name=grpc,proto3,oneof"`
+}
+
+type UsageExportConfig_Http struct {
+	Http *UsageExportConfig_HTTP `protobuf:"bytes,2,opt,name=http,proto3,oneof"`
+}
+
+type UsageExportConfig_Kafka_ struct {
+	Kafka *UsageExportConfig_Kafka `protobuf:"bytes,3,opt,name=kafka,proto3,oneof"`
+}
+
+func (*UsageExportConfig_Grpc) isUsageExportConfig_Destination() {}
+
+func (*UsageExportConfig_Http) isUsageExportConfig_Destination() {}
+
+func (*UsageExportConfig_Kafka_) isUsageExportConfig_Destination() {}
+
+// GRPC exports to a UsageStatsService by ExportUsage.
+type UsageExportConfig_GRPC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url     string               `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Timeout *durationpb.Duration `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"` // Default is 10s
+}
+
+func (x *UsageExportConfig_GRPC) Reset() {
+	*x = UsageExportConfig_GRPC{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_usage_export_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UsageExportConfig_GRPC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageExportConfig_GRPC) ProtoMessage() {}
+
+func (x *UsageExportConfig_GRPC) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_usage_export_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageExportConfig_GRPC.ProtoReflect.Descriptor instead.
+func (*UsageExportConfig_GRPC) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_usage_export_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *UsageExportConfig_GRPC) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *UsageExportConfig_GRPC) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+// HTTP posts the batches to the url as JSON arrays of records.
+type UsageExportConfig_HTTP struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url     string            `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Headers map[string]string `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *UsageExportConfig_HTTP) Reset() {
+	*x = UsageExportConfig_HTTP{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_usage_export_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UsageExportConfig_HTTP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageExportConfig_HTTP) ProtoMessage() {}
+
+func (x *UsageExportConfig_HTTP) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_usage_export_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageExportConfig_HTTP.ProtoReflect.Descriptor instead.
+func (*UsageExportConfig_HTTP) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_usage_export_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *UsageExportConfig_HTTP) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *UsageExportConfig_HTTP) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+// Kafka produces the records to the topic through the Kafka REST Proxy
+// (v2 API) at rest_proxy_url.
+type UsageExportConfig_Kafka struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RestProxyUrl string            `protobuf:"bytes,1,opt,name=rest_proxy_url,json=restProxyUrl,proto3" json:"rest_proxy_url,omitempty"`
+	Topic        string            `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Headers      map[string]string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *UsageExportConfig_Kafka) Reset() {
+	*x = UsageExportConfig_Kafka{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_usage_export_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UsageExportConfig_Kafka) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageExportConfig_Kafka) ProtoMessage() {}
+
+func (x *UsageExportConfig_Kafka) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_usage_export_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageExportConfig_Kafka.ProtoReflect.Descriptor instead.
+func (*UsageExportConfig_Kafka) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_usage_export_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *UsageExportConfig_Kafka) GetRestProxyUrl() string {
+	if x != nil {
+		return x.RestProxyUrl
+	}
+	return ""
+}
+
+func (x *UsageExportConfig_Kafka) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *UsageExportConfig_Kafka) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+type UsageExportConfig_Batch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// max_size is the number of records sent at most in one batch,
+	// default is 100.
+	MaxSize int32 `protobuf:"varint,1,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	// interval is how often the records buffered are sent, default is 5s.
+	Interval *durationpb.Duration `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	// buffer_size is the number of records buffered at most, records
+	// exceeding it are spooled directly, default is 10000.
+	BufferSize int32 `protobuf:"varint,3,opt,name=buffer_size,json=bufferSize,proto3" json:"buffer_size,omitempty"`
+	// timeout of sending a batch, default is 10s.
+	Timeout *durationpb.Duration `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *UsageExportConfig_Batch) Reset() {
+	*x = UsageExportConfig_Batch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_usage_export_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UsageExportConfig_Batch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageExportConfig_Batch) ProtoMessage() {}
+
+func (x *UsageExportConfig_Batch) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_usage_export_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageExportConfig_Batch.ProtoReflect.Descriptor instead.
+func (*UsageExportConfig_Batch) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_usage_export_proto_rawDescGZIP(), []int{0, 3}
+}
+
+func (x *UsageExportConfig_Batch) GetMaxSize() int32 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *UsageExportConfig_Batch) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *UsageExportConfig_Batch) GetBufferSize() int32 {
+	if x != nil {
+		return x.BufferSize
+	}
+	return 0
+}
+
+func (x *UsageExportConfig_Batch) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type UsageExportConfig_Spool struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// directory the batches failed to export are spooled in, spooling is
+	// disabled and these batches are dropped if empty.
+	Directory string `protobuf:"bytes,1,opt,name=directory,proto3" json:"directory,omitempty"`
+	// max_size_bytes bounds the size of the spool, batches are dropped
+	// once it is full, default is 1GiB.
+	MaxSizeBytes int64 `protobuf:"varint,2,opt,name=max_size_bytes,json=maxSizeBytes,proto3" json:"max_size_bytes,omitempty"`
+	// retry_interval is how often the spooled batches are retried,
+	// default is 30s.
+	RetryInterval *durationpb.Duration `protobuf:"bytes,3,opt,name=retry_interval,json=retryInterval,proto3" json:"retry_interval,omitempty"`
+}
+
+func (x *UsageExportConfig_Spool) Reset() {
+	*x = UsageExportConfig_Spool{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_usage_export_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UsageExportConfig_Spool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageExportConfig_Spool) ProtoMessage() {}
+
+func (x *UsageExportConfig_Spool) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_usage_export_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageExportConfig_Spool.ProtoReflect.Descriptor instead.
+func (*UsageExportConfig_Spool) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_usage_export_proto_rawDescGZIP(), []int{0, 4}
+}
+
+func (x *UsageExportConfig_Spool) GetDirectory() string {
+	if x != nil {
+		return x.Directory
+	}
+	return ""
+}
+
+func (x *UsageExportConfig_Spool) GetMaxSizeBytes() int64 {
+	if x != nil {
+		return x.MaxSizeBytes
+	}
+	return 0
+}
+
+func (x *UsageExportConfig_Spool) GetRetryInterval() *durationpb.Duration {
+	if x != nil {
+		return x.RetryInterval
+	}
+	return nil
+}
+
+var File_filters_v1alpha1_usage_export_proto protoreflect.FileDescriptor
+
+var file_filters_v1alpha1_usage_export_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x1e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x23,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2f, 0x61, 0x70, 0x69, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x82, 0x0a, 0x0a, 0x11, 0x55, 0x73, 0x61, 0x67, 0x65, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x45, 0x0a, 0x04, 0x67, 0x72, 0x70,
+	0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79,
+	0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x47, 0x52, 0x50, 0x43, 0x48, 0x00, 0x52, 0x04, 0x67, 0x72, 0x70, 0x63,
+	0x12, 0x45, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f,
+	0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x48,
+	0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x48, 0x0a, 0x05, 0x6b, 0x61, 0x66, 0x6b, 0x61,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x4b, 0x61, 0x66, 0x6b, 0x61, 0x48, 0x00, 0x52, 0x05, 0x6b, 0x61, 0x66, 0x6b,
+	0x61, 0x12, 0x46, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x30, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x12, 0x46, 0x0a, 0x05, 0x73, 0x70, 0x6f,
+	0x6f, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61,
+	0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x53, 0x70, 0x6f, 0x6f, 0x6c, 0x52, 0x05, 0x73, 0x70, 0x6f, 0x6f,
+	0x6c, 0x12, 0x5b, 0x0a, 0x0d, 0x62, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x36, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61,
+	0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x42, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x6c,
+	0x52, 0x0c, 0x62, 0x69, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x1a, 0x4d,
+	0x0a, 0x04, 0x47, 0x52, 0x50, 0x43, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x1a, 0xac, 0x01,
+	0x0a, 0x04, 0x48, 0x54, 0x54, 0x50, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x56, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x6b, 0x6e, 0x6f, 0x77,
+	0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x55, 0x73, 0x61, 0x67, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xd8, 0x01, 0x0a,
+	0x05, 0x4b, 0x61, 0x66, 0x6b, 0x61, 0x12, 0x24, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x70,
+	0x72, 0x6f, 0x78, 0x79, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x72, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x55, 0x72, 0x6c, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70,
+	0x69, 0x63, 0x12, 0x57, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x73,
+	0x61, 0x67, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x4b, 0x61, 0x66, 0x6b, 0x61, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xaf, 0x01, 0x0a, 0x05, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x35, 0x0a, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x1a, 0x8d, 0x01, 0x0a, 0x05, 0x53, 0x70,
+	0x6f, 0x6f, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x79, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x53, 0x69,
+	0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x40, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x72, 0x79,
+	0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x72, 0x65, 0x74, 0x72,
+	0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x42, 0x0d, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77,
+	0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filters_v1alpha1_usage_export_proto_rawDescOnce sync.Once
+	file_filters_v1alpha1_usage_export_proto_rawDescData = file_filters_v1alpha1_usage_export_proto_rawDesc
+)
+
+func file_filters_v1alpha1_usage_export_proto_rawDescGZIP() []byte {
+	file_filters_v1alpha1_usage_export_proto_rawDescOnce.Do(func() {
+		file_filters_v1alpha1_usage_export_proto_rawDescData = protoimpl.X.CompressGZIP(file_filters_v1alpha1_usage_export_proto_rawDescData)
+	})
+	return file_filters_v1alpha1_usage_export_proto_rawDescData
+}
+
+var file_filters_v1alpha1_usage_export_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_filters_v1alpha1_usage_export_proto_goTypes = []interface{}{
+	(*UsageExportConfig)(nil),          // 0: knoway.filters.v1alpha1.UsageExportConfig
+	(*UsageExportConfig_GRPC)(nil),     // 1: knoway.filters.v1alpha1.UsageExportConfig.GRPC
+	(*UsageExportConfig_HTTP)(nil),     // 2: knoway.filters.v1alpha1.UsageExportConfig.HTTP
+	(*UsageExportConfig_Kafka)(nil),    // 3: knoway.filters.v1alpha1.UsageExportConfig.Kafka
+	(*UsageExportConfig_Batch)(nil),    // 4: knoway.filters.v1alpha1.UsageExportConfig.Batch
+	(*UsageExportConfig_Spool)(nil),    // 5: knoway.filters.v1alpha1.UsageExportConfig.Spool
+	nil,                                // 6: knoway.filters.v1alpha1.UsageExportConfig.HTTP.HeadersEntry
+	nil,                                // 7: knoway.filters.v1alpha1.UsageExportConfig.Kafka.HeadersEntry
+	(UsageStatsConfig_BillingModel)(0), // 8: knoway.filters.v1alpha1.UsageStatsConfig.BillingModel
+	(*durationpb.Duration)(nil),        // 9: google.protobuf.Duration
+}
+var file_filters_v1alpha1_usage_export_proto_depIdxs = []int32{
+	1,  // 0: knoway.filters.v1alpha1.UsageExportConfig.grpc:type_name -> knoway.filters.v1alpha1.UsageExportConfig.GRPC
+	2,  // 1: knoway.filters.v1alpha1.UsageExportConfig.http:type_name -> knoway.filters.v1alpha1.UsageExportConfig.HTTP
+	3,  // 2: knoway.filters.v1alpha1.UsageExportConfig.kafka:type_name -> knoway.filters.v1alpha1.UsageExportConfig.Kafka
+	4,  // 3: knoway.filters.v1alpha1.UsageExportConfig.batch:type_name -> knoway.filters.v1alpha1.UsageExportConfig.Batch
+	5,  // 4: knoway.filters.v1alpha1.UsageExportConfig.spool:type_name -> knoway.filters.v1alpha1.UsageExportConfig.Spool
+	8,  // 5: knoway.filters.v1alpha1.UsageExportConfig.billing_model:type_name -> knoway.filters.v1alpha1.UsageStatsConfig.BillingModel
+	9,  // 6: knoway.filters.v1alpha1.UsageExportConfig.GRPC.timeout:type_name -> google.protobuf.Duration
+	6,  // 7: knoway.filters.v1alpha1.UsageExportConfig.HTTP.headers:type_name -> knoway.filters.v1alpha1.UsageExportConfig.HTTP.HeadersEntry
+	7,  // 8: knoway.filters.v1alpha1.UsageExportConfig.Kafka.headers:type_name -> knoway.filters.v1alpha1.UsageExportConfig.Kafka.HeadersEntry
+	9,  // 9: knoway.filters.v1alpha1.UsageExportConfig.Batch.interval:type_name -> google.protobuf.Duration
+	9,  // 10: knoway.filters.v1alpha1.UsageExportConfig.Batch.timeout:type_name -> google.protobuf.Duration
+	9,  // 11: knoway.filters.v1alpha1.UsageExportConfig.Spool.retry_interval:type_name -> google.protobuf.Duration
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_filters_v1alpha1_usage_export_proto_init() }
+func file_filters_v1alpha1_usage_export_proto_init() {
+	if File_filters_v1alpha1_usage_export_proto != nil {
+		return
+	}
+	file_filters_v1alpha1_api_key_auth_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_filters_v1alpha1_usage_export_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UsageExportConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_usage_export_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UsageExportConfig_GRPC); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_usage_export_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UsageExportConfig_HTTP); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_usage_export_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UsageExportConfig_Kafka); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_usage_export_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UsageExportConfig_Batch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filters_v1alpha1_usage_export_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UsageExportConfig_Spool); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_filters_v1alpha1_usage_export_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*UsageExportConfig_Grpc)(nil),
+		(*UsageExportConfig_Http)(nil),
+		(*UsageExportConfig_Kafka_)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filters_v1alpha1_usage_export_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_filters_v1alpha1_usage_export_proto_goTypes,
+		DependencyIndexes: file_filters_v1alpha1_usage_export_proto_depIdxs,
+		MessageInfos:      file_filters_v1alpha1_usage_export_proto_msgTypes,
+	}.Build()
+	File_filters_v1alpha1_usage_export_proto = out.File
+	file_filters_v1alpha1_usage_export_proto_rawDesc = nil
+	file_filters_v1alpha1_usage_export_proto_goTypes = nil
+	file_filters_v1alpha1_usage_export_proto_depIdxs = nil
+}
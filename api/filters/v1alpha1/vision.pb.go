@@ -0,0 +1,188 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: filters/v1alpha1/vision.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// VisionConfig limits the images in the messages of chat completions requests
+// before they are sent to the upstream, and estimates the input tokens of
+// them.
+type VisionConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// max_images of each request, 0 means unlimited
+	MaxImages uint32 `protobuf:"varint,1,opt,name=max_images,json=maxImages,proto3" json:"max_images,omitempty"`
+	// max_image_size_bytes of each image embedded as a data URL, after
+	// decoded, 0 means unlimited
+	MaxImageSizeBytes uint64 `protobuf:"varint,2,opt,name=max_image_size_bytes,json=maxImageSizeBytes,proto3" json:"max_image_size_bytes,omitempty"`
+	// max_dimension downscales the images embedded as data URLs with the
+	// longer sides exceeding it to it, in pixels, 0 disables downscaling
+	MaxDimension uint32 `protobuf:"varint,3,opt,name=max_dimension,json=maxDimension,proto3" json:"max_dimension,omitempty"`
+	// deny_remote_urls rejects the images of HTTP URLs, the sizes of which
+	// can't be limited by the gateway
+	DenyRemoteUrls bool `protobuf:"varint,4,opt,name=deny_remote_urls,json=denyRemoteUrls,proto3" json:"deny_remote_urls,omitempty"`
+}
+
+func (x *VisionConfig) Reset() {
+	*x = VisionConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_vision_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VisionConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VisionConfig) ProtoMessage() {}
+
+func (x *VisionConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_vision_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VisionConfig.ProtoReflect.Descriptor instead.
+func (*VisionConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_vision_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VisionConfig) GetMaxImages() uint32 {
+	if x != nil {
+		return x.MaxImages
+	}
+	return 0
+}
+
+func (x *VisionConfig) GetMaxImageSizeBytes() uint64 {
+	if x != nil {
+		return x.MaxImageSizeBytes
+	}
+	return 0
+}
+
+func (x *VisionConfig) GetMaxDimension() uint32 {
+	if x != nil {
+		return x.MaxDimension
+	}
+	return 0
+}
+
+func (x *VisionConfig) GetDenyRemoteUrls() bool {
+	if x != nil {
+		return x.DenyRemoteUrls
+	}
+	return false
+}
+
+var File_filters_v1alpha1_vision_proto protoreflect.FileDescriptor
+
+var file_filters_v1alpha1_vision_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x17, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x22, 0xad, 0x01, 0x0a, 0x0c, 0x56, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78,
+	0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6d,
+	0x61, 0x78, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x78,
+	0x5f, 0x64, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0c, 0x6d, 0x61, 0x78, 0x44, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28,
+	0x0a, 0x10, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x75, 0x72,
+	0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x64, 0x65, 0x6e, 0x79, 0x52, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x55, 0x72, 0x6c, 0x73, 0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77,
+	0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filters_v1alpha1_vision_proto_rawDescOnce sync.Once
+	file_filters_v1alpha1_vision_proto_rawDescData = file_filters_v1alpha1_vision_proto_rawDesc
+)
+
+func file_filters_v1alpha1_vision_proto_rawDescGZIP() []byte {
+	file_filters_v1alpha1_vision_proto_rawDescOnce.Do(func() {
+		file_filters_v1alpha1_vision_proto_rawDescData = protoimpl.X.CompressGZIP(file_filters_v1alpha1_vision_proto_rawDescData)
+	})
+	return file_filters_v1alpha1_vision_proto_rawDescData
+}
+
+var file_filters_v1alpha1_vision_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_filters_v1alpha1_vision_proto_goTypes = []interface{}{
+	(*VisionConfig)(nil), // 0: knoway.filters.v1alpha1.VisionConfig
+}
+var file_filters_v1alpha1_vision_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_filters_v1alpha1_vision_proto_init() }
+func file_filters_v1alpha1_vision_proto_init() {
+	if File_filters_v1alpha1_vision_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filters_v1alpha1_vision_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VisionConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filters_v1alpha1_vision_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_filters_v1alpha1_vision_proto_goTypes,
+		DependencyIndexes: file_filters_v1alpha1_vision_proto_depIdxs,
+		MessageInfos:      file_filters_v1alpha1_vision_proto_msgTypes,
+	}.Build()
+	File_filters_v1alpha1_vision_proto = out.File
+	file_filters_v1alpha1_vision_proto_rawDesc = nil
+	file_filters_v1alpha1_vision_proto_goTypes = nil
+	file_filters_v1alpha1_vision_proto_depIdxs = nil
+}
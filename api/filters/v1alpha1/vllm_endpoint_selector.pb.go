@@ -0,0 +1,191 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: filters/v1alpha1/vllm_endpoint_selector.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// VLLMEndpointSelectorConfig sends the requests of the cluster to the least
+// loaded of the endpoints of the upstream, by the metrics scraped from the
+// endpoints served by vLLM. Endpoints with fewer requests waiting are picked
+// first, then the ones with lower KV cache usage, then the ones with fewer
+// requests running. Requests sent to an endpoint since it was last scraped
+// are counted as waiting, so that bursts between scrapes are spread.
+//
+// The metrics are scraped in the background once they are older than the
+// interval, endpoints without metrics scraped recently are skipped, and
+// requests are sent to every endpoint in turn when none of them has.
+type VLLMEndpointSelectorConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Interval of scraping the metrics of each endpoint, default is 1s
+	ScrapeInterval *durationpb.Duration `protobuf:"bytes,1,opt,name=scrape_interval,json=scrapeInterval,proto3" json:"scrape_interval,omitempty"`
+	// Timeout of every scrape, default is 1s
+	ScrapeTimeout *durationpb.Duration `protobuf:"bytes,2,opt,name=scrape_timeout,json=scrapeTimeout,proto3" json:"scrape_timeout,omitempty"`
+	// Path of the metrics of the endpoints, default is /metrics
+	MetricsPath string `protobuf:"bytes,3,opt,name=metrics_path,json=metricsPath,proto3" json:"metrics_path,omitempty"`
+}
+
+func (x *VLLMEndpointSelectorConfig) Reset() {
+	*x = VLLMEndpointSelectorConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filters_v1alpha1_vllm_endpoint_selector_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VLLMEndpointSelectorConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VLLMEndpointSelectorConfig) ProtoMessage() {}
+
+func (x *VLLMEndpointSelectorConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_filters_v1alpha1_vllm_endpoint_selector_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VLLMEndpointSelectorConfig.ProtoReflect.Descriptor instead.
+func (*VLLMEndpointSelectorConfig) Descriptor() ([]byte, []int) {
+	return file_filters_v1alpha1_vllm_endpoint_selector_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VLLMEndpointSelectorConfig) GetScrapeInterval() *durationpb.Duration {
+	if x != nil {
+		return x.ScrapeInterval
+	}
+	return nil
+}
+
+func (x *VLLMEndpointSelectorConfig) GetScrapeTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ScrapeTimeout
+	}
+	return nil
+}
+
+func (x *VLLMEndpointSelectorConfig) GetMetricsPath() string {
+	if x != nil {
+		return x.MetricsPath
+	}
+	return ""
+}
+
+var File_filters_v1alpha1_vllm_endpoint_selector_proto protoreflect.FileDescriptor
+
+var file_filters_v1alpha1_vllm_endpoint_selector_proto_rawDesc = []byte{
+	0x0a, 0x2d, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2f, 0x76, 0x6c, 0x6c, 0x6d, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x17, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc5, 0x01, 0x0a, 0x1a, 0x56, 0x4c, 0x4c,
+	0x4d, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x42, 0x0a, 0x0f, 0x73, 0x63, 0x72, 0x61, 0x70,
+	0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x73, 0x63, 0x72,
+	0x61, 0x70, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x40, 0x0a, 0x0e, 0x73,
+	0x63, 0x72, 0x61, 0x70, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d,
+	0x73, 0x63, 0x72, 0x61, 0x70, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x21, 0x0a,
+	0x0c, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x50, 0x61, 0x74, 0x68,
+	0x42, 0x21, 0x5a, 0x1f, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filters_v1alpha1_vllm_endpoint_selector_proto_rawDescOnce sync.Once
+	file_filters_v1alpha1_vllm_endpoint_selector_proto_rawDescData = file_filters_v1alpha1_vllm_endpoint_selector_proto_rawDesc
+)
+
+func file_filters_v1alpha1_vllm_endpoint_selector_proto_rawDescGZIP() []byte {
+	file_filters_v1alpha1_vllm_endpoint_selector_proto_rawDescOnce.Do(func() {
+		file_filters_v1alpha1_vllm_endpoint_selector_proto_rawDescData = protoimpl.X.CompressGZIP(file_filters_v1alpha1_vllm_endpoint_selector_proto_rawDescData)
+	})
+	return file_filters_v1alpha1_vllm_endpoint_selector_proto_rawDescData
+}
+
+var file_filters_v1alpha1_vllm_endpoint_selector_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_filters_v1alpha1_vllm_endpoint_selector_proto_goTypes = []interface{}{
+	(*VLLMEndpointSelectorConfig)(nil), // 0: knoway.filters.v1alpha1.VLLMEndpointSelectorConfig
+	(*durationpb.Duration)(nil),        // 1: google.protobuf.Duration
+}
+var file_filters_v1alpha1_vllm_endpoint_selector_proto_depIdxs = []int32{
+	1, // 0: knoway.filters.v1alpha1.VLLMEndpointSelectorConfig.scrape_interval:type_name -> google.protobuf.Duration
+	1, // 1: knoway.filters.v1alpha1.VLLMEndpointSelectorConfig.scrape_timeout:type_name -> google.protobuf.Duration
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_filters_v1alpha1_vllm_endpoint_selector_proto_init() }
+func file_filters_v1alpha1_vllm_endpoint_selector_proto_init() {
+	if File_filters_v1alpha1_vllm_endpoint_selector_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filters_v1alpha1_vllm_endpoint_selector_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VLLMEndpointSelectorConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filters_v1alpha1_vllm_endpoint_selector_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_filters_v1alpha1_vllm_endpoint_selector_proto_goTypes,
+		DependencyIndexes: file_filters_v1alpha1_vllm_endpoint_selector_proto_depIdxs,
+		MessageInfos:      file_filters_v1alpha1_vllm_endpoint_selector_proto_msgTypes,
+	}.Build()
+	File_filters_v1alpha1_vllm_endpoint_selector_proto = out.File
+	file_filters_v1alpha1_vllm_endpoint_selector_proto_rawDesc = nil
+	file_filters_v1alpha1_vllm_endpoint_selector_proto_goTypes = nil
+	file_filters_v1alpha1_vllm_endpoint_selector_proto_depIdxs = nil
+}
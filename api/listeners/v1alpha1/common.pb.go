@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	anypb "google.golang.org/protobuf/types/known/anypb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -28,6 +29,10 @@ type ListenerFilter struct {
 
 	Name   string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Config *anypb.Any `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// feature_flag optional: the filter only runs for the requests the
+	// feature flag is enabled for. Flags are evaluated after authentication,
+	// filters of OnRequestPre never run when gated.
+	FeatureFlag string `protobuf:"bytes,3,opt,name=feature_flag,json=featureFlag,proto3" json:"feature_flag,omitempty"`
 }
 
 func (x *ListenerFilter) Reset() {
@@ -76,12 +81,22 @@ func (x *ListenerFilter) GetConfig() *anypb.Any {
 	return nil
 }
 
+func (x *ListenerFilter) GetFeatureFlag() string {
+	if x != nil {
+		return x.FeatureFlag
+	}
+	return ""
+}
+
 type Log struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Enable bool `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	// Sinks the entries are written to, the entries are written to the logs
+	// of the gateway when empty.
+	Sinks []*LogSink `protobuf:"bytes,2,rep,name=sinks,proto3" json:"sinks,omitempty"`
 }
 
 func (x *Log) Reset() {
@@ -123,6 +138,394 @@ func (x *Log) GetEnable() bool {
 	return false
 }
 
+func (x *Log) GetSinks() []*LogSink {
+	if x != nil {
+		return x.Sinks
+	}
+	return nil
+}
+
+// LogSink writes access log entries, every entry is a JSON object.
+type LogSink struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Sink:
+	//
+	//	*LogSink_File_
+	//	*LogSink_Kafka_
+	//	*LogSink_Http
+	Sink isLogSink_Sink `protobuf_oneof:"sink"`
+	// Fields of the entries written, all the fields when empty.
+	Fields []string       `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	Batch  *LogSink_Batch `protobuf:"bytes,5,opt,name=batch,proto3" json:"batch,omitempty"`
+}
+
+func (x *LogSink) Reset() {
+	*x = LogSink{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_listeners_v1alpha1_common_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogSink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogSink) ProtoMessage() {}
+
+func (x *LogSink) ProtoReflect() protoreflect.Message {
+	mi := &file_listeners_v1alpha1_common_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogSink.ProtoReflect.Descriptor instead.
+func (*LogSink) Descriptor() ([]byte, []int) {
+	return file_listeners_v1alpha1_common_proto_rawDescGZIP(), []int{2}
+}
+
+func (m *LogSink) GetSink() isLogSink_Sink {
+	if m != nil {
+		return m.Sink
+	}
+	return nil
+}
+
+func (x *LogSink) GetFile() *LogSink_File {
+	if x, ok := x.GetSink().(*LogSink_File_); ok {
+		return x.File
+	}
+	return nil
+}
+
+func (x *LogSink) GetKafka() *LogSink_Kafka {
+	if x, ok := x.GetSink().(*LogSink_Kafka_); ok {
+		return x.Kafka
+	}
+	return nil
+}
+
+func (x *LogSink) GetHttp() *LogSink_HTTP {
+	if x, ok := x.GetSink().(*LogSink_Http); ok {
+		return x.Http
+	}
+	return nil
+}
+
+func (x *LogSink) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *LogSink) GetBatch() *LogSink_Batch {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+type isLogSink_Sink interface {
+	isLogSink_Sink()
+}
+
+type LogSink_File_ struct {
+	File *LogSink_File `protobuf:"bytes,1,opt,name=file,proto3,oneof"`
+}
+
+type LogSink_Kafka_ struct {
+	Kafka *LogSink_Kafka `protobuf:"bytes,2,opt,name=kafka,proto3,oneof"`
+}
+
+type LogSink_Http struct {
+	Http *LogSink_HTTP `protobuf:"bytes,3,opt,name=http,proto3,oneof"`
+}
+
+func (*LogSink_File_) isLogSink_Sink() {}
+
+func (*LogSink_Kafka_) isLogSink_Sink() {}
+
+func (*LogSink_Http) isLogSink_Sink() {}
+
+// File appends the entries as JSON lines.
+type LogSink_File struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Size in bytes to rotate the file at, the rotated files are
+	// suffixed with .1, .2 and so on, 0 disables rotation.
+	MaxSizeBytes uint64 `protobuf:"varint,2,opt,name=max_size_bytes,json=maxSizeBytes,proto3" json:"max_size_bytes,omitempty"`
+	// Rotated files to keep, default is 5
+	MaxBackups *uint32 `protobuf:"varint,3,opt,name=max_backups,json=maxBackups,proto3,oneof" json:"max_backups,omitempty"`
+}
+
+func (x *LogSink_File) Reset() {
+	*x = LogSink_File{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_listeners_v1alpha1_common_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogSink_File) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogSink_File) ProtoMessage() {}
+
+func (x *LogSink_File) ProtoReflect() protoreflect.Message {
+	mi := &file_listeners_v1alpha1_common_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogSink_File.ProtoReflect.Descriptor instead.
+func (*LogSink_File) Descriptor() ([]byte, []int) {
+	return file_listeners_v1alpha1_common_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *LogSink_File) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *LogSink_File) GetMaxSizeBytes() uint64 {
+	if x != nil {
+		return x.MaxSizeBytes
+	}
+	return 0
+}
+
+func (x *LogSink_File) GetMaxBackups() uint32 {
+	if x != nil && x.MaxBackups != nil {
+		return *x.MaxBackups
+	}
+	return 0
+}
+
+// Kafka produces the entries to a topic through the Kafka REST Proxy,
+// a batch of entries per request.
+type LogSink_Kafka struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// URL of the REST Proxy, e.g. http://kafka-rest:8082
+	RestProxyUrl string `protobuf:"bytes,1,opt,name=rest_proxy_url,json=restProxyUrl,proto3" json:"rest_proxy_url,omitempty"`
+	Topic        string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	// Headers of the requests, e.g. Authorization
+	Headers map[string]string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *LogSink_Kafka) Reset() {
+	*x = LogSink_Kafka{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_listeners_v1alpha1_common_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogSink_Kafka) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogSink_Kafka) ProtoMessage() {}
+
+func (x *LogSink_Kafka) ProtoReflect() protoreflect.Message {
+	mi := &file_listeners_v1alpha1_common_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogSink_Kafka.ProtoReflect.Descriptor instead.
+func (*LogSink_Kafka) Descriptor() ([]byte, []int) {
+	return file_listeners_v1alpha1_common_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *LogSink_Kafka) GetRestProxyUrl() string {
+	if x != nil {
+		return x.RestProxyUrl
+	}
+	return ""
+}
+
+func (x *LogSink_Kafka) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *LogSink_Kafka) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+// HTTP posts the entries to a webhook as a JSON array, a batch of entries
+// per request.
+type LogSink_HTTP struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url     string            `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Headers map[string]string `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *LogSink_HTTP) Reset() {
+	*x = LogSink_HTTP{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_listeners_v1alpha1_common_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogSink_HTTP) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogSink_HTTP) ProtoMessage() {}
+
+func (x *LogSink_HTTP) ProtoReflect() protoreflect.Message {
+	mi := &file_listeners_v1alpha1_common_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogSink_HTTP.ProtoReflect.Descriptor instead.
+func (*LogSink_HTTP) Descriptor() ([]byte, []int) {
+	return file_listeners_v1alpha1_common_proto_rawDescGZIP(), []int{2, 2}
+}
+
+func (x *LogSink_HTTP) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *LogSink_HTTP) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+// Batching of Kafka and HTTP sinks, the entries are buffered and sent
+// in the background so that requests are never blocked by the sinks.
+type LogSink_Batch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum entries per batch, default is 100
+	MaxSize uint32 `protobuf:"varint,1,opt,name=max_size,json=maxSize,proto3" json:"max_size,omitempty"`
+	// Interval to send batches that are not full, default is 1s
+	Interval *durationpb.Duration `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Entries buffered before new ones are dropped, default is 10000
+	BufferSize uint32 `protobuf:"varint,3,opt,name=buffer_size,json=bufferSize,proto3" json:"buffer_size,omitempty"`
+	// Timeout of each request, default is 10s
+	Timeout *durationpb.Duration `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *LogSink_Batch) Reset() {
+	*x = LogSink_Batch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_listeners_v1alpha1_common_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogSink_Batch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogSink_Batch) ProtoMessage() {}
+
+func (x *LogSink_Batch) ProtoReflect() protoreflect.Message {
+	mi := &file_listeners_v1alpha1_common_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogSink_Batch.ProtoReflect.Descriptor instead.
+func (*LogSink_Batch) Descriptor() ([]byte, []int) {
+	return file_listeners_v1alpha1_common_proto_rawDescGZIP(), []int{2, 3}
+}
+
+func (x *LogSink_Batch) GetMaxSize() uint32 {
+	if x != nil {
+		return x.MaxSize
+	}
+	return 0
+}
+
+func (x *LogSink_Batch) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *LogSink_Batch) GetBufferSize() uint32 {
+	if x != nil {
+		return x.BufferSize
+	}
+	return 0
+}
+
+func (x *LogSink_Batch) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
 var File_listeners_v1alpha1_common_proto protoreflect.FileDescriptor
 
 var file_listeners_v1alpha1_common_proto_rawDesc = []byte{
@@ -131,17 +534,86 @@ var file_listeners_v1alpha1_common_proto_rawDesc = []byte{
 	0x6f, 0x12, 0x19, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e,
 	0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x19, 0x67, 0x6f,
 	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e,
-	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x52, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x65,
+	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x75, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x65,
 	0x6e, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
 	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a,
 	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x41, 0x6e, 0x79, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x1d, 0x0a, 0x03, 0x4c,
-	0x6f, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x23, 0x5a, 0x21, 0x6b, 0x6e,
-	0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x69, 0x73,
-	0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x41, 0x6e, 0x79, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x21, 0x0a, 0x0c, 0x66,
+	0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x22, 0x57,
+	0x0a, 0x03, 0x4c, 0x6f, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x38, 0x0a,
+	0x05, 0x73, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b,
+	0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x53, 0x69, 0x6e, 0x6b,
+	0x52, 0x05, 0x73, 0x69, 0x6e, 0x6b, 0x73, 0x22, 0xcd, 0x07, 0x0a, 0x07, 0x4c, 0x6f, 0x67, 0x53,
+	0x69, 0x6e, 0x6b, 0x12, 0x3d, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x27, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x6f,
+	0x67, 0x53, 0x69, 0x6e, 0x6b, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x04, 0x66, 0x69,
+	0x6c, 0x65, 0x12, 0x40, 0x0a, 0x05, 0x6b, 0x61, 0x66, 0x6b, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x6f,
+	0x67, 0x53, 0x69, 0x6e, 0x6b, 0x2e, 0x4b, 0x61, 0x66, 0x6b, 0x61, 0x48, 0x00, 0x52, 0x05, 0x6b,
+	0x61, 0x66, 0x6b, 0x61, 0x12, 0x3d, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c,
+	0x6f, 0x67, 0x53, 0x69, 0x6e, 0x6b, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x48, 0x00, 0x52, 0x04, 0x68,
+	0x74, 0x74, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x3e, 0x0a, 0x05, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6b, 0x6e, 0x6f,
+	0x77, 0x61, 0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x53, 0x69, 0x6e, 0x6b, 0x2e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x1a, 0x76, 0x0a, 0x04, 0x46,
+	0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0c, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x24, 0x0a,
+	0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x48, 0x00, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x73,
+	0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x63, 0x6b,
+	0x75, 0x70, 0x73, 0x1a, 0xd0, 0x01, 0x0a, 0x05, 0x4b, 0x61, 0x66, 0x6b, 0x61, 0x12, 0x24, 0x0a,
+	0x0e, 0x72, 0x65, 0x73, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x5f, 0x75, 0x72, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x78, 0x79,
+	0x55, 0x72, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x4f, 0x0a, 0x07, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x6b, 0x6e, 0x6f,
+	0x77, 0x61, 0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x53, 0x69, 0x6e, 0x6b, 0x2e, 0x4b,
+	0x61, 0x66, 0x6b, 0x61, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xa4, 0x01, 0x0a, 0x04, 0x48, 0x54, 0x54, 0x50, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
+	0x6c, 0x12, 0x4e, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x34, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c,
+	0x6f, 0x67, 0x53, 0x69, 0x6e, 0x6b, 0x2e, 0x48, 0x54, 0x54, 0x50, 0x2e, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xaf, 0x01,
+	0x0a, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x66,
+	0x66, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x42,
+	0x06, 0x0a, 0x04, 0x73, 0x69, 0x6e, 0x6b, 0x42, 0x23, 0x5a, 0x21, 0x6b, 0x6e, 0x6f, 0x77, 0x61,
+	0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -156,19 +628,36 @@ func file_listeners_v1alpha1_common_proto_rawDescGZIP() []byte {
 	return file_listeners_v1alpha1_common_proto_rawDescData
 }
 
-var file_listeners_v1alpha1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_listeners_v1alpha1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_listeners_v1alpha1_common_proto_goTypes = []interface{}{
-	(*ListenerFilter)(nil), // 0: knoway.listeners.v1alpha1.ListenerFilter
-	(*Log)(nil),            // 1: knoway.listeners.v1alpha1.Log
-	(*anypb.Any)(nil),      // 2: google.protobuf.Any
+	(*ListenerFilter)(nil),      // 0: knoway.listeners.v1alpha1.ListenerFilter
+	(*Log)(nil),                 // 1: knoway.listeners.v1alpha1.Log
+	(*LogSink)(nil),             // 2: knoway.listeners.v1alpha1.LogSink
+	(*LogSink_File)(nil),        // 3: knoway.listeners.v1alpha1.LogSink.File
+	(*LogSink_Kafka)(nil),       // 4: knoway.listeners.v1alpha1.LogSink.Kafka
+	(*LogSink_HTTP)(nil),        // 5: knoway.listeners.v1alpha1.LogSink.HTTP
+	(*LogSink_Batch)(nil),       // 6: knoway.listeners.v1alpha1.LogSink.Batch
+	nil,                         // 7: knoway.listeners.v1alpha1.LogSink.Kafka.HeadersEntry
+	nil,                         // 8: knoway.listeners.v1alpha1.LogSink.HTTP.HeadersEntry
+	(*anypb.Any)(nil),           // 9: google.protobuf.Any
+	(*durationpb.Duration)(nil), // 10: google.protobuf.Duration
 }
 var file_listeners_v1alpha1_common_proto_depIdxs = []int32{
-	2, // 0: knoway.listeners.v1alpha1.ListenerFilter.config:type_name -> google.protobuf.Any
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	9,  // 0: knoway.listeners.v1alpha1.ListenerFilter.config:type_name -> google.protobuf.Any
+	2,  // 1: knoway.listeners.v1alpha1.Log.sinks:type_name -> knoway.listeners.v1alpha1.LogSink
+	3,  // 2: knoway.listeners.v1alpha1.LogSink.file:type_name -> knoway.listeners.v1alpha1.LogSink.File
+	4,  // 3: knoway.listeners.v1alpha1.LogSink.kafka:type_name -> knoway.listeners.v1alpha1.LogSink.Kafka
+	5,  // 4: knoway.listeners.v1alpha1.LogSink.http:type_name -> knoway.listeners.v1alpha1.LogSink.HTTP
+	6,  // 5: knoway.listeners.v1alpha1.LogSink.batch:type_name -> knoway.listeners.v1alpha1.LogSink.Batch
+	7,  // 6: knoway.listeners.v1alpha1.LogSink.Kafka.headers:type_name -> knoway.listeners.v1alpha1.LogSink.Kafka.HeadersEntry
+	8,  // 7: knoway.listeners.v1alpha1.LogSink.HTTP.headers:type_name -> knoway.listeners.v1alpha1.LogSink.HTTP.HeadersEntry
+	10, // 8: knoway.listeners.v1alpha1.LogSink.Batch.interval:type_name -> google.protobuf.Duration
+	10, // 9: knoway.listeners.v1alpha1.LogSink.Batch.timeout:type_name -> google.protobuf.Duration
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_listeners_v1alpha1_common_proto_init() }
@@ -201,14 +690,80 @@ func file_listeners_v1alpha1_common_proto_init() {
 				return nil
 			}
 		}
+		file_listeners_v1alpha1_common_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogSink); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_listeners_v1alpha1_common_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogSink_File); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_listeners_v1alpha1_common_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogSink_Kafka); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_listeners_v1alpha1_common_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogSink_HTTP); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_listeners_v1alpha1_common_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogSink_Batch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_listeners_v1alpha1_common_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*LogSink_File_)(nil),
+		(*LogSink_Kafka_)(nil),
+		(*LogSink_Http)(nil),
 	}
+	file_listeners_v1alpha1_common_proto_msgTypes[3].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_listeners_v1alpha1_common_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,336 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: listeners/v1alpha1/event_source.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EventSource lets browser EventSource clients, which can send neither bodies
+// nor headers, stream chat completions with GET requests. The model and a
+// reference of the prompt are passed by query parameters, and translated into
+// a streaming chat completions request:
+//
+//	GET /v1/chat/completions?model=gpt-4o&thread_id=thread_abc
+//	GET /v1/chat/completions?model=gpt-4o&prompt_id=translate&text=Bonjour
+//
+// With thread_id, the history of the thread is sent and the reply is saved
+// back to it, which requires threads to be enabled. With prompt_id, the
+// messages of the prompt are sent, appended to the thread if both are set.
+type EventSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enable  bool                  `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Prompts []*EventSource_Prompt `protobuf:"bytes,2,rep,name=prompts,proto3" json:"prompts,omitempty"`
+	// Allows the API key to be passed by the api_key query parameter, since
+	// EventSource cannot set the Authorization header. URLs end up in the
+	// logs of proxies and the history of browsers, short-lived keys should be
+	// used.
+	AllowApiKeyQuery bool `protobuf:"varint,3,opt,name=allow_api_key_query,json=allowApiKeyQuery,proto3" json:"allow_api_key_query,omitempty"`
+}
+
+func (x *EventSource) Reset() {
+	*x = EventSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_listeners_v1alpha1_event_source_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventSource) ProtoMessage() {}
+
+func (x *EventSource) ProtoReflect() protoreflect.Message {
+	mi := &file_listeners_v1alpha1_event_source_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventSource.ProtoReflect.Descriptor instead.
+func (*EventSource) Descriptor() ([]byte, []int) {
+	return file_listeners_v1alpha1_event_source_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EventSource) GetEnable() bool {
+	if x != nil {
+		return x.Enable
+	}
+	return false
+}
+
+func (x *EventSource) GetPrompts() []*EventSource_Prompt {
+	if x != nil {
+		return x.Prompts
+	}
+	return nil
+}
+
+func (x *EventSource) GetAllowApiKeyQuery() bool {
+	if x != nil {
+		return x.AllowApiKeyQuery
+	}
+	return false
+}
+
+type EventSource_Prompt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string                        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Messages []*EventSource_Prompt_Message `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (x *EventSource_Prompt) Reset() {
+	*x = EventSource_Prompt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_listeners_v1alpha1_event_source_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventSource_Prompt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventSource_Prompt) ProtoMessage() {}
+
+func (x *EventSource_Prompt) ProtoReflect() protoreflect.Message {
+	mi := &file_listeners_v1alpha1_event_source_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventSource_Prompt.ProtoReflect.Descriptor instead.
+func (*EventSource_Prompt) Descriptor() ([]byte, []int) {
+	return file_listeners_v1alpha1_event_source_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *EventSource_Prompt) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EventSource_Prompt) GetMessages() []*EventSource_Prompt_Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type EventSource_Prompt_Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Role string `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	// Go template executed with the other query parameters, e.g.
+	// "Translate {{ .text }} into English", missing parameters are
+	// rejected.
+	Content string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *EventSource_Prompt_Message) Reset() {
+	*x = EventSource_Prompt_Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_listeners_v1alpha1_event_source_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventSource_Prompt_Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventSource_Prompt_Message) ProtoMessage() {}
+
+func (x *EventSource_Prompt_Message) ProtoReflect() protoreflect.Message {
+	mi := &file_listeners_v1alpha1_event_source_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventSource_Prompt_Message.ProtoReflect.Descriptor instead.
+func (*EventSource_Prompt_Message) Descriptor() ([]byte, []int) {
+	return file_listeners_v1alpha1_event_source_proto_rawDescGZIP(), []int{0, 0, 0}
+}
+
+func (x *EventSource_Prompt_Message) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *EventSource_Prompt_Message) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+var File_listeners_v1alpha1_event_source_proto protoreflect.FileDescriptor
+
+var file_listeners_v1alpha1_event_source_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x19, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e,
+	0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x22, 0xc4, 0x02, 0x0a, 0x0b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x47, 0x0a, 0x07, 0x70, 0x72,
+	0x6f, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x61, 0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x6d,
+	0x70, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x13, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x61, 0x70, 0x69,
+	0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x10, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x41, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x1a, 0xa4, 0x01, 0x0a, 0x06, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x51, 0x0a,
+	0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x35, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65,
+	0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x1a, 0x37, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x6f, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x42, 0x23, 0x5a, 0x21, 0x6b, 0x6e, 0x6f,
+	0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_listeners_v1alpha1_event_source_proto_rawDescOnce sync.Once
+	file_listeners_v1alpha1_event_source_proto_rawDescData = file_listeners_v1alpha1_event_source_proto_rawDesc
+)
+
+func file_listeners_v1alpha1_event_source_proto_rawDescGZIP() []byte {
+	file_listeners_v1alpha1_event_source_proto_rawDescOnce.Do(func() {
+		file_listeners_v1alpha1_event_source_proto_rawDescData = protoimpl.X.CompressGZIP(file_listeners_v1alpha1_event_source_proto_rawDescData)
+	})
+	return file_listeners_v1alpha1_event_source_proto_rawDescData
+}
+
+var file_listeners_v1alpha1_event_source_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_listeners_v1alpha1_event_source_proto_goTypes = []interface{}{
+	(*EventSource)(nil),                // 0: knoway.listeners.v1alpha1.EventSource
+	(*EventSource_Prompt)(nil),         // 1: knoway.listeners.v1alpha1.EventSource.Prompt
+	(*EventSource_Prompt_Message)(nil), // 2: knoway.listeners.v1alpha1.EventSource.Prompt.Message
+}
+var file_listeners_v1alpha1_event_source_proto_depIdxs = []int32{
+	1, // 0: knoway.listeners.v1alpha1.EventSource.prompts:type_name -> knoway.listeners.v1alpha1.EventSource.Prompt
+	2, // 1: knoway.listeners.v1alpha1.EventSource.Prompt.messages:type_name -> knoway.listeners.v1alpha1.EventSource.Prompt.Message
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_listeners_v1alpha1_event_source_proto_init() }
+func file_listeners_v1alpha1_event_source_proto_init() {
+	if File_listeners_v1alpha1_event_source_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_listeners_v1alpha1_event_source_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_listeners_v1alpha1_event_source_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventSource_Prompt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_listeners_v1alpha1_event_source_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventSource_Prompt_Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_listeners_v1alpha1_event_source_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_listeners_v1alpha1_event_source_proto_goTypes,
+		DependencyIndexes: file_listeners_v1alpha1_event_source_proto_depIdxs,
+		MessageInfos:      file_listeners_v1alpha1_event_source_proto_msgTypes,
+	}.Build()
+	File_listeners_v1alpha1_event_source_proto = out.File
+	file_listeners_v1alpha1_event_source_proto_rawDesc = nil
+	file_listeners_v1alpha1_event_source_proto_goTypes = nil
+	file_listeners_v1alpha1_event_source_proto_depIdxs = nil
+}
@@ -0,0 +1,181 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: listeners/v1alpha1/speech_to_text_listener.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	_ "google.golang.org/protobuf/types/known/anypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SpeechToTextListener struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Filters   []*ListenerFilter `protobuf:"bytes,2,rep,name=filters,proto3" json:"filters,omitempty"`
+	AccessLog *Log              `protobuf:"bytes,3,opt,name=access_log,json=accessLog,proto3" json:"access_log,omitempty"`
+}
+
+func (x *SpeechToTextListener) Reset() {
+	*x = SpeechToTextListener{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_listeners_v1alpha1_speech_to_text_listener_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpeechToTextListener) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpeechToTextListener) ProtoMessage() {}
+
+func (x *SpeechToTextListener) ProtoReflect() protoreflect.Message {
+	mi := &file_listeners_v1alpha1_speech_to_text_listener_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpeechToTextListener.ProtoReflect.Descriptor instead.
+func (*SpeechToTextListener) Descriptor() ([]byte, []int) {
+	return file_listeners_v1alpha1_speech_to_text_listener_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SpeechToTextListener) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SpeechToTextListener) GetFilters() []*ListenerFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *SpeechToTextListener) GetAccessLog() *Log {
+	if x != nil {
+		return x.AccessLog
+	}
+	return nil
+}
+
+var File_listeners_v1alpha1_speech_to_text_listener_proto protoreflect.FileDescriptor
+
+var file_listeners_v1alpha1_speech_to_text_listener_proto_rawDesc = []byte{
+	0x0a, 0x30, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2f, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x5f, 0x74, 0x6f, 0x5f, 0x74,
+	0x65, 0x78, 0x74, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x19, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x19, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61,
+	0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xae, 0x01, 0x0a, 0x14, 0x53, 0x70,
+	0x65, 0x65, 0x63, 0x68, 0x54, 0x6f, 0x54, 0x65, 0x78, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x43, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79,
+	0x2e, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x3d, 0x0a, 0x0a, 0x61,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6c, 0x6f, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65,
+	0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x52,
+	0x09, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4c, 0x6f, 0x67, 0x42, 0x23, 0x5a, 0x21, 0x6b, 0x6e,
+	0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_listeners_v1alpha1_speech_to_text_listener_proto_rawDescOnce sync.Once
+	file_listeners_v1alpha1_speech_to_text_listener_proto_rawDescData = file_listeners_v1alpha1_speech_to_text_listener_proto_rawDesc
+)
+
+func file_listeners_v1alpha1_speech_to_text_listener_proto_rawDescGZIP() []byte {
+	file_listeners_v1alpha1_speech_to_text_listener_proto_rawDescOnce.Do(func() {
+		file_listeners_v1alpha1_speech_to_text_listener_proto_rawDescData = protoimpl.X.CompressGZIP(file_listeners_v1alpha1_speech_to_text_listener_proto_rawDescData)
+	})
+	return file_listeners_v1alpha1_speech_to_text_listener_proto_rawDescData
+}
+
+var file_listeners_v1alpha1_speech_to_text_listener_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_listeners_v1alpha1_speech_to_text_listener_proto_goTypes = []interface{}{
+	(*SpeechToTextListener)(nil), // 0: knoway.listeners.v1alpha1.SpeechToTextListener
+	(*ListenerFilter)(nil),       // 1: knoway.listeners.v1alpha1.ListenerFilter
+	(*Log)(nil),                  // 2: knoway.listeners.v1alpha1.Log
+}
+var file_listeners_v1alpha1_speech_to_text_listener_proto_depIdxs = []int32{
+	1, // 0: knoway.listeners.v1alpha1.SpeechToTextListener.filters:type_name -> knoway.listeners.v1alpha1.ListenerFilter
+	2, // 1: knoway.listeners.v1alpha1.SpeechToTextListener.access_log:type_name -> knoway.listeners.v1alpha1.Log
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_listeners_v1alpha1_speech_to_text_listener_proto_init() }
+func file_listeners_v1alpha1_speech_to_text_listener_proto_init() {
+	if File_listeners_v1alpha1_speech_to_text_listener_proto != nil {
+		return
+	}
+	file_listeners_v1alpha1_common_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_listeners_v1alpha1_speech_to_text_listener_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpeechToTextListener); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_listeners_v1alpha1_speech_to_text_listener_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_listeners_v1alpha1_speech_to_text_listener_proto_goTypes,
+		DependencyIndexes: file_listeners_v1alpha1_speech_to_text_listener_proto_depIdxs,
+		MessageInfos:      file_listeners_v1alpha1_speech_to_text_listener_proto_msgTypes,
+	}.Build()
+	File_listeners_v1alpha1_speech_to_text_listener_proto = out.File
+	file_listeners_v1alpha1_speech_to_text_listener_proto_rawDesc = nil
+	file_listeners_v1alpha1_speech_to_text_listener_proto_goTypes = nil
+	file_listeners_v1alpha1_speech_to_text_listener_proto_depIdxs = nil
+}
@@ -0,0 +1,374 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.0
+// 	protoc        (unknown)
+// source: listeners/v1alpha1/threads.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Threads enables the /v1/threads endpoints and lets chat completions requests
+// carrying the X-Knoway-Thread-ID header have their history assembled by the
+// gateway.
+type Threads struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Store:
+	//
+	//	*Threads_Redis
+	//	*Threads_Postgres
+	Store isThreads_Store `protobuf_oneof:"store"`
+	// Maximum number of history messages to be assembled into the request,
+	// 0 means unlimited.
+	MaxContextMessages uint32 `protobuf:"varint,3,opt,name=max_context_messages,json=maxContextMessages,proto3" json:"max_context_messages,omitempty"`
+	// Maximum estimated tokens of history messages to be assembled into the
+	// request, 0 means unlimited.
+	MaxContextTokens uint64 `protobuf:"varint,4,opt,name=max_context_tokens,json=maxContextTokens,proto3" json:"max_context_tokens,omitempty"`
+}
+
+func (x *Threads) Reset() {
+	*x = Threads{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_listeners_v1alpha1_threads_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Threads) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Threads) ProtoMessage() {}
+
+func (x *Threads) ProtoReflect() protoreflect.Message {
+	mi := &file_listeners_v1alpha1_threads_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Threads.ProtoReflect.Descriptor instead.
+func (*Threads) Descriptor() ([]byte, []int) {
+	return file_listeners_v1alpha1_threads_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *Threads) GetStore() isThreads_Store {
+	if m != nil {
+		return m.Store
+	}
+	return nil
+}
+
+func (x *Threads) GetRedis() *Threads_RedisStore {
+	if x, ok := x.GetStore().(*Threads_Redis); ok {
+		return x.Redis
+	}
+	return nil
+}
+
+func (x *Threads) GetPostgres() *Threads_PostgresStore {
+	if x, ok := x.GetStore().(*Threads_Postgres); ok {
+		return x.Postgres
+	}
+	return nil
+}
+
+func (x *Threads) GetMaxContextMessages() uint32 {
+	if x != nil {
+		return x.MaxContextMessages
+	}
+	return 0
+}
+
+func (x *Threads) GetMaxContextTokens() uint64 {
+	if x != nil {
+		return x.MaxContextTokens
+	}
+	return 0
+}
+
+type isThreads_Store interface {
+	isThreads_Store()
+}
+
+type Threads_Redis struct {
+	Redis *Threads_RedisStore `protobuf:"bytes,1,opt,name=redis,proto3,oneof"`
+}
+
+type Threads_Postgres struct {
+	Postgres *Threads_PostgresStore `protobuf:"bytes,2,opt,name=postgres,proto3,oneof"`
+}
+
+func (*Threads_Redis) isThreads_Store() {}
+
+func (*Threads_Postgres) isThreads_Store() {}
+
+type Threads_RedisStore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url string               `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Ttl *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"` // Default is no expiration
+}
+
+func (x *Threads_RedisStore) Reset() {
+	*x = Threads_RedisStore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_listeners_v1alpha1_threads_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Threads_RedisStore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Threads_RedisStore) ProtoMessage() {}
+
+func (x *Threads_RedisStore) ProtoReflect() protoreflect.Message {
+	mi := &file_listeners_v1alpha1_threads_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Threads_RedisStore.ProtoReflect.Descriptor instead.
+func (*Threads_RedisStore) Descriptor() ([]byte, []int) {
+	return file_listeners_v1alpha1_threads_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *Threads_RedisStore) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Threads_RedisStore) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+type Threads_PostgresStore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dsn string `protobuf:"bytes,1,opt,name=dsn,proto3" json:"dsn,omitempty"`
+	// Table names will be <table_prefix>threads and <table_prefix>thread_messages
+	TablePrefix string `protobuf:"bytes,2,opt,name=table_prefix,json=tablePrefix,proto3" json:"table_prefix,omitempty"` // Default is knoway_
+}
+
+func (x *Threads_PostgresStore) Reset() {
+	*x = Threads_PostgresStore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_listeners_v1alpha1_threads_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Threads_PostgresStore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Threads_PostgresStore) ProtoMessage() {}
+
+func (x *Threads_PostgresStore) ProtoReflect() protoreflect.Message {
+	mi := &file_listeners_v1alpha1_threads_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Threads_PostgresStore.ProtoReflect.Descriptor instead.
+func (*Threads_PostgresStore) Descriptor() ([]byte, []int) {
+	return file_listeners_v1alpha1_threads_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *Threads_PostgresStore) GetDsn() string {
+	if x != nil {
+		return x.Dsn
+	}
+	return ""
+}
+
+func (x *Threads_PostgresStore) GetTablePrefix() string {
+	if x != nil {
+		return x.TablePrefix
+	}
+	return ""
+}
+
+var File_listeners_v1alpha1_threads_proto protoreflect.FileDescriptor
+
+var file_listeners_v1alpha1_threads_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2f, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x19, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x1a, 0x1e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9c, 0x03,
+	0x0a, 0x07, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x12, 0x45, 0x0a, 0x05, 0x72, 0x65, 0x64,
+	0x69, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61,
+	0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x52, 0x65, 0x64,
+	0x69, 0x73, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x48, 0x00, 0x52, 0x05, 0x72, 0x65, 0x64, 0x69, 0x73,
+	0x12, 0x4e, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x6c, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x65, 0x72, 0x73, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x54,
+	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x48, 0x00, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73,
+	0x12, 0x30, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x5f,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12,
+	0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10,
+	0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
+	0x1a, 0x4b, 0x0a, 0x0a, 0x52, 0x65, 0x64, 0x69, 0x73, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c,
+	0x12, 0x2b, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x1a, 0x44, 0x0a,
+	0x0d, 0x50, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x64, 0x73, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x73, 0x6e,
+	0x12, 0x21, 0x0a, 0x0c, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x42, 0x07, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x42, 0x23, 0x5a, 0x21,
+	0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_listeners_v1alpha1_threads_proto_rawDescOnce sync.Once
+	file_listeners_v1alpha1_threads_proto_rawDescData = file_listeners_v1alpha1_threads_proto_rawDesc
+)
+
+func file_listeners_v1alpha1_threads_proto_rawDescGZIP() []byte {
+	file_listeners_v1alpha1_threads_proto_rawDescOnce.Do(func() {
+		file_listeners_v1alpha1_threads_proto_rawDescData = protoimpl.X.CompressGZIP(file_listeners_v1alpha1_threads_proto_rawDescData)
+	})
+	return file_listeners_v1alpha1_threads_proto_rawDescData
+}
+
+var file_listeners_v1alpha1_threads_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_listeners_v1alpha1_threads_proto_goTypes = []interface{}{
+	(*Threads)(nil),               // 0: knoway.listeners.v1alpha1.Threads
+	(*Threads_RedisStore)(nil),    // 1: knoway.listeners.v1alpha1.Threads.RedisStore
+	(*Threads_PostgresStore)(nil), // 2: knoway.listeners.v1alpha1.Threads.PostgresStore
+	(*durationpb.Duration)(nil),   // 3: google.protobuf.Duration
+}
+var file_listeners_v1alpha1_threads_proto_depIdxs = []int32{
+	1, // 0: knoway.listeners.v1alpha1.Threads.redis:type_name -> knoway.listeners.v1alpha1.Threads.RedisStore
+	2, // 1: knoway.listeners.v1alpha1.Threads.postgres:type_name -> knoway.listeners.v1alpha1.Threads.PostgresStore
+	3, // 2: knoway.listeners.v1alpha1.Threads.RedisStore.ttl:type_name -> google.protobuf.Duration
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_listeners_v1alpha1_threads_proto_init() }
+func file_listeners_v1alpha1_threads_proto_init() {
+	if File_listeners_v1alpha1_threads_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_listeners_v1alpha1_threads_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Threads); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_listeners_v1alpha1_threads_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Threads_RedisStore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_listeners_v1alpha1_threads_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Threads_PostgresStore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_listeners_v1alpha1_threads_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*Threads_Redis)(nil),
+		(*Threads_Postgres)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_listeners_v1alpha1_threads_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_listeners_v1alpha1_threads_proto_goTypes,
+		DependencyIndexes: file_listeners_v1alpha1_threads_proto_depIdxs,
+		MessageInfos:      file_listeners_v1alpha1_threads_proto_msgTypes,
+	}.Build()
+	File_listeners_v1alpha1_threads_proto = out.File
+	file_listeners_v1alpha1_threads_proto_rawDesc = nil
+	file_listeners_v1alpha1_threads_proto_goTypes = nil
+	file_listeners_v1alpha1_threads_proto_depIdxs = nil
+}
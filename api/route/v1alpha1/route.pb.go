@@ -31,6 +31,9 @@ const (
 	LoadBalancePolicy_LOAD_BALANCE_POLICY_UNSPECIFIED   LoadBalancePolicy = 0
 	LoadBalancePolicy_LOAD_BALANCE_POLICY_ROUND_ROBIN   LoadBalancePolicy = 1
 	LoadBalancePolicy_LOAD_BALANCE_POLICY_LEAST_REQUEST LoadBalancePolicy = 2
+	// LOAD_BALANCE_POLICY_LEAST_LATENCY prefers the target with the lowest
+	// moving average of latencies, divided by the weight of the target.
+	LoadBalancePolicy_LOAD_BALANCE_POLICY_LEAST_LATENCY LoadBalancePolicy = 3
 )
 
 // Enum value maps for LoadBalancePolicy.
@@ -39,11 +42,13 @@ var (
 		0: "LOAD_BALANCE_POLICY_UNSPECIFIED",
 		1: "LOAD_BALANCE_POLICY_ROUND_ROBIN",
 		2: "LOAD_BALANCE_POLICY_LEAST_REQUEST",
+		3: "LOAD_BALANCE_POLICY_LEAST_LATENCY",
 	}
 	LoadBalancePolicy_value = map[string]int32{
 		"LOAD_BALANCE_POLICY_UNSPECIFIED":   0,
 		"LOAD_BALANCE_POLICY_ROUND_ROBIN":   1,
 		"LOAD_BALANCE_POLICY_LEAST_REQUEST": 2,
+		"LOAD_BALANCE_POLICY_LEAST_LATENCY": 3,
 	}
 )
 
@@ -74,6 +79,181 @@ func (LoadBalancePolicy) EnumDescriptor() ([]byte, []int) {
 	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{0}
 }
 
+// Classes of errors of upstreams that fail over to other clusters
+// immediately.
+type FallbackErrorClass int32
+
+const (
+	FallbackErrorClass_FALLBACK_ERROR_CLASS_UNSPECIFIED FallbackErrorClass = 0
+	// Requests or responses refused by the content filters of upstreams, e.g.
+	// content_filter of Azure OpenAI, content_policy_violation of OpenAI
+	FallbackErrorClass_FALLBACK_ERROR_CLASS_CONTENT_FILTER FallbackErrorClass = 1
+	// Upstreams out of quota or credits, e.g. insufficient_quota of OpenAI
+	FallbackErrorClass_FALLBACK_ERROR_CLASS_INSUFFICIENT_QUOTA FallbackErrorClass = 2
+	// Responses with 429 statuses, other than the insufficient quota ones
+	FallbackErrorClass_FALLBACK_ERROR_CLASS_RATE_LIMITED FallbackErrorClass = 3
+)
+
+// Enum value maps for FallbackErrorClass.
+var (
+	FallbackErrorClass_name = map[int32]string{
+		0: "FALLBACK_ERROR_CLASS_UNSPECIFIED",
+		1: "FALLBACK_ERROR_CLASS_CONTENT_FILTER",
+		2: "FALLBACK_ERROR_CLASS_INSUFFICIENT_QUOTA",
+		3: "FALLBACK_ERROR_CLASS_RATE_LIMITED",
+	}
+	FallbackErrorClass_value = map[string]int32{
+		"FALLBACK_ERROR_CLASS_UNSPECIFIED":        0,
+		"FALLBACK_ERROR_CLASS_CONTENT_FILTER":     1,
+		"FALLBACK_ERROR_CLASS_INSUFFICIENT_QUOTA": 2,
+		"FALLBACK_ERROR_CLASS_RATE_LIMITED":       3,
+	}
+)
+
+func (x FallbackErrorClass) Enum() *FallbackErrorClass {
+	p := new(FallbackErrorClass)
+	*p = x
+	return p
+}
+
+func (x FallbackErrorClass) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FallbackErrorClass) Descriptor() protoreflect.EnumDescriptor {
+	return file_route_v1alpha1_route_proto_enumTypes[1].Descriptor()
+}
+
+func (FallbackErrorClass) Type() protoreflect.EnumType {
+	return &file_route_v1alpha1_route_proto_enumTypes[1]
+}
+
+func (x FallbackErrorClass) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FallbackErrorClass.Descriptor instead.
+func (FallbackErrorClass) EnumDescriptor() ([]byte, []int) {
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{1}
+}
+
+// Classes of errors of upstreams that retry policies retry on.
+type RetryErrorClass int32
+
+const (
+	RetryErrorClass_RETRY_ERROR_CLASS_UNSPECIFIED RetryErrorClass = 0
+	// Responses with 5xx statuses
+	RetryErrorClass_RETRY_ERROR_CLASS_SERVER_ERROR RetryErrorClass = 1
+	// Responses with 429 statuses
+	RetryErrorClass_RETRY_ERROR_CLASS_RATE_LIMITED RetryErrorClass = 2
+	// Connections refused, reset or closed before the responses are received
+	RetryErrorClass_RETRY_ERROR_CLASS_CONNECTION_FAILURE RetryErrorClass = 3
+	// Upstreams timed out
+	RetryErrorClass_RETRY_ERROR_CLASS_TIMEOUT RetryErrorClass = 4
+)
+
+// Enum value maps for RetryErrorClass.
+var (
+	RetryErrorClass_name = map[int32]string{
+		0: "RETRY_ERROR_CLASS_UNSPECIFIED",
+		1: "RETRY_ERROR_CLASS_SERVER_ERROR",
+		2: "RETRY_ERROR_CLASS_RATE_LIMITED",
+		3: "RETRY_ERROR_CLASS_CONNECTION_FAILURE",
+		4: "RETRY_ERROR_CLASS_TIMEOUT",
+	}
+	RetryErrorClass_value = map[string]int32{
+		"RETRY_ERROR_CLASS_UNSPECIFIED":        0,
+		"RETRY_ERROR_CLASS_SERVER_ERROR":       1,
+		"RETRY_ERROR_CLASS_RATE_LIMITED":       2,
+		"RETRY_ERROR_CLASS_CONNECTION_FAILURE": 3,
+		"RETRY_ERROR_CLASS_TIMEOUT":            4,
+	}
+)
+
+func (x RetryErrorClass) Enum() *RetryErrorClass {
+	p := new(RetryErrorClass)
+	*p = x
+	return p
+}
+
+func (x RetryErrorClass) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RetryErrorClass) Descriptor() protoreflect.EnumDescriptor {
+	return file_route_v1alpha1_route_proto_enumTypes[2].Descriptor()
+}
+
+func (RetryErrorClass) Type() protoreflect.EnumType {
+	return &file_route_v1alpha1_route_proto_enumTypes[2]
+}
+
+func (x RetryErrorClass) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RetryErrorClass.Descriptor instead.
+func (RetryErrorClass) EnumDescriptor() ([]byte, []int) {
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{2}
+}
+
+// Modes of handling the seed parameter of requests.
+type SeedPolicyMode int32
+
+const (
+	SeedPolicyMode_SEED_POLICY_MODE_UNSPECIFIED SeedPolicyMode = 0
+	// Seeds of requests are passed to upstreams as they are
+	SeedPolicyMode_SEED_POLICY_MODE_PASS_THROUGH SeedPolicyMode = 1
+	// Every request is sent with the seed of the policy, for reproducible
+	// evaluations
+	SeedPolicyMode_SEED_POLICY_MODE_FORCE SeedPolicyMode = 2
+	// Seeds of requests are removed before sent to upstreams
+	SeedPolicyMode_SEED_POLICY_MODE_STRIP SeedPolicyMode = 3
+)
+
+// Enum value maps for SeedPolicyMode.
+var (
+	SeedPolicyMode_name = map[int32]string{
+		0: "SEED_POLICY_MODE_UNSPECIFIED",
+		1: "SEED_POLICY_MODE_PASS_THROUGH",
+		2: "SEED_POLICY_MODE_FORCE",
+		3: "SEED_POLICY_MODE_STRIP",
+	}
+	SeedPolicyMode_value = map[string]int32{
+		"SEED_POLICY_MODE_UNSPECIFIED":  0,
+		"SEED_POLICY_MODE_PASS_THROUGH": 1,
+		"SEED_POLICY_MODE_FORCE":        2,
+		"SEED_POLICY_MODE_STRIP":        3,
+	}
+)
+
+func (x SeedPolicyMode) Enum() *SeedPolicyMode {
+	p := new(SeedPolicyMode)
+	*p = x
+	return p
+}
+
+func (x SeedPolicyMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SeedPolicyMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_route_v1alpha1_route_proto_enumTypes[3].Descriptor()
+}
+
+func (SeedPolicyMode) Type() protoreflect.EnumType {
+	return &file_route_v1alpha1_route_proto_enumTypes[3]
+}
+
+func (x SeedPolicyMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SeedPolicyMode.Descriptor instead.
+func (SeedPolicyMode) EnumDescriptor() ([]byte, []int) {
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{3}
+}
+
 type RouteFilter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -81,6 +261,9 @@ type RouteFilter struct {
 
 	Name   string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Config *anypb.Any `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// feature_flag optional: the filter only runs for the requests the
+	// feature flag is enabled for.
+	FeatureFlag string `protobuf:"bytes,3,opt,name=feature_flag,json=featureFlag,proto3" json:"feature_flag,omitempty"`
 }
 
 func (x *RouteFilter) Reset() {
@@ -129,6 +312,13 @@ func (x *RouteFilter) GetConfig() *anypb.Any {
 	return nil
 }
 
+func (x *RouteFilter) GetFeatureFlag() string {
+	if x != nil {
+		return x.FeatureFlag
+	}
+	return ""
+}
+
 type StringMatch struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -210,19 +400,90 @@ func (*StringMatch_Exact) isStringMatch_Match() {}
 
 func (*StringMatch_Prefix) isStringMatch_Match() {}
 
+// HeaderMatch matches a header of the HTTP requests, header names are
+// case-insensitive.
+type HeaderMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// value optional: the header only needs to be present if unset.
+	Value *StringMatch `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *HeaderMatch) Reset() {
+	*x = HeaderMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_v1alpha1_route_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HeaderMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeaderMatch) ProtoMessage() {}
+
+func (x *HeaderMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_route_v1alpha1_route_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeaderMatch.ProtoReflect.Descriptor instead.
+func (*HeaderMatch) Descriptor() ([]byte, []int) {
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HeaderMatch) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HeaderMatch) GetValue() *StringMatch {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Match matches the requests of the model, and all the other conditions set,
+// e.g. to route the requests of a tenant, or the streaming ones, to canary
+// targets. Routes with more conditions are matched first.
 type Match struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Model   *StringMatch `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
-	Message *StringMatch `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Model   *StringMatch   `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	Message *StringMatch   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Headers []*HeaderMatch `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty"`
+	// api_key_id, user_id and tenant_id are given by the auth filter, they
+	// never match the requests of listeners without it.
+	ApiKeyId *StringMatch `protobuf:"bytes,4,opt,name=api_key_id,json=apiKeyId,proto3" json:"api_key_id,omitempty"`
+	UserId   *StringMatch `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TenantId *StringMatch `protobuf:"bytes,6,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// stream optional: whether the requests are streaming.
+	Stream *bool `protobuf:"varint,7,opt,name=stream,proto3,oneof" json:"stream,omitempty"`
+	// has_tools optional: whether the chat completions requests have tools.
+	HasTools *bool `protobuf:"varint,8,opt,name=has_tools,json=hasTools,proto3,oneof" json:"has_tools,omitempty"`
 }
 
 func (x *Match) Reset() {
 	*x = Match{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_route_v1alpha1_route_proto_msgTypes[2]
+		mi := &file_route_v1alpha1_route_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -235,7 +496,7 @@ func (x *Match) String() string {
 func (*Match) ProtoMessage() {}
 
 func (x *Match) ProtoReflect() protoreflect.Message {
-	mi := &file_route_v1alpha1_route_proto_msgTypes[2]
+	mi := &file_route_v1alpha1_route_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -248,7 +509,7 @@ func (x *Match) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Match.ProtoReflect.Descriptor instead.
 func (*Match) Descriptor() ([]byte, []int) {
-	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{2}
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Match) GetModel() *StringMatch {
@@ -265,6 +526,48 @@ func (x *Match) GetMessage() *StringMatch {
 	return nil
 }
 
+func (x *Match) GetHeaders() []*HeaderMatch {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *Match) GetApiKeyId() *StringMatch {
+	if x != nil {
+		return x.ApiKeyId
+	}
+	return nil
+}
+
+func (x *Match) GetUserId() *StringMatch {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *Match) GetTenantId() *StringMatch {
+	if x != nil {
+		return x.TenantId
+	}
+	return nil
+}
+
+func (x *Match) GetStream() bool {
+	if x != nil && x.Stream != nil {
+		return *x.Stream
+	}
+	return false
+}
+
+func (x *Match) GetHasTools() bool {
+	if x != nil && x.HasTools != nil {
+		return *x.HasTools
+	}
+	return false
+}
+
 type RouteDestination struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -279,7 +582,7 @@ type RouteDestination struct {
 func (x *RouteDestination) Reset() {
 	*x = RouteDestination{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_route_v1alpha1_route_proto_msgTypes[3]
+		mi := &file_route_v1alpha1_route_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -292,7 +595,7 @@ func (x *RouteDestination) String() string {
 func (*RouteDestination) ProtoMessage() {}
 
 func (x *RouteDestination) ProtoReflect() protoreflect.Message {
-	mi := &file_route_v1alpha1_route_proto_msgTypes[3]
+	mi := &file_route_v1alpha1_route_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -305,7 +608,7 @@ func (x *RouteDestination) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RouteDestination.ProtoReflect.Descriptor instead.
 func (*RouteDestination) Descriptor() ([]byte, []int) {
-	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{3}
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *RouteDestination) GetNamespace() string {
@@ -347,7 +650,7 @@ type RouteTarget struct {
 func (x *RouteTarget) Reset() {
 	*x = RouteTarget{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_route_v1alpha1_route_proto_msgTypes[4]
+		mi := &file_route_v1alpha1_route_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -360,7 +663,7 @@ func (x *RouteTarget) String() string {
 func (*RouteTarget) ProtoMessage() {}
 
 func (x *RouteTarget) ProtoReflect() protoreflect.Message {
-	mi := &file_route_v1alpha1_route_proto_msgTypes[4]
+	mi := &file_route_v1alpha1_route_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,7 +676,7 @@ func (x *RouteTarget) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RouteTarget.ProtoReflect.Descriptor instead.
 func (*RouteTarget) Descriptor() ([]byte, []int) {
-	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{4}
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *RouteTarget) GetDestination() *RouteDestination {
@@ -383,6 +686,66 @@ func (x *RouteTarget) GetDestination() *RouteDestination {
 	return nil
 }
 
+// ErrorFallback fails over the requests failed with a class of errors to the
+// next destination immediately, instead of retrying the same cluster. Each
+// destination is tried at most once per request.
+type ErrorFallback struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Class FallbackErrorClass `protobuf:"varint,1,opt,name=class,proto3,enum=knoway.route.v1alpha1.FallbackErrorClass" json:"class,omitempty"`
+	// Destinations tried in order, the other targets of the route in their
+	// order when empty
+	Destinations []*RouteDestination `protobuf:"bytes,2,rep,name=destinations,proto3" json:"destinations,omitempty"`
+}
+
+func (x *ErrorFallback) Reset() {
+	*x = ErrorFallback{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_v1alpha1_route_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorFallback) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorFallback) ProtoMessage() {}
+
+func (x *ErrorFallback) ProtoReflect() protoreflect.Message {
+	mi := &file_route_v1alpha1_route_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorFallback.ProtoReflect.Descriptor instead.
+func (*ErrorFallback) Descriptor() ([]byte, []int) {
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ErrorFallback) GetClass() FallbackErrorClass {
+	if x != nil {
+		return x.Class
+	}
+	return FallbackErrorClass_FALLBACK_ERROR_CLASS_UNSPECIFIED
+}
+
+func (x *ErrorFallback) GetDestinations() []*RouteDestination {
+	if x != nil {
+		return x.Destinations
+	}
+	return nil
+}
+
 type RouteFallback struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -396,12 +759,15 @@ type RouteFallback struct {
 	PostDelay *durationpb.Duration `protobuf:"bytes,3,opt,name=post_delay,json=postDelay,proto3,oneof" json:"post_delay,omitempty"`
 	// default: 3
 	MaxRetries *uint64 `protobuf:"varint,1,opt,name=max_retries,json=maxRetries,proto3,oneof" json:"max_retries,omitempty"`
+	// Failovers of the classes of errors, they take precedence over the
+	// retry policy and the retries above
+	OnErrors []*ErrorFallback `protobuf:"bytes,4,rep,name=on_errors,json=onErrors,proto3" json:"on_errors,omitempty"`
 }
 
 func (x *RouteFallback) Reset() {
 	*x = RouteFallback{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_route_v1alpha1_route_proto_msgTypes[5]
+		mi := &file_route_v1alpha1_route_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -414,7 +780,7 @@ func (x *RouteFallback) String() string {
 func (*RouteFallback) ProtoMessage() {}
 
 func (x *RouteFallback) ProtoReflect() protoreflect.Message {
-	mi := &file_route_v1alpha1_route_proto_msgTypes[5]
+	mi := &file_route_v1alpha1_route_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -427,7 +793,7 @@ func (x *RouteFallback) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RouteFallback.ProtoReflect.Descriptor instead.
 func (*RouteFallback) Descriptor() ([]byte, []int) {
-	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{5}
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RouteFallback) GetPreDelay() *durationpb.Duration {
@@ -451,36 +817,44 @@ func (x *RouteFallback) GetMaxRetries() uint64 {
 	return 0
 }
 
-type Route struct {
+func (x *RouteFallback) GetOnErrors() []*ErrorFallback {
+	if x != nil {
+		return x.OnErrors
+	}
+	return nil
+}
+
+// Exponential backoff with full jitter, the delay before the nth retry is
+// picked at random between 0 and base_interval * 2^(n-1), capped by
+// max_interval.
+type RetryBackoff struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name              string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Matches           []*Match          `protobuf:"bytes,2,rep,name=matches,proto3" json:"matches,omitempty"`
-	Filters           []*RouteFilter    `protobuf:"bytes,3,rep,name=filters,proto3" json:"filters,omitempty"`
-	LoadBalancePolicy LoadBalancePolicy `protobuf:"varint,4,opt,name=load_balance_policy,json=loadBalancePolicy,proto3,enum=knoway.route.v1alpha1.LoadBalancePolicy" json:"load_balance_policy,omitempty"`
-	Targets           []*RouteTarget    `protobuf:"bytes,5,rep,name=targets,proto3" json:"targets,omitempty"`
-	Fallback          *RouteFallback    `protobuf:"bytes,6,opt,name=fallback,proto3,oneof" json:"fallback,omitempty"`
+	// default: 25ms
+	BaseInterval *durationpb.Duration `protobuf:"bytes,1,opt,name=base_interval,json=baseInterval,proto3,oneof" json:"base_interval,omitempty"`
+	// default: 10 times of base_interval
+	MaxInterval *durationpb.Duration `protobuf:"bytes,2,opt,name=max_interval,json=maxInterval,proto3,oneof" json:"max_interval,omitempty"`
 }
 
-func (x *Route) Reset() {
-	*x = Route{}
+func (x *RetryBackoff) Reset() {
+	*x = RetryBackoff{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_route_v1alpha1_route_proto_msgTypes[6]
+		mi := &file_route_v1alpha1_route_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Route) String() string {
+func (x *RetryBackoff) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Route) ProtoMessage() {}
+func (*RetryBackoff) ProtoMessage() {}
 
-func (x *Route) ProtoReflect() protoreflect.Message {
-	mi := &file_route_v1alpha1_route_proto_msgTypes[6]
+func (x *RetryBackoff) ProtoReflect() protoreflect.Message {
+	mi := &file_route_v1alpha1_route_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -491,37 +865,528 @@ func (x *Route) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Route.ProtoReflect.Descriptor instead.
-func (*Route) Descriptor() ([]byte, []int) {
-	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use RetryBackoff.ProtoReflect.Descriptor instead.
+func (*RetryBackoff) Descriptor() ([]byte, []int) {
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Route) GetName() string {
+func (x *RetryBackoff) GetBaseInterval() *durationpb.Duration {
 	if x != nil {
-		return x.Name
+		return x.BaseInterval
 	}
-	return ""
+	return nil
 }
 
-func (x *Route) GetMatches() []*Match {
+func (x *RetryBackoff) GetMaxInterval() *durationpb.Duration {
 	if x != nil {
-		return x.Matches
+		return x.MaxInterval
 	}
 	return nil
 }
 
-func (x *Route) GetFilters() []*RouteFilter {
-	if x != nil {
-		return x.Filters
-	}
-	return nil
+// Limits the retries of a route to a percent of its requests within a
+// sliding window, so that retries don't amplify the load of upstreams which
+// are already failing.
+type RetryBudget struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum retries in percent of the requests within the window
+	RetryPercent uint32 `protobuf:"varint,1,opt,name=retry_percent,json=retryPercent,proto3" json:"retry_percent,omitempty"`
+	// Retries allowed per second regardless of the percent, default: 10
+	MinRetriesPerSecond *uint32 `protobuf:"varint,2,opt,name=min_retries_per_second,json=minRetriesPerSecond,proto3,oneof" json:"min_retries_per_second,omitempty"`
+	// default: 10s
+	Window *durationpb.Duration `protobuf:"bytes,3,opt,name=window,proto3,oneof" json:"window,omitempty"`
 }
 
-func (x *Route) GetLoadBalancePolicy() LoadBalancePolicy {
-	if x != nil {
-		return x.LoadBalancePolicy
+func (x *RetryBudget) Reset() {
+	*x = RetryBudget{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_v1alpha1_route_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return LoadBalancePolicy_LOAD_BALANCE_POLICY_UNSPECIFIED
+}
+
+func (x *RetryBudget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryBudget) ProtoMessage() {}
+
+func (x *RetryBudget) ProtoReflect() protoreflect.Message {
+	mi := &file_route_v1alpha1_route_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryBudget.ProtoReflect.Descriptor instead.
+func (*RetryBudget) Descriptor() ([]byte, []int) {
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RetryBudget) GetRetryPercent() uint32 {
+	if x != nil {
+		return x.RetryPercent
+	}
+	return 0
+}
+
+func (x *RetryBudget) GetMinRetriesPerSecond() uint32 {
+	if x != nil && x.MinRetriesPerSecond != nil {
+		return *x.MinRetriesPerSecond
+	}
+	return 0
+}
+
+func (x *RetryBudget) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+// RetryPolicy controls which errors of upstreams are retried and how, it
+// takes precedence over the fallback when both are set. Retries are sent to
+// the targets picked by the load balancer, as the fallback does.
+type RetryPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// default: 3
+	MaxRetries *uint64 `protobuf:"varint,1,opt,name=max_retries,json=maxRetries,proto3,oneof" json:"max_retries,omitempty"`
+	// Classes of errors retried, all of them when neither retry_on nor
+	// retry_on_statuses is set
+	RetryOn []RetryErrorClass `protobuf:"varint,2,rep,packed,name=retry_on,json=retryOn,proto3,enum=knoway.route.v1alpha1.RetryErrorClass" json:"retry_on,omitempty"`
+	// Statuses of the responses of upstreams retried, in addition to the
+	// classes of retry_on
+	RetryOnStatuses []uint32      `protobuf:"varint,3,rep,packed,name=retry_on_statuses,json=retryOnStatuses,proto3" json:"retry_on_statuses,omitempty"`
+	Backoff         *RetryBackoff `protobuf:"bytes,4,opt,name=backoff,proto3" json:"backoff,omitempty"`
+	// Unlimited if unset
+	Budget *RetryBudget `protobuf:"bytes,5,opt,name=budget,proto3" json:"budget,omitempty"`
+}
+
+func (x *RetryPolicy) Reset() {
+	*x = RetryPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_v1alpha1_route_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RetryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryPolicy) ProtoMessage() {}
+
+func (x *RetryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_route_v1alpha1_route_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryPolicy.ProtoReflect.Descriptor instead.
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RetryPolicy) GetMaxRetries() uint64 {
+	if x != nil && x.MaxRetries != nil {
+		return *x.MaxRetries
+	}
+	return 0
+}
+
+func (x *RetryPolicy) GetRetryOn() []RetryErrorClass {
+	if x != nil {
+		return x.RetryOn
+	}
+	return nil
+}
+
+func (x *RetryPolicy) GetRetryOnStatuses() []uint32 {
+	if x != nil {
+		return x.RetryOnStatuses
+	}
+	return nil
+}
+
+func (x *RetryPolicy) GetBackoff() *RetryBackoff {
+	if x != nil {
+		return x.Backoff
+	}
+	return nil
+}
+
+func (x *RetryPolicy) GetBudget() *RetryBudget {
+	if x != nil {
+		return x.Budget
+	}
+	return nil
+}
+
+// FirstChunkSLO demotes targets whose P95 latency of the first chunks of
+// streams exceeds the objective for a sustained window, by reducing their
+// effective weights step by step, and restores them gradually once they
+// recover.
+type FirstChunkSLO struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Objective of the P95 latency of the first chunks
+	P95 *durationpb.Duration `protobuf:"bytes,1,opt,name=p95,proto3" json:"p95,omitempty"`
+	// Window of samples the P95 latency is computed over, and the time the
+	// objective must be violated or met before the effective weight changes,
+	// default: 60s
+	Window *durationpb.Duration `protobuf:"bytes,2,opt,name=window,proto3,oneof" json:"window,omitempty"`
+	// Lower bound of the effective weight, in percent of the configured
+	// weight, default: 10
+	MinWeightPercent *uint32 `protobuf:"varint,3,opt,name=min_weight_percent,json=minWeightPercent,proto3,oneof" json:"min_weight_percent,omitempty"`
+	// Percent of the configured weight restored at each step of recovery,
+	// default: 20
+	RecoveryStepPercent *uint32 `protobuf:"varint,4,opt,name=recovery_step_percent,json=recoveryStepPercent,proto3,oneof" json:"recovery_step_percent,omitempty"`
+}
+
+func (x *FirstChunkSLO) Reset() {
+	*x = FirstChunkSLO{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_v1alpha1_route_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FirstChunkSLO) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirstChunkSLO) ProtoMessage() {}
+
+func (x *FirstChunkSLO) ProtoReflect() protoreflect.Message {
+	mi := &file_route_v1alpha1_route_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirstChunkSLO.ProtoReflect.Descriptor instead.
+func (*FirstChunkSLO) Descriptor() ([]byte, []int) {
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FirstChunkSLO) GetP95() *durationpb.Duration {
+	if x != nil {
+		return x.P95
+	}
+	return nil
+}
+
+func (x *FirstChunkSLO) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *FirstChunkSLO) GetMinWeightPercent() uint32 {
+	if x != nil && x.MinWeightPercent != nil {
+		return *x.MinWeightPercent
+	}
+	return 0
+}
+
+func (x *FirstChunkSLO) GetRecoveryStepPercent() uint32 {
+	if x != nil && x.RecoveryStepPercent != nil {
+		return *x.RecoveryStepPercent
+	}
+	return 0
+}
+
+// SeedPolicy controls the seed parameter of the chat completions and
+// completions requests of a route.
+type SeedPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode SeedPolicyMode `protobuf:"varint,1,opt,name=mode,proto3,enum=knoway.route.v1alpha1.SeedPolicyMode" json:"mode,omitempty"`
+	// Seed of the requests when mode is SEED_POLICY_MODE_FORCE
+	Seed int64 `protobuf:"varint,2,opt,name=seed,proto3" json:"seed,omitempty"`
+}
+
+func (x *SeedPolicy) Reset() {
+	*x = SeedPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_v1alpha1_route_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeedPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeedPolicy) ProtoMessage() {}
+
+func (x *SeedPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_route_v1alpha1_route_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeedPolicy.ProtoReflect.Descriptor instead.
+func (*SeedPolicy) Descriptor() ([]byte, []int) {
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SeedPolicy) GetMode() SeedPolicyMode {
+	if x != nil {
+		return x.Mode
+	}
+	return SeedPolicyMode_SEED_POLICY_MODE_UNSPECIFIED
+}
+
+func (x *SeedPolicy) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+// RouteMirror sends copies of the requests to a shadow cluster
+// asynchronously, e.g. to evaluate new models with the production traffic.
+// Responses of the copies are discarded, and they never affect the requests
+// they are copied from.
+type RouteMirror struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Destination *RouteDestination `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	// Percent of the requests mirrored, default: 100
+	Percent *uint32 `protobuf:"varint,2,opt,name=percent,proto3,oneof" json:"percent,omitempty"`
+}
+
+func (x *RouteMirror) Reset() {
+	*x = RouteMirror{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_v1alpha1_route_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RouteMirror) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteMirror) ProtoMessage() {}
+
+func (x *RouteMirror) ProtoReflect() protoreflect.Message {
+	mi := &file_route_v1alpha1_route_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteMirror.ProtoReflect.Descriptor instead.
+func (*RouteMirror) Descriptor() ([]byte, []int) {
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RouteMirror) GetDestination() *RouteDestination {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *RouteMirror) GetPercent() uint32 {
+	if x != nil && x.Percent != nil {
+		return *x.Percent
+	}
+	return 0
+}
+
+// SessionAffinity sends the requests of the same session, e.g. the turns of
+// a conversation, to the same target so that the prefix caches of the
+// upstreams are reused. Sessions are hashed over the targets by their weights,
+// only the sessions of the targets removed move to other targets. Requests
+// without sessions are load balanced as usual, and the affinity doesn't apply
+// to routes without load balance policies.
+type SessionAffinity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Header carrying the IDs of the sessions, e.g. X-Conversation-ID
+	Header string `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// user_field identifies the sessions by the user field of the requests
+	// when the header is unset or absent.
+	UserField bool `protobuf:"varint,2,opt,name=user_field,json=userField,proto3" json:"user_field,omitempty"`
+}
+
+func (x *SessionAffinity) Reset() {
+	*x = SessionAffinity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_v1alpha1_route_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionAffinity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionAffinity) ProtoMessage() {}
+
+func (x *SessionAffinity) ProtoReflect() protoreflect.Message {
+	mi := &file_route_v1alpha1_route_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionAffinity.ProtoReflect.Descriptor instead.
+func (*SessionAffinity) Descriptor() ([]byte, []int) {
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SessionAffinity) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *SessionAffinity) GetUserField() bool {
+	if x != nil {
+		return x.UserField
+	}
+	return false
+}
+
+type Route struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name              string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Matches           []*Match          `protobuf:"bytes,2,rep,name=matches,proto3" json:"matches,omitempty"`
+	Filters           []*RouteFilter    `protobuf:"bytes,3,rep,name=filters,proto3" json:"filters,omitempty"`
+	LoadBalancePolicy LoadBalancePolicy `protobuf:"varint,4,opt,name=load_balance_policy,json=loadBalancePolicy,proto3,enum=knoway.route.v1alpha1.LoadBalancePolicy" json:"load_balance_policy,omitempty"`
+	Targets           []*RouteTarget    `protobuf:"bytes,5,rep,name=targets,proto3" json:"targets,omitempty"`
+	Fallback          *RouteFallback    `protobuf:"bytes,6,opt,name=fallback,proto3,oneof" json:"fallback,omitempty"`
+	FirstChunkSlo     *FirstChunkSLO    `protobuf:"bytes,7,opt,name=first_chunk_slo,json=firstChunkSlo,proto3,oneof" json:"first_chunk_slo,omitempty"`
+	RetryPolicy       *RetryPolicy      `protobuf:"bytes,8,opt,name=retry_policy,json=retryPolicy,proto3,oneof" json:"retry_policy,omitempty"`
+	SeedPolicy        *SeedPolicy       `protobuf:"bytes,9,opt,name=seed_policy,json=seedPolicy,proto3,oneof" json:"seed_policy,omitempty"`
+	// Timeout is the latency budget of the requests, counted from when the
+	// gateway receives them. No retries or fallbacks are attempted past it,
+	// and the time remaining is forwarded to the upstreams of the clusters
+	// configured with deadline headers. 0 means no timeout.
+	Timeout         *durationpb.Duration `protobuf:"bytes,10,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Mirror          *RouteMirror         `protobuf:"bytes,11,opt,name=mirror,proto3,oneof" json:"mirror,omitempty"`
+	SessionAffinity *SessionAffinity     `protobuf:"bytes,12,opt,name=session_affinity,json=sessionAffinity,proto3,oneof" json:"session_affinity,omitempty"`
+}
+
+func (x *Route) Reset() {
+	*x = Route{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_v1alpha1_route_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Route) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Route) ProtoMessage() {}
+
+func (x *Route) ProtoReflect() protoreflect.Message {
+	mi := &file_route_v1alpha1_route_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Route.ProtoReflect.Descriptor instead.
+func (*Route) Descriptor() ([]byte, []int) {
+	return file_route_v1alpha1_route_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Route) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Route) GetMatches() []*Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *Route) GetFilters() []*RouteFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *Route) GetLoadBalancePolicy() LoadBalancePolicy {
+	if x != nil {
+		return x.LoadBalancePolicy
+	}
+	return LoadBalancePolicy_LOAD_BALANCE_POLICY_UNSPECIFIED
 }
 
 func (x *Route) GetTargets() []*RouteTarget {
@@ -538,6 +1403,48 @@ func (x *Route) GetFallback() *RouteFallback {
 	return nil
 }
 
+func (x *Route) GetFirstChunkSlo() *FirstChunkSLO {
+	if x != nil {
+		return x.FirstChunkSlo
+	}
+	return nil
+}
+
+func (x *Route) GetRetryPolicy() *RetryPolicy {
+	if x != nil {
+		return x.RetryPolicy
+	}
+	return nil
+}
+
+func (x *Route) GetSeedPolicy() *SeedPolicy {
+	if x != nil {
+		return x.SeedPolicy
+	}
+	return nil
+}
+
+func (x *Route) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Route) GetMirror() *RouteMirror {
+	if x != nil {
+		return x.Mirror
+	}
+	return nil
+}
+
+func (x *Route) GetSessionAffinity() *SessionAffinity {
+	if x != nil {
+		return x.SessionAffinity
+	}
+	return nil
+}
+
 var File_route_v1alpha1_route_proto protoreflect.FileDescriptor
 
 var file_route_v1alpha1_route_proto_rawDesc = []byte{
@@ -547,89 +1454,281 @@ var file_route_v1alpha1_route_proto_rawDesc = []byte{
 	0x68, 0x61, 0x31, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
-	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4f,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x72,
 	0x0a, 0x0b, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a,
 	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
 	0x65, 0x12, 0x2c, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22,
-	0x48, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x16,
-	0x0a, 0x05, 0x65, 0x78, 0x61, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x05, 0x65, 0x78, 0x61, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
-	0x42, 0x07, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x22, 0x7f, 0x0a, 0x05, 0x4d, 0x61, 0x74,
-	0x63, 0x68, 0x12, 0x38, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x22, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
-	0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x3c, 0x0a, 0x07,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x21, 0x0a, 0x0c, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c,
+	0x61, 0x67, 0x22, 0x48, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x16, 0x0a, 0x05, 0x65, 0x78, 0x61, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x05, 0x65, 0x78, 0x61, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x06, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x42, 0x07, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x22, 0x5b, 0x0a, 0x0b,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x38, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xd5, 0x03, 0x0a, 0x05, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x38, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x3c, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3c, 0x0a, 0x07, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b,
+	0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x40, 0x0a, 0x0a, 0x61, 0x70, 0x69,
+	0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
 	0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x61,
 	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x74, 0x63,
-	0x68, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x8c, 0x01, 0x0a, 0x10, 0x52,
-	0x6f, 0x75, 0x74, 0x65, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x18, 0x0a,
-	0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12, 0x1b, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68,
-	0x74, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x42, 0x09,
-	0x0a, 0x07, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x58, 0x0a, 0x0b, 0x52, 0x6f, 0x75,
-	0x74, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x49, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74,
-	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e,
-	0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x44, 0x65, 0x73, 0x74, 0x69,
-	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x22, 0xde, 0x01, 0x0a, 0x0d, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x46, 0x61, 0x6c,
-	0x6c, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x3b, 0x0a, 0x09, 0x70, 0x72, 0x65, 0x5f, 0x64, 0x65, 0x6c,
-	0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x65, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x88,
-	0x01, 0x01, 0x12, 0x3d, 0x0a, 0x0a, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x48, 0x01, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x74, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x88, 0x01,
-	0x01, 0x12, 0x24, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x48, 0x02, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74,
-	0x72, 0x69, 0x65, 0x73, 0x88, 0x01, 0x01, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x72, 0x65, 0x5f,
-	0x64, 0x65, 0x6c, 0x61, 0x79, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x64,
-	0x65, 0x6c, 0x61, 0x79, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x74,
-	0x72, 0x69, 0x65, 0x73, 0x22, 0xfd, 0x02, 0x0a, 0x05, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75,
-	0x74, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63,
-	0x68, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x07, 0x66, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b, 0x6e,
+	0x68, 0x52, 0x08, 0x61, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x3b, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b,
+	0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x3f, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x06, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x68, 0x61, 0x73, 0x5f, 0x74, 0x6f,
+	0x6f, 0x6c, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x48, 0x01, 0x52, 0x08, 0x68, 0x61, 0x73,
+	0x54, 0x6f, 0x6f, 0x6c, 0x73, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x68, 0x61, 0x73, 0x5f, 0x74, 0x6f, 0x6f, 0x6c,
+	0x73, 0x22, 0x8c, 0x01, 0x0a, 0x10, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x44, 0x65, 0x73, 0x74, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12, 0x1b,
+	0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00,
+	0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6c,
+	0x75, 0x73, 0x74, 0x65, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x22, 0x58, 0x0a, 0x0b, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12,
+	0x49, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f,
+	0x75, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x9d, 0x01, 0x0a, 0x0d, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x46, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x3f, 0x0a, 0x05,
+	0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x6b, 0x6e,
 	0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
-	0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x58, 0x0a, 0x13, 0x6c, 0x6f, 0x61, 0x64,
-	0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72,
-	0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x6f,
-	0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
-	0x11, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x6f, 0x6c, 0x69,
-	0x63, 0x79, 0x12, 0x3c, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75,
+	0x68, 0x61, 0x31, 0x2e, 0x46, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x4b, 0x0a,
+	0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75,
 	0x74, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x74,
-	0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73,
-	0x12, 0x45, 0x0a, 0x08, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xa1, 0x02, 0x0a, 0x0d, 0x52,
+	0x6f, 0x75, 0x74, 0x65, 0x46, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x3b, 0x0a, 0x09,
+	0x70, 0x72, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72,
+	0x65, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x88, 0x01, 0x01, 0x12, 0x3d, 0x0a, 0x0a, 0x70, 0x6f, 0x73,
+	0x74, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x01, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x74,
+	0x44, 0x65, 0x6c, 0x61, 0x79, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f,
+	0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x48, 0x02, 0x52,
+	0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x41,
+	0x0a, 0x09, 0x6f, 0x6e, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x46,
+	0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x08, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x72, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x42,
+	0x0d, 0x0a, 0x0b, 0x5f, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x42, 0x0e,
+	0x0a, 0x0c, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0xb9,
+	0x01, 0x0a, 0x0c, 0x52, 0x65, 0x74, 0x72, 0x79, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12,
+	0x43, 0x0a, 0x0d, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x48, 0x00, 0x52, 0x0c, 0x62, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x88, 0x01, 0x01, 0x12, 0x41, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x01, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x88, 0x01, 0x01, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x62, 0x61, 0x73, 0x65,
+	0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x6d, 0x61,
+	0x78, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0xca, 0x01, 0x0a, 0x0b, 0x52,
+	0x65, 0x74, 0x72, 0x79, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65,
+	0x74, 0x72, 0x79, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0c, 0x72, 0x65, 0x74, 0x72, 0x79, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12,
+	0x38, 0x0a, 0x16, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x70,
+	0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48,
+	0x00, 0x52, 0x13, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x50, 0x65, 0x72,
+	0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x88, 0x01, 0x01, 0x12, 0x36, 0x0a, 0x06, 0x77, 0x69, 0x6e,
+	0x64, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x48, 0x01, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x88, 0x01,
+	0x01, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x42, 0x09, 0x0a, 0x07,
+	0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22, 0xad, 0x02, 0x0a, 0x0b, 0x52, 0x65, 0x74, 0x72,
+	0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x24, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72,
+	0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x0a,
+	0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x41, 0x0a,
+	0x08, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32,
+	0x26, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x07, 0x72, 0x65, 0x74, 0x72, 0x79, 0x4f, 0x6e,
+	0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x74,
+	0x72, 0x79, 0x4f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x07,
+	0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x42, 0x61, 0x63, 0x6b, 0x6f,
+	0x66, 0x66, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x3a, 0x0a, 0x06, 0x62,
+	0x75, 0x64, 0x67, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x52,
+	0x06, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x6d, 0x61, 0x78, 0x5f,
+	0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x9c, 0x02, 0x0a, 0x0d, 0x46, 0x69, 0x72, 0x73,
+	0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x53, 0x4c, 0x4f, 0x12, 0x2b, 0x0a, 0x03, 0x70, 0x39, 0x35,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x03, 0x70, 0x39, 0x35, 0x12, 0x36, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x48, 0x00, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x88, 0x01, 0x01, 0x12, 0x31,
+	0x0a, 0x12, 0x6d, 0x69, 0x6e, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x70, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x10, 0x6d, 0x69,
+	0x6e, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x88, 0x01,
+	0x01, 0x12, 0x37, 0x0a, 0x15, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x73, 0x74,
+	0x65, 0x70, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x48, 0x02, 0x52, 0x13, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x53, 0x74, 0x65, 0x70,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x77,
+	0x69, 0x6e, 0x64, 0x6f, 0x77, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x77, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x42, 0x18, 0x0a, 0x16,
+	0x5f, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x73, 0x74, 0x65, 0x70, 0x5f, 0x70,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0x5b, 0x0a, 0x0a, 0x53, 0x65, 0x65, 0x64, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x12, 0x39, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x25, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x65, 0x64, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73,
+	0x65, 0x65, 0x64, 0x22, 0x83, 0x01, 0x0a, 0x0b, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x4d, 0x69, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x49, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61,
+	0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d,
+	0x0a, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48,
+	0x00, 0x52, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a,
+	0x08, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0x48, 0x0a, 0x0f, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x22, 0x88, 0x07, 0x0a, 0x05, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x36, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x07, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b, 0x6e, 0x6f,
+	0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x07,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x58, 0x0a, 0x13, 0x6c, 0x6f, 0x61, 0x64, 0x5f,
+	0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f,
+	0x75, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x6f, 0x61,
+	0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x11,
+	0x6c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x12, 0x3c, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74,
 	0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65,
-	0x46, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x08, 0x66, 0x61, 0x6c, 0x6c,
-	0x62, 0x61, 0x63, 0x6b, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x66, 0x61, 0x6c, 0x6c,
-	0x62, 0x61, 0x63, 0x6b, 0x2a, 0x84, 0x01, 0x0a, 0x11, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c,
-	0x61, 0x6e, 0x63, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x23, 0x0a, 0x1f, 0x4c, 0x4f,
-	0x41, 0x44, 0x5f, 0x42, 0x41, 0x4c, 0x41, 0x4e, 0x43, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43,
-	0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
-	0x23, 0x0a, 0x1f, 0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x42, 0x41, 0x4c, 0x41, 0x4e, 0x43, 0x45, 0x5f,
-	0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x52, 0x4f, 0x42,
-	0x49, 0x4e, 0x10, 0x01, 0x12, 0x25, 0x0a, 0x21, 0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x42, 0x41, 0x4c,
-	0x41, 0x4e, 0x43, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x4c, 0x45, 0x41, 0x53,
-	0x54, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x02, 0x42, 0x1f, 0x5a, 0x1d, 0x6b,
-	0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72, 0x6f,
-	0x75, 0x74, 0x65, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12,
+	0x45, 0x0a, 0x08, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x46,
+	0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x08, 0x66, 0x61, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x88, 0x01, 0x01, 0x12, 0x51, 0x0a, 0x0f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f,
+	0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x73, 0x6c, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x46, 0x69, 0x72, 0x73, 0x74, 0x43, 0x68, 0x75,
+	0x6e, 0x6b, 0x53, 0x4c, 0x4f, 0x48, 0x01, 0x52, 0x0d, 0x66, 0x69, 0x72, 0x73, 0x74, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x53, 0x6c, 0x6f, 0x88, 0x01, 0x01, 0x12, 0x4a, 0x0a, 0x0c, 0x72, 0x65, 0x74,
+	0x72, 0x79, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76,
+	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x48, 0x02, 0x52, 0x0b, 0x72, 0x65, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x88, 0x01, 0x01, 0x12, 0x47, 0x0a, 0x0b, 0x73, 0x65, 0x65, 0x64, 0x5f, 0x70, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6b, 0x6e, 0x6f,
+	0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x53, 0x65, 0x65, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x48, 0x03, 0x52,
+	0x0a, 0x73, 0x65, 0x65, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x88, 0x01, 0x01, 0x12, 0x33,
+	0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x12, 0x3f, 0x0a, 0x06, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x74,
+	0x65, 0x4d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x04, 0x52, 0x06, 0x6d, 0x69, 0x72, 0x72, 0x6f,
+	0x72, 0x88, 0x01, 0x01, 0x12, 0x56, 0x0a, 0x10, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
+	0x61, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26,
+	0x2e, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x66,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x48, 0x05, 0x52, 0x0f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x41, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09,
+	0x5f, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x66, 0x69,
+	0x72, 0x73, 0x74, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x73, 0x6c, 0x6f, 0x42, 0x0f, 0x0a,
+	0x0d, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x42, 0x0e,
+	0x0a, 0x0c, 0x5f, 0x73, 0x65, 0x65, 0x64, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x42, 0x09,
+	0x0a, 0x07, 0x5f, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x66, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x79, 0x2a, 0xab,
+	0x01, 0x0a, 0x11, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x12, 0x23, 0x0a, 0x1f, 0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x42, 0x41, 0x4c,
+	0x41, 0x4e, 0x43, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x23, 0x0a, 0x1f, 0x4c, 0x4f, 0x41,
+	0x44, 0x5f, 0x42, 0x41, 0x4c, 0x41, 0x4e, 0x43, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59,
+	0x5f, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x52, 0x4f, 0x42, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x25,
+	0x0a, 0x21, 0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x42, 0x41, 0x4c, 0x41, 0x4e, 0x43, 0x45, 0x5f, 0x50,
+	0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x4c, 0x45, 0x41, 0x53, 0x54, 0x5f, 0x52, 0x45, 0x51, 0x55,
+	0x45, 0x53, 0x54, 0x10, 0x02, 0x12, 0x25, 0x0a, 0x21, 0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x42, 0x41,
+	0x4c, 0x41, 0x4e, 0x43, 0x45, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x4c, 0x45, 0x41,
+	0x53, 0x54, 0x5f, 0x4c, 0x41, 0x54, 0x45, 0x4e, 0x43, 0x59, 0x10, 0x03, 0x2a, 0xb7, 0x01, 0x0a,
+	0x12, 0x46, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6c,
+	0x61, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x20, 0x46, 0x41, 0x4c, 0x4c, 0x42, 0x41, 0x43, 0x4b, 0x5f,
+	0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x27, 0x0a, 0x23, 0x46, 0x41, 0x4c,
+	0x4c, 0x42, 0x41, 0x43, 0x4b, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4c, 0x41, 0x53,
+	0x53, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x45, 0x4e, 0x54, 0x5f, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52,
+	0x10, 0x01, 0x12, 0x2b, 0x0a, 0x27, 0x46, 0x41, 0x4c, 0x4c, 0x42, 0x41, 0x43, 0x4b, 0x5f, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x49, 0x4e, 0x53, 0x55, 0x46,
+	0x46, 0x49, 0x43, 0x49, 0x45, 0x4e, 0x54, 0x5f, 0x51, 0x55, 0x4f, 0x54, 0x41, 0x10, 0x02, 0x12,
+	0x25, 0x0a, 0x21, 0x46, 0x41, 0x4c, 0x4c, 0x42, 0x41, 0x43, 0x4b, 0x5f, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d,
+	0x49, 0x54, 0x45, 0x44, 0x10, 0x03, 0x2a, 0xc5, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x74, 0x72, 0x79,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x21, 0x0a, 0x1d, 0x52, 0x45,
+	0x54, 0x52, 0x59, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x22, 0x0a,
+	0x1e, 0x52, 0x45, 0x54, 0x52, 0x59, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4c, 0x41,
+	0x53, 0x53, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45, 0x52, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10,
+	0x01, 0x12, 0x22, 0x0a, 0x1e, 0x52, 0x45, 0x54, 0x52, 0x59, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52,
+	0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49,
+	0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x28, 0x0a, 0x24, 0x52, 0x45, 0x54, 0x52, 0x59, 0x5f, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45,
+	0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x10, 0x03, 0x12,
+	0x1d, 0x0a, 0x19, 0x52, 0x45, 0x54, 0x52, 0x59, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43,
+	0x4c, 0x41, 0x53, 0x53, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x04, 0x2a, 0x8d,
+	0x01, 0x0a, 0x0e, 0x53, 0x65, 0x65, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x20, 0x0a, 0x1c, 0x53, 0x45, 0x45, 0x44, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59,
+	0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x21, 0x0a, 0x1d, 0x53, 0x45, 0x45, 0x44, 0x5f, 0x50, 0x4f, 0x4c, 0x49,
+	0x43, 0x59, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x50, 0x41, 0x53, 0x53, 0x5f, 0x54, 0x48, 0x52,
+	0x4f, 0x55, 0x47, 0x48, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45, 0x45, 0x44, 0x5f, 0x50,
+	0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x46, 0x4f, 0x52, 0x43, 0x45,
+	0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45, 0x45, 0x44, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43,
+	0x59, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x54, 0x52, 0x49, 0x50, 0x10, 0x03, 0x42, 0x1f,
+	0x5a, 0x1d, 0x6b, 0x6e, 0x6f, 0x77, 0x61, 0x79, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -644,37 +1743,73 @@ func file_route_v1alpha1_route_proto_rawDescGZIP() []byte {
 	return file_route_v1alpha1_route_proto_rawDescData
 }
 
-var file_route_v1alpha1_route_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_route_v1alpha1_route_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_route_v1alpha1_route_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_route_v1alpha1_route_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_route_v1alpha1_route_proto_goTypes = []interface{}{
 	(LoadBalancePolicy)(0),      // 0: knoway.route.v1alpha1.LoadBalancePolicy
-	(*RouteFilter)(nil),         // 1: knoway.route.v1alpha1.RouteFilter
-	(*StringMatch)(nil),         // 2: knoway.route.v1alpha1.StringMatch
-	(*Match)(nil),               // 3: knoway.route.v1alpha1.Match
-	(*RouteDestination)(nil),    // 4: knoway.route.v1alpha1.RouteDestination
-	(*RouteTarget)(nil),         // 5: knoway.route.v1alpha1.RouteTarget
-	(*RouteFallback)(nil),       // 6: knoway.route.v1alpha1.RouteFallback
-	(*Route)(nil),               // 7: knoway.route.v1alpha1.Route
-	(*anypb.Any)(nil),           // 8: google.protobuf.Any
-	(*durationpb.Duration)(nil), // 9: google.protobuf.Duration
+	(FallbackErrorClass)(0),     // 1: knoway.route.v1alpha1.FallbackErrorClass
+	(RetryErrorClass)(0),        // 2: knoway.route.v1alpha1.RetryErrorClass
+	(SeedPolicyMode)(0),         // 3: knoway.route.v1alpha1.SeedPolicyMode
+	(*RouteFilter)(nil),         // 4: knoway.route.v1alpha1.RouteFilter
+	(*StringMatch)(nil),         // 5: knoway.route.v1alpha1.StringMatch
+	(*HeaderMatch)(nil),         // 6: knoway.route.v1alpha1.HeaderMatch
+	(*Match)(nil),               // 7: knoway.route.v1alpha1.Match
+	(*RouteDestination)(nil),    // 8: knoway.route.v1alpha1.RouteDestination
+	(*RouteTarget)(nil),         // 9: knoway.route.v1alpha1.RouteTarget
+	(*ErrorFallback)(nil),       // 10: knoway.route.v1alpha1.ErrorFallback
+	(*RouteFallback)(nil),       // 11: knoway.route.v1alpha1.RouteFallback
+	(*RetryBackoff)(nil),        // 12: knoway.route.v1alpha1.RetryBackoff
+	(*RetryBudget)(nil),         // 13: knoway.route.v1alpha1.RetryBudget
+	(*RetryPolicy)(nil),         // 14: knoway.route.v1alpha1.RetryPolicy
+	(*FirstChunkSLO)(nil),       // 15: knoway.route.v1alpha1.FirstChunkSLO
+	(*SeedPolicy)(nil),          // 16: knoway.route.v1alpha1.SeedPolicy
+	(*RouteMirror)(nil),         // 17: knoway.route.v1alpha1.RouteMirror
+	(*SessionAffinity)(nil),     // 18: knoway.route.v1alpha1.SessionAffinity
+	(*Route)(nil),               // 19: knoway.route.v1alpha1.Route
+	(*anypb.Any)(nil),           // 20: google.protobuf.Any
+	(*durationpb.Duration)(nil), // 21: google.protobuf.Duration
 }
 var file_route_v1alpha1_route_proto_depIdxs = []int32{
-	8,  // 0: knoway.route.v1alpha1.RouteFilter.config:type_name -> google.protobuf.Any
-	2,  // 1: knoway.route.v1alpha1.Match.model:type_name -> knoway.route.v1alpha1.StringMatch
-	2,  // 2: knoway.route.v1alpha1.Match.message:type_name -> knoway.route.v1alpha1.StringMatch
-	4,  // 3: knoway.route.v1alpha1.RouteTarget.destination:type_name -> knoway.route.v1alpha1.RouteDestination
-	9,  // 4: knoway.route.v1alpha1.RouteFallback.pre_delay:type_name -> google.protobuf.Duration
-	9,  // 5: knoway.route.v1alpha1.RouteFallback.post_delay:type_name -> google.protobuf.Duration
-	3,  // 6: knoway.route.v1alpha1.Route.matches:type_name -> knoway.route.v1alpha1.Match
-	1,  // 7: knoway.route.v1alpha1.Route.filters:type_name -> knoway.route.v1alpha1.RouteFilter
-	0,  // 8: knoway.route.v1alpha1.Route.load_balance_policy:type_name -> knoway.route.v1alpha1.LoadBalancePolicy
-	5,  // 9: knoway.route.v1alpha1.Route.targets:type_name -> knoway.route.v1alpha1.RouteTarget
-	6,  // 10: knoway.route.v1alpha1.Route.fallback:type_name -> knoway.route.v1alpha1.RouteFallback
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	20, // 0: knoway.route.v1alpha1.RouteFilter.config:type_name -> google.protobuf.Any
+	5,  // 1: knoway.route.v1alpha1.HeaderMatch.value:type_name -> knoway.route.v1alpha1.StringMatch
+	5,  // 2: knoway.route.v1alpha1.Match.model:type_name -> knoway.route.v1alpha1.StringMatch
+	5,  // 3: knoway.route.v1alpha1.Match.message:type_name -> knoway.route.v1alpha1.StringMatch
+	6,  // 4: knoway.route.v1alpha1.Match.headers:type_name -> knoway.route.v1alpha1.HeaderMatch
+	5,  // 5: knoway.route.v1alpha1.Match.api_key_id:type_name -> knoway.route.v1alpha1.StringMatch
+	5,  // 6: knoway.route.v1alpha1.Match.user_id:type_name -> knoway.route.v1alpha1.StringMatch
+	5,  // 7: knoway.route.v1alpha1.Match.tenant_id:type_name -> knoway.route.v1alpha1.StringMatch
+	8,  // 8: knoway.route.v1alpha1.RouteTarget.destination:type_name -> knoway.route.v1alpha1.RouteDestination
+	1,  // 9: knoway.route.v1alpha1.ErrorFallback.class:type_name -> knoway.route.v1alpha1.FallbackErrorClass
+	8,  // 10: knoway.route.v1alpha1.ErrorFallback.destinations:type_name -> knoway.route.v1alpha1.RouteDestination
+	21, // 11: knoway.route.v1alpha1.RouteFallback.pre_delay:type_name -> google.protobuf.Duration
+	21, // 12: knoway.route.v1alpha1.RouteFallback.post_delay:type_name -> google.protobuf.Duration
+	10, // 13: knoway.route.v1alpha1.RouteFallback.on_errors:type_name -> knoway.route.v1alpha1.ErrorFallback
+	21, // 14: knoway.route.v1alpha1.RetryBackoff.base_interval:type_name -> google.protobuf.Duration
+	21, // 15: knoway.route.v1alpha1.RetryBackoff.max_interval:type_name -> google.protobuf.Duration
+	21, // 16: knoway.route.v1alpha1.RetryBudget.window:type_name -> google.protobuf.Duration
+	2,  // 17: knoway.route.v1alpha1.RetryPolicy.retry_on:type_name -> knoway.route.v1alpha1.RetryErrorClass
+	12, // 18: knoway.route.v1alpha1.RetryPolicy.backoff:type_name -> knoway.route.v1alpha1.RetryBackoff
+	13, // 19: knoway.route.v1alpha1.RetryPolicy.budget:type_name -> knoway.route.v1alpha1.RetryBudget
+	21, // 20: knoway.route.v1alpha1.FirstChunkSLO.p95:type_name -> google.protobuf.Duration
+	21, // 21: knoway.route.v1alpha1.FirstChunkSLO.window:type_name -> google.protobuf.Duration
+	3,  // 22: knoway.route.v1alpha1.SeedPolicy.mode:type_name -> knoway.route.v1alpha1.SeedPolicyMode
+	8,  // 23: knoway.route.v1alpha1.RouteMirror.destination:type_name -> knoway.route.v1alpha1.RouteDestination
+	7,  // 24: knoway.route.v1alpha1.Route.matches:type_name -> knoway.route.v1alpha1.Match
+	4,  // 25: knoway.route.v1alpha1.Route.filters:type_name -> knoway.route.v1alpha1.RouteFilter
+	0,  // 26: knoway.route.v1alpha1.Route.load_balance_policy:type_name -> knoway.route.v1alpha1.LoadBalancePolicy
+	9,  // 27: knoway.route.v1alpha1.Route.targets:type_name -> knoway.route.v1alpha1.RouteTarget
+	11, // 28: knoway.route.v1alpha1.Route.fallback:type_name -> knoway.route.v1alpha1.RouteFallback
+	15, // 29: knoway.route.v1alpha1.Route.first_chunk_slo:type_name -> knoway.route.v1alpha1.FirstChunkSLO
+	14, // 30: knoway.route.v1alpha1.Route.retry_policy:type_name -> knoway.route.v1alpha1.RetryPolicy
+	16, // 31: knoway.route.v1alpha1.Route.seed_policy:type_name -> knoway.route.v1alpha1.SeedPolicy
+	21, // 32: knoway.route.v1alpha1.Route.timeout:type_name -> google.protobuf.Duration
+	17, // 33: knoway.route.v1alpha1.Route.mirror:type_name -> knoway.route.v1alpha1.RouteMirror
+	18, // 34: knoway.route.v1alpha1.Route.session_affinity:type_name -> knoway.route.v1alpha1.SessionAffinity
+	35, // [35:35] is the sub-list for method output_type
+	35, // [35:35] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_route_v1alpha1_route_proto_init() }
@@ -708,7 +1843,7 @@ func file_route_v1alpha1_route_proto_init() {
 			}
 		}
 		file_route_v1alpha1_route_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Match); i {
+			switch v := v.(*HeaderMatch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -720,7 +1855,7 @@ func file_route_v1alpha1_route_proto_init() {
 			}
 		}
 		file_route_v1alpha1_route_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RouteDestination); i {
+			switch v := v.(*Match); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -732,7 +1867,7 @@ func file_route_v1alpha1_route_proto_init() {
 			}
 		}
 		file_route_v1alpha1_route_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RouteTarget); i {
+			switch v := v.(*RouteDestination); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -744,7 +1879,7 @@ func file_route_v1alpha1_route_proto_init() {
 			}
 		}
 		file_route_v1alpha1_route_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RouteFallback); i {
+			switch v := v.(*RouteTarget); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -756,6 +1891,114 @@ func file_route_v1alpha1_route_proto_init() {
 			}
 		}
 		file_route_v1alpha1_route_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErrorFallback); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_v1alpha1_route_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteFallback); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_v1alpha1_route_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetryBackoff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_v1alpha1_route_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetryBudget); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_v1alpha1_route_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetryPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_v1alpha1_route_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FirstChunkSLO); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_v1alpha1_route_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeedPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_v1alpha1_route_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RouteMirror); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_v1alpha1_route_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionAffinity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_v1alpha1_route_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Route); i {
 			case 0:
 				return &v.state
@@ -773,15 +2016,21 @@ func file_route_v1alpha1_route_proto_init() {
 		(*StringMatch_Prefix)(nil),
 	}
 	file_route_v1alpha1_route_proto_msgTypes[3].OneofWrappers = []interface{}{}
-	file_route_v1alpha1_route_proto_msgTypes[5].OneofWrappers = []interface{}{}
-	file_route_v1alpha1_route_proto_msgTypes[6].OneofWrappers = []interface{}{}
+	file_route_v1alpha1_route_proto_msgTypes[4].OneofWrappers = []interface{}{}
+	file_route_v1alpha1_route_proto_msgTypes[7].OneofWrappers = []interface{}{}
+	file_route_v1alpha1_route_proto_msgTypes[8].OneofWrappers = []interface{}{}
+	file_route_v1alpha1_route_proto_msgTypes[9].OneofWrappers = []interface{}{}
+	file_route_v1alpha1_route_proto_msgTypes[10].OneofWrappers = []interface{}{}
+	file_route_v1alpha1_route_proto_msgTypes[11].OneofWrappers = []interface{}{}
+	file_route_v1alpha1_route_proto_msgTypes[13].OneofWrappers = []interface{}{}
+	file_route_v1alpha1_route_proto_msgTypes[15].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_route_v1alpha1_route_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   7,
+			NumEnums:      4,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
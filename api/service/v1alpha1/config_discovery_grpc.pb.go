@@ -0,0 +1,146 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: service/v1alpha1/config_discovery.proto
+
+package v1alpha1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ConfigDiscoveryService_StreamConfig_FullMethodName = "/knoway.service.v1alpha1.ConfigDiscoveryService/StreamConfig"
+)
+
+// ConfigDiscoveryServiceClient is the client API for ConfigDiscoveryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ConfigDiscoveryServiceClient interface {
+	// StreamConfig streams the configuration once subscribed, and whenever it
+	// changes.
+	StreamConfig(ctx context.Context, opts ...grpc.CallOption) (ConfigDiscoveryService_StreamConfigClient, error)
+}
+
+type configDiscoveryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConfigDiscoveryServiceClient(cc grpc.ClientConnInterface) ConfigDiscoveryServiceClient {
+	return &configDiscoveryServiceClient{cc}
+}
+
+func (c *configDiscoveryServiceClient) StreamConfig(ctx context.Context, opts ...grpc.CallOption) (ConfigDiscoveryService_StreamConfigClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ConfigDiscoveryService_ServiceDesc.Streams[0], ConfigDiscoveryService_StreamConfig_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &configDiscoveryServiceStreamConfigClient{stream}
+	return x, nil
+}
+
+type ConfigDiscoveryService_StreamConfigClient interface {
+	Send(*DiscoveryRequest) error
+	Recv() (*DiscoveryResponse, error)
+	grpc.ClientStream
+}
+
+type configDiscoveryServiceStreamConfigClient struct {
+	grpc.ClientStream
+}
+
+func (x *configDiscoveryServiceStreamConfigClient) Send(m *DiscoveryRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *configDiscoveryServiceStreamConfigClient) Recv() (*DiscoveryResponse, error) {
+	m := new(DiscoveryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ConfigDiscoveryServiceServer is the server API for ConfigDiscoveryService service.
+// All implementations must embed UnimplementedConfigDiscoveryServiceServer
+// for forward compatibility
+type ConfigDiscoveryServiceServer interface {
+	// StreamConfig streams the configuration once subscribed, and whenever it
+	// changes.
+	StreamConfig(ConfigDiscoveryService_StreamConfigServer) error
+	mustEmbedUnimplementedConfigDiscoveryServiceServer()
+}
+
+// UnimplementedConfigDiscoveryServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedConfigDiscoveryServiceServer struct {
+}
+
+func (UnimplementedConfigDiscoveryServiceServer) StreamConfig(ConfigDiscoveryService_StreamConfigServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamConfig not implemented")
+}
+func (UnimplementedConfigDiscoveryServiceServer) mustEmbedUnimplementedConfigDiscoveryServiceServer() {
+}
+
+// UnsafeConfigDiscoveryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConfigDiscoveryServiceServer will
+// result in compilation errors.
+type UnsafeConfigDiscoveryServiceServer interface {
+	mustEmbedUnimplementedConfigDiscoveryServiceServer()
+}
+
+func RegisterConfigDiscoveryServiceServer(s grpc.ServiceRegistrar, srv ConfigDiscoveryServiceServer) {
+	s.RegisterService(&ConfigDiscoveryService_ServiceDesc, srv)
+}
+
+func _ConfigDiscoveryService_StreamConfig_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ConfigDiscoveryServiceServer).StreamConfig(&configDiscoveryServiceStreamConfigServer{stream})
+}
+
+type ConfigDiscoveryService_StreamConfigServer interface {
+	Send(*DiscoveryResponse) error
+	Recv() (*DiscoveryRequest, error)
+	grpc.ServerStream
+}
+
+type configDiscoveryServiceStreamConfigServer struct {
+	grpc.ServerStream
+}
+
+func (x *configDiscoveryServiceStreamConfigServer) Send(m *DiscoveryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *configDiscoveryServiceStreamConfigServer) Recv() (*DiscoveryRequest, error) {
+	m := new(DiscoveryRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ConfigDiscoveryService_ServiceDesc is the grpc.ServiceDesc for ConfigDiscoveryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ConfigDiscoveryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "knoway.service.v1alpha1.ConfigDiscoveryService",
+	HandlerType: (*ConfigDiscoveryServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamConfig",
+			Handler:       _ConfigDiscoveryService_StreamConfig_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "service/v1alpha1/config_discovery.proto",
+}
@@ -0,0 +1,110 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: service/v1alpha1/external_processor.proto
+
+package v1alpha1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ExternalProcessorService_Process_FullMethodName = "/knoway.service.v1alpha1.ExternalProcessorService/Process"
+)
+
+// ExternalProcessorServiceClient is the client API for ExternalProcessorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExternalProcessorServiceClient interface {
+	Process(ctx context.Context, in *ProcessingRequest, opts ...grpc.CallOption) (*ProcessingResponse, error)
+}
+
+type externalProcessorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExternalProcessorServiceClient(cc grpc.ClientConnInterface) ExternalProcessorServiceClient {
+	return &externalProcessorServiceClient{cc}
+}
+
+func (c *externalProcessorServiceClient) Process(ctx context.Context, in *ProcessingRequest, opts ...grpc.CallOption) (*ProcessingResponse, error) {
+	out := new(ProcessingResponse)
+	err := c.cc.Invoke(ctx, ExternalProcessorService_Process_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExternalProcessorServiceServer is the server API for ExternalProcessorService service.
+// All implementations must embed UnimplementedExternalProcessorServiceServer
+// for forward compatibility
+type ExternalProcessorServiceServer interface {
+	Process(context.Context, *ProcessingRequest) (*ProcessingResponse, error)
+	mustEmbedUnimplementedExternalProcessorServiceServer()
+}
+
+// UnimplementedExternalProcessorServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedExternalProcessorServiceServer struct {
+}
+
+func (UnimplementedExternalProcessorServiceServer) Process(context.Context, *ProcessingRequest) (*ProcessingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Process not implemented")
+}
+func (UnimplementedExternalProcessorServiceServer) mustEmbedUnimplementedExternalProcessorServiceServer() {
+}
+
+// UnsafeExternalProcessorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExternalProcessorServiceServer will
+// result in compilation errors.
+type UnsafeExternalProcessorServiceServer interface {
+	mustEmbedUnimplementedExternalProcessorServiceServer()
+}
+
+func RegisterExternalProcessorServiceServer(s grpc.ServiceRegistrar, srv ExternalProcessorServiceServer) {
+	s.RegisterService(&ExternalProcessorService_ServiceDesc, srv)
+}
+
+func _ExternalProcessorService_Process_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalProcessorServiceServer).Process(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExternalProcessorService_Process_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalProcessorServiceServer).Process(ctx, req.(*ProcessingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExternalProcessorService_ServiceDesc is the grpc.ServiceDesc for ExternalProcessorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExternalProcessorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "knoway.service.v1alpha1.ExternalProcessorService",
+	HandlerType: (*ExternalProcessorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Process",
+			Handler:    _ExternalProcessorService_Process_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "service/v1alpha1/external_processor.proto",
+}
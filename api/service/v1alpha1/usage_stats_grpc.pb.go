@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion7
 
 const (
 	UsageStatsService_UsageReport_FullMethodName = "/knoway.service.v1alpha1.UsageStatsService/UsageReport"
+	UsageStatsService_ExportUsage_FullMethodName = "/knoway.service.v1alpha1.UsageStatsService/ExportUsage"
 )
 
 // UsageStatsServiceClient is the client API for UsageStatsService service.
@@ -27,6 +28,10 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type UsageStatsServiceClient interface {
 	UsageReport(ctx context.Context, in *UsageReportRequest, opts ...grpc.CallOption) (*UsageReportResponse, error)
+	// ExportUsage receives the usage records in batches, records are
+	// delivered at least once, an error fails the whole batch which will be
+	// retried.
+	ExportUsage(ctx context.Context, in *ExportUsageRequest, opts ...grpc.CallOption) (*ExportUsageResponse, error)
 }
 
 type usageStatsServiceClient struct {
@@ -46,11 +51,24 @@ func (c *usageStatsServiceClient) UsageReport(ctx context.Context, in *UsageRepo
 	return out, nil
 }
 
+func (c *usageStatsServiceClient) ExportUsage(ctx context.Context, in *ExportUsageRequest, opts ...grpc.CallOption) (*ExportUsageResponse, error) {
+	out := new(ExportUsageResponse)
+	err := c.cc.Invoke(ctx, UsageStatsService_ExportUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UsageStatsServiceServer is the server API for UsageStatsService service.
 // All implementations must embed UnimplementedUsageStatsServiceServer
 // for forward compatibility
 type UsageStatsServiceServer interface {
 	UsageReport(context.Context, *UsageReportRequest) (*UsageReportResponse, error)
+	// ExportUsage receives the usage records in batches, records are
+	// delivered at least once, an error fails the whole batch which will be
+	// retried.
+	ExportUsage(context.Context, *ExportUsageRequest) (*ExportUsageResponse, error)
 	mustEmbedUnimplementedUsageStatsServiceServer()
 }
 
@@ -61,6 +79,9 @@ type UnimplementedUsageStatsServiceServer struct {
 func (UnimplementedUsageStatsServiceServer) UsageReport(context.Context, *UsageReportRequest) (*UsageReportResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UsageReport not implemented")
 }
+func (UnimplementedUsageStatsServiceServer) ExportUsage(context.Context, *ExportUsageRequest) (*ExportUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUsage not implemented")
+}
 func (UnimplementedUsageStatsServiceServer) mustEmbedUnimplementedUsageStatsServiceServer() {}
 
 // UnsafeUsageStatsServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -92,6 +113,24 @@ func _UsageStatsService_UsageReport_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UsageStatsService_ExportUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsageStatsServiceServer).ExportUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UsageStatsService_ExportUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsageStatsServiceServer).ExportUsage(ctx, req.(*ExportUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UsageStatsService_ServiceDesc is the grpc.ServiceDesc for UsageStatsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -103,6 +142,10 @@ var UsageStatsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UsageReport",
 			Handler:    _UsageStatsService_UsageReport_Handler,
 		},
+		{
+			MethodName: "ExportUsage",
+			Handler:    _UsageStatsService_ExportUsage_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "service/v1alpha1/usage_stats.proto",
@@ -0,0 +1,125 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knoway.dev/api/v1alpha2"
+	"knoway.dev/api/v1beta1"
+)
+
+func TestLLMBackend_Conversion(t *testing.T) {
+	backend := &LLMBackend{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4o", ResourceVersion: "42"},
+		Spec: LLMBackendSpec{
+			ModelName: lo.ToPtr("gpt-4o"),
+			Provider:  ProviderOpenAI,
+			Upstream: BackendUpstream{
+				BaseURL: "https://api.openai.com/v1",
+				Headers: []Header{{Key: "X-Org", Value: "knoway"}},
+			},
+		},
+		Status: LLMBackendStatus{Status: Healthy},
+	}
+
+	hub := new(v1beta1.LLMBackend)
+	require.NoError(t, backend.ConvertTo(hub))
+
+	assert.Equal(t, v1beta1.GroupVersion.WithKind("LLMBackend"), hub.GroupVersionKind())
+	assert.Equal(t, "42", hub.ResourceVersion)
+	assert.Equal(t, "gpt-4o", lo.FromPtr(hub.Spec.ModelName))
+	assert.Equal(t, v1beta1.ProviderOpenAI, hub.Spec.Provider)
+	assert.Equal(t, []v1beta1.Header{{Key: "X-Org", Value: "knoway"}}, hub.Spec.Upstream.Headers)
+	assert.Equal(t, v1beta1.Healthy, hub.Status.Status)
+
+	converted := new(LLMBackend)
+	require.NoError(t, converted.ConvertFrom(hub))
+
+	assert.Equal(t, GroupVersion.WithKind("LLMBackend"), converted.GroupVersionKind())
+
+	converted.TypeMeta = backend.TypeMeta
+	assert.Equal(t, backend, converted)
+}
+
+func TestModelRoute_Conversion(t *testing.T) {
+	route := &ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4o"},
+		Spec: ModelRouteSpec{
+			ModelName: "gpt-4o",
+			Fallback: &ModelRouteFallback{
+				MaxRetries: lo.ToPtr[uint64](3),
+			},
+		},
+	}
+
+	hub := new(v1beta1.ModelRoute)
+	require.NoError(t, route.ConvertTo(hub))
+
+	assert.Equal(t, v1beta1.GroupVersion.WithKind("ModelRoute"), hub.GroupVersionKind())
+	assert.Equal(t, "gpt-4o", hub.Spec.ModelName)
+	require.NotNil(t, hub.Spec.Fallback)
+	assert.Equal(t, uint64(3), lo.FromPtr(hub.Spec.Fallback.MaxRetries))
+
+	converted := new(ModelRoute)
+	require.NoError(t, converted.ConvertFrom(hub))
+
+	converted.TypeMeta = route.TypeMeta
+	assert.Equal(t, route, converted)
+}
+
+func TestImageGenerationBackend_Conversion(t *testing.T) {
+	backend := &ImageGenerationBackend{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dall-e-3"},
+		Spec: ImageGenerationBackendSpec{
+			ModelName: lo.ToPtr("dall-e-3"),
+			Provider:  ProviderOpenAI,
+			Upstream: ImageGenerationBackendUpstream{
+				BaseURL:         "https://api.openai.com/v1",
+				RemoveParamKeys: []string{"user"},
+			},
+		},
+	}
+
+	hub := new(v1alpha2.ImageGenerationBackend)
+	require.NoError(t, backend.ConvertTo(hub))
+
+	assert.Equal(t, v1alpha2.GroupVersion.WithKind("ImageGenerationBackend"), hub.GroupVersionKind())
+	assert.Equal(t, "dall-e-3", lo.FromPtr(hub.Spec.ModelName))
+	assert.Equal(t, []string{"user"}, hub.Spec.Upstream.RemoveParamKeys)
+
+	converted := new(ImageGenerationBackend)
+	require.NoError(t, converted.ConvertFrom(hub))
+
+	converted.TypeMeta = backend.TypeMeta
+	assert.Equal(t, backend, converted)
+}
+
+func TestEmbeddingBackend_Conversion(t *testing.T) {
+	backend := &EmbeddingBackend{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "bge-m3"},
+		Spec: EmbeddingBackendSpec{
+			ModelName: lo.ToPtr("bge-m3"),
+			Provider:  ProviderVLLM,
+			Upstream: EmbeddingBackendUpstream{
+				BaseURL:         "http://bge-m3.default.svc.cluster.local:8000/v1",
+				RemoveParamKeys: []string{"dimensions"},
+			},
+		},
+	}
+
+	hub := new(v1alpha2.EmbeddingBackend)
+	require.NoError(t, backend.ConvertTo(hub))
+
+	assert.Equal(t, v1alpha2.GroupVersion.WithKind("EmbeddingBackend"), hub.GroupVersionKind())
+	assert.Equal(t, []string{"dimensions"}, hub.Spec.Upstream.RemoveParamKeys)
+
+	converted := new(EmbeddingBackend)
+	require.NoError(t, converted.ConvertFrom(hub))
+
+	converted.TypeMeta = backend.TypeMeta
+	assert.Equal(t, backend, converted)
+}
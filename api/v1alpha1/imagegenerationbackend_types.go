@@ -18,12 +18,12 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // +genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
 //+kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.provider`
 //+kubebuilder:printcolumn:name="Model Name",type=string,JSONPath=`.spec.modelName`
 //+kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.spec.upstream.baseUrl`
@@ -68,6 +68,17 @@ type ImageGenerationBackendSpec struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	MeteringPolicy *ImageGenerationMeteringPolicy `json:"meteringPolicy,omitempty"`
+	// Pricing is the price of the usage of the model, the cost of requests
+	// is computed by the cost filter of listeners
+	// +optional
+	Pricing *Pricing `json:"pricing,omitempty"`
+	// Maintenance takes the backend out of rotation
+	// +optional
+	Maintenance *MaintenanceSpec `json:"maintenance,omitempty"`
+	// Disabled removes the backend from the gateway while keeping the
+	// resource and its configuration, set it back to false to restore.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
 }
 
 // BackendUpstream defines the upstream server configuration.
@@ -94,12 +105,36 @@ type ImageGenerationBackendUpstream struct {
 	//	  refType: Secret
 	//	  refName: common-gpt4-apikey
 	HeadersFrom []HeaderFromSource `json:"headersFrom,omitempty"`
+	// Auth places credentials into the query parameters or cookies of
+	// upstream requests, for upstreams not authenticating with headers.
+	// Example:
+	//
+	// auth:
+	// 	- scheme: QueryParam
+	// 	  name: key
+	// 	  valueFrom:
+	// 	    secretKeyRef:
+	// 	      name: gemini-apikey
+	// 	      key: apikey
+	Auth []UpstreamAuth `json:"auth,omitempty"`
 
 	DefaultParams   *ImageGenerationModelParams `json:"defaultParams,omitempty"`
 	OverrideParams  *ImageGenerationModelParams `json:"overrideParams,omitempty"`
 	RemoveParamKeys []string                    `json:"RemoveParamKeys,omitempty"`
 
 	Timeout int32 `json:"timeout,omitempty"`
+	// HealthCheck probes the upstream periodically when set, the backend is
+	// removed from routing while the upstream is unhealthy.
+	// +optional
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+	// CircuitBreaker ejects the backend from the route targets for a
+	// cooldown after consecutive upstream errors when set.
+	// +optional
+	CircuitBreaker *CircuitBreaker `json:"circuitBreaker,omitempty"`
+	// Connection tunes the connections to the upstream, the connections of
+	// every backend are kept apart, with the defaults when not set.
+	// +optional
+	Connection *Connection `json:"connection,omitempty"`
 }
 
 type ImageGenerationModelParams struct {
@@ -175,19 +210,23 @@ type ImageGenerationFilter struct {
 // At least one of the following must be specified: CustomConfig
 // +kubebuilder:validation:Required
 type ImageGenerationFilterFilterConfig struct {
-	// Custom: Custom plugin configuration
+	// Custom transforms the bodies of the requests sent to the backend and of
+	// the responses of it with JSON Patch operations and CEL expressions
 	// Example:
 	//
 	// 	custom:
-	// 		pluginName: examplePlugin
-	// 		pluginVersion: "1.0.0"
-	// 		settings:
-	//   		setting1: value1
-	//   		setting2: value2
+	// 		request:
+	// 			jsonPatch:
+	// 			- op: move
+	// 			  from: /max_tokens
+	// 			  path: /max_completion_tokens
+	// 			cel:
+	// 			- path: /temperature
+	// 			  expression: "has(body.temperature) ? dyn(body.temperature / 2.0) : null"
 	//
 	// +kubebuilder:validation:OneOf
 	// +optional
-	Custom *runtime.RawExtension `json:"custom,omitempty"`
+	Custom *CustomFilter `json:"custom,omitempty"`
 }
 
 // This enum may also be used for LLMBackend to track down token spent
@@ -223,12 +262,54 @@ type ImageGenerationMeteringPolicy struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	SizeFrom *SizeFrom `json:"sizeFrom,omitempty"`
+
+	// ImageFetch limits how images returned as URLs by the upstream are fetched
+	// when metering the size of the generated images.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	ImageFetch *ImageFetchPolicy `json:"imageFetch,omitempty"`
+
+	// Expressions compute billable units of the requests from the metadata of
+	// the requests and responses, see MeteringPolicy.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Expressions []MeteringExpression `json:"expressions,omitempty"`
+}
+
+// ImageFetchPolicy defines the limits of fetching images from upstream-provided URLs.
+type ImageFetchPolicy struct {
+	// Timeout of fetching a single image, defaults to 30s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// MaxSizeBytes is the maximum size of a single image, defaults to 20MiB.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSizeBytes *int64 `json:"maxSizeBytes,omitempty"`
+
+	// MaxRedirects is the maximum number of redirects to follow, defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRedirects *int32 `json:"maxRedirects,omitempty"`
+
+	// AllowPrivateNetworks allows fetching images from loopback, private and
+	// link-local addresses, which are rejected by default.
+	// +optional
+	AllowPrivateNetworks bool `json:"allowPrivateNetworks,omitempty"`
+
+	// AllowedContentTypes is the list of allowed content types, wildcards such
+	// as image/* are supported, defaults to image/*.
+	// +optional
+	AllowedContentTypes []string `json:"allowedContentTypes,omitempty"`
 }
 
 // ImageGenerationBackendStatus defines the observed state of ImageGenerationBackend.
 type ImageGenerationBackendStatus struct {
-	// Status indicates the health of the backend: Unknown, Healthy, or Failed
-	// +kubebuilder:validation:Enum=Unknown;Healthy;Failed
+	// Status indicates the health of the backend: Unknown, Healthy, Failed,
+	// Maintenance, or Disabled
+	// +kubebuilder:validation:Enum=Unknown;Healthy;Failed;Maintenance;Disabled
 	Status StatusEnum `json:"status,omitempty"`
 
 	// Conditions represent the current conditions of the backend
@@ -0,0 +1,166 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.provider`
+//+kubebuilder:printcolumn:name="Model Name",type=string,JSONPath=`.spec.modelName`
+//+kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.spec.upstream.baseUrl`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+
+// RerankBackend is the Schema for the rerankbackends API.
+type RerankBackend struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RerankBackendSpec   `json:"spec,omitempty"`
+	Status RerankBackendStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RerankBackendList contains a list of RerankBackend.
+type RerankBackendList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RerankBackend `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RerankBackend{}, &RerankBackendList{})
+}
+
+// RerankBackendSpec defines the desired state of RerankBackend.
+type RerankBackendSpec struct {
+	// ModelName specifies the name of the model
+	// +kubebuilder:validation:Optional
+	// +optional
+	ModelName *string `json:"modelName,omitempty"`
+	// Provider indicates the organization providing the model, the upstream
+	// serves the rerank API compatible with Cohere and Jina, e.g. vLLM, TEI,
+	// Jina or Cohere
+	// +kubebuilder:validation:Enum=OpenAI;vLLM
+	Provider Provider `json:"provider,omitempty"`
+	// Upstream contains information about the upstream configuration
+	Upstream RerankBackendUpstream `json:"upstream,omitempty"`
+	// Filters are applied to the model's requests
+	Filters []RerankFilter `json:"filters,omitempty"`
+	// MeteringPolicy contains configurations about how to count the usage of the model
+	// +kubebuilder:validation:Optional
+	// +optional
+	MeteringPolicy *MeteringPolicy `json:"meteringPolicy,omitempty"`
+	// Pricing is the price of the usage of the model, the cost of requests
+	// is computed by the cost filter of listeners
+	// +optional
+	Pricing *Pricing `json:"pricing,omitempty"`
+	// Maintenance takes the backend out of rotation
+	// +optional
+	Maintenance *MaintenanceSpec `json:"maintenance,omitempty"`
+	// Disabled removes the backend from the gateway while keeping the
+	// resource and its configuration, set it back to false to restore.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// RerankBackendUpstream defines the upstream server configuration.
+type RerankBackendUpstream struct {
+	// BaseUrl define upstream endpoint url, the requests are sent to the
+	// /rerank path of it
+	// Example:
+	// 		https://api.jina.ai/v1
+	//
+	//  	http://bge-reranker-v2-m3.default.svc.cluster.local:8000/v1
+	BaseURL string `json:"baseUrl,omitempty"`
+
+	// Headers defines the common headers for the model, such as the authentication header for the API key.
+	// Example:
+	//
+	// headers：
+	// 	- key: apikey
+	// 	  value: "sk-or-v1-xxxxxxxxxx"
+	Headers []Header `json:"headers,omitempty"`
+	// Headers defines the common headers for the model, such as the authentication header for the API key.
+	// Example:
+	//
+	// headersFrom：
+	// 	- prefix: sk-or-v1-
+	//	  refType: Secret
+	//	  refName: common-gpt4-apikey
+	HeadersFrom []HeaderFromSource `json:"headersFrom,omitempty"`
+	// Auth places credentials into the query parameters or cookies of
+	// upstream requests, for upstreams not authenticating with headers.
+	Auth []UpstreamAuth `json:"auth,omitempty"`
+
+	DefaultParams   *RerankModelParams `json:"defaultParams,omitempty"`
+	OverrideParams  *RerankModelParams `json:"overrideParams,omitempty"`
+	RemoveParamKeys []string           `json:"RemoveParamKeys,omitempty"`
+
+	Timeout int32 `json:"timeout,omitempty"`
+	// CircuitBreaker ejects the backend from the route targets for a
+	// cooldown after consecutive upstream errors when set.
+	// +optional
+	CircuitBreaker *CircuitBreaker `json:"circuitBreaker,omitempty"`
+	// Connection tunes the connections to the upstream, the connections of
+	// every backend are kept apart, with the defaults when not set.
+	// +optional
+	Connection *Connection `json:"connection,omitempty"`
+}
+
+type RerankModelParams struct {
+	// OpenAI model parameters, the ones of the rerank API compatible with
+	// Cohere and Jina
+	OpenAI *OpenAIRerankParam `json:"openai,omitempty"`
+}
+
+type OpenAIRerankParam struct {
+	Model string `json:"model,omitempty"`
+
+	// TopN specifies the number of the most relevant documents to return.
+	// +kubebuilder:validation:Minimum=1
+	TopN *int `json:"top_n,omitempty"`
+	// ReturnDocuments specifies whether to return the documents along with
+	// the relevance scores.
+	ReturnDocuments *bool `json:"return_documents,omitempty"`
+}
+
+// RerankFilter represents the rerank backend filter configuration.
+type RerankFilter struct {
+	Name string `json:"name,omitempty"` // Filter name
+
+	FilterConfig `json:",inline"`
+}
+
+// RerankBackendStatus defines the observed state of RerankBackend.
+type RerankBackendStatus struct {
+	// Status indicates the health of the backend: Unknown, Healthy, Failed,
+	// Maintenance, or Disabled
+	// +kubebuilder:validation:Enum=Unknown;Healthy;Failed;Maintenance;Disabled
+	Status StatusEnum `json:"status,omitempty"`
+
+	// Conditions represent the current conditions of the backend
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Endpoints holds the upstream addresses of the current model (pod IP addresses)
+	Endpoints []string `json:"endpoints,omitempty"`
+}
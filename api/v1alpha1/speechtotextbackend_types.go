@@ -0,0 +1,159 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.provider`
+//+kubebuilder:printcolumn:name="Model Name",type=string,JSONPath=`.spec.modelName`
+//+kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.spec.upstream.baseUrl`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+
+// SpeechToTextBackend is the Schema for the speechtotextbackends API.
+type SpeechToTextBackend struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SpeechToTextBackendSpec   `json:"spec,omitempty"`
+	Status SpeechToTextBackendStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SpeechToTextBackendList contains a list of SpeechToTextBackend.
+type SpeechToTextBackendList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SpeechToTextBackend `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SpeechToTextBackend{}, &SpeechToTextBackendList{})
+}
+
+// SpeechToTextBackendSpec defines the desired state of SpeechToTextBackend.
+type SpeechToTextBackendSpec struct {
+	// ModelName specifies the name of the model
+	// +kubebuilder:validation:Optional
+	// +optional
+	ModelName *string `json:"modelName,omitempty"`
+	// Provider indicates the organization providing the model
+	// +kubebuilder:validation:Enum=OpenAI;vLLM;OpenAIV1Speech;DeepgramWebSocketV1
+	Provider Provider `json:"provider,omitempty"`
+	// Upstream contains information about the upstream configuration
+	Upstream SpeechToTextBackendUpstream `json:"upstream,omitempty"`
+	// Filters are applied to the model's requests
+	Filters []SpeechToTextFilter `json:"filters,omitempty"`
+}
+
+// SpeechToTextBackendUpstream defines the upstream server configuration.
+type SpeechToTextBackendUpstream struct {
+	// BaseUrl define upstream endpoint url, /audio/transcriptions or
+	// /audio/translations is appended for OpenAI compatible providers
+	// Example:
+	// 		https://api.openai.com/v1
+	//
+	//  	https://api.deepgram.com/v1/listen
+	BaseURL string `json:"baseUrl,omitempty"`
+
+	// Headers defines the common headers for the model, such as the authentication header for the API key.
+	// Example:
+	//
+	// headers：
+	// 	- key: apikey
+	// 	  value: "sk-or-v1-xxxxxxxxxx"
+	Headers []Header `json:"headers,omitempty"`
+	// Headers defines the common headers for the model, such as the authentication header for the API key.
+	// Example:
+	//
+	// headersFrom：
+	// 	- prefix: sk-or-v1-
+	//	  refType: Secret
+	//	  refName: common-gpt4-apikey
+	HeadersFrom []HeaderFromSource `json:"headersFrom,omitempty"`
+
+	DefaultParams   *SpeechToTextModelParams `json:"defaultParams,omitempty"`
+	OverrideParams  *SpeechToTextModelParams `json:"overrideParams,omitempty"`
+	RemoveParamKeys []string                 `json:"RemoveParamKeys,omitempty"`
+
+	Timeout int32 `json:"timeout,omitempty"`
+}
+
+type SpeechToTextModelParams struct {
+	// OpenAI model parameters
+	OpenAI *OpenAISpeechToTextParam `json:"openai,omitempty"`
+}
+
+type OpenAISpeechToTextParam struct {
+	Model string `json:"model,omitempty"`
+
+	// The language of the input audio in ISO-639-1 format, e.g. en.
+	Language *string `json:"language,omitempty"`
+	// An optional text to guide the model's style or continue a previous
+	// audio segment.
+	Prompt *string `json:"prompt,omitempty"`
+	// The format of the output, one of json, text, srt, verbose_json, or vtt.
+	// +kubebuilder:validation:Enum=json;text;srt;verbose_json;vtt
+	ResponseFormat *string `json:"response_format,omitempty"`
+	// The sampling temperature, between 0 and 1.
+	Temperature *string `json:"temperature,omitempty" floatString:"true"`
+}
+
+// SpeechToTextFilter represents the speech-to-text backend filter configuration.
+type SpeechToTextFilter struct {
+	Name string `json:"name,omitempty"` // Filter name
+
+	SpeechToTextFilterConfig `json:",inline"`
+}
+
+// SpeechToTextFilterConfig represents the configuration for filters.
+// At least one of the following must be specified: CustomConfig
+// +kubebuilder:validation:Required
+type SpeechToTextFilterConfig struct {
+	// Custom: Custom plugin configuration
+	// Example:
+	//
+	// 	custom:
+	// 		pluginName: examplePlugin
+	// 		pluginVersion: "1.0.0"
+	// 		settings:
+	//   		setting1: value1
+	//   		setting2: value2
+	//
+	// +kubebuilder:validation:OneOf
+	// +optional
+	Custom *runtime.RawExtension `json:"custom,omitempty"`
+}
+
+// SpeechToTextBackendStatus defines the observed state of SpeechToTextBackend.
+type SpeechToTextBackendStatus struct {
+	// Status indicates the health of the backend: Unknown, Healthy, or Failed
+	// +kubebuilder:validation:Enum=Unknown;Healthy;Failed
+	Status StatusEnum `json:"status,omitempty"`
+
+	// Conditions represent the current conditions of the backend
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Endpoints holds the upstream addresses of the current model (pod IP addresses)
+	Endpoints []string `json:"endpoints,omitempty"`
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.provider`
+//+kubebuilder:printcolumn:name="Model Name",type=string,JSONPath=`.spec.modelName`
+//+kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.spec.upstream.baseUrl`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+
+// VideoGenerationBackend is the Schema for the videogenerationbackends API.
+type VideoGenerationBackend struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VideoGenerationBackendSpec   `json:"spec,omitempty"`
+	Status VideoGenerationBackendStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VideoGenerationBackendList contains a list of VideoGenerationBackend.
+type VideoGenerationBackendList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VideoGenerationBackend `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VideoGenerationBackend{}, &VideoGenerationBackendList{})
+}
+
+// VideoGenerationBackendSpec defines the desired state of VideoGenerationBackend.
+type VideoGenerationBackendSpec struct {
+	// ModelName specifies the name of the model
+	// +kubebuilder:validation:Optional
+	// +optional
+	ModelName *string `json:"modelName,omitempty"`
+	// Provider indicates the organization providing the model, OpenAI for
+	// the upstreams serving the videos API compatible with OpenAI, Runway for
+	// the tasks API of Runway
+	// +kubebuilder:validation:Enum=OpenAI;Runway
+	Provider Provider `json:"provider,omitempty"`
+	// Upstream contains information about the upstream configuration
+	Upstream VideoGenerationBackendUpstream `json:"upstream,omitempty"`
+	// Filters are applied to the model's requests
+	Filters []VideoGenerationFilter `json:"filters,omitempty"`
+	// MeteringPolicy contains configurations about how to count the usage of the model
+	// +kubebuilder:validation:Optional
+	// +optional
+	MeteringPolicy *VideoGenerationMeteringPolicy `json:"meteringPolicy,omitempty"`
+	// Pricing is the price of the usage of the model, the cost of requests
+	// is computed by the cost filter of listeners
+	// +optional
+	Pricing *Pricing `json:"pricing,omitempty"`
+	// Maintenance takes the backend out of rotation
+	// +optional
+	Maintenance *MaintenanceSpec `json:"maintenance,omitempty"`
+	// Disabled removes the backend from the gateway while keeping the
+	// resource and its configuration, set it back to false to restore.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// VideoGenerationBackendUpstream defines the upstream server configuration.
+type VideoGenerationBackendUpstream struct {
+	// BaseUrl define upstream endpoint url, the jobs are submitted to the
+	// /videos path of it, or to the /text_to_video and /image_to_video paths
+	// for Runway
+	// Example:
+	// 		https://api.openai.com/v1
+	//
+	//  	https://api.dev.runwayml.com/v1
+	BaseURL string `json:"baseUrl,omitempty"`
+
+	// Headers defines the common headers for the model, such as the authentication header for the API key.
+	// Example:
+	//
+	// headers：
+	// 	- key: apikey
+	// 	  value: "sk-or-v1-xxxxxxxxxx"
+	Headers []Header `json:"headers,omitempty"`
+	// Headers defines the common headers for the model, such as the authentication header for the API key.
+	// Example:
+	//
+	// headersFrom：
+	// 	- prefix: sk-or-v1-
+	//	  refType: Secret
+	//	  refName: common-gpt4-apikey
+	HeadersFrom []HeaderFromSource `json:"headersFrom,omitempty"`
+	// Auth places credentials into the query parameters or cookies of
+	// upstream requests, for upstreams not authenticating with headers.
+	Auth []UpstreamAuth `json:"auth,omitempty"`
+
+	DefaultParams   *VideoGenerationModelParams `json:"defaultParams,omitempty"`
+	OverrideParams  *VideoGenerationModelParams `json:"overrideParams,omitempty"`
+	RemoveParamKeys []string                    `json:"RemoveParamKeys,omitempty"`
+
+	Timeout int32 `json:"timeout,omitempty"`
+	// CircuitBreaker ejects the backend from the route targets for a
+	// cooldown after consecutive upstream errors when set.
+	// +optional
+	CircuitBreaker *CircuitBreaker `json:"circuitBreaker,omitempty"`
+	// Connection tunes the connections to the upstream, the connections of
+	// every backend are kept apart, with the defaults when not set.
+	// +optional
+	Connection *Connection `json:"connection,omitempty"`
+}
+
+type VideoGenerationModelParams struct {
+	// OpenAI model parameters, the ones of the videos API compatible with
+	// OpenAI, also converted for Runway
+	OpenAI *OpenAIVideoGenerationParam `json:"openai,omitempty"`
+}
+
+type OpenAIVideoGenerationParam struct {
+	Model string `json:"model,omitempty"`
+
+	// Seconds specifies the duration of the generated videos, e.g. "4".
+	Seconds *string `json:"seconds,omitempty"`
+	// Size specifies the resolution of the generated videos, e.g. "720x1280".
+	Size *string `json:"size,omitempty"`
+}
+
+// VideoGenerationFilter represents the video generation backend filter configuration.
+type VideoGenerationFilter struct {
+	Name string `json:"name,omitempty"` // Filter name
+
+	FilterConfig `json:",inline"`
+}
+
+// VideoGenerationMeteringPolicy defines how the videos are metered, once when
+// the jobs are submitted.
+type VideoGenerationMeteringPolicy struct {
+	// DurationFrom indicates whether the duration of the generated video is
+	// the requested one, the one reported by the upstream for the submitted
+	// job, or the greatest of them.
+	//
+	// +kubebuilder:validation:Enum=Input;Output;Greatest
+	// +kubebuilder:validation:Optional
+	// +optional
+	DurationFrom *SizeFrom `json:"durationFrom,omitempty"`
+
+	// ResolutionFrom indicates whether the resolution of the generated video
+	// is the requested one, the one reported by the upstream for the
+	// submitted job, or the greatest of them.
+	//
+	// +kubebuilder:validation:Enum=Input;Output;Greatest
+	// +kubebuilder:validation:Optional
+	// +optional
+	ResolutionFrom *SizeFrom `json:"resolutionFrom,omitempty"`
+
+	// Expressions compute billable units of the requests from the metadata of
+	// the requests and responses, see MeteringPolicy.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Expressions []MeteringExpression `json:"expressions,omitempty"`
+}
+
+// VideoGenerationBackendStatus defines the observed state of VideoGenerationBackend.
+type VideoGenerationBackendStatus struct {
+	// Status indicates the health of the backend: Unknown, Healthy, Failed,
+	// Maintenance, or Disabled
+	// +kubebuilder:validation:Enum=Unknown;Healthy;Failed;Maintenance;Disabled
+	Status StatusEnum `json:"status,omitempty"`
+
+	// Conditions represent the current conditions of the backend
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Endpoints holds the upstream addresses of the current model (pod IP addresses)
+	Endpoints []string `json:"endpoints,omitempty"`
+}
@@ -21,13 +21,49 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSBedrockUpstream) DeepCopyInto(out *AWSBedrockUpstream) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSBedrockUpstream.
+func (in *AWSBedrockUpstream) DeepCopy() *AWSBedrockUpstream {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSBedrockUpstream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureOpenAIUpstream) DeepCopyInto(out *AzureOpenAIUpstream) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureOpenAIUpstream.
+func (in *AzureOpenAIUpstream) DeepCopy() *AzureOpenAIUpstream {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureOpenAIUpstream)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendUpstream) DeepCopyInto(out *BackendUpstream) {
 	*out = *in
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(UpstreamService)
+		**out = **in
+	}
 	if in.Headers != nil {
 		in, out := &in.Headers, &out.Headers
 		*out = make([]Header, len(*in))
@@ -36,7 +72,43 @@ func (in *BackendUpstream) DeepCopyInto(out *BackendUpstream) {
 	if in.HeadersFrom != nil {
 		in, out := &in.HeadersFrom, &out.HeadersFrom
 		*out = make([]HeaderFromSource, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = make([]UpstreamAuth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.APIKeys != nil {
+		in, out := &in.APIKeys, &out.APIKeys
+		*out = new(UpstreamAPIKeys)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = new(UpstreamPaths)
+		**out = **in
+	}
+	if in.Query != nil {
+		in, out := &in.Query, &out.Query
+		*out = make([]UpstreamQueryParam, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AzureOpenAI != nil {
+		in, out := &in.AzureOpenAI, &out.AzureOpenAI
+		*out = new(AzureOpenAIUpstream)
+		**out = **in
+	}
+	if in.AWSBedrock != nil {
+		in, out := &in.AWSBedrock, &out.AWSBedrock
+		*out = new(AWSBedrockUpstream)
+		**out = **in
 	}
 	if in.DefaultParams != nil {
 		in, out := &in.DefaultParams, &out.DefaultParams
@@ -53,6 +125,26 @@ func (in *BackendUpstream) DeepCopyInto(out *BackendUpstream) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheck)
+		**out = **in
+	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreaker)
+		**out = **in
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(Connection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeadlineHeader != nil {
+		in, out := &in.DeadlineHeader, &out.DeadlineHeader
+		*out = new(DeadlineHeader)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendUpstream.
@@ -65,6 +157,113 @@ func (in *BackendUpstream) DeepCopy() *BackendUpstream {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BodyTransform) DeepCopyInto(out *BodyTransform) {
+	*out = *in
+	if in.JSONPatch != nil {
+		in, out := &in.JSONPatch, &out.JSONPatch
+		*out = make([]JSONPatchOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CEL != nil {
+		in, out := &in.CEL, &out.CEL
+		*out = make([]CELAssignment, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BodyTransform.
+func (in *BodyTransform) DeepCopy() *BodyTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(BodyTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CELAssignment) DeepCopyInto(out *CELAssignment) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELAssignment.
+func (in *CELAssignment) DeepCopy() *CELAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(CELAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheEmbedding) DeepCopyInto(out *CacheEmbedding) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheEmbedding.
+func (in *CacheEmbedding) DeepCopy() *CacheEmbedding {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheEmbedding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CachePolicy) DeepCopyInto(out *CachePolicy) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxEntries != nil {
+		in, out := &in.MaxEntries, &out.MaxEntries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Embedding != nil {
+		in, out := &in.Embedding, &out.Embedding
+		*out = new(CacheEmbedding)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CachePolicy.
+func (in *CachePolicy) DeepCopy() *CachePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CachePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreaker) DeepCopyInto(out *CircuitBreaker) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreaker.
+func (in *CircuitBreaker) DeepCopy() *CircuitBreaker {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreaker)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonParams) DeepCopyInto(out *CommonParams) {
 	*out = *in
@@ -86,57 +285,123 @@ func (in *CommonParams) DeepCopy() *CommonParams {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FilterConfig) DeepCopyInto(out *FilterConfig) {
+func (in *ConcurrencyLimitPolicy) DeepCopyInto(out *ConcurrencyLimitPolicy) {
 	*out = *in
-	if in.Custom != nil {
-		in, out := &in.Custom, &out.Custom
-		*out = new(runtime.RawExtension)
-		(*in).DeepCopyInto(*out)
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]*ConcurrencyLimitRule, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ConcurrencyLimitRule)
+				(*in).DeepCopyInto(*out)
+			}
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterConfig.
-func (in *FilterConfig) DeepCopy() *FilterConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConcurrencyLimitPolicy.
+func (in *ConcurrencyLimitPolicy) DeepCopy() *ConcurrencyLimitPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(FilterConfig)
+	out := new(ConcurrencyLimitPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Header) DeepCopyInto(out *Header) {
+func (in *ConcurrencyLimitRule) DeepCopyInto(out *ConcurrencyLimitRule) {
 	*out = *in
+	if in.Match != nil {
+		in, out := &in.Match, &out.Match
+		*out = new(StringMatch)
+		**out = **in
+	}
+	if in.QueueTimeout != nil {
+		in, out := &in.QueueTimeout, &out.QueueTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetryAfter != nil {
+		in, out := &in.RetryAfter, &out.RetryAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Header.
-func (in *Header) DeepCopy() *Header {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConcurrencyLimitRule.
+func (in *ConcurrencyLimitRule) DeepCopy() *ConcurrencyLimitRule {
 	if in == nil {
 		return nil
 	}
-	out := new(Header)
+	out := new(ConcurrencyLimitRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HeaderFromSource) DeepCopyInto(out *HeaderFromSource) {
+func (in *Connection) DeepCopyInto(out *Connection) {
 	*out = *in
+	if in.TLSSessionCacheSize != nil {
+		in, out := &in.TLSSessionCacheSize, &out.TLSSessionCacheSize
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderFromSource.
-func (in *HeaderFromSource) DeepCopy() *HeaderFromSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Connection.
+func (in *Connection) DeepCopy() *Connection {
 	if in == nil {
 		return nil
 	}
-	out := new(HeaderFromSource)
+	out := new(Connection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageGenerationBackend) DeepCopyInto(out *ImageGenerationBackend) {
+func (in *CustomFilter) DeepCopyInto(out *CustomFilter) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(BodyTransform)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = new(BodyTransform)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomFilter.
+func (in *CustomFilter) DeepCopy() *CustomFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeadlineHeader) DeepCopyInto(out *DeadlineHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeadlineHeader.
+func (in *DeadlineHeader) DeepCopy() *DeadlineHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(DeadlineHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddingBackend) DeepCopyInto(out *EmbeddingBackend) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -144,18 +409,18 @@ func (in *ImageGenerationBackend) DeepCopyInto(out *ImageGenerationBackend) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationBackend.
-func (in *ImageGenerationBackend) DeepCopy() *ImageGenerationBackend {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddingBackend.
+func (in *EmbeddingBackend) DeepCopy() *EmbeddingBackend {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageGenerationBackend)
+	out := new(EmbeddingBackend)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ImageGenerationBackend) DeepCopyObject() runtime.Object {
+func (in *EmbeddingBackend) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -163,31 +428,31 @@ func (in *ImageGenerationBackend) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageGenerationBackendList) DeepCopyInto(out *ImageGenerationBackendList) {
+func (in *EmbeddingBackendList) DeepCopyInto(out *EmbeddingBackendList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ImageGenerationBackend, len(*in))
+		*out = make([]EmbeddingBackend, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationBackendList.
-func (in *ImageGenerationBackendList) DeepCopy() *ImageGenerationBackendList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddingBackendList.
+func (in *EmbeddingBackendList) DeepCopy() *EmbeddingBackendList {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageGenerationBackendList)
+	out := new(EmbeddingBackendList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ImageGenerationBackendList) DeepCopyObject() runtime.Object {
+func (in *EmbeddingBackendList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -195,7 +460,7 @@ func (in *ImageGenerationBackendList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageGenerationBackendSpec) DeepCopyInto(out *ImageGenerationBackendSpec) {
+func (in *EmbeddingBackendSpec) DeepCopyInto(out *EmbeddingBackendSpec) {
 	*out = *in
 	if in.ModelName != nil {
 		in, out := &in.ModelName, &out.ModelName
@@ -205,30 +470,40 @@ func (in *ImageGenerationBackendSpec) DeepCopyInto(out *ImageGenerationBackendSp
 	in.Upstream.DeepCopyInto(&out.Upstream)
 	if in.Filters != nil {
 		in, out := &in.Filters, &out.Filters
-		*out = make([]ImageGenerationFilter, len(*in))
+		*out = make([]EmbeddingFilter, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.MeteringPolicy != nil {
 		in, out := &in.MeteringPolicy, &out.MeteringPolicy
-		*out = new(ImageGenerationMeteringPolicy)
+		*out = new(MeteringPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pricing != nil {
+		in, out := &in.Pricing, &out.Pricing
+		*out = new(Pricing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationBackendSpec.
-func (in *ImageGenerationBackendSpec) DeepCopy() *ImageGenerationBackendSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddingBackendSpec.
+func (in *EmbeddingBackendSpec) DeepCopy() *EmbeddingBackendSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageGenerationBackendSpec)
+	out := new(EmbeddingBackendSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageGenerationBackendStatus) DeepCopyInto(out *ImageGenerationBackendStatus) {
+func (in *EmbeddingBackendStatus) DeepCopyInto(out *EmbeddingBackendStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -244,18 +519,18 @@ func (in *ImageGenerationBackendStatus) DeepCopyInto(out *ImageGenerationBackend
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationBackendStatus.
-func (in *ImageGenerationBackendStatus) DeepCopy() *ImageGenerationBackendStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddingBackendStatus.
+func (in *EmbeddingBackendStatus) DeepCopy() *EmbeddingBackendStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageGenerationBackendStatus)
+	out := new(EmbeddingBackendStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageGenerationBackendUpstream) DeepCopyInto(out *ImageGenerationBackendUpstream) {
+func (in *EmbeddingBackendUpstream) DeepCopyInto(out *EmbeddingBackendUpstream) {
 	*out = *in
 	if in.Headers != nil {
 		in, out := &in.Headers, &out.Headers
@@ -265,16 +540,25 @@ func (in *ImageGenerationBackendUpstream) DeepCopyInto(out *ImageGenerationBacke
 	if in.HeadersFrom != nil {
 		in, out := &in.HeadersFrom, &out.HeadersFrom
 		*out = make([]HeaderFromSource, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = make([]UpstreamAuth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.DefaultParams != nil {
 		in, out := &in.DefaultParams, &out.DefaultParams
-		*out = new(ImageGenerationModelParams)
+		*out = new(EmbeddingModelParams)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.OverrideParams != nil {
 		in, out := &in.OverrideParams, &out.OverrideParams
-		*out = new(ImageGenerationModelParams)
+		*out = new(EmbeddingModelParams)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.RemoveParamKeys != nil {
@@ -282,183 +566,257 @@ func (in *ImageGenerationBackendUpstream) DeepCopyInto(out *ImageGenerationBacke
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreaker)
+		**out = **in
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(Connection)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationBackendUpstream.
-func (in *ImageGenerationBackendUpstream) DeepCopy() *ImageGenerationBackendUpstream {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddingBackendUpstream.
+func (in *EmbeddingBackendUpstream) DeepCopy() *EmbeddingBackendUpstream {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageGenerationBackendUpstream)
+	out := new(EmbeddingBackendUpstream)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageGenerationCommonParams) DeepCopyInto(out *ImageGenerationCommonParams) {
+func (in *EmbeddingFilter) DeepCopyInto(out *EmbeddingFilter) {
 	*out = *in
-	if in.Prompt != nil {
-		in, out := &in.Prompt, &out.Prompt
-		*out = new(string)
-		**out = **in
-	}
+	in.FilterConfig.DeepCopyInto(&out.FilterConfig)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationCommonParams.
-func (in *ImageGenerationCommonParams) DeepCopy() *ImageGenerationCommonParams {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddingFilter.
+func (in *EmbeddingFilter) DeepCopy() *EmbeddingFilter {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageGenerationCommonParams)
+	out := new(EmbeddingFilter)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageGenerationFilter) DeepCopyInto(out *ImageGenerationFilter) {
+func (in *EmbeddingModelParams) DeepCopyInto(out *EmbeddingModelParams) {
 	*out = *in
-	in.ImageGenerationFilterFilterConfig.DeepCopyInto(&out.ImageGenerationFilterFilterConfig)
+	if in.OpenAI != nil {
+		in, out := &in.OpenAI, &out.OpenAI
+		*out = new(OpenAIEmbeddingParam)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationFilter.
-func (in *ImageGenerationFilter) DeepCopy() *ImageGenerationFilter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddingModelParams.
+func (in *EmbeddingModelParams) DeepCopy() *EmbeddingModelParams {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageGenerationFilter)
+	out := new(EmbeddingModelParams)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageGenerationFilterFilterConfig) DeepCopyInto(out *ImageGenerationFilterFilterConfig) {
+func (in *FilterConfig) DeepCopyInto(out *FilterConfig) {
 	*out = *in
 	if in.Custom != nil {
 		in, out := &in.Custom, &out.Custom
-		*out = new(runtime.RawExtension)
+		*out = new(CustomFilter)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationFilterFilterConfig.
-func (in *ImageGenerationFilterFilterConfig) DeepCopy() *ImageGenerationFilterFilterConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterConfig.
+func (in *FilterConfig) DeepCopy() *FilterConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageGenerationFilterFilterConfig)
+	out := new(FilterConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageGenerationMeteringPolicy) DeepCopyInto(out *ImageGenerationMeteringPolicy) {
+func (in *GeminiParam) DeepCopyInto(out *GeminiParam) {
 	*out = *in
-	if in.SizeFrom != nil {
-		in, out := &in.SizeFrom, &out.SizeFrom
-		*out = new(SizeFrom)
-		**out = **in
+	if in.SafetySettings != nil {
+		in, out := &in.SafetySettings, &out.SafetySettings
+		*out = make([]GeminiSafetySetting, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationMeteringPolicy.
-func (in *ImageGenerationMeteringPolicy) DeepCopy() *ImageGenerationMeteringPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeminiParam.
+func (in *GeminiParam) DeepCopy() *GeminiParam {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageGenerationMeteringPolicy)
+	out := new(GeminiParam)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageGenerationModelParams) DeepCopyInto(out *ImageGenerationModelParams) {
+func (in *GeminiSafetySetting) DeepCopyInto(out *GeminiSafetySetting) {
 	*out = *in
-	if in.OpenAI != nil {
-		in, out := &in.OpenAI, &out.OpenAI
-		*out = new(OpenAIImageGenerationParam)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeminiSafetySetting.
+func (in *GeminiSafetySetting) DeepCopy() *GeminiSafetySetting {
+	if in == nil {
+		return nil
 	}
+	out := new(GeminiSafetySetting)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationModelParams.
-func (in *ImageGenerationModelParams) DeepCopy() *ImageGenerationModelParams {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Header) DeepCopyInto(out *Header) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Header.
+func (in *Header) DeepCopy() *Header {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageGenerationModelParams)
+	out := new(Header)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LLMBackend) DeepCopyInto(out *LLMBackend) {
+func (in *HeaderFromSource) DeepCopyInto(out *HeaderFromSource) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultSource)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMBackend.
-func (in *LLMBackend) DeepCopy() *LLMBackend {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderFromSource.
+func (in *HeaderFromSource) DeepCopy() *HeaderFromSource {
 	if in == nil {
 		return nil
 	}
-	out := new(LLMBackend)
+	out := new(HeaderFromSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LLMBackend) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
+func (in *HealthCheck) DeepCopy() *HealthCheck {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(HealthCheck)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LLMBackendFilter) DeepCopyInto(out *LLMBackendFilter) {
+func (in *ImageFetchPolicy) DeepCopyInto(out *ImageFetchPolicy) {
 	*out = *in
-	in.FilterConfig.DeepCopyInto(&out.FilterConfig)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxSizeBytes != nil {
+		in, out := &in.MaxSizeBytes, &out.MaxSizeBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRedirects != nil {
+		in, out := &in.MaxRedirects, &out.MaxRedirects
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllowedContentTypes != nil {
+		in, out := &in.AllowedContentTypes, &out.AllowedContentTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMBackendFilter.
-func (in *LLMBackendFilter) DeepCopy() *LLMBackendFilter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageFetchPolicy.
+func (in *ImageFetchPolicy) DeepCopy() *ImageFetchPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(LLMBackendFilter)
+	out := new(ImageFetchPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LLMBackendList) DeepCopyInto(out *LLMBackendList) {
+func (in *ImageGenerationBackend) DeepCopyInto(out *ImageGenerationBackend) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationBackend.
+func (in *ImageGenerationBackend) DeepCopy() *ImageGenerationBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageGenerationBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageGenerationBackend) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageGenerationBackendList) DeepCopyInto(out *ImageGenerationBackendList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]LLMBackend, len(*in))
+		*out = make([]ImageGenerationBackend, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMBackendList.
-func (in *LLMBackendList) DeepCopy() *LLMBackendList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationBackendList.
+func (in *ImageGenerationBackendList) DeepCopy() *ImageGenerationBackendList {
 	if in == nil {
 		return nil
 	}
-	out := new(LLMBackendList)
+	out := new(ImageGenerationBackendList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LLMBackendList) DeepCopyObject() runtime.Object {
+func (in *ImageGenerationBackendList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -466,7 +824,7 @@ func (in *LLMBackendList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LLMBackendSpec) DeepCopyInto(out *LLMBackendSpec) {
+func (in *ImageGenerationBackendSpec) DeepCopyInto(out *ImageGenerationBackendSpec) {
 	*out = *in
 	if in.ModelName != nil {
 		in, out := &in.ModelName, &out.ModelName
@@ -476,25 +834,40 @@ func (in *LLMBackendSpec) DeepCopyInto(out *LLMBackendSpec) {
 	in.Upstream.DeepCopyInto(&out.Upstream)
 	if in.Filters != nil {
 		in, out := &in.Filters, &out.Filters
-		*out = make([]LLMBackendFilter, len(*in))
+		*out = make([]ImageGenerationFilter, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MeteringPolicy != nil {
+		in, out := &in.MeteringPolicy, &out.MeteringPolicy
+		*out = new(ImageGenerationMeteringPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pricing != nil {
+		in, out := &in.Pricing, &out.Pricing
+		*out = new(Pricing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMBackendSpec.
-func (in *LLMBackendSpec) DeepCopy() *LLMBackendSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationBackendSpec.
+func (in *ImageGenerationBackendSpec) DeepCopy() *ImageGenerationBackendSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LLMBackendSpec)
+	out := new(ImageGenerationBackendSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LLMBackendStatus) DeepCopyInto(out *LLMBackendStatus) {
+func (in *ImageGenerationBackendStatus) DeepCopyInto(out *ImageGenerationBackendStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -510,257 +883,376 @@ func (in *LLMBackendStatus) DeepCopyInto(out *LLMBackendStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMBackendStatus.
-func (in *LLMBackendStatus) DeepCopy() *LLMBackendStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationBackendStatus.
+func (in *ImageGenerationBackendStatus) DeepCopy() *ImageGenerationBackendStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LLMBackendStatus)
+	out := new(ImageGenerationBackendStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelParams) DeepCopyInto(out *ModelParams) {
+func (in *ImageGenerationBackendUpstream) DeepCopyInto(out *ImageGenerationBackendUpstream) {
 	*out = *in
-	if in.OpenAI != nil {
-		in, out := &in.OpenAI, &out.OpenAI
-		*out = new(OpenAIParam)
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		copy(*out, *in)
+	}
+	if in.HeadersFrom != nil {
+		in, out := &in.HeadersFrom, &out.HeadersFrom
+		*out = make([]HeaderFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = make([]UpstreamAuth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultParams != nil {
+		in, out := &in.DefaultParams, &out.DefaultParams
+		*out = new(ImageGenerationModelParams)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OverrideParams != nil {
+		in, out := &in.OverrideParams, &out.OverrideParams
+		*out = new(ImageGenerationModelParams)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoveParamKeys != nil {
+		in, out := &in.RemoveParamKeys, &out.RemoveParamKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheck)
+		**out = **in
+	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreaker)
+		**out = **in
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(Connection)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelParams.
-func (in *ModelParams) DeepCopy() *ModelParams {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationBackendUpstream.
+func (in *ImageGenerationBackendUpstream) DeepCopy() *ImageGenerationBackendUpstream {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelParams)
+	out := new(ImageGenerationBackendUpstream)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRoute) DeepCopyInto(out *ModelRoute) {
+func (in *ImageGenerationCommonParams) DeepCopyInto(out *ImageGenerationCommonParams) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Prompt != nil {
+		in, out := &in.Prompt, &out.Prompt
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRoute.
-func (in *ModelRoute) DeepCopy() *ModelRoute {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationCommonParams.
+func (in *ImageGenerationCommonParams) DeepCopy() *ImageGenerationCommonParams {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRoute)
+	out := new(ImageGenerationCommonParams)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ModelRoute) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRouteFallback) DeepCopyInto(out *ModelRouteFallback) {
+func (in *ImageGenerationFilter) DeepCopyInto(out *ImageGenerationFilter) {
 	*out = *in
-	if in.PreDelay != nil {
-		in, out := &in.PreDelay, &out.PreDelay
-		*out = new(int64)
-		**out = **in
-	}
-	if in.PostDelay != nil {
-		in, out := &in.PostDelay, &out.PostDelay
-		*out = new(int64)
-		**out = **in
-	}
-	if in.MaxRetires != nil {
-		in, out := &in.MaxRetires, &out.MaxRetires
-		*out = new(uint64)
-		**out = **in
-	}
+	in.ImageGenerationFilterFilterConfig.DeepCopyInto(&out.ImageGenerationFilterFilterConfig)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteFallback.
-func (in *ModelRouteFallback) DeepCopy() *ModelRouteFallback {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationFilter.
+func (in *ImageGenerationFilter) DeepCopy() *ImageGenerationFilter {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRouteFallback)
+	out := new(ImageGenerationFilter)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRouteFilter) DeepCopyInto(out *ModelRouteFilter) {
+func (in *ImageGenerationFilterFilterConfig) DeepCopyInto(out *ImageGenerationFilterFilterConfig) {
 	*out = *in
-	if in.RateLimit != nil {
-		in, out := &in.RateLimit, &out.RateLimit
-		*out = new(RateLimitPolicy)
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(CustomFilter)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteFilter.
-func (in *ModelRouteFilter) DeepCopy() *ModelRouteFilter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationFilterFilterConfig.
+func (in *ImageGenerationFilterFilterConfig) DeepCopy() *ImageGenerationFilterFilterConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRouteFilter)
+	out := new(ImageGenerationFilterFilterConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRouteList) DeepCopyInto(out *ModelRouteList) {
+func (in *ImageGenerationMeteringPolicy) DeepCopyInto(out *ImageGenerationMeteringPolicy) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ModelRoute, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.SizeFrom != nil {
+		in, out := &in.SizeFrom, &out.SizeFrom
+		*out = new(SizeFrom)
+		**out = **in
+	}
+	if in.ImageFetch != nil {
+		in, out := &in.ImageFetch, &out.ImageFetch
+		*out = new(ImageFetchPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expressions != nil {
+		in, out := &in.Expressions, &out.Expressions
+		*out = make([]MeteringExpression, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteList.
-func (in *ModelRouteList) DeepCopy() *ModelRouteList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationMeteringPolicy.
+func (in *ImageGenerationMeteringPolicy) DeepCopy() *ImageGenerationMeteringPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRouteList)
+	out := new(ImageGenerationMeteringPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ModelRouteList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageGenerationModelParams) DeepCopyInto(out *ImageGenerationModelParams) {
+	*out = *in
+	if in.OpenAI != nil {
+		in, out := &in.OpenAI, &out.OpenAI
+		*out = new(OpenAIImageGenerationParam)
+		(*in).DeepCopyInto(*out)
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageGenerationModelParams.
+func (in *ImageGenerationModelParams) DeepCopy() *ImageGenerationModelParams {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageGenerationModelParams)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRouteRoute) DeepCopyInto(out *ModelRouteRoute) {
+func (in *ImagePrice) DeepCopyInto(out *ImagePrice) {
 	*out = *in
-	if in.Targets != nil {
-		in, out := &in.Targets, &out.Targets
-		*out = make([]ModelRouteRouteTarget, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteRoute.
-func (in *ModelRouteRoute) DeepCopy() *ModelRouteRoute {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePrice.
+func (in *ImagePrice) DeepCopy() *ImagePrice {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRouteRoute)
+	out := new(ImagePrice)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRouteRouteFallback) DeepCopyInto(out *ModelRouteRouteFallback) {
+func (in *ImagePromptPolicy) DeepCopyInto(out *ImagePromptPolicy) {
 	*out = *in
-	if in.Order != nil {
-		in, out := &in.Order, &out.Order
+	if in.NegativePrompts != nil {
+		in, out := &in.NegativePrompts, &out.NegativePrompts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BannedTerms != nil {
+		in, out := &in.BannedTerms, &out.BannedTerms
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteRouteFallback.
-func (in *ModelRouteRouteFallback) DeepCopy() *ModelRouteRouteFallback {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePromptPolicy.
+func (in *ImagePromptPolicy) DeepCopy() *ImagePromptPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRouteRouteFallback)
+	out := new(ImagePromptPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRouteRouteTarget) DeepCopyInto(out *ModelRouteRouteTarget) {
+func (in *JSONPatchOperation) DeepCopyInto(out *JSONPatchOperation) {
 	*out = *in
-	in.Destination.DeepCopyInto(&out.Destination)
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteRouteTarget.
-func (in *ModelRouteRouteTarget) DeepCopy() *ModelRouteRouteTarget {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JSONPatchOperation.
+func (in *JSONPatchOperation) DeepCopy() *JSONPatchOperation {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRouteRouteTarget)
+	out := new(JSONPatchOperation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRouteRouteTargetDestination) DeepCopyInto(out *ModelRouteRouteTargetDestination) {
+func (in *LLMBackend) DeepCopyInto(out *LLMBackend) {
 	*out = *in
-	if in.Weight != nil {
-		in, out := &in.Weight, &out.Weight
-		*out = new(int)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMBackend.
+func (in *LLMBackend) DeepCopy() *LLMBackend {
+	if in == nil {
+		return nil
 	}
+	out := new(LLMBackend)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteRouteTargetDestination.
-func (in *ModelRouteRouteTargetDestination) DeepCopy() *ModelRouteRouteTargetDestination {
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LLMBackend) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LLMBackendFilter) DeepCopyInto(out *LLMBackendFilter) {
+	*out = *in
+	in.FilterConfig.DeepCopyInto(&out.FilterConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMBackendFilter.
+func (in *LLMBackendFilter) DeepCopy() *LLMBackendFilter {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRouteRouteTargetDestination)
+	out := new(LLMBackendFilter)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRouteSpec) DeepCopyInto(out *ModelRouteSpec) {
+func (in *LLMBackendList) DeepCopyInto(out *LLMBackendList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LLMBackend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMBackendList.
+func (in *LLMBackendList) DeepCopy() *LLMBackendList {
+	if in == nil {
+		return nil
+	}
+	out := new(LLMBackendList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LLMBackendList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LLMBackendSpec) DeepCopyInto(out *LLMBackendSpec) {
 	*out = *in
+	if in.ModelName != nil {
+		in, out := &in.ModelName, &out.ModelName
+		*out = new(string)
+		**out = **in
+	}
+	in.Upstream.DeepCopyInto(&out.Upstream)
 	if in.Filters != nil {
 		in, out := &in.Filters, &out.Filters
-		*out = make([]ModelRouteFilter, len(*in))
+		*out = make([]LLMBackendFilter, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Route != nil {
-		in, out := &in.Route, &out.Route
-		*out = new(ModelRouteRoute)
+	if in.MeteringPolicy != nil {
+		in, out := &in.MeteringPolicy, &out.MeteringPolicy
+		*out = new(MeteringPolicy)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Fallback != nil {
-		in, out := &in.Fallback, &out.Fallback
-		*out = new(ModelRouteFallback)
+	if in.Pricing != nil {
+		in, out := &in.Pricing, &out.Pricing
+		*out = new(Pricing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModelInfo != nil {
+		in, out := &in.ModelInfo, &out.ModelInfo
+		*out = new(ModelInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PromptTemplate != nil {
+		in, out := &in.PromptTemplate, &out.PromptTemplate
+		*out = new(PromptTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteSpec.
-func (in *ModelRouteSpec) DeepCopy() *ModelRouteSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMBackendSpec.
+func (in *LLMBackendSpec) DeepCopy() *LLMBackendSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRouteSpec)
+	out := new(LLMBackendSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRouteStatus) DeepCopyInto(out *ModelRouteStatus) {
+func (in *LLMBackendStatus) DeepCopyInto(out *LLMBackendStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -769,259 +1261,2015 @@ func (in *ModelRouteStatus) DeepCopyInto(out *ModelRouteStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Targets != nil {
-		in, out := &in.Targets, &out.Targets
-		*out = make([]ModelRouteStatusTarget, len(*in))
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteStatus.
-func (in *ModelRouteStatus) DeepCopy() *ModelRouteStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LLMBackendStatus.
+func (in *LLMBackendStatus) DeepCopy() *LLMBackendStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRouteStatus)
+	out := new(LLMBackendStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceSpec) DeepCopyInto(out *MaintenanceSpec) {
+	*out = *in
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceSpec.
+func (in *MaintenanceSpec) DeepCopy() *MaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeteringExpression) DeepCopyInto(out *MeteringExpression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringExpression.
+func (in *MeteringExpression) DeepCopy() *MeteringExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(MeteringExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeteringPolicy) DeepCopyInto(out *MeteringPolicy) {
+	*out = *in
+	if in.Expressions != nil {
+		in, out := &in.Expressions, &out.Expressions
+		*out = make([]MeteringExpression, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeteringPolicy.
+func (in *MeteringPolicy) DeepCopy() *MeteringPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MeteringPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelInfo) DeepCopyInto(out *ModelInfo) {
+	*out = *in
+	if in.ContextWindow != nil {
+		in, out := &in.ContextWindow, &out.ContextWindow
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]ModelCapability, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelInfo.
+func (in *ModelInfo) DeepCopy() *ModelInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelParams) DeepCopyInto(out *ModelParams) {
+	*out = *in
+	if in.OpenAI != nil {
+		in, out := &in.OpenAI, &out.OpenAI
+		*out = new(OpenAIParam)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gemini != nil {
+		in, out := &in.Gemini, &out.Gemini
+		*out = new(GeminiParam)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelParams.
+func (in *ModelParams) DeepCopy() *ModelParams {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRoute) DeepCopyInto(out *ModelRoute) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRoute.
+func (in *ModelRoute) DeepCopy() *ModelRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelRoute) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteCanary) DeepCopyInto(out *ModelRouteCanary) {
+	*out = *in
+	if in.StepWeight != nil {
+		in, out := &in.StepWeight, &out.StepWeight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxWeight != nil {
+		in, out := &in.MaxWeight, &out.MaxWeight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AnalysisWindow != nil {
+		in, out := &in.AnalysisWindow, &out.AnalysisWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinRequests != nil {
+		in, out := &in.MinRequests, &out.MinRequests
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxLatencyP95 != nil {
+		in, out := &in.MaxLatencyP95, &out.MaxLatencyP95
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteCanary.
+func (in *ModelRouteCanary) DeepCopy() *ModelRouteCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteCanaryStatus) DeepCopyInto(out *ModelRouteCanaryStatus) {
+	*out = *in
+	in.StepStartTime.DeepCopyInto(&out.StepStartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteCanaryStatus.
+func (in *ModelRouteCanaryStatus) DeepCopy() *ModelRouteCanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteCanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteErrorFallback) DeepCopyInto(out *ModelRouteErrorFallback) {
+	*out = *in
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]ModelRouteFallbackBackend, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteErrorFallback.
+func (in *ModelRouteErrorFallback) DeepCopy() *ModelRouteErrorFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteErrorFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteFallback) DeepCopyInto(out *ModelRouteFallback) {
+	*out = *in
+	if in.PreDelay != nil {
+		in, out := &in.PreDelay, &out.PreDelay
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PostDelay != nil {
+		in, out := &in.PostDelay, &out.PostDelay
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.OnErrors != nil {
+		in, out := &in.OnErrors, &out.OnErrors
+		*out = make([]ModelRouteErrorFallback, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteFallback.
+func (in *ModelRouteFallback) DeepCopy() *ModelRouteFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteFallbackBackend) DeepCopyInto(out *ModelRouteFallbackBackend) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteFallbackBackend.
+func (in *ModelRouteFallbackBackend) DeepCopy() *ModelRouteFallbackBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteFallbackBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteFilter) DeepCopyInto(out *ModelRouteFilter) {
+	*out = *in
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimitPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(CachePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConcurrencyLimit != nil {
+		in, out := &in.ConcurrencyLimit, &out.ConcurrencyLimit
+		*out = new(ConcurrencyLimitPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PromptCompression != nil {
+		in, out := &in.PromptCompression, &out.PromptCompression
+		*out = new(PromptCompressionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePromptPolicy != nil {
+		in, out := &in.ImagePromptPolicy, &out.ImagePromptPolicy
+		*out = new(ImagePromptPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PromptTemplate != nil {
+		in, out := &in.PromptTemplate, &out.PromptTemplate
+		*out = new(PromptTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Vision != nil {
+		in, out := &in.Vision, &out.Vision
+		*out = new(VisionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteFilter.
+func (in *ModelRouteFilter) DeepCopy() *ModelRouteFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteFirstChunkSLO) DeepCopyInto(out *ModelRouteFirstChunkSLO) {
+	*out = *in
+	out.P95 = in.P95
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinWeightPercent != nil {
+		in, out := &in.MinWeightPercent, &out.MinWeightPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RecoveryStepPercent != nil {
+		in, out := &in.RecoveryStepPercent, &out.RecoveryStepPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteFirstChunkSLO.
+func (in *ModelRouteFirstChunkSLO) DeepCopy() *ModelRouteFirstChunkSLO {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteFirstChunkSLO)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteList) DeepCopyInto(out *ModelRouteList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ModelRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteList.
+func (in *ModelRouteList) DeepCopy() *ModelRouteList {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelRouteList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteMirror) DeepCopyInto(out *ModelRouteMirror) {
+	*out = *in
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteMirror.
+func (in *ModelRouteMirror) DeepCopy() *ModelRouteMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteRetryBackoff) DeepCopyInto(out *ModelRouteRetryBackoff) {
+	*out = *in
+	if in.BaseInterval != nil {
+		in, out := &in.BaseInterval, &out.BaseInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxInterval != nil {
+		in, out := &in.MaxInterval, &out.MaxInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteRetryBackoff.
+func (in *ModelRouteRetryBackoff) DeepCopy() *ModelRouteRetryBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteRetryBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteRetryBudget) DeepCopyInto(out *ModelRouteRetryBudget) {
+	*out = *in
+	if in.MinRetriesPerSecond != nil {
+		in, out := &in.MinRetriesPerSecond, &out.MinRetriesPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteRetryBudget.
+func (in *ModelRouteRetryBudget) DeepCopy() *ModelRouteRetryBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteRetryBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteRetryPolicy) DeepCopyInto(out *ModelRouteRetryPolicy) {
+	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]RetryErrorClass, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetryOnStatuses != nil {
+		in, out := &in.RetryOnStatuses, &out.RetryOnStatuses
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(ModelRouteRetryBackoff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Budget != nil {
+		in, out := &in.Budget, &out.Budget
+		*out = new(ModelRouteRetryBudget)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteRetryPolicy.
+func (in *ModelRouteRetryPolicy) DeepCopy() *ModelRouteRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteRoute) DeepCopyInto(out *ModelRouteRoute) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]ModelRouteRouteTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FirstChunkSLO != nil {
+		in, out := &in.FirstChunkSLO, &out.FirstChunkSLO
+		*out = new(ModelRouteFirstChunkSLO)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(ModelRouteCanary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SessionAffinity != nil {
+		in, out := &in.SessionAffinity, &out.SessionAffinity
+		*out = new(ModelRouteSessionAffinity)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteRoute.
+func (in *ModelRouteRoute) DeepCopy() *ModelRouteRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteRouteFallback) DeepCopyInto(out *ModelRouteRouteFallback) {
+	*out = *in
+	if in.Order != nil {
+		in, out := &in.Order, &out.Order
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteRouteFallback.
+func (in *ModelRouteRouteFallback) DeepCopy() *ModelRouteRouteFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteRouteFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteRouteTarget) DeepCopyInto(out *ModelRouteRouteTarget) {
+	*out = *in
+	in.Destination.DeepCopyInto(&out.Destination)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteRouteTarget.
+func (in *ModelRouteRouteTarget) DeepCopy() *ModelRouteRouteTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteRouteTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteRouteTargetDestination) DeepCopyInto(out *ModelRouteRouteTargetDestination) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteRouteTargetDestination.
+func (in *ModelRouteRouteTargetDestination) DeepCopy() *ModelRouteRouteTargetDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteRouteTargetDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteSeedPolicy) DeepCopyInto(out *ModelRouteSeedPolicy) {
+	*out = *in
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteSeedPolicy.
+func (in *ModelRouteSeedPolicy) DeepCopy() *ModelRouteSeedPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteSeedPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteSessionAffinity) DeepCopyInto(out *ModelRouteSessionAffinity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteSessionAffinity.
+func (in *ModelRouteSessionAffinity) DeepCopy() *ModelRouteSessionAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteSessionAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteSpec) DeepCopyInto(out *ModelRouteSpec) {
+	*out = *in
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]ModelRouteFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Route != nil {
+		in, out := &in.Route, &out.Route
+		*out = new(ModelRouteRoute)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(ModelRouteFallback)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(ModelRouteRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SeedPolicy != nil {
+		in, out := &in.SeedPolicy, &out.SeedPolicy
+		*out = new(ModelRouteSeedPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(ModelRouteMirror)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteSpec.
+func (in *ModelRouteSpec) DeepCopy() *ModelRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteStatus) DeepCopyInto(out *ModelRouteStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]ModelRouteStatusTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(ModelRouteCanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteStatus.
+func (in *ModelRouteStatus) DeepCopy() *ModelRouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRouteStatusTarget) DeepCopyInto(out *ModelRouteStatusTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteStatusTarget.
+func (in *ModelRouteStatusTarget) DeepCopy() *ModelRouteStatusTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRouteStatusTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAIEmbeddingParam) DeepCopyInto(out *OpenAIEmbeddingParam) {
+	*out = *in
+	if in.EncodingFormat != nil {
+		in, out := &in.EncodingFormat, &out.EncodingFormat
+		*out = new(string)
+		**out = **in
+	}
+	if in.Dimensions != nil {
+		in, out := &in.Dimensions, &out.Dimensions
+		*out = new(int)
+		**out = **in
+	}
+	if in.User != nil {
+		in, out := &in.User, &out.User
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAIEmbeddingParam.
+func (in *OpenAIEmbeddingParam) DeepCopy() *OpenAIEmbeddingParam {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAIEmbeddingParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAIImageGenerationParam) DeepCopyInto(out *OpenAIImageGenerationParam) {
+	*out = *in
+	in.ImageGenerationCommonParams.DeepCopyInto(&out.ImageGenerationCommonParams)
+	if in.N != nil {
+		in, out := &in.N, &out.N
+		*out = new(string)
+		**out = **in
+	}
+	if in.Quality != nil {
+		in, out := &in.Quality, &out.Quality
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResponseFormat != nil {
+		in, out := &in.ResponseFormat, &out.ResponseFormat
+		*out = new(OpenAIImageGenerationResponseFormat)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(string)
+		**out = **in
+	}
+	if in.Style != nil {
+		in, out := &in.Style, &out.Style
+		*out = new(OpenAIImageGenerationStyle)
+		**out = **in
+	}
+	if in.User != nil {
+		in, out := &in.User, &out.User
+		*out = new(string)
+		**out = **in
+	}
+	if in.NegativePrompt != nil {
+		in, out := &in.NegativePrompt, &out.NegativePrompt
+		*out = new(string)
+		**out = **in
+	}
+	if in.GuidanceScale != nil {
+		in, out := &in.GuidanceScale, &out.GuidanceScale
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAIImageGenerationParam.
+func (in *OpenAIImageGenerationParam) DeepCopy() *OpenAIImageGenerationParam {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAIImageGenerationParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAIModelNameRewriteConfig) DeepCopyInto(out *OpenAIModelNameRewriteConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAIModelNameRewriteConfig.
+func (in *OpenAIModelNameRewriteConfig) DeepCopy() *OpenAIModelNameRewriteConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAIModelNameRewriteConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAIParam) DeepCopyInto(out *OpenAIParam) {
+	*out = *in
+	in.CommonParams.DeepCopyInto(&out.CommonParams)
+	if in.MaxTokens != nil {
+		in, out := &in.MaxTokens, &out.MaxTokens
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxCompletionTokens != nil {
+		in, out := &in.MaxCompletionTokens, &out.MaxCompletionTokens
+		*out = new(int)
+		**out = **in
+	}
+	if in.TopP != nil {
+		in, out := &in.TopP, &out.TopP
+		*out = new(string)
+		**out = **in
+	}
+	if in.Stream != nil {
+		in, out := &in.Stream, &out.Stream
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StreamOptions != nil {
+		in, out := &in.StreamOptions, &out.StreamOptions
+		*out = new(StreamOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAIParam.
+func (in *OpenAIParam) DeepCopy() *OpenAIParam {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAIParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAIRerankParam) DeepCopyInto(out *OpenAIRerankParam) {
+	*out = *in
+	if in.TopN != nil {
+		in, out := &in.TopN, &out.TopN
+		*out = new(int)
+		**out = **in
+	}
+	if in.ReturnDocuments != nil {
+		in, out := &in.ReturnDocuments, &out.ReturnDocuments
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAIRerankParam.
+func (in *OpenAIRerankParam) DeepCopy() *OpenAIRerankParam {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAIRerankParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAISpeechToTextParam) DeepCopyInto(out *OpenAISpeechToTextParam) {
+	*out = *in
+	if in.Language != nil {
+		in, out := &in.Language, &out.Language
+		*out = new(string)
+		**out = **in
+	}
+	if in.Prompt != nil {
+		in, out := &in.Prompt, &out.Prompt
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResponseFormat != nil {
+		in, out := &in.ResponseFormat, &out.ResponseFormat
+		*out = new(string)
+		**out = **in
+	}
+	if in.Temperature != nil {
+		in, out := &in.Temperature, &out.Temperature
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAISpeechToTextParam.
+func (in *OpenAISpeechToTextParam) DeepCopy() *OpenAISpeechToTextParam {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAISpeechToTextParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAITextToSpeechParam) DeepCopyInto(out *OpenAITextToSpeechParam) {
+	*out = *in
+	in.TextToSpeechCommonParams.DeepCopyInto(&out.TextToSpeechCommonParams)
+	if in.ResponseFormat != nil {
+		in, out := &in.ResponseFormat, &out.ResponseFormat
+		*out = new(OpenAITextToSpeechResponseFormat)
+		**out = **in
+	}
+	if in.Speed != nil {
+		in, out := &in.Speed, &out.Speed
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAITextToSpeechParam.
+func (in *OpenAITextToSpeechParam) DeepCopy() *OpenAITextToSpeechParam {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAITextToSpeechParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAIVideoGenerationParam) DeepCopyInto(out *OpenAIVideoGenerationParam) {
+	*out = *in
+	if in.Seconds != nil {
+		in, out := &in.Seconds, &out.Seconds
+		*out = new(string)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAIVideoGenerationParam.
+func (in *OpenAIVideoGenerationParam) DeepCopy() *OpenAIVideoGenerationParam {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAIVideoGenerationParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Pricing) DeepCopyInto(out *Pricing) {
+	*out = *in
+	if in.PromptPer1KTokens != nil {
+		in, out := &in.PromptPer1KTokens, &out.PromptPer1KTokens
+		*out = new(string)
+		**out = **in
+	}
+	if in.CompletionPer1KTokens != nil {
+		in, out := &in.CompletionPer1KTokens, &out.CompletionPer1KTokens
+		*out = new(string)
+		**out = **in
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImagePrice, len(*in))
+		copy(*out, *in)
+	}
+	if in.PerAudioSecond != nil {
+		in, out := &in.PerAudioSecond, &out.PerAudioSecond
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Pricing.
+func (in *Pricing) DeepCopy() *Pricing {
+	if in == nil {
+		return nil
+	}
+	out := new(Pricing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromptCompressionPolicy) DeepCopyInto(out *PromptCompressionPolicy) {
+	*out = *in
+	if in.Summarization != nil {
+		in, out := &in.Summarization, &out.Summarization
+		*out = new(PromptSummarization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinPromptTokens != nil {
+		in, out := &in.MinPromptTokens, &out.MinPromptTokens
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromptCompressionPolicy.
+func (in *PromptCompressionPolicy) DeepCopy() *PromptCompressionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PromptCompressionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromptSummarization) DeepCopyInto(out *PromptSummarization) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int64)
+		**out = **in
+	}
+	if in.KeepLastMessages != nil {
+		in, out := &in.KeepLastMessages, &out.KeepLastMessages
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromptSummarization.
+func (in *PromptSummarization) DeepCopy() *PromptSummarization {
+	if in == nil {
+		return nil
+	}
+	out := new(PromptSummarization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromptTemplate) DeepCopyInto(out *PromptTemplate) {
+	*out = *in
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromptTemplate.
+func (in *PromptTemplate) DeepCopy() *PromptTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PromptTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitPolicy) DeepCopyInto(out *RateLimitPolicy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]*RateLimitRule, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RateLimitRule)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitPolicy.
+func (in *RateLimitPolicy) DeepCopy() *RateLimitPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitRule) DeepCopyInto(out *RateLimitRule) {
+	*out = *in
+	if in.Match != nil {
+		in, out := &in.Match, &out.Match
+		*out = new(StringMatch)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitRule.
+func (in *RateLimitRule) DeepCopy() *RateLimitRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RerankBackend) DeepCopyInto(out *RerankBackend) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RerankBackend.
+func (in *RerankBackend) DeepCopy() *RerankBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(RerankBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RerankBackend) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RerankBackendList) DeepCopyInto(out *RerankBackendList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RerankBackend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RerankBackendList.
+func (in *RerankBackendList) DeepCopy() *RerankBackendList {
+	if in == nil {
+		return nil
+	}
+	out := new(RerankBackendList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RerankBackendList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RerankBackendSpec) DeepCopyInto(out *RerankBackendSpec) {
+	*out = *in
+	if in.ModelName != nil {
+		in, out := &in.ModelName, &out.ModelName
+		*out = new(string)
+		**out = **in
+	}
+	in.Upstream.DeepCopyInto(&out.Upstream)
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]RerankFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MeteringPolicy != nil {
+		in, out := &in.MeteringPolicy, &out.MeteringPolicy
+		*out = new(MeteringPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pricing != nil {
+		in, out := &in.Pricing, &out.Pricing
+		*out = new(Pricing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RerankBackendSpec.
+func (in *RerankBackendSpec) DeepCopy() *RerankBackendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RerankBackendSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RerankBackendStatus) DeepCopyInto(out *RerankBackendStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RerankBackendStatus.
+func (in *RerankBackendStatus) DeepCopy() *RerankBackendStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RerankBackendStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RerankBackendUpstream) DeepCopyInto(out *RerankBackendUpstream) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		copy(*out, *in)
+	}
+	if in.HeadersFrom != nil {
+		in, out := &in.HeadersFrom, &out.HeadersFrom
+		*out = make([]HeaderFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = make([]UpstreamAuth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultParams != nil {
+		in, out := &in.DefaultParams, &out.DefaultParams
+		*out = new(RerankModelParams)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OverrideParams != nil {
+		in, out := &in.OverrideParams, &out.OverrideParams
+		*out = new(RerankModelParams)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoveParamKeys != nil {
+		in, out := &in.RemoveParamKeys, &out.RemoveParamKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreaker)
+		**out = **in
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(Connection)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RerankBackendUpstream.
+func (in *RerankBackendUpstream) DeepCopy() *RerankBackendUpstream {
+	if in == nil {
+		return nil
+	}
+	out := new(RerankBackendUpstream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RerankFilter) DeepCopyInto(out *RerankFilter) {
+	*out = *in
+	in.FilterConfig.DeepCopyInto(&out.FilterConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RerankFilter.
+func (in *RerankFilter) DeepCopy() *RerankFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(RerankFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RerankModelParams) DeepCopyInto(out *RerankModelParams) {
+	*out = *in
+	if in.OpenAI != nil {
+		in, out := &in.OpenAI, &out.OpenAI
+		*out = new(OpenAIRerankParam)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RerankModelParams.
+func (in *RerankModelParams) DeepCopy() *RerankModelParams {
+	if in == nil {
+		return nil
+	}
+	out := new(RerankModelParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpeechToTextBackend) DeepCopyInto(out *SpeechToTextBackend) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpeechToTextBackend.
+func (in *SpeechToTextBackend) DeepCopy() *SpeechToTextBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(SpeechToTextBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpeechToTextBackend) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpeechToTextBackendList) DeepCopyInto(out *SpeechToTextBackendList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SpeechToTextBackend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpeechToTextBackendList.
+func (in *SpeechToTextBackendList) DeepCopy() *SpeechToTextBackendList {
+	if in == nil {
+		return nil
+	}
+	out := new(SpeechToTextBackendList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpeechToTextBackendList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpeechToTextBackendSpec) DeepCopyInto(out *SpeechToTextBackendSpec) {
+	*out = *in
+	if in.ModelName != nil {
+		in, out := &in.ModelName, &out.ModelName
+		*out = new(string)
+		**out = **in
+	}
+	in.Upstream.DeepCopyInto(&out.Upstream)
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]SpeechToTextFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpeechToTextBackendSpec.
+func (in *SpeechToTextBackendSpec) DeepCopy() *SpeechToTextBackendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SpeechToTextBackendSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpeechToTextBackendStatus) DeepCopyInto(out *SpeechToTextBackendStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpeechToTextBackendStatus.
+func (in *SpeechToTextBackendStatus) DeepCopy() *SpeechToTextBackendStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SpeechToTextBackendStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpeechToTextBackendUpstream) DeepCopyInto(out *SpeechToTextBackendUpstream) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		copy(*out, *in)
+	}
+	if in.HeadersFrom != nil {
+		in, out := &in.HeadersFrom, &out.HeadersFrom
+		*out = make([]HeaderFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultParams != nil {
+		in, out := &in.DefaultParams, &out.DefaultParams
+		*out = new(SpeechToTextModelParams)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OverrideParams != nil {
+		in, out := &in.OverrideParams, &out.OverrideParams
+		*out = new(SpeechToTextModelParams)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoveParamKeys != nil {
+		in, out := &in.RemoveParamKeys, &out.RemoveParamKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpeechToTextBackendUpstream.
+func (in *SpeechToTextBackendUpstream) DeepCopy() *SpeechToTextBackendUpstream {
+	if in == nil {
+		return nil
+	}
+	out := new(SpeechToTextBackendUpstream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpeechToTextFilter) DeepCopyInto(out *SpeechToTextFilter) {
+	*out = *in
+	in.SpeechToTextFilterConfig.DeepCopyInto(&out.SpeechToTextFilterConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpeechToTextFilter.
+func (in *SpeechToTextFilter) DeepCopy() *SpeechToTextFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(SpeechToTextFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpeechToTextFilterConfig) DeepCopyInto(out *SpeechToTextFilterConfig) {
+	*out = *in
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpeechToTextFilterConfig.
+func (in *SpeechToTextFilterConfig) DeepCopy() *SpeechToTextFilterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SpeechToTextFilterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpeechToTextModelParams) DeepCopyInto(out *SpeechToTextModelParams) {
+	*out = *in
+	if in.OpenAI != nil {
+		in, out := &in.OpenAI, &out.OpenAI
+		*out = new(OpenAISpeechToTextParam)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpeechToTextModelParams.
+func (in *SpeechToTextModelParams) DeepCopy() *SpeechToTextModelParams {
+	if in == nil {
+		return nil
+	}
+	out := new(SpeechToTextModelParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamOptions) DeepCopyInto(out *StreamOptions) {
+	*out = *in
+	if in.IncludeUsage != nil {
+		in, out := &in.IncludeUsage, &out.IncludeUsage
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamOptions.
+func (in *StreamOptions) DeepCopy() *StreamOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StringMatch) DeepCopyInto(out *StringMatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StringMatch.
+func (in *StringMatch) DeepCopy() *StringMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(StringMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TextToSpeechBackend) DeepCopyInto(out *TextToSpeechBackend) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechBackend.
+func (in *TextToSpeechBackend) DeepCopy() *TextToSpeechBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(TextToSpeechBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TextToSpeechBackend) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TextToSpeechBackendList) DeepCopyInto(out *TextToSpeechBackendList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TextToSpeechBackend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechBackendList.
+func (in *TextToSpeechBackendList) DeepCopy() *TextToSpeechBackendList {
+	if in == nil {
+		return nil
+	}
+	out := new(TextToSpeechBackendList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TextToSpeechBackendList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TextToSpeechBackendSpec) DeepCopyInto(out *TextToSpeechBackendSpec) {
+	*out = *in
+	if in.ModelName != nil {
+		in, out := &in.ModelName, &out.ModelName
+		*out = new(string)
+		**out = **in
+	}
+	in.Upstream.DeepCopyInto(&out.Upstream)
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]TextToSpeechFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechBackendSpec.
+func (in *TextToSpeechBackendSpec) DeepCopy() *TextToSpeechBackendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TextToSpeechBackendSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TextToSpeechBackendStatus) DeepCopyInto(out *TextToSpeechBackendStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechBackendStatus.
+func (in *TextToSpeechBackendStatus) DeepCopy() *TextToSpeechBackendStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TextToSpeechBackendStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TextToSpeechBackendUpstream) DeepCopyInto(out *TextToSpeechBackendUpstream) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		copy(*out, *in)
+	}
+	if in.HeadersFrom != nil {
+		in, out := &in.HeadersFrom, &out.HeadersFrom
+		*out = make([]HeaderFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultParams != nil {
+		in, out := &in.DefaultParams, &out.DefaultParams
+		*out = new(TextToSpeechModelParams)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OverrideParams != nil {
+		in, out := &in.OverrideParams, &out.OverrideParams
+		*out = new(TextToSpeechModelParams)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoveParamKeys != nil {
+		in, out := &in.RemoveParamKeys, &out.RemoveParamKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechBackendUpstream.
+func (in *TextToSpeechBackendUpstream) DeepCopy() *TextToSpeechBackendUpstream {
+	if in == nil {
+		return nil
+	}
+	out := new(TextToSpeechBackendUpstream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TextToSpeechCommonParams) DeepCopyInto(out *TextToSpeechCommonParams) {
+	*out = *in
+	if in.Input != nil {
+		in, out := &in.Input, &out.Input
+		*out = new(string)
+		**out = **in
+	}
+	if in.Voice != nil {
+		in, out := &in.Voice, &out.Voice
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechCommonParams.
+func (in *TextToSpeechCommonParams) DeepCopy() *TextToSpeechCommonParams {
+	if in == nil {
+		return nil
+	}
+	out := new(TextToSpeechCommonParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TextToSpeechFilter) DeepCopyInto(out *TextToSpeechFilter) {
+	*out = *in
+	in.TextToSpeechFilterConfig.DeepCopyInto(&out.TextToSpeechFilterConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechFilter.
+func (in *TextToSpeechFilter) DeepCopy() *TextToSpeechFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(TextToSpeechFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TextToSpeechFilterConfig) DeepCopyInto(out *TextToSpeechFilterConfig) {
+	*out = *in
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechFilterConfig.
+func (in *TextToSpeechFilterConfig) DeepCopy() *TextToSpeechFilterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TextToSpeechFilterConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelRouteStatusTarget) DeepCopyInto(out *ModelRouteStatusTarget) {
+func (in *TextToSpeechModelParams) DeepCopyInto(out *TextToSpeechModelParams) {
 	*out = *in
+	if in.OpenAI != nil {
+		in, out := &in.OpenAI, &out.OpenAI
+		*out = new(OpenAITextToSpeechParam)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteStatusTarget.
-func (in *ModelRouteStatusTarget) DeepCopy() *ModelRouteStatusTarget {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechModelParams.
+func (in *TextToSpeechModelParams) DeepCopy() *TextToSpeechModelParams {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelRouteStatusTarget)
+	out := new(TextToSpeechModelParams)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenAIImageGenerationParam) DeepCopyInto(out *OpenAIImageGenerationParam) {
+func (in *UpstreamAPIKeys) DeepCopyInto(out *UpstreamAPIKeys) {
 	*out = *in
-	in.ImageGenerationCommonParams.DeepCopyInto(&out.ImageGenerationCommonParams)
-	if in.N != nil {
-		in, out := &in.N, &out.N
-		*out = new(string)
-		**out = **in
-	}
-	if in.Quality != nil {
-		in, out := &in.Quality, &out.Quality
-		*out = new(string)
-		**out = **in
-	}
-	if in.ResponseFormat != nil {
-		in, out := &in.ResponseFormat, &out.ResponseFormat
-		*out = new(OpenAIImageGenerationResponseFormat)
-		**out = **in
-	}
-	if in.Size != nil {
-		in, out := &in.Size, &out.Size
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
 		*out = new(string)
 		**out = **in
 	}
-	if in.Style != nil {
-		in, out := &in.Style, &out.Style
-		*out = new(OpenAIImageGenerationStyle)
-		**out = **in
-	}
-	if in.User != nil {
-		in, out := &in.User, &out.User
-		*out = new(string)
-		**out = **in
-	}
-	if in.NegativePrompt != nil {
-		in, out := &in.NegativePrompt, &out.NegativePrompt
-		*out = new(string)
-		**out = **in
-	}
-	if in.GuidanceScale != nil {
-		in, out := &in.GuidanceScale, &out.GuidanceScale
-		*out = new(string)
-		**out = **in
+	if in.SecretKeyRefs != nil {
+		in, out := &in.SecretKeyRefs, &out.SecretKeyRefs
+		*out = make([]corev1.SecretKeySelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAIImageGenerationParam.
-func (in *OpenAIImageGenerationParam) DeepCopy() *OpenAIImageGenerationParam {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamAPIKeys.
+func (in *UpstreamAPIKeys) DeepCopy() *UpstreamAPIKeys {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenAIImageGenerationParam)
+	out := new(UpstreamAPIKeys)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenAIModelNameRewriteConfig) DeepCopyInto(out *OpenAIModelNameRewriteConfig) {
+func (in *UpstreamAuth) DeepCopyInto(out *UpstreamAuth) {
 	*out = *in
+	in.ValueFrom.DeepCopyInto(&out.ValueFrom)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAIModelNameRewriteConfig.
-func (in *OpenAIModelNameRewriteConfig) DeepCopy() *OpenAIModelNameRewriteConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamAuth.
+func (in *UpstreamAuth) DeepCopy() *UpstreamAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenAIModelNameRewriteConfig)
+	out := new(UpstreamAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenAIParam) DeepCopyInto(out *OpenAIParam) {
+func (in *UpstreamAuthValueSource) DeepCopyInto(out *UpstreamAuthValueSource) {
 	*out = *in
-	in.CommonParams.DeepCopyInto(&out.CommonParams)
-	if in.MaxTokens != nil {
-		in, out := &in.MaxTokens, &out.MaxTokens
-		*out = new(int)
-		**out = **in
-	}
-	if in.MaxCompletionTokens != nil {
-		in, out := &in.MaxCompletionTokens, &out.MaxCompletionTokens
-		*out = new(int)
-		**out = **in
-	}
-	if in.TopP != nil {
-		in, out := &in.TopP, &out.TopP
-		*out = new(string)
-		**out = **in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Stream != nil {
-		in, out := &in.Stream, &out.Stream
-		*out = new(bool)
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultKeySource)
 		**out = **in
 	}
-	if in.StreamOptions != nil {
-		in, out := &in.StreamOptions, &out.StreamOptions
-		*out = new(StreamOptions)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAIParam.
-func (in *OpenAIParam) DeepCopy() *OpenAIParam {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamAuthValueSource.
+func (in *UpstreamAuthValueSource) DeepCopy() *UpstreamAuthValueSource {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenAIParam)
+	out := new(UpstreamAuthValueSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpenAITextToSpeechParam) DeepCopyInto(out *OpenAITextToSpeechParam) {
+func (in *UpstreamPaths) DeepCopyInto(out *UpstreamPaths) {
 	*out = *in
-	in.TextToSpeechCommonParams.DeepCopyInto(&out.TextToSpeechCommonParams)
-	if in.ResponseFormat != nil {
-		in, out := &in.ResponseFormat, &out.ResponseFormat
-		*out = new(OpenAITextToSpeechResponseFormat)
-		**out = **in
-	}
-	if in.Speed != nil {
-		in, out := &in.Speed, &out.Speed
-		*out = new(string)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAITextToSpeechParam.
-func (in *OpenAITextToSpeechParam) DeepCopy() *OpenAITextToSpeechParam {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamPaths.
+func (in *UpstreamPaths) DeepCopy() *UpstreamPaths {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenAITextToSpeechParam)
+	out := new(UpstreamPaths)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RateLimitPolicy) DeepCopyInto(out *RateLimitPolicy) {
+func (in *UpstreamQueryParam) DeepCopyInto(out *UpstreamQueryParam) {
 	*out = *in
-	if in.Rules != nil {
-		in, out := &in.Rules, &out.Rules
-		*out = make([]*RateLimitRule, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(RateLimitRule)
-				(*in).DeepCopyInto(*out)
-			}
-		}
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(UpstreamAuthValueSource)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitPolicy.
-func (in *RateLimitPolicy) DeepCopy() *RateLimitPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamQueryParam.
+func (in *UpstreamQueryParam) DeepCopy() *UpstreamQueryParam {
 	if in == nil {
 		return nil
 	}
-	out := new(RateLimitPolicy)
+	out := new(UpstreamQueryParam)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RateLimitRule) DeepCopyInto(out *RateLimitRule) {
+func (in *UpstreamService) DeepCopyInto(out *UpstreamService) {
 	*out = *in
-	if in.Match != nil {
-		in, out := &in.Match, &out.Match
-		*out = new(StringMatch)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitRule.
-func (in *RateLimitRule) DeepCopy() *RateLimitRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpstreamService.
+func (in *UpstreamService) DeepCopy() *UpstreamService {
 	if in == nil {
 		return nil
 	}
-	out := new(RateLimitRule)
+	out := new(UpstreamService)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StreamOptions) DeepCopyInto(out *StreamOptions) {
+func (in *UsageStatsConfig) DeepCopyInto(out *UsageStatsConfig) {
 	*out = *in
-	if in.IncludeUsage != nil {
-		in, out := &in.IncludeUsage, &out.IncludeUsage
-		*out = new(bool)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageStatsConfig.
+func (in *UsageStatsConfig) DeepCopy() *UsageStatsConfig {
+	if in == nil {
+		return nil
 	}
+	out := new(UsageStatsConfig)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamOptions.
-func (in *StreamOptions) DeepCopy() *StreamOptions {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultKeySource) DeepCopyInto(out *VaultKeySource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKeySource.
+func (in *VaultKeySource) DeepCopy() *VaultKeySource {
 	if in == nil {
 		return nil
 	}
-	out := new(StreamOptions)
+	out := new(VaultKeySource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StringMatch) DeepCopyInto(out *StringMatch) {
+func (in *VaultSource) DeepCopyInto(out *VaultSource) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StringMatch.
-func (in *StringMatch) DeepCopy() *StringMatch {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSource.
+func (in *VaultSource) DeepCopy() *VaultSource {
 	if in == nil {
 		return nil
 	}
-	out := new(StringMatch)
+	out := new(VaultSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TextToSpeechBackend) DeepCopyInto(out *TextToSpeechBackend) {
+func (in *VideoGenerationBackend) DeepCopyInto(out *VideoGenerationBackend) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1029,18 +3277,18 @@ func (in *TextToSpeechBackend) DeepCopyInto(out *TextToSpeechBackend) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechBackend.
-func (in *TextToSpeechBackend) DeepCopy() *TextToSpeechBackend {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VideoGenerationBackend.
+func (in *VideoGenerationBackend) DeepCopy() *VideoGenerationBackend {
 	if in == nil {
 		return nil
 	}
-	out := new(TextToSpeechBackend)
+	out := new(VideoGenerationBackend)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TextToSpeechBackend) DeepCopyObject() runtime.Object {
+func (in *VideoGenerationBackend) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1048,31 +3296,31 @@ func (in *TextToSpeechBackend) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TextToSpeechBackendList) DeepCopyInto(out *TextToSpeechBackendList) {
+func (in *VideoGenerationBackendList) DeepCopyInto(out *VideoGenerationBackendList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]TextToSpeechBackend, len(*in))
+		*out = make([]VideoGenerationBackend, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechBackendList.
-func (in *TextToSpeechBackendList) DeepCopy() *TextToSpeechBackendList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VideoGenerationBackendList.
+func (in *VideoGenerationBackendList) DeepCopy() *VideoGenerationBackendList {
 	if in == nil {
 		return nil
 	}
-	out := new(TextToSpeechBackendList)
+	out := new(VideoGenerationBackendList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TextToSpeechBackendList) DeepCopyObject() runtime.Object {
+func (in *VideoGenerationBackendList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1080,7 +3328,7 @@ func (in *TextToSpeechBackendList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TextToSpeechBackendSpec) DeepCopyInto(out *TextToSpeechBackendSpec) {
+func (in *VideoGenerationBackendSpec) DeepCopyInto(out *VideoGenerationBackendSpec) {
 	*out = *in
 	if in.ModelName != nil {
 		in, out := &in.ModelName, &out.ModelName
@@ -1090,25 +3338,40 @@ func (in *TextToSpeechBackendSpec) DeepCopyInto(out *TextToSpeechBackendSpec) {
 	in.Upstream.DeepCopyInto(&out.Upstream)
 	if in.Filters != nil {
 		in, out := &in.Filters, &out.Filters
-		*out = make([]TextToSpeechFilter, len(*in))
+		*out = make([]VideoGenerationFilter, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MeteringPolicy != nil {
+		in, out := &in.MeteringPolicy, &out.MeteringPolicy
+		*out = new(VideoGenerationMeteringPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pricing != nil {
+		in, out := &in.Pricing, &out.Pricing
+		*out = new(Pricing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechBackendSpec.
-func (in *TextToSpeechBackendSpec) DeepCopy() *TextToSpeechBackendSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VideoGenerationBackendSpec.
+func (in *VideoGenerationBackendSpec) DeepCopy() *VideoGenerationBackendSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(TextToSpeechBackendSpec)
+	out := new(VideoGenerationBackendSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TextToSpeechBackendStatus) DeepCopyInto(out *TextToSpeechBackendStatus) {
+func (in *VideoGenerationBackendStatus) DeepCopyInto(out *VideoGenerationBackendStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -1124,18 +3387,18 @@ func (in *TextToSpeechBackendStatus) DeepCopyInto(out *TextToSpeechBackendStatus
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechBackendStatus.
-func (in *TextToSpeechBackendStatus) DeepCopy() *TextToSpeechBackendStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VideoGenerationBackendStatus.
+func (in *VideoGenerationBackendStatus) DeepCopy() *VideoGenerationBackendStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TextToSpeechBackendStatus)
+	out := new(VideoGenerationBackendStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TextToSpeechBackendUpstream) DeepCopyInto(out *TextToSpeechBackendUpstream) {
+func (in *VideoGenerationBackendUpstream) DeepCopyInto(out *VideoGenerationBackendUpstream) {
 	*out = *in
 	if in.Headers != nil {
 		in, out := &in.Headers, &out.Headers
@@ -1145,16 +3408,25 @@ func (in *TextToSpeechBackendUpstream) DeepCopyInto(out *TextToSpeechBackendUpst
 	if in.HeadersFrom != nil {
 		in, out := &in.HeadersFrom, &out.HeadersFrom
 		*out = make([]HeaderFromSource, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = make([]UpstreamAuth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.DefaultParams != nil {
 		in, out := &in.DefaultParams, &out.DefaultParams
-		*out = new(TextToSpeechModelParams)
+		*out = new(VideoGenerationModelParams)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.OverrideParams != nil {
 		in, out := &in.OverrideParams, &out.OverrideParams
-		*out = new(TextToSpeechModelParams)
+		*out = new(VideoGenerationModelParams)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.RemoveParamKeys != nil {
@@ -1162,110 +3434,120 @@ func (in *TextToSpeechBackendUpstream) DeepCopyInto(out *TextToSpeechBackendUpst
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechBackendUpstream.
-func (in *TextToSpeechBackendUpstream) DeepCopy() *TextToSpeechBackendUpstream {
-	if in == nil {
-		return nil
-	}
-	out := new(TextToSpeechBackendUpstream)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TextToSpeechCommonParams) DeepCopyInto(out *TextToSpeechCommonParams) {
-	*out = *in
-	if in.Input != nil {
-		in, out := &in.Input, &out.Input
-		*out = new(string)
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreaker)
 		**out = **in
 	}
-	if in.Voice != nil {
-		in, out := &in.Voice, &out.Voice
-		*out = new(string)
-		**out = **in
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(Connection)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechCommonParams.
-func (in *TextToSpeechCommonParams) DeepCopy() *TextToSpeechCommonParams {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VideoGenerationBackendUpstream.
+func (in *VideoGenerationBackendUpstream) DeepCopy() *VideoGenerationBackendUpstream {
 	if in == nil {
 		return nil
 	}
-	out := new(TextToSpeechCommonParams)
+	out := new(VideoGenerationBackendUpstream)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TextToSpeechFilter) DeepCopyInto(out *TextToSpeechFilter) {
+func (in *VideoGenerationFilter) DeepCopyInto(out *VideoGenerationFilter) {
 	*out = *in
-	in.TextToSpeechFilterConfig.DeepCopyInto(&out.TextToSpeechFilterConfig)
+	in.FilterConfig.DeepCopyInto(&out.FilterConfig)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechFilter.
-func (in *TextToSpeechFilter) DeepCopy() *TextToSpeechFilter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VideoGenerationFilter.
+func (in *VideoGenerationFilter) DeepCopy() *VideoGenerationFilter {
 	if in == nil {
 		return nil
 	}
-	out := new(TextToSpeechFilter)
+	out := new(VideoGenerationFilter)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TextToSpeechFilterConfig) DeepCopyInto(out *TextToSpeechFilterConfig) {
+func (in *VideoGenerationMeteringPolicy) DeepCopyInto(out *VideoGenerationMeteringPolicy) {
 	*out = *in
-	if in.Custom != nil {
-		in, out := &in.Custom, &out.Custom
-		*out = new(runtime.RawExtension)
-		(*in).DeepCopyInto(*out)
+	if in.DurationFrom != nil {
+		in, out := &in.DurationFrom, &out.DurationFrom
+		*out = new(SizeFrom)
+		**out = **in
+	}
+	if in.ResolutionFrom != nil {
+		in, out := &in.ResolutionFrom, &out.ResolutionFrom
+		*out = new(SizeFrom)
+		**out = **in
+	}
+	if in.Expressions != nil {
+		in, out := &in.Expressions, &out.Expressions
+		*out = make([]MeteringExpression, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechFilterConfig.
-func (in *TextToSpeechFilterConfig) DeepCopy() *TextToSpeechFilterConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VideoGenerationMeteringPolicy.
+func (in *VideoGenerationMeteringPolicy) DeepCopy() *VideoGenerationMeteringPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(TextToSpeechFilterConfig)
+	out := new(VideoGenerationMeteringPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TextToSpeechModelParams) DeepCopyInto(out *TextToSpeechModelParams) {
+func (in *VideoGenerationModelParams) DeepCopyInto(out *VideoGenerationModelParams) {
 	*out = *in
 	if in.OpenAI != nil {
 		in, out := &in.OpenAI, &out.OpenAI
-		*out = new(OpenAITextToSpeechParam)
+		*out = new(OpenAIVideoGenerationParam)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TextToSpeechModelParams.
-func (in *TextToSpeechModelParams) DeepCopy() *TextToSpeechModelParams {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VideoGenerationModelParams.
+func (in *VideoGenerationModelParams) DeepCopy() *VideoGenerationModelParams {
 	if in == nil {
 		return nil
 	}
-	out := new(TextToSpeechModelParams)
+	out := new(VideoGenerationModelParams)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *UsageStatsConfig) DeepCopyInto(out *UsageStatsConfig) {
+func (in *VisionPolicy) DeepCopyInto(out *VisionPolicy) {
 	*out = *in
+	if in.MaxImages != nil {
+		in, out := &in.MaxImages, &out.MaxImages
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxImageSizeBytes != nil {
+		in, out := &in.MaxImageSizeBytes, &out.MaxImageSizeBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxDimension != nil {
+		in, out := &in.MaxDimension, &out.MaxDimension
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageStatsConfig.
-func (in *UsageStatsConfig) DeepCopy() *UsageStatsConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VisionPolicy.
+func (in *VisionPolicy) DeepCopy() *VisionPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(UsageStatsConfig)
+	out := new(VisionPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
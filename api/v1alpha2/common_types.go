@@ -0,0 +1,499 @@
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type Header struct {
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// HeaderFromSource represents the source of a set of ConfigMaps, Secrets or
+// Vault secrets
+type HeaderFromSource struct {
+	// An optional identifier to prepend to each key in the ref.
+	Prefix string `json:"prefix,omitempty"`
+	// Type of the source (ConfigMap, Secret or Vault)
+	RefType ValueFromType `json:"refType,omitempty"`
+	// Name of the source
+	RefName string `json:"refName,omitempty"`
+	// Vault references a secret of HashiCorp Vault when RefType is Vault, the
+	// secret is read and rotated by the gateway and never stored in
+	// Kubernetes.
+	// +optional
+	Vault *VaultSource `json:"vault,omitempty"`
+}
+
+// VaultSource references a secret of HashiCorp Vault.
+type VaultSource struct {
+	// Path of the secret, e.g. secret/data/openai for KV v2
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+	// Role to login with through the Kubernetes auth method
+	// +kubebuilder:validation:Required
+	Role string `json:"role"`
+}
+
+// UpstreamAuth places a credential into the query parameters or cookies of
+// upstream requests, for upstreams not authenticating with headers.
+type UpstreamAuth struct {
+	// Scheme is where the credential is placed
+	// +kubebuilder:validation:Required
+	Scheme UpstreamAuthScheme `json:"scheme"`
+	// Name of the query parameter or the cookie, e.g. key
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// ValueFrom references the credential
+	// +kubebuilder:validation:Required
+	ValueFrom UpstreamAuthValueSource `json:"valueFrom"`
+}
+
+// UpstreamAPIKeys rotates the upstream requests between multiple API keys,
+// keys answered with 401, 403 or 429 are disabled for a cooldown.
+type UpstreamAPIKeys struct {
+	// Header the keys are placed into, default is Authorization
+	// +optional
+	Header string `json:"header,omitempty"`
+	// Prefix prepended to the keys, default is "Bearer " for the
+	// Authorization header and none for the others
+	// +optional
+	Prefix *string `json:"prefix,omitempty"`
+	// SecretKeyRefs select the keys, each a key of a Secret in the
+	// namespace of the backend
+	// +kubebuilder:validation:MinItems=1
+	SecretKeyRefs []corev1.SecretKeySelector `json:"secretKeyRefs"`
+	// Cooldown of the disabled keys, unit: second, default is 60
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=60
+	// +optional
+	Cooldown int32 `json:"cooldown,omitempty"`
+	// FailureThreshold is the number of consecutive 401, 403 or 429
+	// responses for a key to be disabled, default is 1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// UpstreamPaths defines the paths appended to the base URL of an upstream
+// for each type of requests, the OpenAI compatible ones are used if not set.
+type UpstreamPaths struct {
+	// ChatCompletions path, default is /chat/completions
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	ChatCompletions string `json:"chatCompletions,omitempty"`
+	// Completions path, default is /completions
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Completions string `json:"completions,omitempty"`
+	// Embeddings path, default is /embeddings
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Embeddings string `json:"embeddings,omitempty"`
+	// ImageGenerations path, default is /images/generations
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	ImageGenerations string `json:"imageGenerations,omitempty"`
+	// Moderations path, default is /moderations
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Moderations string `json:"moderations,omitempty"`
+	// Models path listing the models of the upstream, default is /models
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Models string `json:"models,omitempty"`
+}
+
+// CircuitBreaker passively tracks the errors of upstream requests, the
+// backend is ejected from the route targets for a cooldown after consecutive
+// 5xx responses or timeouts.
+type CircuitBreaker struct {
+	// ConsecutiveErrors is the number of consecutive 5xx responses or
+	// timeouts for the backend to be ejected, default is 5
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=5
+	// +optional
+	ConsecutiveErrors int32 `json:"consecutiveErrors,omitempty"`
+	// Cooldown of the ejection, unit: second, default is 30
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=30
+	// +optional
+	Cooldown int32 `json:"cooldown,omitempty"`
+}
+
+// Connection tunes the connections to the upstream, which cuts the overhead
+// of setting up connections for bursts of short requests, such as embeddings.
+type Connection struct {
+	// PinAddresses resolves the host of the upstream when the backend is
+	// registered, and dials the resolved addresses instead of resolving on
+	// every new connection
+	// +optional
+	PinAddresses bool `json:"pinAddresses,omitempty"`
+	// DNSRefreshInterval to resolve the pinned addresses again, unit:
+	// second, default is 30
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DNSRefreshInterval int32 `json:"dnsRefreshInterval,omitempty"`
+	// TLSSessionCacheSize is the number of TLS sessions cached to resume them
+	// on new connections, 0 disables the cache, default is 64
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TLSSessionCacheSize *int32 `json:"tlsSessionCacheSize,omitempty"`
+	// MaxIdleConns is the maximum of idle connections kept to the upstream,
+	// default is 32
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxIdleConns int32 `json:"maxIdleConns,omitempty"`
+	// MaxIdleConnsPerHost is the maximum of idle connections kept per host
+	// of the upstream, default is MaxIdleConns
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxIdleConnsPerHost int32 `json:"maxIdleConnsPerHost,omitempty"`
+	// DialTimeout is the timeout of dialing new connections, unit: second,
+	// default is 30
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=30
+	// +optional
+	DialTimeout int32 `json:"dialTimeout,omitempty"`
+	// ResponseHeaderTimeout is how long to wait for the response headers
+	// after the request is written, which bounds the time to the first
+	// token of streams, unit: second, default is no timeout
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ResponseHeaderTimeout int32 `json:"responseHeaderTimeout,omitempty"`
+	// KeepAlive is the interval of the TCP keep-alive probes, unit: second,
+	// default is 30
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=30
+	// +optional
+	KeepAlive int32 `json:"keepAlive,omitempty"`
+	// IdleConnTimeout is how long idle connections are kept, unit: second,
+	// default is 90
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=90
+	// +optional
+	IdleConnTimeout int32 `json:"idleConnTimeout,omitempty"`
+	// DisableHTTP2 sends requests over HTTP/1.1 only, for upstreams with
+	// broken HTTP/2 support
+	// +optional
+	DisableHTTP2 bool `json:"disableHTTP2,omitempty"`
+}
+
+// DeadlineHeader is the header forwarding the time remaining of the timeout
+// of the model route to the upstream.
+type DeadlineHeader struct {
+	// Name of the header, default is X-Knoway-Deadline-Ms
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// HealthCheck actively probes the upstream, the backend is removed from
+// routing while the upstream is unhealthy.
+type HealthCheck struct {
+	// Path is probed with GET requests, relative to the base url of the
+	// upstream, default is the path listing the models, i.e. /models
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	Path string `json:"path,omitempty"`
+	// Interval between probes, unit: second, default is 30
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=30
+	// +optional
+	Interval int32 `json:"interval,omitempty"`
+	// Timeout of each probe, unit: second, default is 5
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=5
+	// +optional
+	Timeout int32 `json:"timeout,omitempty"`
+	// FailureThreshold is the number of consecutive failed probes for the
+	// upstream to be considered unhealthy, default is 3
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+	// SuccessThreshold is the number of consecutive successful probes for
+	// the unhealthy upstream to be considered healthy again, default is 1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	SuccessThreshold int32 `json:"successThreshold,omitempty"`
+}
+
+// UpstreamQueryParam defines a query parameter of upstream requests, either
+// Value or ValueFrom must be set.
+type UpstreamQueryParam struct {
+	// Name of the query parameter
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Value of the query parameter
+	// +optional
+	Value string `json:"value,omitempty"`
+	// ValueFrom references the value kept in secrets, for query parameters
+	// carrying credentials
+	// +optional
+	ValueFrom *UpstreamAuthValueSource `json:"valueFrom,omitempty"`
+}
+
+// UpstreamAuthScheme defines where the credential of an upstream is placed.
+// +kubebuilder:validation:Enum=QueryParam;Cookie
+type UpstreamAuthScheme string
+
+const (
+	// UpstreamAuthSchemeQueryParam places the credential into a query
+	// parameter.
+	UpstreamAuthSchemeQueryParam UpstreamAuthScheme = "QueryParam"
+	// UpstreamAuthSchemeCookie places the credential into a cookie.
+	UpstreamAuthSchemeCookie UpstreamAuthScheme = "Cookie"
+)
+
+// UpstreamAuthValueSource references a credential, exactly one of the
+// sources must be set.
+type UpstreamAuthValueSource struct {
+	// SecretKeyRef selects a key of a Secret in the namespace of the backend
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+	// Vault selects a key of a secret of HashiCorp Vault, the secret is read
+	// and rotated by the gateway and never stored in Kubernetes.
+	// +optional
+	Vault *VaultKeySource `json:"vault,omitempty"`
+}
+
+// VaultKeySource selects a key of a secret of HashiCorp Vault.
+type VaultKeySource struct {
+	// Path of the secret, e.g. secret/data/gemini for KV v2
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+	// Role to login with through the Kubernetes auth method
+	// +kubebuilder:validation:Required
+	Role string `json:"role"`
+	// Key of the secret holding the credential
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+}
+
+// ValueFromType defines the type of source for headers.
+// +kubebuilder:validation:Enum=ConfigMap;Secret;Vault
+type ValueFromType string
+
+const (
+	// ConfigMap indicates that the header source is a ConfigMap.
+	ConfigMap ValueFromType = "ConfigMap"
+	// Secret indicates that the header source is a Secret.
+	Secret ValueFromType = "Secret"
+	// Vault indicates that the header source is a secret of HashiCorp Vault.
+	Vault ValueFromType = "Vault"
+)
+
+// StatusEnum defines the possible statuses for the LLMBackend, ImageGenerationBackend, EmbeddingBackend, and other types.
+type StatusEnum string
+
+const (
+	Unknown     StatusEnum = "Unknown"
+	Healthy     StatusEnum = "Healthy"
+	Failed      StatusEnum = "Failed"
+	Maintenance StatusEnum = "Maintenance"
+	Disabled    StatusEnum = "Disabled"
+)
+
+// MaintenanceSpec takes a backend out of rotation without deleting it, new
+// requests are rejected or fall back to other targets of the route while the
+// in-flight ones are finished.
+type MaintenanceSpec struct {
+	// Enabled turns the maintenance on, either immediately or within the
+	// window between Start and End
+	Enabled bool `json:"enabled,omitempty"`
+	// Start of the maintenance window, unset means immediately
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+	// End of the maintenance window, unset means until disabled
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
+	// Reason is returned to the clients whose requests are rejected
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// MeteringPolicy contains configurations about how to count the usage of the model
+type MeteringPolicy struct {
+	// Expressions compute billable units of the requests from the metadata of
+	// the requests and responses, reported to the usage stats server along
+	// with the usage reported by the upstream.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Expressions []MeteringExpression `json:"expressions,omitempty"`
+}
+
+// Pricing is the price of the usage of the model, used to compute the cost
+// of requests. Prices are decimal strings in the Currency.
+type Pricing struct {
+	// Currency of the prices, such as USD
+	// +optional
+	Currency string `json:"currency,omitempty"`
+	// PromptPer1KTokens is the price of 1000 prompt tokens
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	PromptPer1KTokens *string `json:"promptPer1KTokens,omitempty"`
+	// CompletionPer1KTokens is the price of 1000 completion tokens
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	CompletionPer1KTokens *string `json:"completionPer1KTokens,omitempty"`
+	// Images are the prices of generated images, the first one matching the
+	// size and the quality of an image applies.
+	// +optional
+	Images []ImagePrice `json:"images,omitempty"`
+	// PerAudioSecond is the price of a second of transcribed audio
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	PerAudioSecond *string `json:"perAudioSecond,omitempty"`
+}
+
+// ImagePrice is the price of an image.
+type ImagePrice struct {
+	// Size of the image such as 1024x1024, empty matches any size
+	// +kubebuilder:validation:Pattern=`^([0-9]+x[0-9]+)?$`
+	// +optional
+	Size string `json:"size,omitempty"`
+	// Quality of the image such as hd, empty matches any quality
+	// +optional
+	Quality string `json:"quality,omitempty"`
+	// Price of an image
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +kubebuilder:validation:Required
+	Price string `json:"price"`
+}
+
+// ModelCapability is a feature of a model, listed along with the model.
+// +kubebuilder:validation:Enum=Streaming;Tools;Vision;JSONSchema;Reasoning
+type ModelCapability string
+
+const (
+	// ModelCapabilityStreaming streams the completions.
+	ModelCapabilityStreaming ModelCapability = "Streaming"
+	// ModelCapabilityTools calls the tools given in the requests.
+	ModelCapabilityTools ModelCapability = "Tools"
+	// ModelCapabilityVision takes images in the prompts.
+	ModelCapabilityVision ModelCapability = "Vision"
+	// ModelCapabilityJSONSchema responds with JSON conforming to the schema
+	// given by response_format.
+	ModelCapabilityJSONSchema ModelCapability = "JSONSchema"
+	// ModelCapabilityReasoning reasons before completing.
+	ModelCapabilityReasoning ModelCapability = "Reasoning"
+)
+
+// ModelInfo describes a model to the clients listing the models.
+type ModelInfo struct {
+	// ContextWindow is the max number of tokens of the prompt and the
+	// completion together
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ContextWindow *int64 `json:"contextWindow,omitempty"`
+	// Capabilities of the model
+	// +optional
+	Capabilities []ModelCapability `json:"capabilities,omitempty"`
+}
+
+// MeteringExpression computes the billable units of a unit with a CEL expression.
+//
+// Variables available to the expression:
+//
+//	request:          model, type, stream and body of the request
+//	response:         model of the response
+//	usage:            prompt_tokens, completion_tokens, total_tokens, images and videos,
+//	                  images is a list of width, height, quality and style
+//	                  videos is a list of width, height and seconds
+//	duration_seconds: seconds elapsed since the request arrived
+type MeteringExpression struct {
+	// Unit of the billable units, such as tokens, images, characters or seconds
+	// +kubebuilder:validation:Required
+	Unit string `json:"unit"`
+	// Expression is a CEL expression evaluates to a number.
+	// Example:
+	//
+	// 	usage.prompt_tokens + usage.completion_tokens * 3
+	//
+	// 	size(request.body.input)
+	// +kubebuilder:validation:Required
+	Expression string `json:"expression"`
+}
+
+type Provider string
+
+const (
+	ProviderOpenAI Provider = "OpenAI"
+	ProviderVLLM   Provider = "vLLM"
+	ProviderOllama Provider = "Ollama"
+
+	ProviderAzureOpenAI Provider = "AzureOpenAI"
+	ProviderAWSBedrock  Provider = "AWSBedrock"
+	ProviderGemini      Provider = "Gemini"
+	ProviderAnthropic   Provider = "Anthropic"
+
+	ProviderOpenAIV1Speech           Provider = "OpenAIV1Speech"
+	ProviderDeepgramWebSocketV1      Provider = "DeepgramWebSocketV1"
+	ProviderElevenLabsV1             Provider = "ElevenLabsV1"
+	ProviderKoemotionV1              Provider = "KoemotionV1"
+	ProviderVolcengineSeedSpeechV1   Provider = "VolcengineSeedSpeechServiceV1"
+	ProviderAlibabaCosyVoiceService  Provider = "AlibabaCosyVoiceService"
+	ProviderMicrosoftSpeechServiceV1 Provider = "MicrosoftSpeechServiceV1"
+)
+
+type BackendType string
+
+const (
+	BackendTypeLLM             BackendType = "LLM"
+	BackendTypeImageGeneration BackendType = "ImageGeneration"
+	BackendTypeEmbedding       BackendType = "Embedding"
+)
+
+// SystemPromptMode is how the system prompt of the prompt template is injected
+// +kubebuilder:validation:Enum=Default;Prepend;Override
+type SystemPromptMode string
+
+const (
+	// SystemPromptModeDefault adds the system prompt only when the request
+	// has no system message
+	SystemPromptModeDefault SystemPromptMode = "Default"
+	// SystemPromptModePrepend adds the system prompt before the system
+	// messages of the request
+	SystemPromptModePrepend SystemPromptMode = "Prepend"
+	// SystemPromptModeOverride replaces the system messages of the request
+	// with the system prompt
+	SystemPromptModeOverride SystemPromptMode = "Override"
+)
+
+// PromptTemplate injects the system prompt of the operators into the chat
+// completions requests, and wraps the last user message by the prefix and the
+// suffix. The variables {{user_id}}, {{api_key_id}}, {{model}} and {{date}},
+// and the custom ones, are substituted in them.
+type PromptTemplate struct {
+	// SystemPrompt injected into the requests
+	// +kubebuilder:validation:Optional
+	// +optional
+	SystemPrompt string `json:"systemPrompt,omitempty"`
+	// SystemPromptMode is how the system prompt is injected, default is
+	// Default
+	// +kubebuilder:validation:Optional
+	// +optional
+	SystemPromptMode SystemPromptMode `json:"systemPromptMode,omitempty"`
+	// UserPrefix prepended to the last user message, separated by a new line
+	// +kubebuilder:validation:Optional
+	// +optional
+	UserPrefix string `json:"userPrefix,omitempty"`
+	// UserSuffix appended to the last user message, separated by a new line
+	// +kubebuilder:validation:Optional
+	// +optional
+	UserSuffix string `json:"userSuffix,omitempty"`
+	// Variables are the custom variables, e.g. team: search for {{team}}
+	// +kubebuilder:validation:Optional
+	// +optional
+	Variables map[string]string `json:"variables,omitempty"`
+	// DateFormat is the layout of {{date}} in Go, default is 2006-01-02
+	// +kubebuilder:validation:Optional
+	// +optional
+	DateFormat string `json:"dateFormat,omitempty"`
+}
@@ -0,0 +1,117 @@
+package v1alpha2
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"knoway.dev/api/v1beta1"
+)
+
+// Hub marks ImageGenerationBackend as the version the other versions convert
+// through.
+func (*ImageGenerationBackend) Hub() {}
+
+// Hub marks EmbeddingBackend as the version the other versions convert
+// through.
+func (*EmbeddingBackend) Hub() {}
+
+// convert copies src into dst through their JSON representation, the
+// versions share the same schema except for the fields renamed in v1alpha2,
+// which are handled by the callers.
+func convert(src, dst any, gvk schema.GroupVersionKind) error {
+	bs, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", gvk.Kind, err)
+	}
+
+	err = json.Unmarshal(bs, dst)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s to %s: %w", gvk.Kind, gvk.GroupVersion(), err)
+	}
+
+	return nil
+}
+
+// ConvertTo converts the LLMBackend to the hub version.
+func (l *LLMBackend) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1beta1.LLMBackend)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+
+	gvk := v1beta1.GroupVersion.WithKind("LLMBackend")
+
+	err := convert(l, dst, gvk)
+	if err != nil {
+		return err
+	}
+
+	dst.SetGroupVersionKind(gvk)
+
+	// v1beta1 still names removeParamKeys RemoveParamKeys
+	dst.Spec.Upstream.RemoveParamKeys = l.Spec.Upstream.RemoveParamKeys
+
+	return nil
+}
+
+// ConvertFrom converts the hub version to the LLMBackend.
+func (l *LLMBackend) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1beta1.LLMBackend)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+
+	gvk := GroupVersion.WithKind("LLMBackend")
+
+	err := convert(src, l, gvk)
+	if err != nil {
+		return err
+	}
+
+	l.SetGroupVersionKind(gvk)
+
+	l.Spec.Upstream.RemoveParamKeys = src.Spec.Upstream.RemoveParamKeys
+
+	return nil
+}
+
+// ConvertTo converts the ModelRoute to the hub version.
+func (m *ModelRoute) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1beta1.ModelRoute)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+
+	gvk := v1beta1.GroupVersion.WithKind("ModelRoute")
+
+	err := convert(m, dst, gvk)
+	if err != nil {
+		return err
+	}
+
+	dst.SetGroupVersionKind(gvk)
+
+	return nil
+}
+
+// ConvertFrom converts the hub version to the ModelRoute.
+func (m *ModelRoute) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1beta1.ModelRoute)
+	if !ok {
+		return fmt.Errorf("unexpected hub type %T", hub)
+	}
+
+	gvk := GroupVersion.WithKind("ModelRoute")
+
+	err := convert(src, m, gvk)
+	if err != nil {
+		return err
+	}
+
+	m.SetGroupVersionKind(gvk)
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knoway.dev/api/v1beta1"
+)
+
+func TestLLMBackend_Conversion(t *testing.T) {
+	backend := &LLMBackend{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4o", ResourceVersion: "42"},
+		Spec: LLMBackendSpec{
+			ModelName: lo.ToPtr("gpt-4o"),
+			Provider:  ProviderOpenAI,
+			Upstream: BackendUpstream{
+				BaseURL:         "https://api.openai.com/v1",
+				RemoveParamKeys: []string{"user"},
+				CircuitBreaker:  &CircuitBreaker{ConsecutiveErrors: 5, Cooldown: 30},
+			},
+		},
+		Status: LLMBackendStatus{Status: Healthy},
+	}
+
+	hub := new(v1beta1.LLMBackend)
+	require.NoError(t, backend.ConvertTo(hub))
+
+	assert.Equal(t, v1beta1.GroupVersion.WithKind("LLMBackend"), hub.GroupVersionKind())
+	assert.Equal(t, "42", hub.ResourceVersion)
+	assert.Equal(t, []string{"user"}, hub.Spec.Upstream.RemoveParamKeys)
+	require.NotNil(t, hub.Spec.Upstream.CircuitBreaker)
+	assert.Equal(t, int32(5), hub.Spec.Upstream.CircuitBreaker.ConsecutiveErrors)
+	assert.Equal(t, v1beta1.Healthy, hub.Status.Status)
+
+	converted := new(LLMBackend)
+	require.NoError(t, converted.ConvertFrom(hub))
+
+	assert.Equal(t, GroupVersion.WithKind("LLMBackend"), converted.GroupVersionKind())
+
+	converted.TypeMeta = backend.TypeMeta
+	assert.Equal(t, backend, converted)
+}
+
+func TestModelRoute_Conversion(t *testing.T) {
+	route := &ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4o"},
+		Spec: ModelRouteSpec{
+			ModelName: "gpt-4o",
+			Route: &ModelRouteRoute{
+				LoadBalancePolicy: LoadBalancePolicyWeightedRoundRobin,
+				Targets: []ModelRouteRouteTarget{
+					{Destination: ModelRouteRouteTargetDestination{Backend: "gpt-4o", Weight: lo.ToPtr(1)}},
+				},
+			},
+			Fallback: &ModelRouteFallback{
+				MaxRetries: lo.ToPtr[uint64](3),
+			},
+		},
+	}
+
+	hub := new(v1beta1.ModelRoute)
+	require.NoError(t, route.ConvertTo(hub))
+
+	assert.Equal(t, v1beta1.GroupVersion.WithKind("ModelRoute"), hub.GroupVersionKind())
+	require.NotNil(t, hub.Spec.Route)
+	assert.Equal(t, v1beta1.LoadBalancePolicyWeightedRoundRobin, hub.Spec.Route.LoadBalancePolicy)
+	assert.Equal(t, uint64(3), lo.FromPtr(hub.Spec.Fallback.MaxRetries))
+
+	converted := new(ModelRoute)
+	require.NoError(t, converted.ConvertFrom(hub))
+
+	converted.TypeMeta = route.TypeMeta
+	assert.Equal(t, route, converted)
+}
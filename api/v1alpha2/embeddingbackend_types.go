@@ -0,0 +1,166 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.provider`
+//+kubebuilder:printcolumn:name="Model Name",type=string,JSONPath=`.spec.modelName`
+//+kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.spec.upstream.baseUrl`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+
+// EmbeddingBackend is the Schema for the embeddingbackends API.
+type EmbeddingBackend struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EmbeddingBackendSpec   `json:"spec,omitempty"`
+	Status EmbeddingBackendStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EmbeddingBackendList contains a list of EmbeddingBackend.
+type EmbeddingBackendList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EmbeddingBackend `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EmbeddingBackend{}, &EmbeddingBackendList{})
+}
+
+// EmbeddingBackendSpec defines the desired state of EmbeddingBackend.
+type EmbeddingBackendSpec struct {
+	// ModelName specifies the name of the model
+	// +kubebuilder:validation:Optional
+	// +optional
+	ModelName *string `json:"modelName,omitempty"`
+	// Provider indicates the organization providing the model
+	// +kubebuilder:validation:Enum=OpenAI;vLLM;Ollama
+	Provider Provider `json:"provider,omitempty"`
+	// Upstream contains information about the upstream configuration
+	Upstream EmbeddingBackendUpstream `json:"upstream,omitempty"`
+	// Filters are applied to the model's requests
+	Filters []EmbeddingFilter `json:"filters,omitempty"`
+	// MeteringPolicy contains configurations about how to count the usage of the model
+	// +kubebuilder:validation:Optional
+	// +optional
+	MeteringPolicy *MeteringPolicy `json:"meteringPolicy,omitempty"`
+	// Pricing is the price of the usage of the model, the cost of requests
+	// is computed by the cost filter of listeners
+	// +optional
+	Pricing *Pricing `json:"pricing,omitempty"`
+	// Maintenance takes the backend out of rotation
+	// +optional
+	Maintenance *MaintenanceSpec `json:"maintenance,omitempty"`
+	// Disabled removes the backend from the gateway while keeping the
+	// resource and its configuration, set it back to false to restore.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// EmbeddingBackendUpstream defines the upstream server configuration.
+type EmbeddingBackendUpstream struct {
+	// BaseUrl define upstream endpoint url
+	// Example:
+	// 		https://api.openai.com/v1
+	//
+	//  	http://bge-m3.default.svc.cluster.local:8000/v1
+	BaseURL string `json:"baseUrl,omitempty"`
+
+	// Headers defines the common headers for the model, such as the authentication header for the API key.
+	// Example:
+	//
+	// headers：
+	// 	- key: apikey
+	// 	  value: "sk-or-v1-xxxxxxxxxx"
+	Headers []Header `json:"headers,omitempty"`
+	// Headers defines the common headers for the model, such as the authentication header for the API key.
+	// Example:
+	//
+	// headersFrom：
+	// 	- prefix: sk-or-v1-
+	//	  refType: Secret
+	//	  refName: common-gpt4-apikey
+	HeadersFrom []HeaderFromSource `json:"headersFrom,omitempty"`
+	// Auth places credentials into the query parameters or cookies of
+	// upstream requests, for upstreams not authenticating with headers.
+	Auth []UpstreamAuth `json:"auth,omitempty"`
+
+	DefaultParams   *EmbeddingModelParams `json:"defaultParams,omitempty"`
+	OverrideParams  *EmbeddingModelParams `json:"overrideParams,omitempty"`
+	RemoveParamKeys []string              `json:"removeParamKeys,omitempty"`
+
+	Timeout int32 `json:"timeout,omitempty"`
+	// CircuitBreaker ejects the backend from the route targets for a
+	// cooldown after consecutive upstream errors when set.
+	// +optional
+	CircuitBreaker *CircuitBreaker `json:"circuitBreaker,omitempty"`
+	// Connection tunes the connections to the upstream, the connections of
+	// every backend are kept apart, with the defaults when not set.
+	// +optional
+	Connection *Connection `json:"connection,omitempty"`
+}
+
+type EmbeddingModelParams struct {
+	// OpenAI model parameters
+	OpenAI *OpenAIEmbeddingParam `json:"openai,omitempty"`
+}
+
+type OpenAIEmbeddingParam struct {
+	Model string `json:"model,omitempty"`
+
+	// EncodingFormat specifies the format to return the embeddings in.
+	// Must be one of float or base64.
+	// +kubebuilder:validation:Enum=float;base64
+	EncodingFormat *string `json:"encoding_format,omitempty"`
+	// Dimensions specifies the number of dimensions the resulting output
+	// embeddings should have, only supported by some of the models.
+	// +kubebuilder:validation:Minimum=1
+	Dimensions *int `json:"dimensions,omitempty"`
+	// A unique identifier representing your end-user, which can help OpenAI to
+	// monitor and detect abuse.
+	User *string `json:"user,omitempty"`
+}
+
+// EmbeddingFilter represents the embedding backend filter configuration.
+type EmbeddingFilter struct {
+	Name string `json:"name,omitempty"` // Filter name
+
+	FilterConfig `json:",inline"`
+}
+
+// EmbeddingBackendStatus defines the observed state of EmbeddingBackend.
+type EmbeddingBackendStatus struct {
+	// Status indicates the health of the backend: Unknown, Healthy, Failed,
+	// Maintenance, or Disabled
+	// +kubebuilder:validation:Enum=Unknown;Healthy;Failed;Maintenance;Disabled
+	Status StatusEnum `json:"status,omitempty"`
+
+	// Conditions represent the current conditions of the backend
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Endpoints holds the upstream addresses of the current model (pod IP addresses)
+	Endpoints []string `json:"endpoints,omitempty"`
+}
@@ -0,0 +1,443 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Provider",type=string,JSONPath=`.spec.provider`
+//+kubebuilder:printcolumn:name="Model Name",type=string,JSONPath=`.spec.modelName`
+//+kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.spec.upstream.baseUrl`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+
+// LLMBackend is the Schema for the llmbackends API
+type LLMBackend struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LLMBackendSpec   `json:"spec,omitempty"`
+	Status LLMBackendStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LLMBackendList contains a list of LLMBackend
+type LLMBackendList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LLMBackend `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LLMBackend{}, &LLMBackendList{})
+}
+
+// LLMBackendSpec defines the desired state of LLMBackend
+type LLMBackendSpec struct {
+	// ModelName specifies the name of the model
+	// +kubebuilder:validation:Optional
+	// +optional
+	ModelName *string `json:"modelName,omitempty"`
+	// Provider indicates the organization providing the model
+	// +kubebuilder:validation:Enum=OpenAI;vLLM;Ollama;AzureOpenAI;AWSBedrock;Gemini;Anthropic;OpenAIV1Speech;DeepgramWebSocketV1;ElevenLabsV1;KoemotionV1;VolcengineSeedSpeechServiceV1;AlibabaCosyVoiceService;MicrosoftSpeechServiceV1
+	Provider Provider `json:"provider,omitempty"`
+	// Upstream contains information about the upstream configuration
+	Upstream BackendUpstream `json:"upstream,omitempty"`
+	// Filters are applied to the model's requests
+	Filters []LLMBackendFilter `json:"filters,omitempty"`
+	// MeteringPolicy contains configurations about how to count the usage of the model
+	// +kubebuilder:validation:Optional
+	// +optional
+	MeteringPolicy *MeteringPolicy `json:"meteringPolicy,omitempty"`
+	// Pricing is the price of the usage of the model, the cost of requests
+	// is computed by the cost filter of listeners
+	// +optional
+	Pricing *Pricing `json:"pricing,omitempty"`
+	// ModelInfo describes the model to the clients listing the models
+	// +optional
+	ModelInfo *ModelInfo `json:"modelInfo,omitempty"`
+	// PromptTemplate injects the system prompt and wraps the user prompts of
+	// the chat completions requests before they are sent to the upstream
+	// +optional
+	PromptTemplate *PromptTemplate `json:"promptTemplate,omitempty"`
+	// Maintenance takes the backend out of rotation
+	// +optional
+	Maintenance *MaintenanceSpec `json:"maintenance,omitempty"`
+	// Disabled removes the backend from the gateway while keeping the
+	// resource and its configuration, set it back to false to restore.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// BackendUpstream defines the upstream server configuration.
+type BackendUpstream struct {
+	// BaseUrl define upstream endpoint url
+	// Example:
+	// 		https://openrouter.ai/api/v1/chat/completions
+	//
+	//  	http://phi3-mini.default.svc.cluster.local:8000/api/v1/chat/completions
+	BaseURL string `json:"baseUrl,omitempty"`
+	// Service references the Kubernetes Service serving the upstream in the
+	// namespace of the backend instead of BaseUrl, requests are sent to the
+	// ready pods of the Service tracked by its EndpointSlices directly.
+	// Example:
+	//
+	// service:
+	// 	name: qwen3-vllm
+	// 	port: 8000
+	// 	path: /v1
+	// +optional
+	Service *UpstreamService `json:"service,omitempty"`
+	// LoadBalancePolicy balances the requests across the endpoints of the
+	// upstream, i.e. the ready pods of Service, default: RoundRobin, or the
+	// least loaded endpoint by the metrics of vLLM for the vLLM provider.
+	// +optional
+	LoadBalancePolicy EndpointLoadBalancePolicy `json:"loadBalancePolicy,omitempty"`
+
+	// Headers defines the common headers for the model, such as the authentication header for the API key.
+	// Example:
+	//
+	// headers：
+	// 	- key: apikey
+	// 	  value: "sk-or-v1-xxxxxxxxxx"
+	Headers []Header `json:"headers,omitempty"`
+	// Headers defines the common headers for the model, such as the authentication header for the API key.
+	// Example:
+	//
+	// headersFrom：
+	// 	- prefix: sk-or-v1-
+	//	  refType: Secret
+	//	  refName: common-gpt4-apikey
+	HeadersFrom []HeaderFromSource `json:"headersFrom,omitempty"`
+	// Auth places credentials into the query parameters or cookies of
+	// upstream requests, for upstreams not authenticating with headers.
+	// Example:
+	//
+	// auth:
+	// 	- scheme: QueryParam
+	// 	  name: key
+	// 	  valueFrom:
+	// 	    secretKeyRef:
+	// 	      name: gemini-apikey
+	// 	      key: apikey
+	Auth []UpstreamAuth `json:"auth,omitempty"`
+	// APIKeys rotates the requests between multiple API keys kept in
+	// Secrets, keys answered with 401, 403 or 429 are disabled for a
+	// cooldown.
+	// Example:
+	//
+	// apiKeys:
+	// 	secretKeyRefs:
+	// 	  - name: openai-apikeys
+	// 	    key: primary
+	// 	  - name: openai-apikeys
+	// 	    key: secondary
+	// +optional
+	APIKeys *UpstreamAPIKeys `json:"apiKeys,omitempty"`
+	// Paths overrides the paths appended to BaseUrl for each type of
+	// requests, so that BaseUrl only needs to be the base of the API, the
+	// {model} placeholder is replaced by the model name.
+	// Example:
+	//
+	// baseUrl: https://api.example.com
+	// paths:
+	// 	chatCompletions: /v2/{model}/chat
+	// 	embeddings: /v2/{model}/embed
+	// +optional
+	Paths *UpstreamPaths `json:"paths,omitempty"`
+	// Query defines the static query parameters of upstream requests.
+	// Example:
+	//
+	// query:
+	// 	- name: api-version
+	// 	  value: "2024-10-21"
+	// 	- name: key
+	// 	  valueFrom:
+	// 	    secretKeyRef:
+	// 	      name: upstream-apikey
+	// 	      key: apikey
+	// +optional
+	Query []UpstreamQueryParam `json:"query,omitempty"`
+	// AzureOpenAI configures the deployment serving the model when the
+	// provider is AzureOpenAI, BaseUrl is the endpoint of the resource.
+	// Example:
+	//
+	// baseUrl: https://my-resource.openai.azure.com
+	// azureOpenAI:
+	// 	deployment: gpt-4o
+	// 	apiVersion: 2024-10-21
+	// +optional
+	AzureOpenAI *AzureOpenAIUpstream `json:"azureOpenAI,omitempty"`
+	// AWSBedrock configures the signing of requests when the provider is
+	// AWSBedrock, BaseUrl is the endpoint of Bedrock Runtime, and the model
+	// name is the model ID. Credentials are read from the AWS_ACCESS_KEY_ID,
+	// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables of
+	// the gateway.
+	// Example:
+	//
+	// baseUrl: https://bedrock-runtime.us-east-1.amazonaws.com
+	// awsBedrock:
+	// 	region: us-east-1
+	// +optional
+	AWSBedrock *AWSBedrockUpstream `json:"awsBedrock,omitempty"`
+
+	DefaultParams   *ModelParams `json:"defaultParams,omitempty"`
+	OverrideParams  *ModelParams `json:"overrideParams,omitempty"`
+	RemoveParamKeys []string     `json:"removeParamKeys,omitempty"`
+
+	Timeout int32 `json:"timeout,omitempty"`
+	// HealthCheck probes the upstream periodically when set, the backend is
+	// removed from routing while the upstream is unhealthy.
+	// +optional
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+	// CircuitBreaker ejects the backend from the route targets for a
+	// cooldown after consecutive upstream errors when set.
+	// +optional
+	CircuitBreaker *CircuitBreaker `json:"circuitBreaker,omitempty"`
+	// Connection tunes the connections to the upstream, the connections of
+	// every backend are kept apart, with the defaults when not set.
+	// +optional
+	Connection *Connection `json:"connection,omitempty"`
+	// DeadlineHeader forwards the time remaining of the timeout of the model
+	// route to the upstream in milliseconds when set, for in-house upstreams
+	// able to adapt the length of generations to it.
+	// +optional
+	DeadlineHeader *DeadlineHeader `json:"deadlineHeader,omitempty"`
+}
+
+// EndpointLoadBalancePolicy is the load balance policy across the endpoints of
+// an upstream.
+// +kubebuilder:validation:Enum=RoundRobin;LeastLatency
+type EndpointLoadBalancePolicy string
+
+const (
+	EndpointLoadBalancePolicyRoundRobin EndpointLoadBalancePolicy = "RoundRobin"
+	// EndpointLoadBalancePolicyLeastLatency prefers the endpoint with the
+	// lowest moving average of time to first chunk, or to response headers
+	// when no stream was observed.
+	EndpointLoadBalancePolicyLeastLatency EndpointLoadBalancePolicy = "LeastLatency"
+)
+
+// UpstreamService references the Service of the upstream.
+type UpstreamService struct {
+	// Name of the Service
+	Name string `json:"name"`
+	// Port of the Service, can be omitted if the Service has only one port
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// Scheme of the upstream, default: http
+	// +kubebuilder:validation:Enum=http;https
+	// +optional
+	Scheme string `json:"scheme,omitempty"`
+	// Path is the base path of the API appended to the address of the
+	// Service, such as /v1
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+type AWSBedrockUpstream struct {
+	// Region is the region of Bedrock Runtime, default is parsed from BaseUrl
+	// +optional
+	Region string `json:"region,omitempty"`
+}
+
+type AzureOpenAIUpstream struct {
+	// Deployment is the name of the deployment, default is the model name
+	// +optional
+	Deployment string `json:"deployment,omitempty"`
+	// APIVersion is the api-version query parameter, default is 2024-10-21
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+	// KeepAuthorization sends the Authorization header as is instead of as
+	// the api-key header, for Microsoft Entra ID tokens
+	// +optional
+	KeepAuthorization bool `json:"keepAuthorization,omitempty"`
+}
+
+type ModelParams struct {
+	// OpenAI model parameters
+	OpenAI *OpenAIParam `json:"openai,omitempty"`
+	// Gemini model parameters
+	Gemini *GeminiParam `json:"gemini,omitempty"`
+}
+
+type CommonParams struct {
+	Model string `json:"model,omitempty"`
+
+	// Temperature is the sampling temperature, between 0 and 2.
+	// Higher values like 0.8 make the output more random, while lower values like 0.2 make it more focused and deterministic.
+	Temperature *string `json:"temperature,omitempty" floatString:"true"`
+}
+
+type OpenAIParam struct {
+	CommonParams `json:",inline"`
+
+	// MaxTokens is deprecated. Use MaxCompletionTokens instead.
+	// This value is not compatible with o1 series models.
+	MaxTokens *int `json:"max_tokens,omitempty"`
+	// MaxCompletionTokens limits the maximum number of tokens for completion.
+	MaxCompletionTokens *int `json:"max_completion_tokens,omitempty"`
+	// TopP is the nucleus sampling probability, between 0 and 1.
+	TopP *string `json:"top_p,omitempty" floatString:"true"`
+	// Stream specifies whether to enable streaming responses.
+	Stream *bool `json:"stream,omitempty"`
+	// StreamOptions defines additional options for streaming responses.
+	StreamOptions *StreamOptions `json:"stream_options,omitempty"`
+}
+
+type GeminiParam struct {
+	// SafetySettings are passed through as the safetySettings of Gemini
+	// requests.
+	SafetySettings []GeminiSafetySetting `json:"safety_settings,omitempty"`
+}
+
+type GeminiSafetySetting struct {
+	// Category is the harm category, such as HARM_CATEGORY_HATE_SPEECH
+	Category string `json:"category"`
+	// Threshold is the blocking threshold, such as BLOCK_ONLY_HIGH
+	Threshold string `json:"threshold"`
+}
+
+type StreamOptions struct {
+	// IncludeUsage indicates whether to include usage statistics before the [DONE] message.
+	IncludeUsage *bool `json:"include_usage,omitempty"`
+}
+
+// LLMBackendFilter represents the backend filter configuration.
+type LLMBackendFilter struct {
+	Name string `json:"name,omitempty"` // Filter name
+
+	FilterConfig `json:",inline"`
+}
+
+// FilterConfig represents the configuration for filters.
+// At least one of the following must be specified: UsageStatsConfig, ModelRewriteConfig, or CustomConfig
+// +kubebuilder:validation:Required
+type FilterConfig struct {
+	// Custom transforms the bodies of the requests sent to the backend and of
+	// the responses of it with JSON Patch operations and CEL expressions
+	// Example:
+	//
+	// 	custom:
+	// 		request:
+	// 			jsonPatch:
+	// 			- op: move
+	// 			  from: /max_tokens
+	// 			  path: /max_completion_tokens
+	// 			cel:
+	// 			- path: /temperature
+	// 			  expression: "has(body.temperature) ? dyn(body.temperature / 2.0) : null"
+	//
+	// +kubebuilder:validation:OneOf
+	// +optional
+	Custom *CustomFilter `json:"custom,omitempty"`
+}
+
+// CustomFilter transforms the bodies of the requests sent to the backend and
+// of the responses of it, for the quirks of providers which are not worth
+// new filters.
+type CustomFilter struct {
+	// Request transforms the bodies of the requests, after they are translated
+	// for the provider of the backend
+	// +kubebuilder:validation:Optional
+	// +optional
+	Request *BodyTransform `json:"request,omitempty"`
+	// Response transforms the bodies of the non-streaming responses, after
+	// they are translated from the provider of the backend
+	// +kubebuilder:validation:Optional
+	// +optional
+	Response *BodyTransform `json:"response,omitempty"`
+}
+
+// BodyTransform transforms JSON bodies, the JSON Patch operations are applied
+// before the CEL assignments.
+type BodyTransform struct {
+	// JSONPatch operations applied to the body, in the form of RFC 6902
+	// +kubebuilder:validation:Optional
+	// +optional
+	JSONPatch []JSONPatchOperation `json:"jsonPatch,omitempty"`
+	// CEL assignments of the fields of the body
+	// +kubebuilder:validation:Optional
+	// +optional
+	CEL []CELAssignment `json:"cel,omitempty"`
+}
+
+// JSONPatchOp is the operation of JSON Patch
+// +kubebuilder:validation:Enum=add;remove;replace;move;copy;test
+type JSONPatchOp string
+
+// JSONPatchOperation is an operation of JSON Patch.
+type JSONPatchOperation struct {
+	// Op of the operation
+	// +kubebuilder:validation:Required
+	Op JSONPatchOp `json:"op"`
+	// Path is the JSON pointer of the target, e.g. /max_completion_tokens
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+	// From is the JSON pointer of the source of move and copy
+	// +kubebuilder:validation:Optional
+	// +optional
+	From string `json:"from,omitempty"`
+	// Value of add, replace and test
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Optional
+	// +optional
+	Value *runtime.RawExtension `json:"value,omitempty"`
+}
+
+// CELAssignment sets the field of the path to the result of the expression,
+// the field is removed when the result is null. The expression is evaluated
+// against the body as body, and the body of the request as request when the
+// body is the one of a response.
+type CELAssignment struct {
+	// Path is the JSON pointer of the field, the parents missing are created
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+	// Expression of CEL, e.g. body.max_tokens * 2
+	// +kubebuilder:validation:Required
+	Expression string `json:"expression"`
+}
+
+// UsageStatsConfig defines the configuration for usage statistics.
+type UsageStatsConfig struct {
+	Address string `json:"address,omitempty"`
+}
+
+// OpenAIModelNameRewriteConfig defines the configuration for rewriting OpenAI model names.
+type OpenAIModelNameRewriteConfig struct {
+	ModelName string `json:"modelName,omitempty"`
+}
+
+// LLMBackendStatus defines the observed state of LLMBackend
+type LLMBackendStatus struct {
+	// Status indicates the health of the backend: Unknown, Healthy, Failed,
+	// Maintenance, or Disabled
+	// +kubebuilder:validation:Enum=Unknown;Healthy;Failed;Maintenance;Disabled
+	Status StatusEnum `json:"status,omitempty"`
+
+	// Conditions represent the current conditions of the backend
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Endpoints holds the upstream addresses of the current model (pod IP addresses)
+	Endpoints []string `json:"endpoints,omitempty"`
+}
@@ -0,0 +1,7 @@
+package v1beta1
+
+// Hub marks LLMBackend as the version the other versions convert through.
+func (*LLMBackend) Hub() {}
+
+// Hub marks ModelRoute as the version the other versions convert through.
+func (*ModelRoute) Hub() {}
@@ -0,0 +1,813 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Model Name",type=string,JSONPath=`.spec.modelName`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+
+type RateLimitBasedOn string
+
+type RateLimitUnit string
+
+type ConcurrencyLimitBasedOn string
+
+const (
+	// ModelRouteRateLimitBasedOnAPIKey indicates rate limiting based on API key
+	ModelRouteRateLimitBasedOnAPIKey RateLimitBasedOn = "APIKey"
+	// ModelRouteRateLimitBasedOnUserID indicates rate limiting based on user identity
+	ModelRouteRateLimitBasedOnUserID RateLimitBasedOn = "UserID"
+
+	// RateLimitUnitRequests limits the number of requests
+	RateLimitUnitRequests RateLimitUnit = "Requests"
+	// RateLimitUnitTokens limits the total tokens of prompts and completions,
+	// deducted after the responses are received
+	RateLimitUnitTokens RateLimitUnit = "Tokens"
+
+	// ConcurrencyLimitBasedOnRoute shares the limit among all of the requests
+	// of the route
+	ConcurrencyLimitBasedOnRoute ConcurrencyLimitBasedOn = "Route"
+	// ConcurrencyLimitBasedOnUserID shares the limit among the requests of
+	// each user
+	ConcurrencyLimitBasedOnUserID ConcurrencyLimitBasedOn = "UserID"
+	// ConcurrencyLimitBasedOnAPIKey shares the limit among the requests of
+	// each API key
+	ConcurrencyLimitBasedOnAPIKey ConcurrencyLimitBasedOn = "APIKey"
+
+	FilterTypeRateLimit         string = "RateLimit"
+	FilterTypeCache             string = "Cache"
+	FilterTypeConcurrencyLimit  string = "ConcurrencyLimit"
+	FilterTypePromptCompression string = "PromptCompression"
+	FilterTypeImagePromptPolicy string = "ImagePromptPolicy"
+	FilterTypePromptTemplate    string = "PromptTemplate"
+	FilterTypeVision            string = "Vision"
+)
+
+type StringMatch struct {
+	// Exact match value
+	Exact string `json:"exact,omitempty"`
+	// Prefix match value
+	Prefix string `json:"prefix,omitempty"`
+}
+
+type RateLimitRule struct {
+	// Match specifies the match criteria for this rate limit
+	Match *StringMatch `json:"match,omitempty"`
+	// Number of requests (or tokens, see Unit) allowed in the duration window
+	// If set to 0, rate limiting will be disabled
+	Limit int `json:"limit,omitempty"`
+	// BasedOn specifies what the rate limit is based on
+	// +kubebuilder:validation:Enum=APIKey;UserID
+	BasedOn RateLimitBasedOn `json:"basedOn,omitempty"`
+	// Default duration is 300 seconds, with the unit being seconds
+	Duration int64 `json:"duration,omitempty"`
+	// Unit of the limit, defaults to Requests
+	// +kubebuilder:validation:Enum=Requests;Tokens
+	// +optional
+	Unit RateLimitUnit `json:"unit,omitempty"`
+	// Prepaid turns the limit into a budget of tokens that never gets
+	// replenished, Duration is ignored, only valid for the Tokens unit
+	// +optional
+	Prepaid bool `json:"prepaid,omitempty"`
+}
+
+type ConcurrencyLimitRule struct {
+	// Match specifies the user or the API key the limit applies to, ignored
+	// when based on Route
+	// +optional
+	Match *StringMatch `json:"match,omitempty"`
+	// BasedOn specifies what the limit is shared among, defaults to Route
+	// +kubebuilder:validation:Enum=Route;UserID;APIKey
+	// +optional
+	BasedOn ConcurrencyLimitBasedOn `json:"basedOn,omitempty"`
+	// MaxInFlight is the maximum number of requests in-flight at the same time
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Required
+	MaxInFlight int32 `json:"maxInFlight"`
+	// MaxQueued is the maximum number of requests waiting for a slot,
+	// requests beyond it are rejected immediately, 0 disables queueing
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxQueued int32 `json:"maxQueued,omitempty"`
+	// QueueTimeout is the maximum time a request waits for a slot, defaults
+	// to 30s
+	// +optional
+	QueueTimeout *metav1.Duration `json:"queueTimeout,omitempty"`
+	// RetryAfter is returned in the Retry-After header of the rejections,
+	// defaults to 1s
+	// +optional
+	RetryAfter *metav1.Duration `json:"retryAfter,omitempty"`
+}
+
+// See also:
+// Supported load balancers — envoy 1.34.0-dev-e3a97f documentation
+// https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/upstream/load_balancing/load_balancers#arch-overview-load-balancing-types
+type LoadBalancePolicy string
+
+const (
+	LoadBalancePolicyWeightedRoundRobin   LoadBalancePolicy = "WeightedRoundRobin"
+	LoadBalancePolicyWeightedLeastRequest LoadBalancePolicy = "WeightedLeastRequest"
+	// LoadBalancePolicyWeightedLeastLatency prefers the backend with the lowest
+	// moving average of time to first chunk (or to response for non-streaming
+	// requests) divided by its weight, while still sending a small portion of
+	// requests by weights to keep observing the slower backends.
+	LoadBalancePolicyWeightedLeastLatency LoadBalancePolicy = "WeightedLeastLatency"
+)
+
+type ModelRouteRouteTargetDestination struct {
+	// Namespace of the backend to lookup for
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+	// Backend that the route target points to
+	// +kubebuilder:validation:Required
+	Backend string `json:"backend"`
+	// Weight of the target, only used in WeightedRoundRobin, WeightedLeastRequest and WeightedLeastLatency
+	// +kubebuilder:validation:Optional
+	// +optional
+	Weight *int `json:"weight"`
+}
+
+type ModelRouteRouteTarget struct {
+	// Destination specifies the destination of the route target
+	Destination ModelRouteRouteTargetDestination `json:"destination"`
+}
+
+type ModelRouteRouteFallback struct {
+	// Order specifies the order of the fallback
+	// +kubebuilder:validation:Optional
+	// +optional
+	Order []string `json:"order"`
+}
+
+// ModelRouteSessionAffinity sends the requests of the same session, e.g.
+// the turns of a conversation, to the same target so that the prefix caches
+// of the backends, e.g. the KV caches of vLLM, are reused. Requests without
+// sessions are load balanced as usual.
+type ModelRouteSessionAffinity struct {
+	// Header carrying the IDs of the sessions, e.g. X-Conversation-ID
+	// +kubebuilder:validation:Optional
+	// +optional
+	Header string `json:"header,omitempty"`
+	// UserField identifies the sessions by the user field of the requests
+	// when the header is unset or absent
+	// +kubebuilder:validation:Optional
+	// +optional
+	UserField bool `json:"userField,omitempty"`
+}
+
+type ModelRouteRoute struct {
+	// LoadBalancePolicy specifies the load balancing policy to use
+	// +kubebuilder:validation:Enum=WeightedRoundRobin;WeightedLeastRequest;WeightedLeastLatency
+	LoadBalancePolicy LoadBalancePolicy `json:"loadBalancePolicy"`
+	// Targets specifies the targets of the route
+	// +kubebuilder:validation:Required
+	Targets []ModelRouteRouteTarget `json:"targets"`
+	// FirstChunkSLO demotes targets whose first chunks of streams are slower
+	// than the objective
+	// +kubebuilder:validation:Optional
+	// +optional
+	FirstChunkSLO *ModelRouteFirstChunkSLO `json:"firstChunkSLO,omitempty"`
+	// Canary shifts the weight from a stable target to a canary target
+	// gradually
+	// +kubebuilder:validation:Optional
+	// +optional
+	Canary *ModelRouteCanary `json:"canary,omitempty"`
+	// SessionAffinity sends the requests of the same session to the same
+	// target
+	// +kubebuilder:validation:Optional
+	// +optional
+	SessionAffinity *ModelRouteSessionAffinity `json:"sessionAffinity,omitempty"`
+}
+
+// ModelRouteFirstChunkSLO reduces the effective weights of targets whose P95
+// latency of the first chunks of streams exceeds the objective for a
+// sustained window, and restores them gradually once they recover.
+type ModelRouteFirstChunkSLO struct {
+	// P95 is the objective of the P95 latency of the first chunks
+	// +kubebuilder:validation:Required
+	P95 metav1.Duration `json:"p95"`
+	// Window the P95 latency is computed over, as well as the time the
+	// objective must be violated or met before the weight changes, defaults
+	// to 60s.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+	// MinWeightPercent is the lower bound of the effective weight in percent
+	// of the configured weight, defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MinWeightPercent *int32 `json:"minWeightPercent,omitempty"`
+	// RecoveryStepPercent is the percent of the configured weight restored
+	// at each step of recovery, defaults to 20.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	RecoveryStepPercent *int32 `json:"recoveryStepPercent,omitempty"`
+}
+
+// ModelRouteCanary shifts the weight of the stable target to the canary target
+// step by step while the canary target meets the objectives over each analysis
+// window, and shifts it back to the stable target at once when the canary
+// target regresses.
+//
+// The weights of the other targets are kept, the combined weight of the stable
+// and the canary targets is split between them by the percent of the rollout.
+// The requests of the canary target are observed by the gateway the controller
+// runs in.
+type ModelRouteCanary struct {
+	// Stable is the backend of the target the weight is shifted from
+	// +kubebuilder:validation:Required
+	Stable string `json:"stable"`
+	// Canary is the backend of the target the weight is shifted to, the
+	// rollout restarts when it changes
+	// +kubebuilder:validation:Required
+	Canary string `json:"canary"`
+	// StepWeight is the percent of the weight shifted at each step, defaults
+	// to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	StepWeight *int32 `json:"stepWeight,omitempty"`
+	// MaxWeight is the percent of the weight of the canary target once the
+	// rollout is promoted, defaults to 100.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxWeight *int32 `json:"maxWeight,omitempty"`
+	// AnalysisWindow is how long each step lasts, as well as the window the
+	// error rate and the latency of the canary target are computed over,
+	// defaults to 60s, at most 15m.
+	// +kubebuilder:validation:Optional
+	// +optional
+	AnalysisWindow *metav1.Duration `json:"analysisWindow,omitempty"`
+	// MinRequests is the number of requests the canary target must serve in
+	// the analysis window before the step is analyzed, the step lasts until
+	// then, defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinRequests *int32 `json:"minRequests,omitempty"`
+	// MaxErrorRate is the objective of the ratio of requests of the canary
+	// target responded with 5xx status codes, defaults to 0.05
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +kubebuilder:validation:Optional
+	// +optional
+	MaxErrorRate string `json:"maxErrorRate,omitempty"`
+	// MaxLatencyP95 is the objective of the P95 latency of the requests of
+	// the canary target, not checked if unset
+	// +kubebuilder:validation:Optional
+	// +optional
+	MaxLatencyP95 *metav1.Duration `json:"maxLatencyP95,omitempty"`
+}
+
+type RateLimitPolicy struct {
+	// Rate limit rules
+	// +kubebuilder:validation:Optional
+	// +optional
+	Rules []*RateLimitRule `json:"rules"`
+}
+
+type ConcurrencyLimitPolicy struct {
+	// Concurrency limit rules, for each kind of BasedOn the first rule
+	// matching the request applies
+	// +kubebuilder:validation:Optional
+	// +optional
+	Rules []*ConcurrencyLimitRule `json:"rules"`
+}
+
+type CacheMode string
+
+const (
+	// CacheModeExact serves requests with the same body from the cache
+	CacheModeExact CacheMode = "Exact"
+	// CacheModeSemantic serves requests with similar messages from the cache
+	CacheModeSemantic CacheMode = "Semantic"
+)
+
+type CacheEmbedding struct {
+	// URL of the OpenAI compatible embeddings endpoint
+	// Example:
+	//		http://bge-m3.default.svc.cluster.local:8000/v1/embeddings
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+	// Model of the embeddings
+	// +kubebuilder:validation:Optional
+	// +optional
+	Model string `json:"model,omitempty"`
+	// Headers sent to the endpoint, such as the authentication header
+	// +kubebuilder:validation:Optional
+	// +optional
+	Headers []Header `json:"headers,omitempty"`
+}
+
+type CachePolicy struct {
+	// Mode of the cache, Exact matches the normalized request body, Semantic
+	// matches the similarity of the embeddings of messages
+	// +kubebuilder:validation:Enum=Exact;Semantic
+	// +kubebuilder:default=Exact
+	Mode CacheMode `json:"mode,omitempty"`
+	// How long the responses are cached, unit: second, default is 600 seconds
+	// +kubebuilder:validation:Optional
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+	// The maximum number of responses cached, default is 1000
+	// +kubebuilder:validation:Optional
+	// +optional
+	MaxEntries *int32 `json:"maxEntries,omitempty"`
+	// The minimum cosine similarity for a cache hit in Semantic mode, default
+	// is 0.95
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +kubebuilder:validation:Optional
+	// +optional
+	SimilarityThreshold string `json:"similarityThreshold,omitempty"`
+	// Embedding endpoint, required in Semantic mode
+	// +kubebuilder:validation:Optional
+	// +optional
+	Embedding *CacheEmbedding `json:"embedding,omitempty"`
+	// PerUser keeps the cached responses from being shared across users
+	// +kubebuilder:validation:Optional
+	// +optional
+	PerUser bool `json:"perUser,omitempty"`
+}
+
+// PromptSummarization summarizes the earlier messages of requests with a
+// cheap model.
+type PromptSummarization struct {
+	// URL of the OpenAI compatible chat completions endpoint
+	// Example:
+	//		http://qwen-mini.default.svc.cluster.local:8000/v1/chat/completions
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+	// Model of the summarization
+	// +kubebuilder:validation:Optional
+	// +optional
+	Model string `json:"model,omitempty"`
+	// Headers sent to the endpoint, such as the authentication header
+	// +kubebuilder:validation:Optional
+	// +optional
+	Headers []Header `json:"headers,omitempty"`
+	// Timeout of the summarization, unit: second, default is 10 seconds
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	// +optional
+	Timeout *int64 `json:"timeout,omitempty"`
+	// The number of the last messages never summarized, default is 4
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	// +optional
+	KeepLastMessages *int32 `json:"keepLastMessages,omitempty"`
+}
+
+// PromptCompressionPolicy compresses the prompts of chat completions requests
+// before they are sent to the backends, the steps enabled are applied in the
+// order of whitespace collapse, deduplication and summarization.
+type PromptCompressionPolicy struct {
+	// CollapseWhitespace collapses runs of whitespaces and blank lines in the
+	// text of messages
+	// +kubebuilder:validation:Optional
+	// +optional
+	CollapseWhitespace bool `json:"collapseWhitespace,omitempty"`
+	// Deduplicate drops the paragraphs of system and user messages repeated
+	// from earlier messages
+	// +kubebuilder:validation:Optional
+	// +optional
+	Deduplicate bool `json:"deduplicate,omitempty"`
+	// Summarization replaces the earlier messages with a summary of them
+	// +kubebuilder:validation:Optional
+	// +optional
+	Summarization *PromptSummarization `json:"summarization,omitempty"`
+	// Prompts estimated to be shorter than the tokens are not compressed
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	// +optional
+	MinPromptTokens *int64 `json:"minPromptTokens,omitempty"`
+	// The maximum fraction of the estimated prompt tokens removed, steps
+	// exceeding it are skipped, unlimited if unset
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +kubebuilder:validation:Optional
+	// +optional
+	MaxCompressionRatio string `json:"maxCompressionRatio,omitempty"`
+}
+
+// ImagePromptPolicy enforces the brand-safety rules of image generation
+// requests before they are sent to the backends, the prompts with banned terms
+// are rejected, the others are wrapped by the prefix and the suffix, and the
+// mandatory negative prompts are added.
+type ImagePromptPolicy struct {
+	// Prefix prepended to the prompts
+	// +kubebuilder:validation:Optional
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// Suffix appended to the prompts
+	// +kubebuilder:validation:Optional
+	// +optional
+	Suffix string `json:"suffix,omitempty"`
+	// NegativePrompts added to every request
+	// +kubebuilder:validation:Optional
+	// +optional
+	NegativePrompts []string `json:"negativePrompts,omitempty"`
+	// NegativePromptParam is the request parameter the negative prompts are
+	// merged into, e.g. negative_prompt of Stable Diffusion backends, they are
+	// appended to the prompt as "Avoid: ..." when empty
+	// +kubebuilder:validation:Optional
+	// +optional
+	NegativePromptParam string `json:"negativePromptParam,omitempty"`
+	// BannedTerms reject the prompts containing any of them as words,
+	// case-insensitively
+	// +kubebuilder:validation:Optional
+	// +optional
+	BannedTerms []string `json:"bannedTerms,omitempty"`
+}
+
+// VisionPolicy limits the images in the messages of chat completions requests
+// before they are sent to the backends, and estimates the input tokens of
+// them. Images embedded as data URLs are downscaled before their sizes are
+// checked.
+type VisionPolicy struct {
+	// MaxImages of each request, unlimited if unset
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	// +optional
+	MaxImages *int32 `json:"maxImages,omitempty"`
+	// MaxImageSizeBytes of each image embedded as a data URL, after decoded,
+	// unlimited if unset
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	// +optional
+	MaxImageSizeBytes *int64 `json:"maxImageSizeBytes,omitempty"`
+	// MaxDimension downscales the images embedded as data URLs with the
+	// longer sides exceeding it to it, in pixels, images are not downscaled
+	// if unset
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	// +optional
+	MaxDimension *int32 `json:"maxDimension,omitempty"`
+	// DenyRemoteURLs rejects the images of HTTP URLs, the sizes of which can't
+	// be limited by the gateway
+	// +kubebuilder:validation:Optional
+	// +optional
+	DenyRemoteURLs bool `json:"denyRemoteURLs,omitempty"`
+}
+
+// FallbackErrorClass is a class of errors of upstreams failed over to other
+// backends immediately
+// +kubebuilder:validation:Enum=ContentFilter;InsufficientQuota;RateLimited
+type FallbackErrorClass string
+
+const (
+	// FallbackErrorClassContentFilter matches the requests or responses
+	// refused by the content filters of upstreams, e.g. content_filter of
+	// Azure OpenAI
+	FallbackErrorClassContentFilter FallbackErrorClass = "ContentFilter"
+	// FallbackErrorClassInsufficientQuota matches the upstreams out of quota
+	// or credits, e.g. insufficient_quota of OpenAI
+	FallbackErrorClassInsufficientQuota FallbackErrorClass = "InsufficientQuota"
+	// FallbackErrorClassRateLimited matches the responses with 429 statuses,
+	// other than the insufficient quota ones
+	FallbackErrorClassRateLimited FallbackErrorClass = "RateLimited"
+)
+
+type ModelRouteFallbackBackend struct {
+	// Namespace of the backend, defaults to the namespace of the ModelRoute
+	// +kubebuilder:validation:Optional
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Backend the requests are failed over to
+	// +kubebuilder:validation:Required
+	Backend string `json:"backend"`
+}
+
+// ModelRouteErrorFallback fails over the requests failed with a class of
+// errors to the next backend immediately, instead of retrying the same one.
+// Each backend is tried at most once per request.
+type ModelRouteErrorFallback struct {
+	// Class of the errors failed over
+	// +kubebuilder:validation:Required
+	Class FallbackErrorClass `json:"class"`
+	// Backends tried in order, the other targets of the route in their order
+	// when empty
+	// +kubebuilder:validation:Optional
+	// +optional
+	Backends []ModelRouteFallbackBackend `json:"backends,omitempty"`
+}
+
+type ModelRouteFallback struct {
+	// The delay time before the next retry over request, unit: second
+	// +kubebuilder:validation:Optional
+	// +optional
+	PreDelay *int64 `json:"preDelay"`
+	// The delay time after the request is retried, unit: second
+	// +kubebuilder:validation:Optional
+	// +optional
+	PostDelay *int64 `json:"postDelay"`
+	// The maximum number of retries
+	// +kubebuilder:validation:Optional
+	// +optional
+	MaxRetries *uint64 `json:"maxRetries"`
+	// OnErrors fails over the classes of errors to other backends, they take
+	// precedence over the retry policy and the retries of the fallback
+	// +kubebuilder:validation:Optional
+	// +optional
+	OnErrors []ModelRouteErrorFallback `json:"onErrors,omitempty"`
+}
+
+// RetryErrorClass is a class of errors of upstreams retried by retry policies
+// +kubebuilder:validation:Enum=ServerError;RateLimited;ConnectionFailure;Timeout
+type RetryErrorClass string
+
+const (
+	// RetryErrorClassServerError matches the responses with 5xx statuses
+	RetryErrorClassServerError RetryErrorClass = "ServerError"
+	// RetryErrorClassRateLimited matches the responses with 429 statuses
+	RetryErrorClassRateLimited RetryErrorClass = "RateLimited"
+	// RetryErrorClassConnectionFailure matches the connections refused, reset
+	// or closed before the responses are received
+	RetryErrorClassConnectionFailure RetryErrorClass = "ConnectionFailure"
+	// RetryErrorClassTimeout matches the upstreams timed out
+	RetryErrorClassTimeout RetryErrorClass = "Timeout"
+)
+
+// ModelRouteRetryPolicy controls which errors of upstreams are retried and
+// how, it takes precedence over the fallback when both are set.
+type ModelRouteRetryPolicy struct {
+	// MaxRetries is the maximum number of retries, defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+	// RetryOn lists the classes of errors retried, all of them when neither
+	// retryOn nor retryOnStatuses is set.
+	// +kubebuilder:validation:Optional
+	// +optional
+	RetryOn []RetryErrorClass `json:"retryOn,omitempty"`
+	// RetryOnStatuses lists the statuses of the responses of upstreams
+	// retried, in addition to the classes of retryOn.
+	// +kubebuilder:validation:items:Minimum=400
+	// +kubebuilder:validation:items:Maximum=599
+	// +kubebuilder:validation:Optional
+	// +optional
+	RetryOnStatuses []int32 `json:"retryOnStatuses,omitempty"`
+	// Backoff between the retries
+	// +kubebuilder:validation:Optional
+	// +optional
+	Backoff *ModelRouteRetryBackoff `json:"backoff,omitempty"`
+	// Budget limits the retries to a percent of the requests, unlimited if
+	// unset.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Budget *ModelRouteRetryBudget `json:"budget,omitempty"`
+}
+
+// ModelRouteRetryBackoff is an exponential backoff with full jitter, the delay
+// before the nth retry is picked at random up to baseInterval * 2^(n-1),
+// capped by maxInterval.
+type ModelRouteRetryBackoff struct {
+	// BaseInterval defaults to 25ms.
+	// +kubebuilder:validation:Optional
+	// +optional
+	BaseInterval *metav1.Duration `json:"baseInterval,omitempty"`
+	// MaxInterval defaults to 10 times of baseInterval.
+	// +kubebuilder:validation:Optional
+	// +optional
+	MaxInterval *metav1.Duration `json:"maxInterval,omitempty"`
+}
+
+// ModelRouteRetryBudget limits the retries of the route within a sliding
+// window, so that retries don't amplify the load of failing upstreams.
+type ModelRouteRetryBudget struct {
+	// RetryPercent is the maximum retries in percent of the requests within
+	// the window.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:Required
+	RetryPercent int32 `json:"retryPercent"`
+	// MinRetriesPerSecond is the retries allowed per second regardless of
+	// retryPercent, defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinRetriesPerSecond *int32 `json:"minRetriesPerSecond,omitempty"`
+	// Window defaults to 10s.
+	// +kubebuilder:validation:Optional
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
+// SeedPolicyMode is how the seeds of requests are handled
+// +kubebuilder:validation:Enum=PassThrough;Force;Strip
+type SeedPolicyMode string
+
+const (
+	// SeedPolicyModePassThrough passes the seeds of requests to upstreams as
+	// they are
+	SeedPolicyModePassThrough SeedPolicyMode = "PassThrough"
+	// SeedPolicyModeForce sends every request with the seed of the policy, for
+	// reproducible evaluations
+	SeedPolicyModeForce SeedPolicyMode = "Force"
+	// SeedPolicyModeStrip removes the seeds of requests
+	SeedPolicyModeStrip SeedPolicyMode = "Strip"
+)
+
+// ModelRouteSeedPolicy controls the seed parameter of the chat completions and
+// completions requests of the route.
+type ModelRouteSeedPolicy struct {
+	// Mode of handling the seeds of requests
+	// +kubebuilder:validation:Required
+	Mode SeedPolicyMode `json:"mode"`
+	// Seed of the requests, required when mode is Force
+	// +kubebuilder:validation:Optional
+	// +optional
+	Seed *int64 `json:"seed,omitempty"`
+}
+
+// ModelRouteMirror sends copies of a percent of the requests to a shadow
+// backend asynchronously, e.g. to evaluate a new model with the production
+// traffic. Responses of the copies are discarded, and they never affect the
+// requests they are copied from.
+type ModelRouteMirror struct {
+	// Namespace of the backend, defaults to the namespace of the ModelRoute
+	// +kubebuilder:validation:Optional
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Backend the requests are mirrored to
+	// +kubebuilder:validation:Required
+	Backend string `json:"backend"`
+	// Percent of the requests mirrored, defaults to 100
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Percent *int32 `json:"percent,omitempty"`
+}
+
+type ModelRouteFilter struct {
+	// Filter name
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Filter type
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=RateLimit;Cache;ConcurrencyLimit;PromptCompression;ImagePromptPolicy;PromptTemplate;Vision
+	Type string `json:"type,omitempty"`
+	// Rate limit Filter, if the type is RateLimit
+	// +kubebuilder:validation:Optional
+	// +optional
+	RateLimit *RateLimitPolicy `json:"rateLimit"`
+	// Response cache Filter, if the type is Cache
+	// +kubebuilder:validation:Optional
+	// +optional
+	Cache *CachePolicy `json:"cache,omitempty"`
+	// Concurrency limit Filter, if the type is ConcurrencyLimit
+	// +kubebuilder:validation:Optional
+	// +optional
+	ConcurrencyLimit *ConcurrencyLimitPolicy `json:"concurrencyLimit,omitempty"`
+	// Prompt compression Filter, if the type is PromptCompression
+	// +kubebuilder:validation:Optional
+	// +optional
+	PromptCompression *PromptCompressionPolicy `json:"promptCompression,omitempty"`
+	// Image prompt policy Filter, if the type is ImagePromptPolicy
+	// +kubebuilder:validation:Optional
+	// +optional
+	ImagePromptPolicy *ImagePromptPolicy `json:"imagePromptPolicy,omitempty"`
+	// Prompt template Filter, if the type is PromptTemplate
+	// +kubebuilder:validation:Optional
+	// +optional
+	PromptTemplate *PromptTemplate `json:"promptTemplate,omitempty"`
+	// Vision Filter, if the type is Vision
+	// +kubebuilder:validation:Optional
+	// +optional
+	Vision *VisionPolicy `json:"vision,omitempty"`
+	// FeatureFlag gates the filter, it only runs for the requests the feature
+	// flag of the gateway is enabled for
+	// +kubebuilder:validation:Optional
+	// +optional
+	FeatureFlag string `json:"featureFlag,omitempty"`
+}
+
+// ModelRouteSpec defines the desired state of ModelRoute.
+type ModelRouteSpec struct {
+	ModelName string `json:"modelName"`
+	// Filters for the route
+	// +kubebuilder:validation:Optional
+	Filters []ModelRouteFilter `json:"filters,omitempty"`
+	// Route policy
+	// +kubebuilder:validation:Optional
+	// +optional
+	Route *ModelRouteRoute `json:"route"`
+	// Fallback
+	// +kubebuilder:validation:Optional
+	// +optional
+	Fallback *ModelRouteFallback `json:"fallback"`
+	// Retry policy, takes precedence over the fallback
+	// +kubebuilder:validation:Optional
+	// +optional
+	RetryPolicy *ModelRouteRetryPolicy `json:"retryPolicy,omitempty"`
+	// Seed policy of the requests
+	// +kubebuilder:validation:Optional
+	// +optional
+	SeedPolicy *ModelRouteSeedPolicy `json:"seedPolicy,omitempty"`
+	// Mirror sends copies of the requests to a shadow backend
+	// +kubebuilder:validation:Optional
+	// +optional
+	Mirror *ModelRouteMirror `json:"mirror,omitempty"`
+	// Timeout is the latency budget of the requests, counted from when the
+	// gateway receives them. No retries or fallbacks are attempted past it,
+	// and the time remaining is forwarded to the backends configured with
+	// deadline headers, unit: second
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Timeout int32 `json:"timeout,omitempty"`
+}
+
+type ModelRouteStatusTarget struct {
+	Namespace string     `json:"namespace"`
+	Backend   string     `json:"backend"`
+	ModelName string     `json:"modelName"`
+	Status    StatusEnum `json:"status"`
+}
+
+type CanaryPhase string
+
+const (
+	// CanaryPhaseProgressing shifts the weight to the canary target step by
+	// step
+	CanaryPhaseProgressing CanaryPhase = "Progressing"
+	// CanaryPhasePromoted keeps the maximum weight of the canary target
+	CanaryPhasePromoted CanaryPhase = "Promoted"
+	// CanaryPhaseRolledBack shifts all the weight back to the stable target
+	// until the canary backend changes
+	CanaryPhaseRolledBack CanaryPhase = "RolledBack"
+)
+
+type ModelRouteCanaryStatus struct {
+	// Canary is the backend being rolled out
+	Canary string `json:"canary"`
+	// Phase of the rollout: Progressing, Promoted or RolledBack
+	// +kubebuilder:validation:Enum=Progressing;Promoted;RolledBack
+	Phase CanaryPhase `json:"phase"`
+	// Weight is the percent of the weight shifted to the canary target
+	Weight int32 `json:"weight"`
+	// StepStartTime is when the current step started
+	StepStartTime metav1.Time `json:"stepStartTime"`
+	// Message describes the result of the last analysis
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ModelRouteStatus defines the observed state of ModelRoute.
+type ModelRouteStatus struct {
+	// Status indicates the health of the ModelRoute CR: Unknown, Healthy, or Failed
+	// +kubebuilder:validation:Enum=Unknown;Healthy;Failed
+	Status StatusEnum `json:"status,omitempty"`
+
+	// Conditions represent the current conditions of the backend
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Targets represents the targets of the model route
+	Targets []ModelRouteStatusTarget `json:"targets,omitempty"`
+
+	// Canary is the progress of the rollout of the canary target
+	// +optional
+	Canary *ModelRouteCanaryStatus `json:"canary,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// ModelRoute is the Schema for the modelroutes API.
+type ModelRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ModelRouteSpec   `json:"spec,omitempty"`
+	Status ModelRouteStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ModelRouteList contains a list of ModelRoute.
+type ModelRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ModelRoute `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ModelRoute{}, &ModelRouteList{})
+}
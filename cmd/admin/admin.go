@@ -2,6 +2,7 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net"
 	"net/http"
@@ -17,18 +18,36 @@ import (
 	"github.com/samber/lo"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clustermanager "knoway.dev/pkg/clusters/manager"
+	"knoway.dev/pkg/events"
 	"knoway.dev/pkg/listener"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/observation"
 	routemanager "knoway.dev/pkg/route/manager"
+	"knoway.dev/pkg/utils"
 )
 
 type debugListener struct {
-	staticListeners []*anypb.Any
+	// staticListeners returns the listeners being served, which change as
+	// the configuration file is reloaded.
+	staticListeners func() []*anypb.Any
+	drainer         Drainer
+	kubeClient      client.Client
+	// playground is the handler of the gateway the playground sends requests
+	// to, the playground is disabled if nil.
+	playground http.Handler
+	// shutdown is closed when the admin server shuts down.
+	shutdown <-chan struct{}
 }
 
-func NewAdminListener(staticListeners []*anypb.Any) (listener.Listener, error) {
-	return &debugListener{staticListeners: staticListeners}, nil
+func NewAdminListener(staticListeners func() []*anypb.Any, drainer Drainer, kubeClient client.Client, playground http.Handler) (listener.Listener, error) {
+	return newDebugListener(staticListeners, drainer, kubeClient, playground, nil), nil
+}
+
+func newDebugListener(staticListeners func() []*anypb.Any, drainer Drainer, kubeClient client.Client, playground http.Handler, shutdown <-chan struct{}) *debugListener {
+	return &debugListener{staticListeners: staticListeners, drainer: drainer, kubeClient: kubeClient, playground: playground, shutdown: shutdown}
 }
 
 func (d *debugListener) Drain(ctx context.Context) error {
@@ -58,7 +77,7 @@ func sliceToAny[T proto.Message](s []T) []*anypb.Any {
 func (d *debugListener) configDump(writer http.ResponseWriter, request *http.Request) {
 	clusters := clustermanager.DebugDumpAllClusters()
 	routes := routemanager.DebugDumpAllRoutes()
-	listeners := d.staticListeners
+	listeners := d.staticListeners()
 	cd := &v1alpha1.ConfigDump{
 		Clusters:  sliceToAny(clusters),
 		Routes:    sliceToAny(routes),
@@ -77,20 +96,162 @@ func (d *debugListener) configDump(writer http.ResponseWriter, request *http.Req
 	_, _ = writer.Write(bs)
 }
 
+type inflightUpstreamAttempt struct {
+	Cluster       string `json:"cluster"`
+	Target        string `json:"target"`
+	Provider      string `json:"provider"`
+	RequestModel  string `json:"request_model,omitempty"`
+	ResponseModel string `json:"response_model,omitempty"`
+	StatusCode    int    `json:"status_code,omitempty"`
+	Error         string `json:"error,omitempty"`
+	Duration      string `json:"duration,omitempty"`
+}
+
+type inflightRequest struct {
+	Method           string                    `json:"method"`
+	Path             string                    `json:"path"`
+	StartedAt        time.Time                 `json:"started_at"`
+	Duration         string                    `json:"duration"`
+	UpstreamAttempts []inflightUpstreamAttempt `json:"upstream_attempts"`
+}
+
+type inflightResponse struct {
+	Requests []inflightRequest `json:"requests"`
+}
+
+func (d *debugListener) inflight(writer http.ResponseWriter, request *http.Request) {
+	resp := &inflightResponse{
+		Requests: make([]inflightRequest, 0),
+	}
+
+	for _, r := range metadata.InflightRequests() {
+		attempts := make([]inflightUpstreamAttempt, 0, len(r.UpstreamAttempts))
+		for _, attempt := range r.UpstreamAttempts {
+			attempts = append(attempts, inflightUpstreamAttempt{
+				Cluster:       attempt.Cluster,
+				Target:        attempt.Target,
+				Provider:      attempt.Provider.String(),
+				RequestModel:  attempt.RequestModel,
+				ResponseModel: attempt.ResponseModel,
+				StatusCode:    attempt.ResponseStatusCode,
+				Error:         attempt.ResponseErrorMessage,
+				Duration:      lo.Ternary(attempt.Duration() > 0, attempt.Duration().String(), ""),
+			})
+		}
+
+		resp.Requests = append(resp.Requests, inflightRequest{
+			Method:           r.Method,
+			Path:             r.Path,
+			StartedAt:        r.StartedAt,
+			Duration:         time.Since(r.StartedAt).String(),
+			UpstreamAttempts: attempts,
+		})
+	}
+
+	utils.WriteJSONForHTTP(http.StatusOK, resp, writer)
+}
+
+type routeConflictsResponse struct {
+	Conflicts []routemanager.Conflict `json:"conflicts"`
+}
+
+func (d *debugListener) routeConflicts(writer http.ResponseWriter, request *http.Request) {
+	utils.WriteJSONForHTTP(http.StatusOK, &routeConflictsResponse{
+		Conflicts: routemanager.Conflicts(),
+	}, writer)
+}
+
+const defaultRouteStatsWindow = time.Minute
+
+type routeStatsResponse struct {
+	Window string                     `json:"window"`
+	Routes []observation.RouteSummary `json:"routes"`
+}
+
+// routeStats returns the aggregates of routes over the sliding window given by
+// the window query parameter, optionally filtered by the route query
+// parameter.
+func (d *debugListener) routeStats(writer http.ResponseWriter, request *http.Request) {
+	window := defaultRouteStatsWindow
+
+	if w := request.URL.Query().Get("window"); w != "" {
+		var err error
+
+		window, err = time.ParseDuration(w)
+		if err != nil || window <= 0 {
+			writeError(writer, http.StatusBadRequest, errors.New("invalid window "+w))
+			return
+		}
+	}
+
+	window = min(window, observation.RouteStatsMaxWindow)
+
+	routes := observation.RouteSummaries(window)
+	if route := request.URL.Query().Get("route"); route != "" {
+		routes = lo.Filter(routes, func(item observation.RouteSummary, _ int) bool {
+			return item.Route == route
+		})
+	}
+
+	utils.WriteJSONForHTTP(http.StatusOK, &routeStatsResponse{
+		Window: window.String(),
+		Routes: routes,
+	}, writer)
+}
+
 func (d *debugListener) RegisterRoutes(mux *mux.Router) error {
 	mux.HandleFunc("/config_dump", d.configDump)
+	mux.HandleFunc("/inflight", d.inflight)
+	mux.HandleFunc("/route_conflicts", d.routeConflicts)
+	mux.HandleFunc("/routes/stats", d.routeStats).Methods(http.MethodGet)
+
+	eh := &eventsHandler{bus: events.Default(), shutdown: d.shutdown, heartbeatInterval: eventsHeartbeatInterval}
+	mux.HandleFunc("/events", eh.stream).Methods(http.MethodGet)
+
+	if d.drainer != nil {
+		h := &drainHandler{drainer: d.drainer}
+		mux.HandleFunc("/drain", h.status).Methods(http.MethodGet)
+		mux.HandleFunc("/drain", h.start).Methods(http.MethodPost)
+	}
+
+	// Backends are only manageable when running with the controller
+	if d.kubeClient != nil {
+		h := &backendsHandler{kubeClient: d.kubeClient}
+		mux.HandleFunc("/backends/{resource}/{namespace}/{name}/history", h.history).Methods(http.MethodGet)
+		mux.HandleFunc("/backends/{resource}/{namespace}/{name}/restore", h.restore).Methods(http.MethodPost)
+	}
+
+	if d.playground != nil {
+		h := &playgroundHandler{gateway: d.playground}
+
+		err := h.registerRoutes(mux)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func NewAdminServer(_ context.Context, staticListeners []*anypb.Any, addr string, lifecycle bootkit.LifeCycle) error {
+// NewAdminServer serves the admin endpoints on addr, along with the
+// playground sending requests to the playground handler if it's not nil.
+func NewAdminServer(_ context.Context, staticListeners func() []*anypb.Any, drainer Drainer, kubeClient client.Client, playground http.Handler, addr string, lifecycle bootkit.LifeCycle) error {
+	// Closed on shutdown to end the event streams, which would otherwise
+	// keep the server waiting for them.
+	shutdown := make(chan struct{})
+
 	m := listener.NewMux()
-	m.Register(NewAdminListener(staticListeners))
+	m.Register(newDebugListener(staticListeners, drainer, kubeClient, playground, shutdown), nil)
 
 	server, err := m.BuildServer(&http.Server{Addr: addr, ReadTimeout: time.Minute})
 	if err != nil {
 		return err
 	}
 
+	server.RegisterOnShutdown(func() {
+		close(shutdown)
+	})
+
 	ln, err := net.Listen("tcp", addr)
 	if err != nil {
 		return err
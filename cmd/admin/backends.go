@@ -0,0 +1,114 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	knowaydevv1alpha1 "knoway.dev/api/v1alpha1"
+	"knoway.dev/internal/controller"
+	"knoway.dev/pkg/utils"
+)
+
+var backendTypesByResource = map[string]knowaydevv1alpha1.BackendType{
+	"llmbackends":             knowaydevv1alpha1.BackendTypeLLM,
+	"imagegenerationbackends": knowaydevv1alpha1.BackendTypeImageGeneration,
+	"embeddingbackends":       knowaydevv1alpha1.BackendTypeEmbedding,
+	"rerankbackends":          knowaydevv1alpha1.BackendTypeRerank,
+	"videogenerationbackends": knowaydevv1alpha1.BackendTypeVideoGeneration,
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type backendHistoryResponse struct {
+	Revisions []controller.BackendRevision `json:"revisions"`
+}
+
+type restoreBackendResponse struct {
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	Generation int64  `json:"generation"`
+}
+
+func writeError(writer http.ResponseWriter, status int, err error) {
+	utils.WriteJSONForHTTP(status, &errorResponse{Error: err.Error()}, writer)
+}
+
+type backendsHandler struct {
+	kubeClient client.Client
+}
+
+func (h *backendsHandler) backendRef(writer http.ResponseWriter, request *http.Request) (knowaydevv1alpha1.BackendType, string, string, bool) {
+	vars := mux.Vars(request)
+
+	typ, ok := backendTypesByResource[vars["resource"]]
+	if !ok {
+		writeError(writer, http.StatusNotFound, errors.New("unknown backend resource "+vars["resource"]))
+		return "", "", "", false
+	}
+
+	return typ, vars["namespace"], vars["name"], true
+}
+
+// history lists the recorded configuration revisions of a backend.
+func (h *backendsHandler) history(writer http.ResponseWriter, request *http.Request) {
+	typ, namespace, name, ok := h.backendRef(writer, request)
+	if !ok {
+		return
+	}
+
+	revisions, err := controller.ListBackendRevisions(request.Context(), h.kubeClient, typ, namespace, name)
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, err)
+		return
+	}
+
+	utils.WriteJSONForHTTP(http.StatusOK, &backendHistoryResponse{Revisions: revisions}, writer)
+}
+
+// restore re-creates or rolls back a backend to the revision specified by the
+// generation query parameter, or the latest revision if omitted.
+func (h *backendsHandler) restore(writer http.ResponseWriter, request *http.Request) {
+	typ, namespace, name, ok := h.backendRef(writer, request)
+	if !ok {
+		return
+	}
+
+	var generation int64
+
+	if g := request.URL.Query().Get("generation"); g != "" {
+		var err error
+
+		generation, err = strconv.ParseInt(g, 10, 64)
+		if err != nil {
+			writeError(writer, http.StatusBadRequest, errors.New("invalid generation "+g))
+			return
+		}
+	}
+
+	obj, err := controller.RestoreBackend(request.Context(), h.kubeClient, typ, namespace, name, generation)
+	if err != nil {
+		switch {
+		case errors.Is(err, controller.ErrBackendRevisionNotFound):
+			writeError(writer, http.StatusNotFound, err)
+		case errors.Is(err, controller.ErrBackendBeingDeleted), apierrors.IsConflict(err), apierrors.IsAlreadyExists(err):
+			writeError(writer, http.StatusConflict, err)
+		default:
+			writeError(writer, http.StatusInternalServerError, err)
+		}
+
+		return
+	}
+
+	utils.WriteJSONForHTTP(http.StatusOK, &restoreBackendResponse{
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Generation: obj.GetGeneration(),
+	}, writer)
+}
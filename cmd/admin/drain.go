@@ -0,0 +1,44 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"knoway.dev/pkg/listener"
+	"knoway.dev/pkg/utils"
+)
+
+// Drainer drains the listeners of the gateway.
+type Drainer interface {
+	StartDrain(timeout time.Duration) listener.DrainStatus
+	DrainStatus() listener.DrainStatus
+	DrainTimeout() time.Duration
+}
+
+type drainHandler struct {
+	drainer Drainer
+}
+
+// start puts the gateway into drain mode, the requests in flight are waited
+// for until the timeout given by the timeout query parameter, or the drain
+// timeout configured. The progress is reported by status.
+func (h *drainHandler) start(writer http.ResponseWriter, request *http.Request) {
+	timeout := h.drainer.DrainTimeout()
+
+	if t := request.URL.Query().Get("timeout"); t != "" {
+		var err error
+
+		timeout, err = time.ParseDuration(t)
+		if err != nil || timeout <= 0 {
+			writeError(writer, http.StatusBadRequest, errors.New("invalid timeout "+t))
+			return
+		}
+	}
+
+	utils.WriteJSONForHTTP(http.StatusAccepted, h.drainer.StartDrain(timeout), writer)
+}
+
+func (h *drainHandler) status(writer http.ResponseWriter, request *http.Request) {
+	utils.WriteJSONForHTTP(http.StatusOK, h.drainer.DrainStatus(), writer)
+}
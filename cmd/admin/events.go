@@ -0,0 +1,111 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/pkg/events"
+)
+
+// eventsHeartbeatInterval is how often comments are sent to keep idle event
+// streams from being closed by proxies.
+const eventsHeartbeatInterval = 15 * time.Second
+
+type eventsHandler struct {
+	bus *events.Bus
+	// shutdown is closed when the admin server shuts down, ending the streams
+	// which would otherwise keep it waiting.
+	shutdown <-chan struct{}
+
+	heartbeatInterval time.Duration
+}
+
+// parseEventTypes parses the comma separated types of events, empty means all
+// the types.
+func parseEventTypes(value string) ([]events.Type, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	types := make([]events.Type, 0)
+
+	for _, t := range strings.Split(value, ",") {
+		t = strings.TrimSpace(t)
+		if !lo.Contains(events.Types, events.Type(t)) {
+			return nil, errors.New("unknown event type " + t)
+		}
+
+		types = append(types, events.Type(t))
+	}
+
+	return types, nil
+}
+
+// stream streams the events of the gateway as server-sent events, filtered by
+// the types given by the comma separated types query parameter. Only the
+// events published after connecting are streamed.
+func (h *eventsHandler) stream(writer http.ResponseWriter, request *http.Request) {
+	types, err := parseEventTypes(request.URL.Query().Get("types"))
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err)
+		return
+	}
+
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		writeError(writer, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+
+	subscription := h.bus.Subscribe(events.DefaultSubscriptionBuffer, types...)
+	defer subscription.Close()
+
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.Header().Set("Connection", "keep-alive")
+	writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-request.Context().Done():
+			return
+		case <-h.shutdown:
+			return
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(writer, ": heartbeat\n\n")
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return
+			}
+
+			err = writeEvent(writer, event)
+		}
+
+		if err != nil {
+			return
+		}
+
+		flusher.Flush()
+	}
+}
+
+func writeEvent(writer http.ResponseWriter, event events.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+
+	return err
+}
@@ -0,0 +1,91 @@
+package admin
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/pkg/events"
+)
+
+func TestParseEventTypes(t *testing.T) {
+	types, err := parseEventTypes("")
+	require.NoError(t, err)
+	assert.Empty(t, types)
+
+	types, err = parseEventTypes("cluster.registered, breaker.opened")
+	require.NoError(t, err)
+	assert.Equal(t, []events.Type{events.TypeClusterRegistered, events.TypeBreakerOpened}, types)
+
+	_, err = parseEventTypes("cluster.registered,cluster.unknown")
+	require.EqualError(t, err, "unknown event type cluster.unknown")
+}
+
+func TestEventsHandler_Stream(t *testing.T) {
+	bus := events.NewBus()
+	shutdown := make(chan struct{})
+	h := &eventsHandler{bus: bus, shutdown: shutdown, heartbeatInterval: time.Hour}
+
+	server := httptest.NewServer(http.HandlerFunc(h.stream))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"?types=route.removed", nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// The headers are flushed after subscribing
+	bus.Publish(events.TypeRouteChanged, map[string]string{"route": "gpt-4o"})
+	bus.Publish(events.TypeRouteRemoved, map[string]string{"route": "gpt-4o"})
+
+	reader := bufio.NewReader(resp.Body)
+
+	var lines []string
+
+	for len(lines) < 3 {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+
+	assert.Equal(t, "id: 2", lines[0])
+	assert.Equal(t, "event: route.removed", lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], `data: {"id":2,"type":"route.removed",`))
+	assert.True(t, strings.HasSuffix(lines[2], `"attributes":{"route":"gpt-4o"}}`))
+
+	// Streams end on shutdown
+	close(shutdown)
+
+	_, err = reader.ReadString('\n')
+	require.NoError(t, err)
+
+	_, err = reader.ReadString('\n')
+	require.Error(t, err)
+}
+
+func TestEventsHandler_StreamUnknownType(t *testing.T) {
+	h := &eventsHandler{bus: events.NewBus(), heartbeatInterval: time.Hour}
+
+	recorder := httptest.NewRecorder()
+	h.stream(recorder, httptest.NewRequest(http.MethodGet, "/events?types=unknown", nil))
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.JSONEq(t, `{"error":"unknown event type unknown"}`, recorder.Body.String())
+}
@@ -0,0 +1,212 @@
+package admin
+
+import (
+	"bytes"
+	"embed"
+	"io/fs"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+
+	clustermanager "knoway.dev/pkg/clusters/manager"
+	"knoway.dev/pkg/metadata"
+	routemanager "knoway.dev/pkg/route/manager"
+	"knoway.dev/pkg/utils"
+)
+
+//go:embed playground
+var playgroundAssets embed.FS
+
+const playgroundProxyPrefix = "/playground/proxy"
+
+// playgroundPaths are the endpoints of the gateway the playground sends
+// requests to.
+var playgroundPaths = []string{
+	"/v1/chat/completions",
+	"/v1/audio/speech",
+	"/v1/images/generations",
+}
+
+// playgroundHeaders are the headers of the playground requests forwarded to
+// the gateway, the API key chosen is sent as Authorization.
+var playgroundHeaders = []string{
+	"Authorization",
+	"Content-Type",
+	"Accept",
+}
+
+// playgroundHandler serves a web UI sending test requests to the gateway in
+// process, showing the responses along with the metadata exported for them.
+type playgroundHandler struct {
+	gateway http.Handler
+}
+
+type playgroundModel struct {
+	Model string `json:"model"`
+	Route string `json:"route"`
+	// Type is the type of the cluster of the first target of the route,
+	// e.g. LLM, SPEECH_GENERATION or IMAGE_GENERATION, empty if unknown.
+	Type string `json:"type,omitempty"`
+}
+
+type playgroundModelsResponse struct {
+	Models []playgroundModel `json:"models"`
+}
+
+func (h *playgroundHandler) registerRoutes(m *mux.Router) error {
+	assets, err := fs.Sub(playgroundAssets, "playground")
+	if err != nil {
+		return err
+	}
+
+	m.HandleFunc("/playground/models", h.models).Methods(http.MethodGet)
+	m.PathPrefix(playgroundProxyPrefix + "/").HandlerFunc(h.proxy).Methods(http.MethodPost)
+	m.Handle("/playground", http.RedirectHandler("/playground/", http.StatusMovedPermanently)).Methods(http.MethodGet)
+	m.PathPrefix("/playground/").Handler(http.StripPrefix("/playground/", http.FileServerFS(assets))).Methods(http.MethodGet)
+
+	return nil
+}
+
+// models lists the models matched exactly by the routes being served, routes
+// matching models by prefixes or patterns only are not listed.
+func (h *playgroundHandler) models(writer http.ResponseWriter, request *http.Request) {
+	models := make([]playgroundModel, 0)
+
+	for _, r := range routemanager.DebugDumpAllRoutes() {
+		var clusterType string
+
+		if len(r.GetTargets()) > 0 {
+			cluster, ok := clustermanager.GetClusterConfig(r.GetTargets()[0].GetDestination().GetCluster())
+			if ok {
+				clusterType = cluster.GetType().String()
+			}
+		}
+
+		for _, match := range r.GetMatches() {
+			model := match.GetModel().GetExact()
+			if model == "" {
+				continue
+			}
+
+			models = append(models, playgroundModel{Model: model, Route: r.GetName(), Type: clusterType})
+		}
+	}
+
+	slices.SortFunc(models, func(a, b playgroundModel) int {
+		return strings.Compare(a.Model, b.Model)
+	})
+
+	utils.WriteJSONForHTTP(http.StatusOK, &playgroundModelsResponse{
+		Models: lo.UniqBy(models, func(item playgroundModel) string {
+			return item.Model
+		}),
+	}, writer)
+}
+
+// proxy sends the request to the gateway with the metadata export requested,
+// the path after /playground/proxy is the endpoint of the gateway.
+func (h *playgroundHandler) proxy(writer http.ResponseWriter, request *http.Request) {
+	path := strings.TrimPrefix(request.URL.Path, playgroundProxyPrefix)
+	if !lo.Contains(playgroundPaths, path) {
+		http.NotFound(writer, request)
+		return
+	}
+
+	proxied := request.Clone(request.Context())
+	proxied.URL.Path = path
+	proxied.URL.RawPath = ""
+	proxied.RequestURI = proxied.URL.RequestURI()
+	proxied.Header = make(http.Header, len(playgroundHeaders)+1)
+
+	for _, key := range playgroundHeaders {
+		if value := request.Header.Get(key); value != "" {
+			proxied.Header.Set(key, value)
+		}
+	}
+
+	proxied.Header.Set(metadata.HeaderExportMetadata, "true")
+
+	pw := &playgroundResponseWriter{ResponseWriter: writer}
+	h.gateway.ServeHTTP(pw, proxied)
+	pw.finish()
+}
+
+// playgroundResponseWriter passes streams through, whose metadata is
+// exported in their terminal events, and buffers other responses to move the
+// metadata exported in the trailer, which browsers can't read, into a header.
+type playgroundResponseWriter struct {
+	http.ResponseWriter
+
+	status   int
+	buffered bool
+	body     bytes.Buffer
+}
+
+func isStreamContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, "text/event-stream") || strings.HasPrefix(contentType, "application/x-ndjson")
+}
+
+func (w *playgroundResponseWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+
+	w.status = status
+
+	if !isStreamContentType(w.Header().Get("Content-Type")) {
+		w.buffered = true
+		return
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *playgroundResponseWriter) Write(bs []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.buffered {
+		return w.body.Write(bs)
+	}
+
+	return w.ResponseWriter.Write(bs)
+}
+
+func (w *playgroundResponseWriter) Flush() {
+	if w.status == 0 || w.buffered {
+		return
+	}
+
+	utils.SafeFlush(w.ResponseWriter)
+}
+
+func (w *playgroundResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// finish writes the buffered response, with the metadata in the
+// X-Knoway-Metadata header.
+func (w *playgroundResponseWriter) finish() {
+	if !w.buffered {
+		return
+	}
+
+	header := w.Header()
+
+	trailer := http.TrailerPrefix + metadata.TrailerMetadata
+	if exported := header.Get(trailer); exported != "" {
+		header.Del(trailer)
+		header.Set(metadata.TrailerMetadata, exported)
+	}
+
+	header.Del("Transfer-Encoding")
+	header.Set("Content-Length", strconv.Itoa(w.body.Len()))
+
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(w.body.Bytes())
+}
@@ -0,0 +1,104 @@
+package admin
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/utils"
+)
+
+func newPlaygroundServer(t *testing.T, gateway http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	m := mux.NewRouter()
+	h := &playgroundHandler{gateway: gateway}
+	require.NoError(t, h.registerRoutes(m))
+
+	server := httptest.NewServer(m)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestPlaygroundHandler_Index(t *testing.T) {
+	server := newPlaygroundServer(t, func(writer http.ResponseWriter, request *http.Request) {})
+
+	resp, err := http.Get(server.URL + "/playground") //nolint:noctx
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "Knoway Playground")
+}
+
+func TestPlaygroundHandler_Proxy(t *testing.T) {
+	server := newPlaygroundServer(t, func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", request.URL.Path)
+		assert.Equal(t, "Bearer sk-test", request.Header.Get("Authorization"))
+		assert.Empty(t, request.Header.Get("Cookie"))
+		assert.True(t, metadata.ExportMetadataRequested(request))
+
+		utils.WriteJSONForHTTP(http.StatusOK, map[string]string{"id": "chatcmpl-1"}, writer)
+		writer.Header().Set(http.TrailerPrefix+metadata.TrailerMetadata, `{"served_model":"gpt-4o"}`)
+	})
+
+	request, err := http.NewRequest(http.MethodPost, server.URL+"/playground/proxy/v1/chat/completions", strings.NewReader(`{}`)) //nolint:noctx
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "Bearer sk-test")
+	request.Header.Set("Cookie", "session=admin")
+
+	resp, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"id":"chatcmpl-1"}`, string(body))
+	assert.JSONEq(t, `{"served_model":"gpt-4o"}`, resp.Header.Get(metadata.TrailerMetadata))
+}
+
+func TestPlaygroundHandler_ProxyStream(t *testing.T) {
+	server := newPlaygroundServer(t, func(writer http.ResponseWriter, request *http.Request) {
+		utils.WriteEventStreamHeadersForHTTP(writer)
+		_, _ = writer.Write([]byte("event: knoway.metadata\ndata: {}\n\ndata: [DONE]\n\n"))
+	})
+
+	resp, err := http.Post(server.URL+"/playground/proxy/v1/chat/completions", "application/json", strings.NewReader(`{}`)) //nolint:noctx
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, "text/event-stream; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "event: knoway.metadata\ndata: {}\n\ndata: [DONE]\n\n", string(body))
+}
+
+func TestPlaygroundHandler_ProxyUnknownPath(t *testing.T) {
+	server := newPlaygroundServer(t, func(writer http.ResponseWriter, request *http.Request) {
+		t.Errorf("unexpected request to %s", request.URL.Path)
+	})
+
+	resp, err := http.Post(server.URL+"/playground/proxy/v1/embeddings", "application/json", strings.NewReader(`{}`)) //nolint:noctx
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -1,11 +1,14 @@
 package gateway
 
 import (
+	"fmt"
+
 	"github.com/samber/lo"
 	"google.golang.org/protobuf/types/known/anypb"
 
 	clusters "knoway.dev/api/clusters/v1alpha1"
 	filters "knoway.dev/api/filters/v1alpha1"
+	routes "knoway.dev/api/route/v1alpha1"
 	"knoway.dev/pkg/bootkit"
 	clustermanager "knoway.dev/pkg/clusters/manager"
 	routemanager "knoway.dev/pkg/route/manager"
@@ -60,3 +63,16 @@ func StaticRegisterClusters(clusterDetails map[string]*clusters.Cluster, lifecyc
 
 	return nil
 }
+
+// StaticRegisterRoutes registers the routes as match routes, the clusters
+// their targets refer to must have been registered.
+func StaticRegisterRoutes(routes []*routes.Route, lifecycle bootkit.LifeCycle) error {
+	for _, r := range routes {
+		err := routemanager.RegisterMatchRouteWithConfig(r, lifecycle)
+		if err != nil {
+			return fmt.Errorf("failed to register static route %s: %w", r.GetName(), err)
+		}
+	}
+
+	return nil
+}
@@ -7,59 +7,330 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"google.golang.org/protobuf/types/known/anypb"
-
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/samber/lo"
+	"golang.org/x/net/netutil"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"knoway.dev/api/listeners/v1alpha1"
+	"knoway.dev/config"
+	"knoway.dev/pkg/artifacts"
 	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/constants"
+	"knoway.dev/pkg/events"
 	"knoway.dev/pkg/listener"
 	"knoway.dev/pkg/listener/manager/chat"
+	"knoway.dev/pkg/listener/manager/embedding"
 	"knoway.dev/pkg/listener/manager/image"
+	"knoway.dev/pkg/listener/manager/moderation"
+	"knoway.dev/pkg/listener/manager/realtime"
+	"knoway.dev/pkg/listener/manager/rerank"
+	"knoway.dev/pkg/listener/manager/stt"
 	"knoway.dev/pkg/listener/manager/tts"
+	"knoway.dev/pkg/listener/manager/video"
+	"knoway.dev/pkg/metadata"
 )
 
-func StartGateway(_ context.Context, lifecycle bootkit.LifeCycle, listenerAddr string, cfg []*anypb.Any) error {
-	if listenerAddr == "" {
-		listenerAddr = ":8080"
+const (
+	defaultReadTimeout       = time.Minute
+	defaultReadHeaderTimeout = 10 * time.Second
+
+	metricsPath = "/metrics"
+)
+
+// Gateway serves the listeners of the gateway, the listeners are replaced as
+// a whole on reload without restarting the server.
+type Gateway struct {
+	serverCfg config.GatewayConfig
+	handler   atomic.Pointer[http.Handler]
+
+	mutex      sync.Mutex
+	listeners  []*anypb.Any
+	drainables []listener.Drainable
+	generation *bootkit.ReloadableLifeCycle
+
+	drainStartedAt  time.Time
+	drainDeadline   time.Time
+	drainFinishedAt time.Time
+}
+
+func NewGateway(serverCfg config.GatewayConfig) *Gateway {
+	return &Gateway{
+		serverCfg:  serverCfg,
+		generation: bootkit.NewReloadableLifeCycle(),
 	}
+}
+
+func (g *Gateway) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	handler := g.handler.Load()
+	if handler == nil {
+		http.Error(writer, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+
+	(*handler).ServeHTTP(writer, request)
+}
+
+// Listeners returns the configurations of the listeners being served.
+func (g *Gateway) Listeners() []*anypb.Any {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
 
+	return g.listeners
+}
+
+// ReloadListeners replaces the listeners being served, the listeners replaced
+// are drained in the background. The listeners being served are kept if any
+// of the new ones fails to build.
+func (g *Gateway) ReloadListeners(cfg []*anypb.Any) error {
 	if len(cfg) == 0 {
 		return errors.New("no listener found")
 	}
 
+	generation := bootkit.NewReloadableLifeCycle()
+
+	handler, drainables, err := buildHandler(cfg, g.serverCfg, generation)
+	if err != nil {
+		go stopGeneration(generation)
+		return err
+	}
+
+	g.mutex.Lock()
+	replaced := g.generation
+	g.listeners = cfg
+	g.drainables = drainables
+	g.generation = generation
+	g.handler.Store(&handler)
+	draining, deadline := !g.drainStartedAt.IsZero(), g.drainDeadline
+	g.mutex.Unlock()
+
+	// Listeners reloaded while draining are drained as well
+	if draining {
+		go func() {
+			ctx, cancel := context.WithDeadline(context.Background(), deadline)
+			defer cancel()
+
+			listener.DrainAll(ctx, drainables)
+		}()
+	}
+
+	go stopGeneration(replaced)
+
+	return nil
+}
+
+// StartDrain puts the listeners into drain mode, where new requests are
+// rejected with 503, and waits for the requests in flight in the background,
+// the ones still in flight after the timeout are canceled. Draining can't be
+// undone, calling it again reports the progress of the first call.
+func (g *Gateway) StartDrain(timeout time.Duration) listener.DrainStatus {
+	g.mutex.Lock()
+
+	if g.drainStartedAt.IsZero() {
+		g.drainStartedAt = time.Now()
+		g.drainDeadline = g.drainStartedAt.Add(timeout)
+
+		slog.Info("Draining gateway ...", "timeout", timeout)
+		events.Publish(events.TypeDrainStarted, map[string]string{
+			"timeout":  timeout.String(),
+			"inflight": strconv.Itoa(len(metadata.InflightRequests())),
+		})
+
+		go g.drain(g.drainables, g.drainDeadline)
+	}
+
+	g.mutex.Unlock()
+
+	return g.DrainStatus()
+}
+
+func (g *Gateway) drain(drainables []listener.Drainable, deadline time.Time) {
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	listener.DrainAll(ctx, drainables)
+
+	g.mutex.Lock()
+	g.drainFinishedAt = time.Now()
+	g.mutex.Unlock()
+
+	slog.Info("Gateway drained.")
+}
+
+// DrainStatus reports the progress of draining started by StartDrain.
+func (g *Gateway) DrainStatus() listener.DrainStatus {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return listener.DrainStatus{
+		Draining:   !g.drainStartedAt.IsZero(),
+		StartedAt:  g.drainStartedAt,
+		Deadline:   g.drainDeadline,
+		Inflight:   len(metadata.InflightRequests()),
+		Drained:    !g.drainFinishedAt.IsZero(),
+		FinishedAt: g.drainFinishedAt,
+	}
+}
+
+// DrainTimeout is how long the requests in flight are waited for when
+// draining without an explicit timeout, including on shutdown.
+func (g *Gateway) DrainTimeout() time.Duration {
+	return lo.CoalesceOrEmpty(g.serverCfg.DrainTimeout, constants.DefaultDrainWaitTime)
+}
+
+// stop drains the listeners within the drain timeout on shutdown, then
+// releases the resources of them.
+func (g *Gateway) stop(ctx context.Context) error {
+	g.mutex.Lock()
+	generation := g.generation
+	g.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, g.DrainTimeout())
+	defer cancel()
+
+	return generation.Stop(ctx)
+}
+
+// stopGeneration stops the resources of a replaced configuration, e.g.
+// draining the listeners.
+func stopGeneration(generation *bootkit.ReloadableLifeCycle) {
+	ctx, cancel := context.WithTimeout(context.Background(), bootkit.DefaultStopTimeout)
+	defer cancel()
+
+	err := generation.Stop(ctx)
+	if err != nil {
+		slog.Error("failed to stop replaced static configuration", "error", err)
+	}
+}
+
+func buildHandler(cfg []*anypb.Any, serverCfg config.GatewayConfig, lifecycle bootkit.LifeCycle) (http.Handler, []listener.Drainable, error) {
 	mux := listener.NewMux()
+	drainables := make([]listener.Drainable, 0, len(cfg))
+
+	register := func(l listener.Listener, err error) {
+		if err == nil {
+			drainables = append(drainables, l)
+		}
+
+		mux.Register(l, err)
+	}
 
 	for _, c := range cfg {
 		obj, err := anypb.UnmarshalNew(c, proto.UnmarshalOptions{})
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 
 		switch obj.(type) {
 		case *v1alpha1.ChatCompletionListener:
-			mux.Register(chat.NewOpenAIChatListenerConfigs(obj, lifecycle))
+			register(chat.NewOpenAIChatListenerConfigs(obj, lifecycle))
 		case *v1alpha1.ImageListener:
-			mux.Register(image.NewOpenAIImageListenerConfigs(obj, lifecycle))
+			register(image.NewOpenAIImageListenerConfigs(obj, lifecycle))
 		case *v1alpha1.TextToSpeechListener:
-			mux.Register(tts.NewOpenAITextToSpeechListenerConfigs(obj, lifecycle))
+			register(tts.NewOpenAITextToSpeechListenerConfigs(obj, lifecycle))
+		case *v1alpha1.SpeechToTextListener:
+			register(stt.NewOpenAISpeechToTextListenerConfigs(obj, lifecycle))
+		case *v1alpha1.ModerationListener:
+			register(moderation.NewOpenAIModerationListenerConfigs(obj, lifecycle))
+		case *v1alpha1.EmbeddingListener:
+			register(embedding.NewOpenAIEmbeddingListenerConfigs(obj, lifecycle))
+		case *v1alpha1.RerankListener:
+			register(rerank.NewOpenAIRerankListenerConfigs(obj, lifecycle))
+		case *v1alpha1.RealtimeListener:
+			register(realtime.NewOpenAIRealtimeListenerConfigs(obj, lifecycle))
+		case *v1alpha1.VideoGenerationListener:
+			register(video.NewOpenAIVideoListenerConfigs(obj, lifecycle))
 		default:
-			return fmt.Errorf("%s is not a valid listener", c.GetTypeUrl())
+			return nil, nil, fmt.Errorf("%s is not a valid listener", c.GetTypeUrl())
+		}
+	}
+
+	// Artifacts are signed by the gateway instead of the clients, so they
+	// are served by the gateway regardless of the listeners.
+	mux.HandleFunc(artifacts.PathPrefix+"{id}", artifacts.ServeHTTP).Methods(http.MethodGet, http.MethodHead)
+
+	// Metrics of the data plane are served by the gateway itself, since the
+	// controller may not be running, e.g. with static clusters only.
+	if !serverCfg.DisableMetrics {
+		mux.Handle(metricsPath, promhttp.HandlerFor(ctrlmetrics.Registry, promhttp.HandlerOpts{})).Methods(http.MethodGet)
+	}
+
+	err := mux.Error()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	handler := listener.WithRequestLimits(mux.Router, lo.MapValues(serverCfg.RequestLimits, func(limits config.RequestLimitsConfig, _ string) listener.RequestLimits {
+		return listener.RequestLimits{
+			MaxBodyBytes:   limits.MaxBodyBytes,
+			MaxMessages:    limits.MaxMessages,
+			MaxPromptChars: limits.MaxPromptChars,
 		}
+	}))
+	handler = listener.WithAllowedMethods(handler, serverCfg.AllowedMethods)
+	handler = listener.WithMaxURILength(handler, serverCfg.MaxURILength)
+
+	return handler, drainables, nil
+}
+
+func (g *Gateway) Start(_ context.Context, lifecycle bootkit.LifeCycle, listenerAddr string, cfg []*anypb.Any) error {
+	if listenerAddr == "" {
+		listenerAddr = ":8080"
 	}
 
-	server, err := mux.BuildServer(&http.Server{Addr: listenerAddr, ReadTimeout: time.Minute})
+	err := g.ReloadListeners(cfg)
 	if err != nil {
 		return err
 	}
 
+	server := &http.Server{
+		Addr:              listenerAddr,
+		Handler:           g,
+		ReadTimeout:       lo.CoalesceOrEmpty(g.serverCfg.ReadTimeout, defaultReadTimeout),
+		ReadHeaderTimeout: lo.CoalesceOrEmpty(g.serverCfg.ReadHeaderTimeout, defaultReadHeaderTimeout),
+		WriteTimeout:      g.serverCfg.WriteTimeout,
+		IdleTimeout:       g.serverCfg.IdleTimeout,
+		MaxHeaderBytes:    g.serverCfg.MaxHeaderBytes,
+	}
+
 	ln, err := net.Listen("tcp", listenerAddr)
 	if err != nil {
 		return err
 	}
 
+	if g.serverCfg.MaxConcurrentConnections > 0 {
+		ln = netutil.LimitListener(ln, g.serverCfg.MaxConcurrentConnections)
+	}
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStop: g.stop,
+	})
+
+	// Reaps the streams exceeding the limits, protecting the gateway from the
+	// connections leaked by clients
+	reaperCtx, stopReaper := context.WithCancel(context.Background())
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStart: func(_ context.Context) error {
+			go listener.Streams().Run(reaperCtx, listener.StreamLimits{
+				MaxAge:  g.serverCfg.StreamMaxAge,
+				MaxIdle: g.serverCfg.StreamMaxIdle,
+			}, listener.DefaultStreamReapInterval)
+
+			return nil
+		},
+		OnStop: func(_ context.Context) error {
+			stopReaper()
+			return nil
+		},
+	})
 	lifecycle.Append(bootkit.LifeCycleHook{
 		OnStart: func(ctx context.Context) error {
 			slog.Info("Starting gateway ...", "addr", ln.Addr().String())
@@ -87,3 +358,7 @@ func StartGateway(_ context.Context, lifecycle bootkit.LifeCycle, listenerAddr s
 
 	return nil
 }
+
+func StartGateway(ctx context.Context, lifecycle bootkit.LifeCycle, listenerAddr string, cfg []*anypb.Any, serverCfg config.GatewayConfig) error {
+	return NewGateway(serverCfg).Start(ctx, lifecycle, listenerAddr, cfg)
+}
@@ -0,0 +1,242 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	clusters "knoway.dev/api/clusters/v1alpha1"
+	routes "knoway.dev/api/route/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	clustermanager "knoway.dev/pkg/clusters/manager"
+	"knoway.dev/pkg/constants"
+	routemanager "knoway.dev/pkg/route/manager"
+)
+
+const (
+	// configWatchDebounce coalesces the events of a single change, editors
+	// and ConfigMap updates write files in several steps.
+	configWatchDebounce = 500 * time.Millisecond
+)
+
+// StaticRegistry registers the static clusters and routes, and replaces them
+// as a whole on reload, removing the ones absent from the new configuration.
+type StaticRegistry struct {
+	mutex       sync.Mutex
+	clusters    map[string]struct{}
+	routes      map[string]struct{}
+	generations []*bootkit.ReloadableLifeCycle
+}
+
+func NewStaticRegistry() *StaticRegistry {
+	return &StaticRegistry{
+		clusters: make(map[string]struct{}),
+		routes:   make(map[string]struct{}),
+	}
+}
+
+// Apply registers the clusters and the routes, the clusters and the routes
+// registered by the previous Apply but absent from the arguments are removed,
+// and their resources are released once the in-flight requests are drained.
+//
+// When any of them fails to register, the registered ones are kept along with
+// the previous ones, so that the routes never refer to missing clusters.
+func (r *StaticRegistry) Apply(clusterDetails map[string]*clusters.Cluster, staticRoutes []*routes.Route) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	generation := bootkit.NewReloadableLifeCycle()
+	r.generations = append(r.generations, generation)
+
+	err := StaticRegisterClusters(clusterDetails, generation)
+	if err != nil {
+		r.track(clusterDetails, staticRoutes)
+		return err
+	}
+
+	err = StaticRegisterRoutes(staticRoutes, generation)
+	if err != nil {
+		r.track(clusterDetails, staticRoutes)
+		return err
+	}
+
+	routeNames := make(map[string]struct{}, len(staticRoutes))
+	for _, route := range staticRoutes {
+		routeNames[route.GetName()] = struct{}{}
+	}
+
+	for name := range r.routes {
+		if _, ok := routeNames[name]; !ok {
+			routemanager.RemoveMatchRoute(name)
+		}
+	}
+
+	for name := range r.clusters {
+		if _, ok := clusterDetails[name]; !ok {
+			routemanager.RemoveBaseRoute(name)
+			clustermanager.RemoveCluster(&clusters.Cluster{Name: name})
+		}
+	}
+
+	r.clusters = make(map[string]struct{}, len(clusterDetails))
+	r.routes = make(map[string]struct{}, len(staticRoutes))
+	r.track(clusterDetails, staticRoutes)
+
+	replaced := r.generations[:len(r.generations)-1]
+	r.generations = []*bootkit.ReloadableLifeCycle{generation}
+
+	// The clusters replaced may still be serving requests
+	for _, g := range replaced {
+		time.AfterFunc(constants.DefaultDrainWaitTime, func() {
+			stopGeneration(g)
+		})
+	}
+
+	return nil
+}
+
+func (r *StaticRegistry) track(clusterDetails map[string]*clusters.Cluster, staticRoutes []*routes.Route) {
+	for name := range clusterDetails {
+		r.clusters[name] = struct{}{}
+	}
+
+	for _, route := range staticRoutes {
+		r.routes[route.GetName()] = struct{}{}
+	}
+}
+
+// Release hands the clusters and the routes registered over to the
+// controller, e.g. once the replica is elected as the leader, which replaces
+// them as the backends and the ModelRoutes are reconciled. They are no longer
+// removed by Apply, while their resources are still released by Stop.
+func (r *StaticRegistry) Release() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.clusters = make(map[string]struct{})
+	r.routes = make(map[string]struct{})
+}
+
+// Stop releases the resources of the clusters and the routes registered.
+func (r *StaticRegistry) Stop(ctx context.Context) error {
+	r.mutex.Lock()
+	generations := r.generations
+	r.generations = nil
+	r.mutex.Unlock()
+
+	errs := make([]error, 0, len(generations))
+	for _, g := range generations {
+		errs = append(errs, g.Stop(ctx))
+	}
+
+	return errors.Join(errs...)
+}
+
+// WatchConfig calls reload when SIGHUP is received, or the configuration file
+// changes if watchFile is true. The directory of the file is watched instead
+// of the file itself, since ConfigMaps mounted as volumes are updated by
+// swapping symlinks.
+func WatchConfig(path string, watchFile bool, lifecycle bootkit.LifeCycle, reload func()) error {
+	var watcher *fsnotify.Watcher
+
+	if watchFile {
+		var err error
+
+		watcher, err = fsnotify.NewWatcher()
+		if err != nil {
+			return err
+		}
+
+		err = watcher.Add(filepath.Dir(path))
+		if err != nil {
+			_ = watcher.Close()
+			return err
+		}
+	}
+
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+
+	done := make(chan struct{})
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStart: func(_ context.Context) error {
+			go watchConfigLoop(path, watcher, sighup, done, reload)
+			return nil
+		},
+		OnStop: func(_ context.Context) error {
+			signal.Stop(sighup)
+			close(done)
+
+			if watcher == nil {
+				return nil
+			}
+
+			return watcher.Close()
+		},
+	})
+
+	return nil
+}
+
+func watchConfigLoop(path string, watcher *fsnotify.Watcher, sighup <-chan os.Signal, done <-chan struct{}, reload func()) {
+	name := filepath.Base(path)
+
+	debounce := time.NewTimer(configWatchDebounce)
+	debounce.Stop()
+
+	// Receiving from nil channels blocks forever without watching the file
+	var (
+		events <-chan fsnotify.Event
+		errs   <-chan error
+	)
+
+	if watcher != nil {
+		events = watcher.Events
+		errs = watcher.Errors
+	}
+
+	for {
+		select {
+		case <-done:
+			debounce.Stop()
+			return
+		case <-sighup:
+			slog.Info("received SIGHUP, reloading configuration", "config", path)
+			reload()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			// ConfigMaps swap the ..data symlink pointing to the files
+			base := filepath.Base(event.Name)
+			if base != name && base != "..data" {
+				continue
+			}
+
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+				continue
+			}
+
+			debounce.Reset(configWatchDebounce)
+		case <-debounce.C:
+			slog.Info("configuration file changed, reloading", "config", path)
+			reload()
+		case err, ok := <-errs:
+			if !ok {
+				return
+			}
+
+			slog.Error("failed to watch configuration file", "config", path, "error", err)
+		}
+	}
+}
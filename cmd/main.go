@@ -17,16 +17,36 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
+	"maps"
+	"net"
+	"net/http"
 	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"buf.build/go/protoyaml"
 	"github.com/samber/lo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	grpccredentials "google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
 	"knoway.dev/cmd/admin"
@@ -34,13 +54,33 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 
+	routes "knoway.dev/api/route/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
 	knowaydevv1alpha1 "knoway.dev/api/v1alpha1"
+	knowaydevv1alpha2 "knoway.dev/api/v1alpha2"
+	knowaydevv1beta1 "knoway.dev/api/v1beta1"
 
 	clusters "knoway.dev/api/clusters/v1alpha1"
+	filters "knoway.dev/api/filters/v1alpha1"
 	"knoway.dev/cmd/gateway"
+	"knoway.dev/cmd/migrate"
 	"knoway.dev/cmd/server"
 	"knoway.dev/config"
+	"knoway.dev/internal/controller"
+	"knoway.dev/pkg/artifacts"
+	"knoway.dev/pkg/audit"
 	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/configdiscovery"
+	"knoway.dev/pkg/credentials"
+	"knoway.dev/pkg/egress"
+	"knoway.dev/pkg/events"
+	"knoway.dev/pkg/featureflag"
+	"knoway.dev/pkg/filters/extproc"
+	"knoway.dev/pkg/observation"
+	routemanager "knoway.dev/pkg/route/manager"
+	"knoway.dev/pkg/route/normalize"
+	"knoway.dev/pkg/sharedstate"
+	"knoway.dev/pkg/tenant"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -52,10 +92,22 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(clientgoscheme.Scheme))
 
 	utilruntime.Must(knowaydevv1alpha1.AddToScheme(clientgoscheme.Scheme))
+	utilruntime.Must(knowaydevv1alpha2.AddToScheme(clientgoscheme.Scheme))
+	utilruntime.Must(knowaydevv1beta1.AddToScheme(clientgoscheme.Scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err := migrate.Run(context.Background(), os.Args[2:])
+		if err != nil {
+			slog.Error("Failed to migrate", "error", err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	var (
 		metricsAddr       string
 		probeAddr         string
@@ -63,6 +115,8 @@ func main() {
 		adminAddr         string
 		configPath        string
 		staticClusterOnly bool
+		watchConfig       bool
+		adminPlayground   bool
 	)
 
 	flag.StringVar(&listenerAddr, "gateway-listener-address", ":8080", "The address the gateway listener binds to.")
@@ -72,6 +126,9 @@ func main() {
 		"Use the port :8080. If not set, it will be 0 in order to disable the metrics server")
 	flag.StringVar(&configPath, "config", "config/config.yaml", "Path to the configuration file")
 	flag.BoolVar(&staticClusterOnly, "static-cluster-only", false, "If true, only use static cluster configuration and disable the controller.")
+	flag.BoolVar(&watchConfig, "watch-config", true, "If true, reload static listeners, and static clusters and routes with -static-cluster-only, "+
+		"when the configuration file changes. SIGHUP always triggers a reload.")
+	flag.BoolVar(&adminPlayground, "admin-playground", false, "If true, serve a playground sending test requests through the gateway on /playground of the admin listener.")
 	flag.Parse()
 
 	cfg, err := config.LoadConfig(configPath)
@@ -87,21 +144,91 @@ func main() {
 		logLevel = slog.LevelDebug
 	}
 
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+	slog.SetDefault(slog.New(tenant.NewLogHandler(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 		Level: logLevel,
-	})))
+	}))))
+
+	tenants, err := tenantsFromConfig(cfg.Tenants)
+	if err != nil {
+		slog.Error("Failed to load tenants", "error", err)
+		return
+	}
+
+	tenant.SetGlobal(tenants)
+
+	featureFlags, err := featureFlagsFromConfig(cfg.FeatureFlags)
+	if err != nil {
+		slog.Error("Failed to load feature flags", "error", err)
+		return
+	}
+
+	featureflag.SetGlobal(featureFlags)
+
+	egressAllowlist, err := egress.NewAllowlist(cfg.Egress.AllowedHosts, cfg.Egress.AllowedCIDRs)
+	if err != nil {
+		slog.Error("Failed to load egress allowlist", "error", err)
+		return
+	}
+
+	egress.SetGlobal(egressAllowlist)
+	normalize.SetGlobal(normalize.New(normalize.Rules{
+		Lowercase:           cfg.ModelNormalization.Lowercase,
+		StripTagSuffix:      cfg.ModelNormalization.StripTagSuffix,
+		StripRegistryPrefix: cfg.ModelNormalization.StripRegistryPrefix,
+		RegistryPrefixes:    cfg.ModelNormalization.RegistryPrefixes,
+	}))
+	extproc.RegisterWASMRuntime(extproc.WazeroRuntime)
+
+	app.Add(func(_ context.Context, lifeCycle bootkit.LifeCycle) error {
+		observation.ObserveEvents(events.Default(), lifeCycle)
+		return nil
+	})
+
+	if cfg.Audit.Enabled {
+		app.Add(func(_ context.Context, lifeCycle bootkit.LifeCycle) error {
+			return setupAudit(cfg.Audit, lifeCycle)
+		})
+	}
+
+	if cfg.Artifacts.Enabled {
+		app.Add(func(_ context.Context, lifeCycle bootkit.LifeCycle) error {
+			return setupArtifacts(cfg.Artifacts, lifeCycle)
+		})
+	}
+
+	if cfg.Tracing.Enabled {
+		app.Add(func(ctx context.Context, lifeCycle bootkit.LifeCycle) error {
+			return setupTracing(ctx, cfg.Tracing, lifeCycle)
+		})
+	}
+
+	app.Add(func(_ context.Context, lifeCycle bootkit.LifeCycle) error {
+		return setupSharedState(cfg.SharedState, lifeCycle)
+	})
+
+	if cfg.Credentials.Vault != nil {
+		app.Add(func(_ context.Context, lifeCycle bootkit.LifeCycle) error {
+			return setupCredentials(cfg.Credentials, lifeCycle)
+		})
+	}
 
 	// development static server
 	devStaticServer := false
 
+	// kubeClient is only available when running with the controller
+	var kubeClient client.Client
+
+	// staticRegistry is only available when running with static clusters
+	var staticRegistry *gateway.StaticRegistry
+
 	if devStaticServer {
 		app.Add(func(_ context.Context, lifeCycle bootkit.LifeCycle) error {
 			return gateway.StaticRegisterClusters(gateway.StaticClustersConfig, lifeCycle)
 		})
 	} else if staticClusterOnly {
-		staticClusters, err := toClusterMap(cfg.StaticClusters)
+		staticClusters, staticRoutes, err := toStaticConfig(context.Background(), cfg)
 		if err != nil {
-			slog.Error("Failed to load static clusters", "error", err)
+			slog.Error("Failed to load static clusters and routes", "error", err)
 			return
 		}
 
@@ -109,44 +236,568 @@ func main() {
 			slog.Warn("No static clusters configured", "config", configPath)
 		}
 
+		staticRegistry = gateway.NewStaticRegistry()
+
 		app.Add(func(_ context.Context, lifeCycle bootkit.LifeCycle) error {
-			return gateway.StaticRegisterClusters(staticClusters, lifeCycle)
+			lifeCycle.Append(bootkit.LifeCycleHook{
+				OnStop: staticRegistry.Stop,
+			})
+
+			return staticRegistry.Apply(staticClusters, staticRoutes)
+		})
+	} else if cfg.ConfigDiscovery.Client.Address != "" {
+		if len(cfg.StaticClusters) > 0 || len(cfg.StaticRoutes) > 0 || len(cfg.StaticResources) > 0 {
+			slog.Warn("Static clusters, routes and resources are ignored with config discovery", "config", configPath)
+		}
+
+		discoveryRegistry := gateway.NewStaticRegistry()
+
+		app.Add(func(_ context.Context, lifeCycle bootkit.LifeCycle) error {
+			return setupConfigDiscoveryClient(cfg.ConfigDiscovery.Client, discoveryRegistry, lifeCycle)
 		})
 	} else {
+		if len(cfg.StaticRoutes) > 0 {
+			slog.Warn("Static routes are ignored without -static-cluster-only, use ModelRoutes instead", "config", configPath)
+		}
+
+		if len(cfg.StaticResources) > 0 {
+			slog.Warn("Static resources are ignored without -static-cluster-only, apply them to the cluster instead", "config", configPath)
+		}
+
+		kubeClient, err = client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: clientgoscheme.Scheme})
+		if err != nil {
+			slog.Error("Failed to create kubernetes client", "error", err)
+			return
+		}
+
+		if cfg.SharedState.Registrations && cfg.SharedState.RedisURL == "" {
+			slog.Error("shared_state.redis_url is required to share registrations", "config", configPath)
+			return
+		}
+
 		// Start the server and handle errors gracefully
 		app.Add(func(ctx context.Context, lifeCycle bootkit.LifeCycle) error {
+			var runnables []server.LeaderRunnable
+
+			if cfg.SharedState.Registrations {
+				replicator, err := setupReplication(cfg.SharedState, lifeCycle)
+				if err != nil {
+					return err
+				}
+
+				runnables = append(runnables, replicator.Lead)
+			}
+
 			return server.StartController(ctx, lifeCycle,
 				metricsAddr,
 				probeAddr,
-				cfg.Controller)
+				cfg.Controller,
+				runnables...)
 		})
 	}
 
-	staticListeners := toAnySlice(cfg.StaticListeners)
+	if cfg.ConfigDiscovery.Server.Address != "" {
+		app.Add(func(_ context.Context, lifeCycle bootkit.LifeCycle) error {
+			return setupConfigDiscoveryServer(cfg.ConfigDiscovery.Server, lifeCycle)
+		})
+	}
+
+	staticListeners, err := toAnySlice(cfg.StaticListeners)
+	if err != nil {
+		slog.Error("Failed to load static listeners", "error", err)
+		return
+	}
+
+	gw := gateway.NewGateway(cfg.Gateway)
 
 	app.Add(func(ctx context.Context, lifeCycle bootkit.LifeCycle) error {
-		return gateway.StartGateway(ctx, lifeCycle,
+		return gw.Start(ctx, lifeCycle,
 			listenerAddr,
 			staticListeners)
 	})
 	app.Add(func(ctx context.Context, lifeCycle bootkit.LifeCycle) error {
-		return admin.NewAdminServer(ctx, staticListeners, adminAddr, lifeCycle)
+		var playground http.Handler
+		if adminPlayground {
+			playground = gw
+		}
+
+		return admin.NewAdminServer(ctx, gw.Listeners, gw, kubeClient, playground, adminAddr, lifeCycle)
+	})
+	app.Add(func(_ context.Context, lifeCycle bootkit.LifeCycle) error {
+		return setupConfigReload(configPath, watchConfig, gw, staticRegistry, lifeCycle)
 	})
 
 	app.Start()
 }
 
-func toAnySlice(cfg []map[string]interface{}) []*anypb.Any {
+// setupConfigReload reloads the static listeners, and the static clusters and
+// routes when staticRegistry is given, on SIGHUP, or whenever the
+// configuration file changes if watch is true. Other settings require a
+// restart to take effect.
+func setupConfigReload(configPath string, watch bool, gw *gateway.Gateway, staticRegistry *gateway.StaticRegistry, lifeCycle bootkit.LifeCycle) error {
+	reload := func() {
+		err := reloadStaticConfig(configPath, gw, staticRegistry)
+		if err != nil {
+			slog.Error("Failed to reload configuration, keeping the current one", "config", configPath, "error", err)
+			return
+		}
+
+		slog.Info("Reloaded configuration", "config", configPath)
+	}
+
+	return gateway.WatchConfig(configPath, watch, lifeCycle, reload)
+}
+
+func reloadStaticConfig(configPath string, gw *gateway.Gateway, staticRegistry *gateway.StaticRegistry) error {
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	staticListeners, err := toAnySlice(cfg.StaticListeners)
+	if err != nil {
+		return err
+	}
+
+	tenants, err := tenantsFromConfig(cfg.Tenants)
+	if err != nil {
+		return err
+	}
+
+	featureFlags, err := featureFlagsFromConfig(cfg.FeatureFlags)
+	if err != nil {
+		return err
+	}
+
+	if staticRegistry != nil {
+		staticClusters, staticRoutes, err := toStaticConfig(context.Background(), cfg)
+		if err != nil {
+			return err
+		}
+
+		err = staticRegistry.Apply(staticClusters, staticRoutes)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = gw.ReloadListeners(staticListeners)
+	if err != nil {
+		return err
+	}
+
+	tenant.SetGlobal(tenants)
+	featureflag.SetGlobal(featureFlags)
+
+	return nil
+}
+
+func setupArtifacts(cfg config.ArtifactsConfig, lifeCycle bootkit.LifeCycle) error {
+	key, err := os.ReadFile(cfg.SigningKeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read artifacts signing key file: %w", err)
+	}
+
+	store, err := artifacts.NewStore(artifacts.Options{
+		Dir:           cfg.Dir,
+		SigningKey:    bytes.TrimSpace(key),
+		TTL:           cfg.TTL,
+		PublicBaseURL: cfg.PublicBaseURL,
+		MaxSizeBytes:  cfg.MaxSizeBytes,
+	})
+	if err != nil {
+		return err
+	}
+
+	store.Start(lifeCycle)
+	artifacts.SetGlobal(store)
+
+	return nil
+}
+
+func setupTracing(ctx context.Context, cfg config.TracingConfig, lifeCycle bootkit.LifeCycle) error {
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+
+	switch lo.CoalesceOrEmpty(cfg.Protocol, "grpc") {
+	case "grpc":
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint), otlptracegrpc.WithHeaders(cfg.Headers)}
+		if cfg.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err = otlptracegrpc.New(ctx, options...)
+	case "http":
+		options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint), otlptracehttp.WithHeaders(cfg.Headers)}
+		if cfg.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err = otlptracehttp.New(ctx, options...)
+	default:
+		return fmt.Errorf("unsupported tracing protocol %q, must be grpc or http", cfg.Protocol)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to create otlp trace exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(lo.FromPtrOr(cfg.SampleRatio, 1)))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", lo.CoalesceOrEmpty(cfg.ServiceName, "knoway-gateway")))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	observation.SetPropagateUpstream(cfg.PropagateUpstream)
+
+	lifeCycle.Append(bootkit.LifeCycleHook{
+		OnStop: func(ctx context.Context) error {
+			return provider.Shutdown(ctx)
+		},
+	})
+
+	return nil
+}
+
+// setupSharedState shares the state through Redis if configured, the state
+// is kept in the process otherwise, with the expired entries removed in the
+// background.
+func setupSharedState(cfg config.SharedStateConfig, lifeCycle bootkit.LifeCycle) error {
+	options := sharedstate.Options{
+		SyncInterval: cfg.SyncInterval,
+		SyncJitter:   cfg.SyncJitter,
+	}
+
+	if cfg.RedisURL != "" {
+		remote, err := sharedstate.NewRedisRemote(cfg.RedisURL, lifeCycle)
+		if err != nil {
+			return fmt.Errorf("failed to create shared state redis client: %w", err)
+		}
+
+		options.Remote = remote
+	}
+
+	store := sharedstate.NewStore(options)
+
+	store.Start(lifeCycle)
+	sharedstate.SetGlobal(store)
+
+	return nil
+}
+
+// setupReplication applies the clusters and the routes registered by the
+// leader until the replica is elected, the replicator returned publishes the
+// ones registered by the replica once run as the leader.
+func setupReplication(cfg config.SharedStateConfig, lifeCycle bootkit.LifeCycle) (*configdiscovery.Replicator, error) {
+	channel, err := configdiscovery.NewRedisChannel(cfg.RedisURL, lifeCycle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replication redis client: %w", err)
+	}
+
+	registry := gateway.NewStaticRegistry()
+
+	lifeCycle.Append(bootkit.LifeCycleHook{
+		OnStop: registry.Stop,
+	})
+
+	replicator := configdiscovery.NewReplicator(channel, func(snapshot *configdiscovery.Snapshot) error {
+		return registry.Apply(snapshot.ClusterMap(), snapshot.Routes)
+	}, registry.Release)
+	replicator.Start(lifeCycle)
+
+	return replicator, nil
+}
+
+// setupConfigDiscoveryServer serves the clusters and the routes registered in
+// the process to the gateways subscribed.
+func setupConfigDiscoveryServer(cfg config.ConfigDiscoveryServerConfig, lifeCycle bootkit.LifeCycle) error {
+	options := make([]grpc.ServerOption, 0, 1)
+
+	if cfg.CertFile != "" {
+		creds, err := grpccredentials.NewServerTLSFromFile(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config discovery server certificate: %w", err)
+		}
+
+		options = append(options, grpc.Creds(creds))
+	}
+
+	listener, err := net.Listen("tcp", cfg.Address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on config discovery server address: %w", err)
+	}
+
+	discovery := configdiscovery.NewServer()
+	discovery.SyncRegistered(lifeCycle)
+
+	server := grpc.NewServer(options...)
+	service.RegisterConfigDiscoveryServiceServer(server, discovery)
+
+	lifeCycle.Append(bootkit.LifeCycleHook{
+		OnStart: func(context.Context) error {
+			slog.Info("Starting config discovery server", "address", cfg.Address)
+
+			go func() {
+				err := server.Serve(listener)
+				if err != nil {
+					slog.Error("Config discovery server stopped", "error", err)
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			// The streams of the gateways never end on their own
+			server.Stop()
+			return nil
+		},
+	})
+
+	return nil
+}
+
+// setupConfigDiscoveryClient configures the gateway with the clusters and the
+// routes pushed by the control plane, registered the same way as the static
+// ones.
+func setupConfigDiscoveryClient(cfg config.ConfigDiscoveryClientConfig, registry *gateway.StaticRegistry, lifeCycle bootkit.LifeCycle) error {
+	creds := insecure.NewCredentials()
+
+	if cfg.CAFile != "" {
+		var err error
+
+		creds, err = grpccredentials.NewClientTLSFromFile(cfg.CAFile, "")
+		if err != nil {
+			return fmt.Errorf("failed to load config discovery ca file: %w", err)
+		}
+	}
+
+	conn, err := grpc.NewClient(cfg.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return fmt.Errorf("failed to connect config discovery server: %w", err)
+	}
+
+	nodeID := cfg.NodeID
+	if nodeID == "" {
+		nodeID, err = os.Hostname()
+		if err != nil {
+			return fmt.Errorf("failed to get hostname as the node id: %w", err)
+		}
+	}
+
+	lifeCycle.Append(bootkit.LifeCycleHook{
+		OnStop: func(ctx context.Context) error {
+			return errors.Join(conn.Close(), registry.Stop(ctx))
+		},
+	})
+
+	configdiscovery.NewClient(conn, nodeID, func(snapshot *configdiscovery.Snapshot) error {
+		return registry.Apply(snapshot.ClusterMap(), snapshot.Routes)
+	}).Start(lifeCycle)
+
+	return nil
+}
+
+func setupAudit(cfg config.AuditConfig, lifeCycle bootkit.LifeCycle) error {
+	key, err := os.ReadFile(cfg.HMACKeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read audit hmac key file: %w", err)
+	}
+
+	signer, err := audit.NewSigner(cfg.HMACKeyID, bytes.TrimSpace(key))
+	if err != nil {
+		return err
+	}
+
+	sink := audit.NewLogSink()
+	if cfg.File != "" {
+		sink, err = audit.NewFileSink(cfg.File)
+		if err != nil {
+			return err
+		}
+	}
+
+	auditor := audit.NewAuditor(signer, sink, cfg.FailClosed)
+	audit.SetGlobal(auditor)
+
+	lifeCycle.Append(bootkit.LifeCycleHook{
+		OnStop: func(ctx context.Context) error {
+			audit.SetGlobal(nil)
+			return auditor.Close()
+		},
+	})
+
+	return nil
+}
+
+func setupCredentials(cfg config.CredentialsConfig, lifeCycle bootkit.LifeCycle) error {
+	providers := make([]credentials.Provider, 0)
+
+	if cfg.Vault != nil {
+		vault, err := credentials.NewVaultProvider(credentials.VaultOptions{
+			Address:   cfg.Vault.Address,
+			Namespace: cfg.Vault.Namespace,
+			AuthMount: cfg.Vault.AuthMount,
+			TokenFile: cfg.Vault.TokenFile,
+		})
+		if err != nil {
+			return err
+		}
+
+		providers = append(providers, vault)
+	}
+
+	store := credentials.NewStore(cfg.RefreshInterval, providers...)
+	store.Start(lifeCycle)
+	credentials.SetGlobal(store)
+
+	return nil
+}
+
+func featureFlagsFromConfig(cfg config.FeatureFlagsConfig) (*featureflag.Evaluator, error) {
+	flags := make([]featureflag.Flag, 0, len(cfg.Flags))
+
+	for i, flagCfg := range cfg.Flags {
+		if flagCfg.Name == "" {
+			return nil, fmt.Errorf("feature flag %d: name is required", i)
+		}
+
+		if flagCfg.Percent > 100 { //nolint:mnd
+			return nil, fmt.Errorf("feature flag %s: percent must be between 0 and 100", flagCfg.Name)
+		}
+
+		bucketBy := featureflag.BucketBy(lo.CoalesceOrEmpty(flagCfg.BucketBy, string(featureflag.BucketByRequest)))
+
+		switch bucketBy {
+		case featureflag.BucketByRequest, featureflag.BucketByUser, featureflag.BucketByAPIKey, featureflag.BucketByTenant:
+		default:
+			return nil, fmt.Errorf("feature flag %s: unsupported bucket_by %q, must be request, user, api_key or tenant", flagCfg.Name, flagCfg.BucketBy)
+		}
+
+		flags = append(flags, featureflag.Flag{
+			Name:     flagCfg.Name,
+			Percent:  flagCfg.Percent,
+			BucketBy: bucketBy,
+			UserIDs:  flagCfg.UserIDs,
+		})
+	}
+
+	var service *featureflag.Service
+	if cfg.Service != nil {
+		service = &featureflag.Service{
+			URL:     cfg.Service.URL,
+			Timeout: cfg.Service.Timeout,
+			Headers: cfg.Service.Headers,
+		}
+	}
+
+	return featureflag.New(flags, service)
+}
+
+func tenantsFromConfig(cfg map[string]config.TenantConfig) (*tenant.Registry, error) {
+	tenants := make(map[string]tenant.Overrides, len(cfg))
+	namespaces := make(map[string]string)
+
+	for _, id := range slices.Sorted(maps.Keys(cfg)) {
+		tenantCfg := cfg[id]
+		overrides := tenant.Overrides{
+			ErrorVerbosity: tenant.ErrorVerbosity(tenantCfg.ErrorVerbosity),
+			Namespace:      tenantCfg.Namespace,
+		}
+
+		if overrides.Namespace != "" {
+			if strings.Contains(overrides.Namespace, "/") {
+				return nil, fmt.Errorf("tenant %s: namespace %q cannot contain /", id, overrides.Namespace)
+			}
+
+			if owner, ok := namespaces[overrides.Namespace]; ok {
+				return nil, fmt.Errorf("tenant %s: namespace %q is already used by tenant %s", id, overrides.Namespace, owner)
+			}
+
+			namespaces[overrides.Namespace] = id
+		}
+
+		switch overrides.ErrorVerbosity {
+		case "", tenant.ErrorVerbosityDetailed, tenant.ErrorVerbosityMinimal:
+		default:
+			return nil, fmt.Errorf("tenant %s: unsupported error verbosity %q, must be detailed or minimal", id, tenantCfg.ErrorVerbosity)
+		}
+
+		if tenantCfg.LogLevel != "" {
+			var level slog.Level
+
+			err := level.UnmarshalText([]byte(tenantCfg.LogLevel))
+			if err != nil {
+				return nil, fmt.Errorf("tenant %s: invalid log level: %w", id, err)
+			}
+
+			overrides.LogLevel = &level
+		}
+
+		for _, provider := range tenantCfg.AllowedProviders {
+			value, ok := clusters.ClusterProvider_value[strings.ToUpper(provider)]
+			if !ok {
+				return nil, fmt.Errorf("tenant %s: unknown provider %q", id, provider)
+			}
+
+			overrides.AllowedProviders = append(overrides.AllowedProviders, clusters.ClusterProvider(value))
+		}
+
+		for i, rateLimit := range tenantCfg.RateLimits {
+			basedOn, ok := filters.RateLimitBaseOn_value[strings.ToUpper(rateLimit.BasedOn)]
+			if !ok || basedOn == int32(filters.RateLimitBaseOn_RATE_LIMIT_BASE_ON_UNSPECIFIED) {
+				return nil, fmt.Errorf("tenant %s: rate limit %d: based_on must be user_id or api_key", id, i)
+			}
+
+			unit := filters.RateLimitUnit_REQUESTS
+			if rateLimit.Unit != "" {
+				value, ok := filters.RateLimitUnit_value[strings.ToUpper(rateLimit.Unit)]
+				if !ok || value == int32(filters.RateLimitUnit_RATE_LIMIT_UNIT_UNSPECIFIED) {
+					return nil, fmt.Errorf("tenant %s: rate limit %d: unit must be requests or tokens", id, i)
+				}
+
+				unit = filters.RateLimitUnit(value)
+			}
+
+			policy := &filters.RateLimitPolicy{
+				BasedOn: filters.RateLimitBaseOn(basedOn),
+				Unit:    unit,
+				Limit:   rateLimit.Limit,
+			}
+			if rateLimit.Duration > 0 {
+				policy.Duration = durationpb.New(rateLimit.Duration)
+			}
+
+			overrides.RateLimits = append(overrides.RateLimits, policy)
+		}
+
+		tenants[id] = overrides
+	}
+
+	return tenant.New(tenants), nil
+}
+
+func toAnySlice(cfg []map[string]interface{}) ([]*anypb.Any, error) {
 	anys := make([]*anypb.Any, 0, len(cfg))
 
-	for _, c := range cfg {
-		bs := lo.Must1(yaml.Marshal(c))
+	for i, c := range cfg {
+		bs, err := yaml.Marshal(c)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal static listener %d: %w", i, err)
+		}
+
 		n := new(anypb.Any)
-		lo.Must0(protoyaml.Unmarshal(bs, n))
+		if err := protoyaml.Unmarshal(bs, n); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal static listener %d: %w", i, err)
+		}
+
 		anys = append(anys, n)
 	}
 
-	return anys
+	return anys, nil
 }
 
 func toClusterMap(staticCluster []map[string]interface{}) (map[string]*clusters.Cluster, error) {
@@ -172,3 +823,106 @@ func toClusterMap(staticCluster []map[string]interface{}) (map[string]*clusters.
 
 	return clusterMap, nil
 }
+
+// toStaticConfig converts the static clusters and routes, along with the
+// clusters and routes of the backends and the ModelRoutes of the static
+// resources, all of which must have unique names.
+func toStaticConfig(ctx context.Context, cfg *config.Config) (map[string]*clusters.Cluster, []*routes.Route, error) {
+	staticClusters, err := toClusterMap(cfg.StaticClusters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	objects, err := controller.LoadStandaloneObjects(cfg.StaticResources)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load static resources: %w", err)
+	}
+
+	resourceClusters, resourceRoutes, err := controller.StandaloneConfig(ctx, objects)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert static resources: %w", err)
+	}
+
+	for name, cluster := range resourceClusters {
+		if _, ok := staticClusters[name]; ok {
+			return nil, nil, fmt.Errorf("static cluster %s is also defined by a backend of the static resources", name)
+		}
+
+		staticClusters[name] = cluster
+	}
+
+	staticRoutes, err := toRoutes(cfg.StaticRoutes, staticClusters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, route := range resourceRoutes {
+		if slices.ContainsFunc(staticRoutes, func(r *routes.Route) bool { return r.GetName() == route.GetName() }) {
+			return nil, nil, fmt.Errorf("static route %s is also defined by a ModelRoute of the static resources", route.GetName())
+		}
+	}
+
+	return staticClusters, append(staticRoutes, resourceRoutes...), nil
+}
+
+// toRoutes converts the static routes, routes without matches, and matches
+// without models, match the model of the same name as the route, and targets
+// must refer to static clusters.
+func toRoutes(staticRoutes []map[string]interface{}, staticClusters map[string]*clusters.Cluster) ([]*routes.Route, error) {
+	routeList := make([]*routes.Route, 0, len(staticRoutes))
+	names := make(map[string]struct{}, len(staticRoutes))
+
+	for i, r := range staticRoutes {
+		bs, err := yaml.Marshal(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal static route %d: %w", i, err)
+		}
+
+		route := new(routes.Route)
+		if err := protoyaml.Unmarshal(bs, route); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal static route %d: %w", i, err)
+		}
+
+		if route.GetName() == "" {
+			return nil, fmt.Errorf("static route %d missing name", i)
+		}
+
+		if _, ok := names[route.GetName()]; ok {
+			return nil, fmt.Errorf("static route %s is defined more than once", route.GetName())
+		}
+
+		names[route.GetName()] = struct{}{}
+
+		if len(route.GetTargets()) == 0 {
+			return nil, fmt.Errorf("static route %s has no targets", route.GetName())
+		}
+
+		for _, target := range route.GetTargets() {
+			cluster := target.GetDestination().GetCluster()
+			if _, ok := staticClusters[cluster]; !ok {
+				return nil, fmt.Errorf("static route %s refers to unknown static cluster %q", route.GetName(), cluster)
+			}
+		}
+
+		if mirror := route.GetMirror(); mirror != nil {
+			cluster := mirror.GetDestination().GetCluster()
+			if _, ok := staticClusters[cluster]; !ok {
+				return nil, fmt.Errorf("static route %s mirrors to unknown static cluster %q", route.GetName(), cluster)
+			}
+		}
+
+		if len(route.GetMatches()) == 0 {
+			route.Matches = routemanager.InitDirectModelRoute(route.GetName()).GetMatches()
+		}
+
+		for _, match := range route.GetMatches() {
+			if match.GetModel() == nil {
+				match.Model = routemanager.InitDirectModelRoute(route.GetName()).GetMatches()[0].GetModel()
+			}
+		}
+
+		routeList = append(routeList, route)
+	}
+
+	return routeList, nil
+}
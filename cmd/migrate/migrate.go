@@ -0,0 +1,68 @@
+package migrate
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"knoway.dev/api/v1alpha1"
+	"knoway.dev/api/v1beta1"
+	"knoway.dev/internal/migrate"
+)
+
+// Run runs `knoway migrate`, rewriting the v1alpha1 LLMBackends and
+// ModelRoutes of the cluster as v1beta1, args are the arguments after the
+// subcommand.
+func Run(ctx context.Context, args []string) error {
+	var (
+		namespace string
+		dryRun    bool
+	)
+
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	flags.StringVar(&namespace, "namespace", "", "The namespace of the objects to migrate, all namespaces if empty. "+
+		"The stored versions of the CRDs are only updated when migrating all namespaces.")
+	flags.BoolVar(&dryRun, "dry-run", false, "If true, print the migrated objects instead of updating them.")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	result, err := migrate.Migrate(ctx, c, migrate.Options{
+		Namespace: namespace,
+		DryRun:    dryRun,
+		Out:       os.Stdout,
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.Info("migration finished", "llmBackends", result.LLMBackends, "modelRoutes", result.ModelRoutes, "dryRun", dryRun)
+
+	return nil
+}
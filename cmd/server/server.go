@@ -11,11 +11,14 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"k8s.io/client-go/kubernetes/scheme"
 
+	knowaydevv1alpha2 "knoway.dev/api/v1alpha2"
+	knowaydevv1beta1 "knoway.dev/api/v1beta1"
 	"knoway.dev/internal/controller"
 	"knoway.dev/pkg/bootkit"
 )
@@ -24,7 +27,14 @@ var (
 	setupLog = ctrl.Log.WithName("setup")
 )
 
-func StartController(ctx context.Context, lifecycle bootkit.LifeCycle, metricsAddr, probeAddr string, cfg config.ControllerConfig) error {
+// LeaderRunnable is run along with the controllers, initialReconcile blocks
+// until the controllers have reconciled the objects existing.
+type LeaderRunnable func(ctx context.Context, initialReconcile func(ctx context.Context) error) error
+
+// StartController starts the controllers along with the lifecycle, the
+// runnables are started along with them, only once elected as the leader if
+// leader election is enabled.
+func StartController(ctx context.Context, lifecycle bootkit.LifeCycle, metricsAddr, probeAddr string, cfg config.ControllerConfig, runnables ...LeaderRunnable) error {
 	if metricsAddr == "" {
 		metricsAddr = "0"
 	}
@@ -59,6 +69,7 @@ func StartController(ctx context.Context, lifecycle bootkit.LifeCycle, metricsAd
 
 	webhookServer := webhook.NewServer(webhook.Options{
 		TLSOpts: tlsOpts,
+		CertDir: cfg.WebhookCertDir,
 	})
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
@@ -90,23 +101,55 @@ func StartController(ctx context.Context, lifecycle bootkit.LifeCycle, metricsAd
 	}
 
 	if err = (&controller.LLMBackendReconciler{
-		Client:    mgr.GetClient(),
-		Scheme:    mgr.GetScheme(),
-		LifeCycle: lifecycle,
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		LifeCycle:    lifecycle,
+		HistoryLimit: cfg.BackendHistoryLimit,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "LLMBackend")
 		os.Exit(1)
 	}
 
 	if err = (&controller.ImageGenerationBackendReconciler{
-		Client:    mgr.GetClient(),
-		Scheme:    mgr.GetScheme(),
-		LifeCycle: lifecycle,
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		LifeCycle:    lifecycle,
+		HistoryLimit: cfg.BackendHistoryLimit,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ImageGenerationBackend")
 		os.Exit(1)
 	}
 
+	if err = (&controller.EmbeddingBackendReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		LifeCycle:    lifecycle,
+		HistoryLimit: cfg.BackendHistoryLimit,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EmbeddingBackend")
+		os.Exit(1)
+	}
+
+	if err = (&controller.RerankBackendReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		LifeCycle:    lifecycle,
+		HistoryLimit: cfg.BackendHistoryLimit,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RerankBackend")
+		os.Exit(1)
+	}
+
+	if err = (&controller.VideoGenerationBackendReconciler{
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		LifeCycle:    lifecycle,
+		HistoryLimit: cfg.BackendHistoryLimit,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "VideoGenerationBackend")
+		os.Exit(1)
+	}
+
 	if err = (&controller.ModelRouteReconciler{
 		Client:    mgr.GetClient(),
 		Scheme:    mgr.GetScheme(),
@@ -115,8 +158,50 @@ func StartController(ctx context.Context, lifecycle bootkit.LifeCycle, metricsAd
 		setupLog.Error(err, "unable to create controller", "controller", "ModelRoute")
 		os.Exit(1)
 	}
+
+	if cfg.EnableConversionWebhook {
+		if err = ctrl.NewWebhookManagedBy(mgr, &knowaydevv1beta1.LLMBackend{}).Complete(); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "LLMBackend")
+			os.Exit(1)
+		}
+
+		if err = ctrl.NewWebhookManagedBy(mgr, &knowaydevv1beta1.ModelRoute{}).Complete(); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ModelRoute")
+			os.Exit(1)
+		}
+
+		if err = ctrl.NewWebhookManagedBy(mgr, &knowaydevv1alpha2.ImageGenerationBackend{}).Complete(); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ImageGenerationBackend")
+			os.Exit(1)
+		}
+
+		if err = ctrl.NewWebhookManagedBy(mgr, &knowaydevv1alpha2.EmbeddingBackend{}).Complete(); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "EmbeddingBackend")
+			os.Exit(1)
+		}
+	}
+
+	if cfg.EnableValidatingWebhook {
+		if err = controller.SetupValidatingWebhooks(mgr); err != nil {
+			setupLog.Error(err, "unable to create validating webhooks")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	initialReconcile := func(ctx context.Context) error {
+		return controller.WaitForInitialReconcile(ctx, mgr)
+	}
+
+	for _, runnable := range runnables {
+		if err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			return runnable(ctx, initialReconcile)
+		})); err != nil {
+			setupLog.Error(err, "unable to add runnable")
+			os.Exit(1)
+		}
+	}
+
 	err = mgr.AddHealthzCheck("healthz", healthz.Ping)
 	if err != nil {
 		setupLog.Error(err, "unable to set up health check")
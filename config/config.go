@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -11,16 +12,348 @@ type ControllerConfig struct {
 	EnableLeaderElection bool `yaml:"enable_leader_election" json:"enable_leader_election"`
 	SecureMetrics        bool `yaml:"secure_metrics" json:"secure_metrics"`
 	EnableHTTP2          bool `yaml:"enable_http2" json:"enable_http_2"`
+	// BackendHistoryLimit is the number of configuration revisions kept for
+	// each backend to restore from, default: 10
+	BackendHistoryLimit int `yaml:"backend_history_limit" json:"backend_history_limit"`
+	// EnableConversionWebhook serves the webhook converting the backends and
+	// ModelRoutes between v1alpha1, v1alpha2 and v1beta1, required by the
+	// CRDs installed by config/crd and the chart, which enables it.
+	EnableConversionWebhook bool `yaml:"enable_conversion_webhook" json:"enable_conversion_webhook"`
+	// EnableValidatingWebhook serves the webhooks validating LLMBackends,
+	// ImageGenerationBackends, EmbeddingBackends, RerankBackends,
+	// VideoGenerationBackends and ModelRoutes at admission time, see
+	// config/webhook, the chart enables it.
+	EnableValidatingWebhook bool `yaml:"enable_validating_webhook" json:"enable_validating_webhook"`
+	// WebhookCertDir is the directory containing tls.crt and tls.key of the
+	// webhook server, default: <tmp>/k8s-webhook-server/serving-certs
+	WebhookCertDir string `yaml:"webhook_cert_dir" json:"webhook_cert_dir"`
+}
+
+// GatewayConfig hardens the HTTP server of the gateway, zero values fall
+// back to defaults.
+type GatewayConfig struct {
+	// ReadTimeout is the maximum duration for reading the entire request,
+	// including the body. Default is 1m.
+	ReadTimeout time.Duration `yaml:"read_timeout" json:"read_timeout"`
+	// ReadHeaderTimeout is the maximum duration for reading request headers.
+	// Default is 10s.
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" json:"read_header_timeout"`
+	// WriteTimeout is the maximum duration before timing out writes of the
+	// response. Default is unlimited since streaming responses may last long.
+	WriteTimeout time.Duration `yaml:"write_timeout" json:"write_timeout"`
+	// IdleTimeout is the maximum amount of time to wait for the next request
+	// when keep-alives are enabled. Default is the same as ReadTimeout.
+	IdleTimeout time.Duration `yaml:"idle_timeout" json:"idle_timeout"`
+	// MaxHeaderBytes is the maximum size of request headers. Default is 1MB.
+	MaxHeaderBytes int `yaml:"max_header_bytes" json:"max_header_bytes"`
+	// MaxURILength is the maximum length of request URIs, requests exceeding
+	// it are rejected with 414. Default is unlimited.
+	MaxURILength int `yaml:"max_uri_length" json:"max_uri_length"`
+	// MaxConcurrentConnections is the maximum number of simultaneous
+	// connections accepted, further connections wait to be accepted. Default
+	// is unlimited.
+	MaxConcurrentConnections int `yaml:"max_concurrent_connections" json:"max_concurrent_connections"`
+	// AllowedMethods maps path patterns to methods allowed on them, patterns
+	// are exact paths or prefixes ending with "*", e.g. /v1/threads/*. Paths
+	// matching no pattern are not restricted.
+	AllowedMethods map[string][]string `yaml:"allowed_methods" json:"allowed_methods"`
+	// RequestLimits maps path patterns, the same as AllowedMethods, to the
+	// limits of the requests on them, the most specific pattern applies.
+	RequestLimits map[string]RequestLimitsConfig `yaml:"request_limits" json:"request_limits"`
+	// DisableMetrics stops serving Prometheus metrics of the gateway on
+	// /metrics of the listener address.
+	DisableMetrics bool `yaml:"disable_metrics" json:"disable_metrics"`
+	// DrainTimeout is how long the requests in flight, including streaming
+	// ones, are waited for when draining on shutdown or through the admin
+	// endpoint before they are canceled. Default is 30s.
+	DrainTimeout time.Duration `yaml:"drain_timeout" json:"drain_timeout"`
+	// StreamMaxAge closes streaming responses open for longer than it,
+	// however active they are. Default is unlimited.
+	StreamMaxAge time.Duration `yaml:"stream_max_age" json:"stream_max_age"`
+	// StreamMaxIdle closes streaming responses that wrote nothing to the
+	// client for longer than it, such as the ones of clients that stopped
+	// reading. Default is unlimited.
+	StreamMaxIdle time.Duration `yaml:"stream_max_idle" json:"stream_max_idle"`
+}
+
+// RequestLimitsConfig rejects requests exceeding the limits before they
+// reach the listeners, zero values are unlimited.
+type RequestLimitsConfig struct {
+	// MaxBodyBytes is the maximum size of request bodies, larger ones are
+	// rejected with 413 without being read entirely.
+	MaxBodyBytes int64 `yaml:"max_body_bytes" json:"max_body_bytes"`
+	// MaxMessages is the maximum number of messages of chat completions
+	// requests.
+	MaxMessages int `yaml:"max_messages" json:"max_messages"`
+	// MaxPromptChars is the maximum number of characters of the text of
+	// messages, or the prompt of legacy completions, in total.
+	MaxPromptChars int `yaml:"max_prompt_chars" json:"max_prompt_chars"`
+}
+
+// EgressConfig restricts the destinations the gateway sends requests to,
+// including upstreams of clusters and URLs returned by upstreams (e.g.
+// generated images). Everything is allowed when both are empty.
+type EgressConfig struct {
+	// AllowedHosts are exact hostnames, e.g. api.openai.com, or wildcards
+	// matching any subdomain, e.g. *.openai.azure.com.
+	AllowedHosts []string `yaml:"allowed_hosts" json:"allowed_hosts"`
+	// AllowedCIDRs allow hosts whose addresses are all in the CIDRs, e.g.
+	// 10.0.0.0/8.
+	AllowedCIDRs []string `yaml:"allowed_cidrs" json:"allowed_cidrs"`
+}
+
+// AuditConfig records an HMAC signed digest of every request sent to
+// upstreams, together with the identity that triggered it.
+type AuditConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// HMACKeyFile is the path to the file containing the HMAC key, trailing
+	// whitespaces are trimmed.
+	HMACKeyFile string `yaml:"hmac_key_file" json:"hmac_key_file"`
+	// HMACKeyID identifies the key in audit entries to help key rotation.
+	HMACKeyID string `yaml:"hmac_key_id" json:"hmac_key_id"`
+	// File is the path of the file audit entries are appended to as JSON
+	// lines, entries are written to logs if empty.
+	File string `yaml:"file" json:"file"`
+	// FailClosed rejects requests whose audit entries can't be recorded
+	// instead of sending them anyway.
+	FailClosed bool `yaml:"fail_closed" json:"fail_closed"`
+}
+
+// CredentialsConfig configures the external providers of upstream
+// credentials referenced by headersFrom of backends.
+type CredentialsConfig struct {
+	// RefreshInterval is how often credentials are read again to pick up
+	// rotations, leases expiring earlier are renewed before they expire.
+	// Default is 5m.
+	RefreshInterval time.Duration `yaml:"refresh_interval" json:"refresh_interval"`
+	// Vault enables the HashiCorp Vault provider
+	Vault *VaultConfig `yaml:"vault" json:"vault"`
+}
+
+// VaultConfig configures the HashiCorp Vault provider, the gateway logins
+// with the Kubernetes auth method using the roles given by headersFrom.
+type VaultConfig struct {
+	Address string `yaml:"address" json:"address"`
+	// Namespace of Vault Enterprise
+	Namespace string `yaml:"namespace" json:"namespace"`
+	// AuthMount is the mount path of the Kubernetes auth method. Default is
+	// kubernetes.
+	AuthMount string `yaml:"auth_mount" json:"auth_mount"`
+	// TokenFile is the service account token used to login. Default is the
+	// token mounted into the pod.
+	TokenFile string `yaml:"token_file" json:"token_file"`
+}
+
+// ArtifactsConfig stores generated images and audio in the gateway and serves
+// them with short-lived signed URLs instead of raw provider URLs or base64
+// blobs. Images requested in the url format are always stored, audio is
+// stored when asked with the X-Knoway-Artifact-URL: true header.
+type ArtifactsConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// Dir keeps the artifacts, it must be shared between replicas of the
+	// gateway, e.g. a ReadWriteMany volume.
+	Dir string `yaml:"dir" json:"dir"`
+	// SigningKeyFile is the path to the file containing the key signing the
+	// URLs, trailing whitespaces are trimmed.
+	SigningKeyFile string `yaml:"signing_key_file" json:"signing_key_file"`
+	// TTL is how long artifacts are kept and their URLs stay valid. Default
+	// is 1h.
+	TTL time.Duration `yaml:"ttl" json:"ttl"`
+	// PublicBaseURL is the externally reachable address of the gateway,
+	// e.g. https://llm.example.com, URLs are relative if empty.
+	PublicBaseURL string `yaml:"public_base_url" json:"public_base_url"`
+	// MaxSizeBytes is the maximum size of a single artifact. Default is
+	// 100MB.
+	MaxSizeBytes int64 `yaml:"max_size_bytes" json:"max_size_bytes"`
+}
+
+// ModelNormalizationConfig rewrites requested model names matching no route
+// to their canonical spelling before matching routes again, e.g. "GPT-4o",
+// "gpt-4o:latest" and "openai/gpt-4o" all become "gpt-4o".
+type ModelNormalizationConfig struct {
+	// Lowercase folds model names to lower case.
+	Lowercase bool `yaml:"lowercase" json:"lowercase"`
+	// StripTagSuffix removes tags like ":latest" from model names.
+	StripTagSuffix bool `yaml:"strip_tag_suffix" json:"strip_tag_suffix"`
+	// StripRegistryPrefix removes registry or provider prefixes like
+	// "openai/" from model names.
+	StripRegistryPrefix bool `yaml:"strip_registry_prefix" json:"strip_registry_prefix"`
+	// RegistryPrefixes limits the prefixes stripped by StripRegistryPrefix,
+	// any prefix before the last "/" is stripped if empty.
+	RegistryPrefixes []string `yaml:"registry_prefixes" json:"registry_prefixes"`
+}
+
+// SharedStateConfig shares the circuit breaker state and the capabilities of
+// backends across replicas of the gateway through Redis, the state is kept in each
+// replica otherwise.
+type SharedStateConfig struct {
+	// RedisURL enables the shared state, e.g. redis://redis:6379/0
+	RedisURL string `yaml:"redis_url" json:"redis_url"`
+	// Registrations shares the clusters and the routes registered by the
+	// controller of the replica elected as the leader, see
+	// controller.enable_leader_election, with the other replicas, so that
+	// all of them serve requests while only the leader reconciles.
+	Registrations bool `yaml:"registrations" json:"registrations"`
+	// SyncInterval is how often the local cache is synced with Redis.
+	// Default is 5s.
+	SyncInterval time.Duration `yaml:"sync_interval" json:"sync_interval"`
+	// SyncJitter randomizes the sync interval by the fraction to spread the
+	// syncs of replicas. Default is 0.2.
+	SyncJitter float64 `yaml:"sync_jitter" json:"sync_jitter"`
+}
+
+// TracingConfig exports OpenTelemetry traces of requests through OTLP,
+// covering request filters, cluster filters, upstream calls and chunks of
+// streaming responses.
+type TracingConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// Endpoint of the OTLP collector, e.g. otel-collector:4317 for grpc or
+	// otel-collector:4318 for http.
+	Endpoint string `yaml:"endpoint" json:"endpoint"`
+	// Protocol is either grpc or http. Default is grpc.
+	Protocol string `yaml:"protocol" json:"protocol"`
+	// Insecure disables TLS to the collector.
+	Insecure bool `yaml:"insecure" json:"insecure"`
+	// Headers are sent to the collector with every export, e.g. for auth.
+	Headers map[string]string `yaml:"headers" json:"headers"`
+	// SampleRatio is the fraction of new traces sampled, traces propagated by
+	// clients follow the decision of their parents. Default is 1.
+	SampleRatio *float64 `yaml:"sample_ratio" json:"sample_ratio"`
+	// ServiceName is reported as service.name. Default is knoway-gateway.
+	ServiceName string `yaml:"service_name" json:"service_name"`
+	// PropagateUpstream sends trace context headers to upstream providers,
+	// useful when upstreams are traced too, e.g. self-hosted vLLM.
+	PropagateUpstream bool `yaml:"propagate_upstream" json:"propagate_upstream"`
+}
+
+// FeatureFlagsConfig configures the feature flags evaluated for every
+// request, which gate the filters of listeners and routes with feature_flag.
+type FeatureFlagsConfig struct {
+	Flags []FeatureFlagConfig `yaml:"flags" json:"flags"`
+	// Service is a flag service implementing the bulk evaluation of OFREP,
+	// flags evaluated by it take precedence over Flags, which are used when
+	// it fails.
+	Service *FeatureFlagServiceConfig `yaml:"service" json:"service"`
+}
+
+// FeatureFlagConfig enables a feature flag for a percent of the traffic.
+type FeatureFlagConfig struct {
+	Name string `yaml:"name" json:"name"`
+	// Percent of the traffic the flag is enabled for, from 0 to 100.
+	Percent uint32 `yaml:"percent" json:"percent"`
+	// BucketBy is one of request, the default, user, api_key and tenant,
+	// requests of the same user, API key or tenant get the same result.
+	BucketBy string `yaml:"bucket_by" json:"bucket_by"`
+	// UserIDs are the users the flag is always enabled for.
+	UserIDs []string `yaml:"user_ids" json:"user_ids"`
+}
+
+type FeatureFlagServiceConfig struct {
+	URL string `yaml:"url" json:"url"`
+	// Timeout of the evaluation, requests wait on it. Default is 200ms.
+	Timeout time.Duration `yaml:"timeout" json:"timeout"`
+	// Headers are sent with every evaluation, e.g. for auth.
+	Headers map[string]string `yaml:"headers" json:"headers"`
+}
+
+// TenantConfig overrides settings for the requests of a tenant, the tenant
+// of a request is given by the auth server along with its API key. Unset
+// settings follow the global configuration.
+type TenantConfig struct {
+	// RateLimits replace the policies of rate limit filters for the
+	// requests of the tenant.
+	RateLimits []TenantRateLimitConfig `yaml:"rate_limits" json:"rate_limits"`
+	// AllowedProviders limits the providers of the clusters serving the
+	// tenant, e.g. OPEN_AI or VLLM, requests routed to clusters of other
+	// providers are rejected as if the models didn't exist.
+	AllowedProviders []string `yaml:"allowed_providers" json:"allowed_providers"`
+	// LogLevel is the minimum level of the logs of the requests of the
+	// tenant, one of debug, info, warn and error.
+	LogLevel string `yaml:"log_level" json:"log_level"`
+	// ErrorVerbosity is either detailed, the default, or minimal, which hides
+	// the causes of internal errors and the messages of upstreams.
+	ErrorVerbosity string `yaml:"error_verbosity" json:"error_verbosity"`
+	// Namespace of the models of the tenant, e.g. org-a, backends and routes
+	// of the models named "org-a/gpt-4o" are served to the tenant only, as
+	// "gpt-4o" in place of the shared one. Unique across the tenants.
+	Namespace string `yaml:"namespace" json:"namespace"`
+}
+
+// TenantRateLimitConfig is a rate limit policy applied to every user or API
+// key of a tenant.
+type TenantRateLimitConfig struct {
+	// BasedOn is either user_id or api_key.
+	BasedOn string `yaml:"based_on" json:"based_on"`
+	// Unit is either requests, the default, or tokens.
+	Unit  string `yaml:"unit" json:"unit"`
+	Limit int32  `yaml:"limit" json:"limit"`
+	// Duration is the window of the limit. Default is 1m.
+	Duration time.Duration `yaml:"duration" json:"duration"`
+}
+
+// ConfigDiscoveryConfig pushes the clusters and the routes from a control
+// plane to the gateways over the gRPC
+// knoway.service.v1alpha1.ConfigDiscoveryService, so that the gateways run
+// without access to the Kubernetes API.
+type ConfigDiscoveryConfig struct {
+	// Server serves the clusters and the routes registered in this process,
+	// by the controller or from the static configuration, to the gateways.
+	Server ConfigDiscoveryServerConfig `yaml:"server" json:"server"`
+	// Client configures the gateway with the clusters and the routes of the
+	// control plane instead of the controller and the static configuration.
+	Client ConfigDiscoveryClientConfig `yaml:"client" json:"client"`
+}
+
+type ConfigDiscoveryServerConfig struct {
+	// Address the server listens on, e.g. :18000, disabled if empty.
+	Address string `yaml:"address" json:"address"`
+	// CertFile and KeyFile serve with TLS, the configuration carries the
+	// credentials of the upstreams, plaintext is only for trusted networks.
+	CertFile string `yaml:"cert_file" json:"cert_file"`
+	KeyFile  string `yaml:"key_file" json:"key_file"`
+}
+
+type ConfigDiscoveryClientConfig struct {
+	// Address of the control plane, e.g. knoway-control-plane:18000,
+	// disabled if empty.
+	Address string `yaml:"address" json:"address"`
+	// NodeID identifies the gateway to the control plane. Default is the
+	// hostname.
+	NodeID string `yaml:"node_id" json:"node_id"`
+	// CAFile verifies the control plane with TLS, plaintext if empty.
+	CAFile string `yaml:"ca_file" json:"ca_file"`
 }
 
 type Config struct {
-	Debug      bool             `yaml:"debug" json:"debug"`
-	Controller ControllerConfig `yaml:"controller" json:"controller"`
+	Debug       bool              `yaml:"debug" json:"debug"`
+	Controller  ControllerConfig  `yaml:"controller" json:"controller"`
+	Gateway     GatewayConfig     `yaml:"gateway" json:"gateway"`
+	Egress      EgressConfig      `yaml:"egress" json:"egress"`
+	Audit       AuditConfig       `yaml:"audit" json:"audit"`
+	Credentials CredentialsConfig `yaml:"credentials" json:"credentials"`
+
+	ModelNormalization ModelNormalizationConfig `yaml:"model_normalization" json:"model_normalization"`
+	Artifacts          ArtifactsConfig          `yaml:"artifacts" json:"artifacts"`
+	SharedState        SharedStateConfig        `yaml:"shared_state" json:"shared_state"`
+	Tracing            TracingConfig            `yaml:"tracing" json:"tracing"`
+	FeatureFlags       FeatureFlagsConfig       `yaml:"feature_flags" json:"feature_flags"`
+	ConfigDiscovery    ConfigDiscoveryConfig    `yaml:"config_discovery" json:"config_discovery"`
+	// Tenants maps the ids of tenants to their overrides.
+	Tenants map[string]TenantConfig `yaml:"tenants" json:"tenants"`
 	// KubeConfig is the path to the kubeconfig file, used for local development, if empty, in-cluster config will be used.
 	KubeConfig string `yaml:"kubeConfig" json:"kubeConfig"`
 
 	StaticListeners []map[string]interface{} `yaml:"staticListeners" json:"staticListeners"`
 	StaticClusters  []map[string]interface{} `yaml:"staticClusters" json:"staticClusters"`
+	// StaticRoutes are weighted or fallback routes across static clusters,
+	// the equivalent of ModelRoutes, only used with -static-cluster-only.
+	StaticRoutes []map[string]interface{} `yaml:"staticRoutes" json:"staticRoutes"`
+	// StaticResources are the YAML files, or directories of them, of
+	// LLMBackends, ImageGenerationBackends, EmbeddingBackends, RerankBackends,
+	// VideoGenerationBackends and ModelRoutes, along with the Secrets and
+	// ConfigMaps they refer to, served as by the controller, only used with
+	// -static-cluster-only.
+	StaticResources []string `yaml:"staticResources" json:"staticResources"`
 }
 
 // LoadConfig loads the configuration from the specified YAML file
@@ -0,0 +1,656 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/samber/lo"
+	"github.com/stoewer/go-strcase"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	filtersv1alpha1 "knoway.dev/api/filters/v1alpha1"
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	knowaydevv1alpha1 "knoway.dev/api/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/clusters/cluster"
+	clustermanager "knoway.dev/pkg/clusters/manager"
+	routemanager "knoway.dev/pkg/route/manager"
+)
+
+type reconcileHandler[T runtime.Object] struct {
+	typ        string
+	reconciler func(ctx context.Context, backend T) error
+}
+
+const (
+	KnowayFinalzer = "knoway.dev"
+
+	deleteCondPrefix = "delete-"
+)
+
+const (
+	condConfig             = "config"
+	condValidator          = "validator"
+	condUpstreamHealthy    = "upstreamHealthy"
+	condDestinationHealthy = "destinationHealthy"
+	condRegister           = "register"
+	condFinalDelete        = "finalDelete"
+	condHistory            = "history"
+)
+
+const graceDeletePeriod = time.Minute * 10
+
+// backendSpec is the part of the specs shared by all kinds of backends.
+type backendSpec struct {
+	provider        knowaydevv1alpha1.Provider
+	baseURL         string
+	service         *knowaydevv1alpha1.UpstreamService
+	endpointPolicy  knowaydevv1alpha1.EndpointLoadBalancePolicy
+	headers         []knowaydevv1alpha1.Header
+	headersFrom     []knowaydevv1alpha1.HeaderFromSource
+	auth            []knowaydevv1alpha1.UpstreamAuth
+	apiKeys         *knowaydevv1alpha1.UpstreamAPIKeys
+	paths           *knowaydevv1alpha1.UpstreamPaths
+	query           []knowaydevv1alpha1.UpstreamQueryParam
+	timeout         int32
+	removeParamKeys []string
+	filters         []knowaydevv1alpha1.FilterConfig
+	healthCheck     *knowaydevv1alpha1.HealthCheck
+	circuitBreaker  *knowaydevv1alpha1.CircuitBreaker
+	connection      *knowaydevv1alpha1.Connection
+	deadlineHeader  *knowaydevv1alpha1.DeadlineHeader
+
+	meteringExpressions []knowaydevv1alpha1.MeteringExpression
+	pricing             *knowaydevv1alpha1.Pricing
+	modelInfo           *knowaydevv1alpha1.ModelInfo
+}
+
+// backendKind adapts a kind of backends to backendReconciler, supporting a
+// new kind of backends only takes a Backend wrapper and a backendKind of it.
+type backendKind[T client.Object] struct {
+	// name of the kind, such as LLMBackend
+	name        string
+	typ         knowaydevv1alpha1.BackendType
+	clusterType v1alpha1.ClusterType
+
+	newObject func() T
+	list      func(ctx context.Context, c client.Reader) ([]T, error)
+	toBackend func(backend T) Backend
+	// copyStatus copies the status of src to dst
+	copyStatus func(dst, src T)
+	spec       func(backend T) backendSpec
+	// params returns the default and override params of the backend
+	params func(backend T) (map[string]*structpb.Value, map[string]*structpb.Value, error)
+	// customizeCluster sets the fields of the cluster specific to the kind,
+	// optional
+	customizeCluster func(backend T, cluster *v1alpha1.Cluster)
+}
+
+// backendReconciler reconciles backends of any kind into the cluster and the
+// direct route of them, see backendKind.
+type backendReconciler[T client.Object] struct {
+	client.Client
+
+	kind         backendKind[T]
+	lifeCycle    bootkit.LifeCycle
+	historyLimit int
+}
+
+func newBackendReconciler[T client.Object](c client.Client, kind backendKind[T], lifeCycle bootkit.LifeCycle, historyLimit int) *backendReconciler[T] {
+	return &backendReconciler[T]{
+		Client:       c,
+		kind:         kind,
+		lifeCycle:    lifeCycle,
+		historyLimit: historyLimit,
+	}
+}
+
+func (r *backendReconciler[T]) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	defer markReconciled(r.kind.name, req.NamespacedName)
+
+	currentBackend := r.kind.newObject()
+	err := r.Get(ctx, req.NamespacedName, currentBackend)
+	if err != nil {
+		log.Log.Error(err, "reconcile "+r.kind.name, "name", req.String())
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	backend := r.kind.toBackend(currentBackend)
+
+	log.Log.Info("reconcile "+r.kind.name+" modelName", "modelName", backend.GetModelName())
+
+	rrs := r.getReconciles()
+	if isBackendDeleted(backend) {
+		rrs = r.getDeleteReconciles()
+	} else if isDryRun(currentBackend) {
+		rrs = r.getDryRunReconciles()
+	}
+
+	backend.GetStatus().SetConditions(nil)
+
+	for _, rr := range rrs {
+		typ := rr.typ
+
+		err := rr.reconciler(ctx, currentBackend)
+		if err != nil {
+			if isBackendDeleted(backend) && shouldForceDeleteBackend(backend) {
+				continue
+			}
+
+			log.Log.Error(err, r.kind.name+" reconcile error", "name", currentBackend.GetName(), "type", typ)
+			setStatusCondition(backend, typ, false, err.Error())
+
+			break
+		} else {
+			setStatusCondition(backend, typ, true, "")
+		}
+	}
+
+	reconcileBackendRouteConflict(ctx, r.Client, backend)
+
+	if isDryRun(currentBackend) {
+		r.reconcileDryRun(ctx, currentBackend)
+	}
+	reconcileBackendPhase(backend)
+
+	var after time.Duration
+	if backend.GetStatus().GetStatus() == knowaydevv1alpha1.Failed {
+		after = 30 * time.Second //nolint:mnd
+	} else {
+		after = maintenanceRequeueAfter(backend.GetMaintenance(), time.Now())
+	}
+
+	if healthCheck := r.kind.spec(currentBackend).healthCheck; healthCheck != nil && !isBackendDeleted(backend) && !backend.IsDisabled() {
+		after = minRequeueAfter(after, healthCheckInterval(healthCheck))
+	}
+
+	newBackend := r.kind.newObject()
+
+	err = r.Get(ctx, req.NamespacedName, newBackend)
+	if err != nil {
+		log.Log.Error(err, "reconcile "+r.kind.name, "name", req.String())
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !statusEqual(backend.GetStatus(), r.kind.toBackend(newBackend).GetStatus()) ||
+		!endpointsEqual(backend.GetStatus(), r.kind.toBackend(newBackend).GetStatus()) {
+		r.kind.copyStatus(newBackend, currentBackend)
+		err := r.Status().Update(ctx, newBackend)
+		if err != nil {
+			log.Log.Error(err, "update "+r.kind.name+" status error", "name", currentBackend.GetName())
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: after}, nil
+}
+
+func (r *backendReconciler[T]) getReconciles() []reconcileHandler[T] {
+	rhs := []reconcileHandler[T]{
+		{
+			typ:        condConfig,
+			reconciler: r.reconcileConfig,
+		},
+		{
+			typ:        condValidator,
+			reconciler: r.reconcileValidator,
+		},
+		{
+			typ:        condUpstreamHealthy,
+			reconciler: r.reconcileUpstreamHealthy,
+		},
+		{
+			typ:        condRegister,
+			reconciler: r.reconcileRegister,
+		},
+		{
+			typ:        condHistory,
+			reconciler: r.reconcileHistory,
+		},
+	}
+
+	return rhs
+}
+
+// getDryRunReconciles only validates the backend, see also reconcileDryRun.
+func (r *backendReconciler[T]) getDryRunReconciles() []reconcileHandler[T] {
+	rhs := []reconcileHandler[T]{
+		{
+			typ:        condConfig,
+			reconciler: r.reconcileConfig,
+		},
+		{
+			typ:        condValidator,
+			reconciler: r.reconcileValidator,
+		},
+	}
+
+	return rhs
+}
+
+func (r *backendReconciler[T]) getDeleteReconciles() []reconcileHandler[T] {
+	rhs := []reconcileHandler[T]{
+		{
+			typ:        condConfig,
+			reconciler: r.reconcileConfig,
+		},
+		{
+			typ:        strcase.LowerCamelCase(deleteCondPrefix + condRegister),
+			reconciler: r.reconcileRegister,
+		},
+		{
+			typ:        condFinalDelete,
+			reconciler: r.reconcileFinalDelete,
+		},
+	}
+
+	return rhs
+}
+
+func (r *backendReconciler[T]) reconcileConfig(ctx context.Context, backend T) error {
+	if len(backend.GetFinalizers()) == 0 {
+		backend.SetFinalizers([]string{KnowayFinalzer})
+
+		updated, _ := backend.DeepCopyObject().(client.Object)
+
+		err := r.Update(ctx, updated)
+		if err != nil {
+			log.Log.Error(err, "update cluster finalizer error")
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *backendReconciler[T]) reconcileValidator(ctx context.Context, backend T) error {
+	modelName := r.kind.toBackend(backend).GetModelName()
+	if modelName == "" {
+		return errors.New("spec.modelName cannot be empty")
+	}
+
+	spec := r.kind.spec(backend)
+
+	switch {
+	case spec.service != nil:
+		if spec.baseURL != "" {
+			return errors.New("upstream.baseUrl and upstream.service cannot be set at the same time")
+		}
+	case spec.baseURL == "":
+		return errors.New("upstream.baseUrl cannot be empty")
+	default:
+		if _, err := url.Parse(spec.baseURL); err != nil {
+			return fmt.Errorf("upstream.baseUrl parse error: %w", err)
+		}
+	}
+
+	allExistingBackend, err := r.kind.list(ctx, r.Client)
+	if err != nil {
+		return fmt.Errorf("failed to list %s resources: %w", r.kind.name, err)
+	}
+
+	for _, existing := range allExistingBackend {
+		if r.kind.toBackend(existing).GetModelName() == modelName && existing.GetName() != backend.GetName() {
+			return fmt.Errorf("%s name '%s' must be unique globally", r.kind.name, modelName)
+		}
+	}
+
+	// validator cluster filter by new
+	clusterCfg, err := r.toClusterConfig(ctx, backend)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s to cluster config: %w", r.kind.name, err)
+	}
+
+	_, err = cluster.NewWithConfigs(clusterCfg, nil)
+	if err != nil {
+		return fmt.Errorf("invalid cluster configuration: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileUpstreamHealthy probes the upstream when the health check is
+// enabled, the backend is removed from routing while the upstream is
+// unhealthy and registered again once it recovers.
+func (r *backendReconciler[T]) reconcileUpstreamHealthy(ctx context.Context, backend T) error {
+	b := r.kind.toBackend(backend)
+	healthCheck := r.kind.spec(backend).healthCheck
+	key := healthCheckKey(r.kind.name, backend)
+
+	if b.IsDisabled() {
+		upstreamHealthChecker.Forget(key)
+		setEndpoints(b, nil)
+
+		return nil
+	}
+
+	var serviceEndpoints []string
+
+	if ref := r.kind.spec(backend).service; ref != nil {
+		service, err := resolveServiceUpstream(ctx, r.Client, backend.GetNamespace(), ref)
+		if err != nil {
+			return err
+		}
+
+		serviceEndpoints = service.endpoints
+	}
+
+	if healthCheck == nil {
+		upstreamHealthChecker.Forget(key)
+		setEndpoints(b, serviceEndpoints)
+
+		return nil
+	}
+
+	clusterCfg, err := r.toClusterConfig(ctx, backend)
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	status := upstreamHealthChecker.Check(ctx, key, clusterCfg, healthCheckOptions(healthCheck))
+	if status.Healthy {
+		setEndpoints(b, lo.Ternary(len(serviceEndpoints) > 0, serviceEndpoints, []string{status.Endpoint}))
+		return nil
+	}
+
+	setEndpoints(b, nil)
+
+	modelName := b.GetModelName()
+	clustermanager.RemoveCluster(&v1alpha1.Cluster{
+		Name: modelName,
+	})
+	routemanager.RemoveBaseRoute(modelName)
+
+	return fmt.Errorf("upstream is unhealthy after %d consecutive failed probes: %w", status.ConsecutiveFailures, status.LastError)
+}
+
+func (r *backendReconciler[T]) reconcileRegister(ctx context.Context, backend T) error {
+	modelName := r.kind.toBackend(backend).GetModelName()
+
+	removeBackendFunc := func() {
+		if modelName != "" {
+			clustermanager.RemoveCluster(&v1alpha1.Cluster{
+				Name: modelName,
+			})
+			routemanager.RemoveBaseRoute(modelName)
+		}
+	}
+	if isBackendDeleted(r.kind.toBackend(backend)) || r.kind.toBackend(backend).IsDisabled() {
+		removeBackendFunc()
+		return nil
+	}
+
+	clusterCfg, err := r.toClusterConfig(ctx, backend)
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	routeCfg := routemanager.InitDirectModelRoute(modelName)
+
+	mulErrs := &multierror.Error{}
+
+	if clusterCfg != nil {
+		err = clustermanager.UpsertAndRegisterCluster(clusterCfg, r.lifeCycle)
+		if err != nil {
+			log.Log.Error(err, "Failed to upsert "+r.kind.name, "cluster", clusterCfg)
+			mulErrs = multierror.Append(mulErrs, fmt.Errorf("failed to upsert %s %s: %w", r.kind.name, backend.GetName(), err))
+		}
+
+		err = routemanager.RegisterBaseRouteWithConfig(routeCfg, r.lifeCycle)
+		if err != nil {
+			log.Log.Error(err, "Failed to register route", "route", modelName)
+			mulErrs = multierror.Append(mulErrs, fmt.Errorf("failed to upsert %s %s route: %w", r.kind.name, backend.GetName(), err))
+		}
+	}
+
+	if mulErrs.ErrorOrNil() != nil {
+		removeBackendFunc()
+	}
+
+	return mulErrs.ErrorOrNil()
+}
+
+func (r *backendReconciler[T]) reconcileHistory(ctx context.Context, backend T) error {
+	return recordBackendRevision(ctx, r.Client, r.kind.toBackend(backend), r.historyLimit)
+}
+
+func (r *backendReconciler[T]) reconcileFinalDelete(ctx context.Context, backend T) error {
+	canDelete := true
+
+	for _, con := range r.kind.toBackend(backend).GetStatus().GetConditions() {
+		if strings.Contains(con.Type, deleteCondPrefix) && con.Status == metav1.ConditionFalse {
+			canDelete = false
+		}
+	}
+
+	if !canDelete && !shouldForceDeleteBackend(r.kind.toBackend(backend)) {
+		return errors.New("have delete condition not ready")
+	}
+
+	backend.SetFinalizers(nil)
+	err := r.Update(ctx, backend)
+	if err != nil {
+		log.Log.Error(err, "update "+r.kind.name+" finalizer error")
+		return err
+	}
+
+	upstreamHealthChecker.Forget(healthCheckKey(r.kind.name, backend))
+
+	log.Log.Info("remove "+r.kind.name+" finalizer", "name", backend.GetName())
+
+	return nil
+}
+
+// reconcileDryRun records what registering the validated backend would do
+// to the cluster and the route of the backend, without registering it.
+func (r *backendReconciler[T]) reconcileDryRun(ctx context.Context, backend T) {
+	b := r.kind.toBackend(backend)
+	if hasFailedCondition(b.GetStatus().GetConditions()) {
+		return
+	}
+
+	message, err := r.dryRunMessage(ctx, backend)
+	if err != nil {
+		setStatusCondition(b, condDryRun, false, err.Error())
+		return
+	}
+
+	setStatusCondition(b, condDryRun, true, message)
+}
+
+func (r *backendReconciler[T]) dryRunMessage(ctx context.Context, backend T) (string, error) {
+	modelName := r.kind.toBackend(backend).GetModelName()
+
+	var (
+		clusterCfg *v1alpha1.Cluster
+		routeCfg   *routev1alpha1.Route
+		err        error
+	)
+
+	if !r.kind.toBackend(backend).IsDisabled() {
+		clusterCfg, err = r.toClusterConfig(ctx, backend)
+		if err != nil {
+			return "", fmt.Errorf("invalid config: %w", err)
+		}
+
+		routeCfg = routemanager.InitDirectModelRoute(modelName)
+	}
+
+	appliedCluster, _ := clustermanager.GetClusterConfig(modelName)
+	appliedRoute, _ := routemanager.GetBaseRouteConfig(modelName)
+
+	return dryRunMessage(
+		dryRunSection{name: "cluster " + modelName, applied: appliedCluster, desired: clusterCfg},
+		dryRunSection{name: "route " + modelName, applied: appliedRoute, desired: routeCfg},
+	)
+}
+
+func (r *backendReconciler[T]) toClusterConfig(ctx context.Context, backend T) (*v1alpha1.Cluster, error) {
+	if lo.IsNil(backend) {
+		return nil, nil
+	}
+
+	modelName := r.kind.toBackend(backend).GetModelName()
+
+	spec := r.kind.spec(backend)
+
+	baseURL := spec.baseURL
+
+	var endpoints []string
+
+	if spec.service != nil {
+		service, err := resolveServiceUpstream(ctx, r.Client, backend.GetNamespace(), spec.service)
+		if err != nil {
+			return nil, err
+		}
+
+		baseURL = service.baseURL
+		endpoints = service.endpointURLs()
+	}
+
+	hs, err := headerFromSpec(ctx, r.Client, backend.GetNamespace(), spec.headers, spec.headersFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	query, queryAuth, err := queryFromSpec(spec.query)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := authFromSpec(ctx, r.Client, backend.GetNamespace(), append(slices.Clone(spec.auth), queryAuth...))
+	if err != nil {
+		return nil, err
+	}
+
+	defaultParams, overrideParams, err := r.kind.params(backend)
+	if err != nil {
+		return nil, err
+	}
+
+	pricing, err := pricingFromSpec(spec.pricing)
+	if err != nil {
+		return nil, err
+	}
+
+	modelInfo, err := modelInfoFromSpec(spec.modelInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	// filters
+	var filters []*v1alpha1.ClusterFilter
+
+	for i, fc := range spec.filters {
+		switch {
+		case fc.Custom != nil:
+			transform, err := customFilterFromSpec(fc.Custom)
+			if err != nil {
+				return nil, err
+			}
+
+			filters = append(filters, &v1alpha1.ClusterFilter{
+				Name:   fmt.Sprintf("custom-%d", i),
+				Config: lo.Must(anypb.New(transform)),
+			})
+		default:
+			// TODO: Implement unknown filter
+			log.Log.Info("Discovered filter during registration of cluster", "type", "Unknown", "cluster", backend.GetName(), "modelName", modelName)
+		}
+	}
+
+	apiKeys, err := apiKeysFromSpec(ctx, r.Client, backend.GetNamespace(), spec.apiKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKeys != nil {
+		filters = append(filters, &v1alpha1.ClusterFilter{
+			Name:   "upstream-api-keys",
+			Config: lo.Must(anypb.New(apiKeys)),
+		})
+	}
+
+	clusterCfg := &v1alpha1.Cluster{
+		Type:     r.kind.clusterType,
+		Name:     modelName,
+		Provider: MapBackendProviderToClusterProvider(spec.provider),
+		Created:  backend.GetCreationTimestamp().Unix(),
+
+		LoadBalancePolicy: v1alpha1.LoadBalancePolicy_ROUND_ROBIN,
+
+		Upstream: &v1alpha1.Upstream{
+			Url:             baseURL,
+			Endpoints:       endpoints,
+			Headers:         hs,
+			HeadersFrom:     externalHeadersFromSpec(spec.headersFrom),
+			Auth:            auth,
+			Paths:           pathsFromSpec(spec.paths),
+			Query:           query,
+			Timeout:         spec.timeout,
+			DefaultParams:   defaultParams,
+			OverrideParams:  overrideParams,
+			RemoveParamKeys: spec.removeParamKeys,
+			DeadlineHeader:  deadlineHeaderFromSpec(spec.deadlineHeader),
+		},
+		Filters:        filters,
+		Maintenance:    maintenanceFromSpec(r.kind.toBackend(backend).GetMaintenance()),
+		CircuitBreaker: circuitBreakerFromSpec(spec.circuitBreaker),
+		Connection:     connectionFromSpec(spec.connection),
+		Pricing:        pricing,
+		ModelInfo:      modelInfo,
+	}
+
+	switch {
+	case spec.endpointPolicy != "":
+		clusterCfg.LoadBalancePolicy = MapEndpointLoadBalancePolicyClusterLoadBalancePolicy(spec.endpointPolicy)
+	case spec.provider == knowaydevv1alpha1.ProviderVLLM:
+		// Picks the least loaded of the endpoints by the metrics of vLLM
+		// instead of each of them in turn
+		clusterCfg.LoadBalancePolicy = v1alpha1.LoadBalancePolicy_CUSTOM
+		clusterCfg.Filters = append(clusterCfg.Filters, &v1alpha1.ClusterFilter{
+			Name:   "vllm-endpoint-selector",
+			Config: lo.Must(anypb.New(&filtersv1alpha1.VLLMEndpointSelectorConfig{})),
+		})
+	}
+
+	if len(spec.meteringExpressions) > 0 {
+		clusterCfg.MeteringPolicy = &v1alpha1.ClusterMeteringPolicy{
+			Expressions: meteringExpressionsFromSpec(spec.meteringExpressions),
+		}
+	}
+
+	if r.kind.customizeCluster != nil {
+		r.kind.customizeCluster(backend, clusterCfg)
+	}
+
+	return clusterCfg, nil
+}
+
+func (r *backendReconciler[T]) setupWithManager(mgr ctrl.Manager, reconciler reconcile.Reconciler) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(r.kind.newObject()).
+		Watches(&knowaydevv1alpha1.ModelRoute{}, handler.EnqueueRequestsFromMapFunc(modelRouteToBackends(r.Client, r.kind.typ)),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&discoveryv1.EndpointSlice{}, handler.EnqueueRequestsFromMapFunc(r.endpointSliceToBackends())).
+		Named(strings.ToLower(r.kind.name)).
+		Complete(reconciler)
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"knoway.dev/api/v1alpha1"
+	clustermanager "knoway.dev/pkg/clusters/manager"
+)
+
+func TestBackendReconciler_DryRun(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := NewFakeClientWithStatus()
+
+	resource := &v1alpha1.ImageGenerationBackend{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "dall-e-3",
+			Namespace:   "default",
+			Annotations: map[string]string{DryRunAnnotation: "true"},
+		},
+		Spec: v1alpha1.ImageGenerationBackendSpec{
+			ModelName: lo.ToPtr("dry-run-dall-e-3"),
+			Provider:  v1alpha1.ProviderOpenAI,
+			Upstream: v1alpha1.ImageGenerationBackendUpstream{
+				BaseURL: "https://api.openai.com/v1",
+			},
+		},
+	}
+	require.NoError(t, fakeClient.Create(ctx, resource))
+
+	reconciler := &ImageGenerationBackendReconciler{
+		Client: fakeClient,
+	}
+
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{
+		NamespacedName: client.ObjectKeyFromObject(resource),
+	})
+	require.NoError(t, err)
+
+	updated := &v1alpha1.ImageGenerationBackend{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(resource), updated))
+
+	cond := meta.FindStatusCondition(updated.Status.Conditions, condDryRun)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Contains(t, cond.Message, "cluster dry-run-dall-e-3: would be added")
+	assert.Nil(t, meta.FindStatusCondition(updated.Status.Conditions, condRegister))
+
+	_, ok := clustermanager.GetClusterConfig("dry-run-dall-e-3")
+	assert.False(t, ok, "dry-run backends should not be registered")
+}
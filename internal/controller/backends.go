@@ -16,6 +16,9 @@ type Backend interface {
 	GetObjectObjectMeta() metav1.ObjectMeta
 	GetStatus() Statusable[knowaydevv1alpha1.StatusEnum]
 	GetModelName() string
+	GetMaintenance() *knowaydevv1alpha1.MaintenanceSpec
+	IsDisabled() bool
+	GetSpec() any
 }
 
 var _ Backend = (*LLMBackend)(nil)
@@ -40,6 +43,18 @@ func (b *LLMBackend) GetModelName() string {
 	return modelNameOrNamespacedName(b.LLMBackend)
 }
 
+func (b *LLMBackend) GetMaintenance() *knowaydevv1alpha1.MaintenanceSpec {
+	return b.Spec.Maintenance
+}
+
+func (b *LLMBackend) IsDisabled() bool {
+	return b.Spec.Disabled
+}
+
+func (b *LLMBackend) GetSpec() any {
+	return b.Spec
+}
+
 func BackendFromLLMBackend(llmBackend *knowaydevv1alpha1.LLMBackend) Backend {
 	return &LLMBackend{
 		LLMBackend: llmBackend,
@@ -66,6 +81,14 @@ func (s *LLMBackendStatus) SetConditions(conditions []metav1.Condition) {
 	s.Conditions = conditions
 }
 
+func (s *LLMBackendStatus) GetEndpoints() []string {
+	return s.Endpoints
+}
+
+func (s *LLMBackendStatus) SetEndpoints(endpoints []string) {
+	s.Endpoints = endpoints
+}
+
 var _ Backend = (*ImageGenerationBackend)(nil)
 
 type ImageGenerationBackend struct {
@@ -88,6 +111,18 @@ func (b *ImageGenerationBackend) GetModelName() string {
 	return modelNameOrNamespacedName(b.ImageGenerationBackend)
 }
 
+func (b *ImageGenerationBackend) GetMaintenance() *knowaydevv1alpha1.MaintenanceSpec {
+	return b.Spec.Maintenance
+}
+
+func (b *ImageGenerationBackend) IsDisabled() bool {
+	return b.Spec.Disabled
+}
+
+func (b *ImageGenerationBackend) GetSpec() any {
+	return b.Spec
+}
+
 func BackendFromImageGenerationBackend(imageGenerationBackend *knowaydevv1alpha1.ImageGenerationBackend) Backend {
 	return &ImageGenerationBackend{
 		ImageGenerationBackend: imageGenerationBackend,
@@ -114,6 +149,218 @@ func (s *ImageGenerationBackendStatus) SetConditions(conditions []metav1.Conditi
 	s.Conditions = conditions
 }
 
+func (s *ImageGenerationBackendStatus) GetEndpoints() []string {
+	return s.Endpoints
+}
+
+func (s *ImageGenerationBackendStatus) SetEndpoints(endpoints []string) {
+	s.Endpoints = endpoints
+}
+
+var _ Backend = (*EmbeddingBackend)(nil)
+
+type EmbeddingBackend struct {
+	*knowaydevv1alpha1.EmbeddingBackend
+}
+
+func (b *EmbeddingBackend) GetType() knowaydevv1alpha1.BackendType {
+	return knowaydevv1alpha1.BackendTypeEmbedding
+}
+
+func (b *EmbeddingBackend) GetObjectObjectMeta() metav1.ObjectMeta {
+	return b.ObjectMeta
+}
+
+func (b *EmbeddingBackend) GetStatus() Statusable[knowaydevv1alpha1.StatusEnum] {
+	return &EmbeddingBackendStatus{EmbeddingBackendStatus: &b.Status}
+}
+
+func (b *EmbeddingBackend) GetModelName() string {
+	return modelNameOrNamespacedName(b.EmbeddingBackend)
+}
+
+func (b *EmbeddingBackend) GetMaintenance() *knowaydevv1alpha1.MaintenanceSpec {
+	return b.Spec.Maintenance
+}
+
+func (b *EmbeddingBackend) IsDisabled() bool {
+	return b.Spec.Disabled
+}
+
+func (b *EmbeddingBackend) GetSpec() any {
+	return b.Spec
+}
+
+func BackendFromEmbeddingBackend(embeddingBackend *knowaydevv1alpha1.EmbeddingBackend) Backend {
+	return &EmbeddingBackend{
+		EmbeddingBackend: embeddingBackend,
+	}
+}
+
+type EmbeddingBackendStatus struct {
+	*knowaydevv1alpha1.EmbeddingBackendStatus
+}
+
+func (s *EmbeddingBackendStatus) GetStatus() knowaydevv1alpha1.StatusEnum {
+	return s.Status
+}
+
+func (s *EmbeddingBackendStatus) SetStatus(status knowaydevv1alpha1.StatusEnum) {
+	s.Status = status
+}
+
+func (s *EmbeddingBackendStatus) GetConditions() []metav1.Condition {
+	return s.Conditions
+}
+
+func (s *EmbeddingBackendStatus) SetConditions(conditions []metav1.Condition) {
+	s.Conditions = conditions
+}
+
+func (s *EmbeddingBackendStatus) GetEndpoints() []string {
+	return s.Endpoints
+}
+
+func (s *EmbeddingBackendStatus) SetEndpoints(endpoints []string) {
+	s.Endpoints = endpoints
+}
+
+var _ Backend = (*RerankBackend)(nil)
+
+type RerankBackend struct {
+	*knowaydevv1alpha1.RerankBackend
+}
+
+func (b *RerankBackend) GetType() knowaydevv1alpha1.BackendType {
+	return knowaydevv1alpha1.BackendTypeRerank
+}
+
+func (b *RerankBackend) GetObjectObjectMeta() metav1.ObjectMeta {
+	return b.ObjectMeta
+}
+
+func (b *RerankBackend) GetStatus() Statusable[knowaydevv1alpha1.StatusEnum] {
+	return &RerankBackendStatus{RerankBackendStatus: &b.Status}
+}
+
+func (b *RerankBackend) GetModelName() string {
+	return modelNameOrNamespacedName(b.RerankBackend)
+}
+
+func (b *RerankBackend) GetMaintenance() *knowaydevv1alpha1.MaintenanceSpec {
+	return b.Spec.Maintenance
+}
+
+func (b *RerankBackend) IsDisabled() bool {
+	return b.Spec.Disabled
+}
+
+func (b *RerankBackend) GetSpec() any {
+	return b.Spec
+}
+
+func BackendFromRerankBackend(rerankBackend *knowaydevv1alpha1.RerankBackend) Backend {
+	return &RerankBackend{
+		RerankBackend: rerankBackend,
+	}
+}
+
+type RerankBackendStatus struct {
+	*knowaydevv1alpha1.RerankBackendStatus
+}
+
+func (s *RerankBackendStatus) GetStatus() knowaydevv1alpha1.StatusEnum {
+	return s.Status
+}
+
+func (s *RerankBackendStatus) SetStatus(status knowaydevv1alpha1.StatusEnum) {
+	s.Status = status
+}
+
+func (s *RerankBackendStatus) GetConditions() []metav1.Condition {
+	return s.Conditions
+}
+
+func (s *RerankBackendStatus) SetConditions(conditions []metav1.Condition) {
+	s.Conditions = conditions
+}
+
+func (s *RerankBackendStatus) GetEndpoints() []string {
+	return s.Endpoints
+}
+
+func (s *RerankBackendStatus) SetEndpoints(endpoints []string) {
+	s.Endpoints = endpoints
+}
+
+var _ Backend = (*VideoGenerationBackend)(nil)
+
+type VideoGenerationBackend struct {
+	*knowaydevv1alpha1.VideoGenerationBackend
+}
+
+func (b *VideoGenerationBackend) GetType() knowaydevv1alpha1.BackendType {
+	return knowaydevv1alpha1.BackendTypeVideoGeneration
+}
+
+func (b *VideoGenerationBackend) GetObjectObjectMeta() metav1.ObjectMeta {
+	return b.ObjectMeta
+}
+
+func (b *VideoGenerationBackend) GetStatus() Statusable[knowaydevv1alpha1.StatusEnum] {
+	return &VideoGenerationBackendStatus{VideoGenerationBackendStatus: &b.Status}
+}
+
+func (b *VideoGenerationBackend) GetModelName() string {
+	return modelNameOrNamespacedName(b.VideoGenerationBackend)
+}
+
+func (b *VideoGenerationBackend) GetMaintenance() *knowaydevv1alpha1.MaintenanceSpec {
+	return b.Spec.Maintenance
+}
+
+func (b *VideoGenerationBackend) IsDisabled() bool {
+	return b.Spec.Disabled
+}
+
+func (b *VideoGenerationBackend) GetSpec() any {
+	return b.Spec
+}
+
+func BackendFromVideoGenerationBackend(videoGenerationBackend *knowaydevv1alpha1.VideoGenerationBackend) Backend {
+	return &VideoGenerationBackend{
+		VideoGenerationBackend: videoGenerationBackend,
+	}
+}
+
+type VideoGenerationBackendStatus struct {
+	*knowaydevv1alpha1.VideoGenerationBackendStatus
+}
+
+func (s *VideoGenerationBackendStatus) GetStatus() knowaydevv1alpha1.StatusEnum {
+	return s.Status
+}
+
+func (s *VideoGenerationBackendStatus) SetStatus(status knowaydevv1alpha1.StatusEnum) {
+	s.Status = status
+}
+
+func (s *VideoGenerationBackendStatus) GetConditions() []metav1.Condition {
+	return s.Conditions
+}
+
+func (s *VideoGenerationBackendStatus) SetConditions(conditions []metav1.Condition) {
+	s.Conditions = conditions
+}
+
+func (s *VideoGenerationBackendStatus) GetEndpoints() []string {
+	return s.Endpoints
+}
+
+func (s *VideoGenerationBackendStatus) SetEndpoints(endpoints []string) {
+	s.Endpoints = endpoints
+}
+
 func getBackendFromNamespacedName(ctx context.Context, kubeClient client.Client, namespacedName types.NamespacedName) (Backend, error) {
 	var llmBackend knowaydevv1alpha1.LLMBackend
 
@@ -137,5 +384,38 @@ func getBackendFromNamespacedName(ctx context.Context, kubeClient client.Client,
 		return BackendFromImageGenerationBackend(&imageGenerationBackend), nil
 	}
 
+	var embeddingBackend knowaydevv1alpha1.EmbeddingBackend
+
+	err = kubeClient.Get(ctx, namespacedName, &embeddingBackend)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if err == nil {
+		return BackendFromEmbeddingBackend(&embeddingBackend), nil
+	}
+
+	var rerankBackend knowaydevv1alpha1.RerankBackend
+
+	err = kubeClient.Get(ctx, namespacedName, &rerankBackend)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if err == nil {
+		return BackendFromRerankBackend(&rerankBackend), nil
+	}
+
+	var videoGenerationBackend knowaydevv1alpha1.VideoGenerationBackend
+
+	err = kubeClient.Get(ctx, namespacedName, &videoGenerationBackend)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if err == nil {
+		return BackendFromVideoGenerationBackend(&videoGenerationBackend), nil
+	}
+
 	return nil, nil
 }
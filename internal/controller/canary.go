@@ -0,0 +1,282 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	knowaydevv1alpha1 "knoway.dev/api/v1alpha1"
+	"knoway.dev/pkg/observation"
+)
+
+const (
+	defaultCanaryStepWeight     = 10
+	defaultCanaryMaxWeight      = 100
+	defaultCanaryAnalysisWindow = time.Minute
+	defaultCanaryMinRequests    = 10
+	defaultCanaryMaxErrorRate   = 0.05
+
+	// canaryRecheckInterval is how often the steps waiting for more requests
+	// of the canary target are analyzed again.
+	canaryRecheckInterval = 10 * time.Second
+)
+
+func validateModelRouteCanary(route *knowaydevv1alpha1.ModelRouteRoute) error {
+	canary := route.Canary
+
+	if canary.Stable == "" {
+		return errors.New("spec.route.canary.stable cannot be empty")
+	}
+
+	if canary.Canary == "" {
+		return errors.New("spec.route.canary.canary cannot be empty")
+	}
+
+	if canary.Stable == canary.Canary {
+		return errors.New("spec.route.canary.stable and spec.route.canary.canary must be different backends")
+	}
+
+	for field, backend := range map[string]string{"stable": canary.Stable, "canary": canary.Canary} {
+		if lo.CountBy(route.Targets, func(target knowaydevv1alpha1.ModelRouteRouteTarget) bool {
+			return target.Destination.Backend == backend
+		}) != 1 {
+			return fmt.Errorf("spec.route.canary.%s must be the backend of exactly one of spec.route.targets", field)
+		}
+	}
+
+	if canary.StepWeight != nil && (*canary.StepWeight < 1 || *canary.StepWeight > 100) {
+		return errors.New("spec.route.canary.stepWeight must be between 1 and 100")
+	}
+
+	if canary.MaxWeight != nil && (*canary.MaxWeight < 1 || *canary.MaxWeight > 100) {
+		return errors.New("spec.route.canary.maxWeight must be between 1 and 100")
+	}
+
+	if canary.AnalysisWindow != nil && (canary.AnalysisWindow.Duration <= 0 || canary.AnalysisWindow.Duration > observation.RouteStatsMaxWindow) {
+		return fmt.Errorf("spec.route.canary.analysisWindow must be greater than 0 and at most %s", observation.RouteStatsMaxWindow)
+	}
+
+	if canary.MinRequests != nil && *canary.MinRequests < 1 {
+		return errors.New("spec.route.canary.minRequests must be greater than 0")
+	}
+
+	if _, err := canaryMaxErrorRate(canary); err != nil {
+		return err
+	}
+
+	if canary.MaxLatencyP95 != nil && canary.MaxLatencyP95.Duration <= 0 {
+		return errors.New("spec.route.canary.maxLatencyP95 must be greater than 0")
+	}
+
+	return nil
+}
+
+func canaryMaxErrorRate(canary *knowaydevv1alpha1.ModelRouteCanary) (float64, error) {
+	if canary.MaxErrorRate == "" {
+		return defaultCanaryMaxErrorRate, nil
+	}
+
+	rate, err := strconv.ParseFloat(canary.MaxErrorRate, 64)
+	if err != nil || rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("invalid max error rate %q of spec.route.canary", canary.MaxErrorRate)
+	}
+
+	return rate, nil
+}
+
+func canaryAnalysisWindow(canary *knowaydevv1alpha1.ModelRouteCanary) time.Duration {
+	if canary.AnalysisWindow == nil {
+		return defaultCanaryAnalysisWindow
+	}
+
+	return canary.AnalysisWindow.Duration
+}
+
+func getModelRouteCanary(modelRoute *knowaydevv1alpha1.ModelRoute) *knowaydevv1alpha1.ModelRouteCanary {
+	if modelRoute.Spec.Route == nil {
+		return nil
+	}
+
+	return modelRoute.Spec.Route.Canary
+}
+
+// canaryServingTarget returns the canary target in the form the gateway
+// records the requests it serves, see metadata.RequestMetadata.ServingTarget.
+func canaryServingTarget(modelRoute *knowaydevv1alpha1.ModelRoute) string {
+	target, _ := lo.Find(modelRoute.Spec.Route.Targets, func(target knowaydevv1alpha1.ModelRouteRouteTarget) bool {
+		return target.Destination.Backend == modelRoute.Spec.Route.Canary.Canary
+	})
+
+	return lo.CoalesceOrEmpty(target.Destination.Namespace, modelRoute.GetNamespace()) + "/" + target.Destination.Backend
+}
+
+// nextCanaryStatus analyzes the requests served by the canary target over
+// the analysis window once the current step has lasted for the window, and
+// returns the status of the rollout after the analysis.
+func nextCanaryStatus(canary *knowaydevv1alpha1.ModelRouteCanary, status *knowaydevv1alpha1.ModelRouteCanaryStatus, target observation.TargetShare, now time.Time) *knowaydevv1alpha1.ModelRouteCanaryStatus {
+	stepWeight := lo.FromPtrOr(canary.StepWeight, defaultCanaryStepWeight)
+	maxWeight := lo.FromPtrOr(canary.MaxWeight, defaultCanaryMaxWeight)
+
+	if status == nil || status.Canary != canary.Canary {
+		return &knowaydevv1alpha1.ModelRouteCanaryStatus{
+			Canary:        canary.Canary,
+			Phase:         knowaydevv1alpha1.CanaryPhaseProgressing,
+			Weight:        min(stepWeight, maxWeight),
+			StepStartTime: metav1.NewTime(now),
+			Message:       "rollout started",
+		}
+	}
+
+	status = status.DeepCopy()
+	if status.Phase != knowaydevv1alpha1.CanaryPhaseProgressing || now.Sub(status.StepStartTime.Time) < canaryAnalysisWindow(canary) {
+		return status
+	}
+
+	minRequests := lo.FromPtrOr(canary.MinRequests, defaultCanaryMinRequests)
+	if target.Requests < uint64(max(minRequests, 1)) {
+		status.Message = fmt.Sprintf("waiting for %d requests of the canary target to analyze, %d served", minRequests, target.Requests)
+		return status
+	}
+
+	// Validated before
+	maxErrorRate, _ := canaryMaxErrorRate(canary)
+
+	var regression string
+
+	switch {
+	case target.ErrorRate > maxErrorRate:
+		regression = fmt.Sprintf("error rate %.4f exceeds %.4f", target.ErrorRate, maxErrorRate)
+	case canary.MaxLatencyP95 != nil && target.LatencyP95Seconds > canary.MaxLatencyP95.Seconds():
+		regression = fmt.Sprintf("P95 latency %.3fs exceeds %s", target.LatencyP95Seconds, canary.MaxLatencyP95.Duration)
+	}
+
+	status.StepStartTime = metav1.NewTime(now)
+
+	if regression != "" {
+		status.Phase = knowaydevv1alpha1.CanaryPhaseRolledBack
+		status.Message = fmt.Sprintf("rolled back at weight %d, %s", status.Weight, regression)
+		status.Weight = 0
+
+		return status
+	}
+
+	status.Weight = min(status.Weight+stepWeight, maxWeight)
+	status.Message = fmt.Sprintf("error rate %.4f and P95 latency %.3fs of %d requests met the objectives", target.ErrorRate, target.LatencyP95Seconds, target.Requests)
+
+	if status.Weight >= maxWeight {
+		status.Phase = knowaydevv1alpha1.CanaryPhasePromoted
+	}
+
+	return status
+}
+
+// reconcileCanary advances the rollout of the canary target by the requests
+// the gateway observed, and returns when the rollout should be analyzed
+// again, 0 if it's finished.
+func (r *ModelRouteReconciler) reconcileCanary(modelRoute *knowaydevv1alpha1.ModelRoute, now time.Time) time.Duration {
+	canary := getModelRouteCanary(modelRoute)
+	if canary == nil {
+		modelRoute.Status.Canary = nil
+		return 0
+	}
+
+	// Reported by the validator
+	if validateModelRouteCanary(modelRoute.Spec.Route) != nil {
+		return 0
+	}
+
+	window := canaryAnalysisWindow(canary)
+	target := canaryServingTarget(modelRoute)
+
+	summary, _ := lo.Find(observation.RouteSummaries(window), func(summary observation.RouteSummary) bool {
+		return summary.Route == modelRoute.Spec.ModelName
+	})
+	targetShare, _ := lo.Find(summary.Targets, func(share observation.TargetShare) bool {
+		return share.Target == target
+	})
+
+	previous := modelRoute.Status.Canary
+	next := nextCanaryStatus(canary, previous, targetShare, now)
+
+	if previous == nil || previous.Canary != next.Canary || previous.Phase != next.Phase || previous.Weight != next.Weight {
+		log.Log.Info("canary rollout of ModelRoute", "name", modelRoute.GetName(), "canary", target, "phase", next.Phase, "weight", next.Weight, "message", next.Message)
+
+		rolledBack := next.Phase == knowaydevv1alpha1.CanaryPhaseRolledBack && (previous == nil || previous.Phase != next.Phase)
+		observation.ObserveRouteCanaryWeight(modelRoute.Spec.ModelName, target, next.Weight, rolledBack)
+	}
+
+	modelRoute.Status.Canary = next
+
+	if next.Phase != knowaydevv1alpha1.CanaryPhaseProgressing {
+		return 0
+	}
+
+	remaining := next.StepStartTime.Add(window).Sub(now)
+	if remaining <= 0 {
+		return canaryRecheckInterval
+	}
+
+	return remaining
+}
+
+// applyCanaryWeights splits the combined weight of the stable and the canary
+// targets between them by the percent of the rollout, the weights of the
+// other targets are scaled accordingly. Targets left with no weight are
+// removed from the route.
+func applyCanaryWeights(modelRoute *knowaydevv1alpha1.ModelRoute, targets []*routev1alpha1.RouteTarget) []*routev1alpha1.RouteTarget {
+	canary := getModelRouteCanary(modelRoute)
+	status := modelRoute.Status.Canary
+
+	if canary == nil || status == nil || status.Canary != canary.Canary {
+		return targets
+	}
+
+	_, stableIndex, stableFound := lo.FindIndexOf(targets, func(target *routev1alpha1.RouteTarget) bool {
+		return target.GetDestination().GetBackend() == canary.Stable
+	})
+	_, canaryIndex, canaryFound := lo.FindIndexOf(targets, func(target *routev1alpha1.RouteTarget) bool {
+		return target.GetDestination().GetBackend() == canary.Canary
+	})
+
+	if !stableFound || !canaryFound {
+		return targets
+	}
+
+	total := targets[stableIndex].GetDestination().GetWeight() + targets[canaryIndex].GetDestination().GetWeight()
+	if total <= 0 {
+		return targets
+	}
+
+	weighted := make([]*routev1alpha1.RouteTarget, 0, len(targets))
+
+	for i, target := range targets {
+		weight := target.GetDestination().GetWeight() * 100 //nolint:mnd
+
+		switch i {
+		case stableIndex:
+			weight = total * (100 - status.Weight) //nolint:mnd
+		case canaryIndex:
+			weight = total * status.Weight
+		}
+
+		if weight <= 0 && (i == stableIndex || i == canaryIndex) {
+			continue
+		}
+
+		weighted = append(weighted, &routev1alpha1.RouteTarget{
+			Destination: &routev1alpha1.RouteDestination{
+				Namespace: target.GetDestination().GetNamespace(),
+				Backend:   target.GetDestination().GetBackend(),
+				Weight:    lo.ToPtr(weight),
+			},
+		})
+	}
+
+	return weighted
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	"knoway.dev/api/v1alpha1"
+	"knoway.dev/pkg/observation"
+)
+
+func newCanaryModelRoute() *v1alpha1.ModelRoute {
+	return &v1alpha1.ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4o"},
+		Spec: v1alpha1.ModelRouteSpec{
+			ModelName: "gpt-4o",
+			Route: &v1alpha1.ModelRouteRoute{
+				LoadBalancePolicy: v1alpha1.LoadBalancePolicyWeightedRoundRobin,
+				Targets: []v1alpha1.ModelRouteRouteTarget{
+					{Destination: v1alpha1.ModelRouteRouteTargetDestination{Backend: "gpt-4o-stable", Weight: lo.ToPtr(3)}},
+					{Destination: v1alpha1.ModelRouteRouteTargetDestination{Backend: "gpt-4o-canary", Weight: lo.ToPtr(1)}},
+					{Destination: v1alpha1.ModelRouteRouteTargetDestination{Backend: "gpt-4o-azure", Weight: lo.ToPtr(1)}},
+				},
+				Canary: &v1alpha1.ModelRouteCanary{
+					Stable:        "gpt-4o-stable",
+					Canary:        "gpt-4o-canary",
+					StepWeight:    lo.ToPtr[int32](25),
+					MaxWeight:     lo.ToPtr[int32](50),
+					MaxErrorRate:  "0.1",
+					MaxLatencyP95: &metav1.Duration{Duration: 2 * time.Second},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateModelRouteCanary(t *testing.T) {
+	modelRoute := newCanaryModelRoute()
+	require.NoError(t, validateModelRouteCanary(modelRoute.Spec.Route))
+
+	modelRoute.Spec.Route.Canary.Canary = "gpt-4.1"
+	require.EqualError(t, validateModelRouteCanary(modelRoute.Spec.Route), "spec.route.canary.canary must be the backend of exactly one of spec.route.targets")
+
+	modelRoute = newCanaryModelRoute()
+	modelRoute.Spec.Route.Canary.MaxErrorRate = "2"
+	require.Error(t, validateModelRouteCanary(modelRoute.Spec.Route))
+
+	modelRoute = newCanaryModelRoute()
+	modelRoute.Spec.Route.Canary.AnalysisWindow = &metav1.Duration{Duration: time.Hour}
+	require.Error(t, validateModelRouteCanary(modelRoute.Spec.Route))
+}
+
+func TestNextCanaryStatus(t *testing.T) {
+	canary := newCanaryModelRoute().Spec.Route.Canary
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	healthy := observation.TargetShare{Target: "default/gpt-4o-canary", Requests: 20, ErrorRate: 0.05, LatencyP95Seconds: 1}
+
+	status := nextCanaryStatus(canary, nil, observation.TargetShare{}, now)
+	assert.Equal(t, v1alpha1.CanaryPhaseProgressing, status.Phase)
+	assert.Equal(t, int32(25), status.Weight)
+
+	// The step lasts for the analysis window
+	assert.Equal(t, status, nextCanaryStatus(canary, status, healthy, now.Add(30*time.Second)))
+
+	now = now.Add(time.Minute)
+
+	// Not enough requests to analyze
+	waiting := nextCanaryStatus(canary, status, observation.TargetShare{Requests: 3}, now)
+	assert.Equal(t, int32(25), waiting.Weight)
+	assert.Contains(t, waiting.Message, "waiting for 10 requests")
+
+	status = nextCanaryStatus(canary, status, healthy, now)
+	assert.Equal(t, v1alpha1.CanaryPhasePromoted, status.Phase)
+	assert.Equal(t, int32(50), status.Weight)
+	assert.Equal(t, now, status.StepStartTime.Time)
+
+	// Promoted rollouts are kept
+	assert.Equal(t, status, nextCanaryStatus(canary, status, observation.TargetShare{Requests: 20, ErrorRate: 1}, now.Add(time.Hour)))
+
+	t.Run("rollback", func(t *testing.T) {
+		started := nextCanaryStatus(canary, nil, observation.TargetShare{}, now)
+
+		status := nextCanaryStatus(canary, started, observation.TargetShare{Requests: 20, ErrorRate: 0.05, LatencyP95Seconds: 3}, now.Add(time.Minute))
+		assert.Equal(t, v1alpha1.CanaryPhaseRolledBack, status.Phase)
+		assert.Equal(t, int32(0), status.Weight)
+		assert.Equal(t, "rolled back at weight 25, P95 latency 3.000s exceeds 2s", status.Message)
+
+		status = nextCanaryStatus(canary, started, observation.TargetShare{Requests: 20, ErrorRate: 0.2}, now.Add(time.Minute))
+		assert.Equal(t, v1alpha1.CanaryPhaseRolledBack, status.Phase)
+
+		// Restarted once the canary backend changes
+		canary := canary.DeepCopy()
+		canary.Canary = "gpt-4o-azure"
+
+		status = nextCanaryStatus(canary, status, observation.TargetShare{}, now.Add(2*time.Minute))
+		assert.Equal(t, v1alpha1.CanaryPhaseProgressing, status.Phase)
+		assert.Equal(t, "gpt-4o-azure", status.Canary)
+	})
+}
+
+func TestModelRouteCanaryWeights(t *testing.T) {
+	r := &ModelRouteReconciler{}
+	modelRoute := newCanaryModelRoute()
+
+	backends := map[string]Backend{}
+	for _, target := range modelRoute.Spec.Route.Targets {
+		backends["default/"+target.Destination.Backend] = BackendFromLLMBackend(&v1alpha1.LLMBackend{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: target.Destination.Backend},
+			Spec:       v1alpha1.LLMBackendSpec{ModelName: lo.ToPtr(target.Destination.Backend)},
+		})
+	}
+
+	weights := func() map[string]int32 {
+		route, err := r.toRegisterRouteConfig(context.Background(), modelRoute, backends)
+		require.NoError(t, err)
+
+		return lo.SliceToMap(route.GetTargets(), func(target *routev1alpha1.RouteTarget) (string, int32) {
+			return target.GetDestination().GetBackend(), target.GetDestination().GetWeight()
+		})
+	}
+
+	// Weights are kept before the rollout starts
+	assert.Equal(t, map[string]int32{"gpt-4o-stable": 3, "gpt-4o-canary": 1, "gpt-4o-azure": 1}, weights())
+
+	assert.Equal(t, time.Minute, r.reconcileCanary(modelRoute, time.Now()))
+	assert.Equal(t, map[string]int32{"gpt-4o-stable": 300, "gpt-4o-canary": 100, "gpt-4o-azure": 100}, weights())
+
+	modelRoute.Status.Canary.Weight = 0
+	modelRoute.Status.Canary.Phase = v1alpha1.CanaryPhaseRolledBack
+	assert.Equal(t, map[string]int32{"gpt-4o-stable": 400, "gpt-4o-azure": 100}, weights())
+	assert.Zero(t, r.reconcileCanary(modelRoute, time.Now()))
+
+	modelRoute.Spec.Route.Canary = nil
+	assert.Zero(t, r.reconcileCanary(modelRoute, time.Now()))
+	assert.Nil(t, modelRoute.Status.Canary)
+}
@@ -2,6 +2,8 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -9,13 +11,19 @@ import (
 	"time"
 
 	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"knoway.dev/api/clusters/v1alpha1"
+	filtersv1alpha1 "knoway.dev/api/filters/v1alpha1"
 	knowaydevv1alpha1 "knoway.dev/api/v1alpha1"
+	"knoway.dev/pkg/clusters/upstream"
+	"knoway.dev/pkg/object"
 )
 
 func isBackendDeleted(backend Backend) bool {
@@ -42,7 +50,7 @@ func shouldForceDeleteModelRoute(modelRoute *knowaydevv1alpha1.ModelRoute) bool
 	return modelRoute.ObjectMeta.GetDeletionTimestamp().Add(graceDeletePeriod).Before(time.Now())
 }
 
-func modelNameOrNamespacedName[B *knowaydevv1alpha1.LLMBackend | *knowaydevv1alpha1.ImageGenerationBackend | knowaydevv1alpha1.LLMBackend | knowaydevv1alpha1.ImageGenerationBackend](backend B) string {
+func modelNameOrNamespacedName[B *knowaydevv1alpha1.LLMBackend | *knowaydevv1alpha1.ImageGenerationBackend | *knowaydevv1alpha1.EmbeddingBackend | *knowaydevv1alpha1.RerankBackend | *knowaydevv1alpha1.VideoGenerationBackend | knowaydevv1alpha1.LLMBackend | knowaydevv1alpha1.ImageGenerationBackend | knowaydevv1alpha1.EmbeddingBackend | knowaydevv1alpha1.RerankBackend | knowaydevv1alpha1.VideoGenerationBackend](backend B) string {
 	switch v := any(backend).(type) {
 	case *knowaydevv1alpha1.LLMBackend:
 		if lo.IsNil(v) {
@@ -75,6 +83,54 @@ func modelNameOrNamespacedName[B *knowaydevv1alpha1.LLMBackend | *knowaydevv1alp
 			return *v.Spec.ModelName
 		}
 
+		return fmt.Sprintf("%s/%s", v.Namespace, v.Name)
+	case *knowaydevv1alpha1.EmbeddingBackend:
+		if lo.IsNil(v) {
+			return ""
+		}
+
+		if v.Spec.ModelName != nil {
+			return *v.Spec.ModelName
+		}
+
+		return fmt.Sprintf("%s/%s", v.Namespace, v.Name)
+	case knowaydevv1alpha1.EmbeddingBackend:
+		if v.Spec.ModelName != nil {
+			return *v.Spec.ModelName
+		}
+
+		return fmt.Sprintf("%s/%s", v.Namespace, v.Name)
+	case *knowaydevv1alpha1.RerankBackend:
+		if lo.IsNil(v) {
+			return ""
+		}
+
+		if v.Spec.ModelName != nil {
+			return *v.Spec.ModelName
+		}
+
+		return fmt.Sprintf("%s/%s", v.Namespace, v.Name)
+	case knowaydevv1alpha1.RerankBackend:
+		if v.Spec.ModelName != nil {
+			return *v.Spec.ModelName
+		}
+
+		return fmt.Sprintf("%s/%s", v.Namespace, v.Name)
+	case *knowaydevv1alpha1.VideoGenerationBackend:
+		if lo.IsNil(v) {
+			return ""
+		}
+
+		if v.Spec.ModelName != nil {
+			return *v.Spec.ModelName
+		}
+
+		return fmt.Sprintf("%s/%s", v.Namespace, v.Name)
+	case knowaydevv1alpha1.VideoGenerationBackend:
+		if v.Spec.ModelName != nil {
+			return *v.Spec.ModelName
+		}
+
 		return fmt.Sprintf("%s/%s", v.Namespace, v.Name)
 	default:
 		panic("unknown backend type :" + fmt.Sprintf("%T", backend))
@@ -201,6 +257,288 @@ func reconcileBackendPhase(backend Backend) {
 			return
 		}
 	}
+
+	switch {
+	case backend.IsDisabled():
+		backend.GetStatus().SetStatus(knowaydevv1alpha1.Disabled)
+	case isMaintenanceActive(backend.GetMaintenance(), time.Now()):
+		backend.GetStatus().SetStatus(knowaydevv1alpha1.Maintenance)
+	}
+}
+
+func isMaintenanceActive(maintenance *knowaydevv1alpha1.MaintenanceSpec, now time.Time) bool {
+	if maintenance == nil || !maintenance.Enabled {
+		return false
+	}
+
+	if maintenance.Start != nil && now.Before(maintenance.Start.Time) {
+		return false
+	}
+
+	if maintenance.End != nil && !now.Before(maintenance.End.Time) {
+		return false
+	}
+
+	return true
+}
+
+// maintenanceRequeueAfter returns how long to wait until the next boundary
+// of the maintenance window so that the status of the backend gets updated in
+// time, 0 if there is none ahead.
+func maintenanceRequeueAfter(maintenance *knowaydevv1alpha1.MaintenanceSpec, now time.Time) time.Duration {
+	if maintenance == nil || !maintenance.Enabled {
+		return 0
+	}
+
+	for _, boundary := range []*metav1.Time{maintenance.Start, maintenance.End} {
+		if boundary != nil && boundary.After(now) {
+			return boundary.Sub(now)
+		}
+	}
+
+	return 0
+}
+
+func maintenanceFromSpec(maintenance *knowaydevv1alpha1.MaintenanceSpec) *v1alpha1.ClusterMaintenance {
+	if maintenance == nil || !maintenance.Enabled {
+		return nil
+	}
+
+	clusterMaintenance := &v1alpha1.ClusterMaintenance{
+		Reason: maintenance.Reason,
+	}
+
+	if maintenance.Start != nil {
+		clusterMaintenance.Start = timestamppb.New(maintenance.Start.Time)
+	}
+	if maintenance.End != nil {
+		clusterMaintenance.End = timestamppb.New(maintenance.End.Time)
+	}
+
+	return clusterMaintenance
+}
+
+func circuitBreakerFromSpec(circuitBreaker *knowaydevv1alpha1.CircuitBreaker) *v1alpha1.ClusterCircuitBreaker {
+	if circuitBreaker == nil {
+		return nil
+	}
+
+	clusterCircuitBreaker := &v1alpha1.ClusterCircuitBreaker{
+		ConsecutiveErrors: uint32(max(circuitBreaker.ConsecutiveErrors, 0)),
+	}
+
+	if circuitBreaker.Cooldown > 0 {
+		clusterCircuitBreaker.Cooldown = durationpb.New(time.Duration(circuitBreaker.Cooldown) * time.Second)
+	}
+
+	return clusterCircuitBreaker
+}
+
+func connectionFromSpec(connection *knowaydevv1alpha1.Connection) *v1alpha1.ClusterConnection {
+	if connection == nil {
+		return nil
+	}
+
+	clusterConnection := &v1alpha1.ClusterConnection{
+		PinAddresses:        connection.PinAddresses,
+		MaxIdleConns:        uint32(max(connection.MaxIdleConns, 0)),
+		MaxIdleConnsPerHost: uint32(max(connection.MaxIdleConnsPerHost, 0)),
+		DisableHTTP2:        connection.DisableHTTP2,
+	}
+
+	clusterConnection.DnsRefreshInterval = durationFromSeconds(connection.DNSRefreshInterval)
+	clusterConnection.DialTimeout = durationFromSeconds(connection.DialTimeout)
+	clusterConnection.ResponseHeaderTimeout = durationFromSeconds(connection.ResponseHeaderTimeout)
+	clusterConnection.KeepAlive = durationFromSeconds(connection.KeepAlive)
+	clusterConnection.IdleConnTimeout = durationFromSeconds(connection.IdleConnTimeout)
+
+	if connection.TLSSessionCacheSize != nil {
+		clusterConnection.TlsSessionCacheSize = lo.ToPtr(uint32(max(*connection.TLSSessionCacheSize, 0)))
+	}
+
+	return clusterConnection
+}
+
+func deadlineHeaderFromSpec(deadlineHeader *knowaydevv1alpha1.DeadlineHeader) *v1alpha1.Upstream_DeadlineHeader {
+	if deadlineHeader == nil {
+		return nil
+	}
+
+	return &v1alpha1.Upstream_DeadlineHeader{
+		Name: deadlineHeader.Name,
+	}
+}
+
+// durationFromSeconds returns nil for the seconds not set.
+var promptTemplateSystemPromptModes = map[knowaydevv1alpha1.SystemPromptMode]filtersv1alpha1.PromptTemplateConfig_SystemPromptMode{
+	knowaydevv1alpha1.SystemPromptModeDefault:  filtersv1alpha1.PromptTemplateConfig_SYSTEM_PROMPT_MODE_DEFAULT,
+	knowaydevv1alpha1.SystemPromptModePrepend:  filtersv1alpha1.PromptTemplateConfig_SYSTEM_PROMPT_MODE_PREPEND,
+	knowaydevv1alpha1.SystemPromptModeOverride: filtersv1alpha1.PromptTemplateConfig_SYSTEM_PROMPT_MODE_OVERRIDE,
+}
+
+func promptTemplateFromSpec(template *knowaydevv1alpha1.PromptTemplate) *filtersv1alpha1.PromptTemplateConfig {
+	return &filtersv1alpha1.PromptTemplateConfig{
+		SystemPrompt:     template.SystemPrompt,
+		SystemPromptMode: promptTemplateSystemPromptModes[template.SystemPromptMode],
+		UserPrefix:       template.UserPrefix,
+		UserSuffix:       template.UserSuffix,
+		Variables:        template.Variables,
+		DateFormat:       template.DateFormat,
+	}
+}
+
+func durationFromSeconds(seconds int32) *durationpb.Duration {
+	if seconds <= 0 {
+		return nil
+	}
+
+	return durationpb.New(time.Duration(seconds) * time.Second)
+}
+
+func meteringExpressionsFromSpec(expressions []knowaydevv1alpha1.MeteringExpression) []*v1alpha1.ClusterMeteringPolicy_Expression {
+	return lo.Map(expressions, func(expr knowaydevv1alpha1.MeteringExpression, _ int) *v1alpha1.ClusterMeteringPolicy_Expression {
+		return &v1alpha1.ClusterMeteringPolicy_Expression{
+			Unit:       expr.Unit,
+			Expression: expr.Expression,
+		}
+	})
+}
+
+var modelCapabilities = map[knowaydevv1alpha1.ModelCapability]v1alpha1.ModelCapability{
+	knowaydevv1alpha1.ModelCapabilityStreaming:  v1alpha1.ModelCapability_STREAMING,
+	knowaydevv1alpha1.ModelCapabilityTools:      v1alpha1.ModelCapability_TOOLS,
+	knowaydevv1alpha1.ModelCapabilityVision:     v1alpha1.ModelCapability_VISION,
+	knowaydevv1alpha1.ModelCapabilityJSONSchema: v1alpha1.ModelCapability_JSON_SCHEMA,
+	knowaydevv1alpha1.ModelCapabilityReasoning:  v1alpha1.ModelCapability_REASONING,
+}
+
+func modelInfoFromSpec(modelInfo *knowaydevv1alpha1.ModelInfo) (*v1alpha1.ClusterModelInfo, error) {
+	if modelInfo == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	capabilities := make([]v1alpha1.ModelCapability, 0, len(modelInfo.Capabilities))
+
+	for _, capability := range lo.Uniq(modelInfo.Capabilities) {
+		c, ok := modelCapabilities[capability]
+		if !ok {
+			return nil, fmt.Errorf("unsupported model capability %s", capability)
+		}
+
+		capabilities = append(capabilities, c)
+	}
+
+	return &v1alpha1.ClusterModelInfo{
+		ContextWindow: lo.FromPtr(modelInfo.ContextWindow),
+		Capabilities:  capabilities,
+	}, nil
+}
+
+func bodyTransformFromSpec(transform *knowaydevv1alpha1.BodyTransform) (*filtersv1alpha1.BodyTransform, error) {
+	if transform == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	res := &filtersv1alpha1.BodyTransform{
+		Cel: lo.Map(transform.CEL, func(a knowaydevv1alpha1.CELAssignment, _ int) *filtersv1alpha1.BodyTransform_CELAssignment {
+			return &filtersv1alpha1.BodyTransform_CELAssignment{Path: a.Path, Expression: a.Expression}
+		}),
+	}
+
+	for _, op := range transform.JSONPatch {
+		operation := &filtersv1alpha1.BodyTransform_JSONPatchOperation{
+			Op:   string(op.Op),
+			Path: op.Path,
+			From: op.From,
+		}
+
+		if op.Value != nil && len(op.Value.Raw) > 0 {
+			var value any
+
+			err := json.Unmarshal(op.Value.Raw, &value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of json patch operation %s %s: %w", op.Op, op.Path, err)
+			}
+
+			operation.Value, err = structpb.NewValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of json patch operation %s %s: %w", op.Op, op.Path, err)
+			}
+		}
+
+		res.JsonPatch = append(res.JsonPatch, operation)
+	}
+
+	return res, nil
+}
+
+// customFilterFromSpec converts the custom filter to the body transform
+// cluster filter.
+func customFilterFromSpec(custom *knowaydevv1alpha1.CustomFilter) (*filtersv1alpha1.BodyTransformConfig, error) {
+	request, err := bodyTransformFromSpec(custom.Request)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request transform: %w", err)
+	}
+
+	response, err := bodyTransformFromSpec(custom.Response)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response transform: %w", err)
+	}
+
+	return &filtersv1alpha1.BodyTransformConfig{
+		Request:  request,
+		Response: response,
+	}, nil
+}
+
+func pricingFromSpec(pricing *knowaydevv1alpha1.Pricing) (*v1alpha1.ClusterPricing, error) {
+	if pricing == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	parsePrice := func(field string, price *string) (float64, error) {
+		if price == nil {
+			return 0, nil
+		}
+
+		value, err := strconv.ParseFloat(*price, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s price %q: %w", field, *price, err)
+		}
+
+		return value, nil
+	}
+
+	var err error
+
+	clusterPricing := &v1alpha1.ClusterPricing{
+		Currency: pricing.Currency,
+	}
+
+	if clusterPricing.PromptPer1KTokens, err = parsePrice("promptPer1KTokens", pricing.PromptPer1KTokens); err != nil {
+		return nil, err
+	}
+	if clusterPricing.CompletionPer1KTokens, err = parsePrice("completionPer1KTokens", pricing.CompletionPer1KTokens); err != nil {
+		return nil, err
+	}
+	if clusterPricing.PerAudioSecond, err = parsePrice("perAudioSecond", pricing.PerAudioSecond); err != nil {
+		return nil, err
+	}
+
+	for _, image := range pricing.Images {
+		price, err := parsePrice("image", &image.Price)
+		if err != nil {
+			return nil, err
+		}
+
+		clusterPricing.Images = append(clusterPricing.Images, &v1alpha1.ClusterPricing_Image{
+			Size:    image.Size,
+			Quality: image.Quality,
+			Price:   price,
+		})
+	}
+
+	return clusterPricing, nil
 }
 
 func reconcileModelRoutePhase(modelRoute *knowaydevv1alpha1.ModelRoute) {
@@ -310,6 +648,24 @@ func processStruct(v interface{}, params map[string]*structpb.Value) error {
 			continue
 		}
 
+		// Slices and maps of structs are not supported by structpb, convert
+		// them through JSON
+		if kind := reflect.ValueOf(fieldValue).Kind(); kind == reflect.Slice || kind == reflect.Map {
+			if reflect.ValueOf(fieldValue).Len() == 0 {
+				continue
+			}
+
+			bs, err := json.Marshal(fieldValue)
+			if err != nil {
+				return fmt.Errorf("failed to marshal field %s: %w", jsonKey, err)
+			}
+
+			err = json.Unmarshal(bs, &fieldValue)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal field %s: %w", jsonKey, err)
+			}
+		}
+
 		// Convert fieldValue to *structpb.Value
 		value, err := structpb.NewValue(fieldValue)
 		if err != nil {
@@ -342,6 +698,8 @@ func resolveHeaderFrom(ctx context.Context, c client.Client, namespace string, f
 		}
 
 		data = configMap.Data
+	case knowaydevv1alpha1.Vault:
+		// Resolved by the gateway, see externalHeadersFromSpec
 	default:
 		// noting
 	}
@@ -379,3 +737,181 @@ func headerFromSpec(ctx context.Context, c client.Client, namespace string, head
 
 	return hs, nil
 }
+
+// authFromSpec resolves the credentials of the upstream auth, credentials
+// kept in Vault are left to the gateway to resolve at runtime.
+func authFromSpec(ctx context.Context, c client.Client, namespace string, auths []knowaydevv1alpha1.UpstreamAuth) ([]*v1alpha1.Upstream_Auth, error) {
+	as := make([]*v1alpha1.Upstream_Auth, 0, len(auths))
+
+	for _, auth := range auths {
+		a := &v1alpha1.Upstream_Auth{
+			Name: auth.Name,
+		}
+
+		switch auth.Scheme {
+		case knowaydevv1alpha1.UpstreamAuthSchemeQueryParam:
+			a.Scheme = v1alpha1.Upstream_Auth_QUERY_PARAM
+		case knowaydevv1alpha1.UpstreamAuthSchemeCookie:
+			a.Scheme = v1alpha1.Upstream_Auth_COOKIE
+		default:
+			return nil, fmt.Errorf("unsupported upstream auth scheme %s", auth.Scheme)
+		}
+
+		switch from := auth.ValueFrom; {
+		case from.SecretKeyRef != nil && from.Vault != nil:
+			return nil, fmt.Errorf("upstream auth %s must reference exactly one of secretKeyRef and vault", auth.Name)
+		case from.SecretKeyRef != nil:
+			secret := &corev1.Secret{}
+
+			err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: from.SecretKeyRef.Name}, secret)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get Secret %s: %w", from.SecretKeyRef.Name, err)
+			}
+
+			value, ok := secret.Data[from.SecretKeyRef.Key]
+			if !ok {
+				if lo.FromPtr(from.SecretKeyRef.Optional) {
+					continue
+				}
+
+				return nil, fmt.Errorf("key %s not found in Secret %s", from.SecretKeyRef.Key, from.SecretKeyRef.Name)
+			}
+
+			a.Source = &v1alpha1.Upstream_Auth_Value{Value: string(value)}
+		case from.Vault != nil:
+			a.Source = &v1alpha1.Upstream_Auth_Vault_{
+				Vault: &v1alpha1.Upstream_Auth_Vault{
+					Path: from.Vault.Path,
+					Role: from.Vault.Role,
+					Key:  from.Vault.Key,
+				},
+			}
+		default:
+			return nil, fmt.Errorf("upstream auth %s must reference one of secretKeyRef and vault", auth.Name)
+		}
+
+		as = append(as, a)
+	}
+
+	return as, nil
+}
+
+// apiKeysFromSpec resolves the keys rotated between by the upstream requests,
+// nil if the upstream doesn't reference multiple keys.
+func apiKeysFromSpec(ctx context.Context, c client.Client, namespace string, apiKeys *knowaydevv1alpha1.UpstreamAPIKeys) (*filtersv1alpha1.UpstreamAPIKeysConfig, error) {
+	if apiKeys == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	keys := make([]*filtersv1alpha1.UpstreamAPIKeysConfig_Key, 0, len(apiKeys.SecretKeyRefs))
+
+	for _, ref := range apiKeys.SecretKeyRefs {
+		secret := &corev1.Secret{}
+
+		err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret)
+		if err != nil {
+			if lo.FromPtr(ref.Optional) && apierrors.IsNotFound(err) {
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to get Secret %s: %w", ref.Name, err)
+		}
+
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			if lo.FromPtr(ref.Optional) {
+				continue
+			}
+
+			return nil, fmt.Errorf("key %s not found in Secret %s", ref.Key, ref.Name)
+		}
+
+		keys = append(keys, &filtersv1alpha1.UpstreamAPIKeysConfig_Key{
+			Name:  ref.Name + "/" + ref.Key,
+			Value: string(value),
+		})
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("none of the upstream api keys is found")
+	}
+
+	cfg := &filtersv1alpha1.UpstreamAPIKeysConfig{
+		Keys:             keys,
+		Header:           apiKeys.Header,
+		Prefix:           apiKeys.Prefix,
+		FailureThreshold: uint32(max(apiKeys.FailureThreshold, 0)), //nolint:gosec
+	}
+
+	if apiKeys.Cooldown > 0 {
+		cfg.Cooldown = durationpb.New(time.Duration(apiKeys.Cooldown) * time.Second)
+	}
+
+	return cfg, nil
+}
+
+// pathsFromSpec returns the paths of the upstream keyed by the types of
+// requests, see upstream.BuildURL.
+func pathsFromSpec(paths *knowaydevv1alpha1.UpstreamPaths) map[string]string {
+	if paths == nil {
+		return nil
+	}
+
+	return lo.OmitByValues(map[string]string{
+		string(object.RequestTypeChatCompletions):  paths.ChatCompletions,
+		string(object.RequestTypeCompletions):      paths.Completions,
+		string(object.RequestTypeEmbeddings):       paths.Embeddings,
+		string(object.RequestTypeImageGenerations): paths.ImageGenerations,
+		string(object.RequestTypeModerations):      paths.Moderations,
+		upstream.PathModels:                        paths.Models,
+	}, []string{""})
+}
+
+// queryFromSpec returns the static query parameters of the upstream, the
+// ones referencing credentials are returned as auth of the QueryParam scheme.
+func queryFromSpec(query []knowaydevv1alpha1.UpstreamQueryParam) (map[string]string, []knowaydevv1alpha1.UpstreamAuth, error) {
+	static := make(map[string]string)
+	auths := make([]knowaydevv1alpha1.UpstreamAuth, 0)
+
+	for _, param := range query {
+		switch {
+		case param.Value != "" && param.ValueFrom != nil:
+			return nil, nil, fmt.Errorf("upstream query parameter %s must set exactly one of value and valueFrom", param.Name)
+		case param.ValueFrom != nil:
+			auths = append(auths, knowaydevv1alpha1.UpstreamAuth{
+				Scheme:    knowaydevv1alpha1.UpstreamAuthSchemeQueryParam,
+				Name:      param.Name,
+				ValueFrom: *param.ValueFrom,
+			})
+		default:
+			static[param.Name] = param.Value
+		}
+	}
+
+	return static, auths, nil
+}
+
+// externalHeadersFromSpec returns the headersFrom resolved by the gateway at
+// runtime instead of the controller, so that the values never get stored in
+// the cluster config.
+func externalHeadersFromSpec(headersFrom []knowaydevv1alpha1.HeaderFromSource) []*v1alpha1.Upstream_HeaderFrom {
+	hfs := make([]*v1alpha1.Upstream_HeaderFrom, 0)
+
+	for _, valueFrom := range headersFrom {
+		if valueFrom.RefType != knowaydevv1alpha1.Vault || valueFrom.Vault == nil {
+			continue
+		}
+
+		hfs = append(hfs, &v1alpha1.Upstream_HeaderFrom{
+			Prefix: valueFrom.Prefix,
+			Source: &v1alpha1.Upstream_HeaderFrom_Vault_{
+				Vault: &v1alpha1.Upstream_HeaderFrom_Vault{
+					Path: valueFrom.Vault.Path,
+					Role: valueFrom.Vault.Role,
+				},
+			},
+		})
+	}
+
+	return hfs
+}
@@ -1,13 +1,21 @@
 package controller
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/structpb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	clustersv1alpha1 "knoway.dev/api/clusters/v1alpha1"
+	filtersv1alpha1 "knoway.dev/api/filters/v1alpha1"
 	"knoway.dev/api/v1alpha1"
 )
 
@@ -50,6 +58,29 @@ func TestProcessStruct_OpenAIChatParams(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "Gemini safety settings",
+			input: &v1alpha1.ModelParams{
+				Gemini: &v1alpha1.GeminiParam{
+					SafetySettings: []v1alpha1.GeminiSafetySetting{
+						{Category: "HARM_CATEGORY_HATE_SPEECH", Threshold: "BLOCK_ONLY_HIGH"},
+					},
+				},
+			},
+			expected: map[string]*structpb.Value{
+				"safety_settings": structpb.NewListValue(&structpb.ListValue{
+					Values: []*structpb.Value{
+						structpb.NewStructValue(&structpb.Struct{
+							Fields: map[string]*structpb.Value{
+								"category":  structpb.NewStringValue("HARM_CATEGORY_HATE_SPEECH"),
+								"threshold": structpb.NewStringValue("BLOCK_ONLY_HIGH"),
+							},
+						}),
+					},
+				}),
+			},
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -69,3 +100,194 @@ func TestProcessStruct_OpenAIChatParams(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryFromSpec(t *testing.T) {
+	valueFrom := &v1alpha1.UpstreamAuthValueSource{
+		Vault: &v1alpha1.VaultKeySource{Path: "secret/data/upstream", Role: "knoway", Key: "apikey"},
+	}
+
+	static, auths, err := queryFromSpec([]v1alpha1.UpstreamQueryParam{
+		{Name: "api-version", Value: "2024-10-21"},
+		{Name: "key", ValueFrom: valueFrom},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"api-version": "2024-10-21"}, static)
+	assert.Equal(t, []v1alpha1.UpstreamAuth{
+		{Scheme: v1alpha1.UpstreamAuthSchemeQueryParam, Name: "key", ValueFrom: *valueFrom},
+	}, auths)
+
+	_, _, err = queryFromSpec([]v1alpha1.UpstreamQueryParam{
+		{Name: "key", Value: "value", ValueFrom: valueFrom},
+	})
+	require.Error(t, err)
+}
+
+func TestAPIKeysFromSpec(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "openai"},
+		Data:       map[string][]byte{"key-1": []byte("sk-1"), "key-2": []byte("sk-2")},
+	}).Build()
+
+	ref := func(name, key string, optional bool) corev1.SecretKeySelector {
+		return corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key, Optional: lo.ToPtr(optional)}
+	}
+
+	cfg, err := apiKeysFromSpec(ctx, c, "default", nil)
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+
+	cfg, err = apiKeysFromSpec(ctx, c, "default", &v1alpha1.UpstreamAPIKeys{
+		SecretKeyRefs: []corev1.SecretKeySelector{
+			ref("openai", "key-1", false),
+			ref("openai", "key-2", false),
+			ref("openai", "key-3", true),
+			ref("anthropic", "key-1", true),
+		},
+		Cooldown:         120,
+		FailureThreshold: 3,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"openai/key-1", "openai/key-2"}, lo.Map(cfg.GetKeys(), func(k *filtersv1alpha1.UpstreamAPIKeysConfig_Key, _ int) string {
+		return k.GetName()
+	}))
+	assert.Equal(t, "sk-2", cfg.GetKeys()[1].GetValue())
+	assert.Equal(t, 2*time.Minute, cfg.GetCooldown().AsDuration())
+	assert.Equal(t, uint32(3), cfg.GetFailureThreshold())
+
+	_, err = apiKeysFromSpec(ctx, c, "default", &v1alpha1.UpstreamAPIKeys{
+		SecretKeyRefs: []corev1.SecretKeySelector{ref("openai", "key-3", false)},
+	})
+	require.Error(t, err)
+
+	_, err = apiKeysFromSpec(ctx, c, "default", &v1alpha1.UpstreamAPIKeys{
+		SecretKeyRefs: []corev1.SecretKeySelector{ref("openai", "key-3", true)},
+	})
+	require.EqualError(t, err, "none of the upstream api keys is found")
+}
+
+func TestModelInfoFromSpec(t *testing.T) {
+	modelInfo, err := modelInfoFromSpec(nil)
+	require.NoError(t, err)
+	assert.Nil(t, modelInfo)
+
+	modelInfo, err = modelInfoFromSpec(&v1alpha1.ModelInfo{
+		ContextWindow: lo.ToPtr[int64](128000),
+		Capabilities:  []v1alpha1.ModelCapability{v1alpha1.ModelCapabilityTools, v1alpha1.ModelCapabilityVision, v1alpha1.ModelCapabilityTools},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(128000), modelInfo.GetContextWindow())
+	assert.Equal(t, []clustersv1alpha1.ModelCapability{clustersv1alpha1.ModelCapability_TOOLS, clustersv1alpha1.ModelCapability_VISION}, modelInfo.GetCapabilities())
+
+	_, err = modelInfoFromSpec(&v1alpha1.ModelInfo{Capabilities: []v1alpha1.ModelCapability{"Telepathy"}})
+	require.Error(t, err)
+}
+
+func TestPathsFromSpec(t *testing.T) {
+	assert.Nil(t, pathsFromSpec(nil))
+	assert.Equal(t, map[string]string{
+		"chat_completions": "/v2/{model}/chat",
+		"models":           "/v2/models",
+	}, pathsFromSpec(&v1alpha1.UpstreamPaths{
+		ChatCompletions: "/v2/{model}/chat",
+		Models:          "/v2/models",
+	}))
+}
+
+func TestConnectionFromSpec(t *testing.T) {
+	assert.Nil(t, connectionFromSpec(nil))
+
+	connection := connectionFromSpec(&v1alpha1.Connection{})
+	require.NotNil(t, connection)
+	assert.Nil(t, connection.TlsSessionCacheSize)
+	assert.Nil(t, connection.GetDnsRefreshInterval())
+	assert.Nil(t, connection.GetResponseHeaderTimeout())
+
+	connection = connectionFromSpec(&v1alpha1.Connection{
+		PinAddresses:          true,
+		DNSRefreshInterval:    60,
+		TLSSessionCacheSize:   lo.ToPtr(int32(0)),
+		MaxIdleConns:          8,
+		MaxIdleConnsPerHost:   4,
+		DialTimeout:           5,
+		ResponseHeaderTimeout: 120,
+		KeepAlive:             15,
+		IdleConnTimeout:       60,
+		DisableHTTP2:          true,
+	})
+	assert.True(t, connection.GetPinAddresses())
+	assert.Equal(t, time.Minute, connection.GetDnsRefreshInterval().AsDuration())
+	assert.Equal(t, lo.ToPtr(uint32(0)), connection.TlsSessionCacheSize)
+	assert.Equal(t, uint32(8), connection.GetMaxIdleConns())
+	assert.Equal(t, uint32(4), connection.GetMaxIdleConnsPerHost())
+	assert.Equal(t, 5*time.Second, connection.GetDialTimeout().AsDuration())
+	assert.Equal(t, 2*time.Minute, connection.GetResponseHeaderTimeout().AsDuration())
+	assert.Equal(t, 15*time.Second, connection.GetKeepAlive().AsDuration())
+	assert.Equal(t, time.Minute, connection.GetIdleConnTimeout().AsDuration())
+	assert.True(t, connection.GetDisableHTTP2())
+}
+
+func TestDeadlineHeaderFromSpec(t *testing.T) {
+	assert.Nil(t, deadlineHeaderFromSpec(nil))
+	assert.Empty(t, deadlineHeaderFromSpec(&v1alpha1.DeadlineHeader{}).GetName())
+	assert.Equal(t, "X-Request-Budget-Ms", deadlineHeaderFromSpec(&v1alpha1.DeadlineHeader{Name: "X-Request-Budget-Ms"}).GetName())
+}
+
+func TestPricingFromSpec(t *testing.T) {
+	pricing, err := pricingFromSpec(nil)
+	require.NoError(t, err)
+	assert.Nil(t, pricing)
+
+	pricing, err = pricingFromSpec(&v1alpha1.Pricing{
+		Currency:          "USD",
+		PromptPer1KTokens: lo.ToPtr("0.0025"),
+		PerAudioSecond:    lo.ToPtr("0.0001"),
+		Images: []v1alpha1.ImagePrice{
+			{Size: "1024x1024", Quality: "hd", Price: "0.08"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "USD", pricing.GetCurrency())
+	assert.InDelta(t, 0.0025, pricing.GetPromptPer1KTokens(), 1e-9)
+	assert.Zero(t, pricing.GetCompletionPer1KTokens())
+	assert.InDelta(t, 0.0001, pricing.GetPerAudioSecond(), 1e-9)
+	require.Len(t, pricing.GetImages(), 1)
+	assert.Equal(t, "1024x1024", pricing.GetImages()[0].GetSize())
+	assert.InDelta(t, 0.08, pricing.GetImages()[0].GetPrice(), 1e-9)
+
+	_, err = pricingFromSpec(&v1alpha1.Pricing{CompletionPer1KTokens: lo.ToPtr("free")})
+	require.EqualError(t, err, `invalid completionPer1KTokens price "free": strconv.ParseFloat: parsing "free": invalid syntax`)
+}
+
+func TestCustomFilterFromSpec(t *testing.T) {
+	custom, err := customFilterFromSpec(&v1alpha1.CustomFilter{
+		Request: &v1alpha1.BodyTransform{
+			JSONPatch: []v1alpha1.JSONPatchOperation{
+				{Op: "move", From: "/max_tokens", Path: "/max_completion_tokens"},
+				{Op: "add", Path: "/extra_body", Value: &runtime.RawExtension{Raw: []byte(`{"safe_mode": true}`)}},
+			},
+			CEL: []v1alpha1.CELAssignment{
+				{Path: "/user", Expression: "null"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, custom.GetResponse())
+	require.Len(t, custom.GetRequest().GetJsonPatch(), 2)
+	assert.Equal(t, "move", custom.GetRequest().GetJsonPatch()[0].GetOp())
+	assert.Equal(t, "/max_tokens", custom.GetRequest().GetJsonPatch()[0].GetFrom())
+	assert.Nil(t, custom.GetRequest().GetJsonPatch()[0].GetValue())
+	assert.Equal(t, map[string]any{"safe_mode": true}, custom.GetRequest().GetJsonPatch()[1].GetValue().AsInterface())
+	require.Len(t, custom.GetRequest().GetCel(), 1)
+	assert.Equal(t, "/user", custom.GetRequest().GetCel()[0].GetPath())
+
+	_, err = customFilterFromSpec(&v1alpha1.CustomFilter{
+		Response: &v1alpha1.BodyTransform{
+			JSONPatch: []v1alpha1.JSONPatchOperation{
+				{Op: "add", Path: "/a", Value: &runtime.RawExtension{Raw: []byte(`{`)}},
+			},
+		},
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,230 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	knowaydevv1alpha1 "knoway.dev/api/v1alpha1"
+)
+
+// condRouteConflict is set to True on both of the ModelRoute and the backends
+// whose direct routes are shadowed by it. A ModelRoute always takes
+// precedence over the direct route of a backend with the same model name,
+// see routemanager for details.
+const condRouteConflict = "routeConflict"
+
+func listBackends(ctx context.Context, c client.Reader) ([]Backend, error) {
+	llmBackends := &knowaydevv1alpha1.LLMBackendList{}
+	if err := c.List(ctx, llmBackends); err != nil {
+		return nil, fmt.Errorf("failed to list LLMBackend resources: %w", err)
+	}
+
+	imageGenerationBackends := &knowaydevv1alpha1.ImageGenerationBackendList{}
+	if err := c.List(ctx, imageGenerationBackends); err != nil {
+		return nil, fmt.Errorf("failed to list ImageGenerationBackend resources: %w", err)
+	}
+
+	embeddingBackends := &knowaydevv1alpha1.EmbeddingBackendList{}
+	if err := c.List(ctx, embeddingBackends); err != nil {
+		return nil, fmt.Errorf("failed to list EmbeddingBackend resources: %w", err)
+	}
+
+	rerankBackends := &knowaydevv1alpha1.RerankBackendList{}
+	if err := c.List(ctx, rerankBackends); err != nil {
+		return nil, fmt.Errorf("failed to list RerankBackend resources: %w", err)
+	}
+
+	videoGenerationBackends := &knowaydevv1alpha1.VideoGenerationBackendList{}
+	if err := c.List(ctx, videoGenerationBackends); err != nil {
+		return nil, fmt.Errorf("failed to list VideoGenerationBackend resources: %w", err)
+	}
+
+	backends := make([]Backend, 0, len(llmBackends.Items)+len(imageGenerationBackends.Items)+len(embeddingBackends.Items)+len(rerankBackends.Items)+len(videoGenerationBackends.Items))
+	for i := range llmBackends.Items {
+		backends = append(backends, BackendFromLLMBackend(&llmBackends.Items[i]))
+	}
+	for i := range imageGenerationBackends.Items {
+		backends = append(backends, BackendFromImageGenerationBackend(&imageGenerationBackends.Items[i]))
+	}
+	for i := range embeddingBackends.Items {
+		backends = append(backends, BackendFromEmbeddingBackend(&embeddingBackends.Items[i]))
+	}
+	for i := range rerankBackends.Items {
+		backends = append(backends, BackendFromRerankBackend(&rerankBackends.Items[i]))
+	}
+	for i := range videoGenerationBackends.Items {
+		backends = append(backends, BackendFromVideoGenerationBackend(&videoGenerationBackends.Items[i]))
+	}
+
+	return backends, nil
+}
+
+func isModelRouteTarget(modelRoute *knowaydevv1alpha1.ModelRoute, backend Backend) bool {
+	if modelRoute.Spec.Route == nil {
+		return false
+	}
+
+	meta := backend.GetObjectObjectMeta()
+
+	return lo.ContainsBy(modelRoute.Spec.Route.Targets, func(target knowaydevv1alpha1.ModelRouteRouteTarget) bool {
+		namespace := lo.CoalesceOrEmpty(target.Destination.Namespace, modelRoute.GetNamespace())
+		return namespace == meta.Namespace && target.Destination.Backend == meta.Name
+	})
+}
+
+// shadows reports whether the ModelRoute takes over the direct route of the
+// backend. Backends targeted by the ModelRoute are not considered shadowed
+// since they are still served through it.
+func shadows(modelRoute *knowaydevv1alpha1.ModelRoute, backend Backend) bool {
+	if isModelRouteDeleted(modelRoute) || isBackendDeleted(backend) || backend.IsDisabled() {
+		return false
+	}
+
+	return modelRoute.Spec.ModelName == backend.GetModelName() && !isModelRouteTarget(modelRoute, backend)
+}
+
+// reconcileBackendRouteConflict surfaces the ModelRoute shadowing the direct
+// route of the backend, if any.
+func reconcileBackendRouteConflict(ctx context.Context, c client.Reader, backend Backend) {
+	if isBackendDeleted(backend) {
+		return
+	}
+
+	modelRoutes := &knowaydevv1alpha1.ModelRouteList{}
+	if err := c.List(ctx, modelRoutes); err != nil {
+		log.Log.Error(err, "failed to list ModelRoute resources for conflict detection")
+		return
+	}
+
+	for i := range modelRoutes.Items {
+		modelRoute := &modelRoutes.Items[i]
+		if !shadows(modelRoute, backend) {
+			continue
+		}
+
+		setStatusCondition(backend, condRouteConflict, true, fmt.Sprintf(
+			"direct route of model '%s' is shadowed by ModelRoute %s, requests are served by the ModelRoute instead",
+			backend.GetModelName(), client.ObjectKeyFromObject(modelRoute)))
+
+		return
+	}
+}
+
+// reconcileModelRouteConflict surfaces the backends whose direct routes are
+// shadowed by the ModelRoute, if any.
+func reconcileModelRouteConflict(ctx context.Context, c client.Reader, modelRoute *knowaydevv1alpha1.ModelRoute) {
+	if isModelRouteDeleted(modelRoute) {
+		return
+	}
+
+	backends, err := listBackends(ctx, c)
+	if err != nil {
+		log.Log.Error(err, "failed to list backends for conflict detection")
+		return
+	}
+
+	shadowed := lo.FilterMap(backends, func(backend Backend, _ int) (string, bool) {
+		meta := backend.GetObjectObjectMeta()
+
+		return types.NamespacedName{Namespace: meta.Namespace, Name: meta.Name}.String(), shadows(modelRoute, backend)
+	})
+	if len(shadowed) == 0 {
+		return
+	}
+
+	setModelRouteStatusCondition(modelRoute, condRouteConflict, true, fmt.Sprintf(
+		"shadows the direct routes of model '%s' of backends %s",
+		modelRoute.Spec.ModelName, strings.Join(shadowed, ", ")))
+}
+
+// modelRouteToBackends enqueues the backends sharing the model name with the
+// ModelRoute so that their conflict conditions get updated.
+func modelRouteToBackends(c client.Reader, typ knowaydevv1alpha1.BackendType) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		modelRoute, ok := obj.(*knowaydevv1alpha1.ModelRoute)
+		if !ok {
+			return nil
+		}
+
+		backends, err := listBackends(ctx, c)
+		if err != nil {
+			log.Log.Error(err, "failed to list backends for ModelRoute", "name", modelRoute.GetName())
+			return nil
+		}
+
+		return lo.FilterMap(backends, func(backend Backend, _ int) (reconcile.Request, bool) {
+			meta := backend.GetObjectObjectMeta()
+
+			return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: meta.Namespace, Name: meta.Name}},
+				backend.GetType() == typ && backend.GetModelName() == modelRoute.Spec.ModelName
+		})
+	}
+}
+
+// backendToModelRoutes enqueues the ModelRoutes sharing the model name with
+// the backend so that their conflict conditions get updated, and the ones
+// mirroring or failing over to the backend so that they follow its health.
+func backendToModelRoutes(c client.Reader) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var backend Backend
+
+		switch v := obj.(type) {
+		case *knowaydevv1alpha1.LLMBackend:
+			backend = BackendFromLLMBackend(v)
+		case *knowaydevv1alpha1.ImageGenerationBackend:
+			backend = BackendFromImageGenerationBackend(v)
+		case *knowaydevv1alpha1.EmbeddingBackend:
+			backend = BackendFromEmbeddingBackend(v)
+		case *knowaydevv1alpha1.RerankBackend:
+			backend = BackendFromRerankBackend(v)
+		case *knowaydevv1alpha1.VideoGenerationBackend:
+			backend = BackendFromVideoGenerationBackend(v)
+		default:
+			return nil
+		}
+
+		modelRoutes := &knowaydevv1alpha1.ModelRouteList{}
+		if err := c.List(ctx, modelRoutes); err != nil {
+			log.Log.Error(err, "failed to list ModelRoute resources for backend", "name", obj.GetName())
+			return nil
+		}
+
+		return lo.FilterMap(modelRoutes.Items, func(modelRoute knowaydevv1alpha1.ModelRoute, _ int) (reconcile.Request, bool) {
+			return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: modelRoute.Namespace, Name: modelRoute.Name}},
+				modelRoute.Spec.ModelName == backend.GetModelName() || mirrorsTo(modelRoute, obj) || failsOverTo(modelRoute, obj)
+		})
+	}
+}
+
+// mirrorsTo reports whether the ModelRoute mirrors its requests to the
+// backend.
+func mirrorsTo(modelRoute knowaydevv1alpha1.ModelRoute, backend client.Object) bool {
+	mirror := modelRoute.Spec.Mirror
+	if mirror == nil {
+		return false
+	}
+
+	return mirror.Backend == backend.GetName() && lo.CoalesceOrEmpty(mirror.Namespace, modelRoute.Namespace) == backend.GetNamespace()
+}
+
+// failsOverTo reports whether the ModelRoute fails over any class of errors
+// to the backend.
+func failsOverTo(modelRoute knowaydevv1alpha1.ModelRoute, backend client.Object) bool {
+	if modelRoute.Spec.Fallback == nil {
+		return false
+	}
+
+	return lo.SomeBy(modelRoute.Spec.Fallback.OnErrors, func(onError knowaydevv1alpha1.ModelRouteErrorFallback) bool {
+		return lo.SomeBy(onError.Backends, func(item knowaydevv1alpha1.ModelRouteFallbackBackend) bool {
+			return item.Backend == backend.GetName() && lo.CoalesceOrEmpty(item.Namespace, modelRoute.Namespace) == backend.GetNamespace()
+		})
+	})
+}
@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DryRunAnnotation makes the controller evaluate the changes of a backend
+	// or a ModelRoute without applying them to the data plane when set to
+	// "true". The differences between the applied and the desired cluster
+	// and route configurations are recorded in the dryRun condition, remove
+	// the annotation to apply the changes.
+	//
+	// Nothing is applied while the annotation is set, including after the
+	// gateway restarts, so it's meant to be set only while validating edits.
+	DryRunAnnotation = "knoway.dev/dry-run"
+
+	condDryRun = "dryRun"
+
+	// maxDryRunDiffLength keeps the message well below the limit of 32768
+	// characters of conditions.
+	maxDryRunDiffLength = 8192
+)
+
+// secretConfigPath matches the fields of cluster and route configurations
+// holding credentials, which are never written into diffs as is.
+var secretConfigPath = regexp.MustCompile(`(^|\.)((headers|auth)\[\d+\]\.value|headers\.[^.\[]+)$`)
+
+func isDryRun(obj metav1.Object) bool {
+	return obj.GetDeletionTimestamp() == nil && obj.GetAnnotations()[DryRunAnnotation] == "true"
+}
+
+func hasFailedCondition(conditions []metav1.Condition) bool {
+	return lo.ContainsBy(conditions, func(cond metav1.Condition) bool {
+		return cond.Status == metav1.ConditionFalse
+	})
+}
+
+// dryRunSection is the diff of one of the configurations affected by a
+// change, e.g. the cluster or the route of a backend.
+type dryRunSection struct {
+	name    string
+	applied proto.Message
+	desired proto.Message
+}
+
+// dryRunMessage describes what applying the desired configurations would do,
+// in form of one section per configuration followed by its changes.
+func dryRunMessage(sections ...dryRunSection) (string, error) {
+	lines := make([]string, 0)
+
+	for _, section := range sections {
+		changes, err := diffConfig(section.applied, section.desired)
+		if err != nil {
+			return "", fmt.Errorf("failed to diff %s: %w", section.name, err)
+		}
+
+		appliedValid := section.applied != nil && section.applied.ProtoReflect().IsValid()
+		desiredValid := section.desired != nil && section.desired.ProtoReflect().IsValid()
+
+		switch {
+		case !appliedValid && desiredValid:
+			lines = append(lines, section.name+": would be added")
+		case appliedValid && !desiredValid:
+			lines = append(lines, section.name+": would be removed")
+		case len(changes) == 0:
+			lines = append(lines, section.name+": no changes")
+
+			continue
+		default:
+			lines = append(lines, section.name+": would be changed")
+		}
+
+		lines = append(lines, changes...)
+	}
+
+	message := strings.Join(lines, "\n")
+	if len(message) > maxDryRunDiffLength {
+		message = message[:maxDryRunDiffLength] + "\n... (truncated)"
+	}
+
+	return message, nil
+}
+
+// diffConfig returns the differences between the applied and the desired
+// configurations sorted by field path, one per line in form of
+// "+ path: value", "- path: value" or "~ path: applied -> desired". nil
+// messages are treated as empty.
+func diffConfig(applied, desired proto.Message) ([]string, error) {
+	appliedFields, err := flattenConfig(applied)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredFields, err := flattenConfig(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := lo.Uniq(append(lo.Keys(appliedFields), lo.Keys(desiredFields)...))
+	sort.Strings(paths)
+
+	changes := make([]string, 0)
+
+	for _, path := range paths {
+		appliedValue, inApplied := appliedFields[path]
+		desiredValue, inDesired := desiredFields[path]
+
+		switch {
+		case !inApplied:
+			changes = append(changes, fmt.Sprintf("+ %s: %s", path, desiredValue))
+		case !inDesired:
+			changes = append(changes, fmt.Sprintf("- %s: %s", path, appliedValue))
+		case appliedValue != desiredValue:
+			changes = append(changes, fmt.Sprintf("~ %s: %s -> %s", path, appliedValue, desiredValue))
+		}
+	}
+
+	return changes, nil
+}
+
+// flattenConfig returns the leaf fields of the message keyed by their paths,
+// e.g. upstream.headers[0].key, with JSON encoded values.
+func flattenConfig(msg proto.Message) (map[string]string, error) {
+	fields := make(map[string]string)
+
+	if msg == nil || !msg.ProtoReflect().IsValid() {
+		return fields, nil
+	}
+
+	bs, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+
+	err = json.Unmarshal(bs, &value)
+	if err != nil {
+		return nil, err
+	}
+
+	flattenValue("", value, fields)
+
+	return fields, nil
+}
+
+func flattenValue(path string, value any, fields map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			flattenValue(strings.TrimPrefix(path+"."+key, "."), item, fields)
+		}
+	case []any:
+		for i, item := range v {
+			flattenValue(fmt.Sprintf("%s[%d]", path, i), item, fields)
+		}
+	default:
+		bs, _ := json.Marshal(v)
+
+		if secretConfigPath.MatchString(path) {
+			// Changes of credentials are still visible by the digest
+			sum := sha256.Sum256(bs)
+			bs = []byte("<redacted:" + hex.EncodeToString(sum[:4]) + ">")
+		}
+
+		fields[path] = string(bs)
+	}
+}
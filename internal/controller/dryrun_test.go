@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	routemanager "knoway.dev/pkg/route/manager"
+)
+
+func TestDiffConfig(t *testing.T) {
+	applied := &v1alpha1.Cluster{
+		Name: "gpt-4o",
+		Upstream: &v1alpha1.Upstream{
+			Url:     "https://api.openai.com/v1",
+			Timeout: 30,
+			Headers: []*v1alpha1.Upstream_Header{
+				{Key: "Authorization", Value: "Bearer sk-old"},
+			},
+		},
+	}
+	desired := &v1alpha1.Cluster{
+		Name: "gpt-4o",
+		Upstream: &v1alpha1.Upstream{
+			Url: "https://gateway.example.com/v1",
+			Headers: []*v1alpha1.Upstream_Header{
+				{Key: "Authorization", Value: "Bearer sk-new"},
+			},
+			RemoveParamKeys: []string{"user"},
+		},
+	}
+
+	changes, err := diffConfig(applied, desired)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+
+	assert.Regexp(t, `^~ upstream\.headers\[0\]\.value: <redacted:[0-9a-f]{8}> -> <redacted:[0-9a-f]{8}>$`, changes[0])
+	assert.NotContains(t, changes[0], "sk-")
+	assert.Equal(t, `+ upstream.removeParamKeys[0]: "user"`, changes[1])
+	assert.Equal(t, `- upstream.timeout: 30`, changes[2])
+	assert.Equal(t, `~ upstream.url: "https://api.openai.com/v1" -> "https://gateway.example.com/v1"`, changes[3])
+
+	changes, err = diffConfig(applied, applied)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestDryRunMessage(t *testing.T) {
+	var removed *v1alpha1.Cluster
+
+	message, err := dryRunMessage(
+		dryRunSection{name: "cluster gpt-4o", applied: &v1alpha1.Cluster{Name: "gpt-4o"}, desired: removed},
+		dryRunSection{name: "route gpt-4o", applied: routemanager.InitDirectModelRoute("gpt-4o"), desired: routemanager.InitDirectModelRoute("gpt-4o")},
+		dryRunSection{name: "route gpt-4o-mini", desired: routemanager.InitDirectModelRoute("gpt-4o-mini")},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, `cluster gpt-4o: would be removed
+- name: "gpt-4o"
+route gpt-4o: no changes
+route gpt-4o-mini: would be added
++ matches[0].model.exact: "gpt-4o-mini"
++ name: "gpt-4o-mini"
++ targets[0].destination.cluster: "gpt-4o-mini"`, message)
+}
+
+func TestIsDryRun(t *testing.T) {
+	obj := &metav1.ObjectMeta{Annotations: map[string]string{DryRunAnnotation: "true"}}
+	assert.True(t, isDryRun(obj))
+
+	now := metav1.Now()
+	obj.DeletionTimestamp = &now
+	assert.False(t, isDryRun(obj), "deletion is never dry-run")
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	knowaydevv1alpha1 "knoway.dev/api/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+)
+
+// EmbeddingBackendReconciler reconciles a EmbeddingBackend object
+type EmbeddingBackendReconciler struct {
+	client.Client
+
+	Scheme    *runtime.Scheme
+	LifeCycle bootkit.LifeCycle
+	// HistoryLimit is the number of revisions kept for each backend
+	HistoryLimit int
+}
+
+// +kubebuilder:rbac:groups=llm.knoway.dev,resources=embeddingbackends,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=llm.knoway.dev,resources=embeddingbackends/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=llm.knoway.dev,resources=embeddingbackends/finalizers,verbs=update
+
+var embeddingBackendKind = backendKind[*knowaydevv1alpha1.EmbeddingBackend]{
+	name:        "EmbeddingBackend",
+	typ:         knowaydevv1alpha1.BackendTypeEmbedding,
+	clusterType: v1alpha1.ClusterType_EMBEDDING,
+	newObject: func() *knowaydevv1alpha1.EmbeddingBackend {
+		return &knowaydevv1alpha1.EmbeddingBackend{}
+	},
+	list: func(ctx context.Context, c client.Reader) ([]*knowaydevv1alpha1.EmbeddingBackend, error) {
+		backends := &knowaydevv1alpha1.EmbeddingBackendList{}
+		if err := c.List(ctx, backends); err != nil {
+			return nil, err
+		}
+
+		return lo.ToSlicePtr(backends.Items), nil
+	},
+	toBackend: BackendFromEmbeddingBackend,
+	copyStatus: func(dst, src *knowaydevv1alpha1.EmbeddingBackend) {
+		dst.Status = src.Status
+	},
+	spec: func(backend *knowaydevv1alpha1.EmbeddingBackend) backendSpec {
+		return backendSpec{
+			provider:        backend.Spec.Provider,
+			baseURL:         backend.Spec.Upstream.BaseURL,
+			headers:         backend.Spec.Upstream.Headers,
+			headersFrom:     backend.Spec.Upstream.HeadersFrom,
+			auth:            backend.Spec.Upstream.Auth,
+			timeout:         backend.Spec.Upstream.Timeout,
+			removeParamKeys: backend.Spec.Upstream.RemoveParamKeys,
+			circuitBreaker:  backend.Spec.Upstream.CircuitBreaker,
+			connection:      backend.Spec.Upstream.Connection,
+			filters: lo.Map(backend.Spec.Filters, func(f knowaydevv1alpha1.EmbeddingFilter, _ int) knowaydevv1alpha1.FilterConfig {
+				return f.FilterConfig
+			}),
+			meteringExpressions: lo.FromPtr(backend.Spec.MeteringPolicy).Expressions,
+			pricing:             backend.Spec.Pricing,
+		}
+	},
+	params: toEmbeddingBackendParams,
+}
+
+func (r *EmbeddingBackendReconciler) backendReconciler() *backendReconciler[*knowaydevv1alpha1.EmbeddingBackend] {
+	return newBackendReconciler(r.Client, embeddingBackendKind, r.LifeCycle, r.HistoryLimit)
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// TODO(user): Modify the Reconcile function to compare the state specified by
+// the EmbeddingBackend object against the actual cluster state, and then
+// perform operations to make the cluster state reflect the state specified by
+// the user.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.4/pkg/reconcile
+func (r *EmbeddingBackendReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return r.backendReconciler().Reconcile(ctx, req)
+}
+
+func parseEmbeddingBackendModelParams(modelParams *knowaydevv1alpha1.EmbeddingModelParams, params map[string]*structpb.Value) error {
+	if modelParams == nil {
+		return nil
+	}
+
+	modelTypes := map[string]interface{}{
+		"OpenAI": modelParams.OpenAI,
+	}
+
+	for name, model := range modelTypes {
+		if !lo.IsNil(model) {
+			err := processStruct(model, params)
+			if err != nil {
+				return fmt.Errorf("error processing %s params: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func toEmbeddingBackendParams(backed *knowaydevv1alpha1.EmbeddingBackend) (map[string]*structpb.Value, map[string]*structpb.Value, error) {
+	var defaultParams, overrideParams map[string]*structpb.Value
+
+	if backed == nil {
+		return nil, nil, nil
+	}
+
+	defaultParams, overrideParams = make(map[string]*structpb.Value), make(map[string]*structpb.Value)
+
+	err := parseEmbeddingBackendModelParams(backed.Spec.Upstream.DefaultParams, defaultParams)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error processing DefaultParams: %w", err)
+	}
+
+	err = parseEmbeddingBackendModelParams(backed.Spec.Upstream.OverrideParams, overrideParams)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error processing OverrideParams: %w", err)
+	}
+
+	return defaultParams, overrideParams, nil
+}
+
+func (r *EmbeddingBackendReconciler) toRegisterClusterConfig(ctx context.Context, backend *knowaydevv1alpha1.EmbeddingBackend) (*v1alpha1.Cluster, error) {
+	return r.backendReconciler().toClusterConfig(ctx, backend)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EmbeddingBackendReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return r.backendReconciler().setupWithManager(mgr, r)
+}
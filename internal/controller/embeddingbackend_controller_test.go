@@ -0,0 +1,79 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clustersv1alpha1 "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/v1alpha1"
+)
+
+func TestEmbeddingBackendReconciler_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := NewFakeClientWithStatus()
+
+	resource := &v1alpha1.EmbeddingBackend{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "text-embedding",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.EmbeddingBackendSpec{
+			ModelName: lo.ToPtr("text-embedding-3-small"),
+			Provider:  v1alpha1.ProviderOpenAI,
+			Upstream: v1alpha1.EmbeddingBackendUpstream{
+				BaseURL: "https://api.openai.com/v1",
+				OverrideParams: &v1alpha1.EmbeddingModelParams{
+					OpenAI: &v1alpha1.OpenAIEmbeddingParam{
+						Model:          "text-embedding-3-small",
+						EncodingFormat: lo.ToPtr("base64"),
+						Dimensions:     lo.ToPtr(256),
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, fakeClient.Create(ctx, resource))
+
+	reconciler := &EmbeddingBackendReconciler{
+		Client: fakeClient,
+	}
+
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{
+		NamespacedName: client.ObjectKeyFromObject(resource),
+	})
+	require.NoError(t, err)
+
+	clusterCfg, err := reconciler.toRegisterClusterConfig(ctx, resource)
+	require.NoError(t, err)
+
+	assert.Equal(t, clustersv1alpha1.ClusterType_EMBEDDING, clusterCfg.GetType())
+	assert.Equal(t, clustersv1alpha1.ClusterProvider_OPEN_AI, clusterCfg.GetProvider())
+	assert.Equal(t, "text-embedding-3-small", clusterCfg.GetName())
+
+	overrideParams := clusterCfg.GetUpstream().GetOverrideParams()
+	assert.Equal(t, "base64", overrideParams["encoding_format"].GetStringValue())
+	assert.InDelta(t, 256, overrideParams["dimensions"].GetNumberValue(), 0.0001)
+}
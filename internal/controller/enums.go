@@ -12,14 +12,24 @@ import (
 
 var (
 	mapClusterProviderBackendProvider = map[v1alpha1.ClusterProvider]knowaydevv1alpha1.Provider{
-		v1alpha1.ClusterProvider_OPEN_AI: knowaydevv1alpha1.ProviderOpenAI,
-		v1alpha1.ClusterProvider_VLLM:    knowaydevv1alpha1.ProviderVLLM,
-		v1alpha1.ClusterProvider_OLLAMA:  knowaydevv1alpha1.ProviderOllama,
+		v1alpha1.ClusterProvider_OPEN_AI:       knowaydevv1alpha1.ProviderOpenAI,
+		v1alpha1.ClusterProvider_VLLM:          knowaydevv1alpha1.ProviderVLLM,
+		v1alpha1.ClusterProvider_OLLAMA:        knowaydevv1alpha1.ProviderOllama,
+		v1alpha1.ClusterProvider_AZURE_OPEN_AI: knowaydevv1alpha1.ProviderAzureOpenAI,
+		v1alpha1.ClusterProvider_AWS_BEDROCK:   knowaydevv1alpha1.ProviderAWSBedrock,
+		v1alpha1.ClusterProvider_GOOGLE_GEMINI: knowaydevv1alpha1.ProviderGemini,
+		v1alpha1.ClusterProvider_ANTHROPIC:     knowaydevv1alpha1.ProviderAnthropic,
+		v1alpha1.ClusterProvider_RUNWAY:        knowaydevv1alpha1.ProviderRunway,
 	}
 	mapBackendProviderClusterProvider = map[knowaydevv1alpha1.Provider]v1alpha1.ClusterProvider{
-		knowaydevv1alpha1.ProviderOpenAI: v1alpha1.ClusterProvider_OPEN_AI,
-		knowaydevv1alpha1.ProviderVLLM:   v1alpha1.ClusterProvider_VLLM,
-		knowaydevv1alpha1.ProviderOllama: v1alpha1.ClusterProvider_OLLAMA,
+		knowaydevv1alpha1.ProviderOpenAI:      v1alpha1.ClusterProvider_OPEN_AI,
+		knowaydevv1alpha1.ProviderVLLM:        v1alpha1.ClusterProvider_VLLM,
+		knowaydevv1alpha1.ProviderOllama:      v1alpha1.ClusterProvider_OLLAMA,
+		knowaydevv1alpha1.ProviderAzureOpenAI: v1alpha1.ClusterProvider_AZURE_OPEN_AI,
+		knowaydevv1alpha1.ProviderAWSBedrock:  v1alpha1.ClusterProvider_AWS_BEDROCK,
+		knowaydevv1alpha1.ProviderGemini:      v1alpha1.ClusterProvider_GOOGLE_GEMINI,
+		knowaydevv1alpha1.ProviderAnthropic:   v1alpha1.ClusterProvider_ANTHROPIC,
+		knowaydevv1alpha1.ProviderRunway:      v1alpha1.ClusterProvider_RUNWAY,
 	}
 )
 
@@ -60,10 +70,12 @@ var (
 	mapClusterLoadBalancePolicyBackendLoadBalancePolicy = map[knowaydevv1alpha1.LoadBalancePolicy]routev1alpha1.LoadBalancePolicy{
 		knowaydevv1alpha1.LoadBalancePolicyWeightedLeastRequest: routev1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_LEAST_REQUEST,
 		knowaydevv1alpha1.LoadBalancePolicyWeightedRoundRobin:   routev1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_ROUND_ROBIN,
+		knowaydevv1alpha1.LoadBalancePolicyWeightedLeastLatency: routev1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_LEAST_LATENCY,
 	}
 	mapBackendLoadBalancePolicyClusterLoadBalancePolicy = map[routev1alpha1.LoadBalancePolicy]knowaydevv1alpha1.LoadBalancePolicy{
 		routev1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_LEAST_REQUEST: knowaydevv1alpha1.LoadBalancePolicyWeightedLeastRequest,
 		routev1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_ROUND_ROBIN:   knowaydevv1alpha1.LoadBalancePolicyWeightedRoundRobin,
+		routev1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_LEAST_LATENCY: knowaydevv1alpha1.LoadBalancePolicyWeightedLeastLatency,
 	}
 )
 
@@ -75,6 +87,15 @@ func MapCRDLoadBalancePolicyModelConfigLoadBalancePolicy(policy knowaydevv1alpha
 	return mapClusterLoadBalancePolicyBackendLoadBalancePolicy[policy]
 }
 
+var mapClusterLoadBalancePolicyEndpointLoadBalancePolicy = map[knowaydevv1alpha1.EndpointLoadBalancePolicy]v1alpha1.LoadBalancePolicy{
+	knowaydevv1alpha1.EndpointLoadBalancePolicyRoundRobin:   v1alpha1.LoadBalancePolicy_ROUND_ROBIN,
+	knowaydevv1alpha1.EndpointLoadBalancePolicyLeastLatency: v1alpha1.LoadBalancePolicy_LEAST_LATENCY,
+}
+
+func MapEndpointLoadBalancePolicyClusterLoadBalancePolicy(policy knowaydevv1alpha1.EndpointLoadBalancePolicy) v1alpha1.LoadBalancePolicy {
+	return mapClusterLoadBalancePolicyEndpointLoadBalancePolicy[policy]
+}
+
 var (
 	mapClusterRateLimitBaseOnBackendRateLimitBaseOn = map[knowaydevv1alpha1.RateLimitBasedOn]filtersv1alpha1.RateLimitBaseOn{
 		knowaydevv1alpha1.ModelRouteRateLimitBasedOnUserID: filtersv1alpha1.RateLimitBaseOn_USER_ID,
@@ -93,3 +114,54 @@ func MapConfigRateLimitBaseOnCRDRateLimitBaseOn(baseOn filtersv1alpha1.RateLimit
 func MapCRDRateLimitBaseOnConfigRateLimitBaseOn(baseOn knowaydevv1alpha1.RateLimitBasedOn) filtersv1alpha1.RateLimitBaseOn {
 	return mapClusterRateLimitBaseOnBackendRateLimitBaseOn[baseOn]
 }
+
+var (
+	mapCRDRateLimitUnitConfigRateLimitUnit = map[knowaydevv1alpha1.RateLimitUnit]filtersv1alpha1.RateLimitUnit{
+		knowaydevv1alpha1.RateLimitUnitRequests: filtersv1alpha1.RateLimitUnit_REQUESTS,
+		knowaydevv1alpha1.RateLimitUnitTokens:   filtersv1alpha1.RateLimitUnit_TOKENS,
+	}
+	mapConfigRateLimitUnitCRDRateLimitUnit = map[filtersv1alpha1.RateLimitUnit]knowaydevv1alpha1.RateLimitUnit{
+		filtersv1alpha1.RateLimitUnit_REQUESTS: knowaydevv1alpha1.RateLimitUnitRequests,
+		filtersv1alpha1.RateLimitUnit_TOKENS:   knowaydevv1alpha1.RateLimitUnitTokens,
+	}
+)
+
+func MapConfigRateLimitUnitCRDRateLimitUnit(unit filtersv1alpha1.RateLimitUnit) knowaydevv1alpha1.RateLimitUnit {
+	return mapConfigRateLimitUnitCRDRateLimitUnit[unit]
+}
+
+func MapCRDRateLimitUnitConfigRateLimitUnit(unit knowaydevv1alpha1.RateLimitUnit) filtersv1alpha1.RateLimitUnit {
+	return mapCRDRateLimitUnitConfigRateLimitUnit[unit]
+}
+
+var (
+	mapCRDConcurrencyLimitBasedOnConfigConcurrencyLimitBaseOn = map[knowaydevv1alpha1.ConcurrencyLimitBasedOn]filtersv1alpha1.ConcurrencyLimitBaseOn{
+		knowaydevv1alpha1.ConcurrencyLimitBasedOnRoute:  filtersv1alpha1.ConcurrencyLimitBaseOn_PER_ROUTE,
+		knowaydevv1alpha1.ConcurrencyLimitBasedOnUserID: filtersv1alpha1.ConcurrencyLimitBaseOn_PER_USER,
+		knowaydevv1alpha1.ConcurrencyLimitBasedOnAPIKey: filtersv1alpha1.ConcurrencyLimitBaseOn_PER_API_KEY,
+	}
+	mapConfigConcurrencyLimitBaseOnCRDConcurrencyLimitBasedOn = map[filtersv1alpha1.ConcurrencyLimitBaseOn]knowaydevv1alpha1.ConcurrencyLimitBasedOn{
+		filtersv1alpha1.ConcurrencyLimitBaseOn_PER_ROUTE:   knowaydevv1alpha1.ConcurrencyLimitBasedOnRoute,
+		filtersv1alpha1.ConcurrencyLimitBaseOn_PER_USER:    knowaydevv1alpha1.ConcurrencyLimitBasedOnUserID,
+		filtersv1alpha1.ConcurrencyLimitBaseOn_PER_API_KEY: knowaydevv1alpha1.ConcurrencyLimitBasedOnAPIKey,
+	}
+)
+
+func MapConfigConcurrencyLimitBaseOnCRDConcurrencyLimitBasedOn(baseOn filtersv1alpha1.ConcurrencyLimitBaseOn) knowaydevv1alpha1.ConcurrencyLimitBasedOn {
+	return mapConfigConcurrencyLimitBaseOnCRDConcurrencyLimitBasedOn[baseOn]
+}
+
+func MapCRDConcurrencyLimitBasedOnConfigConcurrencyLimitBaseOn(baseOn knowaydevv1alpha1.ConcurrencyLimitBasedOn) filtersv1alpha1.ConcurrencyLimitBaseOn {
+	return mapCRDConcurrencyLimitBasedOnConfigConcurrencyLimitBaseOn[baseOn]
+}
+
+var (
+	mapCRDCacheModeConfigResponseCacheMode = map[knowaydevv1alpha1.CacheMode]filtersv1alpha1.ResponseCacheMode{
+		knowaydevv1alpha1.CacheModeExact:    filtersv1alpha1.ResponseCacheMode_EXACT,
+		knowaydevv1alpha1.CacheModeSemantic: filtersv1alpha1.ResponseCacheMode_SEMANTIC,
+	}
+)
+
+func MapCRDCacheModeConfigResponseCacheMode(mode knowaydevv1alpha1.CacheMode) filtersv1alpha1.ResponseCacheMode {
+	return mapCRDCacheModeConfigResponseCacheMode[mode]
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	knowaydevv1alpha1 "knoway.dev/api/v1alpha1"
+	"knoway.dev/pkg/clusters/health"
+)
+
+// upstreamHealthChecker tracks the health of the upstreams of backends across
+// reconciles, the timeout of each probe is set by the health check.
+var upstreamHealthChecker = health.NewChecker(&http.Client{})
+
+func healthCheckKey(kind string, obj client.Object) string {
+	return kind + "/" + client.ObjectKeyFromObject(obj).String()
+}
+
+func healthCheckOptions(healthCheck *knowaydevv1alpha1.HealthCheck) health.Options {
+	return health.Options{
+		Path:             healthCheck.Path,
+		Interval:         time.Duration(healthCheck.Interval) * time.Second,
+		Timeout:          time.Duration(healthCheck.Timeout) * time.Second,
+		FailureThreshold: int(healthCheck.FailureThreshold),
+		SuccessThreshold: int(healthCheck.SuccessThreshold),
+	}
+}
+
+func healthCheckInterval(healthCheck *knowaydevv1alpha1.HealthCheck) time.Duration {
+	return lo.CoalesceOrEmpty(time.Duration(healthCheck.Interval)*time.Second, health.DefaultInterval)
+}
+
+// isUpstreamUnhealthy reports whether the health check of the backend failed,
+// such backends are removed from routing.
+func isUpstreamUnhealthy(backend Backend) bool {
+	return lo.ContainsBy(backend.GetStatus().GetConditions(), func(cond metav1.Condition) bool {
+		return cond.Type == condUpstreamHealthy && cond.Status == metav1.ConditionFalse
+	})
+}
+
+func setEndpoints(backend Backend, endpoints []string) {
+	if status, ok := backend.GetStatus().(EndpointsStatusable); ok {
+		status.SetEndpoints(endpoints)
+	}
+}
+
+func endpointsEqual(status1, status2 Statusable[knowaydevv1alpha1.StatusEnum]) bool {
+	endpoints1, ok1 := status1.(EndpointsStatusable)
+	endpoints2, ok2 := status2.(EndpointsStatusable)
+
+	if !ok1 || !ok2 {
+		return ok1 == ok2
+	}
+
+	return slices.Equal(endpoints1.GetEndpoints(), endpoints2.GetEndpoints())
+}
+
+// minRequeueAfter returns the shorter of the durations, zero means no
+// requeue.
+func minRequeueAfter(after1, after2 time.Duration) time.Duration {
+	if after1 == 0 || (after2 != 0 && after2 < after1) {
+		return after2
+	}
+
+	return after1
+}
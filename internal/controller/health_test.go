@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	"knoway.dev/api/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	clustermanager "knoway.dev/pkg/clusters/manager"
+)
+
+func TestBackendReconciler_UpstreamHealthy(t *testing.T) {
+	ctx := context.Background()
+
+	var healthy atomic.Bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	r := newBackendReconciler(fake.NewClientBuilder().WithScheme(scheme).Build(), llmBackendKind, bootkit.NewEmptyLifeCycle(), 0)
+
+	backend := &v1alpha1.LLMBackend{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "health-check"},
+		Spec: v1alpha1.LLMBackendSpec{
+			ModelName: lo.ToPtr("health-check"),
+			Upstream: v1alpha1.BackendUpstream{
+				BaseURL:     server.URL + "/v1",
+				HealthCheck: &v1alpha1.HealthCheck{FailureThreshold: 1},
+			},
+		},
+	}
+	defer upstreamHealthChecker.Forget(healthCheckKey(llmBackendKind.name, backend))
+
+	healthy.Store(true)
+	require.NoError(t, r.reconcileUpstreamHealthy(ctx, backend))
+	assert.Equal(t, []string{server.Listener.Addr().String()}, backend.Status.Endpoints)
+
+	require.NoError(t, r.reconcileRegister(ctx, backend))
+
+	_, ok := clustermanager.GetClusterConfig("health-check")
+	require.True(t, ok)
+
+	// Probe again regardless of the interval
+	upstreamHealthChecker.Forget(healthCheckKey(llmBackendKind.name, backend))
+	healthy.Store(false)
+
+	err := r.reconcileUpstreamHealthy(ctx, backend)
+	require.ErrorContains(t, err, "upstream is unhealthy after 1 consecutive failed probes")
+	assert.Empty(t, backend.Status.Endpoints)
+
+	_, ok = clustermanager.GetClusterConfig("health-check")
+	assert.False(t, ok)
+
+	t.Run("disabled", func(t *testing.T) {
+		backend := backend.DeepCopy()
+		backend.Spec.Upstream.HealthCheck = nil
+		backend.Status.Endpoints = []string{"stale"}
+
+		require.NoError(t, r.reconcileUpstreamHealthy(ctx, backend))
+		assert.Empty(t, backend.Status.Endpoints)
+	})
+}
+
+func TestModelRouteReconciler_SkipsUnhealthyTargets(t *testing.T) {
+	r := &ModelRouteReconciler{}
+
+	newBackend := func(name string, healthy bool) Backend {
+		backend := BackendFromLLMBackend(&v1alpha1.LLMBackend{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       v1alpha1.LLMBackendSpec{ModelName: lo.ToPtr(name)},
+		})
+		setStatusCondition(backend, condUpstreamHealthy, healthy, "")
+
+		return backend
+	}
+
+	targets := []*routev1alpha1.RouteTarget{
+		{Destination: &routev1alpha1.RouteDestination{Namespace: "default", Backend: "healthy"}},
+		{Destination: &routev1alpha1.RouteDestination{Namespace: "default", Backend: "unhealthy"}},
+	}
+
+	mapped := r.mapModelRouteTargetsToBackends(targets, map[string]Backend{
+		"default/healthy":   newBackend("healthy", true),
+		"default/unhealthy": newBackend("unhealthy", false),
+	})
+
+	require.Len(t, mapped, 1)
+	assert.Equal(t, "healthy", mapped[0].GetDestination().GetCluster())
+}
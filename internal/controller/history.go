@@ -0,0 +1,240 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	knowaydevv1alpha1 "knoway.dev/api/v1alpha1"
+)
+
+const (
+	DefaultBackendHistoryLimit = 10
+
+	backendHistoryDataKey       = "history"
+	backendHistoryLabel         = "knoway.dev/backend-history"
+	backendHistoryTypeLabel     = "knoway.dev/backend-type"
+	backendHistoryBackendLabel  = "knoway.dev/backend-name"
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+var (
+	ErrBackendRevisionNotFound = errors.New("backend revision not found")
+	ErrBackendBeingDeleted     = errors.New("backend is being deleted")
+)
+
+// BackendRevision is a snapshot of the configuration of a backend at a given
+// generation.
+type BackendRevision struct {
+	Generation  int64             `json:"generation"`
+	RecordedAt  metav1.Time       `json:"recordedAt"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Spec        json.RawMessage   `json:"spec"`
+}
+
+// backendHistoryName returns the name of the ConfigMap keeping the revisions
+// of the backend. The ConfigMap is not owned by the backend so that it
+// survives the deletion of the backend and can be used to restore it.
+func backendHistoryName(typ knowaydevv1alpha1.BackendType, name string) string {
+	return "knoway-history-" + strings.ToLower(string(typ)) + "-" + name
+}
+
+func readBackendHistory(ctx context.Context, c client.Reader, typ knowaydevv1alpha1.BackendType, namespace, name string) (*corev1.ConfigMap, []BackendRevision, error) {
+	cm := &corev1.ConfigMap{}
+
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: backendHistoryName(typ, name)}, cm)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+
+		return nil, nil, err
+	}
+
+	revisions := make([]BackendRevision, 0)
+
+	if data := cm.Data[backendHistoryDataKey]; data != "" {
+		err = json.Unmarshal([]byte(data), &revisions)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid history of %s %s/%s: %w", typ, namespace, name, err)
+		}
+	}
+
+	return cm, revisions, nil
+}
+
+// recordBackendRevision appends the current configuration of the backend to
+// its history unless the generation has been recorded already, only the
+// latest limit revisions are kept.
+func recordBackendRevision(ctx context.Context, c client.Client, backend Backend, limit int) error {
+	if limit <= 0 {
+		limit = DefaultBackendHistoryLimit
+	}
+
+	meta := backend.GetObjectObjectMeta()
+
+	cm, revisions, err := readBackendHistory(ctx, c, backend.GetType(), meta.Namespace, meta.Name)
+	if err != nil {
+		return err
+	}
+
+	if lo.ContainsBy(revisions, func(item BackendRevision) bool {
+		return item.Generation == meta.Generation
+	}) {
+		return nil
+	}
+
+	spec, err := json.Marshal(backend.GetSpec())
+	if err != nil {
+		return err
+	}
+
+	revisions = append(revisions, BackendRevision{
+		Generation:  meta.Generation,
+		RecordedAt:  metav1.Now(),
+		Labels:      meta.Labels,
+		Annotations: lo.OmitByKeys(meta.Annotations, []string{lastAppliedConfigAnnotation}),
+		Spec:        spec,
+	})
+	if len(revisions) > limit {
+		revisions = revisions[len(revisions)-limit:]
+	}
+
+	data, err := json.Marshal(revisions)
+	if err != nil {
+		return err
+	}
+
+	if cm == nil {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: meta.Namespace,
+				Name:      backendHistoryName(backend.GetType(), meta.Name),
+				Labels: map[string]string{
+					backendHistoryLabel:        "true",
+					backendHistoryTypeLabel:    strings.ToLower(string(backend.GetType())),
+					backendHistoryBackendLabel: meta.Name,
+				},
+			},
+			Data: map[string]string{
+				backendHistoryDataKey: string(data),
+			},
+		}
+
+		return c.Create(ctx, cm)
+	}
+
+	cm.Data = map[string]string{
+		backendHistoryDataKey: string(data),
+	}
+
+	return c.Update(ctx, cm)
+}
+
+// ListBackendRevisions returns the recorded revisions of the backend, the
+// latest one comes first.
+func ListBackendRevisions(ctx context.Context, c client.Reader, typ knowaydevv1alpha1.BackendType, namespace, name string) ([]BackendRevision, error) {
+	_, revisions, err := readBackendHistory(ctx, c, typ, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[i].Generation > revisions[j].Generation
+	})
+
+	return revisions, nil
+}
+
+// RestoreBackend brings the backend back to the recorded revision of the
+// given generation, or the latest one if generation is 0. The backend is
+// re-created if it has been deleted, otherwise its spec gets rolled back.
+func RestoreBackend(ctx context.Context, c client.Client, typ knowaydevv1alpha1.BackendType, namespace, name string, generation int64) (client.Object, error) {
+	revisions, err := ListBackendRevisions(ctx, c, typ, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, ok := lo.Find(revisions, func(item BackendRevision) bool {
+		return generation == 0 || item.Generation == generation
+	})
+	if !ok {
+		return nil, ErrBackendRevisionNotFound
+	}
+
+	var obj client.Object
+
+	switch typ {
+	case knowaydevv1alpha1.BackendTypeLLM:
+		obj = &knowaydevv1alpha1.LLMBackend{}
+	case knowaydevv1alpha1.BackendTypeImageGeneration:
+		obj = &knowaydevv1alpha1.ImageGenerationBackend{}
+	case knowaydevv1alpha1.BackendTypeEmbedding:
+		obj = &knowaydevv1alpha1.EmbeddingBackend{}
+	case knowaydevv1alpha1.BackendTypeRerank:
+		obj = &knowaydevv1alpha1.RerankBackend{}
+	case knowaydevv1alpha1.BackendTypeVideoGeneration:
+		obj = &knowaydevv1alpha1.VideoGenerationBackend{}
+	default:
+		return nil, fmt.Errorf("unsupported backend type %s", typ)
+	}
+
+	err = c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	exists := err == nil
+	if exists && obj.GetDeletionTimestamp() != nil {
+		return nil, ErrBackendBeingDeleted
+	}
+
+	err = restoreBackendSpec(obj, revision.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return obj, c.Update(ctx, obj)
+	}
+
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(revision.Labels)
+	obj.SetAnnotations(revision.Annotations)
+
+	return obj, c.Create(ctx, obj)
+}
+
+func restoreBackendSpec(obj client.Object, spec json.RawMessage) error {
+	switch v := obj.(type) {
+	case *knowaydevv1alpha1.LLMBackend:
+		v.Spec = knowaydevv1alpha1.LLMBackendSpec{}
+		return json.Unmarshal(spec, &v.Spec)
+	case *knowaydevv1alpha1.ImageGenerationBackend:
+		v.Spec = knowaydevv1alpha1.ImageGenerationBackendSpec{}
+		return json.Unmarshal(spec, &v.Spec)
+	case *knowaydevv1alpha1.EmbeddingBackend:
+		v.Spec = knowaydevv1alpha1.EmbeddingBackendSpec{}
+		return json.Unmarshal(spec, &v.Spec)
+	case *knowaydevv1alpha1.RerankBackend:
+		v.Spec = knowaydevv1alpha1.RerankBackendSpec{}
+		return json.Unmarshal(spec, &v.Spec)
+	case *knowaydevv1alpha1.VideoGenerationBackend:
+		v.Spec = knowaydevv1alpha1.VideoGenerationBackendSpec{}
+		return json.Unmarshal(spec, &v.Spec)
+	default:
+		return fmt.Errorf("unsupported backend %T", obj)
+	}
+}
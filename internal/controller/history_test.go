@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"knoway.dev/api/v1alpha1"
+)
+
+func TestBackendHistory(t *testing.T) {
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	backend := &v1alpha1.LLMBackend{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "gpt-4o",
+			Generation: 1,
+			Labels:     map[string]string{"app": "knoway"},
+		},
+		Spec: v1alpha1.LLMBackendSpec{
+			ModelName: lo.ToPtr("gpt-4o"),
+			Upstream:  v1alpha1.BackendUpstream{BaseURL: "https://api.openai.com/v1"},
+		},
+	}
+
+	for i := range 3 {
+		backend.Generation = int64(i + 1)
+		backend.Spec.Upstream.Timeout = int32(i + 1)
+
+		require.NoError(t, recordBackendRevision(ctx, c, BackendFromLLMBackend(backend), 2))
+		// Recording the same generation again is a no-op
+		require.NoError(t, recordBackendRevision(ctx, c, BackendFromLLMBackend(backend), 2))
+	}
+
+	revisions, err := ListBackendRevisions(ctx, c, v1alpha1.BackendTypeLLM, "default", "gpt-4o")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, int64(3), revisions[0].Generation)
+	assert.Equal(t, int64(2), revisions[1].Generation)
+
+	t.Run("re-create deleted backend", func(t *testing.T) {
+		obj, err := RestoreBackend(ctx, c, v1alpha1.BackendTypeLLM, "default", "gpt-4o", 0)
+		require.NoError(t, err)
+		assert.Equal(t, "gpt-4o", obj.GetName())
+
+		restored := &v1alpha1.LLMBackend{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "gpt-4o"}, restored))
+		assert.Equal(t, int32(3), restored.Spec.Upstream.Timeout)
+		assert.Equal(t, "knoway", restored.Labels["app"])
+	})
+
+	t.Run("roll back existing backend", func(t *testing.T) {
+		_, err := RestoreBackend(ctx, c, v1alpha1.BackendTypeLLM, "default", "gpt-4o", 2)
+		require.NoError(t, err)
+
+		restored := &v1alpha1.LLMBackend{}
+		require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "gpt-4o"}, restored))
+		assert.Equal(t, int32(2), restored.Spec.Upstream.Timeout)
+	})
+
+	t.Run("unknown revision", func(t *testing.T) {
+		_, err := RestoreBackend(ctx, c, v1alpha1.BackendTypeLLM, "default", "gpt-4o", 1)
+		require.ErrorIs(t, err, ErrBackendRevisionNotFound)
+	})
+}
@@ -18,28 +18,19 @@ package controller
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"net/url"
-	"strings"
-	"time"
 
 	structpb "github.com/golang/protobuf/ptypes/struct"
-	"github.com/hashicorp/go-multierror"
 	"github.com/samber/lo"
-	"github.com/stoewer/go-strcase"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"google.golang.org/protobuf/types/known/anypb"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"knoway.dev/api/clusters/v1alpha1"
+	filtersv1alpha1 "knoway.dev/api/filters/v1alpha1"
 	knowaydevv1alpha1 "knoway.dev/api/v1alpha1"
 	"knoway.dev/pkg/bootkit"
-	cluster "knoway.dev/pkg/clusters/cluster"
-	clustermanager "knoway.dev/pkg/clusters/manager"
-	routemanager "knoway.dev/pkg/route/manager"
 )
 
 // LLMBackendReconciler reconciles a LLMBackend object
@@ -48,286 +39,113 @@ type LLMBackendReconciler struct {
 
 	Scheme    *runtime.Scheme
 	LifeCycle bootkit.LifeCycle
+	// HistoryLimit is the number of revisions kept for each backend
+	HistoryLimit int
 }
 
 // +kubebuilder:rbac:groups=llm.knoway.dev,resources=llmbackends,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=llm.knoway.dev,resources=llmbackends/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=llm.knoway.dev,resources=llmbackends/finalizers,verbs=update
-
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the LLMBackend object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
-//
-// For more details, check Reconcile and its Result here:
-// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.18.2/pkg/reconcile
-func (r *LLMBackendReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	currentBackend := &knowaydevv1alpha1.LLMBackend{}
-	err := r.Get(ctx, req.NamespacedName, currentBackend)
-	if err != nil {
-		log.Log.Error(err, "reconcile LLMBackend", "name", req.String())
-		return ctrl.Result{}, client.IgnoreNotFound(err)
-	}
-
-	log.Log.Info("reconcile LLMBackend modelName", "modelName", modelNameOrNamespacedName(currentBackend))
-
-	rrs := r.getReconciles()
-	if isBackendDeleted(BackendFromLLMBackend(currentBackend)) {
-		rrs = r.getDeleteReconciles()
-	}
-
-	currentBackend.Status.Conditions = nil
-
-	for _, rr := range rrs {
-		typ := rr.typ
-
-		err := rr.reconciler(ctx, currentBackend)
-		if err != nil {
-			if isBackendDeleted(BackendFromLLMBackend(currentBackend)) && shouldForceDeleteBackend(BackendFromLLMBackend(currentBackend)) {
-				continue
-			}
-
-			log.Log.Error(err, "LLMBackend reconcile error", "name", currentBackend.Name, "type", typ)
-			setStatusCondition(BackendFromLLMBackend(currentBackend), typ, false, err.Error())
-
-			break
-		} else {
-			setStatusCondition(BackendFromLLMBackend(currentBackend), typ, true, "")
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+
+var llmBackendKind = backendKind[*knowaydevv1alpha1.LLMBackend]{
+	name:        "LLMBackend",
+	typ:         knowaydevv1alpha1.BackendTypeLLM,
+	clusterType: v1alpha1.ClusterType_LLM,
+	newObject: func() *knowaydevv1alpha1.LLMBackend {
+		return &knowaydevv1alpha1.LLMBackend{}
+	},
+	list: func(ctx context.Context, c client.Reader) ([]*knowaydevv1alpha1.LLMBackend, error) {
+		backends := &knowaydevv1alpha1.LLMBackendList{}
+		if err := c.List(ctx, backends); err != nil {
+			return nil, err
 		}
-	}
-
-	r.reconcilePhase(ctx, currentBackend)
-
-	var after time.Duration
-	if currentBackend.Status.Status == knowaydevv1alpha1.Failed {
-		after = 30 * time.Second //nolint:mnd
-	}
-
-	newBackend := &knowaydevv1alpha1.LLMBackend{}
 
-	err = r.Get(ctx, req.NamespacedName, newBackend)
-	if err != nil {
-		log.Log.Error(err, "reconcile LLMBackend", "name", req.String())
-		return ctrl.Result{}, client.IgnoreNotFound(err)
-	}
-
-	if !statusEqual(BackendFromLLMBackend(currentBackend).GetStatus(), BackendFromLLMBackend(newBackend).GetStatus()) {
-		newBackend.Status = currentBackend.Status
-		err := r.Status().Update(ctx, newBackend)
-		if err != nil {
-			log.Log.Error(err, "update LLMBackend status error", "name", currentBackend.GetName())
-			return ctrl.Result{}, client.IgnoreNotFound(err)
+		return lo.ToSlicePtr(backends.Items), nil
+	},
+	toBackend: BackendFromLLMBackend,
+	copyStatus: func(dst, src *knowaydevv1alpha1.LLMBackend) {
+		dst.Status = src.Status
+	},
+	spec: func(backend *knowaydevv1alpha1.LLMBackend) backendSpec {
+		return backendSpec{
+			provider:        backend.Spec.Provider,
+			baseURL:         backend.Spec.Upstream.BaseURL,
+			service:         backend.Spec.Upstream.Service,
+			endpointPolicy:  backend.Spec.Upstream.LoadBalancePolicy,
+			headers:         backend.Spec.Upstream.Headers,
+			headersFrom:     backend.Spec.Upstream.HeadersFrom,
+			auth:            backend.Spec.Upstream.Auth,
+			apiKeys:         backend.Spec.Upstream.APIKeys,
+			paths:           backend.Spec.Upstream.Paths,
+			query:           backend.Spec.Upstream.Query,
+			timeout:         backend.Spec.Upstream.Timeout,
+			removeParamKeys: backend.Spec.Upstream.RemoveParamKeys,
+			healthCheck:     backend.Spec.Upstream.HealthCheck,
+			circuitBreaker:  backend.Spec.Upstream.CircuitBreaker,
+			connection:      backend.Spec.Upstream.Connection,
+			deadlineHeader:  backend.Spec.Upstream.DeadlineHeader,
+			filters: lo.Map(backend.Spec.Filters, func(f knowaydevv1alpha1.LLMBackendFilter, _ int) knowaydevv1alpha1.FilterConfig {
+				return f.FilterConfig
+			}),
+			meteringExpressions: lo.FromPtr(backend.Spec.MeteringPolicy).Expressions,
+			pricing:             backend.Spec.Pricing,
+			modelInfo:           backend.Spec.ModelInfo,
 		}
-	}
-
-	return ctrl.Result{RequeueAfter: after}, nil
-}
-
-func (r *LLMBackendReconciler) reconcileRegister(ctx context.Context, llmBackend *knowaydevv1alpha1.LLMBackend) error {
-	modelName := modelNameOrNamespacedName(llmBackend)
-
-	removeBackendFunc := func() {
-		if modelName != "" {
-			clustermanager.RemoveCluster(&v1alpha1.Cluster{
-				Name: modelName,
+	},
+	params: toLLMBackendParams,
+	customizeCluster: func(backend *knowaydevv1alpha1.LLMBackend, cluster *v1alpha1.Cluster) {
+		if backend.Spec.PromptTemplate != nil {
+			cluster.Filters = append(cluster.Filters, &v1alpha1.ClusterFilter{
+				Name:   "prompt-template",
+				Config: lo.Must(anypb.New(promptTemplateFromSpec(backend.Spec.PromptTemplate))),
 			})
-			routemanager.RemoveBaseRoute(modelName)
 		}
-	}
-	if isBackendDeleted(BackendFromLLMBackend(llmBackend)) {
-		removeBackendFunc()
-		return nil
-	}
-
-	clusterCfg, err := r.toRegisterClusterConfig(ctx, llmBackend)
-	if err != nil {
-		return fmt.Errorf("invalid config: %w", err)
-	}
-
-	routeCfg := routemanager.InitDirectModelRoute(modelName)
-
-	mulErrs := &multierror.Error{}
-
-	if clusterCfg != nil {
-		err = clustermanager.UpsertAndRegisterCluster(clusterCfg, r.LifeCycle)
-		if err != nil {
-			log.Log.Error(err, "Failed to upsert LLMBackend", "cluster", clusterCfg)
-			mulErrs = multierror.Append(mulErrs, fmt.Errorf("failed to upsert LLMBackend %s: %w", llmBackend.GetName(), err))
-		}
-
-		err = routemanager.RegisterBaseRouteWithConfig(routeCfg, r.LifeCycle)
-		if err != nil {
-			log.Log.Error(err, "Failed to register route", "route", modelName)
-			mulErrs = multierror.Append(mulErrs, fmt.Errorf("failed to upsert LLMBackend %s route: %w", llmBackend.GetName(), err))
-		}
-	}
-
-	if mulErrs.ErrorOrNil() != nil {
-		removeBackendFunc()
-	}
-
-	return mulErrs.ErrorOrNil()
-}
 
-func (r *LLMBackendReconciler) reconcileValidator(ctx context.Context, llmBackend *knowaydevv1alpha1.LLMBackend) error {
-	if llmBackend.Spec.ModelName != nil && *llmBackend.Spec.ModelName == "" {
-		return errors.New("spec.modelName cannot be empty")
-	}
-
-	if llmBackend.Spec.Upstream.BaseURL == "" {
-		return errors.New("upstream.baseUrl cannot be empty")
-	}
-
-	if _, err := url.Parse(llmBackend.Spec.Upstream.BaseURL); err != nil {
-		return fmt.Errorf("upstream.baseUrl parse error: %w", err)
-	}
-
-	allExistingBackend := &knowaydevv1alpha1.LLMBackendList{}
-	if err := r.List(ctx, allExistingBackend); err != nil {
-		return fmt.Errorf("failed to list LLMBackend resources: %w", err)
-	}
-
-	llmBackendModelName := modelNameOrNamespacedName(llmBackend)
-
-	for _, existing := range allExistingBackend.Items {
-		if modelNameOrNamespacedName(existing) == llmBackendModelName && existing.Name != llmBackend.Name {
-			return fmt.Errorf("LLMBackend name '%s' must be unique globally", llmBackendModelName)
-		}
-	}
-
-	// validator cluster filter by new
-	clusterCfg, err := r.toRegisterClusterConfig(ctx, llmBackend)
-	if err != nil {
-		return fmt.Errorf("failed to convert LLMBackend to cluster config: %w", err)
-	}
-
-	_, err = cluster.NewWithConfigs(clusterCfg, nil)
-	if err != nil {
-		return fmt.Errorf("invalid cluster configuration: %w", err)
-	}
-
-	return nil
-}
-
-func (r *LLMBackendReconciler) reconcileUpstreamHealthy(ctx context.Context, llmBackend *knowaydevv1alpha1.LLMBackend) error {
-	// todo use model list api ?
-	return nil
-}
-
-func (r *LLMBackendReconciler) reconcilePhase(_ context.Context, llmBackend *knowaydevv1alpha1.LLMBackend) {
-	reconcileBackendPhase(BackendFromLLMBackend(llmBackend))
-}
-
-type reconcileHandler[T runtime.Object] struct {
-	typ        string
-	reconciler func(ctx context.Context, backend T) error
-}
-
-const (
-	KnowayFinalzer = "knoway.dev"
-
-	deleteCondPrefix = "delete-"
-)
-
-const (
-	condConfig             = "config"
-	condValidator          = "validator"
-	condUpstreamHealthy    = "upstreamHealthy"
-	condDestinationHealthy = "destinationHealthy"
-	condRegister           = "register"
-	condFinalDelete        = "finalDelete"
-)
-
-func (r *LLMBackendReconciler) getReconciles() []reconcileHandler[*knowaydevv1alpha1.LLMBackend] {
-	rhs := []reconcileHandler[*knowaydevv1alpha1.LLMBackend]{
-		{
-			typ:        condConfig,
-			reconciler: r.reconcileConfig,
-		},
-		{
-			typ:        condValidator,
-			reconciler: r.reconcileValidator,
-		},
-		{
-			typ:        condUpstreamHealthy,
-			reconciler: r.reconcileUpstreamHealthy,
-		},
-		{
-			typ:        condRegister,
-			reconciler: r.reconcileRegister,
-		},
-	}
-
-	return rhs
-}
-
-func (r *LLMBackendReconciler) getDeleteReconciles() []reconcileHandler[*knowaydevv1alpha1.LLMBackend] {
-	rhs := []reconcileHandler[*knowaydevv1alpha1.LLMBackend]{
-		{
-			typ:        condConfig,
-			reconciler: r.reconcileConfig,
-		},
-		{
-			typ:        strcase.LowerCamelCase(deleteCondPrefix + condRegister),
-			reconciler: r.reconcileRegister,
-		},
-		{
-			typ:        condFinalDelete,
-			reconciler: r.reconcileFinalDelete,
-		},
-	}
-
-	return rhs
-}
-
-func (r *LLMBackendReconciler) reconcileConfig(ctx context.Context, llmBackend *knowaydevv1alpha1.LLMBackend) error {
-	if len(llmBackend.Finalizers) == 0 {
-		llmBackend.Finalizers = []string{KnowayFinalzer}
-		err := r.Update(ctx, llmBackend.DeepCopy())
-		if err != nil {
-			log.Log.Error(err, "update cluster finalizer error")
-			return err
+		switch backend.Spec.Provider { //nolint:exhaustive
+		case knowaydevv1alpha1.ProviderAzureOpenAI:
+			azure := lo.FromPtrOr(backend.Spec.Upstream.AzureOpenAI, knowaydevv1alpha1.AzureOpenAIUpstream{})
+
+			cluster.Filters = append(cluster.Filters, &v1alpha1.ClusterFilter{
+				Name: "azure-openai",
+				Config: lo.Must(anypb.New(&filtersv1alpha1.AzureOpenAIConfig{
+					Deployment:        azure.Deployment,
+					ApiVersion:        azure.APIVersion,
+					KeepAuthorization: azure.KeepAuthorization,
+				})),
+			})
+		case knowaydevv1alpha1.ProviderAWSBedrock:
+			bedrock := lo.FromPtrOr(backend.Spec.Upstream.AWSBedrock, knowaydevv1alpha1.AWSBedrockUpstream{})
+
+			// Signing must be the last one to marshal the upstream request
+			cluster.Filters = append(cluster.Filters, &v1alpha1.ClusterFilter{
+				Name: "aws-sigv4",
+				Config: lo.Must(anypb.New(&filtersv1alpha1.AWSSigV4Config{
+					Region: bedrock.Region,
+				})),
+			})
 		}
-	}
-
-	return nil
+	},
 }
 
-const graceDeletePeriod = time.Minute * 10
-
-func (r *LLMBackendReconciler) reconcileFinalDelete(ctx context.Context, llmBackend *knowaydevv1alpha1.LLMBackend) error {
-	canDelete := true
-
-	for _, con := range llmBackend.Status.Conditions {
-		if strings.Contains(con.Type, deleteCondPrefix) && con.Status == metav1.ConditionFalse {
-			canDelete = false
-		}
-	}
-
-	if !canDelete && !shouldForceDeleteBackend(BackendFromLLMBackend(llmBackend)) {
-		return errors.New("have delete condition not ready")
-	}
-
-	llmBackend.Finalizers = nil
-	err := r.Update(ctx, llmBackend)
-	if err != nil {
-		log.Log.Error(err, "update LLMBackend finalizer error")
-		return err
-	}
-
-	log.Log.Info("remove LLMBackend finalizer", "name", llmBackend.GetName())
-
-	return nil
+func (r *LLMBackendReconciler) backendReconciler() *backendReconciler[*knowaydevv1alpha1.LLMBackend] {
+	return newBackendReconciler(r.Client, llmBackendKind, r.LifeCycle, r.HistoryLimit)
 }
 
-func (r *LLMBackendReconciler) toUpstreamHeaders(ctx context.Context, backend *knowaydevv1alpha1.LLMBackend) ([]*v1alpha1.Upstream_Header, error) {
-	if backend == nil {
-		return nil, nil
-	}
-
-	return headerFromSpec(ctx, r.Client, backend.GetNamespace(), backend.Spec.Upstream.Headers, backend.Spec.Upstream.HeadersFrom)
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// TODO(user): Modify the Reconcile function to compare the state specified by
+// the LLMBackend object against the actual cluster state, and then
+// perform operations to make the cluster state reflect the state specified by
+// the user.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.18.2/pkg/reconcile
+func (r *LLMBackendReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return r.backendReconciler().Reconcile(ctx, req)
 }
 
 func parseModelParams(modelParams *knowaydevv1alpha1.ModelParams, params map[string]*structpb.Value) error {
@@ -337,6 +155,7 @@ func parseModelParams(modelParams *knowaydevv1alpha1.ModelParams, params map[str
 
 	modelTypes := map[string]interface{}{
 		"OpenAI": modelParams.OpenAI,
+		"Gemini": modelParams.Gemini,
 	}
 
 	for name, model := range modelTypes {
@@ -374,60 +193,10 @@ func toLLMBackendParams(backed *knowaydevv1alpha1.LLMBackend) (map[string]*struc
 }
 
 func (r *LLMBackendReconciler) toRegisterClusterConfig(ctx context.Context, backend *knowaydevv1alpha1.LLMBackend) (*v1alpha1.Cluster, error) {
-	if backend == nil {
-		return nil, nil
-	}
-
-	modelName := modelNameOrNamespacedName(backend)
-
-	hs, err := r.toUpstreamHeaders(ctx, backend)
-	if err != nil {
-		return nil, err
-	}
-
-	defaultParams, overrideParams, err := toLLMBackendParams(backend)
-	if err != nil {
-		return nil, err
-	}
-
-	// filters
-	var filters []*v1alpha1.ClusterFilter
-
-	for _, fc := range backend.Spec.Filters {
-		switch {
-		case fc.Custom != nil:
-			// TODO: Implement custom filter
-			log.Log.Info("Discovered filter during registration of cluster", "type", "Custom", "cluster", backend.Name, "modelName", modelName)
-		default:
-			// TODO: Implement unknown filter
-			log.Log.Info("Discovered filter during registration of cluster", "type", "Unknown", "cluster", backend.Name, "modelName", modelName)
-		}
-	}
-
-	return &v1alpha1.Cluster{
-		Type:     v1alpha1.ClusterType_LLM,
-		Name:     modelName,
-		Provider: MapBackendProviderToClusterProvider(backend.Spec.Provider),
-		Created:  backend.GetCreationTimestamp().Unix(),
-
-		// todo configurable to replace hard config
-		LoadBalancePolicy: v1alpha1.LoadBalancePolicy_ROUND_ROBIN,
-
-		Upstream: &v1alpha1.Upstream{
-			Url:             backend.Spec.Upstream.BaseURL,
-			Headers:         hs,
-			Timeout:         backend.Spec.Upstream.Timeout,
-			DefaultParams:   defaultParams,
-			OverrideParams:  overrideParams,
-			RemoveParamKeys: backend.Spec.Upstream.RemoveParamKeys,
-		},
-		Filters: filters,
-	}, nil
+	return r.backendReconciler().toClusterConfig(ctx, backend)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *LLMBackendReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&knowaydevv1alpha1.LLMBackend{}).
-		Complete(r)
+	return r.backendReconciler().setupWithManager(mgr, r)
 }
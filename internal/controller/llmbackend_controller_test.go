@@ -26,7 +26,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	clustersv1alpha1 "knoway.dev/api/clusters/v1alpha1"
+	filtersv1alpha1 "knoway.dev/api/filters/v1alpha1"
 	"knoway.dev/api/v1alpha1"
+	"knoway.dev/pkg/protoutils"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -143,3 +146,74 @@ func createTestScheme() *runtime.Scheme {
 
 	return scheme
 }
+
+func TestLLMBackendReconciler_PromptTemplate(t *testing.T) {
+	ctx := context.Background()
+
+	resource := &v1alpha1.LLMBackend{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpt-4o", Namespace: "default"},
+		Spec: v1alpha1.LLMBackendSpec{
+			Provider: v1alpha1.ProviderOpenAI,
+			Upstream: v1alpha1.BackendUpstream{BaseURL: "https://api.openai.com/v1"},
+			PromptTemplate: &v1alpha1.PromptTemplate{
+				SystemPrompt:     "Today is {{date}}.",
+				SystemPromptMode: v1alpha1.SystemPromptModePrepend,
+				Variables:        map[string]string{"team": "search"},
+			},
+		},
+	}
+
+	reconciler := &LLMBackendReconciler{Client: NewFakeClientWithStatus()}
+
+	clusterCfg, err := reconciler.toRegisterClusterConfig(ctx, resource)
+	require.NoError(t, err)
+
+	filter, ok := lo.Find(clusterCfg.GetFilters(), func(f *clustersv1alpha1.ClusterFilter) bool { return f.GetName() == "prompt-template" })
+	require.True(t, ok)
+
+	cfg, err := protoutils.FromAny(filter.GetConfig(), &filtersv1alpha1.PromptTemplateConfig{})
+	require.NoError(t, err)
+	require.Equal(t, "Today is {{date}}.", cfg.GetSystemPrompt())
+	require.Equal(t, filtersv1alpha1.PromptTemplateConfig_SYSTEM_PROMPT_MODE_PREPEND, cfg.GetSystemPromptMode())
+	require.Equal(t, map[string]string{"team": "search"}, cfg.GetVariables())
+}
+
+func TestLLMBackendReconciler_VLLMEndpointSelector(t *testing.T) {
+	ctx := context.Background()
+
+	resource := &v1alpha1.LLMBackend{
+		ObjectMeta: metav1.ObjectMeta{Name: "qwen3", Namespace: "default"},
+		Spec: v1alpha1.LLMBackendSpec{
+			Provider: v1alpha1.ProviderVLLM,
+			Upstream: v1alpha1.BackendUpstream{BaseURL: "http://qwen3.default.svc.cluster.local:8000/v1"},
+		},
+	}
+
+	reconciler := &LLMBackendReconciler{Client: NewFakeClientWithStatus()}
+
+	clusterCfg, err := reconciler.toRegisterClusterConfig(ctx, resource)
+	require.NoError(t, err)
+	require.Equal(t, clustersv1alpha1.LoadBalancePolicy_CUSTOM, clusterCfg.GetLoadBalancePolicy())
+
+	filter, ok := lo.Find(clusterCfg.GetFilters(), func(f *clustersv1alpha1.ClusterFilter) bool { return f.GetName() == "vllm-endpoint-selector" })
+	require.True(t, ok)
+
+	_, err = protoutils.FromAny(filter.GetConfig(), &filtersv1alpha1.VLLMEndpointSelectorConfig{})
+	require.NoError(t, err)
+
+	resource.Spec.Provider = v1alpha1.ProviderOpenAI
+
+	clusterCfg, err = reconciler.toRegisterClusterConfig(ctx, resource)
+	require.NoError(t, err)
+	require.Equal(t, clustersv1alpha1.LoadBalancePolicy_ROUND_ROBIN, clusterCfg.GetLoadBalancePolicy())
+	require.False(t, lo.ContainsBy(clusterCfg.GetFilters(), func(f *clustersv1alpha1.ClusterFilter) bool { return f.GetName() == "vllm-endpoint-selector" }))
+
+	// The policy of the spec takes precedence over the one of the provider
+	resource.Spec.Provider = v1alpha1.ProviderVLLM
+	resource.Spec.Upstream.LoadBalancePolicy = v1alpha1.EndpointLoadBalancePolicyLeastLatency
+
+	clusterCfg, err = reconciler.toRegisterClusterConfig(ctx, resource)
+	require.NoError(t, err)
+	require.Equal(t, clustersv1alpha1.LoadBalancePolicy_LEAST_LATENCY, clusterCfg.GetLoadBalancePolicy())
+	require.False(t, lo.ContainsBy(clusterCfg.GetFilters(), func(f *clustersv1alpha1.ClusterFilter) bool { return f.GetName() == "vllm-endpoint-selector" }))
+}
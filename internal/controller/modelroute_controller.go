@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,11 +33,13 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/samber/lo"
 	"github.com/stoewer/go-strcase"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	routev1alpha1 "knoway.dev/api/route/v1alpha1"
@@ -68,6 +71,8 @@ type ModelRouteReconciler struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.4/pkg/reconcile
 func (r *ModelRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	defer markReconciled(modelRouteKind, req.NamespacedName)
+
 	modelRoute := &llmv1alpha1.ModelRoute{}
 	err := r.Get(ctx, req.NamespacedName, modelRoute)
 	if err != nil {
@@ -79,10 +84,17 @@ func (r *ModelRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	rrs := r.getReconciles()
 	if modelRoute.GetObjectMeta().GetDeletionTimestamp() != nil {
 		rrs = r.getDeleteReconciles()
+	} else if isDryRun(modelRoute) {
+		rrs = r.getDryRunReconciles()
 	}
 
 	modelRoute.Status.Conditions = nil
 
+	var canaryAfter time.Duration
+	if !isModelRouteDeleted(modelRoute) && !isDryRun(modelRoute) {
+		canaryAfter = r.reconcileCanary(modelRoute, time.Now())
+	}
+
 	for _, rr := range rrs {
 		typ := rr.typ
 
@@ -102,12 +114,20 @@ func (r *ModelRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}
 
+	reconcileModelRouteConflict(ctx, r.Client, modelRoute)
+
+	if isDryRun(modelRoute) {
+		r.reconcileDryRun(ctx, modelRoute)
+	}
 	r.reconcilePhase(ctx, modelRoute)
 
 	var after time.Duration
 	if modelRoute.Status.Status == llmv1alpha1.Failed {
 		after = 30 * time.Second //nolint:mnd
 	}
+	if canaryAfter > 0 && (after == 0 || canaryAfter < after) {
+		after = canaryAfter
+	}
 
 	newModelRoute := &llmv1alpha1.ModelRoute{}
 
@@ -117,7 +137,8 @@ func (r *ModelRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	if !routeStatusEqual(&ModelRouteStatus{ModelRouteStatus: &modelRoute.Status}, &ModelRouteStatus{ModelRouteStatus: &newModelRoute.Status}) {
+	if !routeStatusEqual(&ModelRouteStatus{ModelRouteStatus: &modelRoute.Status}, &ModelRouteStatus{ModelRouteStatus: &newModelRoute.Status}) ||
+		!equality.Semantic.DeepEqual(modelRoute.Status.Canary, newModelRoute.Status.Canary) {
 		newModelRoute.Status = modelRoute.Status
 		err := r.Status().Update(ctx, newModelRoute)
 		if err != nil {
@@ -167,7 +188,7 @@ func (r *ModelRouteReconciler) reconcileRegister(ctx context.Context, modelRoute
 		return nil
 	}
 
-	crdTargets := r.getModelRouteTargets(modelRoute)
+	crdTargets := r.getModelRouteBackendTargets(modelRoute)
 
 	mBackends, err := r.mapCRDTargetsToBackends(ctx, crdTargets)
 	if err != nil {
@@ -196,6 +217,38 @@ func (r *ModelRouteReconciler) reconcileRegister(ctx context.Context, modelRoute
 	return mulErrs.ErrorOrNil()
 }
 
+// reconcileDryRun records what registering the validated ModelRoute would do
+// to the route of the model, without registering it.
+func (r *ModelRouteReconciler) reconcileDryRun(ctx context.Context, modelRoute *llmv1alpha1.ModelRoute) {
+	if hasFailedCondition(modelRoute.Status.Conditions) {
+		return
+	}
+
+	message, err := r.dryRunMessage(ctx, modelRoute)
+	if err != nil {
+		setModelRouteStatusCondition(modelRoute, condDryRun, false, err.Error())
+		return
+	}
+
+	setModelRouteStatusCondition(modelRoute, condDryRun, true, message)
+}
+
+func (r *ModelRouteReconciler) dryRunMessage(ctx context.Context, modelRoute *llmv1alpha1.ModelRoute) (string, error) {
+	mBackends, err := r.mapCRDTargetsToBackends(ctx, r.getModelRouteBackendTargets(modelRoute))
+	if err != nil {
+		return "", err
+	}
+
+	routeConfig, err := r.toRegisterRouteConfig(ctx, modelRoute, mBackends)
+	if err != nil {
+		return "", err
+	}
+
+	appliedRoute, _ := routemanager.GetMatchRouteConfig(modelRoute.Spec.ModelName)
+
+	return dryRunMessage(dryRunSection{name: "route " + modelRoute.Spec.ModelName, applied: appliedRoute, desired: routeConfig})
+}
+
 func (r *ModelRouteReconciler) reconcileDestinationHealthy(ctx context.Context, modelRoute *llmv1alpha1.ModelRoute) error {
 	crdTargets := r.getModelRouteTargets(modelRoute)
 
@@ -269,6 +322,27 @@ func (r *ModelRouteReconciler) getReconciles() []reconcileHandler[*llmv1alpha1.M
 	return rhs
 }
 
+// getDryRunReconciles only validates the ModelRoute, see also
+// reconcileDryRun.
+func (r *ModelRouteReconciler) getDryRunReconciles() []reconcileHandler[*llmv1alpha1.ModelRoute] {
+	rhs := []reconcileHandler[*llmv1alpha1.ModelRoute]{
+		{
+			typ:        condConfig,
+			reconciler: r.reconcileConfig,
+		},
+		{
+			typ:        condValidator,
+			reconciler: r.reconcileValidator,
+		},
+		{
+			typ:        condDestinationHealthy,
+			reconciler: r.reconcileDestinationHealthy,
+		},
+	}
+
+	return rhs
+}
+
 func (r *ModelRouteReconciler) getDeleteReconciles() []reconcileHandler[*llmv1alpha1.ModelRoute] {
 	rhs := []reconcileHandler[*llmv1alpha1.ModelRoute]{
 		{
@@ -349,6 +423,26 @@ func (r *ModelRouteReconciler) reconcileValidator(ctx context.Context, modelRout
 		})) {
 			return errors.New("spec.route.targets.[].destination.weight must be either all set or all unset")
 		}
+
+		if affinity := modelRoute.Spec.Route.SessionAffinity; affinity != nil && affinity.Header == "" && !affinity.UserField {
+			return errors.New("spec.route.sessionAffinity must set either header or userField")
+		}
+
+		if slo := modelRoute.Spec.Route.FirstChunkSLO; slo != nil {
+			if slo.P95.Duration <= 0 {
+				return errors.New("spec.route.firstChunkSLO.p95 must be greater than 0")
+			}
+
+			if slo.Window != nil && slo.Window.Duration <= 0 {
+				return errors.New("spec.route.firstChunkSLO.window must be greater than 0")
+			}
+		}
+	}
+
+	if modelRoute.Spec.Route != nil && modelRoute.Spec.Route.Canary != nil {
+		if err := validateModelRouteCanary(modelRoute.Spec.Route); err != nil {
+			return err
+		}
 	}
 
 	if modelRoute.Spec.Fallback != nil {
@@ -360,9 +454,31 @@ func (r *ModelRouteReconciler) reconcileValidator(ctx context.Context, modelRout
 			return errors.New("spec.fallback.preDelay must be greater than or equal to 0")
 		}
 
-		if modelRoute.Spec.Fallback.MaxRetires != nil && *modelRoute.Spec.Fallback.MaxRetires <= 0 {
+		if modelRoute.Spec.Fallback.MaxRetries != nil && *modelRoute.Spec.Fallback.MaxRetries <= 0 {
 			return errors.New("spec.fallback.maxRetries must be greater than 0")
 		}
+
+		if len(lo.FindDuplicatesBy(modelRoute.Spec.Fallback.OnErrors, func(onError llmv1alpha1.ModelRouteErrorFallback) llmv1alpha1.FallbackErrorClass {
+			return onError.Class
+		})) > 0 {
+			return errors.New("spec.fallback.onErrors.[].class must be unique")
+		}
+	}
+
+	if retryPolicy := modelRoute.Spec.RetryPolicy; retryPolicy != nil {
+		if backoff := retryPolicy.Backoff; backoff != nil {
+			if backoff.BaseInterval != nil && backoff.BaseInterval.Duration <= 0 {
+				return errors.New("spec.retryPolicy.backoff.baseInterval must be greater than 0")
+			}
+
+			if backoff.MaxInterval != nil && backoff.MaxInterval.Duration <= 0 {
+				return errors.New("spec.retryPolicy.backoff.maxInterval must be greater than 0")
+			}
+		}
+
+		if budget := retryPolicy.Budget; budget != nil && budget.Window != nil && budget.Window.Duration < time.Second {
+			return errors.New("spec.retryPolicy.budget.window must be at least 1s")
+		}
 	}
 
 	allExistingBackend := &llmv1alpha1.ModelRouteList{}
@@ -376,7 +492,7 @@ func (r *ModelRouteReconciler) reconcileValidator(ctx context.Context, modelRout
 		}
 	}
 
-	crdTargets := r.getModelRouteTargets(modelRoute)
+	crdTargets := r.getModelRouteBackendTargets(modelRoute)
 
 	mBackends, err := r.mapCRDTargetsToBackends(ctx, crdTargets)
 	if err != nil {
@@ -436,6 +552,77 @@ func (r *ModelRouteReconciler) getModelRouteTargets(modelRoute *llmv1alpha1.Mode
 	return make([]*routev1alpha1.RouteTarget, 0)
 }
 
+// getModelRouteMirrorTarget returns the shadow backend of the mirror as a
+// target, nil if the ModelRoute has no mirror.
+func (r *ModelRouteReconciler) getModelRouteMirrorTarget(modelRoute *llmv1alpha1.ModelRoute) *routev1alpha1.RouteTarget {
+	if modelRoute.Spec.Mirror == nil {
+		return nil
+	}
+
+	return &routev1alpha1.RouteTarget{
+		Destination: &routev1alpha1.RouteDestination{
+			Namespace: lo.CoalesceOrEmpty(modelRoute.Spec.Mirror.Namespace, modelRoute.GetNamespace()),
+			Backend:   modelRoute.Spec.Mirror.Backend,
+		},
+	}
+}
+
+// getModelRouteFallbackTargets returns the backends the requests failed with
+// the class of errors are failed over to as targets.
+func (r *ModelRouteReconciler) getModelRouteFallbackTargets(modelRoute *llmv1alpha1.ModelRoute, onError llmv1alpha1.ModelRouteErrorFallback) []*routev1alpha1.RouteTarget {
+	return lo.Map(onError.Backends, func(backend llmv1alpha1.ModelRouteFallbackBackend, _ int) *routev1alpha1.RouteTarget {
+		return &routev1alpha1.RouteTarget{
+			Destination: &routev1alpha1.RouteDestination{
+				Namespace: lo.CoalesceOrEmpty(backend.Namespace, modelRoute.GetNamespace()),
+				Backend:   backend.Backend,
+			},
+		}
+	})
+}
+
+// getModelRouteBackendTargets returns the targets along with the shadow
+// backend of the mirror and the backends failed over to, all the backends the
+// route refers to.
+func (r *ModelRouteReconciler) getModelRouteBackendTargets(modelRoute *llmv1alpha1.ModelRoute) []*routev1alpha1.RouteTarget {
+	targets := r.getModelRouteTargets(modelRoute)
+
+	if mirrorTarget := r.getModelRouteMirrorTarget(modelRoute); mirrorTarget != nil {
+		targets = append(targets, mirrorTarget)
+	}
+
+	if modelRoute.Spec.Fallback != nil {
+		for _, onError := range modelRoute.Spec.Fallback.OnErrors {
+			targets = append(targets, r.getModelRouteFallbackTargets(modelRoute, onError)...)
+		}
+	}
+
+	return targets
+}
+
+// buildMirror maps the shadow backend of the mirror to its cluster, the
+// requests are not mirrored while the backend is missing or unhealthy.
+func (r *ModelRouteReconciler) buildMirror(modelRoute *llmv1alpha1.ModelRoute, mBackends map[string]Backend) *routev1alpha1.RouteMirror {
+	mirrorTarget := r.getModelRouteMirrorTarget(modelRoute)
+	if mirrorTarget == nil {
+		return nil
+	}
+
+	targets := r.mapModelRouteTargetsToBackends([]*routev1alpha1.RouteTarget{mirrorTarget}, mBackends)
+	if len(targets) == 0 {
+		return nil
+	}
+
+	mirror := &routev1alpha1.RouteMirror{
+		Destination: targets[0].GetDestination(),
+	}
+
+	if modelRoute.Spec.Mirror.Percent != nil {
+		mirror.Percent = lo.ToPtr(uint32(max(*modelRoute.Spec.Mirror.Percent, 0)))
+	}
+
+	return mirror
+}
+
 func (r *ModelRouteReconciler) mapModelRouteTargetsToBackends(targets []*routev1alpha1.RouteTarget, mBackends map[string]Backend) []*routev1alpha1.RouteTarget {
 	backends := make([]*routev1alpha1.RouteTarget, 0, len(targets))
 
@@ -446,7 +633,7 @@ func (r *ModelRouteReconciler) mapModelRouteTargetsToBackends(targets []*routev1
 		}
 
 		backend, ok := mBackends[nsName.String()]
-		if !ok || lo.IsNil(backend) {
+		if !ok || lo.IsNil(backend) || isUpstreamUnhealthy(backend) {
 			continue
 		}
 
@@ -490,12 +677,231 @@ func (r *ModelRouteReconciler) buildRateLimitPolicies(rateLimits []*llmv1alpha1.
 			Limit:    int32(rateLimit.Limit),
 			Duration: durationpb.New(time.Duration(rateLimit.Duration) * time.Second),
 			Match:    pMatch,
+			Unit:     MapCRDRateLimitUnitConfigRateLimitUnit(rateLimit.Unit),
+			Prepaid:  rateLimit.Prepaid,
 		})
 	}
 
 	return res
 }
 
+func (r *ModelRouteReconciler) buildConcurrencyLimitPolicies(rules []*llmv1alpha1.ConcurrencyLimitRule) []*filtersv1alpha1.ConcurrencyLimitPolicy {
+	res := make([]*filtersv1alpha1.ConcurrencyLimitPolicy, 0, len(rules))
+
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+
+		policy := &filtersv1alpha1.ConcurrencyLimitPolicy{
+			BasedOn:     MapCRDConcurrencyLimitBasedOnConfigConcurrencyLimitBaseOn(rule.BasedOn),
+			MaxInFlight: rule.MaxInFlight,
+			MaxQueued:   rule.MaxQueued,
+		}
+
+		if rule.Match != nil {
+			if rule.Match.Exact != "" {
+				policy.Match = &filtersv1alpha1.StringMatch{
+					Match: &filtersv1alpha1.StringMatch_Exact{Exact: rule.Match.Exact},
+				}
+			} else if rule.Match.Prefix != "" {
+				policy.Match = &filtersv1alpha1.StringMatch{
+					Match: &filtersv1alpha1.StringMatch_Prefix{Prefix: rule.Match.Prefix},
+				}
+			}
+		}
+
+		if rule.QueueTimeout != nil {
+			policy.QueueTimeout = durationpb.New(rule.QueueTimeout.Duration)
+		}
+
+		if rule.RetryAfter != nil {
+			policy.RetryAfter = durationpb.New(rule.RetryAfter.Duration)
+		}
+
+		res = append(res, policy)
+	}
+
+	return res
+}
+
+func (r *ModelRouteReconciler) buildResponseCacheConfig(cache *llmv1alpha1.CachePolicy) (*filtersv1alpha1.ResponseCacheConfig, error) {
+	res := &filtersv1alpha1.ResponseCacheConfig{
+		Mode:    MapCRDCacheModeConfigResponseCacheMode(cache.Mode),
+		PerUser: cache.PerUser,
+	}
+
+	if cache.TTL != nil {
+		res.Ttl = durationpb.New(time.Duration(*cache.TTL) * time.Second)
+	}
+
+	if cache.MaxEntries != nil {
+		res.MaxEntries = *cache.MaxEntries
+	}
+
+	if cache.SimilarityThreshold != "" {
+		threshold, err := strconv.ParseFloat(cache.SimilarityThreshold, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid similarity threshold %q: %w", cache.SimilarityThreshold, err)
+		}
+
+		res.SimilarityThreshold = float32(threshold)
+	}
+
+	if res.GetMode() == filtersv1alpha1.ResponseCacheMode_SEMANTIC && cache.Embedding == nil {
+		return nil, errors.New("embedding is required in Semantic mode of cache filter")
+	}
+
+	if cache.Embedding != nil {
+		res.Embedding = &filtersv1alpha1.ResponseCacheEmbedding{
+			Url:   cache.Embedding.URL,
+			Model: cache.Embedding.Model,
+			Headers: lo.SliceToMap(cache.Embedding.Headers, func(header llmv1alpha1.Header) (string, string) {
+				return header.Key, header.Value
+			}),
+		}
+	}
+
+	return res, nil
+}
+
+func (r *ModelRouteReconciler) buildPromptCompressionConfig(compression *llmv1alpha1.PromptCompressionPolicy) (*filtersv1alpha1.PromptCompressionConfig, error) {
+	res := &filtersv1alpha1.PromptCompressionConfig{
+		CollapseWhitespace: compression.CollapseWhitespace,
+		Deduplicate:        compression.Deduplicate,
+	}
+
+	if compression.MinPromptTokens != nil {
+		res.MinPromptTokens = uint64(max(*compression.MinPromptTokens, 0))
+	}
+
+	if compression.MaxCompressionRatio != "" {
+		ratio, err := strconv.ParseFloat(compression.MaxCompressionRatio, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max compression ratio %q: %w", compression.MaxCompressionRatio, err)
+		}
+
+		res.MaxCompressionRatio = float32(ratio)
+	}
+
+	if summarization := compression.Summarization; summarization != nil {
+		res.Summarization = &filtersv1alpha1.PromptCompressionSummarization{
+			Url:   summarization.URL,
+			Model: summarization.Model,
+			Headers: lo.SliceToMap(summarization.Headers, func(header llmv1alpha1.Header) (string, string) {
+				return header.Key, header.Value
+			}),
+			KeepLastMessages: uint32(max(lo.FromPtr(summarization.KeepLastMessages), 0)),
+		}
+
+		if summarization.Timeout != nil {
+			res.Summarization.Timeout = durationpb.New(time.Duration(*summarization.Timeout) * time.Second)
+		}
+	}
+
+	return res, nil
+}
+
+var retryErrorClasses = map[llmv1alpha1.RetryErrorClass]routev1alpha1.RetryErrorClass{
+	llmv1alpha1.RetryErrorClassServerError:       routev1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_SERVER_ERROR,
+	llmv1alpha1.RetryErrorClassRateLimited:       routev1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_RATE_LIMITED,
+	llmv1alpha1.RetryErrorClassConnectionFailure: routev1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_CONNECTION_FAILURE,
+	llmv1alpha1.RetryErrorClassTimeout:           routev1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_TIMEOUT,
+}
+
+var fallbackErrorClasses = map[llmv1alpha1.FallbackErrorClass]routev1alpha1.FallbackErrorClass{
+	llmv1alpha1.FallbackErrorClassContentFilter:     routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_CONTENT_FILTER,
+	llmv1alpha1.FallbackErrorClassInsufficientQuota: routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_INSUFFICIENT_QUOTA,
+	llmv1alpha1.FallbackErrorClassRateLimited:       routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_RATE_LIMITED,
+}
+
+// buildErrorFallbacks maps the backends failed over to to their clusters,
+// the ones missing or unhealthy are skipped. Classes of errors with all their
+// backends skipped are still failed over, to the other targets of the route.
+func (r *ModelRouteReconciler) buildErrorFallbacks(modelRoute *llmv1alpha1.ModelRoute, mBackends map[string]Backend) []*routev1alpha1.ErrorFallback {
+	return lo.FilterMap(modelRoute.Spec.Fallback.OnErrors, func(onError llmv1alpha1.ModelRouteErrorFallback, _ int) (*routev1alpha1.ErrorFallback, bool) {
+		class, ok := fallbackErrorClasses[onError.Class]
+		if !ok {
+			return nil, false
+		}
+
+		targets := r.mapModelRouteTargetsToBackends(r.getModelRouteFallbackTargets(modelRoute, onError), mBackends)
+
+		return &routev1alpha1.ErrorFallback{
+			Class: class,
+			Destinations: lo.Map(targets, func(target *routev1alpha1.RouteTarget, _ int) *routev1alpha1.RouteDestination {
+				return target.GetDestination()
+			}),
+		}, true
+	})
+}
+
+func (r *ModelRouteReconciler) buildRetryPolicy(policy *llmv1alpha1.ModelRouteRetryPolicy) *routev1alpha1.RetryPolicy {
+	res := &routev1alpha1.RetryPolicy{
+		RetryOn: lo.FilterMap(policy.RetryOn, func(class llmv1alpha1.RetryErrorClass, _ int) (routev1alpha1.RetryErrorClass, bool) {
+			c, ok := retryErrorClasses[class]
+			return c, ok
+		}),
+		RetryOnStatuses: lo.Map(policy.RetryOnStatuses, func(status int32, _ int) uint32 {
+			return uint32(max(status, 0))
+		}),
+	}
+
+	if policy.MaxRetries != nil {
+		res.MaxRetries = lo.ToPtr(uint64(max(*policy.MaxRetries, 1)))
+	}
+
+	if backoff := policy.Backoff; backoff != nil {
+		res.Backoff = &routev1alpha1.RetryBackoff{}
+
+		if backoff.BaseInterval != nil {
+			res.Backoff.BaseInterval = durationpb.New(backoff.BaseInterval.Duration)
+		}
+
+		if backoff.MaxInterval != nil {
+			res.Backoff.MaxInterval = durationpb.New(backoff.MaxInterval.Duration)
+		}
+	}
+
+	if budget := policy.Budget; budget != nil {
+		res.Budget = &routev1alpha1.RetryBudget{
+			RetryPercent: uint32(max(budget.RetryPercent, 0)),
+		}
+
+		if budget.MinRetriesPerSecond != nil {
+			res.Budget.MinRetriesPerSecond = lo.ToPtr(uint32(max(*budget.MinRetriesPerSecond, 0)))
+		}
+
+		if budget.Window != nil {
+			res.Budget.Window = durationpb.New(budget.Window.Duration)
+		}
+	}
+
+	return res
+}
+
+func visionConfigFromSpec(policy *llmv1alpha1.VisionPolicy) *filtersv1alpha1.VisionConfig {
+	return &filtersv1alpha1.VisionConfig{
+		MaxImages:         uint32(max(lo.FromPtr(policy.MaxImages), 0)),
+		MaxImageSizeBytes: uint64(max(lo.FromPtr(policy.MaxImageSizeBytes), 0)),
+		MaxDimension:      uint32(max(lo.FromPtr(policy.MaxDimension), 0)),
+		DenyRemoteUrls:    policy.DenyRemoteURLs,
+	}
+}
+
+var seedPolicyModes = map[llmv1alpha1.SeedPolicyMode]routev1alpha1.SeedPolicyMode{
+	llmv1alpha1.SeedPolicyModePassThrough: routev1alpha1.SeedPolicyMode_SEED_POLICY_MODE_PASS_THROUGH,
+	llmv1alpha1.SeedPolicyModeForce:       routev1alpha1.SeedPolicyMode_SEED_POLICY_MODE_FORCE,
+	llmv1alpha1.SeedPolicyModeStrip:       routev1alpha1.SeedPolicyMode_SEED_POLICY_MODE_STRIP,
+}
+
+func (r *ModelRouteReconciler) buildSeedPolicy(policy *llmv1alpha1.ModelRouteSeedPolicy) *routev1alpha1.SeedPolicy {
+	return &routev1alpha1.SeedPolicy{
+		Mode: seedPolicyModes[policy.Mode],
+		Seed: lo.FromPtr(policy.Seed),
+	}
+}
+
 func (r *ModelRouteReconciler) toRegisterRouteConfig(_ context.Context, modelRoute *llmv1alpha1.ModelRoute, mBackends map[string]Backend) (*routev1alpha1.Route, error) {
 	if modelRoute == nil {
 		return nil, errors.New("modelRoute cannot be nil")
@@ -524,9 +930,119 @@ func (r *ModelRouteReconciler) toRegisterRouteConfig(_ context.Context, modelRou
 					Policies: r.buildRateLimitPolicies(filter.RateLimit.Rules),
 				})),
 			})
+		case llmv1alpha1.FilterTypeCache:
+			if filter.Cache == nil {
+				return nil, errors.New("cache filter cannot be nil")
+			}
+
+			cacheConfig, err := r.buildResponseCacheConfig(filter.Cache)
+			if err != nil {
+				return nil, err
+			}
+
+			name, _ := lo.Coalesce(filter.Name, "route-response-cache")
+			filters = append(filters, &routev1alpha1.RouteFilter{
+				Name:   name,
+				Config: lo.Must(anypb.New(cacheConfig)),
+			})
+		case llmv1alpha1.FilterTypeConcurrencyLimit:
+			if filter.ConcurrencyLimit == nil {
+				return nil, errors.New("concurrency limit filter cannot be nil")
+			}
+
+			name, _ := lo.Coalesce(filter.Name, "route-concurrency-limits")
+			filters = append(filters, &routev1alpha1.RouteFilter{
+				Name: name,
+				Config: lo.Must(anypb.New(&filtersv1alpha1.ConcurrencyLimitConfig{
+					Policies: r.buildConcurrencyLimitPolicies(filter.ConcurrencyLimit.Rules),
+				})),
+			})
+		case llmv1alpha1.FilterTypePromptCompression:
+			if filter.PromptCompression == nil {
+				return nil, errors.New("prompt compression filter cannot be nil")
+			}
+
+			compressionConfig, err := r.buildPromptCompressionConfig(filter.PromptCompression)
+			if err != nil {
+				return nil, err
+			}
+
+			name, _ := lo.Coalesce(filter.Name, "route-prompt-compression")
+			filters = append(filters, &routev1alpha1.RouteFilter{
+				Name:   name,
+				Config: lo.Must(anypb.New(compressionConfig)),
+			})
+		case llmv1alpha1.FilterTypeImagePromptPolicy:
+			if filter.ImagePromptPolicy == nil {
+				return nil, errors.New("image prompt policy filter cannot be nil")
+			}
+
+			policy := filter.ImagePromptPolicy
+
+			name, _ := lo.Coalesce(filter.Name, "route-image-prompt-policy")
+			filters = append(filters, &routev1alpha1.RouteFilter{
+				Name: name,
+				Config: lo.Must(anypb.New(&filtersv1alpha1.ImagePromptPolicyConfig{
+					Prefix:              policy.Prefix,
+					Suffix:              policy.Suffix,
+					NegativePrompts:     policy.NegativePrompts,
+					NegativePromptParam: policy.NegativePromptParam,
+					BannedTerms:         policy.BannedTerms,
+				})),
+			})
+		case llmv1alpha1.FilterTypePromptTemplate:
+			if filter.PromptTemplate == nil {
+				return nil, errors.New("prompt template filter cannot be nil")
+			}
+
+			name, _ := lo.Coalesce(filter.Name, "route-prompt-template")
+			filters = append(filters, &routev1alpha1.RouteFilter{
+				Name:   name,
+				Config: lo.Must(anypb.New(promptTemplateFromSpec(filter.PromptTemplate))),
+			})
+		case llmv1alpha1.FilterTypeVision:
+			if filter.Vision == nil {
+				return nil, errors.New("vision filter cannot be nil")
+			}
+
+			name, _ := lo.Coalesce(filter.Name, "route-vision")
+			filters = append(filters, &routev1alpha1.RouteFilter{
+				Name:   name,
+				Config: lo.Must(anypb.New(visionConfigFromSpec(filter.Vision))),
+			})
 		default:
 			return nil, fmt.Errorf("unknown filter type: %s", filter.Type)
 		}
+
+		filters[len(filters)-1].FeatureFlag = filter.FeatureFlag
+	}
+
+	var firstChunkSLO *routev1alpha1.FirstChunkSLO
+	if modelRoute.Spec.Route != nil && modelRoute.Spec.Route.FirstChunkSLO != nil {
+		slo := modelRoute.Spec.Route.FirstChunkSLO
+		firstChunkSLO = &routev1alpha1.FirstChunkSLO{
+			P95: durationpb.New(slo.P95.Duration),
+		}
+
+		if slo.Window != nil {
+			firstChunkSLO.Window = durationpb.New(slo.Window.Duration)
+		}
+
+		if slo.MinWeightPercent != nil {
+			firstChunkSLO.MinWeightPercent = lo.ToPtr(uint32(*slo.MinWeightPercent))
+		}
+
+		if slo.RecoveryStepPercent != nil {
+			firstChunkSLO.RecoveryStepPercent = lo.ToPtr(uint32(*slo.RecoveryStepPercent))
+		}
+	}
+
+	var sessionAffinity *routev1alpha1.SessionAffinity
+	if modelRoute.Spec.Route != nil && modelRoute.Spec.Route.SessionAffinity != nil {
+		sessionAffinity = &routev1alpha1.SessionAffinity{
+			Header:    modelRoute.Spec.Route.SessionAffinity.Header,
+			UserField: modelRoute.Spec.Route.SessionAffinity.UserField,
+		}
 	}
 
 	var fallback *routev1alpha1.RouteFallback
@@ -541,9 +1057,21 @@ func (r *ModelRouteReconciler) toRegisterRouteConfig(_ context.Context, modelRou
 			fallback.PostDelay = durationpb.New(time.Duration(*modelRoute.Spec.Fallback.PostDelay) * time.Second)
 		}
 
-		if modelRoute.Spec.Fallback.MaxRetires != nil {
-			fallback.MaxRetries = modelRoute.Spec.Fallback.MaxRetires
+		if modelRoute.Spec.Fallback.MaxRetries != nil {
+			fallback.MaxRetries = modelRoute.Spec.Fallback.MaxRetries
 		}
+
+		fallback.OnErrors = r.buildErrorFallbacks(modelRoute, mBackends)
+	}
+
+	var retryPolicy *routev1alpha1.RetryPolicy
+	if modelRoute.Spec.RetryPolicy != nil {
+		retryPolicy = r.buildRetryPolicy(modelRoute.Spec.RetryPolicy)
+	}
+
+	var seedPolicy *routev1alpha1.SeedPolicy
+	if modelRoute.Spec.SeedPolicy != nil {
+		seedPolicy = r.buildSeedPolicy(modelRoute.Spec.SeedPolicy)
 	}
 
 	return &routev1alpha1.Route{
@@ -558,9 +1086,15 @@ func (r *ModelRouteReconciler) toRegisterRouteConfig(_ context.Context, modelRou
 			},
 		},
 		LoadBalancePolicy: loadBalancePolicy,
-		Targets:           r.mapModelRouteTargetsToBackends(r.getModelRouteTargets(modelRoute), mBackends),
+		Targets:           r.mapModelRouteTargetsToBackends(applyCanaryWeights(modelRoute, r.getModelRouteTargets(modelRoute)), mBackends),
 		Filters:           filters,
 		Fallback:          fallback,
+		FirstChunkSlo:     firstChunkSLO,
+		RetryPolicy:       retryPolicy,
+		SeedPolicy:        seedPolicy,
+		Timeout:           durationFromSeconds(modelRoute.Spec.Timeout),
+		Mirror:            r.buildMirror(modelRoute, mBackends),
+		SessionAffinity:   sessionAffinity,
 	}, nil
 }
 
@@ -568,6 +1102,11 @@ func (r *ModelRouteReconciler) toRegisterRouteConfig(_ context.Context, modelRou
 func (r *ModelRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&llmv1alpha1.ModelRoute{}).
+		Watches(&llmv1alpha1.LLMBackend{}, handler.EnqueueRequestsFromMapFunc(backendToModelRoutes(r.Client))).
+		Watches(&llmv1alpha1.ImageGenerationBackend{}, handler.EnqueueRequestsFromMapFunc(backendToModelRoutes(r.Client))).
+		Watches(&llmv1alpha1.EmbeddingBackend{}, handler.EnqueueRequestsFromMapFunc(backendToModelRoutes(r.Client))).
+		Watches(&llmv1alpha1.RerankBackend{}, handler.EnqueueRequestsFromMapFunc(backendToModelRoutes(r.Client))).
+		Watches(&llmv1alpha1.VideoGenerationBackend{}, handler.EnqueueRequestsFromMapFunc(backendToModelRoutes(r.Client))).
 		Named("modelroute").
 		Complete(r)
 }
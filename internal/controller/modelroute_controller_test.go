@@ -0,0 +1,393 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	filtersv1alpha1 "knoway.dev/api/filters/v1alpha1"
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	"knoway.dev/api/v1alpha1"
+	"knoway.dev/pkg/protoutils"
+)
+
+func TestModelRouteFallback_MaxRetries(t *testing.T) {
+	r := &ModelRouteReconciler{}
+
+	cases := []struct {
+		name     string
+		fallback *v1alpha1.ModelRouteFallback
+		expected *uint64
+	}{
+		{
+			name:     "maxRetries",
+			fallback: &v1alpha1.ModelRouteFallback{MaxRetries: lo.ToPtr[uint64](2)},
+			expected: lo.ToPtr[uint64](2),
+		},
+		{
+			name:     "unset",
+			fallback: &v1alpha1.ModelRouteFallback{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			modelRoute := &v1alpha1.ModelRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4o"},
+				Spec: v1alpha1.ModelRouteSpec{
+					ModelName: "gpt-4o",
+					Fallback:  c.fallback,
+				},
+			}
+
+			route, err := r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+			require.NoError(t, err)
+			require.NotNil(t, route.GetFallback())
+			assert.Equal(t, c.expected, route.GetFallback().MaxRetries)
+		})
+	}
+}
+
+func TestModelRouteFilter_PromptCompression(t *testing.T) {
+	r := &ModelRouteReconciler{}
+
+	modelRoute := &v1alpha1.ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4o"},
+		Spec: v1alpha1.ModelRouteSpec{
+			ModelName: "gpt-4o",
+			Filters: []v1alpha1.ModelRouteFilter{
+				{
+					Type:        v1alpha1.FilterTypePromptCompression,
+					FeatureFlag: "prompt-compression",
+					PromptCompression: &v1alpha1.PromptCompressionPolicy{
+						CollapseWhitespace:  true,
+						MinPromptTokens:     lo.ToPtr[int64](1000),
+						MaxCompressionRatio: "0.5",
+						Summarization: &v1alpha1.PromptSummarization{
+							URL:     "http://qwen-mini:8000/v1/chat/completions",
+							Model:   "qwen-mini",
+							Headers: []v1alpha1.Header{{Key: "Authorization", Value: "Bearer sk-test"}},
+							Timeout: lo.ToPtr[int64](5),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	route, err := r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.NoError(t, err)
+	require.Len(t, route.GetFilters(), 1)
+	assert.Equal(t, "route-prompt-compression", route.GetFilters()[0].GetName())
+	assert.Equal(t, "prompt-compression", route.GetFilters()[0].GetFeatureFlag())
+
+	cfg, err := protoutils.FromAny(route.GetFilters()[0].GetConfig(), &filtersv1alpha1.PromptCompressionConfig{})
+	require.NoError(t, err)
+	assert.True(t, cfg.GetCollapseWhitespace())
+	assert.False(t, cfg.GetDeduplicate())
+	assert.Equal(t, uint64(1000), cfg.GetMinPromptTokens())
+	assert.InDelta(t, 0.5, cfg.GetMaxCompressionRatio(), 1e-6)
+	assert.Equal(t, "qwen-mini", cfg.GetSummarization().GetModel())
+	assert.Equal(t, map[string]string{"Authorization": "Bearer sk-test"}, cfg.GetSummarization().GetHeaders())
+	assert.Equal(t, 5*time.Second, cfg.GetSummarization().GetTimeout().AsDuration())
+
+	modelRoute.Spec.Filters[0].PromptCompression = nil
+
+	_, err = r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.EqualError(t, err, "prompt compression filter cannot be nil")
+}
+
+func TestModelRouteFilter_ImagePromptPolicy(t *testing.T) {
+	r := &ModelRouteReconciler{}
+
+	modelRoute := &v1alpha1.ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "sdxl"},
+		Spec: v1alpha1.ModelRouteSpec{
+			ModelName: "sdxl",
+			Filters: []v1alpha1.ModelRouteFilter{
+				{
+					Type: v1alpha1.FilterTypeImagePromptPolicy,
+					ImagePromptPolicy: &v1alpha1.ImagePromptPolicy{
+						Prefix:              "Corporate illustration of",
+						NegativePrompts:     []string{"competitor logos"},
+						NegativePromptParam: "negative_prompt",
+						BannedTerms:         []string{"violence"},
+					},
+				},
+			},
+		},
+	}
+
+	route, err := r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.NoError(t, err)
+	require.Len(t, route.GetFilters(), 1)
+	assert.Equal(t, "route-image-prompt-policy", route.GetFilters()[0].GetName())
+
+	cfg, err := protoutils.FromAny(route.GetFilters()[0].GetConfig(), &filtersv1alpha1.ImagePromptPolicyConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "Corporate illustration of", cfg.GetPrefix())
+	assert.Empty(t, cfg.GetSuffix())
+	assert.Equal(t, []string{"competitor logos"}, cfg.GetNegativePrompts())
+	assert.Equal(t, "negative_prompt", cfg.GetNegativePromptParam())
+	assert.Equal(t, []string{"violence"}, cfg.GetBannedTerms())
+
+	modelRoute.Spec.Filters[0].ImagePromptPolicy = nil
+
+	_, err = r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.EqualError(t, err, "image prompt policy filter cannot be nil")
+}
+
+func TestModelRouteFilter_PromptTemplate(t *testing.T) {
+	r := &ModelRouteReconciler{}
+
+	modelRoute := &v1alpha1.ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4o"},
+		Spec: v1alpha1.ModelRouteSpec{
+			ModelName: "gpt-4o",
+			Filters: []v1alpha1.ModelRouteFilter{
+				{
+					Type: v1alpha1.FilterTypePromptTemplate,
+					PromptTemplate: &v1alpha1.PromptTemplate{
+						SystemPrompt: "You are the assistant of {{user_id}}.",
+						UserSuffix:   "Answer in English.",
+						DateFormat:   "Jan 2, 2006",
+					},
+				},
+			},
+		},
+	}
+
+	route, err := r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.NoError(t, err)
+	require.Len(t, route.GetFilters(), 1)
+	assert.Equal(t, "route-prompt-template", route.GetFilters()[0].GetName())
+
+	cfg, err := protoutils.FromAny(route.GetFilters()[0].GetConfig(), &filtersv1alpha1.PromptTemplateConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "You are the assistant of {{user_id}}.", cfg.GetSystemPrompt())
+	assert.Equal(t, filtersv1alpha1.PromptTemplateConfig_SYSTEM_PROMPT_MODE_UNSPECIFIED, cfg.GetSystemPromptMode())
+	assert.Equal(t, "Answer in English.", cfg.GetUserSuffix())
+	assert.Equal(t, "Jan 2, 2006", cfg.GetDateFormat())
+
+	modelRoute.Spec.Filters[0].PromptTemplate = nil
+
+	_, err = r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.EqualError(t, err, "prompt template filter cannot be nil")
+}
+
+func TestModelRouteRetryPolicy(t *testing.T) {
+	r := &ModelRouteReconciler{}
+
+	modelRoute := &v1alpha1.ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4o"},
+		Spec: v1alpha1.ModelRouteSpec{
+			ModelName: "gpt-4o",
+			RetryPolicy: &v1alpha1.ModelRouteRetryPolicy{
+				MaxRetries:      lo.ToPtr[int32](2),
+				RetryOn:         []v1alpha1.RetryErrorClass{v1alpha1.RetryErrorClassRateLimited, v1alpha1.RetryErrorClassTimeout},
+				RetryOnStatuses: []int32{503},
+				Backoff: &v1alpha1.ModelRouteRetryBackoff{
+					BaseInterval: &metav1.Duration{Duration: 100 * time.Millisecond},
+				},
+				Budget: &v1alpha1.ModelRouteRetryBudget{
+					RetryPercent: 20,
+					Window:       &metav1.Duration{Duration: 30 * time.Second},
+				},
+			},
+		},
+	}
+
+	route, err := r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.NoError(t, err)
+
+	policy := route.GetRetryPolicy()
+	require.NotNil(t, policy)
+	assert.Equal(t, uint64(2), policy.GetMaxRetries())
+	assert.Equal(t, []routev1alpha1.RetryErrorClass{
+		routev1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_RATE_LIMITED,
+		routev1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_TIMEOUT,
+	}, policy.GetRetryOn())
+	assert.Equal(t, []uint32{503}, policy.GetRetryOnStatuses())
+	assert.Equal(t, 100*time.Millisecond, policy.GetBackoff().GetBaseInterval().AsDuration())
+	assert.Nil(t, policy.GetBackoff().GetMaxInterval())
+	assert.Equal(t, uint32(20), policy.GetBudget().GetRetryPercent())
+	assert.Nil(t, policy.GetBudget().MinRetriesPerSecond)
+	assert.Equal(t, 30*time.Second, policy.GetBudget().GetWindow().AsDuration())
+
+	modelRoute.Spec.RetryPolicy = nil
+
+	route, err = r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.NoError(t, err)
+	assert.Nil(t, route.GetRetryPolicy())
+}
+
+func TestModelRouteSeedPolicy(t *testing.T) {
+	r := &ModelRouteReconciler{}
+
+	modelRoute := &v1alpha1.ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4o"},
+		Spec: v1alpha1.ModelRouteSpec{
+			ModelName: "gpt-4o",
+			SeedPolicy: &v1alpha1.ModelRouteSeedPolicy{
+				Mode: v1alpha1.SeedPolicyModeForce,
+				Seed: lo.ToPtr[int64](42),
+			},
+		},
+	}
+
+	route, err := r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.NoError(t, err)
+	assert.Equal(t, routev1alpha1.SeedPolicyMode_SEED_POLICY_MODE_FORCE, route.GetSeedPolicy().GetMode())
+	assert.Equal(t, int64(42), route.GetSeedPolicy().GetSeed())
+
+	modelRoute.Spec.SeedPolicy = &v1alpha1.ModelRouteSeedPolicy{Mode: v1alpha1.SeedPolicyModeStrip}
+
+	route, err = r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.NoError(t, err)
+	assert.Equal(t, routev1alpha1.SeedPolicyMode_SEED_POLICY_MODE_STRIP, route.GetSeedPolicy().GetMode())
+	assert.Zero(t, route.GetSeedPolicy().GetSeed())
+
+	modelRoute.Spec.SeedPolicy = nil
+
+	route, err = r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.NoError(t, err)
+	assert.Nil(t, route.GetSeedPolicy())
+}
+
+func TestModelRouteTimeout(t *testing.T) {
+	r := &ModelRouteReconciler{}
+
+	modelRoute := &v1alpha1.ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4o"},
+		Spec: v1alpha1.ModelRouteSpec{
+			ModelName: "gpt-4o",
+			Timeout:   30,
+		},
+	}
+
+	route, err := r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, route.GetTimeout().AsDuration())
+
+	modelRoute.Spec.Timeout = 0
+
+	route, err = r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.NoError(t, err)
+	assert.Nil(t, route.GetTimeout())
+}
+
+func TestModelRouteMirror(t *testing.T) {
+	r := &ModelRouteReconciler{}
+
+	modelRoute := &v1alpha1.ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4o"},
+		Spec: v1alpha1.ModelRouteSpec{
+			ModelName: "gpt-4o",
+			Mirror: &v1alpha1.ModelRouteMirror{
+				Backend: "gpt-4-1",
+				Percent: lo.ToPtr[int32](10),
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"default/gpt-4-1"}, lo.Map(r.getModelRouteBackendTargets(modelRoute), func(target *routev1alpha1.RouteTarget, _ int) string {
+		return target.GetDestination().GetNamespace() + "/" + target.GetDestination().GetBackend()
+	}))
+
+	// Not mirrored while the backend is missing
+	route, err := r.toRegisterRouteConfig(context.Background(), modelRoute, map[string]Backend{"default/gpt-4-1": nil})
+	require.NoError(t, err)
+	assert.Nil(t, route.GetMirror())
+
+	backend := BackendFromLLMBackend(&v1alpha1.LLMBackend{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4-1"},
+		Spec:       v1alpha1.LLMBackendSpec{ModelName: lo.ToPtr("gpt-4.1")},
+	})
+
+	route, err = r.toRegisterRouteConfig(context.Background(), modelRoute, map[string]Backend{"default/gpt-4-1": backend})
+	require.NoError(t, err)
+	assert.Equal(t, "gpt-4.1", route.GetMirror().GetDestination().GetCluster())
+	assert.Equal(t, uint32(10), route.GetMirror().GetPercent())
+
+	modelRoute.Spec.Mirror = nil
+
+	route, err = r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.NoError(t, err)
+	assert.Nil(t, route.GetMirror())
+}
+
+func TestModelRouteErrorFallback(t *testing.T) {
+	r := &ModelRouteReconciler{}
+
+	modelRoute := &v1alpha1.ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4o"},
+		Spec: v1alpha1.ModelRouteSpec{
+			ModelName: "gpt-4o",
+			Fallback: &v1alpha1.ModelRouteFallback{
+				OnErrors: []v1alpha1.ModelRouteErrorFallback{
+					{
+						Class: v1alpha1.FallbackErrorClassContentFilter,
+						Backends: []v1alpha1.ModelRouteFallbackBackend{
+							{Backend: "openai-gpt-4o"},
+							{Namespace: "shared", Backend: "claude-sonnet-4"},
+						},
+					},
+					{Class: v1alpha1.FallbackErrorClassRateLimited},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"default/openai-gpt-4o", "shared/claude-sonnet-4"}, lo.Map(r.getModelRouteBackendTargets(modelRoute), func(target *routev1alpha1.RouteTarget, _ int) string {
+		return target.GetDestination().GetNamespace() + "/" + target.GetDestination().GetBackend()
+	}))
+
+	backend := BackendFromLLMBackend(&v1alpha1.LLMBackend{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "openai-gpt-4o"},
+		Spec:       v1alpha1.LLMBackendSpec{ModelName: lo.ToPtr("openai/gpt-4o")},
+	})
+
+	// Missing backends are skipped
+	route, err := r.toRegisterRouteConfig(context.Background(), modelRoute, map[string]Backend{"default/openai-gpt-4o": backend})
+	require.NoError(t, err)
+	require.Len(t, route.GetFallback().GetOnErrors(), 2)
+	assert.Equal(t, routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_CONTENT_FILTER, route.GetFallback().GetOnErrors()[0].GetClass())
+	require.Len(t, route.GetFallback().GetOnErrors()[0].GetDestinations(), 1)
+	assert.Equal(t, "openai/gpt-4o", route.GetFallback().GetOnErrors()[0].GetDestinations()[0].GetCluster())
+	assert.Equal(t, routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_RATE_LIMITED, route.GetFallback().GetOnErrors()[1].GetClass())
+	assert.Empty(t, route.GetFallback().GetOnErrors()[1].GetDestinations())
+
+	assert.True(t, failsOverTo(*modelRoute, &v1alpha1.LLMBackend{ObjectMeta: metav1.ObjectMeta{Namespace: "shared", Name: "claude-sonnet-4"}}))
+	assert.False(t, failsOverTo(*modelRoute, &v1alpha1.LLMBackend{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "claude-sonnet-4"}}))
+}
+
+func TestModelRouteSessionAffinity(t *testing.T) {
+	r := &ModelRouteReconciler{}
+
+	modelRoute := &v1alpha1.ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "qwen3"},
+		Spec: v1alpha1.ModelRouteSpec{
+			ModelName: "qwen3",
+			Route: &v1alpha1.ModelRouteRoute{
+				LoadBalancePolicy: v1alpha1.LoadBalancePolicyWeightedLeastRequest,
+				SessionAffinity:   &v1alpha1.ModelRouteSessionAffinity{Header: "X-Conversation-ID", UserField: true},
+			},
+		},
+	}
+
+	route, err := r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "X-Conversation-ID", route.GetSessionAffinity().GetHeader())
+	assert.True(t, route.GetSessionAffinity().GetUserField())
+
+	modelRoute.Spec.Route.SessionAffinity = nil
+
+	route, err = r.toRegisterRouteConfig(context.Background(), modelRoute, nil)
+	require.NoError(t, err)
+	assert.Nil(t, route.GetSessionAffinity())
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	knowaydevv1alpha1 "knoway.dev/api/v1alpha1"
+)
+
+// initialReconcilePollInterval is how often the objects reconciled are
+// checked while waiting for the initial reconcile.
+const initialReconcilePollInterval = 100 * time.Millisecond
+
+// modelRouteKind is the kind of ModelRoutes in the keys of reconciledObjects.
+const modelRouteKind = "ModelRoute"
+
+// reconciledObjects is the set of the objects reconciled at least once by the
+// process, keyed by reconciledKey.
+var reconciledObjects sync.Map
+
+func reconciledKey(kind string, key client.ObjectKey) string {
+	return kind + "/" + key.String()
+}
+
+// markReconciled records the object as reconciled, whether it succeeded or
+// not, the failed ones are retried by the controllers as usual.
+func markReconciled(kind string, key client.ObjectKey) {
+	reconciledObjects.Store(reconciledKey(kind, key), struct{}{})
+}
+
+// existingObjects returns the keys of the backends and the ModelRoutes
+// existing, which the controllers reconcile on start.
+func existingObjects(ctx context.Context, c client.Reader) ([]string, error) {
+	keys := make([]string, 0)
+
+	for _, keysOf := range []func() ([]string, error){
+		func() ([]string, error) { return backendKeys(ctx, c, llmBackendKind) },
+		func() ([]string, error) { return backendKeys(ctx, c, imageGenerationBackendKind) },
+		func() ([]string, error) { return backendKeys(ctx, c, embeddingBackendKind) },
+		func() ([]string, error) { return backendKeys(ctx, c, rerankBackendKind) },
+		func() ([]string, error) { return backendKeys(ctx, c, videoGenerationBackendKind) },
+	} {
+		backends, err := keysOf()
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, backends...)
+	}
+
+	modelRoutes := &knowaydevv1alpha1.ModelRouteList{}
+	if err := c.List(ctx, modelRoutes); err != nil {
+		return nil, err
+	}
+
+	for i := range modelRoutes.Items {
+		keys = append(keys, reconciledKey(modelRouteKind, client.ObjectKeyFromObject(&modelRoutes.Items[i])))
+	}
+
+	return keys, nil
+}
+
+func backendKeys[T client.Object](ctx context.Context, c client.Reader, kind backendKind[T]) ([]string, error) {
+	backends, err := kind.list(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(backends))
+	for _, b := range backends {
+		keys = append(keys, reconciledKey(kind.name, client.ObjectKeyFromObject(b)))
+	}
+
+	return keys, nil
+}
+
+// WaitForInitialReconcile blocks until the controllers of the manager have
+// reconciled every backend and ModelRoute existing once the caches are
+// synced, i.e. the clusters and the routes registered by the process are
+// complete, or ctx is done.
+func WaitForInitialReconcile(ctx context.Context, mgr manager.Manager) error {
+	if !mgr.GetCache().WaitForCacheSync(ctx) {
+		return errors.New("failed to wait for the caches to sync")
+	}
+
+	pending, err := existingObjects(ctx, mgr.GetClient())
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(initialReconcilePollInterval)
+	defer ticker.Stop()
+
+	for {
+		pending = filterUnreconciled(pending)
+		if len(pending) == 0 {
+			return nil
+		}
+
+		slog.Debug("waiting for the initial reconcile", "pending", len(pending))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func filterUnreconciled(keys []string) []string {
+	pending := keys[:0]
+
+	for _, key := range keys {
+		if _, ok := reconciledObjects.Load(key); !ok {
+			pending = append(pending, key)
+		}
+	}
+
+	return pending
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clustersv1alpha1 "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/v1alpha1"
+)
+
+func TestRerankBackendReconciler_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := NewFakeClientWithStatus()
+
+	resource := &v1alpha1.RerankBackend{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "reranker",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.RerankBackendSpec{
+			ModelName: lo.ToPtr("jina-reranker-v2-base-multilingual"),
+			Provider:  v1alpha1.ProviderOpenAI,
+			Upstream: v1alpha1.RerankBackendUpstream{
+				BaseURL: "https://api.jina.ai/v1",
+				DefaultParams: &v1alpha1.RerankModelParams{
+					OpenAI: &v1alpha1.OpenAIRerankParam{
+						TopN: lo.ToPtr(5),
+					},
+				},
+				OverrideParams: &v1alpha1.RerankModelParams{
+					OpenAI: &v1alpha1.OpenAIRerankParam{
+						Model:           "jina-reranker-v2-base-multilingual",
+						ReturnDocuments: lo.ToPtr(false),
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, fakeClient.Create(ctx, resource))
+
+	reconciler := &RerankBackendReconciler{
+		Client: fakeClient,
+	}
+
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{
+		NamespacedName: client.ObjectKeyFromObject(resource),
+	})
+	require.NoError(t, err)
+
+	clusterCfg, err := reconciler.toRegisterClusterConfig(ctx, resource)
+	require.NoError(t, err)
+
+	assert.Equal(t, clustersv1alpha1.ClusterType_RERANK, clusterCfg.GetType())
+	assert.Equal(t, clustersv1alpha1.ClusterProvider_OPEN_AI, clusterCfg.GetProvider())
+	assert.Equal(t, "jina-reranker-v2-base-multilingual", clusterCfg.GetName())
+
+	defaultParams := clusterCfg.GetUpstream().GetDefaultParams()
+	assert.InDelta(t, 5, defaultParams["top_n"].GetNumberValue(), 0.0001)
+
+	overrideParams := clusterCfg.GetUpstream().GetOverrideParams()
+	assert.Equal(t, "jina-reranker-v2-base-multilingual", overrideParams["model"].GetStringValue())
+	assert.False(t, overrideParams["return_documents"].GetBoolValue())
+	assert.Contains(t, overrideParams, "return_documents")
+}
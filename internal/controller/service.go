@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	knowaydevv1alpha1 "knoway.dev/api/v1alpha1"
+)
+
+const defaultServiceScheme = "http"
+
+// serviceUpstream is the upstream of a backend referencing a Service.
+type serviceUpstream struct {
+	// baseURL is by the DNS name of the Service, requests are sent to it when
+	// no pods of the Service are ready
+	baseURL string
+	// endpoints are the addresses of the ready pods of the Service, host:port
+	endpoints []string
+	scheme    string
+}
+
+// endpointURLs returns the endpoints as the endpoints of the upstream of the
+// cluster.
+func (s *serviceUpstream) endpointURLs() []string {
+	return lo.Map(s.endpoints, func(endpoint string, _ int) string {
+		return s.scheme + "://" + endpoint
+	})
+}
+
+// resolveServiceUpstream resolves the upstream of the Service in the
+// namespace, the endpoints are taken from the EndpointSlices of the port of
+// the Service.
+func resolveServiceUpstream(ctx context.Context, c client.Reader, namespace string, ref *knowaydevv1alpha1.UpstreamService) (*serviceUpstream, error) {
+	if ref.Name == "" {
+		return nil, errors.New("upstream.service.name cannot be empty")
+	}
+
+	service := &corev1.Service{}
+
+	err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service %s/%s: %w", namespace, ref.Name, err)
+	}
+
+	port, err := servicePort(service, ref.Port)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := lo.CoalesceOrEmpty(ref.Scheme, defaultServiceScheme)
+
+	endpointSlices := &discoveryv1.EndpointSliceList{}
+
+	err = c.List(ctx, endpointSlices, client.InNamespace(namespace), client.MatchingLabels{discoveryv1.LabelServiceName: ref.Name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpointslices of service %s/%s: %w", namespace, ref.Name, err)
+	}
+
+	return &serviceUpstream{
+		baseURL:   fmt.Sprintf("%s://%s.%s.svc:%d%s", scheme, ref.Name, namespace, port.Port, ref.Path),
+		endpoints: readyEndpoints(endpointSlices.Items, port.Name),
+		scheme:    scheme,
+	}, nil
+}
+
+// servicePort returns the port of the Service, the only one when port is 0.
+func servicePort(service *corev1.Service, port int32) (corev1.ServicePort, error) {
+	if port == 0 {
+		if len(service.Spec.Ports) != 1 {
+			return corev1.ServicePort{}, fmt.Errorf("service %s/%s has %d ports, upstream.service.port must be set", service.Namespace, service.Name, len(service.Spec.Ports))
+		}
+
+		return service.Spec.Ports[0], nil
+	}
+
+	servicePort, ok := lo.Find(service.Spec.Ports, func(p corev1.ServicePort) bool {
+		return p.Port == port
+	})
+	if !ok {
+		return corev1.ServicePort{}, fmt.Errorf("service %s/%s has no port %d", service.Namespace, service.Name, port)
+	}
+
+	return servicePort, nil
+}
+
+// readyEndpoints returns the addresses of the ready endpoints of the slices
+// with the port of the name, sorted and deduplicated so that the status of
+// backends only changes along with the endpoints.
+func readyEndpoints(endpointSlices []discoveryv1.EndpointSlice, portName string) []string {
+	var endpoints []string
+
+	for _, slice := range endpointSlices {
+		if slice.AddressType == discoveryv1.AddressTypeFQDN {
+			continue
+		}
+
+		port, ok := lo.Find(slice.Ports, func(p discoveryv1.EndpointPort) bool {
+			return lo.FromPtr(p.Name) == portName && p.Port != nil
+		})
+		if !ok {
+			continue
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			// Unknown readiness is interpreted as ready
+			if !lo.FromPtrOr(endpoint.Conditions.Ready, true) {
+				continue
+			}
+
+			for _, address := range endpoint.Addresses {
+				endpoints = append(endpoints, net.JoinHostPort(address, strconv.Itoa(int(*port.Port))))
+			}
+		}
+	}
+
+	slices.Sort(endpoints)
+
+	return slices.Compact(endpoints)
+}
+
+// endpointSliceToBackends enqueues the backends of the kind referencing the
+// Service of the EndpointSlice, so that their endpoints follow the pods.
+func (r *backendReconciler[T]) endpointSliceToBackends() handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		serviceName := obj.GetLabels()[discoveryv1.LabelServiceName]
+		if serviceName == "" {
+			return nil
+		}
+
+		backends, err := r.kind.list(ctx, r.Client)
+		if err != nil {
+			log.Log.Error(err, "failed to list "+r.kind.name+" for EndpointSlice", "name", obj.GetName())
+			return nil
+		}
+
+		return lo.FilterMap(backends, func(backend T, _ int) (reconcile.Request, bool) {
+			ref := r.kind.spec(backend).service
+
+			return reconcile.Request{NamespacedName: client.ObjectKeyFromObject(backend)},
+				ref != nil && ref.Name == serviceName && backend.GetNamespace() == obj.GetNamespace()
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"knoway.dev/api/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+)
+
+func newServiceObjects() []client.Object {
+	return []client.Object{
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "qwen3-vllm"},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{
+					{Name: "http", Port: 8000},
+					{Name: "metrics", Port: 9090},
+				},
+			},
+		},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "qwen3-vllm-abcde",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "qwen3-vllm"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Ports: []discoveryv1.EndpointPort{
+				{Name: lo.ToPtr("metrics"), Port: lo.ToPtr(int32(9090))},
+				{Name: lo.ToPtr("http"), Port: lo.ToPtr(int32(8080))},
+			},
+			Endpoints: []discoveryv1.Endpoint{
+				{Addresses: []string{"10.0.0.13"}, Conditions: discoveryv1.EndpointConditions{Ready: lo.ToPtr(true)}},
+				{Addresses: []string{"10.0.0.12"}},
+				// Starting or terminating
+				{Addresses: []string{"10.0.0.14"}, Conditions: discoveryv1.EndpointConditions{Ready: lo.ToPtr(false)}},
+			},
+		},
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "qwen3-vllm-fghij",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "qwen3-vllm"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv6,
+			Ports:       []discoveryv1.EndpointPort{{Name: lo.ToPtr("http"), Port: lo.ToPtr(int32(8080))}},
+			Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"fd00::12"}}},
+		},
+		// Of another Service
+		&discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "llama-vllm-abcde",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "llama-vllm"},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Ports:       []discoveryv1.EndpointPort{{Name: lo.ToPtr("http"), Port: lo.ToPtr(int32(8080))}},
+			Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{"10.0.1.12"}}},
+		},
+	}
+}
+
+func newServiceReconciler(t *testing.T, objects ...client.Object) *backendReconciler[*v1alpha1.LLMBackend] {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(newServiceObjects(), objects...)...).Build()
+
+	return newBackendReconciler(c, llmBackendKind, bootkit.NewEmptyLifeCycle(), 0)
+}
+
+func TestResolveServiceUpstream(t *testing.T) {
+	ctx := context.Background()
+	r := newServiceReconciler(t)
+
+	service, err := resolveServiceUpstream(ctx, r.Client, "default", &v1alpha1.UpstreamService{Name: "qwen3-vllm", Port: 8000, Path: "/v1"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://qwen3-vllm.default.svc:8000/v1", service.baseURL)
+	assert.Equal(t, []string{"10.0.0.12:8080", "10.0.0.13:8080", "[fd00::12]:8080"}, service.endpoints)
+	assert.Equal(t, []string{"http://10.0.0.12:8080", "http://10.0.0.13:8080", "http://[fd00::12]:8080"}, service.endpointURLs())
+
+	service, err = resolveServiceUpstream(ctx, r.Client, "default", &v1alpha1.UpstreamService{Name: "qwen3-vllm", Port: 9090, Scheme: "https"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://qwen3-vllm.default.svc:9090", service.baseURL)
+	assert.Equal(t, []string{"https://10.0.0.12:9090", "https://10.0.0.13:9090"}, service.endpointURLs())
+
+	_, err = resolveServiceUpstream(ctx, r.Client, "default", &v1alpha1.UpstreamService{Name: "qwen3-vllm"})
+	require.ErrorContains(t, err, "upstream.service.port must be set")
+
+	_, err = resolveServiceUpstream(ctx, r.Client, "default", &v1alpha1.UpstreamService{Name: "qwen3-vllm", Port: 80})
+	require.ErrorContains(t, err, "has no port 80")
+
+	_, err = resolveServiceUpstream(ctx, r.Client, "default", &v1alpha1.UpstreamService{Name: "missing"})
+	require.ErrorContains(t, err, "failed to get service default/missing")
+}
+
+func TestBackendReconciler_Service(t *testing.T) {
+	ctx := context.Background()
+
+	backend := &v1alpha1.LLMBackend{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "qwen3"},
+		Spec: v1alpha1.LLMBackendSpec{
+			ModelName: lo.ToPtr("qwen3"),
+			Provider:  v1alpha1.ProviderVLLM,
+			Upstream: v1alpha1.BackendUpstream{
+				Service: &v1alpha1.UpstreamService{Name: "qwen3-vllm", Port: 8000, Path: "/v1"},
+			},
+		},
+	}
+
+	r := newServiceReconciler(t, backend.DeepCopy())
+
+	require.NoError(t, r.reconcileValidator(ctx, backend))
+
+	clusterCfg, err := r.toClusterConfig(ctx, backend)
+	require.NoError(t, err)
+	assert.Equal(t, "http://qwen3-vllm.default.svc:8000/v1", clusterCfg.GetUpstream().GetUrl())
+	assert.Equal(t, []string{"http://10.0.0.12:8080", "http://10.0.0.13:8080", "http://[fd00::12]:8080"}, clusterCfg.GetUpstream().GetEndpoints())
+
+	require.NoError(t, r.reconcileUpstreamHealthy(ctx, backend))
+	assert.Equal(t, []string{"10.0.0.12:8080", "10.0.0.13:8080", "[fd00::12]:8080"}, backend.Status.Endpoints)
+
+	// EndpointSlices of the Service enqueue the backend
+	endpointSlice := &discoveryv1.EndpointSlice{}
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "qwen3-vllm-abcde"}, endpointSlice))
+	assert.Equal(t, []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(backend)}}, r.endpointSliceToBackends()(ctx, endpointSlice))
+
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Namespace: "default", Name: "llama-vllm-abcde"}, endpointSlice))
+	assert.Empty(t, r.endpointSliceToBackends()(ctx, endpointSlice))
+
+	t.Run("with baseUrl", func(t *testing.T) {
+		backend := backend.DeepCopy()
+		backend.Spec.Upstream.BaseURL = "http://qwen3-vllm.default.svc:8000/v1"
+
+		require.ErrorContains(t, r.reconcileValidator(ctx, backend), "cannot be set at the same time")
+	})
+}
@@ -0,0 +1,356 @@
+package controller
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/yaml"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	knowaydevv1alpha1 "knoway.dev/api/v1alpha1"
+	knowaydevv1alpha2 "knoway.dev/api/v1alpha2"
+	knowaydevv1beta1 "knoway.dev/api/v1beta1"
+	"knoway.dev/pkg/bootkit"
+)
+
+// standaloneKinds are the kinds of the objects supported without Kubernetes,
+// Secrets and ConfigMaps are the ones the backends refer to.
+var standaloneKinds = []string{
+	"LLMBackend",
+	"ImageGenerationBackend",
+	"EmbeddingBackend",
+	"RerankBackend",
+	"VideoGenerationBackend",
+	"ModelRoute",
+	"Secret",
+	"ConfigMap",
+}
+
+func newStandaloneScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(knowaydevv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(knowaydevv1alpha2.AddToScheme(scheme))
+	utilruntime.Must(knowaydevv1beta1.AddToScheme(scheme))
+
+	return scheme
+}
+
+// LoadStandaloneObjects reads the manifests of the objects from the YAML
+// files, directories are read for the files ending with .yaml, .yml or
+// .json, not recursively. Objects of other versions are converted to
+// v1alpha1, and
+// objects without namespace are in the default namespace.
+func LoadStandaloneObjects(paths []string) ([]client.Object, error) {
+	scheme := newStandaloneScheme()
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+	objects := make([]client.Object, 0)
+
+	for _, path := range paths {
+		files, err := standaloneFiles(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			objs, err := loadStandaloneFile(scheme, decoder, file)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load %s: %w", file, err)
+			}
+
+			objects = append(objects, objs...)
+		}
+	}
+
+	return objects, nil
+}
+
+func standaloneFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() || !lo.Contains([]string{".yaml", ".yml", ".json"}, filepath.Ext(entry.Name())) {
+			continue
+		}
+
+		files = append(files, filepath.Join(path, entry.Name()))
+	}
+
+	return files, nil
+}
+
+func loadStandaloneFile(scheme *runtime.Scheme, decoder runtime.Decoder, file string) ([]client.Object, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	objects := make([]client.Object, 0)
+
+	for index := 0; ; index++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var fields map[string]any
+		if err := yaml.Unmarshal(doc, &fields); err != nil {
+			return nil, fmt.Errorf("document %d: %w", index, err)
+		}
+
+		// documents of comments only
+		if len(fields) == 0 {
+			continue
+		}
+
+		decoded, gvk, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", index, err)
+		}
+
+		if !slices.Contains(standaloneKinds, gvk.Kind) {
+			return nil, fmt.Errorf("document %d: unsupported kind %s, supported kinds are %s", index, gvk.Kind, strings.Join(standaloneKinds, ", "))
+		}
+
+		// spokes other than v1alpha1 are converted through the hub
+		if convertible, ok := decoded.(conversion.Convertible); ok && gvk.Version != knowaydevv1alpha1.GroupVersion.Version {
+			hub, err := scheme.New(knowaydevv1beta1.GroupVersion.WithKind(gvk.Kind))
+			if err != nil {
+				return nil, fmt.Errorf("document %d: %w", index, err)
+			}
+
+			if err := convertible.ConvertTo(hub.(conversion.Hub)); err != nil {
+				return nil, fmt.Errorf("document %d: failed to convert %s: %w", index, gvk.Kind, err)
+			}
+
+			decoded = hub
+		}
+
+		if hub, ok := decoded.(conversion.Hub); ok {
+			spoke, err := scheme.New(knowaydevv1alpha1.GroupVersion.WithKind(gvk.Kind))
+			if err != nil {
+				return nil, fmt.Errorf("document %d: %w", index, err)
+			}
+
+			convertible, _ := spoke.(conversion.Convertible)
+			if err := convertible.ConvertFrom(hub); err != nil {
+				return nil, fmt.Errorf("document %d: failed to convert %s: %w", index, gvk.Kind, err)
+			}
+
+			decoded = spoke
+		}
+
+		obj, _ := decoded.(client.Object)
+		if obj.GetName() == "" {
+			return nil, fmt.Errorf("document %d: %s missing metadata.name", index, gvk.Kind)
+		}
+
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(metav1.NamespaceDefault)
+		}
+
+		// the clusters are listed by the time they are created
+		if creationTimestamp := obj.GetCreationTimestamp(); creationTimestamp.IsZero() {
+			obj.SetCreationTimestamp(metav1.NewTime(info.ModTime()))
+		}
+
+		// stringData is merged by the API server
+		if secret, ok := obj.(*corev1.Secret); ok {
+			for k, v := range secret.StringData {
+				if secret.Data == nil {
+					secret.Data = make(map[string][]byte, len(secret.StringData))
+				}
+
+				secret.Data[k] = []byte(v)
+			}
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+// StandaloneConfig converts the backends and the ModelRoutes to the clusters
+// and the match routes the controller would register, with the Secrets and
+// the ConfigMaps they refer to among the objects. It allows the gateway to
+// run with the same resources without Kubernetes.
+//
+// The resources are validated as by the controller. Disabled backends have no
+// clusters, targets and mirrors referring to the backends missing or disabled
+// are left out. Status is never written back, therefore the upstream health
+// checks are not run and canaries stay at their first step.
+func StandaloneConfig(ctx context.Context, objects []client.Object) (map[string]*v1alpha1.Cluster, []*routev1alpha1.Route, error) {
+	scheme := newStandaloneScheme()
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		Build()
+
+	// the filters are only created for validation, stopped once converted
+	lifeCycle := bootkit.NewReloadableLifeCycle()
+	defer func() {
+		_ = lifeCycle.Stop(ctx)
+	}()
+
+	clusters := make(map[string]*v1alpha1.Cluster)
+
+	err := standaloneClusters(ctx, c, llmBackendKind, lifeCycle, clusters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = standaloneClusters(ctx, c, imageGenerationBackendKind, lifeCycle, clusters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = standaloneClusters(ctx, c, embeddingBackendKind, lifeCycle, clusters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = standaloneClusters(ctx, c, rerankBackendKind, lifeCycle, clusters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = standaloneClusters(ctx, c, videoGenerationBackendKind, lifeCycle, clusters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	routes, err := standaloneRoutes(ctx, c, scheme, lifeCycle, clusters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clusters, routes, nil
+}
+
+func standaloneClusters[T client.Object](ctx context.Context, c client.Client, kind backendKind[T], lifeCycle bootkit.LifeCycle, clusters map[string]*v1alpha1.Cluster) error {
+	r := newBackendReconciler(c, kind, lifeCycle, 0)
+
+	backends, err := kind.list(ctx, c)
+	if err != nil {
+		return fmt.Errorf("failed to list %s resources: %w", kind.name, err)
+	}
+
+	for _, backend := range backends {
+		if kind.toBackend(backend).IsDisabled() {
+			continue
+		}
+
+		err = r.reconcileValidator(ctx, backend)
+		if err != nil {
+			return fmt.Errorf("invalid %s %s: %w", kind.name, client.ObjectKeyFromObject(backend), err)
+		}
+
+		clusterCfg, err := r.toClusterConfig(ctx, backend)
+		if err != nil {
+			return fmt.Errorf("invalid %s %s: %w", kind.name, client.ObjectKeyFromObject(backend), err)
+		}
+
+		if _, ok := clusters[clusterCfg.GetName()]; ok {
+			return fmt.Errorf("invalid %s %s: modelName %s is used by another backend", kind.name, client.ObjectKeyFromObject(backend), clusterCfg.GetName())
+		}
+
+		clusters[clusterCfg.GetName()] = clusterCfg
+	}
+
+	return nil
+}
+
+func standaloneRoutes(ctx context.Context, c client.Client, scheme *runtime.Scheme, lifeCycle bootkit.LifeCycle, clusters map[string]*v1alpha1.Cluster) ([]*routev1alpha1.Route, error) {
+	r := &ModelRouteReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		LifeCycle: lifeCycle,
+	}
+
+	modelRoutes := &knowaydevv1alpha1.ModelRouteList{}
+	if err := c.List(ctx, modelRoutes); err != nil {
+		return nil, fmt.Errorf("failed to list ModelRoute resources: %w", err)
+	}
+
+	routes := make([]*routev1alpha1.Route, 0, len(modelRoutes.Items))
+
+	for i := range modelRoutes.Items {
+		modelRoute := &modelRoutes.Items[i]
+
+		err := r.reconcileValidator(ctx, modelRoute)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ModelRoute %s: %w", client.ObjectKeyFromObject(modelRoute), err)
+		}
+
+		mBackends, err := r.mapCRDTargetsToBackends(ctx, r.getModelRouteBackendTargets(modelRoute))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ModelRoute %s: %w", client.ObjectKeyFromObject(modelRoute), err)
+		}
+
+		routeConfig, err := r.toRegisterRouteConfig(ctx, modelRoute, mBackends)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ModelRoute %s: %w", client.ObjectKeyFromObject(modelRoute), err)
+		}
+
+		routeConfig.Targets = lo.Filter(routeConfig.GetTargets(), func(target *routev1alpha1.RouteTarget, _ int) bool {
+			_, ok := clusters[target.GetDestination().GetCluster()]
+			return ok
+		})
+
+		if mirror := routeConfig.GetMirror(); mirror != nil {
+			if _, ok := clusters[mirror.GetDestination().GetCluster()]; !ok {
+				routeConfig.Mirror = nil
+			}
+		}
+
+		routes = append(routes, routeConfig)
+	}
+
+	return routes, nil
+}
@@ -0,0 +1,241 @@
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	filtersv1alpha1 "knoway.dev/api/filters/v1alpha1"
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	knowaydevv1alpha1 "knoway.dev/api/v1alpha1"
+)
+
+const standaloneBackends = `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: openai
+stringData:
+  Authorization: Bearer sk-test
+---
+apiVersion: llm.knoway.dev/v1beta1
+kind: LLMBackend
+metadata:
+  name: gpt-4o
+spec:
+  modelName: openai/gpt-4o
+  provider: OpenAI
+  upstream:
+    baseUrl: https://api.openai.com/v1
+    headersFrom:
+      - refType: Secret
+        refName: openai
+---
+# comments only
+---
+apiVersion: llm.knoway.dev/v1alpha1
+kind: LLMBackend
+metadata:
+  name: gpt-4o-azure
+spec:
+  modelName: azure/gpt-4o
+  provider: OpenAI
+  upstream:
+    baseUrl: https://example.openai.azure.com/openai
+---
+apiVersion: llm.knoway.dev/v1alpha1
+kind: LLMBackend
+metadata:
+  name: gpt-4o-disabled
+spec:
+  modelName: disabled/gpt-4o
+  provider: OpenAI
+  disabled: true
+  upstream:
+    baseUrl: https://example.com/v1
+`
+
+const standaloneModelRoute = `
+apiVersion: llm.knoway.dev/v1alpha1
+kind: ModelRoute
+metadata:
+  name: gpt-4o
+spec:
+  modelName: gpt-4o
+  filters:
+    - type: RateLimit
+      rateLimit:
+        rules:
+          - limit: 10
+            basedOn: APIKey
+            duration: 60
+  route:
+    loadBalancePolicy: WeightedRoundRobin
+    targets:
+      - destination:
+          backend: gpt-4o
+          weight: 80
+      - destination:
+          backend: gpt-4o-azure
+          weight: 20
+      - destination:
+          backend: gpt-4o-disabled
+          weight: 1
+  fallback:
+    maxRetries: 2
+`
+
+func writeStandaloneFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func TestLoadStandaloneObjects(t *testing.T) {
+	dir := writeStandaloneFiles(t, map[string]string{
+		"backends.yaml": standaloneBackends,
+		"route.yml":     standaloneModelRoute,
+		"README.md":     "not a manifest",
+	})
+
+	objects, err := LoadStandaloneObjects([]string{dir})
+	require.NoError(t, err)
+	require.Len(t, objects, 5)
+
+	for _, obj := range objects {
+		assert.Equal(t, "default", obj.GetNamespace())
+		assert.False(t, obj.GetCreationTimestamp().Time.IsZero())
+	}
+
+	backend, ok := objects[1].(*knowaydevv1alpha1.LLMBackend)
+	require.True(t, ok, "v1beta1 objects are converted to v1alpha1")
+	assert.Equal(t, "openai/gpt-4o", lo.FromPtr(backend.Spec.ModelName))
+
+	t.Run("v1alpha2", func(t *testing.T) {
+		dir := writeStandaloneFiles(t, map[string]string{
+			"backend.yaml": `
+apiVersion: llm.knoway.dev/v1alpha2
+kind: LLMBackend
+metadata:
+  name: gpt-4o
+spec:
+  modelName: openai/gpt-4o
+  provider: OpenAI
+  upstream:
+    baseUrl: https://api.openai.com/v1
+    removeParamKeys:
+      - user
+`,
+		})
+
+		objects, err := LoadStandaloneObjects([]string{dir})
+		require.NoError(t, err)
+		require.Len(t, objects, 1)
+
+		backend, ok := objects[0].(*knowaydevv1alpha1.LLMBackend)
+		require.True(t, ok, "v1alpha2 objects are converted to v1alpha1")
+		assert.Equal(t, []string{"user"}, backend.Spec.Upstream.RemoveParamKeys)
+	})
+
+	t.Run("unsupported kind", func(t *testing.T) {
+		dir := writeStandaloneFiles(t, map[string]string{
+			"deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: knoway\n",
+		})
+
+		_, err := LoadStandaloneObjects([]string{dir})
+		require.ErrorContains(t, err, "unsupported kind Deployment")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadStandaloneObjects([]string{filepath.Join(t.TempDir(), "missing.yaml")})
+		require.Error(t, err)
+	})
+}
+
+func TestStandaloneConfig(t *testing.T) {
+	dir := writeStandaloneFiles(t, map[string]string{
+		"backends.yaml": standaloneBackends,
+		"route.yaml":    standaloneModelRoute,
+	})
+
+	objects, err := LoadStandaloneObjects([]string{dir})
+	require.NoError(t, err)
+
+	clusters, routes, err := StandaloneConfig(context.Background(), objects)
+	require.NoError(t, err)
+
+	require.Len(t, clusters, 2)
+	require.Contains(t, clusters, "openai/gpt-4o")
+	require.Contains(t, clusters, "azure/gpt-4o")
+	assert.NotContains(t, clusters, "disabled/gpt-4o")
+
+	headers := clusters["openai/gpt-4o"].GetUpstream().GetHeaders()
+	require.Len(t, headers, 1)
+	assert.Equal(t, "Authorization", headers[0].GetKey())
+	assert.Equal(t, "Bearer sk-test", headers[0].GetValue())
+
+	require.Len(t, routes, 1)
+
+	route := routes[0]
+	assert.Equal(t, "gpt-4o", route.GetName())
+	assert.Equal(t, routev1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_ROUND_ROBIN, route.GetLoadBalancePolicy())
+	assert.Equal(t, uint64(2), route.GetFallback().GetMaxRetries())
+
+	require.Len(t, route.GetTargets(), 2, "targets of disabled backends are left out")
+	assert.Equal(t, "openai/gpt-4o", route.GetTargets()[0].GetDestination().GetCluster())
+	assert.Equal(t, int32(80), route.GetTargets()[0].GetDestination().GetWeight())
+	assert.Equal(t, "azure/gpt-4o", route.GetTargets()[1].GetDestination().GetCluster())
+	assert.Equal(t, int32(20), route.GetTargets()[1].GetDestination().GetWeight())
+
+	require.Len(t, route.GetFilters(), 1)
+
+	rateLimit := new(filtersv1alpha1.RateLimitConfig)
+	require.NoError(t, route.GetFilters()[0].GetConfig().UnmarshalTo(rateLimit))
+	require.Len(t, rateLimit.GetPolicies(), 1)
+	assert.Equal(t, int32(10), rateLimit.GetPolicies()[0].GetLimit())
+
+	t.Run("duplicated modelName", func(t *testing.T) {
+		dir := writeStandaloneFiles(t, map[string]string{
+			"backends.yaml": standaloneBackends,
+			"duplicated.yaml": `
+apiVersion: llm.knoway.dev/v1alpha1
+kind: LLMBackend
+metadata:
+  name: gpt-4o-copy
+spec:
+  modelName: azure/gpt-4o
+  provider: OpenAI
+  upstream:
+    baseUrl: https://example.com/v1
+`,
+		})
+
+		objects, err := LoadStandaloneObjects([]string{dir})
+		require.NoError(t, err)
+
+		_, _, err = StandaloneConfig(context.Background(), objects)
+		require.ErrorContains(t, err, "must be unique globally")
+	})
+
+	t.Run("invalid ModelRoute", func(t *testing.T) {
+		dir := writeStandaloneFiles(t, map[string]string{
+			"route.yaml": "apiVersion: llm.knoway.dev/v1alpha1\nkind: ModelRoute\nmetadata:\n  name: empty\nspec: {}\n",
+		})
+
+		objects, err := LoadStandaloneObjects([]string{dir})
+		require.NoError(t, err)
+
+		_, _, err = StandaloneConfig(context.Background(), objects)
+		require.ErrorContains(t, err, "invalid ModelRoute default/empty")
+	})
+}
@@ -19,3 +19,10 @@ type RouteStatusable[S any] interface {
 	GetTargetsStatus() []llmv1alpha1.ModelRouteStatusTarget
 	SetTargetsStatus(targets []llmv1alpha1.ModelRouteStatusTarget)
 }
+
+// EndpointsStatusable is implemented by the statuses of backends reporting the
+// addresses of their upstreams.
+type EndpointsStatusable interface {
+	GetEndpoints() []string
+	SetEndpoints(endpoints []string)
+}
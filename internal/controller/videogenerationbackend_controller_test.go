@@ -0,0 +1,92 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clustersv1alpha1 "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/v1alpha1"
+)
+
+func TestVideoGenerationBackendReconciler_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := NewFakeClientWithStatus()
+
+	resource := &v1alpha1.VideoGenerationBackend{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gen4-turbo",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.VideoGenerationBackendSpec{
+			ModelName: lo.ToPtr("gen4-turbo"),
+			Provider:  v1alpha1.ProviderRunway,
+			Upstream: v1alpha1.VideoGenerationBackendUpstream{
+				BaseURL: "https://api.dev.runwayml.com/v1",
+				DefaultParams: &v1alpha1.VideoGenerationModelParams{
+					OpenAI: &v1alpha1.OpenAIVideoGenerationParam{
+						Seconds: lo.ToPtr("5"),
+					},
+				},
+				OverrideParams: &v1alpha1.VideoGenerationModelParams{
+					OpenAI: &v1alpha1.OpenAIVideoGenerationParam{
+						Model: "gen4_turbo",
+						Size:  lo.ToPtr("1280x720"),
+					},
+				},
+			},
+			MeteringPolicy: &v1alpha1.VideoGenerationMeteringPolicy{
+				DurationFrom: lo.ToPtr(v1alpha1.SizeFromOutput),
+			},
+		},
+	}
+	require.NoError(t, fakeClient.Create(ctx, resource))
+
+	reconciler := &VideoGenerationBackendReconciler{
+		Client: fakeClient,
+	}
+
+	_, err := reconciler.Reconcile(ctx, reconcile.Request{
+		NamespacedName: client.ObjectKeyFromObject(resource),
+	})
+	require.NoError(t, err)
+
+	clusterCfg, err := reconciler.toRegisterClusterConfig(ctx, resource)
+	require.NoError(t, err)
+
+	assert.Equal(t, clustersv1alpha1.ClusterType_VIDEO_GENERATION, clusterCfg.GetType())
+	assert.Equal(t, clustersv1alpha1.ClusterProvider_RUNWAY, clusterCfg.GetProvider())
+	assert.Equal(t, "gen4-turbo", clusterCfg.GetName())
+
+	defaultParams := clusterCfg.GetUpstream().GetDefaultParams()
+	assert.Equal(t, "5", defaultParams["seconds"].GetStringValue())
+
+	overrideParams := clusterCfg.GetUpstream().GetOverrideParams()
+	assert.Equal(t, "gen4_turbo", overrideParams["model"].GetStringValue())
+	assert.Equal(t, "1280x720", overrideParams["size"].GetStringValue())
+
+	assert.Equal(t, clustersv1alpha1.ClusterMeteringPolicy_SIZE_FROM_OUTPUT, clusterCfg.GetMeteringPolicy().GetDurationFrom())
+	assert.Nil(t, clusterCfg.GetMeteringPolicy().ResolutionFrom)
+}
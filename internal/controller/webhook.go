@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	knowaydevv1alpha1 "knoway.dev/api/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+)
+
+// +kubebuilder:webhook:path=/validate-llm-knoway-dev-v1alpha1-llmbackend,mutating=false,failurePolicy=fail,sideEffects=None,groups=llm.knoway.dev,resources=llmbackends,verbs=create;update,versions=v1alpha1,name=vllmbackend-v1alpha1.knoway.dev,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-llm-knoway-dev-v1alpha1-imagegenerationbackend,mutating=false,failurePolicy=fail,sideEffects=None,groups=llm.knoway.dev,resources=imagegenerationbackends,verbs=create;update,versions=v1alpha1,name=vimagegenerationbackend-v1alpha1.knoway.dev,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-llm-knoway-dev-v1alpha1-embeddingbackend,mutating=false,failurePolicy=fail,sideEffects=None,groups=llm.knoway.dev,resources=embeddingbackends,verbs=create;update,versions=v1alpha1,name=vembeddingbackend-v1alpha1.knoway.dev,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-llm-knoway-dev-v1alpha1-rerankbackend,mutating=false,failurePolicy=fail,sideEffects=None,groups=llm.knoway.dev,resources=rerankbackends,verbs=create;update,versions=v1alpha1,name=vrerankbackend-v1alpha1.knoway.dev,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-llm-knoway-dev-v1alpha1-videogenerationbackend,mutating=false,failurePolicy=fail,sideEffects=None,groups=llm.knoway.dev,resources=videogenerationbackends,verbs=create;update,versions=v1alpha1,name=vvideogenerationbackend-v1alpha1.knoway.dev,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-llm-knoway-dev-v1alpha1-modelroute,mutating=false,failurePolicy=fail,sideEffects=None,groups=llm.knoway.dev,resources=modelroutes,verbs=create;update,versions=v1alpha1,name=vmodelroute-v1alpha1.knoway.dev,admissionReviewVersions=v1
+
+// SetupValidatingWebhooks registers the webhooks validating the backends and
+// the ModelRoutes at admission time, with the same validation as Reconcile,
+// so that invalid objects are rejected by kubectl instead of failing in
+// status. The objects of v1beta1 are validated once converted to v1alpha1.
+//
+// TextToSpeechBackends and SpeechToTextBackends are not validated, they have
+// no controller whose validation to run, nor a CRD installed.
+func SetupValidatingWebhooks(mgr ctrl.Manager) error {
+	err := setupBackendWebhook(mgr, llmBackendKind)
+	if err != nil {
+		return err
+	}
+
+	err = setupBackendWebhook(mgr, imageGenerationBackendKind)
+	if err != nil {
+		return err
+	}
+
+	err = setupBackendWebhook(mgr, embeddingBackendKind)
+	if err != nil {
+		return err
+	}
+
+	err = setupBackendWebhook(mgr, rerankBackendKind)
+	if err != nil {
+		return err
+	}
+
+	err = setupBackendWebhook(mgr, videoGenerationBackendKind)
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewWebhookManagedBy(mgr, &knowaydevv1alpha1.ModelRoute{}).
+		WithValidator(&modelRouteValidator{client: mgr.GetClient()}).
+		Complete()
+}
+
+func setupBackendWebhook[T client.Object](mgr ctrl.Manager, kind backendKind[T]) error {
+	return ctrl.NewWebhookManagedBy(mgr, kind.newObject()).
+		WithValidator(&backendValidator[T]{reconciler: newBackendReconciler(mgr.GetClient(), kind, nil, 0)}).
+		Complete()
+}
+
+var _ admission.Validator[*knowaydevv1alpha1.LLMBackend] = (*backendValidator[*knowaydevv1alpha1.LLMBackend])(nil)
+
+// backendValidator validates backends of any kind, see backendKind.
+type backendValidator[T client.Object] struct {
+	reconciler *backendReconciler[T]
+}
+
+func (v *backendValidator[T]) ValidateCreate(ctx context.Context, obj T) (admission.Warnings, error) {
+	return nil, v.reconciler.reconcileValidator(ctx, obj)
+}
+
+func (v *backendValidator[T]) ValidateUpdate(ctx context.Context, _, newObj T) (admission.Warnings, error) {
+	// the finalizers of backends deleted must be removable
+	if newObj.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+
+	return nil, v.reconciler.reconcileValidator(ctx, newObj)
+}
+
+func (v *backendValidator[T]) ValidateDelete(context.Context, T) (admission.Warnings, error) {
+	return nil, nil
+}
+
+var _ admission.Validator[*knowaydevv1alpha1.ModelRoute] = (*modelRouteValidator)(nil)
+
+type modelRouteValidator struct {
+	client client.Client
+}
+
+func (v *modelRouteValidator) validate(ctx context.Context, modelRoute *knowaydevv1alpha1.ModelRoute) error {
+	// the filters of the route are only created for validation
+	lifeCycle := bootkit.NewReloadableLifeCycle()
+	defer func() {
+		_ = lifeCycle.Stop(ctx)
+	}()
+
+	r := &ModelRouteReconciler{
+		Client:    v.client,
+		LifeCycle: lifeCycle,
+	}
+
+	return r.reconcileValidator(ctx, modelRoute)
+}
+
+func (v *modelRouteValidator) ValidateCreate(ctx context.Context, obj *knowaydevv1alpha1.ModelRoute) (admission.Warnings, error) {
+	return nil, v.validate(ctx, obj)
+}
+
+func (v *modelRouteValidator) ValidateUpdate(ctx context.Context, _, newObj *knowaydevv1alpha1.ModelRoute) (admission.Warnings, error) {
+	// the finalizers of ModelRoutes deleted must be removable
+	if newObj.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+
+	return nil, v.validate(ctx, newObj)
+}
+
+func (v *modelRouteValidator) ValidateDelete(context.Context, *knowaydevv1alpha1.ModelRoute) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"knoway.dev/api/v1alpha1"
+)
+
+func newTestLLMBackend(name, modelName, baseURL string) *v1alpha1.LLMBackend {
+	return &v1alpha1.LLMBackend{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1alpha1.LLMBackendSpec{
+			ModelName: lo.ToPtr(modelName),
+			Provider:  v1alpha1.ProviderOpenAI,
+			Upstream:  v1alpha1.BackendUpstream{BaseURL: baseURL},
+		},
+	}
+}
+
+func TestBackendValidator(t *testing.T) {
+	ctx := context.Background()
+
+	existing := newTestLLMBackend("gpt-4o", "gpt-4o", "https://api.openai.com/v1")
+	c := fake.NewClientBuilder().WithScheme(createTestScheme()).WithObjects(existing).Build()
+
+	validator := &backendValidator[*v1alpha1.LLMBackend]{reconciler: newBackendReconciler(c, llmBackendKind, nil, 0)}
+
+	_, err := validator.ValidateCreate(ctx, newTestLLMBackend("gpt-4o-mini", "gpt-4o-mini", "https://api.openai.com/v1"))
+	require.NoError(t, err)
+
+	_, err = validator.ValidateCreate(ctx, newTestLLMBackend("gpt-4o-copy", "gpt-4o", "https://api.openai.com/v1"))
+	require.ErrorContains(t, err, "must be unique globally")
+
+	_, err = validator.ValidateCreate(ctx, newTestLLMBackend("no-url", "no-url", ""))
+	require.ErrorContains(t, err, "upstream.baseUrl cannot be empty")
+
+	_, err = validator.ValidateCreate(ctx, newTestLLMBackend("bad-url", "bad-url", "http://[::1"))
+	require.ErrorContains(t, err, "upstream.baseUrl parse error")
+
+	t.Run("update", func(t *testing.T) {
+		updated := existing.DeepCopy()
+
+		_, err := validator.ValidateUpdate(ctx, existing, updated)
+		require.NoError(t, err)
+
+		updated.Spec.Upstream.BaseURL = ""
+
+		_, err = validator.ValidateUpdate(ctx, existing, updated)
+		require.Error(t, err)
+
+		updated.DeletionTimestamp = lo.ToPtr(metav1.Now())
+
+		_, err = validator.ValidateUpdate(ctx, existing, updated)
+		require.NoError(t, err, "finalizers of backends deleted can be removed")
+	})
+
+	_, err = validator.ValidateDelete(ctx, existing)
+	require.NoError(t, err)
+}
+
+func TestModelRouteValidator(t *testing.T) {
+	ctx := context.Background()
+
+	existing := &v1alpha1.ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpt-4o", Namespace: "default"},
+		Spec:       v1alpha1.ModelRouteSpec{ModelName: "gpt-4o"},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(createTestScheme()).
+		WithObjects(existing, newTestLLMBackend("gpt-4o", "openai/gpt-4o", "https://api.openai.com/v1")).
+		Build()
+
+	validator := &modelRouteValidator{client: c}
+
+	newModelRoute := func(name, modelName string, weights ...*int) *v1alpha1.ModelRoute {
+		return &v1alpha1.ModelRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: v1alpha1.ModelRouteSpec{
+				ModelName: modelName,
+				Route: &v1alpha1.ModelRouteRoute{
+					Targets: lo.Map(weights, func(weight *int, _ int) v1alpha1.ModelRouteRouteTarget {
+						return v1alpha1.ModelRouteRouteTarget{
+							Destination: v1alpha1.ModelRouteRouteTargetDestination{Backend: "gpt-4o", Weight: weight},
+						}
+					}),
+				},
+			},
+		}
+	}
+
+	_, err := validator.ValidateCreate(ctx, newModelRoute("gpt-4o-mini", "gpt-4o-mini", lo.ToPtr(1), lo.ToPtr(2)))
+	require.NoError(t, err)
+
+	_, err = validator.ValidateCreate(ctx, newModelRoute("gpt-4o-copy", "gpt-4o", lo.ToPtr(1)))
+	require.ErrorContains(t, err, "must be unique globally")
+
+	_, err = validator.ValidateCreate(ctx, newModelRoute("mixed", "mixed", lo.ToPtr(1), nil))
+	require.ErrorContains(t, err, "must be either all set or all unset")
+
+	_, err = validator.ValidateCreate(ctx, newModelRoute("negative", "negative", lo.ToPtr(-1)))
+	require.ErrorContains(t, err, "cannot be less than 0")
+
+	invalid := newModelRoute("gpt-4o", "", lo.ToPtr(1))
+
+	_, err = validator.ValidateUpdate(ctx, existing, invalid)
+	require.ErrorContains(t, err, "spec.modelName cannot be empty")
+
+	invalid.DeletionTimestamp = lo.ToPtr(metav1.Now())
+
+	_, err = validator.ValidateUpdate(ctx, existing, invalid)
+	assert.NoError(t, err, "finalizers of ModelRoutes deleted can be removed")
+}
@@ -0,0 +1,89 @@
+// Package gatewaytest holds the fixtures shared by the tests of the gateway,
+// e.g. the requests of the clients passed through the filters and the
+// clusters they are routed to. It asserts with testify and is only meant to
+// be imported by the _test.go files.
+package gatewaytest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/types/openai"
+)
+
+const (
+	ChatCompletionsURL  = "http://example.com/v1/chat/completions"
+	ImageGenerationsURL = "http://example.com/v1/images/generations"
+)
+
+// NewHTTPRequest is the POST request of a client with the body, its
+// context carries the metadata of the request, as set by the listeners.
+func NewHTTPRequest(tb testing.TB, url, body string) *http.Request {
+	tb.Helper()
+
+	httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, strings.NewReader(body))
+	require.NoError(tb, err)
+
+	return httpRequest.WithContext(metadata.InitMetadataContext(httpRequest))
+}
+
+// NewChatCompletionRequest parses the body as a chat completions request, the
+// returned context is the one of the raw request.
+func NewChatCompletionRequest(tb testing.TB, body string) (context.Context, *openai.ChatCompletionsRequest) {
+	tb.Helper()
+
+	httpRequest := NewHTTPRequest(tb, ChatCompletionsURL, body)
+
+	request, err := openai.NewChatCompletionRequest(httpRequest)
+	require.NoError(tb, err)
+
+	return httpRequest.Context(), request
+}
+
+// NewImageGenerationsRequest parses the body as an image generations request,
+// the returned context is the one of the raw request.
+func NewImageGenerationsRequest(tb testing.TB, body string) (context.Context, *openai.ImageGenerationsRequest) {
+	tb.Helper()
+
+	httpRequest := NewHTTPRequest(tb, ImageGenerationsURL, body)
+
+	request, err := openai.NewImageGenerationsRequest(httpRequest)
+	require.NoError(tb, err)
+
+	return httpRequest.Context(), request
+}
+
+// ChatCompletionBody encodes the chat completions request of the model with
+// the messages, for the tests building the messages in Go.
+func ChatCompletionBody(model string, stream bool, messages ...map[string]any) string {
+	if messages == nil {
+		messages = make([]map[string]any, 0)
+	}
+
+	body := map[string]any{"model": model, "messages": messages}
+	if stream {
+		body["stream"] = true
+	}
+
+	return string(lo.Must(json.Marshal(body)))
+}
+
+// NewCluster is an OpenAI compatible LLM cluster serving the model of the
+// name from the upstream.
+func NewCluster(name, url string) *v1alpha1.Cluster {
+	return &v1alpha1.Cluster{
+		Name:              name,
+		Type:              v1alpha1.ClusterType_LLM,
+		Provider:          v1alpha1.ClusterProvider_OPEN_AI,
+		LoadBalancePolicy: v1alpha1.LoadBalancePolicy_ROUND_ROBIN,
+		Upstream:          &v1alpha1.Upstream{Url: url},
+	}
+}
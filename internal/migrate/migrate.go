@@ -0,0 +1,160 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/yaml"
+
+	"knoway.dev/api/v1alpha1"
+	"knoway.dev/api/v1beta1"
+)
+
+// migratedCRDs are the CRDs whose objects are rewritten in v1beta1.
+var migratedCRDs = []string{
+	"llmbackends." + v1beta1.GroupVersion.Group,
+	"modelroutes." + v1beta1.GroupVersion.Group,
+}
+
+type Options struct {
+	// Namespace limits the migration to the namespace, all namespaces if
+	// empty.
+	Namespace string
+	// DryRun writes the migrated objects to Out instead of updating them.
+	DryRun bool
+	Out    io.Writer
+}
+
+type Result struct {
+	LLMBackends int
+	ModelRoutes int
+}
+
+// Migrate reads the v1alpha1 LLMBackends and ModelRoutes and writes them back
+// as v1beta1, so that the API server stores them in the new version, fields
+// renamed are handled by the conversion.
+//
+// Once every namespace is migrated, v1alpha1 is removed from the stored
+// versions of the CRDs, after which v1alpha1 may stop being served.
+func Migrate(ctx context.Context, c client.Client, opts Options) (Result, error) {
+	var result Result
+
+	listOpts := []client.ListOption{client.InNamespace(opts.Namespace)}
+
+	backends := new(v1alpha1.LLMBackendList)
+
+	err := c.List(ctx, backends, listOpts...)
+	if err != nil {
+		return result, fmt.Errorf("failed to list LLMBackends: %w", err)
+	}
+
+	for i := range backends.Items {
+		err = migrateObject(ctx, c, opts, &backends.Items[i], new(v1beta1.LLMBackend))
+		if err != nil {
+			return result, err
+		}
+
+		result.LLMBackends++
+	}
+
+	routes := new(v1alpha1.ModelRouteList)
+
+	err = c.List(ctx, routes, listOpts...)
+	if err != nil {
+		return result, fmt.Errorf("failed to list ModelRoutes: %w", err)
+	}
+
+	for i := range routes.Items {
+		err = migrateObject(ctx, c, opts, &routes.Items[i], new(v1beta1.ModelRoute))
+		if err != nil {
+			return result, err
+		}
+
+		result.ModelRoutes++
+	}
+
+	if opts.DryRun || opts.Namespace != "" {
+		return result, nil
+	}
+
+	for _, name := range migratedCRDs {
+		err = pruneStoredVersions(ctx, c, name)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+type convertibleObject interface {
+	client.Object
+	conversion.Convertible
+}
+
+type hubObject interface {
+	client.Object
+	conversion.Hub
+}
+
+func migrateObject(ctx context.Context, c client.Client, opts Options, src convertibleObject, dst hubObject) error {
+	err := src.ConvertTo(dst)
+	if err != nil {
+		return err
+	}
+
+	kind := dst.GetObjectKind().GroupVersionKind().Kind
+
+	if opts.DryRun {
+		bs, err := yaml.Marshal(dst)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(opts.Out, "---\n%s", bs)
+
+		return err
+	}
+
+	// Updating without changes still rewrites the object in the storage
+	// version
+	err = c.Update(ctx, dst)
+	if err != nil {
+		return fmt.Errorf("failed to migrate %s %s/%s: %w", kind, dst.GetNamespace(), dst.GetName(), err)
+	}
+
+	slog.Info("migrated", "kind", kind, "namespace", dst.GetNamespace(), "name", dst.GetName(), "version", v1beta1.GroupVersion.Version)
+
+	return nil
+}
+
+func pruneStoredVersions(ctx context.Context, c client.Client, name string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crd := new(apiextensionsv1.CustomResourceDefinition)
+
+		err := c.Get(ctx, client.ObjectKey{Name: name}, crd)
+		if err != nil {
+			return fmt.Errorf("failed to get CRD %s: %w", name, err)
+		}
+
+		if !slices.Contains(crd.Status.StoredVersions, v1alpha1.GroupVersion.Version) {
+			return nil
+		}
+
+		crd.Status.StoredVersions = []string{v1beta1.GroupVersion.Version}
+
+		err = c.Status().Update(ctx, crd)
+		if err != nil {
+			return fmt.Errorf("failed to update stored versions of CRD %s: %w", name, err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,135 @@
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"knoway.dev/api/v1alpha1"
+	"knoway.dev/api/v1beta1"
+)
+
+func newTestClient(t *testing.T, updated *[]client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	objects := []client.Object{
+		&v1alpha1.LLMBackend{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4o"},
+			Spec:       v1alpha1.LLMBackendSpec{ModelName: lo.ToPtr("gpt-4o")},
+		},
+		&v1alpha1.LLMBackend{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "gpt-4o-mini"},
+			Spec:       v1alpha1.LLMBackendSpec{ModelName: lo.ToPtr("gpt-4o-mini")},
+		},
+		&v1alpha1.ModelRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gpt-4o"},
+			Spec: v1alpha1.ModelRouteSpec{
+				ModelName: "gpt-4o",
+				Fallback:  &v1alpha1.ModelRouteFallback{MaxRetries: lo.ToPtr[uint64](2)},
+			},
+		},
+	}
+
+	for _, name := range migratedCRDs {
+		crd := &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: []string{"v1alpha1", "v1beta1"}},
+		}
+
+		objects = append(objects, crd)
+	}
+
+	// The fake client keeps the versions apart, record the objects written
+	// instead of converting them
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&apiextensionsv1.CustomResourceDefinition{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				*updated = append(*updated, obj)
+				return nil
+			},
+		}).
+		Build()
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+
+	var updated []client.Object
+
+	c := newTestClient(t, &updated)
+
+	result, err := Migrate(ctx, c, Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, Result{LLMBackends: 2, ModelRoutes: 1}, result)
+	require.Len(t, updated, 3)
+
+	route, ok := updated[2].(*v1beta1.ModelRoute)
+	require.True(t, ok)
+	assert.Equal(t, v1beta1.GroupVersion.WithKind("ModelRoute"), route.GroupVersionKind())
+	require.NotNil(t, route.Spec.Fallback)
+	assert.Equal(t, uint64(2), lo.FromPtr(route.Spec.Fallback.MaxRetries))
+
+	for _, name := range migratedCRDs {
+		crd := new(apiextensionsv1.CustomResourceDefinition)
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Name: name}, crd))
+		assert.Equal(t, []string{"v1beta1"}, crd.Status.StoredVersions)
+	}
+}
+
+func TestMigrate_Namespace(t *testing.T) {
+	ctx := context.Background()
+
+	var updated []client.Object
+
+	c := newTestClient(t, &updated)
+
+	result, err := Migrate(ctx, c, Options{Namespace: "team-a"})
+	require.NoError(t, err)
+
+	assert.Equal(t, Result{LLMBackends: 1}, result)
+	require.Len(t, updated, 1)
+	assert.Equal(t, "gpt-4o-mini", updated[0].GetName())
+
+	// Other namespaces may still be stored in v1alpha1
+	crd := new(apiextensionsv1.CustomResourceDefinition)
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: migratedCRDs[0]}, crd))
+	assert.Equal(t, []string{"v1alpha1", "v1beta1"}, crd.Status.StoredVersions)
+}
+
+func TestMigrate_DryRun(t *testing.T) {
+	var (
+		updated []client.Object
+		out     bytes.Buffer
+	)
+
+	c := newTestClient(t, &updated)
+
+	result, err := Migrate(context.Background(), c, Options{DryRun: true, Out: &out})
+	require.NoError(t, err)
+
+	assert.Equal(t, Result{LLMBackends: 2, ModelRoutes: 1}, result)
+	assert.Empty(t, updated)
+	assert.Equal(t, 3, bytes.Count(out.Bytes(), []byte("apiVersion: llm.knoway.dev/v1beta1")))
+	assert.Contains(t, out.String(), "maxRetries: 2")
+}
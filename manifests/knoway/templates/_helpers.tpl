@@ -5,3 +5,63 @@ Return the proper image name
 {{- define "knoway.gateway.image" -}}
 {{ include "common.images.image" (dict "imageRoot" .Values.gateway.image "global" .Values.global "defaultTag" .Chart.Version) }}
 {{- end -}}
+
+{{/*
+Return the name of the Service and the Secret of the webhook server
+*/}}
+{{- define "knoway.webhook.name" -}}
+{{ .Values.fullNameOverride | default .Release.Name }}-webhook
+{{- end -}}
+
+{{/*
+Return whether the webhook server is served by the gateway
+*/}}
+{{- define "knoway.webhook.enabled" -}}
+{{- if or .Values.webhook.conversion .Values.webhook.validating }}true{{ end -}}
+{{- end -}}
+
+{{/*
+Generate the certificates of the webhook server once per render, the ones of
+the Secret installed are reused so that upgrades keep the CA bundles valid
+*/}}
+{{- define "knoway.webhook.certs" -}}
+{{- if not (hasKey .Values.webhook "certs") -}}
+{{- $name := include "knoway.webhook.name" . -}}
+{{- $secret := lookup "v1" "Secret" .Release.Namespace $name -}}
+{{- if $secret -}}
+{{- $_ := set .Values.webhook "certs" (dict "ca" (index $secret.data "ca.crt") "cert" (index $secret.data "tls.crt") "key" (index $secret.data "tls.key")) -}}
+{{- else -}}
+{{- $ca := genCA (printf "%s-ca" $name) 3650 -}}
+{{- $cert := genSignedCert $name nil (list (printf "%s.%s.svc" $name .Release.Namespace) (printf "%s.%s.svc.cluster.local" $name .Release.Namespace)) 3650 $ca -}}
+{{- $_ := set .Values.webhook "certs" (dict "ca" ($ca.Cert | b64enc) "cert" ($cert.Cert | b64enc) "key" ($cert.Key | b64enc)) -}}
+{{- end -}}
+{{- end -}}
+{{- end -}}
+
+{{/*
+Return the client config of the webhook server, with the path given
+Usage:     {{ include "knoway.webhook.clientConfig" (dict "path" "/convert" "context" $) }}
+*/}}
+{{- define "knoway.webhook.clientConfig" -}}
+{{- include "knoway.webhook.certs" .context -}}
+clientConfig:
+  service:
+    namespace: {{ .context.Release.Namespace }}
+    name: {{ include "knoway.webhook.name" .context }}
+    path: {{ .path }}
+  caBundle: {{ .context.Values.webhook.certs.ca }}
+{{- end -}}
+
+{{/*
+Return the conversion of the CRDs served in several versions
+*/}}
+{{- define "knoway.webhook.conversion" -}}
+{{- if .Values.webhook.conversion -}}
+conversion:
+  strategy: Webhook
+  webhook:
+    {{- include "knoway.webhook.clientConfig" (dict "path" "/convert" "context" .) | nindent 4 }}
+    conversionReviewVersions:
+    - v1
+{{- end -}}
+{{- end -}}
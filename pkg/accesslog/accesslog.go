@@ -0,0 +1,211 @@
+// Package accesslog writes the access log entries of listeners to the
+// configured sinks, such as the logs of the gateway, files, Kafka topics and
+// webhooks.
+package accesslog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/api/listeners/v1alpha1"
+)
+
+// Field is a field of an access log entry.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Entry is an access log entry, the fields are kept in order so that every
+// sink writes them the same way.
+type Entry []Field
+
+// Select returns the fields of the entry whose keys are in keys, keeping
+// the order of the entry, or the entry itself if keys is empty.
+func (e Entry) Select(keys []string) Entry {
+	if len(keys) == 0 {
+		return e
+	}
+
+	return lo.Filter(e, func(field Field, _ int) bool {
+		return lo.Contains(keys, field.Key)
+	})
+}
+
+// MarshalJSON encodes the entry as a JSON object, durations are encoded as
+// strings like 1.5s, the same way as the logs of the gateway.
+func (e Entry) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.WriteByte('{')
+
+	for i, field := range e {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		value := field.Value
+		if duration, ok := value.(time.Duration); ok {
+			value = duration.String()
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode field %s: %w", field.Key, err)
+		}
+
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(encoded)
+	}
+
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+// Sink persists access log entries.
+type Sink interface {
+	Write(ctx context.Context, entry Entry) error
+	Close() error
+}
+
+var _ Sink = (*logSink)(nil)
+
+type logSink struct{}
+
+// NewLogSink creates a Sink writing entries into the logs of the gateway.
+func NewLogSink() Sink {
+	return &logSink{}
+}
+
+func (s *logSink) Write(ctx context.Context, entry Entry) error {
+	attrs := make([]any, 0, len(entry))
+	for _, field := range entry {
+		attrs = append(attrs, slog.Any(field.Key, field.Value))
+	}
+
+	slog.InfoContext(ctx, "", attrs...)
+
+	return nil
+}
+
+func (s *logSink) Close() error {
+	return nil
+}
+
+type selectedSink struct {
+	sink   Sink
+	fields []string
+}
+
+// Logger writes the access log entries of a listener to every sink of it.
+// The zero value of *Logger, nil, discards the entries.
+type Logger struct {
+	sinks []selectedSink
+}
+
+// NewLoggerWithConfig creates the Logger of the access log config, nil if
+// the access log is disabled. Entries are written to the logs of the gateway
+// if no sink is configured.
+func NewLoggerWithConfig(cfg *v1alpha1.Log) (*Logger, error) {
+	if !cfg.GetEnable() {
+		return nil, nil
+	}
+
+	if len(cfg.GetSinks()) == 0 {
+		return NewLogger(NewLogSink()), nil
+	}
+
+	logger := &Logger{}
+
+	for i, sinkCfg := range cfg.GetSinks() {
+		sink, err := NewSinkWithConfig(sinkCfg)
+		if err != nil {
+			// Sinks created so far may hold files or goroutines
+			_ = logger.Close()
+
+			return nil, fmt.Errorf("invalid access log sink %d: %w", i, err)
+		}
+
+		logger.sinks = append(logger.sinks, selectedSink{sink: sink, fields: sinkCfg.GetFields()})
+	}
+
+	return logger, nil
+}
+
+// NewLogger creates a Logger writing all the fields of entries to sinks.
+func NewLogger(sinks ...Sink) *Logger {
+	return &Logger{
+		sinks: lo.Map(sinks, func(sink Sink, _ int) selectedSink {
+			return selectedSink{sink: sink}
+		}),
+	}
+}
+
+// NewSinkWithConfig creates the Sink of the config.
+func NewSinkWithConfig(cfg *v1alpha1.LogSink) (Sink, error) {
+	switch {
+	case cfg.GetFile() != nil:
+		return NewFileSink(cfg.GetFile().GetPath(), int64(cfg.GetFile().GetMaxSizeBytes()), int(lo.FromPtrOr(cfg.GetFile().MaxBackups, DefaultMaxBackups)))
+	case cfg.GetKafka() != nil:
+		sender, err := newKafkaSender(cfg.GetKafka())
+		if err != nil {
+			return nil, err
+		}
+
+		return newBatchSink(sender, batchOptionsFromConfig(cfg.GetBatch())), nil
+	case cfg.GetHttp() != nil:
+		sender, err := newHTTPSender(cfg.GetHttp())
+		if err != nil {
+			return nil, err
+		}
+
+		return newBatchSink(sender, batchOptionsFromConfig(cfg.GetBatch())), nil
+	default:
+		return nil, errors.New("no sink configured")
+	}
+}
+
+// Enabled reports whether the entries are written anywhere.
+func (l *Logger) Enabled() bool {
+	return l != nil && len(l.sinks) > 0
+}
+
+// Log writes the entry to every sink, failures are logged instead of failing
+// the request.
+func (l *Logger) Log(ctx context.Context, entry Entry) {
+	if l == nil {
+		return
+	}
+
+	for _, s := range l.sinks {
+		err := s.sink.Write(ctx, entry.Select(s.fields))
+		if err != nil {
+			slog.WarnContext(ctx, "failed to write access log entry", "error", err)
+		}
+	}
+}
+
+// Close flushes and closes every sink.
+func (l *Logger) Close() error {
+	if l == nil {
+		return nil
+	}
+
+	return errors.Join(lo.Map(l.sinks, func(s selectedSink, _ int) error {
+		return s.sink.Close()
+	})...)
+}
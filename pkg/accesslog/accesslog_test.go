@@ -0,0 +1,100 @@
+package accesslog
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/listeners/v1alpha1"
+)
+
+type recordSink struct {
+	entries []Entry
+	closed  bool
+}
+
+func (s *recordSink) Write(_ context.Context, entry Entry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *recordSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestEntry_MarshalJSON(t *testing.T) {
+	entry := Entry{
+		{Key: "method", Value: "POST"},
+		{Key: "response_status", Value: 200},
+		{Key: "response_duration", Value: 1500 * time.Millisecond},
+		{Key: "upstream_attempts", Value: []map[string]any{{"cluster": "gpt-4o"}}},
+	}
+
+	encoded, err := json.Marshal(entry)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"method": "POST",
+		"response_status": 200,
+		"response_duration": "1.5s",
+		"upstream_attempts": [{"cluster": "gpt-4o"}]
+	}`, string(encoded))
+
+	// The fields are kept in order
+	encoded, err = json.Marshal(entry[:2])
+	require.NoError(t, err)
+	assert.Equal(t, `{"method":"POST","response_status":200}`, string(encoded))
+}
+
+func TestEntry_Select(t *testing.T) {
+	entry := Entry{{Key: "method", Value: "POST"}, {Key: "uri", Value: "/v1/embeddings"}, {Key: "response_status", Value: 200}}
+
+	assert.Equal(t, entry, entry.Select(nil))
+	assert.Equal(t, Entry{{Key: "method", Value: "POST"}, {Key: "response_status", Value: 200}}, entry.Select([]string{"response_status", "method", "unknown"}))
+}
+
+func TestNewLoggerWithConfig(t *testing.T) {
+	logger, err := NewLoggerWithConfig(&v1alpha1.Log{})
+	require.NoError(t, err)
+	assert.Nil(t, logger)
+	assert.False(t, logger.Enabled())
+	require.NoError(t, logger.Close())
+
+	logger, err = NewLoggerWithConfig(&v1alpha1.Log{Enable: true})
+	require.NoError(t, err)
+	require.True(t, logger.Enabled())
+	assert.IsType(t, &logSink{}, logger.sinks[0].sink)
+
+	_, err = NewLoggerWithConfig(&v1alpha1.Log{Enable: true, Sinks: []*v1alpha1.LogSink{
+		{Sink: &v1alpha1.LogSink_File_{File: &v1alpha1.LogSink_File{Path: t.TempDir() + "/access.log"}}},
+		{Sink: &v1alpha1.LogSink_Http{Http: &v1alpha1.LogSink_HTTP{Url: "ftp://example.com"}}},
+	}})
+	require.EqualError(t, err, `invalid access log sink 1: invalid url of http sink: unsupported scheme of url "ftp://example.com"`)
+
+	_, err = NewLoggerWithConfig(&v1alpha1.Log{Enable: true, Sinks: []*v1alpha1.LogSink{{}}})
+	require.EqualError(t, err, "invalid access log sink 0: no sink configured")
+}
+
+func TestLogger_Log(t *testing.T) {
+	all := &recordSink{}
+	selected := &recordSink{}
+
+	logger := &Logger{sinks: []selectedSink{
+		{sink: all},
+		{sink: selected, fields: []string{"uri"}},
+	}}
+
+	entry := Entry{{Key: "method", Value: "POST"}, {Key: "uri", Value: "/v1/embeddings"}}
+	logger.Log(context.Background(), entry)
+
+	assert.Equal(t, []Entry{entry}, all.entries)
+	assert.Equal(t, []Entry{{{Key: "uri", Value: "/v1/embeddings"}}}, selected.entries)
+
+	require.NoError(t, logger.Close())
+	assert.True(t, all.closed)
+	assert.True(t, selected.closed)
+}
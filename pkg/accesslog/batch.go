@@ -0,0 +1,151 @@
+package accesslog
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/api/listeners/v1alpha1"
+)
+
+const (
+	DefaultBatchMaxSize    = 100
+	DefaultBatchInterval   = time.Second
+	DefaultBatchBufferSize = 10000
+	DefaultBatchTimeout    = 10 * time.Second
+)
+
+type batchOptions struct {
+	maxSize    int
+	interval   time.Duration
+	bufferSize int
+	timeout    time.Duration
+}
+
+func batchOptionsFromConfig(cfg *v1alpha1.LogSink_Batch) batchOptions {
+	return batchOptions{
+		maxSize:    lo.CoalesceOrEmpty(int(cfg.GetMaxSize()), DefaultBatchMaxSize),
+		interval:   lo.CoalesceOrEmpty(cfg.GetInterval().AsDuration(), DefaultBatchInterval),
+		bufferSize: lo.CoalesceOrEmpty(int(cfg.GetBufferSize()), DefaultBatchBufferSize),
+		timeout:    lo.CoalesceOrEmpty(cfg.GetTimeout().AsDuration(), DefaultBatchTimeout),
+	}
+}
+
+// sender sends a batch of entries.
+type sender interface {
+	Send(ctx context.Context, entries []Entry) error
+}
+
+var _ Sink = (*batchSink)(nil)
+
+// batchSink buffers the entries and sends them in batches in the background,
+// so that slow or unavailable destinations never block requests. Entries are
+// dropped once the buffer is full.
+type batchSink struct {
+	sender  sender
+	options batchOptions
+	entries chan Entry
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	mutex  sync.RWMutex
+	closed bool
+}
+
+func newBatchSink(sender sender, options batchOptions) *batchSink {
+	s := &batchSink{
+		sender:  sender,
+		options: options,
+		entries: make(chan Entry, options.bufferSize),
+		done:    make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s
+}
+
+func (s *batchSink) Write(_ context.Context, entry Entry) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.closed {
+		return nil
+	}
+
+	select {
+	case s.entries <- entry:
+	default:
+		s.dropped.Add(1)
+	}
+
+	return nil
+}
+
+func (s *batchSink) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.options.interval)
+	defer ticker.Stop()
+
+	batch := make([]Entry, 0, s.options.maxSize)
+
+	for {
+		select {
+		case entry, ok := <-s.entries:
+			if !ok {
+				s.flush(batch)
+				return
+			}
+
+			batch = append(batch, entry)
+			if len(batch) >= s.options.maxSize {
+				s.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			s.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (s *batchSink) flush(batch []Entry) {
+	if dropped := s.dropped.Swap(0); dropped > 0 {
+		slog.Warn("access log buffer is full, entries dropped", "dropped", dropped)
+	}
+
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.options.timeout)
+	defer cancel()
+
+	err := s.sender.Send(ctx, batch)
+	if err != nil {
+		slog.Warn("failed to send access log entries", "entries", len(batch), "error", err)
+	}
+}
+
+// Close sends the entries buffered and stops the sink.
+func (s *batchSink) Close() error {
+	s.mutex.Lock()
+
+	if s.closed {
+		s.mutex.Unlock()
+		return nil
+	}
+
+	s.closed = true
+	close(s.entries)
+	s.mutex.Unlock()
+
+	<-s.done
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package accesslog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"sync"
+)
+
+const (
+	DefaultMaxBackups = 5
+)
+
+var _ Sink = (*fileSink)(nil)
+
+type fileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mutex sync.Mutex
+	file  *os.File
+	size  int64
+}
+
+// NewFileSink creates a Sink appending entries to the file as JSON lines. The
+// file is rotated once it would grow beyond maxSize bytes, keeping maxBackups
+// rotated files suffixed with .1, .2 and so on, the larger the older. 0
+// maxSize disables rotation.
+func NewFileSink(path string, maxSize int64, maxBackups int) (Sink, error) {
+	if path == "" {
+		return nil, errors.New("path of the access log file is required")
+	}
+
+	s := &fileSink{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+
+	err := s.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *fileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) //nolint:mnd
+	if err != nil {
+		return fmt.Errorf("failed to open access log file %s: %w", s.path, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat access log file %s: %w", s.path, err)
+	}
+
+	s.file = file
+	s.size = info.Size()
+
+	return nil
+}
+
+func (s *fileSink) backup(n int) string {
+	return s.path + "." + strconv.Itoa(n)
+}
+
+// rotate shifts the rotated files by one, dropping the oldest, and starts a
+// new file.
+func (s *fileSink) rotate() error {
+	err := s.file.Close()
+	if err != nil {
+		return err
+	}
+
+	if s.maxBackups > 0 {
+		for n := s.maxBackups - 1; n > 0; n-- {
+			err = os.Rename(s.backup(n), s.backup(n+1))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+
+		err = os.Rename(s.path, s.backup(1))
+	} else {
+		err = os.Remove(s.path)
+	}
+
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return s.open()
+}
+
+func (s *fileSink) Write(_ context.Context, entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	line = append(line, '\n')
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.file == nil {
+		return fs.ErrClosed
+	}
+
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		err = s.rotate()
+		if err != nil {
+			return fmt.Errorf("failed to rotate access log file %s: %w", s.path, err)
+		}
+	}
+
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+
+	return err
+}
+
+func (s *fileSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	err := s.file.Close()
+	s.file = nil
+
+	return err
+}
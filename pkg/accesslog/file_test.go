@@ -0,0 +1,81 @@
+package accesslog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+
+	sink, err := NewFileSink(path, 0, DefaultMaxBackups)
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Write(context.Background(), Entry{{Key: "uri", Value: "/v1/chat/completions"}}))
+	require.NoError(t, sink.Write(context.Background(), Entry{{Key: "uri", Value: "/v1/embeddings"}}))
+	require.NoError(t, sink.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"uri\":\"/v1/chat/completions\"}\n{\"uri\":\"/v1/embeddings\"}\n", string(content))
+
+	// Appends to the existing file
+	sink, err = NewFileSink(path, 0, DefaultMaxBackups)
+	require.NoError(t, err)
+	require.NoError(t, sink.Write(context.Background(), Entry{{Key: "uri", Value: "/v1/models"}}))
+	require.NoError(t, sink.Close())
+
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(content), "\n"))
+}
+
+func TestFileSink_Rotate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+
+	// Every entry is 12 bytes, 2 entries per file
+	sink, err := NewFileSink(path, 24, 2)
+	require.NoError(t, err)
+
+	for _, value := range []string{"aaa", "bbb", "ccc", "ddd", "eee", "fff", "ggg"} {
+		require.NoError(t, sink.Write(context.Background(), Entry{{Key: "v", Value: value}}))
+	}
+
+	require.NoError(t, sink.Close())
+
+	read := func(name string) string {
+		content, err := os.ReadFile(name)
+		require.NoError(t, err)
+
+		return string(content)
+	}
+
+	assert.Equal(t, "{\"v\":\"ggg\"}\n", read(path))
+	assert.Equal(t, "{\"v\":\"eee\"}\n{\"v\":\"fff\"}\n", read(path+".1"))
+	assert.Equal(t, "{\"v\":\"ccc\"}\n{\"v\":\"ddd\"}\n", read(path+".2"))
+	assert.NoFileExists(t, path+".3")
+}
+
+func TestFileSink_RotateWithoutBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+
+	sink, err := NewFileSink(path, 12, 0)
+	require.NoError(t, err)
+
+	for _, value := range []string{"aaa", "bbb", "ccc"} {
+		require.NoError(t, sink.Write(context.Background(), Entry{{Key: "v", Value: value}}))
+	}
+
+	require.NoError(t, sink.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"v\":\"ccc\"}\n", string(content))
+	assert.NoFileExists(t, path+".1")
+}
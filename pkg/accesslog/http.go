@@ -0,0 +1,55 @@
+package accesslog
+
+import (
+	"context"
+	"fmt"
+
+	"knoway.dev/api/listeners/v1alpha1"
+	"knoway.dev/pkg/sink"
+)
+
+var _ sender = (*httpSender)(nil)
+
+// httpSender posts the batches to a webhook as JSON arrays.
+type httpSender struct {
+	sink *sink.HTTP
+}
+
+func newHTTPSender(cfg *v1alpha1.LogSink_HTTP) (*httpSender, error) {
+	s, err := sink.NewHTTP(cfg.GetUrl(), cfg.GetHeaders())
+	if err != nil {
+		return nil, fmt.Errorf("invalid url of http sink: %w", err)
+	}
+
+	return &httpSender{sink: s}, nil
+}
+
+func (s *httpSender) Send(ctx context.Context, entries []Entry) error {
+	return s.sink.Post(ctx, entries)
+}
+
+var _ sender = (*kafkaSender)(nil)
+
+// kafkaSender produces the batches to a topic through the Kafka REST Proxy,
+// every entry is a record with JSON value.
+type kafkaSender struct {
+	sink *sink.Kafka
+}
+
+func newKafkaSender(cfg *v1alpha1.LogSink_Kafka) (*kafkaSender, error) {
+	s, err := sink.NewKafka(cfg.GetRestProxyUrl(), cfg.GetTopic(), cfg.GetHeaders())
+	if err != nil {
+		return nil, fmt.Errorf("invalid kafka sink: %w", err)
+	}
+
+	return &kafkaSender{sink: s}, nil
+}
+
+func (s *kafkaSender) Send(ctx context.Context, entries []Entry) error {
+	records := make([]sink.KafkaRecord, 0, len(entries))
+	for _, entry := range entries {
+		records = append(records, sink.KafkaRecord{Value: entry})
+	}
+
+	return s.sink.Produce(ctx, records)
+}
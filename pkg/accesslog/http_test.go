@@ -0,0 +1,142 @@
+package accesslog
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/listeners/v1alpha1"
+	"knoway.dev/pkg/sink"
+)
+
+type receivedRequest struct {
+	path        string
+	contentType string
+	headers     http.Header
+	body        string
+}
+
+func newReceiver(t *testing.T, status int) (*httptest.Server, func() []receivedRequest) {
+	t.Helper()
+
+	var (
+		mutex    sync.Mutex
+		received []receivedRequest
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+
+		mutex.Lock()
+		received = append(received, receivedRequest{
+			path:        request.URL.Path,
+			contentType: request.Header.Get("Content-Type"),
+			headers:     request.Header,
+			body:        string(body),
+		})
+		mutex.Unlock()
+
+		writer.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []receivedRequest {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return append([]receivedRequest(nil), received...)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	server, received := newReceiver(t, http.StatusNoContent)
+
+	s, err := NewSinkWithConfig(&v1alpha1.LogSink{
+		Sink: &v1alpha1.LogSink_Http{Http: &v1alpha1.LogSink_HTTP{
+			Url:     server.URL + "/logs",
+			Headers: map[string]string{"Authorization": "Bearer token"},
+		}},
+		Batch: &v1alpha1.LogSink_Batch{MaxSize: 2},
+	})
+	require.NoError(t, err)
+
+	for _, uri := range []string{"/v1/chat/completions", "/v1/embeddings", "/v1/models"} {
+		require.NoError(t, s.Write(context.Background(), Entry{{Key: "uri", Value: uri}}))
+	}
+
+	// The last batch isn't full, sent on close
+	require.NoError(t, s.Close())
+	require.NoError(t, s.Write(context.Background(), Entry{{Key: "uri", Value: "/v1/closed"}}))
+
+	requests := received()
+	require.Len(t, requests, 2)
+	assert.Equal(t, "/logs", requests[0].path)
+	assert.Equal(t, "application/json", requests[0].contentType)
+	assert.Equal(t, "Bearer token", requests[0].headers.Get("Authorization"))
+	assert.JSONEq(t, `[{"uri":"/v1/chat/completions"},{"uri":"/v1/embeddings"}]`, requests[0].body)
+	assert.JSONEq(t, `[{"uri":"/v1/models"}]`, requests[1].body)
+}
+
+func TestHTTPSink_Interval(t *testing.T) {
+	server, received := newReceiver(t, http.StatusOK)
+
+	sender, err := newHTTPSender(&v1alpha1.LogSink_HTTP{Url: server.URL})
+	require.NoError(t, err)
+
+	options := batchOptionsFromConfig(nil)
+	options.interval = 10 * time.Millisecond
+
+	s := newBatchSink(sender, options)
+
+	defer func() {
+		_ = s.Close()
+	}()
+
+	require.NoError(t, s.Write(context.Background(), Entry{{Key: "uri", Value: "/v1/embeddings"}}))
+
+	assert.Eventually(t, func() bool {
+		return len(received()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestKafkaSink(t *testing.T) {
+	server, received := newReceiver(t, http.StatusOK)
+
+	s, err := NewSinkWithConfig(&v1alpha1.LogSink{
+		Sink: &v1alpha1.LogSink_Kafka_{Kafka: &v1alpha1.LogSink_Kafka{
+			RestProxyUrl: server.URL,
+			Topic:        "knoway-access-log",
+		}},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Write(context.Background(), Entry{{Key: "uri", Value: "/v1/embeddings"}, {Key: "response_status", Value: 200}}))
+	require.NoError(t, s.Close())
+
+	requests := received()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "/topics/knoway-access-log", requests[0].path)
+	assert.Equal(t, sink.KafkaRESTContentType, requests[0].contentType)
+	assert.Equal(t, sink.KafkaRESTAccept, requests[0].headers.Get("Accept"))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal([]byte(requests[0].body), &body))
+	assert.Equal(t, map[string]any{
+		"records": []any{
+			map[string]any{"value": map[string]any{"uri": "/v1/embeddings", "response_status": float64(200)}},
+		},
+	}, body)
+
+	_, err = NewSinkWithConfig(&v1alpha1.LogSink{
+		Sink: &v1alpha1.LogSink_Kafka_{Kafka: &v1alpha1.LogSink_Kafka{RestProxyUrl: server.URL}},
+	})
+	require.EqualError(t, err, "invalid kafka sink: topic is required")
+}
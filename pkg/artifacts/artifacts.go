@@ -0,0 +1,365 @@
+// Package artifacts keeps the content generated through the gateway, e.g.
+// images and long audio, and serves it back with short-lived signed URLs, so
+// that clients get neither raw provider URLs nor giant base64 blobs.
+package artifacts
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"knoway.dev/pkg/bootkit"
+)
+
+const (
+	// PathPrefix is the path artifacts are served under by the gateway.
+	PathPrefix = "/v1/artifacts/"
+
+	// HeaderArtifactURL asks the gateway to respond with the signed URL of
+	// the stored artifact instead of the binary content when set to true.
+	HeaderArtifactURL = "X-Knoway-Artifact-URL"
+
+	defaultTTL          = time.Hour
+	defaultMaxSizeBytes = 100 * 1024 * 1024
+	metadataSuffix      = ".json"
+)
+
+var (
+	ErrNotFound         = errors.New("artifact not found")
+	ErrInvalidSignature = errors.New("invalid artifact signature")
+	ErrExpired          = errors.New("artifact url expired")
+	ErrTooLarge         = errors.New("artifact exceeds the maximum size")
+
+	idPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+)
+
+// Options configures the Store, zero values fall back to defaults.
+type Options struct {
+	// Dir keeps the artifacts, it must be shared between replicas of the
+	// gateway for URLs to be served by any of them.
+	Dir string
+	// SigningKey signs the URLs of artifacts.
+	SigningKey []byte
+	// TTL is both how long artifacts are kept and how long their URLs stay
+	// valid. Default is 1h.
+	TTL time.Duration
+	// PublicBaseURL is prepended to the paths of artifacts, e.g.
+	// https://llm.example.com, URLs are relative to the gateway if empty.
+	PublicBaseURL string
+	// MaxSizeBytes is the maximum size of a single artifact. Default is
+	// 100MB.
+	MaxSizeBytes int64
+}
+
+// Artifact describes a stored artifact.
+type Artifact struct {
+	ID          string    `json:"id"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// Store keeps artifacts as files in a directory, each of them along with a
+// metadata file.
+type Store struct {
+	options Options
+	now     func() time.Time
+}
+
+// NewStore creates the Store, the directory is created if not exists.
+func NewStore(options Options) (*Store, error) {
+	if options.Dir == "" {
+		return nil, errors.New("artifacts directory is required")
+	}
+
+	if len(options.SigningKey) == 0 {
+		return nil, errors.New("artifacts signing key is required")
+	}
+
+	if options.TTL <= 0 {
+		options.TTL = defaultTTL
+	}
+
+	if options.MaxSizeBytes <= 0 {
+		options.MaxSizeBytes = defaultMaxSizeBytes
+	}
+
+	options.PublicBaseURL = strings.TrimSuffix(options.PublicBaseURL, "/")
+
+	err := os.MkdirAll(options.Dir, 0o750) //nolint:mnd
+	if err != nil {
+		return nil, fmt.Errorf("failed to create artifacts directory: %w", err)
+	}
+
+	return &Store{options: options, now: time.Now}, nil
+}
+
+func (s *Store) path(id string) string {
+	return filepath.Join(s.options.Dir, id)
+}
+
+// Put stores the content read from r until EOF, content larger than the
+// maximum size is rejected.
+func (s *Store) Put(_ context.Context, contentType string, r io.Reader) (*Artifact, error) {
+	idBytes := make([]byte, 16) //nolint:mnd
+
+	_, err := rand.Read(idBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	artifact := &Artifact{
+		ID:          hex.EncodeToString(idBytes),
+		ContentType: contentType,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.options.TTL),
+	}
+
+	file, err := os.OpenFile(s.path(artifact.ID), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640) //nolint:mnd
+	if err != nil {
+		return nil, err
+	}
+
+	artifact.Size, err = io.Copy(file, io.LimitReader(r, s.options.MaxSizeBytes+1))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil && artifact.Size > s.options.MaxSizeBytes {
+		err = fmt.Errorf("%w of %d bytes", ErrTooLarge, s.options.MaxSizeBytes)
+	}
+
+	if err == nil {
+		err = s.writeMetadata(artifact)
+	}
+
+	if err != nil {
+		s.remove(artifact.ID)
+		return nil, err
+	}
+
+	return artifact, nil
+}
+
+func (s *Store) writeMetadata(artifact *Artifact) error {
+	data, err := json.Marshal(artifact)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path(artifact.ID)+metadataSuffix, data, 0o640) //nolint:mnd
+}
+
+func (s *Store) readMetadata(id string) (*Artifact, error) {
+	data, err := os.ReadFile(s.path(id) + metadataSuffix)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	artifact := new(Artifact)
+
+	err = json.Unmarshal(data, artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	return artifact, nil
+}
+
+func (s *Store) remove(id string) {
+	_ = os.Remove(s.path(id))
+	_ = os.Remove(s.path(id) + metadataSuffix)
+}
+
+func (s *Store) sign(id string, expires int64) string {
+	mac := hmac.New(sha256.New, s.options.SigningKey)
+	mac.Write([]byte(id + "\n" + strconv.FormatInt(expires, 10)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignedURL returns the URL serving the artifact until it expires.
+func (s *Store) SignedURL(artifact *Artifact) string {
+	expires := artifact.ExpiresAt.Unix()
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(artifact.ID, expires))
+
+	return s.options.PublicBaseURL + PathPrefix + artifact.ID + "?" + query.Encode()
+}
+
+// Verify checks the signature and the expiry of the URL of the artifact.
+func (s *Store) Verify(id string, expires string, signature string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.sign(id, expiresAt))) {
+		return ErrInvalidSignature
+	}
+
+	if s.now().Unix() > expiresAt {
+		return ErrExpired
+	}
+
+	return nil
+}
+
+// ServeHTTP serves the artifact of the signed URL, range requests are
+// supported.
+func (s *Store) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	id := mux.Vars(request)["id"]
+	if !idPattern.MatchString(id) {
+		http.Error(writer, ErrNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	query := request.URL.Query()
+
+	err := s.Verify(id, query.Get("expires"), query.Get("signature"))
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	artifact, err := s.readMetadata(id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			http.Error(writer, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		slog.Error("failed to read artifact metadata", "id", id, "error", err)
+		http.Error(writer, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+		return
+	}
+
+	file, err := os.Open(s.path(id))
+	if err != nil {
+		http.Error(writer, ErrNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	defer file.Close()
+
+	if artifact.ContentType != "" {
+		writer.Header().Set("Content-Type", artifact.ContentType)
+	}
+
+	writer.Header().Set("Cache-Control", "private, max-age="+strconv.FormatInt(int64(time.Until(artifact.ExpiresAt).Seconds()), 10))
+	http.ServeContent(writer, request, "", artifact.CreatedAt, file)
+}
+
+// RemoveExpired removes the artifacts that have expired.
+func (s *Store) RemoveExpired() {
+	entries, err := os.ReadDir(s.options.Dir)
+	if err != nil {
+		slog.Error("failed to list artifacts", "error", err)
+		return
+	}
+
+	now := s.now()
+
+	for _, entry := range entries {
+		if entry.IsDir() || !idPattern.MatchString(entry.Name()) {
+			continue
+		}
+
+		artifact, err := s.readMetadata(entry.Name())
+		if err == nil && now.Before(artifact.ExpiresAt) {
+			continue
+		}
+
+		// Artifacts without metadata are left by failed writes, unless
+		// being written right now
+		if err != nil {
+			info, err := entry.Info()
+			if err != nil || now.Sub(info.ModTime()) < s.options.TTL {
+				continue
+			}
+		}
+
+		s.remove(entry.Name())
+	}
+}
+
+// Start removes expired artifacts periodically until the lifecycle stops.
+func (s *Store) Start(lifecycle bootkit.LifeCycle) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStart: func(context.Context) error {
+			go func() {
+				ticker := time.NewTicker(min(s.options.TTL, time.Minute))
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						s.RemoveExpired()
+					}
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+}
+
+var global atomic.Pointer[Store]
+
+// SetGlobal sets the Store used by the whole gateway.
+func SetGlobal(s *Store) {
+	global.Store(s)
+}
+
+// Global returns the Store used by the whole gateway, nil if artifacts are
+// not enabled.
+func Global() *Store {
+	return global.Load()
+}
+
+// ServeHTTP serves artifacts with the global Store, artifacts are not found
+// if not enabled.
+func ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	store := Global()
+	if store == nil {
+		http.NotFound(writer, request)
+		return
+	}
+
+	store.ServeHTTP(writer, request)
+}
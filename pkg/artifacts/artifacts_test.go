@@ -0,0 +1,101 @@
+package artifacts
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewStore(Options{
+		Dir:           t.TempDir(),
+		SigningKey:    []byte("secret"),
+		TTL:           time.Minute,
+		PublicBaseURL: "https://llm.example.com/",
+		MaxSizeBytes:  16,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	router := mux.NewRouter()
+	router.HandleFunc(PathPrefix+"{id}", store.ServeHTTP)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	artifact, err := store.Put(ctx, "audio/mpeg", strings.NewReader("0123456789"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), artifact.Size)
+
+	signedURL, err := url.Parse(store.SignedURL(artifact))
+	require.NoError(t, err)
+	assert.Equal(t, "llm.example.com", signedURL.Host)
+	assert.Equal(t, PathPrefix+artifact.ID, signedURL.Path)
+
+	get := func(t *testing.T, rawQuery string, header http.Header) *http.Response {
+		t.Helper()
+
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+signedURL.Path+"?"+rawQuery, nil)
+		require.NoError(t, err)
+
+		for key, values := range header {
+			request.Header[key] = values
+		}
+
+		resp, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+
+		return resp
+	}
+
+	t.Run("range", func(t *testing.T) {
+		resp := get(t, signedURL.RawQuery, http.Header{"Range": []string{"bytes=2-5"}})
+		require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+		assert.Equal(t, "audio/mpeg", resp.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "2345", string(body))
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		query := signedURL.Query()
+		query.Set("expires", query.Get("expires")+"0")
+
+		resp := get(t, query.Encode(), nil)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := store.Put(ctx, "audio/mpeg", strings.NewReader(strings.Repeat("0", 17)))
+		require.ErrorIs(t, err, ErrTooLarge)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+
+		resp := get(t, signedURL.RawQuery, nil)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		store.RemoveExpired()
+
+		entries, err := os.ReadDir(store.options.Dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
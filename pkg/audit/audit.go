@@ -0,0 +1,244 @@
+// Package audit records signed digests of requests sent to upstreams, so that
+// stored audit entries can later be verified to match what was actually sent.
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"knoway.dev/pkg/metadata"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid audit entry signature")
+	ErrBodyMismatch     = errors.New("request body doesn't match audit entry")
+)
+
+// Entry is a single audit record of a request sent to an upstream.
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	KeyID     string    `json:"key_id,omitempty"`
+	Cluster   string    `json:"cluster"`
+	Target    string    `json:"target,omitempty"`
+	Method    string    `json:"method"`
+	// URL excludes query and user info since they may carry credentials
+	URL        string `json:"url"`
+	APIKeyID   string `json:"api_key_id,omitempty"`
+	UserID     string `json:"user_id,omitempty"`
+	BodySHA256 string `json:"body_sha256"`
+	BodySize   int    `json:"body_size"`
+	Signature  string `json:"signature"`
+}
+
+// canonical is the input of the signature, every field is prefixed with its
+// length so that no content can be moved across the fields, e.g. by a user ID
+// with a newline.
+func (e Entry) canonical() []byte {
+	var buffer bytes.Buffer
+
+	for _, field := range []string{
+		e.Timestamp.UTC().Format(time.RFC3339Nano),
+		e.KeyID,
+		e.Cluster,
+		e.Target,
+		e.Method,
+		e.URL,
+		e.APIKeyID,
+		e.UserID,
+		e.BodySHA256,
+		strconv.Itoa(e.BodySize),
+	} {
+		buffer.WriteString(strconv.Itoa(len(field)))
+		buffer.WriteByte(':')
+		buffer.WriteString(field)
+	}
+
+	return buffer.Bytes()
+}
+
+// Signer signs and verifies audit entries with HMAC-SHA256.
+type Signer struct {
+	keyID string
+	key   []byte
+}
+
+func NewSigner(keyID string, key []byte) (*Signer, error) {
+	if len(key) == 0 {
+		return nil, errors.New("empty audit hmac key")
+	}
+
+	return &Signer{
+		keyID: keyID,
+		key:   key,
+	}, nil
+}
+
+func (s *Signer) signature(entry Entry) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(entry.canonical())
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sign fills the digest of body, key ID and signature of the entry.
+func (s *Signer) Sign(entry Entry, body []byte) Entry {
+	digest := sha256.Sum256(body)
+
+	entry.KeyID = s.keyID
+	entry.BodySHA256 = hex.EncodeToString(digest[:])
+	entry.BodySize = len(body)
+	entry.Signature = s.signature(entry)
+
+	return entry
+}
+
+// Verify checks the signature of the entry, and if body is not nil, whether
+// it's the body the entry was recorded for.
+func (s *Signer) Verify(entry Entry, body []byte) error {
+	expected, err := hex.DecodeString(s.signature(entry))
+	if err != nil {
+		return err
+	}
+
+	actual, err := hex.DecodeString(entry.Signature)
+	if err != nil || !hmac.Equal(expected, actual) {
+		return ErrInvalidSignature
+	}
+
+	if body == nil {
+		return nil
+	}
+
+	digest := sha256.Sum256(body)
+	if hex.EncodeToString(digest[:]) != entry.BodySHA256 || len(body) != entry.BodySize {
+		return ErrBodyMismatch
+	}
+
+	return nil
+}
+
+// Sink persists audit entries.
+type Sink interface {
+	Write(ctx context.Context, entry Entry) error
+	Close() error
+}
+
+// Auditor signs requests sent to upstreams and writes the entries to Sink.
+type Auditor struct {
+	signer *Signer
+	sink   Sink
+	// failClosed rejects requests whose audit entries can't be recorded
+	failClosed bool
+}
+
+func NewAuditor(signer *Signer, sink Sink, failClosed bool) *Auditor {
+	return &Auditor{
+		signer:     signer,
+		sink:       sink,
+		failClosed: failClosed,
+	}
+}
+
+// FailClosed reports whether requests should be rejected when their audit
+// entries can't be recorded.
+func (a *Auditor) FailClosed() bool {
+	return a.failClosed
+}
+
+// RecordUpstreamRequest records the request about to be sent to the cluster,
+// together with the identity that triggered it. The body of the request is
+// left intact for sending.
+func (a *Auditor) RecordUpstreamRequest(ctx context.Context, cluster string, target string, request *http.Request) error {
+	body, err := readBody(request)
+	if err != nil {
+		return fmt.Errorf("failed to read upstream request body: %w", err)
+	}
+
+	entry := Entry{
+		Timestamp: time.Now(),
+		Cluster:   cluster,
+		Target:    target,
+		Method:    request.Method,
+		URL:       redactedURL(request),
+	}
+
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	if rMeta != nil && rMeta.AuthInfo != nil {
+		entry.APIKeyID = rMeta.AuthInfo.GetApiKeyId()
+		entry.UserID = rMeta.AuthInfo.GetUserId()
+	}
+
+	return a.sink.Write(ctx, a.signer.Sign(entry, body))
+}
+
+func (a *Auditor) Close() error {
+	return a.sink.Close()
+}
+
+func readBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return []byte{}, nil
+	}
+
+	if request.GetBody != nil {
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+
+		defer body.Close()
+
+		return io.ReadAll(body)
+	}
+
+	bs, err := io.ReadAll(request.Body)
+	_ = request.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	request.Body = io.NopCloser(bytes.NewReader(bs))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(bs)), nil
+	}
+
+	return bs, nil
+}
+
+func redactedURL(request *http.Request) string {
+	if request.URL == nil {
+		return ""
+	}
+
+	u := *request.URL
+	u.User = nil
+	u.RawQuery = ""
+	u.ForceQuery = false
+	u.Fragment = ""
+
+	return u.String()
+}
+
+var global atomic.Pointer[Auditor]
+
+// SetGlobal sets the Auditor used by the whole gateway.
+func SetGlobal(a *Auditor) {
+	global.Store(a)
+}
+
+// Global returns the Auditor used by the whole gateway, nil if auditing is
+// not enabled.
+func Global() *Auditor {
+	return global.Load()
+}
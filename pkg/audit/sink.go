@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+)
+
+var _ Sink = (*logSink)(nil)
+
+type logSink struct{}
+
+// NewLogSink creates a Sink writing entries into the logs.
+func NewLogSink() Sink {
+	return &logSink{}
+}
+
+func (s *logSink) Write(ctx context.Context, entry Entry) error {
+	slog.InfoContext(ctx, "audit: upstream request",
+		slog.Time("timestamp", entry.Timestamp),
+		slog.String("key_id", entry.KeyID),
+		slog.String("cluster", entry.Cluster),
+		slog.String("target", entry.Target),
+		slog.String("method", entry.Method),
+		slog.String("url", entry.URL),
+		slog.String("api_key_id", entry.APIKeyID),
+		slog.String("user_id", entry.UserID),
+		slog.String("body_sha256", entry.BodySHA256),
+		slog.Int("body_size", entry.BodySize),
+		slog.String("signature", entry.Signature),
+	)
+
+	return nil
+}
+
+func (s *logSink) Close() error {
+	return nil
+}
+
+var _ Sink = (*fileSink)(nil)
+
+type fileSink struct {
+	mutex   sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+// NewFileSink creates a Sink appending entries to the file as JSON lines.
+func NewFileSink(path string) (Sink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) //nolint:mnd
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit file %s: %w", path, err)
+	}
+
+	return &fileSink{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+func (s *fileSink) Write(_ context.Context, entry Entry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.encoder.Encode(entry)
+}
+
+func (s *fileSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.file.Close()
+}
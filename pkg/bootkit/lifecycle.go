@@ -70,3 +70,29 @@ func newLifeCycle() *lifeCycle {
 func NewEmptyLifeCycle() LifeCycle {
 	return &EmptyLifeCycle{}
 }
+
+// ReloadableLifeCycle collects the hooks of resources replaced as a whole on
+// reload, e.g. the listeners of a configuration file, so that they can be
+// stopped without stopping the application. The resources are started as
+// they are created, OnStart of the hooks is never called.
+type ReloadableLifeCycle struct {
+	lifeCycle
+}
+
+func NewReloadableLifeCycle() *ReloadableLifeCycle {
+	return &ReloadableLifeCycle{
+		lifeCycle: lifeCycle{
+			hooks: make([]lifeCycler, 0),
+		},
+	}
+}
+
+// Stop calls OnStop of the hooks appended.
+func (l *ReloadableLifeCycle) Stop(ctx context.Context) error {
+	hooks := l.GetHooks()
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	return callStopHooks(ctx, hooks)
+}
@@ -2,6 +2,7 @@ package bootkit
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -62,3 +63,31 @@ func TestLifeCycle_Append(t *testing.T) {
 
 	assert.Len(t, l.hooks, 2)
 }
+
+func TestReloadableLifeCycle_Stop(t *testing.T) {
+	t.Parallel()
+
+	l := NewReloadableLifeCycle()
+	require.NoError(t, l.Stop(context.Background()))
+
+	var started atomic.Bool
+
+	var stopped atomic.Int32
+
+	for range 2 {
+		l.Append(LifeCycleHook{
+			OnStart: func(ctx context.Context) error {
+				started.Store(true)
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				stopped.Add(1)
+				return nil
+			},
+		})
+	}
+
+	require.NoError(t, l.Stop(context.Background()))
+	assert.False(t, started.Load())
+	assert.Equal(t, int32(2), stopped.Load())
+}
@@ -0,0 +1,199 @@
+package capability
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/sharedstate"
+)
+
+const (
+	defaultTTL           = 10 * time.Minute
+	defaultFailureTTL    = time.Minute
+	defaultJitter        = 0.2
+	defaultStartupSpread = 30 * time.Second
+)
+
+type CacheOptions struct {
+	// Store keeps the probe results, shared across the replicas and kept
+	// over restarts of the gateway if the store has a remote. Default is
+	// sharedstate.Global().
+	Store *sharedstate.Store
+	// TTL is how long the probe results are cached. Default is 10m.
+	TTL time.Duration
+	// FailureTTL is how long the failures of probes are cached, so that
+	// failing backends are not probed on every lookup. Default is 1m.
+	FailureTTL time.Duration
+	// Jitter randomizes the TTLs by the fraction, so that the backends
+	// probed together expire at different times. Default is 0.2.
+	Jitter float64
+	// StartupSpread is the window the first probes of the backends without
+	// cached results are spread over, see NextProbe. Default is 30s.
+	StartupSpread time.Duration
+}
+
+type cacheEntry struct {
+	// URL is the url of the upstream probed, the results are discarded once
+	// the upstream changes.
+	URL          string        `json:"url"`
+	Capabilities *Capabilities `json:"capabilities,omitempty"`
+	Error        string        `json:"error,omitempty"`
+	ProbedAt     time.Time     `json:"probed_at"`
+	ExpiresAt    time.Time     `json:"expires_at"`
+}
+
+// Cache caches the capabilities probed from the backends per cluster.
+// Concurrent lookups of the same cluster share a single probe.
+type Cache struct {
+	options CacheOptions
+	now     func() time.Time
+	group   singleflight.Group
+}
+
+func NewCache(options CacheOptions) *Cache {
+	if options.Store == nil {
+		options.Store = sharedstate.Global()
+	}
+
+	if options.TTL <= 0 {
+		options.TTL = defaultTTL
+	}
+
+	if options.FailureTTL <= 0 {
+		options.FailureTTL = defaultFailureTTL
+	}
+
+	if options.Jitter <= 0 {
+		options.Jitter = defaultJitter
+	}
+
+	if options.StartupSpread <= 0 {
+		options.StartupSpread = defaultStartupSpread
+	}
+
+	return &Cache{
+		options: options,
+		now:     time.Now,
+	}
+}
+
+func (c *Cache) entry(cluster *v1alpha1.Cluster) (*cacheEntry, bool) {
+	value, ok := c.options.Store.Get(sharedstate.TableCapabilities, cluster.GetName())
+	if !ok {
+		return nil, false
+	}
+
+	var entry cacheEntry
+
+	err := json.Unmarshal([]byte(value), &entry)
+	if err != nil || entry.URL != cluster.GetUpstream().GetUrl() || !c.now().Before(entry.ExpiresAt) {
+		return nil, false
+	}
+
+	return &entry, true
+}
+
+// Cached returns the capabilities of the cluster cached, without probing.
+func (c *Cache) Cached(cluster *v1alpha1.Cluster) (*Capabilities, bool) {
+	entry, ok := c.entry(cluster)
+	if !ok || entry.Capabilities == nil {
+		return nil, false
+	}
+
+	return entry.Capabilities, true
+}
+
+// Get returns the capabilities of the cluster cached, or probes them with
+// probe if absent or expired. Failures are cached as well for FailureTTL.
+func (c *Cache) Get(ctx context.Context, cluster *v1alpha1.Cluster, probe ProbeFunc) (*Capabilities, error) {
+	entry, ok := c.entry(cluster)
+	if !ok {
+		v, err, _ := c.group.Do(cluster.GetName()+"\x00"+cluster.GetUpstream().GetUrl(), func() (any, error) {
+			// Probed by another lookup meanwhile
+			if entry, ok := c.entry(cluster); ok {
+				return entry, nil
+			}
+
+			return c.probe(ctx, cluster, probe), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		entry, _ = v.(*cacheEntry)
+	}
+
+	if entry.Error != "" {
+		return nil, errors.New(entry.Error)
+	}
+
+	return entry.Capabilities, nil
+}
+
+func (c *Cache) probe(ctx context.Context, cluster *v1alpha1.Cluster, probe ProbeFunc) *cacheEntry {
+	now := c.now()
+	entry := &cacheEntry{
+		URL:      cluster.GetUpstream().GetUrl(),
+		ProbedAt: now,
+	}
+
+	capabilities, err := probe(ctx, cluster)
+
+	ttl := c.options.TTL
+	if err != nil {
+		ttl = c.options.FailureTTL
+		entry.Error = err.Error()
+
+		slog.WarnContext(ctx, "failed to probe capabilities of cluster", slog.String("cluster", cluster.GetName()), slog.Any("error", err))
+	} else {
+		entry.Capabilities = capabilities
+	}
+
+	ttl = c.jitter(ttl)
+	entry.ExpiresAt = now.Add(ttl)
+
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return entry
+	}
+
+	err = c.options.Store.Set(ctx, sharedstate.TableCapabilities, cluster.GetName(), string(value), ttl)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to cache capabilities of cluster", slog.String("cluster", cluster.GetName()), slog.Any("error", err))
+	}
+
+	return entry
+}
+
+// Invalidate removes the capabilities of the cluster cached, e.g. once the
+// cluster is removed.
+func (c *Cache) Invalidate(ctx context.Context, cluster *v1alpha1.Cluster) error {
+	return c.options.Store.Delete(ctx, sharedstate.TableCapabilities, cluster.GetName())
+}
+
+// NextProbe returns how long to wait before probing the cluster, which is
+// until the results cached expire, or a random delay within StartupSpread if
+// nothing is cached, so that the backends are not probed all at once, e.g.
+// right after the gateway restarts.
+func (c *Cache) NextProbe(cluster *v1alpha1.Cluster) time.Duration {
+	entry, ok := c.entry(cluster)
+	if !ok {
+		return time.Duration(rand.Int64N(int64(c.options.StartupSpread))) //nolint:gosec
+	}
+
+	return entry.ExpiresAt.Sub(c.now())
+}
+
+// jitter returns the duration randomized by the jitter.
+func (c *Cache) jitter(d time.Duration) time.Duration {
+	jitter := (rand.Float64()*2 - 1) * c.options.Jitter //nolint:gosec
+
+	return time.Duration(float64(d) * (1 + jitter))
+}
@@ -0,0 +1,146 @@
+package capability
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/sharedstate"
+)
+
+func countingProbe(calls *atomic.Int64, err error) ProbeFunc {
+	return func(ctx context.Context, cluster *v1alpha1.Cluster) (*Capabilities, error) {
+		calls.Add(1)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &Capabilities{Models: []string{cluster.GetName()}, MaxContextLength: 32768}, nil
+	}
+}
+
+func TestCache_Get(t *testing.T) {
+	cache := NewCache(CacheOptions{Store: sharedstate.NewStore(sharedstate.Options{}), TTL: time.Minute, Jitter: 0.1})
+
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	cluster := gatewaytest.NewCluster("qwen2.5", "http://vllm:8000/v1")
+
+	var calls atomic.Int64
+
+	capabilities, err := cache.Get(context.Background(), cluster, countingProbe(&calls, nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(32768), capabilities.MaxContextLength)
+
+	_, err = cache.Get(context.Background(), cluster, countingProbe(&calls, nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), calls.Load())
+
+	cached, ok := cache.Cached(cluster)
+	require.True(t, ok)
+	assert.Equal(t, capabilities, cached)
+
+	// Expiring within the jitter
+	next := cache.NextProbe(cluster)
+	assert.GreaterOrEqual(t, next, 54*time.Second)
+	assert.LessOrEqual(t, next, 66*time.Second)
+
+	now = now.Add(2 * time.Minute)
+
+	_, err = cache.Get(context.Background(), cluster, countingProbe(&calls, nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), calls.Load())
+
+	// Changing upstreams are probed again
+	_, err = cache.Get(context.Background(), gatewaytest.NewCluster("qwen2.5", "http://vllm-2:8000/v1"), countingProbe(&calls, nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), calls.Load())
+}
+
+func TestCache_GetFailure(t *testing.T) {
+	cache := NewCache(CacheOptions{Store: sharedstate.NewStore(sharedstate.Options{}), FailureTTL: time.Second})
+
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	cluster := gatewaytest.NewCluster("qwen2.5", "http://vllm:8000/v1")
+
+	var calls atomic.Int64
+
+	for range 3 {
+		_, err := cache.Get(context.Background(), cluster, countingProbe(&calls, errors.New("connection refused")))
+		require.EqualError(t, err, "connection refused")
+	}
+
+	assert.Equal(t, int64(1), calls.Load())
+
+	_, ok := cache.Cached(cluster)
+	assert.False(t, ok)
+
+	now = now.Add(2 * time.Second)
+
+	_, err := cache.Get(context.Background(), cluster, countingProbe(&calls, nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), calls.Load())
+}
+
+func TestCache_GetConcurrent(t *testing.T) {
+	cache := NewCache(CacheOptions{Store: sharedstate.NewStore(sharedstate.Options{})})
+	cluster := gatewaytest.NewCluster("qwen2.5", "http://vllm:8000/v1")
+
+	var calls atomic.Int64
+
+	release := make(chan struct{})
+	probe := func(ctx context.Context, cluster *v1alpha1.Cluster) (*Capabilities, error) {
+		<-release
+		return countingProbe(&calls, nil)(ctx, cluster)
+	}
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			capabilities, err := cache.Get(context.Background(), cluster, probe)
+			assert.NoError(t, err)
+			assert.True(t, capabilities.SupportsEndpoint(object.RequestTypeChatCompletions))
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestCache_NextProbeWithoutCache(t *testing.T) {
+	cache := NewCache(CacheOptions{Store: sharedstate.NewStore(sharedstate.Options{}), StartupSpread: time.Minute})
+
+	delays := make(map[time.Duration]struct{})
+
+	for range 10 {
+		delay := cache.NextProbe(gatewaytest.NewCluster("qwen2.5", "http://vllm:8000/v1"))
+		assert.GreaterOrEqual(t, delay, time.Duration(0))
+		assert.Less(t, delay, time.Minute)
+
+		delays[delay] = struct{}{}
+	}
+
+	// Spread instead of probing all at once
+	assert.Greater(t, len(delays), 1)
+}
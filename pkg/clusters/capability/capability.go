@@ -0,0 +1,162 @@
+package capability
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/clusters/upstream"
+	"knoway.dev/pkg/object"
+)
+
+// maxModelsResponseSize bounds the response of listing models, providers
+// serving thousands of models respond with a few megabytes.
+const maxModelsResponseSize = 16 << 20
+
+// Capabilities are what a backend reports it supports.
+type Capabilities struct {
+	// Models are the models listed by the backend.
+	Models []string `json:"models,omitempty"`
+	// Endpoints are the types of requests supported by the model served,
+	// empty if the backend doesn't report them.
+	Endpoints []object.RequestType `json:"endpoints,omitempty"`
+	// MaxContextLength is the max context length of the model served, zero
+	// if the backend doesn't report it.
+	MaxContextLength int64 `json:"max_context_length,omitempty"`
+}
+
+// SupportsEndpoint reports whether the backend supports the type of requests,
+// backends not reporting endpoints are assumed to support all of them.
+func (c *Capabilities) SupportsEndpoint(typ object.RequestType) bool {
+	return len(c.Endpoints) == 0 || slices.Contains(c.Endpoints, typ)
+}
+
+// ProbeFunc probes the capabilities of the backend of the cluster.
+type ProbeFunc func(ctx context.Context, cluster *v1alpha1.Cluster) (*Capabilities, error)
+
+type modelsResponse struct {
+	Data []modelMetadata `json:"data"`
+}
+
+// modelMetadata is the union of the metadata of models reported by the
+// OpenAI compatible providers.
+type modelMetadata struct {
+	ID string `json:"id"`
+	// vLLM
+	MaxModelLen int64 `json:"max_model_len"`
+	// OpenRouter, Together AI
+	ContextLength int64 `json:"context_length"`
+	// Groq
+	ContextWindow int64 `json:"context_window"`
+	// Together AI
+	Type string `json:"type"`
+	// Mistral
+	Capabilities map[string]bool `json:"capabilities"`
+}
+
+func (m modelMetadata) maxContextLength() int64 {
+	return lo.CoalesceOrEmpty(m.MaxModelLen, m.ContextLength, m.ContextWindow)
+}
+
+func (m modelMetadata) endpoints() []object.RequestType {
+	endpoints := make([]object.RequestType, 0)
+
+	switch m.Type {
+	case "chat":
+		endpoints = append(endpoints, object.RequestTypeChatCompletions)
+	case "language", "code":
+		endpoints = append(endpoints, object.RequestTypeCompletions)
+	case "embedding":
+		endpoints = append(endpoints, object.RequestTypeEmbeddings)
+	case "rerank":
+		endpoints = append(endpoints, object.RequestTypeRerank)
+	case "image":
+		endpoints = append(endpoints, object.RequestTypeImageGenerations, object.RequestTypeImageEdits, object.RequestTypeImageVariations)
+	case "video":
+		endpoints = append(endpoints, object.RequestTypeVideoGenerations)
+	case "moderation":
+		endpoints = append(endpoints, object.RequestTypeModerations)
+	case "audio":
+		endpoints = append(endpoints, object.RequestTypeTextToSpeech)
+	case "transcribe":
+		endpoints = append(endpoints, object.RequestTypeSpeechToText)
+	}
+
+	if m.Capabilities["completion_chat"] {
+		endpoints = append(endpoints, object.RequestTypeChatCompletions)
+	}
+
+	if m.Capabilities["completion_fim"] {
+		endpoints = append(endpoints, object.RequestTypeCompletions)
+	}
+
+	return lo.Uniq(endpoints)
+}
+
+// ProbeModels probes the capabilities from the models listed by the OpenAI
+// compatible backend, the metadata of the model served by the cluster, or
+// the only model listed, are taken.
+func ProbeModels(client *http.Client) ProbeFunc {
+	return func(ctx context.Context, cluster *v1alpha1.Cluster) (*Capabilities, error) {
+		u, err := upstream.BuildURL(cluster.GetUpstream(), upstream.PathModels, cluster.GetName())
+		if err != nil {
+			return nil, err
+		}
+
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		err = upstream.ApplyCredentials(ctx, cluster.GetUpstream(), request)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(request)
+		if err != nil {
+			return nil, err
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %d listing models", resp.StatusCode)
+		}
+
+		var models modelsResponse
+
+		err = json.NewDecoder(io.LimitReader(resp.Body, maxModelsResponseSize)).Decode(&models)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode models: %w", err)
+		}
+
+		capabilities := &Capabilities{
+			Models: lo.Map(models.Data, func(item modelMetadata, _ int) string {
+				return item.ID
+			}),
+		}
+
+		// The name of the cluster is the model served unless renamed, then
+		// the backend likely serves the only model
+		served, ok := lo.Find(models.Data, func(item modelMetadata) bool {
+			return item.ID == cluster.GetName()
+		})
+		if !ok && len(models.Data) == 1 {
+			served, ok = models.Data[0], true
+		}
+
+		if ok {
+			capabilities.Endpoints = served.endpoints()
+			capabilities.MaxContextLength = served.maxContextLength()
+		}
+
+		return capabilities, nil
+	}
+}
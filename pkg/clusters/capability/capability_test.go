@@ -0,0 +1,66 @@
+package capability
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/object"
+)
+
+func TestProbeModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "/v1/models", request.URL.Path)
+		assert.Equal(t, "Bearer sk-test", request.Header.Get("Authorization"))
+		assert.Equal(t, "test", request.URL.Query().Get("api-version"))
+
+		_, _ = writer.Write([]byte(`{"object": "list", "data": [
+			{"id": "qwen2.5", "object": "model", "max_model_len": 32768},
+			{"id": "mistral-large", "object": "model", "capabilities": {"completion_chat": true, "completion_fim": false}, "context_length": 131072}
+		]}`))
+	}))
+	defer server.Close()
+
+	probe := ProbeModels(server.Client())
+
+	cluster := &v1alpha1.Cluster{
+		Name: "qwen2.5",
+		Upstream: &v1alpha1.Upstream{
+			Url:     server.URL + "/v1",
+			Headers: []*v1alpha1.Upstream_Header{{Key: "Authorization", Value: "Bearer sk-test"}},
+			Query:   map[string]string{"api-version": "test"},
+		},
+	}
+
+	capabilities, err := probe(context.Background(), cluster)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"qwen2.5", "mistral-large"}, capabilities.Models)
+	assert.Equal(t, int64(32768), capabilities.MaxContextLength)
+	assert.Empty(t, capabilities.Endpoints)
+
+	cluster.Name = "mistral-large"
+
+	capabilities, err = probe(context.Background(), cluster)
+	require.NoError(t, err)
+	assert.Equal(t, int64(131072), capabilities.MaxContextLength)
+	assert.Equal(t, []object.RequestType{object.RequestTypeChatCompletions}, capabilities.Endpoints)
+	assert.False(t, capabilities.SupportsEndpoint(object.RequestTypeEmbeddings))
+}
+
+func TestProbeModelsFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := ProbeModels(server.Client())(context.Background(), &v1alpha1.Cluster{
+		Name:     "qwen2.5",
+		Upstream: &v1alpha1.Upstream{Url: server.URL},
+	})
+	require.Error(t, err)
+}
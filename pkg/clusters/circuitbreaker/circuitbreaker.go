@@ -0,0 +1,248 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/sharedstate"
+)
+
+const (
+	DefaultConsecutiveErrors = 5
+	DefaultCooldown          = 30 * time.Second
+)
+
+// Options of the circuit breaker of a cluster, zero values fall back to
+// defaults.
+type Options struct {
+	// ConsecutiveErrors is the number of consecutive errors for the cluster to
+	// be ejected, default is DefaultConsecutiveErrors.
+	ConsecutiveErrors int
+	// Cooldown is how long the cluster stays ejected, default is
+	// DefaultCooldown.
+	Cooldown time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	o.ConsecutiveErrors = lo.CoalesceOrEmpty(o.ConsecutiveErrors, DefaultConsecutiveErrors)
+	o.Cooldown = lo.CoalesceOrEmpty(o.Cooldown, DefaultCooldown)
+
+	return o
+}
+
+// OptionsFromCluster returns the options of the circuit breaker configured for
+// the cluster, false if the circuit breaker is disabled.
+func OptionsFromCluster(cluster *v1alpha1.Cluster) (Options, bool) {
+	cb := cluster.GetCircuitBreaker()
+	if cb == nil {
+		return Options{}, false
+	}
+
+	return Options{
+		ConsecutiveErrors: int(cb.GetConsecutiveErrors()),
+		Cooldown:          cb.GetCooldown().AsDuration(),
+	}, true
+}
+
+// IsFailure reports whether the error counts towards ejecting the upstream,
+// that is a 5xx response or a timeout. Errors caused by the requests of the
+// clients, such as 4xx responses, never do.
+func IsFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var llmErr object.LLMError
+	if errors.As(err, &llmErr) {
+		return llmErr.GetStatus() >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
+type state struct {
+	consecutiveErrors int
+	ejectedUntil      time.Time
+}
+
+// Breakers tracks the consecutive errors of clusters by name and ejects them
+// for a cooldown once the threshold is reached. When the cooldown elapses the
+// cluster takes requests again, and a single failure ejects it once more.
+//
+// The ejections are shared with the other replicas of the gateway through
+// sharedstate.TableBreakers, expiring with the cooldown, so that the replicas
+// eject the cluster and take it back half-open together.
+type Breakers struct {
+	mutex  sync.Mutex
+	states map[string]*state
+
+	now   func() time.Time
+	store func() *sharedstate.Store
+}
+
+func NewBreakers() *Breakers {
+	return &Breakers{
+		states: make(map[string]*state),
+		now:    time.Now,
+		store:  sharedstate.Global,
+	}
+}
+
+// Record records the outcome of a request sent to the cluster, and reports
+// whether the cluster got ejected by it.
+func (b *Breakers) Record(name string, opts Options, err error) bool {
+	opts = opts.withDefaults()
+
+	ejectedUntil, closed := b.record(name, opts, err)
+
+	switch {
+	case !ejectedUntil.IsZero():
+		b.share(name, ejectedUntil, opts.Cooldown)
+	case closed:
+		b.unshare(name)
+	}
+
+	return !ejectedUntil.IsZero()
+}
+
+// record updates the state of the cluster by the outcome, and returns until
+// when the cluster got ejected by it, or whether the ejection was closed.
+func (b *Breakers) record(name string, opts Options, err error) (time.Time, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	s := b.stateLocked(name)
+	if s == nil {
+		s = new(state)
+		b.states[name] = s
+	}
+
+	if !IsFailure(err) {
+		closed := !s.ejectedUntil.IsZero()
+
+		s.consecutiveErrors = 0
+		s.ejectedUntil = time.Time{}
+
+		return time.Time{}, closed
+	}
+
+	now := b.now()
+	if now.Before(s.ejectedUntil) {
+		// Requests in-flight when the cluster got ejected
+		return time.Time{}, false
+	}
+
+	s.consecutiveErrors++
+
+	// Half-open after a cooldown, the first failure ejects it again
+	if s.consecutiveErrors < opts.ConsecutiveErrors && s.ejectedUntil.IsZero() {
+		return time.Time{}, false
+	}
+
+	s.ejectedUntil = now.Add(opts.Cooldown)
+
+	slog.Warn("cluster ejected by circuit breaker",
+		"cluster", name,
+		"consecutiveErrors", s.consecutiveErrors,
+		"cooldown", opts.Cooldown,
+		"error", err,
+	)
+
+	return s.ejectedUntil, false
+}
+
+// stateLocked returns the state of the cluster with the ejection shared by
+// the other replicas applied, nil if the cluster has neither. Must be called
+// with the mutex held.
+func (b *Breakers) stateLocked(name string) *state {
+	s := b.states[name]
+
+	ejectedUntil := b.sharedEjectedUntil(name)
+	if ejectedUntil.IsZero() {
+		return s
+	}
+
+	if s == nil {
+		s = new(state)
+		b.states[name] = s
+	}
+
+	if ejectedUntil.After(s.ejectedUntil) {
+		s.ejectedUntil = ejectedUntil
+	}
+
+	return s
+}
+
+// sharedEjectedUntil returns until when the cluster is ejected by the shared
+// state, zero if it isn't.
+func (b *Breakers) sharedEjectedUntil(name string) time.Time {
+	value, ok := b.store().Get(sharedstate.TableBreakers, name)
+	if !ok {
+		return time.Time{}
+	}
+
+	ejectedUntil, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return ejectedUntil
+}
+
+// share shares the ejection of the cluster until it's half-open.
+func (b *Breakers) share(name string, ejectedUntil time.Time, cooldown time.Duration) {
+	err := b.store().Set(context.Background(), sharedstate.TableBreakers, name, ejectedUntil.Format(time.RFC3339Nano), cooldown)
+	if err != nil {
+		slog.Warn("failed to share the ejection of cluster", "cluster", name, "error", err)
+	}
+}
+
+// unshare removes the ejection of the cluster from the shared state if any.
+func (b *Breakers) unshare(name string) {
+	store := b.store()
+	if _, ok := store.Get(sharedstate.TableBreakers, name); !ok {
+		return
+	}
+
+	err := store.Delete(context.Background(), sharedstate.TableBreakers, name)
+	if err != nil {
+		slog.Warn("failed to remove the shared ejection of cluster", "cluster", name, "error", err)
+	}
+}
+
+// Ejected reports whether the cluster is ejected, by this replica or by the
+// others.
+func (b *Breakers) Ejected(name string) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	s := b.stateLocked(name)
+	if s == nil {
+		return false
+	}
+
+	return b.now().Before(s.ejectedUntil)
+}
+
+// Forget drops the state of the cluster, e.g. when it's removed or its
+// circuit breaker is disabled.
+func (b *Breakers) Forget(name string) {
+	b.mutex.Lock()
+	delete(b.states, name)
+	b.mutex.Unlock()
+
+	b.unshare(name)
+}
@@ -0,0 +1,145 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/sharedstate"
+)
+
+func TestIsFailure(t *testing.T) {
+	assert.False(t, IsFailure(nil))
+	assert.True(t, IsFailure(object.NewErrorBadGateway(errors.New("connection refused"))))
+	assert.True(t, IsFailure(fmt.Errorf("upstream: %w", context.DeadlineExceeded)))
+	assert.True(t, IsFailure(&object.BaseLLMError{Status: 503}))
+	assert.False(t, IsFailure(&object.BaseLLMError{Status: 429}))
+	assert.False(t, IsFailure(object.NewErrorModelNotFoundOrNotAccessible("gpt-4o")))
+	assert.False(t, IsFailure(errors.New("unknown")))
+}
+
+func TestOptionsFromCluster(t *testing.T) {
+	_, ok := OptionsFromCluster(&v1alpha1.Cluster{})
+	assert.False(t, ok)
+
+	opts, ok := OptionsFromCluster(&v1alpha1.Cluster{
+		CircuitBreaker: &v1alpha1.ClusterCircuitBreaker{
+			ConsecutiveErrors: 3,
+			Cooldown:          durationpb.New(time.Minute),
+		},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, Options{ConsecutiveErrors: 3, Cooldown: time.Minute}, opts)
+
+	// Zero values fall back to defaults
+	opts, ok = OptionsFromCluster(&v1alpha1.Cluster{CircuitBreaker: &v1alpha1.ClusterCircuitBreaker{}})
+	assert.True(t, ok)
+	assert.Equal(t, Options{ConsecutiveErrors: DefaultConsecutiveErrors, Cooldown: DefaultCooldown}, opts.withDefaults())
+}
+
+func newTestBreakers(store *sharedstate.Store, now *time.Time) *Breakers {
+	breakers := NewBreakers()
+	breakers.now = func() time.Time { return *now }
+	breakers.store = func() *sharedstate.Store { return store }
+
+	return breakers
+}
+
+func TestBreakers(t *testing.T) {
+	now := time.Now()
+	breakers := newTestBreakers(sharedstate.NewStore(sharedstate.Options{}), &now)
+
+	opts := Options{ConsecutiveErrors: 3, Cooldown: time.Minute}
+	upstreamErr := object.NewErrorBadGateway(errors.New("connection refused"))
+
+	// Successes reset the consecutive errors
+	assert.False(t, breakers.Record("gpt-4o", opts, upstreamErr))
+	assert.False(t, breakers.Record("gpt-4o", opts, upstreamErr))
+	assert.False(t, breakers.Record("gpt-4o", opts, nil))
+	assert.False(t, breakers.Record("gpt-4o", opts, upstreamErr))
+	assert.False(t, breakers.Record("gpt-4o", opts, upstreamErr))
+	assert.False(t, breakers.Ejected("gpt-4o"))
+
+	// Errors of clients don't count
+	assert.False(t, breakers.Record("gpt-4o", opts, &object.BaseLLMError{Status: 400}))
+	assert.False(t, breakers.Ejected("gpt-4o"))
+
+	assert.False(t, breakers.Record("gpt-4o", opts, upstreamErr))
+	assert.False(t, breakers.Record("gpt-4o", opts, upstreamErr))
+	assert.True(t, breakers.Record("gpt-4o", opts, upstreamErr))
+	assert.True(t, breakers.Ejected("gpt-4o"))
+	assert.False(t, breakers.Ejected("gpt-4o-mini"))
+
+	// Requests in-flight during the ejection don't extend it
+	now = now.Add(opts.Cooldown / 2)
+	assert.False(t, breakers.Record("gpt-4o", opts, upstreamErr))
+	assert.True(t, breakers.Ejected("gpt-4o"))
+
+	now = now.Add(opts.Cooldown / 2)
+	assert.False(t, breakers.Ejected("gpt-4o"))
+
+	// A single failure after the cooldown ejects it again
+	assert.True(t, breakers.Record("gpt-4o", opts, upstreamErr))
+	assert.True(t, breakers.Ejected("gpt-4o"))
+
+	now = now.Add(opts.Cooldown)
+	assert.False(t, breakers.Record("gpt-4o", opts, nil))
+	assert.False(t, breakers.Record("gpt-4o", opts, upstreamErr))
+	assert.False(t, breakers.Ejected("gpt-4o"))
+
+	breakers.Record("gpt-4o", opts, upstreamErr)
+	breakers.Record("gpt-4o", opts, upstreamErr)
+	assert.True(t, breakers.Ejected("gpt-4o"))
+
+	breakers.Forget("gpt-4o")
+	assert.False(t, breakers.Ejected("gpt-4o"))
+}
+
+func TestBreakers_Shared(t *testing.T) {
+	now := time.Now()
+	store := sharedstate.NewStore(sharedstate.Options{})
+	replicaA := newTestBreakers(store, &now)
+	replicaB := newTestBreakers(store, &now)
+
+	opts := Options{ConsecutiveErrors: 2, Cooldown: time.Minute}
+	upstreamErr := object.NewErrorBadGateway(errors.New("connection refused"))
+
+	// Ejections are shared with the other replicas
+	replicaA.Record("gpt-4o", opts, upstreamErr)
+	assert.True(t, replicaA.Record("gpt-4o", opts, upstreamErr))
+	assert.True(t, replicaB.Ejected("gpt-4o"))
+
+	value, ok := store.Get(sharedstate.TableBreakers, "gpt-4o")
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(opts.Cooldown).Format(time.RFC3339Nano), value)
+
+	// Half-open on the other replicas too, a single failure ejects it again
+	now = now.Add(opts.Cooldown)
+	assert.False(t, replicaB.Ejected("gpt-4o"))
+	assert.True(t, replicaB.Record("gpt-4o", opts, upstreamErr))
+	assert.True(t, replicaA.Ejected("gpt-4o"))
+
+	// Closed by a success after the cooldown
+	now = now.Add(opts.Cooldown)
+	assert.False(t, replicaA.Record("gpt-4o", opts, nil))
+
+	_, ok = store.Get(sharedstate.TableBreakers, "gpt-4o")
+	assert.False(t, ok)
+
+	replicaA.Record("gpt-4o", opts, upstreamErr)
+	replicaA.Record("gpt-4o", opts, upstreamErr)
+	assert.True(t, replicaB.Ejected("gpt-4o"))
+
+	replicaB.Forget("gpt-4o")
+	assert.False(t, replicaB.Ejected("gpt-4o"))
+
+	_, ok = store.Get(sharedstate.TableBreakers, "gpt-4o")
+	assert.False(t, ok)
+}
@@ -5,22 +5,36 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/nekomeowww/fo"
 	"github.com/samber/lo"
 	"github.com/samber/lo/mutable"
 	"github.com/samber/mo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
 
 	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/audit"
 	"knoway.dev/pkg/bootkit"
 	"knoway.dev/pkg/clusters"
+	"knoway.dev/pkg/clusters/connection"
 	"knoway.dev/pkg/clusters/filters"
+	"knoway.dev/pkg/clusters/upstream"
 	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/metering"
 	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/observation"
 	registryfilters "knoway.dev/pkg/registry/config"
+	"knoway.dev/pkg/route/loadbalance"
 	"knoway.dev/pkg/utils"
 )
 
@@ -30,6 +44,14 @@ type clusterDefault struct {
 	cluster         *v1alpha1.Cluster
 	filters         filters.ClusterFilters
 	reversedFilters filters.ClusterFilters
+	// marshallers are the default filters followed by the configured ones,
+	// the default filters build the upstream requests for the configured
+	// ones to adapt, the same way the default filters unmarshal responses
+	// before the configured ones in reversedFilters.
+	marshallers filters.ClusterFilters
+	// next is the turn of the endpoints of the upstream when none of the
+	// endpoint selectors chooses one
+	next atomic.Uint64
 }
 
 func NewWithConfigs(clusterProtoMsg proto.Message, lifecycle bootkit.LifeCycle) (clusters.Cluster, error) {
@@ -38,13 +60,23 @@ func NewWithConfigs(clusterProtoMsg proto.Message, lifecycle bootkit.LifeCycle)
 		return nil, fmt.Errorf("invalid config type %T", cluster)
 	}
 
+	err := metering.Validate(cluster.GetMeteringPolicy())
+	if err != nil {
+		return nil, err
+	}
+
+	err = upstream.Validate(cluster.GetUpstream())
+	if err != nil {
+		return nil, err
+	}
+
 	var clusterFilters []filters.ClusterFilter
 
 	for _, fc := range cluster.GetFilters() {
 		if f, err := registryfilters.NewClusterFilterWithConfig(fc.GetName(), fc.GetConfig(), lifecycle); err != nil {
 			return nil, err
 		} else {
-			clusterFilters = append(clusterFilters, f)
+			clusterFilters = append(clusterFilters, filters.Sandbox(f, cluster.GetName(), fc.GetName(), fc.GetTimeout().AsDuration()))
 		}
 	}
 
@@ -58,24 +90,35 @@ func NewWithConfigs(clusterProtoMsg proto.Message, lifecycle bootkit.LifeCycle)
 		// TODO: implement
 	case v1alpha1.LoadBalancePolicy_CUSTOM, v1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_UNSPECIFIED:
 		_, ok := lo.Find(clusterFilters, func(f filters.ClusterFilter) bool {
-			selector, ok := f.(filters.ClusterFilterEndpointSelector)
+			selector, ok := filters.Unwrap(f).(filters.ClusterFilterEndpointSelector)
 			return ok && selector != nil
 		})
 		if !ok {
 			return nil, errors.New("custom load balance policy must be implemented")
 		}
+	case v1alpha1.LoadBalancePolicy_LEAST_LATENCY:
+		// Balanced by the latencies of the endpoints in selectEndpoint
+		fallthrough
 	default:
 		// if use internal lb, filter must NOT implement SelectEndpoint
 		if lo.SomeBy(clusterFilters, func(f filters.ClusterFilter) bool {
-			selector, ok := f.(filters.ClusterFilterEndpointSelector)
+			selector, ok := filters.Unwrap(f).(filters.ClusterFilterEndpointSelector)
 			return ok && selector != nil
 		}) {
 			return nil, errors.New("internal load balance policy must NOT be implemented")
 		}
 	}
 
+	configuredFilters := utils.Clone(clusterFilters)
+
 	// Add default filters
-	clusterFilters = append(clusterFilters, registryfilters.ClusterDefaultFilters(lifecycle)...)
+	var defaultFilters []filters.ClusterFilter
+	for _, f := range registryfilters.ClusterDefaultFilters(lifecycle) {
+		defaultFilters = append(defaultFilters, filters.Sandbox(f, cluster.GetName(), "", 0))
+	}
+
+	clusterFilters = append(clusterFilters, defaultFilters...)
+
 	reversedClusterFilters := utils.Clone(clusterFilters)
 	// NOTICE: mutable.Reverse will modify the original slice, so we need to clone it
 	mutable.Reverse(reversedClusterFilters)
@@ -84,6 +127,7 @@ func NewWithConfigs(clusterProtoMsg proto.Message, lifecycle bootkit.LifeCycle)
 		cluster:         cluster,
 		filters:         clusterFilters,
 		reversedFilters: reversedClusterFilters,
+		marshallers:     append(defaultFilters, configuredFilters...),
 	}, nil
 }
 
@@ -98,54 +142,207 @@ func (m *clusterDefault) GetClusterConfig() *v1alpha1.Cluster {
 func (m *clusterDefault) DoUpstreamRequest(ctx context.Context, llmReq object.LLMRequest) (object.LLMResponse, error) {
 	var err error
 
+	ctx, span := observation.Tracer().Start(ctx, observation.SpanNameCluster, trace.WithAttributes(
+		observation.KnowayClusterName.AsAttribute().String(m.cluster.GetName()),
+		observation.KnowayClusterProvider.AsAttribute().String(m.cluster.GetProvider().String()),
+	))
+	defer span.End()
+
 	rMeta := metadata.RequestMetadataFromCtx(ctx)
-	rMeta.UpstreamProvider = m.cluster.GetProvider()
+
+	attempt := metadata.UpstreamAttemptFromCtx(ctx)
+	if attempt == nil {
+		attempt = rMeta.NewUpstreamAttempt(m.cluster.GetName(), m.cluster.GetName())
+	}
+
+	rMeta.UpdateUpstreamAttempt(attempt, func(attempt *metadata.UpstreamAttempt) {
+		attempt.Provider = m.cluster.GetProvider()
+	})
 
 	llmReq, err = m.filters.ForEachRequestModifier(ctx, m.cluster, llmReq)
 	if err != nil {
 		return nil, object.LLMErrorOrInternalError(err)
 	}
 
-	rMeta.UpstreamRequestModel = llmReq.GetModel()
+	rMeta.UpdateUpstreamAttempt(attempt, func(attempt *metadata.UpstreamAttempt) {
+		attempt.RequestModel = llmReq.GetModel()
+	})
+
+	llmResp, err := m.executeUpstreamRequest(ctx, rMeta, attempt, llmReq)
+	if err != nil {
+		observation.RecordSpanError(span, err)
+		return nil, err
+	}
+
+	if !lo.IsNil(llmResp) {
+		return m.handleUpstreamResponse(ctx, rMeta, attempt, llmReq, llmResp)
+	}
 
 	var req *http.Request
 
-	req, err = m.filters.ForEachUpstreamRequestMarshaller(ctx, m.cluster, llmReq, req)
+	req, err = m.marshallers.ForEachUpstreamRequestMarshaller(ctx, m.cluster, llmReq, req)
 	if err != nil {
 		return nil, object.LLMErrorOrInternalError(err)
 	}
 
-	rMeta.UpstreamRequestAt = time.Now()
+	if endpoint := m.selectEndpoint(ctx, llmReq); endpoint != "" {
+		err = upstream.ApplyEndpoint(req.URL, endpoint)
+		if err != nil {
+			return nil, object.NewErrorInternalError(err)
+		}
+
+		rMeta.UpdateUpstreamAttempt(attempt, func(attempt *metadata.UpstreamAttempt) {
+			attempt.Endpoint = endpoint
+		})
+	}
+
+	setDeadlineHeader(m.cluster, rMeta, req)
+
+	if auditor := audit.Global(); auditor != nil {
+		err = auditor.RecordUpstreamRequest(ctx, m.cluster.GetName(), rMeta.UpstreamAttemptValue(attempt).Target, req)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to record audit entry of upstream request", slog.String("cluster", m.cluster.GetName()), slog.Any("error", err))
+
+			if auditor.FailClosed() {
+				return nil, object.NewErrorInternalError(err)
+			}
+		}
+	}
+
+	rMeta.UpdateUpstreamAttempt(attempt, func(attempt *metadata.UpstreamAttempt) {
+		attempt.RequestAt = time.Now()
+	})
+
+	upstreamCtx, upstreamSpan := observation.Tracer().Start(ctx, observation.SpanNameUpstream,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			// Query parameters may carry upstream credentials
+			observation.KnowayUpstreamURL.AsAttribute().String((&url.URL{Scheme: req.URL.Scheme, Host: req.URL.Host, Path: req.URL.Path}).String()),
+		),
+	)
+
+	if observation.PropagateUpstream() {
+		otel.GetTextMapPropagator().Inject(upstreamCtx, propagation.HeaderCarrier(req.Header))
+	}
 
 	// TODO: body close
-	rawResp, buffer, err := doRequest(req) //nolint:bodyclose
+	rawResp, buffer, err := doRequest(connection.Client(m.cluster), req) //nolint:bodyclose
 	if err != nil {
-		return nil, object.NewErrorBadGateway(err)
+		observation.RecordSpanError(upstreamSpan, err)
+	} else {
+		upstreamSpan.SetAttributes(attribute.Int("http.response.status_code", rawResp.StatusCode))
 	}
 
+	upstreamSpan.End()
+
 	// err != nil means the connection is not possible to establish
 	// or find it's way to the destination, or upstream timeout
-	rMeta.UpstreamRespondAt = time.Now()
+	rMeta.UpdateUpstreamAttempt(attempt, func(attempt *metadata.UpstreamAttempt) {
+		attempt.RespondAt = time.Now()
+
+		if err != nil {
+			return
+		}
+
+		attempt.ResponseStatusCode = rawResp.StatusCode
+		attempt.ResponseHeader = mo.Some(rawResp.Header)
+		attempt.ProcessingDuration, _ = parseUpstreamProcessingDuration(rawResp.Header)
 
-	var llmResp object.LLMResponse
+		observation.ObserveUpstreamAttempt(*attempt)
+	})
+
+	if err != nil {
+		observation.RecordSpanError(span, err)
+		return nil, object.NewErrorBadGateway(err)
+	}
 
 	llmResp, err = m.reversedFilters.ForEachResponseUnmarshaller(ctx, m.cluster, llmReq, rawResp, buffer, llmResp)
 	if err != nil {
 		return nil, object.LLMErrorOrInternalError(err)
 	}
 
-	rMeta.UpstreamResponseModel = llmResp.GetModel()
+	return m.handleUpstreamResponse(ctx, rMeta, attempt, llmReq, llmResp)
+}
+
+// selectEndpoint returns the endpoint of the upstream the request is sent to,
+// chosen by the endpoint selectors, the load balance policy of the cluster or
+// each of the endpoints in turn, empty when the upstream has no endpoints and
+// the request is sent to its url.
+func (m *clusterDefault) selectEndpoint(ctx context.Context, llmReq object.LLMRequest) string {
+	endpoints := m.cluster.GetUpstream().GetEndpoints()
+	if len(endpoints) == 0 {
+		return ""
+	}
+
+	if selected := m.filters.ForEachEndpointSelector(ctx, m.cluster, llmReq, endpoints); selected != "" {
+		return selected
+	}
+
+	nextEndpoint := func() string {
+		return endpoints[(m.next.Add(1)-1)%uint64(len(endpoints))]
+	}
+
+	if m.cluster.GetLoadBalancePolicy() == v1alpha1.LoadBalancePolicy_LEAST_LATENCY {
+		return loadbalance.LeastLatencyEndpoint(endpoints, nextEndpoint)
+	}
+
+	return nextEndpoint()
+}
+
+// executeUpstreamRequest executes the request by the upstream executors of the
+// cluster, the response is nil if none of them executed it and the request is
+// meant to be sent over HTTP.
+func (m *clusterDefault) executeUpstreamRequest(ctx context.Context, rMeta *metadata.RequestMetadata, attempt *metadata.UpstreamAttempt, llmReq object.LLMRequest) (object.LLMResponse, error) {
+	requestAt := time.Now()
+
+	llmResp, err := m.marshallers.ForEachUpstreamExecutor(ctx, m.cluster, llmReq)
+	if err == nil && lo.IsNil(llmResp) {
+		return nil, nil //nolint:nilnil
+	}
+
+	var llmErr object.LLMError
+	if err != nil {
+		llmErr = object.LLMErrorOrInternalError(err)
+	}
+
+	rMeta.UpdateUpstreamAttempt(attempt, func(attempt *metadata.UpstreamAttempt) {
+		attempt.RequestAt = requestAt
+		attempt.RespondAt = time.Now()
+		attempt.ResponseStatusCode = http.StatusOK
+
+		if llmErr != nil {
+			attempt.ResponseStatusCode = llmErr.GetStatus()
+		}
+
+		observation.ObserveUpstreamAttempt(*attempt)
+	})
+
+	if llmErr != nil {
+		return nil, llmErr
+	}
+
+	return llmResp, nil
+}
+
+// handleUpstreamResponse applies the response filters of the cluster to the
+// response of the upstream, and records the usage of it.
+func (m *clusterDefault) handleUpstreamResponse(ctx context.Context, rMeta *metadata.RequestMetadata, attempt *metadata.UpstreamAttempt, llmReq object.LLMRequest, llmResp object.LLMResponse) (object.LLMResponse, error) {
+	var err error
+
+	rMeta.UpdateUpstreamAttempt(attempt, func(attempt *metadata.UpstreamAttempt) {
+		attempt.ResponseModel = llmResp.GetModel()
+	})
 
 	llmResp, err = m.reversedFilters.ForEachResponseModifier(ctx, m.cluster, llmReq, llmResp)
 	if err != nil {
 		return nil, object.LLMErrorOrInternalError(err)
 	}
 
-	rMeta.UpstreamResponseStatusCode = rawResp.StatusCode
-	rMeta.UpstreamResponseHeader = mo.Some(rawResp.Header)
-
 	if !lo.IsNil(llmResp.GetError()) {
-		rMeta.UpstreamResponseErrorMessage = llmResp.GetError().Error()
+		rMeta.UpdateUpstreamAttempt(attempt, func(attempt *metadata.UpstreamAttempt) {
+			attempt.ResponseErrorMessage = llmResp.GetError().Error()
+		})
 	}
 
 	if !llmResp.IsStream() {
@@ -164,6 +361,8 @@ func (m *clusterDefault) DoUpstreamRequest(ctx context.Context, llmReq object.LL
 				rMeta.LLMUpstreamTokensUsage = mo.Some(lo.Must(object.AsLLMTokensUsage(llmResp.GetUsage())))
 			}
 
+			setSystemFingerprint(rMeta, llmResp)
+
 			return m.doUpstreamResponseComplete(ctx, llmReq, llmResp)
 		})
 	}
@@ -174,18 +373,51 @@ func (m *clusterDefault) DoUpstreamRequest(ctx context.Context, llmReq object.LL
 		if !llmResp.IsStream() && !lo.IsNil(llmResp.GetUsage()) {
 			rMeta.LLMUpstreamTokensUsage = mo.Some(lo.Must(object.AsLLMTokensUsage(llmResp.GetUsage())))
 		}
-	case object.RequestTypeImageGenerations:
+
+		if !llmResp.IsStream() {
+			setSystemFingerprint(rMeta, llmResp)
+		}
+	case object.RequestTypeImageGenerations, object.RequestTypeImageEdits, object.RequestTypeImageVariations:
 		// For non-streaming responses, usage should be set here
 		if !lo.IsNil(llmResp.GetUsage()) {
 			rMeta.LLMUpstreamImagesUsage = mo.Some(lo.Must(object.AsLLMImagesUsage(llmResp.GetUsage())))
 		}
+	case object.RequestTypeVideoGenerations:
+		// Only the jobs submitted report usage, they are metered once
+		if !lo.IsNil(llmResp.GetUsage()) {
+			rMeta.LLMUpstreamVideosUsage = mo.Some(lo.Must(object.AsLLMVideosUsage(llmResp.GetUsage())))
+		}
+	case object.RequestTypeEmbeddings, object.RequestTypeRerank:
+		if !lo.IsNil(llmResp.GetUsage()) {
+			rMeta.LLMUpstreamTokensUsage = mo.Some(lo.Must(object.AsLLMTokensUsage(llmResp.GetUsage())))
+		}
+	case object.RequestTypeModerations:
+		// Not every moderation upstream reports usage
+		if !lo.IsNil(llmResp.GetUsage()) {
+			if tokensUsage, ok := object.AsLLMTokensUsage(llmResp.GetUsage()); ok {
+				rMeta.LLMUpstreamTokensUsage = mo.Some(tokensUsage)
+			}
+		}
 	case object.RequestTypeTextToSpeech:
 		// no usage tracking for text-to-speech yet
+	case object.RequestTypeSpeechToText:
+		// no usage tracking for speech-to-text yet
+	case object.RequestTypeRealtime:
+		// usage is accumulated over the session, set once it ends by the
+		// listener
 	}
 
 	return llmResp, nil
 }
 
+// setSystemFingerprint records the system fingerprint of responses of chat
+// completions, for streams it must be called after the stream is done.
+func setSystemFingerprint(rMeta *metadata.RequestMetadata, response object.LLMResponse) {
+	if fingerprinted, ok := response.(interface{ GetSystemFingerprint() string }); ok {
+		rMeta.SystemFingerprint = fingerprinted.GetSystemFingerprint()
+	}
+}
+
 func (m *clusterDefault) doUpstreamResponseComplete(ctx context.Context, req object.LLMRequest, res object.LLMResponse) error {
 	err := m.reversedFilters.ForEachResponseComplete(ctx, req, res)
 	if err != nil {
@@ -195,10 +427,17 @@ func (m *clusterDefault) doUpstreamResponseComplete(ctx context.Context, req obj
 	return nil
 }
 
-func doRequest(req *http.Request) (*http.Response, *bufio.Reader, error) {
+func doRequest(client *http.Client, req *http.Request) (*http.Response, *bufio.Reader, error) {
 	// send request
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
+		// Query parameters may carry upstream credentials, keep them out of
+		// error messages
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			urlErr.URL, _, _ = strings.Cut(urlErr.URL, "?")
+		}
+
 		return nil, nil, err
 	}
 
@@ -0,0 +1,35 @@
+package cluster
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/metadata"
+)
+
+// DefaultDeadlineHeader is the header forwarding the time remaining of the
+// timeout of the route to upstreams, in milliseconds.
+const DefaultDeadlineHeader = "X-Knoway-Deadline-Ms"
+
+// setDeadlineHeader forwards the time remaining of the timeout of the route
+// to the upstream, if the cluster is configured with a deadline header and
+// the route has a timeout. Exceeded deadlines are forwarded as 0.
+func setDeadlineHeader(cluster *v1alpha1.Cluster, rMeta *metadata.RequestMetadata, req *http.Request) {
+	deadlineHeader := cluster.GetUpstream().GetDeadlineHeader()
+	if deadlineHeader == nil {
+		return
+	}
+
+	remaining, ok := rMeta.TimeRemaining()
+	if !ok {
+		return
+	}
+
+	req.Header.Set(
+		lo.CoalesceOrEmpty(deadlineHeader.GetName(), DefaultDeadlineHeader),
+		strconv.FormatInt(max(remaining.Milliseconds(), 0), 10),
+	)
+}
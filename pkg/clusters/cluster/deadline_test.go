@@ -0,0 +1,50 @@
+package cluster
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/metadata"
+)
+
+func TestSetDeadlineHeader(t *testing.T) {
+	newRequest := func() *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	}
+
+	cluster := &v1alpha1.Cluster{Upstream: &v1alpha1.Upstream{DeadlineHeader: &v1alpha1.Upstream_DeadlineHeader{}}}
+	rMeta := &metadata.RequestMetadata{Deadline: mo.Some(time.Now().Add(10 * time.Second))}
+
+	req := newRequest()
+	setDeadlineHeader(cluster, rMeta, req)
+
+	remaining, err := strconv.ParseInt(req.Header.Get(DefaultDeadlineHeader), 10, 64)
+	require.NoError(t, err)
+	assert.InDelta(t, 10000, remaining, 1000)
+
+	// Exceeded
+	rMeta.Deadline = mo.Some(time.Now().Add(-time.Second))
+	cluster.Upstream.DeadlineHeader.Name = "X-Request-Budget-Ms"
+
+	req = newRequest()
+	setDeadlineHeader(cluster, rMeta, req)
+	assert.Equal(t, "0", req.Header.Get("X-Request-Budget-Ms"))
+
+	// Routes without timeouts
+	req = newRequest()
+	setDeadlineHeader(cluster, &metadata.RequestMetadata{}, req)
+	assert.Empty(t, req.Header.Get("X-Request-Budget-Ms"))
+
+	// Clusters without deadline headers
+	req = newRequest()
+	setDeadlineHeader(&v1alpha1.Cluster{}, rMeta, req)
+	assert.Empty(t, req.Header)
+}
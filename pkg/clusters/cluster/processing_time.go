@@ -0,0 +1,40 @@
+package cluster
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// upstreamProcessingTimeHeaders are headers set by upstream providers or
+// proxies in front of them to tell how long it took to process the request,
+// in milliseconds. Ordered by preference, the first one presents wins.
+var upstreamProcessingTimeHeaders = []string{
+	// OpenAI, Azure OpenAI
+	"Openai-Processing-Ms",
+	// Envoy based proxies, e.g. Istio sidecars in front of inference servers
+	"X-Envoy-Upstream-Service-Time",
+}
+
+// parseUpstreamProcessingDuration returns the processing time reported by
+// upstream, which excludes the time spent on network between the gateway and
+// the upstream.
+func parseUpstreamProcessingDuration(header http.Header) (time.Duration, bool) {
+	for _, name := range upstreamProcessingTimeHeaders {
+		value := strings.TrimSpace(header.Get(name))
+		if value == "" {
+			continue
+		}
+
+		// Some providers report fractional milliseconds, e.g. 123.456
+		ms, err := strconv.ParseFloat(value, 64)
+		if err != nil || ms < 0 {
+			continue
+		}
+
+		return time.Duration(ms * float64(time.Millisecond)), true
+	}
+
+	return 0, false
+}
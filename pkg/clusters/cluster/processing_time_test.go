@@ -0,0 +1,63 @@
+package cluster
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUpstreamProcessingDuration(t *testing.T) {
+	t.Run("openai", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("openai-processing-ms", "1234")
+
+		duration, ok := parseUpstreamProcessingDuration(header)
+		assert.True(t, ok)
+		assert.Equal(t, 1234*time.Millisecond, duration)
+	})
+
+	t.Run("envoy", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("x-envoy-upstream-service-time", "56")
+
+		duration, ok := parseUpstreamProcessingDuration(header)
+		assert.True(t, ok)
+		assert.Equal(t, 56*time.Millisecond, duration)
+	})
+
+	t.Run("fractional", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("openai-processing-ms", "12.5")
+
+		duration, ok := parseUpstreamProcessingDuration(header)
+		assert.True(t, ok)
+		assert.Equal(t, 12500*time.Microsecond, duration)
+	})
+
+	t.Run("prefer openai", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("openai-processing-ms", "100")
+		header.Set("x-envoy-upstream-service-time", "120")
+
+		duration, ok := parseUpstreamProcessingDuration(header)
+		assert.True(t, ok)
+		assert.Equal(t, 100*time.Millisecond, duration)
+	})
+
+	t.Run("invalid falls through", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("openai-processing-ms", "abc")
+		header.Set("x-envoy-upstream-service-time", "120")
+
+		duration, ok := parseUpstreamProcessingDuration(header)
+		assert.True(t, ok)
+		assert.Equal(t, 120*time.Millisecond, duration)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, ok := parseUpstreamProcessingDuration(http.Header{})
+		assert.False(t, ok)
+	})
+}
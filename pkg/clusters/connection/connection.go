@@ -0,0 +1,354 @@
+package connection
+
+import (
+	"context"
+	"crypto/tls"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/api/clusters/v1alpha1"
+)
+
+const (
+	DefaultDNSRefreshInterval  = 30 * time.Second
+	DefaultTLSSessionCacheSize = 64
+	DefaultMaxIdleConns        = 32
+	DefaultDialTimeout         = 30 * time.Second
+	DefaultKeepAlive           = 30 * time.Second
+	DefaultIdleConnTimeout     = 90 * time.Second
+
+	resolveTimeout = 5 * time.Second
+)
+
+// Options of the connections to the upstream of a cluster, zero values fall
+// back to defaults.
+type Options struct {
+	// PinAddresses dials the addresses resolved ahead of requests.
+	PinAddresses bool
+	// DNSRefreshInterval is how often the pinned addresses are resolved
+	// again, default is DefaultDNSRefreshInterval.
+	DNSRefreshInterval time.Duration
+	// TLSSessionCacheSize is the capacity of the TLS session cache, 0
+	// disables it.
+	TLSSessionCacheSize int
+	// MaxIdleConns is the maximum of idle connections kept to the upstream,
+	// default is DefaultMaxIdleConns.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost is the maximum of idle connections kept per host
+	// of the upstream, default is MaxIdleConns.
+	MaxIdleConnsPerHost int
+	// DialTimeout of new connections, default is DefaultDialTimeout.
+	DialTimeout time.Duration
+	// ResponseHeaderTimeout is how long to wait for the response headers
+	// after the request is written, 0 waits as long as the request.
+	ResponseHeaderTimeout time.Duration
+	// KeepAlive is the interval of the TCP keep-alive probes, default is
+	// DefaultKeepAlive.
+	KeepAlive time.Duration
+	// IdleConnTimeout is how long idle connections are kept, default is
+	// DefaultIdleConnTimeout.
+	IdleConnTimeout time.Duration
+	// DisableHTTP2 sends requests over HTTP/1.1 only.
+	DisableHTTP2 bool
+}
+
+func (o Options) withDefaults() Options {
+	o.DNSRefreshInterval = lo.CoalesceOrEmpty(o.DNSRefreshInterval, DefaultDNSRefreshInterval)
+	o.MaxIdleConns = lo.CoalesceOrEmpty(o.MaxIdleConns, DefaultMaxIdleConns)
+	o.MaxIdleConnsPerHost = lo.CoalesceOrEmpty(o.MaxIdleConnsPerHost, o.MaxIdleConns)
+	o.DialTimeout = lo.CoalesceOrEmpty(o.DialTimeout, DefaultDialTimeout)
+	o.KeepAlive = lo.CoalesceOrEmpty(o.KeepAlive, DefaultKeepAlive)
+	o.IdleConnTimeout = lo.CoalesceOrEmpty(o.IdleConnTimeout, DefaultIdleConnTimeout)
+
+	return o
+}
+
+// OptionsFromCluster returns the options of the connections configured for
+// the cluster, the defaults if not configured.
+func OptionsFromCluster(cluster *v1alpha1.Cluster) Options {
+	opts := Options{
+		TLSSessionCacheSize: DefaultTLSSessionCacheSize,
+	}
+
+	conn := cluster.GetConnection()
+	if conn == nil {
+		return opts
+	}
+
+	opts.PinAddresses = conn.GetPinAddresses()
+	opts.DNSRefreshInterval = conn.GetDnsRefreshInterval().AsDuration()
+	opts.MaxIdleConns = int(conn.GetMaxIdleConns())
+	opts.MaxIdleConnsPerHost = int(conn.GetMaxIdleConnsPerHost())
+	opts.DialTimeout = conn.GetDialTimeout().AsDuration()
+	opts.ResponseHeaderTimeout = conn.GetResponseHeaderTimeout().AsDuration()
+	opts.KeepAlive = conn.GetKeepAlive().AsDuration()
+	opts.IdleConnTimeout = conn.GetIdleConnTimeout().AsDuration()
+	opts.DisableHTTP2 = conn.GetDisableHTTP2()
+
+	if conn.TlsSessionCacheSize != nil {
+		opts.TLSSessionCacheSize = int(conn.GetTlsSessionCacheSize())
+	}
+
+	return opts
+}
+
+// Pool is the connections to the upstream of a cluster, shared by all the
+// requests sent to it. Idle connections are kept for bursts of requests, and
+// new connections skip resolving the host when the addresses are pinned, and
+// the full TLS handshake when a session is resumed.
+type Pool struct {
+	opts     Options
+	host     string
+	client   *http.Client
+	resolver *resolver
+	cancel   context.CancelFunc
+}
+
+// NewPool creates the pool of connections to the host of upstreamURL, the
+// addresses are resolved in the background right away when pinned.
+func NewPool(upstreamURL string, opts Options) *Pool {
+	opts = opts.withDefaults()
+	host := hostname(upstreamURL)
+
+	dialer := &net.Dialer{Timeout: opts.DialTimeout, KeepAlive: opts.KeepAlive}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	transport.DialContext = dialer.DialContext
+	transport.MaxIdleConns = opts.MaxIdleConns
+	transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	transport.IdleConnTimeout = opts.IdleConnTimeout
+	transport.ResponseHeaderTimeout = opts.ResponseHeaderTimeout
+
+	if opts.DisableHTTP2 {
+		transport.Protocols = new(http.Protocols)
+		transport.Protocols.SetHTTP1(true)
+	}
+
+	if opts.TLSSessionCacheSize > 0 {
+		transport.TLSClientConfig = &tls.Config{
+			ClientSessionCache: tls.NewLRUClientSessionCache(opts.TLSSessionCacheSize),
+		}
+	}
+
+	pool := &Pool{
+		opts:   opts,
+		host:   host,
+		client: &http.Client{Transport: transport},
+		cancel: func() {},
+	}
+
+	// Nothing to resolve for IP literals
+	_, err := netip.ParseAddr(host)
+	if opts.PinAddresses && host != "" && err != nil {
+		pool.resolver = newResolver(host, net.DefaultResolver.LookupNetIP)
+		transport.DialContext = pool.resolver.dialContext(dialer.DialContext)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		pool.cancel = cancel
+
+		go pool.resolver.run(ctx, opts.DNSRefreshInterval)
+	}
+
+	return pool
+}
+
+func hostname(upstreamURL string) string {
+	u, err := url.Parse(upstreamURL)
+	if err != nil {
+		return ""
+	}
+
+	return u.Hostname()
+}
+
+// Client returns the client sending requests through the pool.
+func (p *Pool) Client() *http.Client {
+	return p.client
+}
+
+// Close stops resolving the pinned addresses and closes the idle
+// connections, requests in-flight are not affected.
+func (p *Pool) Close() {
+	p.cancel()
+	p.client.CloseIdleConnections()
+}
+
+type lookupFunc func(ctx context.Context, network, host string) ([]netip.Addr, error)
+
+type dialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// resolver keeps the addresses of a host resolved ahead of dialing.
+type resolver struct {
+	host   string
+	lookup lookupFunc
+
+	mutex sync.RWMutex
+	addrs []netip.Addr
+	next  atomic.Uint32
+}
+
+func newResolver(host string, lookup lookupFunc) *resolver {
+	return &resolver{
+		host:   host,
+		lookup: lookup,
+	}
+}
+
+// refresh resolves the host again, the addresses resolved before are kept if
+// it fails, since they are likely still reachable.
+func (r *resolver) refresh(ctx context.Context) {
+	lookupCtx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+
+	addrs, err := r.lookup(lookupCtx, "ip", r.host)
+	if err != nil {
+		// Closed while resolving
+		if ctx.Err() == nil {
+			slog.Warn("failed to resolve upstream host, keeping the pinned addresses", "host", r.host, "error", err)
+		}
+
+		return
+	}
+
+	if len(addrs) == 0 {
+		return
+	}
+
+	r.mutex.Lock()
+	r.addrs = addrs
+	r.mutex.Unlock()
+}
+
+func (r *resolver) run(ctx context.Context, interval time.Duration) {
+	r.refresh(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.refresh(ctx)
+		}
+	}
+}
+
+// pick returns the next of the pinned addresses in turn.
+func (r *resolver) pick() (netip.Addr, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if len(r.addrs) == 0 {
+		return netip.Addr{}, false
+	}
+
+	return r.addrs[int(r.next.Add(1)-1)%len(r.addrs)], true
+}
+
+// dialContext dials the pinned addresses of the host. It falls back to dial,
+// which resolves the host itself, when nothing is resolved yet or the pinned
+// address is unreachable.
+func (r *resolver) dialContext(dial dialFunc) dialFunc {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil || host != r.host {
+			return dial(ctx, network, address)
+		}
+
+		addr, ok := r.pick()
+		if !ok {
+			return dial(ctx, network, address)
+		}
+
+		conn, err := dial(ctx, network, net.JoinHostPort(addr.Unmap().String(), port))
+		if err == nil {
+			return conn, nil
+		}
+
+		if ctx.Err() != nil {
+			return nil, err
+		}
+
+		slog.Debug("failed to dial pinned address, resolving the host again", "host", r.host, "address", addr, "error", err)
+
+		return dial(ctx, network, address)
+	}
+}
+
+// Pools keeps the pools of connections of clusters by name.
+type Pools struct {
+	mutex sync.Mutex
+	pools map[string]*Pool
+}
+
+func NewPools() *Pools {
+	return &Pools{
+		pools: make(map[string]*Pool),
+	}
+}
+
+// Get returns the pool of the cluster, creating it on first use or when the
+// upstream or the options of the cluster changed.
+func (p *Pools) Get(cluster *v1alpha1.Cluster) *Pool {
+	opts := OptionsFromCluster(cluster).withDefaults()
+	host := hostname(cluster.GetUpstream().GetUrl())
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	pool, ok := p.pools[cluster.GetName()]
+	if ok && pool.opts == opts && pool.host == host {
+		return pool
+	}
+
+	if ok {
+		pool.Close()
+	}
+
+	pool = NewPool(cluster.GetUpstream().GetUrl(), opts)
+	p.pools[cluster.GetName()] = pool
+
+	return pool
+}
+
+// Forget closes the pool of the cluster.
+func (p *Pools) Forget(name string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	pool, ok := p.pools[name]
+	if !ok {
+		return
+	}
+
+	pool.Close()
+	delete(p.pools, name)
+}
+
+var defaultPools = NewPools()
+
+// Warm creates the pool of the cluster ahead of requests, so that the pinned
+// addresses are resolved by the time the first request arrives.
+func Warm(cluster *v1alpha1.Cluster) {
+	defaultPools.Get(cluster)
+}
+
+// Client returns the client sending the requests of the cluster.
+func Client(cluster *v1alpha1.Cluster) *http.Client {
+	return defaultPools.Get(cluster).Client()
+}
+
+// Forget closes the pool of the cluster removed.
+func Forget(name string) {
+	defaultPools.Forget(name)
+}
@@ -0,0 +1,253 @@
+package connection
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"knoway.dev/api/clusters/v1alpha1"
+)
+
+func TestOptionsFromCluster(t *testing.T) {
+	assert.Equal(t, Options{TLSSessionCacheSize: DefaultTLSSessionCacheSize}, OptionsFromCluster(&v1alpha1.Cluster{}))
+
+	opts := OptionsFromCluster(&v1alpha1.Cluster{Connection: &v1alpha1.ClusterConnection{}})
+	assert.Equal(t, Options{TLSSessionCacheSize: DefaultTLSSessionCacheSize}, opts)
+	assert.Equal(t, Options{
+		DNSRefreshInterval:  DefaultDNSRefreshInterval,
+		TLSSessionCacheSize: DefaultTLSSessionCacheSize,
+		MaxIdleConns:        DefaultMaxIdleConns,
+		MaxIdleConnsPerHost: DefaultMaxIdleConns,
+		DialTimeout:         DefaultDialTimeout,
+		KeepAlive:           DefaultKeepAlive,
+		IdleConnTimeout:     DefaultIdleConnTimeout,
+	}, opts.withDefaults())
+
+	opts = OptionsFromCluster(&v1alpha1.Cluster{Connection: &v1alpha1.ClusterConnection{
+		PinAddresses:          true,
+		DnsRefreshInterval:    durationpb.New(time.Minute),
+		TlsSessionCacheSize:   proto.Uint32(0),
+		MaxIdleConns:          8,
+		DialTimeout:           durationpb.New(5 * time.Second),
+		ResponseHeaderTimeout: durationpb.New(time.Minute),
+		KeepAlive:             durationpb.New(15 * time.Second),
+		IdleConnTimeout:       durationpb.New(time.Minute),
+		DisableHTTP2:          true,
+	}})
+	assert.Equal(t, Options{
+		PinAddresses:          true,
+		DNSRefreshInterval:    time.Minute,
+		MaxIdleConns:          8,
+		DialTimeout:           5 * time.Second,
+		ResponseHeaderTimeout: time.Minute,
+		KeepAlive:             15 * time.Second,
+		IdleConnTimeout:       time.Minute,
+		DisableHTTP2:          true,
+	}, opts)
+	assert.Equal(t, 8, opts.withDefaults().MaxIdleConnsPerHost)
+}
+
+func TestNewPool_Transport(t *testing.T) {
+	pool := NewPool("https://api.openai.com/v1", Options{
+		MaxIdleConns:          64,
+		MaxIdleConnsPerHost:   16,
+		ResponseHeaderTimeout: time.Minute,
+		IdleConnTimeout:       time.Minute,
+	})
+	defer pool.Close()
+
+	transport := pool.Client().Transport.(*http.Transport) //nolint:forcetypeassert
+	assert.Equal(t, 64, transport.MaxIdleConns)
+	assert.Equal(t, 16, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, time.Minute, transport.ResponseHeaderTimeout)
+	assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+	assert.Nil(t, transport.Protocols)
+
+	pool = NewPool("https://api.openai.com/v1", Options{DisableHTTP2: true})
+	defer pool.Close()
+
+	transport = pool.Client().Transport.(*http.Transport) //nolint:forcetypeassert
+	require.NotNil(t, transport.Protocols)
+	assert.True(t, transport.Protocols.HTTP1())
+	assert.False(t, transport.Protocols.HTTP2())
+}
+
+func TestPool_ResponseHeaderTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		<-request.Context().Done()
+	}))
+	defer server.Close()
+
+	pool := NewPool(server.URL, Options{ResponseHeaderTimeout: 50 * time.Millisecond})
+	defer pool.Close()
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = pool.Client().Do(request) //nolint:bodyclose
+	require.ErrorContains(t, err, "timeout awaiting response headers")
+}
+
+func TestResolver_DialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+	defer server.Close()
+
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+
+	r := newResolver("upstream.test", func(ctx context.Context, network, host string) ([]netip.Addr, error) {
+		return []netip.Addr{netip.MustParseAddr("127.0.0.1")}, nil
+	})
+
+	var dialed []string
+
+	dial := r.dialContext(func(ctx context.Context, network, address string) (net.Conn, error) {
+		dialed = append(dialed, address)
+
+		// Resolving is left to the fallback
+		if host, _, _ := net.SplitHostPort(address); host == "upstream.test" {
+			return nil, errors.New("no such host")
+		}
+
+		return (&net.Dialer{}).DialContext(ctx, network, address)
+	})
+
+	// Not resolved yet
+	_, err = dial(context.Background(), "tcp", net.JoinHostPort("upstream.test", port))
+	require.Error(t, err)
+	assert.Equal(t, []string{"upstream.test:" + port}, dialed)
+
+	r.refresh(context.Background())
+
+	dialed = nil
+	conn, err := dial(context.Background(), "tcp", net.JoinHostPort("upstream.test", port))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+	assert.Equal(t, []string{"127.0.0.1:" + port}, dialed)
+
+	// Other hosts are dialed as is
+	dialed = nil
+	conn, err = dial(context.Background(), "tcp", server.Listener.Addr().String())
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+	assert.Equal(t, []string{server.Listener.Addr().String()}, dialed)
+}
+
+func TestResolver_Refresh(t *testing.T) {
+	var lookupErr error
+
+	addrs := []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2")}
+	r := newResolver("upstream.test", func(ctx context.Context, network, host string) ([]netip.Addr, error) {
+		return addrs, lookupErr
+	})
+
+	r.refresh(context.Background())
+
+	first, _ := r.pick()
+	second, _ := r.pick()
+	third, _ := r.pick()
+	assert.Equal(t, []netip.Addr{addrs[0], addrs[1], addrs[0]}, []netip.Addr{first, second, third})
+
+	// Failures keep the pinned addresses
+	lookupErr = errors.New("no such host")
+	addrs = nil
+	r.refresh(context.Background())
+
+	_, ok := r.pick()
+	assert.True(t, ok)
+}
+
+func TestResolver_DialContextFallback(t *testing.T) {
+	r := newResolver("upstream.test", func(ctx context.Context, network, host string) ([]netip.Addr, error) {
+		return []netip.Addr{netip.MustParseAddr("10.0.0.1")}, nil
+	})
+	r.refresh(context.Background())
+
+	var dialed []string
+
+	dial := r.dialContext(func(ctx context.Context, network, address string) (net.Conn, error) {
+		dialed = append(dialed, address)
+		return nil, errors.New("unreachable")
+	})
+
+	_, err := dial(context.Background(), "tcp", "upstream.test:443")
+	require.Error(t, err)
+	assert.Equal(t, []string{"10.0.0.1:443", "upstream.test:443"}, dialed)
+}
+
+func TestPool_TLSSessionResumption(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {}))
+	defer server.Close()
+
+	pool := NewPool(server.URL, Options{TLSSessionCacheSize: DefaultTLSSessionCacheSize})
+	defer pool.Close()
+
+	// Trust the certificate of the server
+	serverTransport := server.Client().Transport.(*http.Transport) //nolint:forcetypeassert
+	transport := pool.Client().Transport.(*http.Transport)         //nolint:forcetypeassert
+	transport.TLSClientConfig.RootCAs = serverTransport.TLSClientConfig.RootCAs
+
+	resumed := func() bool {
+		var state tls.ConnectionState
+
+		ctx := httptrace.WithClientTrace(context.Background(), &httptrace.ClientTrace{
+			TLSHandshakeDone: func(s tls.ConnectionState, _ error) { state = s },
+		})
+
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := pool.Client().Do(request)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		// New connections for the next request
+		pool.Client().CloseIdleConnections()
+
+		return state.DidResume
+	}
+
+	assert.False(t, resumed())
+	assert.True(t, resumed())
+}
+
+func TestPools(t *testing.T) {
+	pools := NewPools()
+
+	cluster := &v1alpha1.Cluster{
+		Name:       "openai",
+		Upstream:   &v1alpha1.Upstream{Url: "https://api.openai.com/v1"},
+		Connection: &v1alpha1.ClusterConnection{},
+	}
+
+	pool := pools.Get(cluster)
+	assert.Same(t, pool, pools.Get(proto.Clone(cluster).(*v1alpha1.Cluster))) //nolint:forcetypeassert
+
+	// Changes of the upstream host take a new pool
+	cluster.Upstream.Url = "https://example.com/v1"
+
+	again := pools.Get(cluster)
+	assert.NotSame(t, pool, again)
+
+	// Clusters without options configured get their own pool too
+	cluster.Connection = nil
+	assert.Same(t, again, pools.Get(cluster))
+
+	cluster.Connection = &v1alpha1.ClusterConnection{DisableHTTP2: true}
+	assert.NotSame(t, again, pools.Get(cluster))
+
+	pools.Forget(cluster.GetName())
+	assert.Empty(t, pools.pools)
+}
@@ -0,0 +1,257 @@
+// Package apikeys rotates the upstream requests of clusters between multiple
+// API keys, and disables the keys the upstream keeps rejecting or rate
+// limiting for a cooldown.
+package apikeys
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	clusterfilters "knoway.dev/pkg/clusters/filters"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/observation"
+	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/types/openai"
+)
+
+const (
+	defaultHeader   = "Authorization"
+	defaultCooldown = time.Minute
+
+	outcomeSucceeded    = "succeeded"
+	outcomeUnauthorized = "unauthorized"
+	outcomeRateLimited  = "rate_limited"
+	outcomeFailed       = "failed"
+)
+
+// keyState is the health of a key, shared by the clusters using the same key
+// and kept across the registrations of the clusters, since the upstream
+// limits the key regardless of the cluster it's sent by.
+type keyState struct {
+	mutex         sync.Mutex
+	failures      uint32
+	disabledUntil time.Time
+}
+
+func (s *keyState) availableAt() time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.disabledUntil
+}
+
+// fail records a failed response, and reports whether the key is disabled by
+// it.
+func (s *keyState) fail(threshold uint32, until time.Time) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.failures++
+	if s.failures < threshold {
+		return false
+	}
+
+	s.failures = 0
+	s.disabledUntil = until
+
+	return true
+}
+
+func (s *keyState) succeed() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.failures = 0
+}
+
+// keyStates holds the states of the keys by the digests of their values.
+var keyStates sync.Map
+
+func stateOf(value string) *keyState {
+	digest := sha256.Sum256([]byte(value))
+	state, _ := keyStates.LoadOrStore(hex.EncodeToString(digest[:]), &keyState{})
+
+	return state.(*keyState)
+}
+
+type key struct {
+	name  string
+	value string
+	state *keyState
+}
+
+type keyContextKey struct{}
+
+func NewWithConfig(cfg *anypb.Any, _ bootkit.LifeCycle) (clusterfilters.ClusterFilter, error) {
+	c, err := protoutils.FromAny(cfg, &v1alpha1.UpstreamAPIKeysConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	if len(c.GetKeys()) == 0 {
+		return nil, errors.New("upstream api keys filter requires at least one key")
+	}
+
+	header := http.CanonicalHeaderKey(lo.CoalesceOrEmpty(c.GetHeader(), defaultHeader))
+
+	prefix := c.GetPrefix()
+	if c.Prefix == nil && header == defaultHeader {
+		prefix = "Bearer "
+	}
+
+	return &rotator{
+		keys: lo.Map(c.GetKeys(), func(k *v1alpha1.UpstreamAPIKeysConfig_Key, _ int) *key {
+			return &key{name: k.GetName(), value: k.GetValue(), state: stateOf(k.GetValue())}
+		}),
+		header:           header,
+		prefix:           prefix,
+		cooldown:         lo.CoalesceOrEmpty(c.GetCooldown().AsDuration(), defaultCooldown),
+		failureThreshold: max(c.GetFailureThreshold(), 1),
+		now:              time.Now,
+	}, nil
+}
+
+var _ clusterfilters.ClusterFilterUpstreamRequestMarshaller = (*rotator)(nil)
+var _ clusterfilters.ClusterFilterResponseUnmarshaller = (*rotator)(nil)
+
+// rotator places the keys into the requests built by the OpenAI request
+// handler in turn, skipping the disabled ones, and tracks the responses of
+// each key.
+type rotator struct {
+	clusterfilters.IsClusterFilter
+
+	keys             []*key
+	header           string
+	prefix           string
+	cooldown         time.Duration
+	failureThreshold uint32
+	next             atomic.Uint64
+	now              func() time.Time
+}
+
+// pick returns the next key available, or the one available the earliest
+// when all of them are disabled.
+func (f *rotator) pick() *key {
+	now := f.now()
+	start := f.next.Add(1) - 1
+
+	var (
+		earliest   *key
+		earliestAt time.Time
+	)
+
+	for i := range uint64(len(f.keys)) {
+		k := f.keys[(start+i)%uint64(len(f.keys))]
+
+		availableAt := k.state.availableAt()
+		if !availableAt.After(now) {
+			return k
+		}
+
+		if earliest == nil || availableAt.Before(earliestAt) {
+			earliest, earliestAt = k, availableAt
+		}
+	}
+
+	return earliest
+}
+
+func (f *rotator) MarshalUpstreamRequest(ctx context.Context, cluster *v1alpha1clusters.Cluster, llmRequest object.LLMRequest, request *http.Request) (*http.Request, error) {
+	if request == nil {
+		return nil, openai.NewErrorInternalError().WithMessage("upstream api keys filter requires the request built by the openai request handler")
+	}
+
+	k := f.pick()
+	request.Header.Set(f.header, f.prefix+k.value)
+
+	// The key is looked up from the request of the response
+	return request.WithContext(context.WithValue(request.Context(), keyContextKey{}, k)), nil
+}
+
+func (f *rotator) UnmarshalResponseBody(ctx context.Context, cluster *v1alpha1clusters.Cluster, request object.LLMRequest, rawResponse *http.Response, reader *bufio.Reader, pre object.LLMResponse) (object.LLMResponse, error) {
+	if rawResponse == nil || rawResponse.Request == nil {
+		return pre, nil
+	}
+
+	k, ok := rawResponse.Request.Context().Value(keyContextKey{}).(*key)
+	if !ok {
+		return pre, nil
+	}
+
+	f.observe(ctx, cluster.GetName(), k, rawResponse)
+
+	return pre, nil
+}
+
+func (f *rotator) observe(ctx context.Context, cluster string, k *key, rawResponse *http.Response) {
+	var outcome string
+
+	switch {
+	case rawResponse.StatusCode == http.StatusUnauthorized || rawResponse.StatusCode == http.StatusForbidden:
+		outcome = outcomeUnauthorized
+	case rawResponse.StatusCode == http.StatusTooManyRequests:
+		outcome = outcomeRateLimited
+	case rawResponse.StatusCode < http.StatusBadRequest:
+		k.state.succeed()
+		observation.ObserveUpstreamAPIKeyResponse(cluster, k.name, outcomeSucceeded, false)
+
+		return
+	default:
+		// Not caused by the key
+		observation.ObserveUpstreamAPIKeyResponse(cluster, k.name, outcomeFailed, false)
+		return
+	}
+
+	now := f.now()
+	until := now.Add(f.cooldown)
+
+	if retryAfter, ok := parseRetryAfter(rawResponse.Header.Get("Retry-After"), now); ok && outcome == outcomeRateLimited {
+		until = lo.Latest(until, now.Add(retryAfter))
+	}
+
+	disabled := k.state.fail(f.failureThreshold, until)
+	if disabled {
+		slog.WarnContext(ctx, "upstream api key disabled",
+			slog.String("cluster", cluster),
+			slog.String("key", k.name),
+			slog.Int("status", rawResponse.StatusCode),
+			slog.Time("until", until),
+		)
+	}
+
+	observation.ObserveUpstreamAPIKeyResponse(cluster, k.name, outcome, disabled)
+}
+
+// parseRetryAfter parses the Retry-After header in either seconds or an HTTP
+// date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,189 @@
+package apikeys
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/observation"
+)
+
+func newRotator(t *testing.T, cfg *v1alpha1.UpstreamAPIKeysConfig, now time.Time) *rotator {
+	t.Helper()
+
+	pb, err := anypb.New(cfg)
+	require.NoError(t, err)
+
+	f, err := NewWithConfig(pb, nil)
+	require.NoError(t, err)
+
+	r, ok := f.(*rotator)
+	require.True(t, ok)
+
+	r.now = func() time.Time { return now }
+
+	return r
+}
+
+// send marshals a request by the rotator, and returns the key used along with
+// the response of the status.
+func send(t *testing.T, r *rotator, status int, header http.Header) (string, *http.Response) {
+	t.Helper()
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://api.openai.com/v1/chat/completions", nil)
+	require.NoError(t, err)
+
+	request, err = r.MarshalUpstreamRequest(context.Background(), &v1alpha1clusters.Cluster{}, nil, request)
+	require.NoError(t, err)
+
+	return request.Header.Get(r.header), &http.Response{StatusCode: status, Header: header, Request: request}
+}
+
+func TestNewWithConfig(t *testing.T) {
+	pb, err := anypb.New(&v1alpha1.UpstreamAPIKeysConfig{})
+	require.NoError(t, err)
+
+	_, err = NewWithConfig(pb, nil)
+	require.Error(t, err)
+
+	r := newRotator(t, &v1alpha1.UpstreamAPIKeysConfig{
+		Keys: []*v1alpha1.UpstreamAPIKeysConfig_Key{{Name: "openai/key-1", Value: "sk-new-1"}},
+	}, time.Now())
+	assert.Equal(t, "Authorization", r.header)
+	assert.Equal(t, "Bearer ", r.prefix)
+	assert.Equal(t, time.Minute, r.cooldown)
+	assert.Equal(t, uint32(1), r.failureThreshold)
+
+	r = newRotator(t, &v1alpha1.UpstreamAPIKeysConfig{
+		Keys:   []*v1alpha1.UpstreamAPIKeysConfig_Key{{Name: "azure/key-1", Value: "sk-new-2"}},
+		Header: "api-key",
+	}, time.Now())
+	assert.Equal(t, "Api-Key", r.header)
+	assert.Empty(t, r.prefix)
+}
+
+func TestRotator_Rotate(t *testing.T) {
+	r := newRotator(t, &v1alpha1.UpstreamAPIKeysConfig{
+		Keys: []*v1alpha1.UpstreamAPIKeysConfig_Key{
+			{Name: "openai/key-1", Value: "sk-rotate-1"},
+			{Name: "openai/key-2", Value: "sk-rotate-2"},
+		},
+	}, time.Now())
+
+	used := make([]string, 0, 4)
+
+	for range 4 {
+		key, _ := send(t, r, http.StatusOK, nil)
+		used = append(used, key)
+	}
+
+	assert.Equal(t, []string{"Bearer sk-rotate-1", "Bearer sk-rotate-2", "Bearer sk-rotate-1", "Bearer sk-rotate-2"}, used)
+}
+
+func TestRotator_DisableRateLimited(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := newRotator(t, &v1alpha1.UpstreamAPIKeysConfig{
+		Keys: []*v1alpha1.UpstreamAPIKeysConfig_Key{
+			{Name: "openai/key-1", Value: "sk-limited-1"},
+			{Name: "openai/key-2", Value: "sk-limited-2"},
+		},
+		Cooldown:         durationpb.New(30 * time.Second),
+		FailureThreshold: 2,
+	}, now)
+	cluster := &v1alpha1clusters.Cluster{Name: "gpt-4o-limited"}
+
+	limited := func() {
+		key, resp := send(t, r, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"120"}})
+		require.Equal(t, "Bearer sk-limited-1", key)
+
+		_, err := r.UnmarshalResponseBody(context.Background(), cluster, nil, resp, nil, nil)
+		require.NoError(t, err)
+
+		// Skip the other key
+		r.next.Add(1)
+	}
+
+	// Not disabled until the threshold is reached
+	limited()
+	assert.True(t, r.keys[0].state.availableAt().IsZero())
+
+	limited()
+	assert.Equal(t, now.Add(2*time.Minute), r.keys[0].state.availableAt())
+	assert.InDelta(t, 1, testutil.ToFloat64(observation.UpstreamAPIKeyDisables.WithLabelValues("gpt-4o-limited", "openai/key-1")), 0)
+	assert.InDelta(t, 2, testutil.ToFloat64(observation.UpstreamAPIKeyResponses.WithLabelValues("gpt-4o-limited", "openai/key-1", outcomeRateLimited)), 0)
+
+	// Only the key left is used
+	for range 3 {
+		key, _ := send(t, r, http.StatusOK, nil)
+		assert.Equal(t, "Bearer sk-limited-2", key)
+	}
+
+	// Recovered after the Retry-After
+	r.now = func() time.Time { return now.Add(2 * time.Minute) }
+	r.next.Store(0)
+
+	key, _ := send(t, r, http.StatusOK, nil)
+	assert.Equal(t, "Bearer sk-limited-1", key)
+}
+
+func TestRotator_AllDisabled(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := newRotator(t, &v1alpha1.UpstreamAPIKeysConfig{
+		Keys: []*v1alpha1.UpstreamAPIKeysConfig_Key{
+			{Name: "openai/key-1", Value: "sk-disabled-1"},
+			{Name: "openai/key-2", Value: "sk-disabled-2"},
+		},
+	}, now)
+	cluster := &v1alpha1clusters.Cluster{Name: "gpt-4o-disabled"}
+
+	key, resp := send(t, r, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"300"}})
+	require.Equal(t, "Bearer sk-disabled-1", key)
+	_, err := r.UnmarshalResponseBody(context.Background(), cluster, nil, resp, nil, nil)
+	require.NoError(t, err)
+
+	key, resp = send(t, r, http.StatusUnauthorized, nil)
+	require.Equal(t, "Bearer sk-disabled-2", key)
+	_, err = r.UnmarshalResponseBody(context.Background(), cluster, nil, resp, nil, nil)
+	require.NoError(t, err)
+
+	// The key recovering the earliest is used
+	for range 2 {
+		key, _ = send(t, r, http.StatusOK, nil)
+		assert.Equal(t, "Bearer sk-disabled-2", key)
+	}
+
+	// Errors not caused by the keys don't disable them
+	r.now = func() time.Time { return now.Add(time.Hour) }
+
+	key, resp = send(t, r, http.StatusInternalServerError, nil)
+	_, err = r.UnmarshalResponseBody(context.Background(), cluster, nil, resp, nil, nil)
+	require.NoError(t, err)
+
+	next, _ := send(t, r, http.StatusOK, nil)
+	assert.NotEqual(t, key, next)
+	assert.InDelta(t, 1, testutil.ToFloat64(observation.UpstreamAPIKeyResponses.WithLabelValues("gpt-4o-disabled", "openai/key-1", outcomeFailed)), 0)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	d, ok := parseRetryAfter("30", now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, d)
+
+	d, ok = parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, d)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+}
@@ -0,0 +1,94 @@
+package azure
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	clusterfilters "knoway.dev/pkg/clusters/filters"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/types/microsoft/azureopenai"
+	"knoway.dev/pkg/types/openai"
+)
+
+func NewWithConfig(cfg *anypb.Any, _ bootkit.LifeCycle) (clusterfilters.ClusterFilter, error) {
+	c, err := protoutils.FromAny(cfg, &v1alpha1.AzureOpenAIConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	return &azureOpenAI{
+		cfg: c,
+	}, nil
+}
+
+var _ clusterfilters.ClusterFilterUpstreamRequestMarshaller = (*azureOpenAI)(nil)
+var _ clusterfilters.ClusterFilterResponseUnmarshaller = (*azureOpenAI)(nil)
+
+// azureOpenAI adapts the requests built by the OpenAI request handler to the
+// deployments of Azure OpenAI, and translates the errors of Azure OpenAI
+// unmarshalled by the OpenAI response handler.
+type azureOpenAI struct {
+	clusterfilters.IsClusterFilter
+
+	cfg *v1alpha1.AzureOpenAIConfig
+}
+
+func (f *azureOpenAI) MarshalUpstreamRequest(ctx context.Context, cluster *v1alpha1clusters.Cluster, llmRequest object.LLMRequest, request *http.Request) (*http.Request, error) {
+	if request == nil {
+		return nil, openai.NewErrorInternalError().WithMessage("azure openai filter requires the request built by the openai request handler")
+	}
+
+	deployment := lo.CoalesceOrEmpty(f.cfg.GetDeployment(), llmRequest.GetModel())
+
+	upstreamURL, err := azureopenai.BuildURL(cluster.GetUpstream().GetUrl(), deployment, f.cfg.GetApiVersion(), llmRequest.GetRequestType())
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the credentials placed into the query parameters
+	query := upstreamURL.Query()
+	for key, values := range request.URL.Query() {
+		if !query.Has(key) {
+			query[key] = values
+		}
+	}
+
+	upstreamURL.RawQuery = query.Encode()
+
+	request.URL = upstreamURL
+	request.Host = upstreamURL.Host
+
+	if !f.cfg.GetKeepAuthorization() {
+		azureopenai.MapAPIKeyHeader(request.Header)
+	}
+
+	return request, nil
+}
+
+func (f *azureOpenAI) UnmarshalResponseBody(ctx context.Context, cluster *v1alpha1clusters.Cluster, request object.LLMRequest, rawResponse *http.Response, reader *bufio.Reader, pre object.LLMResponse) (object.LLMResponse, error) {
+	if lo.IsNil(pre) || rawResponse.StatusCode < http.StatusBadRequest || lo.IsNil(pre.GetError()) {
+		return pre, nil
+	}
+
+	var errResp *openai.ErrorResponse
+	if !errors.As(pre.GetError(), &errResp) || errResp.UpstreamErrorBody == "" {
+		return pre, nil
+	}
+
+	translated := azureopenai.ParseErrorResponse(rawResponse.StatusCode, []byte(errResp.UpstreamErrorBody))
+	if translated != nil {
+		*errResp = *translated
+	}
+
+	return pre, nil
+}
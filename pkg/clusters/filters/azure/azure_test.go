@@ -0,0 +1,131 @@
+package azure
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+	clusterfilters "knoway.dev/pkg/clusters/filters"
+	openaifilters "knoway.dev/pkg/clusters/filters/openai"
+	"knoway.dev/pkg/types/openai"
+)
+
+func newFilter(t *testing.T, cfg *v1alpha1.AzureOpenAIConfig) clusterfilters.ClusterFilter {
+	t.Helper()
+
+	pb, err := anypb.New(cfg)
+	require.NoError(t, err)
+
+	f, err := NewWithConfig(pb, nil)
+	require.NoError(t, err)
+
+	return f
+}
+
+func TestMarshalUpstreamRequest(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &v1alpha1clusters.Cluster{
+		Name:     "gpt-4o",
+		Provider: v1alpha1clusters.ClusterProvider_AZURE_OPEN_AI,
+		Upstream: &v1alpha1clusters.Upstream{
+			Url: "https://my-resource.openai.azure.com",
+			Headers: []*v1alpha1clusters.Upstream_Header{
+				{Key: "Authorization", Value: "Bearer sk-azure"},
+			},
+		},
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/v1/chat/completions", bytes.NewBufferString(`{"model": "gpt-4o", "messages": []}`))
+	require.NoError(t, err)
+
+	llmRequest, err := openai.NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	pb, err := anypb.New(&v1alpha1.OpenAIRequestHandlerConfig{})
+	require.NoError(t, err)
+
+	handler, err := openaifilters.NewRequestHandlerWithConfig(pb, nil)
+	require.NoError(t, err)
+
+	request, err := handler.(clusterfilters.ClusterFilterUpstreamRequestMarshaller).MarshalUpstreamRequest(ctx, cluster, llmRequest, nil)
+	require.NoError(t, err)
+
+	t.Run("default deployment", func(t *testing.T) {
+		f := newFilter(t, &v1alpha1.AzureOpenAIConfig{})
+
+		request, err := f.(clusterfilters.ClusterFilterUpstreamRequestMarshaller).MarshalUpstreamRequest(ctx, cluster, llmRequest, request.Clone(ctx))
+		require.NoError(t, err)
+
+		assert.Equal(t, "https://my-resource.openai.azure.com/openai/deployments/gpt-4o/chat/completions?api-version=2024-10-21", request.URL.String())
+		assert.Equal(t, "sk-azure", request.Header.Get("api-key"))
+		assert.Empty(t, request.Header.Get("Authorization"))
+	})
+
+	t.Run("configured deployment", func(t *testing.T) {
+		f := newFilter(t, &v1alpha1.AzureOpenAIConfig{
+			Deployment:        "gpt-4o-prod",
+			ApiVersion:        "2024-06-01",
+			KeepAuthorization: true,
+		})
+
+		request, err := f.(clusterfilters.ClusterFilterUpstreamRequestMarshaller).MarshalUpstreamRequest(ctx, cluster, llmRequest, request.Clone(ctx))
+		require.NoError(t, err)
+
+		assert.Equal(t, "/openai/deployments/gpt-4o-prod/chat/completions", request.URL.Path)
+		assert.Equal(t, "2024-06-01", request.URL.Query().Get("api-version"))
+		assert.Equal(t, "Bearer sk-azure", request.Header.Get("Authorization"))
+		assert.Empty(t, request.Header.Get("api-key"))
+	})
+}
+
+func TestUnmarshalResponseBody(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &v1alpha1clusters.Cluster{Name: "gpt-4o"}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/v1/chat/completions", bytes.NewBufferString(`{"model": "gpt-4o", "messages": []}`))
+	require.NoError(t, err)
+
+	llmRequest, err := openai.NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	pb, err := anypb.New(&v1alpha1.OpenAIResponseHandlerConfig{})
+	require.NoError(t, err)
+
+	handler, err := openaifilters.NewResponseHandlerWithConfig(pb, nil)
+	require.NoError(t, err)
+
+	f := newFilter(t, &v1alpha1.AzureOpenAIConfig{})
+
+	body := `{"error":{"code":"DeploymentNotFound","message":"The API deployment for this resource does not exist."}}`
+	rawResponse := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    httpRequest,
+	}
+	reader := bufio.NewReader(rawResponse.Body)
+
+	pre, err := handler.(clusterfilters.ClusterFilterResponseUnmarshaller).UnmarshalResponseBody(ctx, cluster, llmRequest, rawResponse, reader, nil)
+	require.NoError(t, err)
+
+	resp, err := f.(clusterfilters.ClusterFilterResponseUnmarshaller).UnmarshalResponseBody(ctx, cluster, llmRequest, rawResponse, reader, pre)
+	require.NoError(t, err)
+
+	errResp, ok := resp.GetError().(*openai.ErrorResponse)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, errResp.Status)
+	assert.Equal(t, "model_not_found", *errResp.ErrorBody.Code)
+	assert.Equal(t, "The API deployment for this resource does not exist.", errResp.ErrorBody.Message)
+}
@@ -6,9 +6,13 @@
 //
 // Incoming Request -> Request Preflight x n -> Request Modifier x n -> Endpoint Selector -> Request Marshaller -> Outgoing Request
 //
+// Upstreams that can't be requested with a single HTTP request, such as the speech providers only available over
+// WebSocket, are executed by the Upstream Executor in place of the Request Marshaller and the Response Unmarshaller.
+//
 // Incoming Response -> Response Unmarshaller -> Response Modifier x n -> Response Completer x n -> Outgoing Response
 //
-// The filters are applied in the order they are defined in the configuration.
+// The filters are applied in the order they are defined in the configuration. Every invocation of filters wrapped by Sandbox
+// is isolated from panics, bounded by the timeout of the filter, and attributed to the filter when failed.
 package filters
 
 import (
@@ -16,9 +20,10 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/samber/lo"
+
 	"knoway.dev/api/clusters/v1alpha1"
 	"knoway.dev/pkg/object"
-	"knoway.dev/pkg/utils"
 )
 
 type ClusterFilter interface {
@@ -44,7 +49,7 @@ type ClusterFilterRequestModifier interface {
 type ClusterFilterEndpointSelector interface {
 	ClusterFilter
 
-	SelectEndpoint(ctx context.Context, request object.LLMRequest, endpoints []string) string
+	SelectEndpoint(ctx context.Context, cluster *v1alpha1.Cluster, request object.LLMRequest, endpoints []string) string
 }
 
 type ClusterFilterUpstreamRequestMarshaller interface {
@@ -56,6 +61,15 @@ type ClusterFilterUpstreamRequestMarshaller interface {
 	MarshalUpstreamRequest(ctx context.Context, cluster *v1alpha1.Cluster, llmRequest object.LLMRequest, request *http.Request) (*http.Request, error)
 }
 
+type ClusterFilterUpstreamExecutor interface {
+	ClusterFilter
+
+	// ExecuteUpstreamRequest is an optional method that allows the filter to execute the request against the
+	// upstream cluster by itself, e.g. over WebSocket, instead of sending the marshalled HTTP request. It returns
+	// a nil response if the request is not executed by the filter.
+	ExecuteUpstreamRequest(ctx context.Context, cluster *v1alpha1.Cluster, llmRequest object.LLMRequest) (object.LLMResponse, error)
+}
+
 type ClusterFilterResponseUnmarshaller interface {
 	ClusterFilter
 
@@ -80,12 +94,14 @@ type ClusterFilterResponseComplete interface {
 type ClusterFilters []ClusterFilter
 
 func (c ClusterFilters) RequestPreflights() []ClusterFilterRequestPreflight {
-	return utils.TypeAssertFrom[ClusterFilter, ClusterFilterRequestPreflight](c)
+	return typeAssertFrom[ClusterFilterRequestPreflight](c)
 }
 
 func (c ClusterFilters) ForEachRequestPreflight(ctx context.Context, request object.LLMRequest) error {
-	for _, f := range c.RequestPreflights() {
-		err := f.RequestPreflight(ctx, request)
+	for _, i := range sandboxedInvocationsOf[ClusterFilterRequestPreflight](c) {
+		_, err := invoke(ctx, i.sandbox, StageRequestPreflight, func() (any, error) {
+			return nil, i.filter.RequestPreflight(ctx, request)
+		})
 		if err != nil {
 			return err
 		}
@@ -95,14 +111,20 @@ func (c ClusterFilters) ForEachRequestPreflight(ctx context.Context, request obj
 }
 
 func (c ClusterFilters) RequestModifiers() []ClusterFilterRequestModifier {
-	return utils.TypeAssertFrom[ClusterFilter, ClusterFilterRequestModifier](c)
+	return typeAssertFrom[ClusterFilterRequestModifier](c)
 }
 
 func (c ClusterFilters) ForEachRequestModifier(ctx context.Context, cluster *v1alpha1.Cluster, request object.LLMRequest) (object.LLMRequest, error) {
-	for _, f := range c.RequestModifiers() {
+	for _, i := range sandboxedInvocationsOf[ClusterFilterRequestModifier](c) {
 		var err error
 
-		request, err = f.RequestModifier(ctx, cluster, request)
+		// Timed out invocations may still be running, never share variables
+		// that get reassigned with them.
+		in := request
+
+		request, err = invoke(ctx, i.sandbox, StageRequestModifier, func() (object.LLMRequest, error) {
+			return i.filter.RequestModifier(ctx, cluster, in)
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -112,12 +134,15 @@ func (c ClusterFilters) ForEachRequestModifier(ctx context.Context, cluster *v1a
 }
 
 func (c ClusterFilters) EndpointSelectors() []ClusterFilterEndpointSelector {
-	return utils.TypeAssertFrom[ClusterFilter, ClusterFilterEndpointSelector](c)
+	return typeAssertFrom[ClusterFilterEndpointSelector](c)
 }
 
-func (c ClusterFilters) ForEachEndpointSelector(ctx context.Context, request object.LLMRequest, endpoints []string) string {
-	for _, f := range c.EndpointSelectors() {
-		selected := f.SelectEndpoint(ctx, request, endpoints)
+func (c ClusterFilters) ForEachEndpointSelector(ctx context.Context, cluster *v1alpha1.Cluster, request object.LLMRequest, endpoints []string) string {
+	for _, i := range sandboxedInvocationsOf[ClusterFilterEndpointSelector](c) {
+		// Failed selectors are skipped
+		selected, _ := invoke(ctx, i.sandbox, StageEndpointSelector, func() (string, error) {
+			return i.filter.SelectEndpoint(ctx, cluster, request, endpoints), nil
+		})
 		if selected != "" {
 			return selected
 		}
@@ -127,14 +152,18 @@ func (c ClusterFilters) ForEachEndpointSelector(ctx context.Context, request obj
 }
 
 func (c ClusterFilters) UpstreamRequestMarshallers() []ClusterFilterUpstreamRequestMarshaller {
-	return utils.TypeAssertFrom[ClusterFilter, ClusterFilterUpstreamRequestMarshaller](c)
+	return typeAssertFrom[ClusterFilterUpstreamRequestMarshaller](c)
 }
 
 func (c ClusterFilters) ForEachUpstreamRequestMarshaller(ctx context.Context, cluster *v1alpha1.Cluster, llmRequest object.LLMRequest, request *http.Request) (*http.Request, error) {
-	for _, f := range c.UpstreamRequestMarshallers() {
+	for _, i := range sandboxedInvocationsOf[ClusterFilterUpstreamRequestMarshaller](c) {
 		var err error
 
-		request, err = f.MarshalUpstreamRequest(ctx, cluster, llmRequest, request)
+		in := request
+
+		request, err = invoke(ctx, i.sandbox, StageUpstreamRequestMarshaller, func() (*http.Request, error) {
+			return i.filter.MarshalUpstreamRequest(ctx, cluster, llmRequest, in)
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -147,15 +176,42 @@ func (c ClusterFilters) ForEachUpstreamRequestMarshaller(ctx context.Context, cl
 	return request, nil
 }
 
+func (c ClusterFilters) UpstreamExecutors() []ClusterFilterUpstreamExecutor {
+	return typeAssertFrom[ClusterFilterUpstreamExecutor](c)
+}
+
+// ForEachUpstreamExecutor returns the response of the first filter that
+// executed the request, or nil if none of them did.
+func (c ClusterFilters) ForEachUpstreamExecutor(ctx context.Context, cluster *v1alpha1.Cluster, llmRequest object.LLMRequest) (object.LLMResponse, error) {
+	for _, i := range sandboxedInvocationsOf[ClusterFilterUpstreamExecutor](c) {
+		response, err := invoke(ctx, i.sandbox, StageUpstreamExecutor, func() (object.LLMResponse, error) {
+			return i.filter.ExecuteUpstreamRequest(ctx, cluster, llmRequest)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if !lo.IsNil(response) {
+			return response, nil
+		}
+	}
+
+	return nil, nil //nolint:nilnil
+}
+
 func (c ClusterFilters) ResponseUnmarshallers() []ClusterFilterResponseUnmarshaller {
-	return utils.TypeAssertFrom[ClusterFilter, ClusterFilterResponseUnmarshaller](c)
+	return typeAssertFrom[ClusterFilterResponseUnmarshaller](c)
 }
 
 func (c ClusterFilters) ForEachResponseUnmarshaller(ctx context.Context, cluster *v1alpha1.Cluster, request object.LLMRequest, rawResponse *http.Response, reader *bufio.Reader, pre object.LLMResponse) (object.LLMResponse, error) {
-	for _, f := range c.ResponseUnmarshallers() {
+	for _, i := range sandboxedInvocationsOf[ClusterFilterResponseUnmarshaller](c) {
 		var err error
 
-		pre, err = f.UnmarshalResponseBody(ctx, cluster, request, rawResponse, reader, pre)
+		in := pre
+
+		pre, err = invoke(ctx, i.sandbox, StageResponseUnmarshaller, func() (object.LLMResponse, error) {
+			return i.filter.UnmarshalResponseBody(ctx, cluster, request, rawResponse, reader, in)
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -165,14 +221,18 @@ func (c ClusterFilters) ForEachResponseUnmarshaller(ctx context.Context, cluster
 }
 
 func (c ClusterFilters) ResponseModifiers() []ClusterFilterResponseModifier {
-	return utils.TypeAssertFrom[ClusterFilter, ClusterFilterResponseModifier](c)
+	return typeAssertFrom[ClusterFilterResponseModifier](c)
 }
 
 func (c ClusterFilters) ForEachResponseModifier(ctx context.Context, cluster *v1alpha1.Cluster, request object.LLMRequest, response object.LLMResponse) (object.LLMResponse, error) {
-	for _, f := range c.ResponseModifiers() {
+	for _, i := range sandboxedInvocationsOf[ClusterFilterResponseModifier](c) {
 		var err error
 
-		response, err = f.ResponseModifier(ctx, cluster, request, response)
+		in := response
+
+		response, err = invoke(ctx, i.sandbox, StageResponseModifier, func() (object.LLMResponse, error) {
+			return i.filter.ResponseModifier(ctx, cluster, request, in)
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -182,12 +242,14 @@ func (c ClusterFilters) ForEachResponseModifier(ctx context.Context, cluster *v1
 }
 
 func (c ClusterFilters) ResponseCompleters() []ClusterFilterResponseComplete {
-	return utils.TypeAssertFrom[ClusterFilter, ClusterFilterResponseComplete](c)
+	return typeAssertFrom[ClusterFilterResponseComplete](c)
 }
 
 func (c ClusterFilters) ForEachResponseComplete(ctx context.Context, request object.LLMRequest, response object.LLMResponse) error {
-	for _, f := range c.ResponseCompleters() {
-		err := f.ResponseComplete(ctx, request, response)
+	for _, i := range sandboxedInvocationsOf[ClusterFilterResponseComplete](c) {
+		_, err := invoke(ctx, i.sandbox, StageResponseComplete, func() (any, error) {
+			return nil, i.filter.ResponseComplete(ctx, request, response)
+		})
 		if err != nil {
 			return err
 		}
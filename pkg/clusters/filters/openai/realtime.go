@@ -0,0 +1,93 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/gorilla/websocket"
+	"github.com/samber/lo"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/clusters/upstream"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+// executeRealtime connects to the Realtime API of the upstream over
+// WebSocket, the session is relayed to the client by the listener.
+func executeRealtime(ctx context.Context, cluster *v1alpha1clusters.Cluster, llmRequest object.LLMRequest) (object.LLMResponse, error) {
+	if cluster.GetProvider() != v1alpha1clusters.ClusterProvider_OPEN_AI {
+		return nil, openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("provider %s does not support %s", cluster.GetProvider(), object.RequestTypeRealtime))
+	}
+
+	upstreamURL, err := upstream.BuildURL(cluster.GetUpstream(), string(object.RequestTypeRealtime), llmRequest.GetModel())
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	switch upstreamURL.Scheme {
+	case "http":
+		upstreamURL.Scheme = "ws"
+	case "https":
+		upstreamURL.Scheme = "wss"
+	}
+
+	query := upstreamURL.Query()
+	query.Set("model", llmRequest.GetModel())
+	upstreamURL.RawQuery = query.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, upstreamURL.String(), nil)
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	headers, err := upstreamHeaders(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	lo.ForEach(headers, func(h *v1alpha1clusters.Upstream_Header, _ int) {
+		request.Header.Set(h.GetKey(), h.GetValue())
+	})
+
+	err = applyUpstreamAuth(ctx, cluster, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// The clients of the beta Realtime API opt in with the header, or with
+	// the subprotocol if they are unable to set headers
+	if rawRequest := llmRequest.GetRawRequest(); rawRequest != nil {
+		if beta := rawRequest.Header.Get("OpenAI-Beta"); beta != "" {
+			request.Header.Set("OpenAI-Beta", beta)
+		} else if slices.Contains(websocket.Subprotocols(rawRequest), openai.RealtimeBetaSubprotocol) {
+			request.Header.Set("OpenAI-Beta", "realtime=v1")
+		}
+	}
+
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, request.URL.String(), request.Header)
+	if err != nil {
+		if resp == nil {
+			return nil, openai.NewErrorBadGateway().WithMessage(err.Error())
+		}
+
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, openai.NewErrorBadGateway().WithMessage("upstream error: " + resp.Status)
+		}
+
+		errResp, err := openai.ParseErrorResponse(resp, body)
+		if err != nil || errResp == nil {
+			return nil, openai.NewErrorBadGateway().WithMessage("upstream error: " + resp.Status)
+		}
+
+		return nil, errResp
+	}
+
+	return openai.NewRealtimeSession(conn, llmRequest.GetModel()), nil
+}
@@ -0,0 +1,109 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+func TestExecuteUpstreamRequest_Realtime(t *testing.T) {
+	ctx := context.Background()
+
+	upstreamRequests := make(chan *http.Request, 1)
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("model") == "missing" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"message":"model missing not found","type":"invalid_request_error","code":"model_not_found"}}`))
+
+			return
+		}
+
+		upstreamRequests <- r
+
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		_ = conn.Close()
+	}))
+	defer upstream.Close()
+
+	newRealtimeRequest := func(t *testing.T, model string) object.LLMRequest {
+		t.Helper()
+
+		httpRequest := httptest.NewRequest(http.MethodGet, "/v1/realtime?model="+model, nil)
+		httpRequest.Header.Set("Connection", "Upgrade")
+		httpRequest.Header.Set("Upgrade", "websocket")
+		httpRequest.Header.Set("Sec-WebSocket-Protocol", "realtime, openai-beta.realtime-v1")
+
+		llmRequest, err := openai.NewRealtimeRequest(httpRequest)
+		require.NoError(t, err)
+
+		return llmRequest
+	}
+
+	handler := &requestHandler{cfg: &v1alpha1.OpenAIRequestHandlerConfig{}}
+
+	cluster := &v1alpha1clusters.Cluster{
+		Name:     "gpt-4o-realtime-preview",
+		Provider: v1alpha1clusters.ClusterProvider_OPEN_AI,
+		Upstream: &v1alpha1clusters.Upstream{
+			Url: upstream.URL + "/v1",
+			Headers: []*v1alpha1clusters.Upstream_Header{
+				{Key: "Authorization", Value: "Bearer sk-test"},
+			},
+		},
+	}
+
+	response, err := handler.ExecuteUpstreamRequest(ctx, cluster, newRealtimeRequest(t, "gpt-4o-realtime-preview"))
+	require.NoError(t, err)
+
+	session, ok := response.(*openai.RealtimeSession)
+	require.True(t, ok)
+	assert.Equal(t, "gpt-4o-realtime-preview", session.GetModel())
+	require.NoError(t, session.Close())
+
+	upstreamRequest := <-upstreamRequests
+	assert.Equal(t, "/v1/realtime", upstreamRequest.URL.Path)
+	assert.Equal(t, "gpt-4o-realtime-preview", upstreamRequest.URL.Query().Get("model"))
+	assert.Equal(t, "Bearer sk-test", upstreamRequest.Header.Get("Authorization"))
+	assert.Equal(t, "realtime=v1", upstreamRequest.Header.Get("OpenAI-Beta"))
+
+	t.Run("upstream error", func(t *testing.T) {
+		_, err := handler.ExecuteUpstreamRequest(ctx, cluster, newRealtimeRequest(t, "missing"))
+		require.Error(t, err)
+
+		var llmErr object.LLMError
+		require.ErrorAs(t, err, &llmErr)
+		assert.Equal(t, http.StatusNotFound, llmErr.GetStatus())
+	})
+
+	t.Run("unsupported provider", func(t *testing.T) {
+		cluster := &v1alpha1clusters.Cluster{
+			Name:     "claude",
+			Provider: v1alpha1clusters.ClusterProvider_ANTHROPIC,
+			Upstream: &v1alpha1clusters.Upstream{Url: "https://api.anthropic.com/v1"},
+		}
+
+		_, err := handler.ExecuteUpstreamRequest(ctx, cluster, newRealtimeRequest(t, "claude"))
+		require.Error(t, err)
+
+		var llmErr object.LLMError
+		require.ErrorAs(t, err, &llmErr)
+		assert.Equal(t, http.StatusBadRequest, llmErr.GetStatus())
+		assert.Equal(t, "provider ANTHROPIC does not support realtime", llmErr.Error())
+	})
+}
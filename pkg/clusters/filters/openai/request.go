@@ -8,7 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"strings"
+	"slices"
 
 	"github.com/samber/lo"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -17,14 +17,20 @@ import (
 	"knoway.dev/api/filters/v1alpha1"
 	"knoway.dev/pkg/bootkit"
 	clusterfilters "knoway.dev/pkg/clusters/filters"
+	"knoway.dev/pkg/clusters/upstream"
+	"knoway.dev/pkg/credentials"
 	"knoway.dev/pkg/object"
 	"knoway.dev/pkg/protoutils"
 	"knoway.dev/pkg/types/alibaba/cosyvoice"
+	"knoway.dev/pkg/types/anthropic/claude"
+	"knoway.dev/pkg/types/aws/bedrock"
 	"knoway.dev/pkg/types/deepgram/websocketv1"
 	elevenlabsv1 "knoway.dev/pkg/types/elevenlabs/v1"
+	"knoway.dev/pkg/types/google/gemini"
 	koemotionv1 "knoway.dev/pkg/types/koemotion/v1"
 	"knoway.dev/pkg/types/microsoft/speechservicev1"
 	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/types/stt"
 	"knoway.dev/pkg/types/tts"
 	"knoway.dev/pkg/types/volcengine/seedspeechv1"
 )
@@ -42,6 +48,7 @@ func NewRequestHandlerWithConfig(cfg *anypb.Any, _ bootkit.LifeCycle) (clusterfi
 
 var _ clusterfilters.ClusterFilterRequestModifier = (*requestHandler)(nil)
 var _ clusterfilters.ClusterFilterUpstreamRequestMarshaller = (*requestHandler)(nil)
+var _ clusterfilters.ClusterFilterUpstreamExecutor = (*requestHandler)(nil)
 
 type requestHandler struct {
 	clusterfilters.IsClusterFilter
@@ -50,7 +57,12 @@ type requestHandler struct {
 }
 
 func (f *requestHandler) RequestModifier(ctx context.Context, cluster *v1alpha1clusters.Cluster, request object.LLMRequest) (object.LLMRequest, error) {
-	err := request.SetModel(cluster.GetName())
+	err := checkModelCapabilities(cluster, request)
+	if err != nil {
+		return request, err
+	}
+
+	err = request.SetModel(cluster.GetName())
 	if err != nil {
 		return request, err
 	}
@@ -73,40 +85,68 @@ func (f *requestHandler) RequestModifier(ctx context.Context, cluster *v1alpha1c
 	return request, nil
 }
 
+// checkModelCapabilities rejects the requests using the tools or JSON schemas
+// when the model info of the cluster lists the capabilities without them,
+// rather than letting the upstream ignore them or fail in its own terms.
+// Clusters without any capabilities listed are not checked.
+func checkModelCapabilities(cluster *v1alpha1clusters.Cluster, request object.LLMRequest) error {
+	capabilities := cluster.GetModelInfo().GetCapabilities()
+	if len(capabilities) == 0 {
+		return nil
+	}
+
+	if r, ok := request.(interface{ UsesTools() bool }); ok && r.UsesTools() && !slices.Contains(capabilities, v1alpha1clusters.ModelCapability_TOOLS) {
+		return openai.NewErrorUnsupportedParameter("tools")
+	}
+
+	if r, ok := request.(interface{ UsesJSONSchema() bool }); ok && r.UsesJSONSchema() && !slices.Contains(capabilities, v1alpha1clusters.ModelCapability_JSON_SCHEMA) {
+		return openai.NewErrorUnsupportedParameter("response_format")
+	}
+
+	return nil
+}
+
 func (f *requestHandler) MarshalUpstreamRequest(ctx context.Context, cluster *v1alpha1clusters.Cluster, llmRequest object.LLMRequest, request *http.Request) (*http.Request, error) {
-	upstreamURL := cluster.GetUpstream().GetUrl()
-	upstreamURL = strings.TrimSuffix(upstreamURL, "/")
+	headers, err := upstreamHeaders(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var upstreamURL string
 
 	switch llmRequest.GetRequestType() {
-	case object.RequestTypeChatCompletions:
-		upstreamURL += "/chat/completions"
-	case object.RequestTypeCompletions:
-		upstreamURL += "/completions"
-	case object.RequestTypeImageGenerations:
-		upstreamURL += "/images/generations"
+	case object.RequestTypeChatCompletions,
+		object.RequestTypeCompletions,
+		object.RequestTypeImageGenerations,
+		object.RequestTypeImageEdits,
+		object.RequestTypeImageVariations,
+		object.RequestTypeModerations,
+		object.RequestTypeEmbeddings,
+		object.RequestTypeRerank:
+		builtURL, err := upstream.BuildURL(cluster.GetUpstream(), string(llmRequest.GetRequestType()), llmRequest.GetModel())
+		if err != nil {
+			return nil, openai.NewErrorInternalError().WithCause(err)
+		}
+
+		upstreamURL = builtURL.String()
 	case object.RequestTypeTextToSpeech:
 		ttsReq, ok := llmRequest.(tts.Request)
 		if !ok {
 			return nil, openai.NewErrorInternalError().WithCausef("failed to cast %T to tts.Request", llmRequest)
 		}
 
-		authHeader := ""
-		if request != nil {
-			authHeader = request.Header.Get("Authorization")
+		transport, err := speechTransportOf(cluster.GetProvider(), llmRequest.GetRequestType())
+		if err != nil {
+			return nil, err
 		}
 
-		upstreamHeaders := http.Header{}
-		lo.ForEach(cluster.GetUpstream().GetHeaders(), func(h *v1alpha1clusters.Upstream_Header, _ int) {
-			upstreamHeaders.Set(h.GetKey(), h.GetValue())
-		})
-
-		var downstreamHeaders http.Header
-		if request != nil {
-			downstreamHeaders = request.Header
+		if transport != speechTransportHTTP {
+			return nil, errSpeechOverHTTPUnsupported(cluster.GetProvider(), llmRequest.GetRequestType())
 		}
 
+		authHeader, upstreamHeaders, downstreamHeaders := speechRequestHeaders(headers, request)
+
 		var ttsRequest *http.Request
-		var err error
 
 		switch cluster.GetProvider() {
 		case v1alpha1clusters.ClusterProvider_OPEN_AI_V1_SPEECH, v1alpha1clusters.ClusterProvider_OPEN_AI:
@@ -120,7 +160,7 @@ func (f *requestHandler) MarshalUpstreamRequest(ctx context.Context, cluster *v1
 		case v1alpha1clusters.ClusterProvider_VOLCENGINE_SEED_SPEECH_V1:
 			ttsRequest, err = seedspeechv1.BuildSpeechRequest(ctx, cluster.GetUpstream().GetUrl(), authHeader, ttsReq, upstreamHeaders, downstreamHeaders)
 		case v1alpha1clusters.ClusterProvider_ALIBABA_COSY_VOICE_SERVICE:
-			return nil, cosyvoice.ErrWebSocketOnlyProvider
+			ttsRequest, err = cosyvoice.BuildSpeechRequest(ctx, cluster.GetUpstream().GetUrl(), authHeader, ttsReq, upstreamHeaders, downstreamHeaders)
 		case v1alpha1clusters.ClusterProvider_MICROSOFT_SPEECH_SERVICE_V1:
 			ttsRequest, err = speechservicev1.BuildSpeechRequest(ctx, cluster.GetUpstream().GetUrl(), authHeader, ttsReq, upstreamHeaders, downstreamHeaders)
 		default:
@@ -130,56 +170,214 @@ func (f *requestHandler) MarshalUpstreamRequest(ctx context.Context, cluster *v1
 			return nil, err
 		}
 
-		lo.ForEach(cluster.GetUpstream().GetHeaders(), func(h *v1alpha1clusters.Upstream_Header, _ int) {
-			ttsRequest.Header.Set(h.GetKey(), h.GetValue())
-		})
-
-		if downstreamHeaders != nil {
-			lo.ForEach(cluster.GetUpstream().GetHeaders(), func(h *v1alpha1clusters.Upstream_Header, _ int) {
-				if value := downstreamHeaders.Get(h.GetKey()); value != "" {
-					ttsRequest.Header.Set(h.GetKey(), value)
-				}
-			})
+		err = applySpeechRequestHeaders(ctx, cluster, headers, downstreamHeaders, ttsRequest)
+		if err != nil {
+			return nil, err
 		}
 
 		return ttsRequest, nil
+	case object.RequestTypeSpeechToText:
+		sttReq, ok := llmRequest.(stt.Request)
+		if !ok {
+			return nil, openai.NewErrorInternalError().WithCausef("failed to cast %T to stt.Request", llmRequest)
+		}
+
+		transport, err := speechTransportOf(cluster.GetProvider(), llmRequest.GetRequestType())
+		if err != nil {
+			return nil, err
+		}
+
+		if transport != speechTransportHTTP {
+			return nil, errSpeechOverHTTPUnsupported(cluster.GetProvider(), llmRequest.GetRequestType())
+		}
+
+		authHeader, upstreamHeaders, downstreamHeaders := speechRequestHeaders(headers, request)
+
+		var sttRequest *http.Request
+
+		switch cluster.GetProvider() {
+		case v1alpha1clusters.ClusterProvider_OPEN_AI_V1_SPEECH, v1alpha1clusters.ClusterProvider_OPEN_AI, v1alpha1clusters.ClusterProvider_VLLM:
+			sttRequest, err = openai.BuildTranscriptionRequest(ctx, cluster.GetUpstream().GetUrl(), authHeader, sttReq, upstreamHeaders, downstreamHeaders)
+		case v1alpha1clusters.ClusterProvider_DEEPGRAM_WEBSOCKET_V1:
+			sttRequest, err = websocketv1.BuildTranscriptionRequest(ctx, cluster.GetUpstream().GetUrl(), authHeader, sttReq, upstreamHeaders, downstreamHeaders)
+		default:
+			return nil, openai.NewErrorBadRequest().WithMessage("unsupported STT provider")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		err = applySpeechRequestHeaders(ctx, cluster, headers, downstreamHeaders, sttRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		return sttRequest, nil
 	default:
 		panic("unknown request type: " + string(llmRequest.GetRequestType()))
 	}
 
-	parsedUpstreamURL, err := url.Parse(upstreamURL)
+	var (
+		body        []byte
+		contentType = "application/json"
+	)
+
+	switch cluster.GetProvider() { //nolint:exhaustive
+	case v1alpha1clusters.ClusterProvider_AWS_BEDROCK:
+		upstreamURL, body, err = bedrock.MarshalRequest(cluster.GetUpstream().GetUrl(), llmRequest)
+	case v1alpha1clusters.ClusterProvider_GOOGLE_GEMINI:
+		upstreamURL, body, err = gemini.MarshalRequest(cluster.GetUpstream().GetUrl(), llmRequest)
+	case v1alpha1clusters.ClusterProvider_ANTHROPIC:
+		upstreamURL, body, err = claude.MarshalRequest(cluster.GetUpstream().GetUrl(), llmRequest)
+	default:
+		// The images edited are uploaded in multipart/form-data as they are
+		// received
+		if multipartRequest, ok := llmRequest.(interface {
+			GetBodyBuffer() *bytes.Buffer
+			GetContentType() string
+		}); ok {
+			body, contentType = multipartRequest.GetBodyBuffer().Bytes(), multipartRequest.GetContentType()
+		} else {
+			body, err = json.Marshal(llmRequest)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	jsonBody, err := json.Marshal(llmRequest)
+	parsedUpstreamURL, err := url.Parse(upstreamURL)
 	if err != nil {
 		return nil, err
 	}
 
+	upstream.ApplyQuery(cluster.GetUpstream(), parsedUpstreamURL)
+
 	if request == nil {
-		request, err = http.NewRequestWithContext(ctx, http.MethodPost, upstreamURL, bytes.NewReader(jsonBody))
+		request, err = http.NewRequestWithContext(ctx, http.MethodPost, parsedUpstreamURL.String(), bytes.NewReader(body))
 		if err != nil {
 			return nil, err
 		}
 	} else {
 		request.URL = parsedUpstreamURL
 		request.Method = http.MethodPost
-		request.Body = io.NopCloser(bytes.NewReader(jsonBody))
+		request.Body = io.NopCloser(bytes.NewReader(body))
+		// Filters may have changed the size of the body, e.g. by compressing
+		// the prompts
+		request.ContentLength = int64(len(body))
+		request.Header.Del("Content-Length")
 	}
 
-	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Content-Type", contentType)
+	if cluster.GetProvider() == v1alpha1clusters.ClusterProvider_ANTHROPIC {
+		request.Header.Set("anthropic-version", claude.APIVersion)
+	}
 	// Apply headers
-	if llmRequest.IsStream() { // non stream
+	// ConverseStream of AWS Bedrock responds with event streams rather than
+	// server-sent events
+	if llmRequest.IsStream() && cluster.GetProvider() != v1alpha1clusters.ClusterProvider_AWS_BEDROCK { // non stream
 		request.Header.Set("Accept", "text/event-stream")
 		request.Header.Set("Cache-Control", "no-cache")
 		request.Header.Set("Connection", "keep-alive")
 	}
 
 	// Apply user-defined headers
-	lo.ForEach(cluster.GetUpstream().GetHeaders(), func(h *v1alpha1clusters.Upstream_Header, _ int) {
+	lo.ForEach(headers, func(h *v1alpha1clusters.Upstream_Header, _ int) {
 		request.Header.Set(h.GetKey(), h.GetValue())
 	})
 
+	err = applyUpstreamAuth(ctx, cluster, request)
+	if err != nil {
+		return nil, err
+	}
+
 	return request, nil
 }
+
+// speechRequestHeaders returns the headers speech providers build their
+// requests with.
+func speechRequestHeaders(headers []*v1alpha1clusters.Upstream_Header, request *http.Request) (string, http.Header, http.Header) {
+	authHeader := ""
+	if request != nil {
+		authHeader = request.Header.Get("Authorization")
+	}
+
+	upstreamHeaders := http.Header{}
+	lo.ForEach(headers, func(h *v1alpha1clusters.Upstream_Header, _ int) {
+		upstreamHeaders.Set(h.GetKey(), h.GetValue())
+	})
+
+	var downstreamHeaders http.Header
+	if request != nil {
+		downstreamHeaders = request.Header
+	}
+
+	return authHeader, upstreamHeaders, downstreamHeaders
+}
+
+// applySpeechRequestHeaders applies the headers and credentials of the
+// upstream to the requests built by speech providers, the headers of the
+// upstream can be overridden by the downstream request.
+func applySpeechRequestHeaders(ctx context.Context, cluster *v1alpha1clusters.Cluster, headers []*v1alpha1clusters.Upstream_Header, downstreamHeaders http.Header, request *http.Request) error {
+	lo.ForEach(headers, func(h *v1alpha1clusters.Upstream_Header, _ int) {
+		request.Header.Set(h.GetKey(), h.GetValue())
+	})
+
+	upstream.ApplyQuery(cluster.GetUpstream(), request.URL)
+
+	err := applyUpstreamAuth(ctx, cluster, request)
+	if err != nil {
+		return err
+	}
+
+	if downstreamHeaders != nil {
+		lo.ForEach(headers, func(h *v1alpha1clusters.Upstream_Header, _ int) {
+			if value := downstreamHeaders.Get(h.GetKey()); value != "" {
+				request.Header.Set(h.GetKey(), value)
+			}
+		})
+	}
+
+	return nil
+}
+
+// applyUpstreamAuth places the credentials of the upstream into the query
+// parameters or cookies of the request.
+func applyUpstreamAuth(ctx context.Context, cluster *v1alpha1clusters.Cluster, request *http.Request) error {
+	auths := cluster.GetUpstream().GetAuth()
+	if len(auths) == 0 {
+		return nil
+	}
+
+	query := request.URL.Query()
+
+	for _, auth := range auths {
+		value, err := credentials.ResolveAuthValue(ctx, auth)
+		if err != nil {
+			return openai.NewErrorInternalError().WithCause(err)
+		}
+
+		switch auth.GetScheme() {
+		case v1alpha1clusters.Upstream_Auth_QUERY_PARAM:
+			query.Set(auth.GetName(), value)
+		case v1alpha1clusters.Upstream_Auth_COOKIE:
+			request.AddCookie(&http.Cookie{Name: auth.GetName(), Value: value})
+		default:
+			return openai.NewErrorInternalError().WithCausef("unsupported upstream auth scheme %s", auth.GetScheme())
+		}
+	}
+
+	request.URL.RawQuery = query.Encode()
+
+	return nil
+}
+
+// upstreamHeaders returns the static headers of the upstream along with the
+// ones resolved from credential providers.
+func upstreamHeaders(ctx context.Context, cluster *v1alpha1clusters.Cluster) ([]*v1alpha1clusters.Upstream_Header, error) {
+	resolved, err := credentials.ResolveHeaders(ctx, cluster.GetUpstream().GetHeadersFrom())
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	return append(slices.Clone(cluster.GetUpstream().GetHeaders()), resolved...), nil
+}
@@ -0,0 +1,331 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+func TestMarshalUpstreamRequest_Auth(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &v1alpha1clusters.Cluster{
+		Name: "gemini-2.0-flash",
+		Upstream: &v1alpha1clusters.Upstream{
+			Url: "https://generativelanguage.googleapis.com/v1beta/openai",
+			Auth: []*v1alpha1clusters.Upstream_Auth{
+				{
+					Scheme: v1alpha1clusters.Upstream_Auth_QUERY_PARAM,
+					Name:   "key",
+					Source: &v1alpha1clusters.Upstream_Auth_Value{Value: "secret&key"},
+				},
+				{
+					Scheme: v1alpha1clusters.Upstream_Auth_COOKIE,
+					Name:   "session",
+					Source: &v1alpha1clusters.Upstream_Auth_Value{Value: "s3ss10n"},
+				},
+			},
+		},
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/v1/chat/completions", bytes.NewBufferString(`{"model": "gemini", "messages": []}`))
+	require.NoError(t, err)
+
+	llmRequest, err := openai.NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	handler := &requestHandler{cfg: &v1alpha1.OpenAIRequestHandlerConfig{}}
+
+	request, err := handler.MarshalUpstreamRequest(ctx, cluster, llmRequest, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/v1beta/openai/chat/completions", request.URL.Path)
+	assert.Equal(t, "secret&key", request.URL.Query().Get("key"))
+
+	cookie, err := request.Cookie("session")
+	require.NoError(t, err)
+	assert.Equal(t, "s3ss10n", cookie.Value)
+}
+
+func TestMarshalUpstreamRequest_AWSBedrock(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &v1alpha1clusters.Cluster{
+		Name:     "amazon.nova-lite-v1:0",
+		Provider: v1alpha1clusters.ClusterProvider_AWS_BEDROCK,
+		Upstream: &v1alpha1clusters.Upstream{
+			Url: "https://bedrock-runtime.us-east-1.amazonaws.com",
+		},
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/v1/chat/completions", bytes.NewBufferString(`{"model": "amazon.nova-lite-v1:0", "stream": true, "messages": [{"role": "user", "content": "Hi"}]}`))
+	require.NoError(t, err)
+
+	llmRequest, err := openai.NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	handler := &requestHandler{cfg: &v1alpha1.OpenAIRequestHandlerConfig{}}
+
+	request, err := handler.MarshalUpstreamRequest(ctx, cluster, llmRequest, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/model/amazon.nova-lite-v1%3A0/converse-stream", request.URL.EscapedPath())
+	assert.Empty(t, request.Header.Get("Accept"))
+	assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+}
+
+func TestMarshalUpstreamRequest_Gemini(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &v1alpha1clusters.Cluster{
+		Name:     "gemini-2.0-flash",
+		Provider: v1alpha1clusters.ClusterProvider_GOOGLE_GEMINI,
+		Upstream: &v1alpha1clusters.Upstream{
+			Url: "https://generativelanguage.googleapis.com/v1beta",
+		},
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/v1/chat/completions", bytes.NewBufferString(`{"model": "gemini-2.0-flash", "stream": true, "messages": [{"role": "user", "content": "Hi"}]}`))
+	require.NoError(t, err)
+
+	llmRequest, err := openai.NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	handler := &requestHandler{cfg: &v1alpha1.OpenAIRequestHandlerConfig{}}
+
+	request, err := handler.MarshalUpstreamRequest(ctx, cluster, llmRequest, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/v1beta/models/gemini-2.0-flash:streamGenerateContent", request.URL.Path)
+	assert.Equal(t, "sse", request.URL.Query().Get("alt"))
+	assert.Equal(t, "text/event-stream", request.Header.Get("Accept"))
+}
+
+func TestMarshalUpstreamRequest_UpstreamPathsAndQuery(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &v1alpha1clusters.Cluster{
+		Name:     "text-embedding-3-small",
+		Provider: v1alpha1clusters.ClusterProvider_OPEN_AI,
+		Upstream: &v1alpha1clusters.Upstream{
+			Url:   "https://api.example.com",
+			Paths: map[string]string{"embeddings": "/v2/{model}/embed"},
+			Query: map[string]string{"api-version": "2024-10-21"},
+		},
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/v1/embeddings", bytes.NewBufferString(`{"model": "text-embedding-3-small", "input": "Hi"}`))
+	require.NoError(t, err)
+
+	llmRequest, err := openai.NewEmbeddingsRequest(httpRequest)
+	require.NoError(t, err)
+
+	handler := &requestHandler{cfg: &v1alpha1.OpenAIRequestHandlerConfig{}}
+
+	request, err := handler.MarshalUpstreamRequest(ctx, cluster, llmRequest, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://api.example.com/v2/text-embedding-3-small/embed?api-version=2024-10-21", request.URL.String())
+}
+
+func TestMarshalUpstreamRequest_Anthropic(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := &v1alpha1clusters.Cluster{
+		Name:     "claude-sonnet-4-5",
+		Provider: v1alpha1clusters.ClusterProvider_ANTHROPIC,
+		Upstream: &v1alpha1clusters.Upstream{
+			Url:     "https://api.anthropic.com/v1",
+			Headers: []*v1alpha1clusters.Upstream_Header{{Key: "x-api-key", Value: "sk-ant-test"}},
+		},
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/v1/chat/completions", bytes.NewBufferString(`{"model": "claude-sonnet-4-5", "stream": true, "messages": [{"role": "user", "content": "Hi"}]}`))
+	require.NoError(t, err)
+
+	llmRequest, err := openai.NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	handler := &requestHandler{cfg: &v1alpha1.OpenAIRequestHandlerConfig{}}
+
+	request, err := handler.MarshalUpstreamRequest(ctx, cluster, llmRequest, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/v1/messages", request.URL.Path)
+	assert.Equal(t, "2023-06-01", request.Header.Get("anthropic-version"))
+	assert.Equal(t, "sk-ant-test", request.Header.Get("x-api-key"))
+	assert.Equal(t, "text/event-stream", request.Header.Get("Accept"))
+}
+
+func TestMarshalUpstreamRequest_SpeechCapabilities(t *testing.T) {
+	ctx := context.Background()
+
+	newTTSRequest := func(t *testing.T) object.LLMRequest {
+		t.Helper()
+
+		httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/v1/audio/speech", bytes.NewBufferString(`{"model": "cosyvoice-v1", "input": "Hi", "voice": "longxiaochun"}`))
+		require.NoError(t, err)
+
+		llmRequest, err := openai.NewTextToSpeechRequest(httpRequest)
+		require.NoError(t, err)
+
+		return llmRequest
+	}
+
+	handler := &requestHandler{cfg: &v1alpha1.OpenAIRequestHandlerConfig{}}
+
+	t.Run("websocket only", func(t *testing.T) {
+		cluster := &v1alpha1clusters.Cluster{
+			Name:     "cosyvoice-v1",
+			Provider: v1alpha1clusters.ClusterProvider_ALIBABA_COSY_VOICE_SERVICE,
+			Upstream: &v1alpha1clusters.Upstream{},
+		}
+
+		_, err := handler.MarshalUpstreamRequest(ctx, cluster, newTTSRequest(t), nil)
+		require.Error(t, err)
+
+		var llmErr object.LLMError
+		require.ErrorAs(t, err, &llmErr)
+		assert.Equal(t, http.StatusBadRequest, llmErr.GetStatus())
+		assert.Equal(t, "provider ALIBABA_COSY_VOICE_SERVICE does not support text_to_speech over http, supported: text_to_speech over websocket", llmErr.Error())
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		cluster := &v1alpha1clusters.Cluster{
+			Name:     "whisper",
+			Provider: v1alpha1clusters.ClusterProvider_VLLM,
+			Upstream: &v1alpha1clusters.Upstream{},
+		}
+
+		response, err := handler.ExecuteUpstreamRequest(ctx, cluster, newTTSRequest(t))
+		require.Error(t, err)
+		assert.Nil(t, response)
+
+		var llmErr object.LLMError
+		require.ErrorAs(t, err, &llmErr)
+		assert.Equal(t, http.StatusBadRequest, llmErr.GetStatus())
+		assert.Equal(t, "provider VLLM does not support text_to_speech, supported: speech_to_text over http", llmErr.Error())
+	})
+
+	t.Run("http", func(t *testing.T) {
+		cluster := &v1alpha1clusters.Cluster{
+			Name:     "tts-1",
+			Provider: v1alpha1clusters.ClusterProvider_OPEN_AI,
+			Upstream: &v1alpha1clusters.Upstream{Url: "https://api.openai.com/v1/audio/speech"},
+		}
+
+		// Left to the marshaller
+		response, err := handler.ExecuteUpstreamRequest(ctx, cluster, newTTSRequest(t))
+		require.NoError(t, err)
+		assert.Nil(t, response)
+
+		request, err := handler.MarshalUpstreamRequest(ctx, cluster, newTTSRequest(t), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "/v1/audio/speech", request.URL.Path)
+	})
+}
+
+func TestMarshalUpstreamRequest_ImageEdits(t *testing.T) {
+	ctx := context.Background()
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	require.NoError(t, writer.WriteField("model", "public/gpt-image-1"))
+	require.NoError(t, writer.WriteField("prompt", "Add a party hat to the cat"))
+
+	part, err := writer.CreateFormFile("image", "cat.png")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("image"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/v1/images/edits", body)
+	require.NoError(t, err)
+	httpRequest.Header.Set("Content-Type", writer.FormDataContentType())
+
+	llmRequest, err := openai.NewImageEditsRequest(httpRequest)
+	require.NoError(t, err)
+	require.NoError(t, llmRequest.SetModel("gpt-image-1"))
+
+	cluster := &v1alpha1clusters.Cluster{
+		Name:     "gpt-image-1",
+		Type:     v1alpha1clusters.ClusterType_IMAGE_GENERATION,
+		Provider: v1alpha1clusters.ClusterProvider_OPEN_AI,
+		Upstream: &v1alpha1clusters.Upstream{Url: "https://api.openai.com/v1"},
+	}
+
+	handler := &requestHandler{cfg: &v1alpha1.OpenAIRequestHandlerConfig{}}
+
+	request, err := handler.MarshalUpstreamRequest(ctx, cluster, llmRequest, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/v1/images/edits", request.URL.Path)
+	assert.Equal(t, llmRequest.GetContentType(), request.Header.Get("Content-Type"))
+
+	// The multipart body is sent as is, with the model of the cluster
+	require.NoError(t, request.ParseMultipartForm(1<<20))
+	assert.Equal(t, "gpt-image-1", request.FormValue("model"))
+	assert.Equal(t, "Add a party hat to the cat", request.FormValue("prompt"))
+	require.Len(t, request.MultipartForm.File["image"], 1)
+	assert.Equal(t, "cat.png", request.MultipartForm.File["image"][0].Filename)
+}
+
+func TestRequestModifier_ModelCapabilities(t *testing.T) {
+	ctx := context.Background()
+
+	newRequest := func(body string) object.LLMRequest {
+		httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/v1/chat/completions", bytes.NewBufferString(body))
+		require.NoError(t, err)
+
+		llmRequest, err := openai.NewChatCompletionRequest(httpRequest)
+		require.NoError(t, err)
+
+		return llmRequest
+	}
+
+	withTools := `{"model": "llama", "messages": [], "tools": [{"type": "function", "function": {"name": "get_weather"}}]}`
+	withJSONSchema := `{"model": "llama", "messages": [], "response_format": {"type": "json_schema", "json_schema": {"name": "weather"}}}`
+
+	handler := &requestHandler{cfg: &v1alpha1.OpenAIRequestHandlerConfig{}}
+
+	// Not checked without capabilities listed
+	_, err := handler.RequestModifier(ctx, &v1alpha1clusters.Cluster{Name: "llama"}, newRequest(withTools))
+	require.NoError(t, err)
+
+	cluster := &v1alpha1clusters.Cluster{
+		Name: "llama",
+		ModelInfo: &v1alpha1clusters.ClusterModelInfo{
+			Capabilities: []v1alpha1clusters.ModelCapability{v1alpha1clusters.ModelCapability_STREAMING},
+		},
+	}
+
+	_, err = handler.RequestModifier(ctx, cluster, newRequest(withTools))
+	require.Error(t, err)
+
+	var errResp *openai.ErrorResponse
+	require.ErrorAs(t, err, &errResp)
+	assert.Equal(t, http.StatusBadRequest, errResp.Status)
+	assert.Equal(t, "tools", *errResp.ErrorBody.Param)
+
+	_, err = handler.RequestModifier(ctx, cluster, newRequest(withJSONSchema))
+	require.ErrorAs(t, err, &errResp)
+	assert.Equal(t, "response_format", *errResp.ErrorBody.Param)
+
+	cluster.ModelInfo.Capabilities = append(cluster.ModelInfo.Capabilities, v1alpha1clusters.ModelCapability_TOOLS, v1alpha1clusters.ModelCapability_JSON_SCHEMA)
+
+	_, err = handler.RequestModifier(ctx, cluster, newRequest(withTools))
+	require.NoError(t, err)
+
+	_, err = handler.RequestModifier(ctx, cluster, newRequest(withJSONSchema))
+	require.NoError(t, err)
+}
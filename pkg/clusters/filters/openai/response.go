@@ -14,11 +14,17 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/artifacts"
 	"knoway.dev/pkg/bootkit"
 	clusterfilters "knoway.dev/pkg/clusters/filters"
 	"knoway.dev/pkg/object"
 	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/types/anthropic/claude"
+	"knoway.dev/pkg/types/aws/bedrock"
+	"knoway.dev/pkg/types/deepgram/websocketv1"
+	"knoway.dev/pkg/types/google/gemini"
 	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/types/stt"
 	"knoway.dev/pkg/types/tts"
 )
 
@@ -43,6 +49,20 @@ type responseHandler struct {
 }
 
 func (f *responseHandler) UnmarshalResponseBody(ctx context.Context, cluster *v1alpha12.Cluster, req object.LLMRequest, rawResponse *http.Response, reader *bufio.Reader, pre object.LLMResponse) (object.LLMResponse, error) {
+	var err error
+
+	switch cluster.GetProvider() { //nolint:exhaustive
+	case v1alpha12.ClusterProvider_AWS_BEDROCK:
+		rawResponse, reader, err = bedrock.TranslateResponse(req, rawResponse, reader)
+	case v1alpha12.ClusterProvider_GOOGLE_GEMINI:
+		rawResponse, reader, err = gemini.TranslateResponse(req, rawResponse, reader)
+	case v1alpha12.ClusterProvider_ANTHROPIC:
+		rawResponse, reader, err = claude.TranslateResponse(req, rawResponse, reader)
+	}
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
 	contentType := rawResponse.Header.Get("Content-Type")
 
 	switch req.GetRequestType() {
@@ -58,12 +78,50 @@ func (f *responseHandler) UnmarshalResponseBody(ctx context.Context, cluster *v1
 			break
 		}
 	case
-		object.RequestTypeImageGenerations:
+		object.RequestTypeImageGenerations,
+		object.RequestTypeImageEdits,
+		object.RequestTypeImageVariations:
 		switch {
 		case strings.HasPrefix(contentType, "application/json"):
-			return openai.NewImageGenerationsResponse(ctx, req, rawResponse, reader,
+			resp, err := openai.NewImageGenerationsResponse(ctx, req, rawResponse, reader,
 				openai.NewImageGenerationsResponseWithUsage(cluster.GetMeteringPolicy()),
 			)
+			if err != nil {
+				return nil, err
+			}
+
+			if store := artifacts.Global(); store != nil {
+				err = resp.StoreArtifacts(ctx, store)
+				if err != nil {
+					return nil, openai.NewErrorInternalError().WithCause(err)
+				}
+			}
+
+			return resp, nil
+		default:
+			break
+		}
+	case
+		object.RequestTypeModerations:
+		switch {
+		case strings.HasPrefix(contentType, "application/json"):
+			return openai.NewModerationsResponse(req, rawResponse, reader)
+		default:
+			break
+		}
+	case
+		object.RequestTypeEmbeddings:
+		switch {
+		case strings.HasPrefix(contentType, "application/json"):
+			return openai.NewEmbeddingsResponse(req, rawResponse, reader)
+		default:
+			break
+		}
+	case
+		object.RequestTypeRerank:
+		switch {
+		case strings.HasPrefix(contentType, "application/json"):
+			return openai.NewRerankResponse(req, rawResponse, reader)
 		default:
 			break
 		}
@@ -98,6 +156,23 @@ func (f *responseHandler) UnmarshalResponseBody(ctx context.Context, cluster *v1
 		}
 
 		return tts.NewAudioResponseFromHTTP(rawResponse, req.GetModel()), nil
+	case object.RequestTypeSpeechToText:
+		sttReq, ok := req.(stt.Request)
+		if !ok {
+			return nil, openai.NewErrorInternalError().WithCausef("failed to cast %T to stt.Request", req)
+		}
+
+		body, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read body: %w", err)
+		}
+
+		switch cluster.GetProvider() { //nolint:exhaustive
+		case v1alpha12.ClusterProvider_DEEPGRAM_WEBSOCKET_V1:
+			return websocketv1.ParseTranscriptionResponse(rawResponse, body, sttReq)
+		default:
+			return openai.ParseTranscriptionResponse(rawResponse, body, sttReq)
+		}
 	default:
 		return nil, fmt.Errorf("unsupported request type %s", req.GetRequestType())
 	}
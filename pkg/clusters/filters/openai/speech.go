@@ -0,0 +1,140 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/alibaba/cosyvoice"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/types/tts"
+)
+
+// speechTransport is how the speech requests are sent to the providers.
+type speechTransport string
+
+const (
+	// speechTransportHTTP providers are requested with the upstream requests
+	// built by the request marshaller.
+	speechTransportHTTP speechTransport = "http"
+	// speechTransportWebSocket providers are executed over WebSocket by the
+	// upstream executor, they can't be requested with HTTP requests.
+	speechTransportWebSocket speechTransport = "websocket"
+)
+
+// speechCapabilities are the speech request types the providers support, and
+// the transports they are sent over.
+var speechCapabilities = map[v1alpha1clusters.ClusterProvider]map[object.RequestType]speechTransport{
+	v1alpha1clusters.ClusterProvider_OPEN_AI: {
+		object.RequestTypeTextToSpeech: speechTransportHTTP,
+		object.RequestTypeSpeechToText: speechTransportHTTP,
+	},
+	v1alpha1clusters.ClusterProvider_OPEN_AI_V1_SPEECH: {
+		object.RequestTypeTextToSpeech: speechTransportHTTP,
+		object.RequestTypeSpeechToText: speechTransportHTTP,
+	},
+	v1alpha1clusters.ClusterProvider_VLLM: {
+		object.RequestTypeSpeechToText: speechTransportHTTP,
+	},
+	v1alpha1clusters.ClusterProvider_DEEPGRAM_WEBSOCKET_V1: {
+		object.RequestTypeTextToSpeech: speechTransportHTTP,
+		object.RequestTypeSpeechToText: speechTransportHTTP,
+	},
+	v1alpha1clusters.ClusterProvider_ELEVEN_LABS_V1: {
+		object.RequestTypeTextToSpeech: speechTransportHTTP,
+	},
+	v1alpha1clusters.ClusterProvider_KOEMOTION_V1: {
+		object.RequestTypeTextToSpeech: speechTransportHTTP,
+	},
+	v1alpha1clusters.ClusterProvider_VOLCENGINE_SEED_SPEECH_V1: {
+		object.RequestTypeTextToSpeech: speechTransportHTTP,
+	},
+	v1alpha1clusters.ClusterProvider_ALIBABA_COSY_VOICE_SERVICE: {
+		object.RequestTypeTextToSpeech: speechTransportWebSocket,
+	},
+	v1alpha1clusters.ClusterProvider_MICROSOFT_SPEECH_SERVICE_V1: {
+		object.RequestTypeTextToSpeech: speechTransportHTTP,
+	},
+}
+
+// describeSpeechCapabilities describes the speech capabilities of the
+// provider, e.g. "text_to_speech over websocket".
+func describeSpeechCapabilities(provider v1alpha1clusters.ClusterProvider) string {
+	capabilities := speechCapabilities[provider]
+	if len(capabilities) == 0 {
+		return "none"
+	}
+
+	described := lo.MapToSlice(capabilities, func(requestType object.RequestType, transport speechTransport) string {
+		return fmt.Sprintf("%s over %s", requestType, transport)
+	})
+	slices.Sort(described)
+
+	return strings.Join(described, ", ")
+}
+
+// speechTransportOf returns the transport the speech request is sent to the
+// provider over, or a bad request error describing the capabilities of the
+// provider if the request is not supported by it.
+func speechTransportOf(provider v1alpha1clusters.ClusterProvider, requestType object.RequestType) (speechTransport, error) {
+	transport, ok := speechCapabilities[provider][requestType]
+	if !ok {
+		return "", openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("provider %s does not support %s, supported: %s", provider, requestType, describeSpeechCapabilities(provider)))
+	}
+
+	return transport, nil
+}
+
+// errSpeechOverHTTPUnsupported is the bad request error of the speech requests
+// marshalled into HTTP requests for the providers only available over other
+// transports, e.g. the WebSocket-only providers.
+func errSpeechOverHTTPUnsupported(provider v1alpha1clusters.ClusterProvider, requestType object.RequestType) error {
+	return openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("provider %s does not support %s over %s, supported: %s", provider, requestType, speechTransportHTTP, describeSpeechCapabilities(provider)))
+}
+
+func (f *requestHandler) ExecuteUpstreamRequest(ctx context.Context, cluster *v1alpha1clusters.Cluster, llmRequest object.LLMRequest) (object.LLMResponse, error) {
+	if llmRequest.GetRequestType() == object.RequestTypeRealtime {
+		return executeRealtime(ctx, cluster, llmRequest)
+	}
+
+	if llmRequest.GetRequestType() == object.RequestTypeVideoGenerations {
+		return executeVideoGenerations(ctx, cluster, llmRequest)
+	}
+
+	if llmRequest.GetRequestType() != object.RequestTypeTextToSpeech {
+		return nil, nil //nolint:nilnil
+	}
+
+	transport, err := speechTransportOf(cluster.GetProvider(), llmRequest.GetRequestType())
+	if err != nil {
+		return nil, err
+	}
+
+	if transport != speechTransportWebSocket {
+		return nil, nil //nolint:nilnil
+	}
+
+	ttsReq, ok := llmRequest.(tts.Request)
+	if !ok {
+		return nil, openai.NewErrorInternalError().WithCausef("failed to cast %T to tts.Request", llmRequest)
+	}
+
+	headers, err := upstreamHeaders(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	_, speechHeaders, _ := speechRequestHeaders(headers, nil)
+
+	switch cluster.GetProvider() { //nolint:exhaustive
+	case v1alpha1clusters.ClusterProvider_ALIBABA_COSY_VOICE_SERVICE:
+		return cosyvoice.DoSpeech(ctx, speechHeaders.Get("Authorization"), ttsReq)
+	default:
+		return nil, openai.NewErrorInternalError().WithCausef("no %s executor for provider %s", transport, cluster.GetProvider())
+	}
+}
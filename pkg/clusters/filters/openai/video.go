@@ -0,0 +1,246 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/samber/lo"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/clusters/connection"
+	"knoway.dev/pkg/clusters/upstream"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/types/runway"
+)
+
+// executeVideoGenerations executes the operations on the video generation
+// jobs, the jobs of the providers are converted into the video objects of the
+// OpenAI API, with the IDs encoding the cluster they are submitted to.
+func executeVideoGenerations(ctx context.Context, cluster *v1alpha1clusters.Cluster, llmRequest object.LLMRequest) (object.LLMResponse, error) {
+	req, ok := llmRequest.(*openai.VideoGenerationsRequest)
+	if !ok {
+		return nil, openai.NewErrorInternalError().WithCausef("failed to cast %T to *openai.VideoGenerationsRequest", llmRequest)
+	}
+
+	// The model requested by the clients, not the one of the upstream, so that
+	// the jobs are polled with the route they are submitted with
+	job := openai.VideoJob{
+		Model:   req.GetModel(),
+		Cluster: cluster.GetName(),
+	}
+	if rMeta := metadata.RequestMetadataFromCtx(ctx); rMeta != nil && rMeta.RequestModel != "" {
+		job.Model = rMeta.RequestModel
+	}
+	if req.GetJob() != nil {
+		job.Model = req.GetJob().Model
+	}
+
+	switch cluster.GetProvider() { //nolint:exhaustive
+	case v1alpha1clusters.ClusterProvider_OPEN_AI:
+		return executeOpenAIVideoGenerations(ctx, cluster, req, job)
+	case v1alpha1clusters.ClusterProvider_RUNWAY:
+		return executeRunwayVideoGenerations(ctx, cluster, req, job)
+	default:
+		return nil, openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("provider %s does not support %s", cluster.GetProvider(), object.RequestTypeVideoGenerations))
+	}
+}
+
+func executeOpenAIVideoGenerations(ctx context.Context, cluster *v1alpha1clusters.Cluster, req *openai.VideoGenerationsRequest, job openai.VideoJob) (object.LLMResponse, error) {
+	upstreamURL, err := upstream.BuildURL(cluster.GetUpstream(), string(object.RequestTypeVideoGenerations), req.GetModel())
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	var (
+		method = http.MethodGet
+		body   []byte
+	)
+
+	switch req.GetOperation() {
+	case openai.VideoOperationCreate:
+		method = http.MethodPost
+
+		body, err = req.MarshalJSON()
+		if err != nil {
+			return nil, openai.NewErrorInternalError().WithCause(err)
+		}
+	case openai.VideoOperationRetrieve:
+		upstreamURL = upstreamURL.JoinPath(req.GetJob().UpstreamID)
+	case openai.VideoOperationContent:
+		upstreamURL = upstreamURL.JoinPath(req.GetJob().UpstreamID, "content")
+	}
+
+	resp, err := doVideoRequest(ctx, cluster, method, upstreamURL, body, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetOperation() == openai.VideoOperationContent {
+		return openai.NewVideoContentResponse(resp, req.GetModel()), nil
+	}
+
+	respBody, err := readVideoResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return openai.NewVideoGenerationsResponse(req, job, resp.StatusCode, respBody, cluster.GetMeteringPolicy())
+}
+
+func executeRunwayVideoGenerations(ctx context.Context, cluster *v1alpha1clusters.Cluster, req *openai.VideoGenerationsRequest, job openai.VideoJob) (object.LLMResponse, error) {
+	versionHeader := http.Header{runway.VersionHeader: []string{runway.Version}}
+
+	if req.GetOperation() == openai.VideoOperationCreate {
+		upstreamURL, err := upstream.JoinURL(cluster.GetUpstream(), runway.SubmitPath(req))
+		if err != nil {
+			return nil, openai.NewErrorInternalError().WithCause(err)
+		}
+
+		body, err := runway.BuildSubmitBody(req)
+		if err != nil {
+			return nil, openai.NewErrorInternalError().WithCause(err)
+		}
+
+		resp, err := doVideoRequest(ctx, cluster, http.MethodPost, upstreamURL, body, versionHeader)
+		if err != nil {
+			return nil, err
+		}
+
+		respBody, err := readVideoResponse(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		converted, err := runway.ConvertSubmitResponse(req, respBody)
+		if err != nil {
+			return nil, openai.NewErrorBadGateway().WithCause(err)
+		}
+
+		return openai.NewVideoGenerationsResponse(req, job, resp.StatusCode, converted, cluster.GetMeteringPolicy())
+	}
+
+	upstreamURL, err := upstream.JoinURL(cluster.GetUpstream(), runway.TaskPath(req.GetJob().UpstreamID))
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	resp, err := doVideoRequest(ctx, cluster, http.MethodGet, upstreamURL, nil, versionHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readVideoResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	converted, outputURL, err := runway.ConvertTask(req.GetModel(), respBody)
+	if err != nil {
+		return nil, openai.NewErrorBadGateway().WithCause(err)
+	}
+
+	if req.GetOperation() == openai.VideoOperationRetrieve {
+		return openai.NewVideoGenerationsResponse(req, job, resp.StatusCode, converted, cluster.GetMeteringPolicy())
+	}
+
+	if outputURL == "" {
+		return nil, openai.NewErrorBadRequest().WithMessage("Video is not ready yet, poll the video until its status is completed.")
+	}
+
+	// The outputs are presigned URLs, the credentials of the upstream are not
+	// sent to them
+	outputRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, outputURL, nil)
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	outputResp, err := connection.Client(cluster).Do(outputRequest)
+	if err != nil {
+		return nil, openai.NewErrorBadGateway().WithCause(err)
+	}
+
+	if outputResp.StatusCode >= http.StatusBadRequest {
+		_ = outputResp.Body.Close()
+
+		return nil, openai.NewErrorBadGateway().WithMessage("upstream error: " + outputResp.Status)
+	}
+
+	return openai.NewVideoContentResponse(outputResp, req.GetModel()), nil
+}
+
+// doVideoRequest sends the request with the headers and the credentials of
+// the upstream, the errors of the upstream are parsed into the returned
+// error.
+func doVideoRequest(ctx context.Context, cluster *v1alpha1clusters.Cluster, method string, upstreamURL *url.URL, body []byte, extraHeaders http.Header) (*http.Response, error) {
+	var bodyReader io.Reader = http.NoBody
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, upstreamURL.String(), bodyReader)
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	headers, err := upstreamHeaders(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	lo.ForEach(headers, func(h *v1alpha1clusters.Upstream_Header, _ int) {
+		request.Header.Set(h.GetKey(), h.GetValue())
+	})
+
+	for key, values := range extraHeaders {
+		request.Header[key] = values
+	}
+
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	err = applyUpstreamAuth(ctx, cluster, request)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := connection.Client(cluster).Do(request)
+	if err != nil {
+		return nil, openai.NewErrorBadGateway().WithCause(err)
+	}
+
+	if resp.StatusCode < http.StatusBadRequest {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, openai.NewErrorBadGateway().WithMessage("upstream error: " + resp.Status)
+	}
+
+	errResp, err := openai.ParseErrorResponse(resp, respBody)
+	if err != nil || errResp == nil {
+		return nil, openai.NewErrorBadGateway().WithMessage("upstream error: " + resp.Status)
+	}
+
+	return nil, errResp
+}
+
+func readVideoResponse(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, openai.NewErrorBadGateway().WithCause(err)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,137 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/types/runway"
+)
+
+func TestExecuteUpstreamRequest_RunwayVideoGenerations(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		submitted map[string]any
+		output    *httptest.Server
+	)
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, runway.Version, r.Header.Get(runway.VersionHeader))
+		assert.Equal(t, "Bearer key_test", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/v1/text_to_video":
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &submitted)
+
+			_, _ = w.Write([]byte(`{"id": "17f20503-6c24-4c16-946b-35dbbce2af2f"}`))
+		case "/v1/tasks/17f20503-6c24-4c16-946b-35dbbce2af2f":
+			_, _ = w.Write([]byte(`{"id": "17f20503-6c24-4c16-946b-35dbbce2af2f", "status": "SUCCEEDED", "output": ["` + output.URL + `/video.mp4"]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer upstream.Close()
+
+	output = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The credentials of the upstream are not sent to the outputs
+		assert.Empty(t, r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "video/mp4")
+		_, _ = w.Write([]byte("mp4"))
+	}))
+	defer output.Close()
+
+	handler := &requestHandler{cfg: &v1alpha1.OpenAIRequestHandlerConfig{}}
+
+	cluster := &v1alpha1clusters.Cluster{
+		Name:     "default/gen4-turbo",
+		Type:     v1alpha1clusters.ClusterType_VIDEO_GENERATION,
+		Provider: v1alpha1clusters.ClusterProvider_RUNWAY,
+		Upstream: &v1alpha1clusters.Upstream{
+			Url: upstream.URL + "/v1",
+			Headers: []*v1alpha1clusters.Upstream_Header{
+				{Key: "Authorization", Value: "Bearer key_test"},
+			},
+		},
+	}
+
+	body := []byte(`{"model": "gen4_turbo", "prompt": "A calico cat playing a piano on stage", "seconds": 4.5, "size": "1280x720"}`)
+
+	createRequest, err := openai.NewVideoGenerationsRequest(httptest.NewRequest(http.MethodPost, "/v1/videos", bytes.NewReader(body)))
+	require.NoError(t, err)
+
+	response, err := handler.ExecuteUpstreamRequest(ctx, cluster, createRequest)
+	require.NoError(t, err)
+
+	created, ok := response.(*openai.VideoGenerationsResponse)
+	require.True(t, ok)
+	assert.Equal(t, openai.VideoStatusQueued, created.JobStatus)
+
+	assert.Equal(t, "gen4_turbo", submitted["model"])
+	assert.Equal(t, "1280:720", submitted["ratio"])
+	assert.InDelta(t, 5, submitted["duration"], 0.0001)
+
+	job, err := openai.ParseVideoJobID(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "default/gen4-turbo", job.Cluster)
+	assert.Equal(t, "17f20503-6c24-4c16-946b-35dbbce2af2f", job.UpstreamID)
+
+	retrieveRequest, err := openai.NewVideoJobRequest(httptest.NewRequest(http.MethodGet, "/v1/videos/"+created.ID, nil), openai.VideoOperationRetrieve, created.ID)
+	require.NoError(t, err)
+
+	response, err = handler.ExecuteUpstreamRequest(ctx, cluster, retrieveRequest)
+	require.NoError(t, err)
+
+	retrieved, ok := response.(*openai.VideoGenerationsResponse)
+	require.True(t, ok)
+	assert.Equal(t, openai.VideoStatusCompleted, retrieved.JobStatus)
+	assert.Equal(t, created.ID, retrieved.ID)
+	assert.Nil(t, retrieved.GetUsage())
+
+	contentRequest, err := openai.NewVideoJobRequest(httptest.NewRequest(http.MethodGet, "/v1/videos/"+created.ID+"/content", nil), openai.VideoOperationContent, created.ID)
+	require.NoError(t, err)
+
+	response, err = handler.ExecuteUpstreamRequest(ctx, cluster, contentRequest)
+	require.NoError(t, err)
+
+	content, ok := response.(*openai.VideoContentResponse)
+	require.True(t, ok)
+
+	recorder := httptest.NewRecorder()
+	require.NoError(t, content.WriteTo(recorder))
+	assert.Equal(t, "video/mp4", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "mp4", recorder.Body.String())
+}
+
+func TestExecuteUpstreamRequest_VideoGenerationsUnsupportedProvider(t *testing.T) {
+	handler := &requestHandler{cfg: &v1alpha1.OpenAIRequestHandlerConfig{}}
+
+	body := []byte(`{"model": "sora-2", "prompt": "A calico cat playing a piano on stage"}`)
+
+	request, err := openai.NewVideoGenerationsRequest(httptest.NewRequest(http.MethodPost, "/v1/videos", bytes.NewReader(body)))
+	require.NoError(t, err)
+
+	_, err = handler.ExecuteUpstreamRequest(context.Background(), &v1alpha1clusters.Cluster{
+		Name:     "sora-2",
+		Type:     v1alpha1clusters.ClusterType_VIDEO_GENERATION,
+		Provider: v1alpha1clusters.ClusterProvider_VLLM,
+		Upstream: &v1alpha1clusters.Upstream{Url: "http://localhost/v1"},
+	}, request)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), string(object.RequestTypeVideoGenerations))
+}
@@ -0,0 +1,253 @@
+// Package prompttemplate injects the system prompts of the operators and wraps
+// the user prompts of chat completions by templates. It runs as a cluster
+// filter of LLMBackends in the request modifier stage, and as a request
+// filter of ModelRoutes.
+package prompttemplate
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	clusterfilters "knoway.dev/pkg/clusters/filters"
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/types/openai"
+)
+
+const (
+	defaultDateFormat = time.DateOnly
+
+	VariableUserID   = "user_id"
+	VariableAPIKeyID = "api_key_id"
+	VariableModel    = "model"
+	VariableDate     = "date"
+)
+
+var builtinVariables = []string{VariableUserID, VariableAPIKeyID, VariableModel, VariableDate}
+
+// Template applies the system prompt and the prefix and the suffix of the
+// last user message to the chat completions requests.
+type Template struct {
+	filters.IsRequestFilter
+	clusterfilters.IsClusterFilter
+
+	systemPrompt     string
+	systemPromptMode v1alpha1.PromptTemplateConfig_SystemPromptMode
+	userPrefix       string
+	userSuffix       string
+	variables        map[string]string
+	dateFormat       string
+	now              func() time.Time
+}
+
+var _ filters.RequestFilter = (*Template)(nil)
+var _ filters.OnCompletionRequestFilter = (*Template)(nil)
+var _ clusterfilters.ClusterFilterRequestModifier = (*Template)(nil)
+
+func newTemplate(cfg *anypb.Any) (*Template, error) {
+	c, err := protoutils.FromAny(cfg, &v1alpha1.PromptTemplateConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	for name := range c.GetVariables() {
+		if lo.Contains(builtinVariables, name) {
+			return nil, fmt.Errorf("variable %s is built-in and can't be overridden", name)
+		}
+	}
+
+	return &Template{
+		systemPrompt:     c.GetSystemPrompt(),
+		systemPromptMode: c.GetSystemPromptMode(),
+		userPrefix:       c.GetUserPrefix(),
+		userSuffix:       c.GetUserSuffix(),
+		variables:        c.GetVariables(),
+		dateFormat:       lo.CoalesceOrEmpty(c.GetDateFormat(), defaultDateFormat),
+		now:              time.Now,
+	}, nil
+}
+
+// NewWithConfig creates the cluster filter of LLMBackends.
+func NewWithConfig(cfg *anypb.Any, _ bootkit.LifeCycle) (clusterfilters.ClusterFilter, error) {
+	return newTemplate(cfg)
+}
+
+// NewRequestFilterWithConfig creates the request filter of ModelRoutes.
+func NewRequestFilterWithConfig(cfg *anypb.Any, _ bootkit.LifeCycle) (filters.RequestFilter, error) {
+	return newTemplate(cfg)
+}
+
+// WithNow replaces the clock of {{date}}, which is time.Now by default.
+func (t *Template) WithNow(now func() time.Time) *Template {
+	t.now = now
+	return t
+}
+
+// Variables returns the values of the variables of the request.
+func (t *Template) Variables(ctx context.Context, request object.LLMRequest) map[string]string {
+	variables := maps.Clone(t.variables)
+	if variables == nil {
+		variables = make(map[string]string, len(builtinVariables))
+	}
+
+	variables[VariableModel] = request.GetModel()
+	variables[VariableDate] = t.now().UTC().Format(t.dateFormat)
+
+	if rMeta := metadata.RequestMetadataFromCtx(ctx); rMeta != nil {
+		variables[VariableUserID] = rMeta.AuthInfo.GetUserId()
+		variables[VariableAPIKeyID] = rMeta.AuthInfo.GetApiKeyId()
+		variables[VariableModel] = lo.CoalesceOrEmpty(rMeta.RequestModel, request.GetModel())
+	}
+
+	return variables
+}
+
+// Apply returns the messages templated with the variables, the messages are
+// never modified in place.
+func (t *Template) Apply(messages []map[string]any, variables map[string]string) []map[string]any {
+	oldnew := make([]string, 0, len(variables)*2) //nolint:mnd
+	for name, value := range variables {
+		oldnew = append(oldnew, "{{"+name+"}}", value)
+	}
+
+	replacer := strings.NewReplacer(oldnew...)
+
+	res := make([]map[string]any, 0, len(messages)+1)
+
+	if systemPrompt := replacer.Replace(t.systemPrompt); systemPrompt != "" {
+		switch t.systemPromptMode { //nolint:exhaustive
+		case v1alpha1.PromptTemplateConfig_SYSTEM_PROMPT_MODE_OVERRIDE:
+			messages = lo.Reject(messages, func(message map[string]any, _ int) bool { return isSystem(message) })
+			res = append(res, map[string]any{"role": "system", "content": systemPrompt})
+		case v1alpha1.PromptTemplateConfig_SYSTEM_PROMPT_MODE_PREPEND:
+			res = append(res, map[string]any{"role": "system", "content": systemPrompt})
+		default:
+			if !lo.SomeBy(messages, isSystem) {
+				res = append(res, map[string]any{"role": "system", "content": systemPrompt})
+			}
+		}
+	}
+
+	res = append(res, messages...)
+
+	prefix, suffix := replacer.Replace(t.userPrefix), replacer.Replace(t.userSuffix)
+	if prefix == "" && suffix == "" {
+		return res
+	}
+
+	_, lastUser, found := lo.FindLastIndexOf(res, func(message map[string]any) bool { return message["role"] == "user" })
+	if found {
+		res[lastUser] = wrap(res[lastUser], prefix, suffix)
+	}
+
+	return res
+}
+
+func isSystem(message map[string]any) bool {
+	return message["role"] == "system" || message["role"] == "developer"
+}
+
+// wrap returns the message with the text content wrapped by the prefix and
+// the suffix, or the content parts between the text parts of them.
+func wrap(message map[string]any, prefix string, suffix string) map[string]any {
+	wrapped := maps.Clone(message)
+
+	switch content := message["content"].(type) {
+	case string:
+		wrapped["content"] = strings.Join(lo.Compact([]string{prefix, content, suffix}), "\n")
+	case []any:
+		parts := make([]any, 0, len(content)+2) //nolint:mnd
+		if prefix != "" {
+			parts = append(parts, map[string]any{"type": "text", "text": prefix})
+		}
+
+		parts = append(parts, content...)
+		if suffix != "" {
+			parts = append(parts, map[string]any{"type": "text", "text": suffix})
+		}
+
+		wrapped["content"] = parts
+	}
+
+	return wrapped
+}
+
+func (t *Template) apply(ctx context.Context, request *openai.ChatCompletionsRequest, messages []map[string]any) error {
+	return request.SetMessages(t.Apply(messages, t.Variables(ctx, request)))
+}
+
+func (t *Template) OnCompletionRequest(ctx context.Context, request object.LLMRequest, _ *http.Request) filters.RequestFilterResult {
+	// Legacy completions carry prompts instead of messages
+	chatRequest, ok := request.(*openai.ChatCompletionsRequest)
+	if !ok {
+		return filters.NewOK()
+	}
+
+	err := t.apply(ctx, chatRequest, chatRequest.GetMessages())
+	if err != nil {
+		return filters.NewFailed(openai.NewErrorInternalError().WithCause(err))
+	}
+
+	return filters.NewOK()
+}
+
+// attemptState is the messages of a request before templated by the cluster
+// filters, and the upstream attempt the request was last templated for.
+type attemptState struct {
+	mutex    sync.Mutex
+	original []map[string]any
+	attempt  *metadata.UpstreamAttempt
+}
+
+// originals are the attempt states of the requests, shared by the clusters
+// so that the requests retried or fallen back to other clusters are
+// templated from the original messages, instead of the ones templated by
+// the previous attempts.
+var originals sync.Map
+
+func (t *Template) RequestModifier(ctx context.Context, _ *v1alpha1clusters.Cluster, request object.LLMRequest) (object.LLMRequest, error) {
+	chatRequest, ok := request.(*openai.ChatCompletionsRequest)
+	if !ok {
+		return request, nil
+	}
+
+	attempt := metadata.UpstreamAttemptFromCtx(ctx)
+	messages := chatRequest.GetMessages()
+
+	stored, loaded := originals.LoadOrStore(chatRequest, &attemptState{original: messages, attempt: attempt})
+	if !loaded && chatRequest.GetRawRequest() != nil {
+		context.AfterFunc(chatRequest.GetRawRequest().Context(), func() {
+			originals.Delete(chatRequest)
+		})
+	}
+
+	// The filters of the same attempt are applied one after another
+	state := stored.(*attemptState) //nolint:forcetypeassert
+
+	state.mutex.Lock()
+	if loaded && state.attempt != attempt {
+		state.attempt = attempt
+		messages = state.original
+	}
+	state.mutex.Unlock()
+
+	err := t.apply(ctx, chatRequest, messages)
+	if err != nil {
+		return request, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	return request, nil
+}
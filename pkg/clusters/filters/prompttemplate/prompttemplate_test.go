@@ -0,0 +1,226 @@
+package prompttemplate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	servicev1alpha1 "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/types/openai"
+)
+
+var now = time.Date(2025, 3, 1, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+
+func newTemplateFilter(t *testing.T, cfg *v1alpha1.PromptTemplateConfig) *Template {
+	t.Helper()
+
+	f, err := NewWithConfig(lo.Must(anypb.New(cfg)), bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+
+	tmpl, ok := f.(*Template)
+	require.True(t, ok)
+
+	return tmpl.WithNow(func() time.Time { return now })
+}
+
+// newRequest is the request of the user u-1 authenticated with key-1, to
+// the gpt-4o model.
+func newRequest(t *testing.T, body string) (context.Context, *openai.ChatCompletionsRequest) {
+	t.Helper()
+
+	ctx, request := gatewaytest.NewChatCompletionRequest(t, body)
+
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	rMeta.RequestModel = "gpt-4o"
+	rMeta.AuthInfo = &servicev1alpha1.APIKeyAuthResponse{UserId: "u-1", ApiKeyId: "key-1"}
+
+	return ctx, request
+}
+
+func TestNewWithConfig(t *testing.T) {
+	_, err := NewWithConfig(lo.Must(anypb.New(&v1alpha1.PromptTemplateConfig{Variables: map[string]string{"model": "x"}})), bootkit.NewEmptyLifeCycle())
+	require.EqualError(t, err, "variable model is built-in and can't be overridden")
+
+	_, err = NewRequestFilterWithConfig(lo.Must(anypb.New(&v1alpha1.PromptTemplateConfig{Variables: map[string]string{"team": "search"}})), bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+}
+
+func TestTemplate_Apply(t *testing.T) {
+	variables := map[string]string{"user_id": "u-1", "date": "2025-03-02"}
+
+	withSystem := []map[string]any{
+		{"role": "system", "content": "You are a pirate."},
+		{"role": "user", "content": "Hi"},
+	}
+	withoutSystem := []map[string]any{
+		{"role": "user", "content": "Hi"},
+	}
+
+	testCases := []struct {
+		name     string
+		mode     v1alpha1.PromptTemplateConfig_SystemPromptMode
+		messages []map[string]any
+		expected []map[string]any
+	}{
+		{
+			name:     "default without system messages",
+			messages: withoutSystem,
+			expected: []map[string]any{
+				{"role": "system", "content": "Today is 2025-03-02, the user is u-1."},
+				{"role": "user", "content": "Hi"},
+			},
+		},
+		{
+			name:     "default with system messages",
+			messages: withSystem,
+			expected: withSystem,
+		},
+		{
+			name:     "prepend",
+			mode:     v1alpha1.PromptTemplateConfig_SYSTEM_PROMPT_MODE_PREPEND,
+			messages: withSystem,
+			expected: []map[string]any{
+				{"role": "system", "content": "Today is 2025-03-02, the user is u-1."},
+				{"role": "system", "content": "You are a pirate."},
+				{"role": "user", "content": "Hi"},
+			},
+		},
+		{
+			name: "override",
+			mode: v1alpha1.PromptTemplateConfig_SYSTEM_PROMPT_MODE_OVERRIDE,
+			messages: []map[string]any{
+				{"role": "developer", "content": "You are a pirate."},
+				{"role": "user", "content": "Hi"},
+			},
+			expected: []map[string]any{
+				{"role": "system", "content": "Today is 2025-03-02, the user is u-1."},
+				{"role": "user", "content": "Hi"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := newTemplateFilter(t, &v1alpha1.PromptTemplateConfig{
+				SystemPrompt:     "Today is {{date}}, the user is {{user_id}}.",
+				SystemPromptMode: tc.mode,
+			})
+
+			assert.Equal(t, tc.expected, tmpl.Apply(tc.messages, variables))
+		})
+	}
+
+	// Prefix and suffix of the last user message
+	tmpl := newTemplateFilter(t, &v1alpha1.PromptTemplateConfig{
+		UserPrefix: "Answer in {{language}}.",
+		UserSuffix: "Be concise.",
+	})
+
+	messages := []map[string]any{
+		{"role": "user", "content": "Hi"},
+		{"role": "assistant", "content": "Hello!"},
+		{"role": "user", "content": []any{map[string]any{"type": "image_url", "image_url": map[string]any{"url": "https://example.com/a.png"}}}},
+	}
+
+	assert.Equal(t, []map[string]any{
+		{"role": "user", "content": "Hi"},
+		{"role": "assistant", "content": "Hello!"},
+		{"role": "user", "content": []any{
+			map[string]any{"type": "text", "text": "Answer in French."},
+			map[string]any{"type": "image_url", "image_url": map[string]any{"url": "https://example.com/a.png"}},
+			map[string]any{"type": "text", "text": "Be concise."},
+		}},
+	}, tmpl.Apply(messages, map[string]string{"language": "French"}))
+
+	// Never modified in place
+	assert.Len(t, messages[2]["content"], 1)
+
+	assert.Equal(t, []map[string]any{
+		{"role": "user", "content": "Answer in {{language}}.\nHi\nBe concise."},
+	}, tmpl.Apply(withoutSystem, nil))
+}
+
+func TestTemplate_Variables(t *testing.T) {
+	tmpl := newTemplateFilter(t, &v1alpha1.PromptTemplateConfig{Variables: map[string]string{"team": "search"}})
+
+	ctx, request := newRequest(t, `{"model": "gpt-4o-2024-08-06", "messages": []}`)
+	assert.Equal(t, map[string]string{
+		"team":       "search",
+		"user_id":    "u-1",
+		"api_key_id": "key-1",
+		"model":      "gpt-4o",
+		"date":       "2025-03-02",
+	}, tmpl.Variables(ctx, request))
+
+	tmpl = newTemplateFilter(t, &v1alpha1.PromptTemplateConfig{DateFormat: "January 2, 2006"})
+	assert.Equal(t, "March 2, 2025", tmpl.Variables(ctx, request)["date"])
+}
+
+func TestTemplate_OnCompletionRequest(t *testing.T) {
+	tmpl := newTemplateFilter(t, &v1alpha1.PromptTemplateConfig{SystemPrompt: "You serve {{model}}."})
+
+	ctx, request := newRequest(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	result := tmpl.OnCompletionRequest(ctx, request, request.GetRawRequest())
+	require.True(t, result.IsSSucceeded())
+
+	assert.Equal(t, []map[string]any{
+		{"role": "system", "content": "You serve gpt-4o."},
+		{"role": "user", "content": "Hi"},
+	}, request.GetMessages())
+}
+
+func TestTemplate_RequestModifier(t *testing.T) {
+	prepend := newTemplateFilter(t, &v1alpha1.PromptTemplateConfig{
+		SystemPrompt:     "Be helpful.",
+		SystemPromptMode: v1alpha1.PromptTemplateConfig_SYSTEM_PROMPT_MODE_PREPEND,
+	})
+	suffix := newTemplateFilter(t, &v1alpha1.PromptTemplateConfig{UserSuffix: "Be concise."})
+
+	ctx, request := newRequest(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "Hi"}]}`)
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+
+	// Both of the filters of the cluster are applied
+	attemptCtx := metadata.WithUpstreamAttempt(ctx, rMeta.NewUpstreamAttempt("a", "a"))
+
+	_, err := prepend.RequestModifier(attemptCtx, nil, request)
+	require.NoError(t, err)
+	_, err = suffix.RequestModifier(attemptCtx, nil, request)
+	require.NoError(t, err)
+
+	expected := []map[string]any{
+		{"role": "system", "content": "Be helpful."},
+		{"role": "user", "content": "Hi\nBe concise."},
+	}
+	assert.Equal(t, expected, request.GetMessages())
+
+	// Retried from the original messages
+	attemptCtx = metadata.WithUpstreamAttempt(ctx, rMeta.NewUpstreamAttempt("a", "a"))
+
+	_, err = prepend.RequestModifier(attemptCtx, nil, request)
+	require.NoError(t, err)
+	_, err = suffix.RequestModifier(attemptCtx, nil, request)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, request.GetMessages())
+
+	// Fallen back to the cluster without the suffix
+	attemptCtx = metadata.WithUpstreamAttempt(ctx, rMeta.NewUpstreamAttempt("b", "b"))
+
+	_, err = prepend.RequestModifier(attemptCtx, nil, request)
+	require.NoError(t, err)
+
+	assert.Equal(t, []map[string]any{
+		{"role": "system", "content": "Be helpful."},
+		{"role": "user", "content": "Hi"},
+	}, request.GetMessages())
+}
@@ -0,0 +1,235 @@
+package filters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/observation"
+)
+
+const (
+	StageRequestPreflight          = "request_preflight"
+	StageRequestModifier           = "request_modifier"
+	StageEndpointSelector          = "endpoint_selector"
+	StageUpstreamRequestMarshaller = "upstream_request_marshaller"
+	StageUpstreamExecutor          = "upstream_executor"
+	StageResponseUnmarshaller      = "response_unmarshaller"
+	StageResponseModifier          = "response_modifier"
+	StageResponseComplete          = "response_complete"
+)
+
+const (
+	invocationResultOK      = "ok"
+	invocationResultError   = "error"
+	invocationResultPanic   = "panic"
+	invocationResultTimeout = "timeout"
+)
+
+var (
+	ErrFilterPanicked = errors.New("cluster filter panicked")
+	ErrFilterTimeout  = errors.New("cluster filter timed out")
+)
+
+// FilterError attributes an error to the cluster filter and the stage that
+// caused it.
+type FilterError struct {
+	Filter string
+	Stage  string
+	Err    error
+}
+
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("cluster filter %s failed at %s: %v", e.Filter, e.Stage, e.Err)
+}
+
+func (e *FilterError) Unwrap() error {
+	return e.Err
+}
+
+type sandboxedFilter struct {
+	IsClusterFilter
+
+	filter  ClusterFilter
+	cluster string
+	name    string
+	timeout time.Duration
+}
+
+// Sandbox wraps the filter so that every invocation of it recovers from
+// panics, is bounded by timeout (0 means unlimited), and gets attributed to
+// the name of the filter in errors, logs and metrics.
+//
+// Filters can't be interrupted, when timed out, the invocation keeps running
+// in background and its result is discarded. Filters are still called with
+// the context of the request since the objects they return, such as streaming
+// responses, may outlive the invocation.
+func Sandbox(filter ClusterFilter, cluster string, name string, timeout time.Duration) ClusterFilter {
+	if s, ok := filter.(*sandboxedFilter); ok {
+		filter = s.filter
+	}
+
+	if name == "" {
+		name = filterName(filter)
+	}
+
+	return &sandboxedFilter{
+		filter:  filter,
+		cluster: cluster,
+		name:    name,
+		timeout: timeout,
+	}
+}
+
+// Unwrap returns the filter wrapped by Sandbox, or the filter itself if it's
+// not sandboxed.
+func Unwrap(filter ClusterFilter) ClusterFilter {
+	if s, ok := filter.(*sandboxedFilter); ok {
+		return s.filter
+	}
+
+	return filter
+}
+
+func sandboxOf(filter ClusterFilter) *sandboxedFilter {
+	if s, ok := filter.(*sandboxedFilter); ok {
+		return s
+	}
+
+	return &sandboxedFilter{
+		filter: filter,
+		name:   filterName(filter),
+	}
+}
+
+func filterName(filter ClusterFilter) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", filter), "*")
+}
+
+func typeAssertFrom[T ClusterFilter](c ClusterFilters) []T {
+	res := make([]T, 0, len(c))
+
+	for _, f := range c {
+		if t, ok := Unwrap(f).(T); ok {
+			res = append(res, t)
+		}
+	}
+
+	return res
+}
+
+type sandboxedInvocation[T ClusterFilter] struct {
+	sandbox *sandboxedFilter
+	filter  T
+}
+
+func sandboxedInvocationsOf[T ClusterFilter](c ClusterFilters) []sandboxedInvocation[T] {
+	res := make([]sandboxedInvocation[T], 0, len(c))
+
+	for _, f := range c {
+		s := sandboxOf(f)
+		if t, ok := s.filter.(T); ok {
+			res = append(res, sandboxedInvocation[T]{sandbox: s, filter: t})
+		}
+	}
+
+	return res
+}
+
+type invocationResult[R any] struct {
+	value R
+	err   error
+}
+
+func invoke[R any](ctx context.Context, s *sandboxedFilter, stage string, fn func() (R, error)) (R, error) {
+	var res invocationResult[R]
+
+	_, span := observation.Tracer().Start(ctx, observation.SpanNameClusterFilter, trace.WithAttributes(
+		observation.KnowayClusterName.AsAttribute().String(s.cluster),
+		observation.KnowayClusterFilterName.AsAttribute().String(s.name),
+		observation.KnowayClusterFilterStage.AsAttribute().String(stage),
+	))
+	defer span.End()
+
+	startAt := time.Now()
+
+	if s.timeout <= 0 {
+		res = call(ctx, s, stage, fn)
+	} else {
+		done := make(chan invocationResult[R], 1)
+
+		go func() {
+			done <- call(ctx, s, stage, fn)
+		}()
+
+		timer := time.NewTimer(s.timeout)
+		defer timer.Stop()
+
+		select {
+		case res = <-done:
+		case <-timer.C:
+			res.err = &FilterError{Filter: s.name, Stage: stage, Err: ErrFilterTimeout}
+
+			slog.ErrorContext(ctx, "cluster filter timed out",
+				slog.String("cluster", s.cluster),
+				slog.String("filter", s.name),
+				slog.String("stage", stage),
+				slog.Duration("timeout", s.timeout),
+			)
+		}
+	}
+
+	observation.RecordSpanError(span, res.err)
+	observation.ObserveClusterFilterInvocation(s.cluster, s.name, stage, invocationResultOf(res.err))
+	observation.ObserveClusterFilterDuration(metadata.RequestMetadataFromCtx(ctx), s.cluster, s.name, stage, time.Since(startAt))
+
+	return res.value, res.err
+}
+
+func call[R any](ctx context.Context, s *sandboxedFilter, stage string, fn func() (R, error)) (res invocationResult[R]) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		res = invocationResult[R]{err: &FilterError{Filter: s.name, Stage: stage, Err: fmt.Errorf("%w: %v", ErrFilterPanicked, r)}}
+
+		slog.ErrorContext(ctx, "cluster filter panicked",
+			slog.String("cluster", s.cluster),
+			slog.String("filter", s.name),
+			slog.String("stage", stage),
+			slog.Any("panic", r),
+			slog.String("stack", string(debug.Stack())),
+		)
+	}()
+
+	value, err := fn()
+	if err != nil && !object.IsLLMError(err) {
+		// LLM errors are meant to be returned to clients as is
+		err = &FilterError{Filter: s.name, Stage: stage, Err: err}
+	}
+
+	return invocationResult[R]{value: value, err: err}
+}
+
+func invocationResultOf(err error) string {
+	switch {
+	case err == nil:
+		return invocationResultOK
+	case errors.Is(err, ErrFilterPanicked):
+		return invocationResultPanic
+	case errors.Is(err, ErrFilterTimeout):
+		return invocationResultTimeout
+	default:
+		return invocationResultError
+	}
+}
@@ -0,0 +1,118 @@
+package filters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/object"
+)
+
+type fakeRequestModifier struct {
+	IsClusterFilter
+
+	modify func(request object.LLMRequest) (object.LLMRequest, error)
+}
+
+func (f *fakeRequestModifier) RequestModifier(ctx context.Context, cluster *v1alpha1.Cluster, request object.LLMRequest) (object.LLMRequest, error) {
+	return f.modify(request)
+}
+
+type fakeResponseCompleter struct {
+	IsClusterFilter
+
+	complete func() error
+}
+
+func (f *fakeResponseCompleter) ResponseComplete(ctx context.Context, request object.LLMRequest, response object.LLMResponse) error {
+	return f.complete()
+}
+
+func TestSandbox(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ok", func(t *testing.T) {
+		c := ClusterFilters{
+			Sandbox(&fakeRequestModifier{modify: func(request object.LLMRequest) (object.LLMRequest, error) {
+				return request, nil
+			}}, "cluster", "ok", time.Second),
+		}
+
+		require.Len(t, c.RequestModifiers(), 1)
+		require.Empty(t, c.ResponseCompleters())
+
+		_, err := c.ForEachRequestModifier(ctx, nil, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		c := ClusterFilters{
+			Sandbox(&fakeRequestModifier{modify: func(request object.LLMRequest) (object.LLMRequest, error) {
+				panic("boom")
+			}}, "cluster", "panicking", 0),
+		}
+
+		_, err := c.ForEachRequestModifier(ctx, nil, nil)
+		require.ErrorIs(t, err, ErrFilterPanicked)
+
+		var filterErr *FilterError
+		require.ErrorAs(t, err, &filterErr)
+		assert.Equal(t, "panicking", filterErr.Filter)
+		assert.Equal(t, StageRequestModifier, filterErr.Stage)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+
+		c := ClusterFilters{
+			Sandbox(&fakeResponseCompleter{complete: func() error {
+				<-block
+				return nil
+			}}, "cluster", "hanging", 10*time.Millisecond),
+		}
+
+		err := c.ForEachResponseComplete(ctx, nil, nil)
+		require.ErrorIs(t, err, ErrFilterTimeout)
+
+		var filterErr *FilterError
+		require.ErrorAs(t, err, &filterErr)
+		assert.Equal(t, "hanging", filterErr.Filter)
+		assert.Equal(t, StageResponseComplete, filterErr.Stage)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		expected := errors.New("failed")
+
+		c := ClusterFilters{
+			&fakeResponseCompleter{complete: func() error {
+				return expected
+			}},
+		}
+
+		err := c.ForEachResponseComplete(ctx, nil, nil)
+		require.ErrorIs(t, err, expected)
+
+		var filterErr *FilterError
+		require.ErrorAs(t, err, &filterErr)
+		assert.Equal(t, "filters.fakeResponseCompleter", filterErr.Filter)
+	})
+
+	t.Run("llm errors are kept as is", func(t *testing.T) {
+		expected := object.NewErrorMissingModel()
+
+		c := ClusterFilters{
+			Sandbox(&fakeResponseCompleter{complete: func() error {
+				return expected
+			}}, "cluster", "", 0),
+		}
+
+		err := c.ForEachResponseComplete(ctx, nil, nil)
+		assert.Same(t, expected, err)
+	})
+}
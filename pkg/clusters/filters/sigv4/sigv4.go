@@ -0,0 +1,98 @@
+package sigv4
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	clusterfilters "knoway.dev/pkg/clusters/filters"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/types/aws/sigv4"
+	"knoway.dev/pkg/types/openai"
+)
+
+const (
+	defaultService = "bedrock"
+)
+
+func NewWithConfig(cfg *anypb.Any, _ bootkit.LifeCycle) (clusterfilters.ClusterFilter, error) {
+	c, err := protoutils.FromAny(cfg, &v1alpha1.AWSSigV4Config{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	return &signer{
+		cfg: c,
+		now: time.Now,
+	}, nil
+}
+
+var _ clusterfilters.ClusterFilterUpstreamRequestMarshaller = (*signer)(nil)
+
+// signer signs the requests built by the OpenAI request handler with AWS
+// Signature Version 4, it must run after all filters that modify the
+// requests.
+type signer struct {
+	clusterfilters.IsClusterFilter
+
+	cfg *v1alpha1.AWSSigV4Config
+	now func() time.Time
+}
+
+func (f *signer) credentials() sigv4.Credentials {
+	if f.cfg.GetAccessKeyId() != "" {
+		return sigv4.Credentials{
+			AccessKeyID:     f.cfg.GetAccessKeyId(),
+			SecretAccessKey: f.cfg.GetSecretAccessKey(),
+			SessionToken:    f.cfg.GetSessionToken(),
+		}
+	}
+
+	return sigv4.Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+func (f *signer) MarshalUpstreamRequest(ctx context.Context, cluster *v1alpha1clusters.Cluster, llmRequest object.LLMRequest, request *http.Request) (*http.Request, error) {
+	if request == nil {
+		return nil, openai.NewErrorInternalError().WithMessage("aws sigv4 filter requires the request built by the openai request handler")
+	}
+
+	var body []byte
+
+	if request.Body != nil {
+		var err error
+
+		body, err = io.ReadAll(request.Body)
+		if err != nil {
+			return nil, openai.NewErrorInternalError().WithCause(err)
+		}
+
+		_ = request.Body.Close()
+		request.Body = io.NopCloser(bytes.NewReader(body))
+		request.ContentLength = int64(len(body))
+	}
+
+	region := lo.CoalesceOrEmpty(f.cfg.GetRegion(), sigv4.RegionFromHost(lo.CoalesceOrEmpty(request.Host, request.URL.Host)))
+	service := lo.CoalesceOrEmpty(f.cfg.GetService(), defaultService)
+
+	err := sigv4.Sign(request, body, f.credentials(), region, service, f.now())
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	return request, nil
+}
@@ -0,0 +1,75 @@
+package sigv4
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+)
+
+func TestMarshalUpstreamRequest(t *testing.T) {
+	pb, err := anypb.New(&v1alpha1.AWSSigV4Config{
+		AccessKeyId:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	})
+	require.NoError(t, err)
+
+	f, err := NewWithConfig(pb, nil)
+	require.NoError(t, err)
+
+	s, ok := f.(*signer)
+	require.True(t, ok)
+
+	s.now = func() time.Time {
+		return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+
+	body := `{"messages":[{"role":"user","content":[{"text":"Hi"}]}]}`
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://bedrock-runtime.us-west-2.amazonaws.com/model/amazon.nova-lite-v1%3A0/converse", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer sk-downstream")
+
+	request, err = s.MarshalUpstreamRequest(context.Background(), &v1alpha1clusters.Cluster{}, nil, request)
+	require.NoError(t, err)
+
+	authorization := request.Header.Get("Authorization")
+	assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240102/us-west-2/bedrock/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature="))
+	assert.Equal(t, "20240102T030405Z", request.Header.Get("X-Amz-Date"))
+
+	// The body is still readable after signing
+	readBody, err := io.ReadAll(request.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(readBody))
+}
+
+func TestMarshalUpstreamRequestMissingCredentials(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	pb, err := anypb.New(&v1alpha1.AWSSigV4Config{})
+	require.NoError(t, err)
+
+	f, err := NewWithConfig(pb, nil)
+	require.NoError(t, err)
+
+	s, ok := f.(*signer)
+	require.True(t, ok)
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://bedrock-runtime.us-west-2.amazonaws.com/model/m/converse", bytes.NewBufferString(`{}`))
+	require.NoError(t, err)
+
+	_, err = s.MarshalUpstreamRequest(context.Background(), &v1alpha1clusters.Cluster{}, nil, request)
+	require.Error(t, err)
+}
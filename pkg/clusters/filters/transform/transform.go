@@ -0,0 +1,290 @@
+// Package transform transforms the bodies of the upstream requests and of the
+// responses of clusters with JSON Patch operations and CEL expressions, for
+// the quirks of providers which are not worth new filters.
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/google/cel-go/cel"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	clusterfilters "knoway.dev/pkg/clusters/filters"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/types/openai"
+)
+
+const (
+	// VariableBody is the body transformed.
+	VariableBody = "body"
+	// VariableRequest is the body of the request of the client, before it's
+	// translated for the provider.
+	VariableRequest = "request"
+)
+
+var structValueType = reflect.TypeOf(&structpb.Value{})
+
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable(VariableBody, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(VariableRequest, cel.MapType(cel.StringType, cel.DynType)),
+	)
+}
+
+type assignment struct {
+	path    string
+	program cel.Program
+}
+
+// bodyTransform applies the JSON Patch operations, and then the CEL
+// assignments in order, each of which sees the body assigned by the previous
+// ones.
+type bodyTransform struct {
+	patch       jsonpatch.Patch
+	assignments []assignment
+}
+
+func newBodyTransform(env *cel.Env, t *v1alpha1.BodyTransform) (*bodyTransform, error) {
+	if t == nil || (len(t.GetJsonPatch()) == 0 && len(t.GetCel()) == 0) {
+		return nil, nil //nolint:nilnil
+	}
+
+	res := new(bodyTransform)
+
+	if len(t.GetJsonPatch()) > 0 {
+		operations := lo.Map(t.GetJsonPatch(), func(op *v1alpha1.BodyTransform_JSONPatchOperation, _ int) map[string]any {
+			operation := map[string]any{"op": op.GetOp(), "path": op.GetPath()}
+			if op.GetFrom() != "" {
+				operation["from"] = op.GetFrom()
+			}
+
+			if op.GetValue() != nil {
+				operation["value"] = op.GetValue().AsInterface()
+			}
+
+			return operation
+		})
+
+		patch, err := jsonpatch.DecodePatch(lo.Must(json.Marshal(operations)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid json patch: %w", err)
+		}
+
+		res.patch = patch
+	}
+
+	for _, a := range t.GetCel() {
+		if a.GetPath() == "" {
+			return nil, errors.New("path of cel assignment cannot be empty")
+		}
+
+		ast, issues := env.Compile(a.GetExpression())
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("invalid cel expression of %s: %w", a.GetPath(), issues.Err())
+		}
+
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cel expression of %s: %w", a.GetPath(), err)
+		}
+
+		res.assignments = append(res.assignments, assignment{path: a.GetPath(), program: program})
+	}
+
+	return res, nil
+}
+
+func applyOptions() *jsonpatch.ApplyOptions {
+	options := jsonpatch.NewApplyOptions()
+	options.EnsurePathExistsOnAdd = true
+	options.AllowMissingPathOnRemove = true
+
+	return options
+}
+
+// Apply returns the body transformed, request is the body of the request of
+// the client.
+func (t *bodyTransform) Apply(body []byte, request map[string]any) ([]byte, error) {
+	var err error
+
+	if t.patch != nil {
+		body, err = t.patch.ApplyWithOptions(body, applyOptions())
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply json patch: %w", err)
+		}
+	}
+
+	for _, a := range t.assignments {
+		var parsed map[string]any
+
+		err = json.Unmarshal(body, &parsed)
+		if err != nil {
+			return nil, err
+		}
+
+		out, _, err := a.program.Eval(map[string]any{
+			VariableBody:    parsed,
+			VariableRequest: request,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate cel expression of %s: %w", a.path, err)
+		}
+
+		native, err := out.ConvertToNative(structValueType)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported result of cel expression of %s: %w", a.path, err)
+		}
+
+		value, _ := native.(*structpb.Value)
+
+		operation := map[string]any{"op": "remove", "path": a.path}
+		if _, isNull := value.GetKind().(*structpb.Value_NullValue); !isNull {
+			operation = map[string]any{"op": "add", "path": a.path, "value": value.AsInterface()}
+		}
+
+		patch, err := jsonpatch.DecodePatch(lo.Must(json.Marshal([]any{operation})))
+		if err != nil {
+			return nil, err
+		}
+
+		body, err = patch.ApplyWithOptions(body, applyOptions())
+		if err != nil {
+			return nil, fmt.Errorf("failed to assign %s: %w", a.path, err)
+		}
+	}
+
+	return body, nil
+}
+
+func NewWithConfig(cfg *anypb.Any, _ bootkit.LifeCycle) (clusterfilters.ClusterFilter, error) {
+	c, err := protoutils.FromAny(cfg, &v1alpha1.BodyTransformConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := newBodyTransform(env, c.GetRequest())
+	if err != nil {
+		return nil, fmt.Errorf("invalid request transform: %w", err)
+	}
+
+	response, err := newBodyTransform(env, c.GetResponse())
+	if err != nil {
+		return nil, fmt.Errorf("invalid response transform: %w", err)
+	}
+
+	return &transformer{
+		request:  request,
+		response: response,
+	}, nil
+}
+
+var _ clusterfilters.ClusterFilterUpstreamRequestMarshaller = (*transformer)(nil)
+var _ clusterfilters.ClusterFilterResponseModifier = (*transformer)(nil)
+
+type transformer struct {
+	clusterfilters.IsClusterFilter
+
+	request  *bodyTransform
+	response *bodyTransform
+}
+
+type bodyParsed interface {
+	GetBodyParsed() map[string]any
+}
+
+func requestBodyOf(request object.LLMRequest) map[string]any {
+	if parsed, ok := request.(bodyParsed); ok && parsed.GetBodyParsed() != nil {
+		return parsed.GetBodyParsed()
+	}
+
+	return map[string]any{}
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/json"
+}
+
+// MarshalUpstreamRequest transforms the body of the request built by the
+// OpenAI request handler, requests of other than JSON bodies, such as the
+// ones of audio files, are left as is.
+func (f *transformer) MarshalUpstreamRequest(ctx context.Context, cluster *v1alpha1clusters.Cluster, llmRequest object.LLMRequest, request *http.Request) (*http.Request, error) {
+	if f.request == nil || request == nil || request.Body == nil || !isJSON(request.Header.Get("Content-Type")) {
+		return request, nil
+	}
+
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	_ = request.Body.Close()
+
+	body, err = f.request.Apply(body, requestBodyOf(llmRequest))
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(fmt.Errorf("failed to transform request body: %w", err))
+	}
+
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	request.ContentLength = int64(len(body))
+	request.Header.Del("Content-Length")
+
+	return request, nil
+}
+
+type bodySetter interface {
+	SetBody(body []byte) error
+}
+
+// ResponseModifier transforms the bodies of the non-streaming responses,
+// error responses are left as is.
+func (f *transformer) ResponseModifier(ctx context.Context, cluster *v1alpha1clusters.Cluster, request object.LLMRequest, response object.LLMResponse) (object.LLMResponse, error) {
+	if f.response == nil || lo.IsNil(response) || response.IsStream() || !lo.IsNil(response.GetError()) {
+		return response, nil
+	}
+
+	setter, ok := response.(bodySetter)
+	if !ok {
+		return response, nil
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	body, err = f.response.Apply(body, requestBodyOf(request))
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(fmt.Errorf("failed to transform response body: %w", err))
+	}
+
+	err = setter.SetBody(body)
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,141 @@
+package transform
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/types/openai"
+)
+
+func newTransformer(t *testing.T, cfg *v1alpha1.BodyTransformConfig) *transformer {
+	t.Helper()
+
+	f, err := NewWithConfig(lo.Must(anypb.New(cfg)), nil)
+	require.NoError(t, err)
+
+	tf, ok := f.(*transformer)
+	require.True(t, ok)
+
+	return tf
+}
+
+func newChatCompletionsRequest(t *testing.T, body string) *openai.ChatCompletionsRequest {
+	t.Helper()
+
+	httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com/v1/chat/completions", bytes.NewBufferString(body))
+	require.NoError(t, err)
+
+	request, err := openai.NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	return request
+}
+
+func TestNewWithConfig_Invalid(t *testing.T) {
+	for _, cfg := range []*v1alpha1.BodyTransformConfig{
+		{Request: &v1alpha1.BodyTransform{JsonPatch: []*v1alpha1.BodyTransform_JSONPatchOperation{{Op: "merge", Path: "/a"}}}},
+		{Request: &v1alpha1.BodyTransform{Cel: []*v1alpha1.BodyTransform_CELAssignment{{Path: "/a", Expression: "body."}}}},
+		{Response: &v1alpha1.BodyTransform{Cel: []*v1alpha1.BodyTransform_CELAssignment{{Expression: "1"}}}},
+	} {
+		_, err := NewWithConfig(lo.Must(anypb.New(cfg)), nil)
+		require.Error(t, err)
+	}
+}
+
+func TestTransformer_MarshalUpstreamRequest(t *testing.T) {
+	f := newTransformer(t, &v1alpha1.BodyTransformConfig{
+		Request: &v1alpha1.BodyTransform{
+			JsonPatch: []*v1alpha1.BodyTransform_JSONPatchOperation{
+				{Op: "move", From: "/max_tokens", Path: "/max_completion_tokens"},
+				{Op: "remove", Path: "/user"},
+				{Op: "add", Path: "/extra_body/safe_mode", Value: structpb.NewBoolValue(true)},
+			},
+			Cel: []*v1alpha1.BodyTransform_CELAssignment{
+				{Path: "/temperature", Expression: "has(body.temperature) ? dyn(body.temperature / 2.0) : null"},
+				{Path: "/metadata/model", Expression: "request.model"},
+			},
+		},
+	})
+
+	body := `{"model": "o1", "max_tokens": 100, "temperature": 1.0, "messages": []}`
+	llmRequest := newChatCompletionsRequest(t, body)
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://api.openai.com/v1/chat/completions", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+
+	request, err = f.MarshalUpstreamRequest(context.Background(), &v1alpha1clusters.Cluster{}, llmRequest, request)
+	require.NoError(t, err)
+
+	transformed, err := io.ReadAll(request.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"model": "o1",
+		"max_completion_tokens": 100,
+		"temperature": 0.5,
+		"messages": [],
+		"extra_body": {"safe_mode": true},
+		"metadata": {"model": "o1"}
+	}`, string(transformed))
+	assert.Equal(t, int64(len(transformed)), request.ContentLength)
+
+	// Assigned null removes the field
+	request, err = http.NewRequestWithContext(context.Background(), http.MethodPost, "https://api.openai.com/v1/chat/completions", bytes.NewBufferString(`{"model": "o1", "max_tokens": 100, "messages": []}`))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+
+	request, err = f.MarshalUpstreamRequest(context.Background(), &v1alpha1clusters.Cluster{}, llmRequest, request)
+	require.NoError(t, err)
+
+	transformed, err = io.ReadAll(request.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"model": "o1", "max_completion_tokens": 100, "messages": [], "extra_body": {"safe_mode": true}, "metadata": {"model": "o1"}}`, string(transformed))
+
+	// Bodies other than JSON are left as is
+	request, err = http.NewRequestWithContext(context.Background(), http.MethodPost, "https://api.openai.com/v1/audio/transcriptions", bytes.NewBufferString("audio"))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+
+	request, err = f.MarshalUpstreamRequest(context.Background(), &v1alpha1clusters.Cluster{}, llmRequest, request)
+	require.NoError(t, err)
+
+	transformed, err = io.ReadAll(request.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "audio", string(transformed))
+}
+
+func TestTransformer_ResponseModifier(t *testing.T) {
+	f := newTransformer(t, &v1alpha1.BodyTransformConfig{
+		Response: &v1alpha1.BodyTransform{
+			Cel: []*v1alpha1.BodyTransform_CELAssignment{
+				{Path: "/usage/total_tokens", Expression: "body.usage.prompt_tokens + body.usage.completion_tokens"},
+			},
+		},
+	})
+
+	llmRequest := newChatCompletionsRequest(t, `{"model": "gpt-4o", "messages": []}`)
+	rawResponse := &http.Response{StatusCode: http.StatusOK}
+	reader := bytes.NewBufferString(`{"model": "gpt-4o", "choices": [], "usage": {"prompt_tokens": 10, "completion_tokens": 5, "total_tokens": 0}}`)
+
+	response, err := openai.NewChatCompletionResponse(llmRequest, rawResponse, bufio.NewReader(reader))
+	require.NoError(t, err)
+
+	modified, err := f.ResponseModifier(context.Background(), &v1alpha1clusters.Cluster{}, llmRequest, response)
+	require.NoError(t, err)
+
+	usage, ok := modified.GetUsage().(*openai.ChatCompletionsUsage)
+	require.True(t, ok)
+	assert.Equal(t, uint64(15), usage.GetTotalTokens())
+}
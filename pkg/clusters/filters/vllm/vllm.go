@@ -0,0 +1,299 @@
+// Package vllm picks the least loaded of the endpoints of clusters served by
+// vLLM, by the metrics scraped from the endpoints.
+package vllm
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/clusters/connection"
+	clusterfilters "knoway.dev/pkg/clusters/filters"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/protoutils"
+)
+
+const (
+	defaultScrapeInterval = time.Second
+	defaultScrapeTimeout  = time.Second
+	defaultMetricsPath    = "/metrics"
+
+	// Metrics older than staleIntervals scrape intervals are not trusted,
+	// e.g. of endpoints unreachable since.
+	staleIntervals = 3
+
+	maxMetricsSize = 4 << 20
+
+	metricRequestsWaiting = "vllm:num_requests_waiting"
+	metricRequestsRunning = "vllm:num_requests_running"
+	metricKVCacheUsage    = "vllm:kv_cache_usage_perc"
+	// Deprecated by vLLM in favor of metricKVCacheUsage
+	metricGPUCacheUsage = "vllm:gpu_cache_usage_perc"
+)
+
+// load is the load of an endpoint reported by its metrics.
+type load struct {
+	requestsWaiting float64
+	requestsRunning float64
+	kvCacheUsage    float64
+}
+
+// less reports whether l is less loaded than other.
+func (l load) less(other load) bool {
+	if l.requestsWaiting != other.requestsWaiting {
+		return l.requestsWaiting < other.requestsWaiting
+	}
+
+	if l.kvCacheUsage != other.kvCacheUsage {
+		return l.kvCacheUsage < other.kvCacheUsage
+	}
+
+	return l.requestsRunning < other.requestsRunning
+}
+
+// endpointState is the load of an endpoint, shared by the clusters sending
+// requests to the same endpoint and kept across the registrations of the
+// clusters, since the load of the endpoint is regardless of the cluster.
+type endpointState struct {
+	mutex     sync.Mutex
+	load      load
+	scrapedAt time.Time
+	scraping  bool
+	// dispatched is the number of requests sent to the endpoint since it was
+	// last scraped
+	dispatched int
+}
+
+// endpointStates holds the states of the endpoints by the URLs of their
+// metrics.
+var endpointStates sync.Map
+
+func stateOf(metricsURL string) *endpointState {
+	state, _ := endpointStates.LoadOrStore(metricsURL, &endpointState{})
+
+	return state.(*endpointState)
+}
+
+func NewWithConfig(cfg *anypb.Any, _ bootkit.LifeCycle) (clusterfilters.ClusterFilter, error) {
+	c, err := protoutils.FromAny(cfg, &v1alpha1.VLLMEndpointSelectorConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	metricsPath := lo.CoalesceOrEmpty(c.GetMetricsPath(), defaultMetricsPath)
+	if !strings.HasPrefix(metricsPath, "/") {
+		return nil, fmt.Errorf("metrics path %q of vllm endpoint selector must start with /", metricsPath)
+	}
+
+	return &selector{
+		scrapeInterval: lo.CoalesceOrEmpty(c.GetScrapeInterval().AsDuration(), defaultScrapeInterval),
+		scrapeTimeout:  lo.CoalesceOrEmpty(c.GetScrapeTimeout().AsDuration(), defaultScrapeTimeout),
+		metricsPath:    metricsPath,
+		client:         connection.Client,
+		now:            time.Now,
+	}, nil
+}
+
+var _ clusterfilters.ClusterFilterEndpointSelector = (*selector)(nil)
+
+type selector struct {
+	clusterfilters.IsClusterFilter
+
+	scrapeInterval time.Duration
+	scrapeTimeout  time.Duration
+	metricsPath    string
+	// client returns the client of the cluster, metrics are scraped through
+	// the connections of the cluster the same way as requests
+	client func(cluster *v1alpha1clusters.Cluster) *http.Client
+	now    func() time.Time
+}
+
+// SelectEndpoint returns the least loaded of the endpoints with metrics
+// scraped recently, empty if none of them has. The metrics older than the
+// scrape interval are scraped again in the background, never delaying the
+// request.
+func (f *selector) SelectEndpoint(ctx context.Context, cluster *v1alpha1clusters.Cluster, request object.LLMRequest, endpoints []string) string {
+	now := f.now()
+	client := f.client(cluster)
+
+	var (
+		selected      string
+		selectedState *endpointState
+		selectedLoad  load
+	)
+
+	for _, endpoint := range endpoints {
+		metricsURL := strings.TrimSuffix(endpoint, "/") + f.metricsPath
+		state := stateOf(metricsURL)
+
+		state.mutex.Lock()
+
+		if !state.scraping && now.Sub(state.scrapedAt) >= f.scrapeInterval {
+			state.scraping = true
+			go f.scrape(client, metricsURL, state)
+		}
+
+		fresh := !state.scrapedAt.IsZero() && now.Sub(state.scrapedAt) < staleIntervals*f.scrapeInterval
+
+		l := state.load
+		l.requestsWaiting += float64(state.dispatched)
+
+		state.mutex.Unlock()
+
+		if !fresh {
+			continue
+		}
+
+		if selectedState == nil || l.less(selectedLoad) {
+			selected, selectedState, selectedLoad = endpoint, state, l
+		}
+	}
+
+	if selectedState == nil {
+		return ""
+	}
+
+	selectedState.mutex.Lock()
+	selectedState.dispatched++
+	selectedState.mutex.Unlock()
+
+	slog.DebugContext(ctx, "selected least loaded vllm endpoint",
+		slog.String("endpoint", selected),
+		slog.Float64("requests_waiting", selectedLoad.requestsWaiting),
+		slog.Float64("kv_cache_usage", selectedLoad.kvCacheUsage),
+	)
+
+	return selected
+}
+
+// scrape updates the load of the endpoint from its metrics, the load scraped
+// before is kept if it fails, until it gets stale.
+func (f *selector) scrape(client *http.Client, metricsURL string, state *endpointState) {
+	ctx, cancel := context.WithTimeout(context.Background(), f.scrapeTimeout)
+	defer cancel()
+
+	l, err := f.fetch(ctx, client, metricsURL)
+
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	state.scraping = false
+
+	if err != nil {
+		slog.Debug("failed to scrape vllm metrics", slog.String("url", metricsURL), slog.Any("error", err))
+		return
+	}
+
+	state.load = l
+	state.scrapedAt = f.now()
+	state.dispatched = 0
+}
+
+func (f *selector) fetch(ctx context.Context, client *http.Client, metricsURL string) (load, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metricsURL, nil)
+	if err != nil {
+		return load{}, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return load{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return load{}, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return parseLoad(io.LimitReader(resp.Body, maxMetricsSize))
+}
+
+// parseLoad parses the load from the metrics in the Prometheus text format.
+// The requests of the series of the same metric are summed, e.g. of the
+// engines of data parallel deployments, while the highest KV cache usage
+// among them is taken.
+func parseLoad(r io.Reader) (load, error) {
+	var (
+		l             load
+		kvCacheUsage  = -1.0
+		gpuCacheUsage = -1.0
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMetricsSize)
+
+	for scanner.Scan() {
+		name, value, ok := parseSample(scanner.Text())
+		if !ok {
+			continue
+		}
+
+		switch name {
+		case metricRequestsWaiting:
+			l.requestsWaiting += value
+		case metricRequestsRunning:
+			l.requestsRunning += value
+		case metricKVCacheUsage:
+			kvCacheUsage = max(kvCacheUsage, value)
+		case metricGPUCacheUsage:
+			gpuCacheUsage = max(gpuCacheUsage, value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return load{}, err
+	}
+
+	l.kvCacheUsage = max(lo.Ternary(kvCacheUsage >= 0, kvCacheUsage, gpuCacheUsage), 0)
+
+	return l, nil
+}
+
+// parseSample parses the name and the value of a sample line, false for
+// comments, blank lines and malformed samples.
+func parseSample(line string) (string, float64, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", 0, false
+	}
+
+	var name, rest string
+
+	if i := strings.IndexByte(line, '{'); i >= 0 {
+		end := strings.LastIndexByte(line, '}')
+		if end < i {
+			return "", 0, false
+		}
+
+		name, rest = line[:i], line[end+1:]
+	} else {
+		name, rest, _ = strings.Cut(line, " ")
+	}
+
+	// The value may be followed by a timestamp
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return "", 0, false
+	}
+
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil || math.IsNaN(value) {
+		return "", 0, false
+	}
+
+	return strings.TrimSpace(name), value, true
+}
@@ -0,0 +1,159 @@
+package vllm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	v1alpha1clusters "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+)
+
+var qwen3Cluster = &v1alpha1clusters.Cluster{Name: "qwen3", Upstream: &v1alpha1clusters.Upstream{Url: "http://qwen3.default.svc.cluster.local:8000/v1"}}
+
+func newSelector(t *testing.T, cfg *v1alpha1.VLLMEndpointSelectorConfig) *selector {
+	t.Helper()
+
+	pb, err := anypb.New(cfg)
+	require.NoError(t, err)
+
+	f, err := NewWithConfig(pb, nil)
+	require.NoError(t, err)
+
+	s, ok := f.(*selector)
+	require.True(t, ok)
+
+	return s
+}
+
+func newMetricsServer(t *testing.T, metrics string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = fmt.Fprint(w, metrics)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestNewWithConfig(t *testing.T) {
+	pb, err := anypb.New(&v1alpha1.VLLMEndpointSelectorConfig{MetricsPath: "metrics"})
+	require.NoError(t, err)
+
+	_, err = NewWithConfig(pb, nil)
+	require.Error(t, err)
+
+	s := newSelector(t, &v1alpha1.VLLMEndpointSelectorConfig{})
+	assert.Equal(t, defaultScrapeInterval, s.scrapeInterval)
+	assert.Equal(t, defaultScrapeTimeout, s.scrapeTimeout)
+	assert.Equal(t, defaultMetricsPath, s.metricsPath)
+}
+
+func TestParseLoad(t *testing.T) {
+	t.Run("engines", func(t *testing.T) {
+		l, err := parseLoad(strings.NewReader(`# HELP vllm:num_requests_waiting Number of requests waiting to be processed.
+# TYPE vllm:num_requests_waiting gauge
+vllm:num_requests_waiting{engine="0",model_name="Qwen/Qwen3-8B"} 2.0
+vllm:num_requests_waiting{engine="1",model_name="Qwen/Qwen3-8B"} 1.0
+# TYPE vllm:num_requests_running gauge
+vllm:num_requests_running{engine="0",model_name="Qwen/Qwen3-8B"} 8.0 1760000000000
+vllm:num_requests_running{engine="1",model_name="Qwen/Qwen3-8B"} 6.0
+# TYPE vllm:kv_cache_usage_perc gauge
+vllm:kv_cache_usage_perc{engine="0",model_name="Qwen/Qwen3-8B"} 0.42
+vllm:kv_cache_usage_perc{engine="1",model_name="Qwen/Qwen3-8B"} 0.61
+vllm:gpu_cache_usage_perc{engine="0",model_name="Qwen/Qwen3-8B"} 0.99
+vllm:prompt_tokens_total{model_name="Qwen/Qwen3-8B"} 12345
+process_open_fds 42
+malformed{
+`))
+		require.NoError(t, err)
+
+		assert.Equal(t, load{requestsWaiting: 3, requestsRunning: 14, kvCacheUsage: 0.61}, l)
+	})
+
+	t.Run("gpu cache usage of older versions", func(t *testing.T) {
+		l, err := parseLoad(strings.NewReader(`vllm:num_requests_waiting{model_name="llama"} 0
+vllm:gpu_cache_usage_perc{model_name="llama"} 0.25
+`))
+		require.NoError(t, err)
+
+		assert.Equal(t, load{kvCacheUsage: 0.25}, l)
+	})
+
+	t.Run("not vllm", func(t *testing.T) {
+		l, err := parseLoad(strings.NewReader("go_goroutines 12\n"))
+		require.NoError(t, err)
+
+		assert.Equal(t, load{}, l)
+	})
+}
+
+func TestSelector_SelectEndpoint(t *testing.T) {
+	busy := newMetricsServer(t, `vllm:num_requests_waiting{model_name="qwen3"} 3
+vllm:num_requests_running{model_name="qwen3"} 16
+vllm:kv_cache_usage_perc{model_name="qwen3"} 0.1
+`)
+	idle := newMetricsServer(t, `vllm:num_requests_waiting{model_name="qwen3"} 0
+vllm:num_requests_running{model_name="qwen3"} 4
+vllm:kv_cache_usage_perc{model_name="qwen3"} 0.5
+`)
+
+	// Scraped once throughout the test
+	s := newSelector(t, &v1alpha1.VLLMEndpointSelectorConfig{ScrapeInterval: durationpb.New(time.Minute)})
+	endpoints := []string{busy.URL, idle.URL}
+
+	var selected string
+
+	require.Eventually(t, func() bool {
+		selected = s.SelectEndpoint(context.Background(), qwen3Cluster, nil, endpoints)
+		return selected != ""
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, idle.URL, selected)
+
+	// Requests sent since the scrape are counted as waiting
+	assert.Equal(t, idle.URL, s.SelectEndpoint(context.Background(), qwen3Cluster, nil, endpoints))
+	assert.Equal(t, idle.URL, s.SelectEndpoint(context.Background(), qwen3Cluster, nil, endpoints))
+	// Waiting ties, the KV cache usage of the busy one is lower
+	assert.Equal(t, busy.URL, s.SelectEndpoint(context.Background(), qwen3Cluster, nil, endpoints))
+}
+
+func TestSelector_SelectEndpoint_Unavailable(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	s := newSelector(t, &v1alpha1.VLLMEndpointSelectorConfig{ScrapeInterval: durationpb.New(10 * time.Millisecond)})
+
+	// Nothing scraped, requests are sent to the endpoints in turn by the
+	// cluster
+	for range 5 {
+		assert.Empty(t, s.SelectEndpoint(context.Background(), qwen3Cluster, nil, []string{failing.URL}))
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	// Stale metrics are not trusted
+	state := stateOf(failing.URL + defaultMetricsPath)
+
+	state.mutex.Lock()
+	state.scrapedAt = time.Now().Add(-time.Second)
+	state.mutex.Unlock()
+
+	assert.Empty(t, s.SelectEndpoint(context.Background(), qwen3Cluster, nil, []string{failing.URL}))
+}
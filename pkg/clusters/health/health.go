@@ -0,0 +1,190 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/proto"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/clusters/upstream"
+)
+
+const (
+	DefaultInterval         = 30 * time.Second
+	DefaultTimeout          = 5 * time.Second
+	DefaultFailureThreshold = 3
+	DefaultSuccessThreshold = 1
+
+	// maxDrainedBodySize bounds the body read from probes to reuse the
+	// connections.
+	maxDrainedBodySize = 64 << 10
+)
+
+// Options of the health checks of an upstream, zero values fall back to
+// defaults.
+type Options struct {
+	// Path is probed relative to the url of the upstream, default is the path
+	// listing the models.
+	Path string
+	// Interval between probes, default is DefaultInterval.
+	Interval time.Duration
+	// Timeout of each probe, default is DefaultTimeout.
+	Timeout time.Duration
+	// FailureThreshold is the number of consecutive failed probes for the
+	// upstream to become unhealthy, default is DefaultFailureThreshold.
+	FailureThreshold int
+	// SuccessThreshold is the number of consecutive successful probes for
+	// the upstream to become healthy again, default is
+	// DefaultSuccessThreshold.
+	SuccessThreshold int
+}
+
+func (o Options) withDefaults() Options {
+	o.Interval = lo.CoalesceOrEmpty(o.Interval, DefaultInterval)
+	o.Timeout = lo.CoalesceOrEmpty(o.Timeout, DefaultTimeout)
+	o.FailureThreshold = lo.CoalesceOrEmpty(o.FailureThreshold, DefaultFailureThreshold)
+	o.SuccessThreshold = lo.CoalesceOrEmpty(o.SuccessThreshold, DefaultSuccessThreshold)
+
+	return o
+}
+
+// Status is the health of an upstream.
+type Status struct {
+	Healthy bool
+	// Endpoint is the address of the upstream probed.
+	Endpoint             string
+	LastProbe            time.Time
+	LastError            error
+	ConsecutiveFailures  int
+	ConsecutiveSuccesses int
+}
+
+// Checker probes upstreams and tracks their health between probes, so that
+// transient failures below the thresholds never flip the health.
+type Checker struct {
+	client *http.Client
+
+	mutex    sync.Mutex
+	statuses map[string]*Status
+
+	now func() time.Time
+}
+
+func NewChecker(client *http.Client) *Checker {
+	return &Checker{
+		client:   client,
+		statuses: make(map[string]*Status),
+		now:      time.Now,
+	}
+}
+
+// Check probes the upstream of the cluster unless it was probed by key within
+// the interval, and returns its health. Upstreams are healthy until the
+// failure threshold is reached.
+func (c *Checker) Check(ctx context.Context, key string, cluster *v1alpha1.Cluster, opts Options) Status {
+	opts = opts.withDefaults()
+
+	c.mutex.Lock()
+
+	status, ok := c.statuses[key]
+	if !ok {
+		status = &Status{Healthy: true}
+		c.statuses[key] = status
+	}
+
+	if !status.LastProbe.IsZero() && c.now().Sub(status.LastProbe) < opts.Interval {
+		defer c.mutex.Unlock()
+		return *status
+	}
+
+	c.mutex.Unlock()
+
+	endpoint, err := Probe(ctx, c.client, cluster, opts.Path, opts.Timeout)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	status.Endpoint = endpoint
+	status.LastProbe = c.now()
+	status.LastError = err
+
+	if err != nil {
+		status.ConsecutiveFailures++
+		status.ConsecutiveSuccesses = 0
+
+		if status.ConsecutiveFailures >= opts.FailureThreshold {
+			status.Healthy = false
+		}
+	} else {
+		status.ConsecutiveSuccesses++
+		status.ConsecutiveFailures = 0
+
+		if status.ConsecutiveSuccesses >= opts.SuccessThreshold {
+			status.Healthy = true
+		}
+	}
+
+	return *status
+}
+
+// Forget drops the health tracked by key, e.g. when the backend is deleted.
+func (c *Checker) Forget(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.statuses, key)
+}
+
+// Probe sends a GET request to the path of the upstream of the cluster, the
+// upstream is healthy if it responds with 2xx. The address probed is returned
+// along with the error.
+func Probe(ctx context.Context, client *http.Client, cluster *v1alpha1.Cluster, path string, timeout time.Duration) (string, error) {
+	u := cluster.GetUpstream()
+
+	// The path is taken as the path listing the models of the upstream
+	if path != "" {
+		u = proto.CloneOf(u)
+		u.Paths = map[string]string{upstream.PathModels: path}
+	}
+
+	probeURL, err := upstream.BuildURL(u, upstream.PathModels, cluster.GetName())
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := probeURL.Host
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL.String(), nil)
+	if err != nil {
+		return endpoint, err
+	}
+
+	err = upstream.ApplyCredentials(ctx, u, request)
+	if err != nil {
+		return endpoint, err
+	}
+
+	resp, err := client.Do(request)
+	if err != nil {
+		return endpoint, err
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainedBodySize))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return endpoint, fmt.Errorf("unexpected status %d probing %s", resp.StatusCode, probeURL.Path)
+	}
+
+	return endpoint, nil
+}
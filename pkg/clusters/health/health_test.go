@@ -0,0 +1,102 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+)
+
+func TestProbe(t *testing.T) {
+	var path, authorization atomic.Value
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path.Store(r.URL.Path)
+		authorization.Store(r.Header.Get("Authorization"))
+
+		if r.URL.Path == "/v1/unhealthy" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	cluster := gatewaytest.NewCluster("gpt-4o", server.URL+"/v1")
+	cluster.Upstream.Headers = []*v1alpha1.Upstream_Header{{Key: "Authorization", Value: "Bearer sk-test"}}
+
+	endpoint, err := Probe(context.Background(), server.Client(), cluster, "", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, server.Listener.Addr().String(), endpoint)
+	assert.Equal(t, "/v1/models", path.Load())
+	assert.Equal(t, "Bearer sk-test", authorization.Load())
+
+	_, err = Probe(context.Background(), server.Client(), cluster, "/health", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "/v1/health", path.Load())
+	// The path of the cluster itself is kept
+	assert.Empty(t, cluster.GetUpstream().GetPaths())
+
+	_, err = Probe(context.Background(), server.Client(), cluster, "/unhealthy", time.Second)
+	require.EqualError(t, err, "unexpected status 503 probing /v1/unhealthy")
+}
+
+func TestChecker_Check(t *testing.T) {
+	var healthy atomic.Bool
+
+	probes := atomic.Int64{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	checker := NewChecker(server.Client())
+	checker.now = func() time.Time { return now }
+
+	cluster := gatewaytest.NewCluster("gpt-4o", server.URL)
+	opts := Options{Interval: time.Minute, FailureThreshold: 2, SuccessThreshold: 2}
+
+	check := func() Status {
+		now = now.Add(opts.Interval)
+		return checker.Check(context.Background(), "gpt-4o", cluster, opts)
+	}
+
+	healthy.Store(true)
+	assert.True(t, check().Healthy)
+
+	// Probed within the interval
+	assert.True(t, checker.Check(context.Background(), "gpt-4o", cluster, opts).Healthy)
+	assert.Equal(t, int64(1), probes.Load())
+
+	healthy.Store(false)
+
+	status := check()
+	assert.True(t, status.Healthy)
+	assert.Equal(t, 1, status.ConsecutiveFailures)
+
+	status = check()
+	assert.False(t, status.Healthy)
+	require.Error(t, status.LastError)
+
+	healthy.Store(true)
+	assert.False(t, check().Healthy)
+	assert.True(t, check().Healthy)
+
+	checker.Forget("gpt-4o")
+
+	healthy.Store(false)
+	assert.True(t, checker.Check(context.Background(), "gpt-4o", cluster, opts).Healthy)
+	assert.Equal(t, int64(6), probes.Load())
+}
@@ -0,0 +1,26 @@
+package clusters
+
+import (
+	"time"
+
+	"knoway.dev/api/clusters/v1alpha1"
+)
+
+// InMaintenance reports whether the maintenance window of the cluster covers
+// the given time.
+func InMaintenance(cluster *v1alpha1.Cluster, at time.Time) bool {
+	maintenance := cluster.GetMaintenance()
+	if maintenance == nil {
+		return false
+	}
+
+	if maintenance.GetStart() != nil && at.Before(maintenance.GetStart().AsTime()) {
+		return false
+	}
+
+	if maintenance.GetEnd() != nil && !at.Before(maintenance.GetEnd().AsTime()) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,59 @@
+package clusters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"knoway.dev/api/clusters/v1alpha1"
+)
+
+func TestInMaintenance(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		maintenance *v1alpha1.ClusterMaintenance
+		expected    bool
+	}{
+		{
+			name:     "no maintenance",
+			expected: false,
+		},
+		{
+			name:        "unbounded",
+			maintenance: &v1alpha1.ClusterMaintenance{},
+			expected:    true,
+		},
+		{
+			name: "within window",
+			maintenance: &v1alpha1.ClusterMaintenance{
+				Start: timestamppb.New(now.Add(-time.Hour)),
+				End:   timestamppb.New(now.Add(time.Hour)),
+			},
+			expected: true,
+		},
+		{
+			name: "not started yet",
+			maintenance: &v1alpha1.ClusterMaintenance{
+				Start: timestamppb.New(now.Add(time.Hour)),
+			},
+			expected: false,
+		},
+		{
+			name: "ended",
+			maintenance: &v1alpha1.ClusterMaintenance{
+				End: timestamppb.New(now),
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, InMaintenance(&v1alpha1.Cluster{Maintenance: tt.maintenance}, now))
+		})
+	}
+}
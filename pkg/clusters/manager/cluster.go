@@ -2,8 +2,11 @@ package manager
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/samber/lo"
 	"github.com/samber/mo"
@@ -11,12 +14,26 @@ import (
 	"knoway.dev/api/clusters/v1alpha1"
 	"knoway.dev/pkg/bootkit"
 	clusters2 "knoway.dev/pkg/clusters"
+	"knoway.dev/pkg/clusters/circuitbreaker"
 	cluster "knoway.dev/pkg/clusters/cluster"
+	"knoway.dev/pkg/clusters/connection"
+	"knoway.dev/pkg/egress"
+	"knoway.dev/pkg/events"
 	"knoway.dev/pkg/metadata"
 	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/tenant"
 )
 
-var clusterRegister *Register
+const (
+	egressCheckTimeout = 5 * time.Second
+)
+
+var (
+	clusterRegister *Register
+	// breakers ejects clusters from the route targets after consecutive
+	// upstream errors.
+	breakers = circuitbreaker.NewBreakers()
+)
 
 func HandleRequest(ctx context.Context, clusterName string, request object.LLMRequest) (object.LLMResponse, error) {
 	foundCluster, ok := clusterRegister.FindClusterByName(clusterName)
@@ -24,28 +41,117 @@ func HandleRequest(ctx context.Context, clusterName string, request object.LLMRe
 		return nil, object.NewErrorModelNotFoundOrNotAccessible(request.GetModel())
 	}
 
+	maintenance := foundCluster.GetClusterConfig().GetMaintenance()
+	if clusters2.InMaintenance(foundCluster.GetClusterConfig(), time.Now()) {
+		return nil, object.NewErrorModelUnderMaintenance(request.GetModel(), maintenance.GetReason())
+	}
+
+	// Clusters of providers the tenant isn't allowed to use are treated as
+	// absent, not to reveal them
+	if !tenant.ProviderAllowed(ctx, foundCluster.GetClusterConfig().GetProvider()) {
+		return nil, object.NewErrorModelNotFoundOrNotAccessible(request.GetModel())
+	}
+
 	rMeta := metadata.RequestMetadataFromCtx(ctx)
 	rMeta.SelectedCluster = mo.Some(foundCluster)
 
 	resp, err := foundCluster.DoUpstreamRequest(ctx, request)
 	if err != nil {
 		// Cluster will ensure that error will always be LLMError
+		recordOutcome(ctx, foundCluster.GetClusterConfig(), err)
 		return resp, err
 	}
 
 	if resp.GetError() != nil {
+		recordOutcome(ctx, foundCluster.GetClusterConfig(), resp.GetError())
 		return resp, resp.GetError()
 	}
 
+	recordOutcome(ctx, foundCluster.GetClusterConfig(), nil)
+
 	return resp, err
 }
 
+// recordOutcome feeds the outcome of the request into the circuit breaker of
+// the cluster if configured.
+func recordOutcome(ctx context.Context, cluster *v1alpha1.Cluster, err error) {
+	opts, ok := circuitbreaker.OptionsFromCluster(cluster)
+	if !ok {
+		return
+	}
+
+	// Clients going away says nothing about the upstream
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return
+	}
+
+	if breakers.Record(cluster.GetName(), opts, err) {
+		events.Publish(events.TypeBreakerOpened, map[string]string{
+			"cluster": cluster.GetName(),
+			"error":   err.Error(),
+		})
+	}
+}
+
+// InMaintenance reports whether the cluster is currently under maintenance.
+func InMaintenance(clusterName string) bool {
+	foundCluster, ok := clusterRegister.FindClusterByName(clusterName)
+	if !ok {
+		return false
+	}
+
+	return clusters2.InMaintenance(foundCluster.GetClusterConfig(), time.Now())
+}
+
+// Ejected reports whether the cluster is ejected by its circuit breaker.
+func Ejected(clusterName string) bool {
+	return breakers.Ejected(clusterName)
+}
+
+// Available reports whether the cluster should take requests, that is it's
+// neither under maintenance nor ejected by its circuit breaker.
+func Available(clusterName string) bool {
+	return !InMaintenance(clusterName) && !Ejected(clusterName)
+}
+
+// GetClusterConfig returns the configuration of the registered cluster.
+func GetClusterConfig(name string) (*v1alpha1.Cluster, bool) {
+	cluster, ok := clusterRegister.FindClusterByName(name)
+	if !ok {
+		return nil, false
+	}
+
+	return cluster.GetClusterConfig(), true
+}
+
 func RemoveCluster(cluster *v1alpha1.Cluster) {
 	clusterRegister.DeleteCluster(cluster.GetName())
+	breakers.Forget(cluster.GetName())
+	connection.Forget(cluster.GetName())
+
+	events.Publish(events.TypeClusterRemoved, map[string]string{"cluster": cluster.GetName()})
 }
 
 func UpsertAndRegisterCluster(cluster *v1alpha1.Cluster, lifecycle bootkit.LifeCycle) error {
-	return clusterRegister.UpsertAndRegisterCluster(cluster, lifecycle)
+	err := clusterRegister.UpsertAndRegisterCluster(cluster, lifecycle)
+	if err != nil {
+		return err
+	}
+
+	if cluster.GetCircuitBreaker() == nil {
+		breakers.Forget(cluster.GetName())
+	}
+
+	// Resolves the pinned addresses ahead of the first request, or replaces
+	// the connections of the options changed
+	connection.Warm(cluster)
+
+	events.Publish(events.TypeClusterRegistered, map[string]string{
+		"cluster":  cluster.GetName(),
+		"provider": cluster.GetProvider().String(),
+	})
+
+	return nil
 }
 
 func ListModels() []*v1alpha1.Cluster {
@@ -56,6 +162,15 @@ func ListModels() []*v1alpha1.Cluster {
 	return clusterRegister.ListModels()
 }
 
+// ListAvailableModels lists the clusters that are not under maintenance.
+func ListAvailableModels() []*v1alpha1.Cluster {
+	now := time.Now()
+
+	return lo.Filter(ListModels(), func(item *v1alpha1.Cluster, _ int) bool {
+		return !clusters2.InMaintenance(item, now)
+	})
+}
+
 func ListTTSClusters() []*v1alpha1.Cluster {
 	if clusterRegister == nil {
 		return nil
@@ -114,16 +229,34 @@ func (cr *Register) FindClusterByName(name string) (clusters2.Cluster, bool) {
 }
 
 func (cr *Register) UpsertAndRegisterCluster(c *v1alpha1.Cluster, lifecycle bootkit.LifeCycle) error {
-	cr.clustersLock.Lock()
-	defer cr.clustersLock.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), egressCheckTimeout)
+	defer cancel()
+
+	// Requests and probes, e.g. the scrapes of the metrics of vLLM, are sent
+	// to the endpoints instead of the url
+	for _, upstreamURL := range append([]string{c.GetUpstream().GetUrl()}, c.GetUpstream().GetEndpoints()...) {
+		if upstreamURL == "" {
+			continue
+		}
+
+		err := egress.Global().CheckURL(ctx, upstreamURL)
+		if err != nil {
+			return fmt.Errorf("upstream of cluster %s is not allowed: %w", c.GetName(), err)
+		}
+	}
 
 	name := c.GetName()
 
+	// Building filters of clusters may take a while, keep it out of the lock
+	// so that requests looking up clusters are never blocked by it.
 	newCluster, err := cluster.NewWithConfigs(c, lifecycle)
 	if err != nil {
 		return err
 	}
 
+	cr.clustersLock.Lock()
+	defer cr.clustersLock.Unlock()
+
 	cr.clustersDetails[c.GetName()] = c
 	cr.clusters[name] = newCluster
 
@@ -162,7 +295,7 @@ func (cr *Register) dumpAllClusters() []*v1alpha1.Cluster {
 	cr.clustersLock.RLock()
 	defer cr.clustersLock.RUnlock()
 
-	return lo.Values(clusterRegister.clustersDetails)
+	return lo.Values(cr.clustersDetails)
 }
 
 func DebugDumpAllClusters() []*v1alpha1.Cluster {
@@ -0,0 +1,210 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/clusters/circuitbreaker"
+	"knoway.dev/pkg/egress"
+	"knoway.dev/pkg/object"
+)
+
+const testUpstreamURL = "https://api.openai.com/v1/chat/completions"
+
+// discardLogs silences the logs of registrations which would flood the
+// output of benchmarks.
+func discardLogs(tb testing.TB) {
+	tb.Helper()
+
+	logger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	tb.Cleanup(func() {
+		slog.SetDefault(logger)
+	})
+}
+
+func newTestRegister(tb testing.TB, n int) *Register {
+	tb.Helper()
+
+	r := NewClusterRegister()
+
+	for i := range n {
+		require.NoError(tb, r.UpsertAndRegisterCluster(gatewaytest.NewCluster(fmt.Sprintf("openai/model-%d", i), testUpstreamURL), bootkit.NewEmptyLifeCycle()))
+	}
+
+	return r
+}
+
+// TestRegister_ConcurrentUpsert looks up clusters while they are upserted and
+// deleted, as the controller does while listeners serve requests, run with
+// -race.
+func TestRegister_ConcurrentUpsert(t *testing.T) {
+	r := newTestRegister(t, 10)
+
+	var (
+		wg    sync.WaitGroup
+		stop  atomic.Bool
+		found atomic.Int64
+	)
+
+	for range 4 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for !stop.Load() {
+				_, ok := r.FindClusterByName("openai/model-0")
+				assert.True(t, ok)
+
+				_ = r.ListModels()
+				_ = r.ListTTSClusters()
+				_ = r.dumpAllClusters()
+
+				found.Add(1)
+			}
+		}()
+	}
+
+	// Keep mutating until the readers made progress
+	for i := 0; i < 200 || found.Load() == 0; i++ {
+		require.NoError(t, r.UpsertAndRegisterCluster(gatewaytest.NewCluster("openai/model-0", testUpstreamURL), bootkit.NewEmptyLifeCycle()))
+		require.NoError(t, r.UpsertAndRegisterCluster(gatewaytest.NewCluster("openai/reconciled", testUpstreamURL), bootkit.NewEmptyLifeCycle()))
+		r.DeleteCluster("openai/reconciled")
+	}
+
+	stop.Store(true)
+	wg.Wait()
+
+	assert.Positive(t, found.Load())
+	assert.Len(t, r.ListModels(), 10)
+}
+
+func BenchmarkRegister_FindClusterByName(b *testing.B) {
+	discardLogs(b)
+
+	r := newTestRegister(b, 100)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, ok := r.FindClusterByName("openai/model-99"); !ok {
+				b.Fatal("cluster not found")
+			}
+		}
+	})
+}
+
+// BenchmarkRegister_FindClusterByNameDuringUpsert looks up clusters while
+// clusters are continuously upserted and deleted.
+func BenchmarkRegister_FindClusterByNameDuringUpsert(b *testing.B) {
+	discardLogs(b)
+
+	r := newTestRegister(b, 100)
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			name := fmt.Sprintf("openai/reconciled-%d", i%10)
+			_ = r.UpsertAndRegisterCluster(gatewaytest.NewCluster(name, testUpstreamURL), bootkit.NewEmptyLifeCycle())
+			r.DeleteCluster(name)
+		}
+	}()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, ok := r.FindClusterByName("openai/model-99"); !ok {
+				b.Fatal("cluster not found")
+			}
+		}
+	})
+
+	b.StopTimer()
+	close(done)
+	wg.Wait()
+}
+
+func TestRegister_UpsertEgress(t *testing.T) {
+	allowlist, err := egress.NewAllowlist([]string{"api.openai.com"}, []string{"10.0.0.0/24"})
+	require.NoError(t, err)
+
+	egress.SetGlobal(allowlist)
+	defer egress.SetGlobal(nil)
+
+	r := NewClusterRegister()
+
+	c := gatewaytest.NewCluster("openai/gpt-4o", testUpstreamURL)
+	c.Upstream.Endpoints = []string{"http://10.0.0.1:8000"}
+	require.NoError(t, r.UpsertAndRegisterCluster(c, bootkit.NewEmptyLifeCycle()))
+
+	// Endpoints are checked the same way as the url
+	c = gatewaytest.NewCluster("openai/gpt-4o-mini", testUpstreamURL)
+	c.Upstream.Endpoints = []string{"http://10.0.0.1:8000", "http://192.168.0.1:8000"}
+	require.ErrorContains(t, r.UpsertAndRegisterCluster(c, bootkit.NewEmptyLifeCycle()), "not allowed")
+
+	_, ok := r.FindClusterByName("openai/gpt-4o-mini")
+	assert.False(t, ok)
+}
+
+func TestRecordOutcome(t *testing.T) {
+	discardLogs(t)
+
+	cluster := gatewaytest.NewCluster("openai/circuit-breaker", testUpstreamURL)
+	upstreamErr := object.NewErrorBadGateway(errors.New("connection refused"))
+
+	t.Cleanup(func() {
+		breakers.Forget(cluster.GetName())
+	})
+
+	// Disabled without the circuit breaker configured
+	for range circuitbreaker.DefaultConsecutiveErrors {
+		recordOutcome(context.Background(), cluster, upstreamErr)
+	}
+
+	assert.True(t, Available(cluster.GetName()))
+
+	cluster.CircuitBreaker = &v1alpha1.ClusterCircuitBreaker{ConsecutiveErrors: 2}
+
+	// Requests canceled by clients are not counted
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	recordOutcome(canceled, cluster, upstreamErr)
+	recordOutcome(canceled, cluster, upstreamErr)
+	assert.True(t, Available(cluster.GetName()))
+
+	recordOutcome(context.Background(), cluster, upstreamErr)
+	recordOutcome(context.Background(), cluster, upstreamErr)
+	assert.True(t, Ejected(cluster.GetName()))
+	assert.False(t, Available(cluster.GetName()))
+}
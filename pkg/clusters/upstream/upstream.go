@@ -0,0 +1,201 @@
+package upstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/credentials"
+	"knoway.dev/pkg/object"
+)
+
+// PathModels is the key of the path listing the models of the upstream.
+const PathModels = "models"
+
+const modelPlaceholder = "{model}"
+
+var (
+	defaultPaths = map[string]string{
+		string(object.RequestTypeChatCompletions):  "/chat/completions",
+		string(object.RequestTypeCompletions):      "/completions",
+		string(object.RequestTypeImageGenerations): "/images/generations",
+		string(object.RequestTypeModerations):      "/moderations",
+		string(object.RequestTypeEmbeddings):       "/embeddings",
+		string(object.RequestTypeRealtime):         "/realtime",
+		string(object.RequestTypeRerank):           "/rerank",
+		string(object.RequestTypeVideoGenerations): "/videos",
+		string(object.RequestTypeImageEdits):       "/images/edits",
+		string(object.RequestTypeImageVariations):  "/images/variations",
+		PathModels: "/models",
+	}
+
+	placeholderPattern = regexp.MustCompile(`\{[^}]*\}`)
+)
+
+// Validate checks the url, endpoints, paths and query of the upstream.
+func Validate(upstream *v1alpha1.Upstream) error {
+	if upstream.GetUrl() != "" {
+		parsed, err := url.Parse(upstream.GetUrl())
+		if err != nil {
+			return fmt.Errorf("invalid upstream url: %w", err)
+		}
+
+		if parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("invalid upstream url %q: scheme and host are required", upstream.GetUrl())
+		}
+	}
+
+	for _, endpoint := range upstream.GetEndpoints() {
+		parsed, err := url.Parse(endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid upstream endpoint: %w", err)
+		}
+
+		if parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("invalid upstream endpoint %q: scheme and host are required", endpoint)
+		}
+
+		if strings.Trim(parsed.Path, "/") != "" || parsed.RawQuery != "" {
+			return fmt.Errorf("invalid upstream endpoint %q: must not contain path or query", endpoint)
+		}
+	}
+
+	for key, path := range upstream.GetPaths() {
+		if _, ok := defaultPaths[key]; !ok {
+			return fmt.Errorf("unknown type %q of upstream paths, must be one of %s", key, strings.Join(lo.Keys(defaultPaths), ", "))
+		}
+
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("upstream path %q of %s must start with /", path, key)
+		}
+
+		if strings.ContainsAny(path, "?#") {
+			return fmt.Errorf("upstream path %q of %s must not contain query or fragment, use query instead", path, key)
+		}
+
+		for _, placeholder := range placeholderPattern.FindAllString(path, -1) {
+			if placeholder != modelPlaceholder {
+				return fmt.Errorf("unknown placeholder %s in upstream path %q of %s", placeholder, path, key)
+			}
+		}
+	}
+
+	for name := range upstream.GetQuery() {
+		if name == "" {
+			return errors.New("name of upstream query parameters cannot be empty")
+		}
+	}
+
+	return nil
+}
+
+// BuildURL returns the url of the upstream for the type of requests, the
+// path is taken from the paths of the upstream or the OpenAI compatible one,
+// and is appended to the url.
+func BuildURL(upstream *v1alpha1.Upstream, typ string, model string) (*url.URL, error) {
+	path, ok := upstream.GetPaths()[typ]
+	if !ok {
+		path, ok = defaultPaths[typ]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %q of upstream paths", typ)
+		}
+	}
+
+	path = strings.ReplaceAll(path, modelPlaceholder, url.PathEscape(model))
+
+	return JoinURL(upstream, path)
+}
+
+// JoinURL returns the url of the upstream with the escaped path appended, for
+// the providers whose paths are not per type of requests, e.g. the paths of
+// the jobs submitted.
+func JoinURL(upstream *v1alpha1.Upstream, path string) (*url.URL, error) {
+	parsed, err := url.Parse(upstream.GetUrl())
+	if err != nil {
+		return nil, err
+	}
+
+	rawPath := strings.TrimSuffix(parsed.EscapedPath(), "/") + path
+
+	parsed.Path, err = url.PathUnescape(rawPath)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed.RawPath = rawPath
+
+	ApplyQuery(upstream, parsed)
+
+	return parsed, nil
+}
+
+// ApplyQuery sets the static query parameters of the upstream into u.
+func ApplyQuery(upstream *v1alpha1.Upstream, u *url.URL) {
+	if len(upstream.GetQuery()) == 0 {
+		return
+	}
+
+	query := u.Query()
+	for name, value := range upstream.GetQuery() {
+		query.Set(name, value)
+	}
+
+	u.RawQuery = query.Encode()
+}
+
+// ApplyCredentials sets the headers and the credentials of the upstream into
+// requests not made by clusters, e.g. probes.
+func ApplyCredentials(ctx context.Context, upstream *v1alpha1.Upstream, request *http.Request) error {
+	resolved, err := credentials.ResolveHeaders(ctx, upstream.GetHeadersFrom())
+	if err != nil {
+		return err
+	}
+
+	for _, h := range append(slices.Clone(upstream.GetHeaders()), resolved...) {
+		request.Header.Set(h.GetKey(), h.GetValue())
+	}
+
+	query := request.URL.Query()
+
+	for _, auth := range upstream.GetAuth() {
+		value, err := credentials.ResolveAuthValue(ctx, auth)
+		if err != nil {
+			return err
+		}
+
+		switch auth.GetScheme() {
+		case v1alpha1.Upstream_Auth_QUERY_PARAM:
+			query.Set(auth.GetName(), value)
+		case v1alpha1.Upstream_Auth_COOKIE:
+			request.AddCookie(&http.Cookie{Name: auth.GetName(), Value: value})
+		default:
+			return fmt.Errorf("unsupported upstream auth scheme %s", auth.GetScheme())
+		}
+	}
+
+	request.URL.RawQuery = query.Encode()
+
+	return nil
+}
+
+// ApplyEndpoint sends the request u is of to the endpoint instead, by
+// replacing the scheme and the host of u with the ones of the endpoint.
+func ApplyEndpoint(u *url.URL, endpoint string) error {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+
+	u.Scheme = parsed.Scheme
+	u.Host = parsed.Host
+
+	return nil
+}
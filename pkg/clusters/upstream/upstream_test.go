@@ -0,0 +1,145 @@
+package upstream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/object"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		upstream *v1alpha1.Upstream
+		wantErr  bool
+	}{
+		{
+			name:     "url only",
+			upstream: &v1alpha1.Upstream{Url: "https://api.openai.com/v1"},
+		},
+		{
+			name: "paths and query",
+			upstream: &v1alpha1.Upstream{
+				Url:   "https://api.example.com",
+				Paths: map[string]string{"chat_completions": "/v2/{model}/chat", "models": "/v2/models"},
+				Query: map[string]string{"api-version": "2024-10-21"},
+			},
+		},
+		{
+			name:     "missing scheme",
+			upstream: &v1alpha1.Upstream{Url: "api.openai.com/v1"},
+			wantErr:  true,
+		},
+		{
+			name:     "unknown type of paths",
+			upstream: &v1alpha1.Upstream{Url: "https://api.example.com", Paths: map[string]string{"chat": "/chat"}},
+			wantErr:  true,
+		},
+		{
+			name:     "relative path",
+			upstream: &v1alpha1.Upstream{Url: "https://api.example.com", Paths: map[string]string{"embeddings": "embed"}},
+			wantErr:  true,
+		},
+		{
+			name:     "query in path",
+			upstream: &v1alpha1.Upstream{Url: "https://api.example.com", Paths: map[string]string{"embeddings": "/embed?v=1"}},
+			wantErr:  true,
+		},
+		{
+			name:     "unknown placeholder",
+			upstream: &v1alpha1.Upstream{Url: "https://api.example.com", Paths: map[string]string{"embeddings": "/{deployment}/embed"}},
+			wantErr:  true,
+		},
+		{
+			name:     "endpoints",
+			upstream: &v1alpha1.Upstream{Url: "http://qwen3.default.svc.cluster.local:8000/v1", Endpoints: []string{"http://10.0.0.12:8000", "http://10.0.0.13:8000/"}},
+		},
+		{
+			name:     "endpoint without scheme",
+			upstream: &v1alpha1.Upstream{Url: "http://qwen3.default.svc.cluster.local:8000/v1", Endpoints: []string{"10.0.0.12:8000"}},
+			wantErr:  true,
+		},
+		{
+			name:     "endpoint with path",
+			upstream: &v1alpha1.Upstream{Url: "http://qwen3.default.svc.cluster.local:8000/v1", Endpoints: []string{"http://10.0.0.12:8000/v1"}},
+			wantErr:  true,
+		},
+		{
+			name:     "empty query name",
+			upstream: &v1alpha1.Upstream{Url: "https://api.example.com", Query: map[string]string{"": "value"}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.upstream)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBuildURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		upstream *v1alpha1.Upstream
+		typ      string
+		model    string
+		expected string
+	}{
+		{
+			name:     "default path",
+			upstream: &v1alpha1.Upstream{Url: "https://api.openai.com/v1/"},
+			typ:      string(object.RequestTypeChatCompletions),
+			expected: "https://api.openai.com/v1/chat/completions",
+		},
+		{
+			name:     "default models path",
+			upstream: &v1alpha1.Upstream{Url: "https://api.openai.com/v1"},
+			typ:      PathModels,
+			expected: "https://api.openai.com/v1/models",
+		},
+		{
+			name: "path template",
+			upstream: &v1alpha1.Upstream{
+				Url:   "https://api.example.com",
+				Paths: map[string]string{"embeddings": "/v2/{model}/embed"},
+			},
+			typ:      string(object.RequestTypeEmbeddings),
+			model:    "org/model:v1",
+			expected: "https://api.example.com/v2/org%2Fmodel:v1/embed",
+		},
+		{
+			name: "static query merged with the one of url",
+			upstream: &v1alpha1.Upstream{
+				Url:   "https://api.example.com/openai?tenant=a",
+				Query: map[string]string{"api-version": "2024-10-21"},
+			},
+			typ:      string(object.RequestTypeCompletions),
+			expected: "https://api.example.com/openai/completions?api-version=2024-10-21&tenant=a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := BuildURL(tt.upstream, tt.typ, tt.model)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, u.String())
+		})
+	}
+}
+
+func TestApplyEndpoint(t *testing.T) {
+	u, err := BuildURL(&v1alpha1.Upstream{Url: "http://qwen3.default.svc.cluster.local:8000/v1", Query: map[string]string{"trace": "1"}}, string(object.RequestTypeChatCompletions), "qwen3")
+	require.NoError(t, err)
+
+	require.NoError(t, ApplyEndpoint(u, "https://10.0.0.12:8443"))
+	assert.Equal(t, "https://10.0.0.12:8443/v1/chat/completions?trace=1", u.String())
+}
@@ -0,0 +1,135 @@
+package configdiscovery
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+
+	service "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+)
+
+const (
+	minReconnectInterval = time.Second
+	maxReconnectInterval = 30 * time.Second
+)
+
+// ApplyFunc applies the snapshot received, snapshots failed to apply are
+// rejected, and the control plane is told the reason.
+type ApplyFunc func(snapshot *Snapshot) error
+
+// Client subscribes to the configuration of the control plane, and applies it
+// whenever it's pushed. It reconnects with backoff once disconnected, the
+// configuration applied is kept meanwhile.
+type Client struct {
+	client service.ConfigDiscoveryServiceClient
+	nodeID string
+	apply  ApplyFunc
+
+	// version is the version of the configuration applied
+	version string
+}
+
+func NewClient(conn grpc.ClientConnInterface, nodeID string, apply ApplyFunc) *Client {
+	return &Client{
+		client: service.NewConfigDiscoveryServiceClient(conn),
+		nodeID: nodeID,
+		apply:  apply,
+	}
+}
+
+// Run subscribes to the configuration until ctx is done.
+func (c *Client) Run(ctx context.Context) {
+	interval := minReconnectInterval
+
+	for {
+		received, err := c.stream(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if received {
+			interval = minReconnectInterval
+		}
+
+		slog.Warn("config discovery stream disconnected, reconnecting", "error", err, "after", interval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+
+		interval = min(interval*2, maxReconnectInterval) //nolint:mnd
+	}
+}
+
+// Start runs the client along with the lifecycle.
+func (c *Client) Start(lifecycle bootkit.LifeCycle) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStart: func(context.Context) error {
+			go c.Run(ctx)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+}
+
+// stream subscribes once, and reports whether any response was received
+// before it's disconnected.
+func (c *Client) stream(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.client.StreamConfig(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	err = stream.Send(&service.DiscoveryRequest{NodeId: c.nodeID, VersionInfo: c.version})
+	if err != nil {
+		return false, err
+	}
+
+	received := false
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return received, err
+		}
+
+		received = true
+		request := &service.DiscoveryRequest{NodeId: c.nodeID, ResponseNonce: resp.GetNonce()}
+
+		err = c.apply(&Snapshot{
+			Version:  resp.GetVersionInfo(),
+			Clusters: resp.GetClusters(),
+			Routes:   resp.GetRoutes(),
+		})
+		if err != nil {
+			slog.Error("failed to apply the configuration of config discovery, keeping the current one", "version", resp.GetVersionInfo(), "error", err)
+
+			request.ErrorDetail = err.Error()
+		} else {
+			slog.Info("applied the configuration of config discovery", "version", resp.GetVersionInfo(), "clusters", len(resp.GetClusters()), "routes", len(resp.GetRoutes()))
+
+			c.version = resp.GetVersionInfo()
+		}
+
+		request.VersionInfo = c.version
+
+		err = stream.Send(request)
+		if err != nil {
+			return received, errors.Join(errors.New("failed to acknowledge the configuration"), err)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package configdiscovery
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	clusters "knoway.dev/api/clusters/v1alpha1"
+	routes "knoway.dev/api/route/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+)
+
+func TestNewSnapshot(t *testing.T) {
+	a := &clusters.Cluster{Name: "a", Provider: clusters.ClusterProvider_OPEN_AI}
+	b := &clusters.Cluster{Name: "b", Provider: clusters.ClusterProvider_VLLM}
+	route := &routes.Route{Name: "r"}
+
+	s1 := NewSnapshot([]*clusters.Cluster{a, b}, []*routes.Route{route})
+	s2 := NewSnapshot([]*clusters.Cluster{b, a}, []*routes.Route{route})
+
+	assert.Len(t, s1.Version, versionLength)
+	assert.Equal(t, s1.Version, s2.Version)
+	assert.Equal(t, []string{"a", "b"}, []string{s2.Clusters[0].GetName(), s2.Clusters[1].GetName()})
+	assert.Equal(t, map[string]*clusters.Cluster{"a": a, "b": b}, s2.ClusterMap())
+
+	s3 := NewSnapshot([]*clusters.Cluster{a}, []*routes.Route{route})
+	assert.NotEqual(t, s1.Version, s3.Version)
+
+	s4 := NewSnapshot([]*clusters.Cluster{a, {Name: "b", Provider: clusters.ClusterProvider_OPEN_AI}}, []*routes.Route{route})
+	assert.NotEqual(t, s1.Version, s4.Version)
+}
+
+func newConn(t *testing.T, server *Server) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	service.RegisterConfigDiscoveryServiceServer(s, server)
+
+	go func() {
+		_ = s.Serve(listener)
+	}()
+
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+func waitNode(t *testing.T, server *Server, nodeID string, version string) NodeStatus {
+	t.Helper()
+
+	var status NodeStatus
+
+	require.Eventually(t, func() bool {
+		status = server.Nodes()[nodeID]
+		return status.Version == version
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return status
+}
+
+func TestServerAndClient(t *testing.T) {
+	server := NewServer()
+	server.SetSnapshot(NewSnapshot([]*clusters.Cluster{{Name: "openai/gpt-4o"}}, nil))
+
+	applied := make(chan *Snapshot, 10)
+	rejecting := make(chan struct{})
+
+	client := NewClient(newConn(t, server), "gateway-0", func(snapshot *Snapshot) error {
+		select {
+		case <-rejecting:
+			return errors.New("invalid cluster")
+		default:
+		}
+
+		applied <- snapshot
+
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go client.Run(ctx)
+
+	first := <-applied
+	assert.Equal(t, "openai/gpt-4o", first.Clusters[0].GetName())
+	assert.Empty(t, waitNode(t, server, "gateway-0", first.Version).Error)
+
+	// Pushed whenever the snapshot changes
+	second := NewSnapshot([]*clusters.Cluster{{Name: "openai/gpt-4o"}, {Name: "openai/gpt-4o-mini"}}, []*routes.Route{{Name: "gpt"}})
+	server.SetSnapshot(second)
+
+	received := <-applied
+	assert.Equal(t, second.Version, received.Version)
+	assert.Len(t, received.Clusters, 2)
+	assert.Equal(t, "gpt", received.Routes[0].GetName())
+	waitNode(t, server, "gateway-0", second.Version)
+
+	// Rejected snapshots keep the version applied
+	close(rejecting)
+	server.SetSnapshot(NewSnapshot(nil, nil))
+
+	require.Eventually(t, func() bool {
+		return server.Nodes()["gateway-0"].Error == "invalid cluster"
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, second.Version, server.Nodes()["gateway-0"].Version)
+}
+
+func TestServer_SkipsVersionApplied(t *testing.T) {
+	server := NewServer()
+	snapshot := NewSnapshot([]*clusters.Cluster{{Name: "a"}}, nil)
+	server.SetSnapshot(snapshot)
+
+	stream, err := service.NewConfigDiscoveryServiceClient(newConn(t, server)).StreamConfig(context.Background())
+	require.NoError(t, err)
+
+	// Subscribed with the version applied, e.g. after reconnected
+	require.NoError(t, stream.Send(&service.DiscoveryRequest{NodeId: "gateway-0", VersionInfo: snapshot.Version}))
+	waitNode(t, server, "gateway-0", snapshot.Version)
+
+	updated := NewSnapshot([]*clusters.Cluster{{Name: "b"}}, nil)
+	server.SetSnapshot(updated)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, updated.Version, resp.GetVersionInfo())
+	assert.Equal(t, "b", resp.GetClusters()[0].GetName())
+	assert.NotEmpty(t, resp.GetNonce())
+}
@@ -0,0 +1,95 @@
+package configdiscovery
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/rueidis"
+	"google.golang.org/protobuf/proto"
+
+	service "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/redis"
+)
+
+const (
+	redisSnapshotKey     = "knoway:config:snapshot"
+	redisSnapshotChannel = "knoway:config:published"
+)
+
+var _ Channel = (*RedisChannel)(nil)
+
+// RedisChannel keeps the latest snapshot as a Redis key, encoded as a
+// knoway.service.v1alpha1.DiscoveryResponse, and notifies the subscribers of
+// the versions published through Redis pub/sub.
+type RedisChannel struct {
+	client rueidis.Client
+}
+
+func NewRedisChannel(url string, lifecycle bootkit.LifeCycle) (*RedisChannel, error) {
+	client, err := redis.NewRedisClient(url)
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStop: func(context.Context) error {
+			client.Close()
+			return nil
+		},
+	})
+
+	return &RedisChannel{client: client}, nil
+}
+
+func (c *RedisChannel) Publish(ctx context.Context, snapshot *Snapshot) error {
+	b, err := proto.Marshal(&service.DiscoveryResponse{
+		VersionInfo: snapshot.Version,
+		Clusters:    snapshot.Clusters,
+		Routes:      snapshot.Routes,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, resp := range c.client.DoMulti(ctx,
+		c.client.B().Set().Key(redisSnapshotKey).Value(rueidis.BinaryString(b)).Build(),
+		c.client.B().Publish().Channel(redisSnapshotChannel).Message(snapshot.Version).Build(),
+	) {
+		if err := resp.Error(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *RedisChannel) Latest(ctx context.Context) (*Snapshot, error) {
+	b, err := c.client.Do(ctx, c.client.B().Get().Key(redisSnapshotKey).Build()).AsBytes()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	resp := &service.DiscoveryResponse{}
+
+	err = proto.Unmarshal(b, resp)
+	if err != nil {
+		return nil, fmt.Errorf("malformed snapshot: %w", err)
+	}
+
+	return &Snapshot{
+		Version:  resp.GetVersionInfo(),
+		Clusters: resp.GetClusters(),
+		Routes:   resp.GetRoutes(),
+	}, nil
+}
+
+func (c *RedisChannel) Subscribe(ctx context.Context, notify func()) error {
+	return c.client.Receive(ctx, c.client.B().Subscribe().Channel(redisSnapshotChannel).Build(), func(rueidis.PubSubMessage) {
+		notify()
+	})
+}
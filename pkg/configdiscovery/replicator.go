@@ -0,0 +1,240 @@
+package configdiscovery
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"knoway.dev/pkg/bootkit"
+	clustermanager "knoway.dev/pkg/clusters/manager"
+	routemanager "knoway.dev/pkg/route/manager"
+)
+
+// defaultResyncInterval is how often the leader publishes the snapshot again,
+// and the followers check the latest one, in case of notifications missed or
+// Redis restarted.
+const defaultResyncInterval = 30 * time.Second
+
+// Channel shares the snapshots between the replicas.
+type Channel interface {
+	// Publish keeps the snapshot as the latest one and notifies the
+	// subscribers.
+	Publish(ctx context.Context, snapshot *Snapshot) error
+	// Latest returns the latest snapshot published, nil if none.
+	Latest(ctx context.Context) (*Snapshot, error)
+	// Subscribe calls notify whenever a snapshot is published, until ctx is
+	// done or the subscription fails.
+	Subscribe(ctx context.Context, notify func()) error
+}
+
+// Replicator shares the clusters and the routes registered by the controller
+// of the replica elected as the leader with the other replicas, so that all
+// of them serve requests while only one reconciles. The followers apply the
+// snapshots of the leader until they are elected, then keep serving the
+// snapshot applied until their controllers have reconciled everything, and
+// release it to them.
+type Replicator struct {
+	channel Channel
+	apply   ApplyFunc
+	release func()
+
+	resyncInterval time.Duration
+
+	// mutex guards leading and the snapshots applied, so that no snapshot
+	// is applied once released
+	mutex   sync.Mutex
+	leading bool
+	// version is the version of the snapshot applied
+	version string
+}
+
+// NewReplicator returns the replicator applying the snapshots with apply, and
+// calling release once the replica is elected.
+func NewReplicator(channel Channel, apply ApplyFunc, release func()) *Replicator {
+	return &Replicator{
+		channel:        channel,
+		apply:          apply,
+		release:        release,
+		resyncInterval: defaultResyncInterval,
+	}
+}
+
+// Follow applies the snapshots published until ctx is done, or the replica
+// is elected.
+func (r *Replicator) Follow(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	notified := make(chan struct{}, 1)
+
+	go r.subscribe(ctx, func() {
+		select {
+		case notified <- struct{}{}:
+		default:
+		}
+	})
+
+	ticker := time.NewTicker(r.resyncInterval)
+	defer ticker.Stop()
+
+	for {
+		if !r.sync(ctx) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-notified:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Start follows along with the lifecycle.
+func (r *Replicator) Start(lifecycle bootkit.LifeCycle) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStart: func(context.Context) error {
+			go r.Follow(ctx)
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+}
+
+// subscribe subscribes to the channel until ctx is done, resubscribing with
+// backoff once the subscription fails.
+func (r *Replicator) subscribe(ctx context.Context, notify func()) {
+	interval := minReconnectInterval
+
+	for {
+		subscribed := time.Now()
+
+		err := r.channel.Subscribe(ctx, notify)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(subscribed) > maxReconnectInterval {
+			interval = minReconnectInterval
+		}
+
+		slog.Warn("replication subscription failed, resubscribing", "error", err, "after", interval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+
+		// Snapshots may have been published meanwhile
+		notify()
+
+		interval = min(interval*2, maxReconnectInterval) //nolint:mnd
+	}
+}
+
+// sync applies the latest snapshot, false once the replica leads.
+func (r *Replicator) sync(ctx context.Context) bool {
+	snapshot, err := r.channel.Latest(ctx)
+	if err != nil {
+		slog.Error("failed to get the latest replicated snapshot", "error", err)
+		return true
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.leading {
+		return false
+	}
+
+	// Nothing published yet, or the snapshot applied
+	if snapshot == nil || snapshot.Version == r.version {
+		return true
+	}
+
+	err = r.apply(snapshot)
+	if err != nil {
+		slog.Error("failed to apply the replicated snapshot, keeping the current one", "version", snapshot.Version, "error", err)
+		return true
+	}
+
+	slog.Info("applied the replicated snapshot", "version", snapshot.Version, "clusters", len(snapshot.Clusters), "routes", len(snapshot.Routes))
+
+	r.version = snapshot.Version
+
+	return true
+}
+
+// Lead publishes the snapshot of the clusters and the routes registered in the
+// process whenever they change, until ctx is done. It's run once the replica
+// is elected, e.g. as a runnable of the controller manager.
+//
+// Until initialReconcile returns, the controllers have registered only part of
+// them, so the snapshot followed is kept serving and nothing is published,
+// otherwise every replica would drop the ones not reconciled yet.
+func (r *Replicator) Lead(ctx context.Context, initialReconcile func(ctx context.Context) error) error {
+	r.mutex.Lock()
+	r.leading = true
+	r.mutex.Unlock()
+
+	slog.Info("elected as the leader, waiting for the controllers to reconcile before publishing")
+
+	err := initialReconcile(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		return err
+	}
+
+	r.release()
+
+	slog.Info("publishing the clusters and the routes registered")
+
+	subscription := subscribeRegistered()
+	defer subscription.Close()
+
+	ticker := time.NewTicker(r.resyncInterval)
+	defer ticker.Stop()
+
+	publish := func() {
+		snapshot := NewSnapshot(clustermanager.DebugDumpAllClusters(), routemanager.DumpMatchRoutes())
+
+		err := r.channel.Publish(ctx, snapshot)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("failed to publish the replicated snapshot", "version", snapshot.Version, "error", err)
+		}
+	}
+
+	publish()
+
+	var debounce <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-subscription.Events():
+			if !ok {
+				return nil
+			}
+
+			debounce = time.After(syncDebounce)
+		case <-debounce:
+			debounce = nil
+
+			publish()
+		case <-ticker.C:
+			publish()
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package configdiscovery
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clusters "knoway.dev/api/clusters/v1alpha1"
+)
+
+type memoryChannel struct {
+	mutex       sync.Mutex
+	latest      *Snapshot
+	subscribers []func()
+}
+
+func (c *memoryChannel) Publish(_ context.Context, snapshot *Snapshot) error {
+	c.mutex.Lock()
+	c.latest = snapshot
+	subscribers := c.subscribers
+	c.mutex.Unlock()
+
+	for _, notify := range subscribers {
+		notify()
+	}
+
+	return nil
+}
+
+func (c *memoryChannel) Latest(context.Context) (*Snapshot, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.latest, nil
+}
+
+func (c *memoryChannel) Subscribe(ctx context.Context, notify func()) error {
+	c.mutex.Lock()
+	c.subscribers = append(c.subscribers, notify)
+	c.mutex.Unlock()
+
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func TestReplicator(t *testing.T) {
+	channel := &memoryChannel{}
+
+	first := NewSnapshot([]*clusters.Cluster{{Name: "openai/gpt-4o"}}, nil)
+	require.NoError(t, channel.Publish(context.Background(), first))
+
+	applied := make(chan *Snapshot, 10)
+	released := make(chan struct{})
+
+	replicator := NewReplicator(channel, func(snapshot *Snapshot) error {
+		applied <- snapshot
+		return nil
+	}, func() {
+		close(released)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	followed := make(chan struct{})
+
+	go func() {
+		defer close(followed)
+		replicator.Follow(ctx)
+	}()
+
+	// The snapshot published before following is applied
+	assert.Equal(t, first.Version, (<-applied).Version)
+
+	require.Eventually(t, func() bool {
+		channel.mutex.Lock()
+		defer channel.mutex.Unlock()
+
+		return len(channel.subscribers) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Applied whenever published
+	second := NewSnapshot([]*clusters.Cluster{{Name: "openai/gpt-4o"}, {Name: "openai/gpt-4o-mini"}}, nil)
+	require.NoError(t, channel.Publish(context.Background(), second))
+	assert.Equal(t, second.Version, (<-applied).Version)
+
+	// The same version is never applied twice
+	require.NoError(t, channel.Publish(context.Background(), second))
+
+	// Elected, the snapshots applied are released, and the ones of the
+	// replica are published instead
+	go func() {
+		_ = replicator.Lead(ctx, func(context.Context) error { return nil })
+	}()
+
+	<-released
+
+	require.Eventually(t, func() bool {
+		latest, err := channel.Latest(context.Background())
+		require.NoError(t, err)
+
+		return latest.Version != second.Version
+	}, 5*time.Second, 10*time.Millisecond)
+
+	select {
+	case <-followed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("still following after elected")
+	}
+
+	assert.Empty(t, applied)
+}
+
+func TestReplicator_FailoverKeepsSnapshotUntilReconciled(t *testing.T) {
+	channel := &memoryChannel{}
+
+	followedSnapshot := NewSnapshot([]*clusters.Cluster{{Name: "openai/gpt-4o"}, {Name: "openai/gpt-4o-mini"}}, nil)
+	require.NoError(t, channel.Publish(context.Background(), followedSnapshot))
+
+	applied := make(chan *Snapshot, 10)
+	released := make(chan struct{})
+
+	replicator := NewReplicator(channel, func(snapshot *Snapshot) error {
+		applied <- snapshot
+		return nil
+	}, func() {
+		close(released)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go replicator.Follow(ctx)
+
+	assert.Equal(t, followedSnapshot.Version, (<-applied).Version)
+
+	// Elected while the controllers are still reconciling the backends and
+	// the ModelRoutes existing
+	reconciling := make(chan struct{})
+	reconciled := make(chan struct{})
+
+	go func() {
+		_ = replicator.Lead(ctx, func(ctx context.Context) error {
+			close(reconciling)
+
+			select {
+			case <-reconciled:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	<-reconciling
+
+	// The snapshot followed keeps serving, and nothing is published over it
+	assert.Never(t, func() bool {
+		select {
+		case <-released:
+			return true
+		default:
+		}
+
+		latest, err := channel.Latest(context.Background())
+		require.NoError(t, err)
+
+		return latest.Version != followedSnapshot.Version
+	}, 300*time.Millisecond, 10*time.Millisecond)
+
+	close(reconciled)
+
+	select {
+	case <-released:
+	case <-time.After(5 * time.Second):
+		t.Fatal("not released after reconciled")
+	}
+
+	require.Eventually(t, func() bool {
+		latest, err := channel.Latest(context.Background())
+		require.NoError(t, err)
+
+		return latest.Version != followedSnapshot.Version
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Empty(t, applied)
+}
+
+func TestReplicator_LeadStopsWhileReconciling(t *testing.T) {
+	channel := &memoryChannel{}
+
+	followedSnapshot := NewSnapshot([]*clusters.Cluster{{Name: "openai/gpt-4o"}}, nil)
+	require.NoError(t, channel.Publish(context.Background(), followedSnapshot))
+
+	replicator := NewReplicator(channel, func(*Snapshot) error { return nil }, func() {
+		t.Error("released before reconciled")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := replicator.Lead(ctx, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.NoError(t, err)
+
+	latest, err := channel.Latest(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, followedSnapshot.Version, latest.Version)
+}
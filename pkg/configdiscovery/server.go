@@ -0,0 +1,236 @@
+package configdiscovery
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	service "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	clustermanager "knoway.dev/pkg/clusters/manager"
+	"knoway.dev/pkg/events"
+	routemanager "knoway.dev/pkg/route/manager"
+)
+
+// syncDebounce coalesces the events of a single change, e.g. a backend
+// registering its cluster and its base route.
+const syncDebounce = 200 * time.Millisecond
+
+// NodeStatus is the state of the configuration of a gateway.
+type NodeStatus struct {
+	// Version is the version of the configuration applied.
+	Version string `json:"version"`
+	// Error is the reason the last response was rejected for, empty if it
+	// was applied.
+	Error    string    `json:"error,omitempty"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// Server pushes the snapshot to the gateways subscribed, and again whenever
+// it's replaced.
+type Server struct {
+	service.UnimplementedConfigDiscoveryServiceServer
+
+	mutex    sync.RWMutex
+	snapshot *Snapshot
+	// updated is closed and replaced once the snapshot is replaced
+	updated chan struct{}
+	nodes   map[string]NodeStatus
+
+	nonce atomic.Uint64
+}
+
+var _ service.ConfigDiscoveryServiceServer = (*Server)(nil)
+
+func NewServer() *Server {
+	return &Server{
+		updated: make(chan struct{}),
+		nodes:   make(map[string]NodeStatus),
+	}
+}
+
+// SetSnapshot replaces the snapshot pushed to the gateways, snapshots of the
+// same version as the current one are ignored.
+func (s *Server) SetSnapshot(snapshot *Snapshot) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.snapshot != nil && s.snapshot.Version == snapshot.Version {
+		return
+	}
+
+	s.snapshot = snapshot
+	close(s.updated)
+	s.updated = make(chan struct{})
+
+	slog.Info("config discovery snapshot updated", "version", snapshot.Version, "clusters", len(snapshot.Clusters), "routes", len(snapshot.Routes))
+}
+
+func (s *Server) current() (*Snapshot, <-chan struct{}) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.snapshot, s.updated
+}
+
+// Nodes returns the states of the gateways subscribed since the server
+// started.
+func (s *Server) Nodes() map[string]NodeStatus {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	res := make(map[string]NodeStatus, len(s.nodes))
+	for id, status := range s.nodes {
+		res[id] = status
+	}
+
+	return res
+}
+
+func (s *Server) record(request *service.DiscoveryRequest) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.nodes[request.GetNodeId()] = NodeStatus{
+		Version:  request.GetVersionInfo(),
+		Error:    request.GetErrorDetail(),
+		LastSeen: time.Now(),
+	}
+}
+
+func (s *Server) StreamConfig(stream service.ConfigDiscoveryService_StreamConfigServer) error {
+	ctx := stream.Context()
+
+	// Gateways subscribe with the version they applied, which is not pushed
+	// again, e.g. after reconnected
+	first, err := stream.Recv()
+	if err != nil {
+		return ignoreEOF(err)
+	}
+
+	nodeID := first.GetNodeId()
+	sent := first.GetVersionInfo()
+
+	s.record(first)
+	slog.Info("gateway subscribed to config discovery", "node", nodeID, "version", sent)
+
+	requests := make(chan *service.DiscoveryRequest)
+	errs := make(chan error, 1)
+
+	go func() {
+		for {
+			request, err := stream.Recv()
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			select {
+			case requests <- request:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		snapshot, updated := s.current()
+		if snapshot != nil && snapshot.Version != sent {
+			err = stream.Send(&service.DiscoveryResponse{
+				VersionInfo: snapshot.Version,
+				Nonce:       strconv.FormatUint(s.nonce.Add(1), 10),
+				Clusters:    snapshot.Clusters,
+				Routes:      snapshot.Routes,
+			})
+			if err != nil {
+				return err
+			}
+
+			sent = snapshot.Version
+		}
+
+		select {
+		case <-updated:
+		case request := <-requests:
+			s.record(request)
+
+			if request.GetErrorDetail() != "" {
+				slog.Warn("gateway rejected the configuration", "node", nodeID, "version", request.GetVersionInfo(), "error", request.GetErrorDetail())
+			} else {
+				slog.Debug("gateway applied the configuration", "node", nodeID, "version", request.GetVersionInfo())
+			}
+		case err := <-errs:
+			slog.Info("gateway unsubscribed from config discovery", "node", nodeID)
+			return ignoreEOF(err)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func ignoreEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	return err
+}
+
+// SyncRegistered keeps the snapshot in sync with the clusters and the match
+// routes registered in the process, by the controller or from the static
+// configuration, so that the process serves as the control plane of the
+// gateways.
+func (s *Server) SyncRegistered(lifecycle bootkit.LifeCycle) {
+	update := func() {
+		s.SetSnapshot(NewSnapshot(clustermanager.DebugDumpAllClusters(), routemanager.DumpMatchRoutes()))
+	}
+
+	subscription := subscribeRegistered()
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStart: func(context.Context) error {
+			update()
+
+			go func() {
+				var debounce <-chan time.Time
+
+				for {
+					select {
+					case _, ok := <-subscription.Events():
+						if !ok {
+							return
+						}
+
+						debounce = time.After(syncDebounce)
+					case <-debounce:
+						debounce = nil
+
+						update()
+					}
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			subscription.Close()
+			return nil
+		},
+	})
+}
+
+// subscribeRegistered subscribes to the changes of the clusters and the
+// routes registered in the process.
+func subscribeRegistered() *events.Subscription {
+	return events.Subscribe(events.DefaultSubscriptionBuffer,
+		events.TypeClusterRegistered,
+		events.TypeClusterRemoved,
+		events.TypeRouteChanged,
+		events.TypeRouteRemoved,
+	)
+}
@@ -0,0 +1,81 @@
+// Package configdiscovery pushes the clusters and the routes from a control
+// plane to the gateways over the gRPC
+// knoway.service.v1alpha1.ConfigDiscoveryService, in the way of the
+// state-of-the-world protocol of xDS: every response carries the whole
+// configuration, which the gateways acknowledge or reject.
+package configdiscovery
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	clusters "knoway.dev/api/clusters/v1alpha1"
+	routes "knoway.dev/api/route/v1alpha1"
+)
+
+// versionLength is the number of hex digits of the versions of snapshots.
+const versionLength = 16
+
+// Snapshot is the whole configuration pushed to the gateways.
+type Snapshot struct {
+	// Version is derived from the content, so that the same configuration is
+	// never pushed twice.
+	Version  string
+	Clusters []*clusters.Cluster
+	Routes   []*routes.Route
+}
+
+// NewSnapshot returns the snapshot of the clusters and the routes ordered by
+// their names.
+func NewSnapshot(clusterConfigs []*clusters.Cluster, routeConfigs []*routes.Route) *Snapshot {
+	s := &Snapshot{
+		Clusters: append([]*clusters.Cluster(nil), clusterConfigs...),
+		Routes:   append([]*routes.Route(nil), routeConfigs...),
+	}
+
+	sort.Slice(s.Clusters, func(i, j int) bool { return s.Clusters[i].GetName() < s.Clusters[j].GetName() })
+	sort.Slice(s.Routes, func(i, j int) bool { return s.Routes[i].GetName() < s.Routes[j].GetName() })
+
+	hash := sha256.New()
+	options := proto.MarshalOptions{Deterministic: true}
+
+	writeLength := func(length int) {
+		hash.Write(binary.BigEndian.AppendUint64(nil, uint64(length)))
+	}
+
+	// Everything is prefixed with its length, so that the clusters and the
+	// routes are never mistaken for others concatenated
+	writeLength(len(s.Clusters))
+
+	for _, c := range s.Clusters {
+		b, _ := options.Marshal(c)
+		writeLength(len(b))
+		hash.Write(b)
+	}
+
+	writeLength(len(s.Routes))
+
+	for _, r := range s.Routes {
+		b, _ := options.Marshal(r)
+		writeLength(len(b))
+		hash.Write(b)
+	}
+
+	s.Version = hex.EncodeToString(hash.Sum(nil))[:versionLength]
+
+	return s
+}
+
+// ClusterMap returns the clusters keyed by their names.
+func (s *Snapshot) ClusterMap() map[string]*clusters.Cluster {
+	res := make(map[string]*clusters.Cluster, len(s.Clusters))
+	for _, c := range s.Clusters {
+		res[c.GetName()] = c
+	}
+
+	return res
+}
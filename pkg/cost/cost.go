@@ -0,0 +1,77 @@
+// Package cost computes the cost of requests from the pricing of the
+// clusters that served them.
+package cost
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/stt"
+)
+
+const tokensPerPrice = 1000
+
+// Usage is the usage of a request that is priced.
+type Usage struct {
+	PromptTokens     uint64
+	CompletionTokens uint64
+	Images           []object.ImageGenerationsUsageImage
+	AudioSeconds     float64
+}
+
+// UsageFromMetadata returns the usage reported by the upstream that served
+// the request.
+func UsageFromMetadata(rMeta *metadata.RequestMetadata, response object.LLMResponse) Usage {
+	var usage Usage
+
+	if tokensUsage, ok := rMeta.LLMUpstreamTokensUsage.Get(); ok && !lo.IsNil(tokensUsage) {
+		usage.PromptTokens = tokensUsage.GetPromptTokens()
+		usage.CompletionTokens = tokensUsage.GetCompletionTokens()
+	}
+
+	if imagesUsage, ok := rMeta.LLMUpstreamImagesUsage.Get(); ok && !lo.IsNil(imagesUsage) {
+		usage.Images = imagesUsage.GetOutputImages()
+	}
+
+	if transcription, ok := response.(*stt.TranscriptionResponse); ok && transcription != nil {
+		usage.AudioSeconds = transcription.Duration
+	}
+
+	return usage
+}
+
+// imagePrice returns the price of the first image prices matching the size
+// and the quality of the image.
+func imagePrice(pricing *v1alpha1.ClusterPricing, image object.ImageGenerationsUsageImage) (float64, bool) {
+	size := strconv.FormatUint(image.GetWidth(), 10) + "x" + strconv.FormatUint(image.GetHeight(), 10)
+
+	price, ok := lo.Find(pricing.GetImages(), func(price *v1alpha1.ClusterPricing_Image) bool {
+		return (price.GetSize() == "" || strings.EqualFold(price.GetSize(), size)) &&
+			(price.GetQuality() == "" || strings.EqualFold(price.GetQuality(), image.GetQuality()))
+	})
+	if !ok {
+		return 0, false
+	}
+
+	return price.GetPrice(), true
+}
+
+// Compute computes the cost of the usage, images matching no price are free.
+func Compute(pricing *v1alpha1.ClusterPricing, usage Usage) float64 {
+	cost := float64(usage.PromptTokens)/tokensPerPrice*pricing.GetPromptPer1KTokens() +
+		float64(usage.CompletionTokens)/tokensPerPrice*pricing.GetCompletionPer1KTokens() +
+		usage.AudioSeconds*pricing.GetPerAudioSecond()
+
+	for _, image := range usage.Images {
+		if price, ok := imagePrice(pricing, image); ok {
+			cost += price
+		}
+	}
+
+	return cost
+}
@@ -0,0 +1,62 @@
+package cost
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/types/stt"
+)
+
+func TestCompute(t *testing.T) {
+	pricing := &v1alpha1.ClusterPricing{
+		Currency:              "USD",
+		PromptPer1KTokens:     0.0025,
+		CompletionPer1KTokens: 0.01,
+		PerAudioSecond:        0.0001,
+		Images: []*v1alpha1.ClusterPricing_Image{
+			{Size: "1024x1024", Quality: "hd", Price: 0.08},
+			{Size: "1024x1024", Price: 0.04},
+			{Size: "1792x1024", Price: 0.12},
+		},
+	}
+
+	assert.Zero(t, Compute(pricing, Usage{}))
+	assert.InDelta(t, 0.0125, Compute(pricing, Usage{PromptTokens: 1000, CompletionTokens: 1000}), 1e-9)
+	assert.InDelta(t, 0.006, Compute(pricing, Usage{AudioSeconds: 60}), 1e-9)
+
+	images := []object.ImageGenerationsUsageImage{
+		&openai.ImageGenerationsUsageImage{Width: 1024, Height: 1024, Quality: "HD"},
+		&openai.ImageGenerationsUsageImage{Width: 1024, Height: 1024, Quality: "standard"},
+		&openai.ImageGenerationsUsageImage{Width: 1792, Height: 1024},
+		// Matching no price
+		&openai.ImageGenerationsUsageImage{Width: 512, Height: 512},
+	}
+	assert.InDelta(t, 0.24, Compute(pricing, Usage{Images: images}), 1e-9)
+
+	// Nothing is priced without pricing
+	assert.Zero(t, Compute(nil, Usage{PromptTokens: 1000, Images: images}))
+}
+
+func TestUsageFromMetadata(t *testing.T) {
+	rMeta := &metadata.RequestMetadata{}
+	assert.Equal(t, Usage{}, UsageFromMetadata(rMeta, nil))
+
+	rMeta.LLMUpstreamTokensUsage = mo.Some[object.LLMTokensUsage](&openai.ChatCompletionsUsage{PromptTokens: 10, CompletionTokens: 20})
+	assert.Equal(t, Usage{PromptTokens: 10, CompletionTokens: 20}, UsageFromMetadata(rMeta, nil))
+
+	rMeta = &metadata.RequestMetadata{}
+	rMeta.LLMUpstreamImagesUsage = mo.Some[object.LLMImagesUsage](&openai.ImageGenerationsUsage{
+		Images: []*openai.ImageGenerationsUsageImage{{Width: 1024, Height: 1024}},
+	})
+	assert.Len(t, UsageFromMetadata(rMeta, nil).Images, 1)
+
+	response := stt.NewTranscriptionResponseFromBytes(http.StatusOK, "application/json", "whisper-1", []byte(`{"text":"hello","duration":3.5}`))
+	assert.InDelta(t, 3.5, UsageFromMetadata(&metadata.RequestMetadata{}, response).AudioSeconds, 1e-9)
+}
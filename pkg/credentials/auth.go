@@ -0,0 +1,40 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"knoway.dev/api/clusters/v1alpha1"
+)
+
+// ResolveAuthValue returns the credential of the upstream auth, either the
+// value resolved by the controller, or the key of the secret read from the
+// credential provider with the global Store.
+func ResolveAuthValue(ctx context.Context, auth *v1alpha1.Upstream_Auth) (string, error) {
+	vault := auth.GetVault()
+	if vault == nil {
+		return auth.GetValue(), nil
+	}
+
+	store := Global()
+	if store == nil {
+		return "", errors.New("upstream auth references credential providers, but none is configured")
+	}
+
+	data, err := store.Get(ctx, Reference{
+		Provider: ProviderVault,
+		Path:     vault.GetPath(),
+		Role:     vault.GetRole(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := data[vault.GetKey()]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in %s secret %s", vault.GetKey(), ProviderVault, vault.GetPath())
+	}
+
+	return value, nil
+}
@@ -0,0 +1,217 @@
+// Package credentials resolves upstream credentials kept in external
+// providers such as HashiCorp Vault, and keeps them renewed and rotated at
+// runtime so that they never get stored in the cluster config.
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"knoway.dev/pkg/bootkit"
+)
+
+const (
+	defaultRefreshInterval = 5 * time.Minute
+	refreshCheckInterval   = 10 * time.Second
+	refreshRetryInterval   = 30 * time.Second
+)
+
+var (
+	ErrProviderNotConfigured = errors.New("credential provider is not configured")
+)
+
+// Reference identifies a secret of a credential provider.
+type Reference struct {
+	Provider string
+	Path     string
+	Role     string
+}
+
+func (r Reference) String() string {
+	return r.Provider + ":" + r.Path
+}
+
+// Secret is the key-value data read from a credential provider.
+type Secret struct {
+	Data map[string]string
+	// LeaseID is set for dynamic secrets whose leases can be renewed
+	LeaseID       string
+	LeaseDuration time.Duration
+	Renewable     bool
+}
+
+type Provider interface {
+	Name() string
+	Read(ctx context.Context, ref Reference) (*Secret, error)
+	// Renew extends the lease of the secret, returns the secret with the new
+	// lease.
+	Renew(ctx context.Context, ref Reference, secret *Secret) (*Secret, error)
+}
+
+type entry struct {
+	secret    *Secret
+	refreshAt time.Time
+}
+
+// Store caches secrets read from providers, renews their leases before they
+// expire, and reads them again periodically to pick up rotations.
+type Store struct {
+	providers       map[string]Provider
+	refreshInterval time.Duration
+
+	mutex   sync.RWMutex
+	entries map[Reference]*entry
+}
+
+func NewStore(refreshInterval time.Duration, providers ...Provider) *Store {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultRefreshInterval
+	}
+
+	s := &Store{
+		providers:       make(map[string]Provider, len(providers)),
+		refreshInterval: refreshInterval,
+		entries:         make(map[Reference]*entry),
+	}
+
+	for _, p := range providers {
+		s.providers[p.Name()] = p
+	}
+
+	return s
+}
+
+func (s *Store) nextRefreshAt(secret *Secret) time.Time {
+	interval := s.refreshInterval
+	if secret.LeaseDuration > 0 && secret.LeaseDuration/2 < interval {
+		interval = secret.LeaseDuration / 2 //nolint:mnd
+	}
+
+	return time.Now().Add(interval)
+}
+
+// Get returns the data of the secret, reading it from the provider if it's
+// not cached yet.
+func (s *Store) Get(ctx context.Context, ref Reference) (map[string]string, error) {
+	s.mutex.RLock()
+	e, ok := s.entries[ref]
+	s.mutex.RUnlock()
+
+	if ok {
+		return e.secret.Data, nil
+	}
+
+	provider, ok := s.providers[ref.Provider]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, ref.Provider)
+	}
+
+	secret, err := provider.Read(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret %s: %w", ref, err)
+	}
+
+	s.mutex.Lock()
+	s.entries[ref] = &entry{secret: secret, refreshAt: s.nextRefreshAt(secret)}
+	s.mutex.Unlock()
+
+	return secret.Data, nil
+}
+
+// Refresh renews or reads again the secrets that are due. Renewable leases
+// are renewed, others, or those failed to renew, are read again so that
+// rotated values are picked up. Secrets failed to refresh keep the previous
+// values and get retried later.
+func (s *Store) Refresh(ctx context.Context) {
+	now := time.Now()
+
+	s.mutex.RLock()
+
+	due := make(map[Reference]*Secret)
+
+	for ref, e := range s.entries {
+		if !now.Before(e.refreshAt) {
+			due[ref] = e.secret
+		}
+	}
+
+	s.mutex.RUnlock()
+
+	for ref, secret := range due {
+		refreshed, err := s.refresh(ctx, ref, secret)
+
+		s.mutex.Lock()
+		if err != nil {
+			slog.WarnContext(ctx, "failed to refresh credential", slog.String("ref", ref.String()), slog.Any("error", err))
+			s.entries[ref].refreshAt = time.Now().Add(refreshRetryInterval)
+		} else {
+			s.entries[ref] = &entry{secret: refreshed, refreshAt: s.nextRefreshAt(refreshed)}
+		}
+		s.mutex.Unlock()
+	}
+}
+
+func (s *Store) refresh(ctx context.Context, ref Reference, secret *Secret) (*Secret, error) {
+	provider, ok := s.providers[ref.Provider]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProviderNotConfigured, ref.Provider)
+	}
+
+	if secret.Renewable && secret.LeaseID != "" {
+		renewed, err := provider.Renew(ctx, ref, secret)
+		if err == nil {
+			return renewed, nil
+		}
+
+		slog.DebugContext(ctx, "failed to renew credential lease, reading again", slog.String("ref", ref.String()), slog.Any("error", err))
+	}
+
+	return provider.Read(ctx, ref)
+}
+
+// Start refreshes the secrets in background until the lifecycle stops.
+func (s *Store) Start(lifecycle bootkit.LifeCycle) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStart: func(context.Context) error {
+			go func() {
+				ticker := time.NewTicker(refreshCheckInterval)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						s.Refresh(ctx)
+					}
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+}
+
+var global atomic.Pointer[Store]
+
+// SetGlobal sets the Store used by the whole gateway.
+func SetGlobal(s *Store) {
+	global.Store(s)
+}
+
+// Global returns the Store used by the whole gateway, nil if no credential
+// provider is configured.
+func Global() *Store {
+	return global.Load()
+}
@@ -0,0 +1,207 @@
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/clusters/v1alpha1"
+)
+
+type fakeProvider struct {
+	reads   atomic.Int32
+	renews  atomic.Int32
+	secret  func(reads int32) *Secret
+	renewFn func(secret *Secret) (*Secret, error)
+}
+
+func (p *fakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *fakeProvider) Read(_ context.Context, _ Reference) (*Secret, error) {
+	return p.secret(p.reads.Add(1)), nil
+}
+
+func (p *fakeProvider) Renew(_ context.Context, _ Reference, secret *Secret) (*Secret, error) {
+	p.renews.Add(1)
+	return p.renewFn(secret)
+}
+
+func expire(s *Store) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, e := range s.entries {
+		e.refreshAt = time.Time{}
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	ref := Reference{Provider: "fake", Path: "secret/openai"}
+
+	t.Run("rotation", func(t *testing.T) {
+		provider := &fakeProvider{secret: func(reads int32) *Secret {
+			if reads == 1 {
+				return &Secret{Data: map[string]string{"Authorization": "Bearer old"}}
+			}
+
+			return &Secret{Data: map[string]string{"Authorization": "Bearer new"}}
+		}}
+
+		store := NewStore(time.Minute, provider)
+
+		data, err := store.Get(ctx, ref)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer old", data["Authorization"])
+
+		// Cached
+		_, err = store.Get(ctx, ref)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), provider.reads.Load())
+
+		// Not due yet
+		store.Refresh(ctx)
+		assert.Equal(t, int32(1), provider.reads.Load())
+
+		expire(store)
+		store.Refresh(ctx)
+
+		data, err = store.Get(ctx, ref)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer new", data["Authorization"])
+	})
+
+	t.Run("lease renewal", func(t *testing.T) {
+		provider := &fakeProvider{
+			secret: func(reads int32) *Secret {
+				return &Secret{Data: map[string]string{"key": "value"}, LeaseID: "lease", LeaseDuration: time.Second, Renewable: true}
+			},
+			renewFn: func(secret *Secret) (*Secret, error) {
+				return secret, nil
+			},
+		}
+
+		store := NewStore(time.Hour, provider)
+
+		_, err := store.Get(ctx, ref)
+		require.NoError(t, err)
+
+		// Refreshed at half of the lease
+		store.mutex.RLock()
+		assert.WithinDuration(t, time.Now().Add(500*time.Millisecond), store.entries[ref].refreshAt, 100*time.Millisecond)
+		store.mutex.RUnlock()
+
+		expire(store)
+		store.Refresh(ctx)
+		assert.Equal(t, int32(1), provider.renews.Load())
+		assert.Equal(t, int32(1), provider.reads.Load())
+
+		// Falls back to reading again
+		provider.renewFn = func(secret *Secret) (*Secret, error) {
+			return nil, errors.New("lease expired")
+		}
+
+		expire(store)
+		store.Refresh(ctx)
+		assert.Equal(t, int32(2), provider.reads.Load())
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		store := NewStore(time.Minute)
+
+		_, err := store.Get(ctx, ref)
+		require.ErrorIs(t, err, ErrProviderNotConfigured)
+	})
+}
+
+func TestVaultProvider(t *testing.T) {
+	var logins atomic.Int32
+	var revoked atomic.Bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/kubernetes/login":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+
+			if body["role"] != "knoway" || body["jwt"] != "sa-token" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+
+				return
+			}
+
+			logins.Add(1)
+			revoked.Store(false)
+
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"token","lease_duration":3600}}`))
+		case "/v1/secret/data/openai":
+			if r.Header.Get("X-Vault-Token") != "token" || revoked.Load() {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"lease_duration":0,"data":{"data":{"Authorization":"Bearer sk-xxx"},"metadata":{"version":1}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("sa-token\n"), 0o600))
+
+	provider, err := NewVaultProvider(VaultOptions{
+		Address:   server.URL,
+		TokenFile: tokenFile,
+	})
+	require.NoError(t, err)
+
+	ref := Reference{Provider: ProviderVault, Path: "secret/data/openai", Role: "knoway"}
+
+	secret, err := provider.Read(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer sk-xxx"}, secret.Data)
+	assert.Equal(t, int32(1), logins.Load())
+
+	// Login again once the token is revoked
+	revoked.Store(true)
+
+	_, err = provider.Read(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), logins.Load())
+
+	_, err = provider.Read(context.Background(), Reference{Provider: ProviderVault, Path: "secret/data/openai", Role: "other"})
+	require.Error(t, err)
+
+	store := NewStore(time.Minute, provider)
+	SetGlobal(store)
+
+	defer SetGlobal(nil)
+
+	headers, err := ResolveHeaders(context.Background(), []*v1alpha1.Upstream_HeaderFrom{
+		{
+			Source: &v1alpha1.Upstream_HeaderFrom_Vault_{
+				Vault: &v1alpha1.Upstream_HeaderFrom_Vault{Path: "secret/data/openai", Role: "knoway"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, headers, 1)
+	assert.Equal(t, "Authorization", headers[0].GetKey())
+	assert.Equal(t, "Bearer sk-xxx", headers[0].GetValue())
+}
@@ -0,0 +1,66 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"knoway.dev/api/clusters/v1alpha1"
+)
+
+// ReferenceFromHeaderFrom returns the reference of the secret the headers
+// come from.
+func ReferenceFromHeaderFrom(headerFrom *v1alpha1.Upstream_HeaderFrom) (Reference, bool) {
+	if vault := headerFrom.GetVault(); vault != nil {
+		return Reference{
+			Provider: ProviderVault,
+			Path:     vault.GetPath(),
+			Role:     vault.GetRole(),
+		}, true
+	}
+
+	return Reference{}, false
+}
+
+// ResolveHeaders resolves the headers from credential providers with the
+// global Store, every key of the secrets becomes a header.
+func ResolveHeaders(ctx context.Context, headersFrom []*v1alpha1.Upstream_HeaderFrom) ([]*v1alpha1.Upstream_Header, error) {
+	if len(headersFrom) == 0 {
+		return nil, nil
+	}
+
+	store := Global()
+	if store == nil {
+		return nil, errors.New("upstream headers reference credential providers, but none is configured")
+	}
+
+	headers := make([]*v1alpha1.Upstream_Header, 0)
+
+	for _, headerFrom := range headersFrom {
+		ref, ok := ReferenceFromHeaderFrom(headerFrom)
+		if !ok {
+			continue
+		}
+
+		data, err := store.Get(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		keys := make([]string, 0, len(data))
+		for key := range data {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			headers = append(headers, &v1alpha1.Upstream_Header{
+				Key:   headerFrom.GetPrefix() + key,
+				Value: data[key],
+			})
+		}
+	}
+
+	return headers, nil
+}
@@ -0,0 +1,286 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ProviderVault = "vault"
+
+	defaultVaultAuthMount = "kubernetes"
+	defaultVaultTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token" //nolint:gosec
+	// Login again a bit earlier than the token expires
+	vaultTokenExpiryLeeway = 30 * time.Second
+)
+
+var (
+	errVaultPermissionDenied = errors.New("vault permission denied")
+)
+
+// VaultOptions configures the HashiCorp Vault provider, which logins with
+// the Kubernetes auth method using the roles of references.
+type VaultOptions struct {
+	// Address of Vault, e.g. https://vault.example.com:8200
+	Address string
+	// Namespace of Vault Enterprise, optional
+	Namespace string
+	// AuthMount is the mount path of the Kubernetes auth method, defaults
+	// to kubernetes.
+	AuthMount string
+	// TokenFile is the service account token used to login, defaults to the
+	// token mounted into pods.
+	TokenFile string
+	Client    *http.Client
+}
+
+var _ Provider = (*vaultProvider)(nil)
+
+type vaultToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+type vaultProvider struct {
+	options VaultOptions
+
+	mutex  sync.Mutex
+	tokens map[string]vaultToken
+}
+
+func NewVaultProvider(options VaultOptions) (Provider, error) {
+	if options.Address == "" {
+		return nil, errors.New("invalid vault address")
+	}
+
+	options.Address = strings.TrimSuffix(options.Address, "/")
+
+	if options.AuthMount == "" {
+		options.AuthMount = defaultVaultAuthMount
+	}
+
+	if options.TokenFile == "" {
+		options.TokenFile = defaultVaultTokenFile
+	}
+
+	if options.Client == nil {
+		options.Client = http.DefaultClient
+	}
+
+	return &vaultProvider{
+		options: options,
+		tokens:  make(map[string]vaultToken),
+	}, nil
+}
+
+func (p *vaultProvider) Name() string {
+	return ProviderVault
+}
+
+type vaultResponse struct {
+	LeaseID       string          `json:"lease_id"`
+	LeaseDuration int64           `json:"lease_duration"`
+	Renewable     bool            `json:"renewable"`
+	Data          json.RawMessage `json:"data"`
+	Auth          *struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int64  `json:"lease_duration"`
+	} `json:"auth"`
+	Errors []string `json:"errors"`
+}
+
+func (p *vaultProvider) do(ctx context.Context, method string, path string, token string, body any) (*vaultResponse, error) {
+	var reader io.Reader
+
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+
+		reader = bytes.NewReader(bs)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, p.options.Address+"/v1/"+strings.TrimPrefix(path, "/"), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != "" {
+		request.Header.Set("X-Vault-Token", token)
+	}
+
+	if p.options.Namespace != "" {
+		request.Header.Set("X-Vault-Namespace", p.options.Namespace)
+	}
+
+	resp, err := p.options.Client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	vaultResp := new(vaultResponse)
+
+	err = json.NewDecoder(resp.Body).Decode(vaultResp)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid vault response: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusForbidden:
+		return nil, fmt.Errorf("%w: %s", errVaultPermissionDenied, strings.Join(vaultResp.Errors, ", "))
+	case resp.StatusCode >= http.StatusBadRequest:
+		return nil, fmt.Errorf("vault responded with status %d: %s", resp.StatusCode, strings.Join(vaultResp.Errors, ", "))
+	}
+
+	return vaultResp, nil
+}
+
+func (p *vaultProvider) token(ctx context.Context, role string) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if t, ok := p.tokens[role]; ok && time.Now().Before(t.expiresAt) {
+		return t.token, nil
+	}
+
+	jwt, err := os.ReadFile(p.options.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read service account token: %w", err)
+	}
+
+	resp, err := p.do(ctx, http.MethodPost, "auth/"+p.options.AuthMount+"/login", "", map[string]string{
+		"role": role,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to login to vault with role %s: %w", role, err)
+	}
+
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return "", fmt.Errorf("failed to login to vault with role %s: empty token", role)
+	}
+
+	t := vaultToken{token: resp.Auth.ClientToken}
+	if resp.Auth.LeaseDuration > 0 {
+		t.expiresAt = time.Now().Add(time.Duration(resp.Auth.LeaseDuration)*time.Second - vaultTokenExpiryLeeway)
+	} else {
+		// Tokens without TTL never expire
+		t.expiresAt = time.Now().Add(100 * 365 * 24 * time.Hour) //nolint:mnd
+	}
+
+	p.tokens[role] = t
+
+	return t.token, nil
+}
+
+func (p *vaultProvider) forgetToken(role string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.tokens, role)
+}
+
+// doWithToken retries once with a new token if the cached one was revoked.
+func (p *vaultProvider) doWithToken(ctx context.Context, role string, method string, path string, body any) (*vaultResponse, error) {
+	token, err := p.token(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.do(ctx, method, path, token, body)
+	if err == nil || !errors.Is(err, errVaultPermissionDenied) {
+		return resp, err
+	}
+
+	p.forgetToken(role)
+
+	token, err = p.token(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.do(ctx, method, path, token, body)
+}
+
+func (p *vaultProvider) Read(ctx context.Context, ref Reference) (*Secret, error) {
+	resp, err := p.doWithToken(ctx, ref.Role, http.MethodGet, ref.Path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := vaultSecretData(resp.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Secret{
+		Data:          data,
+		LeaseID:       resp.LeaseID,
+		LeaseDuration: time.Duration(resp.LeaseDuration) * time.Second,
+		Renewable:     resp.Renewable,
+	}, nil
+}
+
+func (p *vaultProvider) Renew(ctx context.Context, ref Reference, secret *Secret) (*Secret, error) {
+	resp, err := p.doWithToken(ctx, ref.Role, http.MethodPut, "sys/leases/renew", map[string]any{
+		"lease_id":  secret.LeaseID,
+		"increment": int64(secret.LeaseDuration.Seconds()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Secret{
+		Data:          secret.Data,
+		LeaseID:       resp.LeaseID,
+		LeaseDuration: time.Duration(resp.LeaseDuration) * time.Second,
+		Renewable:     resp.Renewable,
+	}, nil
+}
+
+// vaultSecretData flattens the data of both KV v1 and KV v2 secrets, KV v2
+// nests the key-values in data.data along with data.metadata.
+func vaultSecretData(raw json.RawMessage) (map[string]string, error) {
+	var data map[string]any
+
+	err := json.Unmarshal(raw, &data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault secret data: %w", err)
+	}
+
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+
+	res := make(map[string]string, len(data))
+
+	for key, value := range data {
+		switch v := value.(type) {
+		case string:
+			res[key] = v
+		default:
+			bs, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+
+			res[key] = string(bs)
+		}
+	}
+
+	return res, nil
+}
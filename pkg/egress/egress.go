@@ -0,0 +1,179 @@
+package egress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	ErrNotAllowed = errors.New("egress destination is not allowed")
+)
+
+// Allowlist restricts the destinations the gateway is allowed to send
+// requests to, either by hostnames or by CIDRs of the resolved addresses. An
+// empty Allowlist allows everything.
+type Allowlist struct {
+	// hosts are lower-cased exact hostnames, or suffixes starting with "."
+	// for wildcards like *.openai.azure.com
+	hosts []string
+	cidrs []netip.Prefix
+}
+
+// NewAllowlist creates the Allowlist from hostnames and CIDRs. Hostnames
+// can be exact, e.g. api.openai.com, or wildcards matching any subdomain,
+// e.g. *.openai.azure.com.
+func NewAllowlist(hosts []string, cidrs []string) (*Allowlist, error) {
+	a := &Allowlist{
+		hosts: make([]string, 0, len(hosts)),
+		cidrs: make([]netip.Prefix, 0, len(cidrs)),
+	}
+
+	for _, host := range hosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host == "" {
+			continue
+		}
+
+		if suffix, ok := strings.CutPrefix(host, "*"); ok {
+			if !strings.HasPrefix(suffix, ".") {
+				return nil, fmt.Errorf("invalid wildcard host %s, must be in form of *.example.com", host)
+			}
+
+			host = suffix
+		}
+
+		a.hosts = append(a.hosts, host)
+	}
+
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr %s: %w", cidr, err)
+		}
+
+		a.cidrs = append(a.cidrs, prefix.Masked())
+	}
+
+	return a, nil
+}
+
+// IsEmpty reports whether the Allowlist allows everything.
+func (a *Allowlist) IsEmpty() bool {
+	return a == nil || (len(a.hosts) == 0 && len(a.cidrs) == 0)
+}
+
+// AllowsHost reports whether the hostname is allowed by name.
+func (a *Allowlist) AllowsHost(host string) bool {
+	if a.IsEmpty() {
+		return true
+	}
+
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+
+	for _, h := range a.hosts {
+		if strings.HasPrefix(h, ".") {
+			if strings.HasSuffix(host, h) {
+				return true
+			}
+
+			continue
+		}
+
+		if host == h {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowsAddr reports whether the address is in the allowed CIDRs.
+func (a *Allowlist) AllowsAddr(addr netip.Addr) bool {
+	if a.IsEmpty() {
+		return true
+	}
+
+	addr = addr.Unmap()
+
+	for _, prefix := range a.cidrs {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckHost checks whether the host is allowed, either by name, or all of
+// the addresses it resolves to are in the allowed CIDRs.
+func (a *Allowlist) CheckHost(ctx context.Context, host string) error {
+	if a.IsEmpty() {
+		return nil
+	}
+
+	if host == "" {
+		return fmt.Errorf("%w: empty host", ErrNotAllowed)
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if a.AllowsAddr(addr) {
+			return nil
+		}
+
+		return fmt.Errorf("%w: %s", ErrNotAllowed, host)
+	}
+
+	if a.AllowsHost(host) {
+		return nil
+	}
+
+	if len(a.cidrs) == 0 {
+		return fmt.Errorf("%w: %s", ErrNotAllowed, host)
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+
+	for _, addr := range addrs {
+		if !a.AllowsAddr(addr) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrNotAllowed, host, addr)
+		}
+	}
+
+	return nil
+}
+
+// CheckURL checks whether the host of the URL is allowed, see CheckHost.
+func (a *Allowlist) CheckURL(ctx context.Context, rawURL string) error {
+	if a.IsEmpty() {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid url %s: %w", rawURL, err)
+	}
+
+	return a.CheckHost(ctx, u.Hostname())
+}
+
+var global atomic.Pointer[Allowlist]
+
+// SetGlobal sets the Allowlist used by the whole gateway.
+func SetGlobal(a *Allowlist) {
+	global.Store(a)
+}
+
+// Global returns the Allowlist used by the whole gateway, nil (allows
+// everything) if not configured.
+func Global() *Allowlist {
+	return global.Load()
+}
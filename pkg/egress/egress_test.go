@@ -0,0 +1,59 @@
+package egress
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAllowlist(t *testing.T) {
+	_, err := NewAllowlist([]string{"*openai.com"}, nil)
+	require.Error(t, err)
+
+	_, err = NewAllowlist(nil, []string{"10.0.0.0/33"})
+	require.Error(t, err)
+
+	a, err := NewAllowlist(nil, nil)
+	require.NoError(t, err)
+	assert.True(t, a.IsEmpty())
+
+	var nilAllowlist *Allowlist
+	assert.True(t, nilAllowlist.IsEmpty())
+	require.NoError(t, nilAllowlist.CheckURL(context.Background(), "http://169.254.169.254/latest/meta-data"))
+}
+
+func TestAllowlist_AllowsHost(t *testing.T) {
+	a, err := NewAllowlist([]string{"api.openai.com", "*.openai.azure.com"}, nil)
+	require.NoError(t, err)
+
+	assert.True(t, a.AllowsHost("api.openai.com"))
+	assert.True(t, a.AllowsHost("API.OpenAI.com."))
+	assert.True(t, a.AllowsHost("eastus.openai.azure.com"))
+	assert.False(t, a.AllowsHost("openai.azure.com"))
+	assert.False(t, a.AllowsHost("evil-api.openai.com"))
+	assert.False(t, a.AllowsHost("api.openai.com.evil.com"))
+}
+
+func TestAllowlist_CheckURL(t *testing.T) {
+	a, err := NewAllowlist([]string{"api.openai.com"}, []string{"10.0.0.0/8", "127.0.0.1/32"})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	require.NoError(t, a.CheckURL(ctx, "https://api.openai.com/v1"))
+	require.NoError(t, a.CheckURL(ctx, "http://10.1.2.3:8000/v1"))
+	require.NoError(t, a.CheckURL(ctx, "http://127.0.0.1:8000/v1"))
+
+	err = a.CheckURL(ctx, "http://169.254.169.254/latest/meta-data")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNotAllowed))
+
+	err = a.CheckURL(ctx, "http://[::ffff:169.254.169.254]/")
+	assert.True(t, errors.Is(err, ErrNotAllowed))
+
+	assert.True(t, a.AllowsAddr(netip.MustParseAddr("::ffff:10.0.0.1")))
+}
@@ -0,0 +1,174 @@
+// Package events is the in-process pub/sub bus of the lifecycle and
+// data-plane events of the gateway, such as clusters registered or routes
+// changed, consumed by subsystems like metrics and the admin event stream.
+package events
+
+import (
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// Type is the type of events.
+type Type string
+
+const (
+	TypeClusterRegistered Type = "cluster.registered"
+	TypeClusterRemoved    Type = "cluster.removed"
+	TypeRouteChanged      Type = "route.changed"
+	TypeRouteRemoved      Type = "route.removed"
+	TypeTargetDemoted     Type = "target.demoted"
+	TypeTargetRestored    Type = "target.restored"
+	TypeBreakerOpened     Type = "breaker.opened"
+	TypeDrainStarted      Type = "drain.started"
+	TypeQuotaExhausted    Type = "quota.exhausted"
+)
+
+// Types are all the types of events published by the gateway.
+var Types = []Type{
+	TypeClusterRegistered,
+	TypeClusterRemoved,
+	TypeRouteChanged,
+	TypeRouteRemoved,
+	TypeTargetDemoted,
+	TypeTargetRestored,
+	TypeBreakerOpened,
+	TypeDrainStarted,
+	TypeQuotaExhausted,
+}
+
+// DefaultSubscriptionBuffer is the number of events buffered for each
+// subscription by default.
+const DefaultSubscriptionBuffer = 256
+
+// Event is an event of the gateway.
+type Event struct {
+	// ID increases with every event published to the bus.
+	ID         uint64            `json:"id"`
+	Type       Type              `json:"type"`
+	Time       time.Time         `json:"time"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Subscription receives the events published to the bus since it was
+// created. Events are dropped rather than blocking publishers when the
+// subscriber falls behind.
+type Subscription struct {
+	bus     *Bus
+	types   []Type
+	events  chan Event
+	dropped atomic.Uint64
+	once    sync.Once
+}
+
+// Events returns the channel of the events, closed once the subscription is
+// closed.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped returns the number of events dropped since the subscriber fell
+// behind.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Close stops receiving events.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.bus.unsubscribe(s)
+	})
+}
+
+func (s *Subscription) accepts(t Type) bool {
+	return len(s.types) == 0 || lo.Contains(s.types, t)
+}
+
+// Bus delivers the events published to every subscription.
+type Bus struct {
+	mutex         sync.RWMutex
+	subscriptions map[*Subscription]struct{}
+	lastID        atomic.Uint64
+
+	now func() time.Time
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		subscriptions: make(map[*Subscription]struct{}),
+		now:           time.Now,
+	}
+}
+
+// Subscribe subscribes to the events of the types, all the events if types is
+// empty, buffering up to buffer events.
+func (b *Bus) Subscribe(buffer int, types ...Type) *Subscription {
+	s := &Subscription{
+		bus:    b,
+		types:  types,
+		events: make(chan Event, max(buffer, 0)),
+	}
+
+	b.mutex.Lock()
+	b.subscriptions[s] = struct{}{}
+	b.mutex.Unlock()
+
+	return s
+}
+
+func (b *Bus) unsubscribe(s *Subscription) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	delete(b.subscriptions, s)
+	close(s.events)
+}
+
+// Publish delivers the event to the subscriptions, it never blocks.
+func (b *Bus) Publish(t Type, attributes map[string]string) Event {
+	event := Event{
+		ID:         b.lastID.Add(1),
+		Type:       t,
+		Time:       b.now(),
+		Attributes: attributes,
+	}
+
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	for s := range b.subscriptions {
+		if !s.accepts(t) {
+			continue
+		}
+
+		select {
+		case s.events <- event:
+		default:
+			if s.dropped.Add(1) == 1 {
+				slog.Warn("event subscriber falls behind, events dropped", "type", t)
+			}
+		}
+	}
+
+	return event
+}
+
+var defaultBus = NewBus()
+
+// Default returns the bus of the gateway.
+func Default() *Bus {
+	return defaultBus
+}
+
+// Publish publishes the event to the bus of the gateway.
+func Publish(t Type, attributes map[string]string) Event {
+	return defaultBus.Publish(t, attributes)
+}
+
+// Subscribe subscribes to the events of the bus of the gateway.
+func Subscribe(buffer int, types ...Type) *Subscription {
+	return defaultBus.Subscribe(buffer, types...)
+}
@@ -0,0 +1,63 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBus_Publish(t *testing.T) {
+	bus := NewBus()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	bus.now = func() time.Time { return now }
+
+	all := bus.Subscribe(DefaultSubscriptionBuffer)
+	defer all.Close()
+
+	clusters := bus.Subscribe(DefaultSubscriptionBuffer, TypeClusterRegistered, TypeClusterRemoved)
+	defer clusters.Close()
+
+	bus.Publish(TypeClusterRegistered, map[string]string{"cluster": "gpt-4o"})
+	bus.Publish(TypeRouteChanged, map[string]string{"route": "gpt-4o"})
+
+	event := <-all.Events()
+	assert.Equal(t, Event{ID: 1, Type: TypeClusterRegistered, Time: now, Attributes: map[string]string{"cluster": "gpt-4o"}}, event)
+
+	event = <-all.Events()
+	assert.Equal(t, uint64(2), event.ID)
+	assert.Equal(t, TypeRouteChanged, event.Type)
+
+	event = <-clusters.Events()
+	assert.Equal(t, TypeClusterRegistered, event.Type)
+	assert.Empty(t, clusters.Events())
+}
+
+func TestBus_PublishDropsWhenFull(t *testing.T) {
+	bus := NewBus()
+
+	s := bus.Subscribe(1)
+	defer s.Close()
+
+	bus.Publish(TypeDrainStarted, nil)
+	bus.Publish(TypeDrainStarted, nil)
+	bus.Publish(TypeDrainStarted, nil)
+
+	assert.Equal(t, uint64(2), s.Dropped())
+	assert.Equal(t, uint64(1), (<-s.Events()).ID)
+}
+
+func TestSubscription_Close(t *testing.T) {
+	bus := NewBus()
+
+	s := bus.Subscribe(DefaultSubscriptionBuffer)
+	s.Close()
+	s.Close()
+
+	bus.Publish(TypeDrainStarted, nil)
+
+	_, ok := <-s.Events()
+	require.False(t, ok)
+	assert.Empty(t, bus.subscriptions)
+}
@@ -0,0 +1,188 @@
+// Package featureflag evaluates the feature flags of requests, so that
+// experimental filters and code paths can be enabled for a percent of the
+// traffic and compared with the stable ones. Flags are evaluated once per
+// request by the listeners, and stored in the metadata of the request.
+package featureflag
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"sync/atomic"
+)
+
+// BucketBy is what requests are bucketed by, requests of the same bucket key
+// get the same result of a flag.
+type BucketBy string
+
+const (
+	// BucketByRequest evaluates every request independently.
+	BucketByRequest BucketBy = "request"
+	// BucketByUser keeps the result of a flag for the requests of a user.
+	BucketByUser BucketBy = "user"
+	// BucketByAPIKey keeps the result of a flag for the requests of an API
+	// key.
+	BucketByAPIKey BucketBy = "api_key"
+	// BucketByTenant keeps the result of a flag for the requests of a tenant.
+	BucketByTenant BucketBy = "tenant"
+)
+
+const fullPercent = 100
+
+// Flag is a feature flag enabled for a percent of the traffic.
+type Flag struct {
+	Name string
+	// Percent of the traffic the flag is enabled for, from 0 to 100.
+	Percent uint32
+	// BucketBy defaults to BucketByRequest, requests without the key of the
+	// bucket, e.g. requests without users, are evaluated independently.
+	BucketBy BucketBy
+	// UserIDs are the users the flag is always enabled for, e.g. the users
+	// testing the feature.
+	UserIDs []string
+}
+
+// Subject is who a request is made by, as known after authentication.
+type Subject struct {
+	UserID   string
+	APIKeyID string
+	TenantID string
+}
+
+func (s Subject) bucketKey(bucketBy BucketBy) string {
+	switch bucketBy {
+	case BucketByUser:
+		return s.UserID
+	case BucketByAPIKey:
+		return s.APIKeyID
+	case BucketByTenant:
+		return s.TenantID
+	case BucketByRequest:
+		return ""
+	default:
+		return ""
+	}
+}
+
+func (f Flag) enabled(subject Subject) bool {
+	if subject.UserID != "" && slices.Contains(f.UserIDs, subject.UserID) {
+		return true
+	}
+
+	if f.Percent == 0 {
+		return false
+	}
+
+	if f.Percent >= fullPercent {
+		return true
+	}
+
+	key := subject.bucketKey(f.BucketBy)
+	if key == "" {
+		return rand.Uint32N(fullPercent) < f.Percent //nolint:gosec
+	}
+
+	// Hashed with the name of the flag, so that the same requests don't get
+	// every flag enabled together
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(f.Name + "/" + key))
+
+	return h.Sum32()%fullPercent < f.Percent
+}
+
+// Flags are the results of the feature flags of a request, flags not
+// evaluated are disabled.
+type Flags map[string]bool
+
+// Enabled reports whether the flag is enabled.
+func (f Flags) Enabled(name string) bool {
+	return f[name]
+}
+
+// EnabledNames returns the sorted names of the flags enabled.
+func (f Flags) EnabledNames() []string {
+	names := make([]string, 0, len(f))
+
+	for name, enabled := range f {
+		if enabled {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Evaluator evaluates the flags of requests with the flags configured, or
+// with the flag service when configured, which falls back to the flags
+// configured when it fails.
+type Evaluator struct {
+	flags  []Flag
+	remote *remote
+}
+
+// New creates the Evaluator, nil is returned when neither flags nor the flag
+// service are configured.
+func New(flags []Flag, service *Service) (*Evaluator, error) {
+	if len(flags) == 0 && service == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	e := &Evaluator{flags: flags}
+
+	if service != nil {
+		var err error
+
+		e.remote, err = newRemote(*service)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return e, nil
+}
+
+// Evaluate returns the flags of the subject, nil Evaluator has no flags.
+func (e *Evaluator) Evaluate(ctx context.Context, subject Subject) Flags {
+	if e == nil {
+		return nil
+	}
+
+	flags := make(Flags, len(e.flags))
+	for _, flag := range e.flags {
+		flags[flag.Name] = flag.enabled(subject)
+	}
+
+	if e.remote == nil {
+		return flags
+	}
+
+	remoteFlags, err := e.remote.Evaluate(ctx, subject)
+	if err != nil {
+		slog.Warn("failed to evaluate feature flags with the flag service, using the flags configured", "error", err)
+		return flags
+	}
+
+	for name, enabled := range remoteFlags {
+		flags[name] = enabled
+	}
+
+	return flags
+}
+
+var global atomic.Pointer[Evaluator]
+
+// SetGlobal sets the Evaluator used by the whole gateway.
+func SetGlobal(e *Evaluator) {
+	global.Store(e)
+}
+
+// Global returns the Evaluator used by the whole gateway, nil (no flags) if
+// not configured.
+func Global() *Evaluator {
+	return global.Load()
+}
@@ -0,0 +1,98 @@
+package featureflag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlag_Enabled(t *testing.T) {
+	subject := Subject{UserID: "user_001", APIKeyID: "key_001", TenantID: "acme"}
+
+	assert.False(t, Flag{Name: "off"}.enabled(subject))
+	assert.True(t, Flag{Name: "on", Percent: 100}.enabled(subject))
+	assert.True(t, Flag{Name: "testers", UserIDs: []string{"user_001"}}.enabled(subject))
+	assert.False(t, Flag{Name: "testers", UserIDs: []string{"user_001"}}.enabled(Subject{}))
+
+	// Requests of the same bucket get the same result
+	for _, bucketBy := range []BucketBy{BucketByUser, BucketByAPIKey, BucketByTenant} {
+		flag := Flag{Name: "sticky", Percent: 50, BucketBy: bucketBy}
+		enabled := flag.enabled(subject)
+
+		for range 20 {
+			assert.Equal(t, enabled, flag.enabled(subject))
+		}
+	}
+
+	// Buckets are spread by the percent
+	flag := Flag{Name: "half", Percent: 30, BucketBy: BucketByUser}
+	enabled := 0
+
+	for i := range 10000 {
+		if flag.enabled(Subject{UserID: fmt.Sprintf("user_%d", i)}) {
+			enabled++
+		}
+	}
+
+	assert.InDelta(t, 3000, enabled, 300)
+}
+
+func TestFlags(t *testing.T) {
+	flags := Flags{"b": true, "a": true, "c": false}
+	assert.True(t, flags.Enabled("a"))
+	assert.False(t, flags.Enabled("c"))
+	assert.False(t, flags.Enabled("unknown"))
+	assert.Equal(t, []string{"a", "b"}, flags.EnabledNames())
+
+	var empty Flags
+	assert.False(t, empty.Enabled("a"))
+	assert.Empty(t, empty.EnabledNames())
+}
+
+func TestEvaluator(t *testing.T) {
+	e, err := New(nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, e)
+	assert.Nil(t, e.Evaluate(context.Background(), Subject{}))
+
+	_, err = New(nil, &Service{URL: "flagd:8016"})
+	require.Error(t, err)
+
+	var received map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, ofrepBulkEvaluationPath, request.URL.Path)
+		assert.Equal(t, "Bearer token", request.Header.Get("Authorization"))
+
+		_ = json.NewDecoder(request.Body).Decode(&received)
+		_, _ = writer.Write([]byte(`{"flags": [
+			{"key": "remote", "value": true, "reason": "TARGETING_MATCH"},
+			{"key": "static", "value": false},
+			{"key": "variant", "value": "blue"},
+			{"key": "broken", "errorCode": "PARSE_ERROR"}
+		]}`))
+	}))
+	defer server.Close()
+
+	e, err = New([]Flag{{Name: "static", Percent: 100}, {Name: "local", Percent: 100}}, &Service{
+		URL:     server.URL,
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	})
+	require.NoError(t, err)
+
+	flags := e.Evaluate(context.Background(), Subject{UserID: "user_001", TenantID: "acme"})
+	assert.Equal(t, Flags{"remote": true, "static": false, "local": true}, flags)
+	assert.Equal(t, map[string]any{"context": map[string]any{"targetingKey": "user_001", "userId": "user_001", "tenantId": "acme"}}, received)
+
+	// Falls back to the flags configured
+	server.Close()
+
+	flags = e.Evaluate(context.Background(), Subject{UserID: "user_001"})
+	assert.Equal(t, Flags{"static": true, "local": true}, flags)
+}
@@ -0,0 +1,137 @@
+package featureflag
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+const (
+	defaultServiceTimeout = 200 * time.Millisecond
+	// ofrepBulkEvaluationPath is the path of the bulk evaluation of the
+	// OpenFeature Remote Evaluation Protocol.
+	ofrepBulkEvaluationPath = "/ofrep/v1/evaluate/flags"
+	serviceMaxBodySize      = 1 << 20
+)
+
+// Service is a flag service implementing the bulk evaluation of the
+// OpenFeature Remote Evaluation Protocol (OFREP), e.g. flagd, GO Feature Flag
+// and flipt. Only boolean flags are used.
+type Service struct {
+	URL string
+	// Timeout of the evaluation, requests wait on it, defaults to 200ms.
+	Timeout time.Duration
+	// Headers sent with the evaluations, e.g. Authorization.
+	Headers map[string]string
+}
+
+type remote struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func newRemote(service Service) (*remote, error) {
+	u, err := url.Parse(service.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url of flag service: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid url of flag service: unsupported scheme of url %q", service.URL)
+	}
+
+	return &remote{
+		url:     strings.TrimSuffix(service.URL, "/") + ofrepBulkEvaluationPath,
+		headers: service.Headers,
+		client:  &http.Client{Timeout: lo.CoalesceOrEmpty(service.Timeout, defaultServiceTimeout)},
+	}, nil
+}
+
+type ofrepEvaluationRequest struct {
+	Context map[string]string `json:"context"`
+}
+
+type ofrepEvaluationResponse struct {
+	Flags []struct {
+		Key       string `json:"key"`
+		Value     any    `json:"value"`
+		ErrorCode string `json:"errorCode"`
+	} `json:"flags"`
+}
+
+func (r *remote) Evaluate(ctx context.Context, subject Subject) (Flags, error) {
+	evaluationContext := map[string]string{
+		// The targeting key is what flag services bucket by
+		"targetingKey": lo.CoalesceOrEmpty(subject.UserID, subject.APIKeyID),
+	}
+
+	for key, value := range map[string]string{"userId": subject.UserID, "apiKeyId": subject.APIKeyID, "tenantId": subject.TenantID} {
+		if value != "" {
+			evaluationContext[key] = value
+		}
+	}
+
+	body, err := json.Marshal(ofrepEvaluationRequest{Context: evaluationContext})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	for key, value := range r.headers {
+		request.Header.Set(key, value)
+	}
+
+	response, err := r.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+
+	var evaluation ofrepEvaluationResponse
+
+	err = json.NewDecoder(io.LimitReader(response.Body, serviceMaxBodySize)).Decode(&evaluation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode evaluation: %w", err)
+	}
+
+	if evaluation.Flags == nil {
+		return nil, errors.New("missing flags in evaluation")
+	}
+
+	flags := make(Flags, len(evaluation.Flags))
+
+	for _, flag := range evaluation.Flags {
+		if flag.ErrorCode != "" {
+			continue
+		}
+
+		if enabled, ok := flag.Value.(bool); ok {
+			flags[flag.Key] = enabled
+		}
+	}
+
+	return flags, nil
+}
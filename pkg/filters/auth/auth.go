@@ -25,6 +25,7 @@ import (
 	"knoway.dev/pkg/filters"
 	"knoway.dev/pkg/metadata"
 	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/priority"
 	"knoway.dev/pkg/protoutils"
 )
 
@@ -38,6 +39,32 @@ func NewWithConfig(cfg *anypb.Any, lifecycle bootkit.LifeCycle) (filters.Request
 		return nil, fmt.Errorf("invalid config type %T", cfg)
 	}
 
+	maxPriorities := make(map[string]priority.Priority, len(c.GetMaxPriorities()))
+
+	for tier, class := range c.GetMaxPriorities() {
+		maxPriorities[tier], err = priority.Parse(class)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max priority of tier %s: %w", tier, err)
+		}
+	}
+
+	if c.GetJwt() != nil {
+		if c.GetAuthServer().GetUrl() != "" {
+			return nil, errors.New("auth server and jwt are exclusive")
+		}
+
+		jwt, err := newJWTAuthenticator(c.GetJwt())
+		if err != nil {
+			return nil, err
+		}
+
+		return &AuthFilter{
+			config:        c,
+			jwt:           jwt,
+			maxPriorities: maxPriorities,
+		}, nil
+	}
+
 	address := c.GetAuthServer().GetUrl()
 	if address == "" {
 		return nil, errors.New("invalid auth server url")
@@ -61,9 +88,10 @@ func NewWithConfig(cfg *anypb.Any, lifecycle bootkit.LifeCycle) (filters.Request
 	authClient := service.NewAuthServiceClient(conn)
 
 	return &AuthFilter{
-		config:     c,
-		conn:       conn,
-		authClient: authClient,
+		config:        c,
+		conn:          conn,
+		authClient:    authClient,
+		maxPriorities: maxPriorities,
 	}, nil
 }
 
@@ -71,13 +99,20 @@ var _ filters.RequestFilter = (*AuthFilter)(nil)
 var _ filters.OnRequestPreFilter = (*AuthFilter)(nil)
 var _ filters.OnCompletionRequestFilter = (*AuthFilter)(nil)
 var _ filters.OnImageGenerationsRequestFilter = (*AuthFilter)(nil)
+var _ filters.OnModerationsRequestFilter = (*AuthFilter)(nil)
+var _ filters.OnEmbeddingsRequestFilter = (*AuthFilter)(nil)
+var _ filters.OnRerankRequestFilter = (*AuthFilter)(nil)
+var _ filters.OnVideoGenerationsRequestFilter = (*AuthFilter)(nil)
+var _ filters.OnRealtimeRequestFilter = (*AuthFilter)(nil)
 
 type AuthFilter struct {
 	filters.IsRequestFilter
 
-	config     *v1alpha1.APIKeyAuthConfig
-	conn       *grpc.ClientConn
-	authClient service.AuthServiceClient
+	config        *v1alpha1.APIKeyAuthConfig
+	conn          *grpc.ClientConn
+	authClient    service.AuthServiceClient
+	jwt           *jwtAuthenticator
+	maxPriorities map[string]priority.Priority
 }
 
 func (a *AuthFilter) OnRequestPre(ctx context.Context, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
@@ -92,6 +127,32 @@ func (a *AuthFilter) OnRequestPre(ctx context.Context, sourceHTTPRequest *http.R
 		return filters.NewFailed(object.NewErrorMissingAPIKey())
 	}
 
+	var response *service.APIKeyAuthResponse
+	if a.jwt != nil {
+		response, err = a.authenticateJWT(ctx, apiKey)
+	} else {
+		response, err = a.authenticateAPIKey(ctx, apiKey)
+	}
+
+	if err != nil {
+		return filters.NewFailed(err)
+	}
+
+	rMeta.AuthInfo = response
+
+	if !response.GetIsValid() {
+		slog.Debug("auth filter: user apikey invalid", "user", response.GetUserId())
+		return filters.NewFailed(object.NewErrorIncorrectAPIKey(apiKey))
+	}
+
+	slog.Debug("auth filter: user authorization succeeds", "user", response.GetUserId(), "allow models", response.GetAllowModels())
+
+	rMeta.Priority = rMeta.Priority.Cap(a.maxPriority(response.GetTier()))
+
+	return filters.NewOK()
+}
+
+func (a *AuthFilter) authenticateAPIKey(ctx context.Context, apiKey string) (*service.APIKeyAuthResponse, error) {
 	getAuthCtx, cancel := context.WithTimeout(ctx, a.config.GetAuthServer().GetTimeout().AsDuration())
 	defer cancel()
 
@@ -105,75 +166,88 @@ func (a *AuthFilter) OnRequestPre(ctx context.Context, sourceHTTPRequest *http.R
 		s, ok := status.FromError(err)
 		if !ok {
 			slog.Error("auth filter: APIKeyAuth error: %s", "error", err)
-			return filters.NewFailed(err)
+			return nil, err
 		}
 
 		switch s.Code() { //nolint:exhaustive
 		case codes.NotFound:
 			slog.Debug("auth filter: user apikey not found", "apikey", apiKey)
-			return filters.NewFailed(object.NewErrorIncorrectAPIKey(apiKey))
+			return nil, object.NewErrorIncorrectAPIKey(apiKey)
 		case codes.Unauthenticated:
 			slog.Debug("auth filter: user apikey invalid", "apikey", apiKey)
-			return filters.NewFailed(object.NewErrorIncorrectAPIKey(apiKey))
+			return nil, object.NewErrorIncorrectAPIKey(apiKey)
 		case codes.PermissionDenied:
 			slog.Debug("auth filter: user apikey permission denied", "apikey", apiKey)
-			return filters.NewFailed(object.NewErrorIncorrectAPIKey(apiKey))
+			return nil, object.NewErrorIncorrectAPIKey(apiKey)
 		case codes.Unavailable:
 			slog.Debug("auth filter: user apikey service unavailable", "apikey", apiKey)
-			return filters.NewFailed(object.NewErrorServiceUnavailable())
+			return nil, object.NewErrorServiceUnavailable()
 		default:
 			slog.Error("auth filter: APIKeyAuth error: %s", "error", err)
-			return filters.NewFailed(err)
+			return nil, err
 		}
 	}
 
-	rMeta.AuthInfo = response
+	return response, nil
+}
 
-	if !response.GetIsValid() {
-		slog.Debug("auth filter: user apikey invalid", "user", response.GetUserId())
-		return filters.NewFailed(object.NewErrorIncorrectAPIKey(apiKey))
-	}
+// authenticateJWT validates the token instead of calling the auth server.
+func (a *AuthFilter) authenticateJWT(ctx context.Context, token string) (*service.APIKeyAuthResponse, error) {
+	response, err := a.jwt.Authenticate(ctx, token)
+	if err != nil {
+		if errors.Is(err, errInvalidToken) {
+			slog.Debug("auth filter: user jwt invalid", "error", err)
+			return nil, object.NewErrorIncorrectAPIKey(redactToken(token))
+		}
 
-	slog.Debug("auth filter: user authorization succeeds", "user", response.GetUserId(), "allow models", response.GetAllowModels())
+		slog.Error("auth filter: failed to validate jwt", "error", err)
 
-	return filters.NewOK()
+		return nil, object.NewErrorServiceUnavailable()
+	}
+
+	return response, nil
 }
 
-func (a *AuthFilter) OnCompletionRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
-	rMeta := metadata.RequestMetadataFromCtx(ctx)
-	if rMeta.AuthInfo == nil {
-		return filters.NewFailed(errors.New("missing auth info in context"))
+// maxPriority returns the highest priority the tier is allowed to request,
+// tiers not configured are capped at normal.
+func (a *AuthFilter) maxPriority(tier string) priority.Priority {
+	maxPriority, ok := a.maxPriorities[tier]
+	if !ok {
+		return priority.Normal
 	}
 
-	authInfo := rMeta.AuthInfo
+	return maxPriority
+}
 
-	accessModel := request.GetModel()
-	if accessModel == "" {
-		slog.Debug("auth filter: user model not found", "user", authInfo.GetUserId())
-		return filters.NewFailed(object.NewErrorMissingModel())
-	}
+func (a *AuthFilter) OnCompletionRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return a.onModelRequest(ctx, request)
+}
 
-	denied := IsDenied(accessModel, authInfo.GetDenyModels())
-	granted := IsGranted(accessModel, authInfo.GetAllowModels())
+func (a *AuthFilter) OnImageGenerationsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return a.onModelRequest(ctx, request)
+}
 
-	if !CanAccessModelFromValues(denied, granted) {
-		if denied {
-			slog.Debug("auth filter: user access model is denied", "user", authInfo.GetUserId(), "model", accessModel)
-			return filters.NewFailed(object.NewErrorModelAccessDenied(accessModel))
-		}
+func (a *AuthFilter) OnModerationsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return a.onModelRequest(ctx, request)
+}
 
-		if !granted {
-			slog.Debug("auth filter: user can not access model", "user", authInfo.GetUserId(), "model", accessModel)
-			return filters.NewFailed(object.NewErrorModelNotFoundOrNotAccessible(accessModel))
-		}
-	}
+func (a *AuthFilter) OnEmbeddingsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return a.onModelRequest(ctx, request)
+}
 
-	slog.Debug("auth filter: user authorization succeeds", "user", authInfo.GetUserId(), "allow models", authInfo.GetAllowModels())
+func (a *AuthFilter) OnRerankRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return a.onModelRequest(ctx, request)
+}
 
-	return filters.NewOK()
+func (a *AuthFilter) OnVideoGenerationsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return a.onModelRequest(ctx, request)
 }
 
-func (a *AuthFilter) OnImageGenerationsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+func (a *AuthFilter) OnRealtimeRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return a.onModelRequest(ctx, request)
+}
+
+func (a *AuthFilter) onModelRequest(ctx context.Context, request object.LLMRequest) filters.RequestFilterResult {
 	rMeta := metadata.RequestMetadataFromCtx(ctx)
 	if rMeta.AuthInfo == nil {
 		return filters.NewFailed(errors.New("missing auth info in context"))
@@ -0,0 +1,562 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"golang.org/x/sync/singleflight"
+
+	"knoway.dev/api/filters/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+)
+
+const (
+	defaultJWKSCacheDuration = 10 * time.Minute
+	defaultJWTClockSkew      = time.Minute
+	defaultJWKSFetchTimeout  = 10 * time.Second
+	// jwksMinRefreshInterval limits how often the keys are fetched again, so
+	// that neither forged tokens nor outages of the provider make every
+	// request wait on it.
+	jwksMinRefreshInterval = 30 * time.Second
+	// jwksMaxBodySize limits the size of the responses of the provider.
+	jwksMaxBodySize = 1 << 20
+
+	defaultUserIDClaim   = "sub"
+	defaultAPIKeyIDClaim = "jti"
+)
+
+// errInvalidToken is returned for the tokens which are malformed, expired or
+// not signed by the provider.
+var errInvalidToken = errors.New("invalid token")
+
+// jwtAuthenticator validates JSON Web Tokens and maps their claims to the
+// auth info the auth server would return for API keys.
+type jwtAuthenticator struct {
+	config *v1alpha1.APIKeyAuthConfig_JWT
+	keys   *jwks
+	now    func() time.Time
+}
+
+func newJWTAuthenticator(config *v1alpha1.APIKeyAuthConfig_JWT) (*jwtAuthenticator, error) {
+	if config.GetIssuer() == "" {
+		return nil, errors.New("issuer of jwt is required")
+	}
+
+	cacheDuration := defaultJWKSCacheDuration
+	if config.GetJwksCacheDuration().AsDuration() > 0 {
+		cacheDuration = config.GetJwksCacheDuration().AsDuration()
+	}
+
+	return &jwtAuthenticator{
+		config: config,
+		keys: &jwks{
+			client:        &http.Client{Timeout: defaultJWKSFetchTimeout},
+			issuer:        config.GetIssuer(),
+			url:           config.GetJwksUrl(),
+			cacheDuration: cacheDuration,
+			now:           time.Now,
+		},
+		now: time.Now,
+	}, nil
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Authenticate validates the token and returns the auth info mapped from its
+// claims, errors wrapping errInvalidToken are caused by the token.
+func (a *jwtAuthenticator) Authenticate(ctx context.Context, token string) (*service.APIKeyAuthResponse, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 { //nolint:mnd
+		return nil, fmt.Errorf("%w: malformed", errInvalidToken)
+	}
+
+	var header jwtHeader
+
+	err := decodeJWTSegment(parts[0], &header)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed header: %w", errInvalidToken, err)
+	}
+
+	var claims map[string]any
+
+	err = decodeJWTSegment(parts[1], &claims)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed claims: %w", errInvalidToken, err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature: %w", errInvalidToken, err)
+	}
+
+	key, err := a.keys.Get(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.alg != "" && key.alg != header.Alg {
+		return nil, fmt.Errorf("%w: algorithm %s doesn't match the key", errInvalidToken, header.Alg)
+	}
+
+	err = verifyJWTSignature(header.Alg, key.key, []byte(parts[0]+"."+parts[1]), signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidToken, err)
+	}
+
+	err = a.validateClaims(claims)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidToken, err)
+	}
+
+	mapping := a.config.GetClaims()
+
+	return &service.APIKeyAuthResponse{
+		IsValid:     true,
+		UserId:      claimString(claims, lo.CoalesceOrEmpty(mapping.GetUserId(), defaultUserIDClaim)),
+		ApiKeyId:    claimString(claims, lo.CoalesceOrEmpty(mapping.GetApiKeyId(), defaultAPIKeyIDClaim)),
+		AllowModels: claimStrings(claims, mapping.GetAllowModels()),
+		DenyModels:  claimStrings(claims, mapping.GetDenyModels()),
+		Tier:        claimString(claims, mapping.GetTier()),
+		TenantId:    claimString(claims, mapping.GetTenantId()),
+	}, nil
+}
+
+// redactToken keeps the ends of the token only, tokens are too long and too
+// sensitive to be echoed in errors.
+func redactToken(token string) string {
+	const visible = 4
+	if len(token) <= 2*visible {
+		return strings.Repeat("*", len(token))
+	}
+
+	return token[:visible] + "***" + token[len(token)-visible:]
+}
+
+func (a *jwtAuthenticator) validateClaims(claims map[string]any) error {
+	if issuer := claimString(claims, "iss"); issuer != a.config.GetIssuer() {
+		return fmt.Errorf("unexpected issuer %q", issuer)
+	}
+
+	if len(a.config.GetAudiences()) > 0 {
+		audiences := claimStrings(claims, "aud")
+		if !lo.SomeBy(a.config.GetAudiences(), func(audience string) bool { return slices.Contains(audiences, audience) }) {
+			return fmt.Errorf("unexpected audiences %v", audiences)
+		}
+	}
+
+	clockSkew := defaultJWTClockSkew
+	if a.config.GetClockSkew() != nil {
+		clockSkew = a.config.GetClockSkew().AsDuration()
+	}
+
+	now := a.now()
+
+	expiresAt, ok := claims["exp"].(float64)
+	if !ok {
+		return errors.New("missing exp claim")
+	}
+
+	if now.After(time.Unix(int64(expiresAt), 0).Add(clockSkew)) {
+		return errors.New("token expired")
+	}
+
+	if notBefore, ok := claims["nbf"].(float64); ok && now.Add(clockSkew).Before(time.Unix(int64(notBefore), 0)) {
+		return errors.New("token not valid yet")
+	}
+
+	return nil
+}
+
+func decodeJWTSegment(segment string, v any) error {
+	bs, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(bs, v)
+}
+
+// jwtCurves are the curves of the ECDSA algorithms, see RFC 7518 3.4.
+var jwtCurves = map[string]string{
+	"ES256": "P-256",
+	"ES384": "P-384",
+	"ES512": "P-521",
+}
+
+func verifyJWTSignature(alg string, key crypto.PublicKey, signed []byte, signature []byte) error {
+	var hash crypto.Hash
+
+	switch alg[len(alg)-min(len(alg), 3):] { //nolint:mnd
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	case "512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+
+	hasher := hash.New()
+	_, _ = hasher.Write(signed)
+	digest := hasher.Sum(nil)
+
+	switch {
+	case strings.HasPrefix(alg, "RS"):
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("algorithm %s doesn't match the key", alg)
+		}
+
+		return rsa.VerifyPKCS1v15(rsaKey, hash, digest, signature)
+	case strings.HasPrefix(alg, "PS"):
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("algorithm %s doesn't match the key", alg)
+		}
+
+		return rsa.VerifyPSS(rsaKey, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case strings.HasPrefix(alg, "ES"):
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("algorithm %s doesn't match the key", alg)
+		}
+
+		// The curve is bound to the algorithm, e.g. ES256 is only signed
+		// with P-256
+		if ecKey.Curve.Params().Name != jwtCurves[alg] {
+			return fmt.Errorf("algorithm %s doesn't match the curve of the key", alg)
+		}
+
+		// Signatures are the concatenation of r and s of the size of the
+		// curve
+		size := (ecKey.Curve.Params().BitSize + 7) / 8 //nolint:mnd
+		if len(signature) != 2*size {
+			return errors.New("invalid signature")
+		}
+
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			return errors.New("invalid signature")
+		}
+
+		return nil
+	default:
+		// none and HMAC algorithms are not accepted, the keys of the
+		// provider are public
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+}
+
+// claimValue returns the claim of the name, names of nested claims are
+// separated by dots, e.g. realm_access.roles.
+func claimValue(claims map[string]any, name string) any {
+	if name == "" {
+		return nil
+	}
+
+	if value, ok := claims[name]; ok {
+		return value
+	}
+
+	var current any = claims
+
+	for _, segment := range strings.Split(name, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		current = m[segment]
+	}
+
+	return current
+}
+
+func claimString(claims map[string]any, name string) string {
+	switch value := claimValue(claims, name).(type) {
+	case string:
+		return value
+	case float64:
+		return big.NewFloat(value).Text('f', -1)
+	default:
+		return ""
+	}
+}
+
+func claimStrings(claims map[string]any, name string) []string {
+	switch value := claimValue(claims, name).(type) {
+	case string:
+		return strings.Fields(value)
+	case []any:
+		return lo.FilterMap(value, func(item any, _ int) (string, bool) {
+			s, ok := item.(string)
+			return s, ok
+		})
+	default:
+		return nil
+	}
+}
+
+type jwk struct {
+	key crypto.PublicKey
+	alg string
+}
+
+// jwks caches the JSON Web Key Set of the provider.
+type jwks struct {
+	client        *http.Client
+	issuer        string
+	url           string
+	cacheDuration time.Duration
+	now           func() time.Time
+
+	// group shares a fetch between the requests waiting on the keys, which
+	// are fetched outside of the mutex, so that the requests with cached keys
+	// never wait on the provider.
+	group singleflight.Group
+
+	mutex       sync.Mutex
+	keys        map[string]jwk
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	fetchErr    error
+}
+
+// Get returns the key of the kid, the keys are fetched again when they are
+// stale or the kid is unknown, at most once per jwksMinRefreshInterval. Stale
+// keys are kept when they can't be fetched.
+func (k *jwks) Get(ctx context.Context, kid string) (jwk, error) {
+	if k.shouldRefresh(kid) {
+		_, _, _ = k.group.Do("", func() (any, error) {
+			// Refreshed by the fetch that was in progress
+			if !k.shouldRefresh(kid) {
+				return nil, nil
+			}
+
+			k.refresh(ctx)
+
+			return nil, nil
+		})
+	}
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if k.keys == nil {
+		return jwk{}, k.fetchErr
+	}
+
+	key, ok := k.keys[kid]
+	if !ok && kid == "" && len(k.keys) == 1 {
+		// Tokens without kid are signed by the only key
+		key, ok = lo.Values(k.keys)[0], true
+	}
+
+	if !ok {
+		return jwk{}, fmt.Errorf("%w: unknown key %q", errInvalidToken, kid)
+	}
+
+	return key, nil
+}
+
+func (k *jwks) shouldRefresh(kid string) bool {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	now := k.now()
+
+	_, known := k.keys[kid]
+	stale := k.keys == nil || now.Sub(k.fetchedAt) > k.cacheDuration
+
+	return (stale || !known) && now.Sub(k.attemptedAt) > jwksMinRefreshInterval
+}
+
+func (k *jwks) refresh(ctx context.Context) {
+	k.mutex.Lock()
+	k.attemptedAt = k.now()
+	url := k.url
+	k.mutex.Unlock()
+
+	keys, url, err := k.fetch(ctx, url)
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	k.fetchErr = err
+	if err != nil {
+		if k.keys != nil {
+			slog.Warn("auth filter: failed to fetch jwks, using cached keys", "error", err)
+		}
+
+		return
+	}
+
+	k.url = url
+	k.keys = keys
+	k.fetchedAt = k.now()
+}
+
+// fetch gets the keys from the url, which is discovered from the openid
+// configuration of the issuer if empty.
+func (k *jwks) fetch(ctx context.Context, url string) (map[string]jwk, string, error) {
+	// The fetch is shared by the requests waiting on it, it must not be
+	// cancelled by the client of the first one
+	ctx = context.WithoutCancel(ctx)
+
+	if url == "" {
+		var configuration struct {
+			JWKSURI string `json:"jwks_uri"`
+		}
+
+		err := k.getJSON(ctx, strings.TrimSuffix(k.issuer, "/")+"/.well-known/openid-configuration", &configuration)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to discover openid configuration: %w", err)
+		}
+
+		if configuration.JWKSURI == "" {
+			return nil, "", errors.New("missing jwks_uri in openid configuration")
+		}
+
+		url = configuration.JWKSURI
+	}
+
+	var set struct {
+		Keys []jwkJSON `json:"keys"`
+	}
+
+	err := k.getJSON(ctx, url, &set)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+
+	keys := make(map[string]jwk, len(set.Keys))
+
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := key.publicKey()
+		if err != nil {
+			slog.Warn("auth filter: skipped invalid key of jwks", "kid", key.Kid, "error", err)
+			continue
+		}
+
+		keys[key.Kid] = jwk{key: publicKey, alg: key.Alg}
+	}
+
+	return keys, url, nil
+}
+
+func (k *jwks) getJSON(ctx context.Context, url string, v any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := k.client.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+
+	return json.NewDecoder(io.LimitReader(response.Body, jwksMaxBodySize)).Decode(v)
+}
+
+type jwkJSON struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (j jwkJSON) publicKey() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid n: %w", err)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid e: %w", err)
+		}
+
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > int64(^uint32(0)>>1) {
+			return nil, errors.New("invalid e")
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", j.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x: %w", err)
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(j.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y: %w", err)
+		}
+
+		// Uncompressed form of the point
+		size := (curve.Params().BitSize + 7) / 8 //nolint:mnd
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("invalid size of coordinates")
+		}
+
+		return ecdsa.ParseUncompressedPublicKey(curve, append(append([]byte{4}, x...), y...))
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", j.Kty)
+	}
+}
@@ -0,0 +1,374 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+)
+
+type testProvider struct {
+	server      *httptest.Server
+	rsaKey      *rsa.PrivateKey
+	ecKey       *ecdsa.PrivateKey
+	jwksFetches atomic.Int32
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ecPublicKey, err := ecKey.PublicKey.Bytes()
+	require.NoError(t, err)
+
+	p := &testProvider{rsaKey: rsaKey, ecKey: ecKey}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(writer http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(writer).Encode(map[string]any{"issuer": p.server.URL, "jwks_uri": p.server.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(writer http.ResponseWriter, _ *http.Request) {
+		p.jwksFetches.Add(1)
+
+		_ = json.NewEncoder(writer).Encode(map[string]any{"keys": []map[string]any{
+			{
+				"kty": "RSA",
+				"kid": "rsa",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+			},
+			{
+				"kty": "EC",
+				"kid": "ec",
+				"crv": "P-256",
+				"x":   base64.RawURLEncoding.EncodeToString(ecPublicKey[1:33]),
+				"y":   base64.RawURLEncoding.EncodeToString(ecPublicKey[33:]),
+			},
+			{
+				// Encryption keys are ignored
+				"kty": "RSA",
+				"kid": "enc",
+				"use": "enc",
+				"n":   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+				"e":   "AQAB",
+			},
+		}})
+	})
+
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	return p
+}
+
+func (p *testProvider) sign(t *testing.T, alg string, kid string, claims map[string]any) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]any{"alg": alg, "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := crypto.SHA256.New()
+	_, _ = digest.Write([]byte(signed))
+
+	var signature []byte
+
+	switch alg {
+	case "RS256":
+		signature, err = rsa.SignPKCS1v15(rand.Reader, p.rsaKey, crypto.SHA256, digest.Sum(nil))
+		require.NoError(t, err)
+	case "ES256":
+		r, s, err := ecdsa.Sign(rand.Reader, p.ecKey, digest.Sum(nil))
+		require.NoError(t, err)
+
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (p *testProvider) claims() map[string]any {
+	return map[string]any{
+		"iss":    p.server.URL,
+		"aud":    []string{"knoway", "other"},
+		"sub":    "user_001",
+		"jti":    "token_001",
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"models": "gpt-4o kebe/*",
+		"tier":   "enterprise",
+		"organization": map[string]any{
+			"id": "tenant-a",
+		},
+	}
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	provider := newTestProvider(t)
+
+	authenticator, err := newJWTAuthenticator(&v1alpha1.APIKeyAuthConfig_JWT{
+		Issuer:    provider.server.URL,
+		Audiences: []string{"knoway"},
+		Claims: &v1alpha1.APIKeyAuthConfig_JWT_Claims{
+			AllowModels: "models",
+			Tier:        "tier",
+			TenantId:    "organization.id",
+		},
+	})
+	require.NoError(t, err)
+
+	response, err := authenticator.Authenticate(context.Background(), provider.sign(t, "RS256", "rsa", provider.claims()))
+	require.NoError(t, err)
+	assert.True(t, response.GetIsValid())
+	assert.Equal(t, "user_001", response.GetUserId())
+	assert.Equal(t, "token_001", response.GetApiKeyId())
+	assert.Equal(t, []string{"gpt-4o", "kebe/*"}, response.GetAllowModels())
+	assert.Empty(t, response.GetDenyModels())
+	assert.Equal(t, "enterprise", response.GetTier())
+	assert.Equal(t, "tenant-a", response.GetTenantId())
+
+	response, err = authenticator.Authenticate(context.Background(), provider.sign(t, "ES256", "ec", provider.claims()))
+	require.NoError(t, err)
+	assert.Equal(t, "user_001", response.GetUserId())
+
+	// Keys are cached
+	assert.Equal(t, int32(1), provider.jwksFetches.Load())
+
+	invalid := map[string]func(claims map[string]any) string{
+		"expired": func(claims map[string]any) string {
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			return provider.sign(t, "RS256", "rsa", claims)
+		},
+		"not valid yet": func(claims map[string]any) string {
+			claims["nbf"] = time.Now().Add(time.Hour).Unix()
+			return provider.sign(t, "RS256", "rsa", claims)
+		},
+		"missing exp": func(claims map[string]any) string {
+			delete(claims, "exp")
+			return provider.sign(t, "RS256", "rsa", claims)
+		},
+		"issuer": func(claims map[string]any) string {
+			claims["iss"] = "https://evil.example.com"
+			return provider.sign(t, "RS256", "rsa", claims)
+		},
+		"audience": func(claims map[string]any) string {
+			claims["aud"] = "other"
+			return provider.sign(t, "RS256", "rsa", claims)
+		},
+		"algorithm of key": func(claims map[string]any) string {
+			return provider.sign(t, "ES256", "rsa", claims)
+		},
+		"encryption key": func(claims map[string]any) string {
+			return provider.sign(t, "RS256", "enc", claims)
+		},
+		"signature": func(claims map[string]any) string {
+			token := provider.sign(t, "RS256", "rsa", claims)
+			claims["sub"] = "admin"
+			forged := provider.sign(t, "RS256", "rsa", claims)
+
+			return strings.Join(append(strings.Split(forged, ".")[:2], strings.Split(token, ".")[2]), ".")
+		},
+		"none algorithm": func(claims map[string]any) string {
+			token := provider.sign(t, "none", "rsa", claims)
+			return strings.TrimSuffix(token, ".") + "."
+		},
+		"malformed": func(claims map[string]any) string {
+			return "not-a-jwt"
+		},
+	}
+
+	for name, token := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := authenticator.Authenticate(context.Background(), token(provider.claims()))
+			require.ErrorIs(t, err, errInvalidToken)
+		})
+	}
+}
+
+func TestJWKS_Refresh(t *testing.T) {
+	provider := newTestProvider(t)
+
+	now := time.Now()
+	keys := &jwks{
+		client:        http.DefaultClient,
+		url:           provider.server.URL + "/keys",
+		cacheDuration: time.Hour,
+		now:           func() time.Time { return now },
+	}
+
+	_, err := keys.Get(context.Background(), "rsa")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), provider.jwksFetches.Load())
+
+	// Unknown keys are fetched again at most once per interval
+	_, err = keys.Get(context.Background(), "unknown")
+	require.ErrorIs(t, err, errInvalidToken)
+	assert.Equal(t, int32(1), provider.jwksFetches.Load())
+
+	now = now.Add(jwksMinRefreshInterval + time.Second)
+
+	_, err = keys.Get(context.Background(), "unknown")
+	require.ErrorIs(t, err, errInvalidToken)
+	assert.Equal(t, int32(2), provider.jwksFetches.Load())
+
+	// Stale keys are kept when the provider is unavailable
+	provider.server.Close()
+
+	now = now.Add(2 * time.Hour)
+
+	_, err = keys.Get(context.Background(), "rsa")
+	require.NoError(t, err)
+}
+
+func TestJWKS_RefreshOutsideLock(t *testing.T) {
+	provider := newTestProvider(t)
+
+	release := make(chan struct{})
+	fetches := atomic.Int32{}
+
+	// The provider hangs from the second fetch until released
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if fetches.Add(1) > 1 {
+			<-release
+		}
+
+		provider.server.Config.Handler.ServeHTTP(writer, request)
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	keys := &jwks{
+		client:        http.DefaultClient,
+		url:           server.URL + "/keys",
+		cacheDuration: time.Hour,
+		now:           func() time.Time { return now },
+	}
+
+	_, err := keys.Get(context.Background(), "rsa")
+	require.NoError(t, err)
+
+	now = now.Add(jwksMinRefreshInterval + time.Second)
+
+	errs := make(chan error, 2)
+
+	for range 2 {
+		go func() {
+			_, err := keys.Get(context.Background(), "unknown")
+			errs <- err
+		}()
+	}
+
+	require.Eventually(t, func() bool { return fetches.Load() == 2 }, time.Second, time.Millisecond)
+
+	// Cached keys are served while the keys are fetched
+	_, err = keys.Get(context.Background(), "rsa")
+	require.NoError(t, err)
+
+	close(release)
+
+	for range 2 {
+		require.ErrorIs(t, <-errs, errInvalidToken)
+	}
+
+	// The requests waiting on the keys shared the fetch
+	assert.Equal(t, int32(2), fetches.Load())
+}
+
+func TestVerifyJWTSignature_Curve(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signed := []byte("header.payload")
+	digest := crypto.SHA384.New()
+	_, _ = digest.Write(signed)
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest.Sum(nil))
+	require.NoError(t, err)
+
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+
+	// Valid with the key, but ES384 is only signed with P-384
+	err = verifyJWTSignature("ES384", &key.PublicKey, signed, signature)
+	require.EqualError(t, err, "algorithm ES384 doesn't match the curve of the key")
+}
+
+func TestAuthFilter_JWT(t *testing.T) {
+	provider := newTestProvider(t)
+
+	_, err := NewWithConfig(newAnyConfig(t, &v1alpha1.APIKeyAuthConfig{
+		AuthServer: &v1alpha1.APIKeyAuthConfig_AuthServer{Url: "localhost:8083"},
+		Jwt:        &v1alpha1.APIKeyAuthConfig_JWT{Issuer: provider.server.URL},
+	}), bootkit.NewEmptyLifeCycle())
+	require.EqualError(t, err, "auth server and jwt are exclusive")
+
+	f, err := NewWithConfig(newAnyConfig(t, &v1alpha1.APIKeyAuthConfig{
+		Jwt: &v1alpha1.APIKeyAuthConfig_JWT{
+			Issuer: provider.server.URL,
+			Claims: &v1alpha1.APIKeyAuthConfig_JWT_Claims{AllowModels: "models"},
+		},
+	}), bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+
+	authFilter, ok := f.(*AuthFilter)
+	require.True(t, ok)
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	request.Header.Set("Authorization", "Bearer "+provider.sign(t, "RS256", "rsa", provider.claims()))
+
+	ctx := metadata.InitMetadataContext(request)
+
+	result := authFilter.OnRequestPre(ctx, request)
+	require.NoError(t, result.Error)
+	assert.Equal(t, "user_001", metadata.RequestMetadataFromCtx(ctx).AuthInfo.GetUserId())
+	assert.Equal(t, []string{"gpt-4o", "kebe/*"}, metadata.RequestMetadataFromCtx(ctx).AuthInfo.GetAllowModels())
+
+	claims := provider.claims()
+	claims["exp"] = time.Now().Add(-time.Hour).Unix()
+	token := provider.sign(t, "RS256", "rsa", claims)
+	request.Header.Set("Authorization", "Bearer "+token)
+
+	result = authFilter.OnRequestPre(metadata.InitMetadataContext(request), request)
+
+	var llmErr *object.BaseLLMError
+	require.ErrorAs(t, result.Error, &llmErr)
+	assert.Equal(t, http.StatusUnauthorized, llmErr.GetStatus())
+	assert.NotContains(t, llmErr.Error(), token)
+}
+
+func newAnyConfig(t *testing.T, config *v1alpha1.APIKeyAuthConfig) *anypb.Any {
+	t.Helper()
+
+	cfg, err := anypb.New(config)
+	require.NoError(t, err)
+
+	return cfg
+}
@@ -0,0 +1,315 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/observation"
+	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/types/openai"
+)
+
+const (
+	defaultTTL                 = 10 * time.Minute
+	defaultMaxEntries          = 1000
+	defaultSimilarityThreshold = 0.95
+	defaultServerPrefix        = "knoway-response-cache"
+
+	// embeddedTTL covers the time of requests from being looked up to
+	// getting responded
+	embeddedTTL = 5 * time.Minute
+)
+
+// ignoredBodyKeys don't change the content of responses, requests differing
+// only in them share the cache.
+var ignoredBodyKeys = []string{"stream", "stream_options", "user"}
+
+// ResponseCache serves non-streaming chat completion responses from a cache,
+// keyed by the normalized request body (EXACT mode) or by the similarity of
+// the embeddings of the messages (SEMANTIC mode).
+type ResponseCache struct {
+	filters.IsRequestFilter
+
+	mode                v1alpha1.ResponseCacheMode
+	ttl                 time.Duration
+	similarityThreshold float64
+	perUser             bool
+
+	store    Store
+	vectors  VectorStore
+	embedder Embedder
+	// embedded keeps the embeddings of requests looked up recently, so that
+	// they are not embedded again when saving their responses
+	embedded *lru[[]float32]
+}
+
+var _ filters.RequestFilter = (*ResponseCache)(nil)
+var _ filters.OnCompletionRequestFilter = (*ResponseCache)(nil)
+var _ filters.OnCompletionResponseFilter = (*ResponseCache)(nil)
+
+func NewWithConfig(cfg *anypb.Any, lifecycle bootkit.LifeCycle) (filters.RequestFilter, error) {
+	rCfg, err := protoutils.FromAny(cfg, &v1alpha1.ResponseCacheConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	rc := &ResponseCache{
+		mode:                rCfg.GetMode(),
+		ttl:                 lo.Ternary(rCfg.GetTtl().AsDuration() > 0, rCfg.GetTtl().AsDuration(), defaultTTL),
+		similarityThreshold: lo.Ternary(rCfg.GetSimilarityThreshold() > 0, float64(rCfg.GetSimilarityThreshold()), defaultSimilarityThreshold),
+		perUser:             rCfg.GetPerUser(),
+	}
+
+	maxEntries := lo.Ternary(rCfg.GetMaxEntries() > 0, int(rCfg.GetMaxEntries()), defaultMaxEntries)
+
+	switch rc.mode {
+	case v1alpha1.ResponseCacheMode_RESPONSE_CACHE_MODE_UNSPECIFIED, v1alpha1.ResponseCacheMode_EXACT:
+		rc.mode = v1alpha1.ResponseCacheMode_EXACT
+
+		if rCfg.GetRedisServer().GetUrl() != "" {
+			rc.store, err = NewRedisStore(rCfg.GetRedisServer().GetUrl(), lo.CoalesceOrEmpty(rCfg.GetServerPrefix(), defaultServerPrefix), lifecycle)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			rc.store = NewMemoryStore(maxEntries)
+		}
+	case v1alpha1.ResponseCacheMode_SEMANTIC:
+		if rCfg.GetEmbedding().GetUrl() == "" {
+			return nil, fmt.Errorf("embedding url is required in %s mode", rc.mode)
+		}
+
+		rc.embedder = NewHTTPEmbedder(rCfg.GetEmbedding())
+		rc.vectors = NewMemoryVectorStore(maxEntries)
+		rc.embedded = newLRU[[]float32](maxEntries)
+	default:
+		return nil, fmt.Errorf("unknown response cache mode %s", rc.mode)
+	}
+
+	slog.Info("initializing response cache", slog.String("filter", "response_cache"), slog.Any("mode", rc.mode), slog.Duration("ttl", rc.ttl))
+
+	return rc, nil
+}
+
+// WithVectorStore replaces the vector store of SEMANTIC mode, which keeps
+// the embeddings in memory by default.
+func (rc *ResponseCache) WithVectorStore(vectors VectorStore) *ResponseCache {
+	rc.vectors = vectors
+	return rc
+}
+
+// WithEmbedder replaces the embedder of SEMANTIC mode.
+func (rc *ResponseCache) WithEmbedder(embedder Embedder) *ResponseCache {
+	rc.embedder = embedder
+	return rc
+}
+
+func (rc *ResponseCache) OnCompletionRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	if request.IsStream() {
+		return filters.NewOK()
+	}
+
+	cached, err := rc.lookup(ctx, request)
+	if err != nil {
+		// The cache is best-effort, failing to read it must not fail the request
+		slog.WarnContext(ctx, "failed to look up response cache", slog.String("filter", "response_cache"), slog.Any("error", err))
+		return filters.NewOK()
+	}
+
+	observation.ObserveResponseCacheLookup(request.GetModel(), rc.mode.String(), cached != nil)
+
+	if cached == nil {
+		return filters.NewOK()
+	}
+
+	resp, err := openai.NewChatCompletionResponse(request, &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, bufio.NewReader(bytes.NewReader(cached)))
+	if err != nil {
+		slog.WarnContext(ctx, "failed to parse cached response", slog.String("filter", "response_cache"), slog.Any("error", err))
+		return filters.NewOK()
+	}
+
+	if rMeta := metadata.RequestMetadataFromCtx(ctx); rMeta != nil {
+		rMeta.ResponseCached = true
+	}
+
+	return filters.NewResponded(resp)
+}
+
+func (rc *ResponseCache) OnCompletionResponse(ctx context.Context, request object.LLMRequest, response object.LLMResponse) filters.RequestFilterResult {
+	if request.IsStream() || response.IsStream() || !lo.IsNil(response.GetError()) {
+		return filters.NewOK()
+	}
+
+	body, err := response.MarshalJSON()
+	if err != nil {
+		return filters.NewFailed(err)
+	}
+
+	err = rc.save(ctx, request, body)
+	if err != nil {
+		return filters.NewFailed(fmt.Errorf("failed to save response to cache: %w", err))
+	}
+
+	return filters.NewOK()
+}
+
+func (rc *ResponseCache) lookup(ctx context.Context, request object.LLMRequest) ([]byte, error) {
+	switch rc.mode {
+	case v1alpha1.ResponseCacheMode_SEMANTIC:
+		namespace, vector, err := rc.embed(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		return rc.vectors.Search(ctx, namespace, vector, rc.similarityThreshold)
+	default:
+		key, err := rc.exactKey(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+
+		return rc.store.Get(ctx, key)
+	}
+}
+
+func (rc *ResponseCache) save(ctx context.Context, request object.LLMRequest, body []byte) error {
+	switch rc.mode {
+	case v1alpha1.ResponseCacheMode_SEMANTIC:
+		namespace, vector, err := rc.embed(ctx, request)
+		if err != nil {
+			return err
+		}
+
+		return rc.vectors.Add(ctx, namespace, vector, body, rc.ttl)
+	default:
+		key, err := rc.exactKey(ctx, request)
+		if err != nil {
+			return err
+		}
+
+		return rc.store.Set(ctx, key, body, rc.ttl)
+	}
+}
+
+// exactKey hashes the normalized request body.
+func (rc *ResponseCache) exactKey(ctx context.Context, request object.LLMRequest) (string, error) {
+	body, err := normalizedBody(request)
+	if err != nil {
+		return "", err
+	}
+
+	return rc.hash(ctx, body), nil
+}
+
+// semanticKey returns the namespace of the request, which is the hash of the
+// normalized body without the messages, and the text of the messages to
+// embed. Only requests with the same parameters are compared.
+func (rc *ResponseCache) semanticKey(ctx context.Context, request object.LLMRequest) (string, string, error) {
+	body, err := normalizedBody(request)
+	if err != nil {
+		return "", "", err
+	}
+
+	messages, _ := body["messages"].([]any)
+	delete(body, "messages")
+
+	var text strings.Builder
+
+	for _, message := range messages {
+		m, ok := message.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		content, err := json.Marshal(m["content"])
+		if err != nil {
+			return "", "", err
+		}
+
+		fmt.Fprintf(&text, "%v: %s\n", m["role"], content)
+	}
+
+	return rc.hash(ctx, body), text.String(), nil
+}
+
+// embed returns the namespace and the embedding of the messages of the
+// request.
+func (rc *ResponseCache) embed(ctx context.Context, request object.LLMRequest) (string, []float32, error) {
+	namespace, text, err := rc.semanticKey(ctx, request)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sum := sha256.Sum256([]byte(text))
+	key := namespace + ":" + hex.EncodeToString(sum[:])
+
+	if vector, ok := rc.embedded.get(key); ok {
+		return namespace, vector, nil
+	}
+
+	vector, err := rc.embedder.Embed(ctx, text)
+	if err != nil {
+		return "", nil, err
+	}
+
+	rc.embedded.set(key, vector, embeddedTTL)
+
+	return namespace, vector, nil
+}
+
+func (rc *ResponseCache) hash(ctx context.Context, body map[string]any) string {
+	hash := sha256.New()
+
+	// encoding/json sorts the keys of maps, the output is stable
+	_ = json.NewEncoder(hash).Encode(body)
+
+	if rc.perUser {
+		if rMeta := metadata.RequestMetadataFromCtx(ctx); rMeta != nil {
+			hash.Write([]byte("\x00" + rMeta.AuthInfo.GetUserId()))
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func normalizedBody(request object.LLMRequest) (map[string]any, error) {
+	marshaler, ok := request.(json.Marshaler)
+	if !ok {
+		return nil, fmt.Errorf("unsupported request type %T", request)
+	}
+
+	bs, err := marshaler.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var body map[string]any
+
+	err = json.Unmarshal(bs, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range ignoredBodyKeys {
+		delete(body, key)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+const testResponseBody = `{"id":"chatcmpl-1","model":"gpt-4o","choices":[{"message":{"role":"assistant","content":"Hi!"}}],"usage":{"prompt_tokens":1,"completion_tokens":1,"total_tokens":2}}`
+
+func newTestResponse(t *testing.T, request object.LLMRequest) object.LLMResponse {
+	t.Helper()
+
+	response, err := openai.NewChatCompletionResponse(request, &http.Response{StatusCode: http.StatusOK}, bufio.NewReader(bytes.NewBufferString(testResponseBody)))
+	require.NoError(t, err)
+
+	return response
+}
+
+func newTestCache(t *testing.T, cfg *v1alpha1.ResponseCacheConfig) *ResponseCache {
+	t.Helper()
+
+	f, err := NewWithConfig(lo.Must(anypb.New(cfg)), bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+
+	rc, ok := f.(*ResponseCache)
+	require.True(t, ok)
+
+	return rc
+}
+
+func TestResponseCacheExact(t *testing.T) {
+	rc := newTestCache(t, &v1alpha1.ResponseCacheConfig{Mode: v1alpha1.ResponseCacheMode_EXACT})
+
+	ctx, request := gatewaytest.NewChatCompletionRequest(t, `{"model":"gpt-4o","messages":[{"role":"user","content":"Hello"}]}`)
+	assert.True(t, rc.OnCompletionRequest(ctx, request, nil).IsSSucceeded())
+	assert.True(t, rc.OnCompletionResponse(ctx, request, newTestResponse(t, request)).IsSSucceeded())
+
+	// Order of keys and the user don't matter
+	ctx, request = gatewaytest.NewChatCompletionRequest(t, `{"user":"alice","messages":[{"role":"user","content":"Hello"}],"model":"gpt-4o"}`)
+	result := rc.OnCompletionRequest(ctx, request, nil)
+	require.True(t, result.IsResponded())
+	assert.True(t, metadata.RequestMetadataFromCtx(ctx).ResponseCached)
+
+	body, err := result.Response.MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, testResponseBody, string(body))
+
+	// Parameters do matter
+	ctx, request = gatewaytest.NewChatCompletionRequest(t, `{"model":"gpt-4o","temperature":0.5,"messages":[{"role":"user","content":"Hello"}]}`)
+	assert.True(t, rc.OnCompletionRequest(ctx, request, nil).IsSSucceeded())
+
+	// Streaming requests are never cached
+	ctx, request = gatewaytest.NewChatCompletionRequest(t, `{"model":"gpt-4o","stream":true,"messages":[{"role":"user","content":"Hello"}]}`)
+	assert.True(t, rc.OnCompletionRequest(ctx, request, nil).IsSSucceeded())
+}
+
+type fakeEmbedder struct {
+	vectors map[string][]float32
+	calls   int
+}
+
+func (e *fakeEmbedder) Embed(_ context.Context, text string) ([]float32, error) {
+	e.calls++
+
+	for prefix, vector := range e.vectors {
+		if strings.Contains(text, prefix) {
+			return vector, nil
+		}
+	}
+
+	return []float32{0, 0, 1}, nil
+}
+
+func TestResponseCacheSemantic(t *testing.T) {
+	embedder := &fakeEmbedder{vectors: map[string][]float32{
+		"weather today":       {1, 0, 0},
+		"today's weather":     {0.99, 0.1, 0},
+		"capital of Atlantis": {0, 1, 0},
+	}}
+
+	rc := newTestCache(t, &v1alpha1.ResponseCacheConfig{
+		Mode:                v1alpha1.ResponseCacheMode_SEMANTIC,
+		SimilarityThreshold: 0.9,
+		Embedding:           &v1alpha1.ResponseCacheEmbedding{Url: "http://localhost/v1/embeddings"},
+	}).WithEmbedder(embedder)
+
+	ctx, request := gatewaytest.NewChatCompletionRequest(t, `{"model":"gpt-4o","messages":[{"role":"user","content":"How is the weather today?"}]}`)
+	assert.True(t, rc.OnCompletionRequest(ctx, request, nil).IsSSucceeded())
+	assert.True(t, rc.OnCompletionResponse(ctx, request, newTestResponse(t, request)).IsSSucceeded())
+	assert.Equal(t, 1, embedder.calls)
+
+	ctx, request = gatewaytest.NewChatCompletionRequest(t, `{"model":"gpt-4o","messages":[{"role":"user","content":"How is today's weather?"}]}`)
+	assert.True(t, rc.OnCompletionRequest(ctx, request, nil).IsResponded())
+
+	ctx, request = gatewaytest.NewChatCompletionRequest(t, `{"model":"gpt-4o","messages":[{"role":"user","content":"What is the capital of Atlantis?"}]}`)
+	assert.True(t, rc.OnCompletionRequest(ctx, request, nil).IsSSucceeded())
+
+	// Only requests with the same parameters are compared
+	ctx, request = gatewaytest.NewChatCompletionRequest(t, `{"model":"gpt-4o","temperature":0,"messages":[{"role":"user","content":"How is the weather today?"}]}`)
+	assert.True(t, rc.OnCompletionRequest(ctx, request, nil).IsSSucceeded())
+}
+
+func TestLRU(t *testing.T) {
+	l := newLRU[int](2)
+
+	l.set("a", 1, time.Minute)
+	l.set("b", 2, time.Minute)
+
+	_, ok := l.get("a")
+	assert.True(t, ok)
+
+	l.set("c", 3, time.Minute)
+
+	_, ok = l.get("b")
+	assert.False(t, ok, "least recently used entry should be evicted")
+
+	l.set("d", 4, -time.Second)
+
+	_, ok = l.get("d")
+	assert.False(t, ok, "expired entry should not be returned")
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	assert.InDelta(t, 1, cosineSimilarity([]float32{1, 2}, []float32{2, 4}), 1e-9)
+	assert.InDelta(t, 0, cosineSimilarity([]float32{1, 0}, []float32{0, 1}), 1e-9)
+	assert.Zero(t, cosineSimilarity([]float32{1}, []float32{1, 0}))
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"knoway.dev/api/filters/v1alpha1"
+)
+
+const defaultEmbeddingTimeout = 5 * time.Second
+
+// Embedder embeds the messages of requests for SEMANTIC mode.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float32, error)
+}
+
+var _ Embedder = (*HTTPEmbedder)(nil)
+
+// HTTPEmbedder calls an OpenAI compatible embeddings endpoint.
+type HTTPEmbedder struct {
+	url     string
+	model   string
+	headers map[string]string
+	client  *http.Client
+}
+
+func NewHTTPEmbedder(cfg *v1alpha1.ResponseCacheEmbedding) *HTTPEmbedder {
+	timeout := cfg.GetTimeout().AsDuration()
+	if timeout <= 0 {
+		timeout = defaultEmbeddingTimeout
+	}
+
+	return &HTTPEmbedder{
+		url:     cfg.GetUrl(),
+		model:   cfg.GetModel(),
+		headers: cfg.GetHeaders(),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+type embeddingsRequest struct {
+	Model string `json:"model,omitempty"`
+	Input string `json:"input"`
+}
+
+type embeddingsResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+func (e *HTTPEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	payload, err := json.Marshal(embeddingsRequest{Model: e.model, Input: text})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	for key, value := range e.headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	httpResp, err := e.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request embeddings: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(httpResp.Body, 1024))
+		return nil, fmt.Errorf("failed to request embeddings, status code %d: %s", httpResp.StatusCode, body)
+	}
+
+	var resp embeddingsResponse
+
+	err = json.NewDecoder(httpResp.Body).Decode(&resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode embeddings: %w", err)
+	}
+
+	if len(resp.Data) == 0 || len(resp.Data[0].Embedding) == 0 {
+		return nil, errors.New("no embedding returned")
+	}
+
+	return resp.Data[0].Embedding, nil
+}
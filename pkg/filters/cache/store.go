@@ -0,0 +1,167 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/rueidis"
+
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/redis"
+)
+
+// Store keeps the responses of EXACT mode by the hash of requests. Get
+// returns nil without error when the key is not found.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// MemoryStore is a Store bounded by the number of entries, the least
+// recently used entries are evicted first.
+type MemoryStore struct {
+	entries *lru[[]byte]
+}
+
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	return &MemoryStore{entries: newLRU[[]byte](maxEntries)}
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	value, _ := s.entries.get(key)
+	return value, nil
+}
+
+func (s *MemoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.entries.set(key, value, ttl)
+	return nil
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// RedisStore shares the cache across replicas of the gateway.
+type RedisStore struct {
+	client rueidis.Client
+	prefix string
+}
+
+func NewRedisStore(url string, prefix string, lifecycle bootkit.LifeCycle) (*RedisStore, error) {
+	client, err := redis.NewRedisClient(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create redis client: %w", err)
+	}
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStop: func(ctx context.Context) error {
+			client.Close()
+			return nil
+		},
+	})
+
+	return &RedisStore{client: client, prefix: prefix}, nil
+}
+
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := s.client.Do(ctx, s.client.B().Get().Key(s.prefix+":"+key).Build()).AsBytes()
+	if rueidis.IsRedisNil(err) {
+		return nil, nil
+	}
+
+	return value, err
+}
+
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Do(ctx, s.client.B().Set().Key(s.prefix+":"+key).Value(rueidis.BinaryString(value)).Px(ttl).Build()).Error()
+}
+
+type lruEntry[T any] struct {
+	key       string
+	value     T
+	expiresAt time.Time
+}
+
+// lru is a map bounded by the number of entries, with expiration.
+type lru[T any] struct {
+	mutex      sync.Mutex
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+func newLRU[T any](maxEntries int) *lru[T] {
+	return &lru[T]{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+func (l *lru[T]) get(key string) (T, bool) {
+	var empty T
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	element, ok := l.entries[key]
+	if !ok {
+		return empty, false
+	}
+
+	entry, _ := element.Value.(*lruEntry[T])
+	if time.Now().After(entry.expiresAt) {
+		l.order.Remove(element)
+		delete(l.entries, key)
+
+		return empty, false
+	}
+
+	l.order.MoveToFront(element)
+
+	return entry.value, true
+}
+
+func (l *lru[T]) set(key string, value T, ttl time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if element, ok := l.entries[key]; ok {
+		entry, _ := element.Value.(*lruEntry[T])
+		entry.value = value
+		entry.expiresAt = time.Now().Add(ttl)
+		l.order.MoveToFront(element)
+
+		return
+	}
+
+	l.entries[key] = l.order.PushFront(&lruEntry[T]{key: key, value: value, expiresAt: time.Now().Add(ttl)})
+
+	for l.order.Len() > l.maxEntries {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+
+		entry, _ := oldest.Value.(*lruEntry[T])
+		delete(l.entries, entry.key)
+	}
+}
+
+// each calls fn with the entries not expired yet, fn must not modify the lru.
+func (l *lru[T]) each(fn func(key string, value T)) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+
+	for element := l.order.Front(); element != nil; element = element.Next() {
+		entry, _ := element.Value.(*lruEntry[T])
+		if now.After(entry.expiresAt) {
+			continue
+		}
+
+		fn(entry.key, entry.value)
+	}
+}
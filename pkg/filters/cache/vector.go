@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// VectorStore keeps the responses of SEMANTIC mode by the embeddings of
+// requests. Entries are only compared to the ones in the same namespace.
+// Search returns nil without error when no entry is similar enough.
+type VectorStore interface {
+	Search(ctx context.Context, namespace string, vector []float32, threshold float64) ([]byte, error)
+	Add(ctx context.Context, namespace string, vector []float32, value []byte, ttl time.Duration) error
+}
+
+type vectorEntry struct {
+	namespace string
+	vector    []float32
+	value     []byte
+}
+
+var _ VectorStore = (*MemoryVectorStore)(nil)
+
+// MemoryVectorStore searches the embeddings linearly, bounded by the number
+// of entries, the least recently used entries are evicted first.
+type MemoryVectorStore struct {
+	entries *lru[vectorEntry]
+	nextID  atomic.Uint64
+}
+
+func NewMemoryVectorStore(maxEntries int) *MemoryVectorStore {
+	return &MemoryVectorStore{entries: newLRU[vectorEntry](maxEntries)}
+}
+
+func (s *MemoryVectorStore) Search(_ context.Context, namespace string, vector []float32, threshold float64) ([]byte, error) {
+	var (
+		bestKey   string
+		bestScore = threshold
+		found     bool
+	)
+
+	s.entries.each(func(key string, entry vectorEntry) {
+		if entry.namespace != namespace {
+			return
+		}
+
+		score := cosineSimilarity(vector, entry.vector)
+		if score >= bestScore {
+			bestKey, bestScore, found = key, score, true
+		}
+	})
+
+	if !found {
+		return nil, nil
+	}
+
+	// Marks the entry as recently used
+	entry, ok := s.entries.get(bestKey)
+	if !ok {
+		return nil, nil
+	}
+
+	return entry.value, nil
+}
+
+func (s *MemoryVectorStore) Add(_ context.Context, namespace string, vector []float32, value []byte, ttl time.Duration) error {
+	s.entries.set(strconv.FormatUint(s.nextID.Add(1), 10), vectorEntry{
+		namespace: namespace,
+		vector:    vector,
+		value:     value,
+	}, ttl)
+
+	return nil
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
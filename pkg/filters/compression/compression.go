@@ -0,0 +1,398 @@
+package compression
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/threads"
+	"knoway.dev/pkg/types/openai"
+)
+
+const (
+	defaultKeepLastMessages = 4
+
+	// minDeduplicatedParagraphRunes keeps short paragraphs such as "Yes." from
+	// being deduplicated.
+	minDeduplicatedParagraphRunes = 64
+
+	summaryPrefix = "Summary of the earlier conversation:\n"
+)
+
+var (
+	horizontalSpaces = regexp.MustCompile(`[ \t\f\v]+`)
+	blankLines       = regexp.MustCompile(`\n{3,}`)
+)
+
+// PromptCompression compresses the messages of chat completions requests
+// before they are sent to the upstream, streaming or not, and records the
+// estimated prompt tokens saved in the metadata of the request.
+type PromptCompression struct {
+	filters.IsRequestFilter
+
+	collapseWhitespace  bool
+	deduplicate         bool
+	minPromptTokens     uint64
+	maxCompressionRatio float64
+
+	keepLastMessages int
+	summarizer       Summarizer
+}
+
+var _ filters.RequestFilter = (*PromptCompression)(nil)
+var _ filters.OnCompletionRequestFilter = (*PromptCompression)(nil)
+
+func NewWithConfig(cfg *anypb.Any, _ bootkit.LifeCycle) (filters.RequestFilter, error) {
+	c, err := protoutils.FromAny(cfg, &v1alpha1.PromptCompressionConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	if c.GetMaxCompressionRatio() < 0 || c.GetMaxCompressionRatio() > 1 {
+		return nil, errors.New("max_compression_ratio must be between 0 and 1")
+	}
+
+	pc := &PromptCompression{
+		collapseWhitespace:  c.GetCollapseWhitespace(),
+		deduplicate:         c.GetDeduplicate(),
+		minPromptTokens:     c.GetMinPromptTokens(),
+		maxCompressionRatio: float64(c.GetMaxCompressionRatio()),
+		keepLastMessages:    defaultKeepLastMessages,
+	}
+
+	if summarization := c.GetSummarization(); summarization != nil {
+		if summarization.GetUrl() == "" {
+			return nil, errors.New("summarization url is required")
+		}
+
+		pc.summarizer = NewHTTPSummarizer(summarization)
+
+		if summarization.GetKeepLastMessages() > 0 {
+			pc.keepLastMessages = int(summarization.GetKeepLastMessages())
+		}
+	}
+
+	slog.Info("initializing prompt compression",
+		slog.String("filter", "prompt_compression"),
+		slog.Bool("collapseWhitespace", pc.collapseWhitespace),
+		slog.Bool("deduplicate", pc.deduplicate),
+		slog.Bool("summarization", pc.summarizer != nil),
+	)
+
+	return pc, nil
+}
+
+// WithSummarizer replaces the summarizer, which calls the endpoint configured
+// by default.
+func (pc *PromptCompression) WithSummarizer(summarizer Summarizer) *PromptCompression {
+	pc.summarizer = summarizer
+	return pc
+}
+
+func (pc *PromptCompression) OnCompletionRequest(ctx context.Context, request object.LLMRequest, _ *http.Request) filters.RequestFilterResult {
+	// Legacy completions carry prompts instead of messages
+	chatRequest, ok := request.(*openai.ChatCompletionsRequest)
+	if !ok {
+		return filters.NewOK()
+	}
+
+	messages := chatRequest.GetMessages()
+	if len(messages) == 0 {
+		return filters.NewOK()
+	}
+
+	original := estimateTokens(messages)
+	if original < pc.minPromptTokens {
+		return filters.NewOK()
+	}
+
+	compressed, tokens := pc.compress(ctx, messages, original)
+	if tokens >= original {
+		return filters.NewOK()
+	}
+
+	err := chatRequest.SetMessages(compressed)
+	if err != nil {
+		return filters.NewFailed(openai.NewErrorInternalError().WithCause(err))
+	}
+
+	if rMeta := metadata.RequestMetadataFromCtx(ctx); rMeta != nil {
+		rMeta.PromptTokensSaved = original - tokens
+	}
+
+	slog.DebugContext(ctx, "prompt compressed",
+		slog.String("filter", "prompt_compression"),
+		slog.Uint64("original_tokens", original),
+		slog.Uint64("compressed_tokens", tokens),
+	)
+
+	return filters.NewOK()
+}
+
+// compress applies the steps enabled in order, a step is skipped if the
+// estimated tokens after it fall below the budget.
+func (pc *PromptCompression) compress(ctx context.Context, messages []map[string]any, original uint64) ([]map[string]any, uint64) {
+	var floor uint64
+	if pc.maxCompressionRatio > 0 {
+		floor = original - uint64(float64(original)*pc.maxCompressionRatio)
+	}
+
+	tokens := original
+
+	apply := func(step string, candidate []map[string]any) {
+		candidateTokens := estimateTokens(candidate)
+		if candidateTokens < floor {
+			slog.DebugContext(ctx, "prompt compression step skipped, exceeding the budget",
+				slog.String("filter", "prompt_compression"),
+				slog.String("step", step),
+			)
+
+			return
+		}
+
+		messages, tokens = candidate, candidateTokens
+	}
+
+	if pc.collapseWhitespace {
+		apply("collapse_whitespace", CollapseWhitespace(messages))
+	}
+
+	if pc.deduplicate {
+		apply("deduplicate", Deduplicate(messages))
+	}
+
+	if pc.summarizer != nil {
+		candidate, err := Summarize(ctx, pc.summarizer, messages, pc.keepLastMessages)
+		if err != nil {
+			// Summarization is best-effort, the request is sent as is
+			slog.WarnContext(ctx, "failed to summarize prompt", slog.String("filter", "prompt_compression"), slog.Any("error", err))
+		} else if candidate != nil {
+			apply("summarization", candidate)
+		}
+	}
+
+	return messages, tokens
+}
+
+func estimateTokens(messages []map[string]any) uint64 {
+	var tokens uint64
+	for _, message := range messages {
+		tokens += threads.EstimateTokens(message)
+	}
+
+	return tokens
+}
+
+// CollapseWhitespace collapses the runs of whitespaces within lines and of
+// blank lines in the text of messages, indentations are kept for code.
+func CollapseWhitespace(messages []map[string]any) []map[string]any {
+	return lo.Map(messages, func(message map[string]any, _ int) map[string]any {
+		return mapText(message, collapseText)
+	})
+}
+
+func collapseText(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+		indent := line[:len(line)-len(trimmed)]
+
+		lines[i] = strings.TrimRight(indent+horizontalSpaces.ReplaceAllString(trimmed, " "), " \t")
+	}
+
+	return strings.Trim(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"), "\n")
+}
+
+// Deduplicate drops the paragraphs of system and user messages repeated from
+// earlier messages, such as documents pasted into the context again. Messages
+// left empty are dropped, except the last one which is kept as is.
+func Deduplicate(messages []map[string]any) []map[string]any {
+	seen := make(map[string]struct{})
+	result := make([]map[string]any, 0, len(messages))
+
+	for i, message := range messages {
+		if role := roleOf(message); role != "system" && role != "user" {
+			mapText(message, func(text string) string {
+				for _, paragraph := range strings.Split(text, "\n\n") {
+					seen[strings.TrimSpace(paragraph)] = struct{}{}
+				}
+
+				return text
+			})
+
+			result = append(result, message)
+
+			continue
+		}
+
+		deduplicated := mapText(message, func(text string) string {
+			paragraphs := strings.Split(text, "\n\n")
+			kept := make([]string, 0, len(paragraphs))
+
+			for _, paragraph := range paragraphs {
+				key := strings.TrimSpace(paragraph)
+				if utf8.RuneCountInString(key) >= minDeduplicatedParagraphRunes {
+					if _, ok := seen[key]; ok {
+						continue
+					}
+				}
+
+				seen[key] = struct{}{}
+				kept = append(kept, paragraph)
+			}
+
+			return strings.Join(kept, "\n\n")
+		})
+
+		if isEmpty(deduplicated) {
+			if i == len(messages)-1 {
+				result = append(result, message)
+			}
+
+			continue
+		}
+
+		result = append(result, deduplicated)
+	}
+
+	return result
+}
+
+// Summarize replaces the messages between the leading system messages and the
+// last keepLastMessages with a summary of them. Nil is returned when there's
+// nothing to summarize, or some of the messages carry other than text, such
+// as images.
+func Summarize(ctx context.Context, summarizer Summarizer, messages []map[string]any, keepLastMessages int) ([]map[string]any, error) {
+	start := 0
+	for start < len(messages) && roleOf(messages[start]) == "system" {
+		start++
+	}
+
+	end := len(messages) - keepLastMessages
+	// Tool results are kept along with the tool calls
+	for end > start && end < len(messages) && roleOf(messages[end]) == "tool" {
+		end--
+	}
+
+	if end-start < 2 {
+		return nil, nil
+	}
+
+	var transcript strings.Builder
+
+	for _, message := range messages[start:end] {
+		text, ok := textOf(message)
+		if !ok {
+			return nil, nil
+		}
+
+		_, _ = fmt.Fprintf(&transcript, "%s: %s\n\n", roleOf(message), text)
+	}
+
+	summary, err := summarizer.Summarize(ctx, transcript.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(
+		messages[:start],
+		[]map[string]any{{"role": "system", "content": summaryPrefix + summary}},
+		messages[end:],
+	), nil
+}
+
+func roleOf(message map[string]any) string {
+	role, _ := message["role"].(string)
+	return role
+}
+
+// mapText returns a copy of the message with the text of its content mapped,
+// the content is either a string or a list of parts.
+func mapText(message map[string]any, fn func(string) string) map[string]any {
+	mapped := maps.Clone(message)
+
+	switch content := message["content"].(type) {
+	case string:
+		mapped["content"] = fn(content)
+	case []any:
+		parts := make([]any, 0, len(content))
+
+		for _, part := range content {
+			partMap, ok := part.(map[string]any)
+			if !ok || partMap["type"] != "text" {
+				parts = append(parts, part)
+				continue
+			}
+
+			text, _ := partMap["text"].(string)
+
+			mappedText := fn(text)
+			if mappedText == "" {
+				continue
+			}
+
+			mappedPart := maps.Clone(partMap)
+			mappedPart["text"] = mappedText
+			parts = append(parts, mappedPart)
+		}
+
+		mapped["content"] = parts
+	}
+
+	return mapped
+}
+
+// textOf returns the text of the content of the message, false if the content
+// has parts other than text.
+func textOf(message map[string]any) (string, bool) {
+	switch content := message["content"].(type) {
+	case nil:
+		return "", true
+	case string:
+		return content, true
+	case []any:
+		texts := make([]string, 0, len(content))
+
+		for _, part := range content {
+			partMap, ok := part.(map[string]any)
+			if !ok || partMap["type"] != "text" {
+				return "", false
+			}
+
+			text, _ := partMap["text"].(string)
+			texts = append(texts, text)
+		}
+
+		return strings.Join(texts, "\n"), true
+	default:
+		return "", false
+	}
+}
+
+func isEmpty(message map[string]any) bool {
+	switch content := message["content"].(type) {
+	case string:
+		return strings.TrimSpace(content) == ""
+	case []any:
+		return len(content) == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,209 @@
+package compression
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/metadata"
+)
+
+var testDocument = strings.Repeat("The quick brown fox jumps over the lazy dog. ", 4)
+
+type fakeSummarizer struct {
+	transcript string
+	err        error
+}
+
+func (s *fakeSummarizer) Summarize(_ context.Context, transcript string) (string, error) {
+	s.transcript = transcript
+	return "They talked about foxes.", s.err
+}
+
+func newTestCompression(t *testing.T, cfg *v1alpha1.PromptCompressionConfig) *PromptCompression {
+	t.Helper()
+
+	f, err := NewWithConfig(lo.Must(anypb.New(cfg)), bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+
+	pc, ok := f.(*PromptCompression)
+	require.True(t, ok)
+
+	return pc
+}
+
+func TestNewWithConfig(t *testing.T) {
+	_, err := NewWithConfig(lo.Must(anypb.New(&v1alpha1.PromptCompressionConfig{MaxCompressionRatio: 1.5})), bootkit.NewEmptyLifeCycle())
+	require.EqualError(t, err, "max_compression_ratio must be between 0 and 1")
+
+	_, err = NewWithConfig(lo.Must(anypb.New(&v1alpha1.PromptCompressionConfig{
+		Summarization: &v1alpha1.PromptCompressionSummarization{Model: "gpt-4o-mini"},
+	})), bootkit.NewEmptyLifeCycle())
+	require.EqualError(t, err, "summarization url is required")
+}
+
+func TestCollapseWhitespace(t *testing.T) {
+	messages := []map[string]any{
+		{"role": "user", "content": "Hello,    world!  \r\n\n\n\nfunc main() {\n\t  fmt.Println(\"hi\")   \n}\n"},
+		{"role": "user", "content": []any{
+			map[string]any{"type": "text", "text": "What  is\tthis?"},
+			map[string]any{"type": "image_url", "image_url": map[string]any{"url": "https://example.com/a.png"}},
+		}},
+	}
+
+	collapsed := CollapseWhitespace(messages)
+	assert.Equal(t, "Hello, world!\n\nfunc main() {\n\t  fmt.Println(\"hi\")\n}", collapsed[0]["content"])
+	assert.Equal(t, []any{
+		map[string]any{"type": "text", "text": "What is this?"},
+		map[string]any{"type": "image_url", "image_url": map[string]any{"url": "https://example.com/a.png"}},
+	}, collapsed[1]["content"])
+
+	// The messages are copied
+	assert.Equal(t, "What  is\tthis?", messages[1]["content"].([]any)[0].(map[string]any)["text"])
+}
+
+func TestDeduplicate(t *testing.T) {
+	messages := []map[string]any{
+		{"role": "system", "content": "You are helpful.\n\n" + testDocument},
+		{"role": "user", "content": testDocument + "\n\nSummarize it."},
+		{"role": "assistant", "content": "Sure."},
+		{"role": "user", "content": testDocument},
+		{"role": "user", "content": "Yes.\n\nYes."},
+		{"role": "user", "content": testDocument},
+	}
+
+	assert.Equal(t, []map[string]any{
+		{"role": "system", "content": "You are helpful.\n\n" + testDocument},
+		{"role": "user", "content": "Summarize it."},
+		{"role": "assistant", "content": "Sure."},
+		// Short paragraphs are kept
+		{"role": "user", "content": "Yes.\n\nYes."},
+		// The last message is kept even though it's repeated
+		{"role": "user", "content": testDocument},
+	}, Deduplicate(messages))
+}
+
+func TestSummarize(t *testing.T) {
+	summarizer := new(fakeSummarizer)
+
+	messages := []map[string]any{
+		{"role": "system", "content": "You are helpful."},
+		{"role": "user", "content": "Tell me about foxes."},
+		{"role": "assistant", "content": "Foxes are quick."},
+		{"role": "assistant", "tool_calls": []any{map[string]any{"id": "call_1"}}},
+		{"role": "tool", "tool_call_id": "call_1", "content": "brown"},
+		{"role": "user", "content": "And dogs?"},
+	}
+
+	summarized, err := Summarize(context.Background(), summarizer, messages, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "user: Tell me about foxes.\n\nassistant: Foxes are quick.\n\n", summarizer.transcript)
+	assert.Equal(t, []map[string]any{
+		{"role": "system", "content": "You are helpful."},
+		{"role": "system", "content": summaryPrefix + "They talked about foxes."},
+		// Tool results are kept with the tool calls
+		{"role": "assistant", "tool_calls": []any{map[string]any{"id": "call_1"}}},
+		{"role": "tool", "tool_call_id": "call_1", "content": "brown"},
+		{"role": "user", "content": "And dogs?"},
+	}, summarized)
+
+	// Nothing to summarize
+	summarized, err = Summarize(context.Background(), summarizer, messages, 5)
+	require.NoError(t, err)
+	assert.Nil(t, summarized)
+
+	// Images are never summarized
+	summarized, err = Summarize(context.Background(), summarizer, []map[string]any{
+		{"role": "user", "content": []any{map[string]any{"type": "image_url"}}},
+		{"role": "assistant", "content": "A fox."},
+		{"role": "user", "content": "And dogs?"},
+	}, 1)
+	require.NoError(t, err)
+	assert.Nil(t, summarized)
+}
+
+func TestPromptCompression_OnCompletionRequest(t *testing.T) {
+	pc := newTestCompression(t, &v1alpha1.PromptCompressionConfig{CollapseWhitespace: true, Deduplicate: true})
+
+	ctx, request := gatewaytest.NewChatCompletionRequest(t, gatewaytest.ChatCompletionBody("gpt-4o", true, []map[string]any{
+		{"role": "user", "content": testDocument + "\n\n\n\nRead   it."},
+		{"role": "assistant", "content": "Done."},
+		{"role": "user", "content": testDocument + "\n\nSummarize   it."},
+	}...))
+
+	require.True(t, pc.OnCompletionRequest(ctx, request, nil).IsSSucceeded())
+	assert.Equal(t, []map[string]any{
+		{"role": "user", "content": strings.TrimSpace(testDocument) + "\n\nRead it."},
+		{"role": "assistant", "content": "Done."},
+		{"role": "user", "content": "Summarize it."},
+	}, request.GetMessages())
+	assert.True(t, request.IsStream())
+
+	saved := metadata.RequestMetadataFromCtx(ctx).PromptTokensSaved
+	assert.Positive(t, saved)
+
+	body := lo.Must(request.MarshalJSON())
+	assert.NotContains(t, string(body), "Summarize   it.")
+}
+
+func TestPromptCompression_Budget(t *testing.T) {
+	messages := []map[string]any{
+		{"role": "user", "content": testDocument + "\n\nRead it."},
+		{"role": "assistant", "content": "Done."},
+		{"role": "user", "content": testDocument + "\n\nSummarize it."},
+	}
+
+	// Deduplication removes more than 10% of the prompt
+	pc := newTestCompression(t, &v1alpha1.PromptCompressionConfig{Deduplicate: true, MaxCompressionRatio: 0.1})
+
+	ctx, request := gatewaytest.NewChatCompletionRequest(t, gatewaytest.ChatCompletionBody("gpt-4o", false, messages...))
+	require.True(t, pc.OnCompletionRequest(ctx, request, nil).IsSSucceeded())
+	assert.Len(t, request.GetMessages()[2]["content"], len(testDocument)+len("\n\nSummarize it."))
+	assert.Zero(t, metadata.RequestMetadataFromCtx(ctx).PromptTokensSaved)
+
+	// Prompts shorter than the minimum are not compressed
+	pc = newTestCompression(t, &v1alpha1.PromptCompressionConfig{Deduplicate: true, MinPromptTokens: 1000})
+
+	ctx, request = gatewaytest.NewChatCompletionRequest(t, gatewaytest.ChatCompletionBody("gpt-4o", false, messages...))
+	require.True(t, pc.OnCompletionRequest(ctx, request, nil).IsSSucceeded())
+	assert.Zero(t, metadata.RequestMetadataFromCtx(ctx).PromptTokensSaved)
+}
+
+func TestPromptCompression_SummarizationFailure(t *testing.T) {
+	pc := newTestCompression(t, &v1alpha1.PromptCompressionConfig{}).
+		WithSummarizer(&fakeSummarizer{err: errors.New("unavailable")})
+	pc.keepLastMessages = 1
+
+	ctx, request := gatewaytest.NewChatCompletionRequest(t, gatewaytest.ChatCompletionBody("gpt-4o", false, []map[string]any{
+		{"role": "user", "content": "Tell me about foxes."},
+		{"role": "assistant", "content": "Foxes are quick."},
+		{"role": "user", "content": "And dogs?"},
+	}...))
+
+	// The request is sent as is
+	require.True(t, pc.OnCompletionRequest(ctx, request, nil).IsSSucceeded())
+	assert.Len(t, request.GetMessages(), 3)
+
+	pc.WithSummarizer(new(fakeSummarizer))
+
+	ctx, request = gatewaytest.NewChatCompletionRequest(t, gatewaytest.ChatCompletionBody("gpt-4o", false, []map[string]any{
+		{"role": "user", "content": testDocument},
+		{"role": "assistant", "content": testDocument},
+		{"role": "user", "content": "And dogs?"},
+	}...))
+
+	require.True(t, pc.OnCompletionRequest(ctx, request, nil).IsSSucceeded())
+	assert.Equal(t, []map[string]any{
+		{"role": "system", "content": summaryPrefix + "They talked about foxes."},
+		{"role": "user", "content": "And dogs?"},
+	}, request.GetMessages())
+}
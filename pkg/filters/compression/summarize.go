@@ -0,0 +1,116 @@
+package compression
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"knoway.dev/api/filters/v1alpha1"
+)
+
+const (
+	defaultSummarizationTimeout = 10 * time.Second
+
+	summarizationPrompt = "Summarize the following conversation concisely. " +
+		"Keep every fact, decision, name, number and open question needed to continue the conversation. " +
+		"Reply with the summary only."
+)
+
+// Summarizer summarizes the earlier messages of requests.
+type Summarizer interface {
+	Summarize(ctx context.Context, transcript string) (string, error)
+}
+
+var _ Summarizer = (*HTTPSummarizer)(nil)
+
+// HTTPSummarizer calls an OpenAI compatible chat completions endpoint.
+type HTTPSummarizer struct {
+	url     string
+	model   string
+	headers map[string]string
+	client  *http.Client
+}
+
+func NewHTTPSummarizer(cfg *v1alpha1.PromptCompressionSummarization) *HTTPSummarizer {
+	timeout := cfg.GetTimeout().AsDuration()
+	if timeout <= 0 {
+		timeout = defaultSummarizationTimeout
+	}
+
+	return &HTTPSummarizer{
+		url:     cfg.GetUrl(),
+		model:   cfg.GetModel(),
+		headers: cfg.GetHeaders(),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+type summarizationMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type summarizationRequest struct {
+	Model    string                 `json:"model,omitempty"`
+	Messages []summarizationMessage `json:"messages"`
+}
+
+type summarizationResponse struct {
+	Choices []struct {
+		Message summarizationMessage `json:"message"`
+	} `json:"choices"`
+}
+
+func (s *HTTPSummarizer) Summarize(ctx context.Context, transcript string) (string, error) {
+	payload, err := json.Marshal(summarizationRequest{
+		Model: s.model,
+		Messages: []summarizationMessage{
+			{Role: "system", Content: summarizationPrompt},
+			{Role: "user", Content: transcript},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewBuffer(payload))
+	if err != nil {
+		return "", err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	for key, value := range s.headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	httpResp, err := s.client.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("failed to request summarization: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(httpResp.Body, 1024))
+		return "", fmt.Errorf("failed to request summarization, status code %d: %s", httpResp.StatusCode, body)
+	}
+
+	var resp summarizationResponse
+
+	err = json.NewDecoder(httpResp.Body).Decode(&resp)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode summarization: %w", err)
+	}
+
+	if len(resp.Choices) == 0 || strings.TrimSpace(resp.Choices[0].Message.Content) == "" {
+		return "", errors.New("no summary returned")
+	}
+
+	return strings.TrimSpace(resp.Choices[0].Message.Content), nil
+}
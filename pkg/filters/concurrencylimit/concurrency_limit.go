@@ -0,0 +1,292 @@
+package concurrencylimit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/observation"
+	"knoway.dev/pkg/priority"
+	"knoway.dev/pkg/protoutils"
+)
+
+const (
+	defaultQueueTimeout = 30 * time.Second
+	defaultRetryAfter   = time.Second
+
+	// maxJumpFraction is the fraction of slots that can be held by requests
+	// of higher priority that jumped the queue.
+	maxJumpFraction = 0.5
+)
+
+// basedOnOrder is the order the limits are acquired in, so that the requests
+// never hold a slot of a limit while waiting for another in the reverse order.
+var basedOnOrder = []v1alpha1.ConcurrencyLimitBaseOn{
+	v1alpha1.ConcurrencyLimitBaseOn_PER_ROUTE,
+	v1alpha1.ConcurrencyLimitBaseOn_PER_USER,
+	v1alpha1.ConcurrencyLimitBaseOn_PER_API_KEY,
+}
+
+type ConcurrencyLimiter struct {
+	filters.IsRequestFilter
+
+	policies []*v1alpha1.ConcurrencyLimitPolicy
+
+	mutex  sync.Mutex
+	queues map[string]*queue
+}
+
+// queue is a priority.Queue shared by the requests of a key, it's dropped
+// once no request holds or waits for its slots.
+type queue struct {
+	*priority.Queue
+
+	refs int
+}
+
+var _ filters.RequestFilter = (*ConcurrencyLimiter)(nil)
+var _ filters.OnCompletionRequestFilter = (*ConcurrencyLimiter)(nil)
+var _ filters.OnImageGenerationsRequestFilter = (*ConcurrencyLimiter)(nil)
+var _ filters.OnModerationsRequestFilter = (*ConcurrencyLimiter)(nil)
+var _ filters.OnEmbeddingsRequestFilter = (*ConcurrencyLimiter)(nil)
+var _ filters.OnRerankRequestFilter = (*ConcurrencyLimiter)(nil)
+var _ filters.OnVideoGenerationsRequestFilter = (*ConcurrencyLimiter)(nil)
+
+func NewWithConfig(cfg *anypb.Any, _ bootkit.LifeCycle) (filters.RequestFilter, error) {
+	c, err := protoutils.FromAny(cfg, &v1alpha1.ConcurrencyLimitConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	for i, policy := range c.GetPolicies() {
+		if policy.GetMaxInFlight() <= 0 {
+			return nil, fmt.Errorf("policies[%d].max_in_flight must be greater than 0", i)
+		}
+
+		if policy.GetMaxQueued() < 0 {
+			return nil, fmt.Errorf("policies[%d].max_queued must be greater than or equal to 0", i)
+		}
+	}
+
+	return &ConcurrencyLimiter{
+		policies: c.GetPolicies(),
+		queues:   make(map[string]*queue),
+	}, nil
+}
+
+func (l *ConcurrencyLimiter) OnCompletionRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return l.onRequest(ctx, request, sourceHTTPRequest)
+}
+
+func (l *ConcurrencyLimiter) OnImageGenerationsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return l.onRequest(ctx, request, sourceHTTPRequest)
+}
+
+func (l *ConcurrencyLimiter) OnModerationsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return l.onRequest(ctx, request, sourceHTTPRequest)
+}
+
+func (l *ConcurrencyLimiter) OnEmbeddingsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return l.onRequest(ctx, request, sourceHTTPRequest)
+}
+
+func (l *ConcurrencyLimiter) OnRerankRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return l.onRequest(ctx, request, sourceHTTPRequest)
+}
+
+func (l *ConcurrencyLimiter) OnVideoGenerationsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return l.onRequest(ctx, request, sourceHTTPRequest)
+}
+
+// onRequest acquires a slot of every limit applying to the request, the
+// slots are held until the request is served, i.e. the context of the source
+// HTTP request is done, which includes streaming the response.
+func (l *ConcurrencyLimiter) onRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	userName := rMeta.AuthInfo.GetUserId()
+
+	releases := make([]func(), 0, len(basedOnOrder))
+	releaseAll := func() {
+		for _, release := range releases {
+			release()
+		}
+	}
+
+	for _, basedOn := range basedOnOrder {
+		value := l.valueOf(rMeta, basedOn)
+
+		policy := l.findMatchingPolicy(basedOn, value)
+		if policy == nil {
+			continue
+		}
+
+		release, err := l.acquire(ctx, rMeta, fmt.Sprintf("%s:%s", basedOn, value), policy)
+		if err != nil {
+			releaseAll()
+
+			slog.DebugContext(ctx, "concurrency limit exceeded",
+				slog.String("filter", "concurrency_limit"),
+				slog.String("model", request.GetModel()),
+				slog.String("userName", userName),
+				slog.Any("basedOn", basedOn),
+				slog.Int64("maxInFlight", int64(policy.GetMaxInFlight())),
+				slog.Any("error", err),
+			)
+			observation.ObserveConcurrencyLimitRejection(request.GetModel(), userName)
+
+			return filters.NewFailed(object.NewErrorConcurrencyLimitExceeded(retryAfter(policy)))
+		}
+
+		releases = append(releases, release)
+	}
+
+	if len(releases) == 0 {
+		return filters.NewOK()
+	}
+
+	served := ctx
+	if sourceHTTPRequest != nil {
+		served = sourceHTTPRequest.Context()
+	}
+
+	context.AfterFunc(served, releaseAll)
+
+	return filters.NewOK()
+}
+
+func (l *ConcurrencyLimiter) valueOf(rMeta *metadata.RequestMetadata, basedOn v1alpha1.ConcurrencyLimitBaseOn) string {
+	switch basedOn {
+	case v1alpha1.ConcurrencyLimitBaseOn_PER_USER:
+		return rMeta.AuthInfo.GetUserId()
+	case v1alpha1.ConcurrencyLimitBaseOn_PER_API_KEY:
+		return rMeta.AuthInfo.GetApiKeyId()
+	case v1alpha1.ConcurrencyLimitBaseOn_PER_ROUTE, v1alpha1.ConcurrencyLimitBaseOn_CONCURRENCY_LIMIT_BASE_ON_UNSPECIFIED:
+		return ""
+	default:
+		return ""
+	}
+}
+
+func (l *ConcurrencyLimiter) findMatchingPolicy(basedOn v1alpha1.ConcurrencyLimitBaseOn, value string) *v1alpha1.ConcurrencyLimitPolicy {
+	for _, policy := range l.policies {
+		policyBasedOn := policy.GetBasedOn()
+		if policyBasedOn == v1alpha1.ConcurrencyLimitBaseOn_CONCURRENCY_LIMIT_BASE_ON_UNSPECIFIED {
+			policyBasedOn = v1alpha1.ConcurrencyLimitBaseOn_PER_ROUTE
+		}
+
+		if policyBasedOn != basedOn {
+			continue
+		}
+
+		if basedOn == v1alpha1.ConcurrencyLimitBaseOn_PER_ROUTE {
+			return policy
+		}
+
+		// Requests without the user or the API key are not limited by them
+		if value == "" {
+			return nil
+		}
+
+		match := policy.GetMatch()
+		if match == nil ||
+			(match.GetExact() != "" && match.GetExact() == value) ||
+			(match.GetPrefix() != "" && strings.HasPrefix(value, match.GetPrefix())) {
+			return policy
+		}
+	}
+
+	return nil
+}
+
+func (l *ConcurrencyLimiter) acquire(ctx context.Context, rMeta *metadata.RequestMetadata, key string, policy *v1alpha1.ConcurrencyLimitPolicy) (func(), error) {
+	q := l.ref(key, int(policy.GetMaxInFlight()))
+
+	if release, ok := q.TryAcquire(); ok {
+		return l.releaseFunc(key, release), nil
+	}
+
+	if q.Len() >= int(policy.GetMaxQueued()) {
+		l.unref(key)
+		return nil, fmt.Errorf("%d requests are queued already", q.Len())
+	}
+
+	timeout := defaultQueueTimeout
+	if policy.GetQueueTimeout() != nil {
+		timeout = policy.GetQueueTimeout().AsDuration()
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	release, waited, err := q.Acquire(waitCtx, rMeta.Priority)
+	observation.ObserveQueueWait("concurrency_limit", rMeta.Priority, waited)
+
+	if err != nil {
+		l.unref(key)
+		return nil, err
+	}
+
+	return l.releaseFunc(key, release), nil
+}
+
+// ref returns the queue of the key, creating it if absent, and holds it
+// until unref is called.
+func (l *ConcurrencyLimiter) ref(key string, slots int) *queue {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	q, ok := l.queues[key]
+	if !ok {
+		q = &queue{Queue: priority.NewQueue(slots, maxJumpFraction)}
+		l.queues[key] = q
+	}
+
+	q.refs++
+
+	return q
+}
+
+func (l *ConcurrencyLimiter) unref(key string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	q, ok := l.queues[key]
+	if !ok {
+		return
+	}
+
+	q.refs--
+	if q.refs <= 0 {
+		delete(l.queues, key)
+	}
+}
+
+func (l *ConcurrencyLimiter) releaseFunc(key string, release func()) func() {
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			release()
+			l.unref(key)
+		})
+	}
+}
+
+func retryAfter(policy *v1alpha1.ConcurrencyLimitPolicy) time.Duration {
+	if policy.GetRetryAfter() != nil && policy.GetRetryAfter().AsDuration() > 0 {
+		return policy.GetRetryAfter().AsDuration()
+	}
+
+	return defaultRetryAfter
+}
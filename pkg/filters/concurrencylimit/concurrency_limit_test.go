@@ -0,0 +1,233 @@
+package concurrencylimit
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	filtersv1alpha1 "knoway.dev/api/filters/v1alpha1"
+	servicev1alpha1 "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+func newConcurrencyLimiter(t *testing.T, policies ...*filtersv1alpha1.ConcurrencyLimitPolicy) *ConcurrencyLimiter {
+	t.Helper()
+
+	cfg, err := anypb.New(&filtersv1alpha1.ConcurrencyLimitConfig{Policies: policies})
+	require.NoError(t, err)
+
+	f, err := NewWithConfig(cfg, bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+
+	limiter, ok := f.(*ConcurrencyLimiter)
+	require.True(t, ok)
+
+	return limiter
+}
+
+// sendRequest runs the filter for a request of the user, the returned cancel
+// function finishes serving the request.
+func sendRequest(t *testing.T, limiter *ConcurrencyLimiter, user string) (filters.RequestFilterResult, context.CancelFunc) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/v1/chat/completions", bytes.NewBufferString(`{"model": "gpt-4o", "messages": [{"role": "user", "content": "Hi"}]}`))
+	require.NoError(t, err)
+
+	httpRequest = httpRequest.WithContext(metadata.InitMetadataContext(httpRequest))
+	rMeta := metadata.RequestMetadataFromCtx(httpRequest.Context())
+	rMeta.AuthInfo = &servicev1alpha1.APIKeyAuthResponse{ApiKeyId: "key-" + user, UserId: user}
+
+	request, err := openai.NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	return limiter.OnCompletionRequest(httpRequest.Context(), request, httpRequest), cancel
+}
+
+func TestNewWithConfig(t *testing.T) {
+	for _, policy := range []*filtersv1alpha1.ConcurrencyLimitPolicy{
+		{MaxInFlight: 0},
+		{MaxInFlight: 1, MaxQueued: -1},
+	} {
+		cfg, err := anypb.New(&filtersv1alpha1.ConcurrencyLimitConfig{Policies: []*filtersv1alpha1.ConcurrencyLimitPolicy{policy}})
+		require.NoError(t, err)
+
+		_, err = NewWithConfig(cfg, bootkit.NewEmptyLifeCycle())
+		require.Error(t, err)
+	}
+}
+
+func TestConcurrencyLimiter_Reject(t *testing.T) {
+	limiter := newConcurrencyLimiter(t, &filtersv1alpha1.ConcurrencyLimitPolicy{
+		MaxInFlight: 1,
+		RetryAfter:  durationpb.New(3 * time.Second),
+	})
+
+	result, finish := sendRequest(t, limiter, "alice")
+	require.False(t, result.IsFailed())
+
+	result, cancel := sendRequest(t, limiter, "bob")
+	defer cancel()
+
+	require.True(t, result.IsFailed())
+
+	llmError := object.AsLLMError(result.Error)
+	require.NotNil(t, llmError)
+	assert.Equal(t, http.StatusTooManyRequests, llmError.GetStatus())
+	assert.Equal(t, string(object.LLMErrorCodeConcurrencyLimitExceeded), llmError.GetCode())
+
+	baseError, ok := result.Error.(*object.BaseLLMError)
+	require.True(t, ok)
+	assert.Equal(t, 3*time.Second, baseError.GetRetryAfter())
+
+	// The slot is released once the first request is served
+	finish()
+
+	require.Eventually(t, func() bool {
+		result, cancel := sendRequest(t, limiter, "bob")
+		defer cancel()
+
+		return !result.IsFailed()
+	}, time.Second, time.Millisecond)
+}
+
+func TestConcurrencyLimiter_Queue(t *testing.T) {
+	limiter := newConcurrencyLimiter(t, &filtersv1alpha1.ConcurrencyLimitPolicy{
+		MaxInFlight:  1,
+		MaxQueued:    1,
+		QueueTimeout: durationpb.New(time.Second),
+	})
+
+	result, finish := sendRequest(t, limiter, "alice")
+	require.False(t, result.IsFailed())
+
+	queued := make(chan filters.RequestFilterResult, 1)
+
+	go func() {
+		result, cancel := sendRequest(t, limiter, "bob")
+		defer cancel()
+
+		queued <- result
+	}()
+
+	require.Eventually(t, func() bool {
+		limiter.mutex.Lock()
+		defer limiter.mutex.Unlock()
+
+		q, ok := limiter.queues["PER_ROUTE:"]
+
+		return ok && q.Len() == 1
+	}, time.Second, time.Millisecond)
+
+	// The queue is full
+	result, cancel := sendRequest(t, limiter, "carol")
+	cancel()
+	require.True(t, result.IsFailed())
+
+	finish()
+
+	select {
+	case result := <-queued:
+		assert.False(t, result.IsFailed())
+	case <-time.After(time.Second):
+		require.Fail(t, "queued request was never served")
+	}
+}
+
+func TestConcurrencyLimiter_QueueTimeout(t *testing.T) {
+	limiter := newConcurrencyLimiter(t, &filtersv1alpha1.ConcurrencyLimitPolicy{
+		MaxInFlight:  1,
+		MaxQueued:    1,
+		QueueTimeout: durationpb.New(10 * time.Millisecond),
+	})
+
+	result, finish := sendRequest(t, limiter, "alice")
+	defer finish()
+
+	require.False(t, result.IsFailed())
+
+	result, cancel := sendRequest(t, limiter, "bob")
+	defer cancel()
+
+	require.True(t, result.IsFailed())
+	assert.Equal(t, http.StatusTooManyRequests, object.AsLLMError(result.Error).GetStatus())
+}
+
+func TestConcurrencyLimiter_PerUser(t *testing.T) {
+	limiter := newConcurrencyLimiter(t,
+		&filtersv1alpha1.ConcurrencyLimitPolicy{
+			BasedOn:     filtersv1alpha1.ConcurrencyLimitBaseOn_PER_USER,
+			Match:       &filtersv1alpha1.StringMatch{Match: &filtersv1alpha1.StringMatch_Exact{Exact: "vip"}},
+			MaxInFlight: 2,
+		},
+		&filtersv1alpha1.ConcurrencyLimitPolicy{
+			BasedOn:     filtersv1alpha1.ConcurrencyLimitBaseOn_PER_USER,
+			MaxInFlight: 1,
+		},
+	)
+
+	result, finishAlice := sendRequest(t, limiter, "alice")
+	defer finishAlice()
+
+	require.False(t, result.IsFailed())
+
+	result, cancel := sendRequest(t, limiter, "alice")
+	defer cancel()
+
+	require.True(t, result.IsFailed())
+
+	// Limits of users are independent
+	result, finishBob := sendRequest(t, limiter, "bob")
+	defer finishBob()
+
+	require.False(t, result.IsFailed())
+
+	for range 2 {
+		result, finish := sendRequest(t, limiter, "vip")
+		defer finish()
+
+		require.False(t, result.IsFailed())
+	}
+
+	result, cancel = sendRequest(t, limiter, "vip")
+	defer cancel()
+
+	require.True(t, result.IsFailed())
+}
+
+func TestConcurrencyLimiter_ReleaseOnRejection(t *testing.T) {
+	limiter := newConcurrencyLimiter(t,
+		&filtersv1alpha1.ConcurrencyLimitPolicy{MaxInFlight: 2},
+		&filtersv1alpha1.ConcurrencyLimitPolicy{
+			BasedOn:     filtersv1alpha1.ConcurrencyLimitBaseOn_PER_USER,
+			MaxInFlight: 1,
+		},
+	)
+
+	result, finish := sendRequest(t, limiter, "alice")
+	defer finish()
+
+	require.False(t, result.IsFailed())
+
+	// Rejected by the limit of the user, the slot of the route is given back
+	result, cancel := sendRequest(t, limiter, "alice")
+	cancel()
+	require.True(t, result.IsFailed())
+
+	result, finishBob := sendRequest(t, limiter, "bob")
+	defer finishBob()
+
+	require.False(t, result.IsFailed())
+}
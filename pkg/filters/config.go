@@ -5,19 +5,22 @@ import (
 	"net/http"
 
 	"knoway.dev/pkg/object"
-	"knoway.dev/pkg/utils"
 )
 
 const (
 	ListenerFilterResultTypeSucceeded = iota
 	ListenerFilterResultTypeFailed
 	ListenerFilterResultTypeSkipped
+	// ListenerFilterResultTypeResponded means the filter answered the request
+	// on its own, the request is not sent to the upstream
+	ListenerFilterResultTypeResponded
 )
 
 type RequestFilterResult struct {
-	// Type Succeeded, Failed, or Skipped
-	Type  int
-	Error error
+	// Type Succeeded, Failed, Skipped, or Responded
+	Type     int
+	Error    error
+	Response object.LLMResponse
 }
 
 func (r RequestFilterResult) IsFailed() bool {
@@ -32,6 +35,10 @@ func (r RequestFilterResult) IsSSucceeded() bool {
 	return r.Type == ListenerFilterResultTypeSucceeded
 }
 
+func (r RequestFilterResult) IsResponded() bool {
+	return r.Type == ListenerFilterResultTypeResponded
+}
+
 func NewOK() RequestFilterResult {
 	return RequestFilterResult{Type: ListenerFilterResultTypeSucceeded}
 }
@@ -40,6 +47,10 @@ func NewFailed(err error) RequestFilterResult {
 	return RequestFilterResult{Type: ListenerFilterResultTypeFailed, Error: err}
 }
 
+func NewResponded(response object.LLMResponse) RequestFilterResult {
+	return RequestFilterResult{Type: ListenerFilterResultTypeResponded, Response: response}
+}
+
 type RequestFilter interface {
 	isRequestFilter()
 }
@@ -68,6 +79,40 @@ type OnImageGenerationsRequestFilter interface {
 	OnImageGenerationsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) RequestFilterResult
 }
 
+type OnModerationsRequestFilter interface {
+	RequestFilter
+
+	OnModerationsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) RequestFilterResult
+}
+
+type OnEmbeddingsRequestFilter interface {
+	RequestFilter
+
+	OnEmbeddingsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) RequestFilterResult
+}
+
+type OnRerankRequestFilter interface {
+	RequestFilter
+
+	OnRerankRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) RequestFilterResult
+}
+
+// OnVideoGenerationsRequestFilter runs for every operation on the video
+// generation jobs, i.e. submitting, polling and fetching the content of them.
+type OnVideoGenerationsRequestFilter interface {
+	RequestFilter
+
+	OnVideoGenerationsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) RequestFilterResult
+}
+
+// OnRealtimeRequestFilter runs once when the sessions of the Realtime API
+// are established, the events of the sessions are not filtered.
+type OnRealtimeRequestFilter interface {
+	RequestFilter
+
+	OnRealtimeRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) RequestFilterResult
+}
+
 type OnCompletionResponseFilter interface {
 	RequestFilter
 
@@ -80,12 +125,28 @@ type OnCompletionStreamResponseFilter interface {
 	OnCompletionStreamResponse(ctx context.Context, request object.LLMRequest, response object.LLMStreamResponse, responseChunk object.LLMChunkResponse) RequestFilterResult
 }
 
+// OnCompletionStreamChunkFilter modifies the chunks of streams before they are
+// sent to the client, while OnCompletionStreamResponse observes them after.
+type OnCompletionStreamChunkFilter interface {
+	RequestFilter
+
+	OnCompletionStreamChunk(ctx context.Context, request object.LLMRequest, response object.LLMStreamResponse, responseChunk object.LLMChunkResponse) RequestFilterResult
+}
+
 type OnImageGenerationsResponseFilter interface {
 	RequestFilter
 
 	OnImageGenerationsResponse(ctx context.Context, request object.LLMRequest, response object.LLMResponse) RequestFilterResult
 }
 
+// OnRealtimeResponseFilter runs once the sessions of the Realtime API end,
+// with the usage of all the responses of the sessions.
+type OnRealtimeResponseFilter interface {
+	RequestFilter
+
+	OnRealtimeResponse(ctx context.Context, request object.LLMRequest, response object.LLMResponse) RequestFilterResult
+}
+
 type OnResponsePostFilter interface {
 	RequestFilter
 
@@ -95,29 +156,57 @@ type OnResponsePostFilter interface {
 type RequestFilters []RequestFilter
 
 func (r RequestFilters) OnRequestPreFilters() []OnRequestPreFilter {
-	return utils.TypeAssertFrom[RequestFilter, OnRequestPreFilter](r)
+	return filtersOf[OnRequestPreFilter](r)
 }
 
 func (r RequestFilters) OnCompletionRequestFilters() []OnCompletionRequestFilter {
-	return utils.TypeAssertFrom[RequestFilter, OnCompletionRequestFilter](r)
+	return filtersOf[OnCompletionRequestFilter](r)
 }
 
 func (r RequestFilters) OnImageGenerationsRequestFilters() []OnImageGenerationsRequestFilter {
-	return utils.TypeAssertFrom[RequestFilter, OnImageGenerationsRequestFilter](r)
+	return filtersOf[OnImageGenerationsRequestFilter](r)
+}
+
+func (r RequestFilters) OnModerationsRequestFilters() []OnModerationsRequestFilter {
+	return filtersOf[OnModerationsRequestFilter](r)
+}
+
+func (r RequestFilters) OnEmbeddingsRequestFilters() []OnEmbeddingsRequestFilter {
+	return filtersOf[OnEmbeddingsRequestFilter](r)
+}
+
+func (r RequestFilters) OnRerankRequestFilters() []OnRerankRequestFilter {
+	return filtersOf[OnRerankRequestFilter](r)
+}
+
+func (r RequestFilters) OnVideoGenerationsRequestFilters() []OnVideoGenerationsRequestFilter {
+	return filtersOf[OnVideoGenerationsRequestFilter](r)
+}
+
+func (r RequestFilters) OnRealtimeRequestFilters() []OnRealtimeRequestFilter {
+	return filtersOf[OnRealtimeRequestFilter](r)
 }
 
 func (r RequestFilters) OnCompletionResponseFilters() []OnCompletionResponseFilter {
-	return utils.TypeAssertFrom[RequestFilter, OnCompletionResponseFilter](r)
+	return filtersOf[OnCompletionResponseFilter](r)
 }
 
 func (r RequestFilters) OnCompletionStreamResponseFilters() []OnCompletionStreamResponseFilter {
-	return utils.TypeAssertFrom[RequestFilter, OnCompletionStreamResponseFilter](r)
+	return filtersOf[OnCompletionStreamResponseFilter](r)
+}
+
+func (r RequestFilters) OnCompletionStreamChunkFilters() []OnCompletionStreamChunkFilter {
+	return filtersOf[OnCompletionStreamChunkFilter](r)
 }
 
 func (r RequestFilters) OnImageGenerationsResponseFilters() []OnImageGenerationsResponseFilter {
-	return utils.TypeAssertFrom[RequestFilter, OnImageGenerationsResponseFilter](r)
+	return filtersOf[OnImageGenerationsResponseFilter](r)
+}
+
+func (r RequestFilters) OnRealtimeResponseFilters() []OnRealtimeResponseFilter {
+	return filtersOf[OnRealtimeResponseFilter](r)
 }
 
 func (r RequestFilters) OnResponsePostFilters() []OnResponsePostFilter {
-	return utils.TypeAssertFrom[RequestFilter, OnResponsePostFilter](r)
+	return filtersOf[OnResponsePostFilter](r)
 }
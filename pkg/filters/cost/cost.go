@@ -0,0 +1,65 @@
+package cost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/cost"
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/types/openai"
+)
+
+func NewWithConfig(cfg *anypb.Any, _ bootkit.LifeCycle) (filters.RequestFilter, error) {
+	_, err := protoutils.FromAny(cfg, &v1alpha1.CostConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	return &CostFilter{}, nil
+}
+
+var _ filters.RequestFilter = (*CostFilter)(nil)
+var _ filters.OnResponsePostFilter = (*CostFilter)(nil)
+
+// CostFilter computes the cost of the request by the pricing of the cluster
+// that served it once the response is complete, including the streams.
+type CostFilter struct {
+	filters.IsRequestFilter
+}
+
+func (f *CostFilter) OnResponsePost(ctx context.Context, _ *http.Request, response any, err error) {
+	if err != nil && !errors.Is(err, openai.SkipStreamResponse) {
+		return
+	}
+
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	if rMeta == nil {
+		return
+	}
+
+	cluster, ok := rMeta.SelectedCluster.Get()
+	if !ok || lo.IsNil(cluster) {
+		return
+	}
+
+	pricing := cluster.GetClusterConfig().GetPricing()
+	if pricing == nil {
+		return
+	}
+
+	llmResponse, _ := response.(object.LLMResponse)
+
+	rMeta.Cost = mo.Some(cost.Compute(pricing, cost.UsageFromMetadata(rMeta, llmResponse)))
+	rMeta.CostCurrency = pricing.GetCurrency()
+}
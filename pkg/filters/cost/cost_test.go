@@ -0,0 +1,79 @@
+package cost
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	clustersv1alpha1 "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/clusters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+type fakeCluster struct {
+	clusters.Cluster
+
+	config *clustersv1alpha1.Cluster
+}
+
+func (c *fakeCluster) GetClusterConfig() *clustersv1alpha1.Cluster {
+	return c.config
+}
+
+func TestCostFilter_OnResponsePost(t *testing.T) {
+	cfg, err := anypb.New(&v1alpha1.CostConfig{})
+	require.NoError(t, err)
+
+	f, err := NewWithConfig(cfg, bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+
+	costFilter, ok := f.(*CostFilter)
+	require.True(t, ok)
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com/v1/chat/completions", nil)
+	require.NoError(t, err)
+
+	ctx := metadata.InitMetadataContext(request)
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	rMeta.LLMUpstreamTokensUsage = mo.Some[object.LLMTokensUsage](&openai.ChatCompletionsUsage{
+		PromptTokens:     2000,
+		CompletionTokens: 1000,
+	})
+
+	// No cluster selected
+	costFilter.OnResponsePost(ctx, request, nil, nil)
+	assert.True(t, rMeta.Cost.IsAbsent())
+
+	// Cluster without pricing
+	rMeta.SelectedCluster = mo.Some[clusters.Cluster](&fakeCluster{config: &clustersv1alpha1.Cluster{Name: "gpt-4o"}})
+	costFilter.OnResponsePost(ctx, request, nil, nil)
+	assert.True(t, rMeta.Cost.IsAbsent())
+
+	rMeta.SelectedCluster = mo.Some[clusters.Cluster](&fakeCluster{config: &clustersv1alpha1.Cluster{
+		Name: "gpt-4o",
+		Pricing: &clustersv1alpha1.ClusterPricing{
+			Currency:              "USD",
+			PromptPer1KTokens:     0.0025,
+			CompletionPer1KTokens: 0.01,
+		},
+	}})
+
+	// Failed requests are not priced
+	costFilter.OnResponsePost(ctx, request, nil, errors.New("upstream unavailable"))
+	assert.True(t, rMeta.Cost.IsAbsent())
+
+	// Streams are
+	costFilter.OnResponsePost(ctx, request, nil, openai.SkipStreamResponse)
+	assert.InDelta(t, 0.015, rMeta.Cost.OrEmpty(), 1e-9)
+	assert.Equal(t, "USD", rMeta.CostCurrency)
+}
@@ -0,0 +1,256 @@
+// Package extproc processes the chat completions with processors out of
+// process, gRPC services or WASM modules, so that policies are added without
+// forking the gateway.
+package extproc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/samber/lo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/types/openai"
+)
+
+const defaultProcessorTimeout = 3 * time.Second
+
+// ExternalProcessor sends the bodies of the chat completions to the processor,
+// which replaces them or rejects the requests.
+type ExternalProcessor struct {
+	filters.IsRequestFilter
+
+	processor       Processor
+	timeout         time.Duration
+	processRequest  bool
+	processResponse bool
+	failClosed      bool
+	forwardHeaders  []string
+	config          *structpb.Struct
+}
+
+var _ filters.RequestFilter = (*ExternalProcessor)(nil)
+var _ filters.OnCompletionRequestFilter = (*ExternalProcessor)(nil)
+var _ filters.OnCompletionResponseFilter = (*ExternalProcessor)(nil)
+
+func NewWithConfig(cfg *anypb.Any, lifecycle bootkit.LifeCycle) (filters.RequestFilter, error) {
+	c, err := protoutils.FromAny(cfg, &v1alpha1.ExternalProcessorConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	if !c.GetProcessRequest() && !c.GetProcessResponse() {
+		return nil, errors.New("either process_request or process_response is required")
+	}
+
+	ep := &ExternalProcessor{
+		timeout:         c.GetTimeout().AsDuration(),
+		processRequest:  c.GetProcessRequest(),
+		processResponse: c.GetProcessResponse(),
+		failClosed:      c.GetFailClosed(),
+		forwardHeaders:  c.GetForwardHeaders(),
+		config:          c.GetConfig(),
+	}
+	if ep.timeout <= 0 {
+		ep.timeout = defaultProcessorTimeout
+	}
+
+	switch {
+	case c.GetGrpcService() != nil:
+		if c.GetGrpcService().GetUrl() == "" {
+			return nil, errors.New("invalid grpc service url")
+		}
+
+		conn, err := grpc.NewClient(c.GetGrpcService().GetUrl(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect external processor: %w", err)
+		}
+
+		lifecycle.Append(bootkit.LifeCycleHook{
+			OnStop: func(ctx context.Context) error {
+				return conn.Close()
+			},
+		})
+
+		ep.processor = NewGRPCProcessor(conn)
+	case c.GetWasm() != nil:
+		ep.processor, err = loadWASMModule(context.Background(), c.GetWasm())
+		if err != nil {
+			return nil, err
+		}
+
+		if closer, ok := ep.processor.(io.Closer); ok {
+			lifecycle.Append(bootkit.LifeCycleHook{
+				OnStop: func(ctx context.Context) error {
+					return closer.Close()
+				},
+			})
+		}
+	default:
+		return nil, errors.New("either grpc_service or wasm is required")
+	}
+
+	return ep, nil
+}
+
+// WithProcessor replaces the processor, which is the one configured by
+// default.
+func (ep *ExternalProcessor) WithProcessor(processor Processor) *ExternalProcessor {
+	ep.processor = processor
+	return ep
+}
+
+func (ep *ExternalProcessor) process(ctx context.Context, request *service.ProcessingRequest, sourceHTTPRequest *http.Request) (*service.ProcessingResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, ep.timeout)
+	defer cancel()
+
+	request.Config = ep.config
+
+	if sourceHTTPRequest != nil && len(ep.forwardHeaders) > 0 {
+		request.Headers = make(map[string]string, len(ep.forwardHeaders))
+
+		for _, header := range ep.forwardHeaders {
+			if value := sourceHTTPRequest.Header.Get(header); value != "" {
+				request.Headers[http.CanonicalHeaderKey(header)] = value
+			}
+		}
+	}
+
+	if rMeta := metadata.RequestMetadataFromCtx(ctx); rMeta != nil {
+		request.ApiKeyId = rMeta.AuthInfo.GetApiKeyId()
+		request.UserId = rMeta.AuthInfo.GetUserId()
+		request.TenantId = rMeta.AuthInfo.GetTenantId()
+	}
+
+	return ep.processor.Process(ctx, request)
+}
+
+// failed decides the result of the request when the processor fails.
+func (ep *ExternalProcessor) failed(ctx context.Context, err error) filters.RequestFilterResult {
+	if ep.failClosed {
+		return filters.NewFailed(openai.NewErrorServiceUnavailable().WithCause(err))
+	}
+
+	slog.WarnContext(ctx, "failed to process with external processor, served unprocessed", slog.String("filter", "external_processor"), slog.Any("error", err))
+
+	return filters.NewOK()
+}
+
+type bodySetter interface {
+	SetBody(body []byte) error
+}
+
+func (ep *ExternalProcessor) OnCompletionRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	if !ep.processRequest {
+		return filters.NewOK()
+	}
+
+	setter, ok := request.(bodySetter)
+	if !ok {
+		return filters.NewOK()
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return filters.NewFailed(openai.NewErrorInternalError().WithCause(err))
+	}
+
+	resp, err := ep.process(ctx, &service.ProcessingRequest{
+		Phase:       service.ProcessingPhase_PROCESSING_PHASE_REQUEST,
+		RequestType: string(request.GetRequestType()),
+		Body:        body,
+	}, sourceHTTPRequest)
+	if err != nil {
+		return ep.failed(ctx, err)
+	}
+
+	if immediate := resp.GetImmediateResponse(); immediate != nil {
+		return filters.NewFailed(immediateError(immediate))
+	}
+
+	if len(resp.GetBody()) == 0 {
+		return filters.NewOK()
+	}
+
+	err = setter.SetBody(resp.GetBody())
+	if err != nil {
+		return filters.NewFailed(object.LLMErrorOrInternalError(err))
+	}
+
+	slog.DebugContext(ctx, "request body replaced by external processor", slog.String("filter", "external_processor"))
+
+	return filters.NewOK()
+}
+
+// OnCompletionResponse processes the responses not streamed, error responses
+// are left as is.
+func (ep *ExternalProcessor) OnCompletionResponse(ctx context.Context, request object.LLMRequest, response object.LLMResponse) filters.RequestFilterResult {
+	if !ep.processResponse || lo.IsNil(response) || response.IsStream() || !lo.IsNil(response.GetError()) {
+		return filters.NewOK()
+	}
+
+	setter, ok := response.(bodySetter)
+	if !ok {
+		return filters.NewOK()
+	}
+
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return filters.NewFailed(openai.NewErrorInternalError().WithCause(err))
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		return filters.NewFailed(openai.NewErrorInternalError().WithCause(err))
+	}
+
+	resp, err := ep.process(ctx, &service.ProcessingRequest{
+		Phase:       service.ProcessingPhase_PROCESSING_PHASE_RESPONSE,
+		RequestType: string(request.GetRequestType()),
+		Body:        body,
+		RequestBody: requestBody,
+	}, request.GetRawRequest())
+	if err != nil {
+		return ep.failed(ctx, err)
+	}
+
+	if len(resp.GetBody()) == 0 {
+		return filters.NewOK()
+	}
+
+	err = setter.SetBody(resp.GetBody())
+	if err != nil {
+		return filters.NewFailed(openai.NewErrorInternalError().WithCause(err))
+	}
+
+	return filters.NewOK()
+}
+
+func immediateError(immediate *service.ImmediateResponse) *openai.ErrorResponse {
+	status := int(immediate.GetStatusCode())
+	if status == 0 {
+		status = http.StatusForbidden
+	}
+
+	return openai.NewErrorResponse(status, openai.Error{
+		Message: lo.CoalesceOrEmpty(immediate.GetMessage(), "Your request was rejected by the gateway."),
+		Type:    lo.CoalesceOrEmpty(immediate.GetType(), "invalid_request_error"),
+		Code:    lo.EmptyableToPtr(immediate.GetCode()),
+	})
+}
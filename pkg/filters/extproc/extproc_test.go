@@ -0,0 +1,272 @@
+package extproc
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+// policyServer rejects the prompts mentioning "forbidden", caps max_tokens of
+// the requests, and appends a notice to the content of the responses.
+type policyServer struct {
+	service.UnimplementedExternalProcessorServiceServer
+
+	requests []*service.ProcessingRequest
+}
+
+func (s *policyServer) Process(_ context.Context, request *service.ProcessingRequest) (*service.ProcessingResponse, error) {
+	s.requests = append(s.requests, request)
+
+	var body map[string]any
+
+	err := json.Unmarshal(request.GetBody(), &body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch request.GetPhase() {
+	case service.ProcessingPhase_PROCESSING_PHASE_REQUEST:
+		if strings.Contains(string(request.GetBody()), "forbidden") {
+			return &service.ProcessingResponse{
+				ImmediateResponse: &service.ImmediateResponse{Message: "Topic is not allowed.", Code: "policy_violation"},
+			}, nil
+		}
+
+		body["max_tokens"] = request.GetConfig().GetFields()["maxTokens"].GetNumberValue()
+	case service.ProcessingPhase_PROCESSING_PHASE_RESPONSE:
+		choices, _ := body["choices"].([]any)
+		for _, choice := range choices {
+			message, _ := choice.(map[string]any)["message"].(map[string]any)
+			message["content"] = message["content"].(string) + " (reviewed)"
+		}
+	default:
+		return nil, errors.New("unexpected phase")
+	}
+
+	return &service.ProcessingResponse{Body: lo.Must(json.Marshal(body))}, nil
+}
+
+func newGRPCProcessor(t *testing.T, server *policyServer) Processor {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	service.RegisterExternalProcessorServiceServer(s, server)
+
+	go func() {
+		_ = s.Serve(listener)
+	}()
+
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return NewGRPCProcessor(conn)
+}
+
+func newExternalProcessor(t *testing.T, cfg *v1alpha1.ExternalProcessorConfig, processor Processor) *ExternalProcessor {
+	t.Helper()
+
+	cfg.Processor = &v1alpha1.ExternalProcessorConfig_GrpcService{GrpcService: &v1alpha1.ExternalProcessorConfig_GRPCService{Url: "localhost:9090"}}
+
+	f, err := NewWithConfig(lo.Must(anypb.New(cfg)), bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+
+	ep, ok := f.(*ExternalProcessor)
+	require.True(t, ok)
+
+	return ep.WithProcessor(processor)
+}
+
+// newRequest is the request of user_001 authenticated with key_001, with the
+// X-Request-Id header.
+func newRequest(t *testing.T, body string) (context.Context, *http.Request, *openai.ChatCompletionsRequest) {
+	t.Helper()
+
+	ctx, request := gatewaytest.NewChatCompletionRequest(t, body)
+	request.GetRawRequest().Header.Set("X-Request-Id", "req-1")
+
+	metadata.RequestMetadataFromCtx(ctx).AuthInfo = &service.APIKeyAuthResponse{ApiKeyId: "key_001", UserId: "user_001"}
+
+	return ctx, request.GetRawRequest(), request
+}
+
+func TestNewWithConfig(t *testing.T) {
+	_, err := NewWithConfig(lo.Must(anypb.New(&v1alpha1.ExternalProcessorConfig{
+		Processor: &v1alpha1.ExternalProcessorConfig_GrpcService{GrpcService: &v1alpha1.ExternalProcessorConfig_GRPCService{Url: "localhost:9090"}},
+	})), bootkit.NewEmptyLifeCycle())
+	require.EqualError(t, err, "either process_request or process_response is required")
+
+	_, err = NewWithConfig(lo.Must(anypb.New(&v1alpha1.ExternalProcessorConfig{ProcessRequest: true})), bootkit.NewEmptyLifeCycle())
+	require.EqualError(t, err, "either grpc_service or wasm is required")
+
+	_, err = NewWithConfig(lo.Must(anypb.New(&v1alpha1.ExternalProcessorConfig{
+		Processor:      &v1alpha1.ExternalProcessorConfig_GrpcService{GrpcService: &v1alpha1.ExternalProcessorConfig_GRPCService{Url: "localhost:9090"}},
+		ProcessRequest: true,
+	})), bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+}
+
+type processorFunc func(ctx context.Context, request *service.ProcessingRequest) (*service.ProcessingResponse, error)
+
+func (f processorFunc) Process(ctx context.Context, request *service.ProcessingRequest) (*service.ProcessingResponse, error) {
+	return f(ctx, request)
+}
+
+func TestNewWithConfig_WASM(t *testing.T) {
+	module := []byte("\x00asm\x01\x00\x00\x00")
+	path := filepath.Join(t.TempDir(), "policy.wasm")
+	require.NoError(t, os.WriteFile(path, module, 0o600))
+
+	digest := sha256.Sum256(module)
+	cfg := &v1alpha1.ExternalProcessorConfig{
+		Processor:      &v1alpha1.ExternalProcessorConfig_Wasm{Wasm: &v1alpha1.ExternalProcessorConfig_WASMModule{Path: path, Sha256: hex.EncodeToString(digest[:])}},
+		ProcessRequest: true,
+	}
+
+	_, err := NewWithConfig(lo.Must(anypb.New(cfg)), bootkit.NewEmptyLifeCycle())
+	require.EqualError(t, err, "no WASM runtime is registered in the gateway")
+
+	var loaded []byte
+
+	RegisterWASMRuntime(func(_ context.Context, module []byte) (Processor, error) {
+		loaded = module
+
+		return processorFunc(func(context.Context, *service.ProcessingRequest) (*service.ProcessingResponse, error) {
+			return &service.ProcessingResponse{}, nil
+		}), nil
+	})
+	t.Cleanup(func() {
+		RegisterWASMRuntime(nil)
+	})
+
+	_, err = NewWithConfig(lo.Must(anypb.New(cfg)), bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+	assert.Equal(t, module, loaded)
+
+	cfg.GetWasm().Sha256 = strings.Repeat("0", 64)
+
+	_, err = NewWithConfig(lo.Must(anypb.New(cfg)), bootkit.NewEmptyLifeCycle())
+	require.ErrorContains(t, err, "sha256 of wasm module")
+}
+
+func TestExternalProcessor_OnCompletionRequest(t *testing.T) {
+	server := new(policyServer)
+	ep := newExternalProcessor(t, &v1alpha1.ExternalProcessorConfig{
+		ProcessRequest: true,
+		ForwardHeaders: []string{"x-request-id"},
+		Config:         lo.Must(structpb.NewStruct(map[string]any{"maxTokens": 256})),
+	}, newGRPCProcessor(t, server))
+
+	ctx, httpRequest, request := newRequest(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "hi"}]}`)
+
+	result := ep.OnCompletionRequest(ctx, request, httpRequest)
+	require.True(t, result.IsSSucceeded())
+	assert.InDelta(t, 256, request.GetBodyParsed()["max_tokens"], 0)
+	assert.Equal(t, "gpt-4o", request.GetModel())
+
+	require.Len(t, server.requests, 1)
+	assert.Equal(t, "chat_completions", server.requests[0].GetRequestType())
+	assert.Equal(t, map[string]string{"X-Request-Id": "req-1"}, server.requests[0].GetHeaders())
+	assert.Equal(t, "key_001", server.requests[0].GetApiKeyId())
+	assert.Equal(t, "user_001", server.requests[0].GetUserId())
+
+	ctx, httpRequest, request = newRequest(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "something forbidden"}]}`)
+
+	result = ep.OnCompletionRequest(ctx, request, httpRequest)
+	require.True(t, result.IsFailed())
+
+	var errorResponse *openai.ErrorResponse
+
+	require.ErrorAs(t, result.Error, &errorResponse)
+	assert.Equal(t, http.StatusForbidden, errorResponse.GetStatus())
+	assert.Equal(t, "policy_violation", errorResponse.GetCode())
+	assert.Equal(t, "Topic is not allowed.", errorResponse.GetMessage())
+}
+
+func TestExternalProcessor_OnCompletionRequest_Failed(t *testing.T) {
+	failing := processorFunc(func(context.Context, *service.ProcessingRequest) (*service.ProcessingResponse, error) {
+		return nil, errors.New("unavailable")
+	})
+
+	ctx, httpRequest, request := newRequest(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "hi"}]}`)
+
+	ep := newExternalProcessor(t, &v1alpha1.ExternalProcessorConfig{ProcessRequest: true}, failing)
+	assert.True(t, ep.OnCompletionRequest(ctx, request, httpRequest).IsSSucceeded())
+
+	ep = newExternalProcessor(t, &v1alpha1.ExternalProcessorConfig{ProcessRequest: true, FailClosed: true}, failing)
+	assert.True(t, ep.OnCompletionRequest(ctx, request, httpRequest).IsFailed())
+
+	invalid := processorFunc(func(context.Context, *service.ProcessingRequest) (*service.ProcessingResponse, error) {
+		return &service.ProcessingResponse{Body: []byte("not json")}, nil
+	})
+
+	ep = newExternalProcessor(t, &v1alpha1.ExternalProcessorConfig{ProcessRequest: true}, invalid)
+	assert.True(t, ep.OnCompletionRequest(ctx, request, httpRequest).IsFailed())
+	assert.Equal(t, "gpt-4o", request.GetModel())
+}
+
+func TestExternalProcessor_OnCompletionResponse(t *testing.T) {
+	server := new(policyServer)
+	ep := newExternalProcessor(t, &v1alpha1.ExternalProcessorConfig{ProcessResponse: true}, newGRPCProcessor(t, server))
+
+	ctx, httpRequest, request := newRequest(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "hi"}]}`)
+
+	// Requests are not processed
+	require.True(t, ep.OnCompletionRequest(ctx, request, httpRequest).IsSSucceeded())
+	assert.Empty(t, server.requests)
+
+	response, err := openai.NewChatCompletionResponse(request, &http.Response{StatusCode: http.StatusOK}, bufio.NewReader(strings.NewReader(`{
+		"model": "gpt-4o",
+		"choices": [{"index": 0, "message": {"role": "assistant", "content": "hello"}}],
+		"usage": {"prompt_tokens": 1, "completion_tokens": 1, "total_tokens": 2}
+	}`)))
+	require.NoError(t, err)
+
+	require.True(t, ep.OnCompletionResponse(ctx, request, response).IsSSucceeded())
+	assert.Equal(t, "hello (reviewed)", response.GetChoiceMessages()[0]["content"])
+
+	usage, ok := response.GetUsage().(object.LLMTokensUsage)
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), usage.GetTotalTokens())
+
+	require.Len(t, server.requests, 1)
+	assert.Equal(t, service.ProcessingPhase_PROCESSING_PHASE_RESPONSE, server.requests[0].GetPhase())
+	assert.JSONEq(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "hi"}]}`, string(server.requests[0].GetRequestBody()))
+}
@@ -0,0 +1,88 @@
+package extproc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"knoway.dev/api/filters/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+)
+
+// Processor processes the requests and the responses out of process.
+type Processor interface {
+	Process(ctx context.Context, request *service.ProcessingRequest) (*service.ProcessingResponse, error)
+}
+
+// GRPCProcessor calls a knoway.service.v1alpha1.ExternalProcessorService.
+type GRPCProcessor struct {
+	client service.ExternalProcessorServiceClient
+}
+
+func NewGRPCProcessor(conn grpc.ClientConnInterface) *GRPCProcessor {
+	return &GRPCProcessor{client: service.NewExternalProcessorServiceClient(conn)}
+}
+
+func (p *GRPCProcessor) Process(ctx context.Context, request *service.ProcessingRequest) (*service.ProcessingResponse, error) {
+	return p.client.Process(ctx, request)
+}
+
+// WASMRuntime instantiates the module as a processor following the ABI of
+// v1alpha1.ExternalProcessorConfig_WASMModule. The processor is closed when
+// the gateway stops if it implements io.Closer.
+type WASMRuntime func(ctx context.Context, module []byte) (Processor, error)
+
+var (
+	wasmRuntimeMutex sync.RWMutex
+	wasmRuntime      WASMRuntime
+)
+
+// RegisterWASMRuntime registers the runtime running the WASM modules. The
+// gateway registers WazeroRuntime on start, filters of WASM modules fail to be
+// created until one is registered.
+func RegisterWASMRuntime(runtime WASMRuntime) {
+	wasmRuntimeMutex.Lock()
+	defer wasmRuntimeMutex.Unlock()
+
+	wasmRuntime = runtime
+}
+
+func loadWASMModule(ctx context.Context, cfg *v1alpha1.ExternalProcessorConfig_WASMModule) (Processor, error) {
+	wasmRuntimeMutex.RLock()
+	runtime := wasmRuntime
+	wasmRuntimeMutex.RUnlock()
+
+	if runtime == nil {
+		return nil, errors.New("no WASM runtime is registered in the gateway")
+	}
+
+	if cfg.GetPath() == "" {
+		return nil, errors.New("invalid wasm module path")
+	}
+
+	module, err := os.ReadFile(cfg.GetPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wasm module: %w", err)
+	}
+
+	if cfg.GetSha256() != "" {
+		digest := sha256.Sum256(module)
+		if hex.EncodeToString(digest[:]) != strings.ToLower(cfg.GetSha256()) {
+			return nil, fmt.Errorf("sha256 of wasm module %s mismatched", cfg.GetPath())
+		}
+	}
+
+	processor, err := runtime(ctx, module)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate wasm module %s: %w", cfg.GetPath(), err)
+	}
+
+	return processor, nil
+}
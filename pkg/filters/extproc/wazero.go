@@ -0,0 +1,146 @@
+package extproc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"google.golang.org/protobuf/proto"
+
+	service "knoway.dev/api/service/v1alpha1"
+)
+
+const (
+	wasmAllocFunction   = "knoway_alloc"
+	wasmProcessFunction = "knoway_process"
+)
+
+// wazeroProcessor runs a module compiled by wazero. Instances are not safe for
+// concurrent use, so the calls are serialized, and the instance is replaced
+// once closed, e.g. when a call times out.
+type wazeroProcessor struct {
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+
+	mutex  sync.Mutex
+	module api.Module
+}
+
+var _ Processor = (*wazeroProcessor)(nil)
+
+// WazeroRuntime runs the WASM modules with wazero, which requires no cgo.
+// WASI is available to the modules, which are initialized by `_initialize`
+// if they export it, e.g. reactors built by TinyGo or Rust.
+func WazeroRuntime(ctx context.Context, module []byte) (Processor, error) {
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().WithCloseOnContextDone(true))
+
+	_, err := wasi_snapshot_preview1.Instantiate(ctx, runtime)
+	if err != nil {
+		return nil, errors.Join(err, runtime.Close(ctx))
+	}
+
+	compiled, err := runtime.CompileModule(ctx, module)
+	if err != nil {
+		return nil, errors.Join(err, runtime.Close(ctx))
+	}
+
+	for _, name := range []string{wasmAllocFunction, wasmProcessFunction} {
+		if _, ok := compiled.ExportedFunctions()[name]; !ok {
+			return nil, errors.Join(fmt.Errorf("module does not export %s", name), runtime.Close(ctx))
+		}
+	}
+
+	if _, ok := compiled.ExportedMemories()["memory"]; !ok {
+		return nil, errors.Join(errors.New("module does not export memory"), runtime.Close(ctx))
+	}
+
+	p := &wazeroProcessor{
+		runtime:  runtime,
+		compiled: compiled,
+	}
+
+	// Instantiated ahead, so that modules failing to initialize are rejected
+	// along with the config
+	_, err = p.instance(ctx)
+	if err != nil {
+		return nil, errors.Join(err, runtime.Close(ctx))
+	}
+
+	return p, nil
+}
+
+func (p *wazeroProcessor) instance(ctx context.Context) (api.Module, error) {
+	if p.module != nil && !p.module.IsClosed() {
+		return p.module, nil
+	}
+
+	// Anonymous, so that the instance replaced never conflicts with the
+	// new one
+	module, err := p.runtime.InstantiateModule(ctx, p.compiled, wazero.NewModuleConfig().
+		WithName("").
+		WithStartFunctions("_initialize"))
+	if err != nil {
+		return nil, err
+	}
+
+	p.module = module
+
+	return module, nil
+}
+
+func (p *wazeroProcessor) Process(ctx context.Context, request *service.ProcessingRequest) (*service.ProcessingResponse, error) {
+	in, err := proto.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	module, err := p.instance(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := module.ExportedFunction(wasmAllocFunction).Call(ctx, uint64(len(in)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", wasmAllocFunction, err)
+	}
+
+	ptr := uint32(results[0]) //nolint:gosec
+	if !module.Memory().Write(ptr, in) {
+		return nil, fmt.Errorf("%s returned memory out of range", wasmAllocFunction)
+	}
+
+	results, err = module.ExportedFunction(wasmProcessFunction).Call(ctx, uint64(ptr), uint64(len(in)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", wasmProcessFunction, err)
+	}
+
+	outPtr, outLen := uint32(results[0]>>32), uint32(results[0]) //nolint:gosec,mnd
+
+	out, ok := module.Memory().Read(outPtr, outLen)
+	if !ok {
+		return nil, fmt.Errorf("%s returned memory out of range", wasmProcessFunction)
+	}
+
+	response := new(service.ProcessingResponse)
+
+	// The memory read is a view of the instance, which the next call reuses
+	err = proto.Unmarshal(bytes.Clone(out), response)
+	if err != nil {
+		return nil, fmt.Errorf("invalid processing response: %w", err)
+	}
+
+	return response, nil
+}
+
+// Close releases the instance and the compiled module.
+func (p *wazeroProcessor) Close() error {
+	return p.runtime.Close(context.Background())
+}
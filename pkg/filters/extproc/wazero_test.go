@@ -0,0 +1,75 @@
+package extproc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	service "knoway.dev/api/service/v1alpha1"
+)
+
+// replaceBodyModule allocates the requests at 1024, and responds with the
+// ProcessingResponse replacing the body with `{}`, stored at 0.
+var replaceBodyModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	// types: (i32) -> i32, (i32, i32) -> i64
+	0x01, 0x0c, 0x02, 0x60, 0x01, 0x7f, 0x01, 0x7f, 0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7e,
+	// functions
+	0x03, 0x03, 0x02, 0x00, 0x01,
+	// memory of 1 page
+	0x05, 0x03, 0x01, 0x00, 0x01,
+	// exports: memory, knoway_alloc, knoway_process
+	0x07, 0x2a, 0x03,
+	0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00,
+	0x0c, 'k', 'n', 'o', 'w', 'a', 'y', '_', 'a', 'l', 'l', 'o', 'c', 0x00, 0x00,
+	0x0e, 'k', 'n', 'o', 'w', 'a', 'y', '_', 'p', 'r', 'o', 'c', 'e', 's', 's', 0x00, 0x01,
+	// code: i32.const 1024, i64.const 4
+	0x0a, 0x0c, 0x02, 0x05, 0x00, 0x41, 0x80, 0x08, 0x0b, 0x04, 0x00, 0x42, 0x04, 0x0b,
+	// data: ProcessingResponse{Body: "{}"} at 0
+	0x0b, 0x0a, 0x01, 0x00, 0x41, 0x00, 0x0b, 0x04, 0x0a, 0x02, '{', '}',
+}
+
+func TestWazeroRuntime(t *testing.T) {
+	processor, err := WazeroRuntime(context.Background(), replaceBodyModule)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, processor.(*wazeroProcessor).Close())
+	})
+
+	request := &service.ProcessingRequest{
+		Phase: service.ProcessingPhase_PROCESSING_PHASE_REQUEST,
+		Body:  []byte(`{"model":"gpt-4o"}`),
+	}
+
+	response, err := processor.Process(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{}`), response.GetBody())
+
+	// The request is written to the memory allocated by the module
+	in, err := proto.Marshal(request)
+	require.NoError(t, err)
+
+	written, ok := processor.(*wazeroProcessor).module.Memory().Read(1024, uint32(len(in))) //nolint:gosec
+	require.True(t, ok)
+	assert.Equal(t, in, written)
+
+	// Instantiated again once the instance is closed, e.g. timed out
+	require.NoError(t, processor.(*wazeroProcessor).module.Close(context.Background()))
+
+	response, err = processor.Process(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{}`), response.GetBody())
+}
+
+func TestWazeroRuntime_InvalidModule(t *testing.T) {
+	_, err := WazeroRuntime(context.Background(), []byte("not a module"))
+	require.Error(t, err)
+
+	// Valid, but without the functions of the ABI
+	_, err = WazeroRuntime(context.Background(), []byte("\x00asm\x01\x00\x00\x00"))
+	require.EqualError(t, err, "module does not export knoway_alloc")
+}
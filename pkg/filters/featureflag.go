@@ -0,0 +1,200 @@
+package filters
+
+import (
+	"context"
+	"net/http"
+
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+)
+
+var (
+	_ OnRequestPreFilter               = (*featureFlagFilter)(nil)
+	_ OnCompletionRequestFilter        = (*featureFlagFilter)(nil)
+	_ OnImageGenerationsRequestFilter  = (*featureFlagFilter)(nil)
+	_ OnModerationsRequestFilter       = (*featureFlagFilter)(nil)
+	_ OnEmbeddingsRequestFilter        = (*featureFlagFilter)(nil)
+	_ OnRerankRequestFilter            = (*featureFlagFilter)(nil)
+	_ OnVideoGenerationsRequestFilter  = (*featureFlagFilter)(nil)
+	_ OnRealtimeRequestFilter          = (*featureFlagFilter)(nil)
+	_ OnCompletionResponseFilter       = (*featureFlagFilter)(nil)
+	_ OnCompletionStreamResponseFilter = (*featureFlagFilter)(nil)
+	_ OnCompletionStreamChunkFilter    = (*featureFlagFilter)(nil)
+	_ OnImageGenerationsResponseFilter = (*featureFlagFilter)(nil)
+	_ OnRealtimeResponseFilter         = (*featureFlagFilter)(nil)
+	_ OnResponsePostFilter             = (*featureFlagFilter)(nil)
+)
+
+// featureFlagFilter runs the filter only for the requests the feature flag is
+// enabled for. It implements every stage, RequestFilters only lists it for the
+// stages implemented by the filter.
+type featureFlagFilter struct {
+	IsRequestFilter
+
+	filter RequestFilter
+	flag   string
+}
+
+// WithFeatureFlag gates the filter behind the feature flag, the filter is
+// returned as is when the flag is empty. Flags are evaluated after the
+// OnRequestPre filters, which therefore never run when gated.
+func WithFeatureFlag(f RequestFilter, flag string) RequestFilter {
+	if flag == "" {
+		return f
+	}
+
+	return &featureFlagFilter{filter: f, flag: flag}
+}
+
+// Unwrap returns the filter gated.
+func (f *featureFlagFilter) Unwrap() RequestFilter {
+	return f.filter
+}
+
+func (f *featureFlagFilter) enabled(ctx context.Context) bool {
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	if rMeta == nil {
+		return false
+	}
+
+	return rMeta.FeatureFlags.Enabled(f.flag)
+}
+
+func (f *featureFlagFilter) OnRequestPre(ctx context.Context, sourceHTTPRequest *http.Request) RequestFilterResult {
+	if !f.enabled(ctx) {
+		return NewOK()
+	}
+
+	return f.filter.(OnRequestPreFilter).OnRequestPre(ctx, sourceHTTPRequest) //nolint:forcetypeassert
+}
+
+func (f *featureFlagFilter) OnCompletionRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) RequestFilterResult {
+	if !f.enabled(ctx) {
+		return NewOK()
+	}
+
+	return f.filter.(OnCompletionRequestFilter).OnCompletionRequest(ctx, request, sourceHTTPRequest) //nolint:forcetypeassert
+}
+
+func (f *featureFlagFilter) OnImageGenerationsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) RequestFilterResult {
+	if !f.enabled(ctx) {
+		return NewOK()
+	}
+
+	return f.filter.(OnImageGenerationsRequestFilter).OnImageGenerationsRequest(ctx, request, sourceHTTPRequest) //nolint:forcetypeassert
+}
+
+func (f *featureFlagFilter) OnModerationsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) RequestFilterResult {
+	if !f.enabled(ctx) {
+		return NewOK()
+	}
+
+	return f.filter.(OnModerationsRequestFilter).OnModerationsRequest(ctx, request, sourceHTTPRequest) //nolint:forcetypeassert
+}
+
+func (f *featureFlagFilter) OnEmbeddingsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) RequestFilterResult {
+	if !f.enabled(ctx) {
+		return NewOK()
+	}
+
+	return f.filter.(OnEmbeddingsRequestFilter).OnEmbeddingsRequest(ctx, request, sourceHTTPRequest) //nolint:forcetypeassert
+}
+
+func (f *featureFlagFilter) OnRerankRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) RequestFilterResult {
+	if !f.enabled(ctx) {
+		return NewOK()
+	}
+
+	return f.filter.(OnRerankRequestFilter).OnRerankRequest(ctx, request, sourceHTTPRequest) //nolint:forcetypeassert
+}
+
+func (f *featureFlagFilter) OnVideoGenerationsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) RequestFilterResult {
+	if !f.enabled(ctx) {
+		return NewOK()
+	}
+
+	return f.filter.(OnVideoGenerationsRequestFilter).OnVideoGenerationsRequest(ctx, request, sourceHTTPRequest) //nolint:forcetypeassert
+}
+
+func (f *featureFlagFilter) OnRealtimeRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) RequestFilterResult {
+	if !f.enabled(ctx) {
+		return NewOK()
+	}
+
+	return f.filter.(OnRealtimeRequestFilter).OnRealtimeRequest(ctx, request, sourceHTTPRequest) //nolint:forcetypeassert
+}
+
+func (f *featureFlagFilter) OnCompletionResponse(ctx context.Context, request object.LLMRequest, response object.LLMResponse) RequestFilterResult {
+	if !f.enabled(ctx) {
+		return NewOK()
+	}
+
+	return f.filter.(OnCompletionResponseFilter).OnCompletionResponse(ctx, request, response) //nolint:forcetypeassert
+}
+
+func (f *featureFlagFilter) OnCompletionStreamResponse(ctx context.Context, request object.LLMRequest, response object.LLMStreamResponse, responseChunk object.LLMChunkResponse) RequestFilterResult {
+	if !f.enabled(ctx) {
+		return NewOK()
+	}
+
+	return f.filter.(OnCompletionStreamResponseFilter).OnCompletionStreamResponse(ctx, request, response, responseChunk) //nolint:forcetypeassert
+}
+
+func (f *featureFlagFilter) OnCompletionStreamChunk(ctx context.Context, request object.LLMRequest, response object.LLMStreamResponse, responseChunk object.LLMChunkResponse) RequestFilterResult {
+	if !f.enabled(ctx) {
+		return NewOK()
+	}
+
+	return f.filter.(OnCompletionStreamChunkFilter).OnCompletionStreamChunk(ctx, request, response, responseChunk) //nolint:forcetypeassert
+}
+
+func (f *featureFlagFilter) OnImageGenerationsResponse(ctx context.Context, request object.LLMRequest, response object.LLMResponse) RequestFilterResult {
+	if !f.enabled(ctx) {
+		return NewOK()
+	}
+
+	return f.filter.(OnImageGenerationsResponseFilter).OnImageGenerationsResponse(ctx, request, response) //nolint:forcetypeassert
+}
+
+func (f *featureFlagFilter) OnRealtimeResponse(ctx context.Context, request object.LLMRequest, response object.LLMResponse) RequestFilterResult {
+	if !f.enabled(ctx) {
+		return NewOK()
+	}
+
+	return f.filter.(OnRealtimeResponseFilter).OnRealtimeResponse(ctx, request, response) //nolint:forcetypeassert
+}
+
+func (f *featureFlagFilter) OnResponsePost(ctx context.Context, request *http.Request, response any, err error) {
+	if !f.enabled(ctx) {
+		return
+	}
+
+	f.filter.(OnResponsePostFilter).OnResponsePost(ctx, request, response, err) //nolint:forcetypeassert
+}
+
+// unwrapFilter returns the filter gated by feature flags, or the filter as is.
+func unwrapFilter(f RequestFilter) RequestFilter {
+	if unwrapper, ok := f.(interface{ Unwrap() RequestFilter }); ok {
+		return unwrapper.Unwrap()
+	}
+
+	return f
+}
+
+// filtersOf returns the filters implementing the stage T, the filters gated
+// by feature flags are listed when the filters gated implement the stage.
+func filtersOf[T any](r RequestFilters) []T {
+	res := make([]T, 0, len(r))
+
+	for _, f := range r {
+		if _, ok := unwrapFilter(f).(T); !ok {
+			continue
+		}
+
+		if stage, ok := f.(T); ok {
+			res = append(res, stage)
+		}
+	}
+
+	return res
+}
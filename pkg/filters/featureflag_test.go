@@ -0,0 +1,57 @@
+package filters
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/pkg/featureflag"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+)
+
+type countingFilter struct {
+	IsRequestFilter
+
+	calls int
+}
+
+func (f *countingFilter) OnCompletionRequest(context.Context, object.LLMRequest, *http.Request) RequestFilterResult {
+	f.calls++
+	return NewOK()
+}
+
+func TestWithFeatureFlag(t *testing.T) {
+	f := &countingFilter{}
+	assert.Same(t, f, WithFeatureFlag(f, ""))
+
+	gated := WithFeatureFlag(f, "experiment")
+	assert.Equal(t, "filters.countingFilter", FilterName(gated))
+
+	// Only listed for the stages implemented by the filter gated
+	r := RequestFilters{gated}
+	require.Len(t, r.OnCompletionRequestFilters(), 1)
+	assert.Empty(t, r.OnRequestPreFilters())
+	assert.Empty(t, r.OnCompletionResponseFilters())
+	assert.Empty(t, r.OnResponsePostFilters())
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	ctx := metadata.InitMetadataContext(request)
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+
+	result := r.OnCompletionRequestFilters()[0].OnCompletionRequest(ctx, nil, request)
+	require.True(t, result.IsSSucceeded())
+	assert.Equal(t, 0, f.calls)
+
+	rMeta.FeatureFlags = featureflag.Flags{"experiment": false}
+	r.OnCompletionRequestFilters()[0].OnCompletionRequest(ctx, nil, request)
+	assert.Equal(t, 0, f.calls)
+
+	rMeta.FeatureFlags = featureflag.Flags{"experiment": true}
+	r.OnCompletionRequestFilters()[0].OnCompletionRequest(ctx, nil, request)
+	assert.Equal(t, 1, f.calls)
+}
@@ -0,0 +1,149 @@
+package imageprompt
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/types/openai"
+)
+
+const (
+	inlineNegativePromptPrefix = "Avoid: "
+)
+
+// PromptPolicy enforces the brand-safety rules of image generation requests
+// centrally, so that every client application doesn't have to.
+type PromptPolicy struct {
+	filters.IsRequestFilter
+
+	prefix              string
+	suffix              string
+	negativePrompts     []string
+	negativePromptParam string
+	bannedTerms         *regexp.Regexp
+}
+
+var _ filters.RequestFilter = (*PromptPolicy)(nil)
+var _ filters.OnImageGenerationsRequestFilter = (*PromptPolicy)(nil)
+
+func NewWithConfig(cfg *anypb.Any, _ bootkit.LifeCycle) (filters.RequestFilter, error) {
+	c, err := protoutils.FromAny(cfg, &v1alpha1.ImagePromptPolicyConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	p := &PromptPolicy{
+		prefix:              strings.TrimSpace(c.GetPrefix()),
+		suffix:              strings.TrimSpace(c.GetSuffix()),
+		negativePrompts:     lo.Compact(lo.Map(c.GetNegativePrompts(), func(s string, _ int) string { return strings.TrimSpace(s) })),
+		negativePromptParam: c.GetNegativePromptParam(),
+	}
+
+	bannedTerms := lo.Compact(lo.Map(c.GetBannedTerms(), func(term string, _ int) string {
+		return regexp.QuoteMeta(strings.TrimSpace(term))
+	}))
+	if len(bannedTerms) > 0 {
+		// Matched as words so that e.g. "gun" never bans "burgundy"
+		p.bannedTerms = regexp.MustCompile(`(?i)(?:^|\P{L})(` + strings.Join(bannedTerms, "|") + `)(?:\P{L}|$)`)
+	}
+
+	return p, nil
+}
+
+// BannedTerm returns the first banned term found in the prompt.
+func (p *PromptPolicy) BannedTerm(prompt string) (string, bool) {
+	if p.bannedTerms == nil {
+		return "", false
+	}
+
+	match := p.bannedTerms.FindStringSubmatch(prompt)
+	if match == nil {
+		return "", false
+	}
+
+	return match[1], true
+}
+
+// Apply returns the prompt wrapped by the prefix and the suffix, and the
+// negative prompts merged into negativePrompt.
+func (p *PromptPolicy) Apply(prompt string, negativePrompt string) (string, string) {
+	prompt = strings.Join(lo.Compact([]string{p.prefix, strings.TrimSpace(prompt), p.suffix}), " ")
+
+	if len(p.negativePrompts) == 0 {
+		return prompt, negativePrompt
+	}
+
+	mandatory := strings.Join(p.negativePrompts, ", ")
+	if p.negativePromptParam == "" {
+		return prompt + "\n\n" + inlineNegativePromptPrefix + mandatory, negativePrompt
+	}
+
+	return prompt, strings.Join(lo.Compact([]string{strings.TrimSpace(negativePrompt), mandatory}), ", ")
+}
+
+// promptRequest is implemented by the requests generating and editing
+// images, the variations of images are created without prompts.
+type promptRequest interface {
+	GetPrompt() string
+	SetPrompt(prompt string) error
+	GetParam(key string) string
+	SetParam(key string, value string) error
+}
+
+var (
+	_ promptRequest = (*openai.ImageGenerationsRequest)(nil)
+	_ promptRequest = (*openai.ImageEditsRequest)(nil)
+)
+
+func (p *PromptPolicy) OnImageGenerationsRequest(ctx context.Context, request object.LLMRequest, _ *http.Request) filters.RequestFilterResult {
+	if request.GetRequestType() == object.RequestTypeImageVariations {
+		return filters.NewOK()
+	}
+
+	imageRequest, ok := request.(promptRequest)
+	if !ok {
+		return filters.NewOK()
+	}
+
+	prompt := imageRequest.GetPrompt()
+
+	term, banned := p.BannedTerm(prompt)
+	if banned {
+		slog.DebugContext(ctx, "image prompt policy: prompt rejected", slog.String("term", term))
+
+		return filters.NewFailed(openai.NewErrorContentPolicyViolation("Your request was rejected by the content policy, the prompt contains a banned term: " + term))
+	}
+
+	var negativePrompt string
+	if p.negativePromptParam != "" {
+		negativePrompt = imageRequest.GetParam(p.negativePromptParam)
+	}
+
+	newPrompt, newNegativePrompt := p.Apply(prompt, negativePrompt)
+
+	err := imageRequest.SetPrompt(newPrompt)
+	if err != nil {
+		return filters.NewFailed(openai.NewErrorInternalError().WithCause(err))
+	}
+
+	if p.negativePromptParam != "" && newNegativePrompt != negativePrompt {
+		err = imageRequest.SetParam(p.negativePromptParam, newNegativePrompt)
+		if err != nil {
+			return filters.NewFailed(openai.NewErrorInternalError().WithCause(err))
+		}
+	}
+
+	return filters.NewOK()
+}
@@ -0,0 +1,106 @@
+package imageprompt
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/object"
+)
+
+func newPolicy(t *testing.T, cfg *v1alpha1.ImagePromptPolicyConfig) *PromptPolicy {
+	t.Helper()
+
+	f, err := NewWithConfig(lo.Must(anypb.New(cfg)), bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+
+	p, ok := f.(*PromptPolicy)
+	require.True(t, ok)
+
+	return p
+}
+
+func TestPromptPolicy_BannedTerm(t *testing.T) {
+	p := newPolicy(t, &v1alpha1.ImagePromptPolicyConfig{BannedTerms: []string{"gun", "Acme Corp", " "}})
+
+	term, banned := p.BannedTerm("A cowboy holding a GUN at sunset")
+	assert.True(t, banned)
+	assert.Equal(t, "GUN", term)
+
+	_, banned = p.BannedTerm("gun")
+	assert.True(t, banned)
+
+	_, banned = p.BannedTerm("the logo of acme corp, vector art")
+	assert.True(t, banned)
+
+	// Words only
+	_, banned = p.BannedTerm("a burgundy gunwale of a boat")
+	assert.False(t, banned)
+
+	_, banned = newPolicy(t, &v1alpha1.ImagePromptPolicyConfig{}).BannedTerm("gun")
+	assert.False(t, banned)
+}
+
+func TestPromptPolicy_Apply(t *testing.T) {
+	p := newPolicy(t, &v1alpha1.ImagePromptPolicyConfig{Prefix: "Brand style:", Suffix: "flat colors"})
+
+	prompt, negativePrompt := p.Apply(" a cat ", "")
+	assert.Equal(t, "Brand style: a cat flat colors", prompt)
+	assert.Empty(t, negativePrompt)
+
+	// Inline negative prompts
+	p = newPolicy(t, &v1alpha1.ImagePromptPolicyConfig{NegativePrompts: []string{"logos", "text"}})
+
+	prompt, _ = p.Apply("a cat", "")
+	assert.Equal(t, "a cat\n\nAvoid: logos, text", prompt)
+
+	// Merged into the parameter
+	p = newPolicy(t, &v1alpha1.ImagePromptPolicyConfig{NegativePrompts: []string{"logos"}, NegativePromptParam: "negative_prompt"})
+
+	prompt, negativePrompt = p.Apply("a cat", "blurry")
+	assert.Equal(t, "a cat", prompt)
+	assert.Equal(t, "blurry, logos", negativePrompt)
+
+	_, negativePrompt = p.Apply("a cat", "")
+	assert.Equal(t, "logos", negativePrompt)
+}
+
+func TestPromptPolicy_OnImageGenerationsRequest(t *testing.T) {
+	p := newPolicy(t, &v1alpha1.ImagePromptPolicyConfig{
+		Prefix:              "Corporate illustration of",
+		NegativePrompts:     []string{"competitor logos"},
+		NegativePromptParam: "negative_prompt",
+		BannedTerms:         []string{"violence"},
+	})
+
+	ctx, request := gatewaytest.NewImageGenerationsRequest(t, `{"model": "sdxl", "prompt": "a team meeting", "negative_prompt": "blurry"}`)
+
+	result := p.OnImageGenerationsRequest(ctx, request, request.GetRawRequest())
+	require.True(t, result.IsSSucceeded())
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(lo.Must(request.MarshalJSON()), &body))
+	assert.Equal(t, "Corporate illustration of a team meeting", body["prompt"])
+	assert.Equal(t, "blurry, competitor logos", body["negative_prompt"])
+	assert.Equal(t, "sdxl", body["model"])
+
+	// Rejected
+	ctx, request = gatewaytest.NewImageGenerationsRequest(t, `{"model": "sdxl", "prompt": "graphic violence"}`)
+
+	result = p.OnImageGenerationsRequest(ctx, request, request.GetRawRequest())
+	require.True(t, result.IsFailed())
+
+	llmError := object.AsLLMError(result.Error)
+	require.NotNil(t, llmError)
+	assert.Equal(t, http.StatusBadRequest, llmError.GetStatus())
+	assert.Equal(t, "content_policy_violation", llmError.GetCode())
+	assert.Equal(t, "graphic violence", request.GetPrompt())
+}
@@ -0,0 +1,336 @@
+// Package moderation moderates the prompts of chat completions, and
+// optionally the streamed output, with an external moderation service.
+package moderation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/types/openai"
+)
+
+const (
+	defaultModerationTimeout   = 3 * time.Second
+	defaultOutputIntervalChars = 1000
+
+	// flaggedCategory is recorded when the service flags the input without
+	// any category
+	flaggedCategory = "flagged"
+)
+
+// ContentModeration blocks or annotates the requests by the scores of the
+// categories classified by the moderation service.
+type ContentModeration struct {
+	filters.IsRequestFilter
+
+	moderator           Moderator
+	timeout             time.Duration
+	blockThresholds     map[string]float64
+	annotateThresholds  map[string]float64
+	failClosed          bool
+	moderateOutput      bool
+	outputIntervalChars int
+
+	// streams are the output not yet moderated of the streams
+	streams sync.Map
+}
+
+var _ filters.RequestFilter = (*ContentModeration)(nil)
+var _ filters.OnCompletionRequestFilter = (*ContentModeration)(nil)
+var _ filters.OnCompletionStreamChunkFilter = (*ContentModeration)(nil)
+
+func NewWithConfig(cfg *anypb.Any, lifecycle bootkit.LifeCycle) (filters.RequestFilter, error) {
+	c, err := protoutils.FromAny(cfg, &v1alpha1.ContentModerationConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	for category, threshold := range lo.Assign(c.GetBlockThresholds(), c.GetAnnotateThresholds()) {
+		if threshold < 0 || threshold > 1 {
+			return nil, fmt.Errorf("threshold of category %s must be between 0 and 1", category)
+		}
+	}
+
+	cm := &ContentModeration{
+		timeout:             c.GetTimeout().AsDuration(),
+		blockThresholds:     c.GetBlockThresholds(),
+		annotateThresholds:  c.GetAnnotateThresholds(),
+		failClosed:          c.GetFailClosed(),
+		moderateOutput:      c.GetModerateOutput(),
+		outputIntervalChars: int(c.GetOutputIntervalChars()),
+	}
+	if cm.timeout <= 0 {
+		cm.timeout = defaultModerationTimeout
+	}
+
+	if cm.outputIntervalChars <= 0 {
+		cm.outputIntervalChars = defaultOutputIntervalChars
+	}
+
+	switch {
+	case c.GetOpenai() != nil:
+		if c.GetOpenai().GetUrl() == "" {
+			return nil, errors.New("invalid moderation url")
+		}
+
+		cm.moderator = NewOpenAIModerator(c.GetOpenai(), &http.Client{})
+	case c.GetModerationServer() != nil:
+		if c.GetModerationServer().GetUrl() == "" {
+			return nil, errors.New("invalid moderation server url")
+		}
+
+		conn, err := grpc.NewClient(c.GetModerationServer().GetUrl(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect moderation server: %w", err)
+		}
+
+		lifecycle.Append(bootkit.LifeCycleHook{
+			OnStop: func(ctx context.Context) error {
+				return conn.Close()
+			},
+		})
+
+		cm.moderator = NewGRPCModerator(conn)
+	default:
+		return nil, errors.New("either openai or moderation_server is required")
+	}
+
+	return cm, nil
+}
+
+// WithModerator replaces the moderator, which calls the service configured
+// by default.
+func (cm *ContentModeration) WithModerator(moderator Moderator) *ContentModeration {
+	cm.moderator = moderator
+	return cm
+}
+
+// Evaluate returns the categories of the results reaching the thresholds, the
+// verdict of the service is used when no threshold is configured.
+func (cm *ContentModeration) Evaluate(results []*service.ModerationResult) metadata.ModerationResult {
+	var evaluated metadata.ModerationResult
+
+	noThresholds := len(cm.blockThresholds) == 0 && len(cm.annotateThresholds) == 0
+
+	for _, result := range results {
+		var blocked []string
+
+		if noThresholds && result.GetFlagged() {
+			blocked = lo.CoalesceSliceOrEmpty(result.GetCategories(), []string{flaggedCategory})
+		}
+
+		evaluated = *evaluated.Merge(metadata.ModerationResult{
+			Scores:    result.GetCategoryScores(),
+			Annotated: exceeding(result.GetCategoryScores(), cm.annotateThresholds),
+			Blocked:   append(blocked, exceeding(result.GetCategoryScores(), cm.blockThresholds)...),
+		})
+	}
+
+	return evaluated
+}
+
+func exceeding(scores map[string]float64, thresholds map[string]float64) []string {
+	var categories []string
+
+	for category, threshold := range thresholds {
+		if score, ok := scores[category]; ok && score >= threshold {
+			categories = append(categories, category)
+		}
+	}
+
+	sort.Strings(categories)
+
+	return categories
+}
+
+func (cm *ContentModeration) moderate(ctx context.Context, request object.LLMRequest, input []string) (metadata.ModerationResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, cm.timeout)
+	defer cancel()
+
+	moderationRequest := &service.ModerationRequest{
+		Input:         input,
+		UserModelName: request.GetModel(),
+	}
+	if rMeta := metadata.RequestMetadataFromCtx(ctx); rMeta != nil {
+		moderationRequest.ApiKeyId = rMeta.AuthInfo.GetApiKeyId()
+	}
+
+	resp, err := cm.moderator.Moderate(ctx, moderationRequest)
+	if err != nil {
+		return metadata.ModerationResult{}, err
+	}
+
+	if len(resp.GetResults()) != len(input) {
+		return metadata.ModerationResult{}, fmt.Errorf("expected %d moderation results, got %d", len(input), len(resp.GetResults()))
+	}
+
+	return cm.Evaluate(resp.GetResults()), nil
+}
+
+// failed decides the result of the request when the service fails.
+func (cm *ContentModeration) failed(ctx context.Context, err error) filters.RequestFilterResult {
+	if cm.failClosed {
+		return filters.NewFailed(openai.NewErrorServiceUnavailable().WithCause(err))
+	}
+
+	slog.WarnContext(ctx, "failed to moderate content, served unmoderated", slog.String("filter", "content_moderation"), slog.Any("error", err))
+
+	return filters.NewOK()
+}
+
+func (cm *ContentModeration) OnCompletionRequest(ctx context.Context, request object.LLMRequest, _ *http.Request) filters.RequestFilterResult {
+	// Legacy completions carry prompts instead of messages
+	chatRequest, ok := request.(*openai.ChatCompletionsRequest)
+	if !ok {
+		return filters.NewOK()
+	}
+
+	input := lo.Compact(lo.Map(chatRequest.GetMessages(), func(message map[string]any, _ int) string {
+		return textOf(message)
+	}))
+	if len(input) == 0 {
+		return filters.NewOK()
+	}
+
+	result, err := cm.moderate(ctx, request, input)
+	if err != nil {
+		return cm.failed(ctx, err)
+	}
+
+	if rMeta := metadata.RequestMetadataFromCtx(ctx); rMeta != nil {
+		rMeta.PromptModeration = rMeta.PromptModeration.Merge(result)
+	}
+
+	if len(result.Blocked) > 0 {
+		return filters.NewFailed(openai.NewErrorContentPolicyViolation("Your request was rejected by the content moderation, flagged categories: " + strings.Join(result.Blocked, ", ")))
+	}
+
+	return filters.NewOK()
+}
+
+// outputState is the output not yet moderated of every choice of a stream.
+type outputState struct {
+	mutex   sync.Mutex
+	pending map[int]string
+}
+
+func (cm *ContentModeration) OnCompletionStreamChunk(ctx context.Context, request object.LLMRequest, stream object.LLMStreamResponse, responseChunk object.LLMChunkResponse) filters.RequestFilterResult {
+	if !cm.moderateOutput {
+		return filters.NewOK()
+	}
+
+	if responseChunk.IsDone() {
+		cm.streams.Delete(stream)
+		return filters.NewOK()
+	}
+
+	chunk, ok := responseChunk.(*openai.ChatCompletionStreamChunk)
+	if !ok || responseChunk.IsEmpty() {
+		return filters.NewOK()
+	}
+
+	deltas := chunk.GetChoiceDeltas()
+	if len(deltas) == 0 {
+		return filters.NewOK()
+	}
+
+	state := cm.stateOf(request, stream)
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	var pendingChars int
+
+	finished := false
+
+	for _, delta := range deltas {
+		state.pending[delta.Index] += delta.Content
+		finished = finished || delta.FinishReason != ""
+	}
+
+	for _, text := range state.pending {
+		pendingChars += len([]rune(text))
+	}
+
+	if pendingChars == 0 || (!finished && pendingChars < cm.outputIntervalChars) {
+		return filters.NewOK()
+	}
+
+	indexes := lo.Keys(state.pending)
+	sort.Ints(indexes)
+
+	input := lo.Compact(lo.Map(indexes, func(index int, _ int) string { return state.pending[index] }))
+	state.pending = make(map[int]string)
+
+	result, err := cm.moderate(ctx, request, input)
+	if err != nil {
+		return cm.failed(ctx, err)
+	}
+
+	if rMeta := metadata.RequestMetadataFromCtx(ctx); rMeta != nil {
+		rMeta.OutputModeration = rMeta.OutputModeration.Merge(result)
+	}
+
+	if len(result.Blocked) > 0 {
+		cm.streams.Delete(stream)
+
+		return filters.NewFailed(openai.NewErrorContentPolicyViolation("The output was stopped by the content moderation, flagged categories: " + strings.Join(result.Blocked, ", ")))
+	}
+
+	return filters.NewOK()
+}
+
+func (cm *ContentModeration) stateOf(request object.LLMRequest, stream object.LLMStreamResponse) *outputState {
+	state, loaded := cm.streams.LoadOrStore(stream, &outputState{pending: make(map[int]string)})
+	if !loaded && request != nil && request.GetRawRequest() != nil {
+		// Streams interrupted never reach the done chunk
+		context.AfterFunc(request.GetRawRequest().Context(), func() {
+			cm.streams.Delete(stream)
+		})
+	}
+
+	return state.(*outputState) //nolint:forcetypeassert
+}
+
+// textOf returns the text of the content of the message, the text parts are
+// joined by new lines.
+func textOf(message map[string]any) string {
+	switch content := message["content"].(type) {
+	case string:
+		return content
+	case []any:
+		texts := make([]string, 0, len(content))
+
+		for _, part := range content {
+			partMap, ok := part.(map[string]any)
+			if !ok || partMap["type"] != "text" {
+				continue
+			}
+
+			text, _ := partMap["text"].(string)
+			texts = append(texts, text)
+		}
+
+		return strings.Join(lo.Compact(texts), "\n")
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,237 @@
+package moderation
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+// keywordModerator scores violence 0.9 for the input containing "attack",
+// and 0.5 for the ones containing "fight".
+type keywordModerator struct {
+	mutex    sync.Mutex
+	requests []*service.ModerationRequest
+	err      error
+}
+
+func (m *keywordModerator) Moderate(_ context.Context, request *service.ModerationRequest) (*service.ModerationResponse, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.requests = append(m.requests, request)
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	results := lo.Map(request.GetInput(), func(input string, _ int) *service.ModerationResult {
+		switch {
+		case strings.Contains(input, "attack"):
+			return &service.ModerationResult{Flagged: true, Categories: []string{"violence"}, CategoryScores: map[string]float64{"violence": 0.9, "hate": 0.1}}
+		case strings.Contains(input, "fight"):
+			return &service.ModerationResult{CategoryScores: map[string]float64{"violence": 0.5, "hate": 0.1}}
+		default:
+			return &service.ModerationResult{CategoryScores: map[string]float64{"violence": 0.01, "hate": 0.01}}
+		}
+	})
+
+	return &service.ModerationResponse{Results: results}, nil
+}
+
+func newModeration(t *testing.T, cfg *v1alpha1.ContentModerationConfig, moderator Moderator) *ContentModeration {
+	t.Helper()
+
+	if cfg.GetService() == nil {
+		cfg.Service = &v1alpha1.ContentModerationConfig_Openai{Openai: &v1alpha1.ContentModerationConfig_OpenAI{Url: "http://moderation.example.com/v1/moderations"}}
+	}
+
+	f, err := NewWithConfig(lo.Must(anypb.New(cfg)), bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+
+	cm, ok := f.(*ContentModeration)
+	require.True(t, ok)
+
+	return cm.WithModerator(moderator)
+}
+
+func TestNewWithConfig(t *testing.T) {
+	_, err := NewWithConfig(lo.Must(anypb.New(&v1alpha1.ContentModerationConfig{})), bootkit.NewEmptyLifeCycle())
+	require.EqualError(t, err, "either openai or moderation_server is required")
+
+	_, err = NewWithConfig(lo.Must(anypb.New(&v1alpha1.ContentModerationConfig{
+		Service:         &v1alpha1.ContentModerationConfig_Openai{Openai: &v1alpha1.ContentModerationConfig_OpenAI{Url: "http://moderation"}},
+		BlockThresholds: map[string]float64{"violence": 1.5},
+	})), bootkit.NewEmptyLifeCycle())
+	require.EqualError(t, err, "threshold of category violence must be between 0 and 1")
+
+	_, err = NewWithConfig(lo.Must(anypb.New(&v1alpha1.ContentModerationConfig{
+		Service: &v1alpha1.ContentModerationConfig_ModerationServer_{ModerationServer: &v1alpha1.ContentModerationConfig_ModerationServer{Url: "localhost:9090"}},
+	})), bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+}
+
+func TestContentModeration_Evaluate(t *testing.T) {
+	results := []*service.ModerationResult{
+		{Flagged: true, Categories: []string{"violence"}, CategoryScores: map[string]float64{"violence": 0.9, "hate": 0.1}},
+		{CategoryScores: map[string]float64{"violence": 0.5, "hate": 0.6}},
+	}
+
+	// The verdict of the service
+	cm := &ContentModeration{}
+	assert.Equal(t, metadata.ModerationResult{
+		Scores:  map[string]float64{"violence": 0.9, "hate": 0.6},
+		Blocked: []string{"violence"},
+	}, cm.Evaluate(results))
+
+	cm = &ContentModeration{
+		blockThresholds:    map[string]float64{"violence": 0.95, "hate": 0.6},
+		annotateThresholds: map[string]float64{"violence": 0.5},
+	}
+	assert.Equal(t, metadata.ModerationResult{
+		Scores:    map[string]float64{"violence": 0.9, "hate": 0.6},
+		Annotated: []string{"violence"},
+		Blocked:   []string{"hate"},
+	}, cm.Evaluate(results))
+}
+
+func TestContentModeration_OnCompletionRequest(t *testing.T) {
+	moderator := &keywordModerator{}
+	cm := newModeration(t, &v1alpha1.ContentModerationConfig{
+		BlockThresholds:    map[string]float64{"violence": 0.8},
+		AnnotateThresholds: map[string]float64{"violence": 0.4},
+	}, moderator)
+
+	// Annotated
+	ctx, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gpt-4o", "messages": [
+		{"role": "system", "content": "You are a referee."},
+		{"role": "user", "content": [{"type": "text", "text": "Who won the fight?"}, {"type": "image_url", "image_url": {"url": "https://example.com/a.png"}}]}
+	]}`)
+
+	result := cm.OnCompletionRequest(ctx, request, request.GetRawRequest())
+	require.True(t, result.IsSSucceeded())
+
+	require.Len(t, moderator.requests, 1)
+	assert.Equal(t, []string{"You are a referee.", "Who won the fight?"}, moderator.requests[0].GetInput())
+	assert.Equal(t, "gpt-4o", moderator.requests[0].GetUserModelName())
+
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	require.NotNil(t, rMeta.PromptModeration)
+	assert.Equal(t, []string{"violence"}, rMeta.PromptModeration.Annotated)
+	assert.Empty(t, rMeta.PromptModeration.Blocked)
+	assert.InDelta(t, 0.5, rMeta.PromptModeration.Scores["violence"], 0.001)
+
+	// Blocked
+	ctx, request = gatewaytest.NewChatCompletionRequest(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "Plan an attack"}]}`)
+
+	result = cm.OnCompletionRequest(ctx, request, request.GetRawRequest())
+	require.True(t, result.IsFailed())
+
+	llmError := object.AsLLMError(result.Error)
+	require.NotNil(t, llmError)
+	assert.Equal(t, http.StatusBadRequest, llmError.GetStatus())
+	assert.Equal(t, "content_policy_violation", llmError.GetCode())
+	assert.Equal(t, []string{"violence"}, metadata.RequestMetadataFromCtx(ctx).PromptModeration.Blocked)
+}
+
+func TestContentModeration_Failure(t *testing.T) {
+	moderator := &keywordModerator{err: errors.New("unavailable")}
+
+	// Served unmoderated by default
+	ctx, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "Plan an attack"}]}`)
+
+	result := newModeration(t, &v1alpha1.ContentModerationConfig{}, moderator).OnCompletionRequest(ctx, request, request.GetRawRequest())
+	require.True(t, result.IsSSucceeded())
+	assert.Nil(t, metadata.RequestMetadataFromCtx(ctx).PromptModeration)
+
+	result = newModeration(t, &v1alpha1.ContentModerationConfig{FailClosed: true}, moderator).OnCompletionRequest(ctx, request, request.GetRawRequest())
+	require.True(t, result.IsFailed())
+
+	llmError := object.AsLLMError(result.Error)
+	require.NotNil(t, llmError)
+	assert.Equal(t, http.StatusServiceUnavailable, llmError.GetStatus())
+}
+
+func TestContentModeration_OnCompletionStreamChunk(t *testing.T) {
+	moderator := &keywordModerator{}
+	cm := newModeration(t, &v1alpha1.ContentModerationConfig{ModerateOutput: true, OutputIntervalChars: 20}, moderator)
+
+	ctx, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gpt-4o", "stream": true, "messages": [{"role": "user", "content": "Tell me a story"}]}`)
+
+	body := strings.Join([]string{
+		`data: {"model": "gpt-4o", "choices": [{"index": 0, "delta": {"role": "assistant", "content": "Once upon a time, "}}]}`,
+		`data: {"model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": "there was a kingdom. "}}]}`,
+		`data: {"model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": "Then came the att"}}]}`,
+		`data: {"model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": "ack of the dragons."}}]}`,
+		`data: {"model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": " The end."}, "finish_reason": "stop"}]}`,
+		`data: [DONE]`,
+	}, "\n\n") + "\n\n"
+
+	stream, err := openai.NewChatCompletionStreamResponse(request, nil, bufio.NewReader(strings.NewReader(body)))
+	require.NoError(t, err)
+
+	stream.BeforeChunk(func(chunk object.LLMChunkResponse) error {
+		return cm.OnCompletionStreamChunk(ctx, request, stream, chunk).Error
+	})
+
+	var (
+		contents  []string
+		streamErr error
+	)
+
+	for {
+		chunk, err := stream.NextChunk()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			streamErr = err
+			break
+		}
+
+		if chunk.IsEmpty() {
+			continue
+		}
+
+		streamChunk, ok := chunk.(*openai.ChatCompletionStreamChunk)
+		require.True(t, ok)
+
+		contents = append(contents, streamChunk.GetDeltaContent())
+	}
+
+	// Stopped once the window of the output is flagged
+	assert.Equal(t, []string{"Once upon a time, ", "there was a kingdom. ", "Then came the att"}, contents)
+	require.Error(t, streamErr)
+
+	llmError := object.AsLLMError(streamErr)
+	require.NotNil(t, llmError)
+	assert.Equal(t, "content_policy_violation", llmError.GetCode())
+
+	require.Len(t, moderator.requests, 2)
+	assert.Equal(t, []string{"Once upon a time, there was a kingdom. "}, moderator.requests[0].GetInput())
+	assert.Equal(t, []string{"Then came the attack of the dragons."}, moderator.requests[1].GetInput())
+
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	require.NotNil(t, rMeta.OutputModeration)
+	assert.Equal(t, []string{"violence"}, rMeta.OutputModeration.Blocked)
+
+	_, ok := cm.streams.Load(stream)
+	assert.False(t, ok)
+}
@@ -0,0 +1,128 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/samber/lo"
+	"google.golang.org/grpc"
+
+	"knoway.dev/api/filters/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+)
+
+// Moderator classifies the texts of requests with a moderation service, one
+// result is returned for each of the input.
+type Moderator interface {
+	Moderate(ctx context.Context, request *service.ModerationRequest) (*service.ModerationResponse, error)
+}
+
+var _ Moderator = (*OpenAIModerator)(nil)
+var _ Moderator = (*GRPCModerator)(nil)
+
+// OpenAIModerator calls an OpenAI compatible /v1/moderations endpoint.
+type OpenAIModerator struct {
+	url     string
+	model   string
+	headers map[string]string
+	client  *http.Client
+}
+
+func NewOpenAIModerator(cfg *v1alpha1.ContentModerationConfig_OpenAI, client *http.Client) *OpenAIModerator {
+	return &OpenAIModerator{
+		url:     cfg.GetUrl(),
+		model:   cfg.GetModel(),
+		headers: cfg.GetHeaders(),
+		client:  client,
+	}
+}
+
+type openAIModerationRequest struct {
+	Model string   `json:"model,omitempty"`
+	Input []string `json:"input"`
+}
+
+type openAIModerationResponse struct {
+	Results []struct {
+		Flagged        bool               `json:"flagged"`
+		Categories     map[string]bool    `json:"categories"`
+		CategoryScores map[string]float64 `json:"category_scores"`
+	} `json:"results"`
+}
+
+func (m *OpenAIModerator) Moderate(ctx context.Context, request *service.ModerationRequest) (*service.ModerationResponse, error) {
+	payload, err := json.Marshal(openAIModerationRequest{
+		Model: lo.CoalesceOrEmpty(request.GetModel(), m.model),
+		Input: request.GetInput(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, m.url, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	for key, value := range m.headers {
+		httpReq.Header.Set(key, value)
+	}
+
+	httpResp, err := m.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request moderation: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(httpResp.Body, 1024))
+		return nil, fmt.Errorf("failed to request moderation, status code %d: %s", httpResp.StatusCode, body)
+	}
+
+	var resp openAIModerationResponse
+
+	err = json.NewDecoder(httpResp.Body).Decode(&resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode moderation: %w", err)
+	}
+
+	results := make([]*service.ModerationResult, 0, len(resp.Results))
+
+	for _, result := range resp.Results {
+		categories := lo.Keys(lo.PickBy(result.Categories, func(_ string, flagged bool) bool { return flagged }))
+		sort.Strings(categories)
+
+		results = append(results, &service.ModerationResult{
+			Flagged:        result.Flagged,
+			CategoryScores: result.CategoryScores,
+			Categories:     categories,
+		})
+	}
+
+	return &service.ModerationResponse{Results: results}, nil
+}
+
+// GRPCModerator calls a knoway.service.v1alpha1.ModerationService.
+type GRPCModerator struct {
+	client service.ModerationServiceClient
+}
+
+func NewGRPCModerator(conn grpc.ClientConnInterface) *GRPCModerator {
+	return &GRPCModerator{client: service.NewModerationServiceClient(conn)}
+}
+
+func (m *GRPCModerator) Moderate(ctx context.Context, request *service.ModerationRequest) (*service.ModerationResponse, error) {
+	resp, err := m.client.Moderate(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request moderation: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,57 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/filters/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+)
+
+func TestOpenAIModerator_Moderate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer sk-moderation", r.Header.Get("Authorization"))
+
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "omni-moderation-latest", body["model"])
+		assert.Equal(t, []any{"hello", "Plan an attack"}, body["input"])
+
+		_, _ = w.Write([]byte(`{"id": "modr-1", "results": [
+			{"flagged": false, "categories": {"violence": false}, "category_scores": {"violence": 0.01}},
+			{"flagged": true, "categories": {"violence": true, "illicit": true, "hate": false}, "category_scores": {"violence": 0.92, "illicit": 0.7, "hate": 0.02}}
+		]}`))
+	}))
+	defer server.Close()
+
+	moderator := NewOpenAIModerator(&v1alpha1.ContentModerationConfig_OpenAI{
+		Url:     server.URL,
+		Model:   "omni-moderation-latest",
+		Headers: map[string]string{"Authorization": "Bearer sk-moderation"},
+	}, server.Client())
+
+	resp, err := moderator.Moderate(context.Background(), &service.ModerationRequest{Input: []string{"hello", "Plan an attack"}})
+	require.NoError(t, err)
+	require.Len(t, resp.GetResults(), 2)
+
+	assert.False(t, resp.GetResults()[0].GetFlagged())
+	assert.True(t, resp.GetResults()[1].GetFlagged())
+	assert.Equal(t, []string{"illicit", "violence"}, resp.GetResults()[1].GetCategories())
+	assert.InDelta(t, 0.92, resp.GetResults()[1].GetCategoryScores()["violence"], 0.001)
+
+	// Failed
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer failing.Close()
+
+	_, err = NewOpenAIModerator(&v1alpha1.ContentModerationConfig_OpenAI{Url: failing.URL}, failing.Client()).
+		Moderate(context.Background(), &service.ModerationRequest{Input: []string{"hello"}})
+	require.Error(t, err)
+}
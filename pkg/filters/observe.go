@@ -0,0 +1,62 @@
+package filters
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/observation"
+)
+
+const (
+	StageOnRequestPre               = "on_request_pre"
+	StageOnCompletionRequest        = "on_completion_request"
+	StageOnImageGenerationsRequest  = "on_image_generations_request"
+	StageOnModerationsRequest       = "on_moderations_request"
+	StageOnEmbeddingsRequest        = "on_embeddings_request"
+	StageOnRerankRequest            = "on_rerank_request"
+	StageOnVideoGenerationsRequest  = "on_video_generations_request"
+	StageOnRealtimeRequest          = "on_realtime_request"
+	StageOnCompletionResponse       = "on_completion_response"
+	StageOnCompletionStreamResponse = "on_completion_stream_response"
+	StageOnCompletionStreamChunk    = "on_completion_stream_chunk"
+	StageOnImageGenerationsResponse = "on_image_generations_response"
+	StageOnRealtimeResponse         = "on_realtime_response"
+	StageOnResponsePost             = "on_response_post"
+)
+
+// FilterName returns the name of the filter used in metrics and logs, the
+// name of filters gated by feature flags is the one of the filters gated.
+func FilterName(f RequestFilter) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", unwrapFilter(f)), "*")
+}
+
+// Observe invokes fn, which is expected to call f for the stage, and records
+// how long it took, both in metrics and as a span.
+func Observe(ctx context.Context, f RequestFilter, stage string, fn func() RequestFilterResult) RequestFilterResult {
+	_, span := observation.Tracer().Start(ctx, observation.SpanNameRequestFilter, trace.WithAttributes(
+		observation.KnowayFilterName.AsAttribute().String(FilterName(f)),
+		observation.KnowayFilterStage.AsAttribute().String(stage),
+	))
+	defer span.End()
+
+	startAt := time.Now()
+
+	result := fn()
+
+	if result.IsFailed() {
+		observation.RecordSpanError(span, result.Error)
+	}
+
+	observation.ObserveRequestFilterDuration(metadata.RequestMetadataFromCtx(ctx), FilterName(f), stage, time.Since(startAt))
+
+	if result.IsFailed() {
+		observation.ObserveRequestFilterError(FilterName(f), stage)
+	}
+
+	return result
+}
@@ -0,0 +1,193 @@
+package pii
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"knoway.dev/api/filters/v1alpha1"
+)
+
+const (
+	minPhoneNumberDigits = 9
+	maxPhoneNumberDigits = 15
+)
+
+type detector struct {
+	name    string
+	pattern *regexp.Regexp
+	// valid rejects the false positives of pattern, nil accepts every match
+	valid func(match string) bool
+}
+
+var (
+	emailDetector = detector{
+		name:    "EMAIL",
+		pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`),
+	}
+	creditCardDetector = detector{
+		name:    "CREDIT_CARD",
+		pattern: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		valid:   luhnValid,
+	}
+	phoneNumberDetector = detector{
+		name:    "PHONE_NUMBER",
+		pattern: regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?(?:\(\d{1,4}\)[ .-]?)?\b\d{2,5}(?:[ .-]?\d{2,5}){1,4}\b`),
+		valid: func(match string) bool {
+			digits := countDigits(match)
+			return digits >= minPhoneNumberDigits && digits <= maxPhoneNumberDigits
+		},
+	}
+
+	builtinDetectors = map[v1alpha1.PIIRedactionConfig_Detector]detector{
+		v1alpha1.PIIRedactionConfig_DETECTOR_EMAIL:        emailDetector,
+		v1alpha1.PIIRedactionConfig_DETECTOR_PHONE_NUMBER: phoneNumberDetector,
+		v1alpha1.PIIRedactionConfig_DETECTOR_CREDIT_CARD:  creditCardDetector,
+	}
+	// Card numbers are detected before phone numbers, which would match them
+	// otherwise
+	builtinDetectorsOrder = []v1alpha1.PIIRedactionConfig_Detector{
+		v1alpha1.PIIRedactionConfig_DETECTOR_CREDIT_CARD,
+		v1alpha1.PIIRedactionConfig_DETECTOR_EMAIL,
+		v1alpha1.PIIRedactionConfig_DETECTOR_PHONE_NUMBER,
+	}
+)
+
+func countDigits(s string) int {
+	digits := 0
+
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			digits++
+		}
+	}
+
+	return digits
+}
+
+// luhnValid reports whether the digits of s pass the Luhn checksum of card
+// numbers.
+func luhnValid(s string) bool {
+	sum := 0
+	double := false
+
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] < '0' || s[i] > '9' {
+			continue
+		}
+
+		digit := int(s[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 { //nolint:mnd
+				digit -= 9
+			}
+		}
+
+		sum += digit
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+// Masker replaces the PII detected in texts with the names of the detectors,
+// e.g. [EMAIL].
+type Masker struct {
+	detectors []detector
+}
+
+// NewMasker creates a Masker of the detectors and the custom patterns, with
+// every built-in detector if neither is given.
+func NewMasker(detectors []v1alpha1.PIIRedactionConfig_Detector, patterns []*v1alpha1.PIIRedactionConfig_Pattern) (*Masker, error) {
+	m := &Masker{}
+
+	if len(detectors) == 0 && len(patterns) == 0 {
+		detectors = builtinDetectorsOrder
+	}
+
+	for _, d := range builtinDetectorsOrder {
+		for _, enabled := range detectors {
+			if enabled == d {
+				m.detectors = append(m.detectors, builtinDetectors[d])
+				break
+			}
+		}
+	}
+
+	for _, d := range detectors {
+		if _, ok := builtinDetectors[d]; !ok {
+			return nil, fmt.Errorf("unknown PII detector %s", d)
+		}
+	}
+
+	for _, p := range patterns {
+		if p.GetName() == "" {
+			return nil, fmt.Errorf("name of PII pattern %q is required", p.GetPattern())
+		}
+
+		pattern, err := regexp.Compile(p.GetPattern())
+		if err != nil {
+			return nil, fmt.Errorf("invalid PII pattern %s: %w", p.GetName(), err)
+		}
+
+		m.detectors = append(m.detectors, detector{
+			name:    strings.ToUpper(p.GetName()),
+			pattern: pattern,
+		})
+	}
+
+	return m, nil
+}
+
+// Mask returns the text with the PII masked, together with the number of the
+// matches masked by the names of the detectors.
+func (m *Masker) Mask(text string) (string, map[string]int) {
+	var counts map[string]int
+
+	for _, d := range m.detectors {
+		text = d.pattern.ReplaceAllStringFunc(text, func(match string) string {
+			if d.valid != nil && !d.valid(match) {
+				return match
+			}
+
+			if counts == nil {
+				counts = make(map[string]int)
+			}
+
+			counts[d.name]++
+
+			return "[" + d.name + "]"
+		})
+	}
+
+	return text, counts
+}
+
+// MaskString is Mask without the counts, for the redaction hooks.
+func (m *Masker) MaskString(text string) string {
+	masked, _ := m.Mask(text)
+	return masked
+}
+
+// splitMaskable splits the text of a stream at the last whitespace a PII can
+// not span, the tail may be continued by the next chunks so it's held back.
+func splitMaskable(text string) (string, string) {
+	runes := []rune(text)
+
+	for i := len(runes) - 1; i > 0; i-- {
+		if !unicode.IsSpace(runes[i]) {
+			continue
+		}
+
+		// Phone and card numbers are separated by spaces
+		if strings.ContainsRune("0123456789+-().", runes[i-1]) {
+			continue
+		}
+
+		return string(runes[:i+1]), string(runes[i+1:])
+	}
+
+	return "", text
+}
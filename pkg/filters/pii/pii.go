@@ -0,0 +1,269 @@
+// Package pii masks the personally identifiable information in the prompts of
+// chat completions, and optionally in the responses.
+package pii
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/types/openai"
+)
+
+const (
+	// maxPendingBytes bounds the text of a choice held back from the client,
+	// texts without whitespaces are masked and released as they are.
+	maxPendingBytes = 1024
+)
+
+// Redaction masks the PII of the messages before they are sent to the
+// upstream, and of the responses before they are returned to the client when
+// redactResponses is true.
+type Redaction struct {
+	filters.IsRequestFilter
+
+	masker          *Masker
+	redactResponses bool
+
+	// streams are the texts held back of the streams being redacted
+	streams sync.Map
+}
+
+var _ filters.RequestFilter = (*Redaction)(nil)
+var _ filters.OnCompletionRequestFilter = (*Redaction)(nil)
+var _ filters.OnCompletionResponseFilter = (*Redaction)(nil)
+var _ filters.OnCompletionStreamChunkFilter = (*Redaction)(nil)
+
+func NewWithConfig(cfg *anypb.Any, _ bootkit.LifeCycle) (filters.RequestFilter, error) {
+	c, err := protoutils.FromAny(cfg, &v1alpha1.PIIRedactionConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	masker, err := NewMasker(c.GetDetectors(), c.GetPatterns())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Redaction{
+		masker:          masker,
+		redactResponses: c.GetRedactResponses(),
+	}, nil
+}
+
+// streamState is the text held back of every choice of a stream.
+type streamState struct {
+	mutex   sync.Mutex
+	pending map[int]string
+}
+
+func record(ctx context.Context, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	if rMeta == nil {
+		return
+	}
+
+	if rMeta.PIIRedacted == nil {
+		rMeta.PIIRedacted = make(map[string]int, len(counts))
+	}
+
+	for name, count := range counts {
+		rMeta.PIIRedacted[name] += count
+	}
+}
+
+// mask returns the text masked, the counts are merged into counts.
+func (r *Redaction) mask(text string, counts map[string]int) string {
+	masked, detected := r.masker.Mask(text)
+	for name, count := range detected {
+		counts[name] += count
+	}
+
+	return masked
+}
+
+func (r *Redaction) OnCompletionRequest(ctx context.Context, request object.LLMRequest, _ *http.Request) filters.RequestFilterResult {
+	// Legacy completions carry prompts instead of messages
+	chatRequest, ok := request.(*openai.ChatCompletionsRequest)
+	if !ok {
+		return filters.NewOK()
+	}
+
+	messages := chatRequest.GetMessages()
+	if len(messages) == 0 {
+		return filters.NewOK()
+	}
+
+	counts := make(map[string]int)
+
+	masked := make([]map[string]any, 0, len(messages))
+	for _, message := range messages {
+		masked = append(masked, mapText(message, func(text string) string {
+			return r.mask(text, counts)
+		}))
+	}
+
+	if len(counts) == 0 {
+		return filters.NewOK()
+	}
+
+	err := chatRequest.SetMessages(masked)
+	if err != nil {
+		return filters.NewFailed(openai.NewErrorInternalError().WithCause(err))
+	}
+
+	record(ctx, counts)
+	slog.DebugContext(ctx, "PII redacted from prompt", slog.String("filter", "pii_redaction"), slog.Any("detected", counts))
+
+	return filters.NewOK()
+}
+
+func (r *Redaction) OnCompletionResponse(ctx context.Context, _ object.LLMRequest, response object.LLMResponse) filters.RequestFilterResult {
+	if !r.redactResponses {
+		return filters.NewOK()
+	}
+
+	chatResponse, ok := response.(*openai.ChatCompletionsResponse)
+	if !ok {
+		return filters.NewOK()
+	}
+
+	counts := make(map[string]int)
+
+	for i, message := range chatResponse.GetChoiceMessages() {
+		choiceCounts := make(map[string]int)
+
+		masked := mapText(message, func(text string) string {
+			return r.mask(text, choiceCounts)
+		})
+		if len(choiceCounts) == 0 {
+			continue
+		}
+
+		err := chatResponse.SetChoiceMessageContent(i, masked["content"])
+		if err != nil {
+			return filters.NewFailed(openai.NewErrorInternalError().WithCause(err))
+		}
+
+		for name, count := range choiceCounts {
+			counts[name] += count
+		}
+	}
+
+	record(ctx, counts)
+
+	return filters.NewOK()
+}
+
+func (r *Redaction) OnCompletionStreamChunk(ctx context.Context, request object.LLMRequest, stream object.LLMStreamResponse, responseChunk object.LLMChunkResponse) filters.RequestFilterResult {
+	if !r.redactResponses {
+		return filters.NewOK()
+	}
+
+	if responseChunk.IsDone() {
+		r.streams.Delete(stream)
+		return filters.NewOK()
+	}
+
+	chunk, ok := responseChunk.(*openai.ChatCompletionStreamChunk)
+	if !ok || responseChunk.IsEmpty() {
+		return filters.NewOK()
+	}
+
+	deltas := chunk.GetChoiceDeltas()
+	if len(deltas) == 0 {
+		return filters.NewOK()
+	}
+
+	state := r.stateOf(request, stream)
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	counts := make(map[string]int)
+
+	for i, delta := range deltas {
+		text := state.pending[delta.Index] + delta.Content
+
+		// The rest is released once the choice is finished, or too long to
+		// be held back
+		var emitted string
+		if delta.FinishReason != "" || len(text) > maxPendingBytes {
+			emitted = text
+			delete(state.pending, delta.Index)
+		} else {
+			emitted, state.pending[delta.Index] = splitMaskable(text)
+		}
+
+		emitted = r.mask(emitted, counts)
+		if emitted == delta.Content {
+			continue
+		}
+
+		err := chunk.SetChoiceDeltaContent(i, emitted)
+		if err != nil {
+			return filters.NewFailed(openai.NewErrorInternalError().WithCause(err))
+		}
+	}
+
+	record(ctx, counts)
+
+	return filters.NewOK()
+}
+
+func (r *Redaction) stateOf(request object.LLMRequest, stream object.LLMStreamResponse) *streamState {
+	state, loaded := r.streams.LoadOrStore(stream, &streamState{pending: make(map[int]string)})
+	if !loaded && request != nil && request.GetRawRequest() != nil {
+		// Streams interrupted never reach the done chunk
+		context.AfterFunc(request.GetRawRequest().Context(), func() {
+			r.streams.Delete(stream)
+		})
+	}
+
+	return state.(*streamState) //nolint:forcetypeassert
+}
+
+// mapText returns a copy of the message with the text of its content mapped,
+// the content is either a string or a list of parts.
+func mapText(message map[string]any, fn func(string) string) map[string]any {
+	mapped := maps.Clone(message)
+
+	switch content := message["content"].(type) {
+	case string:
+		mapped["content"] = fn(content)
+	case []any:
+		parts := make([]any, 0, len(content))
+
+		for _, part := range content {
+			partMap, ok := part.(map[string]any)
+			if !ok || partMap["type"] != "text" {
+				parts = append(parts, part)
+				continue
+			}
+
+			text, _ := partMap["text"].(string)
+
+			mappedPart := maps.Clone(partMap)
+			mappedPart["text"] = fn(text)
+			parts = append(parts, mappedPart)
+		}
+
+		mapped["content"] = parts
+	}
+
+	return mapped
+}
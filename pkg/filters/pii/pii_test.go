@@ -0,0 +1,237 @@
+package pii
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+func newRedaction(t *testing.T, cfg *v1alpha1.PIIRedactionConfig) *Redaction {
+	t.Helper()
+
+	f, err := NewWithConfig(lo.Must(anypb.New(cfg)), bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+
+	r, ok := f.(*Redaction)
+	require.True(t, ok)
+
+	return r
+}
+
+func TestNewMasker(t *testing.T) {
+	_, err := NewMasker([]v1alpha1.PIIRedactionConfig_Detector{v1alpha1.PIIRedactionConfig_DETECTOR_UNSPECIFIED}, nil)
+	require.Error(t, err)
+
+	_, err = NewMasker(nil, []*v1alpha1.PIIRedactionConfig_Pattern{{Pattern: `\d+`}})
+	require.Error(t, err)
+
+	_, err = NewMasker(nil, []*v1alpha1.PIIRedactionConfig_Pattern{{Name: "broken", Pattern: `(`}})
+	require.Error(t, err)
+}
+
+func TestMasker_Mask(t *testing.T) {
+	m, err := NewMasker(nil, nil)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+		counts   map[string]int
+	}{
+		{
+			name:     "email",
+			text:     "Contact alice.smith@mail.example.co.uk please",
+			expected: "Contact [EMAIL] please",
+			counts:   map[string]int{"EMAIL": 1},
+		},
+		{
+			name:     "phone numbers",
+			text:     "Call +1 (415) 555-0132 or 020 7946 0958",
+			expected: "Call [PHONE_NUMBER] or [PHONE_NUMBER]",
+			counts:   map[string]int{"PHONE_NUMBER": 2},
+		},
+		{
+			name:     "credit card",
+			text:     "My card is 4111 1111 1111 1111, expires 12/27",
+			expected: "My card is [CREDIT_CARD], expires 12/27",
+			counts:   map[string]int{"CREDIT_CARD": 1},
+		},
+		{
+			name:     "failing the Luhn check",
+			text:     "Order 4111111111111112 shipped",
+			expected: "Order 4111111111111112 shipped",
+		},
+		{
+			name:     "short numbers",
+			text:     "Released in 2024-10-16, version 1.2.3, 555-0100",
+			expected: "Released in 2024-10-16, version 1.2.3, 555-0100",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			masked, counts := m.Mask(tc.text)
+			assert.Equal(t, tc.expected, masked)
+			assert.Equal(t, tc.counts, counts)
+		})
+	}
+}
+
+func TestMasker_Detectors(t *testing.T) {
+	m, err := NewMasker(
+		[]v1alpha1.PIIRedactionConfig_Detector{v1alpha1.PIIRedactionConfig_DETECTOR_EMAIL},
+		[]*v1alpha1.PIIRedactionConfig_Pattern{{Name: "employee_id", Pattern: `EMP-\d{6}`}},
+	)
+	require.NoError(t, err)
+
+	masked, counts := m.Mask("EMP-123456 is bob@example.com, call 415-555-0132")
+	assert.Equal(t, "[EMPLOYEE_ID] is [EMAIL], call 415-555-0132", masked)
+	assert.Equal(t, map[string]int{"EMAIL": 1, "EMPLOYEE_ID": 1}, counts)
+}
+
+func TestSplitMaskable(t *testing.T) {
+	emitted, pending := splitMaskable("Call me at 415-")
+	assert.Equal(t, "Call me at ", emitted)
+	assert.Equal(t, "415-", pending)
+
+	// Numbers separated by spaces are held back as a whole
+	emitted, pending = splitMaskable("card 4111 1111")
+	assert.Equal(t, "card ", emitted)
+	assert.Equal(t, "4111 1111", pending)
+
+	emitted, pending = splitMaskable("alice@exam")
+	assert.Empty(t, emitted)
+	assert.Equal(t, "alice@exam", pending)
+}
+
+func TestRedaction_OnCompletionRequest(t *testing.T) {
+	r := newRedaction(t, &v1alpha1.PIIRedactionConfig{})
+
+	ctx, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gpt-4o", "messages": [
+		{"role": "system", "content": "You are helpful."},
+		{"role": "user", "content": [
+			{"type": "text", "text": "I am alice@example.com"},
+			{"type": "image_url", "image_url": {"url": "https://example.com/a.png"}}
+		]},
+		{"role": "user", "content": "Charge 4111-1111-1111-1111"}
+	]}`)
+
+	result := r.OnCompletionRequest(ctx, request, request.GetRawRequest())
+	require.True(t, result.IsSSucceeded())
+
+	assert.Equal(t, []map[string]any{
+		{"role": "system", "content": "You are helpful."},
+		{"role": "user", "content": []any{
+			map[string]any{"type": "text", "text": "I am [EMAIL]"},
+			map[string]any{"type": "image_url", "image_url": map[string]any{"url": "https://example.com/a.png"}},
+		}},
+		{"role": "user", "content": "Charge [CREDIT_CARD]"},
+	}, request.GetMessages())
+	assert.Equal(t, "gpt-4o", request.GetModel())
+
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	assert.Equal(t, map[string]int{"EMAIL": 1, "CREDIT_CARD": 1}, rMeta.PIIRedacted)
+
+	// Untouched without PII
+	ctx, request = gatewaytest.NewChatCompletionRequest(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "hello"}]}`)
+
+	result = r.OnCompletionRequest(ctx, request, request.GetRawRequest())
+	require.True(t, result.IsSSucceeded())
+	assert.Nil(t, metadata.RequestMetadataFromCtx(ctx).PIIRedacted)
+}
+
+func TestRedaction_OnCompletionResponse(t *testing.T) {
+	ctx, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "who?"}]}`)
+
+	newResponse := func() *openai.ChatCompletionsResponse {
+		response, err := openai.NewChatCompletionResponse(request, &http.Response{StatusCode: http.StatusOK}, bufio.NewReader(strings.NewReader(`{"model": "gpt-4o", "choices": [
+			{"index": 0, "message": {"role": "assistant", "content": "It's bob@example.com"}},
+			{"index": 1, "message": {"role": "assistant", "content": "No idea"}}
+		]}`)))
+		require.NoError(t, err)
+
+		return response
+	}
+
+	// Responses are kept as is by default
+	response := newResponse()
+
+	result := newRedaction(t, &v1alpha1.PIIRedactionConfig{}).OnCompletionResponse(ctx, request, response)
+	require.True(t, result.IsSSucceeded())
+	assert.Equal(t, "It's bob@example.com", response.GetChoiceMessages()[0]["content"])
+
+	response = newResponse()
+
+	result = newRedaction(t, &v1alpha1.PIIRedactionConfig{RedactResponses: true}).OnCompletionResponse(ctx, request, response)
+	require.True(t, result.IsSSucceeded())
+
+	messages := response.GetChoiceMessages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, "It's [EMAIL]", messages[0]["content"])
+	assert.Equal(t, "No idea", messages[1]["content"])
+	assert.Equal(t, map[string]int{"EMAIL": 1}, metadata.RequestMetadataFromCtx(ctx).PIIRedacted)
+}
+
+func TestRedaction_OnCompletionStreamChunk(t *testing.T) {
+	r := newRedaction(t, &v1alpha1.PIIRedactionConfig{RedactResponses: true})
+
+	ctx, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gpt-4o", "stream": true, "messages": [{"role": "user", "content": "who?"}]}`)
+
+	// PII split across chunks
+	body := strings.Join([]string{
+		`data: {"model": "gpt-4o", "choices": [{"index": 0, "delta": {"role": "assistant", "content": "Call me at 415-"}}]}`,
+		`data: {"model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": "555-0132 or mail a"}}]}`,
+		`data: {"model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": "lice@exam"}}]}`,
+		`data: {"model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": "ple.com today"}, "finish_reason": "stop"}]}`,
+		`data: [DONE]`,
+	}, "\n\n") + "\n\n"
+
+	stream, err := openai.NewChatCompletionStreamResponse(request, nil, bufio.NewReader(strings.NewReader(body)))
+	require.NoError(t, err)
+
+	stream.BeforeChunk(func(chunk object.LLMChunkResponse) error {
+		return r.OnCompletionStreamChunk(ctx, request, stream, chunk).Error
+	})
+
+	var contents []string
+
+	for {
+		chunk, err := stream.NextChunk()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+
+		if chunk.IsEmpty() || chunk.IsDone() {
+			continue
+		}
+
+		streamChunk, ok := chunk.(*openai.ChatCompletionStreamChunk)
+		require.True(t, ok)
+
+		contents = append(contents, streamChunk.GetDeltaContent())
+	}
+
+	assert.Equal(t, []string{"Call me at ", "[PHONE_NUMBER] or mail ", "", "[EMAIL] today"}, contents)
+	assert.Equal(t, map[string]int{"EMAIL": 1, "PHONE_NUMBER": 1}, metadata.RequestMetadataFromCtx(ctx).PIIRedacted)
+
+	_, ok := r.streams.Load(stream)
+	assert.False(t, ok)
+}
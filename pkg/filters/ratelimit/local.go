@@ -4,6 +4,7 @@ import (
 	"context"
 	"hash/fnv"
 	"log/slog"
+	"math"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -40,6 +41,48 @@ func (rl *RateLimiter) checkBucketLocal(key string, window time.Duration, limit
 	defer shard.mu.Unlock()
 
 	now := time.Now()
+	bucket := rl.loadBucket(shard, key, window, limit, false, now)
+
+	return rl.tryConsume(bucket, now, key), nil
+}
+
+// checkTokensLocal checks whether there are tokens left in the bucket without
+// consuming any, the actual tokens are deducted by deductTokensLocal once the
+// usage is known.
+func (rl *RateLimiter) checkTokensLocal(key string, window time.Duration, limit int, prepaid bool) (bool, error) {
+	shard := rl.getShard(key)
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	now := time.Now()
+	bucket := rl.loadBucket(shard, key, window, limit, prepaid, now)
+	rl.refill(bucket, now)
+
+	return bucket.tokens.Load() >= precision, nil
+}
+
+// deductTokensLocal deducts the tokens from the bucket, the bucket is allowed
+// to go below zero so that the overdraft is paid back before further requests
+// are allowed.
+func (rl *RateLimiter) deductTokensLocal(key string, window time.Duration, limit int, prepaid bool, tokens int64) error {
+	shard := rl.getShard(key)
+
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	now := time.Now()
+	bucket := rl.loadBucket(shard, key, window, limit, prepaid, now)
+	rl.refill(bucket, now)
+	bucket.tokens.Add(-tokens * precision)
+
+	return nil
+}
+
+// loadBucket returns the bucket of the key, creating or updating it as needed,
+// the shard must be locked. Buckets of prepaid budgets are never replenished
+// nor expired.
+func (rl *RateLimiter) loadBucket(shard *rateLimitShard, key string, window time.Duration, limit int, prepaid bool, now time.Time) *tokenBucket {
 	shard.lastAccessTime[key] = now
 
 	// Calculate TTL - max(5min, 2x window)
@@ -49,14 +92,17 @@ func (rl *RateLimiter) checkBucketLocal(key string, window time.Duration, limit
 	newCapacity := int64(limit * precision)
 	newRate := int64(float64(newCapacity) / window.Seconds())
 
+	if prepaid {
+		expireAt = math.MaxInt64
+		newRate = 0
+	}
+
 	bucket := shard.buckets[key]
 	if bucket == nil {
-		bucket = rl.initBucket(shard, key, limit, newCapacity, newRate, now, expireAt)
-	} else {
-		bucket = rl.updateBucket(shard, bucket, key, limit, newCapacity, newRate, now, expireAt)
+		return rl.initBucket(shard, key, limit, newCapacity, newRate, now, expireAt)
 	}
 
-	return rl.tryConsume(bucket, now, key), nil
+	return rl.updateBucket(shard, bucket, key, limit, newCapacity, newRate, now, expireAt)
 }
 
 func (rl *RateLimiter) initBucket(shard *rateLimitShard, key string, limit int, newCapacity, newRate int64, now time.Time, expireAt int64) *tokenBucket {
@@ -109,6 +155,11 @@ func (rl *RateLimiter) evictOldestBucket(shard *rateLimitShard, now time.Time) {
 	oldestTime := now
 
 	for k, t := range shard.lastAccessTime {
+		// prepaid budgets must not be reset by eviction
+		if shard.buckets[k] != nil && shard.buckets[k].expireAt.Load() == math.MaxInt64 {
+			continue
+		}
+
 		if t.Before(oldestTime) {
 			oldestTime = t
 			oldestKey = k
@@ -121,6 +172,19 @@ func (rl *RateLimiter) evictOldestBucket(shard *rateLimitShard, now time.Time) {
 }
 
 func (rl *RateLimiter) tryConsume(bucket *tokenBucket, now time.Time, key string) bool {
+	rl.refill(bucket, now)
+
+	if bucket.tokens.Load() >= precision {
+		bucket.tokens.Add(-precision)
+		return true
+	}
+
+	slog.DebugContext(context.Background(), "rate limit exceeded", append(rl.logCommonAttrs(), slog.String("key", key), slog.Int64("tokens", bucket.tokens.Load()), slog.Int("precision", precision))...)
+
+	return false
+}
+
+func (rl *RateLimiter) refill(bucket *tokenBucket, now time.Time) {
 	// calculate tokens to add based on time elapsed
 	lastUpdateNano := bucket.lastUpdate.Load()
 	elapsed := now.Sub(time.Unix(0, lastUpdateNano)).Seconds()
@@ -131,15 +195,6 @@ func (rl *RateLimiter) tryConsume(bucket *tokenBucket, now time.Time, key string
 		bucket.tokens.Store(newTokens)
 		bucket.lastUpdate.Store(now.UnixNano())
 	}
-
-	if bucket.tokens.Load() >= precision {
-		bucket.tokens.Add(-precision)
-		return true
-	}
-
-	slog.DebugContext(context.Background(), "rate limit exceeded", append(rl.logCommonAttrs(), slog.String("key", key), slog.Int64("tokens", bucket.tokens.Load()), slog.Int("precision", precision))...)
-
-	return false
 }
 
 // Cleanup old keys that haven't been accessed for more than 24 hours
@@ -165,8 +220,15 @@ func (rl *RateLimiter) cleanup() {
 
 	now := time.Now()
 
+	rl.decisions.cleanup()
+
 	// Clean each shard
 	for i, shard := range rl.shards {
+		// Shards are only used by the local mode
+		if shard == nil {
+			continue
+		}
+
 		shard.mu.Lock()
 		beforeCount := len(shard.buckets)
 
@@ -6,11 +6,15 @@ import (
 	"log/slog"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
+	"knoway.dev/pkg/events"
 	"knoway.dev/pkg/metadata"
 	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/observation"
 	"knoway.dev/pkg/redis"
+	"knoway.dev/pkg/tenant"
 
 	"knoway.dev/api/filters/v1alpha1"
 	"knoway.dev/pkg/bootkit"
@@ -18,6 +22,7 @@ import (
 	"knoway.dev/pkg/protoutils"
 
 	"github.com/redis/rueidis"
+	"github.com/samber/lo"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
@@ -47,6 +52,15 @@ type RateLimiter struct {
 	serverPrefix string
 
 	redisClient rueidis.Client
+	redisRetry  redisRetryPolicy
+	// decisions caches the rejections of Redis, see decideRedis
+	decisions *decisionCache
+	// failOpen allows the requests when Redis is unavailable
+	failOpen bool
+
+	// streams accumulates the tokens of streaming responses until they
+	// finish, keyed by object.LLMStreamResponse
+	streams sync.Map
 }
 
 func (rl *RateLimiter) logCommonAttrs() []any {
@@ -60,6 +74,14 @@ func (rl *RateLimiter) logCommonAttrs() []any {
 var _ filters.RequestFilter = (*RateLimiter)(nil)
 var _ filters.OnCompletionRequestFilter = (*RateLimiter)(nil)
 var _ filters.OnImageGenerationsRequestFilter = (*RateLimiter)(nil)
+var _ filters.OnModerationsRequestFilter = (*RateLimiter)(nil)
+var _ filters.OnEmbeddingsRequestFilter = (*RateLimiter)(nil)
+var _ filters.OnRerankRequestFilter = (*RateLimiter)(nil)
+var _ filters.OnVideoGenerationsRequestFilter = (*RateLimiter)(nil)
+var _ filters.OnRealtimeRequestFilter = (*RateLimiter)(nil)
+var _ filters.OnCompletionResponseFilter = (*RateLimiter)(nil)
+var _ filters.OnCompletionStreamResponseFilter = (*RateLimiter)(nil)
+var _ filters.OnRealtimeResponseFilter = (*RateLimiter)(nil)
 
 func NewWithConfig(cfg *anypb.Any, lifecycle bootkit.LifeCycle) (filters.RequestFilter, error) {
 	rCfg, err := protoutils.FromAny(cfg, &v1alpha1.RateLimitConfig{})
@@ -101,6 +123,13 @@ func NewWithConfig(cfg *anypb.Any, lifecycle bootkit.LifeCycle) (filters.Request
 		}
 
 		rl.redisClient = redisClient
+		rl.redisRetry = newRedisRetryPolicy(rCfg.GetRedisRetry())
+		rl.decisions = newDecisionCache(rCfg.GetDecisionCacheTtl().AsDuration())
+		rl.failOpen = rCfg.GetFailOpen()
+
+		if rl.decisions.ttl > 0 {
+			go rl.cleanupLoop(ctx)
+		}
 	} else {
 		slog.InfoContext(context.Background(), "initializing local rate limiter shards", rl.logCommonAttrs()...)
 		// init shards for local mode
@@ -139,6 +168,28 @@ func (rl *RateLimiter) OnImageGenerationsRequest(ctx context.Context, request ob
 	return rl.onRequest(ctx, request)
 }
 
+func (rl *RateLimiter) OnModerationsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return rl.onRequest(ctx, request)
+}
+
+func (rl *RateLimiter) OnEmbeddingsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return rl.onRequest(ctx, request)
+}
+
+func (rl *RateLimiter) OnRerankRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return rl.onRequest(ctx, request)
+}
+
+func (rl *RateLimiter) OnVideoGenerationsRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return rl.onRequest(ctx, request)
+}
+
+// OnRealtimeRequest limits the sessions of the Realtime API, the tokens of
+// the sessions are deducted once the sessions end.
+func (rl *RateLimiter) OnRealtimeRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	return rl.onRequest(ctx, request)
+}
+
 func (rl *RateLimiter) buildKey(baseOn v1alpha1.RateLimitBaseOn, value string, routeName string) string {
 	return fmt.Sprintf("%s:%s:%s:%s", rl.serverPrefix, baseOn, value, routeName)
 }
@@ -205,39 +256,198 @@ func (rl *RateLimiter) onRequest(ctx context.Context, request object.LLMRequest)
 		return filters.NewOK()
 	}
 
-	fPolicy := rl.findMatchingPolicy(apiKey, userName, rl.pluginPolicies)
+	policies := tenant.RateLimitPolicies(ctx, rl.pluginPolicies)
+
+	fPolicy := rl.findMatchingPolicy(apiKey, userName, policiesOfUnit(policies, v1alpha1.RateLimitUnit_REQUESTS))
 	if fPolicy == nil {
 		slog.DebugContext(ctx, "no matching policy found, skipping rate limit", append(rl.logCommonAttrs(), slog.String("apiKey", apiKey), slog.String("userName", userName))...)
+	} else {
+		allow, err := rl.allowRequest(apiKey, userName, request.GetModel(), fPolicy)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to check rate limit", append(rl.logCommonAttrs(), slog.Any("error", err))...)
+			return filters.NewFailed(err)
+		}
+
+		if !allow {
+			return rl.rejected(ctx, apiKey, userName, request, fPolicy)
+		}
+	}
+
+	fPolicy = rl.findMatchingPolicy(apiKey, userName, policiesOfUnit(policies, v1alpha1.RateLimitUnit_TOKENS))
+	if fPolicy == nil {
 		return filters.NewOK()
 	}
 
-	allow, err := rl.allowRequest(apiKey, userName, request.GetModel(), fPolicy)
+	allow, err := rl.allowTokens(apiKey, userName, rMeta.RequestModel, fPolicy, 0)
 	if err != nil {
-		slog.ErrorContext(ctx, "failed to check rate limit", append(rl.logCommonAttrs(), slog.Any("error", err))...)
+		slog.ErrorContext(ctx, "failed to check tokens rate limit", append(rl.logCommonAttrs(), slog.Any("error", err))...)
 		return filters.NewFailed(err)
 	}
 
 	if !allow {
-		slog.DebugContext(ctx, "rate limit exceeded", append(
-			rl.logCommonAttrs(),
-			slog.String("apiKey", apiKey),
-			slog.String("userName", userName),
-			slog.String("model", request.GetModel()),
-			slog.Int64("limit", int64(fPolicy.GetLimit())),
-			slog.Duration("duration", fPolicy.GetDuration().AsDuration()),
-		)...)
+		return rl.rejected(ctx, apiKey, userName, request, fPolicy)
+	}
+
+	return filters.NewOK()
+}
 
-		return filters.NewFailed(object.NewErrorRateLimitExceeded())
+func (rl *RateLimiter) rejected(ctx context.Context, apiKey, userName string, request object.LLMRequest, policy *v1alpha1.RateLimitPolicy) filters.RequestFilterResult {
+	slog.DebugContext(ctx, "rate limit exceeded", append(
+		rl.logCommonAttrs(),
+		slog.String("apiKey", apiKey),
+		slog.String("userName", userName),
+		slog.String("model", request.GetModel()),
+		slog.Any("unit", policy.GetUnit()),
+		slog.Int64("limit", int64(policy.GetLimit())),
+		slog.Duration("duration", policy.GetDuration().AsDuration()),
+	)...)
+
+	observation.ObserveRateLimitRejection(request.GetModel(), userName)
+
+	if policy.GetUnit() == v1alpha1.RateLimitUnit_TOKENS && policy.GetPrepaid() {
+		events.Publish(events.TypeQuotaExhausted, map[string]string{
+			"model": request.GetModel(),
+			"user":  userName,
+		})
+
+		return filters.NewFailed(object.NewErrorInsufficientQuota())
 	}
 
+	return filters.NewFailed(object.NewErrorRateLimitExceeded())
+}
+
+// policiesOfUnit returns the policies limiting the given unit, policies
+// without unit limit requests.
+func policiesOfUnit(policies []*v1alpha1.RateLimitPolicy, unit v1alpha1.RateLimitUnit) []*v1alpha1.RateLimitPolicy {
+	return lo.Filter(policies, func(policy *v1alpha1.RateLimitPolicy, _ int) bool {
+		if policy.GetUnit() == v1alpha1.RateLimitUnit_RATE_LIMIT_UNIT_UNSPECIFIED {
+			return unit == v1alpha1.RateLimitUnit_REQUESTS
+		}
+
+		return policy.GetUnit() == unit
+	})
+}
+
+func (rl *RateLimiter) OnCompletionResponse(ctx context.Context, request object.LLMRequest, response object.LLMResponse) filters.RequestFilterResult {
+	usage, ok := object.AsLLMTokensUsage(response.GetUsage())
+	if !ok || lo.IsNil(usage) {
+		return filters.NewOK()
+	}
+
+	rl.deductTokens(ctx, int64(usage.GetTotalTokens()))
+
 	return filters.NewOK()
 }
 
+func (rl *RateLimiter) OnRealtimeResponse(ctx context.Context, request object.LLMRequest, response object.LLMResponse) filters.RequestFilterResult {
+	return rl.OnCompletionResponse(ctx, request, response)
+}
+
+type streamTokens struct {
+	chunks int64
+	usage  object.LLMTokensUsage
+}
+
+// OnCompletionStreamResponse accumulates the tokens of the stream and deducts
+// them once the stream finishes. The usage chunk is preferred, when the client
+// does not ask for it (stream_options.include_usage), each chunk with content
+// is counted as one token.
+func (rl *RateLimiter) OnCompletionStreamResponse(ctx context.Context, request object.LLMRequest, response object.LLMStreamResponse, responseChunk object.LLMChunkResponse) filters.RequestFilterResult {
+	if lo.IsNil(responseChunk) || len(policiesOfUnit(tenant.RateLimitPolicies(request.GetRawRequest().Context(), rl.pluginPolicies), v1alpha1.RateLimitUnit_TOKENS)) == 0 {
+		return filters.NewOK()
+	}
+
+	value, _ := rl.streams.LoadOrStore(response, new(streamTokens))
+	accumulated, _ := value.(*streamTokens)
+
+	switch {
+	case responseChunk.IsUsage():
+		if usage, ok := object.AsLLMTokensUsage(responseChunk.GetUsage()); ok && !lo.IsNil(usage) {
+			accumulated.usage = usage
+		}
+	case !responseChunk.IsEmpty() && !responseChunk.IsDone():
+		accumulated.chunks++
+	}
+
+	// ctx is canceled right before the last chunk of the stream, which is
+	// delivered once the stream reaches EOF or fails
+	if !responseChunk.IsDone() && ctx.Err() == nil {
+		return filters.NewOK()
+	}
+
+	rl.streams.Delete(response)
+
+	tokens := accumulated.chunks
+	if accumulated.usage != nil {
+		tokens = int64(accumulated.usage.GetTotalTokens())
+	}
+
+	rl.deductTokens(request.GetRawRequest().Context(), tokens)
+
+	return filters.NewOK()
+}
+
+func (rl *RateLimiter) deductTokens(ctx context.Context, tokens int64) {
+	if tokens <= 0 {
+		return
+	}
+
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	if rMeta == nil {
+		return
+	}
+
+	apiKey := rMeta.AuthInfo.GetApiKeyId()
+	userName := rMeta.AuthInfo.GetUserId()
+
+	fPolicy := rl.findMatchingPolicy(apiKey, userName, policiesOfUnit(tenant.RateLimitPolicies(ctx, rl.pluginPolicies), v1alpha1.RateLimitUnit_TOKENS))
+	if fPolicy == nil {
+		return
+	}
+
+	_, err := rl.allowTokens(apiKey, userName, rMeta.RequestModel, fPolicy, tokens)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to deduct tokens", append(rl.logCommonAttrs(), slog.Int64("tokens", tokens), slog.Any("error", err))...)
+	}
+}
+
 func (rl *RateLimiter) allowRequest(apiKey, userName string, modelName string, policy *v1alpha1.RateLimitPolicy) (bool, error) {
-	if policy == nil {
+	key, duration, ok := rl.resolvePolicy(apiKey, userName, modelName, policy)
+	if !ok {
 		return true, nil
 	}
 
+	return rl.checkBucket(key, duration, int(policy.GetLimit()))
+}
+
+// allowTokens checks whether there are tokens left for the policy when tokens
+// is 0, otherwise deducts the tokens.
+func (rl *RateLimiter) allowTokens(apiKey, userName string, modelName string, policy *v1alpha1.RateLimitPolicy, tokens int64) (bool, error) {
+	key, duration, ok := rl.resolvePolicy(apiKey, userName, modelName, policy)
+	if !ok {
+		return true, nil
+	}
+
+	key += ":tokens"
+
+	if rl.mode == v1alpha1.RateLimitMode_REDIS {
+		return rl.evalTokensRedis(key, duration, int(policy.GetLimit()), policy.GetPrepaid(), tokens)
+	}
+
+	if tokens == 0 {
+		return rl.checkTokensLocal(key, duration, int(policy.GetLimit()), policy.GetPrepaid())
+	}
+
+	return true, rl.deductTokensLocal(key, duration, int(policy.GetLimit()), policy.GetPrepaid(), tokens)
+}
+
+// resolvePolicy returns the key of the bucket and the window of the policy,
+// false if the policy does not apply.
+func (rl *RateLimiter) resolvePolicy(apiKey, userName string, modelName string, policy *v1alpha1.RateLimitPolicy) (string, time.Duration, bool) {
+	if policy == nil {
+		return "", 0, false
+	}
+
 	var value string
 
 	switch policy.GetBasedOn() {
@@ -246,9 +456,9 @@ func (rl *RateLimiter) allowRequest(apiKey, userName string, modelName string, p
 	case v1alpha1.RateLimitBaseOn_USER_ID:
 		value = userName
 	case v1alpha1.RateLimitBaseOn_RATE_LIMIT_BASE_ON_UNSPECIFIED:
-		return true, nil
+		return "", 0, false
 	default:
-		return true, nil
+		return "", 0, false
 	}
 
 	matched := false
@@ -264,12 +474,12 @@ func (rl *RateLimiter) allowRequest(apiKey, userName string, modelName string, p
 	}
 
 	if !matched {
-		return true, nil
+		return "", 0, false
 	}
 
 	// disabled limit
 	if policy.GetLimit() == 0 {
-		return true, nil
+		return "", 0, false
 	}
 
 	duration := policy.GetDuration().AsDuration()
@@ -277,9 +487,7 @@ func (rl *RateLimiter) allowRequest(apiKey, userName string, modelName string, p
 		duration = defaultDuration
 	}
 
-	key := rl.buildKey(policy.GetBasedOn(), value, modelName)
-
-	return rl.checkBucket(key, duration, int(policy.GetLimit()))
+	return rl.buildKey(policy.GetBasedOn(), value, modelName), duration, true
 }
 
 func (rl *RateLimiter) checkBucket(key string, window time.Duration, limit int) (bool, error) {
@@ -1,13 +1,26 @@
 package ratelimit
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	filtersv1alpha1 "knoway.dev/api/filters/v1alpha1"
+	servicev1alpha1 "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/tenant"
+	"knoway.dev/pkg/types/openai"
 )
 
 func TestCheckBucket(t *testing.T) {
@@ -204,3 +217,209 @@ func TestRateLimiter_CheckRateLimitPolicies(t *testing.T) {
 		})
 	}
 }
+
+func newLocalRateLimiter(policies ...*filtersv1alpha1.RateLimitPolicy) *RateLimiter {
+	_, cancel := context.WithCancel(context.Background())
+
+	rl := &RateLimiter{
+		shards:         make([]*rateLimitShard, numShards),
+		numShards:      numShards,
+		cancel:         cancel,
+		pluginPolicies: policies,
+	}
+
+	for i := range numShards {
+		rl.shards[i] = &rateLimitShard{
+			buckets:        make(map[string]*tokenBucket),
+			lastAccessTime: make(map[string]time.Time),
+		}
+	}
+
+	return rl
+}
+
+func TestRateLimiter_AllowTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepaid bool
+	}{
+		{name: "tokens per minute", prepaid: false},
+		{name: "prepaid budget", prepaid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &filtersv1alpha1.RateLimitPolicy{
+				BasedOn:  filtersv1alpha1.RateLimitBaseOn_API_KEY,
+				Limit:    100,
+				Duration: durationpb.New(60 * time.Second),
+				Unit:     filtersv1alpha1.RateLimitUnit_TOKENS,
+				Prepaid:  tt.prepaid,
+			}
+
+			rl := newLocalRateLimiter(policy)
+
+			allowed, err := rl.allowTokens("key1", "user1", "gpt-4o", policy, 0)
+			require.NoError(t, err)
+			assert.True(t, allowed)
+
+			// checking does not consume tokens
+			allowed, err = rl.allowTokens("key1", "user1", "gpt-4o", policy, 0)
+			require.NoError(t, err)
+			assert.True(t, allowed)
+
+			allowed, err = rl.allowTokens("key1", "user1", "gpt-4o", policy, 60)
+			require.NoError(t, err)
+			assert.True(t, allowed)
+
+			allowed, err = rl.allowTokens("key1", "user1", "gpt-4o", policy, 0)
+			require.NoError(t, err)
+			assert.True(t, allowed)
+
+			// overdraft
+			allowed, err = rl.allowTokens("key1", "user1", "gpt-4o", policy, 60)
+			require.NoError(t, err)
+			assert.True(t, allowed)
+
+			allowed, err = rl.allowTokens("key1", "user1", "gpt-4o", policy, 0)
+			require.NoError(t, err)
+			assert.False(t, allowed)
+
+			// requests are limited separately
+			allowed, err = rl.allowRequest("key1", "user1", "gpt-4o", policy)
+			require.NoError(t, err)
+			assert.True(t, allowed)
+		})
+	}
+}
+
+func TestPoliciesOfUnit(t *testing.T) {
+	requests := &filtersv1alpha1.RateLimitPolicy{Limit: 1}
+	explicitRequests := &filtersv1alpha1.RateLimitPolicy{Limit: 2, Unit: filtersv1alpha1.RateLimitUnit_REQUESTS}
+	tokens := &filtersv1alpha1.RateLimitPolicy{Limit: 3, Unit: filtersv1alpha1.RateLimitUnit_TOKENS}
+
+	policies := []*filtersv1alpha1.RateLimitPolicy{requests, explicitRequests, tokens}
+
+	assert.Equal(t, []*filtersv1alpha1.RateLimitPolicy{requests, explicitRequests}, policiesOfUnit(policies, filtersv1alpha1.RateLimitUnit_REQUESTS))
+	assert.Equal(t, []*filtersv1alpha1.RateLimitPolicy{tokens}, policiesOfUnit(policies, filtersv1alpha1.RateLimitUnit_TOKENS))
+}
+
+func TestRateLimiter_OnCompletionStreamResponse(t *testing.T) {
+	tests := []struct {
+		name      string
+		chunks    []string
+		remaining int64
+	}{
+		{
+			name: "usage chunk",
+			chunks: []string{
+				`{"model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": "Hello"}}]}`,
+				`{"model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": " world"}}]}`,
+				`{"model": "gpt-4o", "choices": [], "usage": {"prompt_tokens": 10, "completion_tokens": 2, "total_tokens": 12}}`,
+			},
+			remaining: 88,
+		},
+		{
+			name: "accumulated chunks without usage",
+			chunks: []string{
+				`{"model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": "Hello"}}]}`,
+				`{"model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": " world"}}]}`,
+			},
+			remaining: 98,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &filtersv1alpha1.RateLimitPolicy{
+				BasedOn:  filtersv1alpha1.RateLimitBaseOn_API_KEY,
+				Limit:    100,
+				Duration: durationpb.New(60 * time.Second),
+				Unit:     filtersv1alpha1.RateLimitUnit_TOKENS,
+			}
+
+			rl := newLocalRateLimiter(policy)
+
+			httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com/v1/chat/completions", bytes.NewBufferString(`{"model": "gpt-4o", "stream": true, "messages": [{"role": "user", "content": "Hi"}]}`))
+			require.NoError(t, err)
+
+			httpRequest = httpRequest.WithContext(metadata.InitMetadataContext(httpRequest))
+			rMeta := metadata.RequestMetadataFromCtx(httpRequest.Context())
+			rMeta.AuthInfo = &servicev1alpha1.APIKeyAuthResponse{ApiKeyId: "key1"}
+			rMeta.RequestModel = "gpt-4o"
+
+			request, err := openai.NewChatCompletionRequest(httpRequest)
+			require.NoError(t, err)
+
+			result := rl.OnCompletionRequest(httpRequest.Context(), request, httpRequest)
+			require.False(t, result.IsFailed())
+
+			body := "data: " + strings.Join(tt.chunks, "\n\ndata: ") + "\n\ndata: [DONE]\n\n"
+			httpResponse := &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": []string{"text/event-stream"}}}
+
+			stream, err := openai.NewChatCompletionStreamResponse(request, httpResponse, bufio.NewReader(bytes.NewBufferString(body)))
+			require.NoError(t, err)
+
+			stream.OnChunk(func(ctx context.Context, stream object.LLMStreamResponse, chunk object.LLMChunkResponse) {
+				rl.OnCompletionStreamResponse(ctx, request, stream, chunk)
+			})
+
+			for {
+				_, err := stream.NextChunk()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+
+				require.NoError(t, err)
+			}
+
+			// Chunks are handled by the filter asynchronously
+			<-stream.WaitUntilEOF()
+
+			key := rl.buildKey(filtersv1alpha1.RateLimitBaseOn_API_KEY, "key1", "gpt-4o") + ":tokens"
+			bucket := rl.getShard(key).buckets[key]
+			require.NotNil(t, bucket)
+			assert.Equal(t, tt.remaining*precision, bucket.tokens.Load())
+		})
+	}
+}
+
+func TestRateLimiter_TenantOverrides(t *testing.T) {
+	tenant.SetGlobal(tenant.New(map[string]tenant.Overrides{
+		"acme": {
+			RateLimits: []*filtersv1alpha1.RateLimitPolicy{
+				{BasedOn: filtersv1alpha1.RateLimitBaseOn_USER_ID, Limit: 1, Duration: durationpb.New(time.Minute)},
+			},
+		},
+	}))
+	defer tenant.SetGlobal(nil)
+
+	rl := newLocalRateLimiter(&filtersv1alpha1.RateLimitPolicy{
+		BasedOn:  filtersv1alpha1.RateLimitBaseOn_USER_ID,
+		Limit:    10,
+		Duration: durationpb.New(time.Minute),
+	})
+
+	newRequest := func(userID, tenantID string) (*http.Request, object.LLMRequest) {
+		httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com/v1/chat/completions", bytes.NewBufferString(`{"model": "gpt-4o", "messages": [{"role": "user", "content": "Hi"}]}`))
+		require.NoError(t, err)
+
+		httpRequest = httpRequest.WithContext(metadata.InitMetadataContext(httpRequest))
+		rMeta := metadata.RequestMetadataFromCtx(httpRequest.Context())
+		rMeta.AuthInfo = &servicev1alpha1.APIKeyAuthResponse{UserId: userID, TenantId: tenantID}
+
+		request, err := openai.NewChatCompletionRequest(httpRequest)
+		require.NoError(t, err)
+
+		return httpRequest, request
+	}
+
+	// The policies of the tenant replace the default ones
+	httpRequest, request := newRequest("user1", "acme")
+	assert.False(t, rl.OnCompletionRequest(httpRequest.Context(), request, httpRequest).IsFailed())
+	assert.True(t, rl.OnCompletionRequest(httpRequest.Context(), request, httpRequest).IsFailed())
+
+	httpRequest, request = newRequest("user2", "other")
+	assert.False(t, rl.OnCompletionRequest(httpRequest.Context(), request, httpRequest).IsFailed())
+	assert.False(t, rl.OnCompletionRequest(httpRequest.Context(), request, httpRequest).IsFailed())
+}
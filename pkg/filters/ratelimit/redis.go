@@ -2,9 +2,19 @@ package ratelimit
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"math/rand/v2"
+	"net"
 	"strconv"
+	"sync"
+	"syscall"
 	"time"
+
+	"github.com/redis/rueidis"
+
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/observation"
 )
 
 //nolint:dupword
@@ -77,28 +87,334 @@ func (rl *RateLimiter) checkBucketRedis(key string, window time.Duration, limit
 	now := time.Now().UnixMilli() // 使用毫秒精度
 	windowMs := window.Milliseconds()
 
-	cmd := rl.redisClient.B().Eval().Script(redisRateLimitScript).
-		Numkeys(1).
-		Key(key).
-		Arg(
+	return rl.decideRedis(key, func() (bool, error) {
+		return rl.evalRedis(key, redisRateLimitScript,
 			strconv.Itoa(limit),
 			strconv.FormatInt(windowMs, 10),
 			strconv.FormatInt(now, 10),
 			strconv.Itoa(precision),
-		).
-		Build()
+		)
+	})
+}
 
-	result := rl.redisClient.Do(context.Background(), cmd)
-	if err := result.NonRedisError(); err != nil {
-		slog.ErrorContext(context.Background(), "redis error", append(rl.logCommonAttrs(), slog.Any("error", err))...)
-		return false, err
+//nolint:dupword
+var redisTokensRateLimitScript = `
+-- KEYS[1]: rate limit key
+-- ARGV[1]: limit (max tokens)
+-- ARGV[2]: window in milliseconds
+-- ARGV[3]: current timestamp in milliseconds
+-- ARGV[4]: precision multiplier
+-- ARGV[5]: tokens to deduct, 0 to only check whether there are tokens left
+-- ARGV[6]: true if the limit is a prepaid budget that never gets replenished
+
+local key = KEYS[1]
+local limit = tonumber(ARGV[1])
+local window_ms = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local precision = tonumber(ARGV[4])
+local deduct = tonumber(ARGV[5])
+local prepaid = ARGV[6] == 'true'
+
+local capacity = limit * precision
+local fill_rate = 0
+if not prepaid then
+    fill_rate = capacity / window_ms -- tokens per millisecond
+end
+
+local bucket = redis.call('HGETALL', key)
+local state = {}
+if #bucket == 0 then
+    state = { tokens = capacity, last_update = now, limit = capacity }
+else
+    for i = 1, #bucket, 2 do
+        state[bucket[i]] = tonumber(bucket[i + 1])
+    end
+
+    -- Handle rate limit changes, the consumed tokens are kept
+    if state.limit ~= capacity then
+        state.tokens = math.min(capacity, state.tokens)
+        state.limit = capacity
+    end
+end
+
+local elapsed_ms = now - state.last_update
+local new_tokens = math.min(capacity, state.tokens + (elapsed_ms * fill_rate))
+
+-- Overdraft is allowed when deducting, it is paid back before further
+-- requests are allowed
+new_tokens = new_tokens - deduct * precision
+
+local allowed = 0
+if new_tokens >= precision or deduct > 0 then
+    allowed = 1
+end
+
+redis.call('HMSET', key,
+    'tokens', new_tokens,
+    'last_update', now,
+    'limit', capacity
+)
+
+if prepaid then
+    redis.call('PERSIST', key)
+else
+    local ttl = math.max(300000, math.ceil(window_ms * 2)) -- Set TTL to max(5min, 2x window) for safety
+    redis.call('PEXPIRE', key, ttl)
+end
+
+return allowed
+`
+
+func (rl *RateLimiter) evalTokensRedis(key string, window time.Duration, limit int, prepaid bool, tokens int64) (bool, error) {
+	now := time.Now().UnixMilli()
+	windowMs := window.Milliseconds()
+
+	eval := func() (bool, error) {
+		return rl.evalRedis(key, redisTokensRateLimitScript,
+			strconv.Itoa(limit),
+			strconv.FormatInt(windowMs, 10),
+			strconv.FormatInt(now, 10),
+			strconv.Itoa(precision),
+			strconv.FormatInt(tokens, 10),
+			strconv.FormatBool(prepaid),
+		)
 	}
 
-	allowed, err := result.AsInt64()
+	// Deductions always go to Redis, only the checks are decided
+	if tokens > 0 {
+		allowed, err := eval()
+		if err != nil {
+			observation.ObserveRateLimitError(rl.mode.String(), rateLimitErrorFailed)
+		}
+
+		return allowed, err
+	}
+
+	return rl.decideRedis(key, eval)
+}
+
+// evalRedis evaluates the script of the key with the args. The scripts count
+// the requests and deduct the tokens, so only the errors before the scripts
+// are sent are retried by the retry policy of Redis, see unsentRedisError.
+func (rl *RateLimiter) evalRedis(key string, script string, args ...string) (bool, error) {
+	var allowed int64
+
+	retried, err := rl.redisRetry.do(context.Background(), func() error {
+		// Commands are recycled once sent, they are built for every attempt
+		cmd := rl.redisClient.B().Eval().Script(script).
+			Numkeys(1).
+			Key(key).
+			Arg(args...).
+			Build()
+
+		result := rl.redisClient.Do(context.Background(), cmd)
+		if err := result.NonRedisError(); err != nil {
+			slog.WarnContext(context.Background(), "redis error", append(rl.logCommonAttrs(), slog.Any("error", err))...)
+			return err
+		}
+
+		var err error
+
+		allowed, err = result.AsInt64()
+		if err != nil {
+			slog.ErrorContext(context.Background(), "failed to parse redis result", append(rl.logCommonAttrs(), slog.Any("error", err))...)
+			return err
+		}
+
+		return nil
+	})
 	if err != nil {
-		slog.ErrorContext(context.Background(), "failed to parse redis result", append(rl.logCommonAttrs(), slog.Any("error", err))...)
 		return false, err
 	}
 
+	if retried > 0 {
+		observation.ObserveRateLimitError(rl.mode.String(), rateLimitErrorRecovered)
+	}
+
 	return allowed != 0, nil
 }
+
+// decideRedis returns the decision of the key made by eval. The rejections
+// are cached so that the keys rejected are not evaluated again until the
+// rejections expire, also while Redis is unavailable. Otherwise the requests
+// fail when eval fails, unless the limiter fails open.
+func (rl *RateLimiter) decideRedis(key string, eval func() (bool, error)) (bool, error) {
+	if rl.decisions.rejected(key) {
+		return false, nil
+	}
+
+	allowed, err := eval()
+	if err == nil {
+		if !allowed {
+			rl.decisions.reject(key)
+		}
+
+		return allowed, nil
+	}
+
+	if rl.failOpen {
+		slog.WarnContext(context.Background(), "redis unavailable, allowing the request", append(rl.logCommonAttrs(), slog.String("key", key), slog.Any("error", err))...)
+		observation.ObserveRateLimitError(rl.mode.String(), rateLimitErrorFailedOpen)
+
+		return true, nil
+	}
+
+	observation.ObserveRateLimitError(rl.mode.String(), rateLimitErrorFailed)
+
+	return false, err
+}
+
+const (
+	defaultRedisMaxRetries   = 2
+	defaultRedisBaseInterval = 10 * time.Millisecond
+	defaultRedisMaxInterval  = 100 * time.Millisecond
+
+	rateLimitErrorRecovered  = "recovered"
+	rateLimitErrorFailedOpen = "failed_open"
+	rateLimitErrorFailed     = "failed"
+)
+
+// redisRetryPolicy retries the Redis operations failed before they were sent
+// with jittered exponential backoff.
+type redisRetryPolicy struct {
+	maxRetries   int
+	baseInterval time.Duration
+	maxInterval  time.Duration
+	jitter       func(n int64) int64
+}
+
+func newRedisRetryPolicy(cfg *v1alpha1.RedisRetryPolicy) redisRetryPolicy {
+	policy := redisRetryPolicy{
+		maxRetries:   defaultRedisMaxRetries,
+		baseInterval: defaultRedisBaseInterval,
+		maxInterval:  defaultRedisMaxInterval,
+		jitter:       rand.Int64N,
+	}
+
+	if cfg != nil && cfg.MaxRetries != nil {
+		policy.maxRetries = int(max(cfg.GetMaxRetries(), 0))
+	}
+
+	if cfg.GetBaseInterval().AsDuration() > 0 {
+		policy.baseInterval = cfg.GetBaseInterval().AsDuration()
+	}
+
+	if cfg.GetMaxInterval().AsDuration() > 0 {
+		policy.maxInterval = cfg.GetMaxInterval().AsDuration()
+	}
+
+	policy.maxInterval = max(policy.maxInterval, policy.baseInterval)
+
+	return policy
+}
+
+// backoff returns the delay before the nth retry, picked at random up to the
+// base interval doubled for each retry made before, capped by the max
+// interval.
+func (p redisRetryPolicy) backoff(retry int) time.Duration {
+	ceiling := p.baseInterval
+	for i := 1; i < retry && ceiling < p.maxInterval; i++ {
+		ceiling *= 2
+	}
+
+	ceiling = min(ceiling, p.maxInterval)
+
+	return time.Duration(p.jitter(int64(ceiling) + 1))
+}
+
+// do runs op until it succeeds, fails with an error after it may have been
+// sent, or runs out of retries, and returns how many times it was retried.
+func (p redisRetryPolicy) do(ctx context.Context, op func() error) (int, error) {
+	for retried := 0; ; retried++ {
+		err := op()
+		if err == nil || retried >= p.maxRetries || !unsentRedisError(err) {
+			return retried, err
+		}
+
+		timer := time.NewTimer(p.backoff(retried + 1))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return retried, err
+		case <-timer.C:
+		}
+	}
+}
+
+// unsentRedisError tells whether the operation failed before it was sent to
+// Redis, i.e. the connection couldn't be made, so that retrying it never
+// applies it twice. Timeouts and broken connections are not retried since
+// the scripts may have run on the server.
+func unsentRedisError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, rueidis.ErrClosing) {
+		return false
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// decisionCache keeps the rejections of Redis per key for a short time, a
+// zero ttl disables the cache.
+type decisionCache struct {
+	ttl        time.Duration
+	now        func() time.Time
+	rejections sync.Map
+}
+
+func newDecisionCache(ttl time.Duration) *decisionCache {
+	return &decisionCache{
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+// rejected tells whether the key has a rejection not expired yet.
+func (c *decisionCache) rejected(key string) bool {
+	if c == nil || c.ttl <= 0 {
+		return false
+	}
+
+	value, ok := c.rejections.Load(key)
+	if !ok {
+		return false
+	}
+
+	expireAt, _ := value.(time.Time)
+	if !c.now().Before(expireAt) {
+		c.rejections.CompareAndDelete(key, value)
+		return false
+	}
+
+	return true
+}
+
+func (c *decisionCache) reject(key string) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+
+	c.rejections.Store(key, c.now().Add(c.ttl))
+}
+
+// cleanup removes the expired rejections.
+func (c *decisionCache) cleanup() {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+
+	now := c.now()
+
+	c.rejections.Range(func(key, value any) bool {
+		if expireAt, _ := value.(time.Time); !now.Before(expireAt) {
+			c.rejections.CompareAndDelete(key, value)
+		}
+
+		return true
+	})
+}
@@ -0,0 +1,187 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/redis/rueidis"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"knoway.dev/api/filters/v1alpha1"
+)
+
+func TestNewRedisRetryPolicy(t *testing.T) {
+	policy := newRedisRetryPolicy(nil)
+	assert.Equal(t, defaultRedisMaxRetries, policy.maxRetries)
+	assert.Equal(t, defaultRedisBaseInterval, policy.baseInterval)
+	assert.Equal(t, defaultRedisMaxInterval, policy.maxInterval)
+
+	policy = newRedisRetryPolicy(&v1alpha1.RedisRetryPolicy{
+		MaxRetries:   lo.ToPtr[int32](0),
+		BaseInterval: durationpb.New(time.Second),
+	})
+	assert.Equal(t, 0, policy.maxRetries)
+	assert.Equal(t, time.Second, policy.baseInterval)
+	assert.Equal(t, time.Second, policy.maxInterval)
+}
+
+func TestRedisRetryPolicy_Backoff(t *testing.T) {
+	policy := newRedisRetryPolicy(&v1alpha1.RedisRetryPolicy{
+		BaseInterval: durationpb.New(10 * time.Millisecond),
+		MaxInterval:  durationpb.New(30 * time.Millisecond),
+	})
+	policy.jitter = func(n int64) int64 { return n - 1 }
+
+	assert.Equal(t, 10*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 20*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 30*time.Millisecond, policy.backoff(3))
+	assert.Equal(t, 30*time.Millisecond, policy.backoff(10))
+}
+
+func TestRedisRetryPolicy_Do(t *testing.T) {
+	policy := newRedisRetryPolicy(&v1alpha1.RedisRetryPolicy{
+		MaxRetries:   lo.ToPtr[int32](2),
+		BaseInterval: durationpb.New(time.Millisecond),
+	})
+
+	refused := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+
+	t.Run("recovered", func(t *testing.T) {
+		calls := 0
+		retried, err := policy.do(context.Background(), func() error {
+			calls++
+			return lo.Ternary(calls < 2, error(refused), nil)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, retried)
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		calls := 0
+		retried, err := policy.do(context.Background(), func() error {
+			calls++
+			return refused
+		})
+		require.ErrorIs(t, err, refused)
+		assert.Equal(t, 2, retried)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("sent", func(t *testing.T) {
+		calls := 0
+		_, err := policy.do(context.Background(), func() error {
+			calls++
+			return &net.OpError{Op: "read", Err: context.DeadlineExceeded}
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("permanent", func(t *testing.T) {
+		calls := 0
+		_, err := policy.do(context.Background(), func() error {
+			calls++
+			return rueidis.ErrClosing
+		})
+		require.ErrorIs(t, err, rueidis.ErrClosing)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestUnsentRedisError(t *testing.T) {
+	assert.True(t, unsentRedisError(&net.OpError{Op: "dial", Err: errors.New("connection refused")}))
+	assert.True(t, unsentRedisError(syscall.ECONNREFUSED))
+	assert.False(t, unsentRedisError(&net.OpError{Op: "read", Err: syscall.ECONNRESET}))
+	assert.False(t, unsentRedisError(context.DeadlineExceeded))
+	assert.False(t, unsentRedisError(io.EOF))
+	assert.False(t, unsentRedisError(context.Canceled))
+	assert.False(t, unsentRedisError(rueidis.ErrClosing))
+	assert.False(t, unsentRedisError(errors.New("parse error")))
+}
+
+func TestRateLimiter_DecideRedis(t *testing.T) {
+	now := time.Now()
+
+	rl := &RateLimiter{mode: v1alpha1.RateLimitMode_REDIS, decisions: newDecisionCache(time.Second)}
+	rl.decisions.now = func() time.Time { return now }
+
+	unavailable := errors.New("redis unavailable")
+
+	// Allowed decisions are not cached, the requests fail when Redis fails
+	allowed, err := rl.decideRedis("key", func() (bool, error) { return true, nil })
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	_, err = rl.decideRedis("key", func() (bool, error) { return false, unavailable })
+	require.ErrorIs(t, err, unavailable)
+
+	// Rejections are served from the cache until they expire, also when
+	// Redis fails
+	allowed, err = rl.decideRedis("key", func() (bool, error) { return false, nil })
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	evaluated := false
+	allowed, err = rl.decideRedis("key", func() (bool, error) { evaluated = true; return true, nil })
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.False(t, evaluated)
+
+	allowed, err = rl.decideRedis("key", func() (bool, error) { return false, unavailable })
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	now = now.Add(time.Second)
+
+	allowed, err = rl.decideRedis("key", func() (bool, error) { return true, nil })
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	// Expired rejections are removed
+	_, _ = rl.decideRedis("other", func() (bool, error) { return false, nil })
+
+	now = now.Add(time.Second)
+	rl.decisions.cleanup()
+
+	_, ok := rl.decisions.rejections.Load("other")
+	assert.False(t, ok)
+}
+
+func TestRateLimiter_DecideRedis_FailOpen(t *testing.T) {
+	rl := &RateLimiter{mode: v1alpha1.RateLimitMode_REDIS, decisions: newDecisionCache(time.Second), failOpen: true}
+
+	unavailable := errors.New("redis unavailable")
+
+	allowed, err := rl.decideRedis("key", func() (bool, error) { return false, unavailable })
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	// Cached rejections are still rejected
+	allowed, err = rl.decideRedis("key", func() (bool, error) { return false, nil })
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	allowed, err = rl.decideRedis("key", func() (bool, error) { return false, unavailable })
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
+
+func TestRateLimiter_DecideRedis_Disabled(t *testing.T) {
+	rl := &RateLimiter{mode: v1alpha1.RateLimitMode_REDIS, decisions: newDecisionCache(0)}
+
+	allowed, err := rl.decideRedis("key", func() (bool, error) { return false, nil })
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	allowed, err = rl.decideRedis("key", func() (bool, error) { return true, nil })
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
@@ -0,0 +1,339 @@
+// Package transcript records the transcripts of chat completions, the prompts
+// and the responses, into audit sinks.
+package transcript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	listenersv1alpha1 "knoway.dev/api/listeners/v1alpha1"
+	"knoway.dev/pkg/accesslog"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/types/openai"
+)
+
+const (
+	defaultReplacement = "[REDACTED]"
+)
+
+// Redactor is a redaction hook, it returns the text with the sensitive parts
+// redacted.
+type Redactor func(text string) string
+
+var (
+	redactorsMutex sync.RWMutex
+	redactors      = map[string]Redactor{}
+)
+
+// RegisterRedactor registers the redaction hook by name, which is then
+// referenced by redactors of TranscriptAuditConfig. Hooks registered later
+// replace the ones of the same name.
+func RegisterRedactor(name string, redactor Redactor) {
+	redactorsMutex.Lock()
+	defer redactorsMutex.Unlock()
+
+	redactors[name] = redactor
+}
+
+func lookupRedactor(name string) (Redactor, bool) {
+	redactorsMutex.RLock()
+	defer redactorsMutex.RUnlock()
+
+	redactor, ok := redactors[name]
+
+	return redactor, ok
+}
+
+func regexpRedactor(redaction *v1alpha1.TranscriptAuditConfig_Redaction) (Redactor, error) {
+	pattern, err := regexp.Compile(redaction.GetPattern())
+	if err != nil {
+		return nil, fmt.Errorf("invalid redaction pattern %q: %w", redaction.GetPattern(), err)
+	}
+
+	replacement := lo.CoalesceOrEmpty(redaction.GetReplacement(), defaultReplacement)
+
+	return func(text string) string {
+		return pattern.ReplaceAllString(text, replacement)
+	}, nil
+}
+
+func NewWithConfig(cfg *anypb.Any, lifecycle bootkit.LifeCycle) (filters.RequestFilter, error) {
+	c, err := protoutils.FromAny(cfg, &v1alpha1.TranscriptAuditConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	samplingRate := lo.FromPtrOr(c.SamplingRate, 1)
+	if samplingRate < 0 || samplingRate > 1 {
+		return nil, errors.New("sampling_rate must be between 0 and 1")
+	}
+
+	for apiKeyID, rate := range c.GetApiKeySamplingRates() {
+		if rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("sampling rate of API key %s must be between 0 and 1", apiKeyID)
+		}
+	}
+
+	recorder := &Recorder{
+		samplingRate:        samplingRate,
+		apiKeySamplingRates: c.GetApiKeySamplingRates(),
+	}
+
+	for _, redaction := range c.GetRedactions() {
+		redactor, err := regexpRedactor(redaction)
+		if err != nil {
+			return nil, err
+		}
+
+		recorder.redactors = append(recorder.redactors, redactor)
+	}
+
+	for _, name := range c.GetRedactors() {
+		redactor, ok := lookupRedactor(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown redactor %q", name)
+		}
+
+		recorder.redactors = append(recorder.redactors, redactor)
+	}
+
+	recorder.logger, err = accesslog.NewLoggerWithConfig(&listenersv1alpha1.Log{
+		Enable: true,
+		Sinks:  c.GetSinks(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid transcript audit sinks: %w", err)
+	}
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStop: func(context.Context) error {
+			return recorder.logger.Close()
+		},
+	})
+
+	return recorder, nil
+}
+
+var _ filters.RequestFilter = (*Recorder)(nil)
+var _ filters.OnCompletionRequestFilter = (*Recorder)(nil)
+var _ filters.OnCompletionResponseFilter = (*Recorder)(nil)
+var _ filters.OnCompletionStreamResponseFilter = (*Recorder)(nil)
+
+// Recorder records the transcripts of the chat completions requests sampled.
+// The chunks of streams are teed as they are sent to the clients, and the
+// transcripts are written to the sinks once the requests are served and the
+// streams end.
+type Recorder struct {
+	filters.IsRequestFilter
+
+	logger              *accesslog.Logger
+	samplingRate        float64
+	apiKeySamplingRates map[string]float64
+	redactors           []Redactor
+
+	// transcripts holds the transcripts being recorded by the requests
+	transcripts sync.Map
+}
+
+type transcript struct {
+	mutex sync.Mutex
+
+	rMeta     *metadata.RequestMetadata
+	startedAt time.Time
+	prompt    any
+	response  object.LLMResponse
+	content   strings.Builder
+	chunks    int
+}
+
+func (f *Recorder) sampled(apiKeyID string) bool {
+	rate := f.samplingRate
+	if apiKeyRate, ok := f.apiKeySamplingRates[apiKeyID]; ok && apiKeyID != "" {
+		rate = apiKeyRate
+	}
+
+	return rate >= 1 || rand.Float64() < rate //nolint:gosec
+}
+
+func (f *Recorder) redact(text string) string {
+	for _, redactor := range f.redactors {
+		text = redactor(text)
+	}
+
+	return text
+}
+
+// redactValue redacts every string of the decoded JSON value.
+func (f *Recorder) redactValue(value any) any {
+	switch v := value.(type) {
+	case string:
+		return f.redact(v)
+	case map[string]any:
+		res := make(map[string]any, len(v))
+		for key, item := range v {
+			res[key] = f.redactValue(item)
+		}
+
+		return res
+	case []map[string]any:
+		return lo.Map(v, func(item map[string]any, _ int) any {
+			return f.redactValue(item)
+		})
+	case []any:
+		return lo.Map(v, func(item any, _ int) any {
+			return f.redactValue(item)
+		})
+	default:
+		return v
+	}
+}
+
+func (f *Recorder) OnCompletionRequest(ctx context.Context, request object.LLMRequest, _ *http.Request) filters.RequestFilterResult {
+	chatRequest, ok := request.(*openai.ChatCompletionsRequest)
+	if !ok || request.GetRawRequest() == nil {
+		return filters.NewOK()
+	}
+
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	if rMeta == nil || !f.sampled(rMeta.AuthInfo.GetApiKeyId()) {
+		return filters.NewOK()
+	}
+
+	t := &transcript{
+		rMeta:     rMeta,
+		startedAt: time.Now(),
+		prompt:    f.redactValue(chatRequest.GetMessages()),
+	}
+
+	if _, loaded := f.transcripts.LoadOrStore(request, t); loaded {
+		return filters.NewOK()
+	}
+
+	// Written once the request is served, streams are still being read by
+	// then, which are waited for
+	context.AfterFunc(request.GetRawRequest().Context(), func() {
+		f.finish(request, t)
+	})
+
+	return filters.NewOK()
+}
+
+func (f *Recorder) OnCompletionResponse(_ context.Context, request object.LLMRequest, response object.LLMResponse) filters.RequestFilterResult {
+	value, ok := f.transcripts.Load(request)
+	if !ok {
+		return filters.NewOK()
+	}
+
+	t, _ := value.(*transcript)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.response = response
+
+	return filters.NewOK()
+}
+
+func (f *Recorder) OnCompletionStreamResponse(_ context.Context, request object.LLMRequest, _ object.LLMStreamResponse, responseChunk object.LLMChunkResponse) filters.RequestFilterResult {
+	value, ok := f.transcripts.Load(request)
+	if !ok {
+		return filters.NewOK()
+	}
+
+	chunk, ok := responseChunk.(*openai.ChatCompletionStreamChunk)
+	if !ok || chunk.IsEmpty() || chunk.IsDone() {
+		return filters.NewOK()
+	}
+
+	t, _ := value.(*transcript)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.content.WriteString(chunk.GetDeltaContent())
+	t.chunks++
+
+	return filters.NewOK()
+}
+
+func (f *Recorder) finish(request object.LLMRequest, t *transcript) {
+	stream, ok := t.rMeta.LLMResponse.(object.LLMStreamResponse)
+	if ok && !lo.IsNil(stream) {
+		// Every chunk is teed once the callbacks of the last one return
+		<-stream.WaitUntilEOF()
+	}
+
+	f.transcripts.Delete(request)
+	f.logger.Log(context.Background(), f.entry(request, t))
+}
+
+func (f *Recorder) entry(request object.LLMRequest, t *transcript) accesslog.Entry {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	rMeta := t.rMeta
+
+	entry := accesslog.Entry{
+		{Key: "id", Value: uuid.NewString()},
+		{Key: "timestamp", Value: t.startedAt},
+		{Key: "route", Value: routeName(rMeta)},
+		{Key: "api_key_id", Value: rMeta.AuthInfo.GetApiKeyId()},
+		{Key: "user_id", Value: rMeta.AuthInfo.GetUserId()},
+		{Key: "request_model", Value: lo.CoalesceOrEmpty(rMeta.RequestModel, request.GetModel())},
+		{Key: "served_model", Value: rMeta.ServedModel},
+		{Key: "stream", Value: request.IsStream()},
+		{Key: "prompt", Value: t.prompt},
+	}
+
+	response := t.response
+	if lo.IsNil(response) {
+		response = rMeta.LLMResponse
+	}
+
+	var completion any
+
+	switch resp := response.(type) {
+	case *openai.ChatCompletionsResponse:
+		if message := resp.GetChoiceMessage(); message != nil {
+			completion = f.redactValue(message["content"])
+		}
+	case object.LLMStreamResponse:
+		completion = f.redact(t.content.String())
+		entry = append(entry, accesslog.Field{Key: "chunks", Value: t.chunks})
+	}
+
+	entry = append(entry,
+		accesslog.Field{Key: "completion", Value: completion},
+		accesslog.Field{Key: "duration", Value: time.Since(t.startedAt)},
+	)
+
+	if !lo.IsNil(response) && !lo.IsNil(response.GetError()) {
+		entry = append(entry, accesslog.Field{Key: "error", Value: f.redact(response.GetError().GetMessage())})
+	}
+
+	return entry
+}
+
+func routeName(rMeta *metadata.RequestMetadata) string {
+	if rMeta.MatchRoute == nil {
+		return ""
+	}
+
+	return rMeta.MatchRoute.GetRouteConfig().GetName()
+}
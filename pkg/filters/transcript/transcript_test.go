@@ -0,0 +1,245 @@
+package transcript
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	listenersv1alpha1 "knoway.dev/api/listeners/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+func newRecorder(t *testing.T, cfg *v1alpha1.TranscriptAuditConfig) (*Recorder, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "transcripts.jsonl")
+	cfg.Sinks = []*listenersv1alpha1.LogSink{
+		{Sink: &listenersv1alpha1.LogSink_File_{File: &listenersv1alpha1.LogSink_File{Path: path}}},
+	}
+
+	f, err := NewWithConfig(lo.Must(anypb.New(cfg)), bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+
+	recorder, ok := f.(*Recorder)
+	require.True(t, ok)
+
+	return recorder, path
+}
+
+func newRequest(t *testing.T, body string) (context.Context, context.CancelFunc, *metadata.RequestMetadata, object.LLMRequest) {
+	t.Helper()
+
+	// The transcripts are written once the context of the request is done
+	httpRequest := gatewaytest.NewHTTPRequest(t, gatewaytest.ChatCompletionsURL, body)
+	ctx, cancel := context.WithCancel(httpRequest.Context())
+
+	request, err := openai.NewChatCompletionRequest(httpRequest.WithContext(ctx))
+	require.NoError(t, err)
+
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	rMeta.LLMRequest = request
+	rMeta.AuthInfo = &service.APIKeyAuthResponse{ApiKeyId: "key-1", UserId: "user-1"}
+
+	return ctx, cancel, rMeta, request
+}
+
+func readTranscripts(t *testing.T, path string, n int) []map[string]any {
+	t.Helper()
+
+	var transcripts []map[string]any
+
+	require.Eventually(t, func() bool {
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			return false
+		}
+
+		transcripts = nil
+
+		for _, line := range strings.Split(strings.TrimSpace(string(bs)), "\n") {
+			if line == "" {
+				continue
+			}
+
+			var transcript map[string]any
+			require.NoError(t, json.Unmarshal([]byte(line), &transcript))
+
+			transcripts = append(transcripts, transcript)
+		}
+
+		return len(transcripts) >= n
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return transcripts
+}
+
+func TestNewWithConfig(t *testing.T) {
+	_, err := NewWithConfig(lo.Must(anypb.New(&v1alpha1.TranscriptAuditConfig{SamplingRate: lo.ToPtr(1.5)})), bootkit.NewEmptyLifeCycle())
+	require.EqualError(t, err, "sampling_rate must be between 0 and 1")
+
+	_, err = NewWithConfig(lo.Must(anypb.New(&v1alpha1.TranscriptAuditConfig{ApiKeySamplingRates: map[string]float64{"key-1": -1}})), bootkit.NewEmptyLifeCycle())
+	require.EqualError(t, err, "sampling rate of API key key-1 must be between 0 and 1")
+
+	_, err = NewWithConfig(lo.Must(anypb.New(&v1alpha1.TranscriptAuditConfig{Redactions: []*v1alpha1.TranscriptAuditConfig_Redaction{{Pattern: "("}}})), bootkit.NewEmptyLifeCycle())
+	require.ErrorContains(t, err, `invalid redaction pattern "("`)
+
+	_, err = NewWithConfig(lo.Must(anypb.New(&v1alpha1.TranscriptAuditConfig{Redactors: []string{"unknown"}})), bootkit.NewEmptyLifeCycle())
+	require.EqualError(t, err, `unknown redactor "unknown"`)
+
+	f, err := NewWithConfig(lo.Must(anypb.New(&v1alpha1.TranscriptAuditConfig{})), bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+	assert.InDelta(t, 1, f.(*Recorder).samplingRate, 0.001) //nolint:forcetypeassert
+}
+
+func TestRecorder_Sampled(t *testing.T) {
+	recorder := &Recorder{
+		samplingRate:        0,
+		apiKeySamplingRates: map[string]float64{"key-1": 1},
+	}
+
+	assert.False(t, recorder.sampled(""))
+	assert.False(t, recorder.sampled("key-2"))
+	assert.True(t, recorder.sampled("key-1"))
+
+	recorder = &Recorder{
+		samplingRate:        1,
+		apiKeySamplingRates: map[string]float64{"key-1": 0},
+	}
+
+	assert.True(t, recorder.sampled("key-2"))
+	assert.False(t, recorder.sampled("key-1"))
+}
+
+func TestRecorder_NonStream(t *testing.T) {
+	RegisterRedactor("upper-secret", func(text string) string {
+		return strings.ReplaceAll(text, "SECRET", "******")
+	})
+
+	recorder, path := newRecorder(t, &v1alpha1.TranscriptAuditConfig{
+		Redactions: []*v1alpha1.TranscriptAuditConfig_Redaction{
+			{Pattern: `[\w.]+@[\w.]+`},
+			{Pattern: `sk-(\w{4})\w+`, Replacement: "sk-$1***"},
+		},
+		Redactors: []string{"upper-secret"},
+	})
+
+	ctx, cancel, rMeta, request := newRequest(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "mail alice@example.com with sk-abcdefgh and SECRET"}]}`)
+
+	result := recorder.OnCompletionRequest(ctx, request, request.GetRawRequest())
+	require.True(t, result.IsSSucceeded())
+
+	response, err := openai.NewChatCompletionResponse(request, &http.Response{StatusCode: http.StatusOK}, bufio.NewReader(strings.NewReader(`{"model": "gpt-4o", "choices": [{"index": 0, "message": {"role": "assistant", "content": "Sent to bob@example.com"}}]}`)))
+	require.NoError(t, err)
+
+	rMeta.LLMResponse = response
+	recorder.OnCompletionResponse(ctx, request, response)
+
+	// The request is served
+	cancel()
+
+	transcripts := readTranscripts(t, path, 1)
+	require.Len(t, transcripts, 1)
+
+	transcript := transcripts[0]
+	assert.NotEmpty(t, transcript["id"])
+	assert.Equal(t, "key-1", transcript["api_key_id"])
+	assert.Equal(t, "user-1", transcript["user_id"])
+	assert.Equal(t, "gpt-4o", transcript["request_model"])
+	assert.Equal(t, false, transcript["stream"])
+	assert.Equal(t, []any{map[string]any{"role": "user", "content": "mail [REDACTED] with sk-abcd*** and ******"}}, transcript["prompt"])
+	assert.Equal(t, "Sent to [REDACTED]", transcript["completion"])
+	assert.NotContains(t, transcript, "chunks")
+
+	// Messages of the request are never modified
+	chatRequest, _ := request.(*openai.ChatCompletionsRequest)
+	assert.Equal(t, "mail alice@example.com with sk-abcdefgh and SECRET", chatRequest.GetMessages()[0]["content"])
+
+	_, ok := recorder.transcripts.Load(request)
+	assert.False(t, ok)
+}
+
+func TestRecorder_Stream(t *testing.T) {
+	recorder, path := newRecorder(t, &v1alpha1.TranscriptAuditConfig{
+		Redactions: []*v1alpha1.TranscriptAuditConfig_Redaction{{Pattern: `\d{3}-\d{4}`}},
+	})
+
+	ctx, cancel, rMeta, request := newRequest(t, `{"model": "gpt-4o", "stream": true, "messages": [{"role": "user", "content": "call me"}]}`)
+
+	result := recorder.OnCompletionRequest(ctx, request, request.GetRawRequest())
+	require.True(t, result.IsSSucceeded())
+
+	// The number is redacted even when split across chunks
+	body := strings.Join([]string{
+		`data: {"model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": "Call 555-"}}]}`,
+		`data: {"model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": "0100 now"}}]}`,
+		`data: [DONE]`,
+	}, "\n\n") + "\n\n"
+
+	stream, err := openai.NewChatCompletionStreamResponse(request, nil, bufio.NewReader(strings.NewReader(body)))
+	require.NoError(t, err)
+
+	rMeta.LLMResponse = stream
+	stream.OnChunk(func(ctx context.Context, stream object.LLMStreamResponse, chunk object.LLMChunkResponse) {
+		recorder.OnCompletionStreamResponse(ctx, request, stream, chunk)
+	})
+
+	for {
+		_, err := stream.NextChunk()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+	}
+
+	cancel()
+
+	transcripts := readTranscripts(t, path, 1)
+	require.Len(t, transcripts, 1)
+
+	transcript := transcripts[0]
+	assert.Equal(t, true, transcript["stream"])
+	assert.Equal(t, []any{map[string]any{"role": "user", "content": "call me"}}, transcript["prompt"])
+	assert.Equal(t, "Call [REDACTED] now", transcript["completion"])
+	assert.InDelta(t, 2, transcript["chunks"], 0.001)
+}
+
+func TestRecorder_NotSampled(t *testing.T) {
+	recorder, path := newRecorder(t, &v1alpha1.TranscriptAuditConfig{
+		ApiKeySamplingRates: map[string]float64{"key-1": 0},
+	})
+
+	ctx, cancel, _, request := newRequest(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "hello"}]}`)
+	defer cancel()
+
+	recorder.OnCompletionRequest(ctx, request, request.GetRawRequest())
+
+	_, ok := recorder.transcripts.Load(request)
+	assert.False(t, ok)
+
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	bs, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, bs)
+}
@@ -0,0 +1,141 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/types/stt"
+	"knoway.dev/pkg/usageexport"
+)
+
+func NewExportWithConfig(cfg *anypb.Any, lifecycle bootkit.LifeCycle) (filters.RequestFilter, error) {
+	c, err := protoutils.FromAny(cfg, &v1alpha1.UsageExportConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	exporter, err := usageexport.NewWithConfig(c)
+	if err != nil {
+		return nil, fmt.Errorf("invalid usage export config: %w", err)
+	}
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStop: func(ctx context.Context) error {
+			return exporter.Close(ctx)
+		},
+	})
+
+	return &ExportFilter{
+		config:   c,
+		exporter: exporter,
+	}, nil
+}
+
+var _ filters.RequestFilter = (*ExportFilter)(nil)
+var _ filters.OnResponsePostFilter = (*ExportFilter)(nil)
+
+// ExportFilter exports the usage of every request served successfully once
+// the response is complete, including the streams.
+type ExportFilter struct {
+	filters.IsRequestFilter
+
+	config   *v1alpha1.UsageExportConfig
+	exporter *usageexport.Exporter
+}
+
+func (f *ExportFilter) newUsageRecord(rMeta *metadata.RequestMetadata, response object.LLMResponse) *service.UsageRecord {
+	request := rMeta.LLMRequest
+
+	// The model of request will be overridden by Cluster, prefer the one
+	// recorded before routing.
+	requestedModel := lo.CoalesceOrEmpty(rMeta.RequestModel, request.GetModel())
+	servedModel := lo.CoalesceOrEmpty(rMeta.ServedModel, request.GetModel())
+
+	record := &service.UsageRecord{
+		Id:                uuid.NewString(),
+		Time:              timestamppb.New(lo.CoalesceOrEmpty(rMeta.RequestAt, time.Now())),
+		RequestType:       string(request.GetRequestType()),
+		ApiKeyId:          rMeta.AuthInfo.GetApiKeyId(),
+		UserId:            rMeta.AuthInfo.GetUserId(),
+		UserModelName:     requestedModel,
+		ServedModelName:   servedModel,
+		ServingTarget:     rMeta.ServingTarget,
+		BillingModelName:  billingModelName(f.config.GetBillingModel(), requestedModel, servedModel),
+		BillableUnits:     billableUnits(rMeta, request, response),
+		Seed:              rMeta.Seed.ToPointer(),
+		SystemFingerprint: rMeta.SystemFingerprint,
+	}
+
+	if !lo.IsNil(response) {
+		record.UpstreamModelName = response.GetModel()
+	}
+
+	if tokensUsage, ok := rMeta.LLMUpstreamTokensUsage.Get(); ok && !lo.IsNil(tokensUsage) {
+		record.InputTokens = tokensUsage.GetPromptTokens()
+		record.OutputTokens = tokensUsage.GetCompletionTokens()
+	}
+
+	if imagesUsage, ok := rMeta.LLMUpstreamImagesUsage.Get(); ok && !lo.IsNil(imagesUsage) {
+		outputImages := imagesUsage.GetOutputImages()
+		if len(outputImages) > 0 {
+			record.OutputImages = &service.UsageReportRequest_UsageImage{
+				Width:   outputImages[0].GetWidth(),
+				Height:  outputImages[0].GetHeight(),
+				Numbers: uint64(len(outputImages)),
+				Style:   outputImages[0].GetStyle(),
+				Quality: outputImages[0].GetQuality(),
+			}
+		}
+	}
+
+	if videosUsage, ok := rMeta.LLMUpstreamVideosUsage.Get(); ok && !lo.IsNil(videosUsage) {
+		outputVideos := videosUsage.GetOutputVideos()
+		if len(outputVideos) > 0 {
+			record.OutputVideos = &service.UsageReportRequest_UsageVideo{
+				Width:   outputVideos[0].GetWidth(),
+				Height:  outputVideos[0].GetHeight(),
+				Numbers: uint64(len(outputVideos)),
+				Seconds: outputVideos[0].GetSeconds(),
+			}
+		}
+	}
+
+	if transcription, ok := response.(*stt.TranscriptionResponse); ok && transcription != nil {
+		record.AudioSeconds = transcription.Duration
+	}
+
+	return record
+}
+
+func (f *ExportFilter) OnResponsePost(ctx context.Context, _ *http.Request, response any, err error) {
+	// Streams are served once the headers are written, failures of them
+	// are reported in the streams
+	if err != nil && !errors.Is(err, openai.SkipStreamResponse) {
+		return
+	}
+
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	if rMeta == nil || lo.IsNil(rMeta.LLMRequest) {
+		return
+	}
+
+	llmResponse, _ := response.(object.LLMResponse)
+
+	f.exporter.Export(f.newUsageRecord(rMeta, llmResponse))
+}
@@ -0,0 +1,142 @@
+package usage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/types/stt"
+)
+
+func newChatCompletionsMetadata(t *testing.T) *metadata.RequestMetadata {
+	t.Helper()
+
+	rMeta := &metadata.RequestMetadata{}
+	setChatCompletionsMetadata(t, rMeta)
+
+	return rMeta
+}
+
+func setChatCompletionsMetadata(t *testing.T, rMeta *metadata.RequestMetadata) {
+	t.Helper()
+
+	httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com/v1/chat/completions", bytes.NewBufferString(`{"model": "auto", "messages": [{"role": "user", "content": "hello"}]}`))
+	require.NoError(t, err)
+
+	request, err := openai.NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	rMeta.LLMRequest = request
+	rMeta.RequestModel = "auto"
+	rMeta.RequestAt = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rMeta.ServedModel = "gpt-4o"
+	rMeta.ServingTarget = "default/gpt-4o"
+	rMeta.AuthInfo = &service.APIKeyAuthResponse{ApiKeyId: "key-1", UserId: "user-1"}
+	rMeta.LLMUpstreamTokensUsage = mo.Some[object.LLMTokensUsage](&openai.ChatCompletionsUsage{
+		PromptTokens:     10,
+		CompletionTokens: 20,
+		TotalTokens:      30,
+	})
+}
+
+func TestExportFilter_NewUsageRecord(t *testing.T) {
+	f := &ExportFilter{config: &v1alpha1.UsageExportConfig{BillingModel: v1alpha1.UsageStatsConfig_BILLING_MODEL_REQUESTED}}
+
+	record := f.newUsageRecord(newChatCompletionsMetadata(t), nil)
+	assert.NotEmpty(t, record.GetId())
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), record.GetTime().AsTime())
+	assert.Equal(t, "chat_completions", record.GetRequestType())
+	assert.Equal(t, "key-1", record.GetApiKeyId())
+	assert.Equal(t, "user-1", record.GetUserId())
+	assert.Equal(t, "auto", record.GetUserModelName())
+	assert.Equal(t, "gpt-4o", record.GetServedModelName())
+	assert.Equal(t, "default/gpt-4o", record.GetServingTarget())
+	assert.Equal(t, "auto", record.GetBillingModelName())
+	assert.Equal(t, uint64(10), record.GetInputTokens())
+	assert.Equal(t, uint64(20), record.GetOutputTokens())
+	assert.Nil(t, record.Seed)
+	assert.Empty(t, record.GetSystemFingerprint())
+
+	// Seed and system fingerprint for reproducibility audits
+	rMeta := newChatCompletionsMetadata(t)
+	rMeta.Seed = mo.Some[int64](42)
+	rMeta.SystemFingerprint = "fp_44709d6fcb"
+
+	record = f.newUsageRecord(rMeta, nil)
+	assert.Equal(t, int64(42), record.GetSeed())
+	assert.Equal(t, "fp_44709d6fcb", record.GetSystemFingerprint())
+
+	// Every record has its own id for deduplication
+	assert.NotEqual(t, record.GetId(), f.newUsageRecord(newChatCompletionsMetadata(t), nil).GetId())
+
+	// Audio seconds of transcriptions
+	rMeta = newChatCompletionsMetadata(t)
+	rMeta.LLMUpstreamTokensUsage = mo.None[object.LLMTokensUsage]()
+
+	response := stt.NewTranscriptionResponseFromBytes(http.StatusOK, "application/json", "whisper-1", []byte(`{"text":"hello","duration":3.5}`))
+
+	record = f.newUsageRecord(rMeta, response)
+	assert.Equal(t, "whisper-1", record.GetUpstreamModelName())
+	assert.InDelta(t, 3.5, record.GetAudioSeconds(), 0.001)
+	assert.Zero(t, record.GetInputTokens())
+}
+
+func TestExportFilter_OnResponsePost(t *testing.T) {
+	received := make(chan []map[string]any, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+
+		var records []map[string]any
+		_ = json.Unmarshal(body, &records)
+		received <- records
+	}))
+	defer server.Close()
+
+	cfg, err := anypb.New(&v1alpha1.UsageExportConfig{
+		Destination: &v1alpha1.UsageExportConfig_Http{Http: &v1alpha1.UsageExportConfig_HTTP{Url: server.URL}},
+	})
+	require.NoError(t, err)
+
+	lifecycle := bootkit.NewEmptyLifeCycle()
+
+	f, err := NewExportWithConfig(cfg, lifecycle)
+	require.NoError(t, err)
+
+	exportFilter, ok := f.(*ExportFilter)
+	require.True(t, ok)
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com/v1/chat/completions", nil)
+	require.NoError(t, err)
+
+	ctx := metadata.InitMetadataContext(request)
+	setChatCompletionsMetadata(t, metadata.RequestMetadataFromCtx(ctx))
+
+	// Failed requests are not exported
+	exportFilter.OnResponsePost(ctx, request, nil, errors.New("upstream unavailable"))
+	exportFilter.OnResponsePost(ctx, request, nil, openai.SkipStreamResponse)
+
+	require.NoError(t, exportFilter.exporter.Close(context.Background()))
+
+	records := <-received
+	require.Len(t, records, 1)
+	assert.Equal(t, "gpt-4o", records[0]["served_model_name"])
+	assert.Equal(t, "10", records[0]["input_tokens"])
+}
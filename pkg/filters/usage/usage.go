@@ -20,6 +20,7 @@ import (
 	"knoway.dev/pkg/bootkit"
 	"knoway.dev/pkg/filters"
 	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/metering"
 	"knoway.dev/pkg/object"
 	"knoway.dev/pkg/protoutils"
 )
@@ -67,6 +68,7 @@ var _ filters.RequestFilter = (*UsageFilter)(nil)
 var _ filters.OnCompletionResponseFilter = (*UsageFilter)(nil)
 var _ filters.OnCompletionStreamResponseFilter = (*UsageFilter)(nil)
 var _ filters.OnImageGenerationsResponseFilter = (*UsageFilter)(nil)
+var _ filters.OnRealtimeResponseFilter = (*UsageFilter)(nil)
 
 type UsageFilter struct {
 	filters.IsRequestFilter
@@ -76,19 +78,84 @@ type UsageFilter struct {
 	usageClient service.UsageStatsServiceClient
 }
 
+// billingModelName returns the model name that pricing applies to, based on
+// the configured billing model.
+func billingModelName(billingModel v1alpha1.UsageStatsConfig_BillingModel, requestedModel, servedModel string) string {
+	if billingModel == v1alpha1.UsageStatsConfig_BILLING_MODEL_REQUESTED {
+		return lo.CoalesceOrEmpty(requestedModel, servedModel)
+	}
+
+	return lo.CoalesceOrEmpty(servedModel, requestedModel)
+}
+
+// billableUnits evaluates the metering expressions of the cluster that served
+// the request.
+func billableUnits(rMeta *metadata.RequestMetadata, request object.LLMRequest, response object.LLMResponse) map[string]float64 {
+	cluster, ok := rMeta.SelectedCluster.Get()
+	if !ok || lo.IsNil(cluster) {
+		return nil
+	}
+
+	units, err := metering.Evaluate(cluster.GetClusterConfig().GetMeteringPolicy(), metering.Input{
+		Request:   request,
+		Response:  response,
+		RequestAt: rMeta.RequestAt,
+	})
+	if err != nil {
+		slog.Warn("failed to evaluate metering expressions", slog.String("model", request.GetModel()), slog.Any("error", err))
+		return nil
+	}
+
+	return units
+}
+
+func (f *UsageFilter) newUsageReportRequest(rMeta *metadata.RequestMetadata, request object.LLMRequest, response object.LLMResponse, usage *service.UsageReportRequest_Usage) *service.UsageReportRequest {
+	usage.BillableUnits = billableUnits(rMeta, request, response)
+
+	// The model of request will be overridden by Cluster, prefer the one
+	// recorded before routing.
+	requestedModel := lo.CoalesceOrEmpty(rMeta.RequestModel, request.GetModel())
+	servedModel := lo.CoalesceOrEmpty(rMeta.ServedModel, request.GetModel())
+
+	return &service.UsageReportRequest{
+		ApiKeyId:          rMeta.AuthInfo.GetApiKeyId(),
+		UserModelName:     requestedModel,
+		UpstreamModelName: response.GetModel(),
+		ServedModelName:   servedModel,
+		ServingTarget:     rMeta.ServingTarget,
+		BillingModelName:  billingModelName(f.config.GetBillingModel(), requestedModel, servedModel),
+		Usage:             usage,
+		Mode:              service.UsageReportRequest_MODE_PER_REQUEST,
+	}
+}
+
+// hasMeteringExpressions reports whether the cluster that served the request
+// computes billable units by itself, requests are reported even if upstream
+// reported no usage in this case.
+func hasMeteringExpressions(rMeta *metadata.RequestMetadata) bool {
+	cluster, ok := rMeta.SelectedCluster.Get()
+	if !ok || lo.IsNil(cluster) {
+		return false
+	}
+
+	return len(cluster.GetClusterConfig().GetMeteringPolicy().GetExpressions()) > 0
+}
+
 func (f *UsageFilter) usageReport(ctx context.Context, request object.LLMRequest, response object.LLMResponse) {
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+
 	usage := response.GetUsage()
-	if lo.IsNil(usage) {
-		slog.Warn("no usage in response", "model", request.GetModel())
+	if lo.IsNil(usage) && request.GetRequestType() == object.RequestTypeVideoGenerations {
+		// Only the jobs submitted are metered, polling them reports no usage
 		return
 	}
 
-	var apiKeyID string
+	if lo.IsNil(usage) && request.GetRequestType() != object.RequestTypeModerations && (rMeta == nil || !hasMeteringExpressions(rMeta)) {
+		slog.Warn("no usage in response", "model", request.GetModel())
+		return
+	}
 
-	rMeta := metadata.RequestMetadataFromCtx(ctx)
-	if rMeta != nil && rMeta.AuthInfo != nil {
-		apiKeyID = rMeta.AuthInfo.GetApiKeyId()
-	} else {
+	if rMeta == nil || rMeta.AuthInfo == nil {
 		slog.Warn("no auth info in context")
 		return
 	}
@@ -99,23 +166,20 @@ func (f *UsageFilter) usageReport(ctx context.Context, request object.LLMRequest
 	switch request.GetRequestType() {
 	case
 		object.RequestTypeChatCompletions,
-		object.RequestTypeCompletions:
+		object.RequestTypeCompletions,
+		object.RequestTypeEmbeddings,
+		object.RequestTypeRerank,
+		object.RequestTypeRealtime:
 		tokensUsage, ok := object.AsLLMTokensUsage(usage)
 		if !ok {
 			slog.Warn("failed to cast usage to LLMUsageTokens")
 			break
 		}
 
-		_, err := f.usageClient.UsageReport(ctx, &service.UsageReportRequest{
-			ApiKeyId:          apiKeyID,
-			UserModelName:     request.GetModel(),
-			UpstreamModelName: response.GetModel(),
-			Usage: &service.UsageReportRequest_Usage{
-				InputTokens:  tokensUsage.GetPromptTokens(),
-				OutputTokens: tokensUsage.GetCompletionTokens(),
-			},
-			Mode: service.UsageReportRequest_MODE_PER_REQUEST,
-		})
+		_, err := f.usageClient.UsageReport(ctx, f.newUsageReportRequest(rMeta, request, response, &service.UsageReportRequest_Usage{
+			InputTokens:  tokensUsage.GetPromptTokens(),
+			OutputTokens: tokensUsage.GetCompletionTokens(),
+		}))
 		if err != nil {
 			slog.Warn("failed to report usage", slog.Any("error", err))
 			return
@@ -127,7 +191,9 @@ func (f *UsageFilter) usageReport(ctx context.Context, request object.LLMRequest
 			slog.Uint64("output_tokens", tokensUsage.GetCompletionTokens()),
 		)
 	case
-		object.RequestTypeImageGenerations:
+		object.RequestTypeImageGenerations,
+		object.RequestTypeImageEdits,
+		object.RequestTypeImageVariations:
 		imagesUsage, ok := object.AsLLMImagesUsage(usage)
 		if !ok {
 			slog.Warn("failed to cast usage to LLMUsageImage")
@@ -147,13 +213,7 @@ func (f *UsageFilter) usageReport(ctx context.Context, request object.LLMRequest
 			Quality: outputImages[0].GetQuality(),
 		}
 
-		_, err := f.usageClient.UsageReport(ctx, &service.UsageReportRequest{
-			ApiKeyId:          apiKeyID,
-			UserModelName:     request.GetModel(),
-			UpstreamModelName: response.GetModel(),
-			Usage:             &service.UsageReportRequest_Usage{OutputImages: usageImage},
-			Mode:              service.UsageReportRequest_MODE_PER_REQUEST,
-		})
+		_, err := f.usageClient.UsageReport(ctx, f.newUsageReportRequest(rMeta, request, response, &service.UsageReportRequest_Usage{OutputImages: usageImage}))
 		if err != nil {
 			slog.Warn("failed to report usage", slog.Any("error", err))
 			return
@@ -165,8 +225,82 @@ func (f *UsageFilter) usageReport(ctx context.Context, request object.LLMRequest
 			slog.Uint64("width", usageImage.GetWidth()),
 			slog.Uint64("height", usageImage.GetHeight()),
 		)
-	case object.RequestTypeTextToSpeech:
-		// no usage tracking for text-to-speech yet
+	case
+		object.RequestTypeVideoGenerations:
+		videosUsage, ok := object.AsLLMVideosUsage(usage)
+		if !ok {
+			slog.Warn("failed to cast usage to LLMVideosUsage")
+			break
+		}
+
+		outputVideos := videosUsage.GetOutputVideos()
+		if len(outputVideos) == 0 {
+			break
+		}
+
+		usageVideo := &service.UsageReportRequest_UsageVideo{
+			Width:   outputVideos[0].GetWidth(),
+			Height:  outputVideos[0].GetHeight(),
+			Numbers: uint64(len(outputVideos)),
+			Seconds: outputVideos[0].GetSeconds(),
+		}
+
+		_, err := f.usageClient.UsageReport(ctx, f.newUsageReportRequest(rMeta, request, response, &service.UsageReportRequest_Usage{OutputVideos: usageVideo}))
+		if err != nil {
+			slog.Warn("failed to report usage", slog.Any("error", err))
+			return
+		}
+
+		slog.Info("report usage",
+			slog.String("model", request.GetModel()),
+			slog.Uint64("output_videos", usageVideo.GetNumbers()),
+			slog.Float64("seconds", usageVideo.GetSeconds()),
+			slog.Uint64("width", usageVideo.GetWidth()),
+			slog.Uint64("height", usageVideo.GetHeight()),
+		)
+	case object.RequestTypeModerations:
+		// Moderation upstreams may not report token usage at all (e.g. OpenAI),
+		// still report the request so that calls can be accounted.
+		reportUsage := &service.UsageReportRequest_Usage{}
+		if tokensUsage, ok := object.AsLLMTokensUsage(usage); ok {
+			reportUsage.InputTokens = tokensUsage.GetPromptTokens()
+			reportUsage.OutputTokens = tokensUsage.GetCompletionTokens()
+		}
+
+		_, err := f.usageClient.UsageReport(ctx, f.newUsageReportRequest(rMeta, request, response, reportUsage))
+		if err != nil {
+			slog.Warn("failed to report usage", slog.Any("error", err))
+			return
+		}
+
+		slog.Info("report usage",
+			slog.String("model", request.GetModel()),
+			slog.Uint64("input_tokens", reportUsage.GetInputTokens()),
+		)
+	case
+		object.RequestTypeTextToSpeech,
+		object.RequestTypeSpeechToText:
+		// No usage reported by upstreams, only the billable units computed by
+		// the metering expressions are reported.
+		if !hasMeteringExpressions(rMeta) {
+			break
+		}
+
+		reportRequest := f.newUsageReportRequest(rMeta, request, response, &service.UsageReportRequest_Usage{})
+		if len(reportRequest.GetUsage().GetBillableUnits()) == 0 {
+			break
+		}
+
+		_, err := f.usageClient.UsageReport(ctx, reportRequest)
+		if err != nil {
+			slog.Warn("failed to report usage", slog.Any("error", err))
+			return
+		}
+
+		slog.Info("report usage",
+			slog.String("model", request.GetModel()),
+			slog.Any("billable_units", reportRequest.GetUsage().GetBillableUnits()),
+		)
 	}
 }
 
@@ -191,3 +325,11 @@ func (f *UsageFilter) OnImageGenerationsResponse(ctx context.Context, request ob
 
 	return filters.NewOK()
 }
+
+// OnRealtimeResponse reports the usage of all the responses of the sessions
+// of the Realtime API at once, when the sessions end.
+func (f *UsageFilter) OnRealtimeResponse(ctx context.Context, request object.LLMRequest, response object.LLMResponse) filters.RequestFilterResult {
+	f.usageReport(ctx, request, response)
+
+	return filters.NewOK()
+}
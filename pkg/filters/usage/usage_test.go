@@ -0,0 +1,63 @@
+package usage
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clustersv1alpha1 "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/clusters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/types/openai"
+)
+
+func TestBillingModelName(t *testing.T) {
+	assert.Equal(t, "gpt-4o", billingModelName(v1alpha1.UsageStatsConfig_BILLING_MODEL_UNSPECIFIED, "auto", "gpt-4o"))
+	assert.Equal(t, "gpt-4o", billingModelName(v1alpha1.UsageStatsConfig_BILLING_MODEL_SERVED, "auto", "gpt-4o"))
+	assert.Equal(t, "auto", billingModelName(v1alpha1.UsageStatsConfig_BILLING_MODEL_REQUESTED, "auto", "gpt-4o"))
+
+	// Fallback to the other one when missing
+	assert.Equal(t, "auto", billingModelName(v1alpha1.UsageStatsConfig_BILLING_MODEL_SERVED, "auto", ""))
+	assert.Equal(t, "gpt-4o", billingModelName(v1alpha1.UsageStatsConfig_BILLING_MODEL_REQUESTED, "", "gpt-4o"))
+}
+
+type fakeCluster struct {
+	clusters.Cluster
+
+	config *clustersv1alpha1.Cluster
+}
+
+func (c *fakeCluster) GetClusterConfig() *clustersv1alpha1.Cluster {
+	return c.config
+}
+
+func TestBillableUnits(t *testing.T) {
+	httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com/v1/audio/speech", bytes.NewBufferString(`{"model": "tts-1", "input": "Hello, world!", "voice": "alloy"}`))
+	require.NoError(t, err)
+
+	request, err := openai.NewTextToSpeechRequest(httpRequest)
+	require.NoError(t, err)
+
+	rMeta := &metadata.RequestMetadata{}
+	assert.False(t, hasMeteringExpressions(rMeta))
+	assert.Nil(t, billableUnits(rMeta, request, nil))
+
+	rMeta.SelectedCluster = mo.Some[clusters.Cluster](&fakeCluster{
+		config: &clustersv1alpha1.Cluster{
+			Name: "tts-1",
+			MeteringPolicy: &clustersv1alpha1.ClusterMeteringPolicy{
+				Expressions: []*clustersv1alpha1.ClusterMeteringPolicy_Expression{
+					{Unit: "characters", Expression: "size(request.body.input)"},
+				},
+			},
+		},
+	})
+	assert.True(t, hasMeteringExpressions(rMeta))
+	assert.Equal(t, map[string]float64{"characters": 13}, billableUnits(rMeta, request, nil))
+}
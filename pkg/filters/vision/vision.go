@@ -0,0 +1,183 @@
+// Package vision limits the images in the messages of chat completions
+// requests, downscales the large ones, and estimates the input tokens of
+// them.
+package vision
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif" // Register the decoder of GIF images
+	"image/jpeg"
+	"image/png"
+	"log/slog"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // Register the decoder of WebP images
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/protoutils"
+	"knoway.dev/pkg/types/openai"
+)
+
+const jpegQuality = 90
+
+// Vision enforces the limits of the images of the route, the images embedded
+// as data URLs are downscaled before their sizes are checked, so that large
+// photos are accepted as long as they fit after downscaled.
+type Vision struct {
+	filters.IsRequestFilter
+
+	maxImages         int
+	maxImageSizeBytes uint64
+	maxDimension      int
+	denyRemoteURLs    bool
+}
+
+var _ filters.RequestFilter = (*Vision)(nil)
+var _ filters.OnCompletionRequestFilter = (*Vision)(nil)
+
+func NewWithConfig(cfg *anypb.Any, _ bootkit.LifeCycle) (filters.RequestFilter, error) {
+	c, err := protoutils.FromAny(cfg, &v1alpha1.VisionConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	return &Vision{
+		maxImages:         int(c.GetMaxImages()),
+		maxImageSizeBytes: c.GetMaxImageSizeBytes(),
+		maxDimension:      int(c.GetMaxDimension()),
+		denyRemoteURLs:    c.GetDenyRemoteUrls(),
+	}, nil
+}
+
+func (v *Vision) OnCompletionRequest(ctx context.Context, request object.LLMRequest, _ *http.Request) filters.RequestFilterResult {
+	// Legacy completions carry prompts instead of messages
+	chatRequest, ok := request.(*openai.ChatCompletionsRequest)
+	if !ok {
+		return filters.NewOK()
+	}
+
+	parts := chatRequest.ImageParts()
+	if len(parts) == 0 {
+		return filters.NewOK()
+	}
+
+	if v.maxImages > 0 && len(parts) > v.maxImages {
+		return filters.NewFailed(openai.NewErrorInvalidValue("messages", fmt.Sprintf("at most %d images are allowed, got %d.", v.maxImages, len(parts))))
+	}
+
+	var tokens uint64
+
+	for _, part := range parts {
+		param := fmt.Sprintf("messages[%d].content[%d].image_url.url", part.Message, part.Part)
+
+		if !part.IsDataURL() {
+			if v.denyRemoteURLs {
+				return filters.NewFailed(openai.NewErrorInvalidValue(param, "only images embedded as base64 data URLs are allowed."))
+			}
+
+			// The dimensions of remote images are unknown without fetching
+			// them, only the ones of low detail are counted
+			if part.Detail == openai.ImageDetailLow {
+				tokens += openai.EstimateImageTokens(0, 0, part.Detail)
+			}
+
+			continue
+		}
+
+		_, data, err := part.DecodeDataURL()
+		if err != nil {
+			return filters.NewFailed(openai.NewErrorInvalidValue(param, err.Error()+"."))
+		}
+
+		config, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return filters.NewFailed(openai.NewErrorInvalidValue(param, "unsupported or corrupted image."))
+		}
+
+		if v.maxDimension > 0 && max(config.Width, config.Height) > v.maxDimension {
+			var mediaType string
+
+			mediaType, data, config, err = v.downscale(data)
+			if err != nil {
+				return filters.NewFailed(openai.NewErrorInvalidValue(param, "unsupported or corrupted image."))
+			}
+
+			err = chatRequest.SetImageURL(part, openai.EncodeImageDataURL(mediaType, data))
+			if err != nil {
+				return filters.NewFailed(openai.NewErrorInternalError().WithCause(err))
+			}
+		}
+
+		if v.maxImageSizeBytes > 0 && uint64(len(data)) > v.maxImageSizeBytes {
+			return filters.NewFailed(openai.NewErrorInvalidValue(param, fmt.Sprintf("image of %d bytes exceeds the max size of %d bytes.", len(data), v.maxImageSizeBytes)))
+		}
+
+		tokens += openai.EstimateImageTokens(config.Width, config.Height, part.Detail)
+	}
+
+	if rMeta := metadata.RequestMetadataFromCtx(ctx); rMeta != nil {
+		rMeta.ImageInputs = len(parts)
+		rMeta.ImageInputTokens = tokens
+	}
+
+	slog.DebugContext(ctx, "images of request checked",
+		slog.String("filter", "vision"),
+		slog.Int("images", len(parts)),
+		slog.Uint64("estimated_tokens", tokens),
+	)
+
+	return filters.NewOK()
+}
+
+// downscale scales the image down to fit in the max dimension, JPEG images
+// are encoded as JPEG again, the others as PNG.
+func (v *Vision) downscale(data []byte) (string, []byte, image.Config, error) {
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", nil, image.Config{}, err
+	}
+
+	bounds := src.Bounds()
+	width, height := ScaleToFit(bounds.Dx(), bounds.Dy(), v.maxDimension)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+
+	var (
+		buffer    bytes.Buffer
+		mediaType string
+	)
+
+	if format == "jpeg" {
+		mediaType = "image/jpeg"
+		err = jpeg.Encode(&buffer, dst, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		mediaType = "image/png"
+		err = png.Encode(&buffer, dst)
+	}
+	if err != nil {
+		return "", nil, image.Config{}, err
+	}
+
+	return mediaType, buffer.Bytes(), image.Config{Width: width, Height: height}, nil
+}
+
+// ScaleToFit returns the dimensions of the image scaled down to have the
+// longer side of maxDimension, keeping the aspect ratio.
+func ScaleToFit(width int, height int, maxDimension int) (int, int) {
+	longer := max(width, height)
+	if longer <= maxDimension {
+		return width, height
+	}
+
+	return max(width*maxDimension/longer, 1), max(height*maxDimension/longer, 1)
+}
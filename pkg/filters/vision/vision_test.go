@@ -0,0 +1,115 @@
+package vision
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/types/openai"
+)
+
+func pngDataURL(t *testing.T, width int, height int) string {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	err := png.Encode(&buffer, image.NewRGBA(image.Rect(0, 0, width, height)))
+	require.NoError(t, err)
+
+	return openai.EncodeImageDataURL("image/png", buffer.Bytes())
+}
+
+func imageMessage(urls ...string) map[string]any {
+	parts := []any{map[string]any{"type": "text", "text": "What's in the images?"}}
+	for _, url := range urls {
+		parts = append(parts, map[string]any{"type": "image_url", "image_url": map[string]any{"url": url}})
+	}
+
+	return map[string]any{"role": "user", "content": parts}
+}
+
+func newTestVision(t *testing.T, cfg *v1alpha1.VisionConfig) *Vision {
+	t.Helper()
+
+	f, err := NewWithConfig(lo.Must(anypb.New(cfg)), bootkit.NewEmptyLifeCycle())
+	require.NoError(t, err)
+
+	v, ok := f.(*Vision)
+	require.True(t, ok)
+
+	return v
+}
+
+func TestVision_Limits(t *testing.T) {
+	v := newTestVision(t, &v1alpha1.VisionConfig{MaxImages: 2, MaxImageSizeBytes: 1024, DenyRemoteUrls: true})
+
+	ctx, request := gatewaytest.NewChatCompletionRequest(t, gatewaytest.ChatCompletionBody("gpt-4o", false, imageMessage(pngDataURL(t, 8, 8), pngDataURL(t, 8, 8), pngDataURL(t, 8, 8))))
+	result := v.OnCompletionRequest(ctx, request, request.GetRawRequest())
+	require.True(t, result.IsFailed())
+	assert.Contains(t, result.Error.Error(), "at most 2 images are allowed, got 3.")
+
+	ctx, request = gatewaytest.NewChatCompletionRequest(t, gatewaytest.ChatCompletionBody("gpt-4o", false, imageMessage("https://example.com/cat.png")))
+	result = v.OnCompletionRequest(ctx, request, request.GetRawRequest())
+	require.True(t, result.IsFailed())
+	assert.Contains(t, result.Error.Error(), "messages[0].content[1].image_url.url")
+
+	ctx, request = gatewaytest.NewChatCompletionRequest(t, gatewaytest.ChatCompletionBody("gpt-4o", false, imageMessage(pngDataURL(t, 2048, 2048))))
+	result = v.OnCompletionRequest(ctx, request, request.GetRawRequest())
+	require.True(t, result.IsFailed())
+	assert.Contains(t, result.Error.Error(), "exceeds the max size of 1024 bytes")
+
+	ctx, request = gatewaytest.NewChatCompletionRequest(t, gatewaytest.ChatCompletionBody("gpt-4o", false, imageMessage(pngDataURL(t, 8, 8))))
+	result = v.OnCompletionRequest(ctx, request, request.GetRawRequest())
+	require.False(t, result.IsFailed())
+}
+
+func TestVision_Downscale(t *testing.T) {
+	v := newTestVision(t, &v1alpha1.VisionConfig{MaxDimension: 1024})
+
+	ctx, request := gatewaytest.NewChatCompletionRequest(t, gatewaytest.ChatCompletionBody("gpt-4o", false, imageMessage(pngDataURL(t, 4096, 2048), "https://example.com/cat.png")))
+	result := v.OnCompletionRequest(ctx, request, request.GetRawRequest())
+	require.False(t, result.IsFailed())
+
+	parts := request.ImageParts()
+	require.Len(t, parts, 2)
+
+	mediaType, data, err := parts[0].DecodeDataURL()
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", mediaType)
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 1024, config.Width)
+	assert.Equal(t, 512, config.Height)
+
+	assert.Equal(t, "https://example.com/cat.png", parts[1].URL)
+
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	assert.Equal(t, 2, rMeta.ImageInputs)
+	// 1024x512 is 2 tiles, the remote image of auto detail is not counted
+	assert.Equal(t, uint64(85+170*2), rMeta.ImageInputTokens)
+}
+
+func TestScaleToFit(t *testing.T) {
+	width, height := ScaleToFit(4000, 3000, 1000)
+	assert.Equal(t, 1000, width)
+	assert.Equal(t, 750, height)
+
+	width, height = ScaleToFit(800, 600, 1000)
+	assert.Equal(t, 800, width)
+	assert.Equal(t, 600, height)
+
+	width, height = ScaleToFit(10000, 1, 1000)
+	assert.Equal(t, 1000, width)
+	assert.Equal(t, 1, height)
+}
@@ -10,13 +10,17 @@ import (
 
 	"github.com/samber/lo"
 	"github.com/samber/mo"
+	"go.opentelemetry.io/otel/trace"
 
+	"knoway.dev/pkg/featureflag"
 	"knoway.dev/pkg/filters"
 	"knoway.dev/pkg/metadata"
 	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/observation"
+	"knoway.dev/pkg/priority"
+	"knoway.dev/pkg/route/loadbalance"
 	routemanager "knoway.dev/pkg/route/manager"
 	"knoway.dev/pkg/types/openai"
-	"knoway.dev/pkg/utils"
 )
 
 func CommonListenerHandler(
@@ -27,18 +31,43 @@ func CommonListenerHandler(
 	return func(writer http.ResponseWriter, request *http.Request) (any, error) {
 		var err error
 
+		// Canceled with ErrStreamReaped when the stream is reaped
+		ctx, cancel := context.WithCancelCause(request.Context())
+		defer cancel(nil)
+
+		request = request.WithContext(ctx)
+
+		rMeta := metadata.RequestMetadataFromCtx(request.Context())
+
+		rMeta.Priority, err = priority.FromHTTPRequest(request)
+		if err != nil {
+			return nil, openai.NewErrorInvalidHeader(priority.HeaderName, err)
+		}
+
 		for _, f := range listenerFilters.OnRequestPreFilters() {
-			fResult := f.OnRequestPre(request.Context(), request)
+			fResult := filters.Observe(request.Context(), f, filters.StageOnRequestPre, func() filters.RequestFilterResult {
+				return f.OnRequestPre(request.Context(), request)
+			})
 			if fResult.IsFailed() {
 				return nil, fResult.Error
 			}
 		}
 
+		rMeta.FeatureFlags = featureflag.Global().Evaluate(request.Context(), featureflag.Subject{
+			UserID:   rMeta.AuthInfo.GetUserId(),
+			APIKeyID: rMeta.AuthInfo.GetApiKeyId(),
+			TenantID: rMeta.AuthInfo.GetTenantId(),
+		})
+
 		var resp object.LLMResponse
 
 		defer func() {
 			for _, f := range reversedFilters.OnResponsePostFilters() {
-				f.OnResponsePost(request.Context(), request, resp, err)
+				filters.Observe(request.Context(), f, filters.StageOnResponsePost, func() filters.RequestFilterResult {
+					f.OnResponsePost(request.Context(), request, resp, err)
+
+					return filters.NewOK()
+				})
 			}
 		}()
 
@@ -47,17 +76,80 @@ func CommonListenerHandler(
 			return nil, err
 		}
 
+		rMeta.LLMRequest = llmRequest
+
+		// Normalize before listener filters so that they see the same model
+		// name as routes do
+		err = routemanager.NormalizeRequestModel(request.Context(), llmRequest)
+		if err != nil {
+			return nil, openai.NewErrorInternalError().WithCause(err)
+		}
+
+		err = routemanager.ScopeRequestModel(request.Context(), llmRequest)
+		if err != nil {
+			return nil, object.LLMErrorOrInternalError(err)
+		}
+
 		switch llmRequest.GetRequestType() {
 		case object.RequestTypeChatCompletions, object.RequestTypeCompletions:
 			for _, f := range listenerFilters.OnCompletionRequestFilters() {
-				fResult := f.OnCompletionRequest(request.Context(), llmRequest, request)
+				fResult := filters.Observe(request.Context(), f, filters.StageOnCompletionRequest, func() filters.RequestFilterResult {
+					return f.OnCompletionRequest(request.Context(), llmRequest, request)
+				})
 				if fResult.IsFailed() {
 					return nil, fResult.Error
 				}
 			}
-		case object.RequestTypeImageGenerations:
+		case object.RequestTypeImageGenerations, object.RequestTypeImageEdits, object.RequestTypeImageVariations:
 			for _, f := range listenerFilters.OnImageGenerationsRequestFilters() {
-				fResult := f.OnImageGenerationsRequest(request.Context(), llmRequest, request)
+				fResult := filters.Observe(request.Context(), f, filters.StageOnImageGenerationsRequest, func() filters.RequestFilterResult {
+					return f.OnImageGenerationsRequest(request.Context(), llmRequest, request)
+				})
+				if fResult.IsFailed() {
+					return nil, fResult.Error
+				}
+			}
+		case object.RequestTypeModerations:
+			for _, f := range listenerFilters.OnModerationsRequestFilters() {
+				fResult := filters.Observe(request.Context(), f, filters.StageOnModerationsRequest, func() filters.RequestFilterResult {
+					return f.OnModerationsRequest(request.Context(), llmRequest, request)
+				})
+				if fResult.IsFailed() {
+					return nil, fResult.Error
+				}
+			}
+		case object.RequestTypeEmbeddings:
+			for _, f := range listenerFilters.OnEmbeddingsRequestFilters() {
+				fResult := filters.Observe(request.Context(), f, filters.StageOnEmbeddingsRequest, func() filters.RequestFilterResult {
+					return f.OnEmbeddingsRequest(request.Context(), llmRequest, request)
+				})
+				if fResult.IsFailed() {
+					return nil, fResult.Error
+				}
+			}
+		case object.RequestTypeRerank:
+			for _, f := range listenerFilters.OnRerankRequestFilters() {
+				fResult := filters.Observe(request.Context(), f, filters.StageOnRerankRequest, func() filters.RequestFilterResult {
+					return f.OnRerankRequest(request.Context(), llmRequest, request)
+				})
+				if fResult.IsFailed() {
+					return nil, fResult.Error
+				}
+			}
+		case object.RequestTypeVideoGenerations:
+			for _, f := range listenerFilters.OnVideoGenerationsRequestFilters() {
+				fResult := filters.Observe(request.Context(), f, filters.StageOnVideoGenerationsRequest, func() filters.RequestFilterResult {
+					return f.OnVideoGenerationsRequest(request.Context(), llmRequest, request)
+				})
+				if fResult.IsFailed() {
+					return nil, fResult.Error
+				}
+			}
+		case object.RequestTypeRealtime:
+			for _, f := range listenerFilters.OnRealtimeRequestFilters() {
+				fResult := filters.Observe(request.Context(), f, filters.StageOnRealtimeRequest, func() filters.RequestFilterResult {
+					return f.OnRealtimeRequest(request.Context(), llmRequest, request)
+				})
 				if fResult.IsFailed() {
 					return nil, fResult.Error
 				}
@@ -67,7 +159,9 @@ func CommonListenerHandler(
 		defer func() {
 			if !lo.IsNil(resp) && !resp.IsStream() {
 				for _, f := range reversedFilters.OnCompletionResponseFilters() {
-					fResult := f.OnCompletionResponse(request.Context(), llmRequest, resp)
+					fResult := filters.Observe(request.Context(), f, filters.StageOnCompletionResponse, func() filters.RequestFilterResult {
+						return f.OnCompletionResponse(request.Context(), llmRequest, resp)
+					})
 					if fResult.IsFailed() {
 						// REVIEW: ignore? Or should fResult be returned?
 						// Related topics: moderation, censorship, or filter keywords from the response
@@ -78,12 +172,23 @@ func CommonListenerHandler(
 		}()
 
 		resp, err = routemanager.HandleRequest(request.Context(), llmRequest)
+		rMeta.LLMResponse = resp
+
 		if err != nil {
 			return resp, err
 		}
 
+		// Sessions of the Realtime API
+		if llmRequest.GetRequestType() == object.RequestTypeRealtime {
+			err = relayRealtimeSession(request.Context(), reversedFilters, llmRequest, resp, writer, request)
+
+			return nil, err
+		}
+
 		// Non-streaming responses
 		if !resp.IsStream() {
+			evaluateExportedBillableUnits(rMeta, llmRequest, resp)
+
 			return resp, err
 		}
 
@@ -93,9 +198,24 @@ func CommonListenerHandler(
 			return resp, openai.NewErrorInternalError().WithCausef("failed to cast %T to object.LLMStreamResponse", resp)
 		}
 
+		streamResp.BeforeChunk(func(chunk object.LLMChunkResponse) error {
+			for _, f := range reversedFilters.OnCompletionStreamChunkFilters() {
+				fResult := filters.Observe(request.Context(), f, filters.StageOnCompletionStreamChunk, func() filters.RequestFilterResult {
+					return f.OnCompletionStreamChunk(request.Context(), llmRequest, streamResp, chunk)
+				})
+				if fResult.IsFailed() {
+					return fResult.Error
+				}
+			}
+
+			return nil
+		})
+
 		streamResp.OnChunk(func(ctx context.Context, stream object.LLMStreamResponse, chunk object.LLMChunkResponse) {
 			for _, f := range reversedFilters.OnCompletionStreamResponseFilters() {
-				fResult := f.OnCompletionStreamResponse(ctx, llmRequest, streamResp, chunk)
+				fResult := filters.Observe(ctx, f, filters.StageOnCompletionStreamResponse, func() filters.RequestFilterResult {
+					return f.OnCompletionStreamResponse(ctx, llmRequest, streamResp, chunk)
+				})
 				if fResult.IsFailed() {
 					// REVIEW: ignore? Or should fResult be returned?
 					// Related topics: moderation, censorship, or filter keywords from the response
@@ -104,31 +224,36 @@ func CommonListenerHandler(
 			}
 		})
 
-		utils.WriteEventStreamHeadersForHTTP(writer)
+		streamFormat := NegotiateStreamFormat(request)
+
+		stream := Streams().Open(writer, string(streamFormat), cancel)
+		defer stream.Close()
+
+		writer = stream.Writer(writer)
+
+		writeStreamHeaders(writer, streamFormat)
 		// NOTICE: from now on, there should not have any explicit error get returned
 		// since the status code will be written by above call. If there is any error
 		// it should be written as a chunk in the stream response.
-		pipeCompletionsStream(request.Context(), listenerFilters, reversedFilters, llmRequest, streamResp, writer)
+		pipeCompletionsStream(request.Context(), listenerFilters, reversedFilters, llmRequest, streamResp, writer, streamFormat)
 
 		return resp, openai.SkipStreamResponse
 	}
 }
 
-func pipeCompletionsStream(ctx context.Context, _ filters.RequestFilters, _ filters.RequestFilters, _ object.LLMRequest, streamResp object.LLMStreamResponse, writer http.ResponseWriter) {
+func pipeCompletionsStream(ctx context.Context, _ filters.RequestFilters, _ filters.RequestFilters, llmRequest object.LLMRequest, streamResp object.LLMStreamResponse, writer http.ResponseWriter, format StreamFormat) {
 	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	span := trace.SpanFromContext(ctx)
+	chunks := 0
 
 	handleChunk := func(chunk object.LLMChunkResponse) error {
-		event, err := chunk.ToServerSentEvent()
+		err := writeStreamChunk(writer, format, chunk)
 		if err != nil {
-			slog.Error("failed to convert chunk body to server sent event payload", "error", err)
 			return err
 		}
 
-		err = event.MarshalTo(writer)
-		if err != nil {
-			slog.Error("failed to write SSE event into http.ResponseWriter", "error", err)
-			return err
-		}
+		span.AddEvent(observation.StreamChunkEventName, trace.WithAttributes(observation.KnowayStreamChunkIndex.AsAttribute().Int(chunks)))
+		chunks++
 
 		return nil
 	}
@@ -138,9 +263,27 @@ func pipeCompletionsStream(ctx context.Context, _ filters.RequestFilters, _ filt
 		if err != nil {
 			if !errors.Is(err, io.EOF) {
 				slog.Error("failed to get next chunk from stream response", slog.Any("error", err))
+
+				// Rejected by the filters of chunks, e.g. the moderation of
+				// the output
+				var errorResponse *openai.ErrorResponse
+				if errors.As(err, &errorResponse) {
+					_ = writeStreamError(writer, format, errorResponse)
+				}
+
 				return
 			}
 
+			if rMeta.ExportMetadata && chunk.IsDone() {
+				evaluateExportedBillableUnits(rMeta, llmRequest, streamResp)
+
+				err := writeStreamMetadataEvent(writer, format, rMeta)
+				if err != nil {
+					// Ignore, terminate stream reading
+					return
+				}
+			}
+
 			// EOF, send last chunk
 			err := handleChunk(chunk)
 			if err != nil {
@@ -161,8 +304,12 @@ func pipeCompletionsStream(ctx context.Context, _ filters.RequestFilters, _ filt
 		}
 
 		if chunk.IsFirst() {
-			rMeta.UpstreamFirstValidChunkAt = time.Now()
-			rMeta.UpstreamResponseModel = chunk.GetModel()
+			rMeta.UpdateUpstreamAttempt(rMeta.LastUpstreamAttempt(), func(attempt *metadata.UpstreamAttempt) {
+				attempt.FirstValidChunkAt = time.Now()
+				attempt.ResponseModel = chunk.GetModel()
+			})
+
+			loadbalance.ObserveUpstreamFirstChunk(rMeta.LastUpstreamAttemptValue())
 		}
 
 		if err := handleChunk(chunk); err != nil {
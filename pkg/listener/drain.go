@@ -0,0 +1,44 @@
+package listener
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DrainStatus reports the progress of draining listeners.
+type DrainStatus struct {
+	Draining  bool      `json:"draining"`
+	StartedAt time.Time `json:"started_at,omitzero"`
+	// Deadline is when the requests still in flight are canceled
+	Deadline time.Time `json:"deadline,omitzero"`
+	// Inflight is the number of requests still being served, including
+	// streaming ones
+	Inflight int `json:"inflight"`
+	// Drained reports whether every request in flight has finished or has
+	// been canceled
+	Drained    bool      `json:"drained"`
+	FinishedAt time.Time `json:"finished_at,omitzero"`
+}
+
+// DrainAll drains the listeners concurrently, and returns once all of them
+// are drained.
+func DrainAll(ctx context.Context, drainables []Drainable) {
+	var wg sync.WaitGroup
+
+	for _, d := range drainables {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := d.Drain(ctx)
+			if err != nil {
+				slog.Error("failed to drain listener", "error", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,85 @@
+package listener
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancellableRequestMap_WaitOrCancelAllWithContext(t *testing.T) {
+	t.Run("finished", func(t *testing.T) {
+		cancellable := NewCancellableRequestMap()
+
+		request := httptest.NewRequest("POST", "/v1/chat/completions", nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		cancellable.Add(request, cancel)
+
+		time.AfterFunc(10*time.Millisecond, func() {
+			cancellable.Remove(request)
+		})
+
+		drainCtx, drainCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer drainCancel()
+
+		cancellable.WaitOrCancelAllWithContext(drainCtx)
+		assert.Zero(t, cancellable.Len())
+		// Finished requests are never canceled
+		require.NoError(t, ctx.Err())
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		cancellable := NewCancellableRequestMap()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		cancellable.Add(httptest.NewRequest("POST", "/v1/chat/completions", nil), cancel)
+
+		drainCtx, drainCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer drainCancel()
+
+		cancellable.WaitOrCancelAllWithContext(drainCtx)
+		require.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+}
+
+type fakeDrainable struct {
+	drained chan struct{}
+}
+
+func (d *fakeDrainable) Drain(ctx context.Context) error {
+	<-ctx.Done()
+	close(d.drained)
+
+	return nil
+}
+
+func (d *fakeDrainable) HasDrained() bool {
+	return false
+}
+
+func TestDrainAll(t *testing.T) {
+	drainables := []Drainable{
+		&fakeDrainable{drained: make(chan struct{})},
+		&fakeDrainable{drained: make(chan struct{})},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	DrainAll(ctx, drainables)
+
+	for _, d := range drainables {
+		select {
+		case <-d.(*fakeDrainable).drained:
+		default:
+			require.Fail(t, "DrainAll returned before draining listeners")
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package listener
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/metering"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/sse"
+)
+
+// WithExportMetadata exports the metadata of the request in the HTTP
+// trailer when the client asks for it, it must be placed outside of
+// WithResponseHandler so that the trailer is written after the body, and
+// outside of WithRequestTimer so that the duration is complete.
+//
+// Trailers are only delivered over HTTP/2 or chunked HTTP/1.1 responses,
+// streaming clients can read the terminal knoway.metadata event instead.
+func WithExportMetadata() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(writer http.ResponseWriter, request *http.Request) (any, error) {
+			rMeta := metadata.RequestMetadataFromCtx(request.Context())
+			rMeta.ExportMetadata = metadata.ExportMetadataRequested(request)
+
+			resp, err := next(writer, request)
+			if !rMeta.ExportMetadata {
+				return resp, err
+			}
+
+			bs, marshalErr := json.Marshal(rMeta.Export())
+			if marshalErr != nil {
+				slog.Error("failed to marshal exported metadata", slog.Any("error", marshalErr))
+				return resp, err
+			}
+
+			writer.Header().Set(http.TrailerPrefix+metadata.TrailerMetadata, string(bs))
+
+			return resp, err
+		}
+	}
+}
+
+// evaluateExportedBillableUnits evaluates the metering expressions of the
+// cluster that served the request when the metadata is to be exported.
+func evaluateExportedBillableUnits(rMeta *metadata.RequestMetadata, request object.LLMRequest, response object.LLMResponse) {
+	if !rMeta.ExportMetadata {
+		return
+	}
+
+	cluster, ok := rMeta.SelectedCluster.Get()
+	if !ok || lo.IsNil(cluster) {
+		return
+	}
+
+	units, err := metering.Evaluate(cluster.GetClusterConfig().GetMeteringPolicy(), metering.Input{
+		Request:   request,
+		Response:  response,
+		RequestAt: rMeta.RequestAt,
+	})
+	if err != nil {
+		slog.Warn("failed to evaluate metering expressions", slog.String("model", request.GetModel()), slog.Any("error", err))
+		return
+	}
+
+	rMeta.BillableUnits = units
+}
+
+// writeStreamMetadataEvent writes the exported metadata as the
+// knoway.metadata event, NDJSON streams rely on the trailer only.
+func writeStreamMetadataEvent(writer http.ResponseWriter, format StreamFormat, rMeta *metadata.RequestMetadata) error {
+	if format == StreamFormatNDJSON {
+		return nil
+	}
+
+	bs, err := json.Marshal(rMeta.Export())
+	if err != nil {
+		slog.Error("failed to marshal exported metadata", slog.Any("error", err))
+		return err
+	}
+
+	event := &sse.Event{
+		Event: []byte(metadata.EventMetadata),
+		Data:  bs,
+	}
+
+	err = event.MarshalTo(writer)
+	if err != nil {
+		slog.Error("failed to write SSE event into http.ResponseWriter", "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package listener
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+func TestWithExportMetadata(t *testing.T) {
+	middlewares := WithMiddlewares(
+		WithInitMetadata(),
+		WithExportMetadata(),
+		WithRequestTimer(),
+		WithResponseHandler(openai.ResponseHandler()),
+	)
+
+	handler := middlewares(func(writer http.ResponseWriter, request *http.Request) (any, error) {
+		rMeta := metadata.RequestMetadataFromCtx(request.Context())
+		rMeta.ServedModel = "gpt-4o"
+		rMeta.LLMUpstreamTokensUsage = mo.Some[object.LLMTokensUsage](&openai.ChatCompletionsUsage{
+			PromptTokens:     10,
+			CompletionTokens: 5,
+			TotalTokens:      15,
+		})
+
+		attempt := rMeta.NewUpstreamAttempt("gpt-4o", "default/gpt-4o")
+		rMeta.UpdateUpstreamAttempt(attempt, func(attempt *metadata.UpstreamAttempt) {
+			attempt.RequestAt = time.Now().Add(-time.Second)
+			attempt.RespondAt = time.Now()
+		})
+
+		return map[string]any{"object": "chat.completion"}, nil
+	})
+
+	server := httptest.NewServer(HTTPHandlerFunc(handler))
+	defer server.Close()
+
+	t.Run("requested", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodPost, server.URL, nil) //nolint:noctx
+		require.NoError(t, err)
+		request.Header.Set(metadata.HeaderExportMetadata, "true")
+
+		resp, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		_, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		var exported metadata.ExportedMetadata
+
+		require.NoError(t, json.Unmarshal([]byte(resp.Trailer.Get(metadata.TrailerMetadata)), &exported))
+		assert.Equal(t, "gpt-4o", exported.ServedModel)
+		assert.Equal(t, &metadata.ExportedUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}, exported.Usage)
+		assert.Equal(t, 1, exported.UpstreamAttempts)
+		assert.InDelta(t, 1000, exported.UpstreamLatencyMs, 100)
+	})
+
+	t.Run("not requested", func(t *testing.T) {
+		request, err := http.NewRequest(http.MethodPost, server.URL, nil) //nolint:noctx
+		require.NoError(t, err)
+
+		resp, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		_, err = io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		assert.Empty(t, resp.Trailer.Get(metadata.TrailerMetadata))
+	})
+}
+
+func TestWriteStreamMetadataEvent(t *testing.T) {
+	rMeta := &metadata.RequestMetadata{ServedModel: "gpt-4o", BillableUnits: map[string]float64{"tokens": 15}}
+
+	recorder := httptest.NewRecorder()
+	require.NoError(t, writeStreamMetadataEvent(recorder, StreamFormatSSE, rMeta))
+	assert.Equal(t, "data: {\"served_model\":\"gpt-4o\",\"billable_units\":{\"tokens\":15},\"duration_ms\":0,\"upstream_attempts\":0,\"upstream_latency_ms\":0}\nevent: knoway.metadata\n\n", recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	require.NoError(t, writeStreamMetadataEvent(recorder, StreamFormatNDJSON, rMeta))
+	assert.Empty(t, recorder.Body.String())
+}
@@ -0,0 +1,157 @@
+package listener
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"unicode/utf8"
+
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/utils"
+)
+
+// RequestLimits are the limits of the requests on a path, zero values are
+// unlimited.
+type RequestLimits struct {
+	// MaxBodyBytes rejects bodies larger than it with 413, without reading
+	// more than it.
+	MaxBodyBytes int64
+	// MaxMessages rejects chat completions requests with more messages than
+	// it with 400.
+	MaxMessages int
+	// MaxPromptChars rejects requests whose text of messages, or prompt of
+	// legacy completions, has more characters than it in total with 400.
+	MaxPromptChars int
+}
+
+// WithRequestLimits enforces the limits of the most specific path pattern
+// matching the path of requests, patterns are the same as the ones of
+// WithAllowedMethods. Bodies are checked before they reach the listeners, so
+// that oversized bodies are never buffered entirely.
+func WithRequestLimits(handler http.Handler, limits map[string]RequestLimits) http.Handler {
+	if len(limits) == 0 {
+		return handler
+	}
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		limit, ok := longestPathPatternMatch(limits, request.URL.Path)
+		if !ok || request.Body == nil || request.Body == http.NoBody {
+			handler.ServeHTTP(writer, request)
+			return
+		}
+
+		err := checkRequestLimits(request, limit)
+		if err != nil {
+			utils.WriteJSONForHTTP(err.Status, err, writer)
+			return
+		}
+
+		handler.ServeHTTP(writer, request)
+	})
+}
+
+type requestBody struct {
+	io.Reader
+	io.Closer
+}
+
+// checkRequestLimits checks the request against the limits, replacing its body
+// with the part read.
+func checkRequestLimits(request *http.Request, limits RequestLimits) *openai.ErrorResponse {
+	if limits.MaxBodyBytes <= 0 && limits.MaxMessages <= 0 && limits.MaxPromptChars <= 0 {
+		return nil
+	}
+
+	if limits.MaxBodyBytes > 0 && request.ContentLength > limits.MaxBodyBytes {
+		return openai.NewErrorRequestEntityTooLarge(limits.MaxBodyBytes)
+	}
+
+	reader := request.Body
+	if limits.MaxBodyBytes > 0 {
+		// One more byte to tell bodies of exactly the limit from larger ones
+		reader = io.NopCloser(io.LimitReader(request.Body, limits.MaxBodyBytes+1))
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return openai.NewErrorInvalidBody()
+	}
+
+	if limits.MaxBodyBytes > 0 && int64(len(body)) > limits.MaxBodyBytes {
+		return openai.NewErrorRequestEntityTooLarge(limits.MaxBodyBytes)
+	}
+
+	request.Body = requestBody{Reader: bytes.NewReader(body), Closer: request.Body}
+
+	if limits.MaxMessages <= 0 && limits.MaxPromptChars <= 0 {
+		return nil
+	}
+
+	// Multipart bodies of audio and images carry no messages
+	mediaType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	if mediaType != "" && mediaType != "application/json" {
+		return nil
+	}
+
+	var parsed struct {
+		Messages []struct {
+			Content any `json:"content"`
+		} `json:"messages"`
+		Prompt any `json:"prompt"`
+	}
+
+	// Invalid bodies are left to the listeners to reject
+	if json.Unmarshal(body, &parsed) != nil {
+		return nil
+	}
+
+	if limits.MaxMessages > 0 && len(parsed.Messages) > limits.MaxMessages {
+		return openai.NewErrorArrayTooLong("messages", limits.MaxMessages, len(parsed.Messages))
+	}
+
+	if limits.MaxPromptChars <= 0 {
+		return nil
+	}
+
+	param, chars := "prompt", textChars(parsed.Prompt)
+	if len(parsed.Messages) > 0 {
+		param, chars = "messages", 0
+
+		for _, message := range parsed.Messages {
+			chars += textChars(message.Content)
+		}
+	}
+
+	if chars > limits.MaxPromptChars {
+		return openai.NewErrorPromptTooLong(param, limits.MaxPromptChars, chars)
+	}
+
+	return nil
+}
+
+// textChars counts the characters of the text of contents or prompts, which
+// are either strings, or arrays of strings or text parts.
+func textChars(content any) int {
+	switch content := content.(type) {
+	case string:
+		return utf8.RuneCountInString(content)
+	case []any:
+		chars := 0
+
+		for _, part := range content {
+			switch part := part.(type) {
+			case string:
+				chars += utf8.RuneCountInString(part)
+			case map[string]any:
+				text, _ := part["text"].(string)
+				chars += utf8.RuneCountInString(text)
+			}
+		}
+
+		return chars
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,90 @@
+package listener
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequestLimits(t *testing.T) {
+	var received string
+
+	handler := WithRequestLimits(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		received = string(body)
+
+		writer.WriteHeader(http.StatusOK)
+	}), map[string]RequestLimits{
+		"/v1/*":                {MaxBodyBytes: 64},
+		"/v1/chat/completions": {MaxBodyBytes: 256, MaxMessages: 2, MaxPromptChars: 20},
+	})
+
+	cases := []struct {
+		name   string
+		path   string
+		body   string
+		status int
+		code   string
+	}{
+		{name: "ok", path: "/v1/chat/completions", body: `{"messages":[{"role":"user","content":"hello"}]}`, status: http.StatusOK},
+		{name: "too large", path: "/v1/chat/completions", body: `{"messages":[{"role":"user","content":"` + strings.Repeat("a", 256) + `"}]}`, status: http.StatusRequestEntityTooLarge, code: "request_too_large"},
+		{name: "too many messages", path: "/v1/chat/completions", body: `{"messages":[{"content":"a"},{"content":"b"},{"content":"c"}]}`, status: http.StatusBadRequest, code: "array_above_max_length"},
+		{name: "prompt too long", path: "/v1/chat/completions", body: `{"messages":[{"content":"hello world"},{"content":[{"type":"text","text":"hello world"},{"type":"image_url"}]}]}`, status: http.StatusBadRequest, code: "string_above_max_length"},
+		{name: "invalid json left to listeners", path: "/v1/chat/completions", body: `{"messages":`, status: http.StatusOK},
+		{name: "less specific pattern", path: "/v1/embeddings", body: `{"input":"` + strings.Repeat("a", 64) + `"}`, status: http.StatusRequestEntityTooLarge, code: "request_too_large"},
+		{name: "unlimited", path: "/healthz", body: strings.Repeat("a", 1024), status: http.StatusOK},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			received = ""
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, c.path, strings.NewReader(c.body)))
+			require.Equal(t, c.status, recorder.Code)
+
+			if c.code == "" {
+				// The body is passed as is
+				assert.Equal(t, c.body, received)
+				return
+			}
+
+			var resp struct {
+				Error struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			}
+
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Equal(t, c.code, resp.Error.Code)
+		})
+	}
+}
+
+func TestWithRequestLimits_ContentLength(t *testing.T) {
+	read := false
+
+	handler := WithRequestLimits(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		read = true
+	}), map[string]RequestLimits{"/v1/*": {MaxBodyBytes: 8}})
+
+	request := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(strings.Repeat("a", 16)))
+	request.ContentLength = 16
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	assert.False(t, read)
+}
+
+func TestTextChars(t *testing.T) {
+	assert.Equal(t, 5, textChars("héllo"))
+	assert.Equal(t, 6, textChars([]any{"abc", map[string]any{"type": "text", "text": "def"}, map[string]any{"type": "image_url"}}))
+	assert.Zero(t, textChars(nil))
+}
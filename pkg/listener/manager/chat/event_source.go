@@ -0,0 +1,191 @@
+package chat
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/api/listeners/v1alpha1"
+	"knoway.dev/pkg/listener"
+	"knoway.dev/pkg/threads"
+	"knoway.dev/pkg/types/openai"
+)
+
+const (
+	eventSourceQueryModel    = "model"
+	eventSourceQueryThreadID = "thread_id"
+	eventSourceQueryPromptID = "prompt_id"
+	eventSourceQueryAPIKey   = "api_key"
+)
+
+// eventSourceReservedQueries are not passed to the templates of prompts.
+var eventSourceReservedQueries = []string{
+	eventSourceQueryModel,
+	eventSourceQueryThreadID,
+	eventSourceQueryPromptID,
+	eventSourceQueryAPIKey,
+}
+
+type eventSourcePromptMessage struct {
+	role    string
+	content *template.Template
+}
+
+// eventSourcePrompts are the prompts referenced by prompt_id, parsed once
+// when the listener is created.
+type eventSourcePrompts map[string][]eventSourcePromptMessage
+
+func newEventSourcePrompts(cfg *v1alpha1.EventSource) (eventSourcePrompts, error) {
+	prompts := make(eventSourcePrompts, len(cfg.GetPrompts()))
+
+	for _, prompt := range cfg.GetPrompts() {
+		if prompt.GetId() == "" {
+			return nil, errors.New("id of event source prompts is required")
+		}
+
+		if _, ok := prompts[prompt.GetId()]; ok {
+			return nil, fmt.Errorf("duplicated event source prompt %q", prompt.GetId())
+		}
+
+		messages := make([]eventSourcePromptMessage, 0, len(prompt.GetMessages()))
+
+		for i, message := range prompt.GetMessages() {
+			tmpl, err := template.New(fmt.Sprintf("%s/%d", prompt.GetId(), i)).Option("missingkey=error").Parse(message.GetContent())
+			if err != nil {
+				return nil, fmt.Errorf("invalid content of event source prompt %q: %w", prompt.GetId(), err)
+			}
+
+			messages = append(messages, eventSourcePromptMessage{
+				role:    lo.CoalesceOrEmpty(message.GetRole(), "user"),
+				content: tmpl,
+			})
+		}
+
+		prompts[prompt.GetId()] = messages
+	}
+
+	return prompts, nil
+}
+
+// render executes the templates of the prompt with the query parameters.
+func (p eventSourcePrompts) render(promptID string, params map[string]string) ([]map[string]any, error) {
+	prompt, ok := p[promptID]
+	if !ok {
+		return nil, openai.NewErrorBadRequest().WithMessage("Prompt '" + promptID + "' not found.")
+	}
+
+	messages := make([]map[string]any, 0, len(prompt))
+
+	for _, message := range prompt {
+		var content strings.Builder
+
+		err := message.content.Execute(&content, params)
+		if err != nil {
+			return nil, openai.NewErrorBadRequest().WithMessage("Failed to render prompt '" + promptID + "': " + err.Error())
+		}
+
+		messages = append(messages, map[string]any{"role": message.role, "content": content.String()})
+	}
+
+	return messages, nil
+}
+
+// withEventSource translates the GET requests of EventSource clients into
+// streaming chat completions requests, other requests are passed as is.
+func (l *OpenAIChatListener) withEventSource(next listener.HandlerFunc) listener.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) (any, error) {
+		if request.Method != http.MethodGet || !l.cfg.GetEventSource().GetEnable() {
+			return next(writer, request)
+		}
+
+		translated, err := l.translateEventSourceRequest(request)
+		if err != nil {
+			return nil, err
+		}
+
+		return next(writer, translated)
+	}
+}
+
+func (l *OpenAIChatListener) translateEventSourceRequest(request *http.Request) (*http.Request, error) {
+	query := request.URL.Query()
+
+	apiKey := query.Get(eventSourceQueryAPIKey)
+	if query.Has(eventSourceQueryAPIKey) {
+		// Keep the key out of the logs of the URL
+		query.Del(eventSourceQueryAPIKey)
+		request.URL.RawQuery = query.Encode()
+
+		if !l.cfg.GetEventSource().GetAllowApiKeyQuery() {
+			return nil, openai.NewErrorBadRequest().WithMessage("The api_key query parameter is not allowed.")
+		}
+	}
+
+	model := query.Get(eventSourceQueryModel)
+	if model == "" {
+		return nil, openai.NewErrorMissingModel()
+	}
+
+	threadID := query.Get(eventSourceQueryThreadID)
+	promptID := query.Get(eventSourceQueryPromptID)
+
+	if threadID == "" && promptID == "" {
+		return nil, openai.NewErrorBadRequest().WithMessage("Either thread_id or prompt_id is required.")
+	}
+
+	if threadID != "" && l.threadStore == nil {
+		return nil, openai.NewErrorBadRequest().WithMessage("Threads are not enabled.")
+	}
+
+	messages := make([]map[string]any, 0)
+
+	if promptID != "" {
+		params := make(map[string]string, len(query))
+		for key := range query {
+			if !lo.Contains(eventSourceReservedQueries, key) {
+				params[key] = query.Get(key)
+			}
+		}
+
+		var err error
+
+		messages, err = l.eventSourcePrompts.render(promptID, params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"model":    model,
+		"stream":   true,
+		"messages": messages,
+	})
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	translated := request.Clone(request.Context())
+	translated.Method = http.MethodPost
+	translated.Body = io.NopCloser(bytes.NewReader(body))
+	translated.ContentLength = int64(len(body))
+	translated.Header.Set("Content-Type", "application/json")
+	// EventSource only understands server-sent events
+	translated.Header.Set("Accept", "text/event-stream")
+
+	if threadID != "" {
+		translated.Header.Set(threads.HeaderThreadID, threadID)
+	}
+
+	if apiKey != "" {
+		translated.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	return translated, nil
+}
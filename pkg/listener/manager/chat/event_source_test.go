@@ -0,0 +1,132 @@
+package chat
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/listeners/v1alpha1"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/threads"
+)
+
+func newTestEventSourceListener(t *testing.T, cfg *v1alpha1.EventSource) *OpenAIChatListener {
+	t.Helper()
+
+	prompts, err := newEventSourcePrompts(cfg)
+	require.NoError(t, err)
+
+	return &OpenAIChatListener{
+		cfg:                &v1alpha1.ChatCompletionListener{EventSource: cfg},
+		eventSourcePrompts: prompts,
+	}
+}
+
+func TestNewEventSourcePrompts(t *testing.T) {
+	_, err := newEventSourcePrompts(&v1alpha1.EventSource{
+		Prompts: []*v1alpha1.EventSource_Prompt{{Id: "a"}, {Id: "a"}},
+	})
+	require.EqualError(t, err, `duplicated event source prompt "a"`)
+
+	_, err = newEventSourcePrompts(&v1alpha1.EventSource{
+		Prompts: []*v1alpha1.EventSource_Prompt{{Id: "a", Messages: []*v1alpha1.EventSource_Prompt_Message{{Content: "{{ .text"}}}},
+	})
+	require.ErrorContains(t, err, `invalid content of event source prompt "a"`)
+}
+
+func TestTranslateEventSourceRequest(t *testing.T) {
+	l := newTestEventSourceListener(t, &v1alpha1.EventSource{
+		Enable: true,
+		Prompts: []*v1alpha1.EventSource_Prompt{
+			{
+				Id: "translate",
+				Messages: []*v1alpha1.EventSource_Prompt_Message{
+					{Role: "system", Content: "You are a translator."},
+					{Content: "Translate {{ .text }} into English."},
+				},
+			},
+		},
+		AllowApiKeyQuery: true,
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/chat/completions?model=gpt-4o&prompt_id=translate&text=Bonjour&api_key=sk-test", nil)
+	request.Header.Set("Accept", "*/*")
+
+	translated, err := l.translateEventSourceRequest(request)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodPost, translated.Method)
+	assert.Equal(t, "text/event-stream", translated.Header.Get("Accept"))
+	assert.Equal(t, "Bearer sk-test", translated.Header.Get("Authorization"))
+	assert.Empty(t, translated.Header.Get(threads.HeaderThreadID))
+	assert.NotContains(t, request.URL.String(), "sk-test")
+
+	body, err := io.ReadAll(translated.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"model": "gpt-4o",
+		"stream": true,
+		"messages": [
+			{"role": "system", "content": "You are a translator."},
+			{"role": "user", "content": "Translate Bonjour into English."}
+		]
+	}`, string(body))
+}
+
+func TestTranslateEventSourceRequest_Thread(t *testing.T) {
+	l := newTestEventSourceListener(t, &v1alpha1.EventSource{Enable: true})
+
+	request := httptest.NewRequest(http.MethodGet, "/v1/chat/completions?model=gpt-4o&thread_id=thread_abc", nil)
+
+	_, err := l.translateEventSourceRequest(request)
+	require.EqualError(t, err, "Threads are not enabled.")
+
+	// Only the presence of the store is checked
+	l.threadStore = struct{ threads.Store }{}
+
+	translated, err := l.translateEventSourceRequest(request)
+	require.NoError(t, err)
+	assert.Equal(t, "thread_abc", translated.Header.Get(threads.HeaderThreadID))
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(translated.Body).Decode(&body))
+	assert.Equal(t, []any{}, body["messages"])
+}
+
+func TestTranslateEventSourceRequest_Errors(t *testing.T) {
+	l := newTestEventSourceListener(t, &v1alpha1.EventSource{
+		Enable: true,
+		Prompts: []*v1alpha1.EventSource_Prompt{
+			{Id: "translate", Messages: []*v1alpha1.EventSource_Prompt_Message{{Content: "Translate {{ .text }}"}}},
+		},
+	})
+
+	cases := []struct {
+		name    string
+		url     string
+		message string
+	}{
+		{name: "api key not allowed", url: "/v1/chat/completions?model=gpt-4o&prompt_id=translate&text=a&api_key=sk-test", message: "The api_key query parameter is not allowed."},
+		{name: "missing model", url: "/v1/chat/completions?prompt_id=translate&text=a", message: "you must provide a model parameter"},
+		{name: "missing reference", url: "/v1/chat/completions?model=gpt-4o", message: "Either thread_id or prompt_id is required."},
+		{name: "unknown prompt", url: "/v1/chat/completions?model=gpt-4o&prompt_id=summarize", message: "Prompt 'summarize' not found."},
+		{name: "missing parameter", url: "/v1/chat/completions?model=gpt-4o&prompt_id=translate", message: "Failed to render prompt 'translate'"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := l.translateEventSourceRequest(httptest.NewRequest(http.MethodGet, c.url, nil))
+			require.Error(t, err)
+			require.ErrorContains(t, err, c.message)
+
+			llmErr := object.AsLLMError(err)
+			require.NotNil(t, llmErr)
+			assert.Equal(t, http.StatusBadRequest, llmErr.GetStatus())
+		})
+	}
+}
@@ -10,11 +10,12 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"knoway.dev/api/listeners/v1alpha1"
+	"knoway.dev/pkg/accesslog"
 	"knoway.dev/pkg/bootkit"
-	"knoway.dev/pkg/constants"
 	"knoway.dev/pkg/filters"
 	"knoway.dev/pkg/listener"
 	"knoway.dev/pkg/registry/config"
+	"knoway.dev/pkg/threads"
 	"knoway.dev/pkg/types/openai"
 	"knoway.dev/pkg/utils"
 )
@@ -27,6 +28,10 @@ type OpenAIChatListener struct {
 	filters         filters.RequestFilters
 	reversedFilters filters.RequestFilters
 	cancellable     *listener.CancellableRequestMap
+	accessLog       *accesslog.Logger
+	threadStore     threads.Store
+
+	eventSourcePrompts eventSourcePrompts
 
 	mutex   sync.RWMutex
 	drained bool
@@ -53,9 +58,37 @@ func NewOpenAIChatListenerConfigs(cfg proto.Message, lifecycle bootkit.LifeCycle
 			return nil, err
 		}
 
-		l.filters = append(l.filters, f)
+		l.filters = append(l.filters, filters.WithFeatureFlag(f, fc.GetFeatureFlag()))
+	}
+
+	if c.GetThreads() != nil {
+		store, err := threads.NewStoreWithConfig(c.GetThreads(), lifecycle)
+		if err != nil {
+			return nil, err
+		}
+
+		l.threadStore = store
+		// Threads filter should always be the last one, so that auth and
+		// rate limit filters are applied before history messages being loaded.
+		l.filters = append(l.filters, threads.NewFilter(c.GetThreads(), store))
 	}
 
+	if c.GetEventSource().GetEnable() {
+		prompts, err := newEventSourcePrompts(c.GetEventSource())
+		if err != nil {
+			return nil, err
+		}
+
+		l.eventSourcePrompts = prompts
+	}
+
+	accessLog, err := accesslog.NewLoggerWithConfig(c.GetAccessLog())
+	if err != nil {
+		return nil, err
+	}
+
+	l.accessLog = accessLog
+
 	l.reversedFilters = utils.Clone(l.filters)
 	mutable.Reverse(l.reversedFilters)
 
@@ -66,7 +99,10 @@ func (l *OpenAIChatListener) RegisterRoutes(mux *mux.Router) error {
 	middlewares := listener.WithMiddlewares(
 		listener.WithCancellable(l.cancellable),
 		listener.WithInitMetadata(),
-		listener.WithAccessLog(l.cfg.GetAccessLog().GetEnable()),
+		listener.WithTracing(),
+		listener.WithAccessLog(l.accessLog),
+		listener.WithRouteMetrics(),
+		listener.WithExportMetadata(),
 		listener.WithRequestTimer(),
 		listener.WithOptions(),
 		listener.WithResponseHandler(openai.ResponseHandler()),
@@ -74,10 +110,16 @@ func (l *OpenAIChatListener) RegisterRoutes(mux *mux.Router) error {
 		listener.WithRejectAfterDrainedWithError(l),
 	)
 
-	mux.HandleFunc("/v1/chat/completions", listener.HTTPHandlerFunc(middlewares(listener.CommonListenerHandler(l.filters, l.reversedFilters, l.unmarshalChatCompletionsRequestToLLMRequest))))
+	mux.HandleFunc("/v1/chat/completions", listener.HTTPHandlerFunc(middlewares(l.withEventSource(listener.CommonListenerHandler(l.filters, l.reversedFilters, l.unmarshalChatCompletionsRequestToLLMRequest)))))
 	mux.HandleFunc("/v1/completions", listener.HTTPHandlerFunc(middlewares(listener.CommonListenerHandler(l.filters, l.reversedFilters, l.unmarshalCompletionsRequestToLLMRequest))))
 	mux.HandleFunc("/v1/models", listener.HTTPHandlerFunc(middlewares(l.listModels)))
 
+	if l.threadStore != nil {
+		mux.HandleFunc("/v1/threads", listener.HTTPHandlerFunc(middlewares(l.withRequestPreFilters(l.createThread))))
+		mux.HandleFunc("/v1/threads/{thread_id}", listener.HTTPHandlerFunc(middlewares(l.withRequestPreFilters(l.thread))))
+		mux.HandleFunc("/v1/threads/{thread_id}/messages", listener.HTTPHandlerFunc(middlewares(l.withRequestPreFilters(l.threadMessages))))
+	}
+
 	return nil
 }
 
@@ -93,7 +135,8 @@ func (l *OpenAIChatListener) Drain(ctx context.Context) error {
 	l.drained = true
 	l.mutex.Unlock()
 
-	l.cancellable.CancelAllAfterWithContext(ctx, constants.DefaultDrainWaitTime)
+	l.cancellable.WaitOrCancelAllWithContext(ctx)
 
-	return nil
+	// The entries of the requests drained are flushed before closing
+	return l.accessLog.Close()
 }
@@ -10,8 +10,10 @@ import (
 
 	v1alpha4 "knoway.dev/api/clusters/v1alpha1"
 	clustermanager "knoway.dev/pkg/clusters/manager"
+	"knoway.dev/pkg/filters"
 	"knoway.dev/pkg/filters/auth"
 	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/tenant"
 )
 
 func ClustersToOpenAIModels(clusters []*v1alpha4.Cluster) []goopenai.Model {
@@ -38,15 +40,130 @@ func ClusterToOpenAIModel(c *v1alpha4.Cluster) goopenai.Model {
 	}
 }
 
+// Model is the model object of OpenAI along with the metadata of the backend
+// serving the model.
+type Model struct {
+	goopenai.Model
+
+	Provider string `json:"provider"`
+	// ContextWindow is the max number of tokens of the prompt and the
+	// completion together, omitted if unknown.
+	ContextWindow int64 `json:"context_window,omitempty"`
+	// Capabilities of the model, such as tools and vision, omitted if unknown.
+	Capabilities []string      `json:"capabilities,omitempty"`
+	Pricing      *ModelPricing `json:"pricing,omitempty"`
+	// Status is either available, or ejected while the circuit breaker of the
+	// backend rejects the requests.
+	Status ModelStatus `json:"status"`
+}
+
+type ModelStatus string
+
+const (
+	ModelStatusAvailable ModelStatus = "available"
+	ModelStatusEjected   ModelStatus = "ejected"
+)
+
+// ModelPricing is the price of the usage of the model.
+type ModelPricing struct {
+	Currency              string            `json:"currency,omitempty"`
+	PromptPer1KTokens     float64           `json:"prompt_per_1k_tokens,omitempty"`
+	CompletionPer1KTokens float64           `json:"completion_per_1k_tokens,omitempty"`
+	Images                []ModelImagePrice `json:"images,omitempty"`
+	PerAudioSecond        float64           `json:"per_audio_second,omitempty"`
+}
+
+type ModelImagePrice struct {
+	Size    string  `json:"size,omitempty"`
+	Quality string  `json:"quality,omitempty"`
+	Price   float64 `json:"price"`
+}
+
+// ModelsList is the list of models, in the form of the one of OpenAI.
+type ModelsList struct {
+	Object string  `json:"object"`
+	Data   []Model `json:"data"`
+}
+
+// ClusterToModel converts the cluster to the model along with its metadata.
+func ClusterToModel(c *v1alpha4.Cluster) Model {
+	model := Model{
+		Model:         ClusterToOpenAIModel(c),
+		Provider:      c.GetProvider().String(),
+		ContextWindow: c.GetModelInfo().GetContextWindow(),
+		Capabilities: lo.Map(c.GetModelInfo().GetCapabilities(), func(capability v1alpha4.ModelCapability, _ int) string {
+			return strings.ToLower(capability.String())
+		}),
+		Status: ModelStatusAvailable,
+	}
+
+	if clustermanager.Ejected(c.GetName()) {
+		model.Status = ModelStatusEjected
+	}
+
+	if pricing := c.GetPricing(); pricing != nil {
+		model.Pricing = &ModelPricing{
+			Currency:              pricing.GetCurrency(),
+			PromptPer1KTokens:     pricing.GetPromptPer1KTokens(),
+			CompletionPer1KTokens: pricing.GetCompletionPer1KTokens(),
+			Images: lo.Map(pricing.GetImages(), func(image *v1alpha4.ClusterPricing_Image, _ int) ModelImagePrice {
+				return ModelImagePrice{Size: image.GetSize(), Quality: image.GetQuality(), Price: image.GetPrice()}
+			}),
+			PerAudioSecond: pricing.GetPerAudioSecond(),
+		}
+	}
+
+	return model
+}
+
+// TenantModels converts the clusters to the models listed to the tenant of
+// the namespace. Models in the namespaces of other tenants are hidden, the
+// ones in the namespace are listed by their names in it, in place of the
+// shared models of the same names.
+func TenantModels(namespace string, clusters []*v1alpha4.Cluster) []Model {
+	scoped := make(map[string]struct{})
+
+	for _, c := range clusters {
+		owner, name := tenant.SplitModel(c.GetName())
+		if owner != "" && owner == namespace {
+			scoped[name] = struct{}{}
+		}
+	}
+
+	res := make([]Model, 0, len(clusters))
+
+	for _, c := range clusters {
+		owner, name := tenant.SplitModel(c.GetName())
+
+		switch {
+		case owner == "":
+			if _, ok := scoped[name]; ok {
+				continue
+			}
+		case owner != namespace:
+			continue
+		}
+
+		model := ClusterToModel(c)
+		model.ID = name
+		res = append(res, model)
+	}
+
+	return res
+}
+
 func (l *OpenAIChatListener) listModels(writer http.ResponseWriter, request *http.Request) (any, error) {
 	for _, f := range l.filters.OnRequestPreFilters() {
-		fResult := f.OnRequestPre(request.Context(), request)
+		fResult := filters.Observe(request.Context(), f, filters.StageOnRequestPre, func() filters.RequestFilterResult {
+			return f.OnRequestPre(request.Context(), request)
+		})
 		if fResult.IsFailed() {
 			return nil, fResult.Error
 		}
 	}
 
-	clusters := clustermanager.ListModels()
+	// Models under maintenance are hidden until the window ends
+	clusters := clustermanager.ListAvailableModels()
 
 	// auth filters
 	rMeta := metadata.RequestMetadataFromCtx(request.Context())
@@ -59,13 +176,21 @@ func (l *OpenAIChatListener) listModels(writer http.ResponseWriter, request *htt
 		}
 	}
 
-	sort.Slice(clusters, func(i, j int) bool {
-		return strings.Compare(clusters[i].GetName(), clusters[j].GetName()) < 0
+	// Requests of the tenant to the providers not allowed are rejected as if
+	// the models didn't exist
+	clusters = lo.Filter(clusters, func(item *v1alpha4.Cluster, _ int) bool {
+		return tenant.ProviderAllowed(request.Context(), item.GetProvider())
+	})
+
+	ms := TenantModels(tenant.NamespaceFromContext(request.Context()), clusters)
+
+	sort.Slice(ms, func(i, j int) bool {
+		return strings.Compare(ms[i].ID, ms[j].ID) < 0
 	})
 
-	ms := ClustersToOpenAIModels(clusters)
-	body := goopenai.ModelsList{
-		Models: ms,
+	body := ModelsList{
+		Object: "list",
+		Data:   ms,
 	}
 
 	return body, nil
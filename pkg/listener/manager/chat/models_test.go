@@ -0,0 +1,65 @@
+package chat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v1alpha4 "knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/tenant"
+)
+
+func TestTenantModels(t *testing.T) {
+	tenant.SetGlobal(tenant.New(map[string]tenant.Overrides{
+		"org-a": {Namespace: "org-a"},
+		"org-b": {Namespace: "org-b"},
+	}))
+	defer tenant.SetGlobal(nil)
+
+	clusters := lo.Map([]string{"gpt-4o", "gpt-4o-mini", "org-a/gpt-4o", "org-b/claude-3", "meta-llama/Llama-3.1-8B"}, func(name string, _ int) *v1alpha4.Cluster {
+		return &v1alpha4.Cluster{Name: name, Provider: v1alpha4.ClusterProvider_OPEN_AI}
+	})
+
+	ids := func(namespace string) []string {
+		return lo.Map(TenantModels(namespace, clusters), func(model Model, _ int) string {
+			return model.ID
+		})
+	}
+
+	assert.Equal(t, []string{"gpt-4o-mini", "gpt-4o", "meta-llama/Llama-3.1-8B"}, ids("org-a"))
+	assert.Equal(t, []string{"gpt-4o", "gpt-4o-mini", "claude-3", "meta-llama/Llama-3.1-8B"}, ids("org-b"))
+	assert.Equal(t, []string{"gpt-4o", "gpt-4o-mini", "meta-llama/Llama-3.1-8B"}, ids(""))
+}
+
+func TestClusterToModel(t *testing.T) {
+	model := ClusterToModel(&v1alpha4.Cluster{
+		Name:     "gpt-4o",
+		Provider: v1alpha4.ClusterProvider_OPEN_AI,
+		Created:  1700000000,
+		ModelInfo: &v1alpha4.ClusterModelInfo{
+			ContextWindow: 128000,
+			Capabilities:  []v1alpha4.ModelCapability{v1alpha4.ModelCapability_TOOLS, v1alpha4.ModelCapability_JSON_SCHEMA},
+		},
+		Pricing: &v1alpha4.ClusterPricing{Currency: "USD", PromptPer1KTokens: 0.0025, CompletionPer1KTokens: 0.01},
+	})
+
+	bs, err := json.Marshal(model)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": "gpt-4o",
+		"object": "model",
+		"created": 1700000000,
+		"owned_by": "OPEN_AI",
+		"permission": null,
+		"root": "",
+		"parent": "",
+		"provider": "OPEN_AI",
+		"context_window": 128000,
+		"capabilities": ["tools", "json_schema"],
+		"pricing": {"currency": "USD", "prompt_per_1k_tokens": 0.0025, "completion_per_1k_tokens": 0.01},
+		"status": "available"
+	}`, string(bs))
+}
@@ -0,0 +1,191 @@
+package chat
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/samber/lo"
+
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/listener"
+	"knoway.dev/pkg/threads"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/utils"
+)
+
+type createThreadRequest struct {
+	Messages []createMessageRequest `json:"messages"`
+	Metadata map[string]string      `json:"metadata"`
+}
+
+type createMessageRequest struct {
+	Role    string `json:"role"`
+	Content any    `json:"content"`
+}
+
+type deleteThreadResponse struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+}
+
+type listMessagesResponse struct {
+	Object string             `json:"object"`
+	Data   []*threads.Message `json:"data"`
+}
+
+func (l *OpenAIChatListener) withRequestPreFilters(next listener.HandlerFunc) listener.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) (any, error) {
+		for _, f := range l.filters.OnRequestPreFilters() {
+			fResult := filters.Observe(request.Context(), f, filters.StageOnRequestPre, func() filters.RequestFilterResult {
+				return f.OnRequestPre(request.Context(), request)
+			})
+			if fResult.IsFailed() {
+				return nil, fResult.Error
+			}
+		}
+
+		return next(writer, request)
+	}
+}
+
+func parseCreateMessageRequest(message createMessageRequest) (createMessageRequest, error) {
+	if message.Role == "" {
+		return message, openai.NewErrorMissingParameter("role")
+	}
+
+	if !lo.Contains([]string{"user", "assistant", "system", "developer"}, message.Role) {
+		return message, openai.NewErrorBadRequest().WithMessage("Invalid value for 'role': '" + message.Role + "'.")
+	}
+
+	if message.Content == nil {
+		return message, openai.NewErrorMissingParameter("content")
+	}
+
+	return message, nil
+}
+
+func (l *OpenAIChatListener) createThread(writer http.ResponseWriter, request *http.Request) (any, error) {
+	if request.Method != http.MethodPost {
+		return nil, openai.NewErrorNotFound(request.Method, request.URL.Path)
+	}
+
+	body := new(createThreadRequest)
+
+	if request.ContentLength != 0 {
+		_, parsed, err := utils.ReadAsJSONWithClose(request.Body)
+		if err != nil {
+			return nil, openai.NewErrorInvalidBody()
+		}
+
+		body, err = utils.FromMap[createThreadRequest](parsed)
+		if err != nil {
+			return nil, openai.NewErrorInvalidBody()
+		}
+	}
+
+	thread := threads.NewThread(threads.OwnerIDFromCtx(request.Context()), body.Metadata)
+
+	messages := make([]*threads.Message, 0, len(body.Messages))
+
+	for _, m := range body.Messages {
+		message, err := parseCreateMessageRequest(m)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, threads.NewMessage(thread.ID, message.Role, message.Content))
+	}
+
+	err := l.threadStore.CreateThread(request.Context(), thread)
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	err = l.threadStore.AppendMessages(request.Context(), thread.ID, messages...)
+	if err != nil {
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	return thread, nil
+}
+
+func (l *OpenAIChatListener) thread(writer http.ResponseWriter, request *http.Request) (any, error) {
+	threadID := mux.Vars(request)["thread_id"]
+
+	thread, err := threads.GetOwnedThread(request.Context(), l.threadStore, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch request.Method {
+	case http.MethodGet:
+		return thread, nil
+	case http.MethodDelete:
+		err = l.threadStore.DeleteThread(request.Context(), threadID)
+		if err != nil {
+			if errors.Is(err, threads.ErrThreadNotFound) {
+				return nil, openai.NewErrorThreadNotFound(threadID)
+			}
+
+			return nil, openai.NewErrorInternalError().WithCause(err)
+		}
+
+		return &deleteThreadResponse{
+			ID:      threadID,
+			Object:  "thread.deleted",
+			Deleted: true,
+		}, nil
+	default:
+		return nil, openai.NewErrorNotFound(request.Method, request.URL.Path)
+	}
+}
+
+func (l *OpenAIChatListener) threadMessages(writer http.ResponseWriter, request *http.Request) (any, error) {
+	threadID := mux.Vars(request)["thread_id"]
+
+	_, err := threads.GetOwnedThread(request.Context(), l.threadStore, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch request.Method {
+	case http.MethodGet:
+		messages, err := l.threadStore.ListMessages(request.Context(), threadID)
+		if err != nil {
+			return nil, openai.NewErrorInternalError().WithCause(err)
+		}
+
+		return &listMessagesResponse{
+			Object: "list",
+			Data:   messages,
+		}, nil
+	case http.MethodPost:
+		_, parsed, err := utils.ReadAsJSONWithClose(request.Body)
+		if err != nil {
+			return nil, openai.NewErrorInvalidBody()
+		}
+
+		body, err := utils.FromMap[createMessageRequest](parsed)
+		if err != nil {
+			return nil, openai.NewErrorInvalidBody()
+		}
+
+		m, err := parseCreateMessageRequest(lo.FromPtr(body))
+		if err != nil {
+			return nil, err
+		}
+
+		message := threads.NewMessage(threadID, m.Role, m.Content)
+
+		err = l.threadStore.AppendMessages(request.Context(), threadID, message)
+		if err != nil {
+			return nil, openai.NewErrorInternalError().WithCause(err)
+		}
+
+		return message, nil
+	default:
+		return nil, openai.NewErrorNotFound(request.Method, request.URL.Path)
+	}
+}
@@ -0,0 +1,38 @@
+package image
+
+import (
+	"net/http"
+
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+func (l *OpenAIImageListener) unmarshalImageEditsRequestToImageEditsRequest(request *http.Request) (object.LLMRequest, error) {
+	llmRequest, err := openai.NewImageEditsRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return withRequestModel(request, llmRequest)
+}
+
+func (l *OpenAIImageListener) unmarshalImageVariationsRequestToImageEditsRequest(request *http.Request) (object.LLMRequest, error) {
+	llmRequest, err := openai.NewImageVariationsRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return withRequestModel(request, llmRequest)
+}
+
+func withRequestModel(request *http.Request, llmRequest *openai.ImageEditsRequest) (object.LLMRequest, error) {
+	if llmRequest.GetModel() == "" {
+		return nil, openai.NewErrorMissingModel()
+	}
+
+	rMeta := metadata.RequestMetadataFromCtx(request.Context())
+	rMeta.RequestModel = llmRequest.GetModel()
+
+	return llmRequest, nil
+}
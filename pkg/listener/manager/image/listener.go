@@ -10,8 +10,8 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"knoway.dev/api/listeners/v1alpha1"
+	"knoway.dev/pkg/accesslog"
 	"knoway.dev/pkg/bootkit"
-	"knoway.dev/pkg/constants"
 	"knoway.dev/pkg/filters"
 	"knoway.dev/pkg/listener"
 	"knoway.dev/pkg/registry/config"
@@ -27,6 +27,7 @@ type OpenAIImageListener struct {
 	filters         filters.RequestFilters
 	reversedFilters filters.RequestFilters
 	cancellable     *listener.CancellableRequestMap
+	accessLog       *accesslog.Logger
 
 	mutex   sync.RWMutex
 	drained bool
@@ -53,9 +54,16 @@ func NewOpenAIImageListenerConfigs(cfg proto.Message, lifecycle bootkit.LifeCycl
 			return nil, err
 		}
 
-		l.filters = append(l.filters, f)
+		l.filters = append(l.filters, filters.WithFeatureFlag(f, fc.GetFeatureFlag()))
 	}
 
+	accessLog, err := accesslog.NewLoggerWithConfig(c.GetAccessLog())
+	if err != nil {
+		return nil, err
+	}
+
+	l.accessLog = accessLog
+
 	l.reversedFilters = utils.Clone(l.filters)
 	mutable.Reverse(l.reversedFilters)
 
@@ -66,7 +74,10 @@ func (l *OpenAIImageListener) RegisterRoutes(mux *mux.Router) error {
 	middlewares := listener.WithMiddlewares(
 		listener.WithCancellable(l.cancellable),
 		listener.WithInitMetadata(),
-		listener.WithAccessLog(l.cfg.GetAccessLog().GetEnable()),
+		listener.WithTracing(),
+		listener.WithAccessLog(l.accessLog),
+		listener.WithRouteMetrics(),
+		listener.WithExportMetadata(),
 		listener.WithRequestTimer(),
 		listener.WithOptions(),
 		listener.WithResponseHandler(openai.ResponseHandler()),
@@ -75,6 +86,8 @@ func (l *OpenAIImageListener) RegisterRoutes(mux *mux.Router) error {
 	)
 
 	mux.HandleFunc("/v1/images/generations", listener.HTTPHandlerFunc(middlewares(listener.CommonListenerHandler(l.filters, l.reversedFilters, l.unmarshalImageGenerationsRequestToImageGenerationRequest))))
+	mux.HandleFunc("/v1/images/edits", listener.HTTPHandlerFunc(middlewares(listener.CommonListenerHandler(l.filters, l.reversedFilters, l.unmarshalImageEditsRequestToImageEditsRequest))))
+	mux.HandleFunc("/v1/images/variations", listener.HTTPHandlerFunc(middlewares(listener.CommonListenerHandler(l.filters, l.reversedFilters, l.unmarshalImageVariationsRequestToImageEditsRequest))))
 
 	return nil
 }
@@ -91,7 +104,8 @@ func (l *OpenAIImageListener) Drain(ctx context.Context) error {
 	l.drained = true
 	l.mutex.Unlock()
 
-	l.cancellable.CancelAllAfterWithContext(ctx, constants.DefaultDrainWaitTime)
+	l.cancellable.WaitOrCancelAllWithContext(ctx)
 
-	return nil
+	// The entries of the requests drained are flushed before closing
+	return l.accessLog.Close()
 }
@@ -0,0 +1,21 @@
+package moderation
+
+import (
+	"net/http"
+
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+func (l *OpenAIModerationListener) unmarshalModerationsRequestToLLMRequest(request *http.Request) (object.LLMRequest, error) {
+	llmRequest, err := openai.NewModerationsRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	rMeta := metadata.RequestMetadataFromCtx(request.Context())
+	rMeta.RequestModel = llmRequest.GetModel()
+
+	return llmRequest, nil
+}
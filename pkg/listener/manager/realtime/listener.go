@@ -0,0 +1,109 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/samber/lo/mutable"
+	"google.golang.org/protobuf/proto"
+
+	"knoway.dev/api/listeners/v1alpha1"
+	"knoway.dev/pkg/accesslog"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/listener"
+	"knoway.dev/pkg/registry/config"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/utils"
+)
+
+var _ listener.Listener = (*OpenAIRealtimeListener)(nil)
+var _ listener.Drainable = (*OpenAIRealtimeListener)(nil)
+
+type OpenAIRealtimeListener struct {
+	cfg             *v1alpha1.RealtimeListener
+	filters         filters.RequestFilters
+	reversedFilters filters.RequestFilters
+	cancellable     *listener.CancellableRequestMap
+	accessLog       *accesslog.Logger
+
+	mutex   sync.RWMutex
+	drained bool
+}
+
+func NewOpenAIRealtimeListenerConfigs(cfg proto.Message, lifecycle bootkit.LifeCycle) (listener.Listener, error) {
+	c, ok := cfg.(*v1alpha1.RealtimeListener)
+	if !ok {
+		return nil, fmt.Errorf("invalid config type %T", cfg)
+	}
+
+	l := &OpenAIRealtimeListener{
+		cfg:         c,
+		cancellable: listener.NewCancellableRequestMap(),
+	}
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStop: l.Drain,
+	})
+
+	for _, fc := range c.GetFilters() {
+		f, err := config.NewRequestFilterWithConfig(fc.GetName(), fc.GetConfig(), lifecycle)
+		if err != nil {
+			return nil, err
+		}
+
+		l.filters = append(l.filters, filters.WithFeatureFlag(f, fc.GetFeatureFlag()))
+	}
+
+	accessLog, err := accesslog.NewLoggerWithConfig(c.GetAccessLog())
+	if err != nil {
+		return nil, err
+	}
+
+	l.accessLog = accessLog
+
+	l.reversedFilters = utils.Clone(l.filters)
+	mutable.Reverse(l.reversedFilters)
+
+	return l, nil
+}
+
+func (l *OpenAIRealtimeListener) RegisterRoutes(mux *mux.Router) error {
+	middlewares := listener.WithMiddlewares(
+		listener.WithCancellable(l.cancellable),
+		listener.WithInitMetadata(),
+		listener.WithTracing(),
+		listener.WithAccessLog(l.accessLog),
+		listener.WithRouteMetrics(),
+		listener.WithRequestTimer(),
+		listener.WithOptions(),
+		listener.WithResponseHandler(openai.ResponseHandler()),
+		listener.WithRecoverWithError(),
+		listener.WithRejectAfterDrainedWithError(l),
+		withAPIKeyFromSubprotocols(),
+	)
+
+	mux.HandleFunc("/v1/realtime", listener.HTTPHandlerFunc(middlewares(listener.CommonListenerHandler(l.filters, l.reversedFilters, l.unmarshalRealtimeRequestToLLMRequest))))
+
+	return nil
+}
+
+func (l *OpenAIRealtimeListener) HasDrained() bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.drained
+}
+
+func (l *OpenAIRealtimeListener) Drain(ctx context.Context) error {
+	l.mutex.Lock()
+	l.drained = true
+	l.mutex.Unlock()
+
+	l.cancellable.WaitOrCancelAllWithContext(ctx)
+
+	// The entries of the requests drained are flushed before closing
+	return l.accessLog.Close()
+}
@@ -0,0 +1,47 @@
+package realtime
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/websocket"
+
+	"knoway.dev/pkg/listener"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+func (l *OpenAIRealtimeListener) unmarshalRealtimeRequestToLLMRequest(request *http.Request) (object.LLMRequest, error) {
+	llmRequest, err := openai.NewRealtimeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	rMeta := metadata.RequestMetadataFromCtx(request.Context())
+	rMeta.RequestModel = llmRequest.GetModel()
+
+	return llmRequest, nil
+}
+
+// withAPIKeyFromSubprotocols authenticates the clients unable to set headers,
+// e.g. browsers, with the API keys in the subprotocols as OpenAI does.
+func withAPIKeyFromSubprotocols() listener.Middleware {
+	return func(next listener.HandlerFunc) listener.HandlerFunc {
+		return func(writer http.ResponseWriter, request *http.Request) (any, error) {
+			if request.Header.Get("Authorization") != "" {
+				return next(writer, request)
+			}
+
+			for _, subprotocol := range websocket.Subprotocols(request) {
+				apiKey, ok := strings.CutPrefix(subprotocol, openai.RealtimeAPIKeySubprotocolPrefix)
+				if ok && apiKey != "" {
+					request.Header.Set("Authorization", "Bearer "+apiKey)
+					break
+				}
+			}
+
+			return next(writer, request)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package rerank
+
+import (
+	"net/http"
+
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+func (l *OpenAIRerankListener) unmarshalRerankRequestToLLMRequest(request *http.Request) (object.LLMRequest, error) {
+	llmRequest, err := openai.NewRerankRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if llmRequest.GetModel() == "" {
+		return nil, openai.NewErrorMissingModel()
+	}
+
+	rMeta := metadata.RequestMetadataFromCtx(request.Context())
+	rMeta.RequestModel = llmRequest.GetModel()
+
+	return llmRequest, nil
+}
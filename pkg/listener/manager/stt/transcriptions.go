@@ -0,0 +1,34 @@
+package stt
+
+import (
+	"net/http"
+
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/types/stt"
+)
+
+func (l *OpenAISpeechToTextListener) unmarshalTranscriptionsRequestToLLMRequest(request *http.Request) (object.LLMRequest, error) {
+	return l.unmarshalSpeechToTextRequestToLLMRequest(request, stt.TaskTranscriptions)
+}
+
+func (l *OpenAISpeechToTextListener) unmarshalTranslationsRequestToLLMRequest(request *http.Request) (object.LLMRequest, error) {
+	return l.unmarshalSpeechToTextRequestToLLMRequest(request, stt.TaskTranslations)
+}
+
+func (l *OpenAISpeechToTextListener) unmarshalSpeechToTextRequestToLLMRequest(request *http.Request, task stt.Task) (object.LLMRequest, error) {
+	llmRequest, err := openai.NewSpeechToTextRequest(request, task)
+	if err != nil {
+		return nil, err
+	}
+
+	if llmRequest.GetModel() == "" {
+		return nil, openai.NewErrorMissingModel()
+	}
+
+	rMeta := metadata.RequestMetadataFromCtx(request.Context())
+	rMeta.RequestModel = llmRequest.GetModel()
+
+	return llmRequest, nil
+}
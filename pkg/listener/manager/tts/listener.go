@@ -10,8 +10,8 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"knoway.dev/api/listeners/v1alpha1"
+	"knoway.dev/pkg/accesslog"
 	"knoway.dev/pkg/bootkit"
-	"knoway.dev/pkg/constants"
 	"knoway.dev/pkg/filters"
 	"knoway.dev/pkg/listener"
 	"knoway.dev/pkg/registry/config"
@@ -27,6 +27,7 @@ type OpenAITextToSpeechListener struct {
 	filters         filters.RequestFilters
 	reversedFilters filters.RequestFilters
 	cancellable     *listener.CancellableRequestMap
+	accessLog       *accesslog.Logger
 
 	mutex   sync.RWMutex
 	drained bool
@@ -53,9 +54,16 @@ func NewOpenAITextToSpeechListenerConfigs(cfg proto.Message, lifecycle bootkit.L
 			return nil, err
 		}
 
-		l.filters = append(l.filters, f)
+		l.filters = append(l.filters, filters.WithFeatureFlag(f, fc.GetFeatureFlag()))
 	}
 
+	accessLog, err := accesslog.NewLoggerWithConfig(c.GetAccessLog())
+	if err != nil {
+		return nil, err
+	}
+
+	l.accessLog = accessLog
+
 	l.reversedFilters = utils.Clone(l.filters)
 	mutable.Reverse(l.reversedFilters)
 
@@ -66,7 +74,10 @@ func (l *OpenAITextToSpeechListener) RegisterRoutes(mux *mux.Router) error {
 	middlewares := listener.WithMiddlewares(
 		listener.WithCancellable(l.cancellable),
 		listener.WithInitMetadata(),
-		listener.WithAccessLog(l.cfg.GetAccessLog().GetEnable()),
+		listener.WithTracing(),
+		listener.WithAccessLog(l.accessLog),
+		listener.WithRouteMetrics(),
+		listener.WithExportMetadata(),
 		listener.WithRequestTimer(),
 		listener.WithOptions(),
 		listener.WithResponseHandler(openai.ResponseHandler()),
@@ -74,7 +85,7 @@ func (l *OpenAITextToSpeechListener) RegisterRoutes(mux *mux.Router) error {
 		listener.WithRejectAfterDrainedWithError(l),
 	)
 
-	mux.HandleFunc("/v1/audio/speech", listener.HTTPHandlerFunc(middlewares(listener.CommonListenerHandler(l.filters, l.reversedFilters, l.unmarshalTextToSpeechRequestToLLMRequest))))
+	mux.HandleFunc("/v1/audio/speech", listener.HTTPHandlerFunc(middlewares(withArtifactURL(listener.CommonListenerHandler(l.filters, l.reversedFilters, l.unmarshalTextToSpeechRequestToLLMRequest)))))
 	mux.HandleFunc("/v1/audio/voices", listener.HTTPHandlerFunc(middlewares(l.listVoices)))
 
 	return nil
@@ -92,7 +103,8 @@ func (l *OpenAITextToSpeechListener) Drain(ctx context.Context) error {
 	l.drained = true
 	l.mutex.Unlock()
 
-	l.cancellable.CancelAllAfterWithContext(ctx, constants.DefaultDrainWaitTime)
+	l.cancellable.WaitOrCancelAllWithContext(ctx)
 
-	return nil
+	// The entries of the requests drained are flushed before closing
+	return l.accessLog.Close()
 }
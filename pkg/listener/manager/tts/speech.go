@@ -1,11 +1,20 @@
 package tts
 
 import (
+	"bytes"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/samber/lo"
+
+	"knoway.dev/pkg/artifacts"
+	"knoway.dev/pkg/listener"
 	"knoway.dev/pkg/metadata"
 	"knoway.dev/pkg/object"
 	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/types/tts"
 )
 
 func (l *OpenAITextToSpeechListener) unmarshalTextToSpeechRequestToLLMRequest(request *http.Request) (object.LLMRequest, error) {
@@ -23,3 +32,59 @@ func (l *OpenAITextToSpeechListener) unmarshalTextToSpeechRequestToLLMRequest(re
 
 	return llmRequest, nil
 }
+
+// artifactResponse is returned instead of the audio when clients ask for the
+// signed URL of the stored audio with the X-Knoway-Artifact-URL header.
+type artifactResponse struct {
+	URL         string    `json:"url"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// withArtifactURL stores the generated audio as an artifact and responds with
+// its signed URL when asked to, so that long audio can be fetched later with
+// range requests. The audio is returned as is if artifacts are not enabled.
+func withArtifactURL(next listener.HandlerFunc) listener.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) (any, error) {
+		resp, err := next(writer, request)
+		if err != nil {
+			return resp, err
+		}
+
+		store := artifacts.Global()
+		if store == nil {
+			return resp, err
+		}
+
+		if wants, _ := strconv.ParseBool(request.Header.Get(artifacts.HeaderArtifactURL)); !wants {
+			return resp, err
+		}
+
+		audio, ok := resp.(*tts.AudioResponse)
+		if !ok || audio.Error != nil || audio.GetStatus() >= http.StatusMultipleChoices {
+			return resp, err
+		}
+
+		var body io.Reader = bytes.NewReader(audio.BodyBytes)
+		if audio.Body != nil {
+			defer func() { _ = audio.Body.Close() }()
+
+			body = audio.Body
+		}
+
+		contentType := lo.CoalesceOrEmpty(audio.ContentType, "audio/mpeg")
+
+		artifact, err := store.Put(request.Context(), contentType, body)
+		if err != nil {
+			return nil, openai.NewErrorInternalError().WithCause(err)
+		}
+
+		return &artifactResponse{
+			URL:         store.SignedURL(artifact),
+			ContentType: contentType,
+			Size:        artifact.Size,
+			ExpiresAt:   artifact.ExpiresAt,
+		}, nil
+	}
+}
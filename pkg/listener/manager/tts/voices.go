@@ -19,6 +19,7 @@ import (
 
 	v1alpha1 "knoway.dev/api/clusters/v1alpha1"
 	clustermanager "knoway.dev/pkg/clusters/manager"
+	"knoway.dev/pkg/filters"
 	"knoway.dev/pkg/filters/auth"
 	"knoway.dev/pkg/metadata"
 )
@@ -110,7 +111,9 @@ func listVoicesForCluster(request *http.Request, cluster *v1alpha1.Cluster) ([]t
 func (l *OpenAITextToSpeechListener) listVoices(_ http.ResponseWriter, request *http.Request) (any, error) {
 	// Run pre-filters (auth, etc.)
 	for _, f := range l.filters.OnRequestPreFilters() {
-		fResult := f.OnRequestPre(request.Context(), request)
+		fResult := filters.Observe(request.Context(), f, filters.StageOnRequestPre, func() filters.RequestFilterResult {
+			return f.OnRequestPre(request.Context(), request)
+		})
 		if fResult.IsFailed() {
 			return nil, fResult.Error
 		}
@@ -0,0 +1,57 @@
+package video
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+func (l *OpenAIVideoListener) unmarshalVideoGenerationsRequestToLLMRequest(request *http.Request) (object.LLMRequest, error) {
+	if request.Method != http.MethodPost {
+		return nil, openai.NewErrorMethodNotAllowed(request.Method, request.URL.Path)
+	}
+
+	llmRequest, err := openai.NewVideoGenerationsRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if llmRequest.GetModel() == "" {
+		return nil, openai.NewErrorMissingModel()
+	}
+
+	rMeta := metadata.RequestMetadataFromCtx(request.Context())
+	rMeta.RequestModel = llmRequest.GetModel()
+
+	return llmRequest, nil
+}
+
+func (l *OpenAIVideoListener) unmarshalRetrieveVideoRequestToLLMRequest(request *http.Request) (object.LLMRequest, error) {
+	return unmarshalVideoJobRequest(request, openai.VideoOperationRetrieve)
+}
+
+func (l *OpenAIVideoListener) unmarshalVideoContentRequestToLLMRequest(request *http.Request) (object.LLMRequest, error) {
+	return unmarshalVideoJobRequest(request, openai.VideoOperationContent)
+}
+
+// unmarshalVideoJobRequest parses the requests on the submitted jobs, they
+// are authorized and routed with the model the jobs were submitted with.
+func unmarshalVideoJobRequest(request *http.Request, operation openai.VideoOperation) (object.LLMRequest, error) {
+	if request.Method != http.MethodGet {
+		return nil, openai.NewErrorMethodNotAllowed(request.Method, request.URL.Path)
+	}
+
+	llmRequest, err := openai.NewVideoJobRequest(request, operation, mux.Vars(request)["video_id"])
+	if err != nil {
+		return nil, err
+	}
+
+	rMeta := metadata.RequestMetadataFromCtx(request.Context())
+	rMeta.RequestModel = llmRequest.GetModel()
+
+	return llmRequest, nil
+}
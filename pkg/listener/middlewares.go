@@ -8,80 +8,237 @@ import (
 	"sync"
 	"time"
 
+	"knoway.dev/pkg/accesslog"
+	"knoway.dev/pkg/constants"
 	"knoway.dev/pkg/metadata"
 
 	"github.com/nekomeowww/fo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 
 	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/observation"
 	"knoway.dev/pkg/types/openai"
 	"knoway.dev/pkg/utils"
 )
 
-func WithAccessLog(enable bool) Middleware {
+const (
+	// drainPollInterval is how often draining checks whether the requests in
+	// flight have finished.
+	drainPollInterval = 100 * time.Millisecond
+)
+
+// WithAccessLog writes an access log entry of every request to the sinks of
+// logger, nil disables the access log.
+func WithAccessLog(logger *accesslog.Logger) Middleware {
 	return func(next HandlerFunc) HandlerFunc {
 		return func(writer http.ResponseWriter, request *http.Request) (any, error) {
 			resp, err := next(writer, request)
 
-			if enable {
-				rMeta := metadata.RequestMetadataFromCtx(request.Context())
-
-				// TODO: make fields configurable
-				attrs := []any{
-					slog.String("method", request.Method),
-					slog.String("protocol", request.Proto),
-					slog.String("host", request.Host),
-					slog.String("uri", request.RequestURI),
-					slog.String("remote_address", request.RemoteAddr),
-					slog.String("x_forwarded_for", request.Header.Get("X-Forwarded-For")),
-					slog.Duration("response_duration", rMeta.RespondAt.Sub(rMeta.RequestAt)),
-					slog.String("auth_info_api_key_id", rMeta.AuthInfo.GetApiKeyId()),
-					slog.String("auth_info_user_id", rMeta.AuthInfo.GetUserId()),
-					slog.String("request_model", rMeta.RequestModel),
-					slog.String("response_model", rMeta.ResponseModel),
-					slog.Int("response_status", rMeta.StatusCode),
-					slog.String("upstream_provider", rMeta.UpstreamProvider.String()),
-					slog.String("upstream_request_model", rMeta.UpstreamRequestModel),
-					slog.String("upstream_response_model", rMeta.UpstreamResponseModel),
-					slog.Int("upstream_response_status_code", rMeta.UpstreamResponseStatusCode),
-				}
+			if logger.Enabled() {
+				logger.Log(request.Context(), accessLogEntry(request))
+			}
 
-				if rMeta.LLMUpstreamTokensUsage.IsPresent() {
-					attrs = append(attrs,
-						slog.Uint64("llm_usage_prompt_tokens", rMeta.LLMUpstreamTokensUsage.MustGet().GetPromptTokens()),
-						slog.Uint64("llm_usage_completion_tokens", rMeta.LLMUpstreamTokensUsage.MustGet().GetCompletionTokens()),
-					)
-				}
+			return resp, err
+		}
+	}
+}
 
-				if rMeta.LLMUpstreamImagesUsage.IsPresent() {
-					attrs = append(attrs,
-						slog.Uint64("llm_usage_images", uint64(len(rMeta.LLMUpstreamImagesUsage.MustGet().GetOutputImages()))),
-					)
-				}
+func accessLogEntry(request *http.Request) accesslog.Entry {
+	rMeta := metadata.RequestMetadataFromCtx(request.Context())
+	attempts := rMeta.UpstreamAttempts()
+	lastAttempt := rMeta.LastUpstreamAttemptValue()
+
+	entry := accesslog.Entry{
+		{Key: "method", Value: request.Method},
+		{Key: "protocol", Value: request.Proto},
+		{Key: "host", Value: request.Host},
+		{Key: "uri", Value: request.RequestURI},
+		{Key: "remote_address", Value: request.RemoteAddr},
+		{Key: "x_forwarded_for", Value: request.Header.Get("X-Forwarded-For")},
+		{Key: "response_duration", Value: rMeta.RespondAt.Sub(rMeta.RequestAt)},
+		{Key: "auth_info_api_key_id", Value: rMeta.AuthInfo.GetApiKeyId()},
+		{Key: "auth_info_user_id", Value: rMeta.AuthInfo.GetUserId()},
+		{Key: "auth_info_tenant_id", Value: rMeta.AuthInfo.GetTenantId()},
+		{Key: "request_model", Value: rMeta.RequestModel},
+		{Key: "response_model", Value: rMeta.ResponseModel},
+		{Key: "served_model", Value: rMeta.ServedModel},
+		{Key: "serving_target", Value: rMeta.ServingTarget},
+		{Key: "response_status", Value: rMeta.StatusCode},
+		{Key: "upstream_provider", Value: lastAttempt.Provider.String()},
+		{Key: "upstream_request_model", Value: lastAttempt.RequestModel},
+		{Key: "upstream_response_model", Value: lastAttempt.ResponseModel},
+		{Key: "upstream_response_status_code", Value: lastAttempt.ResponseStatusCode},
+		{Key: "upstream_attempts_count", Value: len(attempts)},
+	}
 
-				if !rMeta.UpstreamRespondAt.IsZero() {
-					attrs = append(attrs,
-						slog.Duration("upstream_duration", rMeta.UpstreamRespondAt.Sub(rMeta.UpstreamRequestAt)),
-					)
-				}
+	if rMeta.ResponseCached {
+		entry = append(entry, accesslog.Field{Key: "response_cached", Value: true})
+	}
 
-				if !rMeta.UpstreamFirstValidChunkAt.IsZero() {
-					attrs = append(attrs,
-						slog.Duration("upstream_first_chunk_duration", rMeta.UpstreamFirstValidChunkAt.Sub(rMeta.UpstreamRequestAt)),
-					)
-				}
+	if rMeta.PromptTokensSaved > 0 {
+		entry = append(entry, accesslog.Field{Key: "prompt_tokens_saved", Value: rMeta.PromptTokensSaved})
+	}
 
-				slog.Info("", attrs...)
-			}
+	if rMeta.ImageInputs > 0 {
+		entry = append(entry,
+			accesslog.Field{Key: "image_inputs", Value: rMeta.ImageInputs},
+			accesslog.Field{Key: "image_input_tokens", Value: rMeta.ImageInputTokens},
+		)
+	}
 
-			return resp, err
+	if rMeta.RealtimeResponses > 0 {
+		entry = append(entry,
+			accesslog.Field{Key: "realtime_responses", Value: rMeta.RealtimeResponses},
+			accesslog.Field{Key: "realtime_audio_input_tokens", Value: rMeta.RealtimeAudioInputTokens},
+			accesslog.Field{Key: "realtime_audio_output_tokens", Value: rMeta.RealtimeAudioOutputTokens},
+		)
+	}
+
+	if len(rMeta.PIIRedacted) > 0 {
+		entry = append(entry, accesslog.Field{Key: "pii_redacted", Value: rMeta.PIIRedacted})
+	}
+
+	if rMeta.PromptModeration != nil {
+		entry = append(entry, accesslog.Field{Key: "prompt_moderation", Value: rMeta.PromptModeration})
+	}
+
+	if rMeta.OutputModeration != nil {
+		entry = append(entry, accesslog.Field{Key: "output_moderation", Value: rMeta.OutputModeration})
+	}
+
+	if names := rMeta.FeatureFlags.EnabledNames(); len(names) > 0 {
+		entry = append(entry, accesslog.Field{Key: "feature_flags", Value: names})
+	}
+
+	if len(attempts) > 1 {
+		entry = append(entry, accesslog.Field{Key: "upstream_attempts", Value: upstreamAttemptsLogValue(attempts)})
+	}
+
+	if rMeta.LLMUpstreamTokensUsage.IsPresent() {
+		entry = append(entry,
+			accesslog.Field{Key: "llm_usage_prompt_tokens", Value: rMeta.LLMUpstreamTokensUsage.MustGet().GetPromptTokens()},
+			accesslog.Field{Key: "llm_usage_completion_tokens", Value: rMeta.LLMUpstreamTokensUsage.MustGet().GetCompletionTokens()},
+		)
+	}
+
+	if rMeta.LLMUpstreamImagesUsage.IsPresent() {
+		entry = append(entry,
+			accesslog.Field{Key: "llm_usage_images", Value: uint64(len(rMeta.LLMUpstreamImagesUsage.MustGet().GetOutputImages()))},
+		)
+	}
+
+	if rMeta.LLMUpstreamVideosUsage.IsPresent() {
+		entry = append(entry,
+			accesslog.Field{Key: "llm_usage_videos", Value: uint64(len(rMeta.LLMUpstreamVideosUsage.MustGet().GetOutputVideos()))},
+		)
+	}
+
+	if cost, ok := rMeta.Cost.Get(); ok {
+		entry = append(entry,
+			accesslog.Field{Key: "cost", Value: cost},
+			accesslog.Field{Key: "cost_currency", Value: rMeta.CostCurrency},
+		)
+	}
+
+	if seed, ok := rMeta.Seed.Get(); ok {
+		entry = append(entry, accesslog.Field{Key: "seed", Value: seed})
+	}
+
+	if rMeta.SystemFingerprint != "" {
+		entry = append(entry, accesslog.Field{Key: "system_fingerprint", Value: rMeta.SystemFingerprint})
+	}
+
+	if lastAttempt.Duration() > 0 {
+		entry = append(entry, accesslog.Field{Key: "upstream_duration", Value: lastAttempt.Duration()})
+	}
+
+	if lastAttempt.FirstChunkDuration() > 0 {
+		entry = append(entry, accesslog.Field{Key: "upstream_first_chunk_duration", Value: lastAttempt.FirstChunkDuration()})
+	}
+
+	// Per-filter breakdown is verbose, only for debugging tail latency
+	if slog.Default().Enabled(request.Context(), slog.LevelDebug) {
+		if durations := rMeta.FilterDurations(); len(durations) > 0 {
+			entry = append(entry, accesslog.Field{Key: "filter_durations", Value: filterDurationsLogValue(durations)})
 		}
 	}
+
+	return entry
+}
+
+func upstreamAttemptsLogValue(attempts []metadata.UpstreamAttempt) []map[string]any {
+	values := make([]map[string]any, 0, len(attempts))
+
+	for _, attempt := range attempts {
+		value := map[string]any{
+			"cluster":     attempt.Cluster,
+			"target":      attempt.Target,
+			"status_code": attempt.ResponseStatusCode,
+			"duration":    attempt.Duration().String(),
+		}
+		if attempt.ResponseErrorMessage != "" {
+			value["error"] = attempt.ResponseErrorMessage
+		}
+
+		values = append(values, value)
+	}
+
+	return values
+}
+
+func filterDurationsLogValue(durations []metadata.FilterDuration) []map[string]any {
+	values := make([]map[string]any, 0, len(durations))
+
+	for _, d := range durations {
+		values = append(values, map[string]any{
+			"kind":     d.Kind,
+			"filter":   d.Filter,
+			"stage":    d.Stage,
+			"count":    d.Count,
+			"duration": d.Total.String(),
+		})
+	}
+
+	return values
 }
 
 func WithInitMetadata() Middleware {
 	return func(next HandlerFunc) HandlerFunc {
 		return func(writer http.ResponseWriter, request *http.Request) (any, error) {
-			return next(writer, request.WithContext(metadata.InitMetadataContext(request)))
+			ctx := metadata.InitMetadataContext(request)
+
+			untrack := metadata.TrackInflight(request, metadata.RequestMetadataFromCtx(ctx))
+			defer untrack()
+
+			return next(writer, request.WithContext(ctx))
+		}
+	}
+}
+
+// WithTracing starts the server span of the request, continuing the trace
+// propagated by the client if any, it must be placed inside of
+// WithInitMetadata.
+func WithTracing() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(writer http.ResponseWriter, request *http.Request) (any, error) {
+			ctx := otel.GetTextMapPropagator().Extract(request.Context(), propagation.HeaderCarrier(request.Header))
+
+			ctx, span := observation.Tracer().Start(ctx, observation.SpanNameRequest,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", request.Method),
+					attribute.String("url.path", request.URL.Path),
+				),
+			)
+			defer span.End()
+
+			resp, err := next(writer, request.WithContext(ctx))
+			observation.EndRequestSpan(span, metadata.RequestMetadataFromCtx(ctx))
+
+			return resp, err
 		}
 	}
 }
@@ -197,6 +354,38 @@ func (l *CancellableRequestMap) CancelAllAfterWithContext(ctx context.Context, t
 	})
 }
 
+// Len returns the number of requests in flight.
+func (l *CancellableRequestMap) Len() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return len(l.requestCancelMap)
+}
+
+// WaitOrCancelAllWithContext waits for the requests in flight, including
+// streaming ones, to finish, and cancels the remaining ones once ctx is done,
+// or after constants.DefaultDrainWaitTime if ctx has no deadline.
+func (l *CancellableRequestMap) WaitOrCancelAllWithContext(ctx context.Context) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, constants.DefaultDrainWaitTime)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for l.Len() > 0 {
+		select {
+		case <-ctx.Done():
+			l.CancelAll()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func WithCancellable(cancellable *CancellableRequestMap) Middleware {
 	return func(next HandlerFunc) HandlerFunc {
 		return func(writer http.ResponseWriter, request *http.Request) (any, error) {
@@ -234,6 +423,21 @@ func WithRequestTimer() Middleware {
 	}
 }
 
+// WithRouteMetrics records the finished request in the metrics of the gateway
+// and of the route that matched it, it must be placed outside of
+// WithRequestTimer.
+func WithRouteMetrics() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(writer http.ResponseWriter, request *http.Request) (any, error) {
+			resp, err := next(writer, request)
+			observation.ObserveRequest(metadata.RequestMetadataFromCtx(request.Context()))
+			observation.ObserveRouteRequest(metadata.RequestMetadataFromCtx(request.Context()))
+
+			return resp, err
+		}
+	}
+}
+
 func WithResponseHandler(fn func(resp any, err error, writer http.ResponseWriter, request *http.Request)) Middleware {
 	return func(next HandlerFunc) HandlerFunc {
 		return func(writer http.ResponseWriter, request *http.Request) (any, error) {
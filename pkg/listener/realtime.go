@@ -0,0 +1,84 @@
+package listener
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+var realtimeUpgrader = websocket.Upgrader{
+	// The other subprotocols of the clients carry API keys and are never
+	// selected
+	Subprotocols: []string{openai.RealtimeSubprotocol},
+}
+
+// relayRealtimeSession upgrades the connection of the client and relays the
+// session until either side closes it, the OnRealtimeResponse filters are
+// then applied with the usage accumulated over the session.
+func relayRealtimeSession(
+	ctx context.Context,
+	reversedFilters filters.RequestFilters,
+	llmRequest object.LLMRequest,
+	resp object.LLMResponse,
+	writer http.ResponseWriter,
+	request *http.Request,
+) error {
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+
+	session, ok := resp.(*openai.RealtimeSession)
+	if !ok {
+		return openai.NewErrorInternalError().WithCausef("failed to cast %T to *openai.RealtimeSession", resp)
+	}
+
+	client, err := realtimeUpgrader.Upgrade(writer, request, nil)
+	if err != nil {
+		_ = session.Close()
+
+		// The error has been responded by the upgrader
+		rMeta.StatusCode = http.StatusBadRequest
+		rMeta.ErrorMessage = err.Error()
+
+		return nil
+	}
+
+	rMeta.StatusCode = http.StatusSwitchingProtocols
+
+	err = session.Relay(ctx, client)
+	if err != nil {
+		slog.DebugContext(ctx, "realtime session ended with error", slog.String("model", llmRequest.GetModel()), slog.Any("error", err))
+	}
+
+	if usage, ok := object.AsLLMTokensUsage(session.GetUsage()); ok && !lo.IsNil(usage) {
+		rMeta.LLMUpstreamTokensUsage = mo.Some(usage)
+	}
+
+	if usage, ok := session.GetUsage().(*openai.ChatCompletionsUsage); ok && usage != nil {
+		rMeta.RealtimeAudioInputTokens = usage.PromptTokensDetails.AudioTokens
+		rMeta.RealtimeAudioOutputTokens = usage.CompletionTokensDetails.AudioTokens
+	}
+
+	rMeta.RealtimeResponses = session.GetResponses()
+
+	// The session is over, the context of the request may have been canceled
+	filtersCtx := context.WithoutCancel(ctx)
+
+	for _, f := range reversedFilters.OnRealtimeResponseFilters() {
+		fResult := filters.Observe(filtersCtx, f, filters.StageOnRealtimeResponse, func() filters.RequestFilterResult {
+			return f.OnRealtimeResponse(filtersCtx, llmRequest, session)
+		})
+		if fResult.IsFailed() {
+			slog.Error("error occurred during invoking of OnRealtimeResponse filters", "error", fResult.Error)
+		}
+	}
+
+	return nil
+}
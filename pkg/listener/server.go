@@ -0,0 +1,93 @@
+package listener
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/utils"
+)
+
+// WithMaxURILength rejects requests whose URI is longer than maxLength with
+// 414 Request-URI Too Long, 0 means unlimited.
+func WithMaxURILength(handler http.Handler, maxLength int) http.Handler {
+	if maxLength <= 0 {
+		return handler
+	}
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if len(request.RequestURI) > maxLength {
+			err := openai.NewErrorURITooLong(maxLength)
+			utils.WriteJSONForHTTP(err.Status, err, writer)
+
+			return
+		}
+
+		handler.ServeHTTP(writer, request)
+	})
+}
+
+// matchPathPattern matches the path against the pattern, which is either an
+// exact path, or a prefix when ending with "*", e.g. /v1/threads/*.
+func matchPathPattern(pattern string, path string) bool {
+	prefix, ok := strings.CutSuffix(pattern, "*")
+	if !ok {
+		return pattern == path
+	}
+
+	return strings.HasPrefix(path, prefix)
+}
+
+// longestPathPatternMatch returns the value of the most specific (longest)
+// pattern matching the path.
+func longestPathPatternMatch[T any](patterns map[string]T, path string) (T, bool) {
+	var (
+		matchedPattern string
+		matched        bool
+	)
+
+	for pattern := range patterns {
+		if !matchPathPattern(pattern, path) {
+			continue
+		}
+
+		if !matched || len(pattern) > len(matchedPattern) {
+			matchedPattern = pattern
+			matched = true
+		}
+	}
+
+	return patterns[matchedPattern], matched
+}
+
+// allowedMethodsForPath returns the allowed methods of the most specific
+// (longest) pattern matching the path.
+func allowedMethodsForPath(allowedMethods map[string][]string, path string) ([]string, bool) {
+	return longestPathPatternMatch(allowedMethods, path)
+}
+
+// WithAllowedMethods rejects requests with 405 Method Not Allowed when the
+// method is not in the list of allowed methods of the matched path pattern.
+// Paths matching no pattern are not restricted. Note that OPTIONS must be
+// listed as well if CORS preflight requests are expected.
+func WithAllowedMethods(handler http.Handler, allowedMethods map[string][]string) http.Handler {
+	if len(allowedMethods) == 0 {
+		return handler
+	}
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		methods, ok := allowedMethodsForPath(allowedMethods, request.URL.Path)
+		if ok && !lo.ContainsBy(methods, func(method string) bool { return strings.EqualFold(method, request.Method) }) {
+			writer.Header().Set("Allow", strings.ToUpper(strings.Join(methods, ", ")))
+
+			err := openai.NewErrorMethodNotAllowed(request.Method, request.URL.Path)
+			utils.WriteJSONForHTTP(err.Status, err, writer)
+
+			return
+		}
+
+		handler.ServeHTTP(writer, request)
+	})
+}
@@ -0,0 +1,78 @@
+package listener
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var okHandler = http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+	writer.WriteHeader(http.StatusOK)
+})
+
+func TestWithMaxURILength(t *testing.T) {
+	handler := WithMaxURILength(okHandler, 32)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/models", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/models?"+strings.Repeat("a", 32), nil))
+	assert.Equal(t, http.StatusRequestURITooLong, recorder.Code)
+
+	// Unlimited
+	recorder = httptest.NewRecorder()
+	WithMaxURILength(okHandler, 0).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/models?"+strings.Repeat("a", 32), nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestWithAllowedMethods(t *testing.T) {
+	handler := WithAllowedMethods(okHandler, map[string][]string{
+		"/v1/chat/completions": {"POST"},
+		"/v1/threads/*":        {"get", "post", "delete"},
+	})
+
+	cases := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodPost, "/v1/chat/completions", http.StatusOK},
+		{http.MethodGet, "/v1/chat/completions", http.StatusMethodNotAllowed},
+		{http.MethodDelete, "/v1/threads/thread_1", http.StatusOK},
+		{http.MethodPut, "/v1/threads/thread_1", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/v1/models", http.StatusOK},
+	}
+
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(c.method, c.path, nil))
+		assert.Equal(t, c.status, recorder.Code, "%s %s", c.method, c.path)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/chat/completions", nil))
+	assert.Equal(t, "POST", recorder.Header().Get("Allow"))
+}
+
+func TestAllowedMethodsForPath(t *testing.T) {
+	allowedMethods := map[string][]string{
+		"/v1/*":         {"GET"},
+		"/v1/threads/*": {"POST"},
+	}
+
+	methods, ok := allowedMethodsForPath(allowedMethods, "/v1/threads/thread_1")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"POST"}, methods)
+
+	methods, ok = allowedMethodsForPath(allowedMethods, "/v1/models")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"GET"}, methods)
+
+	_, ok = allowedMethodsForPath(allowedMethods, "/healthz")
+	assert.False(t, ok)
+}
@@ -0,0 +1,139 @@
+package listener
+
+import (
+	"errors"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/types/sse"
+	"knoway.dev/pkg/utils"
+)
+
+// StreamFormat is the wire format of streaming responses written to the
+// clients.
+type StreamFormat string
+
+const (
+	// StreamFormatSSE writes every chunk as a Server-Sent Event, the default.
+	StreamFormatSSE StreamFormat = "sse"
+	// StreamFormatNDJSON writes every chunk as one line of JSON, for clients
+	// that can't consume Server-Sent Events.
+	StreamFormatNDJSON StreamFormat = "ndjson"
+)
+
+const ndjsonMediaType = "application/x-ndjson"
+
+// NegotiateStreamFormat picks the stream format from the Accept header of
+// the request, NDJSON is only used when the client explicitly asks for it.
+func NegotiateStreamFormat(request *http.Request) StreamFormat {
+	for _, accept := range request.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err != nil {
+				continue
+			}
+
+			if mediaType == ndjsonMediaType {
+				return StreamFormatNDJSON
+			}
+		}
+	}
+
+	return StreamFormatSSE
+}
+
+// writeStreamHeaders writes the response headers of the stream format.
+func writeStreamHeaders(writer http.ResponseWriter, format StreamFormat) {
+	switch format {
+	case StreamFormatNDJSON:
+		utils.WriteNDJSONHeadersForHTTP(writer)
+	case StreamFormatSSE:
+		utils.WriteEventStreamHeadersForHTTP(writer)
+	default:
+		utils.WriteEventStreamHeadersForHTTP(writer)
+	}
+}
+
+// writeStreamChunk writes one chunk in the stream format, the chunk content
+// is identical for both formats.
+func writeStreamChunk(writer http.ResponseWriter, format StreamFormat, chunk object.LLMChunkResponse) error {
+	if format != StreamFormatNDJSON {
+		event, err := chunk.ToServerSentEvent()
+		if err != nil {
+			slog.Error("failed to convert chunk body to server sent event payload", "error", err)
+			return err
+		}
+
+		err = event.MarshalTo(writer)
+		if err != nil {
+			slog.Error("failed to write SSE event into http.ResponseWriter", "error", err)
+			return err
+		}
+
+		return nil
+	}
+
+	// The [DONE] sentinel is specific to SSE and not valid JSON, NDJSON
+	// clients rely on the end of the response body instead.
+	if chunk.IsDone() {
+		return nil
+	}
+
+	bs, err := chunk.MarshalJSON()
+	if err != nil {
+		slog.Error("failed to marshal chunk body to JSON", "error", err)
+		return err
+	}
+
+	defer utils.SafeFlush(writer)
+
+	_, err = writer.Write(append(bs, '\n'))
+	if err != nil {
+		slog.Error("failed to write NDJSON line into http.ResponseWriter", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// writeStreamError writes the error terminating the stream in the format of
+// the errors of OpenAI streams, errors other than *openai.ErrorResponse are
+// written as internal errors.
+func writeStreamError(writer http.ResponseWriter, format StreamFormat, err error) error {
+	var errorResponse *openai.ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		errorResponse = openai.NewErrorInternalError().WithCause(err)
+	}
+
+	bs, err := errorResponse.Redacted().MarshalJSON()
+	if err != nil {
+		slog.Error("failed to marshal stream error", "error", err)
+		return err
+	}
+
+	defer utils.SafeFlush(writer)
+
+	if format == StreamFormatNDJSON {
+		_, err = writer.Write(append(bs, '\n'))
+		if err != nil {
+			slog.Error("failed to write NDJSON line into http.ResponseWriter", "error", err)
+			return err
+		}
+
+		return nil
+	}
+
+	event := &sse.Event{Data: bs}
+
+	err = event.MarshalTo(writer)
+	if err != nil {
+		slog.Error("failed to write SSE event into http.ResponseWriter", "error", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package listener
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/pkg/types/openai"
+)
+
+func TestNegotiateStreamFormat(t *testing.T) {
+	testCases := []struct {
+		name   string
+		accept string
+		want   StreamFormat
+	}{
+		{name: "no accept", accept: "", want: StreamFormatSSE},
+		{name: "event stream", accept: "text/event-stream", want: StreamFormatSSE},
+		{name: "ndjson", accept: "application/x-ndjson", want: StreamFormatNDJSON},
+		{name: "ndjson with params", accept: "application/json, application/x-ndjson; q=0.9", want: StreamFormatNDJSON},
+		{name: "wildcard", accept: "*/*", want: StreamFormatSSE},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+
+			assert.Equal(t, tc.want, NegotiateStreamFormat(req))
+		})
+	}
+}
+
+func TestWriteStreamChunk(t *testing.T) {
+	streamResp := &openai.ChatCompletionStreamResponse{}
+
+	chunk, err := openai.NewChatCompletionStreamChunk(streamResp, []byte(`{"model":"gpt-4","choices":[{"delta":{"content":"hi"}}]}`))
+	require.NoError(t, err)
+
+	done := openai.NewDoneChatCompletionStreamChunk(streamResp)
+
+	t.Run("sse", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		writeStreamHeaders(recorder, StreamFormatSSE)
+		require.NoError(t, writeStreamChunk(recorder, StreamFormatSSE, chunk))
+		require.NoError(t, writeStreamChunk(recorder, StreamFormatSSE, done))
+
+		assert.Contains(t, recorder.Header().Get("Content-Type"), "text/event-stream")
+		assert.Equal(t, "data: {\"choices\":[{\"delta\":{\"content\":\"hi\"}}],\"model\":\"gpt-4\"}\n\ndata: [DONE]\n\n", recorder.Body.String())
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		writeStreamHeaders(recorder, StreamFormatNDJSON)
+		require.NoError(t, writeStreamChunk(recorder, StreamFormatNDJSON, chunk))
+		require.NoError(t, writeStreamChunk(recorder, StreamFormatNDJSON, done))
+
+		assert.Contains(t, recorder.Header().Get("Content-Type"), "application/x-ndjson")
+		assert.Equal(t, "{\"choices\":[{\"delta\":{\"content\":\"hi\"}}],\"model\":\"gpt-4\"}\n", recorder.Body.String())
+	})
+}
+
+func TestWriteStreamError(t *testing.T) {
+	err := openai.NewErrorContentPolicyViolation("flagged")
+
+	t.Run("sse", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		require.NoError(t, writeStreamError(recorder, StreamFormatSSE, err))
+
+		assert.Equal(t, "data: {\"error\":{\"code\":\"content_policy_violation\",\"message\":\"flagged\",\"param\":null,\"type\":\"invalid_request_error\"}}\n\n", recorder.Body.String())
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		require.NoError(t, writeStreamError(recorder, StreamFormatNDJSON, err))
+
+		assert.Equal(t, "{\"error\":{\"code\":\"content_policy_violation\",\"message\":\"flagged\",\"param\":null,\"type\":\"invalid_request_error\"}}\n", recorder.Body.String())
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		require.NoError(t, writeStreamError(recorder, StreamFormatNDJSON, errors.New("secret cause")))
+
+		assert.NotContains(t, recorder.Body.String(), "secret cause")
+		assert.Contains(t, recorder.Body.String(), "internal server error")
+	})
+}
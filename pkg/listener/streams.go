@@ -0,0 +1,236 @@
+package listener
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"knoway.dev/pkg/observation"
+	"knoway.dev/pkg/utils"
+)
+
+const (
+	// DefaultStreamReapInterval is how often open streams are checked against
+	// the limits.
+	DefaultStreamReapInterval = 5 * time.Second
+
+	streamReapReasonMaxAge  = "max_age"
+	streamReapReasonMaxIdle = "max_idle"
+)
+
+// ErrStreamReaped is the cause of the contexts of the streams closed by the
+// reaper.
+var ErrStreamReaped = errors.New("stream closed by the gateway")
+
+// StreamLimits bound the lifetime of streaming responses, zero values are
+// unlimited.
+type StreamLimits struct {
+	// MaxAge closes streams open for longer than it, however active they are.
+	MaxAge time.Duration
+	// MaxIdle closes streams that wrote nothing to the client for longer than
+	// it, such as the streams of clients that stopped reading.
+	MaxIdle time.Duration
+}
+
+// OpenStream is a streaming response open to a client, such as SSE or
+// WebSocket, the bytes written through Writer are accounted.
+type OpenStream struct {
+	Format   string
+	OpenedAt time.Time
+
+	streams     *OpenStreams
+	controller  *http.ResponseController
+	cancel      context.CancelCauseFunc
+	bytes       atomic.Int64
+	lastWriteAt atomic.Int64
+	reaped      atomic.Bool
+}
+
+// Bytes returns the bytes written to the client so far.
+func (s *OpenStream) Bytes() int64 {
+	return s.bytes.Load()
+}
+
+// Reaped reports whether the stream is closed by the reaper.
+func (s *OpenStream) Reaped() bool {
+	return s.reaped.Load()
+}
+
+// Writer wraps the writer of the stream to account the bytes written.
+func (s *OpenStream) Writer(writer http.ResponseWriter) http.ResponseWriter {
+	return &streamWriter{ResponseWriter: writer, stream: s}
+}
+
+// Close removes the stream from the accounting, it must be called before
+// the handler of the request returns.
+func (s *OpenStream) Close() {
+	s.streams.remove(s)
+
+	observation.ObserveStreamClosed(s.Format, s.Bytes(), time.Since(s.OpenedAt))
+}
+
+func (s *OpenStream) idle(now time.Time) time.Duration {
+	return now.Sub(time.Unix(0, s.lastWriteAt.Load()))
+}
+
+// reap cancels the context of the stream, and unblocks the writes blocked by
+// clients not reading.
+func (s *OpenStream) reap(reason string) {
+	s.reaped.Store(true)
+	s.cancel(ErrStreamReaped)
+
+	err := s.controller.SetWriteDeadline(time.Now())
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		slog.Debug("failed to set write deadline of reaped stream", "error", err)
+	}
+
+	observation.ObserveStreamReaped(s.Format, reason)
+}
+
+type streamWriter struct {
+	http.ResponseWriter
+
+	stream *OpenStream
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	if n > 0 {
+		w.stream.bytes.Add(int64(n))
+		w.stream.lastWriteAt.Store(time.Now().UnixNano())
+	}
+
+	return n, err
+}
+
+func (w *streamWriter) Flush() {
+	utils.SafeFlush(w.ResponseWriter)
+}
+
+func (w *streamWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// OpenStreams keeps the accounting of the streaming responses open, and
+// reaps the ones exceeding the limits, which protects the gateway from the
+// connections leaked by clients never reading the streams to the end.
+type OpenStreams struct {
+	mutex   sync.Mutex
+	streams map[*OpenStream]struct{}
+}
+
+func NewOpenStreams() *OpenStreams {
+	return &OpenStreams{
+		streams: make(map[*OpenStream]struct{}),
+	}
+}
+
+// Open accounts the stream written to writer, cancel is called with
+// ErrStreamReaped when the stream is reaped.
+func (o *OpenStreams) Open(writer http.ResponseWriter, format string, cancel context.CancelCauseFunc) *OpenStream {
+	now := time.Now()
+
+	stream := &OpenStream{
+		Format:     format,
+		OpenedAt:   now,
+		streams:    o,
+		controller: http.NewResponseController(writer),
+		cancel:     cancel,
+	}
+	stream.lastWriteAt.Store(now.UnixNano())
+
+	o.mutex.Lock()
+	o.streams[stream] = struct{}{}
+	o.mutex.Unlock()
+
+	observation.ObserveStreamOpened(format)
+
+	return stream
+}
+
+func (o *OpenStreams) remove(stream *OpenStream) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	delete(o.streams, stream)
+}
+
+// Len returns the number of streams open.
+func (o *OpenStreams) Len() int {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return len(o.streams)
+}
+
+// Reap closes the streams exceeding the limits at now, and returns the
+// number of them.
+func (o *OpenStreams) Reap(now time.Time, limits StreamLimits) int {
+	// Locked while reaping so that the streams are never reaped after their
+	// handlers returned
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	reaped := 0
+
+	for stream := range o.streams {
+		if stream.Reaped() {
+			continue
+		}
+
+		var reason string
+
+		switch {
+		case limits.MaxAge > 0 && now.Sub(stream.OpenedAt) > limits.MaxAge:
+			reason = streamReapReasonMaxAge
+		case limits.MaxIdle > 0 && stream.idle(now) > limits.MaxIdle:
+			reason = streamReapReasonMaxIdle
+		default:
+			continue
+		}
+
+		slog.Warn("closing stream exceeding the limits",
+			slog.String("format", stream.Format),
+			slog.String("reason", reason),
+			slog.Duration("age", now.Sub(stream.OpenedAt)),
+			slog.Int64("bytes", stream.Bytes()),
+		)
+
+		stream.reap(reason)
+		reaped++
+	}
+
+	return reaped
+}
+
+// Run reaps the streams exceeding the limits every interval until ctx is
+// done, it returns right away if the limits are unlimited.
+func (o *OpenStreams) Run(ctx context.Context, limits StreamLimits, interval time.Duration) {
+	if limits.MaxAge <= 0 && limits.MaxIdle <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			o.Reap(now, limits)
+		}
+	}
+}
+
+var defaultOpenStreams = NewOpenStreams()
+
+// Streams returns the accounting of the streams open to clients of all
+// listeners.
+func Streams() *OpenStreams {
+	return defaultOpenStreams
+}
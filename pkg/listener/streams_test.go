@@ -0,0 +1,104 @@
+package listener
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenStreams(t *testing.T) {
+	streams := NewOpenStreams()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	recorder := httptest.NewRecorder()
+	stream := streams.Open(recorder, string(StreamFormatSSE), cancel)
+	assert.Equal(t, 1, streams.Len())
+
+	writer := stream.Writer(recorder)
+	_, err := writer.Write([]byte("data: {}\n\n"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), stream.Bytes())
+	assert.Equal(t, "data: {}\n\n", recorder.Body.String())
+
+	// Within the limits
+	now := time.Now()
+	assert.Zero(t, streams.Reap(now, StreamLimits{}))
+	assert.Zero(t, streams.Reap(now, StreamLimits{MaxAge: time.Minute, MaxIdle: time.Minute}))
+	require.NoError(t, ctx.Err())
+
+	// Idle
+	assert.Equal(t, 1, streams.Reap(now.Add(2*time.Minute), StreamLimits{MaxAge: time.Hour, MaxIdle: time.Minute}))
+	assert.True(t, stream.Reaped())
+	require.ErrorIs(t, context.Cause(ctx), ErrStreamReaped)
+
+	// Reaped only once
+	assert.Zero(t, streams.Reap(now.Add(2*time.Hour), StreamLimits{MaxAge: time.Hour}))
+
+	stream.Close()
+	assert.Zero(t, streams.Len())
+}
+
+func TestOpenStreams_MaxAge(t *testing.T) {
+	streams := NewOpenStreams()
+
+	_, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	stream := streams.Open(httptest.NewRecorder(), string(StreamFormatNDJSON), cancel)
+	defer stream.Close()
+
+	_, err := stream.Writer(httptest.NewRecorder()).Write([]byte("{}\n"))
+	require.NoError(t, err)
+
+	// Active streams are reaped too once too old
+	assert.Equal(t, 1, streams.Reap(time.Now().Add(2*time.Hour), StreamLimits{MaxAge: time.Hour, MaxIdle: 3 * time.Hour}))
+}
+
+func TestOpenStreams_Run(t *testing.T) {
+	streams := NewOpenStreams()
+	handlerDone := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		defer close(handlerDone)
+
+		ctx, cancel := context.WithCancelCause(request.Context())
+		defer cancel(nil)
+
+		stream := streams.Open(writer, string(StreamFormatSSE), cancel)
+		defer stream.Close()
+
+		writer = stream.Writer(writer)
+		writeStreamHeaders(writer, StreamFormatSSE)
+
+		// Upstreams never respond
+		<-ctx.Done()
+	}))
+	defer server.Close()
+
+	runCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	go streams.Run(runCtx, StreamLimits{MaxAge: 50 * time.Millisecond}, 10*time.Millisecond)
+
+	resp, err := http.Get(server.URL) //nolint:noctx
+	require.NoError(t, err)
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	select {
+	case <-handlerDone:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "stream not reaped")
+	}
+
+	assert.Zero(t, streams.Len())
+}
@@ -0,0 +1,118 @@
+package metadata
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// HeaderExportMetadata is the request header that asks the gateway to
+	// export the metadata of the request, for callers that need per-request
+	// stats without parsing access logs.
+	HeaderExportMetadata = "X-Knoway-Export-Metadata"
+	// TrailerMetadata is the HTTP trailer the metadata is exported in.
+	TrailerMetadata = "X-Knoway-Metadata"
+	// EventMetadata is the type of the server-sent event the metadata is
+	// exported in, written right before the [DONE] event of streams.
+	EventMetadata = "knoway.metadata"
+)
+
+// ExportMetadataRequested reports whether the request asks for the metadata
+// to be exported.
+func ExportMetadataRequested(request *http.Request) bool {
+	requested, err := strconv.ParseBool(request.Header.Get(HeaderExportMetadata))
+	if err != nil {
+		return false
+	}
+
+	return requested
+}
+
+type ExportedUsage struct {
+	PromptTokens     uint64 `json:"prompt_tokens"`
+	CompletionTokens uint64 `json:"completion_tokens"`
+	TotalTokens      uint64 `json:"total_tokens"`
+	Images           int    `json:"images,omitempty"`
+	Videos           int    `json:"videos,omitempty"`
+}
+
+// ExportedMetadata is the machine-readable subset of RequestMetadata
+// exported to the callers.
+type ExportedMetadata struct {
+	RequestModel   string `json:"request_model,omitempty"`
+	ServedModel    string `json:"served_model,omitempty"`
+	ServingTarget  string `json:"serving_target,omitempty"`
+	ResponseCached bool   `json:"response_cached,omitempty"`
+	// PromptTokensSaved is estimated by the prompt compression of the route.
+	PromptTokensSaved uint64 `json:"prompt_tokens_saved,omitempty"`
+	// ImageInputs and ImageInputTokens are counted by the vision filter of the
+	// route.
+	ImageInputs      int    `json:"image_inputs,omitempty"`
+	ImageInputTokens uint64 `json:"image_input_tokens,omitempty"`
+
+	Usage *ExportedUsage `json:"usage,omitempty"`
+	// BillableUnits are computed by the metering expressions of the cluster
+	// that served the request, the cost of the request in the units.
+	BillableUnits map[string]float64 `json:"billable_units,omitempty"`
+
+	DurationMs                  int64 `json:"duration_ms"`
+	UpstreamAttempts            int   `json:"upstream_attempts"`
+	UpstreamLatencyMs           int64 `json:"upstream_latency_ms"`
+	UpstreamFirstChunkLatencyMs int64 `json:"upstream_first_chunk_latency_ms,omitempty"`
+}
+
+// Export returns the metadata to export, durations of unfinished requests
+// are counted until now.
+func (m *RequestMetadata) Export() ExportedMetadata {
+	lastAttempt := m.LastUpstreamAttemptValue()
+
+	exported := ExportedMetadata{
+		RequestModel:                m.RequestModel,
+		ServedModel:                 m.ServedModel,
+		ServingTarget:               m.ServingTarget,
+		ResponseCached:              m.ResponseCached,
+		PromptTokensSaved:           m.PromptTokensSaved,
+		ImageInputs:                 m.ImageInputs,
+		ImageInputTokens:            m.ImageInputTokens,
+		BillableUnits:               m.BillableUnits,
+		UpstreamAttempts:            len(m.UpstreamAttempts()),
+		UpstreamLatencyMs:           lastAttempt.Duration().Milliseconds(),
+		UpstreamFirstChunkLatencyMs: lastAttempt.FirstChunkDuration().Milliseconds(),
+	}
+
+	if !m.RequestAt.IsZero() {
+		respondAt := m.RespondAt
+		if respondAt.IsZero() {
+			respondAt = time.Now()
+		}
+
+		exported.DurationMs = respondAt.Sub(m.RequestAt).Milliseconds()
+	}
+
+	if tokensUsage, ok := m.LLMUpstreamTokensUsage.Get(); ok {
+		exported.Usage = &ExportedUsage{
+			PromptTokens:     tokensUsage.GetPromptTokens(),
+			CompletionTokens: tokensUsage.GetCompletionTokens(),
+			TotalTokens:      tokensUsage.GetTotalTokens(),
+		}
+	}
+
+	if imagesUsage, ok := m.LLMUpstreamImagesUsage.Get(); ok {
+		if exported.Usage == nil {
+			exported.Usage = &ExportedUsage{}
+		}
+
+		exported.Usage.Images = len(imagesUsage.GetOutputImages())
+	}
+
+	if videosUsage, ok := m.LLMUpstreamVideosUsage.Get(); ok {
+		if exported.Usage == nil {
+			exported.Usage = &ExportedUsage{}
+		}
+
+		exported.Usage.Videos = len(videosUsage.GetOutputVideos())
+	}
+
+	return exported
+}
@@ -0,0 +1,63 @@
+package metadata
+
+import (
+	"time"
+)
+
+const (
+	FilterKindRequest = "request"
+	FilterKindCluster = "cluster"
+)
+
+// FilterDuration aggregates how long a filter took to handle one stage of
+// the request. Stages like stream response filters are invoked once per
+// chunk, Count is the number of invocations.
+type FilterDuration struct {
+	// Kind is either FilterKindRequest or FilterKindCluster
+	Kind   string
+	Filter string
+	Stage  string
+	Count  int
+	Total  time.Duration
+}
+
+// AddFilterDuration records a single invocation of the filter, it's safe to
+// call on nil RequestMetadata.
+func (m *RequestMetadata) AddFilterDuration(kind string, filter string, stage string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+
+	m.filterDurationsMutex.Lock()
+	defer m.filterDurationsMutex.Unlock()
+
+	for i := range m.filterDurations {
+		d := &m.filterDurations[i]
+		if d.Kind == kind && d.Filter == filter && d.Stage == stage {
+			d.Count++
+			d.Total += duration
+
+			return
+		}
+	}
+
+	m.filterDurations = append(m.filterDurations, FilterDuration{
+		Kind:   kind,
+		Filter: filter,
+		Stage:  stage,
+		Count:  1,
+		Total:  duration,
+	})
+}
+
+// FilterDurations returns copies of the recorded filter durations in the
+// order the filters were first invoked.
+func (m *RequestMetadata) FilterDurations() []FilterDuration {
+	m.filterDurationsMutex.Lock()
+	defer m.filterDurationsMutex.Unlock()
+
+	durations := make([]FilterDuration, len(m.filterDurations))
+	copy(durations, m.filterDurations)
+
+	return durations
+}
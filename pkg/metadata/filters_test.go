@@ -0,0 +1,37 @@
+package metadata
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterDurations(t *testing.T) {
+	rMeta := &RequestMetadata{}
+
+	assert.Empty(t, rMeta.FilterDurations())
+
+	rMeta.AddFilterDuration(FilterKindRequest, "auth.AuthFilter", "on_request_pre", 10*time.Millisecond)
+	rMeta.AddFilterDuration(FilterKindCluster, "openai.requestHandler", "request_modifier", time.Millisecond)
+	rMeta.AddFilterDuration(FilterKindRequest, "auth.AuthFilter", "on_request_pre", 5*time.Millisecond)
+
+	durations := rMeta.FilterDurations()
+	require.Len(t, durations, 2)
+
+	assert.Equal(t, FilterDuration{
+		Kind:   FilterKindRequest,
+		Filter: "auth.AuthFilter",
+		Stage:  "on_request_pre",
+		Count:  2,
+		Total:  15 * time.Millisecond,
+	}, durations[0])
+	assert.Equal(t, "openai.requestHandler", durations[1].Filter)
+
+	var nilMeta *RequestMetadata
+
+	assert.NotPanics(t, func() {
+		nilMeta.AddFilterDuration(FilterKindRequest, "auth.AuthFilter", "on_request_pre", time.Millisecond)
+	})
+}
@@ -0,0 +1,67 @@
+package metadata
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// InflightRequest is a snapshot of a request that is still being handled.
+type InflightRequest struct {
+	Method           string
+	Path             string
+	StartedAt        time.Time
+	UpstreamAttempts []UpstreamAttempt
+}
+
+type inflightEntry struct {
+	method    string
+	path      string
+	startedAt time.Time
+	metadata  *RequestMetadata
+}
+
+var inflightRequests sync.Map
+
+// TrackInflight registers the request to be listed by InflightRequests until
+// the returned function is called.
+func TrackInflight(request *http.Request, rMeta *RequestMetadata) func() {
+	entry := &inflightEntry{
+		method:    request.Method,
+		path:      request.URL.Path,
+		startedAt: time.Now(),
+		metadata:  rMeta,
+	}
+
+	inflightRequests.Store(entry, struct{}{})
+
+	return func() {
+		inflightRequests.Delete(entry)
+	}
+}
+
+// InflightRequests returns snapshots of all the requests being handled,
+// oldest first.
+func InflightRequests() []InflightRequest {
+	requests := make([]InflightRequest, 0)
+
+	inflightRequests.Range(func(key, _ any) bool {
+		entry, _ := key.(*inflightEntry)
+
+		requests = append(requests, InflightRequest{
+			Method:           entry.method,
+			Path:             entry.path,
+			StartedAt:        entry.startedAt,
+			UpstreamAttempts: entry.metadata.UpstreamAttempts(),
+		})
+
+		return true
+	})
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].StartedAt.Before(requests[j].StartedAt)
+	})
+
+	return requests
+}
@@ -3,14 +3,16 @@ package metadata
 import (
 	"context"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/samber/mo"
 
-	"knoway.dev/api/clusters/v1alpha1"
 	servicev1alpha1 "knoway.dev/api/service/v1alpha1"
 	"knoway.dev/pkg/clusters"
+	"knoway.dev/pkg/featureflag"
 	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/priority"
 	"knoway.dev/pkg/route"
 )
 
@@ -20,6 +22,12 @@ type RequestMetadata struct {
 	// Much similar to server_name in nginx or vHost in Apache.
 	RequestModel string
 	RequestAt    time.Time
+	// LLMRequest is the request parsed by the listener, for the filters that
+	// run once the response is complete.
+	LLMRequest object.LLMRequest // Set in Listener
+	// LLMResponse is the response of the routes, streams are still being
+	// read when the filters of the request run.
+	LLMResponse object.LLMResponse // Set in Listener
 	// ResponseModel is the model name that the user expects to receive.
 	// In many scenarios, this is the same as RequestModel, except for
 	// auto-routed models, where RequestModel could be `auto`, and
@@ -27,6 +35,42 @@ type RequestMetadata struct {
 	// the request payload / inference difficulty.
 	ResponseModel string
 	RespondAt     time.Time
+	// ServedModel is the name of the model (Cluster) that actually served the
+	// request. It differs from RequestModel when the request is routed by
+	// ModelRoute, or when fallback to another target happens.
+	ServedModel string // Set in Route
+	// ServingTarget is the route target that actually served the request, in
+	// form of "<namespace>/<backend>", or the name of the cluster when the
+	// target has no backend (e.g. static clusters).
+	ServingTarget string // Set in Route
+
+	// ResponseCached is true when the response is served from the cache of
+	// the route instead of the upstream.
+	ResponseCached bool // Set in ResponseCacheFilter
+	// PromptTokensSaved is the estimated prompt tokens removed by the prompt
+	// compression of the route.
+	PromptTokensSaved uint64 // Set in PromptCompressionFilter
+
+	// ImageInputs is the number of the images in the messages, and
+	// ImageInputTokens is the estimated input tokens of them.
+	ImageInputs      int    // Set in VisionFilter
+	ImageInputTokens uint64 // Set in VisionFilter
+
+	// PIIRedacted counts the PII masked in the prompts and the responses by
+	// the names of the detectors.
+	PIIRedacted map[string]int // Set in PIIRedactionFilter
+
+	// PromptModeration and OutputModeration are the results of the content
+	// moderation of the prompt and the output.
+	PromptModeration *ModerationResult // Set in ContentModerationFilter
+	OutputModeration *ModerationResult // Set in ContentModerationFilter
+
+	// RealtimeResponses is the number of the responses done in the sessions
+	// of the Realtime API, RealtimeAudioInputTokens and
+	// RealtimeAudioOutputTokens are the audio tokens of them.
+	RealtimeResponses         int    // Set in Listener
+	RealtimeAudioInputTokens  uint64 // Set in Listener
+	RealtimeAudioOutputTokens uint64 // Set in Listener
 
 	// Egress related metadata
 	StatusCode   int
@@ -36,36 +80,54 @@ type RequestMetadata struct {
 	EnabledAuthFilter bool                                // Set in AuthFilter
 	AuthInfo          *servicev1alpha1.APIKeyAuthResponse // Set in AuthFilter
 
+	// Priority is requested through the X-Priority header, capped by the
+	// tier of the API key in AuthFilter.
+	Priority priority.Priority // Set in Listener
+
+	// FeatureFlags are evaluated after the OnRequestPre filters, so that they
+	// can be bucketed by the users authenticated.
+	FeatureFlags featureflag.Flags // Set in Listener
+
 	// SelectedCluster is the cluster that the request is routed to
 	SelectedCluster mo.Option[clusters.Cluster]
 
-	// Upstream related metadata
-	UpstreamProvider             v1alpha1.ClusterProvider // Set in Cluster Manager
-	UpstreamResponseStatusCode   int                      // Set in Cluster Manager
-	UpstreamResponseHeader       mo.Option[http.Header]   // Set in Cluster Manager
-	UpstreamResponseErrorMessage string                   // Set in Cluster Manager
-	// UpstreamRequestModel is the model name that the gateway will send to
-	// upstream provider, generally the same as how Cluster overrides `model`
-	// parameter in the request payload.
-	UpstreamRequestModel string    // Set in Cluster Manager
-	UpstreamRequestAt    time.Time // Set in Cluster Manager
-	// UpstreamResponseModel is the model name that the upstream provider
-	// will respond with. Same as explained in ResponseModel, when
-	// UpstreamRequestModel set to `auto`, the actual model name will be
-	// different from the UpstreamRequestModel since the load-balancing or
-	// generic model routing will be done by the upstream provider.
-	UpstreamResponseModel string    // Set in Cluster Manager
-	UpstreamRespondAt     time.Time // Set in Cluster Manager
-	// Setting in Listener is because when reading and handling the stream
-	// of data, the response has been made and processed by Cluster, which
-	// leaves the scope of Cluster Manager, and marshalling and writing to
-	// Connection IO writer is done by Listener, thus the only actor that
-	// knows when the first valid chunk of data is received.
-	UpstreamFirstValidChunkAt time.Time // Set in Listener
+	// Upstream related metadata, one client request may result in several
+	// upstream attempts when fallback, hedging or mirroring happens, use
+	// NewUpstreamAttempt, UpstreamAttempts and LastUpstreamAttempt to access.
+	upstreamAttemptsMutex sync.RWMutex
+	upstreamAttempts      []*UpstreamAttempt
+
+	// Time spent by each of the request and cluster filters, use
+	// AddFilterDuration and FilterDurations to access.
+	filterDurationsMutex sync.Mutex
+	filterDurations      []FilterDuration
 
 	// Overall usage consumption
 	LLMUpstreamTokensUsage mo.Option[object.LLMTokensUsage]
 	LLMUpstreamImagesUsage mo.Option[object.LLMImagesUsage]
+	LLMUpstreamVideosUsage mo.Option[object.LLMVideosUsage]
+
+	// Cost of the request computed from the pricing of the cluster that
+	// served it, in CostCurrency.
+	Cost         mo.Option[float64] // Set in CostFilter
+	CostCurrency string             // Set in CostFilter
+
+	// Seed is the seed the request is sent to the upstream with, after the
+	// seed policy of the route is applied.
+	Seed mo.Option[int64] // Set in Route
+	// SystemFingerprint is the one of the response of the upstream, for
+	// reproducibility audits together with Seed.
+	SystemFingerprint string // Set in Cluster
+
+	// Deadline is when the timeout of the route is exceeded, counted from
+	// RequestAt.
+	Deadline mo.Option[time.Time] // Set in Route
+
+	// ExportMetadata is true when the client asks for the metadata to be
+	// exported in trailers or the terminal event of streams, see Export.
+	ExportMetadata bool // Set in Listener
+	// BillableUnits are only evaluated when ExportMetadata is true.
+	BillableUnits map[string]float64 // Set in Listener
 
 	MatchRoute route.Route
 }
@@ -93,3 +155,14 @@ type metadataKey struct{}
 type metadata struct {
 	request *RequestMetadata
 }
+
+// TimeRemaining returns the time remaining before Deadline, which is negative
+// once exceeded, false if the route has no timeout.
+func (m *RequestMetadata) TimeRemaining() (time.Duration, bool) {
+	deadline, ok := m.Deadline.Get()
+	if !ok {
+		return 0, false
+	}
+
+	return time.Until(deadline), true
+}
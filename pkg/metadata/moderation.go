@@ -0,0 +1,53 @@
+package metadata
+
+import (
+	"maps"
+	"slices"
+)
+
+// ModerationResult is the result of the content moderation of the prompt or
+// the output of a request, the output of streams is moderated several times
+// and the results are merged.
+type ModerationResult struct {
+	// Scores are the highest scores of the categories
+	Scores map[string]float64 `json:"scores,omitempty"`
+	// Annotated are the categories scoring at least the annotate thresholds
+	Annotated []string `json:"annotated,omitempty"`
+	// Blocked are the categories the request is rejected by
+	Blocked []string `json:"blocked,omitempty"`
+}
+
+// Merge returns the result merged with other, it's safe to call on nil
+// ModerationResult.
+func (r *ModerationResult) Merge(other ModerationResult) *ModerationResult {
+	merged := &ModerationResult{}
+	if r != nil {
+		merged.Scores = maps.Clone(r.Scores)
+		merged.Annotated = slices.Clone(r.Annotated)
+		merged.Blocked = slices.Clone(r.Blocked)
+	}
+
+	for category, score := range other.Scores {
+		if merged.Scores == nil {
+			merged.Scores = make(map[string]float64, len(other.Scores))
+		}
+
+		merged.Scores[category] = max(merged.Scores[category], score)
+	}
+
+	merged.Annotated = union(merged.Annotated, other.Annotated)
+	merged.Blocked = union(merged.Blocked, other.Blocked)
+
+	return merged
+}
+
+func union(a []string, b []string) []string {
+	if len(b) == 0 {
+		return a
+	}
+
+	res := slices.Concat(a, b)
+	slices.Sort(res)
+
+	return slices.Compact(res)
+}
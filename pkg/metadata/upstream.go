@@ -0,0 +1,173 @@
+package metadata
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/samber/mo"
+
+	"knoway.dev/api/clusters/v1alpha1"
+)
+
+// UpstreamAttempt records a single request sent to the upstream provider.
+type UpstreamAttempt struct {
+	// Cluster is the name of the cluster the attempt is sent to
+	Cluster string
+	// Target is the route target the attempt is sent to, see also
+	// RequestMetadata.ServingTarget
+	Target string
+	// Endpoint is the endpoint of the upstream the attempt is sent to, empty
+	// if the attempt is sent to the url of the upstream
+	Endpoint string // Set in Cluster Manager
+
+	Provider v1alpha1.ClusterProvider // Set in Cluster Manager
+	// RequestModel is the model name that the gateway will send to
+	// upstream provider, generally the same as how Cluster overrides `model`
+	// parameter in the request payload.
+	RequestModel string    // Set in Cluster Manager
+	RequestAt    time.Time // Set in Cluster Manager
+	// ResponseModel is the model name that the upstream provider
+	// will respond with. Same as explained in RequestMetadata.ResponseModel,
+	// when RequestModel set to `auto`, the actual model name will be
+	// different from the RequestModel since the load-balancing or
+	// generic model routing will be done by the upstream provider.
+	ResponseModel        string                 // Set in Cluster Manager
+	RespondAt            time.Time              // Set in Cluster Manager
+	ResponseStatusCode   int                    // Set in Cluster Manager
+	ResponseHeader       mo.Option[http.Header] // Set in Cluster Manager
+	ResponseErrorMessage string                 // Set in Cluster Manager
+	// ProcessingDuration is how long the upstream provider reports it took to
+	// process the request (e.g. openai-processing-ms), zero if not reported.
+	ProcessingDuration time.Duration // Set in Cluster Manager
+	// Setting in Listener is because when reading and handling the stream
+	// of data, the response has been made and processed by Cluster, which
+	// leaves the scope of Cluster Manager, and marshalling and writing to
+	// Connection IO writer is done by Listener, thus the only actor that
+	// knows when the first valid chunk of data is received.
+	FirstValidChunkAt time.Time // Set in Listener
+}
+
+// Duration returns how long it took for the upstream to respond, zero if
+// the upstream hasn't responded yet.
+func (a UpstreamAttempt) Duration() time.Duration {
+	if a.RequestAt.IsZero() || a.RespondAt.IsZero() {
+		return 0
+	}
+
+	return a.RespondAt.Sub(a.RequestAt)
+}
+
+// NetworkDuration returns the part of Duration not spent by the upstream
+// provider processing the request, i.e. network latency and queueing in
+// between. Zero if the upstream didn't report its processing time.
+func (a UpstreamAttempt) NetworkDuration() time.Duration {
+	if a.ProcessingDuration <= 0 || a.Duration() <= a.ProcessingDuration {
+		return 0
+	}
+
+	return a.Duration() - a.ProcessingDuration
+}
+
+// FirstChunkDuration returns how long it took for the first valid chunk to
+// arrive, zero if the response is not a stream or nothing arrived yet.
+func (a UpstreamAttempt) FirstChunkDuration() time.Duration {
+	if a.RequestAt.IsZero() || a.FirstValidChunkAt.IsZero() {
+		return 0
+	}
+
+	return a.FirstValidChunkAt.Sub(a.RequestAt)
+}
+
+// NewUpstreamAttempt appends a new attempt to the ordered list of upstream
+// attempts and returns it.
+func (m *RequestMetadata) NewUpstreamAttempt(cluster string, target string) *UpstreamAttempt {
+	m.upstreamAttemptsMutex.Lock()
+	defer m.upstreamAttemptsMutex.Unlock()
+
+	attempt := &UpstreamAttempt{
+		Cluster: cluster,
+		Target:  target,
+	}
+
+	m.upstreamAttempts = append(m.upstreamAttempts, attempt)
+
+	return attempt
+}
+
+// UpdateUpstreamAttempt modifies the attempt while holding the lock, so that
+// readers such as the admin inflight view always see consistent values.
+func (m *RequestMetadata) UpdateUpstreamAttempt(attempt *UpstreamAttempt, fn func(attempt *UpstreamAttempt)) {
+	if attempt == nil {
+		return
+	}
+
+	m.upstreamAttemptsMutex.Lock()
+	defer m.upstreamAttemptsMutex.Unlock()
+
+	fn(attempt)
+}
+
+// UpstreamAttemptValue returns a copy of the attempt that is safe to read
+// without locking.
+func (m *RequestMetadata) UpstreamAttemptValue(attempt *UpstreamAttempt) UpstreamAttempt {
+	m.upstreamAttemptsMutex.RLock()
+	defer m.upstreamAttemptsMutex.RUnlock()
+
+	return *attempt
+}
+
+// UpstreamAttempts returns copies of all the upstream attempts in the order
+// they were made.
+func (m *RequestMetadata) UpstreamAttempts() []UpstreamAttempt {
+	m.upstreamAttemptsMutex.RLock()
+	defer m.upstreamAttemptsMutex.RUnlock()
+
+	attempts := make([]UpstreamAttempt, 0, len(m.upstreamAttempts))
+	for _, attempt := range m.upstreamAttempts {
+		attempts = append(attempts, *attempt)
+	}
+
+	return attempts
+}
+
+// LastUpstreamAttempt returns the most recent upstream attempt, which is the
+// one whose response is sent back to the client.
+func (m *RequestMetadata) LastUpstreamAttempt() *UpstreamAttempt {
+	m.upstreamAttemptsMutex.RLock()
+	defer m.upstreamAttemptsMutex.RUnlock()
+
+	if len(m.upstreamAttempts) == 0 {
+		return nil
+	}
+
+	return m.upstreamAttempts[len(m.upstreamAttempts)-1]
+}
+
+// LastUpstreamAttemptValue is the same as LastUpstreamAttempt, but returns
+// a copy that is safe to read without locking.
+func (m *RequestMetadata) LastUpstreamAttemptValue() UpstreamAttempt {
+	m.upstreamAttemptsMutex.RLock()
+	defer m.upstreamAttemptsMutex.RUnlock()
+
+	if len(m.upstreamAttempts) == 0 {
+		return UpstreamAttempt{}
+	}
+
+	return *m.upstreamAttempts[len(m.upstreamAttempts)-1]
+}
+
+type upstreamAttemptKey struct{}
+
+// WithUpstreamAttempt returns a context carrying the attempt, so that
+// clusters can record into their own attempt even if several attempts are
+// in flight concurrently.
+func WithUpstreamAttempt(ctx context.Context, attempt *UpstreamAttempt) context.Context {
+	return context.WithValue(ctx, upstreamAttemptKey{}, attempt)
+}
+
+// UpstreamAttemptFromCtx retrieves the attempt set by WithUpstreamAttempt.
+func UpstreamAttemptFromCtx(ctx context.Context) *UpstreamAttempt {
+	attempt, _ := ctx.Value(upstreamAttemptKey{}).(*UpstreamAttempt)
+	return attempt
+}
@@ -0,0 +1,77 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpstreamAttempts(t *testing.T) {
+	rMeta := &RequestMetadata{}
+
+	assert.Nil(t, rMeta.LastUpstreamAttempt())
+	assert.Empty(t, rMeta.UpstreamAttempts())
+
+	now := time.Now()
+
+	first := rMeta.NewUpstreamAttempt("gpt-4o", "default/gpt-4o")
+	rMeta.UpdateUpstreamAttempt(first, func(attempt *UpstreamAttempt) {
+		attempt.RequestAt = now
+		attempt.RespondAt = now.Add(time.Second)
+		attempt.ResponseStatusCode = 502
+		attempt.ResponseErrorMessage = errors.New("bad gateway").Error()
+	})
+
+	second := rMeta.NewUpstreamAttempt("gpt-4o-mini", "gpt-4o-mini")
+	rMeta.UpdateUpstreamAttempt(second, func(attempt *UpstreamAttempt) {
+		attempt.RequestAt = now.Add(time.Second)
+		attempt.ResponseStatusCode = 200
+	})
+
+	attempts := rMeta.UpstreamAttempts()
+	require.Len(t, attempts, 2)
+	assert.Equal(t, "gpt-4o", attempts[0].Cluster)
+	assert.Equal(t, "default/gpt-4o", attempts[0].Target)
+	assert.Equal(t, time.Second, attempts[0].Duration())
+	assert.Equal(t, "bad gateway", attempts[0].ResponseErrorMessage)
+	assert.Equal(t, "gpt-4o-mini", attempts[1].Cluster)
+	assert.Zero(t, attempts[1].Duration())
+
+	assert.Same(t, second, rMeta.LastUpstreamAttempt())
+	assert.Equal(t, 200, rMeta.LastUpstreamAttemptValue().ResponseStatusCode)
+
+	// Snapshots are copies
+	attempts[1].ResponseStatusCode = 500
+	assert.Equal(t, 200, rMeta.LastUpstreamAttemptValue().ResponseStatusCode)
+}
+
+func TestUpstreamAttemptFromCtx(t *testing.T) {
+	assert.Nil(t, UpstreamAttemptFromCtx(context.Background()))
+
+	attempt := &UpstreamAttempt{Cluster: "gpt-4o"}
+	assert.Same(t, attempt, UpstreamAttemptFromCtx(WithUpstreamAttempt(context.Background(), attempt)))
+}
+
+func TestTrackInflight(t *testing.T) {
+	request := httptest.NewRequest("POST", "/v1/chat/completions", nil)
+	ctx := InitMetadataContext(request)
+	rMeta := RequestMetadataFromCtx(ctx)
+
+	untrack := TrackInflight(request, rMeta)
+	rMeta.NewUpstreamAttempt("gpt-4o", "gpt-4o")
+
+	requests := InflightRequests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "POST", requests[0].Method)
+	assert.Equal(t, "/v1/chat/completions", requests[0].Path)
+	require.Len(t, requests[0].UpstreamAttempts, 1)
+	assert.Equal(t, "gpt-4o", requests[0].UpstreamAttempts[0].Cluster)
+
+	untrack()
+	assert.Empty(t, InflightRequests())
+}
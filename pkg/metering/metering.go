@@ -0,0 +1,220 @@
+package metering
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/samber/lo"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/object"
+)
+
+const (
+	VariableRequest         = "request"
+	VariableResponse        = "response"
+	VariableUsage           = "usage"
+	VariableDurationSeconds = "duration_seconds"
+)
+
+var (
+	envOnce sync.Once
+	env     *cel.Env
+	envErr  error
+
+	// programs caches the compiled programs by the expression, expressions
+	// come from the configuration of clusters so the cache is bounded.
+	programs sync.Map
+)
+
+func celEnv() (*cel.Env, error) {
+	envOnce.Do(func() {
+		env, envErr = cel.NewEnv(
+			cel.Variable(VariableRequest, cel.MapType(cel.StringType, cel.DynType)),
+			cel.Variable(VariableResponse, cel.MapType(cel.StringType, cel.DynType)),
+			cel.Variable(VariableUsage, cel.MapType(cel.StringType, cel.DynType)),
+			cel.Variable(VariableDurationSeconds, cel.DoubleType),
+		)
+	})
+
+	return env, envErr
+}
+
+// Compile compiles the expression, returns an error if the expression is
+// invalid, or does not evaluate to a number.
+func Compile(expression string) (cel.Program, error) {
+	if program, ok := programs.Load(expression); ok {
+		return program.(cel.Program), nil //nolint:forcetypeassert
+	}
+
+	e, err := celEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	ast, issues := e.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+
+	switch ast.OutputType() {
+	case cel.IntType, cel.UintType, cel.DoubleType, cel.DynType:
+	default:
+		return nil, fmt.Errorf("expression must evaluate to a number, got %s", ast.OutputType())
+	}
+
+	program, err := e.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+
+	programs.Store(expression, program)
+
+	return program, nil
+}
+
+// Validate compiles all expressions of the policy.
+func Validate(policy *v1alpha1.ClusterMeteringPolicy) error {
+	for _, expr := range policy.GetExpressions() {
+		if expr.GetUnit() == "" {
+			return errors.New("unit of metering expression cannot be empty")
+		}
+
+		_, err := Compile(expr.GetExpression())
+		if err != nil {
+			return fmt.Errorf("invalid metering expression of unit %s: %w", expr.GetUnit(), err)
+		}
+	}
+
+	return nil
+}
+
+// Input is the metadata that metering expressions are evaluated against.
+type Input struct {
+	Request   object.LLMRequest
+	Response  object.LLMResponse
+	RequestAt time.Time
+}
+
+type bodyParsed interface {
+	GetBodyParsed() map[string]any
+}
+
+func (i Input) activation() map[string]any {
+	request := map[string]any{}
+	if !lo.IsNil(i.Request) {
+		request["model"] = i.Request.GetModel()
+		request["type"] = string(i.Request.GetRequestType())
+		request["stream"] = i.Request.IsStream()
+
+		if parsed, ok := i.Request.(bodyParsed); ok {
+			request["body"] = lo.Ternary(parsed.GetBodyParsed() != nil, parsed.GetBodyParsed(), map[string]any{})
+		} else {
+			request["body"] = map[string]any{}
+		}
+	}
+
+	response := map[string]any{}
+	// Numbers are exposed as int so that they can be computed with int
+	// literals, CEL does not convert between int and uint implicitly.
+	usage := map[string]any{
+		"prompt_tokens":     int64(0),
+		"completion_tokens": int64(0),
+		"total_tokens":      int64(0),
+		"images":            []map[string]any{},
+		"videos":            []map[string]any{},
+	}
+
+	if !lo.IsNil(i.Response) {
+		response["model"] = i.Response.GetModel()
+
+		if tokensUsage, ok := object.AsLLMTokensUsage(i.Response.GetUsage()); ok && !lo.IsNil(tokensUsage) {
+			usage["prompt_tokens"] = int64(tokensUsage.GetPromptTokens())         //nolint:gosec
+			usage["completion_tokens"] = int64(tokensUsage.GetCompletionTokens()) //nolint:gosec
+			usage["total_tokens"] = int64(tokensUsage.GetTotalTokens())           //nolint:gosec
+		}
+
+		if imagesUsage, ok := object.AsLLMImagesUsage(i.Response.GetUsage()); ok && !lo.IsNil(imagesUsage) {
+			usage["images"] = lo.Map(imagesUsage.GetOutputImages(), func(image object.ImageGenerationsUsageImage, _ int) map[string]any {
+				return map[string]any{
+					"width":   int64(image.GetWidth()),  //nolint:gosec
+					"height":  int64(image.GetHeight()), //nolint:gosec
+					"quality": image.GetQuality(),
+					"style":   image.GetStyle(),
+				}
+			})
+		}
+
+		if videosUsage, ok := object.AsLLMVideosUsage(i.Response.GetUsage()); ok && !lo.IsNil(videosUsage) {
+			usage["videos"] = lo.Map(videosUsage.GetOutputVideos(), func(video object.VideoGenerationsUsageVideo, _ int) map[string]any {
+				return map[string]any{
+					"width":   int64(video.GetWidth()),  //nolint:gosec
+					"height":  int64(video.GetHeight()), //nolint:gosec
+					"seconds": video.GetSeconds(),
+				}
+			})
+		}
+	}
+
+	var durationSeconds float64
+	if !i.RequestAt.IsZero() {
+		durationSeconds = time.Since(i.RequestAt).Seconds()
+	}
+
+	return map[string]any{
+		VariableRequest:         request,
+		VariableResponse:        response,
+		VariableUsage:           usage,
+		VariableDurationSeconds: durationSeconds,
+	}
+}
+
+// Evaluate computes the billable units of each unit configured by the
+// expressions of the policy, returns nil if there is no expression.
+func Evaluate(policy *v1alpha1.ClusterMeteringPolicy, input Input) (map[string]float64, error) {
+	if len(policy.GetExpressions()) == 0 {
+		return nil, nil
+	}
+
+	activation := input.activation()
+	units := make(map[string]float64, len(policy.GetExpressions()))
+
+	for _, expr := range policy.GetExpressions() {
+		program, err := Compile(expr.GetExpression())
+		if err != nil {
+			return nil, fmt.Errorf("invalid metering expression of unit %s: %w", expr.GetUnit(), err)
+		}
+
+		out, _, err := program.Eval(activation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate metering expression of unit %s: %w", expr.GetUnit(), err)
+		}
+
+		value, err := toFloat64(out)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate metering expression of unit %s: %w", expr.GetUnit(), err)
+		}
+
+		units[expr.GetUnit()] += value
+	}
+
+	return units, nil
+}
+
+func toFloat64(val ref.Val) (float64, error) {
+	switch v := val.(type) {
+	case types.Int:
+		return float64(v), nil
+	case types.Uint:
+		return float64(v), nil
+	case types.Double:
+		return float64(v), nil
+	default:
+		return 0, fmt.Errorf("expression must evaluate to a number, got %s", val.Type().TypeName())
+	}
+}
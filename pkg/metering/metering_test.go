@@ -0,0 +1,112 @@
+package metering
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/types/openai"
+)
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Validate(nil))
+	require.NoError(t, Validate(&v1alpha1.ClusterMeteringPolicy{
+		Expressions: []*v1alpha1.ClusterMeteringPolicy_Expression{
+			{Unit: "tokens", Expression: "usage.prompt_tokens + usage.completion_tokens * 3"},
+			{Unit: "seconds", Expression: "duration_seconds"},
+		},
+	}))
+
+	assert.Error(t, Validate(&v1alpha1.ClusterMeteringPolicy{
+		Expressions: []*v1alpha1.ClusterMeteringPolicy_Expression{
+			{Unit: "tokens", Expression: "usage.prompt_tokens +"},
+		},
+	}))
+	assert.Error(t, Validate(&v1alpha1.ClusterMeteringPolicy{
+		Expressions: []*v1alpha1.ClusterMeteringPolicy_Expression{
+			{Unit: "tokens", Expression: "'tokens'"},
+		},
+	}))
+	assert.Error(t, Validate(&v1alpha1.ClusterMeteringPolicy{
+		Expressions: []*v1alpha1.ClusterMeteringPolicy_Expression{
+			{Expression: "1"},
+		},
+	}))
+}
+
+func TestEvaluate(t *testing.T) {
+	ctx := context.Background()
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/v1/chat/completions", bytes.NewBufferString(`{"model": "gpt-4o", "messages": [{"role": "user", "content": "hello"}]}`))
+	require.NoError(t, err)
+
+	request, err := openai.NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	body := `{"id": "chatcmpl-1", "model": "gpt-4o-2024-08-06", "choices": [], "usage": {"prompt_tokens": 10, "completion_tokens": 20, "total_tokens": 30}}`
+	httpResponse := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    httpRequest,
+	}
+
+	response, err := openai.NewChatCompletionResponse(request, httpResponse, bufio.NewReader(httpResponse.Body))
+	require.NoError(t, err)
+
+	units, err := Evaluate(&v1alpha1.ClusterMeteringPolicy{
+		Expressions: []*v1alpha1.ClusterMeteringPolicy_Expression{
+			{Unit: "tokens", Expression: "usage.prompt_tokens + usage.completion_tokens * 3"},
+			{Unit: "messages", Expression: "size(request.body.messages)"},
+			{Unit: "requests", Expression: "request.model == 'gpt-4o' && response.model.startsWith('gpt-4o') ? 1.5 : 0.0"},
+			{Unit: "seconds", Expression: "duration_seconds"},
+		},
+	}, Input{
+		Request:   request,
+		Response:  response,
+		RequestAt: time.Now().Add(-2 * time.Second),
+	})
+	require.NoError(t, err)
+
+	assert.InDelta(t, 70, units["tokens"], 0)
+	assert.InDelta(t, 1, units["messages"], 0)
+	assert.InDelta(t, 1.5, units["requests"], 0)
+	assert.GreaterOrEqual(t, units["seconds"], 2.0)
+
+	units, err = Evaluate(nil, Input{Request: request, Response: response})
+	require.NoError(t, err)
+	assert.Nil(t, units)
+
+	_, err = Evaluate(&v1alpha1.ClusterMeteringPolicy{
+		Expressions: []*v1alpha1.ClusterMeteringPolicy_Expression{
+			{Unit: "characters", Expression: "size(request.body.input)"},
+		},
+	}, Input{Request: request, Response: response})
+	require.Error(t, err)
+}
+
+func TestEvaluate_TextToSpeech(t *testing.T) {
+	httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com/v1/audio/speech", bytes.NewBufferString(`{"model": "tts-1", "input": "Hello, world!", "voice": "alloy"}`))
+	require.NoError(t, err)
+
+	request, err := openai.NewTextToSpeechRequest(httpRequest)
+	require.NoError(t, err)
+
+	units, err := Evaluate(&v1alpha1.ClusterMeteringPolicy{
+		Expressions: []*v1alpha1.ClusterMeteringPolicy_Expression{
+			{Unit: "characters", Expression: "size(request.body.input)"},
+		},
+	}, Input{Request: request})
+	require.NoError(t, err)
+
+	assert.InDelta(t, 13, units["characters"], 0)
+}
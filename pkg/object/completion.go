@@ -17,8 +17,19 @@ const (
 	RequestTypeCompletions      RequestType = "completions"
 	RequestTypeImageGenerations RequestType = "image_generations"
 	RequestTypeTextToSpeech     RequestType = "text_to_speech"
+	RequestTypeModerations      RequestType = "moderations"
+	RequestTypeEmbeddings       RequestType = "embeddings"
+	RequestTypeSpeechToText     RequestType = "speech_to_text"
+	RequestTypeRealtime         RequestType = "realtime"
+	RequestTypeRerank           RequestType = "rerank"
+	RequestTypeVideoGenerations RequestType = "video_generations"
+	RequestTypeImageEdits       RequestType = "image_edits"
+	RequestTypeImageVariations  RequestType = "image_variations"
 )
 
+// LLMRequest and the other interfaces in this package are internal to the
+// gateway, filters and plugins outside of this repository should depend on
+// the stable ones in pkg/object/v1 instead.
 type LLMRequest interface {
 	IsStream() bool
 	GetModel() string
@@ -56,6 +67,9 @@ type LLMStreamResponse interface {
 	NextChunk() (LLMChunkResponse, error)
 	WaitUntilEOF() <-chan LLMStreamResponse
 	OnChunk(cb func(ctx context.Context, stream LLMStreamResponse, chunk LLMChunkResponse))
+	// BeforeChunk registers a callback modifying the chunks before NextChunk
+	// returns them, e.g. to redact them.
+	BeforeChunk(cb func(chunk LLMChunkResponse) error)
 }
 
 func IsLLMStreamResponse(r any) bool {
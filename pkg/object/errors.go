@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/samber/lo"
 
@@ -17,6 +18,7 @@ const (
 	LLMErrorCodeModelNotFoundOrNotAccessible LLMErrorCode = "model_not_found"
 	LLMErrorCodeModelAccessDenied            LLMErrorCode = "model_access_denied"
 	LLMErrorCodeRateLimitExceeded            LLMErrorCode = "model_rate_limit_exceeded"
+	LLMErrorCodeConcurrencyLimitExceeded     LLMErrorCode = "model_concurrency_limit_exceeded"
 	LLMErrorCodeInsufficientQuota            LLMErrorCode = "insufficient_quota"
 	LLMErrorCodeMissingAPIKey                LLMErrorCode = "missing_api_key"
 	LLMErrorCodeIncorrectAPIKey              LLMErrorCode = "incorrect_api_key"
@@ -24,6 +26,7 @@ const (
 	LLMErrorCodeServiceUnavailable           LLMErrorCode = "service_unavailable"
 	LLMErrorCodeInternalError                LLMErrorCode = "internal_error"
 	LLMErrorCodeBadGateway                   LLMErrorCode = "bad_gateway"
+	LLMErrorCodeModelUnderMaintenance        LLMErrorCode = "model_under_maintenance"
 )
 
 var _ LLMError = (*BaseLLMError)(nil)
@@ -36,12 +39,22 @@ type BaseError struct {
 type BaseLLMError struct {
 	Status    int        `json:"-"`
 	ErrorBody *BaseError `json:"error"`
+	// RetryAfter hints clients when to retry through the Retry-After header,
+	// zero if unknown
+	RetryAfter time.Duration `json:"-"`
+	// Cause is the underlying error, such as the error of the transport when
+	// the upstream is unreachable
+	Cause error `json:"-"`
 }
 
 func (e *BaseLLMError) Error() string {
 	return e.ErrorBody.Message
 }
 
+func (e *BaseLLMError) Unwrap() error {
+	return e.Cause
+}
+
 func (e *BaseLLMError) GetCode() string {
 	return string(lo.FromPtrOr(e.ErrorBody.Code, ""))
 }
@@ -54,6 +67,10 @@ func (e *BaseLLMError) GetStatus() int {
 	return e.Status
 }
 
+func (e *BaseLLMError) GetRetryAfter() time.Duration {
+	return e.RetryAfter
+}
+
 func (e *BaseLLMError) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]any{
 		"error": map[string]any{
@@ -129,6 +146,17 @@ func NewErrorRateLimitExceeded() *BaseLLMError {
 	}
 }
 
+func NewErrorConcurrencyLimitExceeded(retryAfter time.Duration) *BaseLLMError {
+	return &BaseLLMError{
+		Status: http.StatusTooManyRequests,
+		ErrorBody: &BaseError{
+			Code:    lo.ToPtr(LLMErrorCodeConcurrencyLimitExceeded),
+			Message: "Too many requests are in progress. Please try again later.",
+		},
+		RetryAfter: retryAfter,
+	}
+}
+
 func NewErrorInsufficientQuota() *BaseLLMError {
 	return &BaseLLMError{
 		Status: http.StatusPaymentRequired,
@@ -188,6 +216,7 @@ func NewErrorBadGateway(upstreamErr error) *BaseLLMError {
 			Code:    lo.ToPtr(LLMErrorCodeBadGateway),
 			Message: lo.Must(lo.Coalesce(upstreamErr, errors.New("bad gateway"))).Error(),
 		},
+		Cause: upstreamErr,
 	}
 }
 
@@ -201,6 +230,21 @@ func NewErrorServiceUnavailable() *BaseLLMError {
 	}
 }
 
+func NewErrorModelUnderMaintenance(model string, reason string) *BaseLLMError {
+	message := fmt.Sprintf("The model `%s` is under maintenance. Please try again later.", model)
+	if reason != "" {
+		message = fmt.Sprintf("The model `%s` is under maintenance: %s", model, reason)
+	}
+
+	return &BaseLLMError{
+		Status: http.StatusServiceUnavailable,
+		ErrorBody: &BaseError{
+			Code:    lo.ToPtr(LLMErrorCodeModelUnderMaintenance),
+			Message: message,
+		},
+	}
+}
+
 func LLMErrorOrInternalError(anyErrs ...error) LLMError {
 	anyErrs = lo.Filter(anyErrs, utils.FilterNonNil)
 
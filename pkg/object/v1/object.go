@@ -0,0 +1,227 @@
+// Package v1 is the stable, versioned set of interfaces of requests,
+// responses, streams, usages and errors for filters and plugins that live
+// outside of this repository.
+//
+// The interfaces in pkg/object are internal to the gateway and may change
+// between releases. The ones in this package only ever gain new optional
+// helpers within the same version, any breaking change results in a new
+// package (e.g. v2) while this one keeps being served by the gateway through
+// the shims in this package, so that plugins written against v1 survive
+// upgrades without changes.
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/pkg/object"
+)
+
+// Version is the version of the interfaces in this package, it is reported
+// to plugins, e.g. ext-proc and WASM ones, for them to negotiate with the
+// gateway.
+const Version = "v1"
+
+type RequestType = object.RequestType
+
+const (
+	RequestTypeChatCompletions  = object.RequestTypeChatCompletions
+	RequestTypeCompletions      = object.RequestTypeCompletions
+	RequestTypeImageGenerations = object.RequestTypeImageGenerations
+	RequestTypeTextToSpeech     = object.RequestTypeTextToSpeech
+	RequestTypeModerations      = object.RequestTypeModerations
+	RequestTypeEmbeddings       = object.RequestTypeEmbeddings
+	RequestTypeSpeechToText     = object.RequestTypeSpeechToText
+	RequestTypeRealtime         = object.RequestTypeRealtime
+	RequestTypeRerank           = object.RequestTypeRerank
+	RequestTypeVideoGenerations = object.RequestTypeVideoGenerations
+	RequestTypeImageEdits       = object.RequestTypeImageEdits
+	RequestTypeImageVariations  = object.RequestTypeImageVariations
+)
+
+// Request is the request of the client. Every object.LLMRequest is a
+// Request.
+type Request interface {
+	GetRequestType() RequestType
+	GetModel() string
+	SetModel(modelName string) error
+	IsStream() bool
+	GetRawRequest() *http.Request
+}
+
+var (
+	_ Request = object.LLMRequest(nil)
+	_ Error   = object.LLMError(nil)
+)
+
+// Usage is the tokens consumed by a response or a chunk of a stream.
+type Usage struct {
+	PromptTokens     uint64 `json:"prompt_tokens"`
+	CompletionTokens uint64 `json:"completion_tokens"`
+	TotalTokens      uint64 `json:"total_tokens"`
+}
+
+// Error is the error of a response, or the one returned by filters. Every
+// object.LLMError is an Error, use NewError to create one that the gateway
+// understands.
+type Error interface {
+	error
+
+	GetStatus() int
+	GetCode() string
+	GetMessage() string
+}
+
+// Response is the response of the upstream, streams are StreamResponse.
+type Response interface {
+	json.Marshaler
+
+	IsStream() bool
+	GetRequestID() string
+	GetModel() string
+	SetModel(modelName string) error
+	// GetUsage returns false when the response carries no usage, e.g. the
+	// stream has not finished yet.
+	GetUsage() (Usage, bool)
+	GetError() Error
+}
+
+// StreamResponse is the response of streaming requests.
+type StreamResponse interface {
+	Response
+
+	IsEOF() bool
+	// NextChunk returns io.EOF together with the last chunk when the stream
+	// finishes.
+	NextChunk() (Chunk, error)
+}
+
+// Chunk is a chunk of StreamResponse.
+type Chunk interface {
+	json.Marshaler
+
+	IsFirst() bool
+	IsEmpty() bool
+	IsDone() bool
+	IsUsage() bool
+	GetModel() string
+	SetModel(modelName string) error
+	GetUsage() (Usage, bool)
+}
+
+// WrapResponse adapts object.LLMResponse to Response, the returned one is a
+// StreamResponse when the given one is an object.LLMStreamResponse.
+func WrapResponse(resp object.LLMResponse) Response {
+	if lo.IsNil(resp) {
+		return nil
+	}
+
+	if stream, ok := resp.(object.LLMStreamResponse); ok {
+		return &streamResponse{response: response{LLMResponse: stream}, stream: stream}
+	}
+
+	return &response{LLMResponse: resp}
+}
+
+// UnwrapResponse returns the object.LLMResponse adapted by WrapResponse.
+func UnwrapResponse(resp Response) (object.LLMResponse, bool) {
+	switch r := resp.(type) {
+	case *streamResponse:
+		return r.stream, true
+	case *response:
+		return r.LLMResponse, true
+	default:
+		return nil, false
+	}
+}
+
+// NewError creates an Error that is also an object.LLMError, so that it is
+// written to the client as is when returned by plugins.
+func NewError(status int, code string, message string) Error {
+	return &object.BaseLLMError{
+		Status: status,
+		ErrorBody: &object.BaseError{
+			Code:    lo.ToPtr(object.LLMErrorCode(code)),
+			Message: message,
+		},
+	}
+}
+
+// AsError returns the Error carried by err, if any.
+func AsError(err error) (Error, bool) {
+	llmError := object.AsLLMError(err)
+	if lo.IsNil(llmError) {
+		return nil, false
+	}
+
+	return llmError, true
+}
+
+func usageFrom(u object.LLMUsage) (Usage, bool) {
+	if lo.IsNil(u) {
+		return Usage{}, false
+	}
+
+	tokens, ok := object.AsLLMTokensUsage(u)
+	if !ok {
+		return Usage{}, false
+	}
+
+	return Usage{
+		PromptTokens:     tokens.GetPromptTokens(),
+		CompletionTokens: tokens.GetCompletionTokens(),
+		TotalTokens:      tokens.GetTotalTokens(),
+	}, true
+}
+
+var _ Response = (*response)(nil)
+
+type response struct {
+	object.LLMResponse
+}
+
+func (r *response) GetUsage() (Usage, bool) {
+	return usageFrom(r.LLMResponse.GetUsage())
+}
+
+func (r *response) GetError() Error {
+	llmError := r.LLMResponse.GetError()
+	if lo.IsNil(llmError) {
+		return nil
+	}
+
+	return llmError
+}
+
+var _ StreamResponse = (*streamResponse)(nil)
+
+type streamResponse struct {
+	response
+
+	stream object.LLMStreamResponse
+}
+
+func (r *streamResponse) IsEOF() bool {
+	return r.stream.IsEOF()
+}
+
+func (r *streamResponse) NextChunk() (Chunk, error) {
+	c, err := r.stream.NextChunk()
+	if lo.IsNil(c) {
+		return nil, err
+	}
+
+	return &chunk{LLMChunkResponse: c}, err
+}
+
+var _ Chunk = (*chunk)(nil)
+
+type chunk struct {
+	object.LLMChunkResponse
+}
+
+func (c *chunk) GetUsage() (Usage, bool) {
+	return usageFrom(c.LLMChunkResponse.GetUsage())
+}
@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+func TestWrapResponse(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	body := `{"model": "gpt-4o", "choices": [], "usage": {"prompt_tokens": 10, "completion_tokens": 5, "total_tokens": 15}}`
+	httpResponse := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+
+	llmResponse, err := openai.NewChatCompletionResponse(request, httpResponse, bufio.NewReader(bytes.NewBufferString(body)))
+	require.NoError(t, err)
+
+	resp := WrapResponse(llmResponse)
+	require.NotNil(t, resp)
+
+	_, ok := resp.(StreamResponse)
+	assert.False(t, ok)
+
+	assert.Equal(t, "gpt-4o", resp.GetModel())
+	assert.Nil(t, resp.GetError())
+
+	usage, ok := resp.GetUsage()
+	require.True(t, ok)
+	assert.Equal(t, Usage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}, usage)
+
+	unwrapped, ok := UnwrapResponse(resp)
+	require.True(t, ok)
+	assert.Same(t, llmResponse, unwrapped)
+
+	assert.Nil(t, WrapResponse(nil))
+}
+
+func TestWrapStreamResponse(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gpt-4o", "stream": true, "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	body := strings.Join([]string{
+		`data: {"model": "gpt-4o", "choices": [{"index": 0, "delta": {"role": "assistant", "content": "Hello"}}]}`,
+		`data: {"model": "gpt-4o", "choices": [], "usage": {"prompt_tokens": 10, "completion_tokens": 1, "total_tokens": 11}}`,
+		`data: [DONE]`,
+	}, "\n\n") + "\n\n"
+	httpResponse := &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": []string{"text/event-stream"}}}
+
+	llmResponse, err := openai.NewChatCompletionStreamResponse(request, httpResponse, bufio.NewReader(bytes.NewBufferString(body)))
+	require.NoError(t, err)
+
+	resp, ok := WrapResponse(llmResponse).(StreamResponse)
+	require.True(t, ok)
+
+	var usage Usage
+
+	for {
+		chunk, err := resp.NextChunk()
+		if errors.Is(err, io.EOF) {
+			require.NotNil(t, chunk)
+			assert.True(t, chunk.IsDone())
+
+			break
+		}
+
+		require.NoError(t, err)
+
+		if u, ok := chunk.GetUsage(); ok && chunk.IsUsage() {
+			usage = u
+		}
+	}
+
+	assert.True(t, resp.IsEOF())
+	assert.Equal(t, Usage{PromptTokens: 10, CompletionTokens: 1, TotalTokens: 11}, usage)
+}
+
+func TestErrors(t *testing.T) {
+	err := NewError(http.StatusForbidden, "blocked", "blocked by plugin")
+
+	llmError := object.AsLLMError(err)
+	require.NotNil(t, llmError)
+	assert.Equal(t, http.StatusForbidden, llmError.GetStatus())
+	assert.Equal(t, "blocked", llmError.GetCode())
+	assert.Equal(t, "blocked by plugin", llmError.GetMessage())
+
+	_, ok := AsError(errors.New("not an LLM error"))
+	assert.False(t, ok)
+
+	asError, ok := AsError(openai.NewErrorMissingModel())
+	require.True(t, ok)
+	assert.Equal(t, http.StatusBadRequest, asError.GetStatus())
+}
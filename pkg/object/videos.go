@@ -0,0 +1,18 @@
+package object
+
+type VideoGenerationsUsageVideo interface {
+	GetWidth() uint64
+	GetHeight() uint64
+	GetSeconds() float64
+}
+
+type LLMVideosUsage interface {
+	LLMUsage
+
+	GetOutputVideos() []VideoGenerationsUsageVideo
+}
+
+func AsLLMVideosUsage(u LLMUsage) (LLMVideosUsage, bool) {
+	t, ok := u.(LLMVideosUsage)
+	return t, ok
+}
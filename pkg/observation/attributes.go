@@ -42,8 +42,63 @@ var (
 
 	KnowayAuthInfoAPIKey = AttributeKey("knoway.auth.apikey")
 	KnowayAuthInfoUser   = AttributeKey("knoway.auth.user")
+
+	KnowayClusterName     = AttributeKey("knoway.cluster.name")
+	KnowayClusterProvider = AttributeKey("knoway.cluster.provider")
+
+	KnowayRouteName   = AttributeKey("knoway.route.name")
+	KnowayRouteTarget = AttributeKey("knoway.route.target")
+
+	KnowayRouteTargetWeightChange = AttributeKey("knoway.route.target.weight_change")
+	KnowayRouteMirrorOutcome      = AttributeKey("knoway.route.mirror.outcome")
+	KnowayRouteFallbackErrorClass = AttributeKey("knoway.route.fallback.error_class")
+
+	KnowayQueueName       = AttributeKey("knoway.queue.name")
+	KnowayRequestPriority = AttributeKey("knoway.request.priority")
+
+	KnowayFilterName  = AttributeKey("knoway.filter.name")
+	KnowayFilterStage = AttributeKey("knoway.filter.stage")
+
+	KnowayClusterFilterName   = AttributeKey("knoway.cluster.filter.name")
+	KnowayClusterFilterStage  = AttributeKey("knoway.cluster.filter.stage")
+	KnowayClusterFilterResult = AttributeKey("knoway.cluster.filter.result")
+
+	KnowayUpstreamAttemptsCount        = AttributeKey("knoway.upstream.attempts.count")
+	KnowayUpstreamAttemptIndex         = AttributeKey("knoway.upstream.attempt.index")
+	KnowayUpstreamAttemptCluster       = AttributeKey("knoway.upstream.attempt.cluster")
+	KnowayUpstreamAttemptTarget        = AttributeKey("knoway.upstream.attempt.target")
+	KnowayUpstreamAttemptProvider      = AttributeKey("knoway.upstream.attempt.provider")
+	KnowayUpstreamAttemptRequestModel  = AttributeKey("knoway.upstream.attempt.request_model")
+	KnowayUpstreamAttemptResponseModel = AttributeKey("knoway.upstream.attempt.response_model")
+	KnowayUpstreamAttemptStatusCode    = AttributeKey("knoway.upstream.attempt.status_code")
+	KnowayUpstreamAttemptDuration      = AttributeKey("knoway.upstream.attempt.duration")
+	KnowayUpstreamAttemptProcessing    = AttributeKey("knoway.upstream.attempt.processing_duration")
+	KnowayUpstreamAttemptErrorMessage  = AttributeKey("knoway.upstream.attempt.error_message")
+
+	KnowayUpstreamURL = AttributeKey("knoway.upstream.url")
+
+	KnowayUpstreamAPIKey        = AttributeKey("knoway.upstream.api_key")
+	KnowayUpstreamAPIKeyOutcome = AttributeKey("knoway.upstream.api_key.outcome")
+
+	KnowayEventType = AttributeKey("knoway.event.type")
+
+	KnowayCostCurrency = AttributeKey("knoway.cost.currency")
+
+	KnowayStreamChunkIndex = AttributeKey("knoway.stream.chunk.index")
+	KnowayStreamFormat     = AttributeKey("knoway.stream.format")
+	KnowayStreamReapReason = AttributeKey("knoway.stream.reap_reason")
+
+	KnowayResponseCacheMode   = AttributeKey("knoway.response_cache.mode")
+	KnowayResponseCacheResult = AttributeKey("knoway.response_cache.result")
+
+	KnowayRateLimitMode    = AttributeKey("knoway.rate_limit.mode")
+	KnowayRateLimitOutcome = AttributeKey("knoway.rate_limit.outcome")
 )
 
+// UpstreamAttemptEventName is the name of span events recorded for every
+// upstream attempt of a request.
+const UpstreamAttemptEventName = "knoway.upstream.attempt"
+
 type LLMTokenTypeEnum string
 
 const (
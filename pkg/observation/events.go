@@ -0,0 +1,43 @@
+package observation
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/events"
+)
+
+// GatewayEvents counts the events published to the event bus of the gateway
+// by type.
+var GatewayEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "knoway",
+	Subsystem: "gateway",
+	Name:      "events_total",
+	Help:      "Events published to the event bus of the gateway by type.",
+}, []string{KnowayEventType.AsLabelKey()})
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(GatewayEvents)
+}
+
+// ObserveEvents counts the events published to the bus until the gateway
+// stops.
+func ObserveEvents(bus *events.Bus, lifecycle bootkit.LifeCycle) {
+	subscription := bus.Subscribe(events.DefaultSubscriptionBuffer)
+
+	go func() {
+		for event := range subscription.Events() {
+			GatewayEvents.WithLabelValues(string(event.Type)).Inc()
+		}
+	}()
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStop: func(context.Context) error {
+			subscription.Close()
+			return nil
+		},
+	})
+}
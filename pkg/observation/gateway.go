@@ -0,0 +1,220 @@
+package observation
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samber/lo"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"knoway.dev/pkg/metadata"
+)
+
+var (
+	// GatewayRequests counts the requests handled by the gateway by model,
+	// the cluster that served them and the response status code.
+	GatewayRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "gateway",
+		Name:      "requests_total",
+		Help:      "Requests handled by the gateway by model, serving cluster and response status code.",
+	}, []string{LLMRequestModel.AsLabelKey(), KnowayClusterName.AsLabelKey(), LLMResponseCode.AsLabelKey()})
+
+	// GatewayRequestDuration is the time from receiving the request to
+	// responding to it.
+	GatewayRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "knoway",
+		Subsystem: "gateway",
+		Name:      "request_duration_seconds",
+		Help:      "Time from receiving the request to responding to it.",
+		Buckets:   upstreamDurationBuckets,
+	}, []string{LLMRequestModel.AsLabelKey(), KnowayClusterName.AsLabelKey()})
+
+	// GatewayTimeToFirstToken is the time from sending the request to
+	// upstream to receiving the first valid chunk of streaming responses.
+	GatewayTimeToFirstToken = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "knoway",
+		Subsystem: "gateway",
+		Name:      "time_to_first_token_seconds",
+		Help:      "Time from sending the request to upstream to receiving the first valid chunk of streaming responses.",
+		Buckets:   upstreamDurationBuckets,
+	}, []string{LLMRequestModel.AsLabelKey(), KnowayClusterName.AsLabelKey()})
+
+	// GatewayTokens counts the tokens consumed by model, user and cluster.
+	GatewayTokens = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "gateway",
+		Name:      "tokens_total",
+		Help:      "Tokens consumed by model, user and serving cluster.",
+	}, []string{LLMRequestModel.AsLabelKey(), KnowayAuthInfoUser.AsLabelKey(), KnowayClusterName.AsLabelKey(), LLMTokenType.AsLabelKey()})
+
+	// GatewayCost sums the cost of requests by model, user, cluster and
+	// currency, computed from the pricing of the clusters.
+	GatewayCost = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "gateway",
+		Name:      "cost_total",
+		Help:      "Cost of requests by model, user, serving cluster and currency.",
+	}, []string{LLMRequestModel.AsLabelKey(), KnowayAuthInfoUser.AsLabelKey(), KnowayClusterName.AsLabelKey(), KnowayCostCurrency.AsLabelKey()})
+
+	// RateLimitRejections counts the requests rejected by rate limits.
+	RateLimitRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "gateway",
+		Name:      "rate_limit_rejections_total",
+		Help:      "Requests rejected by rate limits by model and user.",
+	}, []string{LLMRequestModel.AsLabelKey(), KnowayAuthInfoUser.AsLabelKey()})
+
+	// RateLimitErrors counts the errors of the backends of rate limits, such
+	// as Redis, apart from the rejections of requests exceeding the limits.
+	RateLimitErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "gateway",
+		Name:      "rate_limit_errors_total",
+		Help:      "Errors of rate limit backends by mode and outcome (recovered by retries, allowed by failing open, or failed).",
+	}, []string{KnowayRateLimitMode.AsLabelKey(), KnowayRateLimitOutcome.AsLabelKey()})
+
+	// ConcurrencyLimitRejections counts the requests rejected by concurrency
+	// limits, either immediately or after waiting in the queue.
+	ConcurrencyLimitRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "gateway",
+		Name:      "concurrency_limit_rejections_total",
+		Help:      "Requests rejected by concurrency limits by model and user.",
+	}, []string{LLMRequestModel.AsLabelKey(), KnowayAuthInfoUser.AsLabelKey()})
+
+	// RequestFilterErrors counts the failed invocations of request filters of
+	// listeners and routes, including rejections such as failed auth.
+	RequestFilterErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "request_filter",
+		Name:      "errors_total",
+		Help:      "Failed invocations of request filters of listeners and routes.",
+	}, []string{KnowayFilterName.AsLabelKey(), KnowayFilterStage.AsLabelKey()})
+
+	// ResponseCacheLookups counts the lookups of response caches of routes by
+	// model, cache mode and whether the response is found.
+	ResponseCacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "response_cache",
+		Name:      "lookups_total",
+		Help:      "Lookups of response caches by model, cache mode and result (hit or miss).",
+	}, []string{LLMRequestModel.AsLabelKey(), KnowayResponseCacheMode.AsLabelKey(), KnowayResponseCacheResult.AsLabelKey()})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		GatewayRequests,
+		GatewayRequestDuration,
+		GatewayTimeToFirstToken,
+		GatewayTokens,
+		GatewayCost,
+		RateLimitRejections,
+		RateLimitErrors,
+		ConcurrencyLimitRejections,
+		RequestFilterErrors,
+		ResponseCacheLookups,
+	)
+}
+
+// ObserveRequest records a finished request in the metrics of the gateway,
+// including requests that matched no route.
+func ObserveRequest(rMeta *metadata.RequestMetadata) {
+	if rMeta == nil {
+		return
+	}
+
+	model := lo.CoalesceOrEmpty(rMeta.ServedModel, rMeta.RequestModel)
+	attempt := rMeta.LastUpstreamAttemptValue()
+
+	GatewayRequests.With(prometheus.Labels{
+		LLMRequestModel.AsLabelKey():   model,
+		KnowayClusterName.AsLabelKey(): attempt.Cluster,
+		LLMResponseCode.AsLabelKey():   strconv.Itoa(rMeta.StatusCode),
+	}).Inc()
+
+	if !rMeta.RequestAt.IsZero() && !rMeta.RespondAt.IsZero() {
+		GatewayRequestDuration.With(prometheus.Labels{
+			LLMRequestModel.AsLabelKey():   model,
+			KnowayClusterName.AsLabelKey(): attempt.Cluster,
+		}).Observe(rMeta.RespondAt.Sub(rMeta.RequestAt).Seconds())
+	}
+
+	if firstChunk := attempt.FirstChunkDuration(); firstChunk > 0 {
+		GatewayTimeToFirstToken.With(prometheus.Labels{
+			LLMRequestModel.AsLabelKey():   model,
+			KnowayClusterName.AsLabelKey(): attempt.Cluster,
+		}).Observe(firstChunk.Seconds())
+	}
+
+	if usage, ok := rMeta.LLMUpstreamTokensUsage.Get(); ok {
+		user := rMeta.AuthInfo.GetUserId()
+
+		GatewayTokens.With(prometheus.Labels{
+			LLMRequestModel.AsLabelKey():    model,
+			KnowayAuthInfoUser.AsLabelKey(): user,
+			KnowayClusterName.AsLabelKey():  attempt.Cluster,
+			LLMTokenType.AsLabelKey():       string(PromptTokenType),
+		}).Add(float64(usage.GetPromptTokens()))
+		GatewayTokens.With(prometheus.Labels{
+			LLMRequestModel.AsLabelKey():    model,
+			KnowayAuthInfoUser.AsLabelKey(): user,
+			KnowayClusterName.AsLabelKey():  attempt.Cluster,
+			LLMTokenType.AsLabelKey():       string(CompletionTokenType),
+		}).Add(float64(usage.GetCompletionTokens()))
+	}
+
+	if cost, ok := rMeta.Cost.Get(); ok {
+		GatewayCost.With(prometheus.Labels{
+			LLMRequestModel.AsLabelKey():    model,
+			KnowayAuthInfoUser.AsLabelKey(): rMeta.AuthInfo.GetUserId(),
+			KnowayClusterName.AsLabelKey():  attempt.Cluster,
+			KnowayCostCurrency.AsLabelKey(): rMeta.CostCurrency,
+		}).Add(cost)
+	}
+}
+
+// ObserveRateLimitRejection records a request of the user rejected by rate
+// limits.
+func ObserveRateLimitRejection(model, user string) {
+	RateLimitRejections.With(prometheus.Labels{
+		LLMRequestModel.AsLabelKey():    model,
+		KnowayAuthInfoUser.AsLabelKey(): user,
+	}).Inc()
+}
+
+// ObserveRateLimitError records an error of the backend of rate limits by
+// the outcome of it, e.g. recovered, cached or failed.
+func ObserveRateLimitError(mode, outcome string) {
+	RateLimitErrors.With(prometheus.Labels{
+		KnowayRateLimitMode.AsLabelKey():    mode,
+		KnowayRateLimitOutcome.AsLabelKey(): outcome,
+	}).Inc()
+}
+
+// ObserveConcurrencyLimitRejection records a request of the user rejected by
+// concurrency limits.
+func ObserveConcurrencyLimitRejection(model, user string) {
+	ConcurrencyLimitRejections.With(prometheus.Labels{
+		LLMRequestModel.AsLabelKey():    model,
+		KnowayAuthInfoUser.AsLabelKey(): user,
+	}).Inc()
+}
+
+// ObserveRequestFilterError records a failed invocation of a request filter.
+func ObserveRequestFilterError(filter, stage string) {
+	RequestFilterErrors.With(prometheus.Labels{
+		KnowayFilterName.AsLabelKey():  filter,
+		KnowayFilterStage.AsLabelKey(): stage,
+	}).Inc()
+}
+
+// ObserveResponseCacheLookup records a lookup of the response cache, hit
+// tells whether the response is served from the cache.
+func ObserveResponseCacheLookup(model, mode string, hit bool) {
+	ResponseCacheLookups.With(prometheus.Labels{
+		LLMRequestModel.AsLabelKey():           model,
+		KnowayResponseCacheMode.AsLabelKey():   mode,
+		KnowayResponseCacheResult.AsLabelKey(): lo.Ternary(hit, "hit", "miss"),
+	}).Inc()
+}
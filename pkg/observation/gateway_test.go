@@ -0,0 +1,61 @@
+package observation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+
+	servicev1alpha1 "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+)
+
+type tokensUsage struct {
+	object.IsLLMUsage
+
+	prompt     uint64
+	completion uint64
+}
+
+func (u tokensUsage) GetTotalTokens() uint64      { return u.prompt + u.completion }
+func (u tokensUsage) GetCompletionTokens() uint64 { return u.completion }
+func (u tokensUsage) GetPromptTokens() uint64     { return u.prompt }
+
+func TestObserveRequest(t *testing.T) {
+	now := time.Now()
+
+	rMeta := &metadata.RequestMetadata{
+		RequestModel: "gpt-4o",
+		RequestAt:    now,
+		RespondAt:    now.Add(2 * time.Second),
+		StatusCode:   200,
+		AuthInfo:     &servicev1alpha1.APIKeyAuthResponse{UserId: "alice"},
+	}
+	rMeta.LLMUpstreamTokensUsage = mo.Some[object.LLMTokensUsage](tokensUsage{prompt: 10, completion: 20})
+
+	attempt := rMeta.NewUpstreamAttempt("openai-gpt-4o", "default/gpt-4o")
+	rMeta.UpdateUpstreamAttempt(attempt, func(attempt *metadata.UpstreamAttempt) {
+		attempt.RequestAt = now
+		attempt.FirstValidChunkAt = now.Add(500 * time.Millisecond)
+		attempt.RespondAt = now.Add(time.Second)
+	})
+
+	ObserveRequest(rMeta)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(GatewayRequests.With(prometheus.Labels{
+		LLMRequestModel.AsLabelKey():   "gpt-4o",
+		KnowayClusterName.AsLabelKey(): "openai-gpt-4o",
+		LLMResponseCode.AsLabelKey():   "200",
+	})), 0)
+	assert.InDelta(t, 20, testutil.ToFloat64(GatewayTokens.With(prometheus.Labels{
+		LLMRequestModel.AsLabelKey():    "gpt-4o",
+		KnowayAuthInfoUser.AsLabelKey(): "alice",
+		KnowayClusterName.AsLabelKey():  "openai-gpt-4o",
+		LLMTokenType.AsLabelKey():       string(CompletionTokenType),
+	})), 0)
+	assert.Equal(t, 1, testutil.CollectAndCount(GatewayTimeToFirstToken))
+}
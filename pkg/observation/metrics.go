@@ -0,0 +1,342 @@
+package observation
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/priority"
+)
+
+var (
+	upstreamDurationBuckets = prometheus.ExponentialBuckets(0.005, 2, 16)  //nolint:mnd
+	filterDurationBuckets   = prometheus.ExponentialBuckets(0.0005, 2, 16) //nolint:mnd
+
+	// UpstreamDuration is the time from sending the request to upstream to
+	// receiving the response headers.
+	UpstreamDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "knoway",
+		Subsystem: "upstream",
+		Name:      "duration_seconds",
+		Help:      "Time from sending the request to upstream to receiving the response headers.",
+		Buckets:   upstreamDurationBuckets,
+	}, []string{KnowayClusterName.AsLabelKey(), KnowayClusterProvider.AsLabelKey()})
+
+	// UpstreamProcessingDuration is the processing time reported by upstream
+	// providers through headers like openai-processing-ms.
+	UpstreamProcessingDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "knoway",
+		Subsystem: "upstream",
+		Name:      "processing_duration_seconds",
+		Help:      "Processing time reported by upstream providers through response headers.",
+		Buckets:   upstreamDurationBuckets,
+	}, []string{KnowayClusterName.AsLabelKey(), KnowayClusterProvider.AsLabelKey()})
+
+	// UpstreamNetworkDuration is the upstream duration excluding the
+	// processing time reported by upstream providers.
+	UpstreamNetworkDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "knoway",
+		Subsystem: "upstream",
+		Name:      "network_duration_seconds",
+		Help:      "Upstream duration excluding the processing time reported by upstream providers.",
+		Buckets:   upstreamDurationBuckets,
+	}, []string{KnowayClusterName.AsLabelKey(), KnowayClusterProvider.AsLabelKey()})
+
+	// UpstreamAPIKeyResponses counts the upstream responses by the API keys
+	// the requests are sent with, outcomes are one of succeeded,
+	// unauthorized, rate_limited and failed.
+	UpstreamAPIKeyResponses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "upstream",
+		Name:      "api_key_responses_total",
+		Help:      "Upstream responses by the API keys the requests are sent with and outcome.",
+	}, []string{KnowayClusterName.AsLabelKey(), KnowayUpstreamAPIKey.AsLabelKey(), KnowayUpstreamAPIKeyOutcome.AsLabelKey()})
+
+	// UpstreamAPIKeyDisables counts the API keys disabled for cooldowns.
+	UpstreamAPIKeyDisables = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "upstream",
+		Name:      "api_key_disables_total",
+		Help:      "API keys disabled for cooldowns after failed upstream responses.",
+	}, []string{KnowayClusterName.AsLabelKey(), KnowayUpstreamAPIKey.AsLabelKey()})
+
+	// ClusterFilterInvocations counts the invocations of cluster filters by
+	// stage and result, results are one of ok, error, panic and timeout.
+	ClusterFilterInvocations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "cluster_filter",
+		Name:      "invocations_total",
+		Help:      "Invocations of cluster filters by stage and result.",
+	}, []string{KnowayClusterName.AsLabelKey(), KnowayClusterFilterName.AsLabelKey(), KnowayClusterFilterStage.AsLabelKey(), KnowayClusterFilterResult.AsLabelKey()})
+
+	// ClusterFilterDuration is the time spent by every invocation of cluster
+	// filters.
+	ClusterFilterDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "knoway",
+		Subsystem: "cluster_filter",
+		Name:      "duration_seconds",
+		Help:      "Time spent by every invocation of cluster filters.",
+		Buckets:   filterDurationBuckets,
+	}, []string{KnowayClusterName.AsLabelKey(), KnowayClusterFilterName.AsLabelKey(), KnowayClusterFilterStage.AsLabelKey()})
+
+	// RequestFilterDuration is the time spent by every invocation of request
+	// filters of listeners and routes.
+	RequestFilterDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "knoway",
+		Subsystem: "request_filter",
+		Name:      "duration_seconds",
+		Help:      "Time spent by every invocation of request filters of listeners and routes.",
+		Buckets:   filterDurationBuckets,
+	}, []string{KnowayFilterName.AsLabelKey(), KnowayFilterStage.AsLabelKey()})
+
+	// RouteRequests counts the requests handled by routes by the target that
+	// served them and the response status code.
+	RouteRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "route",
+		Name:      "requests_total",
+		Help:      "Requests handled by routes by serving target and response status code.",
+	}, []string{KnowayRouteName.AsLabelKey(), KnowayRouteTarget.AsLabelKey(), LLMResponseCode.AsLabelKey()})
+
+	// RouteRequestDuration is the time from receiving the request to
+	// responding to it.
+	RouteRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "knoway",
+		Subsystem: "route",
+		Name:      "request_duration_seconds",
+		Help:      "Time from receiving the request to responding to it.",
+		Buckets:   upstreamDurationBuckets,
+	}, []string{KnowayRouteName.AsLabelKey()})
+
+	// QueueWaitDuration is the time requests spent waiting for a slot in
+	// queues by priority class.
+	QueueWaitDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "knoway",
+		Subsystem: "queue",
+		Name:      "wait_duration_seconds",
+		Help:      "Time requests spent waiting for a slot in queues by priority class.",
+		Buckets:   upstreamDurationBuckets,
+	}, []string{KnowayQueueName.AsLabelKey(), KnowayRequestPriority.AsLabelKey()})
+
+	// RouteTokens counts the tokens consumed by requests handled by routes.
+	RouteTokens = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "route",
+		Name:      "tokens_total",
+		Help:      "Tokens consumed by requests handled by routes.",
+	}, []string{KnowayRouteName.AsLabelKey(), LLMTokenType.AsLabelKey()})
+
+	// RouteTargetWeightChanges counts the demotions and restorations of the
+	// effective weights of route targets, changes are one of demote and
+	// restore.
+	RouteTargetWeightChanges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "route",
+		Name:      "target_weight_changes_total",
+		Help:      "Demotions and restorations of the effective weights of route targets.",
+	}, []string{KnowayRouteName.AsLabelKey(), KnowayRouteTarget.AsLabelKey(), KnowayRouteTargetWeightChange.AsLabelKey()})
+
+	// RouteTargetWeightPercent is the effective weight of route targets in
+	// percent of the configured weight.
+	RouteTargetWeightPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "knoway",
+		Subsystem: "route",
+		Name:      "target_weight_percent",
+		Help:      "Effective weight of route targets in percent of the configured weight.",
+	}, []string{KnowayRouteName.AsLabelKey(), KnowayRouteTarget.AsLabelKey()})
+
+	// RouteMirroredRequests counts the copies of requests mirrored to shadow
+	// clusters, outcomes are one of succeeded, failed and dropped.
+	RouteMirroredRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "route",
+		Name:      "mirrored_requests_total",
+		Help:      "Copies of requests mirrored to shadow clusters by outcome.",
+	}, []string{KnowayRouteName.AsLabelKey(), KnowayClusterName.AsLabelKey(), KnowayRouteMirrorOutcome.AsLabelKey()})
+
+	// RouteFailovers counts the requests failed over from clusters to the
+	// next destinations of the fallbacks of the classes of errors.
+	RouteFailovers = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "route",
+		Name:      "failovers_total",
+		Help:      "Requests failed over from clusters by class of errors.",
+	}, []string{KnowayRouteName.AsLabelKey(), KnowayClusterName.AsLabelKey(), KnowayRouteFallbackErrorClass.AsLabelKey()})
+
+	// RouteCanaryWeightPercent is the percent of the weight shifted to the
+	// canary targets of routes.
+	RouteCanaryWeightPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "knoway",
+		Subsystem: "route",
+		Name:      "canary_weight_percent",
+		Help:      "Percent of the weight shifted to the canary targets of routes.",
+	}, []string{KnowayRouteName.AsLabelKey(), KnowayRouteTarget.AsLabelKey()})
+
+	// RouteCanaryRollbacks counts the rollouts of canary targets rolled back
+	// on regressions.
+	RouteCanaryRollbacks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "route",
+		Name:      "canary_rollbacks_total",
+		Help:      "Rollouts of canary targets rolled back on regressions.",
+	}, []string{KnowayRouteName.AsLabelKey(), KnowayRouteTarget.AsLabelKey()})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		UpstreamDuration,
+		UpstreamProcessingDuration,
+		UpstreamNetworkDuration,
+		UpstreamAPIKeyResponses,
+		UpstreamAPIKeyDisables,
+		ClusterFilterInvocations,
+		ClusterFilterDuration,
+		RequestFilterDuration,
+		RouteRequests,
+		RouteRequestDuration,
+		RouteTokens,
+		QueueWaitDuration,
+		RouteTargetWeightChanges,
+		RouteTargetWeightPercent,
+		RouteMirroredRequests,
+		RouteFailovers,
+		RouteCanaryWeightPercent,
+		RouteCanaryRollbacks,
+	)
+}
+
+// ObserveRouteTargetWeightChange records a demotion or restoration of the
+// effective weight of a route target.
+func ObserveRouteTargetWeightChange(route, target, change string, percent uint32) {
+	RouteTargetWeightChanges.With(prometheus.Labels{
+		KnowayRouteName.AsLabelKey():               route,
+		KnowayRouteTarget.AsLabelKey():             target,
+		KnowayRouteTargetWeightChange.AsLabelKey(): change,
+	}).Inc()
+	RouteTargetWeightPercent.With(prometheus.Labels{
+		KnowayRouteName.AsLabelKey():   route,
+		KnowayRouteTarget.AsLabelKey(): target,
+	}).Set(float64(percent))
+}
+
+// ObserveUpstreamAPIKeyResponse records the outcome of an upstream response
+// by the API key the request is sent with, and counts the disable if the key
+// is disabled by it.
+func ObserveUpstreamAPIKeyResponse(cluster, key, outcome string, disabled bool) {
+	UpstreamAPIKeyResponses.With(prometheus.Labels{
+		KnowayClusterName.AsLabelKey():           cluster,
+		KnowayUpstreamAPIKey.AsLabelKey():        key,
+		KnowayUpstreamAPIKeyOutcome.AsLabelKey(): outcome,
+	}).Inc()
+
+	if disabled {
+		UpstreamAPIKeyDisables.With(prometheus.Labels{
+			KnowayClusterName.AsLabelKey():    cluster,
+			KnowayUpstreamAPIKey.AsLabelKey(): key,
+		}).Inc()
+	}
+}
+
+// ObserveRouteCanaryWeight records the percent of the weight shifted to the
+// canary target of a route, and counts the rollback if the rollout is rolled
+// back.
+func ObserveRouteCanaryWeight(route, target string, percent int32, rolledBack bool) {
+	RouteCanaryWeightPercent.With(prometheus.Labels{
+		KnowayRouteName.AsLabelKey():   route,
+		KnowayRouteTarget.AsLabelKey(): target,
+	}).Set(float64(percent))
+
+	if rolledBack {
+		RouteCanaryRollbacks.With(prometheus.Labels{
+			KnowayRouteName.AsLabelKey():   route,
+			KnowayRouteTarget.AsLabelKey(): target,
+		}).Inc()
+	}
+}
+
+// ObserveRouteMirroredRequest records the outcome of a copy of a request
+// mirrored to the shadow cluster of the route.
+func ObserveRouteMirroredRequest(route, cluster, outcome string) {
+	RouteMirroredRequests.With(prometheus.Labels{
+		KnowayRouteName.AsLabelKey():          route,
+		KnowayClusterName.AsLabelKey():        cluster,
+		KnowayRouteMirrorOutcome.AsLabelKey(): outcome,
+	}).Inc()
+}
+
+// ObserveRouteFailover records a request failed over from the cluster with
+// the class of errors.
+func ObserveRouteFailover(route, cluster, class string) {
+	RouteFailovers.With(prometheus.Labels{
+		KnowayRouteName.AsLabelKey():               route,
+		KnowayClusterName.AsLabelKey():             cluster,
+		KnowayRouteFallbackErrorClass.AsLabelKey(): class,
+	}).Inc()
+}
+
+// ObserveUpstreamAttempt records the latency metrics of a finished upstream
+// attempt.
+func ObserveUpstreamAttempt(attempt metadata.UpstreamAttempt) {
+	if attempt.Duration() <= 0 {
+		return
+	}
+
+	labels := prometheus.Labels{
+		KnowayClusterName.AsLabelKey():     attempt.Cluster,
+		KnowayClusterProvider.AsLabelKey(): attempt.Provider.String(),
+	}
+
+	UpstreamDuration.With(labels).Observe(attempt.Duration().Seconds())
+
+	if attempt.ProcessingDuration <= 0 {
+		return
+	}
+
+	UpstreamProcessingDuration.With(labels).Observe(attempt.ProcessingDuration.Seconds())
+	UpstreamNetworkDuration.With(labels).Observe(attempt.NetworkDuration().Seconds())
+}
+
+// ObserveQueueWait records the time a request of the priority spent waiting
+// for a slot in the queue.
+func ObserveQueueWait(queue string, p priority.Priority, waited time.Duration) {
+	QueueWaitDuration.With(prometheus.Labels{
+		KnowayQueueName.AsLabelKey():       queue,
+		KnowayRequestPriority.AsLabelKey(): p.String(),
+	}).Observe(waited.Seconds())
+}
+
+// ObserveClusterFilterInvocation records the result of a single invocation of
+// a cluster filter.
+func ObserveClusterFilterInvocation(cluster, filter, stage, result string) {
+	ClusterFilterInvocations.With(prometheus.Labels{
+		KnowayClusterName.AsLabelKey():         cluster,
+		KnowayClusterFilterName.AsLabelKey():   filter,
+		KnowayClusterFilterStage.AsLabelKey():  stage,
+		KnowayClusterFilterResult.AsLabelKey(): result,
+	}).Inc()
+}
+
+// ObserveClusterFilterDuration records the time spent by a single invocation
+// of a cluster filter, both in metrics and in the request metadata if any.
+func ObserveClusterFilterDuration(rMeta *metadata.RequestMetadata, cluster, filter, stage string, duration time.Duration) {
+	ClusterFilterDuration.With(prometheus.Labels{
+		KnowayClusterName.AsLabelKey():        cluster,
+		KnowayClusterFilterName.AsLabelKey():  filter,
+		KnowayClusterFilterStage.AsLabelKey(): stage,
+	}).Observe(duration.Seconds())
+
+	rMeta.AddFilterDuration(metadata.FilterKindCluster, filter, stage, duration)
+}
+
+// ObserveRequestFilterDuration records the time spent by a single invocation
+// of a request filter, both in metrics and in the request metadata if any.
+func ObserveRequestFilterDuration(rMeta *metadata.RequestMetadata, filter, stage string, duration time.Duration) {
+	RequestFilterDuration.With(prometheus.Labels{
+		KnowayFilterName.AsLabelKey():  filter,
+		KnowayFilterStage.AsLabelKey(): stage,
+	}).Observe(duration.Seconds())
+
+	rMeta.AddFilterDuration(metadata.FilterKindRequest, filter, stage, duration)
+}
@@ -0,0 +1,297 @@
+package observation
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/samber/lo"
+
+	"knoway.dev/pkg/metadata"
+)
+
+const (
+	routeStatsBucketWidth = 5 * time.Second
+
+	// RouteStatsMaxWindow is the longest window route summaries can be
+	// computed over.
+	RouteStatsMaxWindow = 15 * time.Minute
+
+	routeStatsBuckets = int(RouteStatsMaxWindow / routeStatsBucketWidth)
+)
+
+type routeStatsBucket struct {
+	start     time.Time
+	requests  uint64
+	errors    uint64
+	tokens    uint64
+	costs     map[string]float64
+	latencies []uint64
+	targets   map[string]*routeTargetStats
+}
+
+type routeTargetStats struct {
+	requests  uint64
+	errors    uint64
+	latencies []uint64
+}
+
+func (s *routeTargetStats) add(other *routeTargetStats) {
+	s.requests += other.requests
+	s.errors += other.errors
+
+	for i, count := range other.latencies {
+		s.latencies[i] += count
+	}
+}
+
+type routeStats struct {
+	buckets [routeStatsBuckets]routeStatsBucket
+}
+
+type routeStatsTracker struct {
+	mutex  sync.Mutex
+	routes map[string]*routeStats
+	now    func() time.Time
+}
+
+var routeStatsWindow = newRouteStatsTracker(time.Now)
+
+func newRouteStatsTracker(now func() time.Time) *routeStatsTracker {
+	return &routeStatsTracker{
+		routes: make(map[string]*routeStats),
+		now:    now,
+	}
+}
+
+func (t *routeStatsTracker) observe(route, target string, statusCode int, duration time.Duration, tokens uint64, cost float64, currency string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	stats, ok := t.routes[route]
+	if !ok {
+		stats = &routeStats{}
+		t.routes[route] = stats
+	}
+
+	start := t.now().Truncate(routeStatsBucketWidth)
+	bucket := &stats.buckets[start.Unix()/int64(routeStatsBucketWidth.Seconds())%int64(routeStatsBuckets)]
+
+	if !bucket.start.Equal(start) {
+		*bucket = routeStatsBucket{
+			start:     start,
+			costs:     make(map[string]float64),
+			latencies: make([]uint64, len(upstreamDurationBuckets)+1),
+			targets:   make(map[string]*routeTargetStats),
+		}
+	}
+
+	targetStats, ok := bucket.targets[target]
+	if !ok {
+		targetStats = &routeTargetStats{latencies: make([]uint64, len(upstreamDurationBuckets)+1)}
+		bucket.targets[target] = targetStats
+	}
+
+	latencyBucket := sort.SearchFloat64s(upstreamDurationBuckets, duration.Seconds())
+
+	bucket.requests++
+	bucket.tokens += tokens
+	bucket.latencies[latencyBucket]++
+	targetStats.requests++
+	targetStats.latencies[latencyBucket]++
+
+	if cost > 0 {
+		bucket.costs[currency] += cost
+	}
+
+	if statusCode >= 500 { //nolint:mnd
+		bucket.errors++
+		targetStats.errors++
+	}
+}
+
+// TargetShare is the share of requests of a route served by a target.
+type TargetShare struct {
+	Target   string  `json:"target"`
+	Requests uint64  `json:"requests"`
+	Share    float64 `json:"share"`
+	// ErrorRate is the ratio of requests of the target responded with 5xx
+	// status codes
+	ErrorRate         float64 `json:"error_rate"`
+	LatencyP95Seconds float64 `json:"latency_p95_seconds"`
+}
+
+// RouteSummary aggregates the requests handled by a route over a window.
+type RouteSummary struct {
+	Route    string  `json:"route"`
+	Requests uint64  `json:"requests"`
+	RPS      float64 `json:"rps"`
+	// ErrorRate is the ratio of requests responded with 5xx status codes
+	ErrorRate         float64 `json:"error_rate"`
+	LatencyP50Seconds float64 `json:"latency_p50_seconds"`
+	LatencyP95Seconds float64 `json:"latency_p95_seconds"`
+	TokensPerSecond   float64 `json:"tokens_per_second"`
+	// Costs is the cost of requests over the window by currency
+	Costs   map[string]float64 `json:"costs"`
+	Targets []TargetShare      `json:"targets"`
+}
+
+func (t *routeStatsTracker) summaries(window time.Duration) []RouteSummary {
+	window = lo.Clamp(window, routeStatsBucketWidth, RouteStatsMaxWindow)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	since := t.now().Add(-window)
+	summaries := make([]RouteSummary, 0, len(t.routes))
+
+	for route, stats := range t.routes {
+		var errors, tokens uint64
+
+		summary := RouteSummary{Route: route, Costs: make(map[string]float64), Targets: make([]TargetShare, 0)}
+		latencies := make([]uint64, len(upstreamDurationBuckets)+1)
+		targets := make(map[string]*routeTargetStats)
+
+		for _, bucket := range stats.buckets {
+			if bucket.requests == 0 || !bucket.start.Add(routeStatsBucketWidth).After(since) {
+				continue
+			}
+
+			summary.Requests += bucket.requests
+			errors += bucket.errors
+			tokens += bucket.tokens
+
+			for currency, cost := range bucket.costs {
+				summary.Costs[currency] += cost
+			}
+
+			for i, count := range bucket.latencies {
+				latencies[i] += count
+			}
+			for target, stats := range bucket.targets {
+				if _, ok := targets[target]; !ok {
+					targets[target] = &routeTargetStats{latencies: make([]uint64, len(upstreamDurationBuckets)+1)}
+				}
+
+				targets[target].add(stats)
+			}
+		}
+
+		if summary.Requests == 0 {
+			continue
+		}
+
+		summary.RPS = float64(summary.Requests) / window.Seconds()
+		summary.ErrorRate = float64(errors) / float64(summary.Requests)
+		summary.TokensPerSecond = float64(tokens) / window.Seconds()
+		summary.LatencyP50Seconds = latencyQuantile(0.5, latencies)  //nolint:mnd
+		summary.LatencyP95Seconds = latencyQuantile(0.95, latencies) //nolint:mnd
+
+		for target, stats := range targets {
+			summary.Targets = append(summary.Targets, TargetShare{
+				Target:            target,
+				Requests:          stats.requests,
+				Share:             float64(stats.requests) / float64(summary.Requests),
+				ErrorRate:         float64(stats.errors) / float64(stats.requests),
+				LatencyP95Seconds: latencyQuantile(0.95, stats.latencies), //nolint:mnd
+			})
+		}
+
+		sort.Slice(summary.Targets, func(i, j int) bool {
+			return summary.Targets[i].Target < summary.Targets[j].Target
+		})
+
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Route < summaries[j].Route
+	})
+
+	return summaries
+}
+
+// latencyQuantile estimates the quantile from the counts of latency buckets
+// the same way as histogram_quantile of Prometheus does, by assuming the
+// samples are evenly distributed within each bucket.
+func latencyQuantile(q float64, counts []uint64) float64 {
+	total := lo.Sum(counts)
+	if total == 0 {
+		return 0
+	}
+
+	rank := q * float64(total)
+
+	var cumulative uint64
+
+	for i, count := range counts {
+		if float64(cumulative+count) < rank {
+			cumulative += count
+			continue
+		}
+
+		if i == len(upstreamDurationBuckets) {
+			// Samples beyond the largest bucket, nothing better than the
+			// upper bound of the largest bucket
+			return upstreamDurationBuckets[len(upstreamDurationBuckets)-1]
+		}
+
+		lower := 0.0
+		if i > 0 {
+			lower = upstreamDurationBuckets[i-1]
+		}
+
+		upper := upstreamDurationBuckets[i]
+
+		return lower + (upper-lower)*(rank-float64(cumulative))/float64(count)
+	}
+
+	return math.NaN()
+}
+
+// ObserveRouteRequest records a finished request in the metrics of the route
+// that matched it, requests that matched no route are ignored.
+func ObserveRouteRequest(rMeta *metadata.RequestMetadata) {
+	if rMeta == nil || rMeta.MatchRoute == nil {
+		return
+	}
+
+	route := rMeta.MatchRoute.GetRouteConfig().GetName()
+	target := lo.CoalesceOrEmpty(rMeta.ServingTarget, rMeta.ServedModel)
+	duration := rMeta.RespondAt.Sub(rMeta.RequestAt)
+
+	RouteRequests.With(prometheus.Labels{
+		KnowayRouteName.AsLabelKey():   route,
+		KnowayRouteTarget.AsLabelKey(): target,
+		LLMResponseCode.AsLabelKey():   strconv.Itoa(rMeta.StatusCode),
+	}).Inc()
+	RouteRequestDuration.With(prometheus.Labels{
+		KnowayRouteName.AsLabelKey(): route,
+	}).Observe(duration.Seconds())
+
+	var tokens uint64
+
+	if usage, ok := rMeta.LLMUpstreamTokensUsage.Get(); ok {
+		RouteTokens.With(prometheus.Labels{
+			KnowayRouteName.AsLabelKey(): route,
+			LLMTokenType.AsLabelKey():    string(PromptTokenType),
+		}).Add(float64(usage.GetPromptTokens()))
+		RouteTokens.With(prometheus.Labels{
+			KnowayRouteName.AsLabelKey(): route,
+			LLMTokenType.AsLabelKey():    string(CompletionTokenType),
+		}).Add(float64(usage.GetCompletionTokens()))
+
+		tokens = usage.GetPromptTokens() + usage.GetCompletionTokens()
+	}
+
+	routeStatsWindow.observe(route, target, rMeta.StatusCode, duration, tokens, rMeta.Cost.OrEmpty(), rMeta.CostCurrency)
+}
+
+// RouteSummaries returns the aggregates of every route over the last window,
+// the window is clamped to RouteStatsMaxWindow.
+func RouteSummaries(window time.Duration) []RouteSummary {
+	return routeStatsWindow.summaries(window)
+}
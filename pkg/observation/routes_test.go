@@ -0,0 +1,69 @@
+package observation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteStatsTracker(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := newRouteStatsTracker(func() time.Time { return now })
+
+	// Outside of the 1m window
+	tracker.observe("gpt-4o", "gpt-4o-old", 200, 10*time.Millisecond, 10, 1, "USD")
+
+	now = now.Add(2 * time.Minute)
+
+	for i := range 10 {
+		target := "gpt-4o-a"
+		if i%5 == 0 {
+			target = "gpt-4o-b"
+		}
+
+		tracker.observe("gpt-4o", target, 200, 100*time.Millisecond, 30, 0.5, "USD")
+	}
+
+	tracker.observe("gpt-4o", "gpt-4o-b", 502, 3*time.Second, 0, 0, "")
+	tracker.observe("gpt-4o", "gpt-4o-b", 429, 3*time.Second, 0, 0, "")
+	tracker.observe("dall-e-3", "dall-e-3", 200, time.Second, 0, 0.04, "USD")
+
+	summaries := tracker.summaries(time.Minute)
+	require.Len(t, summaries, 2)
+
+	assert.Equal(t, "dall-e-3", summaries[0].Route)
+	assert.Equal(t, uint64(1), summaries[0].Requests)
+	assert.Equal(t, map[string]float64{"USD": 0.04}, summaries[0].Costs)
+
+	summary := summaries[1]
+	assert.Equal(t, "gpt-4o", summary.Route)
+	assert.Equal(t, uint64(12), summary.Requests)
+	assert.InDelta(t, 0.2, summary.RPS, 1e-9)
+	assert.InDelta(t, 1.0/12, summary.ErrorRate, 1e-9)
+	assert.InDelta(t, 5.0, summary.TokensPerSecond, 1e-9)
+	assert.Equal(t, map[string]float64{"USD": 5}, summary.Costs)
+	assert.Greater(t, summary.LatencyP50Seconds, 0.08)
+	assert.LessOrEqual(t, summary.LatencyP50Seconds, 0.16)
+	assert.Greater(t, summary.LatencyP95Seconds, 2.56)
+	assert.LessOrEqual(t, summary.LatencyP95Seconds, 5.12)
+
+	require.Len(t, summary.Targets, 2)
+	assert.Equal(t, "gpt-4o-a", summary.Targets[0].Target)
+	assert.Equal(t, uint64(8), summary.Targets[0].Requests)
+	assert.InDelta(t, 8.0/12, summary.Targets[0].Share, 1e-9)
+	assert.Zero(t, summary.Targets[0].ErrorRate)
+	assert.LessOrEqual(t, summary.Targets[0].LatencyP95Seconds, 0.16)
+
+	assert.Equal(t, "gpt-4o-b", summary.Targets[1].Target)
+	assert.Equal(t, uint64(4), summary.Targets[1].Requests)
+	assert.InDelta(t, 4.0/12, summary.Targets[1].Share, 1e-9)
+	assert.InDelta(t, 0.25, summary.Targets[1].ErrorRate, 1e-9)
+	assert.Greater(t, summary.Targets[1].LatencyP95Seconds, 2.56)
+
+	t.Run("expired", func(t *testing.T) {
+		now = now.Add(RouteStatsMaxWindow + time.Minute)
+		assert.Empty(t, tracker.summaries(RouteStatsMaxWindow))
+	})
+}
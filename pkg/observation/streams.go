@@ -0,0 +1,72 @@
+package observation
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// OpenStreams is the number of streaming responses open to clients by
+	// format, such as sse, ndjson or websocket.
+	OpenStreams = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "knoway",
+		Subsystem: "gateway",
+		Name:      "open_streams",
+		Help:      "Streaming responses open to clients by format.",
+	}, []string{KnowayStreamFormat.AsLabelKey()})
+
+	// StreamBytes counts the bytes written to clients by streaming responses.
+	StreamBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "gateway",
+		Name:      "stream_bytes_total",
+		Help:      "Bytes written to clients by streaming responses by format.",
+	}, []string{KnowayStreamFormat.AsLabelKey()})
+
+	// StreamDuration is how long streaming responses were open.
+	StreamDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "knoway",
+		Subsystem: "gateway",
+		Name:      "stream_duration_seconds",
+		Help:      "How long streaming responses were open by format.",
+		Buckets:   upstreamDurationBuckets,
+	}, []string{KnowayStreamFormat.AsLabelKey()})
+
+	// ReapedStreams counts the streaming responses closed by the gateway for
+	// exceeding the max age or staying idle.
+	ReapedStreams = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "knoway",
+		Subsystem: "gateway",
+		Name:      "reaped_streams_total",
+		Help:      "Streaming responses closed by the gateway by format and reason (max_age or max_idle).",
+	}, []string{KnowayStreamFormat.AsLabelKey(), KnowayStreamReapReason.AsLabelKey()})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		OpenStreams,
+		StreamBytes,
+		StreamDuration,
+		ReapedStreams,
+	)
+}
+
+// ObserveStreamOpened records a streaming response opened to a client.
+func ObserveStreamOpened(format string) {
+	OpenStreams.WithLabelValues(format).Inc()
+}
+
+// ObserveStreamClosed records a streaming response closed, with the bytes
+// written by it and how long it was open.
+func ObserveStreamClosed(format string, bytes int64, duration time.Duration) {
+	OpenStreams.WithLabelValues(format).Dec()
+	StreamBytes.WithLabelValues(format).Add(float64(bytes))
+	StreamDuration.WithLabelValues(format).Observe(duration.Seconds())
+}
+
+// ObserveStreamReaped records a streaming response closed by the gateway.
+func ObserveStreamReaped(format, reason string) {
+	ReapedStreams.WithLabelValues(format, reason).Inc()
+}
@@ -0,0 +1,111 @@
+package observation
+
+import (
+	"strconv"
+	"sync/atomic"
+
+	"github.com/samber/lo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"knoway.dev/pkg/metadata"
+)
+
+const tracerName = "knoway.dev"
+
+// Names of the spans and span events recorded for requests, spans are no-op
+// unless tracing is configured.
+const (
+	// SpanNameRequest is the server span of a request handled by listeners.
+	SpanNameRequest = "knoway.request"
+	// SpanNameRequestFilter is a single invocation of a request filter of
+	// listeners or routes.
+	SpanNameRequestFilter = "knoway.request_filter"
+	// SpanNameCluster covers the cluster filter chain and the upstream call
+	// of a single upstream attempt.
+	SpanNameCluster = "knoway.cluster"
+	// SpanNameClusterFilter is a single invocation of a cluster filter.
+	SpanNameClusterFilter = "knoway.cluster_filter"
+	// SpanNameUpstream is the HTTP call to the upstream provider.
+	SpanNameUpstream = "knoway.upstream"
+
+	// StreamChunkEventName is the name of span events recorded for every
+	// chunk of streaming responses written to clients.
+	StreamChunkEventName = "knoway.stream.chunk"
+)
+
+// Tracer returns the tracer of the gateway from the global tracer provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+var propagateUpstream atomic.Bool
+
+// SetPropagateUpstream sets whether trace context is propagated to upstream
+// providers through request headers.
+func SetPropagateUpstream(propagate bool) {
+	propagateUpstream.Store(propagate)
+}
+
+// PropagateUpstream returns whether trace context is propagated to upstream
+// providers through request headers.
+func PropagateUpstream() bool {
+	return propagateUpstream.Load()
+}
+
+// RecordSpanError marks the span as failed with the error, nothing is done
+// if err is nil.
+func RecordSpanError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// EndRequestSpan records the outcome of the request described by rMeta into
+// the server span, including an event for every upstream attempt.
+func EndRequestSpan(span trace.Span, rMeta *metadata.RequestMetadata) {
+	if rMeta == nil || !span.IsRecording() {
+		return
+	}
+
+	span.SetAttributes(
+		LLMRequestModel.AsAttribute().String(rMeta.RequestModel),
+		LLMResponseModel.AsAttribute().String(rMeta.ResponseModel),
+		LLMResponseCode.AsAttribute().Int(rMeta.StatusCode),
+		KnowayRequestPriority.AsAttribute().String(rMeta.Priority.String()),
+	)
+
+	if rMeta.MatchRoute != nil {
+		span.SetAttributes(
+			KnowayRouteName.AsAttribute().String(rMeta.MatchRoute.GetRouteConfig().GetName()),
+			KnowayRouteTarget.AsAttribute().String(rMeta.ServingTarget),
+		)
+	}
+
+	if rMeta.AuthInfo != nil {
+		span.SetAttributes(KnowayAuthInfoUser.AsAttribute().String(rMeta.AuthInfo.GetUserId()))
+	}
+
+	if usage, ok := rMeta.LLMUpstreamTokensUsage.Get(); ok {
+		span.SetAttributes(
+			LLMUsagePromptTokens.AsAttribute().Int64(int64(usage.GetPromptTokens())),         //nolint:gosec
+			LLMUsageCompletionTokens.AsAttribute().Int64(int64(usage.GetCompletionTokens())), //nolint:gosec
+			LLMUsageTotalTokens.AsAttribute().Int64(int64(usage.GetTotalTokens())),           //nolint:gosec
+		)
+	}
+
+	attempts := rMeta.UpstreamAttempts()
+	span.SetAttributes(KnowayUpstreamAttemptsCount.AsAttribute().Int(len(attempts)))
+
+	for i, attempt := range attempts {
+		span.AddEvent(UpstreamAttemptEventName, trace.WithAttributes(UpstreamAttemptAttributes(i, attempt)...))
+	}
+
+	if rMeta.StatusCode >= 500 { //nolint:mnd
+		span.SetStatus(codes.Error, lo.CoalesceOrEmpty(rMeta.ErrorMessage, strconv.Itoa(rMeta.StatusCode)))
+	}
+}
@@ -0,0 +1,56 @@
+package observation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"knoway.dev/pkg/metadata"
+)
+
+func TestEndRequestSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	now := time.Now()
+
+	rMeta := &metadata.RequestMetadata{
+		RequestModel:  "gpt-4o",
+		ResponseModel: "gpt-4o",
+		StatusCode:    502,
+		ErrorMessage:  "upstream unavailable",
+	}
+
+	for _, cluster := range []string{"openai-gpt-4o", "azure-gpt-4o"} {
+		attempt := rMeta.NewUpstreamAttempt(cluster, cluster)
+		rMeta.UpdateUpstreamAttempt(attempt, func(attempt *metadata.UpstreamAttempt) {
+			attempt.RequestAt = now
+			attempt.RespondAt = now.Add(time.Second)
+			attempt.ResponseStatusCode = 502
+		})
+	}
+
+	_, span := provider.Tracer(tracerName).Start(context.Background(), SpanNameRequest)
+	EndRequestSpan(span, rMeta)
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "upstream unavailable", spans[0].Status().Description)
+
+	events := spans[0].Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, UpstreamAttemptEventName, events[1].Name)
+	assert.Contains(t, events[1].Attributes, KnowayUpstreamAttemptCluster.AsAttribute().String("azure-gpt-4o"))
+
+	assert.Contains(t, spans[0].Attributes(), KnowayUpstreamAttemptsCount.AsAttribute().Int(2))
+	assert.Contains(t, spans[0].Attributes(), LLMResponseCode.AsAttribute().Int(502))
+}
@@ -0,0 +1,32 @@
+package observation
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+
+	"knoway.dev/pkg/metadata"
+)
+
+// UpstreamAttemptAttributes returns the attributes of the span event that
+// describes the index-th upstream attempt of a request.
+func UpstreamAttemptAttributes(index int, attempt metadata.UpstreamAttempt) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		KnowayUpstreamAttemptIndex.AsAttribute().Int(index),
+		KnowayUpstreamAttemptCluster.AsAttribute().String(attempt.Cluster),
+		KnowayUpstreamAttemptTarget.AsAttribute().String(attempt.Target),
+		KnowayUpstreamAttemptProvider.AsAttribute().String(attempt.Provider.String()),
+		KnowayUpstreamAttemptRequestModel.AsAttribute().String(attempt.RequestModel),
+		KnowayUpstreamAttemptResponseModel.AsAttribute().String(attempt.ResponseModel),
+		KnowayUpstreamAttemptStatusCode.AsAttribute().Int(attempt.ResponseStatusCode),
+		KnowayUpstreamAttemptDuration.AsAttribute().Int64(attempt.Duration().Milliseconds()),
+	}
+
+	if attempt.ProcessingDuration > 0 {
+		attrs = append(attrs, KnowayUpstreamAttemptProcessing.AsAttribute().Int64(attempt.ProcessingDuration.Milliseconds()))
+	}
+
+	if attempt.ResponseErrorMessage != "" {
+		attrs = append(attrs, KnowayUpstreamAttemptErrorMessage.AsAttribute().String(attempt.ResponseErrorMessage))
+	}
+
+	return attrs
+}
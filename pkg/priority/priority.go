@@ -0,0 +1,59 @@
+package priority
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// HeaderName is the header clients request the priority of their requests
+// with.
+const HeaderName = "X-Priority"
+
+// Priority of a request, requests of higher priority are allowed to jump
+// ahead of the lower ones in queues within the fairness bounds of the queue.
+type Priority int
+
+const (
+	Low    Priority = -1
+	Normal Priority = 0
+	High   Priority = 1
+)
+
+// String returns the priority class, used as the value of the header and in
+// metric labels.
+func (p Priority) String() string {
+	switch {
+	case p <= Low:
+		return "low"
+	case p >= High:
+		return "high"
+	default:
+		return "normal"
+	}
+}
+
+// Parse parses a priority class, an empty class is Normal.
+func Parse(class string) (Priority, error) {
+	switch strings.ToLower(strings.TrimSpace(class)) {
+	case "low":
+		return Low, nil
+	case "", "normal":
+		return Normal, nil
+	case "high":
+		return High, nil
+	default:
+		return Normal, fmt.Errorf("invalid priority %q, must be one of low, normal and high", class)
+	}
+}
+
+// FromHTTPRequest parses the priority requested through the X-Priority
+// header, requests without the header are Normal.
+func FromHTTPRequest(request *http.Request) (Priority, error) {
+	return Parse(request.Header.Get(HeaderName))
+}
+
+// Cap returns the priority lowered to max if it exceeds max.
+func (p Priority) Cap(maxPriority Priority) Priority {
+	return min(p, maxPriority)
+}
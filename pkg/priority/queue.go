@@ -0,0 +1,167 @@
+package priority
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Queue bounds the number of requests served at the same time, requests
+// waiting for a slot are served by priority. To keep the queue fair, a
+// request only jumps ahead of requests queued earlier than it while the
+// slots held by the requests that jumped are below the fairness bound,
+// otherwise the earliest queued request is served.
+type Queue struct {
+	mutex sync.Mutex
+
+	slots    int
+	maxJumps int
+
+	inUse   int
+	jumped  int
+	waiters []*waiter
+}
+
+type waiter struct {
+	priority Priority
+	ready    chan bool // Receives whether the slot is taken by jumping
+}
+
+// NewQueue creates a queue of slots, maxJumpFraction is the max fraction of
+// slots that can be held by requests that jumped the queue, 0 disables
+// jumping and 1 serves the requests strictly by priority.
+func NewQueue(slots int, maxJumpFraction float64) *Queue {
+	maxJumpFraction = math.Max(0, math.Min(1, maxJumpFraction))
+
+	return &Queue{
+		slots:    slots,
+		maxJumps: int(math.Floor(float64(slots) * maxJumpFraction)),
+	}
+}
+
+// Acquire waits for a slot until the context is done, the returned release
+// function must be called once the request is served. The time spent
+// waiting in the queue is returned for the callers to observe.
+func (q *Queue) Acquire(ctx context.Context, priority Priority) (func(), time.Duration, error) {
+	startedAt := time.Now()
+
+	q.mutex.Lock()
+
+	if q.inUse < q.slots && len(q.waiters) == 0 {
+		q.inUse++
+		q.mutex.Unlock()
+
+		return q.releaseFunc(false), 0, nil
+	}
+
+	w := &waiter{
+		priority: priority,
+		ready:    make(chan bool, 1),
+	}
+	q.waiters = append(q.waiters, w)
+	q.mutex.Unlock()
+
+	select {
+	case jumped := <-w.ready:
+		return q.releaseFunc(jumped), time.Since(startedAt), nil
+	case <-ctx.Done():
+		q.mutex.Lock()
+
+		index := slices.Index(q.waiters, w)
+		if index >= 0 {
+			q.waiters = slices.Delete(q.waiters, index, index+1)
+			q.mutex.Unlock()
+
+			return nil, time.Since(startedAt), ctx.Err()
+		}
+
+		q.mutex.Unlock()
+
+		// The slot was handed over right before the context was done,
+		// give it back to the rest of the waiters.
+		q.releaseFunc(<-w.ready)()
+
+		return nil, time.Since(startedAt), ctx.Err()
+	}
+}
+
+// TryAcquire takes a slot only if one is free and no request is waiting for
+// it, the returned release function must be called once the request is
+// served.
+func (q *Queue) TryAcquire() (func(), bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.inUse >= q.slots || len(q.waiters) > 0 {
+		return nil, false
+	}
+
+	q.inUse++
+
+	return q.releaseFunc(false), true
+}
+
+func (q *Queue) releaseFunc(jumped bool) func() {
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			q.mutex.Lock()
+			defer q.mutex.Unlock()
+
+			q.inUse--
+			if jumped {
+				q.jumped--
+			}
+
+			q.dispatch()
+		})
+	}
+}
+
+// dispatch hands the free slots over to the waiters, must be called with
+// the mutex held.
+func (q *Queue) dispatch() {
+	for q.inUse < q.slots && len(q.waiters) > 0 {
+		index := q.next()
+		w := q.waiters[index]
+		q.waiters = slices.Delete(q.waiters, index, index+1)
+
+		jumped := index != 0
+
+		q.inUse++
+		if jumped {
+			q.jumped++
+		}
+
+		w.ready <- jumped
+	}
+}
+
+// next returns the index of the waiter to be served next, the waiters are
+// ordered by the time they were queued.
+func (q *Queue) next() int {
+	best := 0
+
+	for i, w := range q.waiters {
+		if w.priority > q.waiters[best].priority {
+			best = i
+		}
+	}
+
+	if best != 0 && q.jumped >= q.maxJumps {
+		return 0
+	}
+
+	return best
+}
+
+// Len returns the number of requests waiting for a slot.
+func (q *Queue) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.waiters)
+}
@@ -0,0 +1,188 @@
+package priority
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromHTTPRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+
+	p, err := FromHTTPRequest(req)
+	require.NoError(t, err)
+	assert.Equal(t, Normal, p)
+
+	req.Header.Set(HeaderName, "High")
+
+	p, err = FromHTTPRequest(req)
+	require.NoError(t, err)
+	assert.Equal(t, High, p)
+	assert.Equal(t, Normal, p.Cap(Normal))
+	assert.Equal(t, "high", p.String())
+
+	req.Header.Set(HeaderName, "urgent")
+
+	_, err = FromHTTPRequest(req)
+	require.Error(t, err)
+}
+
+type servedRequest struct {
+	priority Priority
+	release  func()
+}
+
+// acquireInBackground queues a request of the priority and waits for it to
+// be queued, served receives the request once it's given a slot.
+func acquireInBackground(t *testing.T, q *Queue, p Priority, served chan<- servedRequest) {
+	t.Helper()
+
+	queued := q.Len()
+
+	go func() {
+		release, _, err := q.Acquire(context.Background(), p)
+		if err != nil {
+			return
+		}
+
+		served <- servedRequest{priority: p, release: release}
+	}()
+
+	require.Eventually(t, func() bool { return q.Len() == queued+1 }, time.Second, time.Millisecond)
+}
+
+func TestQueue(t *testing.T) {
+	testCases := []struct {
+		name            string
+		maxJumpFraction float64
+		want            []Priority
+	}{
+		{
+			name:            "strictly by priority",
+			maxJumpFraction: 1,
+			want:            []Priority{High, Normal, Low},
+		},
+		{
+			name:            "jumping disabled",
+			maxJumpFraction: 0,
+			want:            []Priority{Low, Normal, High},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := NewQueue(1, tc.maxJumpFraction)
+
+			release, waited, err := q.Acquire(context.Background(), Normal)
+			require.NoError(t, err)
+			assert.Zero(t, waited)
+
+			served := make(chan servedRequest, 3)
+
+			acquireInBackground(t, q, Low, served)
+			acquireInBackground(t, q, Normal, served)
+			acquireInBackground(t, q, High, served)
+
+			release()
+
+			got := make([]Priority, 0, len(tc.want))
+
+			for range tc.want {
+				r := <-served
+				got = append(got, r.priority)
+				r.release()
+			}
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestQueue_JumpFairness(t *testing.T) {
+	// Only one of the two slots can be held by requests that jumped
+	q := NewQueue(2, 0.5)
+
+	release1, _, err := q.Acquire(context.Background(), Normal)
+	require.NoError(t, err)
+	release2, _, err := q.Acquire(context.Background(), Normal)
+	require.NoError(t, err)
+
+	served := make(chan servedRequest, 3)
+
+	acquireInBackground(t, q, Low, served)
+	acquireInBackground(t, q, High, served)
+	acquireInBackground(t, q, High, served)
+
+	// The first high jumps ahead of low and keeps the slot
+	release1()
+
+	first := <-served
+	assert.Equal(t, High, first.priority)
+
+	// The bound is reached, low is served before the second high
+	release2()
+
+	second := <-served
+	assert.Equal(t, Low, second.priority)
+
+	second.release()
+
+	third := <-served
+	assert.Equal(t, High, third.priority)
+
+	first.release()
+	third.release()
+}
+
+func TestQueue_ContextDone(t *testing.T) {
+	q := NewQueue(1, 1)
+
+	release, _, err := q.Acquire(context.Background(), Normal)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, waited, err := q.Acquire(ctx, High)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Positive(t, waited)
+	assert.Zero(t, q.Len())
+
+	release()
+
+	release, _, err = q.Acquire(context.Background(), Normal)
+	require.NoError(t, err)
+	release()
+}
+
+func TestQueue_TryAcquire(t *testing.T) {
+	q := NewQueue(1, 0)
+
+	release, ok := q.TryAcquire()
+	require.True(t, ok)
+
+	_, ok = q.TryAcquire()
+	assert.False(t, ok)
+
+	served := make(chan servedRequest, 1)
+	acquireInBackground(t, q, Normal, served)
+
+	release()
+
+	// Waiters are served before requests trying to acquire
+	waiter := <-served
+
+	_, ok = q.TryAcquire()
+	assert.False(t, ok)
+
+	waiter.release()
+
+	release, ok = q.TryAcquire()
+	require.True(t, ok)
+	release()
+}
@@ -8,11 +8,27 @@ import (
 	filtersv1alpha1 "knoway.dev/api/filters/v1alpha1"
 	"knoway.dev/pkg/bootkit"
 	clusterfilters "knoway.dev/pkg/clusters/filters"
+	"knoway.dev/pkg/clusters/filters/apikeys"
+	"knoway.dev/pkg/clusters/filters/azure"
 	"knoway.dev/pkg/clusters/filters/openai"
+	"knoway.dev/pkg/clusters/filters/prompttemplate"
+	"knoway.dev/pkg/clusters/filters/sigv4"
+	"knoway.dev/pkg/clusters/filters/transform"
+	"knoway.dev/pkg/clusters/filters/vllm"
 	"knoway.dev/pkg/filters"
 	"knoway.dev/pkg/filters/auth"
+	"knoway.dev/pkg/filters/cache"
+	"knoway.dev/pkg/filters/compression"
+	"knoway.dev/pkg/filters/concurrencylimit"
+	"knoway.dev/pkg/filters/cost"
+	"knoway.dev/pkg/filters/extproc"
+	"knoway.dev/pkg/filters/imageprompt"
+	"knoway.dev/pkg/filters/moderation"
+	"knoway.dev/pkg/filters/pii"
 	"knoway.dev/pkg/filters/ratelimit"
+	"knoway.dev/pkg/filters/transcript"
 	"knoway.dev/pkg/filters/usage"
+	"knoway.dev/pkg/filters/vision"
 	"knoway.dev/pkg/protoutils"
 )
 
@@ -40,10 +56,33 @@ func init() {
 	requestFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.APIKeyAuthConfig{})] = auth.NewWithConfig
 	requestFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.RateLimitConfig{})] = ratelimit.NewWithConfig
 	requestFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.UsageStatsConfig{})] = usage.NewWithConfig
+	requestFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.UsageExportConfig{})] = usage.NewExportWithConfig
+	requestFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.ResponseCacheConfig{})] = cache.NewWithConfig
+	requestFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.ConcurrencyLimitConfig{})] = concurrencylimit.NewWithConfig
+	requestFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.PromptCompressionConfig{})] = compression.NewWithConfig
+	requestFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.VisionConfig{})] = vision.NewWithConfig
+	requestFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.CostConfig{})] = cost.NewWithConfig
+	requestFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.TranscriptAuditConfig{})] = transcript.NewWithConfig
+	requestFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.ImagePromptPolicyConfig{})] = imageprompt.NewWithConfig
+	requestFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.PIIRedactionConfig{})] = pii.NewWithConfig
+	requestFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.ContentModerationConfig{})] = moderation.NewWithConfig
+	requestFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.ExternalProcessorConfig{})] = extproc.NewWithConfig
+	requestFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.PromptTemplateConfig{})] = prompttemplate.NewRequestFilterWithConfig
 
 	// internal base Filters
 	clustersFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.OpenAIRequestHandlerConfig{})] = openai.NewRequestHandlerWithConfig
 	clustersFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.OpenAIResponseHandlerConfig{})] = openai.NewResponseHandlerWithConfig
+
+	// provider adapters
+	clustersFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.AzureOpenAIConfig{})] = azure.NewWithConfig
+	clustersFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.AWSSigV4Config{})] = sigv4.NewWithConfig
+	clustersFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.UpstreamAPIKeysConfig{})] = apikeys.NewWithConfig
+	clustersFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.BodyTransformConfig{})] = transform.NewWithConfig
+
+	// endpoint selectors
+	clustersFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.VLLMEndpointSelectorConfig{})] = vllm.NewWithConfig
+
+	clustersFilters[protoutils.TypeURLOrDie(&filtersv1alpha1.PromptTemplateConfig{})] = prompttemplate.NewWithConfig
 }
 
 func NewRequestFilterWithConfig(name string, cfg *anypb.Any, lifecycle bootkit.LifeCycle) (filters.RequestFilter, error) {
@@ -0,0 +1,190 @@
+package loadbalance
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"knoway.dev/pkg/metadata"
+)
+
+// latencyEWMAAlpha is the weight of the newest sample, the larger the
+// faster it reacts to latency changes.
+const latencyEWMAAlpha = 0.3
+
+// maxFirstChunkSamples bounds the samples of first chunk latencies kept for
+// each cluster to compute percentiles over windows.
+const maxFirstChunkSamples = 1024
+
+// LatencyStats is the exponentially weighted moving average of latencies of
+// upstream attempts made to a cluster.
+type LatencyStats struct {
+	// Duration is the time from sending the request to receiving the response
+	// headers
+	Duration time.Duration
+	// Processing is the processing time reported by upstream providers, zero
+	// if the upstream never reports it
+	Processing time.Duration
+	// Network is Duration excluding Processing, which grows when the network
+	// or the queue in front of the upstream is congested
+	Network time.Duration
+	// FirstChunk is the time from sending the request to receiving the first
+	// valid chunk of streams, zero if no stream has been observed
+	FirstChunk time.Duration
+	Samples    uint64
+	// FirstChunkSamples is the number of streams observed
+	FirstChunkSamples uint64
+}
+
+// Latency returns the latency that clients perceive before the response
+// starts, i.e. FirstChunk when streams are observed, otherwise Duration.
+func (s LatencyStats) Latency() time.Duration {
+	if s.FirstChunkSamples > 0 {
+		return s.FirstChunk
+	}
+
+	return s.Duration
+}
+
+type latencySample struct {
+	at      time.Time
+	latency time.Duration
+}
+
+type latencyTracker struct {
+	mutex sync.RWMutex
+	stats map[string]LatencyStats
+	// endpoints is the stats of each endpoint of the upstreams, shared by the
+	// clusters sending requests to the same endpoint
+	endpoints map[string]LatencyStats
+	// firstChunks is the ring of latest first chunk latencies of each cluster
+	firstChunks map[string][]latencySample
+}
+
+var upstreamLatencies = &latencyTracker{
+	stats:       make(map[string]LatencyStats),
+	endpoints:   make(map[string]LatencyStats),
+	firstChunks: make(map[string][]latencySample),
+}
+
+func ewma(prev time.Duration, sample time.Duration, samples uint64) time.Duration {
+	if samples == 0 || prev == 0 {
+		return sample
+	}
+
+	return time.Duration(latencyEWMAAlpha*float64(sample) + (1-latencyEWMAAlpha)*float64(prev))
+}
+
+// ObserveUpstreamAttempt feeds latencies of the finished upstream attempt to
+// the load balancers.
+func ObserveUpstreamAttempt(attempt metadata.UpstreamAttempt) {
+	if attempt.Cluster == "" || attempt.Duration() <= 0 {
+		return
+	}
+
+	upstreamLatencies.mutex.Lock()
+	defer upstreamLatencies.mutex.Unlock()
+
+	upstreamLatencies.stats[attempt.Cluster] = observeDuration(upstreamLatencies.stats[attempt.Cluster], attempt)
+
+	if attempt.Endpoint != "" {
+		upstreamLatencies.endpoints[attempt.Endpoint] = observeDuration(upstreamLatencies.endpoints[attempt.Endpoint], attempt)
+	}
+}
+
+func observeDuration(stats LatencyStats, attempt metadata.UpstreamAttempt) LatencyStats {
+	stats.Duration = ewma(stats.Duration, attempt.Duration(), stats.Samples)
+	if attempt.ProcessingDuration > 0 {
+		stats.Processing = ewma(stats.Processing, attempt.ProcessingDuration, stats.Samples)
+		stats.Network = ewma(stats.Network, attempt.NetworkDuration(), stats.Samples)
+	}
+
+	stats.Samples++
+
+	return stats
+}
+
+func observeFirstChunk(stats LatencyStats, attempt metadata.UpstreamAttempt) LatencyStats {
+	stats.FirstChunk = ewma(stats.FirstChunk, attempt.FirstChunkDuration(), stats.FirstChunkSamples)
+	stats.FirstChunkSamples++
+
+	return stats
+}
+
+// ObserveUpstreamFirstChunk feeds the latency of the first valid chunk of
+// the streaming upstream attempt to the load balancers.
+func ObserveUpstreamFirstChunk(attempt metadata.UpstreamAttempt) {
+	if attempt.Cluster == "" || attempt.FirstChunkDuration() <= 0 {
+		return
+	}
+
+	upstreamLatencies.mutex.Lock()
+	defer upstreamLatencies.mutex.Unlock()
+
+	upstreamLatencies.stats[attempt.Cluster] = observeFirstChunk(upstreamLatencies.stats[attempt.Cluster], attempt)
+
+	if attempt.Endpoint != "" {
+		upstreamLatencies.endpoints[attempt.Endpoint] = observeFirstChunk(upstreamLatencies.endpoints[attempt.Endpoint], attempt)
+	}
+
+	samples := upstreamLatencies.firstChunks[attempt.Cluster]
+	if len(samples) >= maxFirstChunkSamples {
+		samples = samples[1:]
+	}
+
+	upstreamLatencies.firstChunks[attempt.Cluster] = append(samples, latencySample{
+		at:      attempt.FirstValidChunkAt,
+		latency: attempt.FirstChunkDuration(),
+	})
+}
+
+// UpstreamFirstChunkPercentile returns the q-th percentile (0 < q <= 1) of
+// the latencies of first chunks received from the cluster since the given
+// time, along with the number of samples it is computed from.
+func UpstreamFirstChunkPercentile(cluster string, q float64, since time.Time) (time.Duration, int) {
+	upstreamLatencies.mutex.RLock()
+
+	latencies := make([]time.Duration, 0)
+
+	for _, sample := range upstreamLatencies.firstChunks[cluster] {
+		if !sample.at.Before(since) {
+			latencies = append(latencies, sample.latency)
+		}
+	}
+
+	upstreamLatencies.mutex.RUnlock()
+
+	if len(latencies) == 0 {
+		return 0, 0
+	}
+
+	slices.Sort(latencies)
+
+	// Nearest-rank method
+	rank := int(math.Ceil(float64(len(latencies))*q)) - 1
+	rank = max(0, min(rank, len(latencies)-1))
+
+	return latencies[rank], len(latencies)
+}
+
+// UpstreamLatency returns the latency stats of the cluster observed so far.
+func UpstreamLatency(cluster string) (LatencyStats, bool) {
+	upstreamLatencies.mutex.RLock()
+	defer upstreamLatencies.mutex.RUnlock()
+
+	stats, ok := upstreamLatencies.stats[cluster]
+
+	return stats, ok
+}
+
+// UpstreamEndpointLatency returns the latency stats of the endpoint of
+// upstreams observed so far.
+func UpstreamEndpointLatency(endpoint string) (LatencyStats, bool) {
+	upstreamLatencies.mutex.RLock()
+	defer upstreamLatencies.mutex.RUnlock()
+
+	stats, ok := upstreamLatencies.endpoints[endpoint]
+
+	return stats, ok
+}
@@ -0,0 +1,112 @@
+package loadbalance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/pkg/metadata"
+)
+
+func TestObserveUpstreamAttempt(t *testing.T) {
+	now := time.Now()
+
+	_, ok := UpstreamLatency("latency-test")
+	assert.False(t, ok)
+
+	ObserveUpstreamAttempt(metadata.UpstreamAttempt{
+		Cluster:            "latency-test",
+		RequestAt:          now,
+		RespondAt:          now.Add(time.Second),
+		ProcessingDuration: 800 * time.Millisecond,
+	})
+
+	stats, ok := UpstreamLatency("latency-test")
+	require.True(t, ok)
+	assert.Equal(t, time.Second, stats.Duration)
+	assert.Equal(t, 800*time.Millisecond, stats.Processing)
+	assert.Equal(t, 200*time.Millisecond, stats.Network)
+	assert.Equal(t, uint64(1), stats.Samples)
+
+	ObserveUpstreamAttempt(metadata.UpstreamAttempt{
+		Cluster:            "latency-test",
+		RequestAt:          now,
+		RespondAt:          now.Add(2 * time.Second),
+		ProcessingDuration: 800 * time.Millisecond,
+	})
+
+	stats, ok = UpstreamLatency("latency-test")
+	require.True(t, ok)
+	assert.Equal(t, 1300*time.Millisecond, stats.Duration)
+	assert.Equal(t, 800*time.Millisecond, stats.Processing)
+	assert.Equal(t, 500*time.Millisecond, stats.Network)
+	assert.Equal(t, uint64(2), stats.Samples)
+
+	// Attempts without response are ignored
+	ObserveUpstreamAttempt(metadata.UpstreamAttempt{Cluster: "latency-test", RequestAt: now})
+
+	stats, _ = UpstreamLatency("latency-test")
+	assert.Equal(t, uint64(2), stats.Samples)
+}
+
+func TestObserveUpstreamFirstChunk(t *testing.T) {
+	now := time.Now()
+
+	ObserveUpstreamAttempt(metadata.UpstreamAttempt{
+		Cluster:   "first-chunk-test",
+		RequestAt: now,
+		RespondAt: now.Add(100 * time.Millisecond),
+	})
+
+	stats, ok := UpstreamLatency("first-chunk-test")
+	require.True(t, ok)
+	assert.Equal(t, 100*time.Millisecond, stats.Latency())
+
+	ObserveUpstreamFirstChunk(metadata.UpstreamAttempt{
+		Cluster:           "first-chunk-test",
+		RequestAt:         now,
+		RespondAt:         now.Add(100 * time.Millisecond),
+		FirstValidChunkAt: now.Add(time.Second),
+	})
+
+	stats, ok = UpstreamLatency("first-chunk-test")
+	require.True(t, ok)
+	assert.Equal(t, time.Second, stats.FirstChunk)
+	assert.Equal(t, uint64(1), stats.FirstChunkSamples)
+	assert.Equal(t, time.Second, stats.Latency())
+
+	// Non-streaming attempts are ignored
+	ObserveUpstreamFirstChunk(metadata.UpstreamAttempt{Cluster: "first-chunk-test", RequestAt: now, RespondAt: now.Add(time.Second)})
+
+	stats, _ = UpstreamLatency("first-chunk-test")
+	assert.Equal(t, uint64(1), stats.FirstChunkSamples)
+}
+
+func TestUpstreamFirstChunkPercentile(t *testing.T) {
+	now := time.Now()
+
+	_, samples := UpstreamFirstChunkPercentile("percentile-test", 0.95, now.Add(-time.Minute))
+	assert.Zero(t, samples)
+
+	for i := 1; i <= 20; i++ {
+		ObserveUpstreamFirstChunk(metadata.UpstreamAttempt{
+			Cluster:           "percentile-test",
+			RequestAt:         now,
+			FirstValidChunkAt: now.Add(time.Duration(i) * 100 * time.Millisecond),
+		})
+	}
+
+	p95, samples := UpstreamFirstChunkPercentile("percentile-test", 0.95, now)
+	assert.Equal(t, 20, samples)
+	assert.Equal(t, 1900*time.Millisecond, p95)
+
+	p50, _ := UpstreamFirstChunkPercentile("percentile-test", 0.5, now)
+	assert.Equal(t, time.Second, p50)
+
+	// Samples received before the window are excluded
+	p95, samples = UpstreamFirstChunkPercentile("percentile-test", 0.95, now.Add(time.Second+time.Millisecond))
+	assert.Equal(t, 10, samples)
+	assert.Equal(t, 2*time.Second, p95)
+}
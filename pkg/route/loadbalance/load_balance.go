@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"math/big"
 	"sync/atomic"
+	"time"
 
 	"github.com/samber/lo"
 
@@ -23,6 +24,19 @@ type server struct {
 	name           string
 	weight         int32
 	requestCounter requestCounter
+	// demotion is the effective weight of the target under the first chunk
+	// latency objective of the route, nil if the route has none
+	demotion *targetWeight
+}
+
+// effectiveWeight returns the weight of the server scaled by its effective
+// weight in percent.
+func (s *server) effectiveWeight() int64 {
+	if s.demotion == nil {
+		return int64(s.weight) * 100 //nolint:mnd
+	}
+
+	return int64(s.weight) * int64(s.demotion.weightPercent())
 }
 
 type requestCounter interface {
@@ -71,7 +85,7 @@ func NewWeightedRoundRobin(destinations []*v1alpha1.RouteDestination) *WeightedR
 
 func (w *WeightedRoundRobin) calculateTotalWeight() int64 {
 	return lo.SumBy(w.servers, func(item *server) int64 {
-		return int64(item.weight)
+		return item.effectiveWeight()
 	})
 }
 
@@ -95,17 +109,13 @@ func (w *WeightedRoundRobin) Next(ctx context.Context, _ object.LLMRequest) stri
 
 	currentIndex := w.current.Load()
 
-	var (
-		currentWeight int32
-		total         int64
-	)
+	var total int64
 
 	foundIdx := -1
 
 	for i := range w.servers {
 		idx := (int(currentIndex) + i) % len(w.servers)
-		currentWeight = w.servers[idx].weight
-		total += int64(currentWeight)
+		total += w.servers[idx].effectiveWeight()
 
 		if total > randomWeight.Int64() {
 			foundIdx = idx
@@ -174,7 +184,7 @@ func (w *WeightedLeastRequest) Next(ctx context.Context, request object.LLMReque
 	selected := w.current
 
 	for i, s := range w.servers {
-		loadRatio := float64(s.requestCounter.Current()) / float64(s.weight)
+		loadRatio := float64(s.requestCounter.Current()) / float64(s.effectiveWeight())
 		requestLess := loadRatio == leastLoadRatio && s.requestCounter.Less(selectedServer.requestCounter)
 
 		if leastLoadRatio == -1 || loadRatio < leastLoadRatio || requestLess {
@@ -195,6 +205,107 @@ func (w *WeightedLeastRequest) Done(_ context.Context) {
 	w.servers[w.current].requestCounter.Desc()
 }
 
+// leastLatencyExplorationPercent is the percentage of requests spread by
+// weights regardless of latencies, so that the latencies of slower targets
+// keep being observed and recovered targets win traffic back.
+const leastLatencyExplorationPercent = 10
+
+var _ LoadBalancer = (*WeightedLeastLatency)(nil)
+
+type WeightedLeastLatency struct {
+	servers []*server
+	explore *WeightedRoundRobin
+	latency func(cluster string) (LatencyStats, bool)
+}
+
+func NewWeightedLeastLatency(destinations []*v1alpha1.RouteDestination) *WeightedLeastLatency {
+	return &WeightedLeastLatency{
+		servers: newServers(destinations),
+		explore: NewWeightedRoundRobin(destinations),
+		latency: UpstreamLatency,
+	}
+}
+
+func (w *WeightedLeastLatency) Next(ctx context.Context, request object.LLMRequest) string {
+	if len(w.servers) == 0 {
+		return ""
+	}
+
+	if len(w.servers) == 1 {
+		return w.servers[0].name
+	}
+
+	var selectedServer *server
+
+	leastScore := float64(-1)
+
+	for _, s := range w.servers {
+		if s.weight <= 0 {
+			continue
+		}
+
+		stats, ok := w.latency(s.name)
+		if !ok || stats.Latency() <= 0 {
+			// Targets never observed are preferred to learn about them
+			return s.name
+		}
+
+		score := float64(stats.Latency()) / float64(s.effectiveWeight())
+		if leastScore == -1 || score < leastScore {
+			selectedServer = s
+			leastScore = score
+		}
+	}
+
+	if selectedServer == nil {
+		return w.servers[0].name
+	}
+
+	if exploring() {
+		return w.explore.Next(ctx, request)
+	}
+
+	return selectedServer.name
+}
+
+func (w *WeightedLeastLatency) Done(_ context.Context) {}
+
+// exploring reports whether the request is one of the share spread regardless
+// of latencies.
+func exploring() bool {
+	percent, err := rand.Int(rand.Reader, big.NewInt(100)) //nolint:mnd
+
+	return err == nil && percent.Int64() < leastLatencyExplorationPercent
+}
+
+// LeastLatencyEndpoint returns the endpoint of an upstream with the lowest
+// latency observed, the endpoints never observed are preferred, and a share
+// of requests is sent to the one returned by next regardless of latencies.
+func LeastLatencyEndpoint(endpoints []string, next func() string) string {
+	var selected string
+
+	leastLatency := time.Duration(-1)
+
+	for _, endpoint := range endpoints {
+		stats, ok := UpstreamEndpointLatency(endpoint)
+		if !ok || stats.Latency() <= 0 {
+			// Endpoints never observed are preferred to learn about them
+			return endpoint
+		}
+
+		if leastLatency == -1 || stats.Latency() < leastLatency {
+			selected = endpoint
+			leastLatency = stats.Latency()
+		}
+	}
+
+	if selected == "" || exploring() {
+		return next()
+	}
+
+	return selected
+}
+
 var _ LoadBalancer = (*emptyLB)(nil)
 
 type emptyLB struct{}
@@ -211,11 +322,41 @@ func New(router *v1alpha1.Route) LoadBalancer {
 		return item.GetDestination()
 	})
 
+	lb := newLoadBalancer(router, destinations)
+
+	if router.GetSessionAffinity() != nil && router.GetLoadBalancePolicy() != v1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_UNSPECIFIED {
+		return newSessionAffinity(lb, router.GetSessionAffinity(), destinations)
+	}
+
+	return lb
+}
+
+func newLoadBalancer(router *v1alpha1.Route, destinations []*v1alpha1.RouteDestination) LoadBalancer {
+	slo := newFirstChunkSLO(router)
+
 	switch router.GetLoadBalancePolicy() {
 	case v1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_ROUND_ROBIN:
-		return NewWeightedRoundRobin(destinations)
+		lb := NewWeightedRoundRobin(destinations)
+		if slo != nil {
+			slo.attach(lb.servers)
+		}
+
+		return lb
 	case v1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_LEAST_REQUEST:
-		return NewWeightedLeastRequest(destinations)
+		lb := &WeightedLeastRequest{servers: newServers(destinations)}
+		if slo != nil {
+			slo.attach(lb.servers)
+		}
+
+		return lb
+	case v1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_LEAST_LATENCY:
+		lb := NewWeightedLeastLatency(destinations)
+		if slo != nil {
+			slo.attach(lb.servers)
+			slo.attach(lb.explore.servers)
+		}
+
+		return lb
 	case v1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_UNSPECIFIED:
 		return &emptyLB{}
 	default:
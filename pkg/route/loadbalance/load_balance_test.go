@@ -5,11 +5,14 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"knoway.dev/api/route/v1alpha1"
+	"knoway.dev/pkg/metadata"
 )
 
 func TestWeightedRoundRobin_Next(t *testing.T) {
@@ -150,3 +153,75 @@ func TestWeightedLeastRequest_Next(t *testing.T) {
 	expect3 := lowerBound3 <= (float32(numBackend1)/total)*100 && (float32(numBackend1)/total)*100 <= upperBound3
 	assert.True(t, expect3)
 }
+
+func TestWeightedLeastLatency_Next(t *testing.T) {
+	destinations := []*v1alpha1.RouteDestination{
+		{Cluster: "fast", Weight: lo.ToPtr(int32(1))},
+		{Cluster: "slow", Weight: lo.ToPtr(int32(1))},
+		{Cluster: "heavy", Weight: lo.ToPtr(int32(4))},
+	}
+
+	latencies := map[string]LatencyStats{
+		"fast": {Duration: 300 * time.Millisecond, Samples: 1},
+		"slow": {Duration: 2 * time.Second, Samples: 1},
+	}
+
+	lb := NewWeightedLeastLatency(destinations)
+	lb.latency = func(cluster string) (LatencyStats, bool) {
+		stats, ok := latencies[cluster]
+		return stats, ok
+	}
+
+	// Targets never observed are tried first
+	assert.Equal(t, "heavy", lb.Next(context.TODO(), nil))
+
+	latencies["heavy"] = LatencyStats{Duration: time.Second, Samples: 1}
+
+	counts := make(map[string]int)
+	for range 1000 {
+		counts[lb.Next(context.TODO(), nil)]++
+	}
+
+	// 1s / 4 beats 300ms / 1, the rest are spread by weights to explore
+	assert.Greater(t, counts["heavy"], 850)
+	assert.Positive(t, counts["fast"])
+	assert.Positive(t, counts["slow"])
+
+	// Time to first chunk takes precedence once streams are observed
+	latencies["heavy"] = LatencyStats{Duration: time.Second, FirstChunk: 4 * time.Second, Samples: 2, FirstChunkSamples: 1}
+
+	counts = make(map[string]int)
+	for range 1000 {
+		counts[lb.Next(context.TODO(), nil)]++
+	}
+
+	assert.Greater(t, counts["fast"], 850)
+}
+
+func TestLeastLatencyEndpoint(t *testing.T) {
+	endpoints := []string{"http://10.0.0.1:8000", "http://10.0.0.2:8000"}
+	now := time.Now()
+
+	next := func() string { return endpoints[1] }
+
+	// Endpoints never observed are tried first
+	assert.Equal(t, endpoints[0], LeastLatencyEndpoint(endpoints, next))
+
+	ObserveUpstreamAttempt(metadata.UpstreamAttempt{Cluster: "least-latency-endpoint-test", Endpoint: endpoints[0], RequestAt: now, RespondAt: now.Add(300 * time.Millisecond)})
+	assert.Equal(t, endpoints[1], LeastLatencyEndpoint(endpoints, next))
+
+	ObserveUpstreamAttempt(metadata.UpstreamAttempt{Cluster: "least-latency-endpoint-test", Endpoint: endpoints[1], RequestAt: now, RespondAt: now.Add(2 * time.Second)})
+
+	counts := make(map[string]int)
+	for range 1000 {
+		counts[LeastLatencyEndpoint(endpoints, next)]++
+	}
+
+	// The rest are sent to the next endpoint to explore
+	assert.Greater(t, counts[endpoints[0]], 850)
+	assert.Positive(t, counts[endpoints[1]])
+
+	stats, ok := UpstreamEndpointLatency(endpoints[0])
+	require.True(t, ok)
+	assert.Equal(t, 300*time.Millisecond, stats.Duration)
+}
@@ -0,0 +1,145 @@
+package loadbalance
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/api/route/v1alpha1"
+	"knoway.dev/pkg/object"
+)
+
+const sessionUserField = "user"
+
+// SessionKey returns the ID of the session the request belongs to under the
+// session affinity, empty if the request has none.
+func SessionKey(cfg *v1alpha1.SessionAffinity, request object.LLMRequest) string {
+	if cfg == nil {
+		return ""
+	}
+
+	if cfg.GetHeader() != "" && request.GetRawRequest() != nil {
+		if key := request.GetRawRequest().Header.Get(cfg.GetHeader()); key != "" {
+			return key
+		}
+	}
+
+	if !cfg.GetUserField() {
+		return ""
+	}
+
+	parsed, ok := request.(interface{ GetBodyParsed() map[string]any })
+	if !ok {
+		return ""
+	}
+
+	user, _ := parsed.GetBodyParsed()[sessionUserField].(string)
+
+	return user
+}
+
+var _ LoadBalancer = (*sessionAffinity)(nil)
+
+// sessionAffinity sends the requests of the same session to the same server
+// by rendezvous hashing weighted by the weights of the servers, so that only
+// the sessions of the servers removed move to other servers. Requests without
+// sessions are balanced by the load balancer wrapped.
+type sessionAffinity struct {
+	LoadBalancer
+
+	cfg     *v1alpha1.SessionAffinity
+	servers []*server
+}
+
+func newSessionAffinity(lb LoadBalancer, cfg *v1alpha1.SessionAffinity, destinations []*v1alpha1.RouteDestination) *sessionAffinity {
+	return &sessionAffinity{
+		LoadBalancer: lb,
+		cfg:          cfg,
+		servers:      newServers(destinations),
+	}
+}
+
+type triedTargetsKey struct{}
+
+// WithTriedTargets returns a copy of ctx carrying the targets the request has
+// been sent to, retries of sessions are sent to the other targets.
+func WithTriedTargets(ctx context.Context, tried []string) context.Context {
+	return context.WithValue(ctx, triedTargetsKey{}, tried)
+}
+
+func triedTargetsFromCtx(ctx context.Context) []string {
+	tried, _ := ctx.Value(triedTargetsKey{}).([]string)
+
+	return tried
+}
+
+func (s *sessionAffinity) Next(ctx context.Context, request object.LLMRequest) string {
+	key := SessionKey(s.cfg, request)
+	if key == "" {
+		return s.LoadBalancer.Next(ctx, request)
+	}
+
+	servers := s.servers
+
+	if tried := triedTargetsFromCtx(ctx); len(tried) > 0 {
+		// Rendezvous hashing picks the same target every time, retries go to
+		// the next of the session instead
+		servers = lo.Reject(s.servers, func(server *server, _ int) bool {
+			return lo.Contains(tried, server.name)
+		})
+	}
+
+	if selected := rendezvousHash(key, servers); selected != "" {
+		return selected
+	}
+
+	return s.LoadBalancer.Next(ctx, request)
+}
+
+// rendezvousHash returns the name of the server with the highest weighted
+// score for the key, servers without weights are only picked when none of
+// them has weights.
+func rendezvousHash(key string, servers []*server) string {
+	weighted := false
+
+	for _, s := range servers {
+		if s.weight > 0 {
+			weighted = true
+			break
+		}
+	}
+
+	var (
+		selected  string
+		bestScore = math.Inf(-1)
+	)
+
+	for _, s := range servers {
+		weight := float64(s.weight)
+		if !weighted {
+			weight = 1
+		}
+
+		if weight <= 0 {
+			continue
+		}
+
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(s.name))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(key))
+
+		// Uniform in (0, 1) from the upper 53 bits of the hash
+		u := (float64(h.Sum64()>>11) + 0.5) / (1 << 53) //nolint:mnd
+
+		score := -weight / math.Log(u)
+		if score > bestScore {
+			selected = s.name
+			bestScore = score
+		}
+	}
+
+	return selected
+}
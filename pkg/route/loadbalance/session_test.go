@@ -0,0 +1,130 @@
+package loadbalance
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/route/v1alpha1"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+func newSessionRequest(t *testing.T, conversationID string, body string) object.LLMRequest {
+	t.Helper()
+
+	httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com/v1/chat/completions", bytes.NewBufferString(body))
+	require.NoError(t, err)
+
+	if conversationID != "" {
+		httpRequest.Header.Set("X-Conversation-ID", conversationID)
+	}
+
+	request, err := openai.NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	return request
+}
+
+func TestSessionKey(t *testing.T) {
+	cfg := &v1alpha1.SessionAffinity{Header: "X-Conversation-ID", UserField: true}
+
+	assert.Equal(t, "conv-1", SessionKey(cfg, newSessionRequest(t, "conv-1", `{"model": "gpt-4o", "messages": [], "user": "u-1"}`)))
+	assert.Equal(t, "u-1", SessionKey(cfg, newSessionRequest(t, "", `{"model": "gpt-4o", "messages": [], "user": "u-1"}`)))
+	assert.Empty(t, SessionKey(cfg, newSessionRequest(t, "", `{"model": "gpt-4o", "messages": []}`)))
+	assert.Empty(t, SessionKey(&v1alpha1.SessionAffinity{Header: "X-Conversation-ID"}, newSessionRequest(t, "", `{"model": "gpt-4o", "messages": [], "user": "u-1"}`)))
+	assert.Empty(t, SessionKey(nil, newSessionRequest(t, "conv-1", `{"model": "gpt-4o", "messages": []}`)))
+}
+
+func TestSessionAffinity_Next(t *testing.T) {
+	destinations := []*v1alpha1.RouteDestination{
+		{Cluster: "vllm-a", Weight: lo.ToPtr(int32(1))},
+		{Cluster: "vllm-b", Weight: lo.ToPtr(int32(1))},
+		{Cluster: "vllm-c", Weight: lo.ToPtr(int32(2))},
+	}
+
+	lb := New(&v1alpha1.Route{
+		LoadBalancePolicy: v1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_ROUND_ROBIN,
+		Targets: lo.Map(destinations, func(item *v1alpha1.RouteDestination, _ int) *v1alpha1.RouteTarget {
+			return &v1alpha1.RouteTarget{Destination: item}
+		}),
+		SessionAffinity: &v1alpha1.SessionAffinity{Header: "X-Conversation-ID"},
+	})
+
+	_, ok := lb.(*sessionAffinity)
+	require.True(t, ok)
+
+	counts := make(map[string]int)
+
+	for i := range 4000 {
+		request := newSessionRequest(t, fmt.Sprintf("conv-%d", i), `{"model": "qwen3", "messages": []}`)
+
+		selected := lb.Next(context.Background(), request)
+		counts[selected]++
+
+		// Every turn of the conversation reaches the same target
+		for range 3 {
+			assert.Equal(t, selected, lb.Next(context.Background(), request))
+		}
+	}
+
+	// Sessions are spread by the weights
+	assert.InDelta(t, 1000, counts["vllm-a"], 150)
+	assert.InDelta(t, 1000, counts["vllm-b"], 150)
+	assert.InDelta(t, 2000, counts["vllm-c"], 150)
+
+	// Only the sessions of the target removed move
+	remaining := newServers(destinations[:2])
+
+	for i := range 1000 {
+		key := fmt.Sprintf("conv-%d", i)
+
+		before := rendezvousHash(key, newServers(destinations))
+		if before != "vllm-c" {
+			assert.Equal(t, before, rendezvousHash(key, remaining))
+		}
+	}
+
+	// Requests without sessions are load balanced
+	assert.NotEmpty(t, lb.Next(context.Background(), newSessionRequest(t, "", `{"model": "qwen3", "messages": []}`)))
+
+	// Retries are sent to the targets not tried yet
+	request := newSessionRequest(t, "conv-retry", `{"model": "qwen3", "messages": []}`)
+
+	first := lb.Next(context.Background(), request)
+	second := lb.Next(WithTriedTargets(context.Background(), []string{first}), request)
+	assert.NotEqual(t, first, second)
+
+	third := lb.Next(WithTriedTargets(context.Background(), []string{first, second}), request)
+	assert.NotContains(t, []string{first, second}, third)
+
+	// The load balancer wrapped takes over once all of them are tried
+	assert.NotEmpty(t, lb.Next(WithTriedTargets(context.Background(), []string{"vllm-a", "vllm-b", "vllm-c"}), request))
+}
+
+func TestNew_SessionAffinityWithoutLoadBalancePolicy(t *testing.T) {
+	lb := New(&v1alpha1.Route{
+		Targets:         []*v1alpha1.RouteTarget{{Destination: &v1alpha1.RouteDestination{Cluster: "vllm-a"}}},
+		SessionAffinity: &v1alpha1.SessionAffinity{Header: "X-Conversation-ID"},
+	})
+
+	_, ok := lb.(*sessionAffinity)
+	assert.False(t, ok)
+}
+
+func TestRendezvousHash_Unweighted(t *testing.T) {
+	servers := newServers([]*v1alpha1.RouteDestination{{Cluster: "vllm-a"}, {Cluster: "vllm-b"}})
+
+	counts := lo.CountValues(lo.Times(1000, func(i int) string {
+		return rendezvousHash(fmt.Sprintf("conv-%d", i), servers)
+	}))
+
+	assert.InDelta(t, 500, counts["vllm-a"], 100)
+	assert.InDelta(t, 500, counts["vllm-b"], 100)
+}
@@ -0,0 +1,189 @@
+package loadbalance
+
+import (
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"knoway.dev/api/route/v1alpha1"
+	"knoway.dev/pkg/events"
+	"knoway.dev/pkg/observation"
+)
+
+const (
+	defaultFirstChunkSLOWindow              = time.Minute
+	defaultFirstChunkSLOMinWeightPercent    = 10
+	defaultFirstChunkSLORecoveryStepPercent = 20
+
+	// firstChunkSLOEvaluationInterval throttles the evaluations of targets,
+	// which are made lazily while picking them.
+	firstChunkSLOEvaluationInterval = time.Second
+	// firstChunkSLOMinSamples is the number of samples required in the window
+	// for the P95 latency to be trusted, targets with fewer samples are
+	// considered meeting the objective, so that demoted targets receiving
+	// little traffic still recover.
+	firstChunkSLOMinSamples = 10
+
+	weightChangeDemote  = "demote"
+	weightChangeRestore = "restore"
+)
+
+// firstChunkSLO demotes the targets of a route whose P95 latency of first
+// chunks exceeds the objective for a sustained window, halving their
+// effective weights down to minPercent at most once per window, and
+// restores them by recoveryStep percent per window once they recover.
+type firstChunkSLO struct {
+	route        string
+	objective    time.Duration
+	window       time.Duration
+	minPercent   uint32
+	recoveryStep uint32
+
+	percentile func(cluster string, q float64, since time.Time) (time.Duration, int)
+	now        func() time.Time
+
+	targets map[string]*targetWeight
+}
+
+func newFirstChunkSLO(router *v1alpha1.Route) *firstChunkSLO {
+	cfg := router.GetFirstChunkSlo()
+	if cfg == nil || cfg.GetP95().AsDuration() <= 0 {
+		return nil
+	}
+
+	slo := &firstChunkSLO{
+		route:        router.GetName(),
+		objective:    cfg.GetP95().AsDuration(),
+		window:       defaultFirstChunkSLOWindow,
+		minPercent:   defaultFirstChunkSLOMinWeightPercent,
+		recoveryStep: defaultFirstChunkSLORecoveryStepPercent,
+		percentile:   UpstreamFirstChunkPercentile,
+		now:          time.Now,
+		targets:      make(map[string]*targetWeight),
+	}
+
+	if cfg.Window != nil && cfg.GetWindow().AsDuration() > 0 {
+		slo.window = cfg.GetWindow().AsDuration()
+	}
+	if cfg.MinWeightPercent != nil {
+		slo.minPercent = min(max(cfg.GetMinWeightPercent(), 1), 100) //nolint:mnd
+	}
+	if cfg.RecoveryStepPercent != nil && cfg.GetRecoveryStepPercent() > 0 {
+		slo.recoveryStep = cfg.GetRecoveryStepPercent()
+	}
+
+	return slo
+}
+
+// attach makes the servers share the effective weights of the targets they
+// point to.
+func (s *firstChunkSLO) attach(servers []*server) {
+	for _, srv := range servers {
+		weight, ok := s.targets[srv.name]
+		if !ok {
+			weight = &targetWeight{slo: s, target: srv.name, percent: 100} //nolint:mnd
+			s.targets[srv.name] = weight
+		}
+
+		srv.demotion = weight
+	}
+}
+
+// targetWeight is the effective weight of a route target, in percent of the
+// configured weight.
+type targetWeight struct {
+	slo    *firstChunkSLO
+	target string
+
+	mutex          sync.Mutex
+	percent        uint32
+	evaluatedAt    time.Time
+	violatingSince time.Time
+	meetingSince   time.Time
+	changedAt      time.Time
+}
+
+func (w *targetWeight) weightPercent() uint32 {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	now := w.slo.now()
+	if now.Sub(w.evaluatedAt) >= firstChunkSLOEvaluationInterval {
+		w.evaluatedAt = now
+		w.evaluate(now)
+	}
+
+	return w.percent
+}
+
+func (w *targetWeight) evaluate(now time.Time) {
+	p95, samples := w.slo.percentile(w.target, 0.95, now.Add(-w.slo.window)) //nolint:mnd
+
+	if samples >= firstChunkSLOMinSamples && p95 > w.slo.objective {
+		w.meetingSince = time.Time{}
+		if w.violatingSince.IsZero() {
+			w.violatingSince = now
+		}
+
+		if w.percent <= w.slo.minPercent || !w.sustained(now, w.violatingSince) {
+			return
+		}
+
+		w.percent = max(w.percent/2, w.slo.minPercent) //nolint:mnd
+		w.changedAt = now
+
+		slog.Warn("demoted route target for exceeding the first chunk latency objective",
+			slog.String("route", w.slo.route),
+			slog.String("target", w.target),
+			slog.Duration("p95", p95),
+			slog.Duration("objective", w.slo.objective),
+			slog.Int("samples", samples),
+			slog.Any("weightPercent", w.percent),
+		)
+		observation.ObserveRouteTargetWeightChange(w.slo.route, w.target, weightChangeDemote, w.percent)
+		w.publish(events.TypeTargetDemoted, p95)
+
+		return
+	}
+
+	w.violatingSince = time.Time{}
+	if w.meetingSince.IsZero() {
+		w.meetingSince = now
+	}
+
+	if w.percent >= 100 || !w.sustained(now, w.meetingSince) {
+		return
+	}
+
+	w.percent = min(w.percent+w.slo.recoveryStep, 100) //nolint:mnd
+	w.changedAt = now
+
+	slog.Info("restored route target meeting the first chunk latency objective",
+		slog.String("route", w.slo.route),
+		slog.String("target", w.target),
+		slog.Duration("p95", p95),
+		slog.Duration("objective", w.slo.objective),
+		slog.Int("samples", samples),
+		slog.Any("weightPercent", w.percent),
+	)
+	observation.ObserveRouteTargetWeightChange(w.slo.route, w.target, weightChangeRestore, w.percent)
+	w.publish(events.TypeTargetRestored, p95)
+}
+
+// publish publishes the change of the weight of the target by its P95
+// latency of first chunks.
+func (w *targetWeight) publish(t events.Type, p95 time.Duration) {
+	events.Publish(t, map[string]string{
+		"route":         w.slo.route,
+		"target":        w.target,
+		"p95":           p95.String(),
+		"weightPercent": strconv.FormatUint(uint64(w.percent), 10),
+	})
+}
+
+// sustained reports whether the state entered since the given time has
+// lasted a window, as well as the last change of the weight.
+func (w *targetWeight) sustained(now time.Time, since time.Time) bool {
+	return now.Sub(since) >= w.slo.window && now.Sub(w.changedAt) >= w.slo.window
+}
@@ -0,0 +1,233 @@
+package loadbalance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"knoway.dev/api/route/v1alpha1"
+	"knoway.dev/pkg/events"
+)
+
+type fakeFirstChunkLatencies struct {
+	now     time.Time
+	p95     map[string]time.Duration
+	samples int
+}
+
+func (f *fakeFirstChunkLatencies) percentile(cluster string, _ float64, _ time.Time) (time.Duration, int) {
+	return f.p95[cluster], f.samples
+}
+
+func newTestFirstChunkSLO(t *testing.T, cfg *v1alpha1.FirstChunkSLO, fake *fakeFirstChunkLatencies) *firstChunkSLO {
+	t.Helper()
+
+	slo := newFirstChunkSLO(&v1alpha1.Route{Name: "slo-test", FirstChunkSlo: cfg})
+	require.NotNil(t, slo)
+
+	slo.percentile = fake.percentile
+	slo.now = func() time.Time { return fake.now }
+
+	return slo
+}
+
+func TestNewFirstChunkSLO(t *testing.T) {
+	assert.Nil(t, newFirstChunkSLO(&v1alpha1.Route{}))
+	assert.Nil(t, newFirstChunkSLO(&v1alpha1.Route{FirstChunkSlo: &v1alpha1.FirstChunkSLO{}}))
+
+	slo := newFirstChunkSLO(&v1alpha1.Route{FirstChunkSlo: &v1alpha1.FirstChunkSLO{P95: durationpb.New(time.Second)}})
+	require.NotNil(t, slo)
+	assert.Equal(t, time.Second, slo.objective)
+	assert.Equal(t, defaultFirstChunkSLOWindow, slo.window)
+	assert.Equal(t, uint32(defaultFirstChunkSLOMinWeightPercent), slo.minPercent)
+	assert.Equal(t, uint32(defaultFirstChunkSLORecoveryStepPercent), slo.recoveryStep)
+
+	slo = newFirstChunkSLO(&v1alpha1.Route{FirstChunkSlo: &v1alpha1.FirstChunkSLO{
+		P95:                 durationpb.New(time.Second),
+		Window:              durationpb.New(5 * time.Minute),
+		MinWeightPercent:    proto.Uint32(0),
+		RecoveryStepPercent: proto.Uint32(50),
+	}})
+	require.NotNil(t, slo)
+	assert.Equal(t, 5*time.Minute, slo.window)
+	assert.Equal(t, uint32(1), slo.minPercent)
+	assert.Equal(t, uint32(50), slo.recoveryStep)
+}
+
+func TestFirstChunkSLO_DemoteAndRestore(t *testing.T) {
+	fake := &fakeFirstChunkLatencies{
+		now:     time.Now(),
+		p95:     map[string]time.Duration{"slow": 3 * time.Second, "fast": 200 * time.Millisecond},
+		samples: 100,
+	}
+
+	slo := newTestFirstChunkSLO(t, &v1alpha1.FirstChunkSLO{
+		P95:              durationpb.New(time.Second),
+		Window:           durationpb.New(time.Minute),
+		MinWeightPercent: proto.Uint32(20),
+	}, fake)
+
+	servers := newServers([]*v1alpha1.RouteDestination{
+		{Cluster: "slow", Weight: proto.Int32(1)},
+		{Cluster: "fast", Weight: proto.Int32(1)},
+	})
+	slo.attach(servers)
+
+	slow, fast := servers[0], servers[1]
+
+	// Violations are tolerated until they last for a window
+	assert.Equal(t, int64(100), slow.effectiveWeight())
+
+	fake.now = fake.now.Add(30 * time.Second)
+	assert.Equal(t, int64(100), slow.effectiveWeight())
+
+	fake.now = fake.now.Add(30 * time.Second)
+	assert.Equal(t, int64(50), slow.effectiveWeight())
+	assert.Equal(t, int64(100), fast.effectiveWeight())
+
+	// At most one demotion per window, down to the lower bound
+	fake.now = fake.now.Add(30 * time.Second)
+	assert.Equal(t, int64(50), slow.effectiveWeight())
+
+	fake.now = fake.now.Add(30 * time.Second)
+	assert.Equal(t, int64(25), slow.effectiveWeight())
+
+	fake.now = fake.now.Add(time.Minute)
+	assert.Equal(t, int64(20), slow.effectiveWeight())
+
+	fake.now = fake.now.Add(time.Minute)
+	assert.Equal(t, int64(20), slow.effectiveWeight())
+
+	// Recovered targets are restored step by step once the objective has
+	// been met for a window
+	fake.p95["slow"] = 500 * time.Millisecond
+
+	fake.now = fake.now.Add(time.Second)
+	assert.Equal(t, int64(20), slow.effectiveWeight())
+
+	fake.now = fake.now.Add(time.Minute)
+	assert.Equal(t, int64(40), slow.effectiveWeight())
+
+	for range 4 {
+		fake.now = fake.now.Add(time.Minute)
+		slow.effectiveWeight()
+	}
+
+	assert.Equal(t, int64(100), slow.effectiveWeight())
+}
+
+func TestFirstChunkSLO_Events(t *testing.T) {
+	fake := &fakeFirstChunkLatencies{
+		now:     time.Now(),
+		p95:     map[string]time.Duration{"slow": 3 * time.Second},
+		samples: 100,
+	}
+
+	slo := newTestFirstChunkSLO(t, &v1alpha1.FirstChunkSLO{
+		P95:    durationpb.New(time.Second),
+		Window: durationpb.New(time.Minute),
+	}, fake)
+
+	servers := newServers([]*v1alpha1.RouteDestination{{Cluster: "slow", Weight: proto.Int32(1)}})
+	slo.attach(servers)
+
+	sub := events.Subscribe(events.DefaultSubscriptionBuffer, events.TypeTargetDemoted, events.TypeTargetRestored)
+	defer sub.Close()
+
+	servers[0].effectiveWeight()
+
+	fake.now = fake.now.Add(time.Minute)
+	servers[0].effectiveWeight()
+
+	fake.p95["slow"] = 500 * time.Millisecond
+
+	fake.now = fake.now.Add(time.Second)
+	servers[0].effectiveWeight()
+
+	fake.now = fake.now.Add(time.Minute)
+	servers[0].effectiveWeight()
+
+	require.Len(t, sub.Events(), 2)
+
+	demoted := <-sub.Events()
+	assert.Equal(t, events.TypeTargetDemoted, demoted.Type)
+	assert.Equal(t, map[string]string{
+		"route":         "slo-test",
+		"target":        "slow",
+		"p95":           "3s",
+		"weightPercent": "50",
+	}, demoted.Attributes)
+
+	restored := <-sub.Events()
+	assert.Equal(t, events.TypeTargetRestored, restored.Type)
+	assert.Equal(t, map[string]string{
+		"route":         "slo-test",
+		"target":        "slow",
+		"p95":           "500ms",
+		"weightPercent": "70",
+	}, restored.Attributes)
+}
+
+func TestFirstChunkSLO_InsufficientSamples(t *testing.T) {
+	fake := &fakeFirstChunkLatencies{
+		now:     time.Now(),
+		p95:     map[string]time.Duration{"slow": 3 * time.Second},
+		samples: firstChunkSLOMinSamples - 1,
+	}
+
+	slo := newTestFirstChunkSLO(t, &v1alpha1.FirstChunkSLO{P95: durationpb.New(time.Second)}, fake)
+
+	servers := newServers([]*v1alpha1.RouteDestination{{Cluster: "slow", Weight: proto.Int32(2)}})
+	slo.attach(servers)
+
+	for range 3 {
+		fake.now = fake.now.Add(time.Minute)
+		assert.Equal(t, int64(200), servers[0].effectiveWeight())
+	}
+}
+
+func TestNew_FirstChunkSLO(t *testing.T) {
+	fake := &fakeFirstChunkLatencies{
+		now:     time.Now(),
+		p95:     map[string]time.Duration{"slow": 3 * time.Second, "fast": 200 * time.Millisecond},
+		samples: 100,
+	}
+
+	lb := New(&v1alpha1.Route{
+		Name:              "slo-test",
+		LoadBalancePolicy: v1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_ROUND_ROBIN,
+		Targets: []*v1alpha1.RouteTarget{
+			{Destination: &v1alpha1.RouteDestination{Cluster: "slow", Weight: proto.Int32(1)}},
+			{Destination: &v1alpha1.RouteDestination{Cluster: "fast", Weight: proto.Int32(1)}},
+		},
+		FirstChunkSlo: &v1alpha1.FirstChunkSLO{
+			P95:              durationpb.New(time.Second),
+			MinWeightPercent: proto.Uint32(1),
+		},
+	})
+
+	wrr, ok := lb.(*WeightedRoundRobin)
+	require.True(t, ok)
+
+	for _, s := range wrr.servers {
+		s.demotion.slo.percentile = fake.percentile
+		s.demotion.slo.now = func() time.Time { return fake.now }
+	}
+
+	for range 8 {
+		fake.now = fake.now.Add(time.Minute)
+		wrr.servers[0].effectiveWeight()
+	}
+
+	counts := make(map[string]int)
+	for range 1000 {
+		counts[lb.Next(context.Background(), nil)]++
+	}
+
+	assert.Greater(t, counts["fast"], 900)
+}
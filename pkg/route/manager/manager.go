@@ -1,16 +1,35 @@
+// Package manager keeps the routes that requests are matched against.
+//
+// Routes come from two sources: match routes registered for ModelRoutes, and
+// base routes registered for every backend so that it can be requested
+// directly by its model name. Both are keyed by model name, when a ModelRoute
+// uses the model name of a backend, the ModelRoute takes precedence and the
+// direct route of the backend is shadowed until the ModelRoute is removed.
+// Routes are matched in a deterministic order: match routes first, then base
+// routes, each sorted by name.
+//
+// Requested model names that match no route as is are normalized according
+// to the rules of the global normalizer before being matched again, see
+// NormalizeRequestModel. Requests of tenants with model namespaces are then
+// scoped to the models of their namespaces, see ScopeRequestModel.
 package manager
 
 import (
 	"context"
 	"log/slog"
+	"sort"
 	"sync"
+	"sync/atomic"
 
 	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/events"
 	"knoway.dev/pkg/metadata"
 	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/tenant"
 
 	"knoway.dev/api/route/v1alpha1"
 	"knoway.dev/pkg/route"
+	"knoway.dev/pkg/route/normalize"
 	rroute "knoway.dev/pkg/route/route"
 
 	"github.com/samber/lo"
@@ -19,9 +38,12 @@ import (
 var (
 	matchRouteRegistry = make(map[string]route.Route)
 	routeRegistry      = make(map[string]route.Route)
+	routeLock          sync.RWMutex
 
-	routes    = make([]route.Route, 0)
-	routeLock sync.RWMutex
+	// routes is the snapshot of the merged routes that requests are matched
+	// against, replaced as a whole whenever the registries change, so that
+	// matching requests never waits for the reconciliation of routes.
+	routes atomic.Pointer[[]route.Route]
 )
 
 func InitDirectModelRoute(modelName string) *v1alpha1.Route {
@@ -48,18 +70,25 @@ func InitDirectModelRoute(modelName string) *v1alpha1.Route {
 }
 
 func RegisterMatchRouteWithConfig(cfg *v1alpha1.Route, lifecycle bootkit.LifeCycle) error {
-	routeLock.Lock()
-	defer routeLock.Unlock()
-
+	// Building filters of routes may take a while, e.g. connecting to
+	// Redis, keep it out of the lock.
 	r, err := rroute.NewWithConfig(cfg, lifecycle)
 	if err != nil {
 		return err
 	}
 
+	routeLock.Lock()
+	defer routeLock.Unlock()
+
 	matchRouteRegistry[cfg.GetName()] = r
-	routes = mergeRoutes()
+	storeRoutes()
 
 	slog.Info("register match route", "name", cfg.GetName())
+	publishRouteEvent(events.TypeRouteChanged, cfg.GetName(), routeKindMatch)
+
+	if _, exists := routeRegistry[cfg.GetName()]; exists && shadowed(cfg.GetName()) {
+		slog.Warn("match route shadows the base route of the same name", "name", cfg.GetName())
+	}
 
 	return nil
 }
@@ -69,67 +98,193 @@ func RemoveMatchRoute(rName string) {
 	defer routeLock.Unlock()
 
 	delete(matchRouteRegistry, rName)
-
-	routes = mergeRoutes()
+	storeRoutes()
 
 	slog.Info("remove match route", "name", rName)
+	publishRouteEvent(events.TypeRouteRemoved, rName, routeKindMatch)
 }
 
 func RegisterBaseRouteWithConfig(cfg *v1alpha1.Route, lifecycle bootkit.LifeCycle) error {
-	routeLock.Lock()
-	defer routeLock.Unlock()
-
 	r, err := rroute.NewWithConfig(cfg, lifecycle)
 	if err != nil {
 		return err
 	}
 
+	routeLock.Lock()
+	defer routeLock.Unlock()
+
 	routeRegistry[cfg.GetName()] = r
 
 	if _, exists := matchRouteRegistry[cfg.GetName()]; exists {
-		slog.Info("route exists in matchRouteRegistry, skipping base route registration", "name", cfg.GetName())
+		slog.Warn("base route is shadowed by the match route of the same name", "name", cfg.GetName())
 		return nil
 	}
 
-	routes = mergeRoutes()
+	storeRoutes()
 
 	slog.Info("register base route", "name", cfg.GetName())
+	publishRouteEvent(events.TypeRouteChanged, cfg.GetName(), routeKindBase)
 
 	return nil
 }
 
+// GetMatchRouteConfig returns the configuration of the registered match
+// route.
+func GetMatchRouteConfig(rName string) (*v1alpha1.Route, bool) {
+	routeLock.RLock()
+	defer routeLock.RUnlock()
+
+	r, ok := matchRouteRegistry[rName]
+	if !ok {
+		return nil, false
+	}
+
+	return r.GetRouteConfig(), true
+}
+
+// GetBaseRouteConfig returns the configuration of the registered base route.
+func GetBaseRouteConfig(rName string) (*v1alpha1.Route, bool) {
+	routeLock.RLock()
+	defer routeLock.RUnlock()
+
+	r, ok := routeRegistry[rName]
+	if !ok {
+		return nil, false
+	}
+
+	return r.GetRouteConfig(), true
+}
+
 func RemoveBaseRoute(rName string) {
 	routeLock.Lock()
 	defer routeLock.Unlock()
 
 	delete(routeRegistry, rName)
-
-	routes = mergeRoutes()
+	storeRoutes()
 
 	slog.Info("remove base route", "name", rName)
+	publishRouteEvent(events.TypeRouteRemoved, rName, routeKindBase)
+}
+
+const (
+	routeKindMatch = "match"
+	routeKindBase  = "base"
+)
+
+func publishRouteEvent(t events.Type, name, kind string) {
+	events.Publish(t, map[string]string{
+		"route": name,
+		"kind":  kind,
+	})
+}
+
+// storeRoutes replaces the snapshot of routes, routeLock must be held.
+func storeRoutes() {
+	merged := mergeRoutes()
+	routes.Store(&merged)
+}
+
+func loadRoutes() []route.Route {
+	snapshot := routes.Load()
+	if snapshot == nil {
+		return nil
+	}
+
+	return *snapshot
 }
 
+// mergeRoutes orders the match routes by their specificity, so that the
+// routes with conditions besides the model are matched before the ones of
+// the same models without, followed by the base routes not shadowed by them.
 func mergeRoutes() []route.Route {
-	uniqueRoutes := make(map[string]route.Route)
+	merged := make([]route.Route, 0, len(matchRouteRegistry)+len(routeRegistry))
 
-	for k, v := range matchRouteRegistry {
-		uniqueRoutes[k] = v
+	for _, name := range sortedKeys(matchRouteRegistry) {
+		merged = append(merged, matchRouteRegistry[name])
 	}
 
-	for k, v := range routeRegistry {
-		if _, exists := uniqueRoutes[k]; !exists {
-			uniqueRoutes[k] = v
+	sort.SliceStable(merged, func(i, j int) bool {
+		return rroute.Specificity(merged[i].GetRouteConfig()) > rroute.Specificity(merged[j].GetRouteConfig())
+	})
+
+	for _, name := range sortedKeys(routeRegistry) {
+		if !shadowed(name) {
+			merged = append(merged, routeRegistry[name])
 		}
 	}
 
-	return lo.Values(uniqueRoutes)
+	return merged
 }
 
-func MatchRoute(ctx context.Context, request object.LLMRequest) route.Route {
+// shadowed reports whether the base route is shadowed by the match route of
+// the same name, conditional match routes leave the requests they don't
+// match to the base route.
+func shadowed(name string) bool {
+	matchRoute, exists := matchRouteRegistry[name]
+
+	return exists && !rroute.Conditional(matchRoute.GetRouteConfig())
+}
+
+func sortedKeys(m map[string]route.Route) []string {
+	keys := lo.Keys(m)
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Conflict describes a base route shadowed by a match route of the same model
+// name.
+type Conflict struct {
+	Model string `json:"model"`
+	// Targets are the clusters the match route sends requests to
+	Targets []string `json:"targets"`
+	// ShadowedCluster is the cluster that is no longer reachable directly by
+	// the model name
+	ShadowedCluster string `json:"shadowed_cluster"`
+}
+
+func routeClusters(r route.Route) []string {
+	return lo.Map(r.GetRouteConfig().GetTargets(), func(item *v1alpha1.RouteTarget, _ int) string {
+		return item.GetDestination().GetCluster()
+	})
+}
+
+// Conflicts lists the base routes shadowed by match routes. Base routes whose
+// cluster is one of the targets of the match route are not reported since
+// the cluster is still served through the match route, neither are the ones
+// of conditional match routes, which serve the rest of the requests.
+func Conflicts() []Conflict {
 	routeLock.RLock()
 	defer routeLock.RUnlock()
 
-	for _, r := range routes {
+	conflicts := make([]Conflict, 0)
+
+	for _, name := range sortedKeys(matchRouteRegistry) {
+		baseRoute, ok := routeRegistry[name]
+		if !ok || !shadowed(name) {
+			continue
+		}
+
+		targets := routeClusters(matchRouteRegistry[name])
+
+		for _, cluster := range routeClusters(baseRoute) {
+			if lo.Contains(targets, cluster) {
+				continue
+			}
+
+			conflicts = append(conflicts, Conflict{
+				Model:           name,
+				Targets:         targets,
+				ShadowedCluster: cluster,
+			})
+		}
+	}
+
+	return conflicts
+}
+
+func MatchRoute(ctx context.Context, request object.LLMRequest) route.Route {
+	for _, r := range loadRoutes() {
 		if r.Match(ctx, request) {
 			return r
 		}
@@ -138,6 +293,61 @@ func MatchRoute(ctx context.Context, request object.LLMRequest) route.Route {
 	return nil
 }
 
+// NormalizeRequestModel rewrites the model of the request to its normalized
+// form. Model names matching a route as is are kept, so that routes whose
+// names look like aliases, e.g. "meta-llama/Llama-3.1-8B", keep working.
+func NormalizeRequestModel(ctx context.Context, request object.LLMRequest) error {
+	normalizer := normalize.Global()
+	if normalizer == nil {
+		return nil
+	}
+
+	model := request.GetModel()
+
+	normalized := normalizer.Normalize(model)
+	if normalized == model || MatchRoute(ctx, request) != nil {
+		return nil
+	}
+
+	slog.Debug("normalized request model", "model", model, "normalized", normalized)
+
+	return request.SetModel(normalized)
+}
+
+// ScopeRequestModel rewrites the model of the request to the one in the
+// namespace of its tenant if there is a route of it, e.g. "gpt-4o" to
+// "org-a/gpt-4o", and rejects the requests for models in the namespaces of
+// other tenants as if the models didn't exist.
+func ScopeRequestModel(ctx context.Context, request object.LLMRequest) error {
+	model := request.GetModel()
+	namespace := tenant.NamespaceFromContext(ctx)
+
+	owner, _ := tenant.SplitModel(model)
+	if owner != "" {
+		if owner != namespace {
+			return object.NewErrorModelNotFoundOrNotAccessible(model)
+		}
+
+		return nil
+	}
+
+	if namespace == "" {
+		return nil
+	}
+
+	err := request.SetModel(tenant.ScopedModel(namespace, model))
+	if err != nil {
+		return err
+	}
+
+	if MatchRoute(ctx, request) != nil {
+		slog.Debug("scoped request model", "model", model, "namespace", namespace)
+		return nil
+	}
+
+	return request.SetModel(model)
+}
+
 func HandleRequest(ctx context.Context, llmRequest object.LLMRequest) (object.LLMResponse, error) {
 	route := MatchRoute(ctx, llmRequest)
 	if route == nil {
@@ -151,10 +361,18 @@ func HandleRequest(ctx context.Context, llmRequest object.LLMRequest) (object.LL
 }
 
 func DebugDumpAllRoutes() []*v1alpha1.Route {
-	routeLock.Lock()
-	defer routeLock.Unlock()
-
-	return lo.Map(routes, func(r route.Route, _ int) *v1alpha1.Route {
+	return lo.Map(loadRoutes(), func(r route.Route, _ int) *v1alpha1.Route {
 		return r.GetRouteConfig()
 	})
 }
+
+// DumpMatchRoutes returns the configurations of the match routes ordered by
+// their names, without the base routes registered along with the clusters.
+func DumpMatchRoutes() []*v1alpha1.Route {
+	routeLock.RLock()
+	defer routeLock.RUnlock()
+
+	return lo.Map(sortedKeys(matchRouteRegistry), func(name string, _ int) *v1alpha1.Route {
+		return matchRouteRegistry[name].GetRouteConfig()
+	})
+}
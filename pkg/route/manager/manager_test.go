@@ -0,0 +1,391 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/api/route/v1alpha1"
+	servicev1alpha1 "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/route/normalize"
+	"knoway.dev/pkg/tenant"
+	"knoway.dev/pkg/types/openai"
+)
+
+func TestRoutePrecedence(t *testing.T) {
+	ctx := context.Background()
+	lifecycle := bootkit.NewEmptyLifeCycle()
+
+	require.NoError(t, RegisterBaseRouteWithConfig(InitDirectModelRoute("gpt-4o"), lifecycle))
+	require.NoError(t, RegisterBaseRouteWithConfig(InitDirectModelRoute("gpt-4o-mini"), lifecycle))
+
+	t.Cleanup(func() {
+		RemoveBaseRoute("gpt-4o")
+		RemoveBaseRoute("gpt-4o-mini")
+		RemoveMatchRoute("gpt-4o")
+	})
+
+	matchRoute := InitDirectModelRoute("gpt-4o")
+	matchRoute.Targets = []*v1alpha1.RouteTarget{
+		{Destination: &v1alpha1.RouteDestination{Cluster: "azure/gpt-4o"}},
+		{Destination: &v1alpha1.RouteDestination{Cluster: "gpt-4o-mini"}},
+	}
+
+	require.NoError(t, RegisterMatchRouteWithConfig(matchRoute, lifecycle))
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com", bytes.NewBufferString(`{"model": "gpt-4o", "messages": []}`))
+	require.NoError(t, err)
+
+	request, err := openai.NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	matched := MatchRoute(ctx, request)
+	require.NotNil(t, matched)
+	assert.Same(t, matchRoute, matched.GetRouteConfig())
+
+	assert.Equal(t, []Conflict{
+		{
+			Model:           "gpt-4o",
+			Targets:         []string{"azure/gpt-4o", "gpt-4o-mini"},
+			ShadowedCluster: "gpt-4o",
+		},
+	}, Conflicts())
+
+	RemoveMatchRoute("gpt-4o")
+
+	matched = MatchRoute(ctx, request)
+	require.NotNil(t, matched)
+	assert.Equal(t, "gpt-4o", matched.GetRouteConfig().GetTargets()[0].GetDestination().GetCluster())
+	assert.Empty(t, Conflicts())
+}
+
+func TestConditionalRoutePrecedence(t *testing.T) {
+	lifecycle := bootkit.NewEmptyLifeCycle()
+
+	require.NoError(t, RegisterBaseRouteWithConfig(InitDirectModelRoute("gpt-4o"), lifecycle))
+
+	t.Cleanup(func() {
+		RemoveBaseRoute("gpt-4o")
+		RemoveMatchRoute("gpt-4o")
+		RemoveMatchRoute("gpt-4o-canary")
+	})
+
+	// The conditional route of the same name leaves the other requests to
+	// the base route
+	tenantRoute := InitDirectModelRoute("gpt-4o")
+	tenantRoute.Matches[0].TenantId = &v1alpha1.StringMatch{Match: &v1alpha1.StringMatch_Exact{Exact: "acme"}}
+	tenantRoute.Targets[0].Destination.Cluster = "acme/gpt-4o"
+	require.NoError(t, RegisterMatchRouteWithConfig(tenantRoute, lifecycle))
+
+	// Matched before the routes with fewer conditions whatever their names
+	canaryRoute := InitDirectModelRoute("gpt-4o")
+	canaryRoute.Name = "gpt-4o-canary"
+	canaryRoute.Matches[0].TenantId = &v1alpha1.StringMatch{Match: &v1alpha1.StringMatch_Exact{Exact: "acme"}}
+	canaryRoute.Matches[0].Headers = []*v1alpha1.HeaderMatch{{Name: "X-Canary"}}
+	canaryRoute.Targets[0].Destination.Cluster = "canary/gpt-4o"
+	require.NoError(t, RegisterMatchRouteWithConfig(canaryRoute, lifecycle))
+
+	assert.Empty(t, Conflicts())
+
+	matchedCluster := func(tenantID string, canary bool) string {
+		httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com", bytes.NewBufferString(`{"model": "gpt-4o", "messages": []}`))
+		require.NoError(t, err)
+
+		if canary {
+			httpRequest.Header.Set("X-Canary", "true")
+		}
+
+		ctx := metadata.InitMetadataContext(httpRequest)
+		metadata.RequestMetadataFromCtx(ctx).AuthInfo = &servicev1alpha1.APIKeyAuthResponse{TenantId: tenantID}
+
+		request, err := openai.NewChatCompletionRequest(httpRequest)
+		require.NoError(t, err)
+
+		matched := MatchRoute(ctx, request)
+		require.NotNil(t, matched)
+
+		return matched.GetRouteConfig().GetTargets()[0].GetDestination().GetCluster()
+	}
+
+	assert.Equal(t, "canary/gpt-4o", matchedCluster("acme", true))
+	assert.Equal(t, "acme/gpt-4o", matchedCluster("acme", false))
+	assert.Equal(t, "gpt-4o", matchedCluster("globex", true))
+}
+
+func TestNormalizeRequestModel(t *testing.T) {
+	ctx := context.Background()
+	lifecycle := bootkit.NewEmptyLifeCycle()
+
+	require.NoError(t, RegisterBaseRouteWithConfig(InitDirectModelRoute("gpt-4o"), lifecycle))
+	require.NoError(t, RegisterBaseRouteWithConfig(InitDirectModelRoute("meta-llama/Llama-3.1-8B"), lifecycle))
+
+	normalize.SetGlobal(normalize.New(normalize.Rules{Lowercase: true, StripTagSuffix: true, StripRegistryPrefix: true}))
+
+	t.Cleanup(func() {
+		RemoveBaseRoute("gpt-4o")
+		RemoveBaseRoute("meta-llama/Llama-3.1-8B")
+		normalize.SetGlobal(nil)
+	})
+
+	cases := []struct {
+		model    string
+		expected string
+	}{
+		{model: "GPT-4o", expected: "gpt-4o"},
+		{model: "openai/gpt-4o:latest", expected: "gpt-4o"},
+		// Matches a route as is
+		{model: "meta-llama/Llama-3.1-8B", expected: "meta-llama/Llama-3.1-8B"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.model, func(t *testing.T) {
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com", bytes.NewBufferString(`{"model": "`+c.model+`", "messages": []}`))
+			require.NoError(t, err)
+
+			request, err := openai.NewChatCompletionRequest(httpRequest)
+			require.NoError(t, err)
+
+			require.NoError(t, NormalizeRequestModel(ctx, request))
+			assert.Equal(t, c.expected, request.GetModel())
+			assert.NotNil(t, MatchRoute(ctx, request))
+		})
+	}
+}
+
+func TestScopeRequestModel(t *testing.T) {
+	lifecycle := bootkit.NewEmptyLifeCycle()
+
+	require.NoError(t, RegisterBaseRouteWithConfig(InitDirectModelRoute("gpt-4o"), lifecycle))
+	require.NoError(t, RegisterBaseRouteWithConfig(InitDirectModelRoute("gpt-4o-mini"), lifecycle))
+	require.NoError(t, RegisterBaseRouteWithConfig(InitDirectModelRoute("org-a/gpt-4o"), lifecycle))
+	require.NoError(t, RegisterBaseRouteWithConfig(InitDirectModelRoute("meta-llama/Llama-3.1-8B"), lifecycle))
+
+	tenant.SetGlobal(tenant.New(map[string]tenant.Overrides{
+		"org-a": {Namespace: "org-a"},
+		"org-b": {Namespace: "org-b"},
+	}))
+
+	t.Cleanup(func() {
+		RemoveBaseRoute("gpt-4o")
+		RemoveBaseRoute("gpt-4o-mini")
+		RemoveBaseRoute("org-a/gpt-4o")
+		RemoveBaseRoute("meta-llama/Llama-3.1-8B")
+		tenant.SetGlobal(nil)
+	})
+
+	scope := func(tenantID, model string) (string, error) {
+		httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com", bytes.NewBufferString(`{"model": "`+model+`", "messages": []}`))
+		require.NoError(t, err)
+
+		ctx := metadata.InitMetadataContext(httpRequest)
+		metadata.RequestMetadataFromCtx(ctx).AuthInfo = &servicev1alpha1.APIKeyAuthResponse{TenantId: tenantID}
+
+		request, err := openai.NewChatCompletionRequest(httpRequest)
+		require.NoError(t, err)
+
+		err = ScopeRequestModel(ctx, request)
+
+		return request.GetModel(), err
+	}
+
+	cases := []struct {
+		tenantID string
+		model    string
+		expected string
+		err      bool
+	}{
+		{tenantID: "org-a", model: "gpt-4o", expected: "org-a/gpt-4o"},
+		{tenantID: "org-a", model: "org-a/gpt-4o", expected: "org-a/gpt-4o"},
+		// Shared models are served if the namespace has none of them
+		{tenantID: "org-a", model: "gpt-4o-mini", expected: "gpt-4o-mini"},
+		{tenantID: "org-a", model: "meta-llama/Llama-3.1-8B", expected: "meta-llama/Llama-3.1-8B"},
+		{tenantID: "org-b", model: "gpt-4o", expected: "gpt-4o"},
+		{tenantID: "org-b", model: "org-a/gpt-4o", err: true},
+		{tenantID: "", model: "org-a/gpt-4o", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.tenantID+"/"+c.model, func(t *testing.T) {
+			model, err := scope(c.tenantID, c.model)
+			if c.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, model)
+		})
+	}
+}
+
+// discardLogs silences the logs of registrations which would flood the
+// output of benchmarks.
+func discardLogs(tb testing.TB) {
+	tb.Helper()
+
+	logger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	tb.Cleanup(func() {
+		slog.SetDefault(logger)
+	})
+}
+
+// registerBaseRoutes registers n base routes named model-0 to model-(n-1).
+func registerBaseRoutes(tb testing.TB, n int) {
+	tb.Helper()
+
+	for i := range n {
+		name := fmt.Sprintf("model-%d", i)
+		require.NoError(tb, RegisterBaseRouteWithConfig(InitDirectModelRoute(name), bootkit.NewEmptyLifeCycle()))
+	}
+
+	tb.Cleanup(func() {
+		for i := range n {
+			RemoveBaseRoute(fmt.Sprintf("model-%d", i))
+		}
+	})
+}
+
+// TestConcurrentRegistration matches requests while routes are registered
+// and removed, as the controller does while listeners serve requests, run
+// with -race.
+func TestConcurrentRegistration(t *testing.T) {
+	ctx := context.Background()
+
+	registerBaseRoutes(t, 10)
+
+	t.Cleanup(func() {
+		RemoveMatchRoute("model-0")
+		RemoveMatchRoute("reconciled")
+	})
+
+	var (
+		wg      sync.WaitGroup
+		stop    atomic.Bool
+		matched atomic.Int64
+	)
+
+	for range 4 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, request := gatewaytest.NewChatCompletionRequest(t, gatewaytest.ChatCompletionBody("model-0", false))
+
+			for !stop.Load() {
+				// Either the match route or the base route it shadows
+				r := MatchRoute(ctx, request)
+				assert.NotNil(t, r)
+
+				_ = DebugDumpAllRoutes()
+				_ = Conflicts()
+
+				matched.Add(1)
+			}
+		}()
+	}
+
+	// Keep mutating until the readers made progress
+	for i := 0; i < 200 || matched.Load() == 0; i++ {
+		matchRoute := InitDirectModelRoute("model-0")
+		matchRoute.Targets[0].Destination.Cluster = fmt.Sprintf("cluster-%d", i)
+
+		require.NoError(t, RegisterMatchRouteWithConfig(matchRoute, bootkit.NewEmptyLifeCycle()))
+		require.NoError(t, RegisterMatchRouteWithConfig(InitDirectModelRoute("reconciled"), bootkit.NewEmptyLifeCycle()))
+
+		_, ok := GetMatchRouteConfig("model-0")
+		assert.True(t, ok)
+
+		RemoveMatchRoute("model-0")
+		RemoveMatchRoute("reconciled")
+	}
+
+	stop.Store(true)
+	wg.Wait()
+
+	assert.Positive(t, matched.Load())
+	_, request := gatewaytest.NewChatCompletionRequest(t, gatewaytest.ChatCompletionBody("model-0", false))
+	assert.Equal(t, "model-0", MatchRoute(ctx, request).GetRouteConfig().GetTargets()[0].GetDestination().GetCluster())
+}
+
+// BenchmarkMatchRoute matches the last of 100 routes.
+func BenchmarkMatchRoute(b *testing.B) {
+	ctx := context.Background()
+
+	discardLogs(b)
+	registerBaseRoutes(b, 100)
+
+	_, request := gatewaytest.NewChatCompletionRequest(b, gatewaytest.ChatCompletionBody("model-99", false))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if MatchRoute(ctx, request) == nil {
+				b.Fatal("no route matched")
+			}
+		}
+	})
+}
+
+// BenchmarkMatchRouteDuringRegistration matches requests while routes are
+// continuously registered and removed.
+func BenchmarkMatchRouteDuringRegistration(b *testing.B) {
+	ctx := context.Background()
+
+	discardLogs(b)
+	registerBaseRoutes(b, 100)
+
+	_, request := gatewaytest.NewChatCompletionRequest(b, gatewaytest.ChatCompletionBody("model-99", false))
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			name := fmt.Sprintf("reconciled-%d", i%10)
+			_ = RegisterMatchRouteWithConfig(InitDirectModelRoute(name), bootkit.NewEmptyLifeCycle())
+			RemoveMatchRoute(name)
+		}
+	}()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if MatchRoute(ctx, request) == nil {
+				b.Fatal("no route matched")
+			}
+		}
+	})
+
+	b.StopTimer()
+	close(done)
+	wg.Wait()
+}
@@ -0,0 +1,106 @@
+// Package normalize rewrites the model names requested by clients to their
+// canonical spelling so that routes don't have to enumerate every variant,
+// e.g. "GPT-4o", "gpt-4o:latest" and "openai/gpt-4o" all become "gpt-4o".
+package normalize
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// Rules configures the normalization steps, steps are applied in the order of
+// registry prefix stripping, tag suffix stripping and case folding.
+type Rules struct {
+	// Lowercase folds model names to lower case.
+	Lowercase bool
+	// StripTagSuffix removes tags like ":latest" from the end of model names.
+	StripTagSuffix bool
+	// StripRegistryPrefix removes registry or provider prefixes like
+	// "openai/" from model names.
+	StripRegistryPrefix bool
+	// RegistryPrefixes limits the prefixes stripped by StripRegistryPrefix,
+	// any prefix before the last "/" is stripped if empty.
+	RegistryPrefixes []string
+}
+
+// Normalizer rewrites model names according to its Rules.
+type Normalizer struct {
+	rules Rules
+}
+
+// New creates the Normalizer, nil is returned when no rule is enabled.
+func New(rules Rules) *Normalizer {
+	if !rules.Lowercase && !rules.StripTagSuffix && !rules.StripRegistryPrefix {
+		return nil
+	}
+
+	prefixes := make([]string, 0, len(rules.RegistryPrefixes))
+
+	for _, prefix := range rules.RegistryPrefixes {
+		prefix = strings.TrimSuffix(strings.TrimSpace(prefix), "/")
+		if prefix == "" {
+			continue
+		}
+
+		prefixes = append(prefixes, prefix+"/")
+	}
+
+	rules.RegistryPrefixes = prefixes
+
+	return &Normalizer{rules: rules}
+}
+
+// Normalize returns the normalized model name, nil Normalizer returns the
+// model name as is.
+func (n *Normalizer) Normalize(model string) string {
+	if n == nil {
+		return model
+	}
+
+	if n.rules.StripRegistryPrefix {
+		model = n.stripRegistryPrefix(model)
+	}
+
+	if n.rules.StripTagSuffix {
+		if i := strings.LastIndex(model, ":"); i > 0 {
+			model = model[:i]
+		}
+	}
+
+	if n.rules.Lowercase {
+		model = strings.ToLower(model)
+	}
+
+	return model
+}
+
+func (n *Normalizer) stripRegistryPrefix(model string) string {
+	if len(n.rules.RegistryPrefixes) == 0 {
+		if i := strings.LastIndex(model, "/"); i >= 0 && i < len(model)-1 {
+			return model[i+1:]
+		}
+
+		return model
+	}
+
+	for _, prefix := range n.rules.RegistryPrefixes {
+		if len(model) > len(prefix) && strings.EqualFold(model[:len(prefix)], prefix) {
+			return model[len(prefix):]
+		}
+	}
+
+	return model
+}
+
+var global atomic.Pointer[Normalizer]
+
+// SetGlobal sets the Normalizer used by the whole gateway.
+func SetGlobal(n *Normalizer) {
+	global.Store(n)
+}
+
+// Global returns the Normalizer used by the whole gateway, nil (keeps model
+// names as is) if not configured.
+func Global() *Normalizer {
+	return global.Load()
+}
@@ -0,0 +1,39 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	require.Nil(t, New(Rules{}))
+	assert.Equal(t, "GPT-4o", New(Rules{}).Normalize("GPT-4o"))
+
+	all := New(Rules{Lowercase: true, StripTagSuffix: true, StripRegistryPrefix: true})
+	limited := New(Rules{StripRegistryPrefix: true, RegistryPrefixes: []string{"openai/", " ollama "}})
+
+	cases := []struct {
+		name       string
+		normalizer *Normalizer
+		model      string
+		expected   string
+	}{
+		{name: "case folding", normalizer: all, model: "GPT-4o", expected: "gpt-4o"},
+		{name: "tag suffix", normalizer: all, model: "gpt-4o:latest", expected: "gpt-4o"},
+		{name: "registry prefix", normalizer: all, model: "openai/gpt-4o", expected: "gpt-4o"},
+		{name: "nested registry prefix", normalizer: all, model: "registry.local/openai/GPT-4o:2024-08-06", expected: "gpt-4o"},
+		{name: "trailing slash kept", normalizer: all, model: "gpt-4o/", expected: "gpt-4o/"},
+		{name: "leading colon kept", normalizer: all, model: ":latest", expected: ":latest"},
+		{name: "listed prefix", normalizer: limited, model: "OpenAI/gpt-4o", expected: "gpt-4o"},
+		{name: "listed prefix without slash", normalizer: limited, model: "ollama/qwen2.5", expected: "qwen2.5"},
+		{name: "unlisted prefix", normalizer: limited, model: "meta-llama/Llama-3.1-8B", expected: "meta-llama/Llama-3.1-8B"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, c.normalizer.Normalize(c.model))
+		})
+	}
+}
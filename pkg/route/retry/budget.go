@@ -0,0 +1,116 @@
+package retry
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"knoway.dev/api/route/v1alpha1"
+)
+
+const (
+	defaultBudgetMinRetriesPerSecond = 10
+	defaultBudgetWindow              = 10 * time.Second
+)
+
+type budgetBucket struct {
+	second   int64
+	requests uint64
+	retries  uint64
+}
+
+// Budget limits the retries to a percent of the requests within a sliding
+// window, plus a number of retries per second always allowed so that routes
+// receiving little traffic can still retry. Requests and retries are counted
+// in buckets of a second.
+type Budget struct {
+	percent         uint64
+	minRetries      uint64
+	now             func() time.Time
+	mutex           sync.Mutex
+	buckets         []budgetBucket
+	exhaustedLogged int64
+}
+
+// NewBudget returns the retry budget, nil if not configured, which allows
+// every retry.
+func NewBudget(cfg *v1alpha1.RetryBudget) *Budget {
+	if cfg == nil {
+		return nil
+	}
+
+	window := defaultBudgetWindow
+	if cfg.GetWindow().AsDuration() >= time.Second {
+		window = cfg.GetWindow().AsDuration()
+	}
+
+	minRetriesPerSecond := uint64(defaultBudgetMinRetriesPerSecond)
+	if cfg.MinRetriesPerSecond != nil {
+		minRetriesPerSecond = uint64(cfg.GetMinRetriesPerSecond())
+	}
+
+	seconds := int64(window / time.Second)
+
+	return &Budget{
+		percent:    uint64(cfg.GetRetryPercent()),
+		minRetries: minRetriesPerSecond * uint64(seconds),
+		now:        time.Now,
+		buckets:    make([]budgetBucket, seconds),
+	}
+}
+
+// RecordRequest accounts a request, which raises the retries allowed.
+func (b *Budget) RecordRequest() {
+	if b == nil {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.bucket(b.now().Unix()).requests++
+}
+
+// Withdraw reports whether a retry is allowed, and accounts it if so.
+func (b *Budget) Withdraw() bool {
+	if b == nil {
+		return true
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := b.now().Unix()
+
+	var requests, retries uint64
+
+	for _, bucket := range b.buckets {
+		if bucket.second > now-int64(len(b.buckets)) {
+			requests += bucket.requests
+			retries += bucket.retries
+		}
+	}
+
+	if retries >= b.minRetries+requests*b.percent/100 {
+		// Logged at most once per second not to flood the logs under outages
+		if b.exhaustedLogged != now {
+			b.exhaustedLogged = now
+			slog.Warn("retry budget exhausted", slog.Uint64("requests", requests), slog.Uint64("retries", retries))
+		}
+
+		return false
+	}
+
+	b.bucket(now).retries++
+
+	return true
+}
+
+func (b *Budget) bucket(second int64) *budgetBucket {
+	bucket := &b.buckets[second%int64(len(b.buckets))]
+	if bucket.second != second {
+		*bucket = budgetBucket{second: second}
+	}
+
+	return bucket
+}
@@ -0,0 +1,171 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/api/route/v1alpha1"
+	"knoway.dev/pkg/object"
+)
+
+const (
+	DefaultMaxRetries uint64 = 3
+
+	defaultBaseInterval = 25 * time.Millisecond
+	// defaultMaxIntervalFactor caps the delays at 10 times of the base
+	// interval unless the max interval is configured.
+	defaultMaxIntervalFactor = 10
+)
+
+// allErrorClasses are retried when neither the classes nor the statuses are
+// configured.
+var allErrorClasses = []v1alpha1.RetryErrorClass{
+	v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_SERVER_ERROR,
+	v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_RATE_LIMITED,
+	v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_CONNECTION_FAILURE,
+	v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_TIMEOUT,
+}
+
+// Policy decides whether the failed attempts of a route are retried, and how
+// long to wait before the retries.
+type Policy struct {
+	maxRetries   uint64
+	retryOn      []v1alpha1.RetryErrorClass
+	statuses     []int
+	baseInterval time.Duration
+	maxInterval  time.Duration
+	budget       *Budget
+
+	// jitter returns a random duration in [0, n)
+	jitter func(n int64) int64
+}
+
+// NewPolicy returns the retry policy of the route, nil if not configured.
+func NewPolicy(cfg *v1alpha1.RetryPolicy) *Policy {
+	if cfg == nil {
+		return nil
+	}
+
+	p := &Policy{
+		maxRetries: lo.CoalesceOrEmpty(cfg.GetMaxRetries(), DefaultMaxRetries),
+		retryOn: lo.Filter(cfg.GetRetryOn(), func(class v1alpha1.RetryErrorClass, _ int) bool {
+			return class != v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_UNSPECIFIED
+		}),
+		statuses: lo.Map(cfg.GetRetryOnStatuses(), func(status uint32, _ int) int {
+			return int(status)
+		}),
+		baseInterval: defaultBaseInterval,
+		budget:       NewBudget(cfg.GetBudget()),
+		jitter:       rand.Int64N,
+	}
+
+	if len(p.retryOn) == 0 && len(p.statuses) == 0 {
+		p.retryOn = allErrorClasses
+	}
+
+	if base := cfg.GetBackoff().GetBaseInterval().AsDuration(); base > 0 {
+		p.baseInterval = base
+	}
+
+	p.maxInterval = p.baseInterval * defaultMaxIntervalFactor
+	if maxInterval := cfg.GetBackoff().GetMaxInterval().AsDuration(); maxInterval > 0 {
+		p.maxInterval = max(maxInterval, p.baseInterval)
+	}
+
+	return p
+}
+
+// RecordRequest accounts a request of the route towards the retry budget.
+func (p *Policy) RecordRequest() {
+	p.budget.RecordRequest()
+}
+
+// Retry reports whether the attempt failed with err is retried, given the
+// number of retries made so far, along with the delay before the retry.
+func (p *Policy) Retry(err error, retried uint64) (time.Duration, bool) {
+	if retried >= p.maxRetries || !p.Retryable(err) {
+		return 0, false
+	}
+
+	if !p.budget.Withdraw() {
+		return 0, false
+	}
+
+	return p.Backoff(retried + 1), true
+}
+
+// Retryable reports whether the error is of the classes or statuses retried.
+func (p *Policy) Retryable(err error) bool {
+	class := Classify(err)
+	if class != v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_UNSPECIFIED && lo.Contains(p.retryOn, class) {
+		return true
+	}
+
+	var llmErr object.LLMError
+	if errors.As(err, &llmErr) {
+		return lo.Contains(p.statuses, llmErr.GetStatus())
+	}
+
+	return false
+}
+
+// Backoff returns the delay before the nth retry, picked at random up to
+// the base interval doubled for each retry made before, capped by the max
+// interval.
+func (p *Policy) Backoff(retry uint64) time.Duration {
+	ceiling := p.baseInterval
+	for i := uint64(1); i < retry && ceiling < p.maxInterval; i++ {
+		ceiling *= 2
+	}
+
+	ceiling = min(ceiling, p.maxInterval)
+
+	return time.Duration(p.jitter(int64(ceiling) + 1))
+}
+
+// Classify returns the class of the error of an upstream, unspecified when the
+// error belongs to none of them, such as 4xx responses other than 429. Errors
+// of the transport are classified by their causes rather than the 502
+// responses they are turned into.
+func Classify(err error) v1alpha1.RetryErrorClass {
+	if err == nil {
+		return v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_UNSPECIFIED
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_TIMEOUT
+	}
+
+	var opErr *net.OpError
+	if errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.As(err, &opErr) {
+		return v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_CONNECTION_FAILURE
+	}
+
+	var llmErr object.LLMError
+	if !errors.As(err, &llmErr) {
+		return v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_UNSPECIFIED
+	}
+
+	switch {
+	case llmErr.GetStatus() == http.StatusTooManyRequests:
+		return v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_RATE_LIMITED
+	case llmErr.GetStatus() >= http.StatusInternalServerError:
+		return v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_SERVER_ERROR
+	default:
+		return v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_UNSPECIFIED
+	}
+}
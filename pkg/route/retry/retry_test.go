@@ -0,0 +1,193 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"knoway.dev/api/route/v1alpha1"
+	"knoway.dev/pkg/object"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func transportError(err error) error {
+	return object.NewErrorBadGateway(&url.Error{Op: "Post", URL: "http://upstream/v1/chat/completions", Err: err})
+}
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		name  string
+		err   error
+		class v1alpha1.RetryErrorClass
+	}{
+		{name: "nil", err: nil, class: v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_UNSPECIFIED},
+		{name: "500", err: object.NewErrorInternalError(errors.New("boom")), class: v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_SERVER_ERROR},
+		{name: "503", err: &object.BaseLLMError{Status: 503}, class: v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_SERVER_ERROR},
+		{name: "429", err: object.NewErrorRateLimitExceeded(), class: v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_RATE_LIMITED},
+		{name: "400", err: &object.BaseLLMError{Status: 400}, class: v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_UNSPECIFIED},
+		{name: "connection refused", err: transportError(&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}), class: v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_CONNECTION_FAILURE},
+		{name: "connection reset", err: transportError(fmt.Errorf("read: %w", syscall.ECONNRESET)), class: v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_CONNECTION_FAILURE},
+		{name: "closed", err: transportError(io.EOF), class: v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_CONNECTION_FAILURE},
+		{name: "timeout", err: transportError(timeoutError{}), class: v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_TIMEOUT},
+		{name: "deadline exceeded", err: transportError(context.DeadlineExceeded), class: v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_TIMEOUT},
+		{name: "unknown", err: errors.New("unknown"), class: v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_UNSPECIFIED},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.class, Classify(c.err))
+		})
+	}
+}
+
+func TestNewPolicy(t *testing.T) {
+	assert.Nil(t, NewPolicy(nil))
+
+	p := NewPolicy(&v1alpha1.RetryPolicy{})
+	require.NotNil(t, p)
+	assert.Equal(t, DefaultMaxRetries, p.maxRetries)
+	assert.Equal(t, allErrorClasses, p.retryOn)
+	assert.Equal(t, defaultBaseInterval, p.baseInterval)
+	assert.Equal(t, defaultBaseInterval*defaultMaxIntervalFactor, p.maxInterval)
+	assert.Nil(t, p.budget)
+
+	p = NewPolicy(&v1alpha1.RetryPolicy{
+		MaxRetries:      proto.Uint64(5),
+		RetryOnStatuses: []uint32{502},
+		Backoff: &v1alpha1.RetryBackoff{
+			BaseInterval: durationpb.New(time.Second),
+			MaxInterval:  durationpb.New(time.Millisecond),
+		},
+	})
+	require.NotNil(t, p)
+	assert.Equal(t, uint64(5), p.maxRetries)
+	assert.Empty(t, p.retryOn)
+	assert.Equal(t, []int{502}, p.statuses)
+	assert.Equal(t, time.Second, p.maxInterval)
+}
+
+func TestPolicy_Retryable(t *testing.T) {
+	p := NewPolicy(&v1alpha1.RetryPolicy{})
+	assert.True(t, p.Retryable(&object.BaseLLMError{Status: 503}))
+	assert.True(t, p.Retryable(object.NewErrorRateLimitExceeded()))
+	assert.True(t, p.Retryable(transportError(syscall.ECONNRESET)))
+	assert.False(t, p.Retryable(&object.BaseLLMError{Status: 400}))
+
+	p = NewPolicy(&v1alpha1.RetryPolicy{
+		RetryOn:         []v1alpha1.RetryErrorClass{v1alpha1.RetryErrorClass_RETRY_ERROR_CLASS_CONNECTION_FAILURE},
+		RetryOnStatuses: []uint32{503, 408},
+	})
+	assert.True(t, p.Retryable(transportError(syscall.ECONNREFUSED)))
+	assert.True(t, p.Retryable(&object.BaseLLMError{Status: 503}))
+	assert.True(t, p.Retryable(&object.BaseLLMError{Status: 408}))
+	assert.False(t, p.Retryable(&object.BaseLLMError{Status: 500}))
+	assert.False(t, p.Retryable(object.NewErrorRateLimitExceeded()))
+	// Timeouts are turned into 502 responses, but not retried by status
+	assert.False(t, p.Retryable(transportError(timeoutError{})))
+}
+
+func TestPolicy_Backoff(t *testing.T) {
+	p := NewPolicy(&v1alpha1.RetryPolicy{
+		Backoff: &v1alpha1.RetryBackoff{
+			BaseInterval: durationpb.New(100 * time.Millisecond),
+			MaxInterval:  durationpb.New(time.Second),
+		},
+	})
+
+	// Always the upper bound of the jitter
+	p.jitter = func(n int64) int64 { return n - 1 }
+
+	assert.Equal(t, 100*time.Millisecond, p.Backoff(1))
+	assert.Equal(t, 200*time.Millisecond, p.Backoff(2))
+	assert.Equal(t, 400*time.Millisecond, p.Backoff(3))
+	assert.Equal(t, 800*time.Millisecond, p.Backoff(4))
+	assert.Equal(t, time.Second, p.Backoff(5))
+	assert.Equal(t, time.Second, p.Backoff(64))
+
+	p.jitter = func(int64) int64 { return 0 }
+	assert.Zero(t, p.Backoff(3))
+}
+
+func TestPolicy_Retry(t *testing.T) {
+	p := NewPolicy(&v1alpha1.RetryPolicy{MaxRetries: proto.Uint64(2)})
+
+	_, ok := p.Retry(&object.BaseLLMError{Status: 503}, 0)
+	assert.True(t, ok)
+
+	_, ok = p.Retry(&object.BaseLLMError{Status: 503}, 2)
+	assert.False(t, ok)
+
+	_, ok = p.Retry(&object.BaseLLMError{Status: 404}, 0)
+	assert.False(t, ok)
+}
+
+func TestBudget(t *testing.T) {
+	assert.Nil(t, NewBudget(nil))
+	assert.True(t, (*Budget)(nil).Withdraw())
+
+	now := time.Unix(1000, 0)
+
+	b := NewBudget(&v1alpha1.RetryBudget{
+		RetryPercent:        20,
+		MinRetriesPerSecond: proto.Uint32(0),
+		Window:              durationpb.New(5 * time.Second),
+	})
+	require.NotNil(t, b)
+	b.now = func() time.Time { return now }
+
+	// No requests, no retries
+	assert.False(t, b.Withdraw())
+
+	for range 10 {
+		b.RecordRequest()
+	}
+
+	assert.True(t, b.Withdraw())
+	assert.True(t, b.Withdraw())
+	assert.False(t, b.Withdraw())
+
+	// The requests and retries slide out of the window
+	now = now.Add(5 * time.Second)
+	assert.False(t, b.Withdraw())
+
+	for range 5 {
+		b.RecordRequest()
+	}
+
+	assert.True(t, b.Withdraw())
+	assert.False(t, b.Withdraw())
+}
+
+func TestBudget_MinRetriesPerSecond(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	b := NewBudget(&v1alpha1.RetryBudget{MinRetriesPerSecond: proto.Uint32(1), Window: durationpb.New(2 * time.Second)})
+	require.NotNil(t, b)
+	b.now = func() time.Time { return now }
+
+	assert.True(t, b.Withdraw())
+	assert.True(t, b.Withdraw())
+	assert.False(t, b.Withdraw())
+
+	now = now.Add(time.Second)
+	assert.False(t, b.Withdraw())
+
+	now = now.Add(time.Second)
+	assert.True(t, b.Withdraw())
+}
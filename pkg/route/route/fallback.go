@@ -0,0 +1,94 @@
+package route
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/samber/lo"
+
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	clustermanager "knoway.dev/pkg/clusters/manager"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/observation"
+	"knoway.dev/pkg/types/openai"
+)
+
+// contentFilterErrorCodes are the codes of the errors of upstreams refusing
+// the requests or the responses by their content filters.
+var contentFilterErrorCodes = []string{
+	// Azure OpenAI
+	"content_filter",
+	// OpenAI
+	"content_policy_violation",
+}
+
+// classifyFallback returns the class of the error of an upstream the
+// fallbacks of routes fail over on, unspecified when the error is of none of
+// them. Errors of the gateway itself, e.g. of the rate limits and the content
+// moderation, are never failed over.
+func classifyFallback(err error) routev1alpha1.FallbackErrorClass {
+	var openAIErr *openai.ErrorResponse
+	if err == nil || !errors.As(err, &openAIErr) || !openAIErr.FromUpstream || openAIErr.ErrorBody == nil {
+		return routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_UNSPECIFIED
+	}
+
+	// OpenAI sets the type to insufficient_quota as well
+	codes := []string{openAIErr.GetCode(), openAIErr.ErrorBody.Type}
+
+	switch {
+	case lo.Contains(codes, string(object.LLMErrorCodeInsufficientQuota)):
+		return routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_INSUFFICIENT_QUOTA
+	case lo.Some(codes, contentFilterErrorCodes):
+		return routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_CONTENT_FILTER
+	case openAIErr.GetStatus() == http.StatusTooManyRequests:
+		return routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_RATE_LIMITED
+	default:
+		return routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_UNSPECIFIED
+	}
+}
+
+// failover returns the next cluster the request failed with err is failed
+// over to, skipping the clusters tried and the ones unavailable, false if
+// the class of the error has no fallback or the destinations are exhausted.
+func (m *routeDefault) failover(ctx context.Context, err error, tried []string) (string, bool) {
+	class := classifyFallback(err)
+	if class == routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_UNSPECIFIED {
+		return "", false
+	}
+
+	onError, ok := lo.Find(m.cfg.GetFallback().GetOnErrors(), func(item *routev1alpha1.ErrorFallback) bool {
+		return item.GetClass() == class
+	})
+	if !ok {
+		return "", false
+	}
+
+	destinations := onError.GetDestinations()
+	if len(destinations) == 0 {
+		destinations = lo.Map(m.cfg.GetTargets(), func(item *routev1alpha1.RouteTarget, _ int) *routev1alpha1.RouteDestination {
+			return item.GetDestination()
+		})
+	}
+
+	for _, destination := range destinations {
+		candidate := destination.GetCluster()
+		if candidate == "" || lo.Contains(tried, candidate) || !clustermanager.Available(candidate) {
+			continue
+		}
+
+		slog.DebugContext(ctx, "failing over upstream attempt",
+			slog.String("route", m.cfg.GetName()),
+			slog.String("from", lo.LastOrEmpty(tried)),
+			slog.String("to", candidate),
+			slog.String("class", class.String()),
+		)
+
+		observation.ObserveRouteFailover(m.cfg.GetName(), lo.LastOrEmpty(tried), class.String())
+
+		return candidate, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,137 @@
+package route
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	"knoway.dev/pkg/types/openai"
+)
+
+func newUpstreamError(status int, code string, typ string) *openai.ErrorResponse {
+	return &openai.ErrorResponse{
+		Status:       status,
+		FromUpstream: true,
+		ErrorBody:    &openai.Error{Code: lo.EmptyableToPtr(code), Type: typ, Message: "upstream error"},
+	}
+}
+
+func TestClassifyFallback(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected routev1alpha1.FallbackErrorClass
+	}{
+		{
+			name:     "azure content filter",
+			err:      newUpstreamError(http.StatusBadRequest, "content_filter", ""),
+			expected: routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_CONTENT_FILTER,
+		},
+		{
+			name:     "openai content policy violation",
+			err:      newUpstreamError(http.StatusBadRequest, "content_policy_violation", "invalid_request_error"),
+			expected: routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_CONTENT_FILTER,
+		},
+		{
+			name:     "insufficient quota",
+			err:      newUpstreamError(http.StatusTooManyRequests, "insufficient_quota", "insufficient_quota"),
+			expected: routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_INSUFFICIENT_QUOTA,
+		},
+		{
+			name:     "insufficient quota by type",
+			err:      newUpstreamError(http.StatusTooManyRequests, "", "insufficient_quota"),
+			expected: routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_INSUFFICIENT_QUOTA,
+		},
+		{
+			name:     "rate limited",
+			err:      newUpstreamError(http.StatusTooManyRequests, "rate_limit_exceeded", "requests"),
+			expected: routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_RATE_LIMITED,
+		},
+		{
+			name:     "server error",
+			err:      newUpstreamError(http.StatusInternalServerError, "", "server_error"),
+			expected: routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_UNSPECIFIED,
+		},
+		{
+			name:     "content moderation of the gateway",
+			err:      openai.NewErrorContentPolicyViolation("Your request was rejected by the content moderation"),
+			expected: routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_UNSPECIFIED,
+		},
+		{
+			name:     "not an upstream error",
+			err:      errors.New("connection refused"),
+			expected: routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_UNSPECIFIED,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, classifyFallback(tt.err))
+		})
+	}
+}
+
+func TestRouteDefault_Failover(t *testing.T) {
+	m := &routeDefault{cfg: &routev1alpha1.Route{
+		Name: "gpt-4o",
+		Targets: []*routev1alpha1.RouteTarget{
+			{Destination: &routev1alpha1.RouteDestination{Cluster: "gpt-4o-eastus"}},
+			{Destination: &routev1alpha1.RouteDestination{Cluster: "gpt-4o-westus"}},
+		},
+		Fallback: &routev1alpha1.RouteFallback{
+			OnErrors: []*routev1alpha1.ErrorFallback{
+				{
+					Class: routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_CONTENT_FILTER,
+					Destinations: []*routev1alpha1.RouteDestination{
+						{Namespace: "default", Backend: "openai-gpt-4o", Cluster: "openai/gpt-4o"},
+						{Namespace: "default", Backend: "anthropic-claude", Cluster: "anthropic/claude-sonnet-4"},
+					},
+				},
+				{
+					Class: routev1alpha1.FallbackErrorClass_FALLBACK_ERROR_CLASS_RATE_LIMITED,
+				},
+			},
+		},
+	}}
+
+	ctx := context.Background()
+	contentFilter := newUpstreamError(http.StatusBadRequest, "content_filter", "")
+
+	next, ok := m.failover(ctx, contentFilter, []string{"gpt-4o-eastus"})
+	assert.True(t, ok)
+	assert.Equal(t, "openai/gpt-4o", next)
+	assert.Equal(t, "default/openai-gpt-4o", m.servingTarget(next))
+
+	next, ok = m.failover(ctx, contentFilter, []string{"gpt-4o-eastus", "openai/gpt-4o"})
+	assert.True(t, ok)
+	assert.Equal(t, "anthropic/claude-sonnet-4", next)
+
+	// Each destination is tried at most once
+	_, ok = m.failover(ctx, contentFilter, []string{"gpt-4o-eastus", "openai/gpt-4o", "anthropic/claude-sonnet-4"})
+	assert.False(t, ok)
+
+	// The other targets of the route without destinations
+	next, ok = m.failover(ctx, newUpstreamError(http.StatusTooManyRequests, "", ""), []string{"gpt-4o-eastus"})
+	assert.True(t, ok)
+	assert.Equal(t, "gpt-4o-westus", next)
+
+	_, ok = m.failover(ctx, newUpstreamError(http.StatusTooManyRequests, "", ""), []string{"gpt-4o-westus", "gpt-4o-eastus"})
+	assert.False(t, ok)
+
+	// Classes without fallbacks
+	_, ok = m.failover(ctx, newUpstreamError(http.StatusTooManyRequests, "insufficient_quota", ""), []string{"gpt-4o-eastus"})
+	assert.False(t, ok)
+
+	_, ok = m.failover(ctx, newUpstreamError(http.StatusBadGateway, "", ""), []string{"gpt-4o-eastus"})
+	assert.False(t, ok)
+
+	m.cfg.Fallback = nil
+
+	_, ok = m.failover(ctx, contentFilter, []string{"gpt-4o-eastus"})
+	assert.False(t, ok)
+}
@@ -0,0 +1,119 @@
+package route
+
+import (
+	"context"
+	"strings"
+
+	"github.com/samber/lo"
+
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	servicev1alpha1 "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+)
+
+// matchString reports whether the value matches, matches with neither exact
+// nor prefix set match any value.
+func matchString(m *routev1alpha1.StringMatch, value string) bool {
+	switch {
+	case m.GetExact() != "":
+		return value == m.GetExact()
+	case m.GetPrefix() != "":
+		return strings.HasPrefix(value, m.GetPrefix())
+	default:
+		return true
+	}
+}
+
+func matchHeaders(headers []*routev1alpha1.HeaderMatch, request object.LLMRequest) bool {
+	if len(headers) == 0 {
+		return true
+	}
+
+	httpRequest := request.GetRawRequest()
+	if httpRequest == nil {
+		return false
+	}
+
+	for _, header := range headers {
+		values := httpRequest.Header.Values(header.GetName())
+		if len(values) == 0 {
+			return false
+		}
+
+		if !lo.SomeBy(values, func(value string) bool {
+			return matchString(header.GetValue(), value)
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hasTools(request object.LLMRequest) bool {
+	parsed, ok := request.(interface{ GetBodyParsed() map[string]any })
+	if !ok {
+		return false
+	}
+
+	tools, _ := parsed.GetBodyParsed()["tools"].([]any)
+
+	return len(tools) > 0
+}
+
+// matchConditions reports whether the request matches the conditions of the
+// match other than the model.
+func matchConditions(ctx context.Context, match *routev1alpha1.Match, request object.LLMRequest) bool {
+	if match.Stream != nil && match.GetStream() != request.IsStream() {
+		return false
+	}
+
+	if match.HasTools != nil && match.GetHasTools() != hasTools(request) {
+		return false
+	}
+
+	if !matchHeaders(match.GetHeaders(), request) {
+		return false
+	}
+
+	var authInfo *servicev1alpha1.APIKeyAuthResponse
+	if rMeta := metadata.RequestMetadataFromCtx(ctx); rMeta != nil {
+		authInfo = rMeta.AuthInfo
+	}
+
+	return matchString(match.GetApiKeyId(), authInfo.GetApiKeyId()) &&
+		matchString(match.GetUserId(), authInfo.GetUserId()) &&
+		matchString(match.GetTenantId(), authInfo.GetTenantId())
+}
+
+// conditions returns the number of conditions of the match other than the
+// model.
+func conditions(match *routev1alpha1.Match) int {
+	return len(match.GetHeaders()) +
+		lo.Count([]bool{
+			match.GetApiKeyId() != nil,
+			match.GetUserId() != nil,
+			match.GetTenantId() != nil,
+			match.Stream != nil,
+			match.HasTools != nil,
+		}, true)
+}
+
+// Specificity returns the number of conditions other than the model of the
+// most specific match of the route, routes with higher specificity are
+// matched first.
+func Specificity(cfg *routev1alpha1.Route) int {
+	return lo.Max(lo.Map(cfg.GetMatches(), func(match *routev1alpha1.Match, _ int) int {
+		return conditions(match)
+	}))
+}
+
+// Conditional reports whether every match of the route has conditions other
+// than the model, so that the route never serves all the requests of its
+// models.
+func Conditional(cfg *routev1alpha1.Route) bool {
+	return len(cfg.GetMatches()) > 0 && lo.EveryBy(cfg.GetMatches(), func(match *routev1alpha1.Match) bool {
+		return conditions(match) > 0
+	})
+}
@@ -0,0 +1,95 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	servicev1alpha1 "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/metadata"
+)
+
+func exactMatch(value string) *routev1alpha1.StringMatch {
+	return &routev1alpha1.StringMatch{Match: &routev1alpha1.StringMatch_Exact{Exact: value}}
+}
+
+func TestRouteDefault_MatchConditions(t *testing.T) {
+	newRoute := func(match *routev1alpha1.Match) *routev1alpha1.Route {
+		match.Model = exactMatch("gpt-4o")
+
+		return &routev1alpha1.Route{
+			Name:    "gpt-4o-canary",
+			Matches: []*routev1alpha1.Match{match},
+			Targets: []*routev1alpha1.RouteTarget{{Destination: &routev1alpha1.RouteDestination{Cluster: "gpt-4o-canary"}}},
+		}
+	}
+
+	ctx := metadata.InitMetadataContext(&http.Request{})
+	metadata.RequestMetadataFromCtx(ctx).AuthInfo = &servicev1alpha1.APIKeyAuthResponse{
+		ApiKeyId: "key-1",
+		UserId:   "user-1",
+		TenantId: "acme",
+	}
+
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gpt-4o", "stream": true, "tools": [{"type": "function", "function": {"name": "get_weather"}}], "messages": []}`)
+	request.GetRawRequest().Header.Set("X-Canary", "true")
+
+	testCases := []struct {
+		name    string
+		match   *routev1alpha1.Match
+		ctx     context.Context
+		matched bool
+	}{
+		{name: "model only", match: &routev1alpha1.Match{}, matched: true},
+		{name: "header present", match: &routev1alpha1.Match{Headers: []*routev1alpha1.HeaderMatch{{Name: "x-canary"}}}, matched: true},
+		{name: "header value", match: &routev1alpha1.Match{Headers: []*routev1alpha1.HeaderMatch{{Name: "X-Canary", Value: exactMatch("false")}}}},
+		{name: "header missing", match: &routev1alpha1.Match{Headers: []*routev1alpha1.HeaderMatch{{Name: "X-Tenant"}}}},
+		{name: "tenant", match: &routev1alpha1.Match{TenantId: exactMatch("acme")}, matched: true},
+		{name: "other tenant", match: &routev1alpha1.Match{TenantId: exactMatch("globex")}},
+		{name: "user prefix", match: &routev1alpha1.Match{UserId: &routev1alpha1.StringMatch{Match: &routev1alpha1.StringMatch_Prefix{Prefix: "user-"}}}, matched: true},
+		{name: "api key without auth", match: &routev1alpha1.Match{ApiKeyId: exactMatch("key-1")}, ctx: context.Background()},
+		{name: "stream", match: &routev1alpha1.Match{Stream: lo.ToPtr(true), HasTools: lo.ToPtr(true)}, matched: true},
+		{name: "not stream", match: &routev1alpha1.Match{Stream: lo.ToPtr(false)}},
+		{name: "no tools", match: &routev1alpha1.Match{HasTools: lo.ToPtr(false)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewWithConfig(newRoute(tc.match), bootkit.NewEmptyLifeCycle())
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.matched, r.Match(lo.CoalesceOrEmpty(tc.ctx, ctx), request))
+		})
+	}
+}
+
+func TestSpecificity(t *testing.T) {
+	route := &routev1alpha1.Route{
+		Matches: []*routev1alpha1.Match{
+			{Model: exactMatch("gpt-4o")},
+		},
+	}
+
+	assert.Equal(t, 0, Specificity(route))
+	assert.False(t, Conditional(route))
+
+	route.Matches = append(route.Matches, &routev1alpha1.Match{
+		Model:    exactMatch("gpt-4o"),
+		Headers:  []*routev1alpha1.HeaderMatch{{Name: "X-Canary"}},
+		TenantId: exactMatch("acme"),
+		Stream:   lo.ToPtr(false),
+	})
+
+	assert.Equal(t, 3, Specificity(route))
+	assert.False(t, Conditional(route))
+
+	route.Matches = route.Matches[1:]
+	assert.True(t, Conditional(route))
+}
@@ -0,0 +1,200 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/samber/lo"
+
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	clustermanager "knoway.dev/pkg/clusters/manager"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/observation"
+	"knoway.dev/pkg/types/openai"
+)
+
+const (
+	// defaultMirrorTimeout bounds the copies of routes without timeout.
+	defaultMirrorTimeout = 5 * time.Minute
+	// maxInflightMirrors bounds the copies in flight of each route, further
+	// copies are dropped while the shadow cluster can't keep up.
+	maxInflightMirrors = 64
+
+	mirrorOutcomeSucceeded = "succeeded"
+	mirrorOutcomeFailed    = "failed"
+	mirrorOutcomeDropped   = "dropped"
+)
+
+// mirror sends copies of a percent of the requests of the route to its
+// shadow cluster in the background, discarding the responses.
+type mirror struct {
+	route    string
+	cluster  string
+	percent  uint32
+	timeout  time.Duration
+	inflight chan struct{}
+}
+
+func newMirror(cfg *routev1alpha1.Route) *mirror {
+	cluster := cfg.GetMirror().GetDestination().GetCluster()
+	if cluster == "" {
+		return nil
+	}
+
+	percent := uint32(100)
+	if cfg.GetMirror().Percent != nil {
+		percent = min(cfg.GetMirror().GetPercent(), 100)
+	}
+
+	return &mirror{
+		route:    cfg.GetName(),
+		cluster:  cluster,
+		percent:  percent,
+		timeout:  lo.CoalesceOrEmpty(cfg.GetTimeout().AsDuration(), defaultMirrorTimeout),
+		inflight: make(chan struct{}, maxInflightMirrors),
+	}
+}
+
+func (m *mirror) sampled() bool {
+	return m.percent >= 100 || rand.Uint32N(100) < m.percent //nolint:gosec
+}
+
+// send copies the request to send it to the shadow cluster, it must be
+// called before the request is modified by the clusters it's sent to.
+func (m *mirror) send(ctx context.Context, request object.LLMRequest) {
+	if m == nil || !m.sampled() {
+		return
+	}
+
+	select {
+	case m.inflight <- struct{}{}:
+	default:
+		observation.ObserveRouteMirroredRequest(m.route, m.cluster, mirrorOutcomeDropped)
+		return
+	}
+
+	mirrorCtx, copied, err := copyRequest(ctx, request)
+	if err != nil {
+		<-m.inflight
+
+		slog.Debug("failed to copy request to mirror", "route", m.route, "cluster", m.cluster, "error", err)
+		observation.ObserveRouteMirroredRequest(m.route, m.cluster, mirrorOutcomeFailed)
+
+		return
+	}
+
+	go func() {
+		defer func() { <-m.inflight }()
+
+		mirrorCtx, cancel := context.WithTimeout(mirrorCtx, m.timeout)
+		defer cancel()
+
+		outcome := mirrorOutcomeSucceeded
+
+		err := discardResponse(clustermanager.HandleRequest(mirrorCtx, m.cluster, copied))
+		if err != nil {
+			outcome = mirrorOutcomeFailed
+
+			slog.Debug("failed to mirror request", "route", m.route, "cluster", m.cluster, "error", err)
+		}
+
+		observation.ObserveRouteMirroredRequest(m.route, m.cluster, outcome)
+	}()
+}
+
+// copyRequest rebuilds the request from its current body, with a context
+// outliving the request and metadata of its own, so that the copy neither is
+// canceled with the request nor affects its usage and stats.
+func copyRequest(ctx context.Context, request object.LLMRequest) (context.Context, object.LLMRequest, error) {
+	rawRequest := request.GetRawRequest()
+	if rawRequest == nil {
+		return nil, nil, errors.New("request has no HTTP request")
+	}
+
+	marshaler, ok := request.(json.Marshaler)
+	if !ok {
+		return nil, nil, fmt.Errorf("request of type %T can't be marshalled", request)
+	}
+
+	body, err := marshaler.MarshalJSON()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpRequest := rawRequest.Clone(context.WithoutCancel(ctx))
+	httpRequest.Body = io.NopCloser(bytes.NewReader(body))
+	httpRequest.ContentLength = int64(len(body))
+
+	mirrorCtx := metadata.InitMetadataContext(httpRequest)
+	httpRequest = httpRequest.WithContext(mirrorCtx)
+
+	var copied object.LLMRequest
+
+	switch request.GetRequestType() { //nolint:exhaustive
+	case object.RequestTypeChatCompletions:
+		copied, err = openai.NewChatCompletionRequest(httpRequest)
+	case object.RequestTypeCompletions:
+		copied, err = openai.NewCompletionsRequest(httpRequest)
+	case object.RequestTypeEmbeddings:
+		copied, err = openai.NewEmbeddingsRequest(httpRequest)
+	case object.RequestTypeRerank:
+		copied, err = openai.NewRerankRequest(httpRequest)
+	case object.RequestTypeModerations:
+		copied, err = openai.NewModerationsRequest(httpRequest)
+	case object.RequestTypeImageGenerations:
+		copied, err = openai.NewImageGenerationsRequest(httpRequest)
+	case object.RequestTypeTextToSpeech:
+		copied, err = openai.NewTextToSpeechRequest(httpRequest)
+	default:
+		return nil, nil, fmt.Errorf("requests of type %s can't be mirrored", request.GetRequestType())
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mirrorMeta := metadata.RequestMetadataFromCtx(mirrorCtx)
+	mirrorMeta.RequestAt = time.Now()
+	mirrorMeta.RequestModel = copied.GetModel()
+	mirrorMeta.LLMRequest = copied
+
+	if rMeta := metadata.RequestMetadataFromCtx(ctx); rMeta != nil {
+		mirrorMeta.EnabledAuthFilter = rMeta.EnabledAuthFilter
+		mirrorMeta.AuthInfo = rMeta.AuthInfo
+		mirrorMeta.Priority = rMeta.Priority
+	}
+
+	return mirrorCtx, copied, nil
+}
+
+// discardResponse reads streams to the end so that their upstream
+// connections are released, and returns the error of the response.
+func discardResponse(resp object.LLMResponse, err error) error {
+	if err != nil {
+		return err
+	}
+
+	stream, ok := resp.(object.LLMStreamResponse)
+	if !ok || !stream.IsStream() {
+		return nil
+	}
+
+	for {
+		_, err := stream.NextChunk()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package route
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	servicev1alpha1 "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/observation"
+)
+
+func TestNewMirror(t *testing.T) {
+	assert.Nil(t, newMirror(&routev1alpha1.Route{Name: "gpt-4o"}))
+
+	m := newMirror(&routev1alpha1.Route{
+		Name:   "gpt-4o",
+		Mirror: &routev1alpha1.RouteMirror{Destination: &routev1alpha1.RouteDestination{Cluster: "gpt-4.1"}},
+	})
+	require.NotNil(t, m)
+	assert.Equal(t, "gpt-4.1", m.cluster)
+	assert.Equal(t, uint32(100), m.percent)
+	assert.Equal(t, defaultMirrorTimeout, m.timeout)
+	assert.True(t, m.sampled())
+
+	m = newMirror(&routev1alpha1.Route{
+		Name:    "gpt-4o",
+		Timeout: durationpb.New(30 * time.Second),
+		Mirror: &routev1alpha1.RouteMirror{
+			Destination: &routev1alpha1.RouteDestination{Cluster: "gpt-4.1"},
+			Percent:     lo.ToPtr(uint32(0)),
+		},
+	})
+	require.NotNil(t, m)
+	assert.Equal(t, 30*time.Second, m.timeout)
+	assert.False(t, m.sampled())
+}
+
+func TestMirror_SendDropped(t *testing.T) {
+	m := newMirror(&routev1alpha1.Route{
+		Name:   "mirror-dropped",
+		Mirror: &routev1alpha1.RouteMirror{Destination: &routev1alpha1.RouteDestination{Cluster: "gpt-4.1"}},
+	})
+
+	for range maxInflightMirrors {
+		m.inflight <- struct{}{}
+	}
+
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gpt-4o", "messages": []}`)
+	m.send(context.Background(), request)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(observation.RouteMirroredRequests.WithLabelValues("mirror-dropped", "gpt-4.1", mirrorOutcomeDropped)), 0)
+}
+
+func TestCopyRequest(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "hello"}]}`)
+	request.GetRawRequest().Header.Set("Authorization", "Bearer sk-test")
+
+	ctx, cancel := context.WithCancel(metadata.InitMetadataContext(request.GetRawRequest()))
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	rMeta.AuthInfo = &servicev1alpha1.APIKeyAuthResponse{ApiKeyId: "key-1"}
+
+	mirrorCtx, copied, err := copyRequest(ctx, request)
+	require.NoError(t, err)
+
+	// Modifying the request doesn't change the copy
+	require.NoError(t, request.SetModel("gpt-4o-2024-08-06"))
+	assert.Equal(t, "gpt-4o", copied.GetModel())
+	assert.Equal(t, object.RequestTypeChatCompletions, copied.GetRequestType())
+	assert.Equal(t, "Bearer sk-test", copied.GetRawRequest().Header.Get("Authorization"))
+
+	bs, err := copied.(json.Marshaler).MarshalJSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"model": "gpt-4o", "messages": [{"role": "user", "content": "hello"}]}`, string(bs))
+
+	// The copy has metadata of its own, outliving the request
+	mirrorMeta := metadata.RequestMetadataFromCtx(mirrorCtx)
+	assert.NotSame(t, rMeta, mirrorMeta)
+	assert.Equal(t, "gpt-4o", mirrorMeta.RequestModel)
+	assert.Equal(t, "key-1", mirrorMeta.AuthInfo.GetApiKeyId())
+
+	cancel()
+	require.NoError(t, mirrorCtx.Err())
+}
+
+func TestDiscardResponse(t *testing.T) {
+	assert.NoError(t, discardResponse(nil, nil))
+
+	err := errors.New("upstream unavailable")
+	assert.ErrorIs(t, discardResponse(nil, err), err)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/samber/lo"
+	"github.com/samber/mo"
 
 	routev1alpha1 "knoway.dev/api/route/v1alpha1"
 	"knoway.dev/pkg/bootkit"
@@ -17,6 +18,7 @@ import (
 	"knoway.dev/pkg/registry/config"
 	"knoway.dev/pkg/route"
 	"knoway.dev/pkg/route/loadbalance"
+	"knoway.dev/pkg/route/retry"
 	"knoway.dev/pkg/types/openai"
 	"knoway.dev/pkg/utils"
 )
@@ -31,6 +33,8 @@ type routeDefault struct {
 	cfg                  *routev1alpha1.Route
 	nsMap                map[string]string
 	loadBalancer         loadbalance.LoadBalancer
+	retryPolicy          *retry.Policy
+	mirror               *mirror
 	routeFilters         filters.RequestFilters
 	reversedRouteFilters filters.RequestFilters
 }
@@ -40,6 +44,8 @@ func NewWithConfig(cfg *routev1alpha1.Route, lifecycle bootkit.LifeCycle) (route
 		cfg:          cfg,
 		nsMap:        buildBackendNsMap(cfg),
 		loadBalancer: loadbalance.New(cfg),
+		retryPolicy:  retry.NewPolicy(cfg.GetRetryPolicy()),
+		mirror:       newMirror(cfg),
 	}
 
 	for _, fc := range cfg.GetFilters() {
@@ -48,7 +54,7 @@ func NewWithConfig(cfg *routev1alpha1.Route, lifecycle bootkit.LifeCycle) (route
 			return nil, err
 		}
 
-		rm.routeFilters = append(rm.routeFilters, f)
+		rm.routeFilters = append(rm.routeFilters, filters.WithFeatureFlag(f, fc.GetFeatureFlag()))
 	}
 
 	rm.reversedRouteFilters = utils.Clone(rm.routeFilters)
@@ -85,6 +91,10 @@ func (m *routeDefault) Match(ctx context.Context, request object.LLMRequest) boo
 			continue
 		}
 
+		if !matchConditions(ctx, match, request) {
+			continue
+		}
+
 		return true
 	}
 
@@ -98,56 +108,160 @@ func (m *routeDefault) HandleRequest(ctx context.Context, request object.LLMRequ
 		return nil, object.NewErrorModelNotFoundOrNotAccessible(request.GetModel())
 	}
 
+	err := applySeedPolicy(m.cfg.GetSeedPolicy(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	rMeta.Seed = requestSeed(request)
+
+	if timeout := m.cfg.GetTimeout().AsDuration(); timeout > 0 {
+		rMeta.Deadline = mo.Some(rMeta.RequestAt.Add(timeout))
+	}
+
 	switch request.GetRequestType() {
 	case object.RequestTypeChatCompletions, object.RequestTypeCompletions:
 		for _, f := range m.routeFilters.OnCompletionRequestFilters() {
-			fResult := f.OnCompletionRequest(ctx, request, request.GetRawRequest())
+			fResult := filters.Observe(ctx, f, filters.StageOnCompletionRequest, func() filters.RequestFilterResult {
+				return f.OnCompletionRequest(ctx, request, request.GetRawRequest())
+			})
 			if fResult.IsFailed() {
 				return nil, fResult.Error
 			}
+			if fResult.IsResponded() {
+				rMeta.ResponseModel = request.GetModel()
+				return fResult.Response, nil
+			}
 		}
-	case object.RequestTypeImageGenerations:
+	case object.RequestTypeImageGenerations, object.RequestTypeImageEdits, object.RequestTypeImageVariations:
 		for _, f := range m.routeFilters.OnImageGenerationsRequestFilters() {
-			fResult := f.OnImageGenerationsRequest(ctx, request, request.GetRawRequest())
+			fResult := filters.Observe(ctx, f, filters.StageOnImageGenerationsRequest, func() filters.RequestFilterResult {
+				return f.OnImageGenerationsRequest(ctx, request, request.GetRawRequest())
+			})
+			if fResult.IsFailed() {
+				return nil, fResult.Error
+			}
+		}
+	case object.RequestTypeModerations:
+		for _, f := range m.routeFilters.OnModerationsRequestFilters() {
+			fResult := filters.Observe(ctx, f, filters.StageOnModerationsRequest, func() filters.RequestFilterResult {
+				return f.OnModerationsRequest(ctx, request, request.GetRawRequest())
+			})
+			if fResult.IsFailed() {
+				return nil, fResult.Error
+			}
+		}
+	case object.RequestTypeEmbeddings:
+		for _, f := range m.routeFilters.OnEmbeddingsRequestFilters() {
+			fResult := filters.Observe(ctx, f, filters.StageOnEmbeddingsRequest, func() filters.RequestFilterResult {
+				return f.OnEmbeddingsRequest(ctx, request, request.GetRawRequest())
+			})
+			if fResult.IsFailed() {
+				return nil, fResult.Error
+			}
+		}
+	case object.RequestTypeRerank:
+		for _, f := range m.routeFilters.OnRerankRequestFilters() {
+			fResult := filters.Observe(ctx, f, filters.StageOnRerankRequest, func() filters.RequestFilterResult {
+				return f.OnRerankRequest(ctx, request, request.GetRawRequest())
+			})
+			if fResult.IsFailed() {
+				return nil, fResult.Error
+			}
+		}
+	case object.RequestTypeVideoGenerations:
+		for _, f := range m.routeFilters.OnVideoGenerationsRequestFilters() {
+			fResult := filters.Observe(ctx, f, filters.StageOnVideoGenerationsRequest, func() filters.RequestFilterResult {
+				return f.OnVideoGenerationsRequest(ctx, request, request.GetRawRequest())
+			})
+			if fResult.IsFailed() {
+				return nil, fResult.Error
+			}
+		}
+	case object.RequestTypeRealtime:
+		for _, f := range m.routeFilters.OnRealtimeRequestFilters() {
+			fResult := filters.Observe(ctx, f, filters.StageOnRealtimeRequest, func() filters.RequestFilterResult {
+				return f.OnRealtimeRequest(ctx, request, request.GetRawRequest())
+			})
 			if fResult.IsFailed() {
 				return nil, fResult.Error
 			}
 		}
 	}
 
-	var retriedCount uint64
+	pinnedCluster := pinnedClusterOf(request)
+	if pinnedCluster != "" && !m.hasTarget(pinnedCluster) {
+		return nil, object.NewErrorModelNotFoundOrNotAccessible(request.GetModel())
+	}
+
+	m.mirror.send(ctx, request)
+
+	var (
+		retriedCount    uint64
+		triedClusters   []string
+		failoverCluster string
+	)
+
+	if m.retryPolicy != nil {
+		m.retryPolicy.RecordRequest()
+	}
 
 	// Fallback loop
 	for {
 		var clusterName string
 
-		// default lb policy
-		if m.cfg.GetLoadBalancePolicy() == routev1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_UNSPECIFIED {
-			clusterName = m.cfg.GetTargets()[0].GetDestination().GetCluster()
-		} else {
-			clusterName = m.loadBalancer.Next(ctx, request)
+		switch {
+		case pinnedCluster != "":
+			clusterName = pinnedCluster
+		case failoverCluster != "":
+			clusterName = failoverCluster
+		case m.cfg.GetLoadBalancePolicy() == routev1alpha1.LoadBalancePolicy_LOAD_BALANCE_POLICY_UNSPECIFIED:
+			// default lb policy
+			clusterName = m.availableCluster(m.cfg.GetTargets()[0].GetDestination().GetCluster())
+		default:
+			clusterName = m.availableCluster(m.loadBalancer.Next(loadbalance.WithTriedTargets(ctx, triedClusters), request))
 		}
 
-		if m.cfg.GetFallback() != nil && m.cfg.GetFallback().GetPreDelay() != nil && retriedCount > 0 {
+		// Failovers are attempted immediately
+		if m.cfg.GetFallback() != nil && m.cfg.GetFallback().GetPreDelay() != nil && retriedCount > 0 && failoverCluster == "" {
 			time.Sleep(m.cfg.GetFallback().GetPreDelay().AsDuration())
 		}
 
-		resp, err := clustermanager.HandleRequest(ctx, clusterName, request)
+		failoverCluster = ""
+		triedClusters = append(triedClusters, clusterName)
+
+		rMeta.ServedModel = clusterName
+		rMeta.ServingTarget = m.servingTarget(clusterName)
+
+		attempt := rMeta.NewUpstreamAttempt(clusterName, rMeta.ServingTarget)
+
+		resp, err := clustermanager.HandleRequest(metadata.WithUpstreamAttempt(ctx, attempt), clusterName, request)
+		if err != nil {
+			rMeta.UpdateUpstreamAttempt(attempt, func(attempt *metadata.UpstreamAttempt) {
+				attempt.ResponseErrorMessage = lo.CoalesceOrEmpty(attempt.ResponseErrorMessage, err.Error())
+			})
+		}
+
+		loadbalance.ObserveUpstreamAttempt(rMeta.UpstreamAttemptValue(attempt))
 
 		switch request.GetRequestType() {
 		case object.RequestTypeChatCompletions, object.RequestTypeCompletions:
 			if !request.IsStream() && !lo.IsNil(resp) {
 				for _, f := range m.reversedRouteFilters.OnCompletionResponseFilters() {
-					fResult := f.OnCompletionResponse(ctx, request, resp)
+					fResult := filters.Observe(ctx, f, filters.StageOnCompletionResponse, func() filters.RequestFilterResult {
+						return f.OnCompletionResponse(ctx, request, resp)
+					})
 					if fResult.IsFailed() {
 						slog.Error("error occurred during invoking of OnCompletionResponse filters", "error", fResult.Error)
 					}
 				}
 			}
-		case object.RequestTypeImageGenerations:
+		case object.RequestTypeImageGenerations, object.RequestTypeImageEdits, object.RequestTypeImageVariations:
 			if !lo.IsNil(resp) {
 				for _, f := range m.reversedRouteFilters.OnImageGenerationsResponseFilters() {
-					fResult := f.OnImageGenerationsResponse(ctx, request, resp)
+					fResult := filters.Observe(ctx, f, filters.StageOnImageGenerationsResponse, func() filters.RequestFilterResult {
+						return f.OnImageGenerationsResponse(ctx, request, resp)
+					})
 					if fResult.IsFailed() {
 						slog.Error("error occurred during invoking of OnImageGenerationsResponse filters", "error", fResult.Error)
 					}
@@ -157,9 +271,23 @@ func (m *routeDefault) HandleRequest(ctx context.Context, request object.LLMRequ
 
 		if !lo.IsNil(resp) && resp.IsStream() {
 			if streamResp, ok := resp.(object.LLMStreamResponse); ok {
+				streamResp.BeforeChunk(func(chunk object.LLMChunkResponse) error {
+					for _, f := range m.reversedRouteFilters.OnCompletionStreamChunkFilters() {
+						fResult := filters.Observe(ctx, f, filters.StageOnCompletionStreamChunk, func() filters.RequestFilterResult {
+							return f.OnCompletionStreamChunk(ctx, request, streamResp, chunk)
+						})
+						if fResult.IsFailed() {
+							return fResult.Error
+						}
+					}
+
+					return nil
+				})
 				streamResp.OnChunk(func(ctx context.Context, stream object.LLMStreamResponse, chunk object.LLMChunkResponse) {
 					for _, f := range m.reversedRouteFilters.OnCompletionStreamResponseFilters() {
-						fResult := f.OnCompletionStreamResponse(ctx, request, streamResp, chunk)
+						fResult := filters.Observe(ctx, f, filters.StageOnCompletionStreamResponse, func() filters.RequestFilterResult {
+							return f.OnCompletionStreamResponse(ctx, request, streamResp, chunk)
+						})
 						if fResult.IsFailed() {
 							// REVIEW: ignore? Or should fResult be returned?
 							// Related topics: moderation, censorship, or filter keywords from the response
@@ -176,16 +304,23 @@ func (m *routeDefault) HandleRequest(ctx context.Context, request object.LLMRequ
 			return resp, err
 		}
 
-		if m.cfg.GetFallback() == nil {
+		// No time left for another attempt
+		if remaining, ok := rMeta.TimeRemaining(); ok && remaining <= 0 {
 			return resp, err
 		}
 
-		if m.cfg.GetFallback().GetPostDelay() != nil {
-			time.Sleep(m.cfg.GetFallback().GetPostDelay().AsDuration())
+		// Fail over the classes of errors to the next destinations instead of
+		// retrying the same cluster, pinned requests can't be served elsewhere
+		if pinnedCluster == "" && ctx.Err() == nil {
+			if next, ok := m.failover(ctx, err, triedClusters); ok {
+				failoverCluster = next
+
+				continue
+			}
 		}
 
-		if m.cfg.GetFallback().MaxRetries != nil {
-			if retriedCount >= lo.CoalesceOrEmpty(m.cfg.GetFallback().GetMaxRetries(), defaultRouteFallbackMaxRetries) {
+		if m.retryPolicy != nil {
+			if !m.retry(ctx, err, retriedCount) {
 				return resp, err
 			}
 
@@ -193,7 +328,127 @@ func (m *routeDefault) HandleRequest(ctx context.Context, request object.LLMRequ
 
 			continue
 		}
+
+		if m.cfg.GetFallback() == nil {
+			return resp, err
+		}
+
+		if m.cfg.GetFallback().GetPostDelay() != nil {
+			time.Sleep(m.cfg.GetFallback().GetPostDelay().AsDuration())
+		}
+
+		// Bounded by the default when unset, fallbacks configured with the
+		// failovers only would retry forever otherwise
+		if retriedCount >= lo.CoalesceOrEmpty(m.cfg.GetFallback().GetMaxRetries(), defaultRouteFallbackMaxRetries) {
+			return resp, err
+		}
+
+		retriedCount++
+	}
+}
+
+// retry waits for the backoff and reports whether the failed attempt is
+// retried under the retry policy, requests canceled by the clients are never
+// retried.
+func (m *routeDefault) retry(ctx context.Context, err error, retriedCount uint64) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	delay, ok := m.retryPolicy.Retry(err, retriedCount)
+	if !ok {
+		return false
+	}
+
+	// The backoff outlasts the timeout of the route
+	if remaining, ok := metadata.RequestMetadataFromCtx(ctx).TimeRemaining(); ok && remaining <= delay {
+		return false
+	}
+
+	slog.DebugContext(ctx, "retrying failed upstream attempt",
+		slog.String("route", m.cfg.GetName()),
+		slog.Uint64("retry", retriedCount+1),
+		slog.Duration("backoff", delay),
+		slog.String("class", retry.Classify(err).String()),
+	)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// availableCluster returns the given cluster unless it's under maintenance or
+// ejected by its circuit breaker, in which case the next target that is
+// available takes over the request. When none of the targets are available,
+// the given cluster is returned, requests are rejected by it if it's under
+// maintenance, or still sent to it if it's only ejected.
+func (m *routeDefault) availableCluster(clusterName string) string {
+	if clustermanager.Available(clusterName) {
+		return clusterName
+	}
+
+	targets := m.cfg.GetTargets()
+
+	index := lo.IndexOf(lo.Map(targets, func(item *routev1alpha1.RouteTarget, _ int) string {
+		return item.GetDestination().GetCluster()
+	}), clusterName)
+
+	for i := 1; i <= len(targets); i++ {
+		candidate := targets[(index+i+len(targets))%len(targets)].GetDestination().GetCluster()
+		if candidate != clusterName && clustermanager.Available(candidate) {
+			return candidate
+		}
+	}
+
+	return clusterName
+}
+
+// pinnedClusterOf returns the cluster the request must be served by, e.g. the
+// one the video generation job polled was submitted to, or empty if the
+// request is load balanced.
+func pinnedClusterOf(request object.LLMRequest) string {
+	pinned, ok := request.(interface{ GetCluster() string })
+	if !ok {
+		return ""
+	}
+
+	return pinned.GetCluster()
+}
+
+func (m *routeDefault) hasTarget(clusterName string) bool {
+	return lo.ContainsBy(m.cfg.GetTargets(), func(item *routev1alpha1.RouteTarget) bool {
+		return item.GetDestination().GetCluster() == clusterName
+	})
+}
+
+func (m *routeDefault) servingTarget(clusterName string) string {
+	destinations := lo.Map(m.cfg.GetTargets(), func(item *routev1alpha1.RouteTarget, _ int) *routev1alpha1.RouteDestination {
+		return item.GetDestination()
+	})
+
+	for _, onError := range m.cfg.GetFallback().GetOnErrors() {
+		destinations = append(destinations, onError.GetDestinations()...)
+	}
+
+	for _, destination := range destinations {
+		if destination.GetCluster() != clusterName || destination.GetBackend() == "" {
+			continue
+		}
+
+		if destination.GetNamespace() == "" {
+			return destination.GetBackend()
+		}
+
+		return destination.GetNamespace() + "/" + destination.GetBackend()
 	}
+
+	return clusterName
 }
 
 func buildBackendNsMap(cfg *routev1alpha1.Route) map[string]string {
@@ -0,0 +1,38 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/types/openai"
+)
+
+func TestPinnedClusterOf(t *testing.T) {
+	_, chatRequest := gatewaytest.NewChatCompletionRequest(t, `{"model": "gpt-4o", "messages": []}`)
+	assert.Empty(t, pinnedClusterOf(chatRequest))
+
+	job := openai.VideoJob{Model: "sora-2", Cluster: "default/sora-2-eastus", UpstreamID: "video_68d7512d"}
+
+	httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://example.com/v1/videos/"+job.ID(), nil)
+	require.NoError(t, err)
+
+	request, err := openai.NewVideoJobRequest(httpRequest, openai.VideoOperationRetrieve, job.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "default/sora-2-eastus", pinnedClusterOf(request))
+
+	m := &routeDefault{cfg: &routev1alpha1.Route{
+		Name: "sora-2",
+		Targets: []*routev1alpha1.RouteTarget{
+			{Destination: &routev1alpha1.RouteDestination{Cluster: "default/sora-2-eastus"}},
+			{Destination: &routev1alpha1.RouteDestination{Cluster: "default/sora-2-westus"}},
+		},
+	}}
+	assert.True(t, m.hasTarget("default/sora-2-eastus"))
+	assert.False(t, m.hasTarget("default/sora-2-northeurope"))
+}
@@ -0,0 +1,54 @@
+package route
+
+import (
+	"math"
+
+	"github.com/samber/mo"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	"knoway.dev/pkg/object"
+)
+
+const seedParamKey = "seed"
+
+// applySeedPolicy forces or strips the seed of chat completions and
+// completions requests according to the policy, other requests don't take
+// seeds and are left as is.
+func applySeedPolicy(policy *routev1alpha1.SeedPolicy, request object.LLMRequest) error {
+	switch request.GetRequestType() {
+	case object.RequestTypeChatCompletions, object.RequestTypeCompletions:
+	default:
+		return nil
+	}
+
+	switch policy.GetMode() {
+	case routev1alpha1.SeedPolicyMode_SEED_POLICY_MODE_FORCE:
+		return request.SetOverrideParams(map[string]*structpb.Value{
+			seedParamKey: structpb.NewNumberValue(float64(policy.GetSeed())),
+		})
+	case routev1alpha1.SeedPolicyMode_SEED_POLICY_MODE_STRIP:
+		return request.RemoveParamKeys([]string{seedParamKey})
+	case routev1alpha1.SeedPolicyMode_SEED_POLICY_MODE_UNSPECIFIED,
+		routev1alpha1.SeedPolicyMode_SEED_POLICY_MODE_PASS_THROUGH:
+		return nil
+	default:
+		return nil
+	}
+}
+
+// requestSeed returns the seed the request is sent with, seeds which are not
+// integers are ignored.
+func requestSeed(request object.LLMRequest) mo.Option[int64] {
+	parsed, ok := request.(interface{ GetBodyParsed() map[string]any })
+	if !ok {
+		return mo.None[int64]()
+	}
+
+	seed, ok := parsed.GetBodyParsed()[seedParamKey].(float64)
+	if !ok || seed != math.Trunc(seed) {
+		return mo.None[int64]()
+	}
+
+	return mo.Some(int64(seed))
+}
@@ -0,0 +1,40 @@
+package route
+
+import (
+	"testing"
+
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	routev1alpha1 "knoway.dev/api/route/v1alpha1"
+	"knoway.dev/internal/gatewaytest"
+)
+
+func TestApplySeedPolicy(t *testing.T) {
+	body := `{"model": "gpt-4o", "seed": 7, "messages": [{"role": "user", "content": "hello"}]}`
+
+	// Passed through without policy
+	_, request := gatewaytest.NewChatCompletionRequest(t, body)
+	require.NoError(t, applySeedPolicy(nil, request))
+	assert.Equal(t, mo.Some[int64](7), requestSeed(request))
+
+	_, request = gatewaytest.NewChatCompletionRequest(t, body)
+	require.NoError(t, applySeedPolicy(&routev1alpha1.SeedPolicy{Mode: routev1alpha1.SeedPolicyMode_SEED_POLICY_MODE_PASS_THROUGH}, request))
+	assert.Equal(t, mo.Some[int64](7), requestSeed(request))
+
+	_, request = gatewaytest.NewChatCompletionRequest(t, body)
+	require.NoError(t, applySeedPolicy(&routev1alpha1.SeedPolicy{Mode: routev1alpha1.SeedPolicyMode_SEED_POLICY_MODE_FORCE, Seed: 42}, request))
+	assert.Equal(t, mo.Some[int64](42), requestSeed(request))
+
+	// Forced even if the request has no seed
+	_, request = gatewaytest.NewChatCompletionRequest(t, `{"model": "gpt-4o", "messages": []}`)
+	assert.Equal(t, mo.None[int64](), requestSeed(request))
+	require.NoError(t, applySeedPolicy(&routev1alpha1.SeedPolicy{Mode: routev1alpha1.SeedPolicyMode_SEED_POLICY_MODE_FORCE, Seed: 42}, request))
+	assert.Equal(t, mo.Some[int64](42), requestSeed(request))
+
+	_, request = gatewaytest.NewChatCompletionRequest(t, body)
+	require.NoError(t, applySeedPolicy(&routev1alpha1.SeedPolicy{Mode: routev1alpha1.SeedPolicyMode_SEED_POLICY_MODE_STRIP}, request))
+	assert.Equal(t, mo.None[int64](), requestSeed(request))
+	assert.NotContains(t, request.GetBodyParsed(), "seed")
+}
@@ -0,0 +1,86 @@
+package sharedstate
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/redis/rueidis"
+
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/redis"
+)
+
+const redisKeyPrefix = "knoway:state:"
+
+var _ Remote = (*RedisRemote)(nil)
+
+// RedisRemote keeps each table as a Redis hash, with the entries encoded as
+// JSON.
+type RedisRemote struct {
+	client rueidis.Client
+}
+
+func NewRedisRemote(url string, lifecycle bootkit.LifeCycle) (*RedisRemote, error) {
+	client, err := redis.NewRedisClient(url)
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStop: func(context.Context) error {
+			client.Close()
+			return nil
+		},
+	})
+
+	return &RedisRemote{client: client}, nil
+}
+
+func (r *RedisRemote) key(table string) string {
+	return redisKeyPrefix + table
+}
+
+func (r *RedisRemote) Load(ctx context.Context, table string) (map[string]Entry, error) {
+	fields, err := r.client.Do(ctx, r.client.B().Hgetall().Key(r.key(table)).Build()).AsStrMap()
+	if err != nil {
+		if rueidis.IsRedisNil(err) {
+			return map[string]Entry{}, nil
+		}
+
+		return nil, err
+	}
+
+	entries := make(map[string]Entry, len(fields))
+
+	for key, field := range fields {
+		var entry Entry
+
+		err := json.Unmarshal([]byte(field), &entry)
+		if err != nil {
+			slog.WarnContext(ctx, "skipped malformed shared state entry", slog.String("table", table), slog.String("key", key), slog.Any("error", err))
+			continue
+		}
+
+		entries[key] = entry
+	}
+
+	return entries, nil
+}
+
+func (r *RedisRemote) Save(ctx context.Context, table string, key string, entry Entry) error {
+	field, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Do(ctx, r.client.B().Hset().Key(r.key(table)).FieldValue().FieldValue(key, string(field)).Build()).Error()
+}
+
+func (r *RedisRemote) Delete(ctx context.Context, table string, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return r.client.Do(ctx, r.client.B().Hdel().Key(r.key(table)).Field(keys...).Build()).Error()
+}
@@ -0,0 +1,293 @@
+package sharedstate
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"knoway.dev/pkg/bootkit"
+)
+
+// Tables of the state shared across the replicas of the gateway.
+const (
+	// TableBreakers keeps the status of circuit breakers by cluster.
+	TableBreakers = "breakers"
+	// TableCapabilities keeps the capabilities probed from the backends by
+	// cluster.
+	TableCapabilities = "capabilities"
+)
+
+const (
+	defaultSyncInterval = 5 * time.Second
+	defaultSyncJitter   = 0.2
+)
+
+// Entry is a value of a table.
+type Entry struct {
+	Value     string    `json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// ExpiresAt is zero if the entry never expires.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+func (e Entry) expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !now.Before(e.ExpiresAt)
+}
+
+// Remote keeps the tables shared by the replicas, e.g. Redis.
+type Remote interface {
+	Load(ctx context.Context, table string) (map[string]Entry, error)
+	Save(ctx context.Context, table string, key string, entry Entry) error
+	Delete(ctx context.Context, table string, keys ...string) error
+}
+
+type Options struct {
+	// Remote shares the tables with the other replicas, the state is kept
+	// in the process only if nil.
+	Remote Remote
+	// SyncInterval is how often the local cache is synced with the remote.
+	// Default is 5s.
+	SyncInterval time.Duration
+	// SyncJitter randomizes the sync interval by the fraction, so that the
+	// replicas don't hit the remote at the same time. Default is 0.2.
+	SyncJitter float64
+}
+
+// Store keeps the state shared across the replicas of the gateway, such as
+// the status of circuit breakers and the capabilities of backends. Reads are
+// served from the local cache, writes go through to the remote and the
+// cache is synced with the remote in the background, so the replicas behave
+// coherently within a sync interval.
+type Store struct {
+	options Options
+	now     func() time.Time
+
+	mutex  sync.RWMutex
+	tables map[string]map[string]Entry
+}
+
+func NewStore(options Options) *Store {
+	if options.SyncInterval <= 0 {
+		options.SyncInterval = defaultSyncInterval
+	}
+
+	if options.SyncJitter <= 0 {
+		options.SyncJitter = defaultSyncJitter
+	}
+
+	return &Store{
+		options: options,
+		now:     time.Now,
+		tables:  make(map[string]map[string]Entry),
+	}
+}
+
+// Get returns the value of the key from the local cache.
+func (s *Store) Get(table string, key string) (string, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	entry, ok := s.tables[table][key]
+	if !ok || entry.expired(s.now()) {
+		return "", false
+	}
+
+	return entry.Value, true
+}
+
+// Set sets the value of the key, the value expires after ttl unless ttl is
+// zero. The local cache is updated even if writing to the remote fails.
+func (s *Store) Set(ctx context.Context, table string, key string, value string, ttl time.Duration) error {
+	now := s.now()
+
+	entry := Entry{
+		Value:     value,
+		UpdatedAt: now,
+	}
+
+	if ttl > 0 {
+		entry.ExpiresAt = now.Add(ttl)
+	}
+
+	s.mutex.Lock()
+	s.tableLocked(table)[key] = entry
+	s.mutex.Unlock()
+
+	if s.options.Remote == nil {
+		return nil
+	}
+
+	return s.options.Remote.Save(ctx, table, key, entry)
+}
+
+// Delete removes the key from the table.
+func (s *Store) Delete(ctx context.Context, table string, key string) error {
+	s.mutex.Lock()
+	delete(s.tableLocked(table), key)
+	s.mutex.Unlock()
+
+	if s.options.Remote == nil {
+		return nil
+	}
+
+	return s.options.Remote.Delete(ctx, table, key)
+}
+
+// tableLocked returns the table, creating it if absent, must be called with
+// the mutex held for writing.
+func (s *Store) tableLocked(table string) map[string]Entry {
+	entries, ok := s.tables[table]
+	if !ok {
+		entries = make(map[string]Entry)
+		s.tables[table] = entries
+	}
+
+	return entries
+}
+
+// Sync replaces the local cache of the tables with the remote ones, entries
+// written locally while loading or newer than the remote ones are kept. The
+// expired entries are removed from the local cache if there is no remote.
+func (s *Store) Sync(ctx context.Context) {
+	if s.options.Remote == nil {
+		s.removeExpired()
+		return
+	}
+
+	// Tables written by the other replicas only are synced too
+	tables := []string{TableBreakers, TableCapabilities}
+
+	s.mutex.RLock()
+	for table := range s.tables {
+		if !slices.Contains(tables, table) {
+			tables = append(tables, table)
+		}
+	}
+	s.mutex.RUnlock()
+
+	for _, table := range tables {
+		err := s.syncTable(ctx, table)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to sync shared state", slog.String("table", table), slog.Any("error", err))
+		}
+	}
+}
+
+func (s *Store) syncTable(ctx context.Context, table string) error {
+	loadedAt := s.now()
+
+	remote, err := s.options.Remote.Load(ctx, table)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	expiredKeys := make([]string, 0)
+	synced := make(map[string]Entry, len(remote))
+
+	for key, entry := range remote {
+		if entry.expired(now) {
+			expiredKeys = append(expiredKeys, key)
+			continue
+		}
+
+		synced[key] = entry
+	}
+
+	s.mutex.Lock()
+
+	for key, entry := range s.tables[table] {
+		if entry.expired(now) {
+			continue
+		}
+
+		remoteEntry, ok := synced[key]
+		if (ok && entry.UpdatedAt.After(remoteEntry.UpdatedAt)) || !entry.UpdatedAt.Before(loadedAt) {
+			synced[key] = entry
+		}
+	}
+
+	s.tables[table] = synced
+	s.mutex.Unlock()
+
+	if len(expiredKeys) > 0 {
+		return s.options.Remote.Delete(ctx, table, expiredKeys...)
+	}
+
+	return nil
+}
+
+// removeExpired removes the expired entries from the local cache.
+func (s *Store) removeExpired() {
+	now := s.now()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, entries := range s.tables {
+		maps.DeleteFunc(entries, func(_ string, entry Entry) bool {
+			return entry.expired(now)
+		})
+	}
+}
+
+// nextSyncInterval returns the sync interval randomized by the jitter.
+func (s *Store) nextSyncInterval() time.Duration {
+	jitter := (rand.Float64()*2 - 1) * s.options.SyncJitter //nolint:gosec
+
+	return time.Duration(float64(s.options.SyncInterval) * (1 + jitter))
+}
+
+// Start syncs the local cache with the remote in the background until the
+// lifecycle stops, or removes the expired entries if there is no remote.
+func (s *Store) Start(lifecycle bootkit.LifeCycle) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStart: func(context.Context) error {
+			go func() {
+				s.Sync(ctx)
+
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(s.nextSyncInterval()):
+						s.Sync(ctx)
+					}
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			cancel()
+			return nil
+		},
+	})
+}
+
+var (
+	global       atomic.Pointer[Store]
+	defaultStore = NewStore(Options{})
+)
+
+// SetGlobal sets the Store used by the whole gateway.
+func SetGlobal(s *Store) {
+	global.Store(s)
+}
+
+// Global returns the Store used by the whole gateway, the state is kept in
+// the process only if no shared state is configured.
+func Global() *Store {
+	if s := global.Load(); s != nil {
+		return s
+	}
+
+	return defaultStore
+}
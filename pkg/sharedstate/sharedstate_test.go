@@ -0,0 +1,157 @@
+package sharedstate
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryRemote struct {
+	mutex  sync.Mutex
+	tables map[string]map[string]Entry
+}
+
+func newMemoryRemote() *memoryRemote {
+	return &memoryRemote{tables: make(map[string]map[string]Entry)}
+}
+
+func (r *memoryRemote) Load(_ context.Context, table string) (map[string]Entry, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return maps.Clone(r.tables[table]), nil
+}
+
+func (r *memoryRemote) Save(_ context.Context, table string, key string, entry Entry) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.tables[table] == nil {
+		r.tables[table] = make(map[string]Entry)
+	}
+
+	r.tables[table][key] = entry
+
+	return nil
+}
+
+func (r *memoryRemote) Delete(_ context.Context, table string, keys ...string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, key := range keys {
+		delete(r.tables[table], key)
+	}
+
+	return nil
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("local only", func(t *testing.T) {
+		store := NewStore(Options{})
+
+		require.NoError(t, store.Set(ctx, TableBreakers, "openai/gpt-4o", "open", 0))
+
+		value, ok := store.Get(TableBreakers, "openai/gpt-4o")
+		assert.True(t, ok)
+		assert.Equal(t, "open", value)
+
+		require.NoError(t, store.Delete(ctx, TableBreakers, "openai/gpt-4o"))
+
+		_, ok = store.Get(TableBreakers, "openai/gpt-4o")
+		assert.False(t, ok)
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		now := time.Now()
+		store := NewStore(Options{})
+		store.now = func() time.Time { return now }
+
+		require.NoError(t, store.Set(ctx, TableBreakers, "cluster-a", "open", time.Minute))
+
+		_, ok := store.Get(TableBreakers, "cluster-a")
+		assert.True(t, ok)
+
+		now = now.Add(time.Minute)
+
+		_, ok = store.Get(TableBreakers, "cluster-a")
+		assert.False(t, ok)
+
+		// Expired entries are removed without a remote too
+		store.Sync(ctx)
+		assert.Empty(t, store.tables[TableBreakers])
+	})
+
+	t.Run("shared across replicas", func(t *testing.T) {
+		remote := newMemoryRemote()
+		replicaA := NewStore(Options{Remote: remote})
+		replicaB := NewStore(Options{Remote: remote})
+
+		require.NoError(t, replicaA.Set(ctx, TableBreakers, "cluster-a", "open", time.Hour))
+
+		_, ok := replicaB.Get(TableBreakers, "cluster-a")
+		assert.False(t, ok, "not synced yet")
+
+		replicaB.Sync(ctx)
+
+		value, ok := replicaB.Get(TableBreakers, "cluster-a")
+		assert.True(t, ok)
+		assert.Equal(t, "open", value)
+
+		require.NoError(t, replicaA.Delete(ctx, TableBreakers, "cluster-a"))
+		replicaB.Sync(ctx)
+
+		_, ok = replicaB.Get(TableBreakers, "cluster-a")
+		assert.False(t, ok)
+	})
+
+	t.Run("newer local entries are kept", func(t *testing.T) {
+		remote := newMemoryRemote()
+		now := time.Now()
+
+		require.NoError(t, remote.Save(ctx, TableBreakers, "cluster-a", Entry{Value: "open", UpdatedAt: now.Add(-time.Minute)}))
+
+		store := NewStore(Options{Remote: remote})
+		store.now = func() time.Time { return now }
+		store.tables[TableBreakers] = map[string]Entry{
+			"cluster-a": {Value: "closed", UpdatedAt: now.Add(-time.Second)},
+		}
+
+		store.Sync(ctx)
+
+		value, ok := store.Get(TableBreakers, "cluster-a")
+		assert.True(t, ok)
+		assert.Equal(t, "closed", value)
+	})
+
+	t.Run("expired remote entries are removed", func(t *testing.T) {
+		remote := newMemoryRemote()
+		now := time.Now()
+
+		require.NoError(t, remote.Save(ctx, TableBreakers, "cluster-a", Entry{Value: "open", UpdatedAt: now.Add(-time.Hour), ExpiresAt: now.Add(-time.Minute)}))
+
+		store := NewStore(Options{Remote: remote})
+		store.Sync(ctx)
+
+		_, ok := store.Get(TableBreakers, "cluster-a")
+		assert.False(t, ok)
+		assert.Empty(t, remote.tables[TableBreakers])
+	})
+}
+
+func TestNextSyncInterval(t *testing.T) {
+	store := NewStore(Options{Remote: newMemoryRemote(), SyncInterval: 10 * time.Second, SyncJitter: 0.2})
+
+	for range 100 {
+		interval := store.nextSyncInterval()
+		assert.GreaterOrEqual(t, interval, 8*time.Second)
+		assert.LessOrEqual(t, interval, 12*time.Second)
+	}
+}
@@ -0,0 +1,165 @@
+// Package sink posts the batches of the exporters of the gateway, e.g. the
+// access logs and the usage records, to webhooks as JSON, or produces them to
+// Kafka topics through the Kafka REST Proxy.
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	KafkaRESTContentType = "application/vnd.kafka.json.v2+json"
+	KafkaRESTAccept      = "application/vnd.kafka.v2+json"
+
+	// errorBodyLimit is how much of the bodies of error responses are kept
+	// in errors.
+	errorBodyLimit = 512
+
+	// defaultTimeout bounds every batch sent, so that a stalled destination
+	// holds the exporters no longer than it.
+	defaultTimeout = 30 * time.Second
+	dialTimeout    = 5 * time.Second
+)
+
+// defaultClient is shared by the sinks, the connections to the same
+// destination are reused between them.
+var defaultClient = newClient()
+
+func newClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	transport.DialContext = (&net.Dialer{Timeout: dialTimeout, KeepAlive: 30 * time.Second}).DialContext
+	transport.TLSHandshakeTimeout = dialTimeout
+	transport.ResponseHeaderTimeout = defaultTimeout
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   defaultTimeout,
+	}
+}
+
+// HTTP posts the batches to a webhook.
+type HTTP struct {
+	url     string
+	headers map[string]string
+}
+
+func NewHTTP(rawURL string, headers map[string]string) (*HTTP, error) {
+	err := validateURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HTTP{
+		url:     rawURL,
+		headers: headers,
+	}, nil
+}
+
+// Post sends the batch encoded in JSON.
+func (s *HTTP) Post(ctx context.Context, batch any) error {
+	return post(ctx, s.url, "application/json", s.headers, batch)
+}
+
+// KafkaRecord is a record produced to Kafka, the value is encoded in JSON.
+type KafkaRecord struct {
+	// Key of the record, the partition is chosen by the proxy if empty
+	Key   string `json:"key,omitempty"`
+	Value any    `json:"value"`
+}
+
+// Kafka produces the batches to a topic through the Kafka REST Proxy.
+type Kafka struct {
+	url     string
+	headers map[string]string
+}
+
+func NewKafka(restProxyURL, topic string, headers map[string]string) (*Kafka, error) {
+	if topic == "" {
+		return nil, errors.New("topic is required")
+	}
+
+	err := validateURL(restProxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rest proxy url: %w", err)
+	}
+
+	endpoint, err := url.JoinPath(restProxyURL, "topics", topic)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]string, len(headers)+1)
+	for key, value := range headers {
+		merged[key] = value
+	}
+
+	merged["Accept"] = KafkaRESTAccept
+
+	return &Kafka{
+		url:     endpoint,
+		headers: merged,
+	}, nil
+}
+
+// Produce sends the records as a single request.
+func (s *Kafka) Produce(ctx context.Context, records []KafkaRecord) error {
+	return post(ctx, s.url, KafkaRESTContentType, s.headers, map[string]any{"records": records})
+}
+
+func post(ctx context.Context, endpoint, contentType string, headers map[string]string, body any) error {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := defaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, errorBodyLimit))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(message))
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+func validateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme of url %q", rawURL)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package sink
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedRequest struct {
+	path    string
+	headers http.Header
+	body    string
+}
+
+func newReceiver(t *testing.T, status int, message string) (*httptest.Server, *[]receivedRequest) {
+	t.Helper()
+
+	received := make([]receivedRequest, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		received = append(received, receivedRequest{path: request.URL.Path, headers: request.Header, body: string(body)})
+
+		writer.WriteHeader(status)
+		_, _ = writer.Write([]byte(message))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &received
+}
+
+func TestHTTP(t *testing.T) {
+	server, received := newReceiver(t, http.StatusNoContent, "")
+
+	s, err := NewHTTP(server.URL+"/logs", map[string]string{"Authorization": "Bearer token"})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Post(context.Background(), []map[string]string{{"uri": "/v1/models"}}))
+
+	require.Len(t, *received, 1)
+	assert.Equal(t, "/logs", (*received)[0].path)
+	assert.Equal(t, "application/json", (*received)[0].headers.Get("Content-Type"))
+	assert.Equal(t, "Bearer token", (*received)[0].headers.Get("Authorization"))
+	assert.JSONEq(t, `[{"uri":"/v1/models"}]`, (*received)[0].body)
+
+	_, err = NewHTTP("ftp://example.com", nil)
+	require.EqualError(t, err, `unsupported scheme of url "ftp://example.com"`)
+}
+
+func TestHTTP_ErrorStatus(t *testing.T) {
+	server, _ := newReceiver(t, http.StatusBadGateway, " upstream unavailable\n")
+
+	s, err := NewHTTP(server.URL, nil)
+	require.NoError(t, err)
+
+	err = s.Post(context.Background(), []any{})
+	require.EqualError(t, err, "unexpected status 502: upstream unavailable")
+}
+
+func TestKafka(t *testing.T) {
+	server, received := newReceiver(t, http.StatusOK, "")
+
+	s, err := NewKafka(server.URL, "knoway-usage", map[string]string{"Authorization": "Bearer token"})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Produce(context.Background(), []KafkaRecord{
+		{Key: "1", Value: map[string]any{"id": "1"}},
+		{Value: map[string]any{"uri": "/v1/models"}},
+	}))
+
+	require.Len(t, *received, 1)
+	assert.Equal(t, "/topics/knoway-usage", (*received)[0].path)
+	assert.Equal(t, KafkaRESTContentType, (*received)[0].headers.Get("Content-Type"))
+	assert.Equal(t, KafkaRESTAccept, (*received)[0].headers.Get("Accept"))
+	assert.Equal(t, "Bearer token", (*received)[0].headers.Get("Authorization"))
+	assert.JSONEq(t, `{"records":[{"key":"1","value":{"id":"1"}},{"value":{"uri":"/v1/models"}}]}`, (*received)[0].body)
+
+	_, err = NewKafka(server.URL, "", nil)
+	require.EqualError(t, err, "topic is required")
+
+	_, err = NewKafka("kafka://localhost:9092", "knoway-usage", nil)
+	require.EqualError(t, err, `invalid rest proxy url: unsupported scheme of url "kafka://localhost:9092"`)
+}
+
+func TestDefaultClient_Timeouts(t *testing.T) {
+	assert.Equal(t, defaultTimeout, defaultClient.Timeout)
+
+	transport, ok := defaultClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, dialTimeout, transport.TLSHandshakeTimeout)
+	assert.Equal(t, defaultTimeout, transport.ResponseHeaderTimeout)
+}
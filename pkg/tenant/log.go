@@ -0,0 +1,38 @@
+package tenant
+
+import (
+	"context"
+	"log/slog"
+)
+
+var _ slog.Handler = (*LogHandler)(nil)
+
+// LogHandler applies the log levels of tenants to the logs of their requests,
+// logs without the context of a request, or of tenants without a log level,
+// follow the level of the wrapped handler.
+type LogHandler struct {
+	slog.Handler
+}
+
+// NewLogHandler wraps the handler, the wrapped handler must not filter the
+// records passed to Handle by their levels, which is the case of the
+// handlers of log/slog.
+func NewLogHandler(handler slog.Handler) *LogHandler {
+	return &LogHandler{Handler: handler}
+}
+
+func (h *LogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if overrides, ok := FromContext(ctx); ok && overrides.LogLevel != nil {
+		return level >= *overrides.LogLevel
+	}
+
+	return h.Handler.Enabled(ctx, level)
+}
+
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &LogHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,188 @@
+// Package tenant resolves the settings overridden for tenants at request time,
+// so that tenants with different limits or policies don't need their own
+// routes and backends. The tenant of a request is the one owning its API key,
+// as given by the auth server.
+package tenant
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/samber/lo"
+
+	clustersv1alpha1 "knoway.dev/api/clusters/v1alpha1"
+	filtersv1alpha1 "knoway.dev/api/filters/v1alpha1"
+	"knoway.dev/pkg/metadata"
+)
+
+// ErrorVerbosity controls how much of the errors are revealed to clients.
+type ErrorVerbosity string
+
+const (
+	// ErrorVerbosityDetailed returns the errors as they are, including the
+	// causes and the messages of upstreams.
+	ErrorVerbosityDetailed ErrorVerbosity = "detailed"
+	// ErrorVerbosityMinimal hides the causes of internal errors and the
+	// messages of upstreams behind generic messages.
+	ErrorVerbosityMinimal ErrorVerbosity = "minimal"
+)
+
+// Overrides are the settings overridden for a tenant, zero values follow the
+// global configuration.
+type Overrides struct {
+	// RateLimits replace the policies of rate limit filters for the requests
+	// of the tenant.
+	RateLimits []*filtersv1alpha1.RateLimitPolicy
+	// AllowedProviders limits the providers of the clusters serving the
+	// tenant, any provider if empty.
+	AllowedProviders []clustersv1alpha1.ClusterProvider
+	// LogLevel is the minimum level of the logs of the requests of the
+	// tenant.
+	LogLevel *slog.Level
+	// ErrorVerbosity of the errors returned to the tenant, detailed if empty.
+	ErrorVerbosity ErrorVerbosity
+	// Namespace of the models of the tenant, models named
+	// "<namespace>/<model>" are served to the tenant only, which requests
+	// them as "<model>" in place of the shared models of the same names.
+	Namespace string
+}
+
+// Registry holds the overrides of every tenant.
+type Registry struct {
+	tenants map[string]*Overrides
+	// namespaces of the models of the tenants
+	namespaces map[string]struct{}
+}
+
+// New creates the Registry, nil is returned when there are no tenants.
+func New(tenants map[string]Overrides) *Registry {
+	if len(tenants) == 0 {
+		return nil
+	}
+
+	registry := &Registry{
+		tenants:    make(map[string]*Overrides, len(tenants)),
+		namespaces: make(map[string]struct{}),
+	}
+
+	for id, overrides := range tenants {
+		registry.tenants[id] = lo.ToPtr(overrides)
+
+		if overrides.Namespace != "" {
+			registry.namespaces[overrides.Namespace] = struct{}{}
+		}
+	}
+
+	return registry
+}
+
+// Lookup returns the overrides of the tenant, nil Registry has none.
+func (r *Registry) Lookup(tenantID string) (*Overrides, bool) {
+	if r == nil || tenantID == "" {
+		return nil, false
+	}
+
+	overrides, ok := r.tenants[tenantID]
+
+	return overrides, ok
+}
+
+// SplitModel splits the model into the namespace of the tenant owning it and
+// the name of it in the namespace, the namespace is empty for shared models.
+// Models with slashes in their names, e.g. "meta-llama/Llama-3.1-8B", are
+// shared unless the part before the slash is the namespace of a tenant.
+func (r *Registry) SplitModel(model string) (string, string) {
+	if r == nil {
+		return "", model
+	}
+
+	namespace, name, found := strings.Cut(model, "/")
+	if !found {
+		return "", model
+	}
+
+	if _, ok := r.namespaces[namespace]; !ok {
+		return "", model
+	}
+
+	return namespace, name
+}
+
+var global atomic.Pointer[Registry]
+
+// SetGlobal sets the Registry used by the whole gateway.
+func SetGlobal(r *Registry) {
+	global.Store(r)
+}
+
+// FromContext returns the overrides of the tenant of the request.
+func FromContext(ctx context.Context) (*Overrides, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	if rMeta == nil {
+		return nil, false
+	}
+
+	return global.Load().Lookup(rMeta.AuthInfo.GetTenantId())
+}
+
+// NamespaceFromContext returns the namespace of the models of the tenant of
+// the request, empty if the tenant has none.
+func NamespaceFromContext(ctx context.Context) string {
+	overrides, ok := FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	return overrides.Namespace
+}
+
+// SplitModel splits the model by the tenants of the gateway, see
+// Registry.SplitModel.
+func SplitModel(model string) (string, string) {
+	return global.Load().SplitModel(model)
+}
+
+// ScopedModel returns the name of the model in the namespace.
+func ScopedModel(namespace, model string) string {
+	return namespace + "/" + model
+}
+
+// RateLimitPolicies returns the rate limit policies of the tenant of the
+// request, or the given defaults if not overridden.
+func RateLimitPolicies(ctx context.Context, defaults []*filtersv1alpha1.RateLimitPolicy) []*filtersv1alpha1.RateLimitPolicy {
+	overrides, ok := FromContext(ctx)
+	if !ok || len(overrides.RateLimits) == 0 {
+		return defaults
+	}
+
+	return overrides.RateLimits
+}
+
+// ProviderAllowed reports whether the tenant of the request may be served by
+// clusters of the provider.
+func ProviderAllowed(ctx context.Context, provider clustersv1alpha1.ClusterProvider) bool {
+	overrides, ok := FromContext(ctx)
+	if !ok || len(overrides.AllowedProviders) == 0 {
+		return true
+	}
+
+	return slices.Contains(overrides.AllowedProviders, provider)
+}
+
+// ErrorVerbosityFromContext returns the error verbosity of the tenant of the
+// request.
+func ErrorVerbosityFromContext(ctx context.Context) ErrorVerbosity {
+	overrides, ok := FromContext(ctx)
+	if !ok || overrides.ErrorVerbosity == "" {
+		return ErrorVerbosityDetailed
+	}
+
+	return overrides.ErrorVerbosity
+}
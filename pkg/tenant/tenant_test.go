@@ -0,0 +1,126 @@
+package tenant
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clustersv1alpha1 "knoway.dev/api/clusters/v1alpha1"
+	filtersv1alpha1 "knoway.dev/api/filters/v1alpha1"
+	servicev1alpha1 "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/pkg/metadata"
+)
+
+func newTenantContext(t *testing.T, tenantID string) context.Context {
+	t.Helper()
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com/v1/chat/completions", nil)
+	require.NoError(t, err)
+
+	ctx := metadata.InitMetadataContext(request)
+	metadata.RequestMetadataFromCtx(ctx).AuthInfo = &servicev1alpha1.APIKeyAuthResponse{TenantId: tenantID}
+
+	return ctx
+}
+
+func TestRegistry(t *testing.T) {
+	assert.Nil(t, New(nil))
+
+	_, ok := New(nil).Lookup("acme")
+	assert.False(t, ok)
+
+	registry := New(map[string]Overrides{"acme": {ErrorVerbosity: ErrorVerbosityMinimal}})
+
+	overrides, ok := registry.Lookup("acme")
+	require.True(t, ok)
+	assert.Equal(t, ErrorVerbosityMinimal, overrides.ErrorVerbosity)
+
+	_, ok = registry.Lookup("")
+	assert.False(t, ok)
+}
+
+func TestOverrides(t *testing.T) {
+	defaults := []*filtersv1alpha1.RateLimitPolicy{{Limit: 10}}
+	overridden := []*filtersv1alpha1.RateLimitPolicy{{Limit: 1}}
+
+	SetGlobal(New(map[string]Overrides{
+		"acme": {
+			RateLimits:       overridden,
+			AllowedProviders: []clustersv1alpha1.ClusterProvider{clustersv1alpha1.ClusterProvider_OPEN_AI},
+			ErrorVerbosity:   ErrorVerbosityMinimal,
+		},
+		"empty": {},
+	}))
+	defer SetGlobal(nil)
+
+	acme := newTenantContext(t, "acme")
+	assert.Equal(t, overridden, RateLimitPolicies(acme, defaults))
+	assert.True(t, ProviderAllowed(acme, clustersv1alpha1.ClusterProvider_OPEN_AI))
+	assert.False(t, ProviderAllowed(acme, clustersv1alpha1.ClusterProvider_VLLM))
+	assert.Equal(t, ErrorVerbosityMinimal, ErrorVerbosityFromContext(acme))
+
+	for _, ctx := range []context.Context{newTenantContext(t, "empty"), newTenantContext(t, "unknown"), newTenantContext(t, ""), context.Background()} {
+		assert.Equal(t, defaults, RateLimitPolicies(ctx, defaults))
+		assert.True(t, ProviderAllowed(ctx, clustersv1alpha1.ClusterProvider_VLLM))
+		assert.Equal(t, ErrorVerbosityDetailed, ErrorVerbosityFromContext(ctx))
+	}
+}
+
+func TestLogHandler(t *testing.T) {
+	debug := slog.LevelDebug
+	errorLevel := slog.LevelError
+
+	SetGlobal(New(map[string]Overrides{
+		"verbose": {LogLevel: &debug},
+		"quiet":   {LogLevel: &errorLevel},
+	}))
+	defer SetGlobal(nil)
+
+	var buffer bytes.Buffer
+
+	logger := slog.New(NewLogHandler(slog.NewTextHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelInfo}))).With("component", "test")
+
+	logger.DebugContext(newTenantContext(t, "verbose"), "verbose debug")
+	logger.InfoContext(newTenantContext(t, "quiet"), "quiet info")
+	logger.DebugContext(newTenantContext(t, "other"), "other debug")
+	logger.InfoContext(context.Background(), "info")
+
+	assert.Contains(t, buffer.String(), "verbose debug")
+	assert.Contains(t, buffer.String(), "component=test")
+	assert.NotContains(t, buffer.String(), "quiet info")
+	assert.NotContains(t, buffer.String(), "other debug")
+	assert.Contains(t, buffer.String(), "msg=info")
+}
+
+func TestSplitModel(t *testing.T) {
+	namespace, model := New(nil).SplitModel("org-a/gpt-4o")
+	assert.Empty(t, namespace)
+	assert.Equal(t, "org-a/gpt-4o", model)
+
+	registry := New(map[string]Overrides{"org-a": {Namespace: "org-a"}, "globex": {}})
+
+	namespace, model = registry.SplitModel("org-a/gpt-4o")
+	assert.Equal(t, "org-a", namespace)
+	assert.Equal(t, "gpt-4o", model)
+
+	// Not the namespace of any tenant
+	namespace, model = registry.SplitModel("meta-llama/Llama-3.1-8B")
+	assert.Empty(t, namespace)
+	assert.Equal(t, "meta-llama/Llama-3.1-8B", model)
+
+	namespace, model = registry.SplitModel("gpt-4o")
+	assert.Empty(t, namespace)
+	assert.Equal(t, "gpt-4o", model)
+
+	SetGlobal(registry)
+	defer SetGlobal(nil)
+
+	assert.Equal(t, "org-a", NamespaceFromContext(newTenantContext(t, "org-a")))
+	assert.Empty(t, NamespaceFromContext(newTenantContext(t, "globex")))
+	assert.Equal(t, "org-a/gpt-4o", ScopedModel("org-a", "gpt-4o"))
+}
@@ -0,0 +1,67 @@
+package threads
+
+import (
+	"encoding/json"
+	"unicode/utf8"
+)
+
+const (
+	// Rough ratio used by OpenAI for English text, good enough for trimming
+	// history without bringing in tokenizers of every model.
+	estimatedCharsPerToken = 4
+	// Every message costs a few more tokens for role and separators.
+	estimatedTokensPerMessage = 4
+)
+
+// EstimateTokens estimates how many tokens the chat completions message takes.
+func EstimateTokens(message map[string]any) uint64 {
+	var chars int
+
+	switch content := message["content"].(type) {
+	case string:
+		chars = utf8.RuneCountInString(content)
+	case nil:
+		chars = 0
+	default:
+		bs, err := json.Marshal(content)
+		if err == nil {
+			chars = utf8.RuneCount(bs)
+		}
+	}
+
+	return uint64(chars/estimatedCharsPerToken) + estimatedTokensPerMessage //nolint:gosec
+}
+
+// AssembleContext builds the messages for chat completions requests from the
+// thread history and the incoming messages. Incoming messages are always kept,
+// the oldest history messages are dropped first when exceeding maxMessages or
+// maxTokens, 0 means unlimited.
+func AssembleContext(history []*Message, incoming []map[string]any, maxMessages uint32, maxTokens uint64) []map[string]any {
+	var usedTokens uint64
+	for _, message := range incoming {
+		usedTokens += EstimateTokens(message)
+	}
+
+	start := len(history)
+
+	for i := len(history) - 1; i >= 0; i-- {
+		if maxMessages > 0 && uint32(len(history)-i) > maxMessages { //nolint:gosec
+			break
+		}
+
+		tokens := EstimateTokens(history[i].ToChatCompletionsMessage())
+		if maxTokens > 0 && usedTokens+tokens > maxTokens {
+			break
+		}
+
+		usedTokens += tokens
+		start = i
+	}
+
+	messages := make([]map[string]any, 0, len(history)-start+len(incoming))
+	for _, message := range history[start:] {
+		messages = append(messages, message.ToChatCompletionsMessage())
+	}
+
+	return append(messages, incoming...)
+}
@@ -0,0 +1,58 @@
+package threads
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	assert.Equal(t, uint64(4), EstimateTokens(map[string]any{"role": "user"}))
+	assert.Equal(t, uint64(5), EstimateTokens(map[string]any{"role": "user", "content": "four"}))
+	assert.Equal(t, uint64(29), EstimateTokens(map[string]any{"role": "user", "content": strings.Repeat("a", 100)}))
+	assert.Positive(t, EstimateTokens(map[string]any{"role": "user", "content": []any{
+		map[string]any{"type": "text", "text": "What's in this image?"},
+	}}))
+}
+
+func TestAssembleContext(t *testing.T) {
+	history := []*Message{
+		NewMessage("thread_1", "system", "You are a helpful assistant."),
+		NewMessage("thread_1", "user", "Hello"),
+		NewMessage("thread_1", "assistant", "Hi, how can I help you?"),
+		NewMessage("thread_1", "user", strings.Repeat("a", 400)),
+		NewMessage("thread_1", "assistant", "OK"),
+	}
+
+	incoming := []map[string]any{
+		{"role": "user", "content": "What did I say?"},
+	}
+
+	t.Run("unlimited", func(t *testing.T) {
+		messages := AssembleContext(history, incoming, 0, 0)
+		assert.Len(t, messages, 6)
+		assert.Equal(t, "You are a helpful assistant.", messages[0]["content"])
+		assert.Equal(t, "What did I say?", messages[5]["content"])
+	})
+
+	t.Run("max messages", func(t *testing.T) {
+		messages := AssembleContext(history, incoming, 2, 0)
+		assert.Len(t, messages, 3)
+		assert.Equal(t, strings.Repeat("a", 400), messages[0]["content"])
+		assert.Equal(t, "OK", messages[1]["content"])
+	})
+
+	t.Run("max tokens", func(t *testing.T) {
+		messages := AssembleContext(history, incoming, 0, 20)
+		assert.Len(t, messages, 2)
+		assert.Equal(t, "OK", messages[0]["content"])
+		assert.Equal(t, "What did I say?", messages[1]["content"])
+	})
+
+	t.Run("incoming always kept", func(t *testing.T) {
+		messages := AssembleContext(history, incoming, 0, 1)
+		assert.Len(t, messages, 1)
+		assert.Equal(t, "What did I say?", messages[0]["content"])
+	})
+}
@@ -0,0 +1,223 @@
+package threads
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/nekomeowww/fo"
+
+	"knoway.dev/api/listeners/v1alpha1"
+	"knoway.dev/pkg/filters"
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+)
+
+const (
+	HeaderThreadID = "X-Knoway-Thread-ID"
+)
+
+func ThreadIDFromHTTPRequest(request *http.Request) string {
+	if request == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(request.Header.Get(HeaderThreadID))
+}
+
+var _ filters.RequestFilter = (*Filter)(nil)
+var _ filters.OnCompletionRequestFilter = (*Filter)(nil)
+var _ filters.OnCompletionResponseFilter = (*Filter)(nil)
+var _ filters.OnCompletionStreamResponseFilter = (*Filter)(nil)
+
+// Filter assembles the history of the thread into chat completions requests
+// and saves both the incoming messages and the reply of the assistant back to
+// the thread.
+type Filter struct {
+	filters.IsRequestFilter
+
+	cfg   *v1alpha1.Threads
+	store Store
+
+	// streams holds the accumulated content of streaming responses
+	streams sync.Map
+}
+
+func NewFilter(cfg *v1alpha1.Threads, store Store) *Filter {
+	return &Filter{
+		cfg:   cfg,
+		store: store,
+	}
+}
+
+// GetOwnedThread returns the thread only if it's owned by the user of the
+// current request.
+func GetOwnedThread(ctx context.Context, store Store, threadID string) (*Thread, error) {
+	thread, err := store.GetThread(ctx, threadID)
+	if err != nil {
+		if errors.Is(err, ErrThreadNotFound) {
+			return nil, openai.NewErrorThreadNotFound(threadID)
+		}
+
+		return nil, openai.NewErrorInternalError().WithCause(err)
+	}
+
+	if thread.OwnerID != OwnerIDFromCtx(ctx) {
+		// Don't tell others that the thread exists
+		return nil, openai.NewErrorThreadNotFound(threadID)
+	}
+
+	return thread, nil
+}
+
+// OwnerIDFromCtx returns the user ID resolved by the auth filter, or empty
+// string when auth filter is not enabled.
+func OwnerIDFromCtx(ctx context.Context) string {
+	rMeta := metadata.RequestMetadataFromCtx(ctx)
+	if rMeta == nil || rMeta.AuthInfo == nil {
+		return ""
+	}
+
+	return rMeta.AuthInfo.GetUserId()
+}
+
+func (f *Filter) OnCompletionRequest(ctx context.Context, request object.LLMRequest, sourceHTTPRequest *http.Request) filters.RequestFilterResult {
+	threadID := ThreadIDFromHTTPRequest(sourceHTTPRequest)
+	if threadID == "" {
+		return filters.NewOK()
+	}
+
+	chatRequest, ok := request.(*openai.ChatCompletionsRequest)
+	if !ok {
+		return filters.NewFailed(openai.NewErrorBadRequest().WithMessage("threads are only supported by chat completions"))
+	}
+
+	_, err := GetOwnedThread(ctx, f.store, threadID)
+	if err != nil {
+		return filters.NewFailed(err)
+	}
+
+	history, err := f.store.ListMessages(ctx, threadID)
+	if err != nil {
+		return filters.NewFailed(openai.NewErrorInternalError().WithCause(err))
+	}
+
+	incoming := chatRequest.GetMessages()
+
+	err = chatRequest.SetMessages(AssembleContext(history, incoming, f.cfg.GetMaxContextMessages(), f.cfg.GetMaxContextTokens()))
+	if err != nil {
+		return filters.NewFailed(openai.NewErrorInternalError().WithCause(err))
+	}
+
+	newMessages := make([]*Message, 0, len(incoming))
+	for _, message := range incoming {
+		role, _ := message["role"].(string)
+		newMessages = append(newMessages, NewMessage(threadID, role, message["content"]))
+	}
+
+	err = f.store.AppendMessages(ctx, threadID, newMessages...)
+	if err != nil {
+		return filters.NewFailed(openai.NewErrorInternalError().WithCause(err))
+	}
+
+	slog.Debug("threads filter: assembled thread messages",
+		slog.String("thread_id", threadID),
+		slog.Int("history_messages", len(history)),
+		slog.Int("incoming_messages", len(incoming)),
+	)
+
+	return filters.NewOK()
+}
+
+func (f *Filter) OnCompletionResponse(ctx context.Context, request object.LLMRequest, response object.LLMResponse) filters.RequestFilterResult {
+	threadID := ThreadIDFromHTTPRequest(request.GetRawRequest())
+	if threadID == "" {
+		return filters.NewOK()
+	}
+
+	chatResponse, ok := response.(*openai.ChatCompletionsResponse)
+	if !ok || chatResponse.GetError() != nil {
+		return filters.NewOK()
+	}
+
+	message := chatResponse.GetChoiceMessage()
+	if message == nil {
+		return filters.NewOK()
+	}
+
+	role, _ := message["role"].(string)
+	if role == "" {
+		role = "assistant"
+	}
+
+	err := f.store.AppendMessages(ctx, threadID, NewMessage(threadID, role, message["content"]))
+	if err != nil {
+		return filters.NewFailed(err)
+	}
+
+	return filters.NewOK()
+}
+
+type streamContent struct {
+	mutex   sync.Mutex
+	builder strings.Builder
+}
+
+func (c *streamContent) Write(s string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.builder.WriteString(s)
+}
+
+func (c *streamContent) String() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.builder.String()
+}
+
+func (f *Filter) OnCompletionStreamResponse(ctx context.Context, request object.LLMRequest, response object.LLMStreamResponse, responseChunk object.LLMChunkResponse) filters.RequestFilterResult {
+	threadID := ThreadIDFromHTTPRequest(request.GetRawRequest())
+	if threadID == "" {
+		return filters.NewOK()
+	}
+
+	chunk, ok := responseChunk.(*openai.ChatCompletionStreamChunk)
+	if !ok {
+		return filters.NewOK()
+	}
+
+	value, loaded := f.streams.LoadOrStore(response, new(streamContent))
+	content, _ := value.(*streamContent)
+
+	if !loaded {
+		// Chunk callbacks are invoked with the context of the stream instead of
+		// the request, save the content once the stream is done.
+		go fo.Invoke0(context.Background(), func() error { //nolint:errcheck
+			<-response.WaitUntilEOF()
+
+			f.streams.Delete(response)
+
+			text := content.String()
+			if response.GetError() != nil || text == "" {
+				return nil
+			}
+
+			err := f.store.AppendMessages(context.Background(), threadID, NewMessage(threadID, "assistant", text))
+			if err != nil {
+				slog.Error("threads filter: failed to save streamed message", slog.String("thread_id", threadID), slog.Any("error", err))
+			}
+
+			return err
+		})
+	}
+
+	content.Write(chunk.GetDeltaContent())
+
+	return filters.NewOK()
+}
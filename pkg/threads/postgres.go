@@ -0,0 +1,224 @@
+package threads
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"knoway.dev/api/listeners/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+)
+
+const (
+	defaultPostgresTablePrefix = "knoway_"
+)
+
+var (
+	postgresTablePrefixRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+var _ Store = (*postgresStore)(nil)
+
+type postgresStore struct {
+	db            *sql.DB
+	threadsTable  string
+	messagesTable string
+}
+
+func newPostgresStore(cfg *v1alpha1.Threads_PostgresStore, lifecycle bootkit.LifeCycle) (*postgresStore, error) {
+	if cfg.GetDsn() == "" {
+		return nil, errors.New("invalid postgres dsn")
+	}
+
+	tablePrefix := cfg.GetTablePrefix()
+	if tablePrefix == "" {
+		tablePrefix = defaultPostgresTablePrefix
+	}
+
+	// Table names can't be parameterized, make sure nothing unexpected gets
+	// concatenated into queries.
+	if !postgresTablePrefixRegexp.MatchString(tablePrefix) {
+		return nil, fmt.Errorf("invalid postgres table prefix %s", tablePrefix)
+	}
+
+	db, err := sql.Open("pgx", cfg.GetDsn())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres connection: %w", err)
+	}
+
+	s := &postgresStore{
+		db:            db,
+		threadsTable:  tablePrefix + "threads",
+		messagesTable: tablePrefix + "thread_messages",
+	}
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStart: s.migrate,
+		OnStop: func(ctx context.Context) error {
+			return db.Close()
+		},
+	})
+
+	return s, nil
+}
+
+func (s *postgresStore) migrate(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`
+CREATE TABLE IF NOT EXISTS %s (
+    id         TEXT PRIMARY KEY,
+    owner_id   TEXT NOT NULL,
+    created_at BIGINT NOT NULL,
+    metadata   JSONB NOT NULL DEFAULT '{}'
+)`, s.threadsTable))
+	if err != nil {
+		return fmt.Errorf("failed to create table %s: %w", s.threadsTable, err)
+	}
+
+	_, err = s.db.ExecContext(ctx, fmt.Sprintf(`
+CREATE TABLE IF NOT EXISTS %s (
+    seq        BIGSERIAL PRIMARY KEY,
+    id         TEXT NOT NULL UNIQUE,
+    thread_id  TEXT NOT NULL REFERENCES %s (id) ON DELETE CASCADE,
+    created_at BIGINT NOT NULL,
+    role       TEXT NOT NULL,
+    content    JSONB NOT NULL
+)`, s.messagesTable, s.threadsTable))
+	if err != nil {
+		return fmt.Errorf("failed to create table %s: %w", s.messagesTable, err)
+	}
+
+	_, err = s.db.ExecContext(ctx, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_thread_id_idx ON %s (thread_id, seq)`, s.messagesTable, s.messagesTable))
+	if err != nil {
+		return fmt.Errorf("failed to create index for table %s: %w", s.messagesTable, err)
+	}
+
+	return nil
+}
+
+func (s *postgresStore) CreateThread(ctx context.Context, thread *Thread) error {
+	metadata, err := json.Marshal(thread.Metadata)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		fmt.Sprintf(`INSERT INTO %s (id, owner_id, created_at, metadata) VALUES ($1, $2, $3, $4)`, s.threadsTable), //nolint:gosec
+		thread.ID, thread.OwnerID, thread.CreatedAt, metadata,
+	)
+
+	return err
+}
+
+func (s *postgresStore) GetThread(ctx context.Context, threadID string) (*Thread, error) {
+	thread := &Thread{Object: "thread"}
+
+	var metadata []byte
+
+	err := s.db.QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT id, owner_id, created_at, metadata FROM %s WHERE id = $1`, s.threadsTable), //nolint:gosec
+		threadID,
+	).Scan(&thread.ID, &thread.OwnerID, &thread.CreatedAt, &metadata)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrThreadNotFound
+		}
+
+		return nil, err
+	}
+
+	err = json.Unmarshal(metadata, &thread.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata of thread %s: %w", threadID, err)
+	}
+
+	return thread, nil
+}
+
+func (s *postgresStore) DeleteThread(ctx context.Context, threadID string) error {
+	result, err := s.db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, s.threadsTable), threadID) //nolint:gosec
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrThreadNotFound
+	}
+
+	return nil
+}
+
+func (s *postgresStore) AppendMessages(ctx context.Context, threadID string, messages ...*Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, message := range messages {
+		content, err := json.Marshal(message.Content)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			fmt.Sprintf(`INSERT INTO %s (id, thread_id, created_at, role, content) VALUES ($1, $2, $3, $4, $5)`, s.messagesTable), //nolint:gosec
+			message.ID, threadID, message.CreatedAt, message.Role, content,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *postgresStore) ListMessages(ctx context.Context, threadID string) ([]*Message, error) {
+	rows, err := s.db.QueryContext(ctx,
+		fmt.Sprintf(`SELECT id, created_at, role, content FROM %s WHERE thread_id = $1 ORDER BY seq ASC`, s.messagesTable), //nolint:gosec
+		threadID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	messages := make([]*Message, 0)
+
+	for rows.Next() {
+		message := &Message{Object: "thread.message", ThreadID: threadID}
+
+		var content []byte
+
+		err := rows.Scan(&message.ID, &message.CreatedAt, &message.Role, &content)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(content, &message.Content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid message of thread %s: %w", threadID, err)
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
@@ -0,0 +1,188 @@
+package threads
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/redis/rueidis"
+
+	"knoway.dev/api/listeners/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+	"knoway.dev/pkg/redis"
+)
+
+const (
+	redisKeyPrefix = "knoway:threads:"
+)
+
+var _ Store = (*redisStore)(nil)
+
+type redisStore struct {
+	client rueidis.Client
+	ttl    time.Duration
+}
+
+func newRedisStore(cfg *v1alpha1.Threads_RedisStore, lifecycle bootkit.LifeCycle) (*redisStore, error) {
+	if cfg.GetUrl() == "" {
+		return nil, errors.New("invalid redis server url")
+	}
+
+	client, err := redis.NewRedisClient(cfg.GetUrl())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create redis client: %w", err)
+	}
+
+	lifecycle.Append(bootkit.LifeCycleHook{
+		OnStop: func(ctx context.Context) error {
+			client.Close()
+			return nil
+		},
+	})
+
+	return &redisStore{
+		client: client,
+		ttl:    cfg.GetTtl().AsDuration(),
+	}, nil
+}
+
+func (s *redisStore) threadKey(threadID string) string {
+	return redisKeyPrefix + threadID
+}
+
+func (s *redisStore) messagesKey(threadID string) string {
+	return redisKeyPrefix + threadID + ":messages"
+}
+
+func (s *redisStore) expire(ctx context.Context, keys ...string) {
+	if s.ttl <= 0 {
+		return
+	}
+
+	for _, key := range keys {
+		err := s.client.Do(ctx, s.client.B().Pexpire().Key(key).Milliseconds(s.ttl.Milliseconds()).Build()).Error()
+		if err != nil {
+			slog.WarnContext(ctx, "failed to set expiration for thread key", slog.String("key", key), slog.Any("error", err))
+		}
+	}
+}
+
+func (s *redisStore) CreateThread(ctx context.Context, thread *Thread) error {
+	metadata, err := json.Marshal(thread.Metadata)
+	if err != nil {
+		return err
+	}
+
+	cmd := s.client.B().Hset().Key(s.threadKey(thread.ID)).FieldValue().
+		FieldValue("id", thread.ID).
+		FieldValue("owner_id", thread.OwnerID).
+		FieldValue("created_at", strconv.FormatInt(thread.CreatedAt, 10)).
+		FieldValue("metadata", string(metadata)).
+		Build()
+
+	err = s.client.Do(ctx, cmd).Error()
+	if err != nil {
+		return err
+	}
+
+	s.expire(ctx, s.threadKey(thread.ID))
+
+	return nil
+}
+
+func (s *redisStore) GetThread(ctx context.Context, threadID string) (*Thread, error) {
+	fields, err := s.client.Do(ctx, s.client.B().Hgetall().Key(s.threadKey(threadID)).Build()).AsStrMap()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 0 {
+		return nil, ErrThreadNotFound
+	}
+
+	createdAt, err := strconv.ParseInt(fields["created_at"], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created_at of thread %s: %w", threadID, err)
+	}
+
+	metadata := make(map[string]string)
+	if fields["metadata"] != "" {
+		err = json.Unmarshal([]byte(fields["metadata"]), &metadata)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metadata of thread %s: %w", threadID, err)
+		}
+	}
+
+	return &Thread{
+		ID:        fields["id"],
+		Object:    "thread",
+		CreatedAt: createdAt,
+		Metadata:  metadata,
+		OwnerID:   fields["owner_id"],
+	}, nil
+}
+
+func (s *redisStore) DeleteThread(ctx context.Context, threadID string) error {
+	deleted, err := s.client.Do(ctx, s.client.B().Del().Key(s.threadKey(threadID), s.messagesKey(threadID)).Build()).AsInt64()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return ErrThreadNotFound
+	}
+
+	return nil
+}
+
+func (s *redisStore) AppendMessages(ctx context.Context, threadID string, messages ...*Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	elements := make([]string, 0, len(messages))
+
+	for _, message := range messages {
+		bs, err := json.Marshal(message)
+		if err != nil {
+			return err
+		}
+
+		elements = append(elements, string(bs))
+	}
+
+	err := s.client.Do(ctx, s.client.B().Rpush().Key(s.messagesKey(threadID)).Element(elements...).Build()).Error()
+	if err != nil {
+		return err
+	}
+
+	s.expire(ctx, s.threadKey(threadID), s.messagesKey(threadID))
+
+	return nil
+}
+
+func (s *redisStore) ListMessages(ctx context.Context, threadID string) ([]*Message, error) {
+	elements, err := s.client.Do(ctx, s.client.B().Lrange().Key(s.messagesKey(threadID)).Start(0).Stop(-1).Build()).AsStrSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*Message, 0, len(elements))
+
+	for _, element := range elements {
+		message := new(Message)
+
+		err := json.Unmarshal([]byte(element), message)
+		if err != nil {
+			return nil, fmt.Errorf("invalid message of thread %s: %w", threadID, err)
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
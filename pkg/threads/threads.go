@@ -0,0 +1,93 @@
+package threads
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"knoway.dev/api/listeners/v1alpha1"
+	"knoway.dev/pkg/bootkit"
+)
+
+var (
+	ErrThreadNotFound = errors.New("thread not found")
+)
+
+type Thread struct {
+	ID        string            `json:"id"`
+	Object    string            `json:"object"`
+	CreatedAt int64             `json:"created_at"`
+	Metadata  map[string]string `json:"metadata"`
+
+	// OwnerID is the user who created the thread, only the owner can access
+	// the thread and its messages.
+	OwnerID string `json:"-"`
+}
+
+func NewThread(ownerID string, metadata map[string]string) *Thread {
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+
+	return &Thread{
+		ID:        "thread_" + uuid.NewString(),
+		Object:    "thread",
+		CreatedAt: time.Now().Unix(),
+		Metadata:  metadata,
+		OwnerID:   ownerID,
+	}
+}
+
+type Message struct {
+	ID        string `json:"id"`
+	Object    string `json:"object"`
+	CreatedAt int64  `json:"created_at"`
+	ThreadID  string `json:"thread_id"`
+	Role      string `json:"role"`
+	// Content is either a string or an array of content parts, same as
+	// the content field of chat completions messages.
+	Content any `json:"content"`
+}
+
+func NewMessage(threadID string, role string, content any) *Message {
+	return &Message{
+		ID:        "msg_" + uuid.NewString(),
+		Object:    "thread.message",
+		CreatedAt: time.Now().Unix(),
+		ThreadID:  threadID,
+		Role:      role,
+		Content:   content,
+	}
+}
+
+// ToChatCompletionsMessage converts the stored message to the message object
+// of chat completions requests.
+func (m *Message) ToChatCompletionsMessage() map[string]any {
+	return map[string]any{
+		"role":    m.Role,
+		"content": m.Content,
+	}
+}
+
+type Store interface {
+	CreateThread(ctx context.Context, thread *Thread) error
+	GetThread(ctx context.Context, threadID string) (*Thread, error)
+	DeleteThread(ctx context.Context, threadID string) error
+
+	AppendMessages(ctx context.Context, threadID string, messages ...*Message) error
+	// ListMessages returns messages of the thread ordered from the oldest to the latest.
+	ListMessages(ctx context.Context, threadID string) ([]*Message, error)
+}
+
+func NewStoreWithConfig(cfg *v1alpha1.Threads, lifecycle bootkit.LifeCycle) (Store, error) {
+	switch {
+	case cfg.GetRedis() != nil:
+		return newRedisStore(cfg.GetRedis(), lifecycle)
+	case cfg.GetPostgres() != nil:
+		return newPostgresStore(cfg.GetPostgres(), lifecycle)
+	default:
+		return nil, errors.New("no store configured for threads")
+	}
+}
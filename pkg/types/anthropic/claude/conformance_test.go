@@ -0,0 +1,15 @@
+package claude
+
+import (
+	"testing"
+
+	"knoway.dev/pkg/types/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, conformance.Adapter{
+		BaseURL:           "https://api.anthropic.com/v1",
+		MarshalRequest:    MarshalRequest,
+		TranslateResponse: TranslateResponse,
+	}, "testdata/conformance")
+}
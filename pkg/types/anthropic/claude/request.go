@@ -0,0 +1,369 @@
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/utils"
+)
+
+const (
+	// APIVersion is the version of the Anthropic API requested, sent as the
+	// anthropic-version header.
+	APIVersion = "2023-06-01"
+
+	// defaultMaxTokens is the max_tokens of requests without one, which is
+	// required by the Messages API, typically overridden by the defaultParams
+	// of the cluster.
+	defaultMaxTokens = 4096
+)
+
+// MarshalRequest converts the OpenAI chat completion request into the request
+// of the Messages API of Anthropic, returns the URL and the body of it.
+//
+// The base URL is the one of the Anthropic API, such as
+// https://api.anthropic.com/v1, which serves the Messages API under /messages.
+func MarshalRequest(baseURL string, llmRequest object.LLMRequest) (string, []byte, error) {
+	if llmRequest.GetRequestType() != object.RequestTypeChatCompletions {
+		return "", nil, openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("%s requests are not supported by Anthropic", llmRequest.GetRequestType()))
+	}
+
+	jsonBody, err := json.Marshal(llmRequest)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var body map[string]any
+
+	err = json.Unmarshal(jsonBody, &body)
+	if err != nil {
+		return "", nil, err
+	}
+
+	converted, err := chatCompletionsToMessages(body)
+	if err != nil {
+		return "", nil, err
+	}
+
+	convertedBody, err := json.Marshal(converted)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return strings.TrimSuffix(baseURL, "/") + "/messages", convertedBody, nil
+}
+
+func chatCompletionsToMessages(body map[string]any) (map[string]any, error) {
+	var (
+		system   []map[string]any
+		messages []map[string]any
+	)
+
+	appendMessage := func(role string, content []map[string]any) {
+		if len(content) == 0 {
+			return
+		}
+
+		// The Messages API requires the roles of messages to alternate
+		if len(messages) > 0 && messages[len(messages)-1]["role"] == role {
+			last := messages[len(messages)-1]
+			last["content"] = append(last["content"].([]map[string]any), content...) //nolint:forcetypeassert
+
+			return
+		}
+
+		messages = append(messages, map[string]any{
+			"role":    role,
+			"content": content,
+		})
+	}
+
+	for _, message := range utils.GetByJSONPath[[]map[string]any](body, "{ .messages }") {
+		role, _ := message["role"].(string)
+
+		switch role {
+		case "system", "developer":
+			blocks, err := contentBlocks(message["content"])
+			if err != nil {
+				return nil, err
+			}
+
+			for _, block := range blocks {
+				if block["type"] != "text" {
+					return nil, openai.NewErrorBadRequest().WithMessage("only text content is supported in system messages by Anthropic")
+				}
+			}
+
+			system = append(system, blocks...)
+		case "user":
+			blocks, err := contentBlocks(message["content"])
+			if err != nil {
+				return nil, err
+			}
+
+			appendMessage("user", blocks)
+		case "assistant":
+			blocks, err := contentBlocks(message["content"])
+			if err != nil {
+				return nil, err
+			}
+
+			toolUses, err := toolUseBlocks(message["tool_calls"])
+			if err != nil {
+				return nil, err
+			}
+
+			appendMessage("assistant", append(blocks, toolUses...))
+		case "tool":
+			toolCallID, _ := message["tool_call_id"].(string)
+
+			toolResult := map[string]any{
+				"type":        "tool_result",
+				"tool_use_id": toolCallID,
+			}
+
+			if content, ok := message["content"].(string); ok {
+				toolResult["content"] = content
+			} else {
+				blocks, err := contentBlocks(message["content"])
+				if err != nil {
+					return nil, err
+				}
+
+				toolResult["content"] = blocks
+			}
+
+			appendMessage("user", []map[string]any{toolResult})
+		default:
+			return nil, openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("unsupported role %q of messages", role))
+		}
+	}
+
+	converted := map[string]any{
+		"model":      body["model"],
+		"messages":   messages,
+		"max_tokens": int64(defaultMaxTokens),
+	}
+	if len(system) > 0 {
+		converted["system"] = system
+	}
+	if stream, ok := body["stream"].(bool); ok && stream {
+		converted["stream"] = true
+	}
+
+	if maxTokens, ok := body["max_completion_tokens"].(float64); ok {
+		converted["max_tokens"] = int64(maxTokens)
+	} else if maxTokens, ok := body["max_tokens"].(float64); ok {
+		converted["max_tokens"] = int64(maxTokens)
+	}
+	if temperature, ok := body["temperature"].(float64); ok {
+		converted["temperature"] = temperature
+	}
+	if topP, ok := body["top_p"].(float64); ok {
+		converted["top_p"] = topP
+	}
+
+	switch stop := body["stop"].(type) {
+	case string:
+		converted["stop_sequences"] = []string{stop}
+	case []any:
+		converted["stop_sequences"] = stop
+	}
+
+	if user, ok := body["user"].(string); ok && user != "" {
+		converted["metadata"] = map[string]any{"user_id": user}
+	}
+
+	tools, toolChoice, err := toolsFromTools(body["tools"], body["tool_choice"], body["parallel_tool_calls"])
+	if err != nil {
+		return nil, err
+	}
+	if tools != nil {
+		converted["tools"] = tools
+	}
+	if toolChoice != nil {
+		converted["tool_choice"] = toolChoice
+	}
+
+	// Parameters specific to Anthropic are passed through as is, typically
+	// configured by the defaultParams of the cluster
+	for _, key := range []string{"top_k", "thinking", "service_tier"} {
+		if value, ok := body[key]; ok {
+			converted[key] = value
+		}
+	}
+
+	return converted, nil
+}
+
+// contentBlocks converts the content of OpenAI messages, either a string or
+// an array of parts, into the content blocks of the Messages API.
+func contentBlocks(content any) ([]map[string]any, error) {
+	switch c := content.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if c == "" {
+			return nil, nil
+		}
+
+		return []map[string]any{{"type": "text", "text": c}}, nil
+	case []any:
+		blocks := make([]map[string]any, 0, len(c))
+
+		for _, part := range c {
+			p, ok := part.(map[string]any)
+			if !ok {
+				return nil, openai.NewErrorBadRequest().WithMessage("invalid content part of messages")
+			}
+
+			switch p["type"] {
+			case "text":
+				text, _ := p["text"].(string)
+				blocks = append(blocks, map[string]any{"type": "text", "text": text})
+			case "image_url":
+				block, err := imageBlock(utils.GetByJSONPath[string](p, "{ .image_url.url }"))
+				if err != nil {
+					return nil, err
+				}
+
+				blocks = append(blocks, block)
+			default:
+				return nil, openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("content parts of type %v are not supported by Anthropic", p["type"]))
+			}
+		}
+
+		return blocks, nil
+	default:
+		return nil, openai.NewErrorBadRequest().WithMessage("invalid content of messages")
+	}
+}
+
+// imageBlock converts data URLs of images into base64 sources, and other URLs
+// into url sources.
+func imageBlock(imageURL string) (map[string]any, error) {
+	if !strings.HasPrefix(imageURL, "data:") {
+		if !strings.HasPrefix(imageURL, "https://") && !strings.HasPrefix(imageURL, "http://") {
+			return nil, openai.NewErrorBadRequest().WithMessage("only images of base64 data URLs or HTTP URLs are supported by Anthropic")
+		}
+
+		return map[string]any{
+			"type": "image",
+			"source": map[string]any{
+				"type": "url",
+				"url":  imageURL,
+			},
+		}, nil
+	}
+
+	mediaType, data, ok := strings.Cut(strings.TrimPrefix(imageURL, "data:"), ";base64,")
+	if !ok {
+		return nil, openai.NewErrorBadRequest().WithMessage("only images of base64 data URLs or HTTP URLs are supported by Anthropic")
+	}
+
+	return map[string]any{
+		"type": "image",
+		"source": map[string]any{
+			"type":       "base64",
+			"media_type": mediaType,
+			"data":       data,
+		},
+	}, nil
+}
+
+func toolUseBlocks(toolCalls any) ([]map[string]any, error) {
+	calls, _ := toolCalls.([]any)
+	blocks := make([]map[string]any, 0, len(calls))
+
+	for _, call := range calls {
+		c, ok := call.(map[string]any)
+		if !ok {
+			return nil, openai.NewErrorBadRequest().WithMessage("invalid tool_calls of messages")
+		}
+
+		input := map[string]any{}
+
+		arguments := utils.GetByJSONPath[string](c, "{ .function.arguments }")
+		if arguments != "" {
+			err := json.Unmarshal([]byte(arguments), &input)
+			if err != nil {
+				return nil, openai.NewErrorBadRequest().WithMessage("arguments of tool_calls must be JSON objects")
+			}
+		}
+
+		blocks = append(blocks, map[string]any{
+			"type":  "tool_use",
+			"id":    c["id"],
+			"name":  utils.GetByJSONPath[string](c, "{ .function.name }"),
+			"input": input,
+		})
+	}
+
+	return blocks, nil
+}
+
+func toolsFromTools(tools any, toolChoice any, parallelToolCalls any) ([]map[string]any, map[string]any, error) {
+	ts, _ := tools.([]any)
+	if len(ts) == 0 {
+		return nil, nil, nil
+	}
+
+	converted := make([]map[string]any, 0, len(ts))
+
+	for i, tool := range ts {
+		t, ok := tool.(map[string]any)
+		if !ok || t["type"] != "function" {
+			return nil, nil, openai.NewErrorInvalidValue(fmt.Sprintf("tools[%d].type", i), "only tools of type function are supported by Anthropic.")
+		}
+
+		parameters := utils.GetByJSONPath[map[string]any](t, "{ .function.parameters }")
+		if len(parameters) == 0 {
+			parameters = map[string]any{"type": "object"}
+		}
+
+		declaration := map[string]any{
+			"name":         utils.GetByJSONPath[string](t, "{ .function.name }"),
+			"input_schema": parameters,
+		}
+
+		if description := utils.GetByJSONPath[string](t, "{ .function.description }"); description != "" {
+			declaration["description"] = description
+		}
+
+		converted = append(converted, declaration)
+	}
+
+	var choice map[string]any
+
+	switch c := toolChoice.(type) {
+	case string:
+		switch c {
+		case "none":
+			choice = map[string]any{"type": "none"}
+		case "required":
+			choice = map[string]any{"type": "any"}
+		default:
+			choice = map[string]any{"type": "auto"}
+		}
+	case map[string]any:
+		choice = map[string]any{
+			"type": "tool",
+			"name": utils.GetByJSONPath[string](c, "{ .function.name }"),
+		}
+	}
+
+	if parallel, ok := parallelToolCalls.(bool); ok && !parallel {
+		if choice == nil {
+			choice = map[string]any{"type": "auto"}
+		}
+
+		if choice["type"] != "none" {
+			choice["disable_parallel_tool_use"] = true
+		}
+	}
+
+	return converted, choice, nil
+}
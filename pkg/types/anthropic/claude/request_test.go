@@ -0,0 +1,100 @@
+package claude
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/internal/gatewaytest"
+)
+
+func TestMarshalRequest(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{
+		"model": "claude-sonnet-4-5",
+		"messages": [
+			{"role": "system", "content": "You are a helpful assistant."},
+			{"role": "user", "content": [
+				{"type": "text", "text": "What is in the image?"},
+				{"type": "image_url", "image_url": {"url": "data:image/png;base64,aGVsbG8="}},
+				{"type": "image_url", "image_url": {"url": "https://example.com/cat.jpg"}}
+			]},
+			{"role": "assistant", "content": "Let me check.", "tool_calls": [
+				{"id": "toolu_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\":\"Paris\"}"}}
+			]},
+			{"role": "tool", "tool_call_id": "toolu_1", "content": "Sunny"},
+			{"role": "user", "content": "Thanks"}
+		],
+		"max_completion_tokens": 256,
+		"temperature": 0.5,
+		"stop": "END",
+		"user": "alice",
+		"tools": [
+			{"type": "function", "function": {"name": "get_weather", "description": "Get the weather", "parameters": {"type": "object", "properties": {"city": {"type": "string"}}}}}
+		],
+		"tool_choice": "required",
+		"parallel_tool_calls": false,
+		"top_k": 5
+	}`)
+
+	upstreamURL, body, err := MarshalRequest("https://api.anthropic.com/v1/", request)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://api.anthropic.com/v1/messages", upstreamURL)
+	assert.JSONEq(t, `{
+		"model": "claude-sonnet-4-5",
+		"system": [{"type": "text", "text": "You are a helpful assistant."}],
+		"messages": [
+			{"role": "user", "content": [
+				{"type": "text", "text": "What is in the image?"},
+				{"type": "image", "source": {"type": "base64", "media_type": "image/png", "data": "aGVsbG8="}},
+				{"type": "image", "source": {"type": "url", "url": "https://example.com/cat.jpg"}}
+			]},
+			{"role": "assistant", "content": [
+				{"type": "text", "text": "Let me check."},
+				{"type": "tool_use", "id": "toolu_1", "name": "get_weather", "input": {"city": "Paris"}}
+			]},
+			{"role": "user", "content": [
+				{"type": "tool_result", "tool_use_id": "toolu_1", "content": "Sunny"},
+				{"type": "text", "text": "Thanks"}
+			]}
+		],
+		"max_tokens": 256,
+		"temperature": 0.5,
+		"stop_sequences": ["END"],
+		"metadata": {"user_id": "alice"},
+		"tools": [
+			{"name": "get_weather", "description": "Get the weather", "input_schema": {"type": "object", "properties": {"city": {"type": "string"}}}}
+		],
+		"tool_choice": {"type": "any", "disable_parallel_tool_use": true},
+		"top_k": 5
+	}`, string(body))
+}
+
+func TestMarshalRequestStream(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "claude-sonnet-4-5", "stream": true, "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	upstreamURL, body, err := MarshalRequest("https://api.anthropic.com/v1", request)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://api.anthropic.com/v1/messages", upstreamURL)
+	assert.JSONEq(t, `{
+		"model": "claude-sonnet-4-5",
+		"stream": true,
+		"max_tokens": 4096,
+		"messages": [{"role": "user", "content": [{"type": "text", "text": "Hi"}]}]
+	}`, string(body))
+}
+
+func TestMarshalRequestUnsupported(t *testing.T) {
+	for _, body := range []string{
+		`{"model": "claude-sonnet-4-5", "messages": [{"role": "system", "content": [{"type": "image_url", "image_url": {"url": "data:image/png;base64,aGVsbG8="}}]}]}`,
+		`{"model": "claude-sonnet-4-5", "messages": [{"role": "user", "content": [{"type": "image_url", "image_url": {"url": "gs://bucket/cat.jpg"}}]}]}`,
+		`{"model": "claude-sonnet-4-5", "messages": [{"role": "user", "content": "Hi"}], "tools": [{"type": "web_search"}]}`,
+	} {
+		_, request := gatewaytest.NewChatCompletionRequest(t, body)
+
+		_, _, err := MarshalRequest("https://api.anthropic.com/v1", request)
+		require.Error(t, err)
+	}
+}
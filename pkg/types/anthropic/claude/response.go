@@ -0,0 +1,440 @@
+package claude
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/utils"
+)
+
+// TranslateResponse translates the response of the Messages API into the one
+// of OpenAI, so that it can be unmarshalled as the response of OpenAI,
+// server-sent events of streaming messages are translated on the fly.
+func TranslateResponse(request object.LLMRequest, response *http.Response, reader *bufio.Reader) (*http.Response, *bufio.Reader, error) {
+	if request.GetRequestType() != object.RequestTypeChatCompletions {
+		return response, reader, nil
+	}
+
+	translated := new(http.Response)
+	*translated = *response
+	translated.Header = response.Header.Clone()
+	translated.ContentLength = -1
+	translated.Header.Del("Content-Length")
+
+	if response.StatusCode < http.StatusBadRequest && strings.HasPrefix(response.Header.Get("Content-Type"), "text/event-stream") {
+		pipeReader, pipeWriter := io.Pipe()
+
+		go translateStream(request.GetModel(), reader, response.Body, pipeWriter)
+
+		translated.Body = pipeReader
+
+		return translated, bufio.NewReader(pipeReader), nil
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	_ = response.Body.Close()
+
+	var converted []byte
+
+	if response.StatusCode >= http.StatusBadRequest {
+		converted = translateError(response.StatusCode, body)
+	} else {
+		converted, err = translateMessage(request.GetModel(), body)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	translated.Header.Set("Content-Type", "application/json")
+	translated.Body = io.NopCloser(bytes.NewReader(converted))
+	translated.ContentLength = int64(len(converted))
+
+	return translated, bufio.NewReader(bytes.NewReader(converted)), nil
+}
+
+// translateError translates the errors of the Anthropic API into the error
+// envelope of OpenAI.
+func translateError(status int, body []byte) []byte {
+	var parsed any
+
+	_ = json.Unmarshal(body, &parsed)
+
+	message := lo.CoalesceOrEmpty(
+		utils.GetByJSONPath[string](parsed, "{ .error.message }"),
+		fmt.Sprintf("upstream returned status code %d", status),
+	)
+
+	return lo.Must(json.Marshal(map[string]any{
+		"error": openAIError(status, utils.GetByJSONPath[string](parsed, "{ .error.type }"), message),
+	}))
+}
+
+func openAIError(status int, errorType string, message string) map[string]any {
+	typ := "invalid_request_error"
+	code := lo.EmptyableToPtr(errorType)
+
+	switch {
+	case status == http.StatusUnauthorized || errorType == "authentication_error":
+		code = lo.ToPtr("invalid_api_key")
+	case status == http.StatusNotFound || errorType == "not_found_error":
+		code = lo.ToPtr("model_not_found")
+	case status == http.StatusTooManyRequests || errorType == "rate_limit_error":
+		typ = "requests"
+		code = lo.ToPtr("rate_limit_exceeded")
+	case status >= http.StatusInternalServerError || errorType == "overloaded_error" || errorType == "api_error":
+		typ = "server_error"
+	}
+
+	return map[string]any{
+		"message": message,
+		"type":    typ,
+		"code":    code,
+		"param":   nil,
+	}
+}
+
+func finishReason(reason string) string {
+	switch reason {
+	case "max_tokens", "model_context_window_exceeded":
+		return "length"
+	case "tool_use":
+		return "tool_calls"
+	case "refusal":
+		return "content_filter"
+	default:
+		return "stop"
+	}
+}
+
+type messageUsage struct {
+	InputTokens              int64 `json:"input_tokens"`
+	OutputTokens             int64 `json:"output_tokens"`
+	CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+}
+
+// openAIUsage converts the usage of Anthropic, the input tokens written to
+// and read from the prompt cache are counted as prompt tokens as they are
+// excluded from the input tokens.
+func (u messageUsage) openAIUsage() map[string]any {
+	promptTokens := u.InputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens
+
+	usage := map[string]any{
+		"prompt_tokens":     promptTokens,
+		"completion_tokens": u.OutputTokens,
+		"total_tokens":      promptTokens + u.OutputTokens,
+	}
+
+	if u.CacheReadInputTokens > 0 {
+		usage["prompt_tokens_details"] = map[string]any{
+			"cached_tokens": u.CacheReadInputTokens,
+		}
+	}
+
+	return usage
+}
+
+type contentBlock struct {
+	Type  string         `json:"type"`
+	Text  string         `json:"text"`
+	ID    string         `json:"id"`
+	Name  string         `json:"name"`
+	Input map[string]any `json:"input"`
+}
+
+type message struct {
+	ID         string         `json:"id"`
+	Model      string         `json:"model"`
+	Content    []contentBlock `json:"content"`
+	StopReason string         `json:"stop_reason"`
+	Usage      messageUsage   `json:"usage"`
+}
+
+func chatCompletionID(id string) string {
+	return "chatcmpl-" + id
+}
+
+// translateMessage translates the message into the chat completion of OpenAI,
+// thinking blocks are left out.
+func translateMessage(model string, body []byte) ([]byte, error) {
+	var parsed message
+
+	err := json.Unmarshal(body, &parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal message: %w", err)
+	}
+
+	var (
+		content   strings.Builder
+		toolCalls []map[string]any
+	)
+
+	for _, block := range parsed.Content {
+		switch block.Type {
+		case "text":
+			content.WriteString(block.Text)
+		case "tool_use":
+			arguments, err := json.Marshal(lo.CoalesceMapOrEmpty(block.Input))
+			if err != nil {
+				return nil, err
+			}
+
+			toolCalls = append(toolCalls, map[string]any{
+				"id":   block.ID,
+				"type": "function",
+				"function": map[string]any{
+					"name":      block.Name,
+					"arguments": string(arguments),
+				},
+			})
+		}
+	}
+
+	msg := map[string]any{
+		"role":    "assistant",
+		"content": content.String(),
+	}
+	if len(toolCalls) > 0 {
+		msg["tool_calls"] = toolCalls
+	}
+
+	return json.Marshal(map[string]any{
+		"id":      chatCompletionID(parsed.ID),
+		"object":  "chat.completion",
+		"created": time.Now().Unix(),
+		"model":   lo.CoalesceOrEmpty(parsed.Model, model),
+		"choices": []map[string]any{
+			{
+				"index":         0,
+				"message":       msg,
+				"finish_reason": finishReason(parsed.StopReason),
+			},
+		},
+		"usage": parsed.Usage.openAIUsage(),
+	})
+}
+
+type streamEvent struct {
+	Type         string        `json:"type"`
+	Message      *message      `json:"message"`
+	Index        int           `json:"index"`
+	ContentBlock *contentBlock `json:"content_block"`
+	Delta        struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		PartialJSON string `json:"partial_json"`
+		StopReason  string `json:"stop_reason"`
+	} `json:"delta"`
+	Usage *messageUsage `json:"usage"`
+	Error *struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// streamTranslator translates the events of streaming messages into the
+// chunks of OpenAI.
+type streamTranslator struct {
+	id      string
+	model   string
+	created int64
+
+	// toolCalls maps the indexes of tool_use blocks to the indexes of tool
+	// calls
+	toolCalls map[int]int
+	usage     messageUsage
+}
+
+func (t *streamTranslator) chunk(delta map[string]any, reason *string) map[string]any {
+	return map[string]any{
+		"id":      t.id,
+		"object":  "chat.completion.chunk",
+		"created": t.created,
+		"model":   t.model,
+		"choices": []map[string]any{
+			{
+				"index":         0,
+				"delta":         delta,
+				"finish_reason": reason,
+			},
+		},
+	}
+}
+
+// translate translates the event into the chunk, returns nil for events
+// without counterparts, such as pings.
+func (t *streamTranslator) translate(data []byte) (map[string]any, error) {
+	var event streamEvent
+
+	err := json.Unmarshal(data, &event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal message event: %w", err)
+	}
+
+	switch event.Type {
+	case "message_start":
+		if event.Message != nil {
+			t.id = chatCompletionID(event.Message.ID)
+			t.model = lo.CoalesceOrEmpty(event.Message.Model, t.model)
+			t.usage = event.Message.Usage
+		}
+
+		return t.chunk(map[string]any{"role": "assistant", "content": ""}, nil), nil
+	case "content_block_start":
+		if event.ContentBlock == nil || event.ContentBlock.Type != "tool_use" {
+			return nil, nil
+		}
+
+		index := len(t.toolCalls)
+		t.toolCalls[event.Index] = index
+
+		return t.chunk(map[string]any{
+			"tool_calls": []map[string]any{
+				{
+					"index": index,
+					"id":    event.ContentBlock.ID,
+					"type":  "function",
+					"function": map[string]any{
+						"name":      event.ContentBlock.Name,
+						"arguments": "",
+					},
+				},
+			},
+		}, nil), nil
+	case "content_block_delta":
+		switch event.Delta.Type {
+		case "text_delta":
+			return t.chunk(map[string]any{"content": event.Delta.Text}, nil), nil
+		case "input_json_delta":
+			index, ok := t.toolCalls[event.Index]
+			if !ok || event.Delta.PartialJSON == "" {
+				return nil, nil
+			}
+
+			return t.chunk(map[string]any{
+				"tool_calls": []map[string]any{
+					{
+						"index": index,
+						"function": map[string]any{
+							"arguments": event.Delta.PartialJSON,
+						},
+					},
+				},
+			}, nil), nil
+		default:
+			return nil, nil
+		}
+	case "message_delta":
+		// The usage of message_delta is cumulative
+		if event.Usage != nil {
+			t.usage.OutputTokens = event.Usage.OutputTokens
+			t.usage.InputTokens = max(t.usage.InputTokens, event.Usage.InputTokens)
+			t.usage.CacheCreationInputTokens = max(t.usage.CacheCreationInputTokens, event.Usage.CacheCreationInputTokens)
+			t.usage.CacheReadInputTokens = max(t.usage.CacheReadInputTokens, event.Usage.CacheReadInputTokens)
+		}
+
+		if event.Delta.StopReason == "" {
+			return nil, nil
+		}
+
+		return t.chunk(map[string]any{}, lo.ToPtr(finishReason(event.Delta.StopReason))), nil
+	case "error":
+		if event.Error == nil {
+			return nil, errors.New("unknown error of message stream")
+		}
+
+		return map[string]any{
+			"error": openAIError(0, event.Error.Type, event.Error.Message),
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (t *streamTranslator) usageChunk() map[string]any {
+	chunk := t.chunk(nil, nil)
+	chunk["choices"] = []map[string]any{}
+	chunk["usage"] = t.usage.openAIUsage()
+
+	return chunk
+}
+
+func translateStream(model string, reader *bufio.Reader, body io.Closer, writer *io.PipeWriter) {
+	defer func() {
+		_ = body.Close()
+	}()
+
+	translator := &streamTranslator{
+		id:        "chatcmpl-",
+		model:     model,
+		created:   time.Now().Unix(),
+		toolCalls: make(map[int]int),
+	}
+
+	writeChunk := func(chunk map[string]any) error {
+		data, err := json.Marshal(chunk)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(writer, "data: %s\n\n", data)
+
+		return err
+	}
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			slog.Error("failed to read anthropic stream", slog.Any("error", err))
+			_ = writer.CloseWithError(err)
+
+			return
+		}
+
+		// The type of events is in the data as well, event lines are skipped
+		if data, ok := bytes.CutPrefix(bytes.TrimSpace(line), []byte("data:")); ok {
+			chunk, translateErr := translator.translate(bytes.TrimSpace(data))
+			if translateErr != nil {
+				_ = writer.CloseWithError(translateErr)
+				return
+			}
+
+			// The reader is closed by downstream
+			if chunk != nil && writeChunk(chunk) != nil {
+				return
+			}
+
+			// Errors end the stream
+			if chunk != nil && chunk["error"] != nil {
+				_ = writer.Close()
+				return
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+
+	if writeChunk(translator.usageChunk()) != nil {
+		return
+	}
+
+	_, _ = fmt.Fprint(writer, "data: [DONE]\n\n")
+	_ = writer.Close()
+}
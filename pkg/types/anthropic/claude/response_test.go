@@ -0,0 +1,128 @@
+package claude
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/internal/gatewaytest"
+)
+
+func newResponse(status int, contentType string, body string) (*http.Response, *bufio.Reader) {
+	response := &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{contentType}},
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	return response, bufio.NewReader(response.Body)
+}
+
+func TestTranslateResponse(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "claude-sonnet-4-5", "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	response, reader := newResponse(http.StatusOK, "application/json", `{
+		"id": "msg_1",
+		"type": "message",
+		"role": "assistant",
+		"model": "claude-sonnet-4-5-20250929",
+		"content": [
+			{"type": "thinking", "thinking": "hmm", "signature": "sig"},
+			{"type": "text", "text": "Let me check."},
+			{"type": "tool_use", "id": "toolu_1", "name": "get_weather", "input": {"city": "Paris"}}
+		],
+		"stop_reason": "tool_use",
+		"usage": {"input_tokens": 10, "output_tokens": 5, "cache_read_input_tokens": 20}
+	}`)
+
+	translated, translatedReader, err := TranslateResponse(request, response, reader)
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", translated.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(translatedReader)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), `"id":"chatcmpl-msg_1"`)
+	assert.Contains(t, string(body), `"model":"claude-sonnet-4-5-20250929"`)
+	assert.Contains(t, string(body), `"finish_reason":"tool_calls"`)
+	assert.Contains(t, string(body), `"content":"Let me check."`)
+	assert.Contains(t, string(body), `"tool_calls":[{"function":{"arguments":"{\"city\":\"Paris\"}","name":"get_weather"},"id":"toolu_1","type":"function"}]`)
+	assert.Contains(t, string(body), `"usage":{"completion_tokens":5,"prompt_tokens":30,"prompt_tokens_details":{"cached_tokens":20},"total_tokens":35}`)
+}
+
+func TestTranslateResponseError(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "claude-sonnet-4-5", "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	response, reader := newResponse(http.StatusTooManyRequests, "application/json", `{"type": "error", "error": {"type": "rate_limit_error", "message": "Number of requests has exceeded your rate limit"}}`)
+
+	translated, translatedReader, err := TranslateResponse(request, response, reader)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, translated.StatusCode)
+
+	body, err := io.ReadAll(translatedReader)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"error": {"message": "Number of requests has exceeded your rate limit", "type": "requests", "code": "rate_limit_exceeded", "param": null}}`, string(body))
+}
+
+func TestTranslateResponseStream(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "claude-sonnet-4-5", "stream": true, "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	response, reader := newResponse(http.StatusOK, "text/event-stream", strings.Join([]string{
+		"event: message_start\ndata: {\"type\": \"message_start\", \"message\": {\"id\": \"msg_1\", \"model\": \"claude-sonnet-4-5-20250929\", \"content\": [], \"usage\": {\"input_tokens\": 10, \"output_tokens\": 1}}}",
+		"event: content_block_start\ndata: {\"type\": \"content_block_start\", \"index\": 0, \"content_block\": {\"type\": \"text\", \"text\": \"\"}}",
+		"event: ping\ndata: {\"type\": \"ping\"}",
+		"event: content_block_delta\ndata: {\"type\": \"content_block_delta\", \"index\": 0, \"delta\": {\"type\": \"text_delta\", \"text\": \"Hello\"}}",
+		"event: content_block_stop\ndata: {\"type\": \"content_block_stop\", \"index\": 0}",
+		"event: content_block_start\ndata: {\"type\": \"content_block_start\", \"index\": 1, \"content_block\": {\"type\": \"tool_use\", \"id\": \"toolu_1\", \"name\": \"get_weather\", \"input\": {}}}",
+		"event: content_block_delta\ndata: {\"type\": \"content_block_delta\", \"index\": 1, \"delta\": {\"type\": \"input_json_delta\", \"partial_json\": \"{\\\"city\\\": \\\"Paris\\\"}\"}}",
+		"event: content_block_stop\ndata: {\"type\": \"content_block_stop\", \"index\": 1}",
+		"event: message_delta\ndata: {\"type\": \"message_delta\", \"delta\": {\"stop_reason\": \"tool_use\"}, \"usage\": {\"output_tokens\": 15}}",
+		"event: message_stop\ndata: {\"type\": \"message_stop\"}",
+	}, "\n\n")+"\n\n")
+
+	_, translatedReader, err := TranslateResponse(request, response, reader)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(translatedReader)
+	require.NoError(t, err)
+
+	events := strings.Split(strings.TrimSuffix(string(body), "\n\n"), "\n\n")
+	require.Len(t, events, 7)
+
+	assert.Contains(t, events[0], `"delta":{"content":"","role":"assistant"}`)
+	assert.Contains(t, events[0], `"id":"chatcmpl-msg_1"`)
+	assert.Contains(t, events[0], `"model":"claude-sonnet-4-5-20250929"`)
+	assert.Contains(t, events[1], `"delta":{"content":"Hello"}`)
+	assert.Contains(t, events[2], `"tool_calls":[{"function":{"arguments":"","name":"get_weather"},"id":"toolu_1","index":0,"type":"function"}]`)
+	assert.Contains(t, events[3], `"tool_calls":[{"function":{"arguments":"{\"city\": \"Paris\"}"},"index":0}]`)
+	assert.Contains(t, events[4], `"finish_reason":"tool_calls"`)
+	assert.Contains(t, events[5], `"choices":[]`)
+	assert.Contains(t, events[5], `"usage":{"completion_tokens":15,"prompt_tokens":10,"total_tokens":25}`)
+	assert.Equal(t, "data: [DONE]", events[6])
+}
+
+func TestTranslateResponseStreamError(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "claude-sonnet-4-5", "stream": true, "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	response, reader := newResponse(http.StatusOK, "text/event-stream", strings.Join([]string{
+		"event: message_start\ndata: {\"type\": \"message_start\", \"message\": {\"id\": \"msg_1\", \"usage\": {\"input_tokens\": 10}}}",
+		"event: error\ndata: {\"type\": \"error\", \"error\": {\"type\": \"overloaded_error\", \"message\": \"Overloaded\"}}",
+	}, "\n\n")+"\n\n")
+
+	_, translatedReader, err := TranslateResponse(request, response, reader)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(translatedReader)
+	require.NoError(t, err)
+
+	events := strings.Split(strings.TrimSuffix(string(body), "\n\n"), "\n\n")
+	require.Len(t, events, 2)
+	assert.JSONEq(t, `{"error": {"message": "Overloaded", "type": "server_error", "code": "overloaded_error", "param": null}}`, strings.TrimPrefix(events[1], "data: "))
+}
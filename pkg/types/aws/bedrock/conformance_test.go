@@ -0,0 +1,51 @@
+package bedrock
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"knoway.dev/pkg/types/conformance"
+)
+
+// encodeEventStream encodes the event streams kept as JSON lines of headers
+// and payloads in the fixtures.
+func encodeEventStream(contentType string, body []byte) ([]byte, error) {
+	if !strings.HasPrefix(contentType, ContentTypeEventStream) {
+		return body, nil
+	}
+
+	var stream bytes.Buffer
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var message struct {
+			Headers map[string]string `json:"headers"`
+			Payload json.RawMessage   `json:"payload"`
+		}
+
+		err := json.Unmarshal(scanner.Bytes(), &message)
+		if err != nil {
+			return nil, err
+		}
+
+		stream.Write(encodeEventStreamMessage(message.Headers, message.Payload))
+	}
+
+	return stream.Bytes(), scanner.Err()
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, conformance.Adapter{
+		BaseURL:           "https://bedrock-runtime.us-east-1.amazonaws.com",
+		MarshalRequest:    MarshalRequest,
+		TranslateResponse: TranslateResponse,
+		EncodeBody:        encodeEventStream,
+	}, "testdata/conformance")
+}
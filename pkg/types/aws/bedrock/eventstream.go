@@ -0,0 +1,130 @@
+package bedrock
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+const (
+	eventStreamPreludeLength = 12
+	eventStreamCRCLength     = 4
+	// maxEventStreamMessageLength is the limit of messages of AWS event streams
+	maxEventStreamMessageLength = 16 * 1024 * 1024
+)
+
+// eventStreamMessage is a message of the application/vnd.amazon.eventstream
+// encoding used by ConverseStream, only string headers are kept.
+//
+// See https://docs.aws.amazon.com/transcribe/latest/dg/streaming-setting-up.html#streaming-event-stream
+type eventStreamMessage struct {
+	Headers map[string]string
+	Payload []byte
+}
+
+// readEventStreamMessage reads the next message, returns io.EOF when the
+// stream ends between messages.
+func readEventStreamMessage(reader io.Reader) (*eventStreamMessage, error) {
+	prelude := make([]byte, eventStreamPreludeLength)
+
+	_, err := io.ReadFull(reader, prelude)
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("truncated event stream prelude: %w", err)
+		}
+
+		return nil, err
+	}
+
+	totalLength := binary.BigEndian.Uint32(prelude[0:4])
+	headersLength := binary.BigEndian.Uint32(prelude[4:8])
+
+	if crc32.ChecksumIEEE(prelude[0:8]) != binary.BigEndian.Uint32(prelude[8:12]) {
+		return nil, errors.New("event stream prelude checksum mismatch")
+	}
+
+	if totalLength > maxEventStreamMessageLength ||
+		totalLength < eventStreamPreludeLength+eventStreamCRCLength+headersLength {
+		return nil, fmt.Errorf("invalid event stream message length %d", totalLength)
+	}
+
+	rest := make([]byte, totalLength-eventStreamPreludeLength)
+
+	_, err = io.ReadFull(reader, rest)
+	if err != nil {
+		return nil, fmt.Errorf("truncated event stream message: %w", err)
+	}
+
+	messageCRC := crc32.NewIEEE()
+	_, _ = messageCRC.Write(prelude)
+	_, _ = messageCRC.Write(rest[:len(rest)-eventStreamCRCLength])
+
+	if messageCRC.Sum32() != binary.BigEndian.Uint32(rest[len(rest)-eventStreamCRCLength:]) {
+		return nil, errors.New("event stream message checksum mismatch")
+	}
+
+	headers, err := parseEventStreamHeaders(rest[:headersLength])
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventStreamMessage{
+		Headers: headers,
+		Payload: rest[headersLength : len(rest)-eventStreamCRCLength],
+	}, nil
+}
+
+func parseEventStreamHeaders(bs []byte) (map[string]string, error) {
+	headers := make(map[string]string)
+
+	for len(bs) > 0 {
+		nameLength := int(bs[0])
+		if len(bs) < 1+nameLength+1 {
+			return nil, errors.New("truncated event stream header")
+		}
+
+		name := string(bs[1 : 1+nameLength])
+		valueType := bs[1+nameLength]
+		bs = bs[1+nameLength+1:]
+
+		var valueLength int
+
+		switch valueType {
+		case 0, 1: // true, false
+			valueLength = 0
+		case 2: // byte
+			valueLength = 1
+		case 3: // short
+			valueLength = 2
+		case 4: // integer
+			valueLength = 4
+		case 5, 8: // long, timestamp
+			valueLength = 8
+		case 9: // uuid
+			valueLength = 16
+		case 6, 7: // byte array, string
+			if len(bs) < 2 {
+				return nil, errors.New("truncated event stream header")
+			}
+
+			valueLength = int(binary.BigEndian.Uint16(bs[0:2]))
+			bs = bs[2:]
+		default:
+			return nil, fmt.Errorf("unknown event stream header value type %d", valueType)
+		}
+
+		if len(bs) < valueLength {
+			return nil, errors.New("truncated event stream header")
+		}
+
+		if valueType == 7 {
+			headers[name] = string(bs[:valueLength])
+		}
+
+		bs = bs[valueLength:]
+	}
+
+	return headers, nil
+}
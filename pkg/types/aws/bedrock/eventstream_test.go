@@ -0,0 +1,74 @@
+package bedrock
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeEventStreamMessage encodes the message with string headers only.
+func encodeEventStreamMessage(headers map[string]string, payload []byte) []byte {
+	var headersBuf bytes.Buffer
+
+	for name, value := range headers {
+		headersBuf.WriteByte(byte(len(name)))
+		headersBuf.WriteString(name)
+		headersBuf.WriteByte(7)
+		_ = binary.Write(&headersBuf, binary.BigEndian, uint16(len(value))) //nolint:gosec
+		headersBuf.WriteString(value)
+	}
+
+	totalLength := eventStreamPreludeLength + headersBuf.Len() + len(payload) + eventStreamCRCLength
+
+	message := make([]byte, 0, totalLength)
+	message = binary.BigEndian.AppendUint32(message, uint32(totalLength))      //nolint:gosec
+	message = binary.BigEndian.AppendUint32(message, uint32(headersBuf.Len())) //nolint:gosec
+	message = binary.BigEndian.AppendUint32(message, crc32.ChecksumIEEE(message))
+	message = append(message, headersBuf.Bytes()...)
+	message = append(message, payload...)
+	message = binary.BigEndian.AppendUint32(message, crc32.ChecksumIEEE(message))
+
+	return message
+}
+
+func TestReadEventStreamMessage(t *testing.T) {
+	var stream bytes.Buffer
+
+	stream.Write(encodeEventStreamMessage(map[string]string{
+		":event-type":   "contentBlockDelta",
+		":message-type": "event",
+	}, []byte(`{"delta":{"text":"Hello"}}`)))
+	stream.Write(encodeEventStreamMessage(map[string]string{
+		":event-type": "messageStop",
+	}, []byte(`{"stopReason":"end_turn"}`)))
+
+	message, err := readEventStreamMessage(&stream)
+	require.NoError(t, err)
+	assert.Equal(t, "contentBlockDelta", message.Headers[":event-type"])
+	assert.Equal(t, "event", message.Headers[":message-type"])
+	assert.JSONEq(t, `{"delta":{"text":"Hello"}}`, string(message.Payload))
+
+	message, err = readEventStreamMessage(&stream)
+	require.NoError(t, err)
+	assert.Equal(t, "messageStop", message.Headers[":event-type"])
+
+	_, err = readEventStreamMessage(&stream)
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestReadEventStreamMessageChecksumMismatch(t *testing.T) {
+	message := encodeEventStreamMessage(map[string]string{":event-type": "metadata"}, []byte(`{}`))
+	message[len(message)-6] ^= 0xff
+
+	_, err := readEventStreamMessage(bytes.NewReader(message))
+	require.Error(t, err)
+
+	_, err = readEventStreamMessage(bytes.NewReader(message[:eventStreamPreludeLength+2]))
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, io.EOF)
+}
@@ -0,0 +1,370 @@
+package bedrock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/utils"
+)
+
+// MarshalRequest converts the OpenAI request into the Bedrock Runtime request,
+// returns the URL and the body of it.
+//
+// Chat completions are sent to the Converse (or ConverseStream) API, which
+// unifies the requests of all models, embeddings are sent to the InvokeModel
+// API in the format of Amazon Titan Text Embeddings. The model ID is the
+// model of the request, which is the name of the cluster unless overridden
+// by the params of the cluster.
+func MarshalRequest(baseURL string, llmRequest object.LLMRequest) (string, []byte, error) {
+	jsonBody, err := json.Marshal(llmRequest)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var body map[string]any
+
+	err = json.Unmarshal(jsonBody, &body)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		operation string
+		converted map[string]any
+	)
+
+	switch llmRequest.GetRequestType() { //nolint:exhaustive
+	case object.RequestTypeChatCompletions:
+		operation = lo.Ternary(llmRequest.IsStream(), "converse-stream", "converse")
+
+		converted, err = chatCompletionsToConverse(body)
+		if err != nil {
+			return "", nil, err
+		}
+	case object.RequestTypeEmbeddings:
+		operation = "invoke"
+
+		converted, err = embeddingsToTitan(body)
+		if err != nil {
+			return "", nil, err
+		}
+	default:
+		return "", nil, openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("%s requests are not supported by AWS Bedrock", llmRequest.GetRequestType()))
+	}
+
+	// Model IDs contain colons, which are escaped the same as the AWS SDKs do
+	// so that the path is signed as it is sent
+	modelID := strings.ReplaceAll(url.PathEscape(llmRequest.GetModel()), ":", "%3A")
+	upstreamURL := strings.TrimSuffix(baseURL, "/") + "/model/" + modelID + "/" + operation
+
+	convertedBody, err := json.Marshal(converted)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return upstreamURL, convertedBody, nil
+}
+
+func chatCompletionsToConverse(body map[string]any) (map[string]any, error) {
+	var (
+		system   []map[string]any
+		messages []map[string]any
+	)
+
+	appendMessage := func(role string, content []map[string]any) {
+		if len(content) == 0 {
+			return
+		}
+
+		// Converse requires the roles of messages to alternate
+		if len(messages) > 0 && messages[len(messages)-1]["role"] == role {
+			last := messages[len(messages)-1]
+			last["content"] = append(last["content"].([]map[string]any), content...) //nolint:forcetypeassert
+
+			return
+		}
+
+		messages = append(messages, map[string]any{
+			"role":    role,
+			"content": content,
+		})
+	}
+
+	for _, message := range utils.GetByJSONPath[[]map[string]any](body, "{ .messages }") {
+		role, _ := message["role"].(string)
+
+		switch role {
+		case "system", "developer":
+			blocks, err := contentBlocks(message["content"])
+			if err != nil {
+				return nil, err
+			}
+
+			system = append(system, blocks...)
+		case "user":
+			blocks, err := contentBlocks(message["content"])
+			if err != nil {
+				return nil, err
+			}
+
+			appendMessage("user", blocks)
+		case "assistant":
+			blocks, err := contentBlocks(message["content"])
+			if err != nil {
+				return nil, err
+			}
+
+			toolUses, err := toolUseBlocks(message["tool_calls"])
+			if err != nil {
+				return nil, err
+			}
+
+			appendMessage("assistant", append(blocks, toolUses...))
+		case "tool":
+			toolCallID, _ := message["tool_call_id"].(string)
+
+			blocks, err := contentBlocks(message["content"])
+			if err != nil {
+				return nil, err
+			}
+
+			appendMessage("user", []map[string]any{
+				{
+					"toolResult": map[string]any{
+						"toolUseId": toolCallID,
+						"content":   blocks,
+					},
+				},
+			})
+		default:
+			return nil, openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("unsupported role %q of messages", role))
+		}
+	}
+
+	converted := map[string]any{
+		"messages": messages,
+	}
+	if len(system) > 0 {
+		converted["system"] = system
+	}
+
+	inferenceConfig := map[string]any{}
+	if maxTokens, ok := body["max_completion_tokens"].(float64); ok {
+		inferenceConfig["maxTokens"] = int64(maxTokens)
+	} else if maxTokens, ok := body["max_tokens"].(float64); ok {
+		inferenceConfig["maxTokens"] = int64(maxTokens)
+	}
+	if temperature, ok := body["temperature"].(float64); ok {
+		inferenceConfig["temperature"] = temperature
+	}
+	if topP, ok := body["top_p"].(float64); ok {
+		inferenceConfig["topP"] = topP
+	}
+
+	switch stop := body["stop"].(type) {
+	case string:
+		inferenceConfig["stopSequences"] = []string{stop}
+	case []any:
+		inferenceConfig["stopSequences"] = stop
+	}
+
+	if len(inferenceConfig) > 0 {
+		converted["inferenceConfig"] = inferenceConfig
+	}
+
+	toolConfig, err := toolConfigFromTools(body["tools"], body["tool_choice"])
+	if err != nil {
+		return nil, err
+	}
+	if toolConfig != nil {
+		converted["toolConfig"] = toolConfig
+	}
+
+	return converted, nil
+}
+
+// contentBlocks converts the content of OpenAI messages, either a string or
+// an array of parts, into the content blocks of Converse.
+func contentBlocks(content any) ([]map[string]any, error) {
+	switch c := content.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if c == "" {
+			return nil, nil
+		}
+
+		return []map[string]any{{"text": c}}, nil
+	case []any:
+		blocks := make([]map[string]any, 0, len(c))
+
+		for _, part := range c {
+			p, ok := part.(map[string]any)
+			if !ok {
+				return nil, openai.NewErrorBadRequest().WithMessage("invalid content part of messages")
+			}
+
+			switch p["type"] {
+			case "text":
+				text, _ := p["text"].(string)
+				blocks = append(blocks, map[string]any{"text": text})
+			case "image_url":
+				block, err := imageBlock(utils.GetByJSONPath[string](p, "{ .image_url.url }"))
+				if err != nil {
+					return nil, err
+				}
+
+				blocks = append(blocks, block)
+			default:
+				return nil, openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("content parts of type %v are not supported by AWS Bedrock", p["type"]))
+			}
+		}
+
+		return blocks, nil
+	default:
+		return nil, openai.NewErrorBadRequest().WithMessage("invalid content of messages")
+	}
+}
+
+// imageBlock converts data URLs of images into image blocks, Converse only
+// accepts images as bytes.
+func imageBlock(imageURL string) (map[string]any, error) {
+	mediaType, data, ok := strings.Cut(strings.TrimPrefix(imageURL, "data:"), ";base64,")
+	if !strings.HasPrefix(imageURL, "data:") || !ok {
+		return nil, openai.NewErrorBadRequest().WithMessage("only images of base64 data URLs are supported by AWS Bedrock")
+	}
+
+	format, ok := strings.CutPrefix(mediaType, "image/")
+	if !ok {
+		return nil, openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("unsupported media type %s of images", mediaType))
+	}
+
+	return map[string]any{
+		"image": map[string]any{
+			"format": strings.TrimPrefix(format, "x-"),
+			"source": map[string]any{
+				"bytes": data,
+			},
+		},
+	}, nil
+}
+
+func toolUseBlocks(toolCalls any) ([]map[string]any, error) {
+	calls, _ := toolCalls.([]any)
+	blocks := make([]map[string]any, 0, len(calls))
+
+	for _, call := range calls {
+		c, ok := call.(map[string]any)
+		if !ok {
+			return nil, openai.NewErrorBadRequest().WithMessage("invalid tool_calls of messages")
+		}
+
+		input := map[string]any{}
+
+		arguments := utils.GetByJSONPath[string](c, "{ .function.arguments }")
+		if arguments != "" {
+			err := json.Unmarshal([]byte(arguments), &input)
+			if err != nil {
+				return nil, openai.NewErrorBadRequest().WithMessage("arguments of tool_calls must be JSON objects")
+			}
+		}
+
+		blocks = append(blocks, map[string]any{
+			"toolUse": map[string]any{
+				"toolUseId": c["id"],
+				"name":      utils.GetByJSONPath[string](c, "{ .function.name }"),
+				"input":     input,
+			},
+		})
+	}
+
+	return blocks, nil
+}
+
+func toolConfigFromTools(tools any, toolChoice any) (map[string]any, error) {
+	ts, _ := tools.([]any)
+	if len(ts) == 0 || toolChoice == "none" {
+		return nil, nil
+	}
+
+	specs := make([]map[string]any, 0, len(ts))
+
+	for i, tool := range ts {
+		t, ok := tool.(map[string]any)
+		if !ok || t["type"] != "function" {
+			return nil, openai.NewErrorInvalidValue(fmt.Sprintf("tools[%d].type", i), "only tools of type function are supported by AWS Bedrock.")
+		}
+
+		parameters := utils.GetByJSONPath[map[string]any](t, "{ .function.parameters }")
+		if len(parameters) == 0 {
+			parameters = map[string]any{"type": "object"}
+		}
+
+		spec := map[string]any{
+			"name": utils.GetByJSONPath[string](t, "{ .function.name }"),
+			"inputSchema": map[string]any{
+				"json": parameters,
+			},
+		}
+
+		if description := utils.GetByJSONPath[string](t, "{ .function.description }"); description != "" {
+			spec["description"] = description
+		}
+
+		specs = append(specs, map[string]any{"toolSpec": spec})
+	}
+
+	toolConfig := map[string]any{
+		"tools": specs,
+	}
+
+	switch choice := toolChoice.(type) {
+	case string:
+		if choice == "required" {
+			toolConfig["toolChoice"] = map[string]any{"any": map[string]any{}}
+		} else {
+			toolConfig["toolChoice"] = map[string]any{"auto": map[string]any{}}
+		}
+	case map[string]any:
+		toolConfig["toolChoice"] = map[string]any{
+			"tool": map[string]any{
+				"name": utils.GetByJSONPath[string](choice, "{ .function.name }"),
+			},
+		}
+	}
+
+	return toolConfig, nil
+}
+
+func embeddingsToTitan(body map[string]any) (map[string]any, error) {
+	var inputText string
+
+	switch input := body["input"].(type) {
+	case string:
+		inputText = input
+	case []any:
+		text, ok := lo.FirstOrEmpty(input).(string)
+		if len(input) != 1 || !ok {
+			return nil, openai.NewErrorBadRequest().WithMessage("only a single string of input is supported by AWS Bedrock")
+		}
+
+		inputText = text
+	default:
+		return nil, openai.NewErrorBadRequest().WithMessage("only a single string of input is supported by AWS Bedrock")
+	}
+
+	converted := map[string]any{
+		"inputText": inputText,
+	}
+	if dimensions, ok := body["dimensions"].(float64); ok {
+		converted["dimensions"] = int64(dimensions)
+	}
+
+	return converted, nil
+}
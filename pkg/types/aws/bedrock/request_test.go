@@ -0,0 +1,98 @@
+package bedrock
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/internal/gatewaytest"
+	"knoway.dev/pkg/types/openai"
+)
+
+func TestMarshalRequestChatCompletions(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{
+		"model": "anthropic.claude-3-5-sonnet-20240620-v1:0",
+		"messages": [
+			{"role": "system", "content": "You are a helpful assistant."},
+			{"role": "user", "content": "What is the weather in Paris?"},
+			{"role": "user", "content": [
+				{"type": "text", "text": "And this?"},
+				{"type": "image_url", "image_url": {"url": "data:image/png;base64,aGVsbG8="}}
+			]},
+			{"role": "assistant", "content": null, "tool_calls": [
+				{"id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\":\"Paris\"}"}}
+			]},
+			{"role": "tool", "tool_call_id": "call_1", "content": "Sunny"}
+		],
+		"max_tokens": 256,
+		"temperature": 0.5,
+		"stop": "END",
+		"tools": [
+			{"type": "function", "function": {"name": "get_weather", "description": "Get the weather", "parameters": {"type": "object", "properties": {"city": {"type": "string"}}}}}
+		],
+		"tool_choice": "required"
+	}`)
+
+	upstreamURL, body, err := MarshalRequest("https://bedrock-runtime.us-east-1.amazonaws.com/", request)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://bedrock-runtime.us-east-1.amazonaws.com/model/anthropic.claude-3-5-sonnet-20240620-v1%3A0/converse", upstreamURL)
+	assert.JSONEq(t, `{
+		"system": [{"text": "You are a helpful assistant."}],
+		"messages": [
+			{"role": "user", "content": [
+				{"text": "What is the weather in Paris?"},
+				{"text": "And this?"},
+				{"image": {"format": "png", "source": {"bytes": "aGVsbG8="}}}
+			]},
+			{"role": "assistant", "content": [
+				{"toolUse": {"toolUseId": "call_1", "name": "get_weather", "input": {"city": "Paris"}}}
+			]},
+			{"role": "user", "content": [
+				{"toolResult": {"toolUseId": "call_1", "content": [{"text": "Sunny"}]}}
+			]}
+		],
+		"inferenceConfig": {"maxTokens": 256, "temperature": 0.5, "stopSequences": ["END"]},
+		"toolConfig": {
+			"tools": [
+				{"toolSpec": {"name": "get_weather", "description": "Get the weather", "inputSchema": {"json": {"type": "object", "properties": {"city": {"type": "string"}}}}}}
+			],
+			"toolChoice": {"any": {}}
+		}
+	}`, string(body))
+}
+
+func TestMarshalRequestStream(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "amazon.nova-lite-v1:0", "stream": true, "messages": [{"role": "user", "content": "Hi"}], "tools": [{"type": "function", "function": {"name": "noop"}}], "tool_choice": "none"}`)
+
+	upstreamURL, body, err := MarshalRequest("https://bedrock-runtime.us-east-1.amazonaws.com", request)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://bedrock-runtime.us-east-1.amazonaws.com/model/amazon.nova-lite-v1%3A0/converse-stream", upstreamURL)
+	assert.JSONEq(t, `{"messages": [{"role": "user", "content": [{"text": "Hi"}]}]}`, string(body))
+}
+
+func TestMarshalRequestEmbeddings(t *testing.T) {
+	httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com/v1/embeddings", bytes.NewBufferString(`{"model": "amazon.titan-embed-text-v2:0", "input": ["Hello"], "dimensions": 256}`))
+	require.NoError(t, err)
+
+	request, err := openai.NewEmbeddingsRequest(httpRequest)
+	require.NoError(t, err)
+
+	upstreamURL, body, err := MarshalRequest("https://bedrock-runtime.us-east-1.amazonaws.com", request)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://bedrock-runtime.us-east-1.amazonaws.com/model/amazon.titan-embed-text-v2%3A0/invoke", upstreamURL)
+	assert.JSONEq(t, `{"inputText": "Hello", "dimensions": 256}`, string(body))
+}
+
+func TestMarshalRequestUnsupported(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "amazon.nova-lite-v1:0", "messages": [{"role": "user", "content": [{"type": "image_url", "image_url": {"url": "https://example.com/cat.png"}}]}]}`)
+
+	_, _, err := MarshalRequest("https://bedrock-runtime.us-east-1.amazonaws.com", request)
+	require.Error(t, err)
+}
@@ -0,0 +1,430 @@
+package bedrock
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/utils"
+)
+
+const (
+	ContentTypeEventStream = "application/vnd.amazon.eventstream"
+
+	headerRequestID = "X-Amzn-Requestid"
+	headerErrorType = "X-Amzn-Errortype"
+)
+
+// TranslateResponse translates the response of Bedrock Runtime into the one
+// of OpenAI, so that it can be unmarshalled as the response of OpenAI, event
+// streams of ConverseStream are translated into server-sent events on the fly.
+func TranslateResponse(request object.LLMRequest, response *http.Response, reader *bufio.Reader) (*http.Response, *bufio.Reader, error) {
+	translated := new(http.Response)
+	*translated = *response
+	translated.Header = response.Header.Clone()
+	translated.ContentLength = -1
+	translated.Header.Del("Content-Length")
+
+	if response.StatusCode >= http.StatusBadRequest {
+		body, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read body: %w", err)
+		}
+
+		_ = response.Body.Close()
+
+		return withBody(translated, "application/json", translateError(response.StatusCode, response.Header, body))
+	}
+
+	id := "chatcmpl-" + response.Header.Get(headerRequestID)
+
+	switch request.GetRequestType() { //nolint:exhaustive
+	case object.RequestTypeChatCompletions:
+		if strings.HasPrefix(response.Header.Get("Content-Type"), ContentTypeEventStream) {
+			pipeReader, pipeWriter := io.Pipe()
+
+			go translateConverseStream(id, request.GetModel(), reader, response.Body, pipeWriter)
+
+			translated.Header.Set("Content-Type", "text/event-stream")
+			translated.Body = pipeReader
+
+			return translated, bufio.NewReader(pipeReader), nil
+		}
+
+		body, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read body: %w", err)
+		}
+
+		_ = response.Body.Close()
+
+		converted, err := translateConverse(id, request.GetModel(), body)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return withBody(translated, "application/json", converted)
+	case object.RequestTypeEmbeddings:
+		body, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read body: %w", err)
+		}
+
+		_ = response.Body.Close()
+
+		converted, err := translateTitanEmbeddings(request.GetModel(), body)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return withBody(translated, "application/json", converted)
+	default:
+		return response, reader, nil
+	}
+}
+
+func withBody(response *http.Response, contentType string, body []byte) (*http.Response, *bufio.Reader, error) {
+	response.Header.Set("Content-Type", contentType)
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	response.ContentLength = int64(len(body))
+
+	return response, bufio.NewReader(bytes.NewReader(body)), nil
+}
+
+// translateError translates the errors of Bedrock Runtime, the type of which
+// is returned in the X-Amzn-Errortype header, into the error envelope of
+// OpenAI.
+func translateError(status int, header http.Header, body []byte) []byte {
+	errorType, _, _ := strings.Cut(header.Get(headerErrorType), ":")
+
+	var parsed map[string]any
+
+	_ = json.Unmarshal(body, &parsed)
+
+	message := lo.CoalesceOrEmpty(
+		utils.GetByJSONPath[string](parsed, "{ .message }"),
+		utils.GetByJSONPath[string](parsed, "{ .Message }"),
+		fmt.Sprintf("upstream returned status code %d", status),
+	)
+
+	return lo.Must(json.Marshal(map[string]any{
+		"error": openAIError(status, errorType, message),
+	}))
+}
+
+func openAIError(status int, errorType string, message string) map[string]any {
+	typ := "invalid_request_error"
+	code := lo.EmptyableToPtr(errorType)
+
+	switch {
+	case status == http.StatusForbidden || errorType == "UnrecognizedClientException":
+		code = lo.ToPtr("invalid_api_key")
+	case status == http.StatusNotFound || errorType == "ResourceNotFoundException":
+		code = lo.ToPtr("model_not_found")
+	case status == http.StatusTooManyRequests || errorType == "ThrottlingException":
+		typ = "requests"
+		code = lo.ToPtr("rate_limit_exceeded")
+	case status >= http.StatusInternalServerError:
+		typ = "server_error"
+	}
+
+	return map[string]any{
+		"message": message,
+		"type":    typ,
+		"code":    code,
+		"param":   nil,
+	}
+}
+
+func finishReason(stopReason string) string {
+	switch stopReason {
+	case "max_tokens", "model_context_window_exceeded":
+		return "length"
+	case "tool_use":
+		return "tool_calls"
+	case "content_filtered", "guardrail_intervened":
+		return "content_filter"
+	default:
+		return "stop"
+	}
+}
+
+func openAIUsage(usage map[string]any) map[string]any {
+	return map[string]any{
+		"prompt_tokens":     utils.GetByJSONPath[int64](usage, "{ .inputTokens }"),
+		"completion_tokens": utils.GetByJSONPath[int64](usage, "{ .outputTokens }"),
+		"total_tokens":      utils.GetByJSONPath[int64](usage, "{ .totalTokens }"),
+	}
+}
+
+// translateConverse translates the response of Converse into the chat
+// completion of OpenAI.
+func translateConverse(id string, model string, body []byte) ([]byte, error) {
+	var parsed map[string]any
+
+	err := json.Unmarshal(body, &parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal converse response: %w", err)
+	}
+
+	var (
+		content   strings.Builder
+		toolCalls []map[string]any
+	)
+
+	for _, block := range utils.GetByJSONPath[[]map[string]any](parsed, "{ .output.message.content }") {
+		if text, ok := block["text"].(string); ok {
+			content.WriteString(text)
+		}
+
+		if toolUse, ok := block["toolUse"].(map[string]any); ok {
+			arguments, err := json.Marshal(toolUse["input"])
+			if err != nil {
+				return nil, err
+			}
+
+			toolCalls = append(toolCalls, map[string]any{
+				"id":   toolUse["toolUseId"],
+				"type": "function",
+				"function": map[string]any{
+					"name":      toolUse["name"],
+					"arguments": string(arguments),
+				},
+			})
+		}
+	}
+
+	message := map[string]any{
+		"role":    "assistant",
+		"content": content.String(),
+	}
+	if len(toolCalls) > 0 {
+		message["tool_calls"] = toolCalls
+	}
+
+	return json.Marshal(map[string]any{
+		"id":      id,
+		"object":  "chat.completion",
+		"created": time.Now().Unix(),
+		"model":   model,
+		"choices": []map[string]any{
+			{
+				"index":         0,
+				"message":       message,
+				"finish_reason": finishReason(utils.GetByJSONPath[string](parsed, "{ .stopReason }")),
+			},
+		},
+		"usage": openAIUsage(utils.GetByJSONPath[map[string]any](parsed, "{ .usage }")),
+	})
+}
+
+// translateTitanEmbeddings translates the response of Amazon Titan Text
+// Embeddings into the embeddings of OpenAI.
+func translateTitanEmbeddings(model string, body []byte) ([]byte, error) {
+	var parsed struct {
+		Embedding           []float64 `json:"embedding"`
+		InputTextTokenCount int64     `json:"inputTextTokenCount"`
+	}
+
+	err := json.Unmarshal(body, &parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal embeddings response: %w", err)
+	}
+
+	return json.Marshal(map[string]any{
+		"object": "list",
+		"model":  model,
+		"data": []map[string]any{
+			{
+				"object":    "embedding",
+				"index":     0,
+				"embedding": parsed.Embedding,
+			},
+		},
+		"usage": map[string]any{
+			"prompt_tokens": parsed.InputTextTokenCount,
+			"total_tokens":  parsed.InputTextTokenCount,
+		},
+	})
+}
+
+// converseStreamTranslator translates the events of ConverseStream into the
+// chunks of OpenAI.
+type converseStreamTranslator struct {
+	id      string
+	model   string
+	created int64
+
+	// toolCallIndexes maps the index of content blocks to the index of tool
+	// calls
+	toolCallIndexes map[int64]int
+}
+
+func (t *converseStreamTranslator) chunk(delta map[string]any, finishReason *string) map[string]any {
+	return map[string]any{
+		"id":      t.id,
+		"object":  "chat.completion.chunk",
+		"created": t.created,
+		"model":   t.model,
+		"choices": []map[string]any{
+			{
+				"index":         0,
+				"delta":         delta,
+				"finish_reason": finishReason,
+			},
+		},
+	}
+}
+
+// translate returns the chunk translated from the event, nil if the event
+// has nothing to translate.
+func (t *converseStreamTranslator) translate(message *eventStreamMessage) (map[string]any, error) {
+	var payload map[string]any
+
+	err := json.Unmarshal(message.Payload, &payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal converse stream event: %w", err)
+	}
+
+	if message.Headers[":message-type"] == "exception" {
+		return map[string]any{
+			"error": openAIError(http.StatusInternalServerError, message.Headers[":exception-type"], utils.GetByJSONPath[string](payload, "{ .message }")),
+		}, nil
+	}
+
+	switch message.Headers[":event-type"] {
+	case "messageStart":
+		return t.chunk(map[string]any{"role": "assistant", "content": ""}, nil), nil
+	case "contentBlockStart":
+		toolUse := utils.GetByJSONPath[map[string]any](payload, "{ .start.toolUse }")
+		if toolUse == nil {
+			return nil, nil
+		}
+
+		index := len(t.toolCallIndexes)
+		t.toolCallIndexes[utils.GetByJSONPath[int64](payload, "{ .contentBlockIndex }")] = index
+
+		return t.chunk(map[string]any{
+			"tool_calls": []map[string]any{
+				{
+					"index": index,
+					"id":    toolUse["toolUseId"],
+					"type":  "function",
+					"function": map[string]any{
+						"name":      toolUse["name"],
+						"arguments": "",
+					},
+				},
+			},
+		}, nil), nil
+	case "contentBlockDelta":
+		delta := utils.GetByJSONPath[map[string]any](payload, "{ .delta }")
+
+		if text, ok := delta["text"].(string); ok {
+			return t.chunk(map[string]any{"content": text}, nil), nil
+		}
+
+		if toolUse, ok := delta["toolUse"].(map[string]any); ok {
+			return t.chunk(map[string]any{
+				"tool_calls": []map[string]any{
+					{
+						"index": t.toolCallIndexes[utils.GetByJSONPath[int64](payload, "{ .contentBlockIndex }")],
+						"function": map[string]any{
+							"arguments": toolUse["input"],
+						},
+					},
+				},
+			}, nil), nil
+		}
+
+		return nil, nil
+	case "messageStop":
+		return t.chunk(map[string]any{}, lo.ToPtr(finishReason(utils.GetByJSONPath[string](payload, "{ .stopReason }")))), nil
+	case "metadata":
+		usage := utils.GetByJSONPath[map[string]any](payload, "{ .usage }")
+		if usage == nil {
+			return nil, nil
+		}
+
+		return map[string]any{
+			"id":      t.id,
+			"object":  "chat.completion.chunk",
+			"created": t.created,
+			"model":   t.model,
+			"choices": []map[string]any{},
+			"usage":   openAIUsage(usage),
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func translateConverseStream(id string, model string, reader io.Reader, body io.Closer, writer *io.PipeWriter) {
+	defer func() {
+		_ = body.Close()
+	}()
+
+	translator := &converseStreamTranslator{
+		id:              id,
+		model:           model,
+		created:         time.Now().Unix(),
+		toolCallIndexes: make(map[int64]int),
+	}
+
+	writeEvent := func(data []byte) error {
+		_, err := fmt.Fprintf(writer, "data: %s\n\n", data)
+		return err
+	}
+
+	for {
+		message, err := readEventStreamMessage(reader)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				slog.Error("failed to read converse stream", slog.Any("error", err))
+				_ = writer.CloseWithError(err)
+
+				return
+			}
+
+			_ = writeEvent([]byte("[DONE]"))
+			_ = writer.Close()
+
+			return
+		}
+
+		chunk, err := translator.translate(message)
+		if err != nil {
+			_ = writer.CloseWithError(err)
+			return
+		}
+		if chunk == nil {
+			continue
+		}
+
+		data, err := json.Marshal(chunk)
+		if err != nil {
+			_ = writer.CloseWithError(err)
+			return
+		}
+
+		// The reader is closed by downstream
+		if writeEvent(data) != nil {
+			return
+		}
+
+		if _, ok := chunk["error"]; ok {
+			_ = writer.Close()
+			return
+		}
+	}
+}
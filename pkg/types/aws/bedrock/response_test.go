@@ -0,0 +1,150 @@
+package bedrock
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/internal/gatewaytest"
+)
+
+func newResponse(status int, header http.Header, body []byte) (*http.Response, *bufio.Reader) {
+	response := &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+
+	return response, bufio.NewReader(response.Body)
+}
+
+func TestTranslateResponseConverse(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "amazon.nova-lite-v1:0", "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	response, reader := newResponse(http.StatusOK, http.Header{
+		"Content-Type":     []string{"application/json"},
+		"X-Amzn-Requestid": []string{"abc"},
+	}, []byte(`{
+		"output": {"message": {"role": "assistant", "content": [
+			{"text": "Let me check."},
+			{"toolUse": {"toolUseId": "tooluse_1", "name": "get_weather", "input": {"city": "Paris"}}}
+		]}},
+		"stopReason": "tool_use",
+		"usage": {"inputTokens": 10, "outputTokens": 5, "totalTokens": 15}
+	}`))
+
+	translated, translatedReader, err := TranslateResponse(request, response, reader)
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", translated.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(translatedReader)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), `"id":"chatcmpl-abc"`)
+	assert.Contains(t, string(body), `"model":"amazon.nova-lite-v1:0"`)
+	assert.Contains(t, string(body), `"finish_reason":"tool_calls"`)
+	assert.Contains(t, string(body), `"content":"Let me check."`)
+	assert.Contains(t, string(body), `"tool_calls":[{"function":{"arguments":"{\"city\":\"Paris\"}","name":"get_weather"},"id":"tooluse_1","type":"function"}]`)
+	assert.Contains(t, string(body), `"usage":{"completion_tokens":5,"prompt_tokens":10,"total_tokens":15}`)
+}
+
+func TestTranslateResponseError(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "amazon.nova-lite-v1:0", "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	response, reader := newResponse(http.StatusTooManyRequests, http.Header{
+		"Content-Type":     []string{"application/json"},
+		"X-Amzn-Errortype": []string{"ThrottlingException:http://internal.amazon.com/coral/com.amazon.bedrock/"},
+	}, []byte(`{"message": "Too many requests, please wait before trying again."}`))
+
+	translated, translatedReader, err := TranslateResponse(request, response, reader)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, translated.StatusCode)
+
+	body, err := io.ReadAll(translatedReader)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"error": {"message": "Too many requests, please wait before trying again.", "type": "requests", "code": "rate_limit_exceeded", "param": null}}`, string(body))
+}
+
+func TestTranslateResponseConverseStream(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "amazon.nova-lite-v1:0", "stream": true, "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	var stream bytes.Buffer
+
+	for _, event := range []struct {
+		eventType string
+		payload   string
+	}{
+		{"messageStart", `{"role":"assistant"}`},
+		{"contentBlockDelta", `{"contentBlockIndex":0,"delta":{"text":"Hello"}}`},
+		{"contentBlockStart", `{"contentBlockIndex":1,"start":{"toolUse":{"toolUseId":"tooluse_1","name":"get_weather"}}}`},
+		{"contentBlockDelta", `{"contentBlockIndex":1,"delta":{"toolUse":{"input":"{\"city\":"}}}`},
+		{"messageStop", `{"stopReason":"tool_use"}`},
+		{"metadata", `{"usage":{"inputTokens":10,"outputTokens":5,"totalTokens":15},"metrics":{"latencyMs":100}}`},
+	} {
+		stream.Write(encodeEventStreamMessage(map[string]string{
+			":event-type":   event.eventType,
+			":message-type": "event",
+		}, []byte(event.payload)))
+	}
+
+	response, reader := newResponse(http.StatusOK, http.Header{
+		"Content-Type":     []string{ContentTypeEventStream},
+		"X-Amzn-Requestid": []string{"abc"},
+	}, stream.Bytes())
+
+	translated, translatedReader, err := TranslateResponse(request, response, reader)
+	require.NoError(t, err)
+	assert.Equal(t, "text/event-stream", translated.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(translatedReader)
+	require.NoError(t, err)
+
+	events := strings.Split(strings.TrimSuffix(string(body), "\n\n"), "\n\n")
+	require.Len(t, events, 7)
+
+	assert.Contains(t, events[0], `"delta":{"content":"","role":"assistant"}`)
+	assert.Contains(t, events[1], `"delta":{"content":"Hello"}`)
+	assert.Contains(t, events[2], `"tool_calls":[{"function":{"arguments":"","name":"get_weather"},"id":"tooluse_1","index":0,"type":"function"}]`)
+	assert.Contains(t, events[3], `"tool_calls":[{"function":{"arguments":"{\"city\":"},"index":0}]`)
+	assert.Contains(t, events[4], `"finish_reason":"tool_calls"`)
+	assert.Contains(t, events[5], `"choices":[]`)
+	assert.Contains(t, events[5], `"usage":{"completion_tokens":5,"prompt_tokens":10,"total_tokens":15}`)
+	assert.Equal(t, "data: [DONE]", events[6])
+}
+
+func TestTranslateResponseConverseStreamException(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "amazon.nova-lite-v1:0", "stream": true, "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	var stream bytes.Buffer
+
+	stream.Write(encodeEventStreamMessage(map[string]string{
+		":event-type":   "messageStart",
+		":message-type": "event",
+	}, []byte(`{"role":"assistant"}`)))
+	stream.Write(encodeEventStreamMessage(map[string]string{
+		":exception-type": "modelStreamErrorException",
+		":message-type":   "exception",
+	}, []byte(`{"message":"The model failed"}`)))
+
+	response, reader := newResponse(http.StatusOK, http.Header{
+		"Content-Type": []string{ContentTypeEventStream},
+	}, stream.Bytes())
+
+	_, translatedReader, err := TranslateResponse(request, response, reader)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(translatedReader)
+	require.NoError(t, err)
+
+	events := strings.Split(strings.TrimSuffix(string(body), "\n\n"), "\n\n")
+	require.Len(t, events, 2)
+	assert.Contains(t, events[1], `"message":"The model failed"`)
+	assert.Contains(t, events[1], `"type":"server_error"`)
+}
@@ -0,0 +1,222 @@
+package sigv4
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	algorithm = "AWS4-HMAC-SHA256"
+
+	headerDate          = "X-Amz-Date"
+	headerSecurityToken = "X-Amz-Security-Token"
+
+	timeFormat = "20060102T150405Z"
+	dateFormat = "20060102"
+)
+
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// RegionFromHost returns the region of regional endpoints of AWS, such as
+// us-east-1 of bedrock-runtime.us-east-1.amazonaws.com, returns an empty
+// string if the host is not a regional endpoint.
+func RegionFromHost(host string) string {
+	host = strings.ToLower(host)
+	if h, _, found := strings.Cut(host, ":"); found {
+		host = h
+	}
+
+	for _, suffix := range []string{".amazonaws.com", ".amazonaws.com.cn"} {
+		trimmed, ok := strings.CutSuffix(host, suffix)
+		if !ok {
+			continue
+		}
+
+		parts := strings.Split(trimmed, ".")
+		if len(parts) < 2 {
+			return ""
+		}
+
+		return parts[len(parts)-1]
+	}
+
+	return ""
+}
+
+// Sign signs the request with AWS Signature Version 4 by setting the
+// Authorization, X-Amz-Date and X-Amz-Security-Token headers, body must be
+// the payload of the request.
+//
+// See https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_sigv-create-signed-request.html
+func Sign(request *http.Request, body []byte, credentials Credentials, region string, service string, now time.Time) error {
+	if credentials.AccessKeyID == "" || credentials.SecretAccessKey == "" {
+		return errors.New("aws credentials are missing")
+	}
+	if region == "" {
+		return errors.New("aws region is missing")
+	}
+
+	now = now.UTC()
+	amzDate := now.Format(timeFormat)
+	date := now.Format(dateFormat)
+
+	request.Header.Del("Authorization")
+	request.Header.Set(headerDate, amzDate)
+
+	if credentials.SessionToken != "" {
+		request.Header.Set(headerSecurityToken, credentials.SessionToken)
+	} else {
+		request.Header.Del(headerSecurityToken)
+	}
+
+	signedHeaders, canonicalHeaders := canonicalHeaders(request)
+
+	payloadHash := sha256.Sum256(body)
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		canonicalURI(request.URL),
+		canonicalQuery(request.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	stringToSign := strings.Join([]string{
+		algorithm,
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+credentials.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm,
+		credentials.AccessKeyID,
+		scope,
+		signedHeaders,
+		signature,
+	))
+
+	return nil
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+
+	return h.Sum(nil)
+}
+
+// canonicalHeaders signs the host, content-type and x-amz-* headers, other
+// headers may be changed by proxies in between.
+func canonicalHeaders(request *http.Request) (string, string) {
+	host := request.Host
+	if host == "" {
+		host = request.URL.Host
+	}
+
+	headers := map[string]string{
+		"host": host,
+	}
+
+	for key, values := range request.Header {
+		lowerKey := strings.ToLower(key)
+		if lowerKey != "content-type" && !strings.HasPrefix(lowerKey, "x-amz-") {
+			continue
+		}
+
+		trimmed := make([]string, 0, len(values))
+		for _, v := range values {
+			trimmed = append(trimmed, strings.Join(strings.Fields(v), " "))
+		}
+
+		headers[lowerKey] = strings.Join(trimmed, ",")
+	}
+
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var canonical strings.Builder
+	for _, key := range keys {
+		canonical.WriteString(key)
+		canonical.WriteString(":")
+		canonical.WriteString(headers[key])
+		canonical.WriteString("\n")
+	}
+
+	return strings.Join(keys, ";"), canonical.String()
+}
+
+// canonicalURI encodes each segment of the escaped path once more, as
+// services other than S3 expect.
+func canonicalURI(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func canonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+
+	for key, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(key)+"="+uriEncode(value))
+		}
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode encodes everything except the unreserved characters defined by
+// RFC 3986.
+func uriEncode(s string) string {
+	var encoded strings.Builder
+
+	for i := range len(s) {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' {
+			encoded.WriteByte(c)
+			continue
+		}
+
+		fmt.Fprintf(&encoded, "%%%02X", c)
+	}
+
+	return encoded.String()
+}
@@ -0,0 +1,346 @@
+// Package conformance is the shared test suite of the provider adapters,
+// which translate the chat completions of OpenAI into the APIs of the
+// providers and back. Every adapter runs the same suite against its own
+// fixtures, so that adding or refactoring an adapter keeps the behavior
+// consistent with the others.
+//
+// The fixtures of an adapter are directories of cases, e.g.
+// pkg/types/anthropic/claude/testdata/conformance/<case>/, each with:
+//
+//   - request.json: the chat completions request of the client, required.
+//   - upstream_request.golden.json: the URL and the body of the request
+//     marshalled for the upstream, or the error of marshalling.
+//   - upstream_response.http: the raw HTTP response of the upstream,
+//     optional, the cases without it only check the request marshalling.
+//   - response.golden.json: the status, the body or the chunks of streams,
+//     the usage and the error of the response translated into the one of
+//     OpenAI, as parsed by the gateway.
+//
+// Every adapter must have the cases chat, stream, tool_calls and error with
+// upstream responses, which are checked for the usage extraction, the
+// streaming chunks, the tool calls and the error mapping on top of goldens.
+//
+// Golden files are written instead of compared when the UPDATE_GOLDEN
+// environment variable is set, e.g.
+//
+//	UPDATE_GOLDEN=1 go test ./pkg/types/...
+package conformance
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/utils"
+)
+
+const (
+	RequestFile               = "request.json"
+	UpstreamRequestGoldenFile = "upstream_request.golden.json"
+	UpstreamResponseFile      = "upstream_response.http"
+	ResponseGoldenFile        = "response.golden.json"
+
+	updateGoldenEnv = "UPDATE_GOLDEN"
+)
+
+// volatileFields vary from run to run, they are zeroed before compared.
+var volatileFields = []string{"created"}
+
+// requiredCases are the cases every adapter must have.
+var requiredCases = map[string]func(t *testing.T, translated response){
+	"chat": func(t *testing.T, translated response) {
+		t.Helper()
+
+		assert.Equal(t, http.StatusOK, translated.Status)
+		assert.NotEmpty(t, translated.Body, "no body")
+		assert.NotEmpty(t, translated.Usage, "no usage extracted")
+		assert.Nil(t, translated.Error)
+	},
+	"stream": func(t *testing.T, translated response) {
+		t.Helper()
+
+		assert.Equal(t, http.StatusOK, translated.Status)
+		assert.NotEmpty(t, translated.Chunks, "no chunks")
+		assert.NotEmpty(t, translated.Usage, "no usage extracted")
+		assert.Nil(t, translated.Error)
+	},
+	"tool_calls": func(t *testing.T, translated response) {
+		t.Helper()
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(translated.Body, &body))
+
+		assert.Equal(t, "tool_calls", utils.GetByJSONPath[string](body, "{ .choices[0].finish_reason }"))
+		assert.NotEmpty(t, utils.GetByJSONPath[[]any](body, "{ .choices[0].message.tool_calls }"), "no tool calls")
+	},
+	"error": func(t *testing.T, translated response) {
+		t.Helper()
+
+		assert.GreaterOrEqual(t, translated.Status, http.StatusBadRequest)
+		require.NotNil(t, translated.Error, "no error mapped")
+		assert.Equal(t, translated.Status, translated.Error.Status)
+		assert.NotEmpty(t, translated.Error.Message)
+	},
+}
+
+// Adapter is a provider adapter of chat completions under test.
+type Adapter struct {
+	// BaseURL of the upstream the requests are marshalled for
+	BaseURL string
+	// MarshalRequest converts the request of OpenAI into the URL and the
+	// body of the request of the upstream.
+	MarshalRequest func(baseURL string, request object.LLMRequest) (string, []byte, error)
+	// TranslateResponse translates the response of the upstream into the
+	// one of OpenAI, nil for the upstreams compatible with OpenAI.
+	TranslateResponse func(request object.LLMRequest, response *http.Response, reader *bufio.Reader) (*http.Response, *bufio.Reader, error)
+	// EncodeBody optional: encodes the body of upstream_response.http before
+	// it's translated, for the upstreams responding in binary formats which
+	// are kept readable in the fixtures.
+	EncodeBody func(contentType string, body []byte) ([]byte, error)
+}
+
+// Run runs the suite against every case of the fixtures in dir.
+func Run(t *testing.T, adapter Adapter, dir string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	cases := lo.FilterMap(entries, func(entry os.DirEntry, _ int) (string, bool) { return entry.Name(), entry.IsDir() })
+
+	for name := range requiredCases {
+		require.Contains(t, cases, name, "required conformance case %s missing in %s", name, dir)
+	}
+
+	for _, name := range cases {
+		t.Run(name, func(t *testing.T) {
+			translated, ok := runCase(t, adapter, filepath.Join(dir, name))
+
+			check, required := requiredCases[name]
+			if !required {
+				return
+			}
+
+			require.True(t, ok, "no %s of the required case", UpstreamResponseFile)
+			check(t, translated)
+		})
+	}
+}
+
+type upstreamRequest struct {
+	URL   string          `json:"url,omitempty"`
+	Body  json.RawMessage `json:"body,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+type responseError struct {
+	Status  int    `json:"status"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+type response struct {
+	Status int               `json:"status"`
+	Body   json.RawMessage   `json:"body,omitempty"`
+	Chunks []json.RawMessage `json:"chunks,omitempty"`
+	Usage  json.RawMessage   `json:"usage,omitempty"`
+	Error  *responseError    `json:"error,omitempty"`
+}
+
+// runCase returns the response translated, false if the case has no upstream
+// response.
+func runCase(t *testing.T, adapter Adapter, dir string) (response, bool) {
+	t.Helper()
+
+	requestBody, err := os.ReadFile(filepath.Join(dir, RequestFile))
+	require.NoError(t, err)
+
+	httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://knoway.local/v1/chat/completions", bytes.NewReader(requestBody))
+	require.NoError(t, err)
+
+	request, err := openai.NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	// Request marshalling
+	var marshalled upstreamRequest
+
+	url, body, err := adapter.MarshalRequest(adapter.BaseURL, request)
+	if err != nil {
+		marshalled.Error = err.Error()
+	} else {
+		marshalled.URL = url
+		marshalled.Body = normalize(t, body)
+	}
+
+	assertGolden(t, filepath.Join(dir, UpstreamRequestGoldenFile), marshalled)
+
+	rawResponse, err := os.ReadFile(filepath.Join(dir, UpstreamResponseFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return response{}, false
+	}
+
+	require.NoError(t, err)
+	require.Empty(t, marshalled.Error, "cases failing to marshal the request have no response")
+
+	// Response translation
+	translated := translate(t, adapter, request, rawResponse)
+	assertGolden(t, filepath.Join(dir, ResponseGoldenFile), translated)
+
+	return translated, true
+}
+
+func translate(t *testing.T, adapter Adapter, request object.LLMRequest, rawResponse []byte) response {
+	t.Helper()
+
+	httpResponse, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(rawResponse)), nil)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(httpResponse.Body)
+	require.NoError(t, err)
+
+	if adapter.EncodeBody != nil {
+		body, err = adapter.EncodeBody(httpResponse.Header.Get("Content-Type"), body)
+		require.NoError(t, err)
+	}
+
+	httpResponse.Body = io.NopCloser(bytes.NewReader(body))
+	httpResponse.ContentLength = int64(len(body))
+	reader := bufio.NewReader(httpResponse.Body)
+
+	if adapter.TranslateResponse != nil {
+		httpResponse, reader, err = adapter.TranslateResponse(request, httpResponse, reader)
+		require.NoError(t, err)
+	}
+
+	translated := response{Status: httpResponse.StatusCode}
+
+	var llmResponse object.LLMResponse
+
+	if strings.HasPrefix(httpResponse.Header.Get("Content-Type"), "text/event-stream") {
+		stream, err := openai.NewChatCompletionStreamResponse(request, httpResponse, reader)
+		require.NoError(t, err)
+
+		translated.Chunks = readChunks(t, stream)
+		llmResponse = stream
+	} else {
+		resp, err := openai.NewChatCompletionResponse(request, httpResponse, reader)
+		require.NoError(t, err)
+
+		translated.Body = normalize(t, lo.Must(resp.MarshalJSON()))
+		llmResponse = resp
+	}
+
+	if usage := llmResponse.GetUsage(); !lo.IsNil(usage) {
+		translated.Usage = normalize(t, lo.Must(json.Marshal(usage)))
+	}
+
+	if llmError := llmResponse.GetError(); !lo.IsNil(llmError) {
+		translated.Error = &responseError{
+			Status:  llmError.GetStatus(),
+			Code:    llmError.GetCode(),
+			Message: llmError.GetMessage(),
+		}
+	}
+
+	return translated
+}
+
+// readChunks reads the stream to the end, streams must be terminated by the
+// [DONE] chunk.
+func readChunks(t *testing.T, stream *openai.ChatCompletionStreamResponse) []json.RawMessage {
+	t.Helper()
+
+	var chunks []json.RawMessage
+
+	for {
+		chunk, err := stream.NextChunk()
+		if errors.Is(err, io.EOF) {
+			require.True(t, !lo.IsNil(chunk) && chunk.IsDone(), "stream not terminated by the [DONE] chunk")
+			break
+		}
+
+		require.NoError(t, err)
+
+		if chunk.IsEmpty() || chunk.IsDone() {
+			continue
+		}
+
+		chunks = append(chunks, normalize(t, lo.Must(chunk.MarshalJSON())))
+	}
+
+	return chunks
+}
+
+// normalize zeroes the volatile fields of the JSON, bodies other than JSON
+// are kept as strings.
+func normalize(t *testing.T, body []byte) json.RawMessage {
+	t.Helper()
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	var value any
+
+	err := json.Unmarshal(body, &value)
+	if err != nil {
+		return lo.Must(json.Marshal(string(body)))
+	}
+
+	return lo.Must(json.Marshal(zeroVolatile(value)))
+}
+
+func zeroVolatile(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if lo.Contains(volatileFields, key) {
+				v[key] = 0
+				continue
+			}
+
+			v[key] = zeroVolatile(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = zeroVolatile(item)
+		}
+	}
+
+	return value
+}
+
+// assertGolden compares the value with the golden file, or writes it when
+// UPDATE_GOLDEN is set.
+func assertGolden(t *testing.T, path string, value any) {
+	t.Helper()
+
+	actual, err := json.MarshalIndent(value, "", "  ")
+	require.NoError(t, err)
+
+	actual = append(actual, '\n')
+
+	if os.Getenv(updateGoldenEnv) != "" {
+		require.NoError(t, os.WriteFile(path, actual, 0o600))
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "golden file missing, run with %s=1 to create it", updateGoldenEnv)
+
+	assert.JSONEq(t, string(expected), string(actual), "mismatched with %s", path)
+}
@@ -0,0 +1,92 @@
+package websocketv1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/types/stt"
+	"knoway.dev/pkg/types/tts"
+	"knoway.dev/pkg/utils"
+)
+
+const (
+	defaultDeepgramListenURL = "https://api.deepgram.com/v1/listen"
+)
+
+// BuildTranscriptionRequest builds the pre-recorded audio request of
+// Deepgram, the audio file is sent as the body. Deepgram has no translation
+// of speech, only transcriptions are supported.
+func BuildTranscriptionRequest(ctx context.Context, baseURL string, authHeader string, req stt.Request, _ http.Header, _ http.Header) (*http.Request, error) {
+	if req.GetTask() != stt.TaskTranscriptions {
+		return nil, openai.NewErrorBadRequest().WithMessage("Deepgram only supports transcriptions")
+	}
+
+	if baseURL == "" {
+		baseURL = defaultDeepgramListenURL
+	}
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+	if req.GetModel() != "" {
+		q.Set("model", req.GetModel())
+	}
+
+	if req.GetLanguage() != "" {
+		q.Set("language", req.GetLanguage())
+	}
+
+	q.Set("smart_format", "true")
+
+	u.RawQuery = q.Encode()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(req.GetFile().Data))
+	if err != nil {
+		return nil, err
+	}
+
+	auth := authHeader
+	if after, ok := strings.CutPrefix(auth, "Bearer "); ok {
+		auth = "Token " + after
+	}
+
+	httpReq.Header.Set("Authorization", auth)
+	httpReq.Header.Set("Content-Type", req.GetFile().ContentType)
+
+	return httpReq, nil
+}
+
+// ParseTranscriptionResponse converts the transcript of the first channel to
+// the response format of OpenAI.
+func ParseTranscriptionResponse(resp *http.Response, body []byte, req stt.Request) (object.LLMResponse, error) {
+	if resp == nil {
+		return nil, openai.NewErrorBadGateway().WithMessage("upstream response is nil")
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, tts.ParseUpstreamError(resp, body)
+	}
+
+	var parsed map[string]any
+
+	err := json.Unmarshal(body, &parsed)
+	if err != nil {
+		return nil, openai.NewErrorBadGateway().WithMessage("failed to parse upstream response: " + err.Error())
+	}
+
+	transcript := utils.GetByJSONPath[string](parsed, "{ .results.channels[0].alternatives[0].transcript }")
+
+	transcription := stt.NewTranscriptionResponseFromText(req.GetModel(), transcript, req.GetResponseFormat())
+	transcription.RequestID = resp.Header.Get("dg-request-id")
+
+	return transcription, nil
+}
@@ -0,0 +1,15 @@
+package gemini
+
+import (
+	"testing"
+
+	"knoway.dev/pkg/types/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, conformance.Adapter{
+		BaseURL:           "https://generativelanguage.googleapis.com/v1beta",
+		MarshalRequest:    MarshalRequest,
+		TranslateResponse: TranslateResponse,
+	}, "testdata/conformance")
+}
@@ -0,0 +1,413 @@
+package gemini
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/utils"
+)
+
+// MarshalRequest converts the OpenAI chat completion request into the
+// generateContent (or streamGenerateContent) request of Gemini, returns the
+// URL and the body of it.
+//
+// The base URL is either the one of the Gemini API, such as
+// https://generativelanguage.googleapis.com/v1beta, or the one of the
+// publisher of Vertex AI, such as
+// https://us-central1-aiplatform.googleapis.com/v1/projects/my-project/locations/us-central1/publishers/google,
+// both of which serve the models under /models/{model}.
+func MarshalRequest(baseURL string, llmRequest object.LLMRequest) (string, []byte, error) {
+	if llmRequest.GetRequestType() != object.RequestTypeChatCompletions {
+		return "", nil, openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("%s requests are not supported by Gemini", llmRequest.GetRequestType()))
+	}
+
+	upstreamURL, err := url.Parse(baseURL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	method := "generateContent"
+	if llmRequest.IsStream() {
+		method = "streamGenerateContent"
+
+		query := upstreamURL.Query()
+		query.Set("alt", "sse")
+		upstreamURL.RawQuery = query.Encode()
+	}
+
+	upstreamURL.Path = path.Join("/", upstreamURL.Path, "models", llmRequest.GetModel()+":"+method)
+	upstreamURL.RawPath = ""
+
+	jsonBody, err := json.Marshal(llmRequest)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var body map[string]any
+
+	err = json.Unmarshal(jsonBody, &body)
+	if err != nil {
+		return "", nil, err
+	}
+
+	converted, err := chatCompletionsToGenerateContent(body)
+	if err != nil {
+		return "", nil, err
+	}
+
+	convertedBody, err := json.Marshal(converted)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return upstreamURL.String(), convertedBody, nil
+}
+
+func chatCompletionsToGenerateContent(body map[string]any) (map[string]any, error) {
+	var (
+		systemParts []map[string]any
+		contents    []map[string]any
+		// toolCallNames maps the IDs of tool calls to the names of functions,
+		// function responses of Gemini are matched by names
+		toolCallNames = make(map[string]string)
+	)
+
+	appendContent := func(role string, parts []map[string]any) {
+		if len(parts) == 0 {
+			return
+		}
+
+		if len(contents) > 0 && contents[len(contents)-1]["role"] == role {
+			last := contents[len(contents)-1]
+			last["parts"] = append(last["parts"].([]map[string]any), parts...) //nolint:forcetypeassert
+
+			return
+		}
+
+		contents = append(contents, map[string]any{
+			"role":  role,
+			"parts": parts,
+		})
+	}
+
+	for _, message := range utils.GetByJSONPath[[]map[string]any](body, "{ .messages }") {
+		role, _ := message["role"].(string)
+
+		switch role {
+		case "system", "developer":
+			parts, err := contentParts(message["content"])
+			if err != nil {
+				return nil, err
+			}
+
+			systemParts = append(systemParts, parts...)
+		case "user":
+			parts, err := contentParts(message["content"])
+			if err != nil {
+				return nil, err
+			}
+
+			appendContent("user", parts)
+		case "assistant":
+			parts, err := contentParts(message["content"])
+			if err != nil {
+				return nil, err
+			}
+
+			functionCalls, err := functionCallParts(message["tool_calls"], toolCallNames)
+			if err != nil {
+				return nil, err
+			}
+
+			appendContent("model", append(parts, functionCalls...))
+		case "tool":
+			toolCallID, _ := message["tool_call_id"].(string)
+
+			name, ok := toolCallNames[toolCallID]
+			if !ok {
+				return nil, openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("tool_call_id %q of messages does not match any tool call", toolCallID))
+			}
+
+			appendContent("user", []map[string]any{
+				{
+					"functionResponse": map[string]any{
+						"name":     name,
+						"response": functionResponse(message["content"]),
+					},
+				},
+			})
+		default:
+			return nil, openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("unsupported role %q of messages", role))
+		}
+	}
+
+	converted := map[string]any{
+		"contents": contents,
+	}
+	if len(systemParts) > 0 {
+		converted["systemInstruction"] = map[string]any{
+			"parts": systemParts,
+		}
+	}
+
+	generationConfig := generationConfig(body)
+	if len(generationConfig) > 0 {
+		converted["generationConfig"] = generationConfig
+	}
+
+	tools, toolConfig, err := toolsFromTools(body["tools"], body["tool_choice"])
+	if err != nil {
+		return nil, err
+	}
+	if tools != nil {
+		converted["tools"] = tools
+	}
+	if toolConfig != nil {
+		converted["toolConfig"] = toolConfig
+	}
+
+	// Safety settings are passed through as is, typically configured by the
+	// defaultParams of the cluster
+	if safetySettings, ok := body["safety_settings"]; ok {
+		converted["safetySettings"] = safetySettings
+	}
+
+	return converted, nil
+}
+
+func generationConfig(body map[string]any) map[string]any {
+	config := map[string]any{}
+
+	if maxTokens, ok := body["max_completion_tokens"].(float64); ok {
+		config["maxOutputTokens"] = int64(maxTokens)
+	} else if maxTokens, ok := body["max_tokens"].(float64); ok {
+		config["maxOutputTokens"] = int64(maxTokens)
+	}
+
+	for from, to := range map[string]string{
+		"temperature":       "temperature",
+		"top_p":             "topP",
+		"presence_penalty":  "presencePenalty",
+		"frequency_penalty": "frequencyPenalty",
+	} {
+		if value, ok := body[from].(float64); ok {
+			config[to] = value
+		}
+	}
+
+	if n, ok := body["n"].(float64); ok {
+		config["candidateCount"] = int64(n)
+	}
+	if seed, ok := body["seed"].(float64); ok {
+		config["seed"] = int64(seed)
+	}
+
+	switch stop := body["stop"].(type) {
+	case string:
+		config["stopSequences"] = []string{stop}
+	case []any:
+		config["stopSequences"] = stop
+	}
+
+	switch utils.GetByJSONPath[string](body, "{ .response_format.type }") {
+	case "json_object":
+		config["responseMimeType"] = "application/json"
+	case "json_schema":
+		config["responseMimeType"] = "application/json"
+
+		if schema := utils.GetByJSONPath[map[string]any](body, "{ .response_format.json_schema.schema }"); schema != nil {
+			config["responseJsonSchema"] = schema
+		}
+	}
+
+	return config
+}
+
+// contentParts converts the content of OpenAI messages, either a string or
+// an array of parts, into the parts of Gemini.
+func contentParts(content any) ([]map[string]any, error) {
+	switch c := content.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if c == "" {
+			return nil, nil
+		}
+
+		return []map[string]any{{"text": c}}, nil
+	case []any:
+		parts := make([]map[string]any, 0, len(c))
+
+		for _, part := range c {
+			p, ok := part.(map[string]any)
+			if !ok {
+				return nil, openai.NewErrorBadRequest().WithMessage("invalid content part of messages")
+			}
+
+			switch p["type"] {
+			case "text":
+				text, _ := p["text"].(string)
+				parts = append(parts, map[string]any{"text": text})
+			case "image_url":
+				parts = append(parts, imagePart(utils.GetByJSONPath[string](p, "{ .image_url.url }")))
+			default:
+				return nil, openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("content parts of type %v are not supported by Gemini", p["type"]))
+			}
+		}
+
+		return parts, nil
+	default:
+		return nil, openai.NewErrorBadRequest().WithMessage("invalid content of messages")
+	}
+}
+
+// imagePart converts data URLs of images into inline data, and other URLs,
+// such as the ones of Cloud Storage, into file data.
+func imagePart(imageURL string) map[string]any {
+	if mediaType, data, ok := strings.Cut(strings.TrimPrefix(imageURL, "data:"), ";base64,"); strings.HasPrefix(imageURL, "data:") && ok {
+		return map[string]any{
+			"inlineData": map[string]any{
+				"mimeType": mediaType,
+				"data":     data,
+			},
+		}
+	}
+
+	fileData := map[string]any{
+		"fileUri": imageURL,
+	}
+
+	if u, err := url.Parse(imageURL); err == nil {
+		if mediaType := mime.TypeByExtension(path.Ext(u.Path)); mediaType != "" {
+			fileData["mimeType"] = mediaType
+		}
+	}
+
+	return map[string]any{
+		"fileData": fileData,
+	}
+}
+
+func functionCallParts(toolCalls any, toolCallNames map[string]string) ([]map[string]any, error) {
+	calls, _ := toolCalls.([]any)
+	parts := make([]map[string]any, 0, len(calls))
+
+	for _, call := range calls {
+		c, ok := call.(map[string]any)
+		if !ok {
+			return nil, openai.NewErrorBadRequest().WithMessage("invalid tool_calls of messages")
+		}
+
+		name := utils.GetByJSONPath[string](c, "{ .function.name }")
+		args := map[string]any{}
+
+		arguments := utils.GetByJSONPath[string](c, "{ .function.arguments }")
+		if arguments != "" {
+			err := json.Unmarshal([]byte(arguments), &args)
+			if err != nil {
+				return nil, openai.NewErrorBadRequest().WithMessage("arguments of tool_calls must be JSON objects")
+			}
+		}
+
+		if id, ok := c["id"].(string); ok {
+			toolCallNames[id] = name
+		}
+
+		parts = append(parts, map[string]any{
+			"functionCall": map[string]any{
+				"name": name,
+				"args": args,
+			},
+		})
+	}
+
+	return parts, nil
+}
+
+// functionResponse converts the content of tool messages into the response
+// of functions, which must be a JSON object.
+func functionResponse(content any) map[string]any {
+	var text string
+
+	switch c := content.(type) {
+	case string:
+		text = c
+	case []any:
+		var builder strings.Builder
+
+		for _, part := range c {
+			if p, ok := part.(map[string]any); ok && p["type"] == "text" {
+				t, _ := p["text"].(string)
+				builder.WriteString(t)
+			}
+		}
+
+		text = builder.String()
+	}
+
+	var response map[string]any
+	if json.Unmarshal([]byte(text), &response) == nil && response != nil {
+		return response
+	}
+
+	return map[string]any{"content": text}
+}
+
+func toolsFromTools(tools any, toolChoice any) ([]map[string]any, map[string]any, error) {
+	ts, _ := tools.([]any)
+	if len(ts) == 0 {
+		return nil, nil, nil
+	}
+
+	declarations := make([]map[string]any, 0, len(ts))
+
+	for i, tool := range ts {
+		t, ok := tool.(map[string]any)
+		if !ok || t["type"] != "function" {
+			return nil, nil, openai.NewErrorInvalidValue(fmt.Sprintf("tools[%d].type", i), "only tools of type function are supported by Gemini.")
+		}
+
+		declaration := map[string]any{
+			"name": utils.GetByJSONPath[string](t, "{ .function.name }"),
+		}
+
+		if description := utils.GetByJSONPath[string](t, "{ .function.description }"); description != "" {
+			declaration["description"] = description
+		}
+
+		if parameters := utils.GetByJSONPath[map[string]any](t, "{ .function.parameters }"); len(parameters) > 0 {
+			declaration["parametersJsonSchema"] = parameters
+		}
+
+		declarations = append(declarations, declaration)
+	}
+
+	functionCallingConfig := map[string]any{}
+
+	switch choice := toolChoice.(type) {
+	case string:
+		switch choice {
+		case "none":
+			functionCallingConfig["mode"] = "NONE"
+		case "required":
+			functionCallingConfig["mode"] = "ANY"
+		default:
+			functionCallingConfig["mode"] = "AUTO"
+		}
+	case map[string]any:
+		functionCallingConfig["mode"] = "ANY"
+		functionCallingConfig["allowedFunctionNames"] = []string{utils.GetByJSONPath[string](choice, "{ .function.name }")}
+	default:
+		return []map[string]any{{"functionDeclarations": declarations}}, nil, nil
+	}
+
+	return []map[string]any{{"functionDeclarations": declarations}}, map[string]any{
+		"functionCallingConfig": functionCallingConfig,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package gemini
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/internal/gatewaytest"
+)
+
+func TestMarshalRequest(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{
+		"model": "gemini-2.0-flash",
+		"messages": [
+			{"role": "system", "content": "You are a helpful assistant."},
+			{"role": "user", "content": [
+				{"type": "text", "text": "What is in the image?"},
+				{"type": "image_url", "image_url": {"url": "data:image/png;base64,aGVsbG8="}},
+				{"type": "image_url", "image_url": {"url": "gs://bucket/cat.jpg"}}
+			]},
+			{"role": "assistant", "content": null, "tool_calls": [
+				{"id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\":\"Paris\"}"}}
+			]},
+			{"role": "tool", "tool_call_id": "call_1", "content": "Sunny"}
+		],
+		"max_completion_tokens": 256,
+		"temperature": 0.5,
+		"stop": ["END"],
+		"response_format": {"type": "json_object"},
+		"tools": [
+			{"type": "function", "function": {"name": "get_weather", "parameters": {"type": "object", "properties": {"city": {"type": "string"}}}}}
+		],
+		"tool_choice": {"type": "function", "function": {"name": "get_weather"}},
+		"safety_settings": [{"category": "HARM_CATEGORY_HATE_SPEECH", "threshold": "BLOCK_ONLY_HIGH"}]
+	}`)
+
+	upstreamURL, body, err := MarshalRequest("https://generativelanguage.googleapis.com/v1beta/", request)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://generativelanguage.googleapis.com/v1beta/models/gemini-2.0-flash:generateContent", upstreamURL)
+	assert.JSONEq(t, `{
+		"systemInstruction": {"parts": [{"text": "You are a helpful assistant."}]},
+		"contents": [
+			{"role": "user", "parts": [
+				{"text": "What is in the image?"},
+				{"inlineData": {"mimeType": "image/png", "data": "aGVsbG8="}},
+				{"fileData": {"mimeType": "image/jpeg", "fileUri": "gs://bucket/cat.jpg"}}
+			]},
+			{"role": "model", "parts": [
+				{"functionCall": {"name": "get_weather", "args": {"city": "Paris"}}}
+			]},
+			{"role": "user", "parts": [
+				{"functionResponse": {"name": "get_weather", "response": {"content": "Sunny"}}}
+			]}
+		],
+		"generationConfig": {"maxOutputTokens": 256, "temperature": 0.5, "stopSequences": ["END"], "responseMimeType": "application/json"},
+		"tools": [{"functionDeclarations": [
+			{"name": "get_weather", "parametersJsonSchema": {"type": "object", "properties": {"city": {"type": "string"}}}}
+		]}],
+		"toolConfig": {"functionCallingConfig": {"mode": "ANY", "allowedFunctionNames": ["get_weather"]}},
+		"safetySettings": [{"category": "HARM_CATEGORY_HATE_SPEECH", "threshold": "BLOCK_ONLY_HIGH"}]
+	}`, string(body))
+}
+
+func TestMarshalRequestStream(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gemini-2.0-flash", "stream": true, "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	upstreamURL, body, err := MarshalRequest("https://us-central1-aiplatform.googleapis.com/v1/projects/p/locations/us-central1/publishers/google", request)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://us-central1-aiplatform.googleapis.com/v1/projects/p/locations/us-central1/publishers/google/models/gemini-2.0-flash:streamGenerateContent?alt=sse", upstreamURL)
+	assert.JSONEq(t, `{"contents": [{"role": "user", "parts": [{"text": "Hi"}]}]}`, string(body))
+}
+
+func TestMarshalRequestUnmatchedToolCall(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gemini-2.0-flash", "messages": [{"role": "tool", "tool_call_id": "call_1", "content": "Sunny"}]}`)
+
+	_, _, err := MarshalRequest("https://generativelanguage.googleapis.com/v1beta", request)
+	require.Error(t, err)
+}
@@ -0,0 +1,439 @@
+package gemini
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/utils"
+)
+
+// TranslateResponse translates the response of Gemini into the one of
+// OpenAI, so that it can be unmarshalled as the response of OpenAI, server-sent
+// events of streamGenerateContent are translated on the fly.
+func TranslateResponse(request object.LLMRequest, response *http.Response, reader *bufio.Reader) (*http.Response, *bufio.Reader, error) {
+	if request.GetRequestType() != object.RequestTypeChatCompletions {
+		return response, reader, nil
+	}
+
+	translated := new(http.Response)
+	*translated = *response
+	translated.Header = response.Header.Clone()
+	translated.ContentLength = -1
+	translated.Header.Del("Content-Length")
+
+	if response.StatusCode < http.StatusBadRequest && strings.HasPrefix(response.Header.Get("Content-Type"), "text/event-stream") {
+		pipeReader, pipeWriter := io.Pipe()
+
+		go translateStream(request.GetModel(), reader, response.Body, pipeWriter)
+
+		translated.Body = pipeReader
+
+		return translated, bufio.NewReader(pipeReader), nil
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	_ = response.Body.Close()
+
+	var converted []byte
+
+	if response.StatusCode >= http.StatusBadRequest {
+		converted = translateError(response.StatusCode, body)
+	} else {
+		converted, err = translateGenerateContent(request.GetModel(), body)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	translated.Header.Set("Content-Type", "application/json")
+	translated.Body = io.NopCloser(bytes.NewReader(converted))
+	translated.ContentLength = int64(len(converted))
+
+	return translated, bufio.NewReader(bytes.NewReader(converted)), nil
+}
+
+// translateError translates the errors of Google APIs into the error
+// envelope of OpenAI, Vertex AI may wrap the error in an array.
+func translateError(status int, body []byte) []byte {
+	var parsed any
+
+	_ = json.Unmarshal(body, &parsed)
+
+	if errs, ok := parsed.([]any); ok {
+		parsed = lo.FirstOrEmpty(errs)
+	}
+
+	errorStatus := utils.GetByJSONPath[string](parsed, "{ .error.status }")
+	message := lo.CoalesceOrEmpty(
+		utils.GetByJSONPath[string](parsed, "{ .error.message }"),
+		fmt.Sprintf("upstream returned status code %d", status),
+	)
+
+	return lo.Must(json.Marshal(map[string]any{
+		"error": openAIError(status, errorStatus, message),
+	}))
+}
+
+func openAIError(status int, errorStatus string, message string) map[string]any {
+	typ := "invalid_request_error"
+	code := lo.EmptyableToPtr(strings.ToLower(errorStatus))
+
+	switch {
+	case status == http.StatusUnauthorized || errorStatus == "UNAUTHENTICATED":
+		code = lo.ToPtr("invalid_api_key")
+	case status == http.StatusNotFound || errorStatus == "NOT_FOUND":
+		code = lo.ToPtr("model_not_found")
+	case status == http.StatusTooManyRequests || errorStatus == "RESOURCE_EXHAUSTED":
+		typ = "requests"
+		code = lo.ToPtr("rate_limit_exceeded")
+	case status >= http.StatusInternalServerError:
+		typ = "server_error"
+	}
+
+	return map[string]any{
+		"message": message,
+		"type":    typ,
+		"code":    code,
+		"param":   nil,
+	}
+}
+
+func finishReason(reason string, hasToolCalls bool) string {
+	switch reason {
+	case "MAX_TOKENS":
+		return "length"
+	case "SAFETY", "RECITATION", "BLOCKLIST", "PROHIBITED_CONTENT", "SPII", "IMAGE_SAFETY":
+		return "content_filter"
+	default:
+		if hasToolCalls {
+			return "tool_calls"
+		}
+
+		return "stop"
+	}
+}
+
+// openAIUsage converts the usage metadata of Gemini, tokens spent on
+// thinking are counted as completion tokens as they are billed as output.
+func openAIUsage(usageMetadata map[string]any) map[string]any {
+	thoughtsTokens := utils.GetByJSONPath[int64](usageMetadata, "{ .thoughtsTokenCount }")
+	promptTokens := utils.GetByJSONPath[int64](usageMetadata, "{ .promptTokenCount }")
+	completionTokens := utils.GetByJSONPath[int64](usageMetadata, "{ .candidatesTokenCount }") + thoughtsTokens
+
+	usage := map[string]any{
+		"prompt_tokens":     promptTokens,
+		"completion_tokens": completionTokens,
+		"total_tokens":      lo.CoalesceOrEmpty(utils.GetByJSONPath[int64](usageMetadata, "{ .totalTokenCount }"), promptTokens+completionTokens),
+	}
+
+	if thoughtsTokens > 0 {
+		usage["completion_tokens_details"] = map[string]any{
+			"reasoning_tokens": thoughtsTokens,
+		}
+	}
+
+	return usage
+}
+
+type generateContentResponse struct {
+	Candidates []struct {
+		Index   int `json:"index"`
+		Content struct {
+			Parts []struct {
+				Text         *string `json:"text"`
+				Thought      bool    `json:"thought"`
+				FunctionCall *struct {
+					ID   string         `json:"id"`
+					Name string         `json:"name"`
+					Args map[string]any `json:"args"`
+				} `json:"functionCall"`
+			} `json:"parts"`
+		} `json:"content"`
+		FinishReason string `json:"finishReason"`
+	} `json:"candidates"`
+	PromptFeedback struct {
+		BlockReason string `json:"blockReason"`
+	} `json:"promptFeedback"`
+	UsageMetadata map[string]any `json:"usageMetadata"`
+	ModelVersion  string         `json:"modelVersion"`
+	ResponseID    string         `json:"responseId"`
+}
+
+// candidateMessage returns the text and the tool calls of the candidate,
+// thoughts are left out.
+func (r *generateContentResponse) candidateMessage(index int, toolCallIndex func(id string) int) (string, []map[string]any, error) {
+	var (
+		content   strings.Builder
+		toolCalls []map[string]any
+	)
+
+	for _, part := range r.Candidates[index].Content.Parts {
+		if part.Text != nil && !part.Thought {
+			content.WriteString(*part.Text)
+		}
+
+		if part.FunctionCall != nil {
+			arguments, err := json.Marshal(lo.CoalesceMapOrEmpty(part.FunctionCall.Args))
+			if err != nil {
+				return "", nil, err
+			}
+
+			i := toolCallIndex(part.FunctionCall.ID)
+			toolCalls = append(toolCalls, map[string]any{
+				"index": i,
+				"id":    lo.CoalesceOrEmpty(part.FunctionCall.ID, fmt.Sprintf("call_%d_%d", index, i)),
+				"type":  "function",
+				"function": map[string]any{
+					"name":      part.FunctionCall.Name,
+					"arguments": string(arguments),
+				},
+			})
+		}
+	}
+
+	return content.String(), toolCalls, nil
+}
+
+func (r *generateContentResponse) id() string {
+	return "chatcmpl-" + r.ResponseID
+}
+
+// translateGenerateContent translates the response of generateContent into
+// the chat completion of OpenAI.
+func translateGenerateContent(model string, body []byte) ([]byte, error) {
+	var parsed generateContentResponse
+
+	err := json.Unmarshal(body, &parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal generateContent response: %w", err)
+	}
+
+	choices := make([]map[string]any, 0, len(parsed.Candidates))
+
+	for i, candidate := range parsed.Candidates {
+		toolCallIndex := 0
+
+		content, toolCalls, err := parsed.candidateMessage(i, func(string) int {
+			toolCallIndex++
+			return toolCallIndex - 1
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		message := map[string]any{
+			"role":    "assistant",
+			"content": content,
+		}
+		if len(toolCalls) > 0 {
+			message["tool_calls"] = lo.Map(toolCalls, func(toolCall map[string]any, _ int) map[string]any {
+				return lo.OmitByKeys(toolCall, []string{"index"})
+			})
+		}
+
+		choices = append(choices, map[string]any{
+			"index":         candidate.Index,
+			"message":       message,
+			"finish_reason": finishReason(candidate.FinishReason, len(toolCalls) > 0),
+		})
+	}
+
+	// The prompt is blocked, no candidate is returned
+	if len(choices) == 0 && parsed.PromptFeedback.BlockReason != "" {
+		choices = append(choices, map[string]any{
+			"index":         0,
+			"message":       map[string]any{"role": "assistant", "content": ""},
+			"finish_reason": "content_filter",
+		})
+	}
+
+	return json.Marshal(map[string]any{
+		"id":      parsed.id(),
+		"object":  "chat.completion",
+		"created": time.Now().Unix(),
+		"model":   lo.CoalesceOrEmpty(parsed.ModelVersion, model),
+		"choices": choices,
+		"usage":   openAIUsage(parsed.UsageMetadata),
+	})
+}
+
+// streamTranslator translates the responses of streamGenerateContent into
+// the chunks of OpenAI.
+type streamTranslator struct {
+	model   string
+	created int64
+
+	started bool
+	// toolCalls counts the tool calls of each candidate, Gemini sends every
+	// function call as a whole in one response
+	toolCalls map[int]int
+	// usageMetadata is sent in every response, only the last one is
+	// translated into the usage chunk
+	usageMetadata map[string]any
+	id            string
+}
+
+func (t *streamTranslator) chunk(choices []map[string]any) map[string]any {
+	return map[string]any{
+		"id":      t.id,
+		"object":  "chat.completion.chunk",
+		"created": t.created,
+		"model":   t.model,
+		"choices": choices,
+	}
+}
+
+func (t *streamTranslator) translate(data []byte) (map[string]any, error) {
+	var parsed generateContentResponse
+
+	err := json.Unmarshal(data, &parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal streamGenerateContent response: %w", err)
+	}
+
+	if parsed.ResponseID != "" {
+		t.id = parsed.id()
+	}
+	if parsed.ModelVersion != "" {
+		t.model = parsed.ModelVersion
+	}
+	if parsed.UsageMetadata != nil {
+		t.usageMetadata = parsed.UsageMetadata
+	}
+
+	choices := make([]map[string]any, 0, len(parsed.Candidates))
+
+	for i, candidate := range parsed.Candidates {
+		content, toolCalls, err := parsed.candidateMessage(i, func(string) int {
+			t.toolCalls[candidate.Index]++
+			return t.toolCalls[candidate.Index] - 1
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		delta := map[string]any{}
+		if !t.started {
+			delta["role"] = "assistant"
+		}
+		if content != "" || !t.started {
+			delta["content"] = content
+		}
+		if len(toolCalls) > 0 {
+			delta["tool_calls"] = toolCalls
+		}
+
+		var reason *string
+		if candidate.FinishReason != "" {
+			reason = lo.ToPtr(finishReason(candidate.FinishReason, t.toolCalls[candidate.Index] > 0))
+		}
+
+		choices = append(choices, map[string]any{
+			"index":         candidate.Index,
+			"delta":         delta,
+			"finish_reason": reason,
+		})
+	}
+
+	if len(choices) == 0 && parsed.PromptFeedback.BlockReason != "" {
+		choices = append(choices, map[string]any{
+			"index":         0,
+			"delta":         map[string]any{"role": "assistant", "content": ""},
+			"finish_reason": "content_filter",
+		})
+	}
+
+	if len(choices) == 0 {
+		return nil, nil
+	}
+
+	t.started = true
+
+	return t.chunk(choices), nil
+}
+
+func (t *streamTranslator) usageChunk() map[string]any {
+	if t.usageMetadata == nil {
+		return nil
+	}
+
+	chunk := t.chunk([]map[string]any{})
+	chunk["usage"] = openAIUsage(t.usageMetadata)
+
+	return chunk
+}
+
+func translateStream(model string, reader *bufio.Reader, body io.Closer, writer *io.PipeWriter) {
+	defer func() {
+		_ = body.Close()
+	}()
+
+	translator := &streamTranslator{
+		model:     model,
+		created:   time.Now().Unix(),
+		toolCalls: make(map[int]int),
+		id:        "chatcmpl-",
+	}
+
+	writeChunk := func(chunk map[string]any) error {
+		data, err := json.Marshal(chunk)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(writer, "data: %s\n\n", data)
+
+		return err
+	}
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			slog.Error("failed to read gemini stream", slog.Any("error", err))
+			_ = writer.CloseWithError(err)
+
+			return
+		}
+
+		if data, ok := bytes.CutPrefix(bytes.TrimSpace(line), []byte("data:")); ok {
+			chunk, translateErr := translator.translate(bytes.TrimSpace(data))
+			if translateErr != nil {
+				_ = writer.CloseWithError(translateErr)
+				return
+			}
+
+			// The reader is closed by downstream
+			if chunk != nil && writeChunk(chunk) != nil {
+				return
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+	}
+
+	if usage := translator.usageChunk(); usage != nil {
+		if writeChunk(usage) != nil {
+			return
+		}
+	}
+
+	_, _ = fmt.Fprint(writer, "data: [DONE]\n\n")
+	_ = writer.Close()
+}
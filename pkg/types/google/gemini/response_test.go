@@ -0,0 +1,99 @@
+package gemini
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/internal/gatewaytest"
+)
+
+func newResponse(status int, contentType string, body string) (*http.Response, *bufio.Reader) {
+	response := &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{contentType}},
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+
+	return response, bufio.NewReader(response.Body)
+}
+
+func TestTranslateResponse(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gemini-2.0-flash", "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	response, reader := newResponse(http.StatusOK, "application/json; charset=UTF-8", `{
+		"candidates": [{
+			"content": {"role": "model", "parts": [
+				{"text": "thinking", "thought": true},
+				{"text": "Let me check."},
+				{"functionCall": {"name": "get_weather", "args": {"city": "Paris"}}}
+			]},
+			"finishReason": "STOP",
+			"index": 0
+		}],
+		"usageMetadata": {"promptTokenCount": 10, "candidatesTokenCount": 5, "thoughtsTokenCount": 3, "totalTokenCount": 18},
+		"modelVersion": "gemini-2.0-flash-001",
+		"responseId": "abc"
+	}`)
+
+	translated, translatedReader, err := TranslateResponse(request, response, reader)
+	require.NoError(t, err)
+	assert.Equal(t, "application/json", translated.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(translatedReader)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), `"id":"chatcmpl-abc"`)
+	assert.Contains(t, string(body), `"model":"gemini-2.0-flash-001"`)
+	assert.Contains(t, string(body), `"finish_reason":"tool_calls"`)
+	assert.Contains(t, string(body), `"content":"Let me check."`)
+	assert.Contains(t, string(body), `"tool_calls":[{"function":{"arguments":"{\"city\":\"Paris\"}","name":"get_weather"},"id":"call_0_0","type":"function"}]`)
+	assert.Contains(t, string(body), `"usage":{"completion_tokens":8,"completion_tokens_details":{"reasoning_tokens":3},"prompt_tokens":10,"total_tokens":18}`)
+}
+
+func TestTranslateResponseError(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gemini-2.0-flash", "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	response, reader := newResponse(http.StatusTooManyRequests, "application/json", `[{"error": {"code": 429, "message": "Resource has been exhausted", "status": "RESOURCE_EXHAUSTED"}}]`)
+
+	translated, translatedReader, err := TranslateResponse(request, response, reader)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, translated.StatusCode)
+
+	body, err := io.ReadAll(translatedReader)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"error": {"message": "Resource has been exhausted", "type": "requests", "code": "rate_limit_exceeded", "param": null}}`, string(body))
+}
+
+func TestTranslateResponseStream(t *testing.T) {
+	_, request := gatewaytest.NewChatCompletionRequest(t, `{"model": "gemini-2.0-flash", "stream": true, "messages": [{"role": "user", "content": "Hi"}]}`)
+
+	response, reader := newResponse(http.StatusOK, "text/event-stream", strings.Join([]string{
+		`data: {"candidates": [{"content": {"role": "model", "parts": [{"text": "Hello"}]}, "index": 0}], "usageMetadata": {"promptTokenCount": 10}, "responseId": "abc"}`,
+		`data: {"candidates": [{"content": {"role": "model", "parts": [{"text": " world"}]}, "finishReason": "STOP", "index": 0}], "usageMetadata": {"promptTokenCount": 10, "candidatesTokenCount": 5, "totalTokenCount": 15}, "responseId": "abc"}`,
+	}, "\r\n\r\n")+"\r\n\r\n")
+
+	_, translatedReader, err := TranslateResponse(request, response, reader)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(translatedReader)
+	require.NoError(t, err)
+
+	events := strings.Split(strings.TrimSuffix(string(body), "\n\n"), "\n\n")
+	require.Len(t, events, 4)
+
+	assert.Contains(t, events[0], `"delta":{"content":"Hello","role":"assistant"}`)
+	assert.Contains(t, events[0], `"id":"chatcmpl-abc"`)
+	assert.Contains(t, events[1], `"delta":{"content":" world"}`)
+	assert.Contains(t, events[1], `"finish_reason":"stop"`)
+	assert.Contains(t, events[2], `"choices":[]`)
+	assert.Contains(t, events[2], `"usage":{"completion_tokens":5,"prompt_tokens":10,"total_tokens":15}`)
+	assert.Equal(t, "data: [DONE]", events[3])
+}
@@ -0,0 +1,160 @@
+package azureopenai
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/utils"
+)
+
+const (
+	DefaultAPIVersion = "2024-10-21"
+
+	APIKeyHeader = "api-key"
+)
+
+// BuildURL returns the URL of the deployment serving the type of requests,
+// e.g. https://{resource}.openai.azure.com/openai/deployments/{deployment}/chat/completions?api-version=2024-10-21,
+// the query parameters of the base URL are kept.
+func BuildURL(baseURL string, deployment string, apiVersion string, requestType object.RequestType) (*url.URL, error) {
+	var operation string
+
+	switch requestType { //nolint:exhaustive
+	case object.RequestTypeChatCompletions:
+		operation = "chat/completions"
+	case object.RequestTypeCompletions:
+		operation = "completions"
+	case object.RequestTypeEmbeddings:
+		operation = "embeddings"
+	case object.RequestTypeImageGenerations:
+		operation = "images/generations"
+	case object.RequestTypeImageEdits:
+		operation = "images/edits"
+	default:
+		return nil, openai.NewErrorBadRequest().WithMessage(fmt.Sprintf("%s requests are not supported by Azure OpenAI", requestType))
+	}
+
+	built, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Base URLs of resources may or may not end with /openai
+	built.Path = strings.TrimSuffix(strings.TrimSuffix(built.Path, "/"), "/openai") + "/openai/deployments/" + url.PathEscape(deployment) + "/" + operation
+	built.RawPath = ""
+
+	query := built.Query()
+	query.Set("api-version", lo.CoalesceOrEmpty(apiVersion, DefaultAPIVersion))
+	built.RawQuery = query.Encode()
+
+	return built, nil
+}
+
+// MapAPIKeyHeader moves the key in the Authorization header to the api-key
+// header, unless the api-key header is set already.
+func MapAPIKeyHeader(header http.Header) {
+	authorization := header.Get("Authorization")
+	if authorization == "" {
+		return
+	}
+
+	header.Del("Authorization")
+
+	if header.Get(APIKeyHeader) != "" {
+		return
+	}
+
+	key, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		key = authorization
+	}
+
+	header.Set(APIKeyHeader, strings.TrimSpace(key))
+}
+
+/*
+ParseErrorResponse translates the error envelopes of Azure OpenAI into the
+ones of OpenAI, returns nil if the body is not an error envelope.
+
+Example:
+
+	{
+		"error": {
+			"code": "DeploymentNotFound",
+			"message": "The API deployment for this resource does not exist."
+		}
+	}
+
+Or, when rejected by the API management in front of the resource:
+
+	{
+		"statusCode": 401,
+		"message": "Access denied due to invalid subscription key or wrong API endpoint."
+	}
+*/
+func ParseErrorResponse(status int, body []byte) *openai.ErrorResponse {
+	var parsed map[string]any
+
+	err := json.Unmarshal(body, &parsed)
+	if err != nil {
+		return nil
+	}
+
+	var (
+		code    string
+		message string
+		param   *string
+	)
+
+	if envelope := utils.GetByJSONPath[map[string]any](parsed, "{ .error }"); len(envelope) > 0 {
+		code, _ = utils.GetByJSONPathWithoutConvert(envelope, "{ .code }")
+		message = utils.GetByJSONPath[string](envelope, "{ .message }")
+		param = utils.GetByJSONPath[*string](envelope, "{ .param }")
+	} else if _, ok := parsed["statusCode"]; ok {
+		message = utils.GetByJSONPath[string](parsed, "{ .message }")
+	} else {
+		return nil
+	}
+
+	if code == "<nil>" || code == "null" {
+		code = ""
+	}
+
+	if message == "" {
+		message = fmt.Sprintf("upstream returned status code %d", status)
+	}
+
+	openaiError := openai.Error{
+		Message: message,
+		Param:   param,
+		Type:    "invalid_request_error",
+	}
+
+	switch {
+	case status == http.StatusUnauthorized:
+		openaiError.Code = lo.ToPtr("invalid_api_key")
+	case status == http.StatusNotFound && strings.EqualFold(code, "DeploymentNotFound"):
+		openaiError.Code = lo.ToPtr("model_not_found")
+	case status == http.StatusTooManyRequests:
+		openaiError.Type = "requests"
+		openaiError.Code = lo.ToPtr("rate_limit_exceeded")
+	case status >= http.StatusInternalServerError:
+		openaiError.Type = "server_error"
+		openaiError.Code = lo.EmptyableToPtr(code)
+	default:
+		openaiError.Code = lo.EmptyableToPtr(code)
+	}
+
+	errResp := openai.NewErrorResponse(status, openaiError)
+	errResp.FromUpstream = true
+	errResp.UpstreamErrorBody = string(body)
+
+	return errResp
+}
@@ -0,0 +1,93 @@
+package azureopenai
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/pkg/object"
+)
+
+func TestBuildURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		baseURL     string
+		apiVersion  string
+		requestType object.RequestType
+		expected    string
+	}{
+		{
+			name:        "resource endpoint",
+			baseURL:     "https://my-resource.openai.azure.com",
+			requestType: object.RequestTypeChatCompletions,
+			expected:    "https://my-resource.openai.azure.com/openai/deployments/gpt-4o/chat/completions?api-version=2024-10-21",
+		},
+		{
+			name:        "endpoint ending with /openai",
+			baseURL:     "https://my-resource.openai.azure.com/openai/",
+			apiVersion:  "2024-06-01",
+			requestType: object.RequestTypeEmbeddings,
+			expected:    "https://my-resource.openai.azure.com/openai/deployments/gpt-4o/embeddings?api-version=2024-06-01",
+		},
+		{
+			name:        "api management with query parameters",
+			baseURL:     "https://apim.example.com/azure?subscription=a",
+			requestType: object.RequestTypeImageGenerations,
+			expected:    "https://apim.example.com/azure/openai/deployments/gpt-4o/images/generations?api-version=2024-10-21&subscription=a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			built, err := BuildURL(tt.baseURL, "gpt-4o", tt.apiVersion, tt.requestType)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, built.String())
+		})
+	}
+
+	_, err := BuildURL("https://my-resource.openai.azure.com", "gpt-4o", "", object.RequestTypeTextToSpeech)
+	require.Error(t, err)
+}
+
+func TestMapAPIKeyHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer sk-azure")
+	MapAPIKeyHeader(header)
+	assert.Equal(t, "sk-azure", header.Get(APIKeyHeader))
+	assert.Empty(t, header.Get("Authorization"))
+
+	header = http.Header{}
+	header.Set("Authorization", "Bearer sk-other")
+	header.Set(APIKeyHeader, "sk-azure")
+	MapAPIKeyHeader(header)
+	assert.Equal(t, "sk-azure", header.Get(APIKeyHeader))
+	assert.Empty(t, header.Get("Authorization"))
+}
+
+func TestParseErrorResponse(t *testing.T) {
+	errResp := ParseErrorResponse(http.StatusNotFound, []byte(`{"error":{"code":"DeploymentNotFound","message":"The API deployment for this resource does not exist."}}`))
+	require.NotNil(t, errResp)
+	assert.Equal(t, http.StatusNotFound, errResp.Status)
+	assert.Equal(t, "model_not_found", *errResp.ErrorBody.Code)
+	assert.Equal(t, "invalid_request_error", errResp.ErrorBody.Type)
+	assert.True(t, errResp.FromUpstream)
+
+	errResp = ParseErrorResponse(http.StatusUnauthorized, []byte(`{"statusCode":401,"message":"Access denied due to invalid subscription key or wrong API endpoint."}`))
+	require.NotNil(t, errResp)
+	assert.Equal(t, "invalid_api_key", *errResp.ErrorBody.Code)
+	assert.Equal(t, "Access denied due to invalid subscription key or wrong API endpoint.", errResp.ErrorBody.Message)
+
+	errResp = ParseErrorResponse(http.StatusTooManyRequests, []byte(`{"error":{"code":"429","message":"Requests have exceeded the token rate limit."}}`))
+	require.NotNil(t, errResp)
+	assert.Equal(t, "rate_limit_exceeded", *errResp.ErrorBody.Code)
+	assert.Equal(t, "requests", errResp.ErrorBody.Type)
+
+	errResp = ParseErrorResponse(http.StatusBadRequest, []byte(`{"error":{"code":"content_filter","message":"filtered","param":"prompt"}}`))
+	require.NotNil(t, errResp)
+	assert.Equal(t, "content_filter", *errResp.ErrorBody.Code)
+	assert.Equal(t, "prompt", *errResp.ErrorBody.Param)
+
+	assert.Nil(t, ParseErrorResponse(http.StatusBadGateway, []byte(`<html>Bad Gateway</html>`)))
+}
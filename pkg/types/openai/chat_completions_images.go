@@ -0,0 +1,193 @@
+package openai
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	ImageDetailAuto = "auto"
+	ImageDetailLow  = "low"
+	ImageDetailHigh = "high"
+)
+
+// ImagePart is an image_url content part of the messages of chat completions
+// requests.
+type ImagePart struct {
+	// Message and Part are the indexes of the message and of the part in the
+	// content of the message.
+	Message int
+	Part    int
+	URL     string
+	Detail  string
+}
+
+// IsDataURL reports whether the image is embedded in the request as a data
+// URL, rather than fetched by the upstream.
+func (p ImagePart) IsDataURL() bool {
+	return strings.HasPrefix(p.URL, "data:")
+}
+
+// DecodeDataURL returns the media type and the content of the image embedded
+// as a base64 data URL.
+func (p ImagePart) DecodeDataURL() (string, []byte, error) {
+	return decodeImageDataURL(p.URL)
+}
+
+func decodeImageDataURL(url string) (string, []byte, error) {
+	mediaType, encoded, ok := strings.Cut(strings.TrimPrefix(url, "data:"), ";base64,")
+	if !strings.HasPrefix(url, "data:") || !ok {
+		return "", nil, errors.New("expected a base64 data URL")
+	}
+
+	if !strings.HasPrefix(mediaType, "image/") {
+		return "", nil, fmt.Errorf("unexpected media type %q of image", mediaType)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid base64 data: %w", err)
+	}
+
+	return mediaType, data, nil
+}
+
+// EncodeImageDataURL encodes the image as a base64 data URL.
+func EncodeImageDataURL(mediaType string, data []byte) string {
+	return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// validateContentParts validates the image_url parts of the content of the
+// messages, the other parts are left to the upstreams.
+func validateContentParts(body map[string]any) error {
+	messages, _ := body["messages"].([]any)
+
+	for i, message := range messages {
+		m, _ := message.(map[string]any)
+		content, _ := m["content"].([]any)
+
+		for j, part := range content {
+			param := fmt.Sprintf("messages[%d].content[%d]", i, j)
+
+			p, ok := part.(map[string]any)
+			if !ok {
+				return NewErrorInvalidValue(param, "expected an object.")
+			}
+
+			if _, ok := p["type"].(string); !ok {
+				return NewErrorMissingParameter(param + ".type")
+			}
+
+			if p["type"] != "image_url" {
+				continue
+			}
+
+			imageURL, ok := p["image_url"].(map[string]any)
+			if !ok {
+				return NewErrorMissingParameter(param + ".image_url")
+			}
+
+			url, _ := imageURL["url"].(string)
+			if url == "" {
+				return NewErrorMissingParameter(param + ".image_url.url")
+			}
+
+			switch imageURL["detail"] {
+			case nil, ImageDetailAuto, ImageDetailLow, ImageDetailHigh:
+			default:
+				return NewErrorInvalidValue(param+".image_url.detail", fmt.Sprintf("%v is not one of 'auto', 'low' and 'high'.", imageURL["detail"]))
+			}
+
+			if strings.HasPrefix(url, "data:") {
+				_, _, err := decodeImageDataURL(url)
+				if err != nil {
+					return NewErrorInvalidValue(param+".image_url.url", err.Error()+".")
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// ImageParts returns the image_url parts of the messages in order.
+func (r *ChatCompletionsRequest) ImageParts() []ImagePart {
+	messages, _ := r.bodyParsed["messages"].([]any)
+	parts := make([]ImagePart, 0)
+
+	for i, message := range messages {
+		m, _ := message.(map[string]any)
+		content, _ := m["content"].([]any)
+
+		for j, part := range content {
+			p, _ := part.(map[string]any)
+			if p["type"] != "image_url" {
+				continue
+			}
+
+			imageURL, _ := p["image_url"].(map[string]any)
+			url, _ := imageURL["url"].(string)
+			detail, _ := imageURL["detail"].(string)
+
+			parts = append(parts, ImagePart{
+				Message: i,
+				Part:    j,
+				URL:     url,
+				Detail:  detail,
+			})
+		}
+	}
+
+	return parts
+}
+
+// SetImageURL replaces the url of the image part, e.g. with the downscaled
+// image.
+func (r *ChatCompletionsRequest) SetImageURL(part ImagePart, url string) error {
+	var err error
+
+	r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, nil, NewReplace(fmt.Sprintf("/messages/%d/content/%d/image_url/url", part.Message, part.Part), url))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+const (
+	imageBaseTokens     = 85
+	imageTileTokens     = 170
+	imageTileSize       = 512
+	imageMaxSide        = 2048
+	imageMaxShorterSide = 768
+)
+
+// EstimateImageTokens estimates the input tokens of an image in the way
+// OpenAI counts them. Images of low detail cost the base tokens, the others
+// are scaled to fit in 2048x2048 and then to 768 pixels on the shorter side,
+// and cost the base tokens along with the tokens of each 512x512 tile.
+func EstimateImageTokens(width int, height int, detail string) uint64 {
+	if detail == ImageDetailLow || width <= 0 || height <= 0 {
+		return imageBaseTokens
+	}
+
+	w, h := float64(width), float64(height)
+
+	if longer := max(w, h); longer > imageMaxSide {
+		w, h = w*imageMaxSide/longer, h*imageMaxSide/longer
+	}
+
+	if shorter := min(w, h); shorter > imageMaxShorterSide {
+		w, h = w*imageMaxShorterSide/shorter, h*imageMaxShorterSide/shorter
+	}
+
+	tiles := ceilDiv(uint64(w), imageTileSize) * ceilDiv(uint64(h), imageTileSize)
+
+	return imageBaseTokens + imageTileTokens*tiles
+}
+
+func ceilDiv(a uint64, b uint64) uint64 {
+	return (a + b - 1) / b
+}
@@ -0,0 +1,79 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewChatCompletionRequest_ImageParts(t *testing.T) {
+	httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com", bytes.NewBufferString(`{
+		"model": "gpt-4o",
+		"messages": [
+			{"role": "system", "content": "You are a helpful assistant."},
+			{"role": "user", "content": [
+				{"type": "text", "text": "What's in the images?"},
+				{"type": "image_url", "image_url": {"url": "https://example.com/cat.png", "detail": "low"}},
+				{"type": "image_url", "image_url": {"url": "data:image/png;base64,aGVsbG8="}}
+			]}
+		]
+	}`))
+	require.NoError(t, err)
+
+	request, err := NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	parts := request.ImageParts()
+	require.Len(t, parts, 2)
+	assert.Equal(t, ImagePart{Message: 1, Part: 1, URL: "https://example.com/cat.png", Detail: ImageDetailLow}, parts[0])
+	assert.False(t, parts[0].IsDataURL())
+	assert.True(t, parts[1].IsDataURL())
+
+	mediaType, data, err := parts[1].DecodeDataURL()
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", mediaType)
+	assert.Equal(t, "hello", string(data))
+
+	err = request.SetImageURL(parts[1], EncodeImageDataURL("image/jpeg", []byte("world")))
+	require.NoError(t, err)
+	assert.Equal(t, "data:image/jpeg;base64,d29ybGQ=", request.ImageParts()[1].URL)
+}
+
+func TestNewChatCompletionRequest_InvalidImageParts(t *testing.T) {
+	testCases := []struct {
+		name  string
+		part  string
+		param string
+	}{
+		{name: "part not an object", part: `"hi"`, param: "messages[0].content[0]"},
+		{name: "image_url missing", part: `{"type": "image_url"}`, param: "messages[0].content[0].image_url"},
+		{name: "url missing", part: `{"type": "image_url", "image_url": {}}`, param: "messages[0].content[0].image_url.url"},
+		{name: "unknown detail", part: `{"type": "image_url", "image_url": {"url": "https://example.com/cat.png", "detail": "medium"}}`, param: "messages[0].content[0].image_url.detail"},
+		{name: "not base64", part: `{"type": "image_url", "image_url": {"url": "data:image/png,hello"}}`, param: "messages[0].content[0].image_url.url"},
+		{name: "not an image", part: `{"type": "image_url", "image_url": {"url": "data:text/plain;base64,aGVsbG8="}}`, param: "messages[0].content[0].image_url.url"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newChatCompletionsRequest(t, `{"messages": [{"role": "user", "content": [`+tc.part+`]}]}`)
+			require.Error(t, err)
+
+			var errResp *ErrorResponse
+			require.ErrorAs(t, err, &errResp)
+			assert.Equal(t, tc.param, *errResp.ErrorBody.Param)
+		})
+	}
+}
+
+func TestEstimateImageTokens(t *testing.T) {
+	assert.Equal(t, uint64(85), EstimateImageTokens(4096, 4096, ImageDetailLow))
+	// Scaled to 768x768, 4 tiles
+	assert.Equal(t, uint64(85+170*4), EstimateImageTokens(1024, 1024, ImageDetailHigh))
+	// Scaled to 2048x1024 and then 1536x768, 6 tiles
+	assert.Equal(t, uint64(85+170*6), EstimateImageTokens(4096, 2048, ImageDetailAuto))
+	assert.Equal(t, uint64(85+170), EstimateImageTokens(512, 512, ""))
+}
@@ -56,6 +56,16 @@ func NewChatCompletionRequest(httpRequest *http.Request) (*ChatCompletionsReques
 		return nil, NewErrorInvalidBody()
 	}
 
+	err = validateTools(parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateContentParts(parsed)
+	if err != nil {
+		return nil, err
+	}
+
 	req := &ChatCompletionsRequest{
 		Model:  utils.GetByJSONPath[string](parsed, "{ .model }"),
 		Stream: utils.GetByJSONPath[bool](parsed, "{ .stream }"),
@@ -93,6 +103,21 @@ func (r *ChatCompletionsRequest) GetModel() string {
 	return r.Model
 }
 
+func (r *ChatCompletionsRequest) GetMessages() []map[string]any {
+	return utils.GetByJSONPath[[]map[string]any](r.bodyParsed, "{ .messages }")
+}
+
+func (r *ChatCompletionsRequest) SetMessages(messages []map[string]any) error {
+	var err error
+
+	r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, nil, NewAdd("/messages", messages))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *ChatCompletionsRequest) SetModel(model string) error {
 	var err error
 
@@ -106,6 +131,33 @@ func (r *ChatCompletionsRequest) SetModel(model string) error {
 	return nil
 }
 
+// SetBody replaces the body of the request, which is validated as the body
+// of a new request.
+func (r *ChatCompletionsRequest) SetBody(body []byte) error {
+	buffer, parsed, err := utils.ReadAsJSON(bytes.NewReader(body))
+	if err != nil {
+		return NewErrorInvalidBody()
+	}
+
+	err = validateTools(parsed)
+	if err != nil {
+		return err
+	}
+
+	err = validateContentParts(parsed)
+	if err != nil {
+		return err
+	}
+
+	r.Model = utils.GetByJSONPath[string](parsed, "{ .model }")
+	r.Stream = utils.GetByJSONPath[bool](parsed, "{ .stream }")
+	r.StreamOptions.IncludeUsage = utils.GetByJSONPath[bool](parsed, "{ .stream_options.include_usage }")
+	r.bodyBuffer = buffer
+	r.bodyParsed = parsed
+
+	return nil
+}
+
 func (r *ChatCompletionsRequest) SetDefaultParams(params map[string]*structpb.Value) error {
 	for k, v := range params {
 		if _, exists := r.bodyParsed[k]; exists {
@@ -172,3 +224,7 @@ func (r *ChatCompletionsRequest) GetRequestType() object.RequestType {
 func (r *ChatCompletionsRequest) GetRawRequest() *http.Request {
 	return r.incomingRequest
 }
+
+func (r *ChatCompletionsRequest) GetBodyParsed() map[string]any {
+	return r.bodyParsed
+}
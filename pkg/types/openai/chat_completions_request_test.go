@@ -129,3 +129,22 @@ func TestSetOverrideParams(t *testing.T) {
 		"include_usage": true,
 	}, chatReq.bodyParsed["stream_options"])
 }
+
+func TestSetBody(t *testing.T) {
+	httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com", bytes.NewBufferString(`{"model": "some", "messages": [{"role": "user", "content": "hi"}]}`))
+	require.NoError(t, err)
+
+	request, err := NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	err = request.SetBody([]byte(`{"model": "other", "stream": true, "messages": [{"role": "user", "content": "hello"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, "other", request.GetModel())
+	assert.True(t, request.IsStream())
+	assert.Equal(t, "hello", request.GetMessages()[0]["content"])
+	assert.JSONEq(t, `{"model": "other", "stream": true, "messages": [{"role": "user", "content": "hello"}]}`, string(lo.Must(json.Marshal(request))))
+
+	err = request.SetBody([]byte(`{"model": "other", "messages": [], "tools": [{"type": "function"}]}`))
+	require.Error(t, err)
+	assert.True(t, request.IsStream())
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/samber/lo"
+
 	"knoway.dev/pkg/object"
 	"knoway.dev/pkg/utils"
 )
@@ -20,6 +22,11 @@ type ChatCompletionsResponse struct {
 	Error  *ErrorResponse        `json:"error,omitempty"`
 	Stream bool                  `json:"stream"`
 
+	// SystemFingerprint identifies the configuration of the backend the
+	// response was generated with, together with the seed of the request it
+	// tells whether the response is reproducible.
+	SystemFingerprint string `json:"system_fingerprint,omitempty"`
+
 	request          object.LLMRequest
 	responseBody     json.RawMessage
 	bodyParsed       map[string]any
@@ -64,6 +71,7 @@ func (r *ChatCompletionsResponse) processBytes(bs []byte, response *http.Respons
 	r.bodyParsed = body
 
 	r.Model = utils.GetByJSONPath[string](body, "{ .model }")
+	r.SystemFingerprint = utils.GetByJSONPath[string](body, "{ .system_fingerprint }")
 	usageMap := utils.GetByJSONPath[map[string]any](body, "{ .usage }")
 
 	r.Usage, err = utils.FromMap[ChatCompletionsUsage](usageMap)
@@ -83,6 +91,12 @@ func (r *ChatCompletionsResponse) processBytes(bs []byte, response *http.Respons
 	return nil
 }
 
+// SetBody replaces the body of the response, the usage and the other fields
+// are parsed from it again.
+func (r *ChatCompletionsResponse) SetBody(body []byte) error {
+	return r.processBytes(body, r.outgoingResponse)
+}
+
 func (r *ChatCompletionsResponse) MarshalJSON() ([]byte, error) {
 	return r.responseBody, nil
 }
@@ -115,6 +129,38 @@ func (r *ChatCompletionsResponse) SetModel(model string) error {
 	return nil
 }
 
+func (r *ChatCompletionsResponse) GetSystemFingerprint() string {
+	return r.SystemFingerprint
+}
+
+// GetChoiceMessage returns the message of the first choice.
+func (r *ChatCompletionsResponse) GetChoiceMessage() map[string]any {
+	return utils.GetByJSONPath[map[string]any](r.bodyParsed, "{ .choices[0].message }")
+}
+
+// GetChoiceMessages returns the messages of the choices in the order of the
+// response.
+func (r *ChatCompletionsResponse) GetChoiceMessages() []map[string]any {
+	choices := utils.GetByJSONPath[[]map[string]any](r.bodyParsed, "{ .choices }")
+
+	return lo.Map(choices, func(choice map[string]any, _ int) map[string]any {
+		return utils.GetByJSONPath[map[string]any](choice, "{ .message }")
+	})
+}
+
+// SetChoiceMessageContent replaces the content of the message of the i-th
+// choice, in the order of GetChoiceMessages.
+func (r *ChatCompletionsResponse) SetChoiceMessageContent(i int, content any) error {
+	var err error
+
+	r.responseBody, r.bodyParsed, err = modifyBytesBodyAndParsed(r.responseBody, NewAdd(fmt.Sprintf("/choices/%d/message/content", i), content))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *ChatCompletionsResponse) GetUsage() object.LLMUsage {
 	return r.Usage
 }
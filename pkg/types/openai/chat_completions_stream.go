@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"sync"
 
 	"knoway.dev/pkg/object"
@@ -18,8 +19,9 @@ import (
 var _ object.LLMChunkResponse = (*ChatCompletionStreamChunk)(nil)
 
 type ChatCompletionStreamChunk struct {
-	Model string                `json:"model"`
-	Usage *ChatCompletionsUsage `json:"usage,omitempty"`
+	Model             string                `json:"model"`
+	Usage             *ChatCompletionsUsage `json:"usage,omitempty"`
+	SystemFingerprint string                `json:"system_fingerprint,omitempty"`
 
 	response     object.LLMStreamResponse
 	responseBody json.RawMessage
@@ -43,8 +45,11 @@ func NewChatCompletionStreamChunk(streamResp *ChatCompletionStreamResponse, bs [
 
 	resp.response = streamResp
 	resp.Model = model
+	resp.SystemFingerprint = utils.GetByJSONPath[string](resp.bodyParsed, "{ .system_fingerprint }")
 	resp.isFirst = streamResp.IsFirst()
 
+	streamResp.setSystemFingerprint(resp.SystemFingerprint)
+
 	if streamResp.GetModel() == "" {
 		err = streamResp.SetModel(model)
 		if err != nil {
@@ -84,8 +89,11 @@ func NewUsageChatCompletionStreamChunk(streamResp *ChatCompletionStreamResponse,
 	resp.isUsage = true
 	resp.response = streamResp
 	resp.Model = model
+	resp.SystemFingerprint = utils.GetByJSONPath[string](resp.bodyParsed, "{ .system_fingerprint }")
 	resp.isFirst = streamResp.IsFirst()
 
+	streamResp.setSystemFingerprint(resp.SystemFingerprint)
+
 	if streamResp.GetModel() == "" {
 		err = streamResp.SetModel(model)
 		if err != nil {
@@ -153,6 +161,48 @@ func (r *ChatCompletionStreamChunk) processBytes(bs []byte) error {
 	return nil
 }
 
+// GetDeltaContent returns the delta content of the first choice.
+func (r *ChatCompletionStreamChunk) GetDeltaContent() string {
+	return utils.GetByJSONPath[string](r.bodyParsed, "{ .choices[0].delta.content }")
+}
+
+// ChatCompletionStreamChoiceDelta is the delta of a choice of a chunk.
+type ChatCompletionStreamChoiceDelta struct {
+	Index        int
+	Content      string
+	FinishReason string
+}
+
+// GetChoiceDeltas returns the deltas of the choices in the order of the
+// chunk, which may differ from the order of their indexes.
+func (r *ChatCompletionStreamChunk) GetChoiceDeltas() []ChatCompletionStreamChoiceDelta {
+	choices := utils.GetByJSONPath[[]map[string]any](r.bodyParsed, "{ .choices }")
+
+	deltas := make([]ChatCompletionStreamChoiceDelta, 0, len(choices))
+	for _, choice := range choices {
+		deltas = append(deltas, ChatCompletionStreamChoiceDelta{
+			Index:        utils.GetByJSONPath[int](choice, "{ .index }"),
+			Content:      utils.GetByJSONPath[string](choice, "{ .delta.content }"),
+			FinishReason: utils.GetByJSONPath[string](choice, "{ .finish_reason }"),
+		})
+	}
+
+	return deltas
+}
+
+// SetChoiceDeltaContent replaces the delta content of the i-th choice of the
+// chunk, in the order of GetChoiceDeltas.
+func (r *ChatCompletionStreamChunk) SetChoiceDeltaContent(i int, content string) error {
+	var err error
+
+	r.responseBody, r.bodyParsed, err = modifyBytesBodyAndParsed(r.responseBody, NewAdd(fmt.Sprintf("/choices/%d/delta/content", i), content))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (r *ChatCompletionStreamChunk) GetResponse() object.LLMStreamResponse {
 	return r.response
 }
@@ -194,6 +244,9 @@ type ChatCompletionStreamResponse struct {
 	Usage *ChatCompletionsUsage `json:"usage,omitempty"`
 	Error *ErrorResponse        `json:"error,omitempty"`
 
+	// SystemFingerprint is the first one found in the chunks of the stream.
+	SystemFingerprint string `json:"system_fingerprint,omitempty"`
+
 	reader           *bufio.Reader
 	request          object.LLMRequest
 	outgoingResponse *http.Response
@@ -202,12 +255,11 @@ type ChatCompletionStreamResponse struct {
 	hasErrorPrefix   bool
 	errorEventBuffer *bytes.Buffer
 	isDone           bool
+	isFinished       bool
 	chunkNum         int
-	eofCancelCtx     context.Context
-	eofCancelFunc    context.CancelFunc
 
-	onChunkCallbacks      []func(ctx context.Context, stream object.LLMStreamResponse, chunk object.LLMChunkResponse)
-	onChunkCallbacksMutex sync.Mutex
+	callbacks   *chunkCallbacks
+	beforeChunk []func(chunk object.LLMChunkResponse) error
 
 	// Mutex for locking
 	mu sync.Mutex
@@ -220,7 +272,15 @@ func NewChatCompletionStreamResponse(request object.LLMRequest, response *http.R
 	resp.request = request
 	resp.outgoingResponse = response
 	resp.errorEventBuffer = new(bytes.Buffer)
-	resp.eofCancelCtx, resp.eofCancelFunc = context.WithCancel(context.Background())
+	resp.callbacks = newChunkCallbacks()
+
+	// The stream may be abandoned before EOF, e.g. the client disconnects,
+	// finish it once the request is served so that WaitUntilEOF returns.
+	if request != nil && request.GetRawRequest() != nil {
+		context.AfterFunc(request.GetRawRequest().Context(), func() {
+			resp.callbacks.dispatch(resp, NewEmptyChatCompletionStreamChunk(resp), true)
+		})
+	}
 
 	return resp, nil
 }
@@ -244,42 +304,73 @@ func (r *ChatCompletionStreamResponse) IsEOF() bool {
 	return r.isDone
 }
 
+// WaitUntilEOF returns a channel closed once the stream finishes and every
+// callback of the chunks returns.
 func (r *ChatCompletionStreamResponse) WaitUntilEOF() <-chan object.LLMStreamResponse {
 	ch := make(chan object.LLMStreamResponse)
 
 	go func() {
-		<-r.eofCancelCtx.Done()
+		<-r.callbacks.done.Done()
 		close(ch)
 	}()
 
 	return ch
 }
 
+// OnChunk registers a callback invoked for every chunk read, the callbacks
+// are invoked in order on a worker detached from NextChunk, the context given
+// is canceled right before the last chunk.
 func (r *ChatCompletionStreamResponse) OnChunk(cb func(ctx context.Context, stream object.LLMStreamResponse, chunk object.LLMChunkResponse)) {
-	r.onChunkCallbacksMutex.Lock()
-	defer r.onChunkCallbacksMutex.Unlock()
+	r.callbacks.add(cb)
+}
+
+// BeforeChunk registers a callback invoked for every chunk read before
+// NextChunk returns it. Unlike OnChunk, the callbacks are invoked in order on
+// the reader of the stream, so they may modify the chunks before the chunks
+// are sent to the client. NextChunk fails with the error of the callbacks.
+func (r *ChatCompletionStreamResponse) BeforeChunk(cb func(chunk object.LLMChunkResponse) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	r.onChunkCallbacks = append(r.onChunkCallbacks, cb)
+	r.beforeChunk = append(r.beforeChunk, cb)
+}
+
+func (r *ChatCompletionStreamResponse) runBeforeChunk(chunk object.LLMChunkResponse) error {
+	r.mu.Lock()
+	callbacks := slices.Clone(r.beforeChunk)
+	r.mu.Unlock()
+
+	for _, cb := range callbacks {
+		err := cb(chunk)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ChatCompletionStreamResponse) finish() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.isFinished = true
 }
 
 func (r *ChatCompletionStreamResponse) NextChunk() (object.LLMChunkResponse, error) {
 	var chunk object.LLMChunkResponse
 
 	defer func() {
-		r.onChunkCallbacksMutex.Lock()
-
-		for _, cb := range r.onChunkCallbacks {
-			cb(r.eofCancelCtx, r, chunk)
-		}
+		r.mu.Lock()
+		finished := r.isFinished
+		r.mu.Unlock()
 
-		r.onChunkCallbacksMutex.Unlock()
+		r.callbacks.dispatch(r, chunk, finished)
 	}()
 
 	line, err := r.reader.ReadBytes('\n')
 	if err != nil || r.hasErrorPrefix {
-		r.mu.Lock()
-		r.eofCancelFunc()
-		r.mu.Unlock()
+		r.finish()
 
 		// TODO: handle error
 		chunk = NewEmptyChatCompletionStreamChunk(r)
@@ -301,9 +392,7 @@ func (r *ChatCompletionStreamResponse) NextChunk() (object.LLMChunkResponse, err
 
 		_, writeErr := r.errorEventBuffer.Write(noSpaceLine)
 		if writeErr != nil {
-			r.mu.Lock()
-			r.eofCancelFunc()
-			r.mu.Unlock()
+			r.finish()
 
 			chunk = NewEmptyChatCompletionStreamChunk(r)
 
@@ -324,20 +413,23 @@ func (r *ChatCompletionStreamResponse) NextChunk() (object.LLMChunkResponse, err
 	if string(noPrefixLine) == "[DONE]" {
 		r.mu.Lock()
 		r.isDone = true
-		r.eofCancelFunc()
+		r.isFinished = true
 		r.mu.Unlock()
 
 		chunk = NewDoneChatCompletionStreamChunk(r)
 
+		err = r.runBeforeChunk(chunk)
+		if err != nil {
+			return chunk, err
+		}
+
 		return chunk, io.EOF
 	}
 
 	if bytes.Contains(noPrefixLine, usageCompletionTokens) {
 		usageChunk, err := NewUsageChatCompletionStreamChunk(r, noPrefixLine)
 		if err != nil {
-			r.mu.Lock()
-			r.eofCancelFunc()
-			r.mu.Unlock()
+			r.finish()
 
 			return chunk, err
 		}
@@ -348,14 +440,26 @@ func (r *ChatCompletionStreamResponse) NextChunk() (object.LLMChunkResponse, err
 
 		chunk = usageChunk
 
+		err = r.runBeforeChunk(chunk)
+		if err != nil {
+			r.finish()
+
+			return chunk, err
+		}
+
 		return chunk, nil
 	}
 
 	chunk, err = NewChatCompletionStreamChunk(r, noPrefixLine)
 	if err != nil {
-		r.mu.Lock()
-		r.eofCancelFunc()
-		r.mu.Unlock()
+		r.finish()
+
+		return chunk, err
+	}
+
+	err = r.runBeforeChunk(chunk)
+	if err != nil {
+		r.finish()
 
 		return chunk, err
 	}
@@ -382,6 +486,26 @@ func (r *ChatCompletionStreamResponse) SetModel(model string) error {
 	return nil
 }
 
+func (r *ChatCompletionStreamResponse) GetSystemFingerprint() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.SystemFingerprint
+}
+
+func (r *ChatCompletionStreamResponse) setSystemFingerprint(fingerprint string) {
+	if fingerprint == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.SystemFingerprint == "" {
+		r.SystemFingerprint = fingerprint
+	}
+}
+
 func (r *ChatCompletionStreamResponse) GetUsage() object.LLMUsage {
 	return r.Usage
 }
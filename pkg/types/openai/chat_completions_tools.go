@@ -0,0 +1,212 @@
+package openai
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// maxTools is the max number of tools of a request, the same as the one of
+// OpenAI.
+const maxTools = 128
+
+var functionNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// validateTools validates the tools, tool_choice, parallel_tool_calls and
+// response_format of chat completions requests, so that malformed ones are
+// rejected before they are translated for upstreams, which would otherwise
+// fail with errors in their own terms, or silently drop the fields.
+func validateTools(body map[string]any) error {
+	names, err := validateToolDefinitions(body["tools"])
+	if err != nil {
+		return err
+	}
+
+	err = validateToolChoice(body["tool_choice"], names)
+	if err != nil {
+		return err
+	}
+
+	if parallel, ok := body["parallel_tool_calls"]; ok && parallel != nil {
+		if _, ok := parallel.(bool); !ok {
+			return NewErrorInvalidValue("parallel_tool_calls", "expected a boolean.")
+		}
+
+		if names == nil {
+			return NewErrorInvalidValue("parallel_tool_calls", "'parallel_tool_calls' is only allowed when 'tools' are specified.")
+		}
+	}
+
+	return validateResponseFormat(body["response_format"])
+}
+
+// validateToolDefinitions returns the names of the tools, nil if there are
+// no tools.
+func validateToolDefinitions(tools any) (map[string]struct{}, error) {
+	if tools == nil {
+		return nil, nil
+	}
+
+	ts, ok := tools.([]any)
+	if !ok {
+		return nil, NewErrorInvalidValue("tools", "expected an array of tools.")
+	}
+
+	if len(ts) == 0 {
+		return nil, nil
+	}
+
+	if len(ts) > maxTools {
+		return nil, NewErrorArrayTooLong("tools", maxTools, len(ts))
+	}
+
+	names := make(map[string]struct{}, len(ts))
+
+	for i, tool := range ts {
+		param := fmt.Sprintf("tools[%d]", i)
+
+		t, ok := tool.(map[string]any)
+		if !ok {
+			return nil, NewErrorInvalidValue(param, "expected an object.")
+		}
+
+		typ, ok := t["type"].(string)
+		if !ok {
+			return nil, NewErrorMissingParameter(param + ".type")
+		}
+
+		// Built-in tools of the providers, such as web_search, are passed
+		// through, the provider translators reject the ones they don't support
+		if typ != "function" && typ != "custom" {
+			continue
+		}
+
+		definition, ok := t[typ].(map[string]any)
+		if !ok {
+			return nil, NewErrorMissingParameter(param + "." + typ)
+		}
+
+		name, _ := definition["name"].(string)
+		if !functionNamePattern.MatchString(name) {
+			return nil, NewErrorInvalidValue(param+"."+typ+".name", fmt.Sprintf("%q does not match pattern %s.", name, functionNamePattern))
+		}
+
+		if _, ok := names[name]; ok {
+			return nil, NewErrorInvalidValue(param+"."+typ+".name", fmt.Sprintf("duplicated tool name %q.", name))
+		}
+
+		names[name] = struct{}{}
+
+		if parameters, ok := definition["parameters"]; ok && parameters != nil {
+			if _, ok := parameters.(map[string]any); !ok {
+				return nil, NewErrorInvalidValue(param+"."+typ+".parameters", "expected a JSON schema object.")
+			}
+		}
+
+		if strict, ok := definition["strict"]; ok && strict != nil {
+			if _, ok := strict.(bool); !ok {
+				return nil, NewErrorInvalidValue(param+"."+typ+".strict", "expected a boolean.")
+			}
+		}
+	}
+
+	return names, nil
+}
+
+func validateToolChoice(toolChoice any, names map[string]struct{}) error {
+	switch choice := toolChoice.(type) {
+	case nil:
+		return nil
+	case string:
+		switch choice {
+		case "none", "auto":
+			return nil
+		case "required":
+			if names == nil {
+				return NewErrorInvalidValue("tool_choice", "'tool_choice' is only allowed when 'tools' are specified.")
+			}
+
+			return nil
+		default:
+			return NewErrorInvalidValue("tool_choice", fmt.Sprintf("%q is not one of 'none', 'auto' and 'required'.", choice))
+		}
+	case map[string]any:
+		if names == nil {
+			return NewErrorInvalidValue("tool_choice", "'tool_choice' is only allowed when 'tools' are specified.")
+		}
+
+		typ, ok := choice["type"].(string)
+		if !ok {
+			return NewErrorMissingParameter("tool_choice.type")
+		}
+
+		if typ != "function" && typ != "custom" {
+			return nil
+		}
+
+		definition, _ := choice[typ].(map[string]any)
+		name, _ := definition["name"].(string)
+		if _, ok := names[name]; !ok {
+			return NewErrorInvalidValue("tool_choice."+typ+".name", fmt.Sprintf("tool %q is not one of 'tools'.", name))
+		}
+
+		return nil
+	default:
+		return NewErrorInvalidValue("tool_choice", "expected a string or an object.")
+	}
+}
+
+func validateResponseFormat(responseFormat any) error {
+	if responseFormat == nil {
+		return nil
+	}
+
+	format, ok := responseFormat.(map[string]any)
+	if !ok {
+		return NewErrorInvalidValue("response_format", "expected an object.")
+	}
+
+	switch format["type"] {
+	case "text", "json_object":
+		return nil
+	case "json_schema":
+	default:
+		return NewErrorInvalidValue("response_format.type", fmt.Sprintf("unsupported type %v, expected 'text', 'json_object' or 'json_schema'.", format["type"]))
+	}
+
+	jsonSchema, ok := format["json_schema"].(map[string]any)
+	if !ok {
+		return NewErrorMissingParameter("response_format.json_schema")
+	}
+
+	name, _ := jsonSchema["name"].(string)
+	if !functionNamePattern.MatchString(name) {
+		return NewErrorInvalidValue("response_format.json_schema.name", fmt.Sprintf("%q does not match pattern %s.", name, functionNamePattern))
+	}
+
+	if schema, ok := jsonSchema["schema"]; ok && schema != nil {
+		if _, ok := schema.(map[string]any); !ok {
+			return NewErrorInvalidValue("response_format.json_schema.schema", "expected a JSON schema object.")
+		}
+	}
+
+	if strict, ok := jsonSchema["strict"]; ok && strict != nil {
+		if _, ok := strict.(bool); !ok {
+			return NewErrorInvalidValue("response_format.json_schema.strict", "expected a boolean.")
+		}
+	}
+
+	return nil
+}
+
+// UsesTools reports whether the request gives tools to the model.
+func (r *ChatCompletionsRequest) UsesTools() bool {
+	tools, _ := r.bodyParsed["tools"].([]any)
+	return len(tools) > 0
+}
+
+// UsesJSONSchema reports whether the request asks for responses conforming
+// to a JSON schema.
+func (r *ChatCompletionsRequest) UsesJSONSchema() bool {
+	format, _ := r.bodyParsed["response_format"].(map[string]any)
+	return format["type"] == "json_schema"
+}
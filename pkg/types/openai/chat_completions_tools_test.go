@@ -0,0 +1,76 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newChatCompletionsRequest(t *testing.T, body string) (*ChatCompletionsRequest, error) {
+	t.Helper()
+
+	httpRequest, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://example.com", bytes.NewBufferString(body))
+	require.NoError(t, err)
+
+	return NewChatCompletionRequest(httpRequest)
+}
+
+func TestNewChatCompletionRequest_Tools(t *testing.T) {
+	request, err := newChatCompletionsRequest(t, `{
+		"model": "gpt-4o",
+		"messages": [{"role": "user", "content": "What's the weather in Paris?"}],
+		"tools": [
+			{"type": "function", "function": {"name": "get_weather", "parameters": {"type": "object"}, "strict": true}},
+			{"type": "web_search"}
+		],
+		"tool_choice": {"type": "function", "function": {"name": "get_weather"}},
+		"parallel_tool_calls": false,
+		"response_format": {"type": "json_schema", "json_schema": {"name": "weather", "schema": {"type": "object"}, "strict": true}}
+	}`)
+	require.NoError(t, err)
+	assert.True(t, request.UsesTools())
+	assert.True(t, request.UsesJSONSchema())
+
+	request, err = newChatCompletionsRequest(t, `{"model": "gpt-4o", "messages": [], "tools": [], "response_format": {"type": "json_object"}}`)
+	require.NoError(t, err)
+	assert.False(t, request.UsesTools())
+	assert.False(t, request.UsesJSONSchema())
+}
+
+func TestNewChatCompletionRequest_InvalidTools(t *testing.T) {
+	testCases := []struct {
+		name  string
+		body  string
+		param string
+	}{
+		{name: "tools not an array", body: `{"tools": {"type": "function"}}`, param: "tools"},
+		{name: "tool without type", body: `{"tools": [{"function": {"name": "get_weather"}}]}`, param: "tools[0].type"},
+		{name: "function missing", body: `{"tools": [{"type": "function"}]}`, param: "tools[0].function"},
+		{name: "invalid function name", body: `{"tools": [{"type": "function", "function": {"name": "get weather"}}]}`, param: "tools[0].function.name"},
+		{name: "duplicated function name", body: `{"tools": [{"type": "function", "function": {"name": "f"}}, {"type": "function", "function": {"name": "f"}}]}`, param: "tools[1].function.name"},
+		{name: "parameters not an object", body: `{"tools": [{"type": "function", "function": {"name": "f", "parameters": "object"}}]}`, param: "tools[0].function.parameters"},
+		{name: "tool_choice without tools", body: `{"tool_choice": "required"}`, param: "tool_choice"},
+		{name: "unknown tool_choice", body: `{"tools": [{"type": "function", "function": {"name": "f"}}], "tool_choice": "any"}`, param: "tool_choice"},
+		{name: "tool_choice of unknown function", body: `{"tools": [{"type": "function", "function": {"name": "f"}}], "tool_choice": {"type": "function", "function": {"name": "g"}}}`, param: "tool_choice.function.name"},
+		{name: "parallel_tool_calls without tools", body: `{"parallel_tool_calls": true}`, param: "parallel_tool_calls"},
+		{name: "unknown response_format", body: `{"response_format": {"type": "yaml"}}`, param: "response_format.type"},
+		{name: "json_schema missing", body: `{"response_format": {"type": "json_schema"}}`, param: "response_format.json_schema"},
+		{name: "json_schema strict not a boolean", body: `{"response_format": {"type": "json_schema", "json_schema": {"name": "weather", "strict": "yes"}}}`, param: "response_format.json_schema.strict"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newChatCompletionsRequest(t, tc.body)
+			require.Error(t, err)
+
+			var errResp *ErrorResponse
+			require.ErrorAs(t, err, &errResp)
+			assert.Equal(t, http.StatusBadRequest, errResp.Status)
+			assert.Contains(t, errResp.Error(), "'"+tc.param+"'")
+		})
+	}
+}
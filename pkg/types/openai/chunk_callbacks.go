@@ -0,0 +1,132 @@
+package openai
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"knoway.dev/pkg/object"
+)
+
+const (
+	// chunkCallbacksQueueSize is the number of chunks the worker may lag
+	// behind, reading further chunks blocks until the worker catches up so
+	// that no usage is ever dropped.
+	chunkCallbacksQueueSize = 256
+)
+
+type chunkCallback func(ctx context.Context, stream object.LLMStreamResponse, chunk object.LLMChunkResponse)
+
+type queuedChunk struct {
+	chunk object.LLMChunkResponse
+	last  bool
+}
+
+// chunkCallbacks invokes the callbacks of chunks on a detached worker, so that
+// forwarding chunks to the client never waits for post-processing, e.g. usage
+// accumulation and reports. Chunks are delivered in order.
+//
+// The context given to the callbacks is canceled right before the last chunk
+// is delivered, and done is closed once every callback of the last chunk
+// returns.
+type chunkCallbacks struct {
+	callbacksMutex sync.Mutex
+	callbacks      []chunkCallback
+
+	dispatchMutex sync.Mutex
+	queue         chan queuedChunk
+	closed        bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	done       context.Context
+	cancelDone context.CancelFunc
+}
+
+func newChunkCallbacks() *chunkCallbacks {
+	c := new(chunkCallbacks)
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	c.done, c.cancelDone = context.WithCancel(context.Background())
+
+	return c
+}
+
+func (c *chunkCallbacks) add(cb chunkCallback) {
+	c.callbacksMutex.Lock()
+	defer c.callbacksMutex.Unlock()
+
+	c.callbacks = append(c.callbacks, cb)
+}
+
+func (c *chunkCallbacks) hasCallbacks() bool {
+	c.callbacksMutex.Lock()
+	defer c.callbacksMutex.Unlock()
+
+	return len(c.callbacks) > 0
+}
+
+// dispatch queues the chunk for the callbacks, chunks dispatched after the
+// last one are ignored.
+func (c *chunkCallbacks) dispatch(stream object.LLMStreamResponse, chunk object.LLMChunkResponse, last bool) {
+	c.dispatchMutex.Lock()
+	defer c.dispatchMutex.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	if c.queue == nil {
+		if !c.hasCallbacks() {
+			if last {
+				c.closed = true
+				c.cancel()
+				c.cancelDone()
+			}
+
+			return
+		}
+
+		c.queue = make(chan queuedChunk, chunkCallbacksQueueSize)
+		go c.run(stream)
+	}
+
+	c.queue <- queuedChunk{chunk: chunk, last: last}
+
+	if last {
+		c.closed = true
+		close(c.queue)
+	}
+}
+
+func (c *chunkCallbacks) run(stream object.LLMStreamResponse) {
+	defer c.cancelDone()
+	defer c.cancel()
+
+	for queued := range c.queue {
+		if queued.last {
+			c.cancel()
+		}
+
+		c.callbacksMutex.Lock()
+		callbacks := slices.Clone(c.callbacks)
+		c.callbacksMutex.Unlock()
+
+		for _, cb := range callbacks {
+			c.invoke(cb, stream, queued.chunk)
+		}
+	}
+}
+
+// invoke calls the callback, recovering from panics since the worker is
+// detached from the request.
+func (c *chunkCallbacks) invoke(cb chunkCallback, stream object.LLMStreamResponse, chunk object.LLMChunkResponse) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("panic in stream chunk callback", slog.Any("panic", r))
+		}
+	}()
+
+	cb(c.ctx, stream, chunk)
+}
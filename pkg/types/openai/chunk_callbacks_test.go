@@ -0,0 +1,118 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/pkg/object"
+)
+
+func TestChatCompletionStreamResponse_OnChunk(t *testing.T) {
+	body := strings.Join([]string{
+		`data: {"model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": "Hello"}}]}`,
+		`data: {"model": "gpt-4o", "choices": [{"index": 0, "delta": {"content": " world"}}]}`,
+		`data: [DONE]`,
+	}, "\n\n") + "\n\n"
+
+	stream, err := NewChatCompletionStreamResponse(&ChatCompletionsRequest{}, nil, bufio.NewReader(strings.NewReader(body)))
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	contents := make([]string, 0)
+	canceled := make([]bool, 0)
+
+	stream.OnChunk(func(ctx context.Context, _ object.LLMStreamResponse, chunk object.LLMChunkResponse) {
+		// Slow callbacks never block reading chunks
+		<-release
+
+		if c, ok := chunk.(*ChatCompletionStreamChunk); ok && !c.IsEmpty() && !c.IsDone() {
+			contents = append(contents, c.GetDeltaContent())
+		}
+
+		canceled = append(canceled, ctx.Err() != nil)
+	})
+
+	for {
+		_, err := stream.NextChunk()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+	}
+
+	select {
+	case <-stream.WaitUntilEOF():
+		require.Fail(t, "stream finished before callbacks returned")
+	default:
+	}
+
+	close(release)
+	<-stream.WaitUntilEOF()
+
+	assert.Equal(t, []string{"Hello", " world"}, contents)
+	// Empty chunks between events are delivered as well
+	require.NotEmpty(t, canceled)
+	assert.True(t, canceled[len(canceled)-1])
+
+	for _, c := range canceled[:len(canceled)-1] {
+		assert.False(t, c)
+	}
+}
+
+func TestChatCompletionStreamResponse_WaitUntilEOFWithoutCallbacks(t *testing.T) {
+	stream, err := NewChatCompletionStreamResponse(&ChatCompletionsRequest{}, nil, bufio.NewReader(strings.NewReader("data: [DONE]\n\n")))
+	require.NoError(t, err)
+
+	_, err = stream.NextChunk()
+	require.ErrorIs(t, err, io.EOF)
+
+	select {
+	case <-stream.WaitUntilEOF():
+	case <-time.After(time.Second):
+		require.Fail(t, "stream never finished")
+	}
+}
+
+func TestChatCompletionStreamResponse_Abandoned(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/v1/chat/completions", bytes.NewBufferString(`{"model": "gpt-4o", "stream": true, "messages": []}`))
+	require.NoError(t, err)
+
+	request, err := NewChatCompletionRequest(httpRequest)
+	require.NoError(t, err)
+
+	reader, writer := io.Pipe()
+	defer writer.Close()
+
+	stream, err := NewChatCompletionStreamResponse(request, nil, bufio.NewReader(reader))
+	require.NoError(t, err)
+
+	lastCanceled := make(chan bool, 1)
+
+	stream.OnChunk(func(ctx context.Context, _ object.LLMStreamResponse, chunk object.LLMChunkResponse) {
+		lastCanceled <- ctx.Err() != nil
+	})
+
+	// The client goes away before the upstream finishes
+	cancel()
+
+	select {
+	case <-stream.WaitUntilEOF():
+	case <-time.After(time.Second):
+		require.Fail(t, "abandoned stream never finished")
+	}
+
+	assert.True(t, <-lastCanceled)
+}
@@ -0,0 +1,27 @@
+// The suite parses the responses with this package, so that it's tested
+// from outside to avoid the import cycle.
+package openai_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/conformance"
+)
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, conformance.Adapter{
+		BaseURL: "https://api.openai.com/v1",
+		// Requests are sent as they are to the upstreams compatible with OpenAI
+		MarshalRequest: func(baseURL string, request object.LLMRequest) (string, []byte, error) {
+			body, err := json.Marshal(request)
+			if err != nil {
+				return "", nil, err
+			}
+
+			return strings.TrimSuffix(baseURL, "/") + "/chat/completions", body, nil
+		},
+	}, "testdata/conformance")
+}
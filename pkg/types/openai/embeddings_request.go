@@ -0,0 +1,159 @@
+package openai
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/utils"
+)
+
+var _ object.LLMRequest = (*EmbeddingsRequest)(nil)
+
+// EmbeddingsRequest represents OpenAI-compatible embedding requests.
+// API reference: https://platform.openai.com/docs/api-reference/embeddings/create
+type EmbeddingsRequest struct {
+	Model string `json:"model,omitempty"`
+	// Input can be a string, an array of strings, an array of tokens or an
+	// array of token arrays, so it is kept as-is.
+	Input          any    `json:"input,omitempty"`
+	EncodingFormat string `json:"encoding_format,omitempty"`
+
+	bodyParsed      map[string]any
+	bodyBuffer      *bytes.Buffer
+	incomingRequest *http.Request
+}
+
+func NewEmbeddingsRequest(httpRequest *http.Request) (*EmbeddingsRequest, error) {
+	buffer, parsed, err := utils.ReadAsJSONWithClose(httpRequest.Body)
+	if err != nil {
+		return nil, NewErrorInvalidBody()
+	}
+
+	req := &EmbeddingsRequest{
+		Model:           utils.GetByJSONPath[string](parsed, "{ .model }"),
+		Input:           parsed["input"],
+		EncodingFormat:  utils.GetByJSONPath[string](parsed, "{ .encoding_format }"),
+		bodyParsed:      parsed,
+		bodyBuffer:      buffer,
+		incomingRequest: httpRequest,
+	}
+
+	if req.Input == nil {
+		return nil, NewErrorMissingParameter("input")
+	}
+
+	return req, nil
+}
+
+func (r *EmbeddingsRequest) MarshalJSON() ([]byte, error) {
+	return r.bodyBuffer.Bytes(), nil
+}
+
+func (r *EmbeddingsRequest) IsStream() bool {
+	return false
+}
+
+func (r *EmbeddingsRequest) GetModel() string {
+	return r.Model
+}
+
+func (r *EmbeddingsRequest) GetInput() any {
+	return r.Input
+}
+
+func (r *EmbeddingsRequest) GetEncodingFormat() string {
+	return r.EncodingFormat
+}
+
+func (r *EmbeddingsRequest) SetModel(model string) error {
+	var err error
+
+	r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, nil, NewReplace("/model", model))
+	if err != nil {
+		return err
+	}
+
+	r.Model = model
+
+	return nil
+}
+
+func (r *EmbeddingsRequest) SetDefaultParams(params map[string]*structpb.Value) error {
+	for k, v := range params {
+		if _, exists := r.bodyParsed[k]; exists {
+			continue
+		}
+
+		var err error
+
+		r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, nil, NewAdd("/"+k, &v))
+		if err != nil {
+			return fmt.Errorf("failed to add key %s: %w", k, err)
+		}
+	}
+
+	r.syncFromParsed()
+
+	return nil
+}
+
+func (r *EmbeddingsRequest) SetOverrideParams(params map[string]*structpb.Value) error {
+	applyOpt := jsonpatch.NewApplyOptions()
+	applyOpt.EnsurePathExistsOnAdd = true
+
+	for k, v := range params {
+		var err error
+
+		r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, applyOpt, NewAdd("/"+k, &v))
+		if err != nil {
+			return err
+		}
+	}
+
+	r.syncFromParsed()
+
+	return nil
+}
+
+func (r *EmbeddingsRequest) RemoveParamKeys(keys []string) error {
+	applyOpt := jsonpatch.NewApplyOptions()
+	applyOpt.AllowMissingPathOnRemove = true
+
+	for _, v := range keys {
+		var err error
+
+		r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, applyOpt, NewRemove("/"+v))
+		if err != nil {
+			return err
+		}
+	}
+
+	r.syncFromParsed()
+
+	return nil
+}
+
+func (r *EmbeddingsRequest) syncFromParsed() {
+	if model, ok := r.bodyParsed["model"].(string); ok && r.Model != model {
+		r.Model = model
+	}
+
+	r.EncodingFormat = utils.GetByJSONPath[string](r.bodyParsed, "{ .encoding_format }")
+}
+
+func (r *EmbeddingsRequest) GetRequestType() object.RequestType {
+	return object.RequestTypeEmbeddings
+}
+
+func (r *EmbeddingsRequest) GetRawRequest() *http.Request {
+	return r.incomingRequest
+}
+
+func (r *EmbeddingsRequest) GetBodyParsed() map[string]any {
+	return r.bodyParsed
+}
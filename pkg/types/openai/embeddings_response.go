@@ -0,0 +1,159 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/utils"
+)
+
+var _ object.LLMResponse = (*EmbeddingsResponse)(nil)
+
+type EmbeddingsData struct {
+	Index int `json:"index"`
+	// Embedding is either an array of floats, or a base64 string when
+	// requested with encoding_format set to base64.
+	Embedding any `json:"embedding"`
+}
+
+type EmbeddingsResponse struct {
+	Status int                   `json:"status"`
+	Model  string                `json:"model"`
+	Data   []*EmbeddingsData     `json:"data"`
+	Usage  *ChatCompletionsUsage `json:"usage,omitempty"`
+	Error  *ErrorResponse        `json:"error,omitempty"`
+
+	request          object.LLMRequest
+	responseBody     json.RawMessage
+	bodyParsed       map[string]any
+	outgoingResponse *http.Response
+}
+
+func NewEmbeddingsResponse(request object.LLMRequest, response *http.Response, reader *bufio.Reader) (*EmbeddingsResponse, error) {
+	resp := new(EmbeddingsResponse)
+	resp.request = request
+	resp.outgoingResponse = response
+
+	buffer := new(bytes.Buffer)
+
+	_, err := buffer.ReadFrom(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	err = resp.processBytes(buffer.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w, body: %s", err, buffer.String())
+	}
+
+	return resp, nil
+}
+
+func (r *EmbeddingsResponse) processBytes(bs []byte, response *http.Response) error {
+	if r == nil {
+		return nil
+	}
+
+	r.responseBody = bs
+	r.Status = response.StatusCode
+
+	var body map[string]any
+
+	err := json.Unmarshal(bs, &body)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	r.bodyParsed = body
+
+	r.Model = utils.GetByJSONPath[string](body, "{ .model }")
+
+	dataArray := utils.GetByJSONPath[[]map[string]any](body, "{ .data }")
+	r.Data = make([]*EmbeddingsData, 0, len(dataArray))
+
+	for _, data := range dataArray {
+		d, err := utils.FromMap[EmbeddingsData](data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal data: %w", err)
+		}
+
+		r.Data = append(r.Data, d)
+	}
+
+	usageMap := utils.GetByJSONPath[map[string]any](body, "{ .usage }")
+	if usageMap != nil {
+		r.Usage, err = utils.FromMap[ChatCompletionsUsage](usageMap)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal usage: %w", err)
+		}
+	}
+
+	errorResponse, err := unmarshalErrorResponseFromParsedBody(body, response, bs)
+	if err != nil {
+		return err
+	}
+
+	if errorResponse != nil {
+		r.Error = errorResponse
+	}
+
+	return nil
+}
+
+// SetBody replaces the body of the response, the usage and the other fields
+// are parsed from it again.
+func (r *EmbeddingsResponse) SetBody(body []byte) error {
+	return r.processBytes(body, r.outgoingResponse)
+}
+
+func (r *EmbeddingsResponse) MarshalJSON() ([]byte, error) {
+	return r.responseBody, nil
+}
+
+func (r *EmbeddingsResponse) IsStream() bool {
+	return false
+}
+
+// GetRequestID returns empty since embedding responses carry no ID.
+func (r *EmbeddingsResponse) GetRequestID() string {
+	return ""
+}
+
+func (r *EmbeddingsResponse) GetModel() string {
+	return r.Model
+}
+
+func (r *EmbeddingsResponse) SetModel(model string) error {
+	if r.Error == nil {
+		var err error
+
+		r.responseBody, r.bodyParsed, err = modifyBytesBodyAndParsed(r.responseBody, NewReplace("/model", model))
+		if err != nil {
+			return err
+		}
+	}
+
+	r.Model = model
+
+	return nil
+}
+
+func (r *EmbeddingsResponse) GetUsage() object.LLMUsage {
+	if r.Usage == nil {
+		return nil
+	}
+
+	return r.Usage
+}
+
+func (r *EmbeddingsResponse) GetError() object.LLMError {
+	if r.Error != nil {
+		return r.Error
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"knoway.dev/pkg/object"
+)
+
+func TestNewEmbeddingsRequest(t *testing.T) {
+	t.Run("with params", func(t *testing.T) {
+		body := []byte(`{
+			"model": "public/text-embedding-3-small",
+			"input": ["first", "second"]
+		}`)
+
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/embeddings", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		embeddingsReq, err := NewEmbeddingsRequest(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, "public/text-embedding-3-small", embeddingsReq.GetModel())
+		assert.Equal(t, []any{"first", "second"}, embeddingsReq.GetInput())
+		assert.Equal(t, object.RequestTypeEmbeddings, embeddingsReq.GetRequestType())
+		assert.False(t, embeddingsReq.IsStream())
+
+		require.NoError(t, embeddingsReq.SetModel("text-embedding-3-small"))
+		require.NoError(t, embeddingsReq.SetDefaultParams(map[string]*structpb.Value{
+			"encoding_format": structpb.NewStringValue("base64"),
+		}))
+		require.NoError(t, embeddingsReq.SetOverrideParams(map[string]*structpb.Value{
+			"dimensions": structpb.NewNumberValue(256),
+		}))
+
+		assert.Equal(t, "text-embedding-3-small", embeddingsReq.bodyParsed["model"])
+		assert.Equal(t, "base64", embeddingsReq.GetEncodingFormat())
+		assert.InDelta(t, 256, embeddingsReq.bodyParsed["dimensions"], 0.0001)
+	})
+
+	t.Run("missing input", func(t *testing.T) {
+		body := []byte(`{
+			"model": "text-embedding-3-small"
+		}`)
+
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/embeddings", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		_, err = NewEmbeddingsRequest(req)
+		require.Error(t, err)
+	})
+}
+
+func TestNewEmbeddingsResponse(t *testing.T) {
+	body := `{
+		"object": "list",
+		"model": "text-embedding-3-small",
+		"data": [
+			{ "object": "embedding", "index": 0, "embedding": [0.1, -0.2] },
+			{ "object": "embedding", "index": 1, "embedding": "AAAAAA==" }
+		],
+		"usage": { "prompt_tokens": 8, "total_tokens": 8 }
+	}`
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+
+	resp, err := NewEmbeddingsResponse(nil, httpResp, bufio.NewReader(httpResp.Body))
+	require.NoError(t, err)
+
+	assert.Equal(t, "text-embedding-3-small", resp.GetModel())
+	require.Len(t, resp.Data, 2)
+	assert.Equal(t, 1, resp.Data[1].Index)
+	assert.Equal(t, "AAAAAA==", resp.Data[1].Embedding)
+	assert.Nil(t, resp.GetError())
+
+	usage, ok := object.AsLLMTokensUsage(resp.GetUsage())
+	require.True(t, ok)
+	assert.Equal(t, uint64(8), usage.GetPromptTokens())
+	assert.Equal(t, uint64(0), usage.GetCompletionTokens())
+
+	err = resp.SetModel("public/text-embedding-3-small")
+	require.NoError(t, err)
+	assert.Equal(t, "public/text-embedding-3-small", resp.bodyParsed["model"])
+}
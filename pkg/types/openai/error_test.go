@@ -2,8 +2,11 @@ package openai
 
 import (
 	"encoding/json"
+	"errors"
+	"net/http"
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -161,3 +164,29 @@ func TestErrorResponseUnmarshalJSON(t *testing.T) {
 		assert.Equal(t, "api_error", e.ErrorBody.Type)
 	})
 }
+
+func TestErrorResponseRedacted(t *testing.T) {
+	// Client errors of the gateway are kept
+	notFound := NewErrorModelNotFoundOrNotAccessible("gpt-4o")
+	assert.Same(t, notFound, notFound.Redacted())
+
+	internal := NewErrorInternalError().WithCause(errors.New("dial tcp 10.0.0.1:443: connection refused"))
+	redacted := internal.Redacted()
+	assert.Equal(t, http.StatusInternalServerError, redacted.Status)
+	assert.Equal(t, "internal server error", redacted.ErrorBody.Message)
+	assert.Equal(t, "internal_error", redacted.ErrorBody.Type)
+	require.NoError(t, redacted.Cause)
+	assert.Contains(t, internal.ErrorBody.Message, "connection refused")
+
+	upstream := &ErrorResponse{
+		Status:            http.StatusBadRequest,
+		FromUpstream:      true,
+		UpstreamErrorBody: `{"error":{"message":"Invalid prompt: sk-abcd"}}`,
+		ErrorBody:         &Error{Message: "Invalid prompt: sk-abcd", Type: "invalid_request_error", Param: lo.ToPtr("prompt")},
+	}
+	redacted = upstream.Redacted()
+	assert.Equal(t, "bad request", redacted.ErrorBody.Message)
+	assert.Equal(t, "invalid_request_error", redacted.ErrorBody.Type)
+	assert.Nil(t, redacted.ErrorBody.Param)
+	assert.Empty(t, redacted.UpstreamErrorBody)
+}
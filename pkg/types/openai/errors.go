@@ -122,6 +122,25 @@ func (e *ErrorResponse) WithCausef(format string, args ...interface{}) *ErrorRes
 	return e
 }
 
+// Redacted returns a copy of the error hiding the causes of internal errors
+// and the messages of upstreams behind the text of the status, other errors
+// are returned as they are.
+func (e *ErrorResponse) Redacted() *ErrorResponse {
+	if !e.FromUpstream && e.Status < http.StatusInternalServerError {
+		return e
+	}
+
+	body := lo.FromPtr(e.ErrorBody)
+	body.Message = lo.CoalesceOrEmpty(strings.ToLower(http.StatusText(e.Status)), "error")
+	body.Param = nil
+
+	return &ErrorResponse{
+		Status:       e.Status,
+		FromUpstream: e.FromUpstream,
+		ErrorBody:    &body,
+	}
+}
+
 func (e *ErrorResponse) GetCode() string {
 	return lo.FromPtrOr(e.ErrorBody.Code, "")
 }
@@ -285,6 +304,87 @@ func NewErrorNotFound(method string, url string) *ErrorResponse {
 	})
 }
 
+func NewErrorMethodNotAllowed(method string, url string) *ErrorResponse {
+	return NewErrorResponse(http.StatusMethodNotAllowed, Error{
+		Message: fmt.Sprintf("Method not allowed (%s %s)", strings.ToUpper(method), url),
+		Type:    "invalid_request_error",
+	})
+}
+
+func NewErrorURITooLong(maxLength int) *ErrorResponse {
+	return NewErrorResponse(http.StatusRequestURITooLong, Error{
+		Message: fmt.Sprintf("Request URI exceeds the maximum length of %d.", maxLength),
+		Type:    "invalid_request_error",
+	})
+}
+
+func NewErrorRequestEntityTooLarge(maxBytes int64) *ErrorResponse {
+	return NewErrorResponse(http.StatusRequestEntityTooLarge, Error{
+		Message: fmt.Sprintf("Request body exceeds the maximum size of %d bytes.", maxBytes),
+		Type:    "invalid_request_error",
+		Code:    lo.ToPtr("request_too_large"),
+	})
+}
+
+/*
+Example:
+
+	{
+	    "error": {
+	        "message": "Invalid 'messages': array too long. Expected an array with maximum length 2048, but got an array with length 2050 instead.",
+	        "type": "invalid_request_error",
+	        "param": "messages",
+	        "code": "array_above_max_length"
+	    }
+	}
+*/
+func NewErrorArrayTooLong(param string, maxLength int, length int) *ErrorResponse {
+	return NewErrorResponse(http.StatusBadRequest, Error{
+		Message: fmt.Sprintf("Invalid '%s': array too long. Expected an array with maximum length %d, but got an array with length %d instead.", param, maxLength, length),
+		Type:    "invalid_request_error",
+		Param:   lo.ToPtr(param),
+		Code:    lo.ToPtr("array_above_max_length"),
+	})
+}
+
+func NewErrorPromptTooLong(param string, maxChars int, chars int) *ErrorResponse {
+	return NewErrorResponse(http.StatusBadRequest, Error{
+		Message: fmt.Sprintf("Invalid '%s': prompt too long. Expected a prompt with maximum %d characters, but got %d characters instead.", param, maxChars, chars),
+		Type:    "invalid_request_error",
+		Param:   lo.ToPtr(param),
+		Code:    lo.ToPtr("string_above_max_length"),
+	})
+}
+
+/*
+Example:
+
+	{
+	    "error": {
+	        "message": "Your request was rejected as a result of our safety system.",
+	        "type": "invalid_request_error",
+	        "param": null,
+	        "code": "content_policy_violation"
+	    }
+	}
+*/
+func NewErrorContentPolicyViolation(message string) *ErrorResponse {
+	return NewErrorResponse(http.StatusBadRequest, Error{
+		Message: message,
+		Type:    "invalid_request_error",
+		Code:    lo.ToPtr("content_policy_violation"),
+	})
+}
+
+func NewErrorInvalidHeader(header string, cause error) *ErrorResponse {
+	return NewErrorResponse(http.StatusBadRequest, Error{
+		Message: fmt.Sprintf("Invalid header %s: %s", header, cause),
+		Type:    "invalid_request_error",
+		Param:   lo.ToPtr(header),
+		Code:    lo.ToPtr("invalid_header"),
+	})
+}
+
 /*
 Example:
 
@@ -305,6 +405,32 @@ func NewErrorModelNotFoundOrNotAccessible(model string) *ErrorResponse {
 	})
 }
 
+/*
+Example:
+
+	{
+	    "error": {
+	        "message": "No thread found with id 'thread_abc'.",
+	        "type": "invalid_request_error",
+	        "param": null,
+	        "code": null
+	    }
+	}
+*/
+func NewErrorThreadNotFound(threadID string) *ErrorResponse {
+	return NewErrorResponse(http.StatusNotFound, Error{
+		Message: fmt.Sprintf("No thread found with id '%s'.", threadID),
+		Type:    "invalid_request_error",
+	})
+}
+
+func NewErrorVideoNotFound(videoID string) *ErrorResponse {
+	return NewErrorResponse(http.StatusNotFound, Error{
+		Message: fmt.Sprintf("No video found with id '%s'.", videoID),
+		Type:    "invalid_request_error",
+	})
+}
+
 func NewErrorModelAccessDenied(model string) *ErrorResponse {
 	return NewErrorResponse(http.StatusForbidden, Error{
 		Message: fmt.Sprintf("You do not have access to the model `%s`.", model),
@@ -359,6 +485,48 @@ func NewErrorMissingParameter(parameter string) *ErrorResponse {
 	})
 }
 
+/*
+Example:
+
+	{
+	  "error": {
+	    "message": "Invalid value for 'tool_choice': 'tool_choice' is only allowed when 'tools' are specified.",
+	    "type": "invalid_request_error",
+	    "param": "tool_choice",
+	    "code": "invalid_value"
+	  }
+	}
+*/
+func NewErrorInvalidValue(parameter string, reason string) *ErrorResponse {
+	return NewErrorResponse(http.StatusBadRequest, Error{
+		Message: "Invalid value for '" + parameter + "': " + reason,
+		Type:    "invalid_request_error",
+		Param:   lo.ToPtr(parameter),
+		Code:    lo.ToPtr("invalid_value"),
+	})
+}
+
+/*
+Example:
+
+	{
+	  "error": {
+	    "message": "Unsupported parameter: 'tools' is not supported with this model.",
+	    "type": "invalid_request_error",
+	    "param": "tools",
+	    "code": "unsupported_parameter"
+	  }
+	}
+*/
+func NewErrorUnsupportedParameter(parameter string) *ErrorResponse {
+	return NewErrorResponse(http.StatusBadRequest, Error{
+		Message: "Unsupported parameter: '" + parameter + "' is not supported with this model.",
+		Type:    "invalid_request_error",
+		Param:   lo.ToPtr(parameter),
+		Code:    lo.ToPtr("unsupported_parameter"),
+	})
+}
+
 func NewErrorInternalError() *ErrorResponse {
 	return NewErrorResponse(http.StatusInternalServerError, Error{
 		Message: "internal error",
@@ -380,6 +548,14 @@ func NewErrorServiceUnavailable() *ErrorResponse {
 	})
 }
 
+func NewErrorModelUnderMaintenance() *ErrorResponse {
+	return NewErrorResponse(http.StatusServiceUnavailable, Error{
+		Message: "The model is under maintenance. Please try again later.",
+		Type:    "server_error",
+		Code:    lo.ToPtr(string(object.LLMErrorCodeModelUnderMaintenance)),
+	})
+}
+
 func NewErrorFromLLMError(err error) *ErrorResponse {
 	llmError := object.AsLLMError(err)
 	if llmError == nil {
@@ -419,6 +595,12 @@ func NewErrorFromLLMError(err error) *ErrorResponse {
 			newError := NewErrorBadGateway()
 			newError.ErrorBody.Message = llmError.GetMessage()
 
+			return newError
+		},
+		string(object.LLMErrorCodeModelUnderMaintenance): func() *ErrorResponse {
+			newError := NewErrorModelUnderMaintenance()
+			newError.ErrorBody.Message = llmError.GetMessage()
+
 			return newError
 		},
 	}
@@ -3,9 +3,13 @@ package openai
 import (
 	"errors"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/tenant"
 	"knoway.dev/pkg/utils"
 )
 
@@ -63,8 +67,8 @@ func ResponseHandler() func(resp any, err error, writer http.ResponseWriter, req
 				"upstream_body", openAIError.UpstreamErrorBody,
 				"cluster", rMeta.SelectedCluster.OrEmpty(),
 				"request_model", rMeta.RequestModel,
-				"upstream_request_model", rMeta.UpstreamRequestModel,
-				"upstream_response_model", rMeta.UpstreamResponseModel,
+				"upstream_request_model", rMeta.LastUpstreamAttemptValue().RequestModel,
+				"upstream_response_model", rMeta.LastUpstreamAttemptValue().ResponseModel,
 			)
 		} else if openAIError.Status >= http.StatusInternalServerError {
 			slog.Error("failed to handle request",
@@ -74,14 +78,23 @@ func ResponseHandler() func(resp any, err error, writer http.ResponseWriter, req
 				"upstream_body", openAIError.UpstreamErrorBody,
 				"cluster", rMeta.SelectedCluster.OrEmpty(),
 				"request_model", rMeta.RequestModel,
-				"upstream_request_model", rMeta.UpstreamRequestModel,
-				"upstream_response_model", rMeta.UpstreamResponseModel,
+				"upstream_request_model", rMeta.LastUpstreamAttemptValue().RequestModel,
+				"upstream_response_model", rMeta.LastUpstreamAttemptValue().ResponseModel,
 			)
 		}
 
+		var retryAfter interface{ GetRetryAfter() time.Duration }
+		if errors.As(err, &retryAfter) && retryAfter.GetRetryAfter() > 0 {
+			writer.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.GetRetryAfter().Seconds()))))
+		}
+
 		rMeta.StatusCode = openAIError.Status
 		rMeta.ErrorMessage = openAIError.Error()
 
+		if tenant.ErrorVerbosityFromContext(request.Context()) == tenant.ErrorVerbosityMinimal {
+			openAIError = openAIError.Redacted()
+		}
+
 		utils.WriteJSONForHTTP(openAIError.Status, openAIError, writer)
 	}
 }
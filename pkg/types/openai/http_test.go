@@ -0,0 +1,30 @@
+package openai
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"knoway.dev/pkg/metadata"
+	"knoway.dev/pkg/object"
+)
+
+func TestResponseHandler_RetryAfter(t *testing.T) {
+	request := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	request = request.WithContext(metadata.InitMetadataContext(request))
+
+	recorder := httptest.NewRecorder()
+	ResponseHandler()(nil, object.NewErrorConcurrencyLimitExceeded(1500*time.Millisecond), recorder, request)
+
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "2", recorder.Header().Get("Retry-After"))
+
+	recorder = httptest.NewRecorder()
+	ResponseHandler()(nil, object.NewErrorRateLimitExceeded(), recorder, request)
+
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Empty(t, recorder.Header().Get("Retry-After"))
+}
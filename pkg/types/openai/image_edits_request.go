@@ -0,0 +1,349 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"slices"
+	"strconv"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/samber/lo"
+
+	"knoway.dev/pkg/object"
+)
+
+const (
+	imageEditsImageField      = "image"
+	imageEditsImageArrayField = "image[]"
+	imageEditsMaskField       = "mask"
+)
+
+// ImageFile is an image uploaded along with the requests editing images.
+type ImageFile struct {
+	// Field is the name of the form field the image is uploaded in, either
+	// image, or image[] for multiple images
+	Field       string
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+var _ object.LLMRequest = (*ImageEditsRequest)(nil)
+
+// ImageEditsRequest represents the requests editing images and creating
+// variations of images, the source images and the mask of the edits are
+// uploaded in multipart/form-data. The images are metered the same way as the
+// ones generated, see ImageGenerationsResponse.
+// API reference: https://platform.openai.com/docs/api-reference/images/createEdit
+type ImageEditsRequest struct {
+	Model   string
+	N       *uint64
+	Quality *string
+	Style   *string
+	Size    *ImageGenerationsRequestSize
+
+	requestType     object.RequestType
+	images          []*ImageFile
+	mask            *ImageFile
+	formValues      url.Values
+	bodyBuffer      *bytes.Buffer
+	contentType     string
+	incomingRequest *http.Request
+}
+
+// NewImageEditsRequest parses the requests editing images, the prompt
+// describing the edits is required.
+func NewImageEditsRequest(httpRequest *http.Request) (*ImageEditsRequest, error) {
+	req, err := newImageEditsRequest(httpRequest, object.RequestTypeImageEdits)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetPrompt() == "" {
+		return nil, NewErrorMissingParameter("prompt")
+	}
+
+	return req, nil
+}
+
+// NewImageVariationsRequest parses the requests creating variations of an
+// image.
+func NewImageVariationsRequest(httpRequest *http.Request) (*ImageEditsRequest, error) {
+	return newImageEditsRequest(httpRequest, object.RequestTypeImageVariations)
+}
+
+func newImageEditsRequest(httpRequest *http.Request, requestType object.RequestType) (*ImageEditsRequest, error) {
+	mediaType, params, err := mime.ParseMediaType(httpRequest.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" || params["boundary"] == "" {
+		return nil, NewErrorBadRequest().WithMessage("Content-Type must be multipart/form-data")
+	}
+
+	defer httpRequest.Body.Close()
+
+	req := &ImageEditsRequest{
+		requestType:     requestType,
+		formValues:      make(url.Values),
+		incomingRequest: httpRequest,
+	}
+
+	reader := multipart.NewReader(httpRequest.Body, params["boundary"])
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, NewErrorBadRequest().WithMessage("failed to parse multipart/form-data body: " + err.Error())
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, NewErrorBadRequest().WithMessage("failed to read multipart/form-data body: " + err.Error())
+		}
+
+		switch part.FormName() {
+		case imageEditsImageField, imageEditsImageArrayField:
+			req.images = append(req.images, newImageFile(part, data))
+		case imageEditsMaskField:
+			req.mask = newImageFile(part, data)
+		default:
+			req.formValues.Add(part.FormName(), string(data))
+		}
+	}
+
+	if len(req.images) == 0 {
+		return nil, NewErrorMissingParameter(imageEditsImageField)
+	}
+
+	err = req.sync()
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func newImageFile(part *multipart.Part, data []byte) *ImageFile {
+	return &ImageFile{
+		Field:       part.FormName(),
+		Name:        part.FileName(),
+		ContentType: lo.CoalesceOrEmpty(part.Header.Get("Content-Type"), "application/octet-stream"),
+		Data:        data,
+	}
+}
+
+// sync parses the fields from the form values and encodes the body again.
+func (r *ImageEditsRequest) sync() error {
+	r.Model = r.formValues.Get("model")
+	r.N = nil
+	r.Quality = lo.EmptyableToPtr(r.formValues.Get("quality"))
+	r.Style = lo.EmptyableToPtr(r.formValues.Get("style"))
+
+	if n := r.formValues.Get("n"); n != "" {
+		parsed, err := strconv.ParseUint(n, 10, 64)
+		if err != nil {
+			return NewErrorInvalidValue("n", fmt.Sprintf("invalid `%s` in \"n\" value", n))
+		}
+
+		r.N = &parsed
+	}
+
+	if size := r.formValues.Get("size"); size != "" {
+		var err error
+
+		r.Size, err = parseImageGenerationsSizeString(&size)
+		if err != nil {
+			return err
+		}
+	} else {
+		r.Size = &ImageGenerationsRequestSize{
+			Width:  defaultImageGenerationRequestSizeWidth,
+			Height: defaultImageGenerationRequestSizeHeight,
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := multipart.NewWriter(buffer)
+
+	keys := lo.Keys(r.formValues)
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		for _, value := range r.formValues[key] {
+			err := writer.WriteField(key, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, file := range append(slices.Clone(r.images), r.mask) {
+		if file == nil {
+			continue
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(file.Field), escapeQuotes(lo.CoalesceOrEmpty(file.Name, file.Field))))
+		header.Set("Content-Type", file.ContentType)
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+
+		_, err = part.Write(file.Data)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := writer.Close()
+	if err != nil {
+		return err
+	}
+
+	r.bodyBuffer = buffer
+	r.contentType = writer.FormDataContentType()
+
+	return nil
+}
+
+func (r *ImageEditsRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.formValues)
+}
+
+// GetBodyParsed returns the form values other than the images, the values
+// of the fields set once are strings, and lists of strings otherwise.
+func (r *ImageEditsRequest) GetBodyParsed() map[string]any {
+	return lo.MapValues(r.formValues, func(values []string, _ string) any {
+		if len(values) == 1 {
+			return values[0]
+		}
+
+		return lo.ToAnySlice(values)
+	})
+}
+
+// GetResponseFormat returns the format images are requested in, either url
+// or b64_json, url if unspecified.
+func (r *ImageEditsRequest) GetResponseFormat() string {
+	return lo.CoalesceOrEmpty(r.formValues.Get("response_format"), "url")
+}
+
+func (r *ImageEditsRequest) GetPrompt() string {
+	return r.formValues.Get("prompt")
+}
+
+func (r *ImageEditsRequest) SetPrompt(prompt string) error {
+	r.formValues.Set("prompt", prompt)
+
+	return r.sync()
+}
+
+// GetParam returns the string parameter of the request, empty if unset.
+func (r *ImageEditsRequest) GetParam(key string) string {
+	return r.formValues.Get(key)
+}
+
+// SetParam sets the string parameter of the request.
+func (r *ImageEditsRequest) SetParam(key string, value string) error {
+	r.formValues.Set(key, value)
+
+	return r.sync()
+}
+
+// GetImages returns the images edited, or the one the variations are
+// created from.
+func (r *ImageEditsRequest) GetImages() []*ImageFile {
+	return r.images
+}
+
+// GetMask returns the mask of the edits, nil if not uploaded.
+func (r *ImageEditsRequest) GetMask() *ImageFile {
+	return r.mask
+}
+
+func (r *ImageEditsRequest) GetSize() *ImageGenerationsRequestSize {
+	return r.Size
+}
+
+func (r *ImageEditsRequest) GetQuality() string {
+	return lo.FromPtr(r.Quality)
+}
+
+func (r *ImageEditsRequest) GetStyle() string {
+	return lo.FromPtr(r.Style)
+}
+
+func (r *ImageEditsRequest) IsStream() bool {
+	return false
+}
+
+func (r *ImageEditsRequest) GetModel() string {
+	return r.Model
+}
+
+func (r *ImageEditsRequest) SetModel(model string) error {
+	r.formValues.Set("model", model)
+
+	return r.sync()
+}
+
+func (r *ImageEditsRequest) SetDefaultParams(params map[string]*structpb.Value) error {
+	for k, v := range params {
+		if r.formValues.Has(k) {
+			continue
+		}
+
+		err := setFormValue(r.formValues, k, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.sync()
+}
+
+func (r *ImageEditsRequest) SetOverrideParams(params map[string]*structpb.Value) error {
+	for k, v := range params {
+		err := setFormValue(r.formValues, k, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.sync()
+}
+
+func (r *ImageEditsRequest) RemoveParamKeys(keys []string) error {
+	for _, k := range keys {
+		r.formValues.Del(k)
+	}
+
+	return r.sync()
+}
+
+func (r *ImageEditsRequest) GetRequestType() object.RequestType {
+	return r.requestType
+}
+
+func (r *ImageEditsRequest) GetRawRequest() *http.Request {
+	return r.incomingRequest
+}
+
+func (r *ImageEditsRequest) GetBodyBuffer() *bytes.Buffer {
+	return r.bodyBuffer
+}
+
+func (r *ImageEditsRequest) GetContentType() string {
+	return r.contentType
+}
@@ -0,0 +1,244 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nekomeowww/xo"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/object"
+)
+
+func newImageEditsHTTPRequest(t *testing.T, path string, fields map[string]string, files map[string][]byte) *http.Request {
+	t.Helper()
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	for k, v := range fields {
+		require.NoError(t, writer.WriteField(k, v))
+	}
+
+	for field, data := range files {
+		part, err := writer.CreateFormFile(field, strings.TrimSuffix(field, "[]")+".png")
+		require.NoError(t, err)
+
+		_, err = part.Write(data)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, path, body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return req
+}
+
+func TestNewImageEditsRequest(t *testing.T) {
+	t.Run("with params", func(t *testing.T) {
+		req := newImageEditsHTTPRequest(t, "/v1/images/edits", map[string]string{
+			"model":  "public/gpt-image-1",
+			"prompt": "Add a party hat to the cat",
+			"n":      "2",
+			"size":   "1024x1536",
+		}, map[string][]byte{
+			"image": []byte("image"),
+			"mask":  []byte("mask"),
+		})
+
+		editsReq, err := NewImageEditsRequest(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, "public/gpt-image-1", editsReq.GetModel())
+		assert.Equal(t, object.RequestTypeImageEdits, editsReq.GetRequestType())
+		assert.Equal(t, "Add a party hat to the cat", editsReq.GetPrompt())
+		assert.Equal(t, lo.ToPtr(uint64(2)), editsReq.N)
+		assert.Equal(t, &ImageGenerationsRequestSize{Width: 1024, Height: 1536}, editsReq.GetSize())
+		require.Len(t, editsReq.GetImages(), 1)
+		assert.Equal(t, []byte("image"), editsReq.GetImages()[0].Data)
+		require.NotNil(t, editsReq.GetMask())
+		assert.Equal(t, "2", editsReq.GetBodyParsed()["n"])
+
+		require.NoError(t, editsReq.SetModel("gpt-image-1"))
+		require.NoError(t, editsReq.SetDefaultParams(map[string]*structpb.Value{
+			"quality": structpb.NewStringValue("high"),
+			"n":       structpb.NewNumberValue(1),
+		}))
+		require.NoError(t, editsReq.SetPrompt("Add a party hat to the cat, watercolor"))
+
+		assert.Equal(t, "high", editsReq.GetQuality())
+		assert.Equal(t, lo.ToPtr(uint64(2)), editsReq.N)
+
+		// The body is encoded again with the changed params and the images
+		mediaType, params, err := mime.ParseMediaType(editsReq.GetContentType())
+		require.NoError(t, err)
+		assert.Equal(t, "multipart/form-data", mediaType)
+
+		reader := multipart.NewReader(bytes.NewReader(editsReq.GetBodyBuffer().Bytes()), params["boundary"])
+		parts := make(map[string]string)
+
+		for {
+			part, err := reader.NextPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+
+			data, err := io.ReadAll(part)
+			require.NoError(t, err)
+
+			parts[part.FormName()] = string(data)
+		}
+
+		assert.Equal(t, map[string]string{
+			"model":   "gpt-image-1",
+			"prompt":  "Add a party hat to the cat, watercolor",
+			"n":       "2",
+			"size":    "1024x1536",
+			"quality": "high",
+			"image":   "image",
+			"mask":    "mask",
+		}, parts)
+	})
+
+	t.Run("multiple images", func(t *testing.T) {
+		req := newImageEditsHTTPRequest(t, "/v1/images/edits", map[string]string{
+			"model":  "gpt-image-1",
+			"prompt": "A gift basket of the items",
+		}, map[string][]byte{
+			"image[]": []byte("soap"),
+		})
+
+		editsReq, err := NewImageEditsRequest(req)
+		require.NoError(t, err)
+
+		require.Len(t, editsReq.GetImages(), 1)
+		assert.Equal(t, "image[]", editsReq.GetImages()[0].Field)
+		assert.Contains(t, editsReq.GetBodyBuffer().String(), `name="image[]"`)
+		assert.Equal(t, &ImageGenerationsRequestSize{Width: 1024, Height: 1024}, editsReq.GetSize())
+	})
+
+	t.Run("missing prompt", func(t *testing.T) {
+		req := newImageEditsHTTPRequest(t, "/v1/images/edits", map[string]string{
+			"model": "gpt-image-1",
+		}, map[string][]byte{
+			"image": []byte("image"),
+		})
+
+		_, err := NewImageEditsRequest(req)
+		require.Error(t, err)
+	})
+
+	t.Run("missing image", func(t *testing.T) {
+		req := newImageEditsHTTPRequest(t, "/v1/images/edits", map[string]string{
+			"model":  "gpt-image-1",
+			"prompt": "Add a party hat to the cat",
+		}, nil)
+
+		_, err := NewImageEditsRequest(req)
+		require.Error(t, err)
+	})
+
+	t.Run("not multipart", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/images/edits", strings.NewReader(`{"model": "gpt-image-1"}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+
+		_, err = NewImageEditsRequest(req)
+		require.Error(t, err)
+	})
+}
+
+func TestNewImageVariationsRequest(t *testing.T) {
+	req := newImageEditsHTTPRequest(t, "/v1/images/variations", map[string]string{
+		"model": "dall-e-2",
+		"size":  "512x512",
+	}, map[string][]byte{
+		"image": []byte("image"),
+	})
+
+	variationsReq, err := NewImageVariationsRequest(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, object.RequestTypeImageVariations, variationsReq.GetRequestType())
+	assert.Empty(t, variationsReq.GetPrompt())
+	assert.Equal(t, &ImageGenerationsRequestSize{Width: 512, Height: 512}, variationsReq.GetSize())
+}
+
+func TestNewImageGenerationsResponse_ImageEdits(t *testing.T) {
+	imageContent, err := os.ReadFile(xo.RelativePathOf("./testdata/SamplePNGImage_100kbmb.png"))
+	require.NoError(t, err)
+
+	responseBody, err := json.Marshal(map[string]any{
+		"data": []map[string]any{
+			{
+				"b64_json": base64.StdEncoding.EncodeToString(imageContent),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	newResponse := func(t *testing.T, sizeFrom v1alpha1.ClusterMeteringPolicy_SizeFrom) *ImageGenerationsResponse {
+		t.Helper()
+
+		req := newImageEditsHTTPRequest(t, "/v1/images/edits", map[string]string{
+			"model":   "gpt-image-1",
+			"prompt":  "Add a party hat to the cat",
+			"size":    "1024x1536",
+			"quality": "high",
+		}, map[string][]byte{
+			"image": []byte("image"),
+		})
+
+		editsReq, err := NewImageEditsRequest(req)
+		require.NoError(t, err)
+
+		resp := &http.Response{
+			StatusCode: http.StatusOK,
+			Request:    req,
+			Body:       io.NopCloser(bytes.NewReader(responseBody)),
+		}
+
+		imageEditsResp, err := NewImageGenerationsResponse(context.Background(), editsReq, resp, bufio.NewReader(bytes.NewReader(responseBody)),
+			NewImageGenerationsResponseWithUsage(&v1alpha1.ClusterMeteringPolicy{SizeFrom: lo.ToPtr(sizeFrom)}),
+		)
+		require.NoError(t, err)
+
+		return imageEditsResp
+	}
+
+	t.Run("input", func(t *testing.T) {
+		usage, ok := object.AsLLMImagesUsage(newResponse(t, v1alpha1.ClusterMeteringPolicy_SIZE_FROM_INPUT).GetUsage())
+		require.True(t, ok)
+		require.Len(t, usage.GetOutputImages(), 1)
+		assert.Equal(t, uint64(1024), usage.GetOutputImages()[0].GetWidth())
+		assert.Equal(t, uint64(1536), usage.GetOutputImages()[0].GetHeight())
+		assert.Equal(t, "high", usage.GetOutputImages()[0].GetQuality())
+	})
+
+	t.Run("output", func(t *testing.T) {
+		usage, ok := object.AsLLMImagesUsage(newResponse(t, v1alpha1.ClusterMeteringPolicy_SIZE_FROM_OUTPUT).GetUsage())
+		require.True(t, ok)
+		require.Len(t, usage.GetOutputImages(), 1)
+		assert.Equal(t, uint64(272), usage.GetOutputImages()[0].GetWidth())
+		assert.Equal(t, uint64(170), usage.GetOutputImages()[0].GetHeight())
+	})
+}
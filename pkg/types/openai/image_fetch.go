@@ -0,0 +1,220 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"path"
+	"syscall"
+	"time"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/egress"
+)
+
+const (
+	defaultImageFetchTimeout      = 30 * time.Second
+	defaultImageFetchMaxSizeBytes = 20 * 1024 * 1024
+	defaultImageFetchMaxRedirects = 3
+)
+
+var (
+	defaultImageFetchAllowedContentTypes = []string{"image/*"}
+
+	ErrImageFetchPrivateAddress = errors.New("fetching images from non-public addresses is not allowed")
+	ErrImageFetchTooLarge       = errors.New("image exceeds the maximum size")
+)
+
+// cgnatPrefix is the shared address space (RFC 6598) commonly used inside
+// cloud providers, which netip.Addr.IsPrivate doesn't cover.
+var cgnatPrefix = netip.MustParsePrefix("100.64.0.0/10")
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!cgnatPrefix.Contains(addr)
+}
+
+// dialControlDenyPrivate is invoked after DNS resolution and right before
+// connecting, which also covers DNS rebinding and redirects.
+func dialControlDenyPrivate(network string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	if !isPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrImageFetchPrivateAddress, addrPort.Addr())
+	}
+
+	return nil
+}
+
+func newImageFetchTransport(allowPrivateNetworks bool) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second, //nolint:mnd
+		KeepAlive: 30 * time.Second, //nolint:mnd
+	}
+	if !allowPrivateNetworks {
+		dialer.Control = dialControlDenyPrivate
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	// Never go through proxies from environment, the proxy could be in the
+	// private network, or it could reach private networks on our behalf.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return transport
+}
+
+var (
+	imageFetchTransport             = newImageFetchTransport(false)
+	imageFetchTransportAllowPrivate = newImageFetchTransport(true)
+)
+
+type imageFetchPolicy struct {
+	timeout             time.Duration
+	maxSizeBytes        uint64
+	maxRedirects        uint32
+	allowPrivate        bool
+	allowedContentTypes []string
+}
+
+func newImageFetchPolicy(cfg *v1alpha1.ClusterMeteringPolicy_ImageFetch) imageFetchPolicy {
+	policy := imageFetchPolicy{
+		timeout:             defaultImageFetchTimeout,
+		maxSizeBytes:        defaultImageFetchMaxSizeBytes,
+		maxRedirects:        defaultImageFetchMaxRedirects,
+		allowPrivate:        cfg.GetAllowPrivateNetworks(),
+		allowedContentTypes: defaultImageFetchAllowedContentTypes,
+	}
+
+	if cfg == nil {
+		return policy
+	}
+
+	if cfg.GetTimeout().AsDuration() > 0 {
+		policy.timeout = cfg.GetTimeout().AsDuration()
+	}
+
+	if cfg.MaxSizeBytes != nil {
+		policy.maxSizeBytes = cfg.GetMaxSizeBytes()
+	}
+
+	if cfg.MaxRedirects != nil {
+		policy.maxRedirects = cfg.GetMaxRedirects()
+	}
+
+	if len(cfg.GetAllowedContentTypes()) > 0 {
+		policy.allowedContentTypes = cfg.GetAllowedContentTypes()
+	}
+
+	return policy
+}
+
+func checkImageFetchURL(ctx context.Context, u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %s of image url", u.Scheme)
+	}
+
+	return egress.Global().CheckURL(ctx, u.String())
+}
+
+// client returns the client for fetching images, base is used instead of
+// the default transport when not nil, e.g. in tests.
+func (p imageFetchPolicy) client(base *http.Client) *http.Client {
+	client := &http.Client{
+		Transport: lo.Ternary[http.RoundTripper](p.allowPrivate, imageFetchTransportAllowPrivate, imageFetchTransport),
+	}
+	if base != nil {
+		client = lo.ToPtr(*base)
+	}
+
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if uint32(len(via)) > p.maxRedirects { //nolint:gosec
+			return fmt.Errorf("stopped after %d redirects", p.maxRedirects)
+		}
+
+		return checkImageFetchURL(req.Context(), req.URL)
+	}
+
+	return client
+}
+
+func (p imageFetchPolicy) isAllowedContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, pattern := range p.allowedContentTypes {
+		matched, err := path.Match(pattern, mediaType)
+		if err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fetch downloads the image with the restrictions of the policy applied.
+func (p imageFetchPolicy) fetch(ctx context.Context, client *http.Client, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkImageFetchURL(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client(client).Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("failed to fetch image, status code %d", resp.StatusCode)
+	}
+
+	if !p.isAllowedContentType(resp.Header.Get("Content-Type")) {
+		return nil, fmt.Errorf("unexpected content type %s of image", resp.Header.Get("Content-Type"))
+	}
+
+	if resp.ContentLength > 0 && uint64(resp.ContentLength) > p.maxSizeBytes {
+		return nil, fmt.Errorf("%w of %d bytes", ErrImageFetchTooLarge, p.maxSizeBytes)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, int64(p.maxSizeBytes)+1)) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+
+	if uint64(len(content)) > p.maxSizeBytes {
+		return nil, fmt.Errorf("%w of %d bytes", ErrImageFetchTooLarge, p.maxSizeBytes)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,131 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"knoway.dev/api/clusters/v1alpha1"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	assert.True(t, isPublicAddr(netip.MustParseAddr("8.8.8.8")))
+	assert.True(t, isPublicAddr(netip.MustParseAddr("2001:4860:4860::8888")))
+
+	for _, addr := range []string{
+		"127.0.0.1",
+		"10.0.0.1",
+		"172.16.0.1",
+		"192.168.1.1",
+		"169.254.169.254",
+		"100.64.0.1",
+		"0.0.0.0",
+		"::1",
+		"fd00::1",
+		"fe80::1",
+		"::ffff:127.0.0.1",
+	} {
+		assert.False(t, isPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestNewImageFetchPolicy(t *testing.T) {
+	policy := newImageFetchPolicy(nil)
+	assert.Equal(t, defaultImageFetchTimeout, policy.timeout)
+	assert.Equal(t, uint64(defaultImageFetchMaxSizeBytes), policy.maxSizeBytes)
+	assert.Equal(t, uint32(defaultImageFetchMaxRedirects), policy.maxRedirects)
+	assert.False(t, policy.allowPrivate)
+
+	policy = newImageFetchPolicy(&v1alpha1.ClusterMeteringPolicy_ImageFetch{
+		Timeout:              durationpb.New(time.Second),
+		MaxSizeBytes:         lo.ToPtr(uint64(1024)),
+		MaxRedirects:         lo.ToPtr(uint32(0)),
+		AllowPrivateNetworks: true,
+		AllowedContentTypes:  []string{"image/png"},
+	})
+	assert.Equal(t, time.Second, policy.timeout)
+	assert.Equal(t, uint64(1024), policy.maxSizeBytes)
+	assert.Equal(t, uint32(0), policy.maxRedirects)
+	assert.True(t, policy.allowPrivate)
+	assert.True(t, policy.isAllowedContentType("image/png; charset=binary"))
+	assert.False(t, policy.isAllowedContentType("image/jpeg"))
+}
+
+func TestImageFetchPolicy_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte("png"))
+		case "/large.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte(strings.Repeat("a", 2048)))
+		case "/page.html":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte("<html></html>"))
+		case "/redirect":
+			http.Redirect(w, r, "/image.png", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	allowPrivate := &v1alpha1.ClusterMeteringPolicy_ImageFetch{AllowPrivateNetworks: true}
+
+	t.Run("private addresses are blocked by default", func(t *testing.T) {
+		_, err := newImageFetchPolicy(nil).fetch(ctx, nil, server.URL+"/image.png")
+		require.ErrorIs(t, err, ErrImageFetchPrivateAddress)
+	})
+
+	t.Run("ok", func(t *testing.T) {
+		content, err := newImageFetchPolicy(allowPrivate).fetch(ctx, nil, server.URL+"/image.png")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("png"), content)
+	})
+
+	t.Run("unsupported scheme", func(t *testing.T) {
+		_, err := newImageFetchPolicy(allowPrivate).fetch(ctx, nil, "file:///etc/passwd")
+		require.Error(t, err)
+	})
+
+	t.Run("unexpected content type", func(t *testing.T) {
+		_, err := newImageFetchPolicy(allowPrivate).fetch(ctx, nil, server.URL+"/page.html")
+		require.Error(t, err)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := newImageFetchPolicy(&v1alpha1.ClusterMeteringPolicy_ImageFetch{
+			AllowPrivateNetworks: true,
+			MaxSizeBytes:         lo.ToPtr(uint64(1024)),
+		}).fetch(ctx, nil, server.URL+"/large.png")
+		require.ErrorIs(t, err, ErrImageFetchTooLarge)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := newImageFetchPolicy(allowPrivate).fetch(ctx, nil, server.URL+"/missing.png")
+		require.Error(t, err)
+	})
+
+	t.Run("redirects", func(t *testing.T) {
+		content, err := newImageFetchPolicy(allowPrivate).fetch(ctx, nil, server.URL+"/redirect")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("png"), content)
+
+		_, err = newImageFetchPolicy(&v1alpha1.ClusterMeteringPolicy_ImageFetch{
+			AllowPrivateNetworks: true,
+			MaxRedirects:         lo.ToPtr(uint32(0)),
+		}).fetch(ctx, nil, server.URL+"/redirect")
+		require.Error(t, err)
+	})
+}
@@ -76,6 +76,57 @@ func (r *ImageGenerationsRequest) MarshalJSON() ([]byte, error) {
 	return r.bodyBuffer.Bytes(), nil
 }
 
+// GetResponseFormat returns the format images are requested in, either url
+// or b64_json, url if unspecified.
+func (r *ImageGenerationsRequest) GetResponseFormat() string {
+	return lo.CoalesceOrEmpty(utils.GetByJSONPath[string](r.bodyParsed, "{ .response_format }"), "url")
+}
+
+func (r *ImageGenerationsRequest) GetPrompt() string {
+	return utils.GetByJSONPath[string](r.bodyParsed, "{ .prompt }")
+}
+
+func (r *ImageGenerationsRequest) SetPrompt(prompt string) error {
+	var err error
+
+	r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, nil, NewAdd("/prompt", prompt))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetParam returns the string parameter of the request, empty if unset.
+func (r *ImageGenerationsRequest) GetParam(key string) string {
+	value, _ := r.bodyParsed[key].(string)
+	return value
+}
+
+// SetParam sets the string parameter of the request.
+func (r *ImageGenerationsRequest) SetParam(key string, value string) error {
+	var err error
+
+	r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, nil, NewAdd("/"+key, value))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ImageGenerationsRequest) GetSize() *ImageGenerationsRequestSize {
+	return r.Size
+}
+
+func (r *ImageGenerationsRequest) GetQuality() string {
+	return lo.FromPtr(r.Quality)
+}
+
+func (r *ImageGenerationsRequest) GetStyle() string {
+	return lo.FromPtr(r.Style)
+}
+
 func (r *ImageGenerationsRequest) IsStream() bool {
 	return false
 }
@@ -177,3 +228,7 @@ func (r *ImageGenerationsRequest) GetRequestType() object.RequestType {
 func (r *ImageGenerationsRequest) GetRawRequest() *http.Request {
 	return r.incomingRequest
 }
+
+func (r *ImageGenerationsRequest) GetBodyParsed() map[string]any {
+	return r.bodyParsed
+}
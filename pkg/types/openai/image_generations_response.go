@@ -9,7 +9,6 @@ import (
 	"errors"
 	"fmt"
 	"image"
-	"io"
 	"net/http"
 	"sync"
 
@@ -21,12 +20,29 @@ import (
 	_ "golang.org/x/image/webp"
 
 	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/artifacts"
 	"knoway.dev/pkg/object"
 	"knoway.dev/pkg/utils"
 )
 
 var _ object.LLMResponse = (*ImageGenerationsResponse)(nil)
 
+var (
+	_ imagesRequest = (*ImageGenerationsRequest)(nil)
+	_ imagesRequest = (*ImageEditsRequest)(nil)
+)
+
+// imagesRequest is implemented by the requests the images are generated for,
+// i.e. the generations, edits and variations of images.
+type imagesRequest interface {
+	object.LLMRequest
+
+	GetSize() *ImageGenerationsRequestSize
+	GetQuality() string
+	GetStyle() string
+	GetResponseFormat() string
+}
+
 type ImageGenerationsImage struct {
 	ImageConfig image.Config `json:"-"`
 	ImageFormat string       `json:"-"`
@@ -52,7 +68,7 @@ func NewImageGenerationsImage(data map[string]any) *ImageGenerationsImage {
 	}
 }
 
-func (i *ImageGenerationsImage) resolveImage(ctx context.Context, client *http.Client) error {
+func (i *ImageGenerationsImage) resolveImage(ctx context.Context, client *http.Client, policy imageFetchPolicy) error {
 	switch {
 	case i.Base64JSON != "":
 		decodedBase64Payload, err := base64.StdEncoding.DecodeString(i.Base64JSON)
@@ -70,24 +86,7 @@ func (i *ImageGenerationsImage) resolveImage(ctx context.Context, client *http.C
 
 		return nil
 	case i.URL != "":
-		httpClient := client
-		if httpClient == nil {
-			httpClient = http.DefaultClient
-		}
-
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, i.URL, nil)
-		if err != nil {
-			return err
-		}
-
-		resp, err := httpClient.Do(req)
-		if err != nil {
-			return err
-		}
-
-		defer resp.Body.Close()
-
-		content, err := io.ReadAll(resp.Body)
+		content, err := policy.fetch(ctx, client, i.URL)
 		if err != nil {
 			return err
 		}
@@ -140,9 +139,7 @@ type ImageGenerationsResponse struct {
 }
 
 func NewImageGenerationsResponse(ctx context.Context, request object.LLMRequest, response *http.Response, reader *bufio.Reader, opts ...NewImageGenerationsResponseOption) (*ImageGenerationsResponse, error) {
-	options := &newImageGenerationsResponseOptions{
-		httpClient: http.DefaultClient,
-	}
+	options := &newImageGenerationsResponseOptions{}
 
 	for _, opt := range opts {
 		opt(options)
@@ -218,27 +215,29 @@ func (r *ImageGenerationsResponse) processBytes(ctx context.Context, bs []byte,
 }
 
 func (r *ImageGenerationsResponse) resolveUsage(ctx context.Context, request object.LLMRequest) error {
-	imageGenerationRequest, ok := request.(*ImageGenerationsRequest)
+	imageGenerationRequest, ok := request.(imagesRequest)
 	if !ok {
-		return errors.New("failed to cast request to ImageGenerationsRequest")
+		return fmt.Errorf("failed to cast %T to images request", request)
 	}
 
+	requestSize := imageGenerationRequest.GetSize()
+
 	r.Usage.Images = lo.Map(r.Images, func(imageObject *ImageGenerationsImage, _ int) *ImageGenerationsUsageImage {
 		var (
 			width  uint64
 			height uint64
 		)
 
-		if imageGenerationRequest.Size != nil {
-			width = imageGenerationRequest.Size.Width
-			height = imageGenerationRequest.Size.Height
+		if requestSize != nil {
+			width = requestSize.Width
+			height = requestSize.Height
 		}
 
 		return &ImageGenerationsUsageImage{
 			Width:   width,
 			Height:  height,
-			Style:   lo.FromPtr(imageGenerationRequest.Style),
-			Quality: lo.FromPtr(imageGenerationRequest.Quality),
+			Style:   imageGenerationRequest.GetStyle(),
+			Quality: imageGenerationRequest.GetQuality(),
 		}
 	})
 
@@ -258,13 +257,13 @@ func (r *ImageGenerationsResponse) resolveUsage(ctx context.Context, request obj
 					image.Width = uint64(r.Images[index].ImageConfig.Width)
 					image.Height = uint64(r.Images[index].ImageConfig.Height)
 				case v1alpha1.ClusterMeteringPolicy_SIZE_FROM_GREATEST:
-					if imageGenerationRequest.Size != nil {
-						requestResolution := imageGenerationRequest.Size.Width * imageGenerationRequest.Size.Height
+					if requestSize != nil {
+						requestResolution := requestSize.Width * requestSize.Height
 						responseResolution := image.Width * image.Height
 
 						if requestResolution > responseResolution {
-							image.Width = imageGenerationRequest.Size.Width
-							image.Height = imageGenerationRequest.Size.Height
+							image.Width = requestSize.Width
+							image.Height = requestSize.Height
 						}
 					}
 				case
@@ -293,13 +292,15 @@ func (r *ImageGenerationsResponse) resolveUsage(ctx context.Context, request obj
 func (r *ImageGenerationsResponse) batchResolveImages(ctx context.Context) error {
 	var wg sync.WaitGroup
 
+	policy := newImageFetchPolicy(r.options.meteringPolicy.GetImageFetch())
+
 	errResults := make([]error, len(r.Images))
 
 	for index, imageObject := range r.Images {
 		wg.Add(1)
 
 		go func(ctx context.Context, index int, imageObject *ImageGenerationsImage) {
-			err := imageObject.resolveImage(ctx, r.options.httpClient)
+			err := imageObject.resolveImage(ctx, r.options.httpClient, policy)
 			if err != nil {
 				errResults[index] = err
 			}
@@ -313,6 +314,85 @@ func (r *ImageGenerationsResponse) batchResolveImages(ctx context.Context) error
 	return errors.Join(lo.Filter(errResults, func(err error, _ int) bool { return err != nil })...)
 }
 
+// StoreArtifacts stores the images requested in the url format with the
+// artifacts Store and replaces them with the signed URLs of the gateway,
+// both provider URLs and base64 encoded images returned regardless of the
+// requested format are replaced.
+func (r *ImageGenerationsResponse) StoreArtifacts(ctx context.Context, store *artifacts.Store) error {
+	if r.Error != nil || len(r.Images) == 0 {
+		return nil
+	}
+
+	imageGenerationRequest, ok := r.request.(imagesRequest)
+	if !ok || imageGenerationRequest.GetResponseFormat() != "url" {
+		return nil
+	}
+
+	dataArray, ok := r.bodyParsed["data"].([]any)
+	if !ok {
+		return nil
+	}
+
+	policy := newImageFetchPolicy(r.options.meteringPolicy.GetImageFetch())
+
+	// Images are parsed from the data items with either b64_json or url
+	imageIndex := 0
+
+	for _, item := range dataArray {
+		data, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		var (
+			content []byte
+			err     error
+		)
+
+		base64JSON, _ := data["b64_json"].(string)
+		url, _ := data["url"].(string)
+
+		switch {
+		case base64JSON != "":
+			content, err = base64.StdEncoding.DecodeString(base64JSON)
+		case url != "":
+			content, err = policy.fetch(ctx, r.options.httpClient, url)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		artifact, err := store.Put(ctx, http.DetectContentType(content), bytes.NewReader(content))
+		if err != nil {
+			return err
+		}
+
+		signedURL := store.SignedURL(artifact)
+
+		data["url"] = signedURL
+		delete(data, "b64_json")
+
+		if imageIndex < len(r.Images) {
+			r.Images[imageIndex].URL = signedURL
+			r.Images[imageIndex].Base64JSON = ""
+		}
+
+		imageIndex++
+	}
+
+	responseBody, err := json.Marshal(r.bodyParsed)
+	if err != nil {
+		return err
+	}
+
+	r.responseBody = responseBody
+
+	return nil
+}
+
 func (r *ImageGenerationsResponse) MarshalJSON() ([]byte, error) {
 	return r.responseBody, nil
 }
@@ -0,0 +1,167 @@
+package openai
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/utils"
+)
+
+const (
+	// API Reference - OpenAI API
+	// https://platform.openai.com/docs/api-reference/moderations/create
+	// model, string, Optional, Defaults to omni-moderation-latest
+	DefaultModerationsModel = "omni-moderation-latest"
+)
+
+var _ object.LLMRequest = (*ModerationsRequest)(nil)
+
+// ModerationsRequest represents OpenAI-compatible moderation requests.
+// API reference: https://platform.openai.com/docs/api-reference/moderations/create
+type ModerationsRequest struct {
+	Model string `json:"model,omitempty"`
+	// Input can be a string, an array of strings, or an array of multi-modal
+	// input objects, so it is kept as-is.
+	Input any `json:"input,omitempty"`
+
+	bodyParsed      map[string]any
+	bodyBuffer      *bytes.Buffer
+	incomingRequest *http.Request
+}
+
+func NewModerationsRequest(httpRequest *http.Request) (*ModerationsRequest, error) {
+	buffer, parsed, err := utils.ReadAsJSONWithClose(httpRequest.Body)
+	if err != nil {
+		return nil, NewErrorInvalidBody()
+	}
+
+	req := &ModerationsRequest{
+		Model:           utils.GetByJSONPath[string](parsed, "{ .model }"),
+		Input:           parsed["input"],
+		bodyParsed:      parsed,
+		bodyBuffer:      buffer,
+		incomingRequest: httpRequest,
+	}
+
+	if req.Input == nil {
+		return nil, NewErrorMissingParameter("input")
+	}
+
+	// Unlike other endpoints, model is optional for moderations, fill in the
+	// default one so that routing can still be done by model name.
+	if req.Model == "" {
+		req.bodyBuffer, req.bodyParsed, err = modifyBufferBodyAndParsed(req.bodyBuffer, nil, NewAdd("/model", DefaultModerationsModel))
+		if err != nil {
+			return nil, err
+		}
+
+		req.Model = DefaultModerationsModel
+	}
+
+	return req, nil
+}
+
+func (r *ModerationsRequest) MarshalJSON() ([]byte, error) {
+	return r.bodyBuffer.Bytes(), nil
+}
+
+func (r *ModerationsRequest) IsStream() bool {
+	return false
+}
+
+func (r *ModerationsRequest) GetModel() string {
+	return r.Model
+}
+
+func (r *ModerationsRequest) GetInput() any {
+	return r.Input
+}
+
+func (r *ModerationsRequest) SetModel(model string) error {
+	var err error
+
+	r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, nil, NewReplace("/model", model))
+	if err != nil {
+		return err
+	}
+
+	r.Model = model
+
+	return nil
+}
+
+func (r *ModerationsRequest) SetDefaultParams(params map[string]*structpb.Value) error {
+	for k, v := range params {
+		if _, exists := r.bodyParsed[k]; exists {
+			continue
+		}
+
+		var err error
+
+		r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, nil, NewAdd("/"+k, &v))
+		if err != nil {
+			return fmt.Errorf("failed to add key %s: %w", k, err)
+		}
+	}
+
+	changedModel := r.bodyParsed["model"]
+	if model, ok := changedModel.(string); ok && r.Model != model {
+		r.Model = model
+	}
+
+	return nil
+}
+
+func (r *ModerationsRequest) SetOverrideParams(params map[string]*structpb.Value) error {
+	applyOpt := jsonpatch.NewApplyOptions()
+	applyOpt.EnsurePathExistsOnAdd = true
+
+	for k, v := range params {
+		var err error
+
+		r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, applyOpt, NewAdd("/"+k, &v))
+		if err != nil {
+			return err
+		}
+	}
+
+	changedModel := r.bodyParsed["model"]
+	if model, ok := changedModel.(string); ok && r.Model != model {
+		r.Model = model
+	}
+
+	return nil
+}
+
+func (r *ModerationsRequest) RemoveParamKeys(keys []string) error {
+	applyOpt := jsonpatch.NewApplyOptions()
+	applyOpt.AllowMissingPathOnRemove = true
+
+	for _, v := range keys {
+		var err error
+
+		r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, applyOpt, NewRemove("/"+v))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ModerationsRequest) GetRequestType() object.RequestType {
+	return object.RequestTypeModerations
+}
+
+func (r *ModerationsRequest) GetRawRequest() *http.Request {
+	return r.incomingRequest
+}
+
+func (r *ModerationsRequest) GetBodyParsed() map[string]any {
+	return r.bodyParsed
+}
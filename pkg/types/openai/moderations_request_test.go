@@ -0,0 +1,86 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"knoway.dev/pkg/object"
+)
+
+func TestNewModerationsRequest(t *testing.T) {
+	t.Run("with model", func(t *testing.T) {
+		body := []byte(`{
+			"model": "public/omni-moderation",
+			"input": "I want to kill them."
+		}`)
+
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/moderations", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		moderationsReq, err := NewModerationsRequest(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, "public/omni-moderation", moderationsReq.GetModel())
+		assert.Equal(t, "I want to kill them.", moderationsReq.GetInput())
+		assert.Equal(t, object.RequestTypeModerations, moderationsReq.GetRequestType())
+		assert.False(t, moderationsReq.IsStream())
+	})
+
+	t.Run("default model", func(t *testing.T) {
+		body := []byte(`{
+			"input": ["first", "second"]
+		}`)
+
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/moderations", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		moderationsReq, err := NewModerationsRequest(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, DefaultModerationsModel, moderationsReq.GetModel())
+		assert.Equal(t, DefaultModerationsModel, moderationsReq.bodyParsed["model"])
+	})
+
+	t.Run("missing input", func(t *testing.T) {
+		body := []byte(`{
+			"model": "public/omni-moderation"
+		}`)
+
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/moderations", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		_, err = NewModerationsRequest(req)
+		require.Error(t, err)
+	})
+}
+
+func TestModerationsSetModelAndParams(t *testing.T) {
+	body := []byte(`{
+		"model": "public/omni-moderation",
+		"input": "hello"
+	}`)
+
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/moderations", bytes.NewReader(body))
+	require.NoError(t, err)
+
+	moderationsReq, err := NewModerationsRequest(req)
+	require.NoError(t, err)
+
+	err = moderationsReq.SetModel("omni-moderation-latest")
+	require.NoError(t, err)
+
+	err = moderationsReq.SetOverrideParams(map[string]*structpb.Value{
+		"threshold": structpb.NewNumberValue(0.5),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "omni-moderation-latest", moderationsReq.GetModel())
+	assert.Equal(t, "omni-moderation-latest", moderationsReq.bodyParsed["model"])
+	assert.InDelta(t, 0.5, moderationsReq.bodyParsed["threshold"], 0.0001)
+}
@@ -0,0 +1,165 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/utils"
+)
+
+var _ object.LLMResponse = (*ModerationsResponse)(nil)
+
+type ModerationsResult struct {
+	Flagged        bool               `json:"flagged"`
+	Categories     map[string]bool    `json:"categories,omitempty"`
+	CategoryScores map[string]float64 `json:"category_scores,omitempty"`
+}
+
+type ModerationsResponse struct {
+	Status  int                   `json:"status"`
+	ID      string                `json:"id"`
+	Model   string                `json:"model"`
+	Results []*ModerationsResult  `json:"results"`
+	Usage   *ChatCompletionsUsage `json:"usage,omitempty"`
+	Error   *ErrorResponse        `json:"error,omitempty"`
+
+	request          object.LLMRequest
+	responseBody     json.RawMessage
+	bodyParsed       map[string]any
+	outgoingResponse *http.Response
+}
+
+func NewModerationsResponse(request object.LLMRequest, response *http.Response, reader *bufio.Reader) (*ModerationsResponse, error) {
+	resp := new(ModerationsResponse)
+	resp.request = request
+	resp.outgoingResponse = response
+
+	buffer := new(bytes.Buffer)
+
+	_, err := buffer.ReadFrom(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	err = resp.processBytes(buffer.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w, body: %s", err, buffer.String())
+	}
+
+	return resp, nil
+}
+
+func (r *ModerationsResponse) processBytes(bs []byte, response *http.Response) error {
+	if r == nil {
+		return nil
+	}
+
+	r.responseBody = bs
+	r.Status = response.StatusCode
+
+	var body map[string]any
+
+	err := json.Unmarshal(bs, &body)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	r.bodyParsed = body
+
+	r.ID = utils.GetByJSONPath[string](body, "{ .id }")
+	r.Model = utils.GetByJSONPath[string](body, "{ .model }")
+
+	resultsArray := utils.GetByJSONPath[[]map[string]any](body, "{ .results }")
+	r.Results = make([]*ModerationsResult, 0, len(resultsArray))
+
+	for _, result := range resultsArray {
+		res, err := utils.FromMap[ModerationsResult](result)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal results: %w", err)
+		}
+
+		r.Results = append(r.Results, res)
+	}
+
+	// OpenAI doesn't report usage for moderations, but self-hosted moderation
+	// models served by vLLM and alike do.
+	usageMap := utils.GetByJSONPath[map[string]any](body, "{ .usage }")
+	if usageMap != nil {
+		r.Usage, err = utils.FromMap[ChatCompletionsUsage](usageMap)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal usage: %w", err)
+		}
+	}
+
+	errorResponse, err := unmarshalErrorResponseFromParsedBody(body, response, bs)
+	if err != nil {
+		return err
+	}
+
+	if errorResponse != nil {
+		r.Error = errorResponse
+	}
+
+	return nil
+}
+
+func (r *ModerationsResponse) MarshalJSON() ([]byte, error) {
+	return r.responseBody, nil
+}
+
+func (r *ModerationsResponse) IsStream() bool {
+	return false
+}
+
+func (r *ModerationsResponse) GetRequestID() string {
+	return r.ID
+}
+
+func (r *ModerationsResponse) GetModel() string {
+	return r.Model
+}
+
+func (r *ModerationsResponse) SetModel(model string) error {
+	if r.Error == nil {
+		var err error
+
+		r.responseBody, r.bodyParsed, err = modifyBytesBodyAndParsed(r.responseBody, NewReplace("/model", model))
+		if err != nil {
+			return err
+		}
+	}
+
+	r.Model = model
+
+	return nil
+}
+
+func (r *ModerationsResponse) IsFlagged() bool {
+	for _, result := range r.Results {
+		if result.Flagged {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *ModerationsResponse) GetUsage() object.LLMUsage {
+	if r.Usage == nil {
+		return nil
+	}
+
+	return r.Usage
+}
+
+func (r *ModerationsResponse) GetError() object.LLMError {
+	if r.Error != nil {
+		return r.Error
+	}
+
+	return nil
+}
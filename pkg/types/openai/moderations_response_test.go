@@ -0,0 +1,90 @@
+package openai
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewModerationsResponse(t *testing.T) {
+	t.Run("without usage", func(t *testing.T) {
+		body := `{
+			"id": "modr-123",
+			"model": "omni-moderation-latest",
+			"results": [
+				{
+					"flagged": true,
+					"categories": { "violence": true, "harassment": false },
+					"category_scores": { "violence": 0.98, "harassment": 0.01 }
+				}
+			]
+		}`
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+
+		resp, err := NewModerationsResponse(nil, httpResp, bufio.NewReader(httpResp.Body))
+		require.NoError(t, err)
+
+		assert.Equal(t, "modr-123", resp.GetRequestID())
+		assert.Equal(t, "omni-moderation-latest", resp.GetModel())
+		require.Len(t, resp.Results, 1)
+		assert.True(t, resp.IsFlagged())
+		assert.True(t, resp.Results[0].Categories["violence"])
+		assert.InDelta(t, 0.98, resp.Results[0].CategoryScores["violence"], 0.0001)
+		assert.True(t, lo.IsNil(resp.GetUsage()))
+		assert.Nil(t, resp.GetError())
+
+		err = resp.SetModel("public/omni-moderation")
+		require.NoError(t, err)
+		assert.Equal(t, "public/omni-moderation", resp.bodyParsed["model"])
+	})
+
+	t.Run("with usage", func(t *testing.T) {
+		body := `{
+			"id": "modr-456",
+			"model": "llama-guard",
+			"results": [{ "flagged": false }],
+			"usage": { "prompt_tokens": 12, "total_tokens": 12 }
+		}`
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+
+		resp, err := NewModerationsResponse(nil, httpResp, bufio.NewReader(httpResp.Body))
+		require.NoError(t, err)
+
+		assert.False(t, resp.IsFlagged())
+		require.NotNil(t, resp.Usage)
+		assert.Equal(t, uint64(12), resp.Usage.GetPromptTokens())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		body := `{
+			"error": {
+				"message": "Incorrect API key provided",
+				"type": "invalid_request_error",
+				"code": "invalid_api_key"
+			}
+		}`
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusUnauthorized,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+
+		resp, err := NewModerationsResponse(nil, httpResp, bufio.NewReader(httpResp.Body))
+		require.NoError(t, err)
+		require.NotNil(t, resp.GetError())
+	})
+}
@@ -0,0 +1,313 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/gorilla/websocket"
+
+	"knoway.dev/pkg/object"
+)
+
+const (
+	// RealtimeSubprotocol is the subprotocol the clients of the Realtime API
+	// connect with.
+	RealtimeSubprotocol = "realtime"
+	// RealtimeAPIKeySubprotocolPrefix prefixes the API keys of the clients
+	// unable to set headers, e.g. browsers.
+	RealtimeAPIKeySubprotocolPrefix = "openai-insecure-api-key."
+	// RealtimeBetaSubprotocol is the subprotocol of the clients of the beta
+	// Realtime API, equivalent to the OpenAI-Beta: realtime=v1 header.
+	RealtimeBetaSubprotocol = "openai-beta.realtime-v1"
+
+	realtimeCloseTimeout = time.Second
+)
+
+var _ object.LLMRequest = (*RealtimeRequest)(nil)
+
+// RealtimeRequest represents the requests establishing sessions of the OpenAI
+// Realtime API over WebSocket, the model is given by the query rather than
+// the body.
+// API reference: https://platform.openai.com/docs/api-reference/realtime
+type RealtimeRequest struct {
+	Model string `json:"model,omitempty"`
+
+	incomingRequest *http.Request
+}
+
+func NewRealtimeRequest(httpRequest *http.Request) (*RealtimeRequest, error) {
+	if !websocket.IsWebSocketUpgrade(httpRequest) {
+		return nil, NewErrorBadRequest().WithMessage("the Realtime API is only available over WebSocket")
+	}
+
+	model := httpRequest.URL.Query().Get("model")
+	if model == "" {
+		return nil, NewErrorMissingModel()
+	}
+
+	return &RealtimeRequest{
+		Model:           model,
+		incomingRequest: httpRequest,
+	}, nil
+}
+
+func (r *RealtimeRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{"model": r.Model})
+}
+
+// IsStream is always true, the events of the sessions are streamed.
+func (r *RealtimeRequest) IsStream() bool {
+	return true
+}
+
+func (r *RealtimeRequest) GetModel() string {
+	return r.Model
+}
+
+func (r *RealtimeRequest) SetModel(model string) error {
+	r.Model = model
+
+	return nil
+}
+
+// SetDefaultParams is a no-op, the sessions are configured by the
+// session.update events of the clients.
+func (r *RealtimeRequest) SetDefaultParams(map[string]*structpb.Value) error {
+	return nil
+}
+
+// SetOverrideParams is a no-op, see SetDefaultParams.
+func (r *RealtimeRequest) SetOverrideParams(map[string]*structpb.Value) error {
+	return nil
+}
+
+// RemoveParamKeys is a no-op, see SetDefaultParams.
+func (r *RealtimeRequest) RemoveParamKeys([]string) error {
+	return nil
+}
+
+func (r *RealtimeRequest) GetRequestType() object.RequestType {
+	return object.RequestTypeRealtime
+}
+
+func (r *RealtimeRequest) GetRawRequest() *http.Request {
+	return r.incomingRequest
+}
+
+var _ object.LLMResponse = (*RealtimeSession)(nil)
+
+// RealtimeSession is the connection to the Realtime API of the upstream,
+// relayed to the client by Relay. The usage of the responses of the session
+// is accumulated from the response.done events.
+type RealtimeSession struct {
+	Model string `json:"model"`
+
+	upstream *websocket.Conn
+
+	mutex     sync.Mutex
+	responses int
+	usage     *ChatCompletionsUsage
+}
+
+func NewRealtimeSession(upstream *websocket.Conn, model string) *RealtimeSession {
+	return &RealtimeSession{
+		Model:    model,
+		upstream: upstream,
+		usage: &ChatCompletionsUsage{
+			PromptTokensDetails:     &PromptTokensDetails{},
+			CompletionTokensDetails: &CompletionTokensDetails{},
+		},
+	}
+}
+
+func (s *RealtimeSession) MarshalJSON() ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return json.Marshal(map[string]any{
+		"model":     s.Model,
+		"responses": s.responses,
+		"usage":     s.usage,
+	})
+}
+
+// IsStream is always true, the events of the sessions are streamed.
+func (s *RealtimeSession) IsStream() bool {
+	return true
+}
+
+func (s *RealtimeSession) GetRequestID() string {
+	return ""
+}
+
+// GetUsage returns the usage of all the responses of the session, or nil if
+// none have been done.
+func (s *RealtimeSession) GetUsage() object.LLMUsage {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.responses == 0 {
+		return nil
+	}
+
+	return s.usage
+}
+
+// GetResponses returns the number of responses done in the session.
+func (s *RealtimeSession) GetResponses() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.responses
+}
+
+func (s *RealtimeSession) GetError() object.LLMError {
+	return nil
+}
+
+func (s *RealtimeSession) GetModel() string {
+	return s.Model
+}
+
+func (s *RealtimeSession) SetModel(model string) error {
+	s.Model = model
+
+	return nil
+}
+
+// Close closes the connection to the upstream, for sessions never relayed.
+func (s *RealtimeSession) Close() error {
+	return s.upstream.Close()
+}
+
+// Relay relays the events between the client and the upstream until either
+// of them closes the session or ctx is done, the close frames are forwarded
+// to the other side.
+func (s *RealtimeSession) Relay(ctx context.Context, client *websocket.Conn) error {
+	errs := make(chan error, 2) //nolint:mnd
+
+	go func() {
+		errs <- relayRealtimeMessages(s.upstream, client, nil)
+	}()
+	go func() {
+		errs <- relayRealtimeMessages(client, s.upstream, s.observeEvent)
+	}()
+
+	var err error
+
+	pending := 2
+
+	select {
+	case err = <-errs:
+		pending--
+	case <-ctx.Done():
+		err = context.Cause(ctx)
+	}
+
+	// Sides closed already reply with ErrCloseSent, which is ignored
+	closeMessage := websocket.FormatCloseMessage(websocket.CloseGoingAway, "")
+	_ = client.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(realtimeCloseTimeout))
+	_ = s.upstream.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(realtimeCloseTimeout))
+
+	_ = client.Close()
+	_ = s.upstream.Close()
+
+	for range pending {
+		<-errs
+	}
+
+	return err
+}
+
+// relayRealtimeMessages copies the messages of src to dst, observe is called
+// with the text messages before they are copied.
+func relayRealtimeMessages(dst *websocket.Conn, src *websocket.Conn, observe func(message []byte)) error {
+	for {
+		messageType, message, err := src.ReadMessage()
+		if err != nil {
+			var closeErr *websocket.CloseError
+			if errors.As(err, &closeErr) && closeErr.Code != websocket.CloseAbnormalClosure {
+				_ = dst.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(closeErr.Code, closeErr.Text), time.Now().Add(realtimeCloseTimeout))
+				return nil
+			}
+
+			return err
+		}
+
+		if observe != nil && messageType == websocket.TextMessage {
+			observe(message)
+		}
+
+		err = dst.WriteMessage(messageType, message)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+type realtimeUsage struct {
+	TotalTokens       uint64 `json:"total_tokens"`
+	InputTokens       uint64 `json:"input_tokens"`
+	OutputTokens      uint64 `json:"output_tokens"`
+	InputTokenDetails struct {
+		AudioTokens  uint64 `json:"audio_tokens"`
+		CachedTokens uint64 `json:"cached_tokens"`
+	} `json:"input_token_details"`
+	OutputTokenDetails struct {
+		AudioTokens uint64 `json:"audio_tokens"`
+	} `json:"output_token_details"`
+}
+
+type realtimeServerEvent struct {
+	Type     string `json:"type"`
+	Response struct {
+		Usage *realtimeUsage `json:"usage"`
+	} `json:"response"`
+}
+
+var realtimeResponseDoneEvent = []byte(`"response.done"`)
+
+// observeEvent accumulates the usage of the response.done events of the
+// upstream.
+func (s *RealtimeSession) observeEvent(message []byte) {
+	// Most of the events are deltas of audio, avoid parsing them
+	if !bytes.Contains(message, realtimeResponseDoneEvent) {
+		return
+	}
+
+	var event realtimeServerEvent
+
+	err := json.Unmarshal(message, &event)
+	if err != nil {
+		slog.Warn("failed to parse event of realtime session", slog.String("model", s.Model), slog.Any("error", err))
+		return
+	}
+
+	if event.Type != "response.done" {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.responses++
+
+	usage := event.Response.Usage
+	if usage == nil {
+		return
+	}
+
+	s.usage.TotalTokens += usage.TotalTokens
+	s.usage.PromptTokens += usage.InputTokens
+	s.usage.CompletionTokens += usage.OutputTokens
+	s.usage.PromptTokensDetails.AudioTokens += usage.InputTokenDetails.AudioTokens
+	s.usage.PromptTokensDetails.CachedTokens += usage.InputTokenDetails.CachedTokens
+	s.usage.CompletionTokensDetails.AudioTokens += usage.OutputTokenDetails.AudioTokens
+}
@@ -0,0 +1,143 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/pkg/object"
+)
+
+func TestNewRealtimeRequest(t *testing.T) {
+	httpRequest := httptest.NewRequest(http.MethodGet, "/v1/realtime?model=gpt-4o-realtime-preview", nil)
+
+	_, err := NewRealtimeRequest(httpRequest)
+	require.ErrorContains(t, err, "only available over WebSocket")
+
+	httpRequest.Header.Set("Connection", "Upgrade")
+	httpRequest.Header.Set("Upgrade", "websocket")
+
+	request, err := NewRealtimeRequest(httpRequest)
+	require.NoError(t, err)
+	assert.Equal(t, "gpt-4o-realtime-preview", request.GetModel())
+	assert.Equal(t, object.RequestTypeRealtime, request.GetRequestType())
+	assert.True(t, request.IsStream())
+
+	httpRequest.URL.RawQuery = ""
+
+	_, err = NewRealtimeRequest(httpRequest)
+	require.ErrorContains(t, err, "you must provide a model parameter")
+}
+
+const realtimeResponseDone = `{
+  "type": "response.done",
+  "response": {
+    "usage": {
+      "total_tokens": 100,
+      "input_tokens": 40,
+      "output_tokens": 60,
+      "input_token_details": {"cached_tokens": 10, "audio_tokens": 30},
+      "output_token_details": {"audio_tokens": 50}
+    }
+  }
+}`
+
+func TestRealtimeSessionRelay(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+
+	// The upstream responds every event of the client with a response
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for {
+			_, _, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			err = conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"response.audio.delta","delta":"AAAA"}`))
+			if err != nil {
+				return
+			}
+
+			err = conn.WriteMessage(websocket.TextMessage, []byte(realtimeResponseDone))
+			if err != nil {
+				return
+			}
+		}
+	}))
+	defer upstream.Close()
+
+	sessions := make(chan *RealtimeSession, 1)
+
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upstreamConn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(upstream.URL, "http"), nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		session := NewRealtimeSession(upstreamConn, "gpt-4o-realtime-preview")
+
+		client, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.NoError(t, session.Relay(context.Background(), client))
+
+		sessions <- session
+	}))
+	defer gateway.Close()
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(gateway.URL, "http"), nil)
+	require.NoError(t, err)
+
+	for range 2 {
+		require.NoError(t, client.WriteMessage(websocket.TextMessage, []byte(`{"type":"response.create"}`)))
+
+		_, message, err := client.ReadMessage()
+		require.NoError(t, err)
+		assert.Contains(t, string(message), "response.audio.delta")
+
+		_, message, err = client.ReadMessage()
+		require.NoError(t, err)
+		assert.Contains(t, string(message), "response.done")
+	}
+
+	require.NoError(t, client.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")))
+
+	// The close frame is forwarded back by the upstream
+	_, _, err = client.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), "unexpected error %v", err)
+
+	session := <-sessions
+	assert.Equal(t, 2, session.GetResponses())
+
+	usage, ok := session.GetUsage().(*ChatCompletionsUsage)
+	require.True(t, ok)
+	assert.Equal(t, uint64(200), usage.GetTotalTokens())
+	assert.Equal(t, uint64(80), usage.GetPromptTokens())
+	assert.Equal(t, uint64(120), usage.GetCompletionTokens())
+	assert.Equal(t, uint64(60), usage.PromptTokensDetails.AudioTokens)
+	assert.Equal(t, uint64(20), usage.PromptTokensDetails.CachedTokens)
+	assert.Equal(t, uint64(100), usage.CompletionTokensDetails.AudioTokens)
+}
+
+func TestRealtimeSessionWithoutResponses(t *testing.T) {
+	session := NewRealtimeSession(nil, "gpt-4o-realtime-preview")
+	assert.Nil(t, session.GetUsage())
+
+	session.observeEvent([]byte(`{"type":"session.created","session":{"model":"gpt-4o-realtime-preview"}}`))
+	assert.Nil(t, session.GetUsage())
+	assert.Equal(t, 0, session.GetResponses())
+}
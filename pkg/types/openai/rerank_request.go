@@ -0,0 +1,173 @@
+package openai
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/utils"
+)
+
+var _ object.LLMRequest = (*RerankRequest)(nil)
+
+// RerankRequest represents the rerank requests compatible with Cohere and
+// Jina, which vLLM and most of the other providers follow.
+// API reference: https://docs.cohere.com/reference/rerank
+// API reference: https://jina.ai/reranker
+type RerankRequest struct {
+	Model string `json:"model,omitempty"`
+	Query string `json:"query,omitempty"`
+	// Documents can be an array of strings or an array of objects with text,
+	// so they are kept as-is.
+	Documents []any `json:"documents,omitempty"`
+	TopN      *int  `json:"top_n,omitempty"`
+
+	bodyParsed      map[string]any
+	bodyBuffer      *bytes.Buffer
+	incomingRequest *http.Request
+}
+
+func NewRerankRequest(httpRequest *http.Request) (*RerankRequest, error) {
+	buffer, parsed, err := utils.ReadAsJSONWithClose(httpRequest.Body)
+	if err != nil {
+		return nil, NewErrorInvalidBody()
+	}
+
+	documents, _ := parsed["documents"].([]any)
+
+	req := &RerankRequest{
+		Model:           utils.GetByJSONPath[string](parsed, "{ .model }"),
+		Query:           utils.GetByJSONPath[string](parsed, "{ .query }"),
+		Documents:       documents,
+		TopN:            utils.GetByJSONPath[*int](parsed, "{ .top_n }"),
+		bodyParsed:      parsed,
+		bodyBuffer:      buffer,
+		incomingRequest: httpRequest,
+	}
+
+	if req.Query == "" {
+		return nil, NewErrorMissingParameter("query")
+	}
+
+	if len(req.Documents) == 0 {
+		return nil, NewErrorMissingParameter("documents")
+	}
+
+	return req, nil
+}
+
+func (r *RerankRequest) MarshalJSON() ([]byte, error) {
+	return r.bodyBuffer.Bytes(), nil
+}
+
+func (r *RerankRequest) IsStream() bool {
+	return false
+}
+
+func (r *RerankRequest) GetModel() string {
+	return r.Model
+}
+
+func (r *RerankRequest) GetQuery() string {
+	return r.Query
+}
+
+func (r *RerankRequest) GetDocuments() []any {
+	return r.Documents
+}
+
+func (r *RerankRequest) GetTopN() *int {
+	return r.TopN
+}
+
+func (r *RerankRequest) SetModel(model string) error {
+	var err error
+
+	r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, nil, NewReplace("/model", model))
+	if err != nil {
+		return err
+	}
+
+	r.Model = model
+
+	return nil
+}
+
+func (r *RerankRequest) SetDefaultParams(params map[string]*structpb.Value) error {
+	for k, v := range params {
+		if _, exists := r.bodyParsed[k]; exists {
+			continue
+		}
+
+		var err error
+
+		r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, nil, NewAdd("/"+k, &v))
+		if err != nil {
+			return fmt.Errorf("failed to add key %s: %w", k, err)
+		}
+	}
+
+	r.syncFromParsed()
+
+	return nil
+}
+
+func (r *RerankRequest) SetOverrideParams(params map[string]*structpb.Value) error {
+	applyOpt := jsonpatch.NewApplyOptions()
+	applyOpt.EnsurePathExistsOnAdd = true
+
+	for k, v := range params {
+		var err error
+
+		r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, applyOpt, NewAdd("/"+k, &v))
+		if err != nil {
+			return err
+		}
+	}
+
+	r.syncFromParsed()
+
+	return nil
+}
+
+func (r *RerankRequest) RemoveParamKeys(keys []string) error {
+	applyOpt := jsonpatch.NewApplyOptions()
+	applyOpt.AllowMissingPathOnRemove = true
+
+	for _, v := range keys {
+		var err error
+
+		r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, applyOpt, NewRemove("/"+v))
+		if err != nil {
+			return err
+		}
+	}
+
+	r.syncFromParsed()
+
+	return nil
+}
+
+func (r *RerankRequest) syncFromParsed() {
+	if model, ok := r.bodyParsed["model"].(string); ok && r.Model != model {
+		r.Model = model
+	}
+
+	r.TopN = utils.GetByJSONPath[*int](r.bodyParsed, "{ .top_n }")
+}
+
+func (r *RerankRequest) GetRequestType() object.RequestType {
+	return object.RequestTypeRerank
+}
+
+func (r *RerankRequest) GetRawRequest() *http.Request {
+	return r.incomingRequest
+}
+
+func (r *RerankRequest) GetBodyParsed() map[string]any {
+	return r.bodyParsed
+}
@@ -0,0 +1,181 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/utils"
+)
+
+var _ object.LLMResponse = (*RerankResponse)(nil)
+
+type RerankResult struct {
+	Index          int     `json:"index"`
+	RelevanceScore float64 `json:"relevance_score"`
+	// Document is returned with return_documents, either a string or an
+	// object with text.
+	Document any `json:"document,omitempty"`
+}
+
+// RerankResponse is the response of rerank requests, the usage is taken from
+// usage as Jina and vLLM report it, or from meta.tokens as Cohere does.
+type RerankResponse struct {
+	Status  int                   `json:"status"`
+	ID      string                `json:"id"`
+	Model   string                `json:"model"`
+	Results []*RerankResult       `json:"results"`
+	Usage   *ChatCompletionsUsage `json:"usage,omitempty"`
+	Error   *ErrorResponse        `json:"error,omitempty"`
+
+	request          object.LLMRequest
+	responseBody     json.RawMessage
+	bodyParsed       map[string]any
+	outgoingResponse *http.Response
+}
+
+func NewRerankResponse(request object.LLMRequest, response *http.Response, reader *bufio.Reader) (*RerankResponse, error) {
+	resp := new(RerankResponse)
+	resp.request = request
+	resp.outgoingResponse = response
+
+	buffer := new(bytes.Buffer)
+
+	_, err := buffer.ReadFrom(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	err = resp.processBytes(buffer.Bytes(), response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w, body: %s", err, buffer.String())
+	}
+
+	return resp, nil
+}
+
+func (r *RerankResponse) processBytes(bs []byte, response *http.Response) error {
+	if r == nil {
+		return nil
+	}
+
+	r.responseBody = bs
+	r.Status = response.StatusCode
+
+	var body map[string]any
+
+	err := json.Unmarshal(bs, &body)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	r.bodyParsed = body
+
+	r.ID = utils.GetByJSONPath[string](body, "{ .id }")
+	r.Model = utils.GetByJSONPath[string](body, "{ .model }")
+
+	resultsArray := utils.GetByJSONPath[[]map[string]any](body, "{ .results }")
+	r.Results = make([]*RerankResult, 0, len(resultsArray))
+
+	for _, result := range resultsArray {
+		res, err := utils.FromMap[RerankResult](result)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal result: %w", err)
+		}
+
+		r.Results = append(r.Results, res)
+	}
+
+	r.Usage = nil
+
+	usageMap := utils.GetByJSONPath[map[string]any](body, "{ .usage }")
+	if usageMap != nil {
+		r.Usage, err = utils.FromMap[ChatCompletionsUsage](usageMap)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal usage: %w", err)
+		}
+
+		// Jina reports the total tokens only, all of them are of the input
+		if r.Usage.PromptTokens == 0 && r.Usage.CompletionTokens == 0 {
+			r.Usage.PromptTokens = r.Usage.TotalTokens
+		}
+	} else if tokensMap := utils.GetByJSONPath[map[string]any](body, "{ .meta.tokens }"); tokensMap != nil {
+		inputTokens := utils.GetByJSONPath[uint64](body, "{ .meta.tokens.input_tokens }")
+		outputTokens := utils.GetByJSONPath[uint64](body, "{ .meta.tokens.output_tokens }")
+
+		r.Usage = &ChatCompletionsUsage{
+			PromptTokens:     inputTokens,
+			CompletionTokens: outputTokens,
+			TotalTokens:      inputTokens + outputTokens,
+		}
+	}
+
+	errorResponse, err := unmarshalErrorResponseFromParsedBody(body, response, bs)
+	if err != nil {
+		return err
+	}
+
+	if errorResponse != nil {
+		r.Error = errorResponse
+	}
+
+	return nil
+}
+
+// SetBody replaces the body of the response, the usage and the other fields
+// are parsed from it again.
+func (r *RerankResponse) SetBody(body []byte) error {
+	return r.processBytes(body, r.outgoingResponse)
+}
+
+func (r *RerankResponse) MarshalJSON() ([]byte, error) {
+	return r.responseBody, nil
+}
+
+func (r *RerankResponse) IsStream() bool {
+	return false
+}
+
+func (r *RerankResponse) GetRequestID() string {
+	return r.ID
+}
+
+func (r *RerankResponse) GetModel() string {
+	return r.Model
+}
+
+// SetModel sets the model of the response, it is added to the responses of
+// the providers not reporting it, e.g. Cohere.
+func (r *RerankResponse) SetModel(model string) error {
+	if r.Error == nil {
+		var err error
+
+		r.responseBody, r.bodyParsed, err = modifyBytesBodyAndParsed(r.responseBody, NewAdd("/model", model))
+		if err != nil {
+			return err
+		}
+	}
+
+	r.Model = model
+
+	return nil
+}
+
+func (r *RerankResponse) GetUsage() object.LLMUsage {
+	if r.Usage == nil {
+		return nil
+	}
+
+	return r.Usage
+}
+
+func (r *RerankResponse) GetError() object.LLMError {
+	if r.Error != nil {
+		return r.Error
+	}
+
+	return nil
+}
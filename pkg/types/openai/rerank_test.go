@@ -0,0 +1,134 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"knoway.dev/pkg/object"
+)
+
+func TestNewRerankRequest(t *testing.T) {
+	t.Run("with params", func(t *testing.T) {
+		body := []byte(`{
+			"model": "public/jina-reranker-v2-base-multilingual",
+			"query": "Organic skincare products for sensitive skin",
+			"documents": ["Eco-friendly kitchenware", {"text": "Organic cotton baby clothes"}]
+		}`)
+
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/rerank", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		rerankReq, err := NewRerankRequest(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, "public/jina-reranker-v2-base-multilingual", rerankReq.GetModel())
+		assert.Equal(t, "Organic skincare products for sensitive skin", rerankReq.GetQuery())
+		assert.Len(t, rerankReq.GetDocuments(), 2)
+		assert.Nil(t, rerankReq.GetTopN())
+		assert.Equal(t, object.RequestTypeRerank, rerankReq.GetRequestType())
+		assert.False(t, rerankReq.IsStream())
+
+		require.NoError(t, rerankReq.SetModel("jina-reranker-v2-base-multilingual"))
+		require.NoError(t, rerankReq.SetDefaultParams(map[string]*structpb.Value{
+			"top_n": structpb.NewNumberValue(3),
+		}))
+		require.NoError(t, rerankReq.SetOverrideParams(map[string]*structpb.Value{
+			"return_documents": structpb.NewBoolValue(false),
+		}))
+
+		assert.Equal(t, "jina-reranker-v2-base-multilingual", rerankReq.bodyParsed["model"])
+		require.NotNil(t, rerankReq.GetTopN())
+		assert.Equal(t, 3, *rerankReq.GetTopN())
+		assert.Equal(t, false, rerankReq.bodyParsed["return_documents"])
+	})
+
+	t.Run("missing documents", func(t *testing.T) {
+		body := []byte(`{
+			"model": "jina-reranker-v2-base-multilingual",
+			"query": "Organic skincare products for sensitive skin"
+		}`)
+
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/rerank", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		_, err = NewRerankRequest(req)
+		require.Error(t, err)
+	})
+}
+
+func TestNewRerankResponse(t *testing.T) {
+	t.Run("jina", func(t *testing.T) {
+		body := `{
+			"model": "jina-reranker-v2-base-multilingual",
+			"results": [
+				{ "index": 1, "relevance_score": 0.87, "document": { "text": "Organic cotton baby clothes" } },
+				{ "index": 0, "relevance_score": 0.12 }
+			],
+			"usage": { "total_tokens": 42 }
+		}`
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+
+		resp, err := NewRerankResponse(nil, httpResp, bufio.NewReader(httpResp.Body))
+		require.NoError(t, err)
+
+		assert.Equal(t, "jina-reranker-v2-base-multilingual", resp.GetModel())
+		require.Len(t, resp.Results, 2)
+		assert.Equal(t, 1, resp.Results[0].Index)
+		assert.InDelta(t, 0.87, resp.Results[0].RelevanceScore, 0.0001)
+		assert.Nil(t, resp.GetError())
+
+		usage, ok := object.AsLLMTokensUsage(resp.GetUsage())
+		require.True(t, ok)
+		assert.Equal(t, uint64(42), usage.GetPromptTokens())
+		assert.Equal(t, uint64(0), usage.GetCompletionTokens())
+		assert.Equal(t, uint64(42), usage.GetTotalTokens())
+	})
+
+	t.Run("cohere", func(t *testing.T) {
+		body := `{
+			"id": "07734bd2-2473-4f07-94e1-0d9f0e6843cf",
+			"results": [
+				{ "index": 0, "relevance_score": 0.9 }
+			],
+			"meta": {
+				"api_version": { "version": "2" },
+				"billed_units": { "search_units": 1 },
+				"tokens": { "input_tokens": 20, "output_tokens": 1 }
+			}
+		}`
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+
+		resp, err := NewRerankResponse(nil, httpResp, bufio.NewReader(httpResp.Body))
+		require.NoError(t, err)
+
+		assert.Equal(t, "07734bd2-2473-4f07-94e1-0d9f0e6843cf", resp.GetRequestID())
+
+		usage, ok := object.AsLLMTokensUsage(resp.GetUsage())
+		require.True(t, ok)
+		assert.Equal(t, uint64(20), usage.GetPromptTokens())
+		assert.Equal(t, uint64(1), usage.GetCompletionTokens())
+		assert.Equal(t, uint64(21), usage.GetTotalTokens())
+
+		// Cohere does not report the model
+		err = resp.SetModel("rerank-v3.5")
+		require.NoError(t, err)
+		assert.Equal(t, "rerank-v3.5", resp.bodyParsed["model"])
+	})
+}
@@ -0,0 +1,291 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/samber/lo"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/stt"
+)
+
+var _ object.LLMRequest = (*SpeechToTextRequest)(nil)
+var _ stt.Request = (*SpeechToTextRequest)(nil)
+
+const speechToTextFileField = "file"
+
+// SpeechToTextRequest represents OpenAI-compatible transcription and
+// translation requests, which are uploaded in multipart/form-data.
+// API reference: https://platform.openai.com/docs/api-reference/audio/createTranscription
+type SpeechToTextRequest struct {
+	Model          string
+	Task           stt.Task
+	Language       string
+	Prompt         string
+	ResponseFormat string
+	Temperature    *float64
+
+	file            *stt.File
+	formValues      url.Values
+	bodyBuffer      *bytes.Buffer
+	contentType     string
+	incomingRequest *http.Request
+}
+
+func NewSpeechToTextRequest(httpRequest *http.Request, task stt.Task) (*SpeechToTextRequest, error) {
+	mediaType, params, err := mime.ParseMediaType(httpRequest.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" || params["boundary"] == "" {
+		return nil, NewErrorBadRequest().WithMessage("Content-Type must be multipart/form-data")
+	}
+
+	defer httpRequest.Body.Close()
+
+	req := &SpeechToTextRequest{
+		Task:            task,
+		formValues:      make(url.Values),
+		incomingRequest: httpRequest,
+	}
+
+	reader := multipart.NewReader(httpRequest.Body, params["boundary"])
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, NewErrorBadRequest().WithMessage("failed to parse multipart/form-data body: " + err.Error())
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, NewErrorBadRequest().WithMessage("failed to read multipart/form-data body: " + err.Error())
+		}
+
+		if part.FormName() == speechToTextFileField {
+			req.file = &stt.File{
+				Name:        part.FileName(),
+				ContentType: lo.CoalesceOrEmpty(part.Header.Get("Content-Type"), "application/octet-stream"),
+				Data:        data,
+			}
+
+			continue
+		}
+
+		req.formValues.Add(part.FormName(), string(data))
+	}
+
+	if req.file == nil {
+		return nil, NewErrorMissingParameter(speechToTextFileField)
+	}
+
+	err = req.sync()
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// sync parses the fields from the form values and encodes the body again.
+func (r *SpeechToTextRequest) sync() error {
+	r.Model = r.formValues.Get("model")
+	r.Language = r.formValues.Get("language")
+	r.Prompt = r.formValues.Get("prompt")
+	r.ResponseFormat = r.formValues.Get("response_format")
+	r.Temperature = nil
+
+	if temperature := r.formValues.Get("temperature"); temperature != "" {
+		parsed, err := strconv.ParseFloat(temperature, 64)
+		if err != nil {
+			return NewErrorBadRequest().WithMessage(fmt.Sprintf("invalid temperature %q", temperature))
+		}
+
+		r.Temperature = &parsed
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := multipart.NewWriter(buffer)
+
+	keys := lo.Keys(r.formValues)
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		for _, value := range r.formValues[key] {
+			err := writer.WriteField(key, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, speechToTextFileField, escapeQuotes(lo.CoalesceOrEmpty(r.file.Name, "audio"))))
+	header.Set("Content-Type", r.file.ContentType)
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	_, err = part.Write(r.file.Data)
+	if err != nil {
+		return err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+
+	r.bodyBuffer = buffer
+	r.contentType = writer.FormDataContentType()
+
+	return nil
+}
+
+func (r *SpeechToTextRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.formValues)
+}
+
+func (r *SpeechToTextRequest) IsStream() bool {
+	return false
+}
+
+func (r *SpeechToTextRequest) GetModel() string {
+	return r.Model
+}
+
+func (r *SpeechToTextRequest) GetTask() stt.Task {
+	return r.Task
+}
+
+func (r *SpeechToTextRequest) GetFile() *stt.File {
+	return r.file
+}
+
+func (r *SpeechToTextRequest) GetLanguage() string {
+	return r.Language
+}
+
+func (r *SpeechToTextRequest) GetPrompt() string {
+	return r.Prompt
+}
+
+func (r *SpeechToTextRequest) GetResponseFormat() string {
+	return r.ResponseFormat
+}
+
+func (r *SpeechToTextRequest) GetTemperature() *float64 {
+	return r.Temperature
+}
+
+func (r *SpeechToTextRequest) SetModel(model string) error {
+	r.formValues.Set("model", model)
+
+	return r.sync()
+}
+
+func (r *SpeechToTextRequest) SetDefaultParams(params map[string]*structpb.Value) error {
+	for k, v := range params {
+		if r.formValues.Has(k) {
+			continue
+		}
+
+		err := setFormValue(r.formValues, k, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.sync()
+}
+
+func (r *SpeechToTextRequest) SetOverrideParams(params map[string]*structpb.Value) error {
+	for k, v := range params {
+		err := setFormValue(r.formValues, k, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.sync()
+}
+
+func (r *SpeechToTextRequest) RemoveParamKeys(keys []string) error {
+	for _, k := range keys {
+		r.formValues.Del(k)
+	}
+
+	return r.sync()
+}
+
+func (r *SpeechToTextRequest) GetRequestType() object.RequestType {
+	return object.RequestTypeSpeechToText
+}
+
+func (r *SpeechToTextRequest) GetRawRequest() *http.Request {
+	return r.incomingRequest
+}
+
+func (r *SpeechToTextRequest) GetBodyBuffer() *bytes.Buffer {
+	return r.bodyBuffer
+}
+
+func (r *SpeechToTextRequest) GetContentType() string {
+	return r.contentType
+}
+
+// setFormValue sets the form value from the param, lists are set as
+// repeated values, e.g. timestamp_granularities[].
+func setFormValue(values url.Values, key string, value *structpb.Value) error {
+	values.Del(key)
+
+	list := []*structpb.Value{value}
+	if listValue := value.GetListValue(); listValue != nil {
+		list = listValue.GetValues()
+	}
+
+	for _, v := range list {
+		switch kind := v.GetKind().(type) {
+		case *structpb.Value_StringValue:
+			values.Add(key, kind.StringValue)
+		case *structpb.Value_NumberValue:
+			values.Add(key, strconv.FormatFloat(kind.NumberValue, 'f', -1, 64))
+		case *structpb.Value_BoolValue:
+			values.Add(key, strconv.FormatBool(kind.BoolValue))
+		case *structpb.Value_NullValue:
+			continue
+		default:
+			bs, err := json.Marshal(v)
+			if err != nil {
+				return fmt.Errorf("failed to marshal param %s: %w", key, err)
+			}
+
+			values.Add(key, string(bs))
+		}
+	}
+
+	return nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// escapeQuotes escapes the file name in Content-Disposition the same way
+// mime/multipart does.
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
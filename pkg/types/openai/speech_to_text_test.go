@@ -0,0 +1,152 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/stt"
+)
+
+func newSpeechToTextHTTPRequest(t *testing.T, fields map[string]string, audio []byte) *http.Request {
+	t.Helper()
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	for k, v := range fields {
+		require.NoError(t, writer.WriteField(k, v))
+	}
+
+	if audio != nil {
+		part, err := writer.CreateFormFile("file", "speech.mp3")
+		require.NoError(t, err)
+
+		_, err = part.Write(audio)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/audio/transcriptions", body)
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return req
+}
+
+func TestNewSpeechToTextRequest(t *testing.T) {
+	t.Run("with params", func(t *testing.T) {
+		req := newSpeechToTextHTTPRequest(t, map[string]string{
+			"model":       "public/whisper-1",
+			"language":    "en",
+			"temperature": "0.2",
+		}, []byte("audio"))
+
+		sttReq, err := NewSpeechToTextRequest(req, stt.TaskTranscriptions)
+		require.NoError(t, err)
+
+		assert.Equal(t, "public/whisper-1", sttReq.GetModel())
+		assert.Equal(t, "en", sttReq.GetLanguage())
+		assert.InDelta(t, 0.2, *sttReq.GetTemperature(), 0.0001)
+		assert.Equal(t, stt.TaskTranscriptions, sttReq.GetTask())
+		assert.Equal(t, object.RequestTypeSpeechToText, sttReq.GetRequestType())
+		assert.Equal(t, []byte("audio"), sttReq.GetFile().Data)
+		assert.Equal(t, "speech.mp3", sttReq.GetFile().Name)
+
+		require.NoError(t, sttReq.SetModel("whisper-1"))
+		require.NoError(t, sttReq.SetDefaultParams(map[string]*structpb.Value{
+			"language":        structpb.NewStringValue("fr"),
+			"response_format": structpb.NewStringValue("text"),
+		}))
+		require.NoError(t, sttReq.SetOverrideParams(map[string]*structpb.Value{
+			"timestamp_granularities[]": structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{
+				structpb.NewStringValue("word"),
+				structpb.NewStringValue("segment"),
+			}}),
+		}))
+		require.NoError(t, sttReq.RemoveParamKeys([]string{"temperature"}))
+
+		assert.Equal(t, "whisper-1", sttReq.GetModel())
+		assert.Equal(t, "en", sttReq.GetLanguage())
+		assert.Equal(t, "text", sttReq.GetResponseFormat())
+		assert.Nil(t, sttReq.GetTemperature())
+
+		// The body is encoded again with the changed params
+		_, params, err := mime.ParseMediaType(sttReq.GetContentType())
+		require.NoError(t, err)
+
+		form, err := multipart.NewReader(bytes.NewReader(sttReq.GetBodyBuffer().Bytes()), params["boundary"]).ReadForm(1 << 20)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"whisper-1"}, form.Value["model"])
+		assert.Equal(t, []string{"word", "segment"}, form.Value["timestamp_granularities[]"])
+		assert.NotContains(t, form.Value, "temperature")
+		require.Len(t, form.File["file"], 1)
+		assert.Equal(t, "speech.mp3", form.File["file"][0].Filename)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		req := newSpeechToTextHTTPRequest(t, map[string]string{"model": "whisper-1"}, nil)
+
+		_, err := NewSpeechToTextRequest(req, stt.TaskTranscriptions)
+		require.Error(t, err)
+	})
+
+	t.Run("not multipart", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/audio/transcriptions", strings.NewReader(`{"model":"whisper-1"}`))
+		require.NoError(t, err)
+
+		req.Header.Set("Content-Type", "application/json")
+
+		_, err = NewSpeechToTextRequest(req, stt.TaskTranscriptions)
+		require.Error(t, err)
+	})
+}
+
+func TestBuildTranscriptionRequest(t *testing.T) {
+	req := newSpeechToTextHTTPRequest(t, map[string]string{"model": "whisper-1"}, []byte("audio"))
+
+	sttReq, err := NewSpeechToTextRequest(req, stt.TaskTranslations)
+	require.NoError(t, err)
+
+	httpReq, err := BuildTranscriptionRequest(context.TODO(), "http://whisper.default.svc.cluster.local:8000/v1/", "Bearer sk-xxx", sttReq, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "http://whisper.default.svc.cluster.local:8000/v1/audio/translations", httpReq.URL.String())
+	assert.Equal(t, sttReq.GetContentType(), httpReq.Header.Get("Content-Type"))
+	assert.Equal(t, "Bearer sk-xxx", httpReq.Header.Get("Authorization"))
+
+	httpResp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+
+	resp, err := ParseTranscriptionResponse(httpResp, []byte(`{"text":"Hello world."}`), sttReq)
+	require.NoError(t, err)
+
+	transcription, ok := resp.(*stt.TranscriptionResponse)
+	require.True(t, ok)
+	assert.Equal(t, "Hello world.", transcription.Text)
+	assert.Equal(t, "whisper-1", transcription.GetModel())
+	assert.Zero(t, transcription.Duration)
+
+	resp, err = ParseTranscriptionResponse(httpResp, []byte(`{"task":"translate","language":"english","duration":8.47,"text":"Hello world."}`), sttReq)
+	require.NoError(t, err)
+
+	transcription, ok = resp.(*stt.TranscriptionResponse)
+	require.True(t, ok)
+	assert.InDelta(t, 8.47, transcription.Duration, 0.001)
+}
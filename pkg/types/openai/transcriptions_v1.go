@@ -0,0 +1,56 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/types/stt"
+)
+
+const (
+	defaultOpenAIAudioBaseURL = "https://api.openai.com/v1"
+)
+
+// BuildTranscriptionRequest builds the request of /audio/transcriptions or
+// /audio/translations for the task, baseURL is the same as the one of chat
+// completions, e.g. https://api.openai.com/v1.
+func BuildTranscriptionRequest(ctx context.Context, baseURL string, authHeader string, req stt.Request, _ http.Header, _ http.Header) (*http.Request, error) {
+	if baseURL == "" {
+		baseURL = defaultOpenAIAudioBaseURL
+	}
+
+	upstreamURL := strings.TrimSuffix(baseURL, "/") + "/audio/" + string(req.GetTask())
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, upstreamURL, bytes.NewReader(req.GetBodyBuffer().Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Authorization", authHeader)
+	httpReq.Header.Set("Content-Type", req.GetContentType())
+
+	return httpReq, nil
+}
+
+func ParseTranscriptionResponse(resp *http.Response, body []byte, req stt.Request) (object.LLMResponse, error) {
+	if resp == nil {
+		return nil, NewErrorBadGateway().WithMessage("upstream response is nil")
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		errResp, err := ParseErrorResponse(resp, body)
+		if err != nil || errResp == nil {
+			return nil, NewErrorBadGateway().WithMessage("upstream error: " + resp.Status)
+		}
+
+		return nil, errResp
+	}
+
+	transcription := stt.NewTranscriptionResponseFromBytes(resp.StatusCode, resp.Header.Get("Content-Type"), req.GetModel(), body)
+	transcription.RequestID = resp.Header.Get("x-request-id")
+
+	return transcription, nil
+}
@@ -95,3 +95,48 @@ func (u *ImageGenerationsUsage) GetPromptTokens() uint64 {
 func (u *ImageGenerationsUsage) GetOutputImages() []object.ImageGenerationsUsageImage {
 	return utils.TypeAssertFrom[*ImageGenerationsUsageImage, object.ImageGenerationsUsageImage](u.Images)
 }
+
+var _ object.VideoGenerationsUsageVideo = (*VideoGenerationsUsageVideo)(nil)
+
+type VideoGenerationsUsageVideo struct {
+	Width   uint64  `json:"width,omitempty"`   // Width of the generated video.
+	Height  uint64  `json:"height,omitempty"`  // Height of the generated video.
+	Seconds float64 `json:"seconds,omitempty"` // Duration of the generated video in seconds.
+}
+
+func (v *VideoGenerationsUsageVideo) GetWidth() uint64 {
+	return v.Width
+}
+
+func (v *VideoGenerationsUsageVideo) GetHeight() uint64 {
+	return v.Height
+}
+
+func (v *VideoGenerationsUsageVideo) GetSeconds() float64 {
+	return v.Seconds
+}
+
+var _ object.LLMUsage = (*VideoGenerationsUsage)(nil)
+var _ object.LLMVideosUsage = (*VideoGenerationsUsage)(nil)
+
+type VideoGenerationsUsage struct {
+	object.IsLLMUsage
+
+	Videos []*VideoGenerationsUsageVideo `json:"videos,omitempty"` // Usage information for each submitted video.
+}
+
+func (u *VideoGenerationsUsage) GetTotalTokens() uint64 {
+	return 0
+}
+
+func (u *VideoGenerationsUsage) GetCompletionTokens() uint64 {
+	return 0
+}
+
+func (u *VideoGenerationsUsage) GetPromptTokens() uint64 {
+	return 0
+}
+
+func (u *VideoGenerationsUsage) GetOutputVideos() []object.VideoGenerationsUsageVideo {
+	return utils.TypeAssertFrom[*VideoGenerationsUsageVideo, object.VideoGenerationsUsageVideo](u.Videos)
+}
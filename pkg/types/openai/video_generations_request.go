@@ -0,0 +1,327 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/samber/lo"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/utils"
+)
+
+const (
+	// API Reference - OpenAI API
+	// https://platform.openai.com/docs/api-reference/videos/create
+	// seconds, string, Optional, Defaults to 4
+	defaultVideoGenerationRequestSeconds = 4
+	// size, string, Optional, Defaults to 720x1280
+	defaultVideoGenerationRequestSizeWidth  = 720
+	defaultVideoGenerationRequestSizeHeight = 1280
+
+	videoJobIDPrefix = "video_"
+)
+
+// VideoOperation is the operation requested on the video generation jobs.
+type VideoOperation string
+
+const (
+	// VideoOperationCreate submits a new job.
+	VideoOperationCreate VideoOperation = "create"
+	// VideoOperationRetrieve polls the status of a submitted job.
+	VideoOperationRetrieve VideoOperation = "retrieve"
+	// VideoOperationContent fetches the video generated by a completed job.
+	VideoOperationContent VideoOperation = "content"
+)
+
+// VideoJob identifies the video generation jobs submitted to the upstreams.
+// The IDs of the jobs returned to the clients encode the model requested and
+// the cluster the job was submitted to, so that the jobs are polled from the
+// same upstream regardless of the load balancing of the route.
+type VideoJob struct {
+	Model      string `json:"m"`
+	Cluster    string `json:"c"`
+	UpstreamID string `json:"id"`
+}
+
+// ID returns the ID of the job returned to the clients.
+func (j VideoJob) ID() string {
+	bs, _ := json.Marshal(j)
+
+	return videoJobIDPrefix + base64.RawURLEncoding.EncodeToString(bs)
+}
+
+// ParseVideoJobID parses the IDs returned by VideoJob.ID, a not found error is
+// returned for the IDs not issued by the gateway.
+func ParseVideoJobID(id string) (*VideoJob, error) {
+	encoded, ok := strings.CutPrefix(id, videoJobIDPrefix)
+	if !ok {
+		return nil, NewErrorVideoNotFound(id)
+	}
+
+	bs, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, NewErrorVideoNotFound(id)
+	}
+
+	job := new(VideoJob)
+
+	err = json.Unmarshal(bs, job)
+	if err != nil || job.Model == "" || job.Cluster == "" || job.UpstreamID == "" {
+		return nil, NewErrorVideoNotFound(id)
+	}
+
+	return job, nil
+}
+
+var _ object.LLMRequest = (*VideoGenerationsRequest)(nil)
+
+// VideoGenerationsRequest represents the requests on the video generation
+// jobs, either submitting a job, or polling and fetching the content of a
+// submitted job, which are routed to the cluster the job was submitted to.
+type VideoGenerationsRequest struct {
+	Model          string                       `json:"model,omitempty"`
+	Prompt         string                       `json:"prompt,omitempty"`
+	Seconds        *float64                     `json:"seconds,omitempty"`
+	Size           *ImageGenerationsRequestSize `json:"size,omitempty"`
+	InputReference *string                      `json:"input_reference,omitempty"`
+
+	operation       VideoOperation
+	job             *VideoJob
+	bodyParsed      map[string]any
+	bodyBuffer      *bytes.Buffer
+	incomingRequest *http.Request
+}
+
+// NewVideoGenerationsRequest parses the requests submitting video generation
+// jobs, the image to start the video from is given by the URL of
+// input_reference.
+func NewVideoGenerationsRequest(httpRequest *http.Request) (*VideoGenerationsRequest, error) {
+	buffer, parsed, err := utils.ReadAsJSONWithClose(httpRequest.Body)
+	if err != nil {
+		return nil, NewErrorInvalidBody()
+	}
+
+	req := &VideoGenerationsRequest{
+		Model:           utils.GetByJSONPath[string](parsed, "{ .model }"),
+		Prompt:          utils.GetByJSONPath[string](parsed, "{ .prompt }"),
+		InputReference:  utils.GetByJSONPath[*string](parsed, "{ .input_reference }"),
+		operation:       VideoOperationCreate,
+		bodyParsed:      parsed,
+		bodyBuffer:      buffer,
+		incomingRequest: httpRequest,
+	}
+
+	if req.Prompt == "" {
+		return nil, NewErrorMissingParameter("prompt")
+	}
+
+	err = req.parseSecondsAndSize()
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewVideoJobRequest creates the requests polling or fetching the content of
+// the job of the given ID, the model is the one the job was submitted with.
+func NewVideoJobRequest(httpRequest *http.Request, operation VideoOperation, id string) (*VideoGenerationsRequest, error) {
+	job, err := ParseVideoJobID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VideoGenerationsRequest{
+		Model:           job.Model,
+		operation:       operation,
+		job:             job,
+		incomingRequest: httpRequest,
+	}, nil
+}
+
+func (r *VideoGenerationsRequest) parseSecondsAndSize() error {
+	switch seconds := r.bodyParsed["seconds"].(type) {
+	case nil:
+		r.Seconds = lo.ToPtr(float64(defaultVideoGenerationRequestSeconds))
+	case float64:
+		r.Seconds = lo.ToPtr(seconds)
+	case string:
+		parsed, err := strconv.ParseFloat(seconds, 64)
+		if err != nil {
+			return NewErrorInvalidValue("seconds", fmt.Sprintf("invalid `%s` in \"seconds\" value", seconds))
+		}
+
+		r.Seconds = lo.ToPtr(parsed)
+	default:
+		return NewErrorInvalidValue("seconds", fmt.Sprintf("invalid type %T of \"seconds\" value", seconds))
+	}
+
+	size := utils.GetByJSONPath[*string](r.bodyParsed, "{ .size }")
+	if size == nil {
+		r.Size = &ImageGenerationsRequestSize{
+			Width:  defaultVideoGenerationRequestSizeWidth,
+			Height: defaultVideoGenerationRequestSizeHeight,
+		}
+
+		return nil
+	}
+
+	var err error
+
+	r.Size, err = parseImageGenerationsSizeString(size)
+
+	return err
+}
+
+func (r *VideoGenerationsRequest) MarshalJSON() ([]byte, error) {
+	if r.operation != VideoOperationCreate {
+		return json.Marshal(map[string]any{"id": r.job.ID()})
+	}
+
+	return r.bodyBuffer.Bytes(), nil
+}
+
+// GetOperation returns the operation requested on the jobs.
+func (r *VideoGenerationsRequest) GetOperation() VideoOperation {
+	return r.operation
+}
+
+// GetJob returns the job polled or fetched, nil when submitting jobs.
+func (r *VideoGenerationsRequest) GetJob() *VideoJob {
+	return r.job
+}
+
+// GetCluster returns the cluster the requests are pinned to, the one the job
+// polled or fetched was submitted to, or empty when submitting jobs.
+func (r *VideoGenerationsRequest) GetCluster() string {
+	if r.job == nil {
+		return ""
+	}
+
+	return r.job.Cluster
+}
+
+func (r *VideoGenerationsRequest) GetBodyParsed() map[string]any {
+	return r.bodyParsed
+}
+
+func (r *VideoGenerationsRequest) IsStream() bool {
+	return false
+}
+
+func (r *VideoGenerationsRequest) GetModel() string {
+	return r.Model
+}
+
+func (r *VideoGenerationsRequest) SetModel(model string) error {
+	if r.operation != VideoOperationCreate {
+		r.Model = model
+
+		return nil
+	}
+
+	var err error
+
+	r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, nil, NewReplace("/model", model))
+	if err != nil {
+		return err
+	}
+
+	r.Model = model
+
+	return nil
+}
+
+// SetDefaultParams sets the params of the jobs submitted, it's a no-op for
+// the other operations.
+func (r *VideoGenerationsRequest) SetDefaultParams(params map[string]*structpb.Value) error {
+	if r.operation != VideoOperationCreate {
+		return nil
+	}
+
+	for k, v := range params {
+		if _, exists := r.bodyParsed[k]; exists {
+			continue
+		}
+
+		var err error
+
+		r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, nil, NewAdd("/"+k, &v))
+		if err != nil {
+			return fmt.Errorf("failed to add key %s: %w", k, err)
+		}
+	}
+
+	return r.afterParamsChanged()
+}
+
+// SetOverrideParams overrides the params of the jobs submitted, it's a no-op
+// for the other operations.
+func (r *VideoGenerationsRequest) SetOverrideParams(params map[string]*structpb.Value) error {
+	if r.operation != VideoOperationCreate {
+		return nil
+	}
+
+	applyOpt := jsonpatch.NewApplyOptions()
+	applyOpt.EnsurePathExistsOnAdd = true
+
+	for k, v := range params {
+		var err error
+
+		r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, applyOpt, NewAdd("/"+k, &v))
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.afterParamsChanged()
+}
+
+// RemoveParamKeys removes the params of the jobs submitted, it's a no-op for
+// the other operations.
+func (r *VideoGenerationsRequest) RemoveParamKeys(keys []string) error {
+	if r.operation != VideoOperationCreate {
+		return nil
+	}
+
+	applyOpt := jsonpatch.NewApplyOptions()
+	applyOpt.AllowMissingPathOnRemove = true
+
+	for _, v := range keys {
+		var err error
+
+		r.bodyBuffer, r.bodyParsed, err = modifyBufferBodyAndParsed(r.bodyBuffer, applyOpt, NewRemove("/"+v))
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.afterParamsChanged()
+}
+
+func (r *VideoGenerationsRequest) afterParamsChanged() error {
+	changedModel := r.bodyParsed["model"]
+	if model, ok := changedModel.(string); ok && r.Model != model {
+		r.Model = model
+	}
+
+	r.InputReference = utils.GetByJSONPath[*string](r.bodyParsed, "{ .input_reference }")
+
+	return r.parseSecondsAndSize()
+}
+
+func (r *VideoGenerationsRequest) GetRequestType() object.RequestType {
+	return object.RequestTypeVideoGenerations
+}
+
+func (r *VideoGenerationsRequest) GetRawRequest() *http.Request {
+	return r.incomingRequest
+}
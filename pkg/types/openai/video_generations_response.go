@@ -0,0 +1,267 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/utils"
+)
+
+// The statuses of the video generation jobs.
+const (
+	VideoStatusQueued     = "queued"
+	VideoStatusInProgress = "in_progress"
+	VideoStatusCompleted  = "completed"
+	VideoStatusFailed     = "failed"
+)
+
+var _ object.LLMResponse = (*VideoGenerationsResponse)(nil)
+
+// VideoGenerationsResponse represents the video generation jobs submitted or
+// polled, in the format of the OpenAI API regardless of the provider. The ID
+// of the upstream is replaced with the one of the gateway, see VideoJob.
+// API reference: https://platform.openai.com/docs/api-reference/videos/object
+type VideoGenerationsResponse struct {
+	Status    int                    `json:"status"`
+	Model     string                 `json:"model"`
+	ID        string                 `json:"id"`
+	JobStatus string                 `json:"job_status"`
+	Usage     *VideoGenerationsUsage `json:"usage,omitempty"`
+
+	request        *VideoGenerationsRequest
+	upstreamVideo  *VideoGenerationsUsageVideo
+	responseBody   json.RawMessage
+	bodyParsed     map[string]any
+	meteringPolicy *v1alpha1.ClusterMeteringPolicy
+}
+
+// NewVideoGenerationsResponse creates the response of the job of the given
+// body in the format of the OpenAI API, the usage is only reported for the
+// jobs submitted, as they are metered once when submitted.
+func NewVideoGenerationsResponse(request *VideoGenerationsRequest, job VideoJob, status int, body []byte, meteringPolicy *v1alpha1.ClusterMeteringPolicy) (*VideoGenerationsResponse, error) {
+	var parsed map[string]any
+
+	err := json.Unmarshal(body, &parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	job.UpstreamID = utils.GetByJSONPath[string](parsed, "{ .id }")
+	if job.UpstreamID == "" {
+		return nil, NewErrorBadGateway().WithMessage("upstream responded video job without id")
+	}
+
+	resp := &VideoGenerationsResponse{
+		Status:         status,
+		Model:          utils.GetByJSONPath[string](parsed, "{ .model }"),
+		ID:             job.ID(),
+		JobStatus:      utils.GetByJSONPath[string](parsed, "{ .status }"),
+		request:        request,
+		meteringPolicy: meteringPolicy,
+	}
+
+	resp.responseBody, resp.bodyParsed, err = modifyBytesBodyAndParsed(body, NewReplace("/id", resp.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	resp.upstreamVideo, err = parseVideoGenerationsVideo(parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.GetOperation() == VideoOperationCreate {
+		resp.resolveUsage()
+	}
+
+	return resp, nil
+}
+
+// parseVideoGenerationsVideo parses the duration and the resolution of the
+// video reported by the upstream, zero if not reported.
+func parseVideoGenerationsVideo(parsed map[string]any) (*VideoGenerationsUsageVideo, error) {
+	video := new(VideoGenerationsUsageVideo)
+
+	switch seconds := parsed["seconds"].(type) {
+	case float64:
+		video.Seconds = seconds
+	case string:
+		// Unparsable durations are treated as not reported
+		video.Seconds, _ = strconv.ParseFloat(seconds, 64)
+	}
+
+	size := utils.GetByJSONPath[*string](parsed, "{ .size }")
+	if size != nil && *size != "" {
+		parsedSize, err := parseImageGenerationsSizeString(size)
+		if err != nil {
+			return nil, err
+		}
+
+		video.Width = parsedSize.Width
+		video.Height = parsedSize.Height
+	}
+
+	return video, nil
+}
+
+func (r *VideoGenerationsResponse) resolveUsage() {
+	video := &VideoGenerationsUsageVideo{
+		Seconds: *r.request.Seconds,
+		Width:   r.request.Size.Width,
+		Height:  r.request.Size.Height,
+	}
+
+	switch r.meteringPolicy.GetDurationFrom() {
+	case v1alpha1.ClusterMeteringPolicy_SIZE_FROM_OUTPUT:
+		if r.upstreamVideo.Seconds > 0 {
+			video.Seconds = r.upstreamVideo.Seconds
+		}
+	case v1alpha1.ClusterMeteringPolicy_SIZE_FROM_GREATEST:
+		video.Seconds = max(video.Seconds, r.upstreamVideo.Seconds)
+	case
+		v1alpha1.ClusterMeteringPolicy_SIZE_FROM_UNSPECIFIED,
+		v1alpha1.ClusterMeteringPolicy_SIZE_FROM_INPUT:
+		break
+	default:
+		break
+	}
+
+	upstreamResolution := r.upstreamVideo.Width * r.upstreamVideo.Height
+
+	switch r.meteringPolicy.GetResolutionFrom() {
+	case v1alpha1.ClusterMeteringPolicy_SIZE_FROM_OUTPUT:
+		if upstreamResolution > 0 {
+			video.Width = r.upstreamVideo.Width
+			video.Height = r.upstreamVideo.Height
+		}
+	case v1alpha1.ClusterMeteringPolicy_SIZE_FROM_GREATEST:
+		if upstreamResolution > video.Width*video.Height {
+			video.Width = r.upstreamVideo.Width
+			video.Height = r.upstreamVideo.Height
+		}
+	case
+		v1alpha1.ClusterMeteringPolicy_SIZE_FROM_UNSPECIFIED,
+		v1alpha1.ClusterMeteringPolicy_SIZE_FROM_INPUT:
+		break
+	default:
+		break
+	}
+
+	r.Usage = &VideoGenerationsUsage{
+		Videos: []*VideoGenerationsUsageVideo{video},
+	}
+}
+
+func (r *VideoGenerationsResponse) MarshalJSON() ([]byte, error) {
+	return r.responseBody, nil
+}
+
+func (r *VideoGenerationsResponse) GetRequestID() string {
+	return ""
+}
+
+func (r *VideoGenerationsResponse) IsStream() bool {
+	return false
+}
+
+func (r *VideoGenerationsResponse) GetModel() string {
+	return r.Model
+}
+
+func (r *VideoGenerationsResponse) SetModel(model string) error {
+	r.Model = model
+
+	return nil
+}
+
+// GetUsage returns the usage of the jobs submitted, nil for the jobs polled.
+func (r *VideoGenerationsResponse) GetUsage() object.LLMUsage {
+	if r.Usage == nil {
+		return nil
+	}
+
+	return r.Usage
+}
+
+func (r *VideoGenerationsResponse) GetError() object.LLMError {
+	return nil
+}
+
+func (r *VideoGenerationsResponse) GetBodyParsed() map[string]any {
+	return r.bodyParsed
+}
+
+var _ object.LLMResponse = (*VideoContentResponse)(nil)
+
+// VideoContentResponse is the video generated by the completed jobs, the body
+// of the upstream is copied to the client as is.
+type VideoContentResponse struct {
+	Model string
+
+	response *http.Response
+}
+
+func NewVideoContentResponse(response *http.Response, model string) *VideoContentResponse {
+	return &VideoContentResponse{
+		Model:    model,
+		response: response,
+	}
+}
+
+func (r *VideoContentResponse) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"status": r.GetStatus(),
+		"model":  r.Model,
+	})
+}
+
+func (r *VideoContentResponse) IsStream() bool {
+	return false
+}
+
+func (r *VideoContentResponse) GetRequestID() string {
+	return ""
+}
+
+func (r *VideoContentResponse) GetUsage() object.LLMUsage {
+	return nil
+}
+
+func (r *VideoContentResponse) GetError() object.LLMError {
+	return nil
+}
+
+func (r *VideoContentResponse) GetModel() string {
+	return r.Model
+}
+
+func (r *VideoContentResponse) SetModel(model string) error {
+	r.Model = model
+
+	return nil
+}
+
+func (r *VideoContentResponse) GetStatus() int {
+	return r.response.StatusCode
+}
+
+func (r *VideoContentResponse) WriteTo(writer http.ResponseWriter) error {
+	defer func() { _ = r.response.Body.Close() }()
+
+	for _, header := range []string{"Content-Type", "Content-Length", "Content-Disposition"} {
+		if value := r.response.Header.Get(header); value != "" {
+			writer.Header().Set(header, value)
+		}
+	}
+
+	writer.WriteHeader(r.response.StatusCode)
+
+	_, err := io.Copy(writer, r.response.Body)
+
+	return err
+}
@@ -0,0 +1,186 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"knoway.dev/api/clusters/v1alpha1"
+	"knoway.dev/pkg/object"
+)
+
+func TestVideoJobID(t *testing.T) {
+	job := VideoJob{
+		Model:      "sora-2",
+		Cluster:    "default/sora-2",
+		UpstreamID: "video_68d7512d",
+	}
+
+	parsed, err := ParseVideoJobID(job.ID())
+	require.NoError(t, err)
+	assert.Equal(t, job, *parsed)
+
+	for _, id := range []string{"video_68d7512d", "task_1", "video_!!", ""} {
+		_, err := ParseVideoJobID(id)
+		require.Error(t, err)
+
+		var openAIErr *ErrorResponse
+		require.ErrorAs(t, err, &openAIErr)
+		assert.Equal(t, http.StatusNotFound, openAIErr.Status)
+	}
+}
+
+func TestNewVideoGenerationsRequest(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		body := []byte(`{"model": "public/sora-2", "prompt": "A calico cat playing a piano on stage"}`)
+
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/videos", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		videoReq, err := NewVideoGenerationsRequest(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, "public/sora-2", videoReq.GetModel())
+		assert.Equal(t, VideoOperationCreate, videoReq.GetOperation())
+		assert.Empty(t, videoReq.GetCluster())
+		assert.Equal(t, object.RequestTypeVideoGenerations, videoReq.GetRequestType())
+		assert.InDelta(t, 4, *videoReq.Seconds, 0.0001)
+		assert.Equal(t, &ImageGenerationsRequestSize{Width: 720, Height: 1280}, videoReq.Size)
+	})
+
+	t.Run("with params", func(t *testing.T) {
+		body := []byte(`{"model": "public/sora-2", "prompt": "A calico cat playing a piano on stage", "seconds": "8"}`)
+
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/videos", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		videoReq, err := NewVideoGenerationsRequest(req)
+		require.NoError(t, err)
+		assert.InDelta(t, 8, *videoReq.Seconds, 0.0001)
+
+		require.NoError(t, videoReq.SetModel("sora-2"))
+		require.NoError(t, videoReq.SetOverrideParams(map[string]*structpb.Value{
+			"size": structpb.NewStringValue("1280x720"),
+		}))
+
+		assert.Equal(t, "sora-2", videoReq.bodyParsed["model"])
+		assert.Equal(t, &ImageGenerationsRequestSize{Width: 1280, Height: 720}, videoReq.Size)
+	})
+
+	t.Run("missing prompt", func(t *testing.T) {
+		body := []byte(`{"model": "sora-2"}`)
+
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/videos", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		_, err = NewVideoGenerationsRequest(req)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid seconds", func(t *testing.T) {
+		body := []byte(`{"model": "sora-2", "prompt": "A calico cat playing a piano on stage", "seconds": "four"}`)
+
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/videos", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		_, err = NewVideoGenerationsRequest(req)
+		require.Error(t, err)
+	})
+}
+
+func TestNewVideoJobRequest(t *testing.T) {
+	job := VideoJob{Model: "sora-2", Cluster: "default/sora-2", UpstreamID: "video_68d7512d"}
+
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, "/v1/videos/"+job.ID(), nil)
+	require.NoError(t, err)
+
+	videoReq, err := NewVideoJobRequest(req, VideoOperationRetrieve, job.ID())
+	require.NoError(t, err)
+
+	assert.Equal(t, "sora-2", videoReq.GetModel())
+	assert.Equal(t, "default/sora-2", videoReq.GetCluster())
+	assert.Equal(t, VideoOperationRetrieve, videoReq.GetOperation())
+
+	// Params of the backends are not applied to the polls
+	require.NoError(t, videoReq.SetOverrideParams(map[string]*structpb.Value{
+		"model": structpb.NewStringValue("sora-2-pro"),
+	}))
+	assert.Equal(t, "sora-2", videoReq.GetModel())
+}
+
+func TestNewVideoGenerationsResponse(t *testing.T) {
+	newRequest := func(t *testing.T) *VideoGenerationsRequest {
+		t.Helper()
+
+		body := []byte(`{"model": "sora-2", "prompt": "A calico cat playing a piano on stage", "seconds": "4", "size": "720x1280"}`)
+
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/v1/videos", bytes.NewReader(body))
+		require.NoError(t, err)
+
+		videoReq, err := NewVideoGenerationsRequest(req)
+		require.NoError(t, err)
+
+		return videoReq
+	}
+
+	job := VideoJob{Model: "public/sora-2", Cluster: "default/sora-2"}
+	body := []byte(`{"id": "video_68d7512d", "object": "video", "model": "sora-2", "status": "queued", "progress": 0, "seconds": "8", "size": "1280x720"}`)
+
+	t.Run("input", func(t *testing.T) {
+		resp, err := NewVideoGenerationsResponse(newRequest(t), job, http.StatusOK, body, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, VideoStatusQueued, resp.JobStatus)
+		assert.Equal(t, resp.ID, resp.GetBodyParsed()["id"])
+
+		parsed, err := ParseVideoJobID(resp.ID)
+		require.NoError(t, err)
+		assert.Equal(t, VideoJob{Model: "public/sora-2", Cluster: "default/sora-2", UpstreamID: "video_68d7512d"}, *parsed)
+
+		usage, ok := object.AsLLMVideosUsage(resp.GetUsage())
+		require.True(t, ok)
+		require.Len(t, usage.GetOutputVideos(), 1)
+		assert.InDelta(t, 4, usage.GetOutputVideos()[0].GetSeconds(), 0.0001)
+		assert.Equal(t, uint64(720), usage.GetOutputVideos()[0].GetWidth())
+		assert.Equal(t, uint64(1280), usage.GetOutputVideos()[0].GetHeight())
+	})
+
+	t.Run("output and greatest", func(t *testing.T) {
+		resp, err := NewVideoGenerationsResponse(newRequest(t), job, http.StatusOK, body, &v1alpha1.ClusterMeteringPolicy{
+			DurationFrom:   lo.ToPtr(v1alpha1.ClusterMeteringPolicy_SIZE_FROM_OUTPUT),
+			ResolutionFrom: lo.ToPtr(v1alpha1.ClusterMeteringPolicy_SIZE_FROM_GREATEST),
+		})
+		require.NoError(t, err)
+
+		usage, ok := object.AsLLMVideosUsage(resp.GetUsage())
+		require.True(t, ok)
+		require.Len(t, usage.GetOutputVideos(), 1)
+		assert.InDelta(t, 8, usage.GetOutputVideos()[0].GetSeconds(), 0.0001)
+		// Resolutions are compared by the number of pixels, the input wins ties
+		assert.Equal(t, uint64(720), usage.GetOutputVideos()[0].GetWidth())
+		assert.Equal(t, uint64(1280), usage.GetOutputVideos()[0].GetHeight())
+	})
+
+	t.Run("polls are not metered", func(t *testing.T) {
+		submitted := job
+		submitted.UpstreamID = "video_68d7512d"
+
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, "/v1/videos/"+submitted.ID(), nil)
+		require.NoError(t, err)
+
+		videoReq, err := NewVideoJobRequest(req, VideoOperationRetrieve, submitted.ID())
+		require.NoError(t, err)
+
+		resp, err := NewVideoGenerationsResponse(videoReq, *videoReq.GetJob(), http.StatusOK, body, nil)
+		require.NoError(t, err)
+
+		assert.Nil(t, resp.GetUsage())
+		assert.Equal(t, submitted.ID(), resp.ID)
+	})
+}
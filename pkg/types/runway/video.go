@@ -0,0 +1,130 @@
+package runway
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+
+	"knoway.dev/pkg/types/openai"
+	"knoway.dev/pkg/utils"
+)
+
+// API reference: https://docs.dev.runwayml.com/api
+const (
+	// VersionHeader is the header the version of the API is required by.
+	VersionHeader = "X-Runway-Version"
+	// Version is the version of the API the requests are made with.
+	Version = "2024-11-06"
+
+	textToVideoPath  = "/text_to_video"
+	imageToVideoPath = "/image_to_video"
+	tasksPath        = "/tasks/"
+)
+
+// SubmitPath returns the path the request is submitted to, the videos are
+// generated from the image of input_reference if given.
+func SubmitPath(req *openai.VideoGenerationsRequest) string {
+	if req.InputReference != nil && *req.InputReference != "" {
+		return imageToVideoPath
+	}
+
+	return textToVideoPath
+}
+
+// TaskPath returns the path the task of the given ID is polled from.
+func TaskPath(id string) string {
+	return tasksPath + url.PathEscape(id)
+}
+
+// BuildSubmitBody converts the request into the body of the tasks submitted,
+// the size is converted into the ratio, e.g. 1280x720 into 1280:720.
+func BuildSubmitBody(req *openai.VideoGenerationsRequest) ([]byte, error) {
+	payload := map[string]any{
+		"model":      req.GetModel(),
+		"promptText": req.Prompt,
+		"ratio":      fmt.Sprintf("%d:%d", req.Size.Width, req.Size.Height),
+		"duration":   int(math.Ceil(*req.Seconds)),
+	}
+
+	if SubmitPath(req) == imageToVideoPath {
+		payload["promptImage"] = *req.InputReference
+	}
+
+	if seed, ok := req.GetBodyParsed()["seed"]; ok {
+		payload["seed"] = seed
+	}
+
+	return json.Marshal(payload)
+}
+
+// ConvertSubmitResponse converts the response of the tasks submitted, which
+// only carries the ID of the task, into the video object of the OpenAI API.
+func ConvertSubmitResponse(req *openai.VideoGenerationsRequest, body []byte) ([]byte, error) {
+	var parsed map[string]any
+
+	err := json.Unmarshal(body, &parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]any{
+		"id":       utils.GetByJSONPath[string](parsed, "{ .id }"),
+		"object":   "video",
+		"model":    req.GetModel(),
+		"status":   openai.VideoStatusQueued,
+		"progress": 0,
+		"prompt":   req.Prompt,
+		"seconds":  fmt.Sprint(*req.Seconds),
+		"size":     fmt.Sprintf("%dx%d", req.Size.Width, req.Size.Height),
+	})
+}
+
+// ConvertTask converts the task polled into the video object of the OpenAI
+// API, the URL of the generated video is returned once the task succeeded.
+func ConvertTask(model string, body []byte) ([]byte, string, error) {
+	var parsed map[string]any
+
+	err := json.Unmarshal(body, &parsed)
+	if err != nil {
+		return nil, "", err
+	}
+
+	video := map[string]any{
+		"id":     utils.GetByJSONPath[string](parsed, "{ .id }"),
+		"object": "video",
+		"model":  model,
+	}
+
+	var outputURL string
+
+	switch status := utils.GetByJSONPath[string](parsed, "{ .status }"); status {
+	case "PENDING", "THROTTLED":
+		video["status"] = openai.VideoStatusQueued
+		video["progress"] = 0
+	case "RUNNING":
+		video["status"] = openai.VideoStatusInProgress
+		video["progress"] = int(utils.GetByJSONPath[float64](parsed, "{ .progress }") * 100) //nolint:mnd
+	case "SUCCEEDED":
+		video["status"] = openai.VideoStatusCompleted
+		video["progress"] = 100
+
+		if outputs := utils.GetByJSONPath[[]string](parsed, "{ .output }"); len(outputs) > 0 {
+			outputURL = outputs[0]
+		}
+	default:
+		// FAILED and CANCELLED
+		video["status"] = openai.VideoStatusFailed
+		video["error"] = map[string]any{
+			"code":    utils.GetByJSONPath[string](parsed, "{ .failureCode }"),
+			"message": utils.GetByJSONPath[string](parsed, "{ .failure }"),
+		}
+	}
+
+	converted, err := json.Marshal(video)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return converted, outputURL, nil
+}
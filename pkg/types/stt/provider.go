@@ -0,0 +1,14 @@
+package stt
+
+import (
+	"context"
+	"net/http"
+
+	"knoway.dev/pkg/object"
+)
+
+type TranscriptionProvider interface {
+	Name() string
+	BuildTranscriptionRequest(ctx context.Context, baseURL string, authHeader string, req Request, upstreamHeaders http.Header, downstreamHeaders http.Header) (*http.Request, error)
+	ParseTranscriptionResponse(resp *http.Response, body []byte, req Request) (object.LLMResponse, error)
+}
@@ -0,0 +1,35 @@
+package stt
+
+import "bytes"
+
+// Task is what the speech is recognized for.
+type Task string
+
+const (
+	// TaskTranscriptions transcribes the speech into text of the language
+	// it is spoken in.
+	TaskTranscriptions Task = "transcriptions"
+	// TaskTranslations translates the speech into English text.
+	TaskTranslations Task = "translations"
+)
+
+// File is the audio file uploaded to be recognized.
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+type Request interface {
+	GetModel() string
+	GetTask() Task
+	GetFile() *File
+	GetLanguage() string
+	GetPrompt() string
+	GetResponseFormat() string
+	GetTemperature() *float64
+	// GetBodyBuffer returns the multipart/form-data encoded body, along with
+	// GetContentType returning the content type with its boundary.
+	GetBodyBuffer() *bytes.Buffer
+	GetContentType() string
+}
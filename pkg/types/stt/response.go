@@ -0,0 +1,136 @@
+package stt
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"knoway.dev/pkg/object"
+	"knoway.dev/pkg/utils"
+)
+
+var _ object.LLMResponse = (*TranscriptionResponse)(nil)
+
+// ResponseFormatText is the response format of plain text transcripts.
+const ResponseFormatText = "text"
+
+type TranscriptionResponse struct {
+	Status      int
+	Model       string
+	RequestID   string
+	ContentType string
+	// Text is the recognized text, only available when the response is in
+	// JSON or plain text.
+	Text string
+	// Duration is the duration of the audio in seconds, only available when
+	// the response is in verbose JSON.
+	Duration float64
+
+	BodyBytes []byte
+	Error     object.LLMError
+}
+
+func NewTranscriptionResponseFromBytes(status int, contentType string, model string, body []byte) *TranscriptionResponse {
+	resp := &TranscriptionResponse{
+		Status:      status,
+		Model:       model,
+		ContentType: contentType,
+		BodyBytes:   body,
+	}
+
+	switch {
+	case strings.HasPrefix(contentType, "application/json"):
+		var parsed map[string]any
+
+		err := json.Unmarshal(body, &parsed)
+		if err == nil {
+			resp.Text = utils.GetByJSONPath[string](parsed, "{ .text }")
+			resp.Duration = utils.GetByJSONPath[float64](parsed, "{ .duration }")
+		}
+	case strings.HasPrefix(contentType, "text/plain"):
+		resp.Text = strings.TrimSpace(string(body))
+	}
+
+	return resp
+}
+
+// NewTranscriptionResponseFromText creates the response of providers not
+// compatible with OpenAI, the text is formatted as OpenAI does in either
+// plain text or JSON.
+func NewTranscriptionResponseFromText(model string, text string, responseFormat string) *TranscriptionResponse {
+	if responseFormat == ResponseFormatText {
+		return NewTranscriptionResponseFromBytes(http.StatusOK, "text/plain; charset=utf-8", model, []byte(text))
+	}
+
+	body, _ := json.Marshal(map[string]any{
+		"text": text,
+	})
+
+	return NewTranscriptionResponseFromBytes(http.StatusOK, "application/json", model, body)
+}
+
+func (r *TranscriptionResponse) MarshalJSON() ([]byte, error) {
+	if r == nil {
+		return []byte("null"), nil
+	}
+
+	if r.Error != nil {
+		return json.Marshal(r.Error)
+	}
+
+	return json.Marshal(map[string]any{
+		"status": r.Status,
+		"model":  r.Model,
+		"text":   r.Text,
+	})
+}
+
+func (r *TranscriptionResponse) IsStream() bool {
+	return false
+}
+
+func (r *TranscriptionResponse) GetRequestID() string {
+	return r.RequestID
+}
+
+func (r *TranscriptionResponse) GetUsage() object.LLMUsage {
+	return nil
+}
+
+func (r *TranscriptionResponse) GetError() object.LLMError {
+	return r.Error
+}
+
+func (r *TranscriptionResponse) GetModel() string {
+	return r.Model
+}
+
+func (r *TranscriptionResponse) SetModel(modelName string) error {
+	r.Model = modelName
+	return nil
+}
+
+func (r *TranscriptionResponse) GetStatus() int {
+	if r == nil || r.Status == 0 {
+		return http.StatusOK
+	}
+
+	return r.Status
+}
+
+func (r *TranscriptionResponse) WriteTo(writer http.ResponseWriter) error {
+	if r == nil {
+		return nil
+	}
+
+	if r.ContentType == "" {
+		r.ContentType = "application/json"
+	}
+
+	writer.Header().Set("Content-Type", r.ContentType)
+	writer.WriteHeader(r.GetStatus())
+
+	_, err := writer.Write(r.BodyBytes)
+
+	return err
+}
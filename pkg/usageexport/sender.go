@@ -0,0 +1,153 @@
+package usageexport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"knoway.dev/api/filters/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/pkg/sink"
+)
+
+// marshalOptions encodes the records in JSON with the field names of the
+// proto, the same as the other exported metadata.
+var marshalOptions = protojson.MarshalOptions{UseProtoNames: true}
+
+// sender sends a batch of records, an error means the whole batch is to be
+// retried.
+type sender interface {
+	Send(ctx context.Context, records []*service.UsageRecord) error
+	Close() error
+}
+
+func newSender(cfg *v1alpha1.UsageExportConfig) (sender, error) {
+	switch {
+	case cfg.GetGrpc() != nil:
+		return newGRPCSender(cfg.GetGrpc())
+	case cfg.GetHttp() != nil:
+		return newHTTPSender(cfg.GetHttp())
+	case cfg.GetKafka() != nil:
+		return newKafkaSender(cfg.GetKafka())
+	default:
+		return nil, errors.New("no destination configured")
+	}
+}
+
+func marshalRecords(records []*service.UsageRecord) ([]json.RawMessage, error) {
+	encoded := make([]json.RawMessage, 0, len(records))
+
+	for _, record := range records {
+		bs, err := marshalOptions.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded = append(encoded, bs)
+	}
+
+	return encoded, nil
+}
+
+var _ sender = (*grpcSender)(nil)
+
+// grpcSender exports the batches to a UsageStatsService by ExportUsage.
+type grpcSender struct {
+	conn   *grpc.ClientConn
+	client service.UsageStatsServiceClient
+}
+
+func newGRPCSender(cfg *v1alpha1.UsageExportConfig_GRPC) (*grpcSender, error) {
+	if cfg.GetUrl() == "" {
+		return nil, errors.New("url of grpc destination is required")
+	}
+
+	conn, err := grpc.NewClient(cfg.GetUrl(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("invalid url of grpc destination: %w", err)
+	}
+
+	return &grpcSender{
+		conn:   conn,
+		client: service.NewUsageStatsServiceClient(conn),
+	}, nil
+}
+
+func (s *grpcSender) Send(ctx context.Context, records []*service.UsageRecord) error {
+	_, err := s.client.ExportUsage(ctx, &service.ExportUsageRequest{Records: records})
+
+	return err
+}
+
+func (s *grpcSender) Close() error {
+	return s.conn.Close()
+}
+
+var _ sender = (*httpSender)(nil)
+
+// httpSender posts the batches to a webhook as JSON arrays.
+type httpSender struct {
+	sink *sink.HTTP
+}
+
+func newHTTPSender(cfg *v1alpha1.UsageExportConfig_HTTP) (*httpSender, error) {
+	s, err := sink.NewHTTP(cfg.GetUrl(), cfg.GetHeaders())
+	if err != nil {
+		return nil, fmt.Errorf("invalid url of http destination: %w", err)
+	}
+
+	return &httpSender{sink: s}, nil
+}
+
+func (s *httpSender) Send(ctx context.Context, records []*service.UsageRecord) error {
+	encoded, err := marshalRecords(records)
+	if err != nil {
+		return err
+	}
+
+	return s.sink.Post(ctx, encoded)
+}
+
+func (s *httpSender) Close() error {
+	return nil
+}
+
+var _ sender = (*kafkaSender)(nil)
+
+// kafkaSender produces the batches to a topic through the Kafka REST Proxy,
+// every usage record is a Kafka record keyed by its id.
+type kafkaSender struct {
+	sink *sink.Kafka
+}
+
+func newKafkaSender(cfg *v1alpha1.UsageExportConfig_Kafka) (*kafkaSender, error) {
+	s, err := sink.NewKafka(cfg.GetRestProxyUrl(), cfg.GetTopic(), cfg.GetHeaders())
+	if err != nil {
+		return nil, fmt.Errorf("invalid kafka destination: %w", err)
+	}
+
+	return &kafkaSender{sink: s}, nil
+}
+
+func (s *kafkaSender) Send(ctx context.Context, records []*service.UsageRecord) error {
+	encoded, err := marshalRecords(records)
+	if err != nil {
+		return err
+	}
+
+	kafkaRecords := make([]sink.KafkaRecord, 0, len(records))
+	for i, record := range records {
+		kafkaRecords = append(kafkaRecords, sink.KafkaRecord{Key: record.GetId(), Value: encoded[i]})
+	}
+
+	return s.sink.Produce(ctx, kafkaRecords)
+}
+
+func (s *kafkaSender) Close() error {
+	return nil
+}
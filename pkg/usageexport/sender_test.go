@@ -0,0 +1,127 @@
+package usageexport
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"knoway.dev/api/filters/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+	"knoway.dev/pkg/sink"
+)
+
+type receivedRequest struct {
+	path    string
+	headers http.Header
+	body    string
+}
+
+func newReceiver(t *testing.T, status int) (*httptest.Server, *[]receivedRequest) {
+	t.Helper()
+
+	received := make([]receivedRequest, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		received = append(received, receivedRequest{path: request.URL.Path, headers: request.Header, body: string(body)})
+
+		writer.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &received
+}
+
+func TestHTTPSender(t *testing.T) {
+	server, received := newReceiver(t, http.StatusNoContent)
+
+	s, err := newSender(&v1alpha1.UsageExportConfig{
+		Destination: &v1alpha1.UsageExportConfig_Http{Http: &v1alpha1.UsageExportConfig_HTTP{
+			Url:     server.URL + "/usage",
+			Headers: map[string]string{"Authorization": "Bearer token"},
+		}},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Send(context.Background(), []*service.UsageRecord{newRecord("1")}))
+
+	require.Len(t, *received, 1)
+	assert.Equal(t, "/usage", (*received)[0].path)
+	assert.Equal(t, "application/json", (*received)[0].headers.Get("Content-Type"))
+	assert.Equal(t, "Bearer token", (*received)[0].headers.Get("Authorization"))
+	assert.JSONEq(t, `[{"id":"1","user_model_name":"gpt-4o","input_tokens":"10","output_tokens":"20"}]`, (*received)[0].body)
+
+	server, _ = newReceiver(t, http.StatusServiceUnavailable)
+	s, err = newHTTPSender(&v1alpha1.UsageExportConfig_HTTP{Url: server.URL})
+	require.NoError(t, err)
+	require.EqualError(t, s.Send(context.Background(), []*service.UsageRecord{newRecord("1")}), "unexpected status 503: ")
+}
+
+func TestKafkaSender(t *testing.T) {
+	server, received := newReceiver(t, http.StatusOK)
+
+	s, err := newSender(&v1alpha1.UsageExportConfig{
+		Destination: &v1alpha1.UsageExportConfig_Kafka_{Kafka: &v1alpha1.UsageExportConfig_Kafka{
+			RestProxyUrl: server.URL,
+			Topic:        "knoway-usage",
+		}},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, s.Send(context.Background(), []*service.UsageRecord{newRecord("1")}))
+
+	require.Len(t, *received, 1)
+	assert.Equal(t, "/topics/knoway-usage", (*received)[0].path)
+	assert.Equal(t, sink.KafkaRESTContentType, (*received)[0].headers.Get("Content-Type"))
+	assert.Equal(t, sink.KafkaRESTAccept, (*received)[0].headers.Get("Accept"))
+	assert.JSONEq(t, `{"records":[{"key":"1","value":{"id":"1","user_model_name":"gpt-4o","input_tokens":"10","output_tokens":"20"}}]}`, (*received)[0].body)
+}
+
+type usageStatsServer struct {
+	service.UnimplementedUsageStatsServiceServer
+
+	requests []*service.ExportUsageRequest
+}
+
+func (s *usageStatsServer) ExportUsage(_ context.Context, request *service.ExportUsageRequest) (*service.ExportUsageResponse, error) {
+	s.requests = append(s.requests, request)
+
+	return &service.ExportUsageResponse{}, nil
+}
+
+func TestGRPCSender(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	usageServer := &usageStatsServer{}
+	server := grpc.NewServer()
+	service.RegisterUsageStatsServiceServer(server, usageServer)
+
+	go func() {
+		_ = server.Serve(ln)
+	}()
+
+	defer server.Stop()
+
+	s, err := newSender(&v1alpha1.UsageExportConfig{
+		Destination: &v1alpha1.UsageExportConfig_Grpc{Grpc: &v1alpha1.UsageExportConfig_GRPC{Url: ln.Addr().String()}},
+	})
+	require.NoError(t, err)
+
+	defer func() {
+		_ = s.Close()
+	}()
+
+	require.NoError(t, s.Send(context.Background(), []*service.UsageRecord{newRecord("1"), newRecord("2")}))
+
+	require.Len(t, usageServer.requests, 1)
+	assert.True(t, proto.Equal(&service.ExportUsageRequest{Records: []*service.UsageRecord{newRecord("1"), newRecord("2")}}, usageServer.requests[0]))
+}
@@ -0,0 +1,229 @@
+package usageexport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	service "knoway.dev/api/service/v1alpha1"
+)
+
+const (
+	spoolFileExt    = ".jsonl"
+	spoolTempExt    = ".tmp"
+	spoolCorruptExt = ".corrupt"
+)
+
+var errSpoolFull = errors.New("spool is full")
+
+// spool keeps the batches failed to export on the local disk, one file per
+// batch with a record per line, until they are exported successfully.
+// Files are named after the time they are spooled so that the batches are
+// retried in order.
+type spool struct {
+	directory string
+	maxSize   int64
+
+	mutex sync.Mutex
+	size  int64
+	seq   uint64
+}
+
+// openSpool opens the spool in the directory, batches spooled by previous
+// runs are kept and retried.
+func openSpool(directory string, maxSize int64) (*spool, error) {
+	err := os.MkdirAll(directory, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &spool{directory: directory, maxSize: maxSize}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		switch filepath.Ext(entry.Name()) {
+		case spoolTempExt:
+			// Left by crashes before the batch was completely written, the
+			// records are still in the buffer of that run and lost anyway.
+			_ = os.Remove(filepath.Join(directory, entry.Name()))
+		case spoolFileExt:
+			info, err := entry.Info()
+			if err != nil {
+				return nil, err
+			}
+
+			s.size += info.Size()
+		}
+	}
+
+	return s, nil
+}
+
+// Write spools the batch, the file is renamed into place once it is
+// completely written so that replays never see partial batches.
+func (s *spool) Write(records []*service.UsageRecord) error {
+	var buffer bytes.Buffer
+
+	for _, record := range records {
+		bs, err := marshalOptions.Marshal(record)
+		if err != nil {
+			return err
+		}
+
+		buffer.Write(bs)
+		buffer.WriteByte('\n')
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.size+int64(buffer.Len()) > s.maxSize {
+		return errSpoolFull
+	}
+
+	s.seq++
+	name := filepath.Join(s.directory, fmt.Sprintf("%020d-%06d", time.Now().UnixNano(), s.seq%1000000))
+
+	err := os.WriteFile(name+spoolTempExt, buffer.Bytes(), 0o600)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(name+spoolTempExt, name+spoolFileExt)
+	if err != nil {
+		_ = os.Remove(name + spoolTempExt)
+		return err
+	}
+
+	s.size += int64(buffer.Len())
+
+	return nil
+}
+
+// Size returns the size of the batches spooled in bytes.
+func (s *spool) Size() int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.size
+}
+
+func (s *spool) files() ([]string, error) {
+	entries, err := os.ReadDir(s.directory)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), spoolFileExt) {
+			files = append(files, entry.Name())
+		}
+	}
+
+	slices.Sort(files)
+
+	return files, nil
+}
+
+func readSpoolFile(path string) ([]*service.UsageRecord, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*service.UsageRecord, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		record := &service.UsageRecord{}
+
+		err := protojson.Unmarshal(scanner.Bytes(), record)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, scanner.Err()
+}
+
+// release removes the spooled batch at path by remove, e.g. os.Remove, and
+// frees its space in the spool.
+func (s *spool) release(path string, remove func(path string) error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return
+	}
+
+	err = remove(path)
+	if err != nil {
+		slog.Error("failed to remove spooled usage records", "path", path, "error", err)
+		return
+	}
+
+	s.mutex.Lock()
+	s.size -= info.Size()
+	s.mutex.Unlock()
+}
+
+// Replay sends the spooled batches in order, batches are removed once sent,
+// it stops at the first batch failed to send. Returns the number of records
+// sent.
+func (s *spool) Replay(ctx context.Context, send func(ctx context.Context, records []*service.UsageRecord) error) (int, error) {
+	files, err := s.files()
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+
+	for _, file := range files {
+		path := filepath.Join(s.directory, file)
+
+		records, err := readSpoolFile(path)
+		if err != nil {
+			// Kept aside for operators to recover, instead of blocking the
+			// batches after it forever.
+			slog.Error("failed to read spooled usage records, moved aside", "path", path, "error", err)
+
+			s.release(path, func(path string) error {
+				return os.Rename(path, path+spoolCorruptExt)
+			})
+
+			continue
+		}
+
+		err = send(ctx, records)
+		if err != nil {
+			return sent, err
+		}
+
+		s.release(path, os.Remove)
+		sent += len(records)
+	}
+
+	return sent, nil
+}
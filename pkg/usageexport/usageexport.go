@@ -0,0 +1,251 @@
+// Package usageexport exports the usage of requests to external billing
+// systems in batches, with the batches failed to export spooled to the
+// local disk and retried, so that usage is delivered at least once.
+package usageexport
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/samber/lo"
+
+	"knoway.dev/api/filters/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+)
+
+const (
+	DefaultBatchMaxSize       = 100
+	DefaultBatchInterval      = 5 * time.Second
+	DefaultBatchBufferSize    = 10000
+	DefaultBatchTimeout       = 10 * time.Second
+	DefaultSpoolMaxSize       = 1 << 30
+	DefaultSpoolRetryInterval = 30 * time.Second
+)
+
+type options struct {
+	maxSize    int
+	interval   time.Duration
+	bufferSize int
+	timeout    time.Duration
+
+	spoolDirectory     string
+	spoolMaxSize       int64
+	spoolRetryInterval time.Duration
+}
+
+func optionsFromConfig(cfg *v1alpha1.UsageExportConfig) options {
+	return options{
+		maxSize:            lo.CoalesceOrEmpty(int(cfg.GetBatch().GetMaxSize()), DefaultBatchMaxSize),
+		interval:           lo.CoalesceOrEmpty(cfg.GetBatch().GetInterval().AsDuration(), DefaultBatchInterval),
+		bufferSize:         lo.CoalesceOrEmpty(int(cfg.GetBatch().GetBufferSize()), DefaultBatchBufferSize),
+		timeout:            lo.CoalesceOrEmpty(cfg.GetBatch().GetTimeout().AsDuration(), cfg.GetGrpc().GetTimeout().AsDuration(), DefaultBatchTimeout),
+		spoolDirectory:     cfg.GetSpool().GetDirectory(),
+		spoolMaxSize:       lo.CoalesceOrEmpty(cfg.GetSpool().GetMaxSizeBytes(), DefaultSpoolMaxSize),
+		spoolRetryInterval: lo.CoalesceOrEmpty(cfg.GetSpool().GetRetryInterval().AsDuration(), DefaultSpoolRetryInterval),
+	}
+}
+
+// Exporter buffers the usage records and exports them in batches in the
+// background, so that slow or unavailable billing systems never block
+// requests.
+type Exporter struct {
+	sender  sender
+	options options
+	// spool is nil when spooling is disabled, batches failed to export are
+	// dropped then.
+	spool *spool
+
+	records chan *service.UsageRecord
+	done    chan struct{}
+	// stopReplay cancels the replays of the spool on close.
+	stopReplay context.CancelFunc
+	replays    sync.WaitGroup
+	dropped    atomic.Uint64
+
+	mutex  sync.RWMutex
+	closed bool
+}
+
+// NewWithConfig creates the exporter, batches spooled by previous runs are
+// retried right away.
+func NewWithConfig(cfg *v1alpha1.UsageExportConfig) (*Exporter, error) {
+	s, err := newSender(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return newExporter(s, optionsFromConfig(cfg))
+}
+
+func newExporter(sender sender, options options) (*Exporter, error) {
+	e := &Exporter{
+		sender:  sender,
+		options: options,
+		records: make(chan *service.UsageRecord, options.bufferSize),
+		done:    make(chan struct{}),
+	}
+
+	if options.spoolDirectory != "" {
+		s, err := openSpool(options.spoolDirectory, options.spoolMaxSize)
+		if err != nil {
+			_ = sender.Close()
+			return nil, fmt.Errorf("failed to open spool: %w", err)
+		}
+
+		e.spool = s
+
+		var ctx context.Context
+		ctx, e.stopReplay = context.WithCancel(context.Background())
+
+		e.replays.Add(1)
+		go e.replayLoop(ctx)
+	}
+
+	go e.run()
+
+	return e, nil
+}
+
+// Export exports the record, it never blocks. Records are spooled directly
+// once the buffer is full.
+func (e *Exporter) Export(record *service.UsageRecord) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	if !e.closed {
+		select {
+		case e.records <- record:
+			return
+		default:
+		}
+	}
+
+	e.spoolOrDrop([]*service.UsageRecord{record})
+}
+
+func (e *Exporter) spoolOrDrop(records []*service.UsageRecord) {
+	if e.spool == nil {
+		// Logged once, as it happens all the time while the billing system
+		// is unavailable.
+		if e.dropped.Add(uint64(len(records))) == uint64(len(records)) {
+			slog.Error("usage records dropped as spool is not configured", "records", len(records))
+		}
+
+		return
+	}
+
+	err := e.spool.Write(records)
+	if err != nil {
+		slog.Error("failed to spool usage records, records dropped", "records", len(records), "error", err)
+	}
+}
+
+func (e *Exporter) run() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.options.interval)
+	defer ticker.Stop()
+
+	batch := make([]*service.UsageRecord, 0, e.options.maxSize)
+
+	for {
+		select {
+		case record, ok := <-e.records:
+			if !ok {
+				e.flush(batch)
+				return
+			}
+
+			batch = append(batch, record)
+			if len(batch) >= e.options.maxSize {
+				e.flush(batch)
+				batch = make([]*service.UsageRecord, 0, e.options.maxSize)
+			}
+		case <-ticker.C:
+			e.flush(batch)
+			batch = make([]*service.UsageRecord, 0, e.options.maxSize)
+		}
+	}
+}
+
+func (e *Exporter) send(ctx context.Context, records []*service.UsageRecord) error {
+	ctx, cancel := context.WithTimeout(ctx, e.options.timeout)
+	defer cancel()
+
+	return e.sender.Send(ctx, records)
+}
+
+func (e *Exporter) flush(batch []*service.UsageRecord) {
+	if len(batch) == 0 {
+		return
+	}
+
+	err := e.send(context.Background(), batch)
+	if err == nil {
+		return
+	}
+
+	slog.Warn("failed to export usage records, spooled to retry", "records", len(batch), "error", err)
+	e.spoolOrDrop(batch)
+}
+
+func (e *Exporter) replayLoop(ctx context.Context) {
+	defer e.replays.Done()
+
+	ticker := time.NewTicker(e.options.spoolRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		e.replay(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Exporter) replay(ctx context.Context) {
+	sent, err := e.spool.Replay(ctx, e.send)
+	if sent > 0 {
+		slog.Info("spooled usage records exported", "records", sent)
+	}
+
+	if err != nil && ctx.Err() == nil {
+		slog.Warn("failed to export spooled usage records, retry later", "spooled_bytes", e.spool.Size(), "error", err)
+	}
+}
+
+// Close exports the records buffered, spooling the ones failed to export,
+// and stops the exporter.
+func (e *Exporter) Close(ctx context.Context) error {
+	e.mutex.Lock()
+
+	if e.closed {
+		e.mutex.Unlock()
+		return nil
+	}
+
+	e.closed = true
+	close(e.records)
+	e.mutex.Unlock()
+
+	if e.stopReplay != nil {
+		e.stopReplay()
+	}
+
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	e.replays.Wait()
+
+	return e.sender.Close()
+}
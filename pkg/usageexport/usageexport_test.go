@@ -0,0 +1,261 @@
+package usageexport
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"knoway.dev/api/filters/v1alpha1"
+	service "knoway.dev/api/service/v1alpha1"
+)
+
+// recordSender records the batches sent, fails while failing is true.
+type recordSender struct {
+	mutex   sync.Mutex
+	batches [][]*service.UsageRecord
+	failing bool
+	closed  bool
+}
+
+func (s *recordSender) Send(_ context.Context, records []*service.UsageRecord) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.failing {
+		return errors.New("unavailable")
+	}
+
+	s.batches = append(s.batches, records)
+
+	return nil
+}
+
+func (s *recordSender) Close() error {
+	s.closed = true
+	return nil
+}
+
+func (s *recordSender) setFailing(failing bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.failing = failing
+}
+
+func (s *recordSender) ids() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return lo.FlatMap(s.batches, func(batch []*service.UsageRecord, _ int) []string {
+		return lo.Map(batch, func(record *service.UsageRecord, _ int) string {
+			return record.GetId()
+		})
+	})
+}
+
+func newRecord(id string) *service.UsageRecord {
+	return &service.UsageRecord{Id: id, UserModelName: "gpt-4o", InputTokens: 10, OutputTokens: 20}
+}
+
+func testOptions() options {
+	options := optionsFromConfig(nil)
+	options.interval = time.Hour
+
+	return options
+}
+
+func TestOptionsFromConfig(t *testing.T) {
+	options := optionsFromConfig(&v1alpha1.UsageExportConfig{})
+	assert.Equal(t, DefaultBatchMaxSize, options.maxSize)
+	assert.Equal(t, DefaultBatchTimeout, options.timeout)
+	assert.Equal(t, int64(DefaultSpoolMaxSize), options.spoolMaxSize)
+	assert.Empty(t, options.spoolDirectory)
+}
+
+func TestNewWithConfig(t *testing.T) {
+	_, err := NewWithConfig(&v1alpha1.UsageExportConfig{})
+	require.EqualError(t, err, "no destination configured")
+
+	_, err = NewWithConfig(&v1alpha1.UsageExportConfig{
+		Destination: &v1alpha1.UsageExportConfig_Http{Http: &v1alpha1.UsageExportConfig_HTTP{Url: "ftp://example.com"}},
+	})
+	require.EqualError(t, err, `invalid url of http destination: unsupported scheme of url "ftp://example.com"`)
+
+	_, err = NewWithConfig(&v1alpha1.UsageExportConfig{
+		Destination: &v1alpha1.UsageExportConfig_Kafka_{Kafka: &v1alpha1.UsageExportConfig_Kafka{RestProxyUrl: "http://kafka-rest:8082"}},
+	})
+	require.EqualError(t, err, "invalid kafka destination: topic is required")
+}
+
+func TestExporter_Batch(t *testing.T) {
+	sender := &recordSender{}
+
+	options := testOptions()
+	options.maxSize = 2
+
+	exporter, err := newExporter(sender, options)
+	require.NoError(t, err)
+
+	for _, id := range []string{"1", "2", "3"} {
+		exporter.Export(newRecord(id))
+	}
+
+	// The last batch isn't full, sent on close
+	require.NoError(t, exporter.Close(context.Background()))
+	assert.True(t, sender.closed)
+
+	require.Len(t, sender.batches, 2)
+	assert.Len(t, sender.batches[0], 2)
+	assert.Equal(t, []string{"1", "2", "3"}, sender.ids())
+}
+
+func TestExporter_SpoolAndReplay(t *testing.T) {
+	directory := t.TempDir()
+	sender := &recordSender{failing: true}
+
+	options := testOptions()
+	options.spoolDirectory = directory
+	options.spoolRetryInterval = 10 * time.Millisecond
+
+	exporter, err := newExporter(sender, options)
+	require.NoError(t, err)
+
+	exporter.Export(newRecord("1"))
+	exporter.Export(newRecord("2"))
+	require.NoError(t, exporter.Close(context.Background()))
+
+	// Failed on close, spooled for the next run
+	files, err := filepath.Glob(filepath.Join(directory, "*"+spoolFileExt))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Empty(t, sender.ids())
+
+	sender.setFailing(false)
+
+	exporter, err = newExporter(sender, options)
+	require.NoError(t, err)
+
+	defer func() {
+		_ = exporter.Close(context.Background())
+	}()
+
+	assert.Eventually(t, func() bool {
+		return len(sender.ids()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, []string{"1", "2"}, sender.ids())
+	assert.True(t, proto.Equal(newRecord("1"), sender.batches[0][0]))
+
+	assert.Eventually(t, func() bool {
+		files, _ := filepath.Glob(filepath.Join(directory, "*"+spoolFileExt))
+		return len(files) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Zero(t, exporter.spool.Size())
+}
+
+func TestExporter_SpoolWhenBufferFull(t *testing.T) {
+	sender := &recordSender{}
+
+	options := testOptions()
+	options.bufferSize = 0
+	options.spoolDirectory = t.TempDir()
+	options.spoolRetryInterval = time.Hour
+
+	exporter, err := newExporter(sender, options)
+	require.NoError(t, err)
+
+	// Nobody receives from the unbuffered channel but run, which may be
+	// receiving already, so the record is either batched or spooled
+	exporter.Export(newRecord("1"))
+	require.NoError(t, exporter.Close(context.Background()))
+
+	spooled, err := exporter.spool.Replay(context.Background(), sender.Send)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, sender.ids())
+	assert.LessOrEqual(t, spooled, 1)
+}
+
+func TestExporter_DropWithoutSpool(t *testing.T) {
+	sender := &recordSender{failing: true}
+
+	exporter, err := newExporter(sender, testOptions())
+	require.NoError(t, err)
+
+	exporter.Export(newRecord("1"))
+	require.NoError(t, exporter.Close(context.Background()))
+
+	assert.Equal(t, uint64(1), exporter.dropped.Load())
+
+	// Records exported after close are dropped as well
+	exporter.Export(newRecord("2"))
+	assert.Equal(t, uint64(2), exporter.dropped.Load())
+}
+
+func TestSpool(t *testing.T) {
+	directory := t.TempDir()
+
+	s, err := openSpool(directory, 1024)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Write([]*service.UsageRecord{newRecord("1")}))
+	require.NoError(t, s.Write([]*service.UsageRecord{newRecord("2"), newRecord("3")}))
+	size := s.Size()
+	assert.Positive(t, size)
+
+	// Full
+	require.ErrorIs(t, s.Write(lo.Times(20, func(i int) *service.UsageRecord { return newRecord("4") })), errSpoolFull)
+
+	// Reopened with the size of the batches spooled, partial batches are
+	// removed
+	require.NoError(t, os.WriteFile(filepath.Join(directory, "partial"+spoolTempExt), []byte("{"), 0o600))
+
+	s, err = openSpool(directory, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, size, s.Size())
+	assert.NoFileExists(t, filepath.Join(directory, "partial"+spoolTempExt))
+
+	sender := &recordSender{failing: true}
+
+	sent, err := s.Replay(context.Background(), sender.Send)
+	require.Error(t, err)
+	assert.Zero(t, sent)
+	assert.Equal(t, size, s.Size())
+
+	sender.setFailing(false)
+
+	sent, err = s.Replay(context.Background(), sender.Send)
+	require.NoError(t, err)
+	assert.Equal(t, 3, sent)
+	assert.Equal(t, []string{"1", "2", "3"}, sender.ids())
+	assert.Zero(t, s.Size())
+}
+
+func TestSpool_Corrupt(t *testing.T) {
+	directory := t.TempDir()
+
+	s, err := openSpool(directory, 1024)
+	require.NoError(t, err)
+
+	path := filepath.Join(directory, "0"+spoolFileExt)
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o600))
+	require.NoError(t, s.Write([]*service.UsageRecord{newRecord("1")}))
+
+	sender := &recordSender{}
+
+	sent, err := s.Replay(context.Background(), sender.Send)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, []string{"1"}, sender.ids())
+
+	assert.NoFileExists(t, path)
+	assert.FileExists(t, path+spoolCorruptExt)
+}
@@ -32,3 +32,13 @@ func WriteEventStreamHeadersForHTTP(writer http.ResponseWriter) {
 
 	SafeFlush(writer)
 }
+
+func WriteNDJSONHeadersForHTTP(writer http.ResponseWriter) {
+	writer.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.Header().Set("Connection", "keep-alive")
+	writer.Header().Set("Transfer-Encoding", "chunked")
+	writer.WriteHeader(http.StatusOK)
+
+	SafeFlush(writer)
+}
@@ -16,17 +16,35 @@ old_version() {
     echo '{{- end }}' >>$2
 }
 
+# Variables of prompt templates in the descriptions, e.g. {{date}}, are kept
+# from Helm
+escape() {
+    sed -i 's/{{\([a-z_]*\)}}/{{`{{\1}}`}}/g' $1
+}
+
+# CRDs served in several versions convert through the webhook of the gateway
+conversion() {
+    if [[ $(cat $1 | yq '.spec.versions | length') -gt 1 ]]; then
+        sed -i 's/^spec:$/spec:\n  {{- include "knoway.webhook.conversion" . | nindent 2 }}/' $2
+    fi
+}
+
+f=$(basename $1)
+
 if [[ "" == $(cat $1 | yq '.. | select(has("x-kubernetes-validations"))') ]]; then
     echo "no x-kubernetes-validations found, skip"
-    cp $1 $2
+    cp $1 $2/${f}
+    escape $2/${f}
+    conversion $1 $2/${f}
     exit
 fi
 
-f=$(basename $1)
 new_version $1 $2/${f}
 
 cat $1 | yq 'del(.. | select(has("x-kubernetes-validations")).x-kubernetes-validations)' >$temp
 
 old_version $temp $2/${f}
+escape $2/${f}
+conversion $1 $2/${f}
 
 rm -f ${temp}
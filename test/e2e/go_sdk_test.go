@@ -0,0 +1,259 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	goopenai "github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knoway.dev/test/e2e/mockprovider"
+)
+
+var weatherTool = goopenai.Tool{
+	Type: goopenai.ToolTypeFunction,
+	Function: &goopenai.FunctionDefinition{
+		Name: "get_weather",
+		Parameters: map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"location": map[string]any{"type": "string"}},
+		},
+	},
+}
+
+func newGoClient() *goopenai.Client {
+	cfg := goopenai.DefaultConfig("sk-e2e")
+	cfg.BaseURL = gatewayURL
+
+	return goopenai.NewClientWithConfig(cfg)
+}
+
+func chatRequest(content string) goopenai.ChatCompletionRequest {
+	return goopenai.ChatCompletionRequest{
+		Model: testModel,
+		Messages: []goopenai.ChatCompletionMessage{
+			{Role: goopenai.ChatMessageRoleSystem, Content: "You are a parrot."},
+			{Role: goopenai.ChatMessageRoleUser, Content: content},
+		},
+	}
+}
+
+func TestGoSDK_ListModels(t *testing.T) {
+	models, err := newGoClient().ListModels(context.Background())
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(models.Models))
+	for _, model := range models.Models {
+		ids = append(ids, model.ID)
+	}
+
+	assert.Contains(t, ids, testModel)
+}
+
+func TestGoSDK_ChatCompletion(t *testing.T) {
+	resp, err := newGoClient().CreateChatCompletion(context.Background(), chatRequest("hello from the go sdk"))
+	require.NoError(t, err)
+
+	require.Len(t, resp.Choices, 1)
+	assert.Equal(t, testModel, resp.Model)
+	assert.Equal(t, goopenai.ChatMessageRoleAssistant, resp.Choices[0].Message.Role)
+	assert.Equal(t, "hello from the go sdk", resp.Choices[0].Message.Content)
+	assert.Equal(t, goopenai.FinishReasonStop, resp.Choices[0].FinishReason)
+
+	assert.Equal(t, 9, resp.Usage.PromptTokens)
+	assert.Equal(t, 5, resp.Usage.CompletionTokens)
+	assert.Equal(t, 14, resp.Usage.TotalTokens)
+}
+
+func TestGoSDK_ChatCompletionStream(t *testing.T) {
+	stream, err := newGoClient().CreateChatCompletionStream(context.Background(), chatRequest("streamed word by word"))
+	require.NoError(t, err)
+
+	defer stream.Close()
+
+	var (
+		content      strings.Builder
+		finishReason goopenai.FinishReason
+		usage        *goopenai.Usage
+	)
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+		assert.Equal(t, testModel, chunk.Model)
+
+		for _, choice := range chunk.Choices {
+			content.WriteString(choice.Delta.Content)
+
+			if choice.FinishReason != "" {
+				finishReason = choice.FinishReason
+			}
+		}
+
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+	}
+
+	assert.Equal(t, "streamed word by word", content.String())
+	assert.Equal(t, goopenai.FinishReasonStop, finishReason)
+
+	// Usage is always requested from the upstream for accounting, the usage
+	// chunk without choices must not break the clients not asking for it
+	streamOptions, _ := provider.LastRequest()["stream_options"].(map[string]any)
+	assert.Equal(t, true, streamOptions["include_usage"])
+
+	if usage != nil {
+		assert.Equal(t, 12, usage.TotalTokens)
+	}
+}
+
+func TestGoSDK_ChatCompletionStreamUsage(t *testing.T) {
+	req := chatRequest("usage please")
+	req.StreamOptions = &goopenai.StreamOptions{IncludeUsage: true}
+
+	stream, err := newGoClient().CreateChatCompletionStream(context.Background(), req)
+	require.NoError(t, err)
+
+	defer stream.Close()
+
+	var usage *goopenai.Usage
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+	}
+
+	require.NotNil(t, usage)
+	assert.Equal(t, 6, usage.PromptTokens)
+	assert.Equal(t, 2, usage.CompletionTokens)
+	assert.Equal(t, 8, usage.TotalTokens)
+}
+
+func TestGoSDK_ToolCalls(t *testing.T) {
+	req := chatRequest(mockprovider.PrefixToolCall + weatherTool.Function.Name)
+	req.Tools = []goopenai.Tool{weatherTool}
+
+	resp, err := newGoClient().CreateChatCompletion(context.Background(), req)
+	require.NoError(t, err)
+
+	require.Len(t, resp.Choices, 1)
+	assert.Equal(t, goopenai.FinishReasonToolCalls, resp.Choices[0].FinishReason)
+	require.Len(t, resp.Choices[0].Message.ToolCalls, 1)
+
+	call := resp.Choices[0].Message.ToolCalls[0]
+	assert.Equal(t, goopenai.ToolTypeFunction, call.Type)
+	assert.Equal(t, weatherTool.Function.Name, call.Function.Name)
+	assert.JSONEq(t, mockprovider.ToolArguments, call.Function.Arguments)
+
+	// The tools are forwarded as is
+	tools, _ := provider.LastRequest()["tools"].([]any)
+	assert.Len(t, tools, 1)
+}
+
+func TestGoSDK_ToolCallsStream(t *testing.T) {
+	req := chatRequest(mockprovider.PrefixToolCall + weatherTool.Function.Name)
+	req.Tools = []goopenai.Tool{weatherTool}
+
+	stream, err := newGoClient().CreateChatCompletionStream(context.Background(), req)
+	require.NoError(t, err)
+
+	defer stream.Close()
+
+	var (
+		name, arguments strings.Builder
+		finishReason    goopenai.FinishReason
+	)
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+
+		for _, choice := range chunk.Choices {
+			for _, call := range choice.Delta.ToolCalls {
+				require.NotNil(t, call.Index)
+				assert.Equal(t, 0, *call.Index)
+
+				name.WriteString(call.Function.Name)
+				arguments.WriteString(call.Function.Arguments)
+			}
+
+			if choice.FinishReason != "" {
+				finishReason = choice.FinishReason
+			}
+		}
+	}
+
+	assert.Equal(t, weatherTool.Function.Name, name.String())
+	assert.JSONEq(t, mockprovider.ToolArguments, arguments.String())
+	assert.Equal(t, goopenai.FinishReasonToolCalls, finishReason)
+}
+
+func TestGoSDK_Errors(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		status  int
+	}{
+		{name: "bad request", content: mockprovider.PrefixError + "400", status: http.StatusBadRequest},
+		{name: "rate limited", content: mockprovider.PrefixError + "429", status: http.StatusTooManyRequests},
+		{name: "server error", content: mockprovider.PrefixError + "500", status: http.StatusInternalServerError},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := newGoClient().CreateChatCompletion(context.Background(), chatRequest(c.content))
+			require.Error(t, err)
+
+			var apiErr *goopenai.APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, c.status, apiErr.HTTPStatusCode)
+			assert.NotEmpty(t, apiErr.Message)
+		})
+	}
+
+	t.Run("model not found", func(t *testing.T) {
+		req := chatRequest("hello")
+		req.Model = "mock/unknown"
+
+		_, err := newGoClient().CreateChatCompletion(context.Background(), req)
+		require.Error(t, err)
+
+		var apiErr *goopenai.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusNotFound, apiErr.HTTPStatusCode)
+		assert.Contains(t, apiErr.Message, "mock/unknown")
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		_, err := newGoClient().CreateChatCompletionStream(context.Background(), chatRequest(mockprovider.PrefixError+"429"))
+		require.Error(t, err)
+
+		var apiErr *goopenai.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusTooManyRequests, apiErr.HTTPStatusCode)
+	})
+}
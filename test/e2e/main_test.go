@@ -0,0 +1,151 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"knoway.dev/test/e2e/mockprovider"
+)
+
+const (
+	// testModel is the static cluster served by the mock provider.
+	testModel = "mock/gpt-4o"
+
+	startupTimeout = time.Minute
+)
+
+var (
+	provider *mockprovider.Provider
+	// gatewayURL is the base url of the OpenAI compatible API of the gateway.
+	gatewayURL string
+)
+
+const configTemplate = `
+staticListeners:
+  - "@type": type.googleapis.com/knoway.listeners.v1alpha1.ChatCompletionListener
+    name: openai-chat
+    accessLog:
+      enable: true
+staticClusters:
+  - name: %s
+    type: LLM
+    provider: OPEN_AI
+    loadBalancePolicy: ROUND_ROBIN
+    upstream:
+      url: %s
+`
+
+// TestMain builds the gateway and runs it with static clusters served by the
+// mock provider, the tests talk to it with the SDKs of OpenAI over the wire.
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	provider = mockprovider.New()
+	defer provider.Close()
+
+	dir, err := os.MkdirTemp("", "knoway-e2e")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer os.RemoveAll(dir)
+
+	binary := filepath.Join(dir, "knoway")
+
+	build := exec.Command("go", "build", "-o", binary, "./cmd")
+	build.Dir = filepath.Join("..", "..")
+	build.Stdout, build.Stderr = os.Stdout, os.Stderr
+
+	err = build.Run()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to build the gateway:", err)
+		return 1
+	}
+
+	configPath := filepath.Join(dir, "config.yaml")
+
+	err = os.WriteFile(configPath, []byte(fmt.Sprintf(configTemplate, testModel, provider.BaseURL())), 0o600)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	gatewayAddr, adminAddr := freeAddr(), freeAddr()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	gateway := exec.CommandContext(ctx, binary,
+		"-config", configPath,
+		"-static-cluster-only",
+		"-watch-config=false",
+		"-gateway-listener-address", gatewayAddr,
+		"-admin-listener-address", adminAddr,
+	)
+	gateway.Stdout, gateway.Stderr = os.Stdout, os.Stderr
+	gateway.Cancel = func() error {
+		return gateway.Process.Signal(os.Interrupt)
+	}
+	gateway.WaitDelay = 10 * time.Second
+
+	err = gateway.Start()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to start the gateway:", err)
+		return 1
+	}
+
+	defer func() {
+		cancel()
+		_ = gateway.Wait()
+	}()
+
+	gatewayURL = "http://" + gatewayAddr + "/v1"
+
+	err = waitUntilReady(gatewayURL + "/models")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return m.Run()
+}
+
+func freeAddr() string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	defer l.Close()
+
+	return l.Addr().String()
+}
+
+func waitUntilReady(url string) error {
+	deadline := time.Now().Add(startupTimeout)
+
+	for time.Now().Before(deadline) {
+		resp, err := http.Get(url) //nolint:noctx
+		if err == nil {
+			resp.Body.Close()
+
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return fmt.Errorf("gateway is not ready after %s", startupTimeout)
+}
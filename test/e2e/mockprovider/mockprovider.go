@@ -0,0 +1,321 @@
+package mockprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// PrefixToolCall makes the provider call the tool named after the prefix,
+	// e.g. "tool:get_weather".
+	PrefixToolCall = "tool:"
+	// PrefixError makes the provider respond with an error of the status
+	// after the prefix, e.g. "error:429".
+	PrefixError = "error:"
+
+	// ToolArguments are the arguments of the tool calls made.
+	ToolArguments = `{"location":"Paris"}`
+)
+
+// Provider is an OpenAI compatible upstream for end-to-end tests, replies are
+// scripted by the content of the last message:
+//
+//	tool:<name>    calls the tool with ToolArguments
+//	error:<status> responds with an OpenAI error of the status
+//	anything else  echoes the content back
+//
+// Streaming requests are answered with a chunk per word, followed by a usage
+// chunk when stream_options.include_usage is set, the same way as OpenAI.
+type Provider struct {
+	*httptest.Server
+
+	mutex    sync.Mutex
+	requests []map[string]any
+}
+
+func New() *Provider {
+	p := new(Provider)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/models", p.listModels)
+	mux.HandleFunc("POST /v1/chat/completions", p.chatCompletions)
+
+	p.Server = httptest.NewServer(mux)
+
+	return p
+}
+
+// BaseURL is the url of the provider to configure the upstreams with.
+func (p *Provider) BaseURL() string {
+	return p.URL + "/v1"
+}
+
+// Requests returns the bodies of the chat completions requests received.
+func (p *Provider) Requests() []map[string]any {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]map[string]any(nil), p.requests...)
+}
+
+// LastRequest returns the body of the last chat completions request
+// received.
+func (p *Provider) LastRequest() map[string]any {
+	requests := p.Requests()
+	if len(requests) == 0 {
+		return nil
+	}
+
+	return requests[len(requests)-1]
+}
+
+func (p *Provider) listModels(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"object": "list",
+		"data":   []any{map[string]any{"id": "mock-model", "object": "model", "owned_by": "mock"}},
+	})
+}
+
+type chatCompletionsRequest struct {
+	Model         string `json:"model"`
+	Stream        bool   `json:"stream"`
+	StreamOptions struct {
+		IncludeUsage bool `json:"include_usage"`
+	} `json:"stream_options"`
+	Messages []struct {
+		Role    string `json:"role"`
+		Content any    `json:"content"`
+	} `json:"messages"`
+}
+
+func (p *Provider) chatCompletions(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "invalid body")
+		return
+	}
+
+	p.mutex.Lock()
+	p.requests = append(p.requests, body)
+	p.mutex.Unlock()
+
+	var req chatCompletionsRequest
+
+	bs, _ := json.Marshal(body)
+	_ = json.Unmarshal(bs, &req)
+
+	if len(req.Messages) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "messages is required")
+		return
+	}
+
+	content, _ := req.Messages[len(req.Messages)-1].Content.(string)
+
+	if status, ok := strings.CutPrefix(content, PrefixError); ok {
+		code, err := strconv.Atoi(status)
+		if err != nil {
+			code = http.StatusInternalServerError
+		}
+
+		writeError(w, code, errorType(code), fmt.Sprintf("mock error %d", code))
+
+		return
+	}
+
+	promptTokens := 0
+	for _, message := range req.Messages {
+		text, _ := message.Content.(string)
+		promptTokens += len(strings.Fields(text))
+	}
+
+	reply := newReply(req.Model, content, promptTokens)
+
+	if !req.Stream {
+		writeJSON(w, http.StatusOK, reply.completion())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	for _, chunk := range reply.chunks(req.StreamOptions.IncludeUsage) {
+		bs, _ := json.Marshal(chunk)
+		_, _ = fmt.Fprintf(w, "data: %s\n\n", bs)
+
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+
+	_, _ = fmt.Fprint(w, "data: [DONE]\n\n")
+}
+
+type reply struct {
+	id      string
+	model   string
+	created int64
+
+	content  string
+	toolName string
+
+	promptTokens     int
+	completionTokens int
+}
+
+func newReply(model, content string, promptTokens int) *reply {
+	r := &reply{
+		id:           fmt.Sprintf("chatcmpl-mock-%d", time.Now().UnixNano()),
+		model:        model,
+		created:      time.Now().Unix(),
+		promptTokens: promptTokens,
+	}
+
+	if name, ok := strings.CutPrefix(content, PrefixToolCall); ok {
+		r.toolName = name
+		r.completionTokens = 1
+	} else {
+		r.content = content
+		r.completionTokens = len(strings.Fields(content))
+	}
+
+	return r
+}
+
+func (r *reply) finishReason() string {
+	if r.toolName != "" {
+		return "tool_calls"
+	}
+
+	return "stop"
+}
+
+func (r *reply) usage() map[string]any {
+	return map[string]any{
+		"prompt_tokens":     r.promptTokens,
+		"completion_tokens": r.completionTokens,
+		"total_tokens":      r.promptTokens + r.completionTokens,
+	}
+}
+
+func (r *reply) toolCall(index bool) map[string]any {
+	call := map[string]any{
+		"id":   "call_mock",
+		"type": "function",
+		"function": map[string]any{
+			"name":      r.toolName,
+			"arguments": ToolArguments,
+		},
+	}
+
+	if index {
+		call["index"] = 0
+	}
+
+	return call
+}
+
+func (r *reply) completion() map[string]any {
+	message := map[string]any{"role": "assistant", "content": r.content}
+	if r.toolName != "" {
+		message["content"] = nil
+		message["tool_calls"] = []any{r.toolCall(false)}
+	}
+
+	return map[string]any{
+		"id":      r.id,
+		"object":  "chat.completion",
+		"created": r.created,
+		"model":   r.model,
+		"choices": []any{map[string]any{
+			"index":         0,
+			"message":       message,
+			"finish_reason": r.finishReason(),
+		}},
+		"usage": r.usage(),
+	}
+}
+
+func (r *reply) chunk(delta map[string]any, finishReason any) map[string]any {
+	return map[string]any{
+		"id":      r.id,
+		"object":  "chat.completion.chunk",
+		"created": r.created,
+		"model":   r.model,
+		"choices": []any{map[string]any{
+			"index":         0,
+			"delta":         delta,
+			"finish_reason": finishReason,
+		}},
+	}
+}
+
+func (r *reply) chunks(includeUsage bool) []map[string]any {
+	chunks := []map[string]any{r.chunk(map[string]any{"role": "assistant", "content": ""}, nil)}
+
+	if r.toolName != "" {
+		chunks = append(chunks, r.chunk(map[string]any{"tool_calls": []any{r.toolCall(true)}}, nil))
+	} else {
+		for i, word := range strings.Fields(r.content) {
+			if i > 0 {
+				word = " " + word
+			}
+
+			chunks = append(chunks, r.chunk(map[string]any{"content": word}, nil))
+		}
+	}
+
+	chunks = append(chunks, r.chunk(map[string]any{}, r.finishReason()))
+
+	if includeUsage {
+		chunks = append(chunks, map[string]any{
+			"id":      r.id,
+			"object":  "chat.completion.chunk",
+			"created": r.created,
+			"model":   r.model,
+			"choices": []any{},
+			"usage":   r.usage(),
+		})
+	}
+
+	return chunks
+}
+
+func errorType(status int) string {
+	switch {
+	case status == http.StatusUnauthorized:
+		return "authentication_error"
+	case status == http.StatusTooManyRequests:
+		return "rate_limit_exceeded"
+	case status >= http.StatusInternalServerError:
+		return "server_error"
+	default:
+		return "invalid_request_error"
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, typ, message string) {
+	writeJSON(w, status, map[string]any{
+		"error": map[string]any{
+			"message": message,
+			"type":    typ,
+			"param":   nil,
+			"code":    typ,
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(body)
+}
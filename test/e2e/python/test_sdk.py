@@ -0,0 +1,126 @@
+"""Compatibility tests of the gateway with the official OpenAI Python SDK.
+
+Run by TestPythonSDK of the e2e Go tests, which sets KNOWAY_E2E_BASE_URL and
+KNOWAY_E2E_MODEL, the replies are scripted by the mock provider.
+"""
+
+import json
+import os
+import unittest
+
+import openai
+
+BASE_URL = os.environ["KNOWAY_E2E_BASE_URL"]
+MODEL = os.environ["KNOWAY_E2E_MODEL"]
+
+WEATHER_TOOL = {
+    "type": "function",
+    "function": {
+        "name": "get_weather",
+        "parameters": {
+            "type": "object",
+            "properties": {"location": {"type": "string"}},
+        },
+    },
+}
+
+
+def messages(content):
+    return [
+        {"role": "system", "content": "You are a parrot."},
+        {"role": "user", "content": content},
+    ]
+
+
+class TestSDK(unittest.TestCase):
+    def setUp(self):
+        self.client = openai.OpenAI(base_url=BASE_URL, api_key="sk-e2e", max_retries=0)
+
+    def test_list_models(self):
+        ids = [model.id for model in self.client.models.list()]
+        self.assertIn(MODEL, ids)
+
+    def test_chat_completion(self):
+        resp = self.client.chat.completions.create(model=MODEL, messages=messages("hello from the python sdk"))
+
+        self.assertEqual(resp.model, MODEL)
+        self.assertEqual(resp.choices[0].message.role, "assistant")
+        self.assertEqual(resp.choices[0].message.content, "hello from the python sdk")
+        self.assertEqual(resp.choices[0].finish_reason, "stop")
+        self.assertEqual(resp.usage.prompt_tokens, 9)
+        self.assertEqual(resp.usage.completion_tokens, 5)
+        self.assertEqual(resp.usage.total_tokens, 14)
+
+    def test_chat_completion_stream(self):
+        stream = self.client.chat.completions.create(
+            model=MODEL,
+            messages=messages("streamed word by word"),
+            stream=True,
+            stream_options={"include_usage": True},
+        )
+
+        content, finish_reason, usage = "", None, None
+        for chunk in stream:
+            for choice in chunk.choices:
+                content += choice.delta.content or ""
+                finish_reason = choice.finish_reason or finish_reason
+            usage = chunk.usage or usage
+
+        self.assertEqual(content, "streamed word by word")
+        self.assertEqual(finish_reason, "stop")
+        self.assertIsNotNone(usage)
+        self.assertEqual(usage.total_tokens, 12)
+
+    def test_tool_calls(self):
+        resp = self.client.chat.completions.create(
+            model=MODEL,
+            messages=messages("tool:get_weather"),
+            tools=[WEATHER_TOOL],
+        )
+
+        self.assertEqual(resp.choices[0].finish_reason, "tool_calls")
+        call = resp.choices[0].message.tool_calls[0]
+        self.assertEqual(call.type, "function")
+        self.assertEqual(call.function.name, "get_weather")
+        self.assertEqual(json.loads(call.function.arguments), {"location": "Paris"})
+
+    def test_tool_calls_stream(self):
+        stream = self.client.chat.completions.create(
+            model=MODEL,
+            messages=messages("tool:get_weather"),
+            tools=[WEATHER_TOOL],
+            stream=True,
+        )
+
+        name, arguments, finish_reason = "", "", None
+        for chunk in stream:
+            for choice in chunk.choices:
+                for call in choice.delta.tool_calls or []:
+                    self.assertEqual(call.index, 0)
+                    name += call.function.name or ""
+                    arguments += call.function.arguments or ""
+                finish_reason = choice.finish_reason or finish_reason
+
+        self.assertEqual(name, "get_weather")
+        self.assertEqual(json.loads(arguments), {"location": "Paris"})
+        self.assertEqual(finish_reason, "tool_calls")
+
+    def test_errors(self):
+        cases = [
+            ("error:400", openai.BadRequestError),
+            ("error:429", openai.RateLimitError),
+            ("error:500", openai.InternalServerError),
+        ]
+
+        for content, error in cases:
+            with self.subTest(content=content), self.assertRaises(error) as ctx:
+                self.client.chat.completions.create(model=MODEL, messages=messages(content))
+
+            self.assertTrue(ctx.exception.message)
+
+        with self.assertRaises(openai.NotFoundError):
+            self.client.chat.completions.create(model="mock/unknown", messages=messages("hello"))
+
+
+if __name__ == "__main__":
+    unittest.main()
@@ -0,0 +1,37 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+)
+
+// TestPythonSDK runs the tests in python/ with the OpenAI Python SDK, which is
+// installed with `pip install -r test/e2e/python/requirements.txt`.
+func TestPythonSDK(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 is not installed")
+	}
+
+	err = exec.Command(python, "-c", "import openai").Run()
+	if err != nil {
+		t.Skip("the openai python package is not installed")
+	}
+
+	cmd := exec.Command(python, "-m", "unittest", "-v", "test_sdk.py")
+	cmd.Dir = "python"
+	cmd.Env = append(os.Environ(),
+		"KNOWAY_E2E_BASE_URL="+gatewayURL,
+		"KNOWAY_E2E_MODEL="+testModel,
+	)
+
+	out, err := cmd.CombinedOutput()
+	t.Log(string(out))
+
+	if err != nil {
+		t.Fatalf("python sdk tests failed: %v", err)
+	}
+}